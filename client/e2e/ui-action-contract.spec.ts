@@ -1231,11 +1231,13 @@ test.describe('UI Action Contract (Playwright + mocked websocket)', () => {
         pendingDecision: {
           type: 'darklings_ordination',
           playerId: 'p1',
+          maxWorkers: 2,
         },
       },
     })
     await expect(page.getByTestId('darklings-ordination-2')).toBeVisible()
-    await expect(page.getByText('Darklings can only convert workers to priests when a player just upgraded to a stronghold.')).toBeVisible()
+    await expect(page.getByTestId('darklings-ordination-3')).toHaveCount(0)
+    await expect(page.getByText('Trade up to 2 workers for one priest each.')).toBeVisible()
     await clearSentMessages(page)
     await clickByTestId(page, 'darklings-ordination-2')
     await waitForPerformAction(page, 'darklings_ordination', { workersToConvert: 2 })
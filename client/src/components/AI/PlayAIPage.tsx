@@ -90,7 +90,7 @@ export function PlayAIPage(): ReactElement {
     if (msg.type === 'action_rejected') {
       const payload = (msg.payload ?? {}) as Record<string, unknown>
       setStarting(false)
-      setError((payload.message as string | undefined) ?? (payload.error as string | undefined) ?? 'AI game failed.')
+      setError((payload.localizedMessage as string | undefined) ?? (payload.message as string | undefined) ?? (payload.error as string | undefined) ?? 'AI game failed.')
       return
     }
     if (msg.type === 'model_game_started') {
@@ -121,7 +121,12 @@ export const EndGameScoring: React.FC<EndGameScoringProps> = ({ gameState }) =>
                                             {score.fireIceVp} <span className="text-gray-400 text-sm">({score.fireIceMetricValue})</span>
                                         </td>
                                     )}
-                                    <td className="p-3 text-right">{score.cultVp}</td>
+                                    <td
+                                        className="p-3 text-right"
+                                        title={score.cultVpByTrack ? `Fire ${score.cultVpByTrack[0]} / Water ${score.cultVpByTrack[1]} / Earth ${score.cultVpByTrack[2]} / Air ${score.cultVpByTrack[3]}` : undefined}
+                                    >
+                                        {score.cultVp}
+                                    </td>
                                     <td className="p-3 text-right">
                                         {score.resourceVp} <span className="text-gray-400 text-sm">(Val: {score.totalResourceValue})</span>
                                     </td>
@@ -8,6 +8,7 @@ interface FactionSelectorProps {
     currentPlayerPosition: number
     enableFanFactions: boolean
     enableFireIceFactions: boolean
+    availableFactions?: string[] | null // server-side selectable pool, when provided
 }
 
 export function FactionSelector({
@@ -17,6 +18,7 @@ export function FactionSelector({
     currentPlayerPosition,
     enableFanFactions,
     enableFireIceFactions,
+    availableFactions,
 }: FactionSelectorProps): React.ReactElement {
     const allFactions = FACTIONS
         .filter((faction) => enableFanFactions || !faction.isFanFaction)
@@ -41,6 +43,9 @@ export function FactionSelector({
         // Check if already selected
         if (selectedFactions.has(type)) return false
 
+        // Prefer the server's pool, which already applies the color restriction
+        if (availableFactions) return availableFactions.includes(type)
+
         // Check if same color as selected faction
         const faction = getFactionData(type)
         if (!faction) return false
@@ -11,7 +11,7 @@ import { CultTracks, type PriestSpot } from './CultTracks/CultTracks'
 import { FactionSelector } from './FactionSelector'
 import { FACTIONS } from '../data/factions'
 import { useGameStore } from '../stores/gameStore'
-import { useActionService } from '../services/actionService'
+import { hotseatActor, useActionService } from '../services/actionService'
 import {
   CultType,
   GamePhase,
@@ -26,6 +26,14 @@ import {
   type PlayerOptions,
   type FavorTileType,
   type TownTileId,
+  type TurnReminder,
+  type LeechPreferences,
+  type AutoLeechDecision,
+  type AnnotationVisibility,
+  type BoardAnnotation,
+  type IncomeReport,
+  type IncomeWarning,
+  type IncomeAmounts,
 } from '../types/game.types'
 import { useWebSocket } from '../services/WebSocketContext'
 import { Responsive, WidthProvider } from 'react-grid-layout'
@@ -67,8 +75,31 @@ const DEFAULT_PLAYER_OPTIONS: PlayerOptions = {
   autoConvertOnPass: false,
   confirmActions: true,
   showIncomePreview: false,
+  confirmPass: false,
+  confirmBurn: false,
 }
 
+const DEFAULT_LEECH_PREFERENCES: LeechPreferences = {
+  acceptUpTo: 0,
+  declineFromVpCost: 0,
+  declineAboveVp: 0,
+}
+
+const formatIncome = (income: IncomeAmounts): string => {
+  const parts = [
+    income.coins ? `${String(income.coins)}c` : '',
+    income.workers ? `${String(income.workers)}w` : '',
+    income.priests ? `${String(income.priests)}p` : '',
+    income.power ? `${String(income.power)}pw` : '',
+    income.vp ? `${String(income.vp)}vp` : '',
+  ].filter(Boolean)
+  return parts.length > 0 ? parts.join(' ') : 'nothing'
+}
+
+const formatIncomeWarning = (warning: IncomeWarning): string => (
+  `${String(warning.wasted)} ${warning.resource === 'power' ? 'power' : (warning.wasted === 1 ? 'priest' : 'priests')}`
+)
+
 const LEECH_AUTO_OPTIONS: Array<{ value: LeechAutoMode; label: string }> = [
   { value: 'off', label: 'Auto accept power: Off' },
   { value: 'accept_1', label: 'Auto accept up to 1 power (0 VP)' },
@@ -244,7 +275,10 @@ export const Game = () => {
   const { gameId } = useParams()
   const { isConnected, connectionGeneration, sendMessage, lastMessage } = useWebSocket()
   const gameState = useGameStore((state) => state.gameState)
-  const localPlayerId = useGameStore((state) => state.localPlayerId)
+  const seatPlayerId = useGameStore((state) => state.localPlayerId)
+  // In hotseat games this connection plays every seat, so the UI acts as
+  // whichever player the game is waiting on.
+  const localPlayerId = gameState?.hotseat ? (hotseatActor(gameState) ?? seatPlayerId) : seatPlayerId
   const gamePlayerId = useGameStore((state) => gameId ? state.playerIdsByGame[gameId] : undefined)
   const lastGameStateRequestRef = useRef<string | null>(null)
 
@@ -252,6 +286,16 @@ export const Game = () => {
 
   const [confirmDialog, setConfirmDialog] = useState<ConfirmDialog | null>(null)
   const [errorMessage, setErrorMessage] = useState<string | null>(null)
+  const [reminderMessage, setReminderMessage] = useState<string | null>(null)
+  const [autoLeechLog, setAutoLeechLog] = useState<string[]>([])
+  // The player's standing leech preferences, as last confirmed by the server.
+  const [accountLeechPreferences, setAccountLeechPreferences] = useState<LeechPreferences | null>(null)
+  // The player's standing pass and burn confirmations, likewise.
+  const [accountConfirmations, setAccountConfirmations] = useState<{ confirmPass: boolean; confirmBurn: boolean } | null>(null)
+  const [incomeReport, setIncomeReport] = useState<IncomeReport | null>(null)
+  const [annotations, setAnnotations] = useState<BoardAnnotation[]>([])
+  const [annotationViewerId, setAnnotationViewerId] = useState<string | null>(null)
+  const [annotationVisibility, setAnnotationVisibility] = useState<AnnotationVisibility>('all')
   const [powerMode, setPowerMode] = useState<PendingPowerMode | null>(null)
   const [, setTreasurersDepositCoins] = useState(0)
   const [, setTreasurersDepositWorkers] = useState(0)
@@ -331,8 +375,8 @@ export const Game = () => {
 
   useEffect(() => {
     if (isConnected && connectionGeneration > 0 && gameId) {
-      const playerId = gamePlayerId ?? localPlayerId
-      if (gamePlayerId && localPlayerId !== gamePlayerId) {
+      const playerId = gamePlayerId ?? seatPlayerId
+      if (gamePlayerId && seatPlayerId !== gamePlayerId) {
         useGameStore.getState().setLocalPlayerId(gamePlayerId)
       }
       const requestKey = `${connectionGeneration}:${gameId}:${playerId ?? ''}`
@@ -340,7 +384,7 @@ export const Game = () => {
       lastGameStateRequestRef.current = requestKey
       sendMessage({ type: 'get_game_state', payload: { gameID: gameId, playerID: playerId } })
     }
-  }, [connectionGeneration, gameId, gamePlayerId, isConnected, localPlayerId, sendMessage])
+  }, [connectionGeneration, gameId, gamePlayerId, isConnected, seatPlayerId, sendMessage])
 
   useEffect(() => {
     if (!import.meta.env.DEV || typeof window === 'undefined') return
@@ -371,7 +415,7 @@ export const Game = () => {
     const msg = lastMessage as { type: string; payload?: unknown }
     if (msg.type === 'action_rejected') {
       const payload = (msg.payload ?? {}) as Record<string, unknown>
-      const text = (payload.message as string | undefined) ?? (payload.error as string | undefined) ?? 'Action rejected'
+      const text = (payload.localizedMessage as string | undefined) ?? (payload.message as string | undefined) ?? (payload.error as string | undefined) ?? 'Action rejected'
       setErrorMessage(text)
       return
     }
@@ -395,8 +439,63 @@ export const Game = () => {
       return
     }
 
+    if (msg.type === 'turn_reminder') {
+      const payload = (msg.payload ?? {}) as Partial<TurnReminder>
+      if (payload.gameId === gameId) {
+        const waitingMinutes = Math.floor((payload.waitingMs ?? 0) / 60000)
+        setReminderMessage(waitingMinutes > 0
+          ? `The game has been waiting on you for ${String(waitingMinutes)} min.`
+          : 'The game is waiting on you.')
+      }
+      return
+    }
+
+    if (msg.type === 'leech_auto_resolved') {
+      const payload = (msg.payload ?? {}) as Partial<AutoLeechDecision>
+      if (typeof payload.playerId === 'string') {
+        const answer = payload.accepted ? 'accepted' : 'declined'
+        const line = `Auto: ${payload.playerId} ${answer} ${String(payload.amount ?? 0)} power from ${payload.fromPlayerId ?? '?'} (${payload.rule ?? 'auto'})`
+        setAutoLeechLog((prev) => [...prev.slice(-4), line])
+      }
+      return
+    }
+
+    if (msg.type === 'leech_preferences') {
+      const payload = (msg.payload ?? {}) as { playerId?: string; preferences?: LeechPreferences }
+      if (payload.playerId === useGameStore.getState().localPlayerId && payload.preferences) {
+        setAccountLeechPreferences(payload.preferences)
+      }
+      return
+    }
+
+    if (msg.type === 'confirmation_preferences') {
+      const payload = (msg.payload ?? {}) as { playerId?: string; confirmPass?: boolean; confirmBurn?: boolean }
+      if (payload.playerId === useGameStore.getState().localPlayerId) {
+        setAccountConfirmations({ confirmPass: payload.confirmPass ?? false, confirmBurn: payload.confirmBurn ?? false })
+      }
+      return
+    }
+
+    if (msg.type === 'annotations') {
+      const payload = (msg.payload ?? {}) as { gameId?: string; viewerId?: string; annotations?: BoardAnnotation[] }
+      if (payload.gameId === gameId) {
+        setAnnotations(payload.annotations ?? [])
+        setAnnotationViewerId(payload.viewerId ?? null)
+      }
+      return
+    }
+
+    if (msg.type === 'income_report') {
+      const payload = (msg.payload ?? {}) as Partial<IncomeReport>
+      if (payload.playerId && payload.playerId === useGameStore.getState().localPlayerId) {
+        setIncomeReport(payload as IncomeReport)
+      }
+      return
+    }
+
     if (msg.type === 'action_accepted') {
       setErrorMessage(null)
+      setReminderMessage(null)
     }
   }, [gameId, lastMessage])
 
@@ -414,9 +513,9 @@ export const Game = () => {
     title: string,
     message: string,
     onConfirm: () => void,
-    scope: 'interaction' | 'turn_end' = 'interaction',
+    scope: 'interaction' | 'turn_end' | 'always' = 'interaction',
   ): void => {
-    const needsConfirmation = localPlayerOptions.confirmActions && scope === 'turn_end'
+    const needsConfirmation = scope === 'always' || (localPlayerOptions.confirmActions && scope === 'turn_end')
     if (!needsConfirmation) {
       onConfirm()
       return
@@ -478,13 +577,41 @@ export const Game = () => {
       autoConvertOnPass: localPlayer.options.autoConvertOnPass ?? DEFAULT_PLAYER_OPTIONS.autoConvertOnPass,
       confirmActions: localPlayer.options.confirmActions ?? DEFAULT_PLAYER_OPTIONS.confirmActions,
       showIncomePreview: localPlayer.options.showIncomePreview ?? DEFAULT_PLAYER_OPTIONS.showIncomePreview,
+      confirmPass: accountConfirmations?.confirmPass ?? localPlayer.options.confirmPass ?? DEFAULT_PLAYER_OPTIONS.confirmPass,
+      confirmBurn: accountConfirmations?.confirmBurn ?? localPlayer.options.confirmBurn ?? DEFAULT_PLAYER_OPTIONS.confirmBurn,
+      leechPreferences: accountLeechPreferences ?? localPlayer.options.leechPreferences ?? DEFAULT_LEECH_PREFERENCES,
     }
-  }, [localPlayer])
+  }, [localPlayer, accountLeechPreferences, accountConfirmations])
 
   const updatePlayerOptions = (patch: Partial<PlayerOptions>): void => {
     performAction('set_player_options', patch as Record<string, unknown>)
   }
 
+  // Leech preferences are kept for the player across games, so they go
+  // through set_leech_preferences rather than this game's options.
+  const updateLeechPreferences = (patch: Partial<LeechPreferences>): void => {
+    if (!localPlayerId) return
+    const current = localPlayerOptions.leechPreferences ?? DEFAULT_LEECH_PREFERENCES
+    sendMessage({
+      type: 'set_leech_preferences',
+      payload: { name: localPlayerId, preferences: { ...current, ...patch } },
+    })
+  }
+
+  // Pass and burn confirmations are kept for the player across games too.
+  // This game's options are cleared so the account setting alone decides.
+  const updateConfirmations = (patch: { confirmPass?: boolean; confirmBurn?: boolean }): void => {
+    if (!localPlayerId) return
+    sendMessage({
+      type: 'set_confirmation_preferences',
+      payload: { name: localPlayerId, ...patch },
+    })
+    const cleared: Partial<PlayerOptions> = {}
+    if (patch.confirmPass === false && localPlayer?.options?.confirmPass) cleared.confirmPass = false
+    if (patch.confirmBurn === false && localPlayer?.options?.confirmBurn) cleared.confirmBurn = false
+    if (Object.keys(cleared).length > 0) updatePlayerOptions(cleared)
+  }
+
   useEffect(() => {
     if (!localPlayerOptions.confirmActions) {
       setConfirmDialog(null)
@@ -496,6 +623,17 @@ export const Game = () => {
     const raw = (localPlayer.faction ?? localPlayer.Faction) as unknown
     return resolveFaction(raw)
   }, [localPlayer])
+  const localCultRewardProgress = seatPlayerId ? gameState?.cultRewardProgress?.[seatPlayerId] : undefined
+  const leechWaitMessage = useMemo(() => {
+    const wait = gameState?.leechWait
+    if (!wait || wait.playerIds.length === 0) return null
+    const factionName = (playerId: string): string => {
+      const faction = resolveFaction(gameState?.players[playerId]?.faction as unknown)
+      return FACTIONS.find((f) => f.id === faction)?.name ?? playerId
+    }
+    const waiting = `Waiting on ${wait.playerIds.map(factionName).join(', ')} to answer power offers`
+    return wait.nextPlayerId ? `${waiting}, then it is ${factionName(wait.nextPlayerId)}'s turn` : waiting
+  }, [gameState?.leechWait, gameState?.players])
   const acolytesCultPaymentOptions = useMemo(() => {
     return CULT_CHOICES.map((choice) => ({
       ...choice,
@@ -580,6 +718,11 @@ export const Game = () => {
     if (pendingDecisionType !== 'town_cult_top_choice') return 0
     return Number(pendingDecision?.maxSelections ?? 0)
   }, [pendingDecision, pendingDecisionType])
+  const darklingsOrdinationChoices = useMemo(() => {
+    if (pendingDecisionType !== 'darklings_ordination') return [] as number[]
+    const maxWorkers = Math.min(3, Math.max(0, Number(pendingDecision?.maxWorkers ?? 3)))
+    return Array.from({ length: maxWorkers + 1 }, (_, count) => count)
+  }, [pendingDecision, pendingDecisionType])
   const pendingArchivistsReturnedCards = useMemo(() => {
     if (pendingDecisionType !== 'archivists_bonus_card') return [] as BonusCardType[]
     return ((pendingDecision?.returnedCards as unknown[]) ?? [])
@@ -769,6 +912,11 @@ export const Game = () => {
 
   const hasUnspentOptionalActions = useMemo(() => {
     if (!localPlayer || !localPlayerId) return false
+    const hasPendingSpades = hasPendingSpadesForMe > 0 || hasPendingCultSpadesForMe > 0
+    // The server lists what is usable now; older payloads fall back to the octagons.
+    if (localPlayer.availableSpecialActions) {
+      return localPlayer.availableSpecialActions.length > 0 || hasPendingSpades
+    }
     const used = localPlayer.specialActionsUsed ?? {}
     const heldCards = [
       ...(gameState?.bonusCards?.playerCards?.[localPlayerId] !== undefined ? [gameState.bonusCards.playerCards[localPlayerId]] : []),
@@ -820,7 +968,7 @@ export const Game = () => {
         break
     }
 
-    return hasUnusedStronghold || hasUnusedBonusSpade || hasUnusedBonusCult || hasPendingSpadesForMe > 0 || hasPendingCultSpadesForMe > 0
+    return hasUnusedStronghold || hasUnusedBonusSpade || hasUnusedBonusCult || hasPendingSpades
   }, [gameState?.bonusCards?.playerCards, gameState?.bonusCards?.playerExtraCards, hasPendingCultSpadesForMe, hasPendingSpadesForMe, localFactionType, localPlayer, localPlayerId])
 
   const bonusCardOwners = useMemo(() => {
@@ -1300,10 +1448,12 @@ export const Game = () => {
   const handleBurnPower = (playerId: string, amount: number): void => {
     if (!gameState || playerId !== localPlayerId || !canUseConversionWindow) return
 
+    // With confirmBurn on, the prompt always shows and the server requires
+    // the burn to arrive confirmed.
     queueConfirm('Confirm Burn Power', `Burn ${String(amount * 2)} power from Bowl II to gain ${String(amount)} power in Bowl III?`, () => {
-      performAction('burn_power', { amount })
+      performAction('burn_power', { amount, confirmed: true })
       setConfirmDialog(null)
-    })
+    }, localPlayerOptions.confirmBurn ? 'always' : 'interaction')
   }
 
   const handleAdvanceShipping = (playerId: string): void => {
@@ -1618,6 +1768,7 @@ export const Game = () => {
       performAction('pass', {
         ...payload,
         ...(snowShamansUpgrade ? { snowShamansUpgrade } : {}),
+        confirmed: true,
       })
       setConfirmDialog(null)
     }
@@ -1662,7 +1813,7 @@ export const Game = () => {
 
     queueConfirm('Confirm Pass', message, () => {
       submitPass()
-    }, 'turn_end')
+    }, localPlayerOptions.confirmPass ? 'always' : 'turn_end')
   }
 
   const isPassingCardClickable = (cardType: BonusCardType): boolean => {
@@ -1848,11 +1999,15 @@ export const Game = () => {
   }
 
   const setupBonusCards = useMemo(() => {
+    const offered = pendingDecisionType === 'setup_bonus_card' ? pendingDecision?.availableBonusCards : undefined
+    if (Array.isArray(offered)) {
+      return offered.map((card) => Number(card)).filter((card) => Number.isInteger(card) && card >= 0)
+    }
     return Object.entries(gameState?.bonusCards?.available ?? {})
       .map(([k]) => Number(k))
       .filter((card) => Number.isInteger(card) && card >= 0)
       .sort((a, b) => a - b)
-  }, [gameState?.bonusCards?.available])
+  }, [gameState?.bonusCards?.available, pendingDecision, pendingDecisionType])
 
   const transformedHalflingsHexes = useMemo(() => {
     const pending = gameState?.pendingHalflingsSpades as unknown as { transformedHexes?: Array<{ Q?: number; R?: number; q?: number; r?: number }> }
@@ -2381,11 +2536,11 @@ export const Game = () => {
           <div>
             <div className="text-sm font-semibold text-slate-900">Darklings Ordination</div>
             <div className="text-sm text-slate-700">
-              Darklings can only convert workers to priests when a player just upgraded to a stronghold.
+              Trade up to {darklingsOrdinationChoices.length - 1} workers for one priest each.
             </div>
           </div>
           <div className="flex flex-wrap items-center gap-2">
-            {[0, 1, 2, 3].map((count) => (
+            {darklingsOrdinationChoices.map((count) => (
               <button
                 key={count}
                 type="button"
@@ -2658,6 +2813,26 @@ export const Game = () => {
                 <span>Confirm Turn End</span>
               </label>
 
+              <label className="flex items-center gap-2 text-sm text-slate-800">
+                <input
+                  data-testid="option-confirm-pass"
+                  type="checkbox"
+                  checked={localPlayerOptions.confirmPass ?? false}
+                  onChange={(e) => { updateConfirmations({ confirmPass: e.target.checked }) }}
+                />
+                <span>Confirm Before Pass</span>
+              </label>
+
+              <label className="flex items-center gap-2 text-sm text-slate-800">
+                <input
+                  data-testid="option-confirm-burn"
+                  type="checkbox"
+                  checked={localPlayerOptions.confirmBurn ?? false}
+                  onChange={(e) => { updateConfirmations({ confirmBurn: e.target.checked }) }}
+                />
+                <span>Confirm Before Burning Power</span>
+              </label>
+
               <label className="flex items-center gap-2 text-sm text-slate-800">
                 <input
                   data-testid="option-show-income-preview"
@@ -2667,16 +2842,164 @@ export const Game = () => {
                 />
                 <span>Show Next Income</span>
               </label>
+
+              <label className="flex items-center gap-2 text-sm text-slate-800">
+                <span>Always accept up to</span>
+                <input
+                  data-testid="option-leech-accept-up-to"
+                  type="number"
+                  min={0}
+                  max={5}
+                  className="w-14 rounded border border-slate-300 px-2 py-1 text-sm"
+                  value={localPlayerOptions.leechPreferences?.acceptUpTo ?? 0}
+                  onChange={(e) => { updateLeechPreferences({ acceptUpTo: Math.max(0, Number(e.target.value)) }) }}
+                />
+                <span>power</span>
+              </label>
+
+              <label className="flex items-center gap-2 text-sm text-slate-800">
+                <span>Decline when it costs</span>
+                <input
+                  data-testid="option-leech-decline-vp-cost"
+                  type="number"
+                  min={0}
+                  className="w-14 rounded border border-slate-300 px-2 py-1 text-sm"
+                  value={localPlayerOptions.leechPreferences?.declineFromVpCost ?? 0}
+                  onChange={(e) => { updateLeechPreferences({ declineFromVpCost: Math.max(0, Number(e.target.value)) }) }}
+                />
+                <span>VP or more above</span>
+                <input
+                  data-testid="option-leech-decline-above-vp"
+                  type="number"
+                  min={0}
+                  className="w-16 rounded border border-slate-300 px-2 py-1 text-sm"
+                  value={localPlayerOptions.leechPreferences?.declineAboveVp ?? 0}
+                  onChange={(e) => { updateLeechPreferences({ declineAboveVp: Math.max(0, Number(e.target.value)) }) }}
+                />
+                <span>VP</span>
+              </label>
             </div>
           </div>
         )}
 
+        {incomeReport && (
+          <div className="mb-3 rounded border border-emerald-200 bg-emerald-50 px-3 py-2 text-xs text-emerald-900" data-testid="income-report">
+            <div className="font-semibold">Round {incomeReport.round} income</div>
+            {incomeReport.contributions.map((contribution) => (
+              <div key={contribution.source}>
+                {contribution.source.replace(/_/g, ' ')}: {formatIncome(contribution.income)}
+              </div>
+            ))}
+            <div className="font-semibold">Received: {formatIncome(incomeReport.applied)}</div>
+            {incomeReport.warnings?.map((warning) => (
+              <div key={warning.resource} className="text-amber-800" data-testid={`income-warning-${warning.resource}`}>
+                You wasted {formatIncomeWarning(warning)} income
+              </div>
+            ))}
+          </div>
+        )}
+
+        {gameState?.phase === GamePhase.End && seatPlayerId && gameId && (
+          <div className="mb-3 rounded border border-sky-200 bg-sky-50 px-3 py-2 text-xs text-sky-900" data-testid="public-summary">
+            <label className="flex items-center gap-2">
+              <input
+                type="checkbox"
+                data-testid="public-summary-toggle"
+                checked={gameState.publicSummaryConsent?.includes(seatPlayerId) ?? false}
+                onChange={(e) => {
+                  sendMessage({ type: 'set_public_summary', payload: { gameID: gameId, public: e.target.checked } })
+                }}
+              />
+              <span>Share the result publicly</span>
+            </label>
+            {!gameState.publicSummary && gameState.publicSummaryConsent?.includes(seatPlayerId) && (
+              <div data-testid="public-summary-waiting">Shared once every player agrees</div>
+            )}
+            {gameState.publicSummary && (
+              <a className="underline" href={`/api/games/${gameId}/summary?format=html`} target="_blank" rel="noreferrer">
+                Shareable result page
+              </a>
+            )}
+          </div>
+        )}
+
+        {(pendingHex || annotations.length > 0) && (
+          <div className="mb-3 rounded border border-violet-200 bg-violet-50 px-3 py-2 text-xs text-violet-900" data-testid="board-annotations">
+            {pendingHex && (
+              <div className="mb-1 flex items-center gap-2">
+                <button
+                  type="button"
+                  className="rounded border border-violet-300 bg-white px-2 py-0.5"
+                  data-testid="annotate-planned-build"
+                  onClick={() => {
+                    sendMessage({
+                      type: 'add_annotation',
+                      payload: { gameID: gameId, kind: 'planned_build', visibility: annotationVisibility, hex: pendingHex, building: 'dwelling' },
+                    })
+                  }}
+                >
+                  Mark planned build at {formatHexCoord(pendingHex)}
+                </button>
+                <select
+                  data-testid="annotation-visibility"
+                  className="rounded border border-violet-300 bg-white px-1 py-0.5"
+                  value={annotationVisibility}
+                  onChange={(e) => { setAnnotationVisibility(e.target.value as AnnotationVisibility) }}
+                >
+                  <option value="private">Only me</option>
+                  <option value="team">My side of the table</option>
+                  <option value="all">Everyone</option>
+                </select>
+              </div>
+            )}
+            {annotations.map((annotation) => (
+              <div key={annotation.id} className="flex items-center gap-2">
+                <span>
+                  {annotation.spectator ? 'Spectator' : annotation.authorId}: {annotation.kind === 'arrow' && annotation.to
+                    ? `${formatHexCoord(annotation.hex)} → ${formatHexCoord(annotation.to)}`
+                    : `${annotation.building ?? 'build'} at ${formatHexCoord(annotation.hex)}`}
+                  {annotation.label ? ` (${annotation.label})` : ''} [{annotation.visibility}]
+                </span>
+                {annotation.authorId === annotationViewerId && (
+                  <button
+                    type="button"
+                    className="underline"
+                    onClick={() => { sendMessage({ type: 'remove_annotation', payload: { gameID: gameId, id: annotation.id } }) }}
+                  >
+                    remove
+                  </button>
+                )}
+              </div>
+            ))}
+          </div>
+        )}
+
+        {autoLeechLog.length > 0 && (
+          <div className="mb-3 rounded border border-sky-200 bg-sky-50 px-3 py-2 text-xs text-sky-900" data-testid="auto-leech-log">
+            {autoLeechLog.map((line, index) => (
+              <div key={index}>{line}</div>
+            ))}
+          </div>
+        )}
+
         {errorMessage && (
           <div className="mb-4 rounded border border-red-300 bg-red-50 px-4 py-2 text-sm text-red-800" data-testid="action-error-message">
             {errorMessage}
           </div>
         )}
 
+        {leechWaitMessage && (
+          <div className="mb-4 rounded border border-sky-300 bg-sky-50 px-4 py-2 text-sm text-sky-800" data-testid="leech-wait-message">
+            {leechWaitMessage}
+          </div>
+        )}
+
+        {reminderMessage && (
+          <div className="mb-4 rounded border border-amber-300 bg-amber-50 px-4 py-2 text-sm text-amber-800" data-testid="turn-reminder-message">
+            {reminderMessage}
+          </div>
+        )}
+
         {(powerMode?.type === 'power_bridge' && powerMode.firstHex == null) && (
           <div className="mb-3 rounded border border-orange-300 bg-orange-50 px-4 py-2 text-sm text-orange-800">
             Bridge mode: click a bridge edge on the board (or click two endpoints).
@@ -2752,6 +3075,7 @@ export const Game = () => {
             currentPlayerPosition={currentPlayerPosition}
             enableFanFactions={gameState?.enableFanFactions ?? false}
             enableFireIceFactions={gameState?.enableFireIceFactions ?? false}
+            availableFactions={gameState?.availableFactions}
           />
         )}
 
@@ -2955,6 +3279,12 @@ export const Game = () => {
                 priestsOnTrack={gameState?.cultTracks?.priestsOnTrack}
                 players={gameState?.players as Record<string, { faction: FactionType }>}
               />
+              {localCultRewardProgress && (
+                <div className="mt-1 px-1 text-xs text-slate-600" data-testid="cult-reward-progress">
+                  {CultType[localCultRewardProgress.track]} reward this round: {localCultRewardProgress.rewardCount} earned
+                  {localCultRewardProgress.stepsToNext > 0 && `, ${String(localCultRewardProgress.stepsToNext)} more ${localCultRewardProgress.stepsToNext === 1 ? 'step' : 'steps'} for the next`}
+                </div>
+              )}
             </div>
           </div>
 
@@ -3025,6 +3355,7 @@ export const Game = () => {
                 onCardClick={handlePassingTileClick}
                 isCardClickable={isPassingCardClickable}
                 activeSpecialCardActionType={activeBonusCardActionType}
+                incomeWarnings={localPlayerId ? (gameState?.passIncomeWarnings?.[localPlayerId] ?? undefined) : undefined}
               />
             </div>
           </div>
@@ -1,5 +1,5 @@
 import React from 'react';
-import { BonusCardType, SpecialActionType, type FactionType, type PlayerState } from '../../types/game.types';
+import { BonusCardType, SpecialActionType, type FactionType, type IncomeWarning, type PlayerState } from '../../types/game.types';
 import {
     CoinIcon,
     WorkerIcon,
@@ -25,8 +25,13 @@ interface PassingTilesProps {
     onCardClick?: (cardType: BonusCardType) => void;
     isCardClickable?: (cardType: BonusCardType) => boolean;
     activeSpecialCardActionType?: SpecialActionType | null;
+    incomeWarnings?: Record<string, IncomeWarning[]>; // Map of BonusCardType -> income the local player would waste
 }
 
+const describeIncomeWarnings = (warnings: IncomeWarning[]): string => (
+    `Wastes ${warnings.map((warning) => `${String(warning.wasted)} ${warning.resource}`).join(' and ')} of next round's income`
+);
+
 const isSplitCard = (type: BonusCardType): boolean => {
     switch (type) {
         case BonusCardType.Priest:
@@ -54,7 +59,8 @@ const BonusCardContent: React.FC<{
     coins?: number;
     playerColor?: string;
     isPassed?: boolean;
-}> = ({ type, isUsed, coins, playerColor, isPassed }) => {
+    wasteWarning?: string;
+}> = ({ type, isUsed, coins, playerColor, isPassed, wasteWarning }) => {
     const split = isSplitCard(type);
 
     const renderContent = (): React.ReactNode => {
@@ -236,6 +242,19 @@ const BonusCardContent: React.FC<{
 
             {split ? renderContent() : <div className="passing-tile-single">{renderContent()}</div>}
             {shouldShowDivider(type) && <div className="passing-tile-divider" />}
+
+            {/* Income Waste Indicator */}
+            {wasteWarning && (
+                <div title={wasteWarning} style={{
+                    position: 'absolute',
+                    bottom: '3%',
+                    right: '5%',
+                    zIndex: 10,
+                    color: '#b45309',
+                    fontWeight: 'bold',
+                    fontSize: '20cqw'
+                }}>!</div>
+            )}
         </div>
     );
 };
@@ -248,7 +267,8 @@ export const PassingTiles: React.FC<PassingTilesProps> = ({
     passedPlayers,
     onCardClick,
     isCardClickable,
-    activeSpecialCardActionType
+    activeSpecialCardActionType,
+    incomeWarnings
 }) => {
     if (!availableCards || availableCards.length === 0) return null;
 
@@ -277,6 +297,8 @@ export const PassingTiles: React.FC<PassingTilesProps> = ({
 
                 const isPassed = ownerId ? passedPlayers?.has(ownerId) : false;
                 const coins = bonusCardCoins?.[String(cardType)] ?? 0;
+                const cardWarnings = incomeWarnings?.[String(cardType)];
+                const wasteWarning = cardWarnings && cardWarnings.length > 0 ? describeIncomeWarnings(cardWarnings) : undefined;
                 const isSpecialActionCard = cardType === BonusCardType.Spade || cardType === BonusCardType.CultAdvance;
                 const isActiveSpecialAction = (
                     (cardType === BonusCardType.Spade && activeSpecialCardActionType === SpecialActionType.BonusCardSpade)
@@ -299,6 +321,7 @@ export const PassingTiles: React.FC<PassingTilesProps> = ({
                         data-testid={`passing-card-${String(cardType)}`}
                         type="button"
                         className={`${isSpecialActionCard ? 'passing-card-special-action' : ''} ${isActiveSpecialAction ? 'passing-card-special-action-active' : ''}`.trim()}
+                        title={wasteWarning}
                         onClick={() => { onCardClick?.(cardType); }}
                         disabled={isCardClickable ? !isCardClickable(cardType) : false}
                         style={{
@@ -316,6 +339,7 @@ export const PassingTiles: React.FC<PassingTilesProps> = ({
                             playerColor={playerColor}
                             coins={coins}
                             isPassed={isPassed}
+                            wasteWarning={wasteWarning}
                         />
                     </button>
                 );
@@ -22,6 +22,31 @@ function extractGameId(input: string): string {
     return trimmed;
 }
 
+interface UnsupportedFeature {
+    kind: string;
+    name: string;
+    line: number;
+}
+
+/**
+ * Read the error body of a failed import, listing unsupported log features
+ * when the server reports them.
+ */
+async function readImportError(res: Response, fallback: string): Promise<string> {
+    const text = await res.text();
+    try {
+        const body = JSON.parse(text) as { error?: string; unsupportedFeatures?: UnsupportedFeature[] };
+        if (body.unsupportedFeatures && body.unsupportedFeatures.length > 0) {
+            const features = body.unsupportedFeatures.map((f) => `${f.kind} ${f.name} (line ${String(f.line)})`);
+            return `this log uses features the replay does not support: ${features.join(', ')}`;
+        }
+        if (body.error) return body.error;
+    } catch {
+        // Plain-text error body.
+    }
+    return text || fallback;
+}
+
 interface ImportStatus {
     status: 'idle' | 'loading' | 'error' | 'success';
     message: string;
@@ -54,8 +79,7 @@ export const ImportGame: React.FC = () => {
             });
 
             if (!res.ok) {
-                const errorText = await res.text();
-                throw new Error(errorText || 'Failed to fetch game');
+                throw new Error(await readImportError(res, 'Failed to fetch game'));
             }
 
             setImportStatus({ status: 'success', message: 'Game loaded! Redirecting...' });
@@ -91,8 +115,7 @@ export const ImportGame: React.FC = () => {
             });
 
             if (!res.ok) {
-                const errorText = await res.text();
-                throw new Error(errorText || 'Failed to import log text');
+                throw new Error(await readImportError(res, 'Failed to import log text'));
             }
 
             setImportStatus({ status: 'success', message: 'Log imported! Redirecting...' });
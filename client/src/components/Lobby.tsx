@@ -5,6 +5,7 @@ import { useWebSocket } from '../services/WebSocketContext'
 import { useGameStore } from '../stores/gameStore'
 import { DEFAULT_MAP_CATALOG } from '../data/mapCatalog'
 import type { CustomMapDefinition, MapSummary } from '../types/map.types'
+import type { SpeedPreset } from '../types/game.types'
 import { CustomMapEditor } from './CustomMapEditor'
 import { buildCustomMapHexes, createEmptyCustomMapDefinition } from '../utils/customMapUtils'
 import { HexGridCanvas } from './GameBoard/HexGridCanvas'
@@ -22,7 +23,29 @@ interface GameInfo {
   customMap?: CustomMapDefinition
   started?: boolean
   players: string[]
+  playerStatus?: Record<string, PlayerPresence>
   maxPlayers: number
+  reservedSeats?: string[]
+  banned?: string[]
+  locked?: boolean
+}
+
+type PlayerPresence = 'offline' | 'online' | 'idle' | 'in_game'
+
+const PRESENCE_LABELS: Record<PlayerPresence, string> = {
+  offline: 'offline',
+  online: 'online',
+  idle: 'idle',
+  in_game: 'in game',
+}
+
+function formatLobbyPlayers(game: GameInfo): string {
+  return game.players
+    .map((player) => {
+      const status = game.playerStatus?.[player]
+      return status ? `${player} (${PRESENCE_LABELS[status]})` : player
+    })
+    .join(', ')
 }
 
 interface LobbyMessage {
@@ -65,6 +88,14 @@ function formatLobbyError(payload: LobbyErrorPayload): string {
       return 'You are not seated in that game.'
     case 'invalid_map':
       return 'Select a valid map.'
+    case 'host_only':
+      return 'Only the host can do that.'
+    case 'player_banned':
+      return 'The host has banned you from that game.'
+    case 'game_locked':
+      return 'The host has locked that game.'
+    case 'seat_reserved':
+      return 'The remaining seats are reserved for invited players.'
     default:
       return 'Lobby action failed.'
   }
@@ -82,8 +113,12 @@ export function Lobby(): React.ReactElement {
   const [availableMaps, setAvailableMaps] = useState<MapSummary[]>(DEFAULT_MAP_CATALOG)
   const [newGameMapId, setNewGameMapId] = useState('base')
   const [customMapDefinition, setCustomMapDefinition] = useState<CustomMapDefinition>(() => createEmptyCustomMapDefinition())
+  const [customMapGrid, setCustomMapGrid] = useState('')
   const [randomizeTurnOrder, setRandomizeTurnOrder] = useState(true)
+  const [hotseat, setHotseat] = useState(false)
   const [setupMode, setSetupMode] = useState<'snellman' | 'auction' | 'fast_auction'>('snellman')
+  const [turnOrderPolicy, setTurnOrderPolicy] = useState<'pass_order' | 'cyclic_from_first_passer'>('pass_order')
+  const [speedPreset, setSpeedPreset] = useState<SpeedPreset | ''>('')
   const [turnTimerEnabled, setTurnTimerEnabled] = useState(false)
   const [turnTimerMinutes, setTurnTimerMinutes] = useState(25)
   const [turnTimerIncrementSeconds, setTurnTimerIncrementSeconds] = useState(0)
@@ -91,6 +126,7 @@ export function Lobby(): React.ReactElement {
   const [enableFireIceFactions, setEnableFireIceFactions] = useState(false)
   const [fireIceScoring, setFireIceScoring] = useState<'off' | 'on' | 'random'>('off')
   const [lobbyError, setLobbyError] = useState<string | null>(null)
+  const [rulesSummaries, setRulesSummaries] = useState<Record<string, string[]>>({})
 
   const trimmedPlayerName = playerName.trim()
   const activePlayerName = trimmedPlayerName || storedLocalPlayerId?.trim() || ''
@@ -118,12 +154,19 @@ export function Lobby(): React.ReactElement {
       if (msg.type === 'lobby_state') {
         setGames(Array.isArray(msg.payload) ? msg.payload as GameInfo[] : [])
         setLobbyError(null)
+      } else if (msg.type === 'rules_summary') {
+        const payload = (msg.payload ?? {}) as { gameId?: string; lines?: string[] }
+        if (payload.gameId) {
+          const gameId = payload.gameId
+          setRulesSummaries((prev) => ({ ...prev, [gameId]: payload.lines ?? [] }))
+        }
       } else if (msg.type === 'available_maps') {
         setAvailableMaps(Array.isArray(msg.payload) ? msg.payload as MapSummary[] : DEFAULT_MAP_CATALOG)
       } else if (msg.type === 'error') {
         setLobbyError(formatLobbyError((msg.payload ?? '') as LobbyErrorPayload))
       } else if (msg.type === 'game_left') {
-        setLobbyError(null)
+        const payload = (msg.payload ?? {}) as { kicked?: boolean; banned?: boolean }
+        setLobbyError(payload.kicked ? (payload.banned ? 'The host removed and banned you from the game.' : 'The host removed you from the game.') : null)
       } else if (msg.type === 'model_game_started') {
         const payload = (msg.payload ?? {}) as StartedGamePayload
         if (payload.playerId && payload.gameId) {
@@ -172,7 +215,9 @@ export function Lobby(): React.ReactElement {
         enableFanFactions,
         enableFireIceFactions,
         fireIceScoring,
-        customMap: newGameMapId === 'custom' ? customMapDefinition : undefined,
+        customMap: newGameMapId === 'custom' && !customMapGrid.trim() ? customMapDefinition : undefined,
+        customMapGrid: newGameMapId === 'custom' && customMapGrid.trim() ? customMapGrid : undefined,
+        customMapName: newGameMapId === 'custom' && customMapGrid.trim() ? customMapDefinition.name : undefined,
       },
     })
     setNewGameName('')
@@ -311,6 +356,18 @@ export function Lobby(): React.ReactElement {
                 disabled={!isConnected || joinedGameId !== null}
               />
             )}
+            {newGameMapId === 'custom' && (
+              <label className="lobby-field-stack">
+                <span className="lobby-label">Or paste a community map grid (one row per line, e.g. U,S,G,B,Y,R,K)</span>
+                <textarea
+                  data-testid="lobby-custom-map-grid"
+                  value={customMapGrid}
+                  onChange={(e) => { setCustomMapGrid(e.target.value) }}
+                  disabled={!isConnected || joinedGameId !== null}
+                  rows={6}
+                />
+              </label>
+            )}
 
             <div className="lobby-option-list">
               <label className="lobby-checkbox-row">
@@ -323,6 +380,16 @@ export function Lobby(): React.ReactElement {
                 <span>Random turn order</span>
               </label>
 
+              <label className="lobby-checkbox-row">
+                <input
+                  type="checkbox"
+                  data-testid="lobby-hotseat"
+                  checked={hotseat}
+                  onChange={(e) => { setHotseat(e.target.checked) }}
+                />
+                <span>Hotseat (this connection plays every seat)</span>
+              </label>
+
               <label className="lobby-field-stack">
                 <span className="lobby-label">Setup mode</span>
                 <select
@@ -338,6 +405,20 @@ export function Lobby(): React.ReactElement {
                 </select>
               </label>
 
+              <label className="lobby-field-stack">
+                <span className="lobby-label">Next round turn order</span>
+                <select
+                  data-testid="lobby-turn-order-policy"
+                  value={turnOrderPolicy}
+                  onChange={(e) => { setTurnOrderPolicy(e.target.value as 'pass_order' | 'cyclic_from_first_passer') }}
+                  className="lobby-select"
+                  disabled={!isConnected || joinedGameId !== null}
+                >
+                  <option value="pass_order">Pass order (variable)</option>
+                  <option value="cyclic_from_first_passer">Seat order from first passer</option>
+                </select>
+              </label>
+
               <label className="lobby-checkbox-row">
                 <input
                   type="checkbox"
@@ -375,51 +456,68 @@ export function Lobby(): React.ReactElement {
                 </select>
               </label>
 
-              <div className="lobby-timer-box">
-                <label className="lobby-checkbox-row">
-                  <input
-                    type="checkbox"
-                    data-testid="lobby-turn-timer-enabled"
-                    checked={turnTimerEnabled}
-                    onChange={(e) => { setTurnTimerEnabled(e.target.checked) }}
-                  />
-                  <span>Enable turn timer</span>
-                </label>
-                {turnTimerEnabled && (
-                  <div className="lobby-create-grid">
-                    <label className="lobby-field-stack">
-                      <span className="lobby-label">Minutes</span>
-                      <input
-                        type="number"
-                        data-testid="lobby-turn-timer-minutes"
-                        min={1}
-                        step={1}
-                        value={turnTimerMinutes}
-                        onChange={(e) => {
-                          const value = Number(e.target.value)
-                          setTurnTimerMinutes(Number.isFinite(value) ? value : 25)
-                        }}
-                        className="lobby-input"
-                      />
-                    </label>
-                    <label className="lobby-field-stack">
-                      <span className="lobby-label">Increment (sec)</span>
-                      <input
-                        type="number"
-                        data-testid="lobby-turn-timer-increment"
-                        min={0}
-                        step={1}
-                        value={turnTimerIncrementSeconds}
-                        onChange={(e) => {
-                          const value = Number(e.target.value)
-                          setTurnTimerIncrementSeconds(Number.isFinite(value) ? value : 0)
-                        }}
-                        className="lobby-input"
-                      />
-                    </label>
-                  </div>
-                )}
-              </div>
+              <label className="lobby-field-stack">
+                <span className="lobby-label">Speed</span>
+                <select
+                  data-testid="lobby-speed-preset"
+                  value={speedPreset}
+                  onChange={(e) => { setSpeedPreset(e.target.value as SpeedPreset | '') }}
+                  className="lobby-select"
+                >
+                  <option value="">Custom</option>
+                  <option value="blitz">Blitz (30s per turn)</option>
+                  <option value="standard">Standard (25 min + 15s)</option>
+                  <option value="async">Async (daily reminders)</option>
+                </select>
+              </label>
+
+              {speedPreset === '' && (
+                <div className="lobby-timer-box">
+                  <label className="lobby-checkbox-row">
+                    <input
+                      type="checkbox"
+                      data-testid="lobby-turn-timer-enabled"
+                      checked={turnTimerEnabled}
+                      onChange={(e) => { setTurnTimerEnabled(e.target.checked) }}
+                    />
+                    <span>Enable turn timer</span>
+                  </label>
+                  {turnTimerEnabled && (
+                    <div className="lobby-create-grid">
+                      <label className="lobby-field-stack">
+                        <span className="lobby-label">Minutes</span>
+                        <input
+                          type="number"
+                          data-testid="lobby-turn-timer-minutes"
+                          min={1}
+                          step={1}
+                          value={turnTimerMinutes}
+                          onChange={(e) => {
+                            const value = Number(e.target.value)
+                            setTurnTimerMinutes(Number.isFinite(value) ? value : 25)
+                          }}
+                          className="lobby-input"
+                        />
+                      </label>
+                      <label className="lobby-field-stack">
+                        <span className="lobby-label">Increment (sec)</span>
+                        <input
+                          type="number"
+                          data-testid="lobby-turn-timer-increment"
+                          min={0}
+                          step={1}
+                          value={turnTimerIncrementSeconds}
+                          onChange={(e) => {
+                            const value = Number(e.target.value)
+                            setTurnTimerIncrementSeconds(Number.isFinite(value) ? value : 0)
+                          }}
+                          className="lobby-input"
+                        />
+                      </label>
+                    </div>
+                  )}
+                </div>
+              )}
             </div>
           </div>
 
@@ -474,12 +572,65 @@ export function Lobby(): React.ReactElement {
                             </span>
                             {isModelGame && <span className="lobby-tag lobby-tag-muted">Opponent: Model</span>}
                             {g.host && <span className="lobby-tag lobby-tag-muted">Host: {g.host}</span>}
+                            {g.locked && <span className="lobby-tag lobby-tag-muted">Locked</span>}
                           </div>
                         </div>
                         <div className="lobby-player-line">
                           <span>{String(g.players.length)}/{String(g.maxPlayers)} players</span>
-                          <span>{g.players.join(', ') || 'No players yet'}</span>
+                          <span>{formatLobbyPlayers(g) || 'No players yet'}</span>
                         </div>
+                        {(g.reservedSeats?.length ?? 0) > 0 && (
+                          <div className="lobby-player-line">
+                            <span>Reserved for {g.reservedSeats?.join(', ')}</span>
+                          </div>
+                        )}
+                        {isHost && (
+                          <div className="lobby-host-controls">
+                            {g.players.filter((player) => player !== g.host).map((player) => (
+                              <span key={player}>
+                                {player}{' '}
+                                <button
+                                  data-testid={`lobby-kick-${g.id}-${player}`}
+                                  onClick={() => { sendMessage({ type: 'kick_player', payload: { id: g.id, name: player } }) }}
+                                  disabled={!isConnected}
+                                  className="lobby-button lobby-button-danger"
+                                >
+                                  Kick
+                                </button>
+                                <button
+                                  data-testid={`lobby-ban-${g.id}-${player}`}
+                                  onClick={() => { sendMessage({ type: 'kick_player', payload: { id: g.id, name: player, ban: true } }) }}
+                                  disabled={!isConnected}
+                                  className="lobby-button lobby-button-danger"
+                                >
+                                  Ban
+                                </button>
+                              </span>
+                            ))}
+                            <input
+                              data-testid={`lobby-reserve-${g.id}`}
+                              placeholder="Reserve seats (comma-separated names)"
+                              defaultValue={(g.reservedSeats ?? []).join(', ')}
+                              onBlur={(e) => {
+                                sendMessage({
+                                  type: 'reserve_seats',
+                                  payload: { id: g.id, names: e.target.value.split(',').map((name) => name.trim()).filter(Boolean) },
+                                })
+                              }}
+                              disabled={!isConnected}
+                            />
+                            <label className="lobby-checkbox-row">
+                              <input
+                                type="checkbox"
+                                data-testid={`lobby-lock-${g.id}`}
+                                checked={g.locked ?? false}
+                                onChange={(e) => { sendMessage({ type: 'lock_game', payload: { id: g.id, locked: e.target.checked } }) }}
+                                disabled={!isConnected}
+                              />
+                              Locked (only reserved players can join)
+                            </label>
+                          </div>
+                        )}
                         {g.mapId === 'custom' && g.customMap && (
                           <div className="lobby-map-preview">
                             <HexGridCanvas
@@ -521,8 +672,11 @@ export function Lobby(): React.ReactElement {
                               payload: {
                                 gameID: g.id,
                                 randomizeTurnOrder,
+                                hotseat,
                                 setupMode: isModelGame ? 'snellman' : setupMode,
-                                turnTimerEnabled,
+                                turnOrderPolicy,
+                                speedPreset: speedPreset || undefined,
+                                turnTimerEnabled: speedPreset === '' && turnTimerEnabled,
                                 turnTimerSeconds: Math.max(1, Math.trunc(turnTimerMinutes * 60)),
                                 turnTimerIncrementSeconds: Math.max(0, Math.trunc(turnTimerIncrementSeconds)),
                               },
@@ -581,7 +735,7 @@ export function Lobby(): React.ReactElement {
                         </div>
                         <div className="lobby-player-line">
                           <span>{String(g.players.length)}/{String(g.maxPlayers)} players</span>
-                          <span>{g.players.join(', ') || 'No players listed'}</span>
+                          <span>{formatLobbyPlayers(g) || 'No players listed'}</span>
                         </div>
                         {g.mapId === 'custom' && g.customMap && (
                           <div className="lobby-map-preview">
@@ -593,9 +747,22 @@ export function Lobby(): React.ReactElement {
                             />
                           </div>
                         )}
+                        {rulesSummaries[g.id] && (
+                          <ul className="lobby-rules-summary" data-testid={`lobby-rules-${g.id}`}>
+                            {rulesSummaries[g.id].map((line) => <li key={line}>{line}</li>)}
+                          </ul>
+                        )}
                       </div>
 
                       <div className="lobby-game-actions">
+                        <button
+                          data-testid={`lobby-rules-button-${g.id}`}
+                          onClick={() => { sendMessage({ type: 'get_rules_summary', payload: { gameID: g.id } }) }}
+                          disabled={!isConnected}
+                          className="lobby-button lobby-button-secondary"
+                        >
+                          Rules
+                        </button>
                         <button
                           data-testid={`lobby-spectate-${g.id}`}
                           onClick={() => { handleSpectateGame(g.id) }}
@@ -74,6 +74,20 @@ export const ReplayControls: React.FC<ReplayControlsProps> = ({
                 >
                     Export Snapshot
                 </button>
+
+                <button
+                    onClick={() => { window.open(`/api/replay/vp_progression?gameId=${gameId}`, '_blank'); }}
+                    className="px-4 py-2 bg-gray-600 hover:bg-gray-700 text-white rounded font-medium transition-colors"
+                >
+                    VP Progression
+                </button>
+
+                <button
+                    onClick={() => { window.open(`/api/replay/score_projection?gameId=${gameId}`, '_blank'); }}
+                    className="px-4 py-2 bg-gray-600 hover:bg-gray-700 text-white rounded font-medium transition-colors"
+                >
+                    Score Projection
+                </button>
             </div>
         </div>
     );
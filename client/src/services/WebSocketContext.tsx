@@ -103,6 +103,16 @@ export const WebSocketProvider: React.FC<WebSocketProviderProps> = ({ children }
         if (data.type === 'game_state_update' && data.payload) {
           useGameStore.getState().setGameState(data.payload as GameState)
         }
+        if (data.type === 'action_accepted' || data.type === 'action_rejected') {
+          const ack = (data.payload ?? {}) as { actionId?: string; seq?: number }
+          if (typeof ack.actionId === 'string') {
+            if (data.type === 'action_accepted') {
+              useGameStore.getState().ackAction(ack.actionId)
+            } else {
+              useGameStore.getState().nackAction(ack.actionId, ack.seq)
+            }
+          }
+        }
 
         setLastMessage(data)
       } catch {
@@ -1,11 +1,13 @@
 import { useWebSocket } from './WebSocketContext'
 import { useGameStore } from '../stores/gameStore'
+import type { GameState } from '../types/game.types'
 
 interface PerformActionPayload {
   type: string
   gameID: string
   actionId: string
   expectedRevision?: number
+  playerId?: string
   params?: Record<string, unknown>
 }
 
@@ -21,36 +23,52 @@ const makeActionID = (): string => {
   return `action-${Date.now()}-${Math.random().toString(16).slice(2)}`
 }
 
+// hotseatActor is the player a hotseat game is waiting on: the owner of the
+// pending decision, or else the player whose turn it is.
+export const hotseatActor = (state: GameState): string | undefined => {
+  const decisionPlayerId = state.pendingDecision?.playerId
+  if (typeof decisionPlayerId === 'string' && decisionPlayerId !== '') return decisionPlayerId
+  return state.turnOrder?.[state.currentTurn]
+}
+
 const shouldDisableExpectedRevision = (): boolean => {
   if (typeof window === 'undefined') return false
   return Boolean((window as Window & { __TM_DISABLE_EXPECTED_REVISION__?: unknown }).__TM_DISABLE_EXPECTED_REVISION__)
 }
 
 export function useActionService(): {
-  submitAction: (gameID: string, type: string, params?: Record<string, unknown>) => void
+  submitAction: (gameID: string, type: string, params?: Record<string, unknown>, optimistic?: (draft: GameState) => void) => void
   submitSetupDwelling: (playerID: string, q: number, r: number, gameID?: string) => void
   submitSelectFaction: (playerID: string, faction: string, gameID: string) => void
 } {
   const { sendMessage } = useWebSocket()
 
-  const submitAction = (gameID: string, type: string, params: Record<string, unknown> = {}): void => {
-    const expectedRevision = useGameStore.getState().gameState?.revision ?? 0
+  // optimistic, if given, is applied to the local state right away and
+  // rolled back if the server rejects the action.
+  const submitAction = (gameID: string, type: string, params: Record<string, unknown> = {}, optimistic?: (draft: GameState) => void): void => {
+    const gameState = useGameStore.getState().gameState
+    const expectedRevision = gameState?.revision ?? 0
+    const actionId = makeActionID()
 
     const payload: PerformActionPayload = {
       type,
       gameID,
-      actionId: makeActionID(),
+      actionId,
       params,
     }
     if (!shouldDisableExpectedRevision()) {
       payload.expectedRevision = expectedRevision
     }
+    if (gameState?.hotseat) {
+      payload.playerId = hotseatActor(gameState)
+    }
 
     const message: ActionMessage = {
       type: 'perform_action',
       payload,
     }
 
+    useGameStore.getState().beginAction(actionId, gameID, type, optimistic)
     sendMessage(message)
   }
 
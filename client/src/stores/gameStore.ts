@@ -3,11 +3,21 @@ import { create } from 'zustand';
 import { immer } from 'zustand/middleware/immer';
 import type { GameState, PlayerState } from '../types/game.types';
 
+// PendingAction is a perform_action awaiting its action_accepted or
+// action_rejected. rollback is the state before an optimistic update, if one
+// was applied.
+export interface PendingAction {
+  gameId: string;
+  type: string;
+  rollback: GameState | null;
+}
+
 interface GameStore {
   // State
   gameState: GameState | null;
   localPlayerId: string | null;
   playerIdsByGame: Record<string, string>;
+  pendingActions: Record<string, PendingAction>;
 
   // Computed getters
   getCurrentPlayer: () => PlayerState | null;
@@ -18,6 +28,9 @@ interface GameStore {
   setLocalPlayerId: (id: string) => void;
   bindLocalPlayerToGame: (gameId: string, playerId: string) => void;
   updateGameState: (updater: (draft: GameState) => void) => void;
+  beginAction: (actionId: string, gameId: string, type: string, optimistic?: (draft: GameState) => void) => void;
+  ackAction: (actionId: string) => void;
+  nackAction: (actionId: string, seq?: number) => void;
   reset: () => void;
 }
 
@@ -30,6 +43,7 @@ export const useGameStore = create<GameStore>()(
       gameState: null,
       localPlayerId: null,
       playerIdsByGame: {},
+      pendingActions: {},
 
       // Computed getters
       getCurrentPlayer: () => {
@@ -69,8 +83,40 @@ export const useGameStore = create<GameStore>()(
         });
       },
 
+      beginAction: (actionId, gameId, type, optimistic) => {
+        const { gameState } = get();
+        const rollback = optimistic && gameState ? gameState : null;
+        set((state) => {
+          state.pendingActions[actionId] = { gameId, type, rollback };
+          if (optimistic && state.gameState) {
+            optimistic(state.gameState);
+          }
+        });
+      },
+
+      // The game_state_update with the acked seq replaces the optimistic state.
+      ackAction: (actionId) => {
+        set((state) => {
+          delete state.pendingActions[actionId];
+        });
+      },
+
+      // Rolls back the optimistic update unless a game_state_update already
+      // replaced it. seq is the server's current revision.
+      nackAction: (actionId, seq) => {
+        const pending = get().pendingActions[actionId];
+        set((state) => {
+          delete state.pendingActions[actionId];
+          const { rollback } = pending ?? {};
+          if (!rollback || !state.gameState) return;
+          if (state.gameState.revision !== rollback.revision) return;
+          if (seq !== undefined && rollback.revision !== seq) return;
+          state.gameState = rollback;
+        });
+      },
+
       reset: () => {
-        set({ gameState: null, localPlayerId: null, playerIdsByGame: {} });
+        set({ gameState: null, localPlayerId: null, playerIdsByGame: {}, pendingActions: {} });
       },
     })),
     {
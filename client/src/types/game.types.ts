@@ -156,9 +156,11 @@ export interface PlayerState {
   name: string
   faction: FactionType
   hasPassed?: boolean
+  resigned?: boolean
   hasStrongholdAbility?: boolean
   resources: Resources
   shipping: number
+  carpetFlightRange?: number | null
   digging: number
   chashIncomeTrackLevel?: number
   cults: Partial<Record<CultType, number>>
@@ -177,16 +179,27 @@ export interface PlayerState {
   unlockedTerrains?: Partial<Record<TerrainType, boolean>>
   Faction?: FactionType | { Type: FactionType }
   specialActionsUsed?: Record<number, boolean>
+  availableSpecialActions?: SpecialActionType[] // Usable now by this player
   options?: PlayerOptions
 }
 
 export type LeechAutoMode = 'off' | 'accept_1' | 'accept_2' | 'accept_3' | 'accept_4' | 'decline_vp'
 
+export interface LeechPreferences {
+  acceptUpTo: number
+  declineFromVpCost: number
+  declineAboveVp: number
+}
+
 export interface PlayerOptions {
   autoLeechMode: LeechAutoMode
   autoConvertOnPass: boolean
   confirmActions: boolean
   showIncomePreview: boolean
+  // The server refuses passes and power burns not sent with confirmed: true.
+  confirmPass?: boolean
+  confirmBurn?: boolean
+  leechPreferences?: LeechPreferences
 }
 
 export interface IncomePreview {
@@ -255,10 +268,12 @@ export enum BonusCardType {
 }
 
 export interface BonusCardState {
+  // Available cards and the coins accumulated on each.
   available: Record<BonusCardType, number>
   playerCards: Record<string, BonusCardType>
   playerExtraCards?: Record<string, BonusCardType[]>
   playerHasCard: Record<string, boolean>
+  coinsPlacedForRound?: number
 }
 
 export interface ScoringTile {
@@ -285,6 +300,80 @@ export interface TownTileState {
   available: Record<number, number>
 }
 
+export interface HexAnalysis {
+  coord: { q: number; r: number }
+  terrain: TerrainType
+  isRiver: boolean
+  building?: { type: BuildingType; ownerPlayerId: string; faction: FactionType }
+  bridges: Array<{ to: { q: number; r: number }; ownerPlayerId: string }>
+  players: Record<string, { reachable: boolean; buildable: boolean }>
+}
+
+export interface MapAnalysis {
+  hexes: Record<string, HexAnalysis>
+}
+
+export interface StartHexPreview {
+  q: number
+  r: number
+  displayCoord?: string
+  room: number
+}
+
+export interface FactionStartPreview {
+  faction: string
+  homeTerrain?: string
+  homeHexes: number
+  contestedHexes: StartHexPreview[]
+  suggestedStarts: StartHexPreview[]
+}
+
+export interface FactionMapPreview {
+  terrainCounts: Record<string, number>
+  factions: FactionStartPreview[]
+}
+
+export interface OpponentSummary {
+  playerId: string
+  faction: FactionType
+  // coins and power are absent while hidden resources withholds them.
+  resources: {
+    coins?: number
+    workers: number
+    priests: number
+    power?: { powerI: number; powerII: number; powerIII: number }
+  }
+  resourcesHidden?: boolean
+  buildingsRemaining: {
+    dwellings: number
+    tradingHouses: number
+    temples: number
+    sanctuaries: number
+    strongholds: number
+  }
+  shipping: number
+  digging: number
+  cults: { fire: number; water: number; earth: number; air: number }
+  bonusCards: BonusCardType[]
+  favors: FavorTileType[]
+  towns: number[]
+  victoryPoints: number
+  passed: boolean
+}
+
+export interface CultRewardProgress {
+  track: CultType
+  position: number
+  rewardCount: number
+  // 0 when no further reward is reachable on the track.
+  stepsToNext: number
+}
+
+export interface LeechWait {
+  playerIds: string[]
+  nextPlayerId?: string
+}
+
 export interface GameState {
   id: string
   mapId?: string
@@ -292,6 +381,14 @@ export interface GameState {
   enableFireIceFactions?: boolean
   fireIceFinalScoringSetting?: 'off' | 'on' | 'random'
   fireIceFinalScoringTile?: 'distance' | 'stronghold_sanctuary' | 'edge' | 'cluster' | ''
+  // Revealed once the game has ended; null while it runs.
+  seed?: number | null
+  // Set when one connection plays every seat.
+  hotseat?: boolean
+  // Public once every seat in publicSummaryConsent has agreed.
+  publicSummary?: boolean
+  publicSummaryConsent?: string[] | null
+  mapAnalysis?: MapAnalysis
   revision?: number
   phase: GamePhase
   setupMode?: 'snellman' | 'auction' | 'fast_auction'
@@ -303,6 +400,12 @@ export interface GameState {
   players: Record<string, PlayerState>
   turnOrder: string[]
   passOrder?: string[]
+  // Next round's turn order from the passes so far, during the action phase.
+  upcomingTurnOrder?: string[] | null
+  // Players whose unanswered leech offers hold up the next turn, and who moves then.
+  leechWait?: LeechWait | null
+  // Every player's resources, buildings left and tiles, in turn order.
+  opponentSummaries?: OpponentSummary[]
   currentTurn: number
   map: MapState
   round: RoundState
@@ -315,6 +418,8 @@ export interface GameState {
   finalScoring?: Record<string, PlayerFinalScore>
   powerActions?: PowerActionState
   cultTracks?: CultTrackState
+  // Each player's progress toward this round's scoring tile cult reward.
+  cultRewardProgress?: Record<string, CultRewardProgress> | null
   pendingLeechOffers?: Record<string, unknown[]>
   pendingTownFormations?: Record<string, unknown[]>
   pendingSpades?: Record<string, number>
@@ -327,8 +432,12 @@ export interface GameState {
   pendingTownCultTopChoice?: unknown
   pendingDecision?: Record<string, unknown> | null
   auctionState?: AuctionState | null
+  availableFactions?: string[] | null
+  factionMapPreview?: FactionMapPreview | null
   turnTimer?: TurnTimerState | null
+  speedPreset?: SpeedPreset
   nextRoundIncome?: Record<string, IncomePreview> | null
+  passIncomeWarnings?: Record<string, Record<string, IncomeWarning[]>> | null
 }
 
 export interface PowerActionState {
@@ -343,10 +452,54 @@ export interface PlayerFinalScore {
   fireIceVp: number
   fireIceMetricValue: number
   cultVp: number
+  // Cult VP per track, indexed by CultTrack (fire, water, earth, air).
+  cultVpByTrack?: number[]
   resourceVp: number
+  // Part of resourceVp that comes from faction conversion rules.
+  factionVp?: number
   totalVp: number
   largestAreaSize: number
   totalResourceValue: number
+  resigned?: boolean
+  // Set in score projections that leave out resources the viewer cannot see.
+  resourcesHidden?: boolean
+}
+
+export interface ScoringAward {
+  playerId: string
+  value: number
+  vp: number
+  totalVp: number
+}
+
+// Payload of a scoring_step message, sent once per final scoring stage.
+export interface ScoringStep {
+  gameId: string
+  index: number
+  count: number
+  category: 'area' | 'fire_ice' | 'cult' | 'resources' | 'faction'
+  track?: number
+  awards: ScoringAward[]
+}
+
+export type VPSource =
+  | 'start'
+  | 'actions'
+  | 'pass'
+  | 'leech'
+  | 'towns'
+  | 'round_end'
+  | 'final_area'
+  | 'final_fire_ice'
+  | 'final_cult'
+  | 'final_resources'
+
+// Score history for progression charts, from /api/replay/vp_progression or
+// /api/games/{gameId}/vp_progression. A player's sources add up to their VP.
+export interface VPProgression {
+  rounds: { round: number; vp: Record<string, number> }[]
+  sources: Record<string, Partial<Record<VPSource, number>>>
+  final?: Record<string, number>
 }
 
 export interface AuctionState {
@@ -365,6 +518,61 @@ export interface AuctionState {
   fastBids: Record<string, Record<string, number>>
 }
 
+export type SpeedPreset = 'blitz' | 'standard' | 'async'
+
+export interface TurnReminder {
+  gameId: string
+  playerId: string
+  waitingMs: number
+}
+
+export interface IncomeAmounts {
+  coins: number
+  workers: number
+  priests: number
+  power: number
+  vp: number
+}
+
+export interface IncomeReport {
+  playerId: string
+  round: number
+  contributions: Array<{ source: string; income: IncomeAmounts }>
+  total: IncomeAmounts
+  applied: IncomeAmounts
+  warnings?: IncomeWarning[]
+}
+
+export interface IncomeWarning {
+  resource: 'power' | 'priests'
+  wasted: number
+}
+
+export interface AutoLeechDecision {
+  playerId: string
+  fromPlayerId: string
+  amount: number
+  accepted: boolean
+  vpCost: number
+  rule: string
+  round: number
+}
+
+export type AnnotationVisibility = 'private' | 'team' | 'all'
+
+export interface BoardAnnotation {
+  id: string
+  kind: 'planned_build' | 'arrow'
+  visibility: AnnotationVisibility
+  authorId: string
+  spectator?: boolean
+  hex: { q: number; r: number }
+  to?: { q: number; r: number }
+  building?: string
+  label?: string
+  createdAtMs: number
+}
+
 export interface TurnTimerPlayerState {
   remainingMs: number
   isActive: boolean
@@ -373,6 +581,7 @@ export interface TurnTimerPlayerState {
 export interface TurnTimerState {
   initialTimeMs: number
   incrementMs: number
+  perTurn?: boolean
   serverNowMs: number
   activePlayerIds: string[]
   players: Record<string, TurnTimerPlayerState>
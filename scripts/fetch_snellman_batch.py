@@ -108,7 +108,7 @@ def main() -> None:
     output_dir = pathlib.Path(args.output_dir)
     output_dir.mkdir(parents=True, exist_ok=True)
 
-    games_manifest: list[dict[str, Any]] = []
+    written = 0
 
     for season in seasons:
         for game_num in games:
@@ -122,28 +122,23 @@ def main() -> None:
                 for faction, payload in sorted(state.get("factions", {}).items())
             }
 
-            file_name = f"{game_id}.txt"
-            (output_dir / file_name).write_text(text, encoding="utf-8")
-
-            games_manifest.append(
-                {
-                    "game_id": game_id,
-                    "log_file": file_name,
-                    "expected_total_vp": expected_scores,
-                }
+            (output_dir / f"{game_id}.txt").write_text(text, encoding="utf-8")
+            (output_dir / f"{game_id}.yaml").write_text(
+                fixture_manifest(text, expected_scores), encoding="utf-8"
             )
+            written += 1
 
-    manifest = {
-        "source": "https://terra.snellman.net/app/view-game/",
-        "seasons": list(seasons),
-        "games": games_manifest,
-    }
-    (output_dir / "manifest.json").write_text(
-        json.dumps(manifest, indent=2, sort_keys=True) + "\n",
-        encoding="utf-8",
-    )
+    print(f"Wrote {written} games to {output_dir}")
 
-    print(f"Wrote {len(games_manifest)} games to {output_dir}")
+
+def fixture_manifest(text: str, expected_scores: dict[str, int]) -> str:
+    """Render the YAML manifest the replay tests read next to each log."""
+    lines = []
+    if "dropped from the game" in text.lower():
+        lines.append("skip: a player dropped from the game")
+    lines.append("final_vp:")
+    lines.extend(f"  {faction}: {vp}" for faction, vp in sorted(expected_scores.items()))
+    return "\n".join(lines) + "\n"
 
 
 if __name__ == "__main__":
@@ -56,6 +56,7 @@ func main() {
 	promoteMinGames := flag.Int("promote_min_games", 0, "minimum arena games for the promotion decision report; 0 disables")
 	promoteCI95LowerBound := flag.Float64("promote_ci95_lower_bound", 0, "minimum 95% confidence interval lower bound for the promotion decision report; 0 disables")
 	seed := flag.Int64("seed", 1, "random seed")
+	openingPlacements := flag.Int("opening_placements", 0, "setup dwellings that identify an opening in the statistics; 0 uses all")
 	progress := flag.Bool("progress", false, "write per-game progress JSON lines to stderr")
 	flag.Parse()
 
@@ -81,13 +82,14 @@ func main() {
 		MaxDepth:    *maxDepth,
 	}
 	result, err := arena.Evaluate(candidate, baseline, arena.Config{
-		Games:          *games,
-		MaxPlies:       *maxPlies,
-		Scenario:       *scenario,
-		Workers:        *workers,
-		ProgressWriter: progressWriter(*progress),
-		RandomSeed:     *seed,
-		Search:         search,
+		Games:             *games,
+		MaxPlies:          *maxPlies,
+		Scenario:          *scenario,
+		Workers:           *workers,
+		ProgressWriter:    progressWriter(*progress),
+		RandomSeed:        *seed,
+		Search:            search,
+		OpeningPlacements: *openingPlacements,
 	})
 	if err != nil {
 		exitf("arena: %v", err)
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/lukev/tm_server/internal/notation"
+	"github.com/lukev/tm_server/internal/replay"
+)
+
+const checkpointVersion = 2
+
+// replayCheckpoint records how far a replay got before it failed, so a rerun
+// after editing the config needs no fresh fetch and carries on from the saved
+// state, stepping only the items from Index on.
+type replayCheckpoint struct {
+	Version int    `json:"version"`
+	Source  string `json:"source"`
+	// Log is the raw log, so resuming needs neither the file nor BGA.
+	Log string `json:"log"`
+	// Index is the item the replay failed at; Total the item count then.
+	Index int    `json:"index"`
+	Total int    `json:"total"`
+	Error string `json:"error,omitempty"`
+	// Items fingerprints the items before Index, so resuming notices config
+	// edits that changed them.
+	Items string `json:"items"`
+	// Snapshot is the state before item Index, in snapshot notation, for
+	// reading.
+	Snapshot string `json:"snapshot"`
+	// State is the simulator before item Index; see
+	// replay.ResumeGameSimulator.
+	State json.RawMessage `json:"state"`
+}
+
+func loadCheckpoint(path string) (*replayCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var checkpoint replayCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+	if checkpoint.Version != checkpointVersion {
+		return nil, fmt.Errorf("unsupported checkpoint version %d", checkpoint.Version)
+	}
+	return &checkpoint, nil
+}
+
+func saveCheckpoint(path string, checkpoint *replayCheckpoint) error {
+	checkpoint.Version = checkpointVersion
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// newCheckpoint captures the state before items[index] by stepping a fresh
+// simulator up to it, since the failed one may hold a half-applied item. A
+// fresh simulator starts from base when the run was resumed from it.
+func newCheckpoint(source, logContent string, items []notation.LogItem, config *GameConfig, base *replayCheckpoint, index int, failure error) (*replayCheckpoint, error) {
+	var simulator *replay.GameSimulator
+	var err error
+	if base != nil {
+		simulator, err = resumeFromCheckpoint(items, config, base)
+	} else {
+		simulator, err = newSimulator(items, config)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := simulator.JumpTo(index); err != nil {
+		return nil, fmt.Errorf("failed to replay up to action %d: %w", index, err)
+	}
+	fingerprint, err := itemsFingerprint(items[:index])
+	if err != nil {
+		return nil, err
+	}
+	state, err := simulator.MarshalResumePoint()
+	if err != nil {
+		return nil, fmt.Errorf("failed to save the state before action %d: %w", index, err)
+	}
+	checkpoint := &replayCheckpoint{
+		Source:   source,
+		Log:      logContent,
+		Index:    index,
+		Total:    len(items),
+		Items:    fingerprint,
+		Snapshot: replay.GenerateSnapshot(simulator.GetState()),
+		State:    state,
+	}
+	if failure != nil {
+		checkpoint.Error = failure.Error()
+	}
+	return checkpoint, nil
+}
+
+// resumeFromCheckpoint restores the simulator the checkpoint saved, over
+// items. The config's replay choices replace the saved ones, keeping how many
+// were used. It fails when config edits changed the items before the
+// checkpoint, since the saved state no longer matches them.
+func resumeFromCheckpoint(items []notation.LogItem, config *GameConfig, checkpoint *replayCheckpoint) (*replay.GameSimulator, error) {
+	if checkpoint.Index > len(items) {
+		return nil, fmt.Errorf("checkpoint is at action %d but the log has %d", checkpoint.Index, len(items))
+	}
+	fingerprint, err := itemsFingerprint(items[:checkpoint.Index])
+	if err != nil {
+		return nil, err
+	}
+	if fingerprint != checkpoint.Items {
+		return nil, fmt.Errorf("the config changed the actions before %d; rerun without -resume", checkpoint.Index)
+	}
+	simulator, err := replay.ResumeGameSimulator(items, checkpoint.State)
+	if err != nil {
+		return nil, err
+	}
+	state := simulator.GetState()
+	acolytesUsed := copyIndex(state.ReplayAcolytesCultTrackIndex)
+	riverBuildsUsed := copyIndex(state.ReplayRiverBuildHexIndex)
+	if err := applyConfigToState(state, config); err != nil {
+		return nil, err
+	}
+	for playerID, used := range acolytesUsed {
+		state.ReplayAcolytesCultTrackIndex[playerID] = used
+	}
+	for playerID, used := range riverBuildsUsed {
+		state.ReplayRiverBuildHexIndex[playerID] = used
+	}
+	return simulator, nil
+}
+
+func copyIndex(index map[string]int) map[string]int {
+	out := make(map[string]int, len(index))
+	for playerID, used := range index {
+		out[playerID] = used
+	}
+	return out
+}
+
+// itemsFingerprint hashes items for comparison with a later run's.
+func itemsFingerprint(items []notation.LogItem) (string, error) {
+	raw, err := json.Marshal(items)
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint actions: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -41,10 +41,12 @@ func main() {
 	bonusFlag := flag.String("bonus", "", "Comma-separated bonus cards (e.g., BON-SPD,BON-6C,BON-TP,BON-BB,BON-P,BON-DW,BON-SHIP-VP)")
 	helpFlag := flag.Bool("help", false, "Show usage")
 	verboseFlag := flag.Bool("v", false, "Verbose output")
+	checkpointFlag := flag.String("checkpoint", "", "Path to save a checkpoint to when the replay fails")
+	resumeFlag := flag.String("resume", "", "Path to a checkpoint to rerun from; the replay carries on from its saved state")
 
 	flag.Parse()
 
-	if *helpFlag || (*urlFlag == "" && *fileFlag == "") {
+	if *helpFlag || (*urlFlag == "" && *fileFlag == "" && *resumeFlag == "") {
 		printUsage()
 		os.Exit(0)
 	}
@@ -64,9 +66,23 @@ func main() {
 	var logContent string
 	var err error
 	var gameID string
+	var checkpoint *replayCheckpoint
+	checkpointPath := *checkpointFlag
 
-	// Get log content from URL or file
-	if *urlFlag != "" {
+	// Get log content from a checkpoint, URL or file
+	if *resumeFlag != "" {
+		checkpoint, err = loadCheckpoint(*resumeFlag)
+		if err != nil {
+			fmt.Printf("❌ Failed to load checkpoint: %v\n", err)
+			os.Exit(1)
+		}
+		logContent = checkpoint.Log
+		gameID = checkpoint.Source
+		if checkpointPath == "" {
+			checkpointPath = *resumeFlag
+		}
+		fmt.Printf("💾 Loaded checkpoint for %s at action %d/%d from %s\n", gameID, checkpoint.Index, checkpoint.Total, *resumeFlag)
+	} else if *urlFlag != "" {
 		gameID = extractTableID(*urlFlag)
 		if gameID == "" {
 			fmt.Println("❌ Invalid URL or table ID")
@@ -93,13 +109,12 @@ func main() {
 
 	// Parse the log
 	fmt.Println("🔍 Parsing log...")
-	parser := notation.NewBGAParser(logContent)
-	items, err := parser.Parse()
+	items, dialect, err := notation.Parse(logContent)
 	if err != nil {
 		fmt.Printf("❌ Failed to parse log: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Printf("✓ Parsed %d log items\n", len(items))
+	fmt.Printf("✓ Parsed %d log items (%s dialect)\n", len(items), dialect)
 
 	// Inject settings from config or flags
 	scoringStr := *scoringFlag
@@ -122,39 +137,36 @@ func main() {
 
 	// Inject bonus card selections from config
 	if config != nil && (len(config.BonusCardSelections) > 0 || len(config.ExtraBonusCardSelections) > 0) {
-		items = injectBonusCardSelections(items, config.BonusCardSelections, config.ExtraBonusCardSelections)
+		items, err = injectBonusCardSelections(items, config.BonusCardSelections, config.ExtraBonusCardSelections)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
 	}
 	if config != nil && len(config.ConspiratorsSwapReturns) > 0 {
 		items = injectConspiratorsSwapReturns(items, config.ConspiratorsSwapReturns)
 	}
 
-	// Create initial game state
-	initialState := replay.CreateInitialState(items)
-	if config != nil && strings.TrimSpace(config.FireIceFinalScoringTile) != "" {
-		if err := applyFireIceFinalScoringTileConfig(initialState, config.FireIceFinalScoringTile); err != nil {
-			fmt.Printf("❌ Failed to apply Fire & Ice final scoring tile config: %v\n", err)
-			os.Exit(1)
-		}
-	}
-	if config != nil && len(config.AcolytesCultTracks) > 0 {
-		if err := applyAcolytesCultTrackConfig(initialState, config.AcolytesCultTracks); err != nil {
-			fmt.Printf("❌ Failed to apply Acolytes cult-track config: %v\n", err)
+	// Create simulator
+	var simulator *replay.GameSimulator
+	if checkpoint != nil {
+		simulator, err = resumeFromCheckpoint(items, config, checkpoint)
+		if err != nil {
+			fmt.Printf("❌ Failed to resume from checkpoint: %v\n", err)
 			os.Exit(1)
 		}
-	}
-	if config != nil && len(config.RiverBuildHexes) > 0 {
-		if err := applyRiverBuildHexConfig(initialState, config.RiverBuildHexes); err != nil {
-			fmt.Printf("❌ Failed to apply replay river-build config: %v\n", err)
+		fmt.Printf("⏩ Restored the state before action %d/%d\n", checkpoint.Index, len(items))
+	} else {
+		simulator, err = newSimulator(items, config)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
 			os.Exit(1)
 		}
 	}
 
-	// Create simulator
-	simulator := replay.NewGameSimulator(initialState, items)
-
 	// Run simulation
 	fmt.Println("\n🎮 Running simulation...")
-	successCount := 0
+	successCount := simulator.CurrentIndex
 	totalActions := len(items)
 
 	for simulator.CurrentIndex < totalActions {
@@ -259,6 +271,18 @@ func main() {
 				fmt.Println("\n   Use -config flag to provide a YAML config file with bonus card selections")
 			}
 
+			if checkpointPath != "" {
+				saved, saveErr := newCheckpoint(gameID, logContent, items, config, checkpoint, simulator.CurrentIndex, err)
+				if saveErr == nil {
+					saveErr = saveCheckpoint(checkpointPath, saved)
+				}
+				if saveErr != nil {
+					fmt.Printf("\n❌ Failed to save checkpoint: %v\n", saveErr)
+				} else {
+					fmt.Printf("\n💾 Saved checkpoint to %s; edit the config and rerun with -resume %s\n", checkpointPath, checkpointPath)
+				}
+			}
+
 			os.Exit(1)
 		}
 		if *verboseFlag {
@@ -287,7 +311,11 @@ func main() {
 						formatBonusCards(simulator.GetState(), verbosePlayerID),
 					)
 				}
-			} else if roundStart, ok := currentItem.(notation.RoundStartItem); ok {
+			}
+			if consequences := simulator.LastConsequences(); consequences != nil {
+				fmt.Printf("    Consequences: %s\n", formatConsequences(consequences))
+			}
+			if roundStart, ok := currentItem.(notation.RoundStartItem); ok && verbosePlayerID == "" {
 				fmt.Printf(
 					"    Round state after round %d start marker: phase=%v incomePending=%t\n",
 					roundStart.Round,
@@ -328,6 +356,39 @@ func main() {
 	}
 }
 
+// newSimulator builds a simulator for items with the setup the config
+// supplies on top of the log.
+func newSimulator(items []notation.LogItem, config *GameConfig) (*replay.GameSimulator, error) {
+	initialState, err := replay.CreateInitialState(items)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyConfigToState(initialState, config); err != nil {
+		return nil, err
+	}
+	return replay.NewGameSimulator(initialState, items), nil
+}
+
+// applyConfigToState gives gs the setup and replay choices of the config.
+func applyConfigToState(gs *game.GameState, config *GameConfig) error {
+	if config != nil && strings.TrimSpace(config.FireIceFinalScoringTile) != "" {
+		if err := applyFireIceFinalScoringTileConfig(gs, config.FireIceFinalScoringTile); err != nil {
+			return fmt.Errorf("failed to apply Fire & Ice final scoring tile config: %w", err)
+		}
+	}
+	if config != nil && len(config.AcolytesCultTracks) > 0 {
+		if err := applyAcolytesCultTrackConfig(gs, config.AcolytesCultTracks); err != nil {
+			return fmt.Errorf("failed to apply Acolytes cult-track config: %w", err)
+		}
+	}
+	if config != nil && len(config.RiverBuildHexes) > 0 {
+		if err := applyRiverBuildHexConfig(gs, config.RiverBuildHexes); err != nil {
+			return fmt.Errorf("failed to apply replay river-build config: %w", err)
+		}
+	}
+	return nil
+}
+
 func applyFireIceFinalScoringTileConfig(gs *game.GameState, tileName string) error {
 	if gs == nil {
 		return fmt.Errorf("game state is nil")
@@ -657,6 +718,7 @@ func printUsage() {
 	fmt.Println("Usage:")
 	fmt.Println("  bga_test -url <table_id_or_url> [options]")
 	fmt.Println("  bga_test -file <log_file.txt> [options]")
+	fmt.Println("  bga_test -resume <checkpoint.json> [options]")
 	fmt.Println()
 	fmt.Println("Options:")
 	fmt.Println("  -url string     BGA table URL or table ID")
@@ -665,27 +727,29 @@ func printUsage() {
 	fmt.Println("  -scoring string Comma-separated scoring tiles (e.g., SCORE1,SCORE2,...)")
 	fmt.Println("  -bonus string   Comma-separated bonus cards (e.g., BON-SPD,BON-6C,...)")
 	fmt.Println("  -v              Verbose output")
+	fmt.Println("  -checkpoint string  Save a checkpoint here when the replay fails")
+	fmt.Println("  -resume string  Rerun from a checkpoint's log and saved state, stepping only")
+	fmt.Println("                  the actions from the failed one on, and save the next")
+	fmt.Println("                  checkpoint back to it")
 	fmt.Println("  -help           Show this help")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  bga_test -url 555795328")
 	fmt.Println("  bga_test -file game.txt -config game_config.yaml")
+	fmt.Println("  bga_test -file game.txt -config game_config.yaml -checkpoint game.ckpt")
+	fmt.Println("  bga_test -resume game.ckpt -config game_config.yaml")
 }
 
 // injectBonusCardSelections updates PassAction bonus cards from config.
+// Round 0 selections are the setup picks and go through the same setup bonus
+// card sub-phase as live games.
 // For Archivists after building the stronghold, an extra bonus-card follow-up action
 // can be injected immediately after the pass.
-func injectBonusCardSelections(items []notation.LogItem, selections map[string]map[string]string, extraSelections map[string]map[string]string) []notation.LogItem {
-	// For round 0 (initial bonus cards), we need to find GameSettingsItem
+func injectBonusCardSelections(items []notation.LogItem, selections map[string]map[string]string, extraSelections map[string]map[string]string) ([]notation.LogItem, error) {
 	if round0, ok := selections["0"]; ok {
-		for i, item := range items {
-			if s, ok := item.(notation.GameSettingsItem); ok {
-				for playerID, cardCode := range round0 {
-					s.Settings["InitialBonusCard:"+playerID] = cardCode
-				}
-				items[i] = s
-				break
-			}
+		var err error
+		if items, err = replay.InjectSetupBonusCardSelections(items, round0); err != nil {
+			return nil, err
 		}
 	}
 
@@ -730,7 +794,7 @@ func injectBonusCardSelections(items []notation.LogItem, selections map[string]m
 		}
 	}
 
-	return items
+	return items, nil
 }
 
 func injectStartingCultChoices(items []notation.LogItem, choices map[string]string) []notation.LogItem {
@@ -888,7 +952,7 @@ func formatBuildingCounts(gs *game.GameState, playerID string) string {
 	return "[" + strings.Join(parts, ",") + "]"
 }
 
-func createInitialState(items []notation.LogItem) *game.GameState {
+func createInitialState(items []notation.LogItem) (*game.GameState, error) {
 	return replay.CreateInitialState(items)
 }
 
@@ -964,3 +1028,25 @@ func injectSettings(items []notation.LogItem, scoringStr, bonusStr string) []not
 
 	return items
 }
+
+func formatConsequences(c *game.ActionConsequences) string {
+	var parts []string
+	playerIDs := make([]string, 0, len(c.VP))
+	for playerID := range c.VP {
+		playerIDs = append(playerIDs, playerID)
+	}
+	sort.Strings(playerIDs)
+	for _, playerID := range playerIDs {
+		parts = append(parts, fmt.Sprintf("%s %+d VP (%s)", playerID, c.VP[playerID], c.VPSource))
+	}
+	for _, offer := range c.LeechOffers {
+		parts = append(parts, fmt.Sprintf("%s offered %d power from %s for %d VP", offer.PlayerID, offer.Amount, offer.FromPlayerID, offer.VPCost))
+	}
+	for _, town := range c.Towns {
+		parts = append(parts, fmt.Sprintf("%s formed a town (%v)", town.PlayerID, town.Tile))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, "; ")
+}
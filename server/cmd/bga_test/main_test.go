@@ -1,15 +1,21 @@
 package main
 
 import (
+	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/lukev/tm_server/internal/game"
 	"github.com/lukev/tm_server/internal/game/board"
 	"github.com/lukev/tm_server/internal/notation"
+	"github.com/lukev/tm_server/internal/replay"
 )
 
 func TestCreateInitialState_EnablesReplayMode(t *testing.T) {
-	state := createInitialState([]notation.LogItem{})
+	state, err := createInitialState([]notation.LogItem{})
+	if err != nil {
+		t.Fatalf("createInitialState: %v", err)
+	}
 	if state == nil {
 		t.Fatal("createInitialState returned nil")
 	}
@@ -19,9 +25,12 @@ func TestCreateInitialState_EnablesReplayMode(t *testing.T) {
 }
 
 func TestCreateInitialState_UsesParsedMap(t *testing.T) {
-	state := createInitialState([]notation.LogItem{
+	state, err := createInitialState([]notation.LogItem{
 		notation.GameSettingsItem{Settings: map[string]string{"Game": "Lakes"}},
 	})
+	if err != nil {
+		t.Fatalf("createInitialState: %v", err)
+	}
 	if state == nil || state.Map == nil {
 		t.Fatal("createInitialState returned nil map")
 	}
@@ -53,3 +62,55 @@ func TestInjectStartingCultChoices_InsertsDjinniChoiceAfterSettings(t *testing.T
 		t.Fatalf("injected action = %+v, want Djinni earth choice", action)
 	}
 }
+
+func TestCheckpoint_ResumesAtSavedState(t *testing.T) {
+	items := []notation.LogItem{
+		notation.GameSettingsItem{Settings: map[string]string{"Game": "Lakes"}},
+		notation.GameSettingsItem{Settings: map[string]string{"StartingVPs": "20"}},
+	}
+	saved, err := newCheckpoint("game.txt", "log text", items, nil, nil, 1, errors.New("missing info"))
+	if err != nil {
+		t.Fatalf("newCheckpoint: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "game.ckpt")
+	if err := saveCheckpoint(path, saved); err != nil {
+		t.Fatalf("saveCheckpoint: %v", err)
+	}
+	loaded, err := loadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("loadCheckpoint: %v", err)
+	}
+	if loaded.Source != "game.txt" || loaded.Log != "log text" || loaded.Index != 1 || loaded.Total != 2 || loaded.Error != "missing info" || loaded.Snapshot == "" || len(loaded.State) == 0 {
+		t.Fatalf("unexpected checkpoint %+v", loaded)
+	}
+
+	simulator, err := resumeFromCheckpoint(items, nil, loaded)
+	if err != nil || simulator.CurrentIndex != 1 {
+		t.Fatalf("resumeFromCheckpoint = %v at %v; want the checkpointed state at 1", err, simulator)
+	}
+	if got := replay.GenerateSnapshot(simulator.GetState()); got != loaded.Snapshot {
+		t.Fatalf("restored state differs from the checkpoint:\n%s\nwant\n%s", got, loaded.Snapshot)
+	}
+	if simulator.GetState().Map.ID != board.MapLakes {
+		t.Fatalf("expected the restored state to keep the map, got %s", simulator.GetState().Map.ID)
+	}
+
+	// A resumed run that fails again checkpoints from the restored state.
+	next, err := newCheckpoint("game.txt", "log text", items, nil, loaded, 2, nil)
+	if err != nil || next.Index != 2 {
+		t.Fatalf("newCheckpoint from a checkpoint = %+v, %v", next, err)
+	}
+
+	edited := []notation.LogItem{
+		notation.GameSettingsItem{Settings: map[string]string{"Game": "Base"}},
+		items[1],
+	}
+	if _, err := resumeFromCheckpoint(edited, nil, loaded); err == nil {
+		t.Fatalf("expected edits before the checkpoint to be refused")
+	}
+
+	loaded.Index = 5
+	if _, err := resumeFromCheckpoint(items, nil, loaded); err == nil {
+		t.Fatalf("expected a checkpoint past the end of the log to be refused")
+	}
+}
@@ -10,10 +10,11 @@ import (
 
 func main() {
 	replay := flag.Bool("replay", false, "use ConvertSnellmanToConciseForReplay (line-preserving)")
+	transcript := flag.Bool("transcript", false, "print a readable per-round transcript instead of the concise log")
 	flag.Parse()
 
 	if flag.NArg() < 1 {
-		fmt.Println("Usage: convert_to_concise [--replay] <input_file>")
+		fmt.Println("Usage: convert_to_concise [--replay] [--transcript] <input_file>")
 		os.Exit(1)
 	}
 
@@ -25,15 +26,29 @@ func main() {
 	}
 
 	var concise string
+	var warnings []notation.ConversionWarning
 	if *replay {
-		concise, err = notation.ConvertSnellmanToConciseForReplay(string(content))
+		concise, warnings, err = notation.ConvertSnellmanToConciseForReplayWithWarnings(string(content))
 	} else {
-		concise, err = notation.ConvertSnellmanToConcise(string(content))
+		concise, warnings, err = notation.ConvertSnellmanToConciseWithWarnings(string(content))
 	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error converting to concise: %v\n", err)
 		os.Exit(1)
 	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	if *transcript {
+		items, err := notation.ParseConciseLog(concise)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing concise log: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(notation.RenderTranscript(items))
+		return
+	}
 
 	fmt.Println(concise)
 }
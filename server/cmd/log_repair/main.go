@@ -0,0 +1,128 @@
+// Command log_repair proposes corrections for game logs that stop replaying,
+// typically community BGA exports with truncated rows, and writes the patched
+// concise log.
+//
+//	log_repair -o fixed.txt game.txt          # pick repairs interactively
+//	log_repair -batch -o fixed.txt game.txt   # apply the best repair each time
+//
+// At the first item that fails to replay, candidate rows (leech declines,
+// free conversions, burns) are inserted in turn and kept only if the log then
+// replays past the failure. Candidates that replay further are listed first.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/lukev/tm_server/internal/notation"
+	"github.com/lukev/tm_server/internal/replay"
+)
+
+func main() {
+	format := flag.String("format", "auto", "log format: auto, snellman, bga or concise")
+	output := flag.String("o", "", "write the patched concise log to this file instead of stdout")
+	batch := flag.Bool("batch", false, "apply the best repair at every failure without asking")
+	maxRepairs := flag.Int("max", 20, "stop after this many repairs")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Println("Usage: log_repair [-format f] [-batch] [-max n] [-o out.txt] <game_log>")
+		os.Exit(1)
+	}
+
+	items := loadItems(flag.Arg(0), *format)
+	var failure *replay.ReplayFailure
+	if *batch {
+		var applied []replay.Repair
+		items, applied, failure = replay.RepairLog(items, *maxRepairs)
+		for _, repair := range applied {
+			fmt.Fprintf(os.Stderr, "Item %d: %s\n", repair.Index, repair.Description)
+		}
+	} else {
+		items, failure = repairInteractively(items, *maxRepairs)
+	}
+
+	lines, _ := notation.GenerateConciseLog(items)
+	concise := strings.Join(lines, "\n") + "\n"
+	if *output == "" {
+		fmt.Print(concise)
+	} else if err := os.WriteFile(*output, []byte(concise), 0o644); err != nil {
+		fail("Error writing log: %v", err)
+	}
+
+	if failure != nil {
+		fmt.Fprintf(os.Stderr, "Log still fails to replay at %v\n", failure)
+		os.Exit(2)
+	}
+	fmt.Fprintln(os.Stderr, "Log replays completely")
+}
+
+func repairInteractively(items []notation.LogItem, maxRepairs int) ([]notation.LogItem, *replay.ReplayFailure) {
+	in := bufio.NewScanner(os.Stdin)
+	for applied := 0; ; applied++ {
+		failure, repairs := replay.ProposeRepairs(items)
+		if failure == nil || applied >= maxRepairs {
+			return items, failure
+		}
+		fmt.Fprintf(os.Stderr, "\nReplay fails at %v\n", failure)
+		if len(repairs) == 0 {
+			fmt.Fprintln(os.Stderr, "No candidate repair replays past this item")
+			return items, failure
+		}
+		for i, repair := range repairs {
+			status := fmt.Sprintf("replays %d items", repair.Reach)
+			if repair.Complete {
+				status = "replays the whole log"
+			}
+			fmt.Fprintf(os.Stderr, "  %d) %s (%s)\n", i+1, repair.Description, status)
+		}
+		fmt.Fprint(os.Stderr, "Apply which repair? [1, q to stop] ")
+		if !in.Scan() {
+			return items, failure
+		}
+		answer := strings.TrimSpace(in.Text())
+		if answer == "q" {
+			return items, failure
+		}
+		choice := 1
+		if answer != "" {
+			n, err := strconv.Atoi(answer)
+			if err != nil || n < 1 || n > len(repairs) {
+				fmt.Fprintf(os.Stderr, "Invalid choice %q\n", answer)
+				applied--
+				continue
+			}
+			choice = n
+		}
+		items = replay.ApplyRepair(items, repairs[choice-1])
+	}
+}
+
+func loadItems(path, format string) []notation.LogItem {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		fail("Error reading log: %v", err)
+	}
+	scriptDir, err := os.MkdirTemp("", "log_repair")
+	if err != nil {
+		fail("Error creating scratch directory: %v", err)
+	}
+	defer os.RemoveAll(scriptDir)
+
+	manager := replay.NewReplayManager(scriptDir)
+	manager.SetSourceAnchoredLeechOrdering(true)
+	const gameID = "repair"
+	if err := manager.ImportText(gameID, string(content), format); err != nil {
+		fail("Error importing log: %v", err)
+	}
+	return manager.GetSession(gameID).Simulator.Actions
+}
+
+func fail(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
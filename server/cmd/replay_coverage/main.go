@@ -0,0 +1,134 @@
+// Command replay_coverage replays a corpus of game logs and reports which
+// rules paths it exercises: log actions, factions, stronghold abilities,
+// special and power actions, and favor, town and bonus tiles. The gaps it
+// lists are what community logs added to the fixtures should cover.
+//
+//	replay_coverage internal/replay/testdata/snellman_batch
+//	replay_coverage -json game1.txt game2.txt > coverage.json
+//
+// Directories contribute their *.txt files.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lukev/tm_server/internal/replay"
+)
+
+func main() {
+	format := flag.String("format", "auto", "log format: auto, snellman, bga or concise")
+	workers := flag.Int("workers", 0, "parallel replays (0 uses GOMAXPROCS)")
+	asJSON := flag.Bool("json", false, "print the coverage and gaps as JSON")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		fmt.Println("Usage: replay_coverage [-format f] [-workers n] [-json] <log or dir>...")
+		os.Exit(1)
+	}
+
+	games, err := loadGames(flag.Args(), *format)
+	if err != nil {
+		fail("Error loading logs: %v", err)
+	}
+	scriptDir, err := os.MkdirTemp("", "replay_coverage")
+	if err != nil {
+		fail("Error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(scriptDir)
+
+	report := replay.ValidateBatch(games, scriptDir, *workers)
+	coverage := report.Coverage()
+	gaps := coverage.Gaps()
+
+	if *asJSON {
+		out, err := json.MarshalIndent(struct {
+			Coverage *replay.Coverage     `json:"coverage"`
+			Gaps     []replay.CoverageGap `json:"gaps"`
+			Failed   int                  `json:"failed"`
+		}{coverage, gaps, report.Failed}, "", "  ")
+		if err != nil {
+			fail("Error encoding report: %v", err)
+		}
+		fmt.Println(string(out))
+		return
+	}
+
+	fmt.Printf("Replayed %d games (%d stopped early) in %s\n", coverage.Games, report.Failed, report.Elapsed)
+	for _, result := range report.Failures() {
+		fmt.Printf("  %s: %v\n", result.GameID, result.Err)
+	}
+	printCounts("Actions", coverage.Actions)
+	printCounts("Factions (games)", coverage.Factions)
+	printCounts("Strongholds", coverage.Strongholds)
+	printCounts("Special actions", coverage.SpecialActions)
+	printCounts("Power actions", coverage.PowerActions)
+	printCounts("Favor tiles", coverage.FavorTiles)
+	printCounts("Town tiles", coverage.TownTiles)
+	printCounts("Bonus cards", coverage.BonusCards)
+
+	fmt.Println("\nNever exercised:")
+	if len(gaps) == 0 {
+		fmt.Println("  nothing")
+	}
+	for _, gap := range gaps {
+		fmt.Printf("  %s: %s\n", gap.Category, strings.Join(gap.Missing, ", "))
+	}
+}
+
+func loadGames(paths []string, format string) ([]replay.BatchGame, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(path, "*.txt"))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+
+	games := make([]replay.BatchGame, 0, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		gameID := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		games = append(games, replay.BatchGame{GameID: gameID, Log: string(content), Format: format})
+	}
+	return games, nil
+}
+
+func printCounts(title string, counts map[string]int) {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	fmt.Printf("\n%s:\n", title)
+	for _, name := range names {
+		fmt.Printf("  %6d  %s\n", counts[name], name)
+	}
+}
+
+func fail(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,123 @@
+// Command replay_diff finds where two replays of the same game log diverge.
+//
+// Record checkpoints with one build of the engine, then compare a replay of
+// another build (or a second recording) against them:
+//
+//	replay_diff -write before.json game.txt      # e.g. on the old revision
+//	replay_diff -against before.json game.txt    # on the new revision
+//	replay_diff before.json after.json           # compare two recordings
+//
+// For every player, the first log item after which resources, VP, cult
+// positions or buildings differ is printed.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/lukev/tm_server/internal/replay"
+)
+
+func main() {
+	format := flag.String("format", "auto", "log format: auto, snellman, bga or concise")
+	write := flag.String("write", "", "record checkpoints of the log to this file")
+	against := flag.String("against", "", "compare a replay of the log against checkpoints in this file")
+	flag.Parse()
+
+	var left, right []replay.Checkpoint
+	var err error
+	switch {
+	case *write != "" && flag.NArg() == 1:
+		checkpoints, replayErr := recordLog(flag.Arg(0), *format)
+		if err := writeCheckpoints(*write, checkpoints); err != nil {
+			fail("Error writing checkpoints: %v", err)
+		}
+		fmt.Printf("Wrote %d checkpoints to %s\n", len(checkpoints), *write)
+		if replayErr != nil {
+			fail("Replay stopped early: %v", replayErr)
+		}
+		return
+	case *against != "" && flag.NArg() == 1:
+		if left, err = readCheckpoints(*against); err != nil {
+			fail("Error reading checkpoints: %v", err)
+		}
+		var replayErr error
+		right, replayErr = recordLog(flag.Arg(0), *format)
+		if replayErr != nil {
+			fmt.Fprintf(os.Stderr, "Replay stopped early: %v\n", replayErr)
+		}
+	case *write == "" && *against == "" && flag.NArg() == 2:
+		if left, err = readCheckpoints(flag.Arg(0)); err != nil {
+			fail("Error reading checkpoints: %v", err)
+		}
+		if right, err = readCheckpoints(flag.Arg(1)); err != nil {
+			fail("Error reading checkpoints: %v", err)
+		}
+	default:
+		fmt.Println("Usage: replay_diff [-format f] -write <out.json> <game_log>")
+		fmt.Println("       replay_diff [-format f] -against <checkpoints.json> <game_log>")
+		fmt.Println("       replay_diff <left.json> <right.json>")
+		os.Exit(1)
+	}
+
+	divergences := replay.DiffCheckpoints(left, right)
+	if len(divergences) == 0 {
+		fmt.Printf("No divergence in %d checkpoints\n", len(left))
+		return
+	}
+	for _, d := range divergences {
+		fmt.Printf("%s: item %d", d.PlayerID, d.Index)
+		if d.Line != "" {
+			fmt.Printf(" [%s]", d.Line)
+		}
+		fmt.Printf(": %s %s -> %s\n", d.Field, d.Left, d.Right)
+	}
+	os.Exit(2)
+}
+
+func recordLog(path, format string) ([]replay.Checkpoint, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		fail("Error reading log: %v", err)
+	}
+	scriptDir, err := os.MkdirTemp("", "replay_diff")
+	if err != nil {
+		fail("Error creating scratch directory: %v", err)
+	}
+	defer os.RemoveAll(scriptDir)
+
+	manager := replay.NewReplayManager(scriptDir)
+	manager.SetSourceAnchoredLeechOrdering(true)
+	const gameID = "diff"
+	if err := manager.ImportText(gameID, string(content), format); err != nil {
+		fail("Error importing log: %v", err)
+	}
+	return replay.RecordCheckpoints(manager.GetSession(gameID))
+}
+
+func readCheckpoints(path string) ([]replay.Checkpoint, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var checkpoints []replay.Checkpoint
+	if err := json.Unmarshal(raw, &checkpoints); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return checkpoints, nil
+}
+
+func writeCheckpoints(path string, checkpoints []replay.Checkpoint) error {
+	raw, err := json.MarshalIndent(checkpoints, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0o644)
+}
+
+func fail(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -27,7 +27,7 @@ func main() {
 	fmt.Println("Validating terrain layout...")
 	if err := replay.ValidateTerrainLayout(); err != nil {
 		fmt.Printf("Terrain layout validation failed: %v\n", err)
-		fmt.Println("\nThe terrain layout in terrain_layout.go needs to be updated to match the snellman base map.")
+		fmt.Println("\nThe base map layout in internal/game/board/data/maps/base.yaml needs to be updated to match the snellman base map.")
 		os.Exit(1)
 	}
 	fmt.Println("✓ Terrain layout validated")
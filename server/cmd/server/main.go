@@ -4,16 +4,25 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/lukev/tm_server/internal/api"
+	"github.com/lukev/tm_server/internal/az/actions"
 	"github.com/lukev/tm_server/internal/az/model"
 	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/i18n"
 	"github.com/lukev/tm_server/internal/lobby"
 	"github.com/lukev/tm_server/internal/replay"
+	"github.com/lukev/tm_server/internal/webhooks"
 	"github.com/lukev/tm_server/internal/websocket"
 )
 
@@ -21,29 +30,78 @@ func main() {
 	if err := verifyRequiredNeuralEvaluator(); err != nil {
 		log.Fatal(err)
 	}
+	if err := configureGameData(); err != nil {
+		log.Fatal(err)
+	}
+	if err := configureMessages(); err != nil {
+		log.Fatal(err)
+	}
 	// Create WebSocket hub
 	hub := websocket.NewHub()
 	go hub.Run()
+	configureStateExport(hub)
 
 	// Create managers
 	gameMgr := game.NewManager()
+	gameMgr.SetMustPassDetector(actions.MustPass)
 	lobbyMgr := lobby.NewManager()
+	websocket.ConnectPresence(hub, lobbyMgr)
 	botMgr := websocket.NewBotManager(gameMgr)
 	// Get scripts directory from environment or default to relative path
 	scriptDir := os.Getenv("SCRIPTS_DIR")
 	if scriptDir == "" {
 		scriptDir = "./scripts"
 	}
+	if err := configureGameArchive(gameMgr); err != nil {
+		log.Fatal(err)
+	}
+	if err := configureVacations(gameMgr, lobbyMgr); err != nil {
+		log.Fatal(err)
+	}
+	if path := strings.TrimSpace(os.Getenv("TM_ACCOUNT_PREFERENCES_FILE")); path != "" {
+		if err := gameMgr.SetAccountPreferenceStore(game.NewFileAccountPreferenceStore(path)); err != nil {
+			log.Fatal(err)
+		}
+	}
 	replayMgr := replay.NewReplayManager(scriptDir)
 	replayMgr.SetSourceAnchoredLeechOrdering(true)
+	if err := configureReplayLogStore(replayMgr, scriptDir); err != nil {
+		log.Fatal(err)
+	}
+	if checkInvariantsEnabled() {
+		log.Printf("checking game invariants after every action (TM_CHECK_INVARIANTS)")
+		gameMgr.SetCheckInvariants(true)
+		replayMgr.SetCheckInvariants(true)
+	}
+	if checkConsistencyEnabled() {
+		log.Printf("replaying recorded actions in shadow games after every action (TM_CHECK_CONSISTENCY)")
+		gameMgr.SetConsistencyCheck(websocket.BuildRecordedAction)
+	}
 	replayHandler := api.NewReplayHandler(replayMgr)
 	aiHandler := api.NewAIHandler(gameMgr)
+	saveFileHandler := api.NewSaveFileHandler(gameMgr, lobbyMgr, websocket.BuildRecordedAction)
+	historyHandler := api.NewHistoryHandler(gameMgr, websocket.BuildRecordedAction)
+	rulesHandler := api.NewRulesHandler(gameMgr, lobbyMgr)
+	summaryHandler := api.NewSummaryHandler(gameMgr, lobbyMgr)
+	adminHandler := api.NewAdminHandler(gameMgr, os.Getenv("TM_ADMIN_TOKEN"), func(gameID string, results []*game.ActionResult) {
+		websocket.BroadcastGameState(hub, gameMgr, gameID)
+		for _, result := range results {
+			websocket.BroadcastActionEvents(hub, gameMgr, gameID, result)
+		}
+	})
+	webhookDispatcher, err := configureWebhooks(gameMgr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	adminHandler.SetWebhooks(webhookDispatcher)
 
 	deps := websocket.ServerDeps{
 		Lobby: lobbyMgr,
 		Games: gameMgr,
 		Bots:  botMgr,
 	}
+	go runSpeedPresetSweeps(hub, deps)
+	go runVacationSweeps(hub, deps)
 
 	// Set up router
 	router := mux.NewRouter()
@@ -65,6 +123,11 @@ func main() {
 	// Register replay routes
 	replayHandler.RegisterRoutes(router)
 	aiHandler.RegisterRoutes(router)
+	saveFileHandler.RegisterRoutes(router)
+	historyHandler.RegisterRoutes(router)
+	rulesHandler.RegisterRoutes(router)
+	summaryHandler.RegisterRoutes(router)
+	adminHandler.RegisterRoutes(router)
 
 	// Start server
 	addr := strings.TrimSpace(os.Getenv("PORT"))
@@ -80,6 +143,286 @@ func main() {
 	}
 }
 
+// configureGameArchive moves finished games to TM_ARCHIVE_DIR and drops them
+// from memory once idle for TM_ARCHIVE_EVICT_AFTER (default 1h). Archived games
+// are loaded back when requested.
+func configureGameArchive(gameMgr *game.Manager) error {
+	dir := strings.TrimSpace(os.Getenv("TM_ARCHIVE_DIR"))
+	if dir == "" {
+		return nil
+	}
+	evictAfter := time.Hour
+	if raw := strings.TrimSpace(os.Getenv("TM_ARCHIVE_EVICT_AFTER")); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid TM_ARCHIVE_EVICT_AFTER %q: %w", raw, err)
+		}
+		evictAfter = parsed
+	}
+	gameMgr.SetArchivePolicy(&game.ArchivePolicy{
+		Archive:     game.NewFileArchive(dir),
+		BuildAction: websocket.BuildRecordedAction,
+		EvictAfter:  evictAfter,
+	})
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			evicted, err := gameMgr.SweepFinishedGames()
+			if err != nil {
+				log.Printf("game archive sweep: %v", err)
+			}
+			if len(evicted) > 0 {
+				log.Printf("evicted %d finished games from memory", len(evicted))
+			}
+		}
+	}()
+	log.Printf("archiving finished games to %s (evict after %s)", dir, evictAfter)
+	return nil
+}
+
+// configureWebhooks posts game events to the webhooks registered through the
+// admin API, and to the server-wide webhooks listed in TM_WEBHOOK_URLS
+// (comma-separated).
+func configureWebhooks(gameMgr *game.Manager) (*webhooks.Dispatcher, error) {
+	dispatcher := webhooks.NewDispatcher(nil)
+	for _, raw := range strings.Split(os.Getenv("TM_WEBHOOK_URLS"), ",") {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		if _, err := dispatcher.Register(webhooks.Webhook{URL: raw}); err != nil {
+			return nil, fmt.Errorf("invalid TM_WEBHOOK_URLS: %w", err)
+		}
+	}
+	gameMgr.SetEventListener(dispatcher.Notify)
+	go dispatcher.Run()
+	if hooks := dispatcher.List(""); len(hooks) > 0 {
+		log.Printf("posting game events to %d webhooks (TM_WEBHOOK_URLS)", len(hooks))
+	}
+	return dispatcher, nil
+}
+
+// configureVacations lets every player pause their clocks for up to
+// TM_VACATION_DAYS days per calendar year. Vacations are disabled when unset.
+// TM_VACATION_FILE keeps the vacations across restarts.
+func configureVacations(gameMgr *game.Manager, lobbyMgr *lobby.Manager) error {
+	raw := strings.TrimSpace(os.Getenv("TM_VACATION_DAYS"))
+	if raw == "" {
+		return nil
+	}
+	days, err := strconv.Atoi(raw)
+	if err != nil || days <= 0 {
+		return fmt.Errorf("invalid TM_VACATION_DAYS %q", raw)
+	}
+	policy := &game.VacationPolicy{AnnualBudget: time.Duration(days) * 24 * time.Hour}
+	if path := strings.TrimSpace(os.Getenv("TM_VACATION_FILE")); path != "" {
+		policy.Store = game.NewFileVacationStore(path)
+	}
+	if err := gameMgr.SetVacationPolicy(policy); err != nil {
+		return err
+	}
+	lobbyMgr.SetVacationSource(gameMgr.OnVacation)
+	log.Printf("vacations enabled (%d days per year)", days)
+	return nil
+}
+
+// runVacationSweeps ends the vacations that used up their budget once a
+// minute, updating the clients of the affected games. It does nothing while
+// vacations are disabled.
+func runVacationSweeps(hub *websocket.Hub, deps websocket.ServerDeps) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for range ticker.C {
+		ended, err := websocket.SweepVacations(hub, deps)
+		if err != nil {
+			log.Printf("vacation sweep: %v", err)
+		}
+		if len(ended) > 0 {
+			log.Printf("ended %d vacations with no budget left", len(ended))
+		}
+	}
+}
+
+// runSpeedPresetSweeps takes the expired turns of blitz games and sends the
+// turn reminders of games created with a speed preset, once a second.
+func runSpeedPresetSweeps(hub *websocket.Hub, deps websocket.ServerDeps) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := websocket.SweepSpeedPresets(hub, deps); err != nil {
+			log.Printf("speed preset sweep: %v", err)
+		}
+	}
+}
+
+// configureGameData loads map layouts from TM_DATA_DIR/maps/*.yaml and tile
+// definitions from TM_DATA_DIR/tiles.yaml on top of the built-in data. Both are
+// validated before the server starts and reloaded on SIGHUP; a reload that
+// fails validation keeps the data already loaded.
+func configureGameData() error {
+	dir := strings.TrimSpace(os.Getenv("TM_DATA_DIR"))
+	if dir == "" {
+		return nil
+	}
+	if err := loadGameData(dir); err != nil {
+		return err
+	}
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			if err := loadGameData(dir); err != nil {
+				log.Printf("game data reload failed, keeping previous data: %v", err)
+				continue
+			}
+			log.Printf("reloaded game data from %s", dir)
+		}
+	}()
+	log.Printf("loaded game data from %s", dir)
+	return nil
+}
+
+func loadGameData(dir string) error {
+	// Both kinds of data are validated before either is applied, so a reload
+	// with one invalid file keeps the previous maps and tiles together.
+	var maps *board.MapData
+	mapsDir := filepath.Join(dir, "maps")
+	if info, err := os.Stat(mapsDir); err == nil && info.IsDir() {
+		if maps, err = board.ReadMapDirectory(mapsDir); err != nil {
+			return fmt.Errorf("invalid map data: %w", err)
+		}
+	}
+	var tiles *game.TileData
+	tilesFile := filepath.Join(dir, "tiles.yaml")
+	if _, err := os.Stat(tilesFile); err == nil {
+		if tiles, err = game.ReadTileDataFile(tilesFile); err != nil {
+			return fmt.Errorf("invalid tile data: %w", err)
+		}
+	}
+	if maps != nil {
+		maps.Apply()
+	}
+	if tiles != nil {
+		tiles.Apply()
+	}
+	return nil
+}
+
+// configureMessages registers the translations in TM_MESSAGES_DIR, one
+// <locale>.json file per language, next to the built-in English messages.
+func configureMessages() error {
+	dir := strings.TrimSpace(os.Getenv("TM_MESSAGES_DIR"))
+	if dir == "" {
+		return nil
+	}
+	if err := i18n.Default.LoadDirectory(dir); err != nil {
+		return err
+	}
+	log.Printf("loaded message catalogs %v from %s", i18n.Default.Locales(), dir)
+	return nil
+}
+
+// configureReplayLogStore picks where fetched and imported replay logs are
+// cached. TM_REPLAY_LOG_BUCKET=s3://bucket/prefix or gs://bucket/prefix keeps
+// them in an object store, signed with TM_REPLAY_LOG_ACCESS_KEY and
+// TM_REPLAY_LOG_SECRET_KEY (HMAC keys for GCS); otherwise they stay in
+// TM_REPLAY_LOG_DIR, or scriptDir when unset. TM_REPLAY_LOG_MAX_BYTES,
+// TM_REPLAY_LOG_MAX_TOTAL_BYTES and TM_REPLAY_LOG_MAX_LOGS bound the cache.
+func configureReplayLogStore(replayMgr *replay.ReplayManager, scriptDir string) error {
+	var limits replay.LogStoreLimits
+	for _, limit := range []struct {
+		env   string
+		value *int64
+	}{
+		{"TM_REPLAY_LOG_MAX_BYTES", &limits.MaxLogBytes},
+		{"TM_REPLAY_LOG_MAX_TOTAL_BYTES", &limits.MaxTotalBytes},
+	} {
+		if raw := strings.TrimSpace(os.Getenv(limit.env)); raw != "" {
+			parsed, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil || parsed <= 0 {
+				return fmt.Errorf("invalid %s %q", limit.env, raw)
+			}
+			*limit.value = parsed
+		}
+	}
+	if raw := strings.TrimSpace(os.Getenv("TM_REPLAY_LOG_MAX_LOGS")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			return fmt.Errorf("invalid TM_REPLAY_LOG_MAX_LOGS %q", raw)
+		}
+		limits.MaxLogs = parsed
+	}
+
+	rawBucket := strings.TrimSpace(os.Getenv("TM_REPLAY_LOG_BUCKET"))
+	if rawBucket == "" {
+		dir := strings.TrimSpace(os.Getenv("TM_REPLAY_LOG_DIR"))
+		if dir == "" {
+			dir = scriptDir
+		}
+		replayMgr.SetLogStore(replay.NewFileLogStore(dir, limits))
+		return nil
+	}
+	location, err := url.Parse(rawBucket)
+	if err != nil || location.Host == "" {
+		return fmt.Errorf("invalid TM_REPLAY_LOG_BUCKET %q", rawBucket)
+	}
+	endpoint := strings.TrimSpace(os.Getenv("TM_REPLAY_LOG_ENDPOINT"))
+	region := strings.TrimSpace(os.Getenv("TM_REPLAY_LOG_REGION"))
+	switch location.Scheme {
+	case "s3":
+		if region == "" {
+			region = "us-east-1"
+		}
+		if endpoint == "" {
+			endpoint = "https://s3." + region + ".amazonaws.com"
+		}
+	case "gs":
+		if region == "" {
+			region = "auto"
+		}
+		if endpoint == "" {
+			endpoint = "https://storage.googleapis.com"
+		}
+	default:
+		return fmt.Errorf("invalid TM_REPLAY_LOG_BUCKET %q: expected s3:// or gs://", rawBucket)
+	}
+	prefix := strings.TrimPrefix(location.Path, "/")
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	bucket := replay.NewS3Bucket(endpoint, region, location.Host,
+		os.Getenv("TM_REPLAY_LOG_ACCESS_KEY"), os.Getenv("TM_REPLAY_LOG_SECRET_KEY"))
+	replayMgr.SetLogStore(replay.NewBucketLogStore(bucket, prefix, limits))
+	log.Printf("caching replay logs in %s", rawBucket)
+	return nil
+}
+
+// configureStateExport writes every broadcast game state to numbered JSON
+// files under TM_STATE_EXPORT_DIR, one directory per game, for front-end
+// snapshot tests. Exporting is off when unset.
+func configureStateExport(hub *websocket.Hub) {
+	dir := strings.TrimSpace(os.Getenv("TM_STATE_EXPORT_DIR"))
+	if dir == "" {
+		return
+	}
+	hub.SetStateExporter(websocket.NewStateExporter(dir))
+	log.Printf("exporting game state updates to %s", dir)
+}
+
+// checkInvariantsEnabled reports whether TM_CHECK_INVARIANTS=true asks for
+// the debug mode that validates the game invariants after every action, in
+// live games and replays.
+func checkInvariantsEnabled() bool {
+	return strings.EqualFold(strings.TrimSpace(os.Getenv("TM_CHECK_INVARIANTS")), "true")
+}
+
+// checkConsistencyEnabled reports whether TM_CHECK_CONSISTENCY=true asks for
+// the debug mode that replays each recorded action in a shadow game and
+// compares it with the live state.
+func checkConsistencyEnabled() bool {
+	return strings.EqualFold(strings.TrimSpace(os.Getenv("TM_CHECK_CONSISTENCY")), "true")
+}
+
 func verifyRequiredNeuralEvaluator() error {
 	if !strings.EqualFold(strings.TrimSpace(os.Getenv("TM_AZ_REQUIRE_NEURAL")), "true") {
 		return nil
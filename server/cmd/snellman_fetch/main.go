@@ -0,0 +1,310 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lukev/tm_server/internal/notation"
+	"gopkg.in/yaml.v3"
+)
+
+// fixtureManifest is the YAML manifest written next to each fetched ledger;
+// see replay.GoldenFixture.
+type fixtureManifest struct {
+	FinalVP map[string]int `yaml:"final_vp"`
+	// Skip is set for games a player dropped out of, which the replay does
+	// not support.
+	Skip string `yaml:"skip,omitempty"`
+	// LogFile is the ledger the manifest belongs to; it is not written, as
+	// it defaults to the manifest name.
+	LogFile string `yaml:"-"`
+}
+
+// viewGameResponse is the subset of terra.snellman.net's view-game JSON we use.
+type viewGameResponse struct {
+	Error    []string        `json:"error"`
+	Finished json.RawMessage `json:"finished"`
+	Ledger   []ledgerRow     `json:"ledger"`
+}
+
+// ledgerRow is either a comment row or a faction row with per-resource cells.
+type ledgerRow struct {
+	Comment  string          `json:"comment"`
+	Faction  string          `json:"faction"`
+	Commands string          `json:"commands"`
+	Leech    json.RawMessage `json:"leech"`
+	VP       ledgerCell      `json:"VP"`
+	C        ledgerCell      `json:"C"`
+	W        ledgerCell      `json:"W"`
+	P        ledgerCell      `json:"P"`
+	PW       ledgerCell      `json:"PW"`
+	CULT     ledgerCell      `json:"CULT"`
+}
+
+type ledgerCell struct {
+	Delta json.RawMessage `json:"delta"`
+	Value json.RawMessage `json:"value"`
+}
+
+var gameIDPattern = regexp.MustCompile(`^[A-Za-z0-9_\-]+$`)
+
+func main() {
+	baseURLFlag := flag.String("base-url", "https://terra.snellman.net", "Snellman server base URL")
+	outFlag := flag.String("out", filepath.Join("internal", "replay", "testdata", "snellman_fetched"), "Fixture directory for the ledgers and their manifests")
+	timeoutFlag := flag.Duration("timeout", 30*time.Second, "HTTP timeout per game")
+	helpFlag := flag.Bool("help", false, "Show usage")
+	flag.Parse()
+
+	if *helpFlag || flag.NArg() == 0 {
+		printUsage()
+		os.Exit(0)
+	}
+
+	client := &http.Client{Timeout: *timeoutFlag}
+	failed := false
+	for _, gameID := range flag.Args() {
+		entry, err := fetchFixture(client, *baseURLFlag, *outFlag, gameID)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", gameID, err)
+			failed = true
+			continue
+		}
+		fmt.Printf("✓ %s: wrote %s (%s)\n", gameID, entry.LogFile, formatScores(entry.FinalVP))
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Println("Usage: snellman_fetch [flags] <game_id> [game_id...]")
+	fmt.Println()
+	fmt.Println("Downloads finished game ledgers from terra.snellman.net, stores them as")
+	fmt.Println("replay fixtures, and writes a YAML manifest next to each one so the")
+	fmt.Println("snellman batch replay tests check their final scores.")
+	fmt.Println()
+	flag.PrintDefaults()
+}
+
+// fetchFixture downloads one game and writes its ledger fixture and manifest
+// to outDir, replacing any earlier fetch of the game.
+func fetchFixture(client *http.Client, baseURL, outDir, gameID string) (fixtureManifest, error) {
+	if !gameIDPattern.MatchString(gameID) {
+		return fixtureManifest{}, fmt.Errorf("invalid game id %q", gameID)
+	}
+	resp, err := fetchGame(client, baseURL, gameID)
+	if err != nil {
+		return fixtureManifest{}, err
+	}
+	if !isFinished(resp.Finished) {
+		return fixtureManifest{}, fmt.Errorf("game is not finished")
+	}
+	text, scores, err := renderLedger(resp.Ledger)
+	if err != nil {
+		return fixtureManifest{}, err
+	}
+	if !notation.IsSnellmanTextFormat(text) {
+		return fixtureManifest{}, fmt.Errorf("rendered ledger is not recognized as snellman text")
+	}
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return fixtureManifest{}, fmt.Errorf("create fixture directory: %w", err)
+	}
+	entry := fixtureManifest{
+		FinalVP: scores,
+		LogFile: gameID + ".txt",
+	}
+	if strings.Contains(strings.ToLower(text), "dropped from the game") {
+		entry.Skip = "a player dropped from the game"
+	}
+	if err := os.WriteFile(filepath.Join(outDir, entry.LogFile), []byte(text), 0o644); err != nil {
+		return fixtureManifest{}, fmt.Errorf("write fixture: %w", err)
+	}
+	out, err := yaml.Marshal(entry)
+	if err != nil {
+		return fixtureManifest{}, fmt.Errorf("encode manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(outDir, gameID+".yaml"), out, 0o644); err != nil {
+		return fixtureManifest{}, fmt.Errorf("write manifest: %w", err)
+	}
+	return entry, nil
+}
+
+func fetchGame(client *http.Client, baseURL, gameID string) (*viewGameResponse, error) {
+	endpoint := strings.TrimRight(baseURL, "/") + "/app/view-game/"
+	httpResp, err := client.PostForm(endpoint, url.Values{"game": {gameID}})
+	if err != nil {
+		return nil, fmt.Errorf("fetch game: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(httpResp.Body, 32*1024*1024))
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch game: HTTP %d", httpResp.StatusCode)
+	}
+
+	var resp viewGameResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	if len(resp.Error) > 0 {
+		return nil, fmt.Errorf("server error: %s", strings.Join(resp.Error, "; "))
+	}
+	if len(resp.Ledger) == 0 {
+		return nil, fmt.Errorf("game has an empty ledger")
+	}
+	return &resp, nil
+}
+
+// isFinished treats a missing flag as finished; the final scores are still
+// required to be present in the ledger.
+func isFinished(raw json.RawMessage) bool {
+	switch strings.TrimSpace(string(raw)) {
+	case "", "null", "1", "true":
+		return true
+	default:
+		return false
+	}
+}
+
+// renderLedger converts ledger rows to the tab-separated text format of the
+// existing fixtures and returns each faction's final VP.
+func renderLedger(rows []ledgerRow) (string, map[string]int, error) {
+	var (
+		lines      []string
+		scores     = make(map[string]int)
+		sawScoring bool
+	)
+	for _, row := range rows {
+		if row.Faction == "" {
+			if comment := strings.TrimSpace(row.Comment); comment != "" {
+				lines = append(lines, comment)
+			}
+			continue
+		}
+
+		cells := []string{row.Faction}
+		for _, col := range []struct {
+			cell   ledgerCell
+			suffix string
+		}{
+			{row.VP, " VP"},
+			{row.C, " C"},
+			{row.W, " W"},
+			{row.P, " P"},
+			{row.PW, " PW"},
+			{row.CULT, ""},
+		} {
+			value := formatValue(col.cell.Value)
+			if value != "" {
+				value += col.suffix
+			}
+			cells = append(cells, formatDelta(col.cell.Delta), value)
+		}
+		cells = append(cells, formatLeech(row.Leech), strings.TrimSpace(row.Commands))
+		lines = append(lines, strings.Join(cells, "\t"))
+
+		vp, err := strconv.Atoi(formatValue(row.VP.Value))
+		if err != nil {
+			return "", nil, fmt.Errorf("faction %s has non-numeric VP %s", row.Faction, string(row.VP.Value))
+		}
+		scores[row.Faction] = vp
+		if strings.Contains(row.Commands, "score_resources") {
+			sawScoring = true
+		}
+	}
+	if len(scores) == 0 {
+		return "", nil, fmt.Errorf("ledger has no faction rows")
+	}
+	if !sawScoring {
+		return "", nil, fmt.Errorf("ledger has no final scoring rows")
+	}
+	return strings.Join(lines, "\n") + "\n", scores, nil
+}
+
+// formatValue renders a ledger value, which is a number, a string such as
+// "5/7/0", or for cults an object keyed by track.
+func formatValue(raw json.RawMessage) string {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return strings.TrimSpace(s)
+	}
+	var tracks map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &tracks); err == nil {
+		parts := make([]string, 0, 4)
+		for _, track := range []string{"FIRE", "WATER", "EARTH", "AIR"} {
+			parts = append(parts, formatValue(tracks[track]))
+		}
+		return strings.Join(parts, "/")
+	}
+	return string(raw)
+}
+
+func formatDelta(raw json.RawMessage) string {
+	delta := formatValue(raw)
+	n, err := strconv.Atoi(delta)
+	if err != nil {
+		return delta
+	}
+	switch {
+	case n > 0:
+		return fmt.Sprintf("+%d", n)
+	case n < 0:
+		return strconv.Itoa(n)
+	default:
+		return ""
+	}
+}
+
+// formatLeech renders the leech column: either a plain value or a map of
+// faction to power offered, listed in faction order.
+func formatLeech(raw json.RawMessage) string {
+	var offers map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &offers); err != nil {
+		return formatValue(raw)
+	}
+	factions := make([]string, 0, len(offers))
+	for faction := range offers {
+		factions = append(factions, faction)
+	}
+	sort.Strings(factions)
+	parts := make([]string, 0, len(factions))
+	for _, faction := range factions {
+		if value := formatValue(offers[faction]); value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatScores(scores map[string]int) string {
+	factions := make([]string, 0, len(scores))
+	for faction := range scores {
+		factions = append(factions, faction)
+	}
+	sort.Strings(factions)
+	parts := make([]string, 0, len(factions))
+	for _, faction := range factions {
+		parts = append(parts, fmt.Sprintf("%s %d", faction, scores[faction]))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/replay"
+)
+
+const testViewGameResponse = `{
+  "finished": 1,
+  "ledger": [
+    {"comment": "Default game options"},
+    {"comment": "option strict-leech"},
+    {"faction": "witches", "commands": "setup",
+     "VP": {"delta": 0, "value": 20}, "C": {"delta": 0, "value": 15}, "W": {"delta": 0, "value": 3},
+     "P": {"delta": 0, "value": 0}, "PW": {"delta": 0, "value": "5/7/0"}, "CULT": {"delta": 0, "value": "0/0/0/2"}},
+    {"faction": "nomads", "commands": "upgrade F3 to TE. +FAV11", "leech": {"witches": 1, "engineers": 3},
+     "VP": {"delta": 0, "value": 22}, "C": {"delta": -5, "value": 7}, "W": {"delta": -2, "value": 3},
+     "P": {"delta": 0, "value": 0}, "PW": {"delta": 0, "value": "0/11/1"}, "CULT": {"delta": 1, "value": {"FIRE": 1, "WATER": 0, "EARTH": 2, "AIR": 0}}},
+    {"comment": "Converting resources to VPs"},
+    {"faction": "witches", "commands": "score_resources",
+     "VP": {"delta": 106, "value": 126}, "C": {"delta": 0, "value": 1}, "W": {"delta": 0, "value": 0},
+     "P": {"delta": 0, "value": 0}, "PW": {"delta": 0, "value": "2/0/0"}, "CULT": {"delta": 0, "value": "4/7/2/10"}},
+    {"faction": "nomads", "commands": "score_resources",
+     "VP": {"delta": 1, "value": 123}, "C": {"delta": 2, "value": 2}, "W": {"delta": -3, "value": 0},
+     "P": {"delta": 0, "value": 0}, "PW": {"delta": -4, "value": "6/1/0"}, "CULT": {"delta": 0, "value": "3/7/7/3"}}
+  ]
+}`
+
+func TestFetchFixture_WritesLedgerAndManifest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app/view-game/" || r.FormValue("game") != "TestGame1" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(testViewGameResponse))
+	}))
+	defer server.Close()
+
+	outDir := t.TempDir()
+	entry, err := fetchFixture(server.Client(), server.URL, outDir, "TestGame1")
+	if err != nil {
+		t.Fatalf("fetchFixture failed: %v", err)
+	}
+	if entry.FinalVP["witches"] != 126 || entry.FinalVP["nomads"] != 123 {
+		t.Fatalf("unexpected final scores: %v", entry.FinalVP)
+	}
+
+	text, err := os.ReadFile(filepath.Join(outDir, "TestGame1.txt"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	wantLine := "nomads\t\t22 VP\t-5\t7 C\t-2\t3 W\t\t0 P\t\t0/11/1 PW\t+1\t1/0/2/0\t3 1\tupgrade F3 to TE. +FAV11"
+	if !strings.Contains(string(text), wantLine+"\n") {
+		t.Fatalf("fixture missing rendered row %q:\n%s", wantLine, text)
+	}
+	if !strings.HasPrefix(string(text), "Default game options\noption strict-leech\n") {
+		t.Fatalf("fixture should start with the ledger comments:\n%s", text)
+	}
+
+	// Fetching again replaces the fixture instead of adding another.
+	if _, err := fetchFixture(server.Client(), server.URL, outDir, "TestGame1"); err != nil {
+		t.Fatalf("refetch failed: %v", err)
+	}
+	fixtures, err := replay.LoadGoldenFixtures(outDir)
+	if err != nil {
+		t.Fatalf("LoadGoldenFixtures: %v", err)
+	}
+	if len(fixtures) != 1 || fixtures[0].GameID != "TestGame1" || fixtures[0].LogFile != "TestGame1.txt" ||
+		fixtures[0].FinalVP["witches"] != 126 || fixtures[0].Skip != "" {
+		t.Fatalf("unexpected fixtures: %+v", fixtures)
+	}
+}
+
+func TestFetchFixture_RejectsUnfinishedGame(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Replace(testViewGameResponse, `"finished": 1`, `"finished": 0`, 1)))
+	}))
+	defer server.Close()
+
+	outDir := t.TempDir()
+	if _, err := fetchFixture(server.Client(), server.URL, outDir, "TestGame1"); err == nil {
+		t.Fatalf("expected unfinished game to be rejected")
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "TestGame1.yaml")); !os.IsNotExist(err) {
+		t.Fatalf("expected no manifest for rejected game, stat err=%v", err)
+	}
+}
+
+func TestFetchFixture_RejectsInvalidGameID(t *testing.T) {
+	if _, err := fetchFixture(http.DefaultClient, "http://127.0.0.1:0", t.TempDir(), "../etc"); err == nil {
+		t.Fatalf("expected invalid game id to be rejected")
+	}
+}
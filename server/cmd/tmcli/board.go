@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+// stateView is the part of a game_state_update the terminal client shows.
+type stateView struct {
+	ID       string `json:"id"`
+	Revision int    `json:"revision"`
+	Round    struct {
+		Round int `json:"round"`
+	} `json:"round"`
+	Phase       game.GamePhase        `json:"phase"`
+	CurrentTurn int                   `json:"currentTurn"`
+	TurnOrder   []string              `json:"turnOrder"`
+	Players     map[string]playerView `json:"players"`
+	Map         struct {
+		Hexes map[string]hexView `json:"hexes"`
+	} `json:"map"`
+}
+
+type playerView struct {
+	ID            string             `json:"id"`
+	Faction       models.FactionType `json:"faction"`
+	VictoryPoints int                `json:"victoryPoints"`
+	HasPassed     bool               `json:"hasPassed"`
+	Resources     struct {
+		Coins   int `json:"coins"`
+		Workers int `json:"workers"`
+		Priests int `json:"priests"`
+		Power   struct {
+			PowerI   int `json:"powerI"`
+			PowerII  int `json:"powerII"`
+			PowerIII int `json:"powerIII"`
+		} `json:"power"`
+	} `json:"resources"`
+}
+
+type hexView struct {
+	Coord struct {
+		Q int `json:"q"`
+		R int `json:"r"`
+	} `json:"coord"`
+	Terrain  models.TerrainType `json:"terrain"`
+	Building *struct {
+		OwnerPlayerID string              `json:"ownerPlayerId"`
+		Type          models.BuildingType `json:"type"`
+	} `json:"building,omitempty"`
+}
+
+// seats returns the players in turn order, then any others by ID, so each
+// has a stable number on the board.
+func (s stateView) seats() []string {
+	seats := append([]string(nil), s.TurnOrder...)
+	var rest []string
+	for id := range s.Players {
+		if !slices.Contains(seats, id) {
+			rest = append(rest, id)
+		}
+	}
+	sort.Strings(rest)
+	return append(seats, rest...)
+}
+
+// currentPlayer returns the seat to move, or "" outside the turn order.
+func (s stateView) currentPlayer() string {
+	if s.CurrentTurn < 0 || s.CurrentTurn >= len(s.TurnOrder) {
+		return ""
+	}
+	return s.TurnOrder[s.CurrentTurn]
+}
+
+var terrainLetters = map[models.TerrainType]byte{
+	models.TerrainPlains:    'u',
+	models.TerrainSwamp:     'k',
+	models.TerrainLake:      'b',
+	models.TerrainForest:    'g',
+	models.TerrainMountain:  's',
+	models.TerrainWasteland: 'r',
+	models.TerrainDesert:    'y',
+	models.TerrainIce:       'i',
+	models.TerrainVolcano:   'v',
+}
+
+var buildingLetters = map[models.BuildingType]byte{
+	models.BuildingDwelling:     'D',
+	models.BuildingTradingHouse: 'H',
+	models.BuildingTemple:       'T',
+	models.BuildingSanctuary:    'A',
+	models.BuildingStronghold:   'S',
+}
+
+// renderBoard draws the map as offset rows of four-character cells. An empty
+// hex shows its terrain in Snellman's colour letters, a building adds its
+// letter and the owner's seat number, and rivers are "~".
+func renderBoard(s stateView) string {
+	if len(s.Map.Hexes) == 0 {
+		return "(no map)\n"
+	}
+	seatNumbers := map[string]int{}
+	for i, id := range s.seats() {
+		seatNumbers[id] = i + 1
+	}
+
+	minR, maxR, minCol := 0, 0, 0
+	first := true
+	for _, hex := range s.Map.Hexes {
+		col := 2*hex.Coord.Q + hex.Coord.R
+		if first || hex.Coord.R < minR {
+			minR = hex.Coord.R
+		}
+		if first || hex.Coord.R > maxR {
+			maxR = hex.Coord.R
+		}
+		if first || col < minCol {
+			minCol = col
+		}
+		first = false
+	}
+
+	rows := make([][]byte, maxR-minR+1)
+	for _, hex := range s.Map.Hexes {
+		row := hex.Coord.R - minR
+		x := (2*hex.Coord.Q + hex.Coord.R - minCol) * 2
+		for len(rows[row]) < x+4 {
+			rows[row] = append(rows[row], ' ')
+		}
+		copy(rows[row][x:x+4], hexCell(hex, seatNumbers))
+	}
+
+	var b strings.Builder
+	for i, row := range rows {
+		fmt.Fprintf(&b, "%c  %s\n", 'A'+i, strings.TrimRight(string(row), " "))
+	}
+	return b.String()
+}
+
+func hexCell(hex hexView, seatNumbers map[string]int) string {
+	if hex.Terrain == models.TerrainRiver {
+		return " ~  "
+	}
+	terrain, ok := terrainLetters[hex.Terrain]
+	if !ok {
+		terrain = '?'
+	}
+	if hex.Building == nil {
+		return " " + string(terrain) + "  "
+	}
+	building, ok := buildingLetters[hex.Building.Type]
+	if !ok {
+		building = '?'
+	}
+	seat := "?"
+	if n, ok := seatNumbers[hex.Building.OwnerPlayerID]; ok {
+		seat = fmt.Sprint(n)
+	}
+	return string([]byte{terrain, building}) + seat + " "
+}
+
+// renderPlayers lists each seat's number, faction, VP and resources.
+func renderPlayers(s stateView) string {
+	var b strings.Builder
+	current := s.currentPlayer()
+	for i, id := range s.seats() {
+		player := s.Players[id]
+		marker := " "
+		if id == current {
+			marker = ">"
+		}
+		status := ""
+		if player.HasPassed {
+			status = " (passed)"
+		}
+		res := player.Resources
+		fmt.Fprintf(&b, "%s%d %-12s %-16s %3d VP  %2dC %2dW %dP  %d/%d/%d%s\n",
+			marker, i+1, id, player.Faction, player.VictoryPoints,
+			res.Coins, res.Workers, res.Priests,
+			res.Power.PowerI, res.Power.PowerII, res.Power.PowerIII, status)
+	}
+	return b.String()
+}
@@ -0,0 +1,311 @@
+// Command tmcli plays a game on a running server from the terminal.
+//
+// It connects over the websocket API, draws the board as ASCII after every
+// state update and, on the player's turn, lists the legal actions the server
+// reports. Pick one by number or option ID:
+//
+//	tmcli -game g1 -player alice                  # interactive
+//	tmcli -game g1 -player bob -join              # take a seat first
+//	tmcli -game g1 -player alice -script moves.txt
+//
+// Commands are read one per line, so a script is just the choices in order;
+// blank lines and lines starting with # are skipped. Besides a choice,
+// "board" redraws the board, "refresh" asks for the state again and "quit"
+// leaves. The client exits when its input ends or the game is over.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"github.com/lukev/tm_server/internal/game"
+)
+
+type message struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+type legalOption struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Label string `json:"label"`
+}
+
+type legalActions struct {
+	GameID   string        `json:"gameId"`
+	PlayerID string        `json:"playerId"`
+	Revision int           `json:"revision"`
+	Actions  []legalOption `json:"actions"`
+}
+
+type actionRejected struct {
+	ActionID string `json:"actionId"`
+	Error    string `json:"error"`
+	Message  string `json:"message"`
+}
+
+func main() {
+	serverURL := flag.String("server", "ws://localhost:8080/api/ws", "websocket URL of the server")
+	gameID := flag.String("game", "", "game to play")
+	playerID := flag.String("player", "", "seat to play as")
+	join := flag.Bool("join", false, "join the game as -player before playing")
+	script := flag.String("script", "", "read commands from this file instead of stdin")
+	quiet := flag.Bool("quiet", false, "do not draw the board after every update")
+	flag.Parse()
+
+	if *gameID == "" || *playerID == "" {
+		fmt.Fprintln(os.Stderr, "Usage: tmcli -game <id> -player <seat> [-server url] [-join] [-script file] [-quiet]")
+		os.Exit(2)
+	}
+
+	input := io.Reader(os.Stdin)
+	if *script != "" {
+		file, err := os.Open(*script)
+		if err != nil {
+			fail("Error opening script: %v", err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(*serverURL, nil)
+	if err != nil {
+		fail("Error connecting to %s: %v", *serverURL, err)
+	}
+	defer conn.Close()
+
+	c := &cli{
+		conn:     conn,
+		out:      os.Stdout,
+		gameID:   *gameID,
+		playerID: *playerID,
+		quiet:    *quiet,
+	}
+	if err := c.run(*join, readLines(input)); err != nil {
+		fail("%v", err)
+	}
+}
+
+// cli is one terminal session in one seat of one game.
+type cli struct {
+	conn     *websocket.Conn
+	out      io.Writer
+	gameID   string
+	playerID string
+	quiet    bool
+
+	state    *stateView
+	options  []legalOption
+	revision int
+	actions  int
+}
+
+// run plays until the input ends, the player quits or the game is over.
+// Input is only read while the player has actions to choose from, so a
+// script stays in step with the game however long the other seats take.
+func (c *cli) run(join bool, lines <-chan string) error {
+	if join {
+		c.send("join_game", map[string]string{"id": c.gameID, "name": c.playerID})
+	}
+	c.requestState()
+
+	messages := make(chan message)
+	readErr := make(chan error, 1)
+	go func() {
+		for {
+			var msg message
+			if err := c.conn.ReadJSON(&msg); err != nil {
+				readErr <- err
+				return
+			}
+			messages <- msg
+		}
+	}()
+
+	for {
+		var prompt <-chan string
+		if len(c.options) > 0 {
+			prompt = lines
+		}
+		select {
+		case err := <-readErr:
+			return fmt.Errorf("connection closed: %w", err)
+		case msg := <-messages:
+			done, err := c.handle(msg)
+			if err != nil || done {
+				return err
+			}
+		case line, ok := <-prompt:
+			if !ok {
+				return nil
+			}
+			if c.command(line) {
+				return nil
+			}
+		}
+	}
+}
+
+func (c *cli) handle(msg message) (done bool, err error) {
+	switch msg.Type {
+	case "game_state_update":
+		var state stateView
+		if err := json.Unmarshal(msg.Payload, &state); err != nil {
+			return false, fmt.Errorf("invalid game state: %w", err)
+		}
+		if state.ID != "" && state.ID != c.gameID {
+			return false, nil
+		}
+		c.state = &state
+		c.options = nil
+		if !c.quiet {
+			c.printState()
+		}
+		if state.Phase == game.PhaseEnd {
+			fmt.Fprintln(c.out, "Game over.")
+			if c.quiet {
+				fmt.Fprint(c.out, renderPlayers(state))
+			}
+			return true, nil
+		}
+		c.send("get_legal_actions", map[string]string{"gameID": c.gameID})
+
+	case "legal_actions":
+		var legal legalActions
+		if err := json.Unmarshal(msg.Payload, &legal); err != nil {
+			return false, fmt.Errorf("invalid legal actions: %w", err)
+		}
+		if legal.GameID != c.gameID || (c.state != nil && legal.Revision < c.state.Revision) {
+			return false, nil
+		}
+		c.options, c.revision = legal.Actions, legal.Revision
+		if len(c.options) == 0 {
+			if c.state != nil && c.state.currentPlayer() != "" {
+				fmt.Fprintf(c.out, "Waiting for %s...\n", c.state.currentPlayer())
+			}
+			return false, nil
+		}
+		c.printOptions()
+
+	case "action_rejected":
+		var rejected actionRejected
+		_ = json.Unmarshal(msg.Payload, &rejected)
+		fmt.Fprintf(c.out, "Rejected (%s): %s\n", rejected.Error, rejected.Message)
+		c.send("get_legal_actions", map[string]string{"gameID": c.gameID})
+
+	case "error":
+		var code string
+		_ = json.Unmarshal(msg.Payload, &code)
+		if code == "not_in_game" {
+			return false, fmt.Errorf("%s is not seated in game %s", c.playerID, c.gameID)
+		}
+		fmt.Fprintf(c.out, "Error: %s\n", code)
+	}
+	return false, nil
+}
+
+// command runs one line of input, reporting whether the player quit.
+func (c *cli) command(line string) (quit bool) {
+	line = strings.TrimSpace(line)
+	switch {
+	case line == "" || strings.HasPrefix(line, "#"):
+		return false
+	case line == "quit" || line == "exit":
+		return true
+	case line == "board":
+		c.printState()
+		c.printOptions()
+		return false
+	case line == "refresh":
+		c.requestState()
+		return false
+	}
+
+	option, ok := c.choose(line)
+	if !ok {
+		fmt.Fprintf(c.out, "No legal action %q; pick a number from 1 to %d or an option ID.\n", line, len(c.options))
+		return false
+	}
+	fmt.Fprintf(c.out, "> %s\n", option.Label)
+	c.actions++
+	c.send("perform_legal_action", map[string]any{
+		"gameID":           c.gameID,
+		"optionId":         option.ID,
+		"actionId":         fmt.Sprintf("tmcli:%s:%d", c.playerID, c.actions),
+		"expectedRevision": c.revision,
+	})
+	c.options = nil
+	return false
+}
+
+// choose finds the listed option a command picks by number or ID.
+func (c *cli) choose(choice string) (legalOption, bool) {
+	if n, err := strconv.Atoi(choice); err == nil {
+		if n >= 1 && n <= len(c.options) {
+			return c.options[n-1], true
+		}
+		return legalOption{}, false
+	}
+	for _, option := range c.options {
+		if option.ID == choice {
+			return option, true
+		}
+	}
+	return legalOption{}, false
+}
+
+func (c *cli) printState() {
+	if c.state == nil {
+		return
+	}
+	fmt.Fprintf(c.out, "\n%s  round %d  revision %d\n\n", c.gameID, c.state.Round.Round, c.state.Revision)
+	fmt.Fprint(c.out, renderBoard(*c.state))
+	fmt.Fprintln(c.out)
+	fmt.Fprint(c.out, renderPlayers(*c.state))
+}
+
+func (c *cli) printOptions() {
+	if len(c.options) == 0 {
+		return
+	}
+	fmt.Fprintf(c.out, "\n%s to move:\n", c.playerID)
+	for i, option := range c.options {
+		fmt.Fprintf(c.out, "%4d  %s\n", i+1, option.Label)
+	}
+}
+
+func (c *cli) requestState() {
+	c.send("get_game_state", map[string]string{"gameID": c.gameID, "playerID": c.playerID})
+}
+
+func (c *cli) send(msgType string, payload any) {
+	if err := c.conn.WriteJSON(map[string]any{"type": msgType, "payload": payload}); err != nil {
+		fail("Error sending %s: %v", msgType, err)
+	}
+}
+
+// readLines streams r line by line, closing the channel at the end of input.
+func readLines(r io.Reader) <-chan string {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+	return lines
+}
+
+func fail(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/lobby"
+	"github.com/lukev/tm_server/internal/testharness"
+	"github.com/lukev/tm_server/internal/websocket"
+)
+
+const testStatePayload = `{
+  "id": "g1",
+  "revision": 7,
+  "round": {"round": 2},
+  "phase": 3,
+  "currentTurn": 1,
+  "turnOrder": ["alice", "bob"],
+  "players": {
+    "alice": {"id": "alice", "faction": 7, "victoryPoints": 24, "hasPassed": true,
+      "resources": {"coins": 5, "workers": 3, "priests": 1, "power": {"powerI": 2, "powerII": 7, "powerIII": 3}}},
+    "bob": {"id": "bob", "faction": 1, "victoryPoints": 20,
+      "resources": {"coins": 9, "workers": 4, "priests": 0, "power": {"powerI": 5, "powerII": 7, "powerIII": 0}}}
+  },
+  "map": {"hexes": {
+    "0,0": {"coord": {"q": 0, "r": 0}, "terrain": 0},
+    "1,0": {"coord": {"q": 1, "r": 0}, "terrain": 7},
+    "2,0": {"coord": {"q": 2, "r": 0}, "terrain": 4, "building": {"ownerPlayerId": "alice", "type": 0}},
+    "0,1": {"coord": {"q": 0, "r": 1}, "terrain": 6, "building": {"ownerPlayerId": "bob", "type": 4}},
+    "1,1": {"coord": {"q": 1, "r": 1}, "terrain": 3}
+  }}
+}`
+
+func TestRenderBoard(t *testing.T) {
+	var state stateView
+	if err := json.Unmarshal([]byte(testStatePayload), &state); err != nil {
+		t.Fatalf("unmarshal state: %v", err)
+	}
+
+	want := "A   u   ~  sD1\n" +
+		"B    yS2  g\n"
+	if got := renderBoard(state); got != want {
+		t.Fatalf("unexpected board:\n%q\nwant:\n%q", got, want)
+	}
+
+	players := renderPlayers(state)
+	lines := strings.Split(strings.TrimRight(players, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per player, got %q", players)
+	}
+	if !strings.HasPrefix(lines[0], " 1 alice") || !strings.HasSuffix(lines[0], "(passed)") {
+		t.Fatalf("unexpected line for alice: %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], ">2 bob") || !strings.Contains(lines[1], "Nomads") {
+		t.Fatalf("expected bob marked as the player to move, got %q", lines[1])
+	}
+}
+
+func TestChooseOption(t *testing.T) {
+	c := &cli{options: []legalOption{
+		{ID: "alice:pass", Label: "Pass"},
+		{ID: "alice:convert:1", Label: "Convert"},
+	}}
+	for choice, want := range map[string]string{"1": "alice:pass", "2": "alice:convert:1", "alice:convert:1": "alice:convert:1"} {
+		if got, ok := c.choose(choice); !ok || got.ID != want {
+			t.Fatalf("choose(%q) = %v, %v; want %s", choice, got, ok, want)
+		}
+	}
+	for _, choice := range []string{"0", "3", "pass"} {
+		if got, ok := c.choose(choice); ok {
+			t.Fatalf("choose(%q) = %v, want no option", choice, got)
+		}
+	}
+}
+
+func TestCLIPlaysScriptedTurns(t *testing.T) {
+	hub := websocket.NewHub()
+	go hub.Run()
+	deps := websocket.ServerDeps{Lobby: lobby.NewManager(), Games: game.NewManager()}
+	server, wsURL := testharness.StartServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		websocket.ServeWs(hub, deps, w, r)
+	}))
+	defer server.Close()
+
+	players := []string{"alice", "bob"}
+	clients := testharness.ConnectPlayers(t, wsURL, players)
+	defer testharness.CloseConnections(clients)
+	session := testharness.CreateAndStartGame(t, clients, players, testharness.GameOptions{Name: "tmcli"})
+
+	// Each seat's script picks the first legal action once: its faction.
+	for _, player := range players {
+		c := &cli{
+			conn:     testharness.Dial(t, wsURL),
+			out:      io.Discard,
+			gameID:   session.GameID,
+			playerID: player,
+			quiet:    true,
+		}
+		defer c.conn.Close()
+		go func() { _ = c.run(false, readLines(strings.NewReader("1\n"))) }()
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		gs, _, ok := deps.Games.GetGameSnapshot(session.GameID)
+		if ok && gs.GetPlayer("alice").Faction != nil && gs.GetPlayer("bob").Faction != nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected both scripted seats to pick a faction")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/webhooks"
+)
+
+// AdminHandler serves operator endpoints for inspecting and unsticking games.
+// Every request must carry "Authorization: Bearer <token>"; with an empty token
+// the endpoints are disabled.
+type AdminHandler struct {
+	games    *game.Manager
+	token    string
+	onChange func(gameID string, results []*game.ActionResult)
+	webhooks *webhooks.Dispatcher
+}
+
+// NewAdminHandler creates an admin handler. onChange, if set, is called after a
+// game is modified, with the results of the actions taken, so connected
+// clients can be notified.
+func NewAdminHandler(games *game.Manager, token string, onChange func(gameID string, results []*game.ActionResult)) *AdminHandler {
+	return &AdminHandler{games: games, token: strings.TrimSpace(token), onChange: onChange}
+}
+
+// SetWebhooks enables the webhook endpoints, which manage dispatcher's
+// webhooks.
+func (h *AdminHandler) SetWebhooks(dispatcher *webhooks.Dispatcher) {
+	h.webhooks = dispatcher
+}
+
+func (h *AdminHandler) RegisterRoutes(router *mux.Router) {
+	s := router.PathPrefix("/api/admin").Subrouter()
+	s.Use(h.requireToken)
+	s.HandleFunc("/games", h.handleListGames).Methods("GET")
+	s.HandleFunc("/games/{gameId}", h.handleDumpGame).Methods("GET")
+	s.HandleFunc("/games/{gameId}/resolve", h.handleResolve).Methods("POST")
+	s.HandleFunc("/webhooks", h.handleListWebhooks).Methods("GET")
+	s.HandleFunc("/webhooks", h.handleAddWebhook).Methods("POST")
+	s.HandleFunc("/webhooks/{webhookId}", h.handleRemoveWebhook).Methods("DELETE")
+	s.HandleFunc("/games/{gameId}/webhooks", h.handleListWebhooks).Methods("GET")
+	s.HandleFunc("/games/{gameId}/webhooks", h.handleAddWebhook).Methods("POST")
+}
+
+func (h *AdminHandler) requireToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.token == "" {
+			http.Error(w, "admin api disabled", http.StatusForbidden)
+			return
+		}
+		provided := strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(h.token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (h *AdminHandler) handleListGames(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"games": h.games.ListGameSummaries()})
+}
+
+func (h *AdminHandler) handleDumpGame(w http.ResponseWriter, r *http.Request) {
+	gameID := mux.Vars(r)["gameId"]
+	state, revision, err := h.games.DumpGameState(gameID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"gameId":   gameID,
+		"revision": revision,
+		"state":    state,
+	})
+}
+
+type adminResolveRequest struct {
+	Resolution game.ForcedResolution `json:"resolution"`
+}
+
+func (h *AdminHandler) handleResolve(w http.ResponseWriter, r *http.Request) {
+	gameID := mux.Vars(r)["gameId"]
+	before, ok := h.games.GetRevision(gameID)
+	if !ok {
+		http.Error(w, "game not found", http.StatusNotFound)
+		return
+	}
+	var req adminResolveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	revision, results, err := h.games.ForceResolvePending(gameID, req.Resolution)
+	if revision != before && h.onChange != nil {
+		h.onChange(gameID, results)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"gameId":   gameID,
+		"revision": revision,
+	})
+}
+
+// handleListWebhooks lists the registered webhooks: all of them, or under
+// /games/{gameId} (or with ?gameId=) those limited to that game.
+func (h *AdminHandler) handleListWebhooks(w http.ResponseWriter, r *http.Request) {
+	if h.webhooks == nil {
+		http.Error(w, "webhooks disabled", http.StatusNotFound)
+		return
+	}
+	gameID := mux.Vars(r)["gameId"]
+	if gameID == "" {
+		gameID = strings.TrimSpace(r.URL.Query().Get("gameId"))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"webhooks": h.webhooks.List(gameID)})
+}
+
+type adminWebhookRequest struct {
+	URL    string               `json:"url"`
+	GameID string               `json:"gameId"`
+	Events []game.GameEventType `json:"events"`
+}
+
+// handleAddWebhook registers a webhook. Under /games/{gameId} it is limited
+// to that game, which must exist; otherwise the body's gameId, if any, does
+// the same.
+func (h *AdminHandler) handleAddWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.webhooks == nil {
+		http.Error(w, "webhooks disabled", http.StatusNotFound)
+		return
+	}
+	var req adminWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if gameID := mux.Vars(r)["gameId"]; gameID != "" {
+		if _, ok := h.games.GetRevision(gameID); !ok {
+			http.Error(w, "game not found", http.StatusNotFound)
+			return
+		}
+		req.GameID = gameID
+	}
+	hook, err := h.webhooks.Register(webhooks.Webhook{URL: req.URL, GameID: req.GameID, Events: req.Events})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, http.StatusCreated, hook)
+}
+
+func (h *AdminHandler) handleRemoveWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.webhooks == nil {
+		http.Error(w, "webhooks disabled", http.StatusNotFound)
+		return
+	}
+	if !h.webhooks.Remove(mux.Vars(r)["webhookId"]) {
+		http.Error(w, "webhook not found", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/webhooks"
+)
+
+func TestAdminRoutesRequireToken(t *testing.T) {
+	games := game.NewManager()
+	if err := games.CreateGameWithOptions("g1", []string{"p1", "p2"}, game.CreateGameOptions{}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+
+	disabled := mux.NewRouter()
+	NewAdminHandler(games, "", nil).RegisterRoutes(disabled)
+	if code := serveAdmin(disabled, http.MethodGet, "/api/admin/games", "", "").Code; code != http.StatusForbidden {
+		t.Fatalf("disabled admin status = %d, want %d", code, http.StatusForbidden)
+	}
+
+	router := mux.NewRouter()
+	NewAdminHandler(games, "secret", nil).RegisterRoutes(router)
+	if code := serveAdmin(router, http.MethodGet, "/api/admin/games", "wrong", "").Code; code != http.StatusUnauthorized {
+		t.Fatalf("wrong token status = %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	list := serveAdmin(router, http.MethodGet, "/api/admin/games", "secret", "")
+	if list.Code != http.StatusOK || !strings.Contains(list.Body.String(), `"id":"g1"`) {
+		t.Fatalf("unexpected list response %d: %s", list.Code, list.Body.String())
+	}
+	dump := serveAdmin(router, http.MethodGet, "/api/admin/games/g1", "secret", "")
+	if dump.Code != http.StatusOK || !strings.Contains(dump.Body.String(), `"pendingLeechOffers"`) {
+		t.Fatalf("unexpected dump response %d: %s", dump.Code, dump.Body.String())
+	}
+}
+
+func TestAdminResolveRejectsWhenNothingPending(t *testing.T) {
+	games := game.NewManager()
+	if err := games.CreateGameWithOptions("g1", []string{"p1", "p2"}, game.CreateGameOptions{}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	notified := false
+	router := mux.NewRouter()
+	NewAdminHandler(games, "secret", func(string, []*game.ActionResult) { notified = true }).RegisterRoutes(router)
+
+	resp := serveAdmin(router, http.MethodPost, "/api/admin/games/g1/resolve", "secret", `{"resolution":"decline_leeches"}`)
+	if resp.Code != http.StatusConflict {
+		t.Fatalf("resolve status = %d, want %d: %s", resp.Code, http.StatusConflict, resp.Body.String())
+	}
+	if notified {
+		t.Fatalf("unchanged game should not notify clients")
+	}
+}
+
+func TestAdminWebhooks(t *testing.T) {
+	games := game.NewManager()
+	if err := games.CreateGameWithOptions("g1", []string{"p1", "p2"}, game.CreateGameOptions{}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	router := mux.NewRouter()
+	admin := NewAdminHandler(games, "secret", nil)
+	admin.RegisterRoutes(router)
+	if code := serveAdmin(router, http.MethodGet, "/api/admin/webhooks", "secret", "").Code; code != http.StatusNotFound {
+		t.Fatalf("webhooks without a dispatcher status = %d, want %d", code, http.StatusNotFound)
+	}
+	admin.SetWebhooks(webhooks.NewDispatcher(nil))
+
+	server := serveAdmin(router, http.MethodPost, "/api/admin/webhooks", "secret", `{"url":"https://example.com/all"}`)
+	if server.Code != http.StatusCreated || !strings.Contains(server.Body.String(), `"id":"wh1"`) {
+		t.Fatalf("add server webhook %d: %s", server.Code, server.Body.String())
+	}
+	perGame := serveAdmin(router, http.MethodPost, "/api/admin/games/g1/webhooks", "secret", `{"url":"https://example.com/g1","events":["turn_changed"]}`)
+	if perGame.Code != http.StatusCreated || !strings.Contains(perGame.Body.String(), `"gameId":"g1"`) {
+		t.Fatalf("add game webhook %d: %s", perGame.Code, perGame.Body.String())
+	}
+	if code := serveAdmin(router, http.MethodPost, "/api/admin/games/nope/webhooks", "secret", `{"url":"https://example.com/x"}`).Code; code != http.StatusNotFound {
+		t.Fatalf("webhook of a missing game status = %d, want %d", code, http.StatusNotFound)
+	}
+	if code := serveAdmin(router, http.MethodPost, "/api/admin/webhooks", "secret", `{"url":"not a url"}`).Code; code != http.StatusBadRequest {
+		t.Fatalf("invalid url status = %d, want %d", code, http.StatusBadRequest)
+	}
+
+	list := serveAdmin(router, http.MethodGet, "/api/admin/games/g1/webhooks", "secret", "")
+	if list.Code != http.StatusOK || strings.Contains(list.Body.String(), "wh1") || !strings.Contains(list.Body.String(), `"id":"wh2"`) {
+		t.Fatalf("list game webhooks %d: %s", list.Code, list.Body.String())
+	}
+	if code := serveAdmin(router, http.MethodDelete, "/api/admin/webhooks/wh1", "secret", "").Code; code != http.StatusNoContent {
+		t.Fatalf("delete status = %d, want %d", code, http.StatusNoContent)
+	}
+	if code := serveAdmin(router, http.MethodDelete, "/api/admin/webhooks/wh1", "secret", "").Code; code != http.StatusNotFound {
+		t.Fatalf("second delete status = %d, want %d", code, http.StatusNotFound)
+	}
+	all := serveAdmin(router, http.MethodGet, "/api/admin/webhooks", "secret", "")
+	if strings.Contains(all.Body.String(), "wh1") || !strings.Contains(all.Body.String(), "wh2") {
+		t.Fatalf("list after delete: %s", all.Body.String())
+	}
+}
+
+func serveAdmin(handler http.Handler, method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	return resp
+}
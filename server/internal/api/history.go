@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/notation"
+)
+
+// HistoryHandler serves views derived from a game's recorded history: its
+// transcript, score progression and seed audit.
+type HistoryHandler struct {
+	games       *game.Manager
+	buildAction game.RecordedActionBuilder
+}
+
+func NewHistoryHandler(games *game.Manager, buildAction game.RecordedActionBuilder) *HistoryHandler {
+	return &HistoryHandler{games: games, buildAction: buildAction}
+}
+
+func (h *HistoryHandler) RegisterRoutes(router *mux.Router) {
+	s := router.PathPrefix("/api/games").Subrouter()
+	s.HandleFunc("/{gameId}/transcript", h.handleTranscript).Methods("GET")
+	s.HandleFunc("/{gameId}/vp_progression", h.handleVPProgression).Methods("GET")
+	s.HandleFunc("/{gameId}/seed_audit", h.handleSeedAudit).Methods("GET")
+}
+
+// handleTranscript renders the game's recorded history as a plain-text
+// narrative, headed by its rules summary. Like export, it needs a game with a
+// replayable history.
+func (h *HistoryHandler) handleTranscript(w http.ResponseWriter, r *http.Request) {
+	gameID := mux.Vars(r)["gameId"]
+	if !historyAvailable(w, h.games, gameID) {
+		return
+	}
+	save, err := h.games.ExportGame(gameID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	items, err := notation.LogItemsFromSaveFile(save, h.buildAction)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	transcript := notation.RenderTranscript(items)
+	if summary, err := h.games.RulesSummary(gameID); err == nil {
+		transcript = strings.Join(summary.Lines, "\n") + "\n\n" + transcript
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte(transcript))
+}
+
+// handleVPProgression returns the game's score history for progression
+// charts. Like export, it needs a game with a replayable history.
+func (h *HistoryHandler) handleVPProgression(w http.ResponseWriter, r *http.Request) {
+	gameID := mux.Vars(r)["gameId"]
+	if !historyAvailable(w, h.games, gameID) {
+		return
+	}
+	progression, err := h.games.VPProgression(gameID, h.buildAction)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(progression)
+}
+
+// handleSeedAudit re-derives a finished game's setup draws from its seed
+// (see game.Manager.AuditSeed). A mismatch is reported with the derived draws.
+func (h *HistoryHandler) handleSeedAudit(w http.ResponseWriter, r *http.Request) {
+	gameID := mux.Vars(r)["gameId"]
+	if _, ok := h.games.GetGame(gameID); !ok {
+		http.Error(w, "game not found", http.StatusNotFound)
+		return
+	}
+	draws, err := h.games.AuditSeed(gameID)
+	if draws == nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	resp := map[string]interface{}{"draws": draws, "verified": err == nil}
+	if err != nil {
+		resp["error"] = err.Error()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// historyAvailable reports whether gameID's history may be served, answering
+// the request itself when it may not.
+func historyAvailable(w http.ResponseWriter, games *game.Manager, gameID string) bool {
+	if _, ok := games.GetGame(gameID); !ok {
+		http.Error(w, "game not found", http.StatusNotFound)
+		return false
+	}
+	if games.HistoryHidden(gameID) {
+		http.Error(w, "the history of a hidden-resources game is available once it ends", http.StatusForbidden)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/lobby"
+)
+
+func TestHistoryOfHiddenResourcesGameWaitsForTheEnd(t *testing.T) {
+	games := game.NewManager()
+	if err := games.CreateGameWithOptions("g1", []string{"p1", "p2"}, game.CreateGameOptions{HiddenResources: true}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	router := mux.NewRouter()
+	NewSaveFileHandler(games, lobby.NewManager(), nil).RegisterRoutes(router)
+	NewHistoryHandler(games, nil).RegisterRoutes(router)
+
+	for _, route := range []string{"export", "transcript", "vp_progression"} {
+		if code := serveAdmin(router, http.MethodGet, "/api/games/g1/"+route, "", "").Code; code != http.StatusForbidden {
+			t.Fatalf("%s of a running hidden-resources game: status %d, want %d", route, code, http.StatusForbidden)
+		}
+	}
+
+	gs, _ := games.GetGame("g1")
+	gs.Phase = game.PhaseEnd
+	if resp := serveAdmin(router, http.MethodGet, "/api/games/g1/export", "", ""); resp.Code != http.StatusOK {
+		t.Fatalf("export of a finished game: status %d: %s", resp.Code, resp.Body.String())
+	}
+}
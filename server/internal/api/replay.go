@@ -2,11 +2,13 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/gorilla/mux"
 	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/notation"
 	"github.com/lukev/tm_server/internal/replay"
 )
 
@@ -28,9 +30,29 @@ func (h *ReplayHandler) RegisterRoutes(router *mux.Router) {
 	s.HandleFunc("/jump", h.handleJump).Methods("POST")
 	s.HandleFunc("/state", h.handleState).Methods("GET")
 	s.HandleFunc("/snapshot", h.handleSnapshot).Methods("GET")
+	s.HandleFunc("/networks", h.handleNetworks).Methods("GET")
+	s.HandleFunc("/vp_progression", h.handleVPProgression).Methods("GET")
+	s.HandleFunc("/score_projection", h.handleScoreProjection).Methods("GET")
 	s.HandleFunc("/provide_info", h.handleProvideInfo).Methods("POST")
 }
 
+// writeUnsupportedFeatures answers 422 with the features listed when err is a
+// notation.UnsupportedFeatureError, so clients can tell the log is not
+// replayable from a replay bug.
+func writeUnsupportedFeatures(w http.ResponseWriter, err error) bool {
+	var unsupported *notation.UnsupportedFeatureError
+	if !errors.As(err, &unsupported) {
+		return false
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error":               err.Error(),
+		"unsupportedFeatures": unsupported.Features,
+	})
+	return true
+}
+
 func (h *ReplayHandler) handleStart(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		GameID  string `json:"gameId"`
@@ -43,6 +65,9 @@ func (h *ReplayHandler) handleStart(w http.ResponseWriter, r *http.Request) {
 
 	session, err := h.manager.StartReplay(req.GameID, req.Restart)
 	if err != nil {
+		if writeUnsupportedFeatures(w, err) {
+			return
+		}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{
@@ -137,6 +162,81 @@ func (h *ReplayHandler) handleState(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(serialized)
 }
 
+// handleNetworks returns the building clusters of every player at the
+// current replay position (see game.GameState.AnalyzeBuildingNetworks).
+func (h *ReplayHandler) handleNetworks(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("gameId")
+	if gameID == "" {
+		http.Error(w, "missing gameId", http.StatusBadRequest)
+		return
+	}
+
+	session := h.manager.GetSession(gameID)
+	if session == nil {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	}
+
+	state := session.Simulator.GetState()
+	if state == nil {
+		http.Error(w, "state is nil", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(state.AnalyzeBuildingNetworks())
+}
+
+// handleVPProgression returns the score history up to the current replay
+// position (see replay.RecordVPProgression).
+func (h *ReplayHandler) handleVPProgression(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("gameId")
+	if gameID == "" {
+		http.Error(w, "missing gameId", http.StatusBadRequest)
+		return
+	}
+
+	session := h.manager.GetSession(gameID)
+	if session == nil {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	}
+
+	progression, err := replay.RecordVPProgression(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(progression)
+}
+
+// handleScoreProjection returns the scores as if the game ended at the
+// current replay position (see game.ProjectFinalScores).
+func (h *ReplayHandler) handleScoreProjection(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("gameId")
+	if gameID == "" {
+		http.Error(w, "missing gameId", http.StatusBadRequest)
+		return
+	}
+
+	session := h.manager.GetSession(gameID)
+	if session == nil {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	}
+
+	state := session.Simulator.GetState()
+	if state == nil {
+		http.Error(w, "state is nil", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(game.ProjectFinalScores(state))
+}
+
 func (h *ReplayHandler) handleProvideInfo(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		GameID string                   `json:"gameId"`
@@ -233,6 +333,9 @@ func (h *ReplayHandler) handleImport(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.manager.ImportLog(req.GameID, req.HTML); err != nil {
+		if writeUnsupportedFeatures(w, err) {
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -286,6 +389,9 @@ func (h *ReplayHandler) handleImportText(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := h.manager.ImportText(req.GameID, req.LogText, req.Format); err != nil {
+		if writeUnsupportedFeatures(w, err) {
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
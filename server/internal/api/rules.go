@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/lobby"
+)
+
+// RulesHandler serves the rules summary of games and of lobbies that have not
+// started yet.
+type RulesHandler struct {
+	games *game.Manager
+	lobby *lobby.Manager
+}
+
+func NewRulesHandler(games *game.Manager, lobbyMgr *lobby.Manager) *RulesHandler {
+	return &RulesHandler{games: games, lobby: lobbyMgr}
+}
+
+func (h *RulesHandler) RegisterRoutes(router *mux.Router) {
+	s := router.PathPrefix("/api/games").Subrouter()
+	s.HandleFunc("/{gameId}/rules", h.handleRules).Methods("GET")
+}
+
+// handleRules returns the options in effect for a game, or for a lobby the
+// options chosen so far, as JSON, or as plain text lines with ?format=text.
+func (h *RulesHandler) handleRules(w http.ResponseWriter, r *http.Request) {
+	gameID := mux.Vars(r)["gameId"]
+	summary, err := h.games.RulesSummary(gameID)
+	if err != nil {
+		meta, ok := h.lobby.GetGame(gameID)
+		if !ok || meta.Started {
+			http.Error(w, "game not found", http.StatusNotFound)
+			return
+		}
+		summary = game.BuildLobbyRulesSummary(meta.GameOptions())
+		summary.GameID = gameID
+	}
+
+	if r.URL.Query().Get("format") == "text" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte(strings.Join(summary.Lines, "\n") + "\n"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(summary)
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/lobby"
+)
+
+func TestRulesOfLobbyBeforeStart(t *testing.T) {
+	lobbyMgr := lobby.NewManager()
+	meta, err := lobbyMgr.CreateGame("open", 4, "host", "fjords", nil, false, true, "")
+	if err != nil {
+		t.Fatalf("create lobby: %v", err)
+	}
+	router := mux.NewRouter()
+	NewRulesHandler(game.NewManager(), lobbyMgr).RegisterRoutes(router)
+
+	resp := serveAdmin(router, http.MethodGet, "/api/games/"+meta.ID+"/rules?format=text", "", "")
+	if resp.Code != http.StatusOK {
+		t.Fatalf("rules of an open lobby: status %d: %s", resp.Code, resp.Body.String())
+	}
+	if body := resp.Body.String(); !strings.Contains(body, "Map: Fjords") || !strings.Contains(body, "Fire & Ice factions: on") {
+		t.Fatalf("unexpected rules:\n%s", body)
+	}
+	if code := serveAdmin(router, http.MethodGet, "/api/games/missing/rules", "", "").Code; code != http.StatusNotFound {
+		t.Fatalf("rules of an unknown game: status %d, want %d", code, http.StatusNotFound)
+	}
+}
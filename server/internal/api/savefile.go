@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/lobby"
+)
+
+// SaveFileHandler exports in-progress games to portable save files and imports
+// them back, possibly on another server instance.
+type SaveFileHandler struct {
+	games       *game.Manager
+	lobby       *lobby.Manager
+	buildAction game.RecordedActionBuilder
+}
+
+func NewSaveFileHandler(games *game.Manager, lobbyMgr *lobby.Manager, buildAction game.RecordedActionBuilder) *SaveFileHandler {
+	return &SaveFileHandler{games: games, lobby: lobbyMgr, buildAction: buildAction}
+}
+
+func (h *SaveFileHandler) RegisterRoutes(router *mux.Router) {
+	s := router.PathPrefix("/api/games").Subrouter()
+	s.HandleFunc("/import", h.handleImport).Methods("POST")
+	s.HandleFunc("/{gameId}/export", h.handleExport).Methods("GET")
+}
+
+func (h *SaveFileHandler) handleExport(w http.ResponseWriter, r *http.Request) {
+	gameID := mux.Vars(r)["gameId"]
+	if !historyAvailable(w, h.games, gameID) {
+		return
+	}
+	save, err := h.games.ExportGame(gameID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"tm_game_%s.json\"", gameID))
+	_ = json.NewEncoder(w).Encode(save)
+}
+
+func (h *SaveFileHandler) handleImport(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 10*1024*1024) // 10MB limit
+
+	var save game.SaveFile
+	if err := json.NewDecoder(r.Body).Decode(&save); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	imported, err := game.ReplaySaveFile(&save, h.buildAction)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	name := strings.TrimSpace(r.URL.Query().Get("name"))
+	if name == "" {
+		name = fmt.Sprintf("Imported game %s", save.GameID)
+	}
+	meta, err := h.lobby.AddStartedGame(
+		name,
+		save.PlayerIDs,
+		string(save.Settings.MapID),
+		save.Settings.CustomMap,
+		save.Settings.EnableFanFactions,
+		save.Settings.EnableFireIceFactions,
+		string(save.Settings.FireIceScoring),
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.games.AddImportedGame(meta.ID, imported); err != nil {
+		_ = h.lobby.RemoveGame(meta.ID)
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"gameId":   meta.ID,
+		"revision": imported.Revision,
+		"players":  meta.Players,
+	})
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/lobby"
+)
+
+func TestImportLeavesNoLobbyGameWhenTheGameCannotBeAdded(t *testing.T) {
+	source := game.NewManager()
+	if err := source.CreateGameWithOptions("g1", []string{"p1", "p2"}, game.CreateGameOptions{}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	save, err := source.ExportGame("g1")
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	body, err := json.Marshal(save)
+	if err != nil {
+		t.Fatalf("encode save: %v", err)
+	}
+
+	// The lobby numbers games from 1, so the import collides with this game.
+	games := game.NewManager()
+	if err := games.CreateGameWithOptions("1", []string{"p1", "p2"}, game.CreateGameOptions{}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	lobbyMgr := lobby.NewManager()
+	router := mux.NewRouter()
+	NewSaveFileHandler(games, lobbyMgr, nil).RegisterRoutes(router)
+
+	if resp := serveAdmin(router, http.MethodPost, "/api/games/import", "", string(body)); resp.Code != http.StatusConflict {
+		t.Fatalf("import over an existing game: status %d: %s", resp.Code, resp.Body.String())
+	}
+	if listed := lobbyMgr.ListGames(); len(listed) != 0 {
+		t.Fatalf("expected no lobby game left behind, got %+v", listed)
+	}
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/lobby"
+)
+
+// SummaryHandler serves the results of finished games whose players made
+// them public, so results can be shared without access to the rest of the
+// server.
+type SummaryHandler struct {
+	games *game.Manager
+	lobby *lobby.Manager
+}
+
+func NewSummaryHandler(games *game.Manager, lobbyMgr *lobby.Manager) *SummaryHandler {
+	return &SummaryHandler{games: games, lobby: lobbyMgr}
+}
+
+func (h *SummaryHandler) RegisterRoutes(router *mux.Router) {
+	s := router.PathPrefix("/api/games").Subrouter()
+	s.HandleFunc("/{gameId}/summary", h.handleSummary).Methods("GET")
+}
+
+type summaryResponse struct {
+	Name string `json:"name,omitempty"`
+	*game.ResultSummary
+}
+
+// handleSummary returns a finished game's result as JSON, or as an HTML page
+// with ?format=html. Games that are not public answer 404, like unknown ones,
+// so private games cannot be probed for.
+func (h *SummaryHandler) handleSummary(w http.ResponseWriter, r *http.Request) {
+	gameID := mux.Vars(r)["gameId"]
+	summary, err := h.games.ResultSummary(gameID)
+	switch {
+	case errors.Is(err, game.ErrGameNotFinished):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, "game not found", http.StatusNotFound)
+		return
+	}
+	resp := summaryResponse{ResultSummary: summary}
+	if meta, ok := h.lobby.GetGame(gameID); ok {
+		resp.Name = meta.Name
+	}
+
+	if r.URL.Query().Get("format") == "html" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = summaryPage.Execute(w, resp)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+var summaryPage = template.Must(template.New("summary").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{if .Name}}{{.Name}}{{else}}Game {{.GameID}}{{end}} - Terra Mystica</title>
+</head>
+<body>
+<h1>{{if .Name}}{{.Name}}{{else}}Game {{.GameID}}{{end}}</h1>
+<p>Finished after {{.Rounds}} rounds.</p>
+<table>
+<tr><th>Rank</th><th>Player</th><th>Faction</th><th>VP</th><th>Base</th><th>Area</th><th>Cults</th><th>Resources</th><th>Fire &amp; Ice</th></tr>
+{{range .Players}}<tr><td>{{.Rank}}</td><td>{{.PlayerID}}{{if .Score.Resigned}} (resigned){{end}}</td><td>{{.Faction}}</td><td>{{.TotalVP}}</td><td>{{.Score.BaseVP}}</td><td>{{.Score.AreaVP}}</td><td>{{.Score.CultVP}}</td><td>{{.Score.ResourceVP}}</td><td>{{.Score.FireIceVP}}</td></tr>
+{{end}}</table>
+<p><a href="{{.ReplayPath}}">Watch the replay</a></p>
+</body>
+</html>
+`))
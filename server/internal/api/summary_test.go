@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/lobby"
+)
+
+func TestSummaryServedOnlyForPublicFinishedGames(t *testing.T) {
+	games := game.NewManager()
+	if err := games.CreateGameWithOptions("g1", []string{"p1", "p2"}, game.CreateGameOptions{}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	router := mux.NewRouter()
+	NewSummaryHandler(games, lobby.NewManager()).RegisterRoutes(router)
+
+	if code := serveAdmin(router, http.MethodGet, "/api/games/g1/summary", "", "").Code; code != http.StatusNotFound {
+		t.Fatalf("private game status = %d, want %d", code, http.StatusNotFound)
+	}
+	if err := games.SetPublicSummary("g1", "p3", true); err == nil {
+		t.Fatalf("expected a player outside the game to be refused")
+	}
+	if err := games.SetPublicSummary("g1", "p1", true); err != nil {
+		t.Fatalf("set public summary: %v", err)
+	}
+	if code := serveAdmin(router, http.MethodGet, "/api/games/g1/summary", "", "").Code; code != http.StatusNotFound {
+		t.Fatalf("one seat's consent status = %d, want %d", code, http.StatusNotFound)
+	}
+	if err := games.SetPublicSummary("g1", "p2", true); err != nil {
+		t.Fatalf("set public summary: %v", err)
+	}
+	if code := serveAdmin(router, http.MethodGet, "/api/games/g1/summary", "", "").Code; code != http.StatusConflict {
+		t.Fatalf("unfinished game status = %d, want %d", code, http.StatusConflict)
+	}
+
+	gs, _ := games.GetGame("g1")
+	gs.Phase = game.PhaseEnd
+	gs.Round = 6
+	gs.FinalScoring = map[string]*game.PlayerFinalScore{
+		"p1": {PlayerID: "p1", BaseVP: 100, AreaVP: 18, TotalVP: 118},
+		"p2": {PlayerID: "p2", BaseVP: 120, TotalVP: 120},
+	}
+
+	resp := serveAdmin(router, http.MethodGet, "/api/games/g1/summary", "", "")
+	if resp.Code != http.StatusOK {
+		t.Fatalf("summary status = %d: %s", resp.Code, resp.Body.String())
+	}
+	var summary game.ResultSummary
+	if err := json.Unmarshal(resp.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("decode summary: %v", err)
+	}
+	if summary.Rounds != 6 || len(summary.Players) != 2 || summary.ReplayPath != "/replay/g1" {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	if first := summary.Players[0]; first.PlayerID != "p2" || first.Rank != 1 || first.TotalVP != 120 {
+		t.Fatalf("expected p2 ranked first, got %+v", first)
+	}
+	if second := summary.Players[1]; second.Rank != 2 || second.Score == nil || second.Score.AreaVP != 18 {
+		t.Fatalf("expected p1 second with its breakdown, got %+v", second)
+	}
+
+	page := serveAdmin(router, http.MethodGet, "/api/games/g1/summary?format=html", "", "")
+	if page.Code != http.StatusOK || !strings.Contains(page.Body.String(), `<a href="/replay/g1">`) {
+		t.Fatalf("unexpected summary page %d: %s", page.Code, page.Body.String())
+	}
+
+	if err := games.SetPublicSummary("g1", "p2", false); err != nil {
+		t.Fatalf("withdraw public summary: %v", err)
+	}
+	if code := serveAdmin(router, http.MethodGet, "/api/games/g1/summary", "", "").Code; code != http.StatusNotFound {
+		t.Fatalf("withdrawn consent status = %d, want %d", code, http.StatusNotFound)
+	}
+}
@@ -47,6 +47,146 @@ func LegalActions(gs *game.GameState) []Option {
 	return legal
 }
 
+// MustPass reports whether playerID has no legal main-turn action left other
+// than passing and free conversions. It stops at the first legal action, so it
+// is cheap for players who are not stuck. It is the game.MustPassDetector of
+// live games.
+//
+// Legality checks fund costs with automatic conversions where the engine
+// does, but not everywhere (sending a priest needs one on hand), so actions
+// are also tried after up to fundingDepth burns and conversions the player
+// could make first, such as a burn into Bowl III and then a power conversion.
+func MustPass(gs *game.GameState, playerID string) bool {
+	if gs == nil || gs.Phase != game.PhaseAction {
+		return false
+	}
+	if player := gs.GetPlayer(playerID); player == nil || player.HasPassed {
+		return false
+	}
+	return !hasFundedAction(gs, playerID, fundingDepth, make(map[string]int))
+}
+
+// fundingDepth bounds the burns and conversions MustPass chains before an
+// action.
+const fundingDepth = 2
+
+// hasFundedAction reports whether playerID has a legal action in gs or after
+// at most depth burns and conversions. seen maps the resources already
+// searched to the depth they were searched with, since different sequences
+// often fund the same way.
+func hasFundedAction(gs *game.GameState, playerID string, depth int, seen map[string]int) bool {
+	key := fundingKey(gs.GetPlayer(playerID))
+	if searched, ok := seen[key]; ok && searched >= depth {
+		return false
+	}
+	seen[key] = depth
+	if hasLegalAction(gs, mainTurnCandidates(gs, playerID)) {
+		return true
+	}
+	if depth == 0 {
+		return false
+	}
+	for _, action := range fundingActions(gs, playerID) {
+		if action.Validate(gs) != nil {
+			continue
+		}
+		funded, err := ApplyToClone(gs, action)
+		if err == nil && hasFundedAction(funded, playerID, depth-1, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+func fundingKey(player *game.Player) string {
+	if player == nil || player.Resources == nil || player.Resources.Power == nil {
+		return ""
+	}
+	r := player.Resources
+	return fmt.Sprintf("%d/%d/%d/%d/%d/%d/%d", r.Coins, r.Workers, r.Priests,
+		r.Power.Bowl1, r.Power.Bowl2, r.Power.Bowl3, player.VictoryPoints)
+}
+
+func hasLegalAction(gs *game.GameState, options []Option) bool {
+	for _, option := range options {
+		switch option.Type {
+		case "pass", "pass_final", "conversion":
+			continue
+		}
+		if option.Action != nil && IsLegal(gs, option.Action) {
+			return true
+		}
+	}
+	return false
+}
+
+// fundingConversions are the free conversions that can pay for a main
+// action.
+var fundingConversions = []game.ConversionType{
+	game.ConversionPowerToCoin,
+	game.ConversionPowerToWorker,
+	game.ConversionPowerToPriest,
+	game.ConversionPriestToWorker,
+	game.ConversionWorkerToCoin,
+	game.ConversionCoinToPower,
+	game.ConversionAlchVPToCoin,
+}
+
+// maxFundingAmount bounds the amount of a single funding burn or conversion.
+const maxFundingAmount = 8
+
+// fundingActions returns the burns and conversions playerID can afford in gs,
+// for hasFundedAction to try one at a time.
+func fundingActions(gs *game.GameState, playerID string) []game.Action {
+	var funding []game.Action
+	step, most := affordableAmount(gs, playerID, game.ConversionBurnPower)
+	for amount := step; amount <= most; amount += step {
+		funding = append(funding, &game.BurnPowerAction{
+			BaseAction: game.BaseAction{Type: game.ActionBurnPower, PlayerID: playerID},
+			Amount:     amount,
+			Confirmed:  true,
+		})
+	}
+	for _, conversion := range fundingConversions {
+		step, most := affordableAmount(gs, playerID, conversion)
+		for amount := step; amount <= most; amount += step {
+			funding = append(funding, &game.ConversionAction{
+				BaseAction:     game.BaseAction{Type: game.ActionConversion, PlayerID: playerID},
+				ConversionType: conversion,
+				Amount:         amount,
+			})
+		}
+	}
+	return funding
+}
+
+// affordableAmount returns the step conversionType converts in and the most
+// of it playerID's resources pay for at their conversion rate, up to
+// maxFundingAmount. The most is 0 when the conversion is not available.
+func affordableAmount(gs *game.GameState, playerID string, conversionType game.ConversionType) (int, int) {
+	player := gs.GetPlayer(playerID)
+	rate, ok := gs.ConversionRateFor(playerID, conversionType)
+	if !ok || rate.Ordination || player.Resources == nil || player.Resources.Power == nil {
+		return 1, 0
+	}
+	r := player.Resources
+	step := max(rate.Step, 1)
+	trades := maxFundingAmount / step
+	for _, limit := range []struct{ have, perTrade int }{
+		{r.Power.Bowl2, rate.Cost.SacrificedPower + rate.Gain.Power},
+		{r.Power.Bowl3, rate.Cost.Power},
+		{r.Coins, rate.Cost.Coins},
+		{r.Workers, rate.Cost.Workers},
+		{r.Priests, rate.Cost.Priests},
+		{player.VictoryPoints, rate.Cost.VP},
+	} {
+		if limit.perTrade > 0 {
+			trades = min(trades, limit.have/limit.perTrade)
+		}
+	}
+	return step, trades * step
+}
+
 // IsLegal validates an action by executing it on a cloned state through the
 // same manager path used by live games.
 func IsLegal(gs *game.GameState, action game.Action) bool {
@@ -1,6 +1,7 @@
 package actions_test
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/lukev/tm_server/internal/az/actions"
@@ -359,3 +360,127 @@ func TestLegalActionsExcludeRepeatedMermaidsRiverTownConnect(t *testing.T) {
 		}
 	}
 }
+
+func TestMustPassDetectsPlayerWithoutResources(t *testing.T) {
+	position, err := env.BuiltInScenario("base_nomads_witches")
+	if err != nil {
+		t.Fatalf("BuiltInScenario failed: %v", err)
+	}
+	gs := position.State
+	current := gs.GetCurrentPlayer()
+	if current == nil {
+		t.Fatal("expected a current player")
+	}
+	if actions.MustPass(gs, current.ID) {
+		t.Fatal("player with starting resources should not be forced to pass")
+	}
+
+	current.Resources.Coins = 0
+	current.Resources.Workers = 0
+	current.Resources.Priests = 0
+	current.Resources.Power.Bowl1 += current.Resources.Power.Bowl2 + current.Resources.Power.Bowl3
+	current.Resources.Power.Bowl2 = 0
+	current.Resources.Power.Bowl3 = 0
+	for _, actionType := range []game.SpecialActionType{game.SpecialActionBonusCardSpade, game.SpecialActionBonusCardCultAdvance, game.SpecialActionWitchesRide, game.SpecialActionNomadsSandstorm} {
+		current.SpecialActionsUsed[actionType] = true
+	}
+	if !actions.MustPass(gs, current.ID) {
+		var legal []string
+		for _, option := range actions.LegalActions(gs) {
+			legal = append(legal, option.ID)
+		}
+		t.Fatalf("player without resources should be forced to pass; legal: %v", legal)
+	}
+}
+
+func TestRandomPolicyDrivesPlayout(t *testing.T) {
+	position, err := env.BuiltInScenario("base_nomads_witches")
+	if err != nil {
+		t.Fatalf("BuiltInScenario failed: %v", err)
+	}
+	before := position.State.Round
+	result, err := game.Playout(position.State, actions.RandomPolicy{Rand: rand.New(rand.NewSource(1))}, 12)
+	if err != nil {
+		t.Fatalf("Playout failed: %v", err)
+	}
+	if result.Actions == 0 || len(result.Scores) != 2 {
+		t.Fatalf("expected a playout with scores for both players, got %+v", result)
+	}
+	if position.State.Round != before {
+		t.Fatalf("playout changed the source state round: %d -> %d", before, position.State.Round)
+	}
+}
+
+func TestMustPassCountsActionsFundedByConversions(t *testing.T) {
+	position, err := env.BuiltInScenario("base_nomads_witches")
+	if err != nil {
+		t.Fatalf("BuiltInScenario failed: %v", err)
+	}
+	gs := position.State
+	current := gs.GetCurrentPlayer()
+	if current == nil {
+		t.Fatal("expected a current player")
+	}
+	current.Resources.Coins = 0
+	current.Resources.Workers = 0
+	current.Resources.Priests = 0
+	total := current.Resources.Power.Bowl1 + current.Resources.Power.Bowl2 + current.Resources.Power.Bowl3
+	current.Resources.Power.Bowl1 = total - 5
+	current.Resources.Power.Bowl2 = 0
+	current.Resources.Power.Bowl3 = 5
+	for _, actionType := range []game.SpecialActionType{game.SpecialActionBonusCardSpade, game.SpecialActionBonusCardCultAdvance, game.SpecialActionWitchesRide, game.SpecialActionNomadsSandstorm} {
+		current.SpecialActionsUsed[actionType] = true
+	}
+	for _, actionType := range []game.PowerActionType{game.PowerActionBridge, game.PowerActionPriest, game.PowerActionWorkers, game.PowerActionCoins, game.PowerActionSpade1, game.PowerActionSpade2} {
+		gs.PowerActions.MarkUsed(actionType)
+	}
+	// No dwelling without a spade, which 5 power cannot pay for.
+	home := current.Faction.GetHomeTerrain()
+	for _, hex := range gs.Map.Hexes {
+		if hex.Building == nil && hex.Terrain == home {
+			hex.Terrain = models.TerrainForest
+		}
+	}
+
+	// Converting 5 power to a priest lets the player send it to a cult.
+	if actions.MustPass(gs, current.ID) {
+		t.Fatal("player who can convert power into a priest for a cult should not be forced to pass")
+	}
+}
+
+func TestMustPassCountsActionsFundedByBurnThenConversion(t *testing.T) {
+	position, err := env.BuiltInScenario("base_nomads_witches")
+	if err != nil {
+		t.Fatalf("BuiltInScenario failed: %v", err)
+	}
+	gs := position.State
+	current := gs.GetCurrentPlayer()
+	if current == nil {
+		t.Fatal("expected a current player")
+	}
+	current.Resources.Coins = 0
+	current.Resources.Workers = 0
+	current.Resources.Priests = 0
+	total := current.Resources.Power.Bowl1 + current.Resources.Power.Bowl2 + current.Resources.Power.Bowl3
+	current.Resources.Power.Bowl1 = total - 10
+	current.Resources.Power.Bowl2 = 10
+	current.Resources.Power.Bowl3 = 0
+	for _, actionType := range []game.SpecialActionType{game.SpecialActionBonusCardSpade, game.SpecialActionBonusCardCultAdvance, game.SpecialActionWitchesRide, game.SpecialActionNomadsSandstorm} {
+		current.SpecialActionsUsed[actionType] = true
+	}
+	for _, actionType := range []game.PowerActionType{game.PowerActionBridge, game.PowerActionPriest, game.PowerActionWorkers, game.PowerActionCoins, game.PowerActionSpade1, game.PowerActionSpade2} {
+		gs.PowerActions.MarkUsed(actionType)
+	}
+	home := current.Faction.GetHomeTerrain()
+	for _, hex := range gs.Map.Hexes {
+		if hex.Building == nil && hex.Terrain == home {
+			hex.Terrain = models.TerrainForest
+		}
+	}
+
+	// Burning 5 power into Bowl III and converting it into a priest lets the
+	// player send it to a cult; neither step alone does.
+	if actions.MustPass(gs, current.ID) {
+		t.Fatal("player who can burn and then convert power into a priest should not be forced to pass")
+	}
+}
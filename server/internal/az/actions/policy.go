@@ -0,0 +1,22 @@
+package actions
+
+import (
+	"math/rand"
+
+	"github.com/lukev/tm_server/internal/game"
+)
+
+// RandomPolicy is a game.PlayoutPolicy that plays a uniformly random legal
+// action, for Monte Carlo playouts.
+type RandomPolicy struct {
+	Rand *rand.Rand
+}
+
+// NextAction implements game.PlayoutPolicy.
+func (p RandomPolicy) NextAction(gs *game.GameState) game.Action {
+	legal := LegalActions(gs)
+	if len(legal) == 0 {
+		return nil
+	}
+	return legal[p.Rand.Intn(len(legal))].Action
+}
@@ -24,6 +24,9 @@ type Config struct {
 	ProgressWriter io.Writer
 	Search         mcts.Config
 	RandomSeed     int64
+	// OpeningPlacements is how many setup dwellings identify a game's
+	// opening; 0 uses all of them.
+	OpeningPlacements int
 }
 
 type Result struct {
@@ -50,6 +53,9 @@ type Result struct {
 	UnorderedMatchupStats          map[string]MatchupResult `json:"unorderedMatchupStats,omitempty"`
 	CandidateR1BuildRatesByFaction stats.R1BuildRates       `json:"candidateR1BuildRatesByFaction,omitempty"`
 	BaselineR1BuildRatesByFaction  stats.R1BuildRates       `json:"baselineR1BuildRatesByFaction,omitempty"`
+	OpeningCounts                  map[string]int           `json:"openingCounts,omitempty"`
+	CandidateOpeningWinRates       stats.OpeningWinRates    `json:"candidateOpeningWinRates,omitempty"`
+	BaselineOpeningWinRates        stats.OpeningWinRates    `json:"baselineOpeningWinRates,omitempty"`
 	FinalRoundCounts               map[string]int           `json:"finalRoundCounts,omitempty"`
 	FinalPhaseCounts               map[string]int           `json:"finalPhaseCounts,omitempty"`
 	TerminalPhaseCounts            map[string]int           `json:"terminalPhaseCounts,omitempty"`
@@ -123,6 +129,9 @@ func Evaluate(candidate, baseline model.Evaluator, config Config) (Result, error
 		BaselineR1BuildRatesByFaction:  make(stats.R1BuildRates),
 		CandidateFinalScoresByFaction:  make(stats.FinalScoreRates),
 		BaselineFinalScoresByFaction:   make(stats.FinalScoreRates),
+		OpeningCounts:                  make(map[string]int),
+		CandidateOpeningWinRates:       make(stats.OpeningWinRates),
+		BaselineOpeningWinRates:        make(stats.OpeningWinRates),
 		FinalRoundCounts:               make(map[string]int),
 		FinalPhaseCounts:               make(map[string]int),
 		TerminalPhaseCounts:            make(map[string]int),
@@ -200,6 +209,7 @@ type gameResult struct {
 	searchNanos        int64
 	candidateR1Samples []stats.R1BuildSample
 	baselineR1Samples  []stats.R1BuildSample
+	opening            stats.Opening
 	elapsed            time.Duration
 	err                error
 }
@@ -227,6 +237,7 @@ func evaluateGame(gameIndex, workerID int, candidate, baseline model.Evaluator,
 		out.metadata.Scenario = scenarioName
 	}
 	r1Tracker := stats.NewR1BuildTracker(position.State, playerIDs(position))
+	openingTracker := stats.NewOpeningTracker(position.State, config.OpeningPlacements)
 	for ply := 0; ply < config.MaxPlies && !position.IsTerminal(); ply++ {
 		out.plies++
 		legal := position.LegalActions()
@@ -262,7 +273,9 @@ func evaluateGame(gameIndex, workerID int, candidate, baseline model.Evaluator,
 			return out
 		}
 		r1Tracker.Observe(position.State)
+		openingTracker.Observe(position.State)
 	}
+	out.opening = openingTracker.Opening()
 	r1Tracker.Finalize(position.State)
 	for _, sample := range r1Tracker.Samples() {
 		if sample.PlayerID == candidatePlayer {
@@ -320,6 +333,11 @@ func mergeGameResult(result *Result, game gameResult) {
 	}
 	stats.AddR1BuildSamples(result.CandidateR1BuildRatesByFaction, game.candidateR1Samples)
 	stats.AddR1BuildSamples(result.BaselineR1BuildRatesByFaction, game.baselineR1Samples)
+	if game.opening.ID != "" {
+		result.OpeningCounts[game.opening.ID]++
+	}
+	stats.AddOpeningResult(result.CandidateOpeningWinRates, game.opening.StartFor(game.candidateFaction), game.margin > 0.01, math.Abs(game.margin) <= 0.01)
+	stats.AddOpeningResult(result.BaselineOpeningWinRates, game.opening.StartFor(game.baselineFaction), game.margin < -0.01, math.Abs(game.margin) <= 0.01)
 	matchupKey := game.metadata.UnorderedMatchup
 	if matchupKey != "" {
 		stats := result.UnorderedMatchupStats[matchupKey]
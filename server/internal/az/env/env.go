@@ -689,7 +689,7 @@ func configureRoundAssets(gs *game.GameState, players []string, rng *rand.Rand,
 		}
 		_, _ = gs.BonusCards.TakeBonusCard(playerID, card)
 	}
-	gs.BonusCards.AddCoinsToLeftoverCards()
+	gs.BonusCards.AddCoins(gs.Round + 1)
 	gs.BonusCards.PlayerHasCard = make(map[string]bool)
 }
 
@@ -775,11 +775,8 @@ func parseMatchupScenario(name string) (models.FactionType, models.FactionType,
 }
 
 func parseBaseFaction(name string) models.FactionType {
-	normalized := strings.ReplaceAll(strings.TrimSpace(name), " ", "")
-	for _, faction := range baseFactionPool {
-		if strings.EqualFold(normalized, strings.ReplaceAll(faction.String(), " ", "")) {
-			return faction
-		}
+	if faction := models.FactionTypeFromString(name); faction.IsBaseGame() {
+		return faction
 	}
 	return models.FactionUnknown
 }
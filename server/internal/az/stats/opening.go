@@ -0,0 +1,250 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+// OpeningPlacement is one setup dwelling: the faction that placed it and the
+// hex, as a display coordinate ("E7") where the map has one, "q,r" otherwise.
+type OpeningPlacement struct {
+	Faction string `json:"faction"`
+	Hex     string `json:"hex"`
+}
+
+// Opening identifies a game by its first setup placements. Placements are
+// sorted by faction and hex, so the same dwellings placed in another order or
+// from another seat rotation give the same ID. On a map that looks the same
+// turned half way around, an opening and its turned copy are equivalent and
+// share the ID of whichever sorts first.
+type Opening struct {
+	ID         string             `json:"id"`
+	Placements []OpeningPlacement `json:"placements"`
+	// turned maps each placement's hex to the hex it lands on when the board
+	// is turned half way around, or is nil when the map has no such symmetry.
+	turned map[string]string
+}
+
+// StartFor returns the starting position key of faction in the opening, e.g.
+// "Witches@E6,F4", or "" if faction placed nothing. Like the opening, a start
+// and its turned copy share the key of whichever sorts first, whichever way
+// the rest of the opening was turned.
+func (o Opening) StartFor(faction string) string {
+	var hexes, turned []string
+	for _, placement := range o.Placements {
+		if placement.Faction == faction {
+			hexes = append(hexes, placement.Hex)
+			turned = append(turned, o.turned[placement.Hex])
+		}
+	}
+	if len(hexes) == 0 {
+		return ""
+	}
+	start := strings.Join(hexes, ",")
+	if o.turned != nil {
+		sort.Strings(turned)
+		if alt := strings.Join(turned, ","); alt < start {
+			start = alt
+		}
+	}
+	return faction + "@" + start
+}
+
+// OpeningTracker records the first setup dwellings of a game. Dwellings on
+// the board when the tracker is created count as placed first; afterwards
+// only dwellings placed during faction selection and setup are recorded.
+type OpeningTracker struct {
+	limit      int
+	seen       map[string]bool
+	placements []OpeningPlacement
+	// turned holds each placement's hex on the board turned half way around,
+	// or is nil when the map has no such symmetry.
+	turned []string
+	turn   board.Hex
+}
+
+// NewOpeningTracker tracks the first limit placements, or every setup
+// placement when limit is 0.
+func NewOpeningTracker(gs *game.GameState, limit int) *OpeningTracker {
+	t := &OpeningTracker{limit: limit, seen: make(map[string]bool)}
+	if gs != nil && gs.Map != nil {
+		if turn, ok := halfTurn(gs.Map); ok {
+			t.turn = turn
+			t.turned = []string{}
+		}
+	}
+	t.record(gs)
+	return t
+}
+
+// Observe records the dwellings placed since the last observation, while gs
+// is still in faction selection or setup.
+func (t *OpeningTracker) Observe(gs *game.GameState) {
+	if t == nil || gs == nil {
+		return
+	}
+	if gs.Phase != game.PhaseFactionSelection && gs.Phase != game.PhaseSetup {
+		return
+	}
+	t.record(gs)
+}
+
+// Opening returns the canonical opening of the placements recorded so far.
+func (t *OpeningTracker) Opening() Opening {
+	if t == nil {
+		return Opening{}
+	}
+	opening := newOpening(t.placements)
+	if t.turned != nil {
+		turned := make([]OpeningPlacement, len(t.placements))
+		turnedHexes := make(map[string]string, 2*len(t.placements))
+		for i, placement := range t.placements {
+			turned[i] = OpeningPlacement{Faction: placement.Faction, Hex: t.turned[i]}
+			turnedHexes[placement.Hex] = t.turned[i]
+			turnedHexes[t.turned[i]] = placement.Hex
+		}
+		if alt := newOpening(turned); alt.ID < opening.ID {
+			opening = alt
+		}
+		opening.turned = turnedHexes
+	}
+	return opening
+}
+
+func newOpening(placements []OpeningPlacement) Opening {
+	placements = append([]OpeningPlacement(nil), placements...)
+	sortPlacements(placements)
+	parts := make([]string, len(placements))
+	for i, placement := range placements {
+		parts[i] = placement.Faction + ":" + placement.Hex
+	}
+	return Opening{ID: strings.Join(parts, "|"), Placements: placements}
+}
+
+func sortPlacements(placements []OpeningPlacement) {
+	sort.Slice(placements, func(i, j int) bool {
+		if placements[i].Faction != placements[j].Faction {
+			return placements[i].Faction < placements[j].Faction
+		}
+		return placements[i].Hex < placements[j].Hex
+	})
+}
+
+func (t *OpeningTracker) record(gs *game.GameState) {
+	if gs == nil || gs.Map == nil {
+		return
+	}
+	var placed []OpeningPlacement
+	turnedLabels := make(map[string]string)
+	for _, hex := range gs.Map.Hexes {
+		if hex == nil || hex.Building == nil || hex.Building.Type != models.BuildingDwelling {
+			continue
+		}
+		label := hexLabel(gs.Map, hex.Coord)
+		if t.seen[label] {
+			continue
+		}
+		t.seen[label] = true
+		placed = append(placed, OpeningPlacement{Faction: hex.Building.Faction.String(), Hex: label})
+		if t.turned != nil {
+			turnedLabels[label] = hexLabel(gs.Map, board.NewHex(t.turn.Q-hex.Coord.Q, t.turn.R-hex.Coord.R))
+		}
+	}
+	// Dwellings that appear in the same observation have no order between
+	// them; sort so a limit cuts them deterministically.
+	sortPlacements(placed)
+	for _, placement := range placed {
+		if t.limit > 0 && len(t.placements) >= t.limit {
+			return
+		}
+		t.placements = append(t.placements, placement)
+		if t.turned != nil {
+			t.turned = append(t.turned, turnedLabels[placement.Hex])
+		}
+	}
+}
+
+func hexLabel(m *board.TerraMysticaMap, hex board.Hex) string {
+	if label, ok := m.DisplayCoordinateForHex(hex); ok {
+		return label
+	}
+	return fmt.Sprintf("%d,%d", hex.Q, hex.R)
+}
+
+// halfTurn reports whether m looks the same turned half way around: every hex
+// h lands on turn-h, a hex of the same terrain. The first and last hex in
+// reading order swap places under the turn, so turn is their sum.
+func halfTurn(m *board.TerraMysticaMap) (board.Hex, bool) {
+	if len(m.Hexes) == 0 {
+		return board.Hex{}, false
+	}
+	var first, last board.Hex
+	started := false
+	for hex := range m.Hexes {
+		if !started || hex.R < first.R || (hex.R == first.R && hex.Q < first.Q) {
+			first = hex
+		}
+		if !started || hex.R > last.R || (hex.R == last.R && hex.Q > last.Q) {
+			last = hex
+		}
+		started = true
+	}
+	turn := board.NewHex(first.Q+last.Q, first.R+last.R)
+	for hex, mapHex := range m.Hexes {
+		turned := m.GetHex(board.NewHex(turn.Q-hex.Q, turn.R-hex.R))
+		if turned == nil || mapHex == nil || turned.Terrain != mapHex.Terrain || m.IsRiver(hex) != m.IsRiver(turned.Coord) {
+			return board.Hex{}, false
+		}
+	}
+	return turn, true
+}
+
+// OpeningWinRate is how a starting position fared: draws count as half a win.
+type OpeningWinRate struct {
+	Samples int     `json:"samples"`
+	Wins    int     `json:"wins"`
+	Draws   int     `json:"draws"`
+	WinRate float64 `json:"winRate"`
+}
+
+// OpeningWinRates is keyed by starting position (see Opening.StartFor), so
+// win rates can be queried per faction and starting hexes.
+type OpeningWinRates map[string]OpeningWinRate
+
+// AddOpeningResult counts one game played from start, as a win, a draw or a
+// loss. Games with no starting position are skipped.
+func AddOpeningResult(rates OpeningWinRates, start string, won, drawn bool) {
+	if start == "" {
+		return
+	}
+	entry := rates[start]
+	entry.Samples++
+	switch {
+	case won:
+		entry.Wins++
+	case drawn:
+		entry.Draws++
+	}
+	entry.WinRate = (float64(entry.Wins) + 0.5*float64(entry.Draws)) / float64(entry.Samples)
+	rates[start] = entry
+}
+
+// MergeOpeningWinRates adds the counts of src to dst and recomputes the win
+// rates of the merged entries.
+func MergeOpeningWinRates(dst, src OpeningWinRates) {
+	for start, part := range src {
+		entry := dst[start]
+		entry.Samples += part.Samples
+		entry.Wins += part.Wins
+		entry.Draws += part.Draws
+		if entry.Samples > 0 {
+			entry.WinRate = (float64(entry.Wins) + 0.5*float64(entry.Draws)) / float64(entry.Samples)
+		}
+		dst[start] = entry
+	}
+}
@@ -0,0 +1,119 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+func TestOpeningTrackerCanonicalizesPlacementOrder(t *testing.T) {
+	place := func(gs *game.GameState, hex board.Hex, faction models.FactionType) {
+		gs.Map.GetHex(hex).Building = &models.Building{Type: models.BuildingDwelling, Faction: faction}
+	}
+	newSetup := func() *game.GameState {
+		return &game.GameState{Phase: game.PhaseSetup, Map: board.NewTerraMysticaMap()}
+	}
+
+	first := newSetup()
+	firstTracker := NewOpeningTracker(first, 0)
+	place(first, board.NewHex(0, 0), models.FactionGiants)
+	firstTracker.Observe(first)
+	place(first, board.NewHex(3, 2), models.FactionSwarmlings)
+	firstTracker.Observe(first)
+	place(first, board.NewHex(5, 4), models.FactionGiants)
+	firstTracker.Observe(first)
+
+	second := newSetup()
+	secondTracker := NewOpeningTracker(second, 0)
+	place(second, board.NewHex(3, 2), models.FactionSwarmlings)
+	secondTracker.Observe(second)
+	place(second, board.NewHex(5, 4), models.FactionGiants)
+	place(second, board.NewHex(0, 0), models.FactionGiants)
+	secondTracker.Observe(second)
+
+	opening := firstTracker.Opening()
+	if opening.ID == "" || opening.ID != secondTracker.Opening().ID {
+		t.Fatalf("opening IDs differ: %q vs %q", opening.ID, secondTracker.Opening().ID)
+	}
+	if len(opening.Placements) != 3 {
+		t.Fatalf("placements = %v, want 3", opening.Placements)
+	}
+	giants := opening.StartFor("Giants")
+	if giants == "" || giants == opening.StartFor("Swarmlings") || opening.StartFor("Witches") != "" {
+		t.Fatalf("unexpected starts: giants %q swarmlings %q", giants, opening.StartFor("Swarmlings"))
+	}
+
+	// Dwellings built after setup are not part of the opening.
+	first.Phase = game.PhaseAction
+	place(first, board.NewHex(1, 0), models.FactionGiants)
+	firstTracker.Observe(first)
+	if got := firstTracker.Opening().ID; got != opening.ID {
+		t.Fatalf("opening changed after setup: %q", got)
+	}
+
+	limited := NewOpeningTracker(newSetup(), 2)
+	gs := newSetup()
+	place(gs, board.NewHex(0, 0), models.FactionGiants)
+	limited.Observe(gs)
+	place(gs, board.NewHex(3, 2), models.FactionSwarmlings)
+	limited.Observe(gs)
+	place(gs, board.NewHex(5, 4), models.FactionGiants)
+	limited.Observe(gs)
+	if got := limited.Opening().Placements; len(got) != 2 {
+		t.Fatalf("limited placements = %v, want 2", got)
+	}
+
+	rates := OpeningWinRates{}
+	AddOpeningResult(rates, giants, true, false)
+	AddOpeningResult(rates, giants, false, true)
+	merged := OpeningWinRates{}
+	MergeOpeningWinRates(merged, rates)
+	MergeOpeningWinRates(merged, OpeningWinRates{giants: {Samples: 2}})
+	if got := merged[giants]; got.Samples != 4 || got.Wins != 1 || got.Draws != 1 || got.WinRate != 0.375 {
+		t.Fatalf("merged rate = %+v", got)
+	}
+}
+
+func TestOpeningTrackerNormalizesHalfTurnOfSymmetricMap(t *testing.T) {
+	newSetup := func() *game.GameState {
+		m := &board.TerraMysticaMap{Hexes: map[board.Hex]*board.MapHex{}}
+		for r, row := range [][]models.TerrainType{
+			{models.TerrainPlains, models.TerrainSwamp, models.TerrainForest},
+			{models.TerrainForest, models.TerrainSwamp, models.TerrainPlains},
+		} {
+			for q, terrain := range row {
+				hex := board.NewHex(q, r)
+				m.Hexes[hex] = &board.MapHex{Coord: hex, Terrain: terrain}
+			}
+		}
+		return &game.GameState{Phase: game.PhaseSetup, Map: m}
+	}
+	openingOf := func(giants, swarmlings board.Hex) Opening {
+		gs := newSetup()
+		tracker := NewOpeningTracker(gs, 0)
+		gs.Map.GetHex(giants).Building = &models.Building{Type: models.BuildingDwelling, Faction: models.FactionGiants}
+		gs.Map.GetHex(swarmlings).Building = &models.Building{Type: models.BuildingDwelling, Faction: models.FactionSwarmlings}
+		tracker.Observe(gs)
+		return tracker.Opening()
+	}
+
+	opening := openingOf(board.NewHex(0, 0), board.NewHex(1, 0))
+	if turned := openingOf(board.NewHex(2, 1), board.NewHex(1, 1)); turned.ID != opening.ID {
+		t.Fatalf("turned opening ID %q, want %q", turned.ID, opening.ID)
+	}
+	other := openingOf(board.NewHex(0, 0), board.NewHex(1, 1))
+	if other.ID == opening.ID {
+		t.Fatalf("expected a different opening, got %q", other.ID)
+	}
+	// The Swarmlings start on turned copies of each other; the openings around
+	// them are not, but the start is keyed the same.
+	if start, otherStart := opening.StartFor("Swarmlings"), other.StartFor("Swarmlings"); start != "Swarmlings@1,0" || otherStart != start {
+		t.Fatalf("Swarmlings starts %q and %q, want both Swarmlings@1,0", start, otherStart)
+	}
+
+	if _, ok := halfTurn(board.NewTerraMysticaMap()); ok {
+		t.Fatalf("expected the base map to have no half-turn symmetry")
+	}
+}
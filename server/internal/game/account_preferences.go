@@ -0,0 +1,136 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// AccountPreferences are a player's standing preferences, kept across their
+// games rather than in any one game's PlayerOptions.
+type AccountPreferences struct {
+	// Leech are the standing answers to leech offers; see
+	// SetLeechPreferences.
+	Leech LeechPreferences `json:"leech"`
+	// ConfirmPass and ConfirmBurn make every game of the player refuse passes
+	// and power burns sent without confirmation; see
+	// SetConfirmationPreferences.
+	ConfirmPass bool `json:"confirmPass,omitempty"`
+	ConfirmBurn bool `json:"confirmBurn,omitempty"`
+}
+
+// AccountPreferenceStore keeps the AccountPreferences of every player.
+type AccountPreferenceStore interface {
+	// Load returns the saved preferences, or none if nothing was saved yet.
+	Load() (map[string]AccountPreferences, error)
+	// Save replaces the saved preferences with prefs.
+	Save(prefs map[string]AccountPreferences) error
+}
+
+// SetAccountPreferenceStore keeps the players' AccountPreferences in store
+// across restarts. The preferences saved in it replace those in memory.
+func (m *Manager) SetAccountPreferenceStore(store AccountPreferenceStore) error {
+	var loaded map[string]AccountPreferences
+	if store != nil {
+		var err error
+		if loaded, err = store.Load(); err != nil {
+			return fmt.Errorf("failed to load account preferences: %w", err)
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.accountPreferenceStore = store
+	if store != nil {
+		m.accountPreferences = make(map[string]AccountPreferences, len(loaded))
+		for playerID, prefs := range loaded {
+			m.accountPreferences[playerID] = prefs
+		}
+	}
+	return nil
+}
+
+// SetConfirmationPreferences sets whether playerID's passes and power burns
+// need confirmation in all their games. Nil leaves a setting unchanged. It
+// returns the resulting preferences.
+func (m *Manager) SetConfirmationPreferences(playerID string, confirmPass, confirmBurn *bool) (AccountPreferences, error) {
+	m.mu.Lock()
+	account := m.accountPreferences[playerID]
+	if confirmPass != nil {
+		account.ConfirmPass = *confirmPass
+	}
+	if confirmBurn != nil {
+		account.ConfirmBurn = *confirmBurn
+	}
+	m.accountPreferences[playerID] = account
+	m.mu.Unlock()
+	return account, m.saveAccountPreferences()
+}
+
+// AccountPreferences returns playerID's standing preferences.
+func (m *Manager) AccountPreferences(playerID string) AccountPreferences {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.accountPreferences[playerID]
+}
+
+// saveAccountPreferences writes a snapshot of the preferences to the store,
+// outside the manager's lock, one save at a time.
+func (m *Manager) saveAccountPreferences() error {
+	m.accountPreferenceSaveMu.Lock()
+	defer m.accountPreferenceSaveMu.Unlock()
+
+	m.mu.RLock()
+	store := m.accountPreferenceStore
+	prefs := make(map[string]AccountPreferences, len(m.accountPreferences))
+	for playerID, p := range m.accountPreferences {
+		prefs[playerID] = p
+	}
+	m.mu.RUnlock()
+
+	if store == nil {
+		return nil
+	}
+	if err := store.Save(prefs); err != nil {
+		return fmt.Errorf("failed to save account preferences: %w", err)
+	}
+	return nil
+}
+
+// FileAccountPreferenceStore is an AccountPreferenceStore keeping the
+// preferences in one JSON file.
+type FileAccountPreferenceStore struct {
+	path string
+}
+
+// NewFileAccountPreferenceStore returns a FileAccountPreferenceStore writing
+// path. Its directory is created on the first Save.
+func NewFileAccountPreferenceStore(path string) *FileAccountPreferenceStore {
+	return &FileAccountPreferenceStore{path: path}
+}
+
+// Load reads the saved preferences, returning none if the file does not
+// exist.
+func (s *FileAccountPreferenceStore) Load() (map[string]AccountPreferences, error) {
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]AccountPreferences{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	prefs := map[string]AccountPreferences{}
+	if err := json.Unmarshal(raw, &prefs); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", s.path, err)
+	}
+	return prefs, nil
+}
+
+// Save replaces the file with prefs.
+func (s *FileAccountPreferenceStore) Save(prefs map[string]AccountPreferences) error {
+	raw, err := json.Marshal(prefs)
+	if err != nil {
+		return err
+	}
+	return replaceFile(s.path, ".account-preferences-*", raw)
+}
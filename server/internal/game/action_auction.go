@@ -20,8 +20,8 @@ func NewAuctionNominateFactionAction(playerID string, faction models.FactionType
 }
 
 func (a *AuctionNominateFactionAction) Validate(gs *GameState) error {
-	if gs.Phase != PhaseFactionSelection {
-		return fmt.Errorf("not in faction selection phase")
+	if err := gs.Phases().Require(PhaseFactionSelection, "not in faction selection phase"); err != nil {
+		return err
 	}
 	if gs.SetupMode != SetupModeAuction && gs.SetupMode != SetupModeFastAuction {
 		return fmt.Errorf("auction nomination unavailable for setup mode %s", gs.SetupMode)
@@ -30,13 +30,13 @@ func (a *AuctionNominateFactionAction) Validate(gs *GameState) error {
 		return fmt.Errorf("auction is not active")
 	}
 	if !gs.AuctionState.NominationPhase {
-		return fmt.Errorf("auction nomination phase is complete")
+		return gs.Phases().Reject("auction nomination phase is complete")
 	}
 	if !isAllowedFaction(gs, a.FactionType) {
 		return fmt.Errorf("invalid faction type: %s", a.FactionType)
 	}
 	if current := gs.GetCurrentPlayer(); current == nil || current.ID != a.PlayerID {
-		return fmt.Errorf("not your turn")
+		return ruleErrorf(ReasonNotYourTurn, "not your turn")
 	}
 	return nil
 }
@@ -68,8 +68,8 @@ func NewAuctionPlaceBidAction(playerID string, faction models.FactionType, vpRed
 }
 
 func (a *AuctionPlaceBidAction) Validate(gs *GameState) error {
-	if gs.Phase != PhaseFactionSelection {
-		return fmt.Errorf("not in faction selection phase")
+	if err := gs.Phases().Require(PhaseFactionSelection, "not in faction selection phase"); err != nil {
+		return err
 	}
 	if gs.SetupMode != SetupModeAuction {
 		return fmt.Errorf("regular auction bidding unavailable for setup mode %s", gs.SetupMode)
@@ -78,13 +78,13 @@ func (a *AuctionPlaceBidAction) Validate(gs *GameState) error {
 		return fmt.Errorf("auction is not active")
 	}
 	if gs.AuctionState.NominationPhase {
-		return fmt.Errorf("auction is still in nomination phase")
+		return gs.Phases().Reject("auction is still in nomination phase")
 	}
 	if !isAllowedFaction(gs, a.FactionType) {
 		return fmt.Errorf("invalid faction type: %s", a.FactionType)
 	}
 	if current := gs.GetCurrentPlayer(); current == nil || current.ID != a.PlayerID {
-		return fmt.Errorf("not your turn")
+		return ruleErrorf(ReasonNotYourTurn, "not your turn")
 	}
 	return nil
 }
@@ -117,8 +117,8 @@ func NewFastAuctionSubmitBidsAction(playerID string, bids map[models.FactionType
 }
 
 func (a *FastAuctionSubmitBidsAction) Validate(gs *GameState) error {
-	if gs.Phase != PhaseFactionSelection {
-		return fmt.Errorf("not in faction selection phase")
+	if err := gs.Phases().Require(PhaseFactionSelection, "not in faction selection phase"); err != nil {
+		return err
 	}
 	if gs.SetupMode != SetupModeFastAuction {
 		return fmt.Errorf("fast auction bidding unavailable for setup mode %s", gs.SetupMode)
@@ -127,7 +127,7 @@ func (a *FastAuctionSubmitBidsAction) Validate(gs *GameState) error {
 		return fmt.Errorf("auction is not active")
 	}
 	if gs.AuctionState.NominationPhase {
-		return fmt.Errorf("auction is still in nomination phase")
+		return gs.Phases().Reject("auction is still in nomination phase")
 	}
 	if len(a.Bids) == 0 {
 		return fmt.Errorf("missing fast auction bid payload")
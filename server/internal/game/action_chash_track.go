@@ -52,7 +52,7 @@ func (a *AdvanceChashTrackAction) Validate(gs *GameState) error {
 
 	cost := factions.Cost{Coins: 2, Workers: 2}
 	if !player.Resources.CanAfford(cost) {
-		return fmt.Errorf("cannot afford chash dallah income-track upgrade")
+		return ruleErrorf(ReasonInsufficientResources, "cannot afford chash dallah income-track upgrade")
 	}
 	return nil
 }
@@ -0,0 +1,135 @@
+package game
+
+import (
+	"sort"
+
+	"github.com/lukev/tm_server/internal/models"
+)
+
+// ActionConsequences are the effects of one action as the engine computed
+// them, reported to clients with the accepted action and kept with the logged
+// action for reference. Transcripts and VP progressions re-derive what they
+// show from the replayed state instead.
+type ActionConsequences struct {
+	// VP maps players to the VP the action made them gain or lose.
+	VP map[string]int `json:"vp,omitempty"`
+	// VPSource is the source VPSourceForAction gives the action as a whole.
+	VPSource VPSource `json:"vpSource"`
+	// LeechOffers are the power leech offers the action created, including
+	// those answered automatically.
+	LeechOffers []LeechOfferConsequence `json:"leechOffers,omitempty"`
+	// Towns are the towns the action formed.
+	Towns []TownConsequence `json:"towns,omitempty"`
+	// AutoLeech are the leech offers the engine answered for players during
+	// the action.
+	AutoLeech []AutoLeechDecision `json:"autoLeech,omitempty"`
+}
+
+// LeechOfferConsequence is a power leech offer made to PlayerID.
+type LeechOfferConsequence struct {
+	PlayerID     string `json:"playerId"`
+	FromPlayerID string `json:"fromPlayerId"`
+	Amount       int    `json:"amount"`
+	VPCost       int    `json:"vpCost"`
+}
+
+// TownConsequence is a town PlayerID formed, with the town tile taken.
+type TownConsequence struct {
+	PlayerID string              `json:"playerId"`
+	Tile     models.TownTileType `json:"tile"`
+}
+
+// ConsequenceTracker computes the consequences of one action from the state
+// before and after it.
+type ConsequenceTracker struct {
+	vp     map[string]int
+	towns  map[string]int
+	offers map[*PowerLeechOffer]bool
+	leech  []LeechOfferConsequence
+}
+
+// TrackConsequences starts tracking the action about to be applied to gs.
+func TrackConsequences(gs *GameState) *ConsequenceTracker {
+	t := &ConsequenceTracker{
+		vp:     map[string]int{},
+		towns:  map[string]int{},
+		offers: map[*PowerLeechOffer]bool{},
+	}
+	if gs == nil {
+		return t
+	}
+	for id, player := range gs.Players {
+		if player == nil {
+			continue
+		}
+		t.vp[id] = player.VictoryPoints
+		t.towns[id] = len(player.TownTiles)
+	}
+	t.NoteLeechOffers(gs)
+	t.leech = nil
+	return t
+}
+
+// NoteLeechOffers records the offers pending in gs that the tracker has not
+// seen yet. Call it before automatic leech decisions answer them.
+func (t *ConsequenceTracker) NoteLeechOffers(gs *GameState) {
+	if gs == nil {
+		return
+	}
+	playerIDs := make([]string, 0, len(gs.PendingLeechOffers))
+	for id := range gs.PendingLeechOffers {
+		playerIDs = append(playerIDs, id)
+	}
+	sort.Strings(playerIDs)
+	for _, id := range playerIDs {
+		for _, offer := range gs.PendingLeechOffers[id] {
+			if offer == nil || t.offers[offer] {
+				continue
+			}
+			t.offers[offer] = true
+			t.leech = append(t.leech, LeechOfferConsequence{
+				PlayerID:     id,
+				FromPlayerID: offer.FromPlayerID,
+				Amount:       offer.Amount,
+				VPCost:       offer.VPCost,
+			})
+		}
+	}
+}
+
+// Consequences returns the consequences of action, which took the game to
+// gs.
+func (t *ConsequenceTracker) Consequences(gs *GameState, action Action) *ActionConsequences {
+	t.NoteLeechOffers(gs)
+	out := &ActionConsequences{
+		VPSource:    VPSourceForAction(action),
+		LeechOffers: t.leech,
+	}
+	if gs == nil {
+		return out
+	}
+	out.AutoLeech = append([]AutoLeechDecision(nil), gs.AutoLeechDecisions...)
+	playerIDs := make([]string, 0, len(gs.Players))
+	for id := range gs.Players {
+		playerIDs = append(playerIDs, id)
+	}
+	sort.Strings(playerIDs)
+	for _, id := range playerIDs {
+		player := gs.Players[id]
+		if player == nil {
+			continue
+		}
+		if delta := player.VictoryPoints - t.vp[id]; delta != 0 {
+			if out.VP == nil {
+				out.VP = map[string]int{}
+			}
+			out.VP[id] = delta
+		}
+		if from := t.towns[id]; from < len(player.TownTiles) {
+			for _, tile := range player.TownTiles[from:] {
+				out.Towns = append(out.Towns, TownConsequence{PlayerID: id, Tile: tile})
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/factions"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+func TestConsequenceTracker_ReportsVPLeechOffersAndTowns(t *testing.T) {
+	gs := NewGameState()
+	mustAddPlayer(t, gs, "p1", factions.NewWitches())
+	mustAddPlayer(t, gs, "p2", factions.NewSwarmlings())
+	earlier := &PowerLeechOffer{Amount: 1, FromPlayerID: "p1"}
+	gs.PendingLeechOffers["p2"] = []*PowerLeechOffer{earlier}
+
+	tracker := TrackConsequences(gs)
+	gs.GetPlayer("p1").VictoryPoints += 5
+	gs.GetPlayer("p1").TownTiles = append(gs.GetPlayer("p1").TownTiles, models.TownTile5Points)
+	answered := &PowerLeechOffer{Amount: 2, VPCost: 1, FromPlayerID: "p1"}
+	gs.PendingLeechOffers["p2"] = append(gs.PendingLeechOffers["p2"], answered)
+	tracker.NoteLeechOffers(gs)
+	// An automatic decision answers the new offer before the action ends.
+	gs.PendingLeechOffers["p2"] = []*PowerLeechOffer{earlier}
+	gs.GetPlayer("p2").VictoryPoints--
+
+	got := tracker.Consequences(gs, &PassAction{BaseAction: BaseAction{Type: ActionPass, PlayerID: "p1"}})
+	if got.VPSource != VPSourcePass || got.VP["p1"] != 5 || got.VP["p2"] != -1 {
+		t.Fatalf("unexpected VP consequences %+v", got)
+	}
+	if len(got.LeechOffers) != 1 || got.LeechOffers[0] != (LeechOfferConsequence{PlayerID: "p2", FromPlayerID: "p1", Amount: 2, VPCost: 1}) {
+		t.Fatalf("expected only the new leech offer, got %+v", got.LeechOffers)
+	}
+	if len(got.Towns) != 1 || got.Towns[0] != (TownConsequence{PlayerID: "p1", Tile: models.TownTile5Points}) {
+		t.Fatalf("expected the formed town, got %+v", got.Towns)
+	}
+}
+
+func TestManager_LogsActionConsequencesWithRecordedAction(t *testing.T) {
+	mgr := NewManager()
+	if err := mgr.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	gs, _ := mgr.GetGame("g1")
+	gs.GetPlayer("p1").VictoryPoints = 0
+	record := RecordedAction{PlayerID: "p1", Type: "select_faction", Params: []byte(`{"faction":"Witches"}`)}
+	action := &SelectFactionAction{PlayerID: "p1", FactionType: models.FactionWitches}
+	result, err := mgr.ExecuteActionWithMeta("g1", action, ActionMeta{ExpectedRevision: -1, SeatID: "p1", Record: &record})
+	if err != nil {
+		t.Fatalf("select faction: %v", err)
+	}
+	startingVP := gs.GetPlayer("p1").VictoryPoints
+	if result.Consequences == nil || result.Consequences.VPSource != VPSourceActions || startingVP == 0 || result.Consequences.VP["p1"] != startingVP {
+		t.Fatalf("expected the starting VP as a consequence, got %+v", result.Consequences)
+	}
+
+	save, err := mgr.ExportGame("g1")
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if logged := save.Actions[0].Consequences; logged == nil || logged.VP["p1"] != startingVP {
+		t.Fatalf("expected the logged action to carry its consequences, got %+v", logged)
+	}
+	if record.Consequences != nil {
+		t.Fatalf("expected the caller's record left alone")
+	}
+}
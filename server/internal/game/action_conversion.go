@@ -36,122 +36,90 @@ func (a *ConversionAction) GetType() ActionType {
 	return ActionConversion
 }
 
-// Validate checks if the conversion is valid.
+// Validate checks the conversion against the conversion table.
 func (a *ConversionAction) Validate(gs *GameState) error {
+	_, _, err := a.validate(gs)
+	return err
+}
+
+func (a *ConversionAction) validate(gs *GameState) (ConversionRate, int, error) {
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
-	}
-	if a.ConversionType == ConversionCoinToPower && player.Faction.GetType() != models.FactionTheEnlightened {
-		return fmt.Errorf("coin to power conversion is only available to The Enlightened")
+		return ConversionRate{}, 0, ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 	if player.HasPassed {
-		return fmt.Errorf("player has already passed")
+		return ConversionRate{}, 0, fmt.Errorf("player has already passed")
 	}
 	if a.Amount <= 0 {
-		return fmt.Errorf("conversion amount must be positive")
+		return ConversionRate{}, 0, fmt.Errorf("conversion amount must be positive")
 	}
 	if _, ok, err := parseRiverwalkersUnlockConversion(a.ConversionType); ok {
 		if err != nil {
-			return err
+			return ConversionRate{}, 0, err
 		}
 		if !isRiverwalkers(player) {
-			return fmt.Errorf("riverwalker terrain unlock is only available to Riverwalkers")
+			return ConversionRate{}, 0, fmt.Errorf("riverwalker terrain unlock is only available to Riverwalkers")
 		}
-		return fmt.Errorf("riverwalkers terrain unlock must be resolved when gaining a priest")
+		return ConversionRate{}, 0, fmt.Errorf("riverwalkers terrain unlock must be resolved when gaining a priest")
 	}
-	if a.ConversionType == ConversionWorkerToPriest {
-		return fmt.Errorf("worker to priest conversion is only allowed through Darklings priest ordination")
+	if rate, ok := gs.ConversionRateFor(a.PlayerID, a.ConversionType); ok && rate.Ordination {
+		return rate, 0, fmt.Errorf("%s conversion is only allowed through Darklings priest ordination",
+			strings.ReplaceAll(string(a.ConversionType), "_", " "))
 	}
-	if a.ConversionType == ConversionPowerToPriest {
-		requiredCapacity := a.Amount
-		if player.Faction.GetType() == models.FactionTheEnlightened && player.HasStrongholdAbility {
-			requiredCapacity = a.Amount * 2
-		}
+	rate, trades, err := gs.validateConversion(a.PlayerID, a.ConversionType, a.Amount)
+	if err != nil {
+		return rate, 0, err
+	}
+	if requiredCapacity := rate.Gain.Priests * trades; requiredCapacity > 0 {
 		if isRiverwalkers(player) {
 			if gs.riverwalkersPriestChoiceCapacity(player) < requiredCapacity {
-				return fmt.Errorf("not enough priest capacity or terrain unlock options")
+				return rate, 0, fmt.Errorf("not enough priest capacity or terrain unlock options")
 			}
-		} else if gs.RemainingPriestCapacity(a.PlayerID) < requiredCapacity {
-			return fmt.Errorf("not enough priest capacity")
+		} else if !gs.PriestLedger(a.PlayerID).CanGain(requiredCapacity) {
+			return rate, 0, fmt.Errorf("not enough priest capacity")
 		}
 	}
-
-	return nil
+	return rate, trades, nil
 }
 
-// Execute performs the conversion without ending the turn.
-func (a *ConversionAction) Execute(gs *GameState) error {
-	if err := a.Validate(gs); err != nil {
+// ValidateFreeActionTiming checks that playerID may take a free action
+// (conversion or burn) now: during the action phase, on their own turn, in
+// the free-action window right after it, or while resolving a decision of
+// their own. Leech responses are decided out of turn, but do not open a window
+// for free actions.
+func (gs *GameState) ValidateFreeActionTiming(playerID string) error {
+	if err := gs.Phases().Require(PhaseAction, "free actions can only be taken during the action phase"); err != nil {
 		return err
 	}
-
-	player := gs.GetPlayer(a.PlayerID)
-	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+	if strings.TrimSpace(gs.PendingFreeActionsPlayerID) == playerID {
+		return nil
 	}
-
-	if _, ok, _ := parseRiverwalkersUnlockConversion(a.ConversionType); ok {
-		return fmt.Errorf("riverwalkers terrain unlock must be resolved when gaining a priest")
+	if current := gs.GetCurrentPlayer(); current != nil && current.ID == playerID {
+		return nil
 	}
-
-	switch a.ConversionType {
-	case ConversionPowerToCoin:
-		if player.Faction.GetType() == models.FactionTheEnlightened && player.HasStrongholdAbility {
-			if err := player.Resources.Power.SpendPower(a.Amount); err != nil {
-				return err
-			}
-			player.Resources.Coins += a.Amount * 2
-			return nil
-		}
-		return player.Resources.ConvertPowerToCoins(a.Amount)
-	case ConversionPowerToWorker:
-		if player.Faction.GetType() == models.FactionTheEnlightened && player.HasStrongholdAbility {
-			powerNeeded := a.Amount * 3
-			if err := player.Resources.Power.SpendPower(powerNeeded); err != nil {
-				return err
-			}
-			player.Resources.Workers += a.Amount * 2
-			return nil
-		}
-		return player.Resources.ConvertPowerToWorkers(a.Amount)
-	case ConversionPowerToPriest:
-		if player.Faction.GetType() == models.FactionTheEnlightened && player.HasStrongholdAbility {
-			powerNeeded := a.Amount * 5
-			if err := player.Resources.Power.SpendPower(powerNeeded); err != nil {
-				return err
-			}
-			gs.GainPriests(a.PlayerID, a.Amount*2)
-			return nil
-		}
-		powerNeeded := a.Amount * 5
-		if err := player.Resources.Power.SpendPower(powerNeeded); err != nil {
-			return err
-		}
-		gs.GainPriests(a.PlayerID, a.Amount)
+	if len(gs.PendingLeechOffers[playerID]) > 0 {
+		return ruleErrorf(ReasonNotYourTurn, "free actions cannot be taken while responding to a leech")
+	}
+	if gs.resolvingOwnDecision(playerID) {
 		return nil
-	case ConversionPriestToWorker:
-		if player.Faction.GetType() == models.FactionDynionGeifr {
-			if player.Resources.Priests < a.Amount {
-				return fmt.Errorf("need %d priests, only have %d", a.Amount, player.Resources.Priests)
-			}
-			player.Resources.Priests -= a.Amount
-			player.Resources.Workers += 2 * a.Amount
-			player.Resources.Coins += 2 * a.Amount
-			return nil
-		}
-		return player.Resources.ConvertPriestToWorker(a.Amount)
-	case ConversionWorkerToCoin:
-		return player.Resources.ConvertWorkerToCoin(a.Amount)
-	case ConversionCoinToPower:
-		return player.Resources.ConvertCoinToPowerTokens(a.Amount)
-	case ConversionAlchVPToCoin:
-		return gs.AlchemistsConvertVPToCoins(a.PlayerID, a.Amount)
-	case ConversionAlchCoinToVP:
-		return gs.AlchemistsConvertCoinsToVP(a.PlayerID, a.Amount)
-	default:
-		return fmt.Errorf("unsupported conversion type: %s", a.ConversionType)
 	}
+	return ruleErrorf(ReasonNotYourTurn, "free actions can only be taken on your own turn")
+}
+
+// resolvingOwnDecision reports whether the game waits on playerID alone.
+func (gs *GameState) resolvingOwnDecision(playerID string) bool {
+	active := gs.Phases().ActivePlayers()
+	return len(active) == 1 && active[0] == playerID
+}
+
+// Execute performs the conversion without ending the turn.
+func (a *ConversionAction) Execute(gs *GameState) error {
+	rate, trades, err := a.validate(gs)
+	if err != nil {
+		return err
+	}
+	gs.applyConversion(a.PlayerID, rate, trades)
+	return nil
 }
 
 func parseRiverwalkersUnlockConversion(conversionType ConversionType) (models.TerrainType, bool, error) {
@@ -175,6 +143,8 @@ func parseRiverwalkersUnlockConversion(conversionType ConversionType) (models.Te
 type BurnPowerAction struct {
 	BaseAction
 	Amount int
+	// Confirmed marks a burn the player confirmed (see PlayerOptions.ConfirmBurn).
+	Confirmed bool
 }
 
 // GetType returns the action type.
@@ -182,44 +152,32 @@ func (a *BurnPowerAction) GetType() ActionType {
 	return ActionBurnPower
 }
 
-// Validate checks if burning power is valid.
+// Validate checks burning power against the conversion table.
 func (a *BurnPowerAction) Validate(gs *GameState) error {
+	_, _, err := a.validate(gs)
+	return err
+}
+
+func (a *BurnPowerAction) validate(gs *GameState) (ConversionRate, int, error) {
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+		return ConversionRate{}, 0, ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 	if player.HasPassed {
-		return fmt.Errorf("player has already passed")
+		return ConversionRate{}, 0, fmt.Errorf("player has already passed")
 	}
 	if a.Amount <= 0 {
-		return fmt.Errorf("burn amount must be positive")
-	}
-	if player.Faction != nil && player.Faction.GetType() == models.FactionChildrenOfTheWyrm {
-		if !player.Resources.Power.CanBurnChildren(a.Amount) {
-			return fmt.Errorf("cannot burn %d power", a.Amount)
-		}
-		return nil
+		return ConversionRate{}, 0, fmt.Errorf("burn amount must be positive")
 	}
-	if !player.Resources.Power.CanBurn(a.Amount) {
-		return fmt.Errorf("cannot burn %d power", a.Amount)
-	}
-
-	return nil
+	return gs.validateConversion(a.PlayerID, ConversionBurnPower, a.Amount)
 }
 
 // Execute burns power without ending the turn.
 func (a *BurnPowerAction) Execute(gs *GameState) error {
-	if err := a.Validate(gs); err != nil {
+	rate, trades, err := a.validate(gs)
+	if err != nil {
 		return err
 	}
-
-	player := gs.GetPlayer(a.PlayerID)
-	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
-	}
-
-	if player.Faction != nil && player.Faction.GetType() == models.FactionChildrenOfTheWyrm {
-		return player.Resources.Power.BurnPowerChildren(a.Amount)
-	}
-	return player.Resources.BurnPower(a.Amount)
+	gs.applyConversion(a.PlayerID, rate, trades)
+	return nil
 }
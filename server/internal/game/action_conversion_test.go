@@ -36,3 +36,86 @@ func TestConversionAction_RejectsWorkerToPriest(t *testing.T) {
 		t.Fatalf("unexpected execute error: %v", err)
 	}
 }
+
+func TestManager_FreeActionsOnlyOnOwnTurn(t *testing.T) {
+	gs := NewGameState()
+	if err := gs.AddPlayer("actor", factions.NewCultists()); err != nil {
+		t.Fatalf("add actor: %v", err)
+	}
+	if err := gs.AddPlayer("other", factions.NewWitches()); err != nil {
+		t.Fatalf("add other: %v", err)
+	}
+	gs.TurnOrder = []string{"actor", "other"}
+	gs.CurrentPlayerIndex = 0
+	gs.Phase = PhaseAction
+	gs.GetPlayer("other").Resources.Power = NewPowerSystem(0, 4, 2)
+
+	mgr := NewManager()
+	mgr.CreateGameWithState("g1", gs)
+	burn := &BurnPowerAction{BaseAction: BaseAction{Type: ActionBurnPower, PlayerID: "other"}, Amount: 1}
+	convert := &ConversionAction{
+		BaseAction:     BaseAction{Type: ActionConversion, PlayerID: "other"},
+		ConversionType: ConversionPowerToCoin,
+		Amount:         1,
+	}
+
+	for _, action := range []Action{burn, convert} {
+		_, err := mgr.ExecuteActionWithMeta("g1", action, ActionMeta{ExpectedRevision: -1})
+		if ReasonCodeOf(err) != ReasonNotYourTurn {
+			t.Fatalf("%T out of turn: expected NOT_YOUR_TURN, got %v", action, err)
+		}
+	}
+
+	gs.PendingLeechOffers["other"] = []*PowerLeechOffer{{Amount: 1, FromPlayerID: "actor", EventID: 1}}
+	_, err := mgr.ExecuteActionWithMeta("g1", convert, ActionMeta{ExpectedRevision: -1})
+	if ReasonCodeOf(err) != ReasonNotYourTurn || !strings.Contains(err.Error(), "leech") {
+		t.Fatalf("expected a leech responder's conversion to be rejected, got %v", err)
+	}
+	gs.PendingLeechOffers["other"] = nil
+
+	gs.Phase = PhaseIncome
+	if err := gs.ValidateFreeActionTiming("actor"); ReasonCodeOf(err) != ReasonWrongPhase {
+		t.Fatalf("expected free actions outside the action phase to be WRONG_PHASE, got %v", err)
+	}
+	gs.Phase = PhaseAction
+	if err := gs.ValidateFreeActionTiming("actor"); err != nil {
+		t.Fatalf("expected the current player to take free actions, got %v", err)
+	}
+	gs.PendingFavorTileSelection = &PendingFavorTileSelection{PlayerID: "other", Count: 1}
+	if err := gs.ValidateFreeActionTiming("other"); err != nil {
+		t.Fatalf("expected a player resolving their own decision to be in turn, got %v", err)
+	}
+}
+
+func TestManager_ApplyConversionWithoutTurnCheckUsesTheActionPipeline(t *testing.T) {
+	gs := NewGameState()
+	if err := gs.AddPlayer("actor", factions.NewCultists()); err != nil {
+		t.Fatalf("add actor: %v", err)
+	}
+	if err := gs.AddPlayer("other", factions.NewWitches()); err != nil {
+		t.Fatalf("add other: %v", err)
+	}
+	gs.TurnOrder = []string{"actor", "other"}
+	gs.CurrentPlayerIndex = 0
+	gs.Phase = PhaseAction
+	other := gs.GetPlayer("other")
+	other.Resources.Power = NewPowerSystem(0, 4, 2)
+	coins := other.Resources.Coins
+
+	mgr := NewManager()
+	mgr.CreateGameWithState("g1", gs)
+	revision, err := mgr.ApplyConversionWithoutTurnCheck("g1", "other", ConversionPowerToCoin, 2)
+	if err != nil {
+		t.Fatalf("out-of-turn replay conversion: %v", err)
+	}
+	if revision != 1 || other.Resources.Coins != coins+2 {
+		t.Fatalf("expected revision 1 and 2 more coins, got revision %d and %d coins", revision, other.Resources.Coins)
+	}
+
+	if _, err := mgr.ApplyConversionWithoutTurnCheck("g1", "other", ConversionPowerToCoin, 1); err == nil {
+		t.Fatalf("expected the rules to still reject a conversion without power in bowl III")
+	}
+	if got, _ := mgr.GetRevision("g1"); got != 1 {
+		t.Fatalf("expected a rejected conversion to keep revision 1, got %d", got)
+	}
+}
@@ -43,7 +43,7 @@ func (a *UseCultSpadeAction) GetType() ActionType {
 func (a *UseCultSpadeAction) Validate(gs *GameState) error {
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 
 	// Check if player has pending cult reward spades
@@ -54,18 +54,18 @@ func (a *UseCultSpadeAction) Validate(gs *GameState) error {
 	// Check if hex exists
 	mapHex := gs.Map.GetHex(a.TargetHex)
 	if mapHex == nil {
-		return fmt.Errorf("hex does not exist: %v", a.TargetHex)
+		return ruleErrorf(ReasonInvalidHex, "hex does not exist: %v", a.TargetHex)
 	}
 
 	// Check if hex is already occupied by a building
 	if mapHex.Building != nil {
-		return fmt.Errorf("hex already has a building")
+		return ruleErrorf(ReasonTerrainOccupied, "hex already has a building")
 	}
 
 	// Check if hex is adjacent (directly or indirectly) to player's territory
 	// Note: Temporary shipping bonus does NOT apply to cult reward spades
-	if !gs.IsAdjacentToPlayerBuilding(a.TargetHex, a.PlayerID) {
-		return fmt.Errorf("hex is not adjacent to your territory (cult spades can only be used on adjacent hexes)")
+	if !gs.isAdjacentWithoutTemporaryShipping(a.TargetHex, a.PlayerID) {
+		return ruleErrorf(ReasonNotReachable, "hex is not adjacent to your territory (cult spades can only be used on adjacent hexes)")
 	}
 
 	// Check if terrain can be transformed
@@ -109,18 +109,10 @@ func (a *UseCultSpadeAction) Execute(gs *GameState) error {
 	spadesUsed := 1 // Cult reward spades are always 1 spade at a time
 
 	// Award faction-specific spade bonuses (Halflings VP, Alchemists power)
-	AwardFactionSpadeBonuses(player, spadesUsed)
-
-	// During round-start income interlude, once all pending cult-reward spades
-	// are resolved, proceed with normal income and then start the action phase.
-	if gs.Phase == PhaseIncome {
-		if _, count := gs.GetPendingCultRewardSpadePlayer(); count == 0 {
-			gs.GrantIncome()
-			if !gs.HasPendingIncomeDecisions() {
-				gs.StartActionPhase()
-			}
-		}
-	}
+	AwardFactionSpadeBonuses(gs, player, spadesUsed)
+
+	// Pass the turn on once this player is done; income follows the last one.
+	gs.advanceCultRewardSpadePhase()
 
 	return nil
 }
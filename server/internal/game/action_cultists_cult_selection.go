@@ -43,7 +43,7 @@ func (a *SelectCultistsCultTrackAction) Validate(gs *GameState) error {
 	// Verify player is Cultists
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found")
+		return ruleErrorf(ReasonPlayerNotFound, "player not found")
 	}
 
 	if player.Faction.GetType() != models.FactionCultists {
@@ -77,8 +77,11 @@ func (a *SelectCultistsCultTrackAction) Execute(gs *GameState) error {
 		return fmt.Errorf("failed to advance cult track: %w", err)
 	}
 
-	// Clear the pending state
-	gs.PendingCultistsCultSelection = nil
+	// Resolve this trigger; further queued advances keep the selection pending
+	gs.ConsumeCultistsCultSelection(a.PlayerID)
+	if gs.PendingCultistsCultSelection != nil {
+		return nil
+	}
 
 	if gs.AllPlayersPassed() && !gs.HasLateRoundPendingDecisions() {
 		advanceAfterRoundComplete(gs)
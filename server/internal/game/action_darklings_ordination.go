@@ -23,7 +23,7 @@ func (a *UseDarklingsPriestOrdinationAction) GetType() ActionType {
 func (a *UseDarklingsPriestOrdinationAction) Validate(gs *GameState) error {
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 
 	// Check if player has passed
@@ -54,13 +54,25 @@ func (a *UseDarklingsPriestOrdinationAction) Validate(gs *GameState) error {
 
 	// Check if player has enough workers
 	if player.Resources.Workers < a.WorkersToConvert {
-		return fmt.Errorf("not enough workers (have %d, need %d)",
+		return ruleErrorf(ReasonInsufficientWorkers, "not enough workers (have %d, need %d)",
 			player.Resources.Workers, a.WorkersToConvert)
 	}
 
 	return nil
 }
 
+// DarklingsOrdinationMaxWorkers returns how many workers playerID can trade
+// through priest ordination and still gain a priest for each: at most 3, no
+// more than the workers in hand, and no more than the 7-priest limit allows.
+// Trading more is legal but the extra workers are lost.
+func (gs *GameState) DarklingsOrdinationMaxWorkers(playerID string) int {
+	player := gs.GetPlayer(playerID)
+	if player == nil || player.Resources == nil {
+		return 0
+	}
+	return min(3, player.Resources.Workers, gs.PriestLedger(playerID).Gainable(3))
+}
+
 // Execute performs the action
 func (a *UseDarklingsPriestOrdinationAction) Execute(gs *GameState) error {
 	if err := a.Validate(gs); err != nil {
@@ -79,15 +91,16 @@ func (a *UseDarklingsPriestOrdinationAction) Execute(gs *GameState) error {
 		return fmt.Errorf("failed to use priest ordination: %w", err)
 	}
 
-	// Pay the workers
-	player.Resources.Workers -= a.WorkersToConvert
-
-	// Gain priests (respects 7-priest limit)
-	actualPriestsGained := gs.GainPriests(a.PlayerID, priestsGained)
-
-	// If we couldn't gain all priests due to 7-priest limit, that's okay
-	// The workers are still spent (this is the official rule)
-	_ = actualPriestsGained
+	// Pay the workers and gain priests at the conversion table's rate. Gains
+	// respect the 7-priest limit, but the workers are still spent (this is
+	// the official rule)
+	if priestsGained > 0 {
+		rate, ok := gs.ConversionRateFor(a.PlayerID, ConversionWorkerToPriest)
+		if !ok || !rate.Ordination {
+			return fmt.Errorf("no priest ordination rate for player %s", a.PlayerID)
+		}
+		gs.applyConversion(a.PlayerID, rate, priestsGained)
+	}
 
 	// Clear pending state
 	gs.PendingDarklingsPriestOrdination = nil
@@ -70,14 +70,7 @@ func (a *DiscardPendingSpadeAction) Execute(gs *GameState) error {
 		if gs.PendingCultRewardSpades[a.PlayerID] <= 0 {
 			delete(gs.PendingCultRewardSpades, a.PlayerID)
 		}
-		if gs.Phase == PhaseIncome {
-			if _, count := gs.GetPendingCultRewardSpadePlayer(); count == 0 {
-				gs.GrantIncome()
-				if !gs.HasPendingIncomeDecisions() {
-					gs.StartActionPhase()
-				}
-			}
-		}
+		gs.advanceCultRewardSpadePhase()
 		return nil
 	}
 
@@ -3,7 +3,9 @@ package game
 import (
 	"testing"
 
+	"github.com/lukev/tm_server/internal/game/board"
 	"github.com/lukev/tm_server/internal/game/factions"
+	"github.com/lukev/tm_server/internal/models"
 )
 
 func TestDiscardPendingSpadeAction_Execute(t *testing.T) {
@@ -74,3 +76,137 @@ func TestDiscardPendingSpadeAction_ExecuteCultRewardDuringIncome(t *testing.T) {
 		t.Fatalf("expected phase to advance to action after resolving income cult spades, got %d", gs.Phase)
 	}
 }
+
+func TestPendingSpadeTargets_IncludesPartialTransforms(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewHalflings() // Plains
+	if err := gs.AddPlayer("p1", faction); err != nil {
+		t.Fatalf("failed to add p1: %v", err)
+	}
+	player := gs.GetPlayer("p1")
+	player.Resources.Workers = 0
+	gs.Map.GetHex(board.NewHex(1, 1)).Building = &models.Building{
+		Type:       models.BuildingDwelling,
+		Faction:    faction.GetType(),
+		PlayerID:   "p1",
+		PowerValue: 1,
+	}
+	gs.Map.TransformTerrain(board.NewHex(1, 0), models.TerrainSwamp) // 1 spade from Plains
+	gs.Map.TransformTerrain(board.NewHex(2, 1), models.TerrainLake)  // 2 spades from Plains
+	gs.PendingSpades["p1"] = 1
+
+	targets := make(map[board.Hex][]models.TerrainType)
+	for _, target := range gs.PendingSpadeTargets("p1") {
+		targets[target.Hex] = target.Terrains
+	}
+	if !containsTerrain(targets[board.NewHex(1, 0)], models.TerrainPlains) {
+		t.Fatalf("expected (1,0) to be transformable to Plains, got %v", targets[board.NewHex(1, 0)])
+	}
+	lake := targets[board.NewHex(2, 1)]
+	if containsTerrain(lake, models.TerrainPlains) {
+		t.Fatalf("expected (2,1) not to reach Plains without workers, got %v", lake)
+	}
+	if !containsTerrain(lake, models.TerrainSwamp) {
+		t.Fatalf("expected (2,1) to allow a partial transform to Swamp, got %v", lake)
+	}
+	if gs.Map.GetHex(board.NewHex(8, 4)) == nil {
+		t.Fatalf("expected 8,4 to be on the map")
+	}
+	if _, ok := targets[board.NewHex(8, 4)]; ok {
+		t.Fatalf("expected non-adjacent hex to be excluded")
+	}
+
+	gs.TurnOrder = []string{"p1"}
+	decision, _ := serializePendingDecision(gs).(map[string]interface{})
+	if decision["type"] != "spade_followup" {
+		t.Fatalf("expected spade_followup decision, got %v", decision["type"])
+	}
+	if got := len(decision["targets"].([]SpadeTarget)); got != len(targets) {
+		t.Fatalf("expected %d serialized targets, got %d", len(targets), got)
+	}
+}
+
+func TestPendingSpadeFollowup_DarklingsPayPriestsOnlyForExtraSpades(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewDarklings() // Swamp
+	if err := gs.AddPlayer("p1", faction); err != nil {
+		t.Fatalf("failed to add p1: %v", err)
+	}
+	player := gs.GetPlayer("p1")
+	player.Resources.Priests = 1
+	gs.Map.GetHex(board.NewHex(1, 1)).Building = &models.Building{
+		Type:       models.BuildingDwelling,
+		Faction:    faction.GetType(),
+		PlayerID:   "p1",
+		PowerValue: 1,
+	}
+	target := board.NewHex(1, 0)
+	gs.Map.TransformTerrain(target, models.TerrainForest) // 2 spades from Swamp
+	gs.PendingSpades["p1"] = 1
+	vpBefore := player.VictoryPoints
+
+	action := NewTransformAndBuildAction("p1", target, false, models.TerrainTypeUnknown)
+	if err := action.Execute(gs); err != nil {
+		t.Fatalf("follow-up transform should succeed: %v", err)
+	}
+	if gs.Map.GetHex(target).Terrain != models.TerrainSwamp {
+		t.Fatalf("expected target to become Swamp, got %v", gs.Map.GetHex(target).Terrain)
+	}
+	if player.Resources.Priests != 0 {
+		t.Fatalf("expected one priest for the extra spade, have %d left", player.Resources.Priests)
+	}
+	if got := player.VictoryPoints - vpBefore; got != 2 {
+		t.Fatalf("expected +2 VP for the priest-paid spade only, got %d", got)
+	}
+	if gs.PendingSpades["p1"] != 0 {
+		t.Fatalf("expected pending spade to be consumed")
+	}
+}
+
+func containsTerrain(terrains []models.TerrainType, want models.TerrainType) bool {
+	for _, terrain := range terrains {
+		if terrain == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestManagerSpadeTargets_EnumeratesOncePerRevision(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewHalflings()
+	mustAddPlayer(t, gs, "p1", faction)
+	gs.Map.GetHex(board.NewHex(1, 1)).Building = &models.Building{
+		Type:       models.BuildingDwelling,
+		Faction:    faction.GetType(),
+		PlayerID:   "p1",
+		PowerValue: 1,
+	}
+	gs.Phase = PhaseAction
+	gs.TurnOrder = []string{"p1"}
+	gs.PendingSpades["p1"] = 1
+	mgr := NewManager()
+	mgr.CreateGameWithState("g1", gs)
+
+	targetCount := func() int {
+		decision, _ := mgr.SerializeGameState("g1")["pendingDecision"].(map[string]interface{})
+		return len(decision["targets"].([]interface{}))
+	}
+	want := len(gs.PendingSpadeTargets("p1"))
+	if want == 0 || targetCount() != want {
+		t.Fatalf("expected %d serialized targets", want)
+	}
+
+	// A second broadcast at the same revision reuses the cached list.
+	cached := mgr.spadeTargets["g1"]
+	cached.targets = cached.targets[:1]
+	mgr.spadeTargets["g1"] = cached
+	if got := targetCount(); got != 1 {
+		t.Fatalf("expected the cached targets to be served, got %d", got)
+	}
+
+	mgr.revisions["g1"]++
+	if got := targetCount(); got != want {
+		t.Fatalf("expected targets to be enumerated again at a new revision, got %d want %d", got, want)
+	}
+}
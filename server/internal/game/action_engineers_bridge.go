@@ -47,10 +47,10 @@ func (a *EngineersBridgeAction) Validate(gs *GameState) error {
 	}
 	if player.Faction.GetType() == models.FactionArchitects {
 		if player.Resources.Priests < architectsBridgePriestCost {
-			return fmt.Errorf("not enough priests: need %d, have %d", architectsBridgePriestCost, player.Resources.Priests)
+			return ruleErrorf(ReasonInsufficientPriests, "not enough priests: need %d, have %d", architectsBridgePriestCost, player.Resources.Priests)
 		}
 	} else if player.Resources.Workers < engineersBridgeWorkerCost {
-		return fmt.Errorf("not enough workers: need %d, have %d", engineersBridgeWorkerCost, player.Resources.Workers)
+		return ruleErrorf(ReasonInsufficientWorkers, "not enough workers: need %d, have %d", engineersBridgeWorkerCost, player.Resources.Workers)
 	}
 	if player.BridgesBuilt >= 3 {
 		return fmt.Errorf("player has already built 3 bridges (maximum)")
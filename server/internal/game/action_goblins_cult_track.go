@@ -35,7 +35,7 @@ func (a *SelectGoblinsCultTrackAction) Validate(gs *GameState) error {
 
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found")
+		return ruleErrorf(ReasonPlayerNotFound, "player not found")
 	}
 	if player.Faction == nil || player.Faction.GetType() != models.FactionGoblins {
 		return fmt.Errorf("only Goblins can resolve goblins cult steps")
@@ -37,7 +37,7 @@ func (a *UseGoblinsTreasureAction) GetType() ActionType {
 func (a *UseGoblinsTreasureAction) Validate(gs *GameState) error {
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found")
+		return ruleErrorf(ReasonPlayerNotFound, "player not found")
 	}
 	if player.Faction == nil || player.Faction.GetType() != models.FactionGoblins {
 		return fmt.Errorf("only Goblins can use treasure actions")
@@ -86,7 +86,7 @@ func (a *UseGoblinsTreasureAction) Execute(gs *GameState) error {
 
 	switch a.RewardType {
 	case GoblinsTreasureDwellings:
-		player.Resources.GainPower(dwellingCount)
+		gs.GainPowerForReason(a.PlayerID, dwellingCount, PowerGainGoblinsTreasure, "")
 	case GoblinsTreasureTradingPosts:
 		player.Resources.Coins += tradingPostCount * 2
 	case GoblinsTreasureTemples:
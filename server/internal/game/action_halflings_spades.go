@@ -35,7 +35,7 @@ func (a *ApplyHalflingsSpadeAction) GetSpadesNeeded(gs *GameState) int {
 func (a *ApplyHalflingsSpadeAction) Validate(gs *GameState) error {
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 
 	// Check if player has passed
@@ -67,7 +67,7 @@ func (a *ApplyHalflingsSpadeAction) Validate(gs *GameState) error {
 
 	// Check if hex already has a building
 	if targetHex.Building != nil {
-		return fmt.Errorf("hex already has a building")
+		return ruleErrorf(ReasonTerrainOccupied, "hex already has a building")
 	}
 
 	// Check if terrain can be transformed
@@ -118,13 +118,12 @@ func (a *ApplyHalflingsSpadeAction) Execute(gs *GameState) error {
 		return fmt.Errorf("failed to transform terrain: %w", err)
 	}
 
-	// Award VP for each spade used (Halflings get +1 VP per spade)
-	player.VictoryPoints += spadesNeeded
-
-	// Award VP from scoring tile for each spade (if applicable)
+	// Award VP from scoring tile for each spade (if applicable), and the
+	// Halflings +1 VP per spade
 	for i := 0; i < spadesNeeded; i++ {
 		gs.AwardActionVP(a.PlayerID, ScoringActionSpades)
 	}
+	AwardFactionSpadeBonuses(gs, player, spadesNeeded)
 
 	// Update pending spades - decrement by actual spades used
 	gs.PendingHalflingsSpades.SpadesRemaining -= spadesNeeded
@@ -156,7 +155,7 @@ func (a *BuildHalflingsDwellingAction) GetType() ActionType {
 func (a *BuildHalflingsDwellingAction) Validate(gs *GameState) error {
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 
 	// Check if player has passed
@@ -203,13 +202,16 @@ func (a *BuildHalflingsDwellingAction) Validate(gs *GameState) error {
 		return fmt.Errorf("invalid hex: %v", a.TargetHex)
 	}
 	if targetHex.Building != nil {
-		return fmt.Errorf("hex already has a building")
+		return ruleErrorf(ReasonTerrainOccupied, "hex already has a building")
+	}
+	if err := gs.CheckBuildingLimit(a.PlayerID, models.BuildingDwelling); err != nil {
+		return err
 	}
 
 	// Check if player can afford dwelling
 	cost := getDwellingBuildCost(gs, player, a.TargetHex)
 	if !player.Resources.CanAfford(cost) {
-		return fmt.Errorf("cannot afford dwelling")
+		return ruleErrorf(ReasonInsufficientResources, "cannot afford dwelling")
 	}
 
 	return nil
@@ -229,38 +231,40 @@ func (a *BuildHalflingsDwellingAction) Execute(gs *GameState) error {
 		return fmt.Errorf("failed to pay for dwelling: %w", err)
 	}
 
-	// Place dwelling
-	dwelling := &models.Building{
-		Type:       models.BuildingDwelling,
-		Faction:    player.Faction.GetType(),
-		PlayerID:   a.PlayerID,
-		PowerValue: 1,
-	}
-	if err := gs.Map.PlaceBuilding(a.TargetHex, dwelling); err != nil {
+	// Place dwelling: favor tile and scoring tile VP, power leech and town
+	// formation as for any other dwelling
+	if err := gs.BuildDwelling(a.PlayerID, a.TargetHex); err != nil {
 		return fmt.Errorf("failed to place building: %w", err)
 	}
 
-	// Award VP from Earth+1 favor tile (+2 VP when building Dwelling)
-	playerTiles := gs.FavorTiles.GetPlayerTiles(a.PlayerID)
-	if HasFavorTile(playerTiles, FavorEarth1) {
-		player.VictoryPoints += 2
-	}
-
-	// Award VP from scoring tile
-	gs.AwardActionVP(a.PlayerID, ScoringActionDwelling)
-
-	// Trigger power leech for adjacent players
-	gs.TriggerPowerLeech(a.TargetHex, a.PlayerID)
-
-	// Check for town formation
-	gs.CheckForTownFormation(a.PlayerID, a.TargetHex)
-
 	// Clear pending Halflings spades
 	gs.PendingHalflingsSpades = nil
 
+	gs.NextTurn()
 	return nil
 }
 
+// halflingsDwellingHexes lists the hexes transformed by the pending Halflings
+// stronghold spades on which its player may build the optional dwelling. It
+// is empty until all spades are applied.
+func (gs *GameState) halflingsDwellingHexes() []HexCoord {
+	pending := gs.PendingHalflingsSpades
+	if pending == nil || pending.SpadesRemaining > 0 {
+		return []HexCoord{}
+	}
+	hexes := make([]HexCoord, 0, len(pending.TransformedHexes))
+	for _, hex := range pending.TransformedHexes {
+		build := &BuildHalflingsDwellingAction{
+			BaseAction: BaseAction{Type: ActionBuildHalflingsDwelling, PlayerID: pending.PlayerID},
+			TargetHex:  hex,
+		}
+		if build.Validate(gs) == nil {
+			hexes = append(hexes, HexCoord{Q: hex.Q, R: hex.R})
+		}
+	}
+	return hexes
+}
+
 // SkipHalflingsDwellingAction represents choosing not to build the optional dwelling
 type SkipHalflingsDwellingAction struct {
 	BaseAction
@@ -275,7 +279,7 @@ func (a *SkipHalflingsDwellingAction) GetType() ActionType {
 func (a *SkipHalflingsDwellingAction) Validate(gs *GameState) error {
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 
 	// Check if there's a pending Halflings spades application
@@ -306,5 +310,6 @@ func (a *SkipHalflingsDwellingAction) Execute(gs *GameState) error {
 	// Clear pending Halflings spades
 	gs.PendingHalflingsSpades = nil
 
+	gs.NextTurn()
 	return nil
 }
@@ -0,0 +1,126 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/game/factions"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+func TestManager_HalflingsStrongholdSpadesFlow(t *testing.T) {
+	gs := NewGameState()
+	if err := gs.AddPlayer("halflings", factions.NewHalflings()); err != nil {
+		t.Fatalf("add halflings: %v", err)
+	}
+	if err := gs.AddPlayer("next", factions.NewNomads()); err != nil {
+		t.Fatalf("add next: %v", err)
+	}
+	gs.TurnOrder = []string{"halflings", "next"}
+	gs.CurrentPlayerIndex = 0
+	gs.Phase = PhaseAction
+	gs.Round = 1
+	gs.ScoringTiles = &ScoringTileState{Tiles: []ScoringTile{
+		{Type: ScoringSpades, ActionType: ScoringActionSpades, ActionVP: 2},
+	}}
+	gs.FavorTiles.TakeFavorTile("halflings", FavorEarth1)
+
+	player := gs.GetPlayer("halflings")
+	player.Resources.Coins = 20
+	player.Resources.Workers = 10
+
+	tradingHouse := board.NewHex(0, 1)
+	gs.Map.TransformTerrain(tradingHouse, models.TerrainPlains)
+	gs.Map.PlaceBuilding(tradingHouse, &models.Building{
+		Type: models.BuildingTradingHouse, Faction: models.FactionHalflings, PlayerID: "halflings", PowerValue: 2,
+	})
+	oneSpade := board.NewHex(1, 1)
+	twoSpades := board.NewHex(2, 1)
+	gs.Map.TransformTerrain(oneSpade, models.TerrainSwamp)
+	gs.Map.TransformTerrain(twoSpades, models.TerrainLake)
+
+	mgr := NewManager()
+	mgr.CreateGameWithState("g1", gs)
+	revision := 0
+	execute := func(action Action) {
+		t.Helper()
+		if _, err := mgr.ExecuteActionWithMeta("g1", action, ActionMeta{ExpectedRevision: revision}); err != nil {
+			t.Fatalf("%T: %v", action, err)
+		}
+		revision++
+	}
+
+	execute(NewUpgradeBuildingAction("halflings", tradingHouse, models.BuildingStronghold))
+	decision, err := DecodePendingDecision(mustMarshalPendingDecision(t, gs))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	spades, ok := decision.(HalflingsSpadesDecision)
+	if !ok || spades.PlayerID != "halflings" || spades.SpadesRemaining != 3 || len(spades.DwellingHexes) != 0 {
+		t.Fatalf("decision after stronghold = %+v, want 3 halflings spades", decision)
+	}
+	if !hasSpadeTarget(spades.Targets, oneSpade, models.TerrainPlains) || !hasSpadeTarget(spades.Targets, twoSpades, models.TerrainPlains) {
+		t.Fatalf("targets %+v do not offer %v and %v as plains", spades.Targets, oneSpade, twoSpades)
+	}
+
+	vp := player.VictoryPoints
+	for _, hex := range []board.Hex{oneSpade, twoSpades} {
+		execute(&ApplyHalflingsSpadeAction{
+			BaseAction:    BaseAction{Type: ActionApplyHalflingsSpade, PlayerID: "halflings"},
+			TargetHex:     hex,
+			TargetTerrain: models.TerrainPlains,
+		})
+	}
+	// 3 spades at 2 VP from the scoring tile plus 1 VP from the Halflings.
+	if player.VictoryPoints != vp+9 {
+		t.Fatalf("VP after spades = %d, want %d", player.VictoryPoints, vp+9)
+	}
+
+	decision, err = DecodePendingDecision(mustMarshalPendingDecision(t, gs))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	spades, ok = decision.(HalflingsSpadesDecision)
+	if !ok || spades.SpadesRemaining != 0 || len(spades.Targets) != 0 || len(spades.DwellingHexes) != 2 {
+		t.Fatalf("decision after spades = %+v, want both transformed hexes open to a dwelling", decision)
+	}
+
+	vp = player.VictoryPoints
+	execute(&BuildHalflingsDwellingAction{
+		BaseAction: BaseAction{Type: ActionBuildHalflingsDwelling, PlayerID: "halflings"},
+		TargetHex:  twoSpades,
+	})
+	if player.VictoryPoints != vp+gs.FavorModifiers("halflings").DwellingVP {
+		t.Fatalf("VP after dwelling = %d, want the Earth +1 favor VP on top of %d", player.VictoryPoints, vp)
+	}
+	if gs.PendingHalflingsSpades != nil {
+		t.Fatal("expected the dwelling to end the stronghold spades")
+	}
+	if current := gs.GetCurrentPlayer(); current == nil || current.ID != "next" {
+		t.Fatalf("current player = %v, want next", current)
+	}
+}
+
+func mustMarshalPendingDecision(t *testing.T, gs *GameState) []byte {
+	t.Helper()
+	raw, err := json.Marshal(serializePendingDecision(gs))
+	if err != nil {
+		t.Fatalf("marshal pending decision: %v", err)
+	}
+	return raw
+}
+
+func hasSpadeTarget(targets []SpadeTarget, hex board.Hex, terrain models.TerrainType) bool {
+	for _, target := range targets {
+		if target.Q != hex.Q || target.R != hex.R {
+			continue
+		}
+		for _, t := range target.Terrains {
+			if t == int(terrain) {
+				return true
+			}
+		}
+	}
+	return false
+}
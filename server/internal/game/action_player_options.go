@@ -10,6 +10,10 @@ type SetPlayerOptionsAction struct {
 	AutoConvertOnPass *bool
 	ConfirmActions    *bool
 	ShowIncomePreview *bool
+	AutoPassWhenStuck *bool
+	LeechPreferences  *LeechPreferences
+	ConfirmPass       *bool
+	ConfirmBurn       *bool
 }
 
 func NewSetPlayerOptionsAction(
@@ -18,6 +22,7 @@ func NewSetPlayerOptionsAction(
 	autoConvertOnPass *bool,
 	confirmActions *bool,
 	showIncomePreview *bool,
+	autoPassWhenStuck *bool,
 ) *SetPlayerOptionsAction {
 	return &SetPlayerOptionsAction{
 		BaseAction: BaseAction{
@@ -28,17 +33,21 @@ func NewSetPlayerOptionsAction(
 		AutoConvertOnPass: autoConvertOnPass,
 		ConfirmActions:    confirmActions,
 		ShowIncomePreview: showIncomePreview,
+		AutoPassWhenStuck: autoPassWhenStuck,
 	}
 }
 
 func (a *SetPlayerOptionsAction) Validate(gs *GameState) error {
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found")
+		return ruleErrorf(ReasonPlayerNotFound, "player not found")
 	}
 	if a.AutoLeechMode != nil && !a.AutoLeechMode.IsValid() {
 		return fmt.Errorf("invalid auto leech mode: %s", string(*a.AutoLeechMode))
 	}
+	if a.LeechPreferences != nil {
+		return a.LeechPreferences.Validate()
+	}
 	return nil
 }
 
@@ -48,7 +57,7 @@ func (a *SetPlayerOptionsAction) Execute(gs *GameState) error {
 	}
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found")
+		return ruleErrorf(ReasonPlayerNotFound, "player not found")
 	}
 	if a.AutoLeechMode != nil {
 		player.Options.AutoLeechMode = *a.AutoLeechMode
@@ -62,5 +71,43 @@ func (a *SetPlayerOptionsAction) Execute(gs *GameState) error {
 	if a.ShowIncomePreview != nil {
 		player.Options.ShowIncomePreview = *a.ShowIncomePreview
 	}
+	if a.AutoPassWhenStuck != nil {
+		player.Options.AutoPassWhenStuck = *a.AutoPassWhenStuck
+	}
+	if a.LeechPreferences != nil {
+		player.Options.LeechPreferences = *a.LeechPreferences
+	}
+	if a.ConfirmPass != nil {
+		player.Options.ConfirmPass = *a.ConfirmPass
+	}
+	if a.ConfirmBurn != nil {
+		player.Options.ConfirmBurn = *a.ConfirmBurn
+	}
+	return nil
+}
+
+// requireSeatConfirmation refuses a pass or power burn submitted by a seat
+// without the confirmation the player's game options or account preferences
+// ask for. Power actions that burn power to pay for themselves count as
+// burns. Automatic passes carry no seat and are not checked.
+func requireSeatConfirmation(gs *GameState, action Action, account AccountPreferences) error {
+	player := gs.GetPlayer(action.GetPlayerID())
+	if player == nil {
+		return nil
+	}
+	switch a := action.(type) {
+	case *PassAction:
+		if (player.Options.ConfirmPass || account.ConfirmPass) && !a.Confirmed {
+			return ruleErrorf(ReasonConfirmationRequired, "passing needs confirmation")
+		}
+	case *BurnPowerAction:
+		if (player.Options.ConfirmBurn || account.ConfirmBurn) && !a.Confirmed {
+			return ruleErrorf(ReasonConfirmationRequired, "burning power needs confirmation")
+		}
+	case *PowerAction:
+		if (player.Options.ConfirmBurn || account.ConfirmBurn) && !a.Confirmed && a.requiredAutoBurn(player) > 0 {
+			return ruleErrorf(ReasonConfirmationRequired, "this power action burns power and needs confirmation")
+		}
+	}
 	return nil
 }
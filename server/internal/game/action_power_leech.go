@@ -32,7 +32,10 @@ func NewAcceptPowerLeechAmountAction(playerID string, offerIndex int, amount int
 
 // Validate checks if the action is valid
 func (a *AcceptPowerLeechAction) Validate(gs *GameState) error {
-	return validatePowerLeechOffer(gs, a.PlayerID, a.OfferIndex)
+	if err := validatePowerLeechOffer(gs, a.PlayerID, a.OfferIndex); err != nil {
+		return err
+	}
+	return validatePowerLeechAcceptance(gs, a.PlayerID, a.OfferIndex, a.Amount)
 }
 
 // Execute performs the action
@@ -70,7 +73,7 @@ func (a *DeclinePowerLeechAction) Execute(gs *GameState) error {
 func validatePowerLeechOffer(gs *GameState, playerID string, offerIndex int) error {
 	player := gs.GetPlayer(playerID)
 	if player == nil {
-		return fmt.Errorf("player not found")
+		return ruleErrorf(ReasonPlayerNotFound, "player not found")
 	}
 
 	offers := gs.PendingLeechOffers[playerID]
@@ -85,6 +88,42 @@ func validatePowerLeechOffer(gs *GameState, playerID string, offerIndex int) err
 	return nil
 }
 
+// validatePowerLeechAcceptance checks that accepting the offer (or a partial
+// amount of it) is within the offer and does not take the player below 0 VP.
+func validatePowerLeechAcceptance(gs *GameState, playerID string, offerIndex int, amount int) error {
+	player := gs.GetPlayer(playerID)
+	offer := gs.PendingLeechOffers[playerID][offerIndex]
+	if offer == nil || player.Resources == nil || player.Resources.Power == nil {
+		return nil
+	}
+	if amount > offer.Amount {
+		return fmt.Errorf("cannot accept %d power from offer of %d", amount, offer.Amount)
+	}
+	requested := offer.Amount
+	if amount > 0 {
+		requested = amount
+	}
+	vpCost := leechVPCost(player, requested)
+	if vpCost > player.VictoryPoints {
+		return fmt.Errorf("cannot accept %d power: costs %d VP but player has %d VP", requested, vpCost, player.VictoryPoints)
+	}
+	return nil
+}
+
+// leechVPCost returns the VP the player would pay to gain up to amount power
+// given their current bowl capacity.
+func leechVPCost(player *Player, amount int) int {
+	gained := amount
+	if capacity := player.Resources.Power.LeechCapacity(); gained > capacity {
+		gained = capacity
+	}
+	vpCost := maxInt(0, gained-1)
+	if player.Faction != nil && player.Faction.GetType() == models.FactionChildrenOfTheWyrm && vpCost > 0 {
+		vpCost--
+	}
+	return vpCost
+}
+
 func executePowerLeechOffer(gs *GameState, playerID string, offerIndex int, accepted bool) error {
 	return executePowerLeechOfferAmount(gs, playerID, offerIndex, accepted, 0)
 }
@@ -99,17 +138,20 @@ func executePowerLeechOfferAmount(gs *GameState, playerID string, offerIndex int
 	offers := gs.PendingLeechOffers[playerID]
 	offer := offers[offerIndex]
 	var remainder *PowerLeechOffer
-	if accepted && amount > 0 && offer != nil {
-		if amount > offer.Amount {
-			return fmt.Errorf("cannot accept %d power from offer of %d", amount, offer.Amount)
+	if accepted {
+		if err := validatePowerLeechAcceptance(gs, playerID, offerIndex, amount); err != nil {
+			return err
 		}
+	}
+	if accepted && amount > 0 && offer != nil {
 		if amount < offer.Amount {
 			partial := *offer
 			partial.Amount = amount
-			partial.VPCost = maxInt(0, amount-1)
+			partial.CappedAmount = minInt(amount, offer.CappedAmount)
+			partial.VPCost = leechVPCost(player, amount)
 			remaining := *offer
 			remaining.Amount -= amount
-			remaining.VPCost = maxInt(0, remaining.Amount-1)
+			remaining.CappedAmount = minInt(remaining.Amount, offer.CappedAmount-partial.CappedAmount)
 			offers[offerIndex] = &partial
 			offer = offers[offerIndex]
 			remainder = &remaining
@@ -127,7 +169,7 @@ func executePowerLeechOfferAmount(gs *GameState, playerID string, offerIndex int
 	}
 
 	if accepted {
-		vpCost := player.Resources.AcceptPowerLeech(offer)
+		vpCost := gs.AcceptPowerLeech(playerID, offer)
 		if player.Faction != nil && player.Faction.GetType() == models.FactionChildrenOfTheWyrm && vpCost > 0 {
 			vpCost--
 		}
@@ -137,32 +179,13 @@ func executePowerLeechOfferAmount(gs *GameState, playerID string, offerIndex int
 	}
 
 	// Track for Cultists ability (if the building player is Cultists)
-	if offer != nil {
-		if bonus, exists := gs.PendingCultistsLeech[offer.EventID]; exists && bonus != nil && bonus.PlayerID == offer.FromPlayerID {
-			bonus.ResolvedCount++
-			if potentialGain > 0 {
-				if accepted {
-					bonus.AcceptedCount++
-				} else {
-					bonus.DeclinedCount++
-				}
-			}
-		}
-		if bonus, exists := gs.PendingShapeshiftersLeech[offer.EventID]; exists && bonus != nil && bonus.PlayerID == offer.FromPlayerID {
-			bonus.ResolvedCount++
-			if potentialGain > 0 {
-				if accepted {
-					bonus.AcceptedCount++
-				} else {
-					bonus.DeclinedCount++
-				}
-			}
-		}
-	}
+	gs.recordLeechResponse(offer, potentialGain, accepted)
 
 	// Remove the accepted/declined offer, preserving any unaccepted remainder.
 	updatedOffers := append(offers[:offerIndex], offers[offerIndex+1:]...)
 	if remainder != nil {
+		// Priced after the accepted part filled the bowls.
+		remainder.VPCost = leechVPCost(player, remainder.Amount)
 		updatedOffers = append(updatedOffers[:offerIndex], append([]*PowerLeechOffer{remainder}, updatedOffers[offerIndex:]...)...)
 	}
 	gs.PendingLeechOffers[playerID] = updatedOffers
@@ -185,6 +208,29 @@ func executePowerLeechOfferAmount(gs *GameState, playerID string, offerIndex int
 	return nil
 }
 
+// recordLeechResponse counts a response to offer toward the Cultists or
+// Shapeshifters bonus of its leech event. Only responses from players who
+// could have gained power count as accepted or declined.
+func (gs *GameState) recordLeechResponse(offer *PowerLeechOffer, potentialGain int, accepted bool) {
+	if offer == nil {
+		return
+	}
+	for _, pending := range []map[int]*CultistsLeechBonus{gs.PendingCultistsLeech, gs.PendingShapeshiftersLeech} {
+		bonus, exists := pending[offer.EventID]
+		if !exists || bonus == nil || bonus.PlayerID != offer.FromPlayerID {
+			continue
+		}
+		bonus.ResolvedCount++
+		if potentialGain > 0 {
+			if accepted {
+				bonus.AcceptedCount++
+			} else {
+				bonus.DeclinedCount++
+			}
+		}
+	}
+}
+
 func (gs *GameState) ResolveShapeshiftersLeechBonus(eventID int) {
 	bonus, exists := gs.PendingShapeshiftersLeech[eventID]
 	if !exists {
@@ -196,7 +242,7 @@ func (gs *GameState) ResolveShapeshiftersLeechBonus(eventID int) {
 	delete(gs.PendingShapeshiftersLeech, eventID)
 
 	player := gs.GetPlayer(bonus.PlayerID)
-	if !isShapeshifters(player) {
+	if !isShapeshifters(player) || player.Resigned {
 		return
 	}
 	if gs.ReplayMode != nil && gs.ReplayMode["__bga__"] {
@@ -209,11 +255,11 @@ func (gs *GameState) ResolveShapeshiftersLeechBonus(eventID int) {
 		// UI choice is not modeled yet; take the generally beneficial errata option when affordable.
 		if player.VictoryPoints > 0 {
 			player.VictoryPoints--
-			player.Resources.Power.Bowl3++
+			gs.GainPowerTokensForReason(bonus.PlayerID, 3, 1, PowerGainShapeshiftersLeech, "")
 		}
 		return
 	}
-	player.Resources.GainPower(1)
+	gs.GainPowerForReason(bonus.PlayerID, 1, PowerGainShapeshiftersLeech, "")
 }
 
 // ResolveCultistsLeechBonus checks if all leech offers for a Cultists player are resolved
@@ -233,7 +279,7 @@ func (gs *GameState) ResolveCultistsLeechBonus(eventID int) {
 	// All offers resolved - apply Cultists bonus
 	cultistsPlayerID := bonus.PlayerID
 	player := gs.GetPlayer(cultistsPlayerID)
-	if player == nil {
+	if player == nil || player.Resigned {
 		delete(gs.PendingCultistsLeech, eventID)
 		return
 	}
@@ -253,17 +299,42 @@ func (gs *GameState) ResolveCultistsLeechBonus(eventID int) {
 	if bonus.AcceptedCount > 0 {
 		// At least one opponent accepted power - Cultists must choose a cult track to advance
 		// Cultists advance 1 space on cult track (if at least one opponent takes power)
-		// Create pending cult track selection
-		gs.PendingCultistsCultSelection = &PendingCultistsCultSelection{
-			PlayerID: cultistsPlayerID,
-		}
+		// Queue a cult track selection for this event behind any still pending
+		gs.queueCultistsCultSelection(cultistsPlayerID, eventID)
 	} else {
 		// All opponents declined - gain 1 power
 		// Cultists gain 1 power if all opponents refuse power
 		powerBonus := 1
-		player.Resources.GainPower(powerBonus)
+		gs.GainPowerForReason(cultistsPlayerID, powerBonus, PowerGainCultistsLeech, "")
 	}
 
 	// Clean up
 	delete(gs.PendingCultistsLeech, eventID)
 }
+
+// queueCultistsCultSelection adds a cult advance owed for eventID to the
+// Cultists' pending selection, creating it if none is pending.
+func (gs *GameState) queueCultistsCultSelection(playerID string, eventID int) {
+	pending := gs.PendingCultistsCultSelection
+	if pending == nil || pending.PlayerID != playerID {
+		gs.PendingCultistsCultSelection = &PendingCultistsCultSelection{PlayerID: playerID}
+		pending = gs.PendingCultistsCultSelection
+	}
+	pending.EventIDs = append(pending.EventIDs, eventID)
+}
+
+// ConsumeCultistsCultSelection resolves the oldest queued cult advance of
+// playerID and clears the pending selection once none is left. It reports
+// whether an advance was pending.
+func (gs *GameState) ConsumeCultistsCultSelection(playerID string) bool {
+	pending := gs.PendingCultistsCultSelection
+	if pending == nil || pending.PlayerID != playerID {
+		return false
+	}
+	if len(pending.EventIDs) > 1 {
+		pending.EventIDs = pending.EventIDs[1:]
+		return true
+	}
+	gs.PendingCultistsCultSelection = nil
+	return true
+}
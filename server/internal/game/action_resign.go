@@ -0,0 +1,180 @@
+package game
+
+import "sort"
+
+// ResignAction removes a player from the rest of the game. Their buildings
+// stay on the map: they still count for adjacency and towns, and neighbours
+// building next to them still raise leech offers, which are declined for
+// them. The player returns their bonus card, passes every remaining round,
+// collects no income or cult rewards, and is ranked last in final scoring
+// with their VP frozen.
+// Like SetPlayerOptionsAction it is not bound to the turn order.
+type ResignAction struct {
+	BaseAction
+}
+
+func NewResignAction(playerID string) *ResignAction {
+	return &ResignAction{
+		BaseAction: BaseAction{
+			Type:     ActionResign,
+			PlayerID: playerID,
+		},
+	}
+}
+
+func (a *ResignAction) Validate(gs *GameState) error {
+	player, err := gs.ValidatePlayer(a.PlayerID)
+	if err != nil {
+		return err
+	}
+	if player.Resigned {
+		return ruleErrorf(ReasonUnspecified, "player has already resigned")
+	}
+	switch gs.Phases().Current() {
+	case PhaseIncome, PhaseAction, PhaseCleanup:
+	default:
+		return gs.Phases().Reject("cannot resign outside of the rounds")
+	}
+	for id, other := range gs.Players {
+		if id != a.PlayerID && !other.Resigned {
+			return nil
+		}
+	}
+	return ruleErrorf(ReasonUnspecified, "the last active player cannot resign")
+}
+
+func (a *ResignAction) Execute(gs *GameState) error {
+	if err := a.Validate(gs); err != nil {
+		return err
+	}
+	player := gs.GetPlayer(a.PlayerID)
+	wasBlocking := gs.HasBlockingPendingLeechOffers()
+	hadIncomeDecision := gs.HasPendingIncomeDecisions()
+	current := gs.GetCurrentPlayer()
+	wasCurrent := gs.Phases().Current() == PhaseAction && current != nil && current.ID == a.PlayerID
+
+	player.Resigned = true
+	player.HasPassed = true
+	if gs.BonusCards != nil {
+		gs.BonusCards.ReturnAllBonusCards(a.PlayerID)
+	}
+	gs.declineResignedLeechOffers(a.PlayerID)
+	gs.clearResignedPlayerDecisions(a.PlayerID)
+
+	switch gs.Phase {
+	case PhaseIncome:
+		if gs.InCultRewardSpadePhase() {
+			gs.advanceCultRewardSpadePhase()
+		} else if hadIncomeDecision && !gs.HasPendingIncomeDecisions() {
+			gs.StartActionPhase()
+		}
+	case PhaseAction:
+		if gs.AllPlayersPassed() {
+			if !gs.HasLateRoundPendingDecisions() {
+				advanceAfterRoundComplete(gs)
+			}
+			return nil
+		}
+		if wasCurrent || (wasBlocking && !gs.HasBlockingPendingLeechOffers()) {
+			gs.NextTurn()
+		}
+	}
+	return nil
+}
+
+// declineResignedLeechOffers declines every leech offer waiting on a resigned
+// player, settling the builder's Cultists or Shapeshifters bonus as if they
+// had declined by hand.
+func (gs *GameState) declineResignedLeechOffers(playerID string) {
+	player := gs.GetPlayer(playerID)
+	offers := gs.PendingLeechOffers[playerID]
+	gs.ClearPendingLeechOffers(playerID)
+	for _, offer := range offers {
+		if offer == nil {
+			continue
+		}
+		potentialGain := 0
+		if player != nil && player.Resources != nil && player.Resources.Power != nil {
+			potentialGain = player.Resources.Power.Clone().GainPower(offer.Amount)
+		}
+		gs.recordLeechResponse(offer, potentialGain, false)
+		gs.ResolveCultistsLeechBonus(offer.EventID)
+		gs.ResolveShapeshiftersLeechBonus(offer.EventID)
+	}
+}
+
+// clearResignedPlayerDecisions drops every decision still owed by playerID.
+func (gs *GameState) clearResignedPlayerDecisions(playerID string) {
+	if gs.PendingFavorTileSelection != nil && gs.PendingFavorTileSelection.PlayerID == playerID {
+		gs.PendingFavorTileSelection = nil
+	}
+	if gs.PendingHalflingsSpades != nil && gs.PendingHalflingsSpades.PlayerID == playerID {
+		gs.PendingHalflingsSpades = nil
+	}
+	if gs.PendingGoblinsCultSteps != nil && gs.PendingGoblinsCultSteps.PlayerID == playerID {
+		gs.PendingGoblinsCultSteps = nil
+	}
+	if gs.PendingWispsStrongholdDwelling != nil && gs.PendingWispsStrongholdDwelling.PlayerID == playerID {
+		gs.PendingWispsStrongholdDwelling = nil
+	}
+	if gs.PendingDarklingsPriestOrdination != nil && gs.PendingDarklingsPriestOrdination.PlayerID == playerID {
+		gs.PendingDarklingsPriestOrdination = nil
+	}
+	if gs.PendingCultistsCultSelection != nil && gs.PendingCultistsCultSelection.PlayerID == playerID {
+		gs.PendingCultistsCultSelection = nil
+	}
+	if gs.PendingArchivistsBonusSelection != nil && gs.PendingArchivistsBonusSelection.PlayerID == playerID {
+		gs.PendingArchivistsBonusSelection = nil
+	}
+	if gs.PendingRiverwalkersPriestChoice != nil && gs.PendingRiverwalkersPriestChoice.PlayerID == playerID {
+		gs.PendingRiverwalkersPriestChoice = nil
+	}
+	if gs.PendingTownCultTopChoice != nil && gs.PendingTownCultTopChoice.PlayerID == playerID {
+		gs.PendingTownCultTopChoice = nil
+	}
+	queue := gs.PendingTreasurersDepositQueue[:0]
+	for _, deposit := range gs.PendingTreasurersDepositQueue {
+		if deposit.PlayerID != playerID {
+			queue = append(queue, deposit)
+		}
+	}
+	gs.PendingTreasurersDepositQueue = queue
+	if gs.PendingTreasurersDeposit != nil && gs.PendingTreasurersDeposit.PlayerID == playerID {
+		gs.advanceTreasurersDepositQueue()
+	}
+	delete(gs.PendingTownFormations, playerID)
+	delete(gs.PendingSpades, playerID)
+	delete(gs.PendingSpadeBuildAllowed, playerID)
+	delete(gs.PendingCultRewardSpades, playerID)
+	gs.clearPendingPostActionSpecialActions(playerID)
+	if gs.PendingFreeActionsPlayerID == playerID {
+		gs.PendingFreeActionsPlayerID = ""
+	}
+	if gs.PendingTurnConfirmationPlayerID == playerID {
+		gs.ClearPendingTurnConfirmation()
+	}
+}
+
+// withResignedPlayersLast returns order with resigned players moved to the
+// end, adding back those who dropped out of it by never passing.
+func (gs *GameState) withResignedPlayersLast(order []string) []string {
+	active := make([]string, 0, len(gs.Players))
+	var resigned []string
+	seen := make(map[string]bool, len(order))
+	for _, playerID := range order {
+		seen[playerID] = true
+		if player := gs.GetPlayer(playerID); player != nil && player.Resigned {
+			resigned = append(resigned, playerID)
+			continue
+		}
+		active = append(active, playerID)
+	}
+	var missing []string
+	for playerID, player := range gs.Players {
+		if player.Resigned && !seen[playerID] {
+			missing = append(missing, playerID)
+		}
+	}
+	sort.Strings(missing)
+	return append(append(active, resigned...), missing...)
+}
@@ -0,0 +1,129 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/game/factions"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+func newResignTestGame(t *testing.T) *GameState {
+	t.Helper()
+	return newActionTestGame(t, 1,
+		testSeat{"swarmlings", factions.NewSwarmlings()},
+		testSeat{"cultists", factions.NewCultists()},
+		testSeat{"witches", factions.NewWitches()},
+	)
+}
+
+func TestResign_SkipsTurnsAndIncome(t *testing.T) {
+	gs := newResignTestGame(t)
+	resigned := gs.GetPlayer("swarmlings")
+	resigned.VictoryPoints = 30
+
+	if err := NewResignAction("swarmlings").Execute(gs); err != nil {
+		t.Fatalf("resign: %v", err)
+	}
+	if current := gs.GetCurrentPlayer(); current == nil || current.ID != "cultists" {
+		t.Fatalf("expected the turn to pass to cultists, got %v", current)
+	}
+	if err := NewResignAction("swarmlings").Validate(gs); err == nil {
+		t.Fatalf("expected a second resignation to be rejected")
+	}
+
+	gs.PassOrder = []string{"witches", "cultists"}
+	gs.StartNewRound()
+	if got := gs.TurnOrder; len(got) != 3 || got[0] != "witches" || got[1] != "cultists" || got[2] != "swarmlings" {
+		t.Fatalf("expected the resigned player last in turn order, got %v", got)
+	}
+	if !resigned.HasPassed {
+		t.Fatalf("expected the resigned player to stay passed in the new round")
+	}
+
+	before := *resigned.Resources
+	gs.GrantIncome()
+	if resigned.Resources.Coins != before.Coins || resigned.Resources.Workers != before.Workers {
+		t.Fatalf("expected no income for the resigned player, got %+v", resigned.Resources)
+	}
+	gs.StartActionPhase()
+	if current := gs.GetCurrentPlayer(); current == nil || current.ID != "witches" {
+		t.Fatalf("expected witches to start the round, got %v", current)
+	}
+}
+
+func TestResign_LastActivePlayerCannotResign(t *testing.T) {
+	gs := newResignTestGame(t)
+	for _, playerID := range []string{"swarmlings", "cultists"} {
+		if err := NewResignAction(playerID).Execute(gs); err != nil {
+			t.Fatalf("resign %s: %v", playerID, err)
+		}
+	}
+	if err := NewResignAction("witches").Validate(gs); err == nil {
+		t.Fatalf("expected the last active player to be unable to resign")
+	}
+}
+
+func TestResign_BuildingsStillGrantLeech(t *testing.T) {
+	gs := newResignTestGame(t)
+	cultists := gs.GetPlayer("cultists")
+	cultists.Resources.Power.Bowl1 = 5
+	cultists.Resources.Power.Bowl2 = 5
+	if err := NewResignAction("swarmlings").Execute(gs); err != nil {
+		t.Fatalf("resign: %v", err)
+	}
+
+	swarmlingsHex := board.NewHex(1, 0)
+	gs.Map.GetHex(swarmlingsHex).Terrain = models.TerrainLake
+	gs.Map.PlaceBuilding(swarmlingsHex, &models.Building{
+		Type:       models.BuildingDwelling,
+		Faction:    models.FactionSwarmlings,
+		PlayerID:   "swarmlings",
+		PowerValue: 1,
+	})
+	cultistsHex := board.NewHex(0, 0)
+	gs.Map.GetHex(cultistsHex).Terrain = models.TerrainPlains
+	gs.Map.PlaceBuilding(cultistsHex, &models.Building{
+		Type:       models.BuildingDwelling,
+		Faction:    models.FactionCultists,
+		PlayerID:   "cultists",
+		PowerValue: 1,
+	})
+	gs.TriggerPowerLeech(cultistsHex, "cultists")
+
+	if offers := gs.PendingLeechOffers["swarmlings"]; len(offers) != 0 {
+		t.Fatalf("expected no leech offer left for the resigned player, got %v", offers)
+	}
+	if cultists.Resources.Power.Bowl1 != 4 || cultists.Resources.Power.Bowl2 != 6 {
+		t.Fatalf("expected the cultists decline bonus of 1 power, got bowls %d/%d",
+			cultists.Resources.Power.Bowl1, cultists.Resources.Power.Bowl2)
+	}
+}
+
+func TestFinalScoring_RanksResignedPlayersLastWithFrozenVP(t *testing.T) {
+	gs := newResignTestGame(t)
+	gs.GetPlayer("swarmlings").VictoryPoints = 90
+	gs.GetPlayer("cultists").VictoryPoints = 40
+	gs.GetPlayer("witches").VictoryPoints = 30
+	if err := NewResignAction("swarmlings").Execute(gs); err != nil {
+		t.Fatalf("resign: %v", err)
+	}
+	gs.CultTracks.PlayerPositions["swarmlings"][CultFire] = 10
+	placeFinalScoringTestBuilding(t, gs, "swarmlings", board.NewHex(0, 0), models.BuildingDwelling)
+
+	scores := gs.CalculateFinalScoring()
+	resigned := scores["swarmlings"]
+	if !resigned.Resigned || resigned.TotalVP != 90 {
+		t.Fatalf("expected the resigned score frozen at 90 VP, got %+v", resigned)
+	}
+	if scores["cultists"].AreaVP == 0 {
+		t.Fatalf("expected area bonuses to go to active players, got %+v", scores["cultists"])
+	}
+	if winner := gs.GetWinner(scores); winner == "swarmlings" {
+		t.Fatalf("expected a resigned player never to win")
+	}
+	ranked := GetRankedPlayers(scores)
+	if ranked[len(ranked)-1].PlayerID != "swarmlings" {
+		t.Fatalf("expected the resigned player ranked last, got %s", ranked[len(ranked)-1].PlayerID)
+	}
+}
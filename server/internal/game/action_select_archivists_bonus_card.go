@@ -44,14 +44,14 @@ func (a *SelectArchivistsBonusCardAction) Execute(gs *GameState) error {
 	pending := gs.PendingArchivistsBonusSelection
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 	coins, err := gs.BonusCards.TakeAdditionalBonusCard(a.PlayerID, a.BonusCard)
 	if err != nil {
 		return fmt.Errorf("failed to take archivists second bonus card: %w", err)
 	}
 	player.Resources.Coins += coins
-	player.Resources.Power.GainPower(coins * 2)
+	gs.GainPowerForReason(a.PlayerID, coins*2, PowerGainBonusCard, "")
 	gs.PendingArchivistsBonusSelection = nil
 	gs.ApplyAutoConvertOnPass(a.PlayerID)
 	applyPostPassBonuses(gs, player)
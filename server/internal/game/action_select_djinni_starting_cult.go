@@ -32,7 +32,7 @@ func (a *SelectDjinniStartingCultTrackAction) Validate(gs *GameState) error {
 	}
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found")
+		return ruleErrorf(ReasonPlayerNotFound, "player not found")
 	}
 	if player.Faction == nil || player.Faction.GetType() != models.FactionDjinni {
 		return fmt.Errorf("only Djinni can choose a starting cult track")
@@ -1,7 +1,6 @@
 package game
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/lukev/tm_server/internal/game/factions"
@@ -26,8 +25,8 @@ func (a *SelectFactionAction) GetType() ActionType {
 
 // Validate checks if the action is valid
 func (a *SelectFactionAction) Validate(gs *GameState) error {
-	if gs.Phase != PhaseFactionSelection {
-		return errors.New("not in faction selection phase")
+	if err := gs.Phases().Require(PhaseFactionSelection, "not in faction selection phase"); err != nil {
+		return err
 	}
 	if gs.SetupMode != SetupModeSnellman {
 		return fmt.Errorf("select_faction is disabled for setup mode %s", gs.SetupMode)
@@ -35,7 +34,7 @@ func (a *SelectFactionAction) Validate(gs *GameState) error {
 
 	// Check if it's player's turn
 	if gs.TurnOrder[gs.CurrentPlayerIndex] != a.PlayerID {
-		return errors.New("not your turn")
+		return ruleErrorf(ReasonNotYourTurn, "not your turn")
 	}
 
 	// Check if faction is valid
@@ -46,13 +45,59 @@ func (a *SelectFactionAction) Validate(gs *GameState) error {
 	// Check if faction is already taken
 	for _, p := range gs.Players {
 		if p.Faction != nil && p.Faction.GetType() == a.FactionType {
-			return fmt.Errorf("faction %s is already taken", a.FactionType)
+			return ruleErrorf(ReasonFactionTaken, "faction %s is already taken", a.FactionType)
 		}
 	}
 
+	// Only one faction per color can be in play
+	if taken, ok := factionColorTakenBy(gs, a.FactionType); ok {
+		return ruleErrorf(ReasonFactionColorTaken, "faction %s shares its color with %s, which is already in play", a.FactionType, taken)
+	}
+
 	return nil
 }
 
+// factionColorTakenBy returns the selected faction, if any, that has the same
+// color as f. Colorless factions share no color, with each other or anyone.
+func factionColorTakenBy(gs *GameState, f models.FactionType) (models.FactionType, bool) {
+	color := f.GetFactionColor()
+	if color == models.ColorColorless {
+		return models.FactionUnknown, false
+	}
+	for _, p := range gs.Players {
+		if p.Faction != nil && p.Faction.GetType() != f && p.Faction.GetType().GetFactionColor() == color {
+			return p.Faction.GetType(), true
+		}
+	}
+	return models.FactionUnknown, false
+}
+
+// AvailableFactions lists the factions that can still be selected: allowed by
+// the game's expansion settings, not taken, and not sharing a color with a
+// selected faction.
+func AvailableFactions(gs *GameState) []models.FactionType {
+	var out []models.FactionType
+	for f := models.FactionNomads; f <= models.FactionSnowShamans; f++ {
+		if !isAllowedFaction(gs, f) {
+			continue
+		}
+		if _, ok := factionColorTakenBy(gs, f); ok {
+			continue
+		}
+		taken := false
+		for _, p := range gs.Players {
+			if p.Faction != nil && p.Faction.GetType() == f {
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
 // Execute performs the action
 func (a *SelectFactionAction) Execute(gs *GameState) error {
 	if err := assignFactionToPlayer(gs, a.PlayerID, a.FactionType, 20); err != nil {
@@ -142,7 +187,7 @@ func assignFactionToPlayer(gs *GameState, playerID string, factionType models.Fa
 	faction := factions.NewFaction(factionType)
 	player := gs.Players[playerID]
 	if player == nil {
-		return fmt.Errorf("player not found: %s", playerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", playerID)
 	}
 
 	player.Faction = faction
@@ -172,5 +217,9 @@ func assignFactionToPlayer(gs *GameState, playerID string, factionType models.Fa
 		player.ShippingLevel = shippingFaction.GetShippingLevel()
 	}
 	gs.updateFactionDiggingLevel(player)
-	return gs.applyFactionSpecificSetup(playerID)
+	if err := gs.applyFactionSpecificSetup(playerID); err != nil {
+		return err
+	}
+	gs.Handicaps[playerID].Apply(player)
+	return nil
 }
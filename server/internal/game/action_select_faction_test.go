@@ -1,6 +1,7 @@
 package game
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/lukev/tm_server/internal/models"
@@ -148,3 +149,73 @@ func TestSelectFaction_FanFireIceFactionRequiresBothToggles(t *testing.T) {
 		t.Fatalf("expected fan Fire & Ice faction to be allowed with both toggles: %v", err)
 	}
 }
+
+func TestSelectFaction_RejectsFactionSharingColorWithSelectedFaction(t *testing.T) {
+	gs := NewGameState()
+	if err := gs.AddPlayer("p1", nil); err != nil {
+		t.Fatalf("add p1: %v", err)
+	}
+	if err := gs.AddPlayer("p2", nil); err != nil {
+		t.Fatalf("add p2: %v", err)
+	}
+	gs.Phase = PhaseFactionSelection
+	gs.TurnOrder = []string{"p1", "p2"}
+	gs.CurrentPlayerIndex = 0
+
+	if err := (&SelectFactionAction{PlayerID: "p1", FactionType: models.FactionWitches}).Execute(gs); err != nil {
+		t.Fatalf("execute select faction: %v", err)
+	}
+
+	err := (&SelectFactionAction{PlayerID: "p2", FactionType: models.FactionAuren}).Validate(gs)
+	if err == nil {
+		t.Fatalf("expected Auren to be rejected after Witches were selected")
+	}
+	if got := ReasonCodeOf(err); got != ReasonFactionColorTaken {
+		t.Fatalf("reason code mismatch: got %s want %s", got, ReasonFactionColorTaken)
+	}
+
+	for _, f := range AvailableFactions(gs) {
+		if f == models.FactionWitches || f == models.FactionAuren {
+			t.Fatalf("expected %s to be unavailable, got %v", f, AvailableFactions(gs))
+		}
+	}
+	names, ok := serializeAvailableFactions(gs).([]string)
+	if !ok || len(names) == 0 {
+		t.Fatalf("expected serialized available factions during selection, got %#v", serializeAvailableFactions(gs))
+	}
+	for _, name := range names {
+		if name == models.FactionAuren.String() {
+			t.Fatalf("expected Auren to be omitted from serialized available factions: %v", names)
+		}
+	}
+
+	gs.Phase = PhaseAction
+	if got := serializeAvailableFactions(gs); got != nil {
+		t.Fatalf("expected no available factions outside selection, got %#v", got)
+	}
+}
+
+func TestSelectFaction_ColorlessFactionsDoNotShareAColor(t *testing.T) {
+	gs := NewGameState()
+	if err := gs.AddPlayer("p1", nil); err != nil {
+		t.Fatalf("add p1: %v", err)
+	}
+	if err := gs.AddPlayer("p2", nil); err != nil {
+		t.Fatalf("add p2: %v", err)
+	}
+	gs.Phase = PhaseFactionSelection
+	gs.TurnOrder = []string{"p1", "p2"}
+	gs.CurrentPlayerIndex = 0
+	gs.EnableFireIceFactions = true
+
+	if err := (&SelectFactionAction{PlayerID: "p1", FactionType: models.FactionShapeshifters}).Execute(gs); err != nil {
+		t.Fatalf("execute select Shapeshifters: %v", err)
+	}
+	available := AvailableFactions(gs)
+	if !slices.Contains(available, models.FactionRiverwalkers) {
+		t.Fatalf("expected Riverwalkers to stay available after Shapeshifters, got %v", available)
+	}
+	if err := (&SelectFactionAction{PlayerID: "p2", FactionType: models.FactionRiverwalkers}).Validate(gs); err != nil {
+		t.Fatalf("expected Riverwalkers to be selectable after Shapeshifters: %v", err)
+	}
+}
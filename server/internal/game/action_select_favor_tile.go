@@ -19,7 +19,7 @@ func (a *SelectFavorTileAction) GetType() ActionType {
 func (a *SelectFavorTileAction) Validate(gs *GameState) error {
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 
 	// Check if player has passed
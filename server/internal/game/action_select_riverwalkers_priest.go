@@ -38,7 +38,7 @@ func (a *SelectRiverwalkersPriestChoiceAction) Validate(gs *GameState) error {
 		return fmt.Errorf("riverwalkers priest choice is only available to Riverwalkers")
 	}
 	if a.TakePriest {
-		if gs.RemainingPriestCapacity(a.PlayerID) < 1 {
+		if !gs.PriestLedger(a.PlayerID).CanGain(1) {
 			return fmt.Errorf("not enough priest capacity")
 		}
 		return nil
@@ -51,7 +51,7 @@ func (a *SelectRiverwalkersPriestChoiceAction) Validate(gs *GameState) error {
 	}
 	cost := gs.riverwalkersUnlockCost(player, a.Terrain)
 	if player.Resources.Coins < cost {
-		return fmt.Errorf("not enough coins to unlock terrain: need %d, have %d", cost, player.Resources.Coins)
+		return ruleErrorf(ReasonInsufficientCoins, "not enough coins to unlock terrain: need %d, have %d", cost, player.Resources.Coins)
 	}
 	return nil
 }
@@ -17,7 +17,7 @@ func (a *SelectTownCultTopAction) GetType() ActionType {
 func (a *SelectTownCultTopAction) Validate(gs *GameState) error {
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 	if gs.PendingTownCultTopChoice == nil {
 		return fmt.Errorf("no pending town cult-top choice")
@@ -57,7 +57,7 @@ func (a *SelectTownCultTopAction) Execute(gs *GameState) error {
 
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 
 	pending := gs.PendingTownCultTopChoice
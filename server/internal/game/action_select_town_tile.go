@@ -23,7 +23,7 @@ func (a *SelectTownTileAction) GetType() ActionType {
 func (a *SelectTownTileAction) Validate(gs *GameState) error {
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 	if player.HasPassed {
 		return fmt.Errorf("player has already passed")
@@ -37,7 +37,7 @@ func (a *SelectTownTileAction) Validate(gs *GameState) error {
 	if !gs.TownTiles.IsAvailable(a.TileType) {
 		return fmt.Errorf("town tile %v is not available", a.TileType)
 	}
-	if a.TileType == models.TownTile9Points && gs.RemainingPriestCapacity(a.PlayerID) < 1 {
+	if a.TileType == models.TownTile9Points && !gs.PriestLedger(a.PlayerID).CanGain(1) {
 		return fmt.Errorf("cannot take priest town tile at the 7-priest limit")
 	}
 	pending := pendingTowns[0]
@@ -47,7 +47,7 @@ func (a *SelectTreasurersDepositAction) Validate(gs *GameState) error {
 		return fmt.Errorf("only Treasurers can use treasury deposits")
 	}
 	if player.Resources.Coins < a.CoinsToTreasury || player.Resources.Workers < a.WorkersToTreasury || player.Resources.Priests < a.PriestsToTreasury {
-		return fmt.Errorf("not enough on-board resources to move to treasury")
+		return ruleErrorf(ReasonInsufficientResources, "not enough on-board resources to move to treasury")
 	}
 	return nil
 }
@@ -65,7 +65,7 @@ func (a *SelectTreasurersDepositAction) Execute(gs *GameState) error {
 	player.TreasuryPriests += a.PriestsToTreasury
 
 	gs.advanceTreasurersDepositQueue()
-	if !gs.HasPendingIncomeDecisions() && gs.Phase == PhaseIncome {
+	if !gs.HasPendingIncomeDecisions() && gs.Phases().Current() == PhaseIncome {
 		gs.StartActionPhase()
 	}
 	return nil
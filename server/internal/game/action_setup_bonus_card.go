@@ -1,6 +1,9 @@
 package game
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+)
 
 // SetupBonusCardAction represents selecting a setup bonus card before round 1 starts.
 type SetupBonusCardAction struct {
@@ -15,23 +18,23 @@ func (a *SetupBonusCardAction) GetType() ActionType {
 
 // Validate checks if setup bonus card selection is valid.
 func (a *SetupBonusCardAction) Validate(gs *GameState) error {
-	if gs.Phase != PhaseSetup {
-		return fmt.Errorf("setup bonus cards can only be selected during setup phase")
+	if err := gs.Phases().Require(PhaseSetup, "setup bonus cards can only be selected during setup phase"); err != nil {
+		return err
 	}
-	if gs.SetupSubphase != SetupSubphaseBonusCards {
-		return fmt.Errorf("not in setup bonus card selection subphase")
+	if err := gs.Phases().RequireSetup("not in setup bonus card selection subphase", SetupSubphaseBonusCards); err != nil {
+		return err
 	}
 
 	expectedPlayer := gs.currentSetupBonusPlayerID()
 	if expectedPlayer == "" {
-		return fmt.Errorf("no setup bonus card selection expected")
+		return gs.Phases().Reject("no setup bonus card selection expected")
 	}
 	if expectedPlayer != a.PlayerID {
-		return fmt.Errorf("not your setup bonus selection turn")
+		return ruleErrorf(ReasonNotYourTurn, "not your setup bonus selection turn: waiting for %s", expectedPlayer)
 	}
 
 	if !gs.BonusCards.IsAvailable(a.BonusCard) {
-		return fmt.Errorf("bonus card %v is not available", a.BonusCard)
+		return ruleErrorf(ReasonActionSpaceTaken, "bonus card %v is not available", a.BonusCard)
 	}
 
 	return nil
@@ -45,7 +48,7 @@ func (a *SetupBonusCardAction) Execute(gs *GameState) error {
 
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 
 	if _, err := gs.BonusCards.TakeBonusCard(a.PlayerID, a.BonusCard); err != nil {
@@ -56,3 +59,21 @@ func (a *SetupBonusCardAction) Execute(gs *GameState) error {
 	gs.AdvanceSetupAfterBonusSelection()
 	return nil
 }
+
+// SetupBonusCardOptions lists the bonus cards playerID may take in the setup
+// bonus card sub-phase, sorted by card type. It returns nil when it is not
+// playerID's pick.
+func (gs *GameState) SetupBonusCardOptions(playerID string) []BonusCardType {
+	if gs.Phases().Current() != PhaseSetup || gs.SetupSubphase != SetupSubphaseBonusCards || gs.BonusCards == nil {
+		return nil
+	}
+	if gs.currentSetupBonusPlayerID() != playerID {
+		return nil
+	}
+	options := make([]BonusCardType, 0, len(gs.BonusCards.Available))
+	for cardType := range gs.BonusCards.Available {
+		options = append(options, cardType)
+	}
+	sort.Slice(options, func(i, j int) bool { return options[i] < options[j] })
+	return options
+}
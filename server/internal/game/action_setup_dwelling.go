@@ -28,8 +28,8 @@ func NewSetupDwellingAction(playerID string, hex board.Hex) *SetupDwellingAction
 // Validate checks if the setup dwelling placement is valid
 func (a *SetupDwellingAction) Validate(gs *GameState) error {
 	// Must be in setup phase
-	if gs.Phase != PhaseSetup {
-		return fmt.Errorf("can only place setup dwellings during setup phase")
+	if err := gs.Phases().Require(PhaseSetup, "can only place setup dwellings during setup phase"); err != nil {
+		return err
 	}
 
 	// Replay imports can begin directly in setup without going through faction
@@ -38,8 +38,8 @@ func (a *SetupDwellingAction) Validate(gs *GameState) error {
 	if gs.SetupSubphase == SetupSubphaseNone && len(gs.SetupDwellingOrder) == 0 && len(gs.TurnOrder) > 0 && allPlayersHaveFactions(gs) {
 		gs.InitializeSetupSequence()
 	}
-	if gs.SetupSubphase != SetupSubphaseDwellings && gs.SetupSubphase != SetupSubphaseNone {
-		return fmt.Errorf("setup dwellings are only allowed during setup phase")
+	if err := gs.Phases().RequireSetup("setup dwellings are only allowed during setup phase", SetupSubphaseDwellings, SetupSubphaseNone); err != nil {
+		return err
 	}
 
 	// Strict setup-order validation is used for live multiplayer once the sequence
@@ -48,16 +48,16 @@ func (a *SetupDwellingAction) Validate(gs *GameState) error {
 	if gs.SetupSubphase == SetupSubphaseDwellings {
 		expectedPlayer := gs.currentSetupDwellingPlayerID()
 		if expectedPlayer == "" {
-			return fmt.Errorf("no setup dwelling placement expected")
+			return gs.Phases().Reject("no setup dwelling placement expected")
 		}
 		if expectedPlayer != a.PlayerID {
-			return fmt.Errorf("not your setup dwelling turn")
+			return ruleErrorf(ReasonNotYourTurn, "not your setup dwelling turn: waiting for %s", expectedPlayer)
 		}
 	}
 
 	player, exists := gs.Players[a.PlayerID]
 	if !exists {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 	if player.Faction == nil {
 		return fmt.Errorf("player has no faction selected")
@@ -66,12 +66,12 @@ func (a *SetupDwellingAction) Validate(gs *GameState) error {
 	// Check if hex exists
 	hexData := gs.Map.GetHex(a.Hex)
 	if hexData == nil {
-		return fmt.Errorf("hex does not exist: %v", a.Hex)
+		return ruleErrorf(ReasonInvalidHex, "hex does not exist: %v", a.Hex)
 	}
 
 	// Check if hex already has a building
 	if hexData.Building != nil {
-		return fmt.Errorf("hex already has a building")
+		return ruleErrorf(ReasonTerrainOccupied, "hex already has a building")
 	}
 
 	factionType := player.Faction.GetType()
@@ -119,7 +119,7 @@ func (a *SetupDwellingAction) Execute(gs *GameState) error {
 
 	player, exists := gs.Players[a.PlayerID]
 	if !exists {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 
 	buildingType := models.BuildingDwelling
@@ -205,6 +205,85 @@ func TestTransformAndBuild_PowerLeech(t *testing.T) {
 	}
 }
 
+// addBridgeTestHexes adds a bridge-sized river crossing to gs.Map, with land
+// hexes h1 and h2 that are only adjacent once a bridge spans the river.
+func addBridgeTestHexes(gs *GameState) (h1, h2 board.Hex) {
+	h1, h2 = board.NewHex(0, 0), board.NewHex(1, -2)
+	for _, river := range []board.Hex{board.NewHex(0, -1), board.NewHex(1, -1)} {
+		gs.Map.Hexes[river] = &board.MapHex{Coord: river, Terrain: models.TerrainRiver}
+		gs.Map.RiverHexes[river] = true
+	}
+	for _, land := range []board.Hex{h2, board.NewHex(2, -2)} {
+		gs.Map.Hexes[land] = &board.MapHex{Coord: land, Terrain: models.TerrainSwamp}
+	}
+	return h1, h2
+}
+
+func TestTransformAndBuild_ReachesAcrossBridge(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewHalflings()
+	gs.AddPlayer("player1", faction)
+	player := gs.GetPlayer("player1")
+	player.Resources.Coins = 10
+	player.Resources.Workers = 10
+
+	h1, h2 := addBridgeTestHexes(gs)
+	gs.Map.GetHex(h1).Building = testBuilding("player1", faction.GetType(), models.BuildingDwelling)
+
+	if err := NewTransformAndBuildAction("player1", h2, true, models.TerrainTypeUnknown).Validate(gs); err == nil {
+		t.Fatalf("expected %v to be out of reach without a bridge", h2)
+	}
+	if err := gs.Map.BuildBridge(h1, h2, "player1"); err != nil {
+		t.Fatalf("BuildBridge: %v", err)
+	}
+	if err := NewTransformAndBuildAction("player1", h2, true, models.TerrainTypeUnknown).Execute(gs); err != nil {
+		t.Fatalf("expected to build across the bridge, got error: %v", err)
+	}
+}
+
+func TestTransformAndBuild_PowerLeechAcrossBridge(t *testing.T) {
+	gs := NewGameState()
+	faction1 := factions.NewHalflings()
+	faction2 := factions.NewSwarmlings()
+	gs.AddPlayer("player1", faction1)
+	gs.AddPlayer("player2", faction2)
+	player1 := gs.GetPlayer("player1")
+	player1.Resources.Coins = 10
+	player1.Resources.Workers = 10
+	player2 := gs.GetPlayer("player2")
+	player2.Resources.Coins = 20
+	player2.Resources.Workers = 10
+
+	// player2 sits on one end of the bridge; player1 reaches the other end
+	// from a dwelling that is not adjacent to player2.
+	h1, h2 := addBridgeTestHexes(gs)
+	gs.Map.GetHex(h1).Terrain = models.TerrainLake
+	gs.Map.GetHex(h1).Building = testBuilding("player2", faction2.GetType(), models.BuildingDwelling)
+	player1Hex := board.NewHex(2, -2)
+	gs.Map.GetHex(player1Hex).Terrain = models.TerrainPlains
+	gs.Map.GetHex(player1Hex).Building = testBuilding("player1", faction1.GetType(), models.BuildingDwelling)
+	if err := gs.Map.BuildBridge(h1, h2, "player2"); err != nil {
+		t.Fatalf("BuildBridge: %v", err)
+	}
+
+	if err := NewTransformAndBuildAction("player1", h2, true, models.TerrainTypeUnknown).Execute(gs); err != nil {
+		t.Fatalf("expected player1 to build at %v, got error: %v", h2, err)
+	}
+	offers := gs.PendingLeechOffers["player2"]
+	if len(offers) != 1 || offers[0].Amount != 1 {
+		t.Fatalf("expected player2 to leech 1 across the bridge, got %+v", offers)
+	}
+
+	gs.PendingLeechOffers = make(map[string][]*PowerLeechOffer)
+	if err := NewUpgradeBuildingAction("player2", h1, models.BuildingTradingHouse).Execute(gs); err != nil {
+		t.Fatalf("expected player2 to upgrade at %v, got error: %v", h1, err)
+	}
+	offers = gs.PendingLeechOffers["player1"]
+	if len(offers) != 1 || offers[0].Amount != 1 {
+		t.Fatalf("expected player1 to leech 1 from its dwelling across the bridge, got %+v", offers)
+	}
+}
+
 func TestTransformAndBuild_HexAlreadyOccupied(t *testing.T) {
 	gs := NewGameState()
 	faction := factions.NewHalflings()
@@ -642,3 +721,88 @@ func TestTransformAndBuild_MultipleAdjacentBuildings(t *testing.T) {
 		t.Errorf("expected player2 to lose 4 VP, initial: %d, new: %d", initialVP, player2.VictoryPoints)
 	}
 }
+
+func TestPreviewAction_TransformAndBuildMatchesExecute(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewHalflings()
+	gs.AddPlayer("player1", faction)
+	gs.Phase = PhaseAction
+	gs.TurnOrder = []string{"player1"}
+
+	player := gs.GetPlayer("player1")
+	player.Resources.Coins = 10
+	player.Resources.Workers = 10
+	gs.Map.GetHex(board.NewHex(0, 1)).Building = testBuilding("player1", faction.GetType(), models.BuildingDwelling)
+	gs.ScoringTiles = NewScoringTileState()
+	gs.ScoringTiles.Tiles = []ScoringTile{{Type: ScoringDwellingWater, ActionType: ScoringActionDwelling, ActionVP: 2}}
+	gs.Round = 1
+
+	targetHex := board.NewHex(0, 0) // Forest
+	wantSpades := board.TerrainDistance(gs.Map.GetHex(targetHex).Terrain, models.TerrainPlains)
+	action := NewTransformAndBuildAction("player1", targetHex, true, models.TerrainTypeUnknown)
+
+	preview, err := PreviewAction(gs, action)
+	if err != nil {
+		t.Fatalf("preview failed: %v", err)
+	}
+	if gs.Map.GetHex(targetHex).Building != nil || player.Resources.Workers != 10 {
+		t.Fatalf("preview mutated the game state")
+	}
+	if preview.Cost.Spades != wantSpades {
+		t.Fatalf("preview spades: got %d, want %d", preview.Cost.Spades, wantSpades)
+	}
+
+	beforeVP := player.VictoryPoints
+	if err := action.Execute(gs); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	if got := 10 - player.Resources.Workers; preview.Cost.Workers != got {
+		t.Fatalf("preview workers: got %d, execute spent %d", preview.Cost.Workers, got)
+	}
+	if got := 10 - player.Resources.Coins; preview.Cost.Coins != got {
+		t.Fatalf("preview coins: got %d, execute spent %d", preview.Cost.Coins, got)
+	}
+	if got := player.VictoryPoints - beforeVP; preview.VictoryPoints != got || got == 0 {
+		t.Fatalf("preview VP: got %d, execute earned %d", preview.VictoryPoints, got)
+	}
+}
+
+func TestTransformAndBuild_NonHomeTargetTerrain(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewHalflings()
+	gs.AddPlayer("player1", faction)
+	player := gs.GetPlayer("player1")
+	player.Resources.Coins = 10
+	player.Resources.Workers = 10
+	gs.Map.GetHex(board.NewHex(0, 1)).Building = testBuilding("player1", faction.GetType(), models.BuildingDwelling)
+
+	targetHex := board.NewHex(0, 0) // Forest
+	forest := gs.Map.GetHex(targetHex).Terrain
+
+	same := NewTransformAndBuildAction("player1", targetHex, false, forest)
+	if err := same.Validate(gs); err == nil {
+		t.Fatalf("expected transforming to the current terrain to be rejected")
+	}
+	build := NewTransformAndBuildAction("player1", targetHex, true, models.TerrainLake)
+	if err := build.Validate(gs); err == nil {
+		t.Fatalf("expected building a dwelling on non-home target terrain to be rejected")
+	}
+
+	action := NewTransformAndBuildAction("player1", targetHex, false, models.TerrainLake)
+	preview, err := PreviewAction(gs, action)
+	if err != nil {
+		t.Fatalf("preview failed: %v", err)
+	}
+	if want := board.TerrainDistance(forest, models.TerrainLake); preview.Cost.Spades != want {
+		t.Fatalf("spades for chosen color: got %d, want %d", preview.Cost.Spades, want)
+	}
+	if err := action.Execute(gs); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	if got := gs.Map.GetHex(targetHex).Terrain; got != models.TerrainLake {
+		t.Fatalf("expected hex transformed to lake, got %v", got)
+	}
+	if gs.Map.GetHex(targetHex).Building != nil {
+		t.Fatalf("transform-only action should not build")
+	}
+}
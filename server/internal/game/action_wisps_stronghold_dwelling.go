@@ -30,7 +30,7 @@ func (a *BuildWispsStrongholdDwellingAction) GetType() ActionType {
 func (a *BuildWispsStrongholdDwellingAction) Validate(gs *GameState) error {
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 	if player.Faction == nil || player.Faction.GetType() != models.FactionWisps {
 		return fmt.Errorf("only Wisps can use this action")
@@ -44,7 +44,7 @@ func (a *BuildWispsStrongholdDwellingAction) Validate(gs *GameState) error {
 		return err
 	}
 	if mapHex.Building != nil {
-		return fmt.Errorf("hex already has a building")
+		return ruleErrorf(ReasonTerrainOccupied, "hex already has a building")
 	}
 	if mapHex.Terrain != models.TerrainLake {
 		return fmt.Errorf("wisps stronghold dwelling must be built on an unoccupied lakes space")
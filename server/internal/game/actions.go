@@ -53,6 +53,7 @@ const (
 	ActionSetPlayerOptions               // Update player UX/automation options
 	ActionConfirmTurn                    // Confirm the current turn before the next player may act
 	ActionUndoTurn                       // Undo the current turn back to the last snapshot
+	ActionResign                         // Leave the game for good; buildings stay on the map
 )
 
 // Action represents a player action
@@ -131,7 +132,11 @@ func (a *TransformAndBuildAction) Validate(gs *GameState) error {
 		return err
 	}
 	if mapHex.Building != nil {
-		return fmt.Errorf("hex already has a building: %v", a.TargetHex)
+		return ruleErrorf(ReasonTerrainOccupied, "hex already has a building: %v", a.TargetHex)
+	}
+	// An explicit non-home target only makes sense as a transform.
+	if a.TargetTerrain != models.TerrainTypeUnknown && !a.BuildDwelling && mapHex.Terrain == a.TargetTerrain {
+		return fmt.Errorf("hex is already %v", a.TargetTerrain)
 	}
 
 	if gs.PendingSpades != nil && gs.PendingSpades[a.PlayerID] > 0 {
@@ -192,24 +197,24 @@ func (a *TransformAndBuildAction) Validate(gs *GameState) error {
 
 	// Check total workers needed (terraform + dwelling)
 	if player.Resources.Workers < totalWorkersNeeded {
-		return fmt.Errorf("not enough workers: need %d, have %d", totalWorkersNeeded, player.Resources.Workers)
+		return ruleErrorf(ReasonInsufficientWorkers, "not enough workers: need %d, have %d", totalWorkersNeeded, player.Resources.Workers)
 	}
 
 	// Check total priests needed (Darklings terraform cost)
 	if player.Resources.Priests < totalPriestsNeeded {
-		return fmt.Errorf("not enough priests for terraform: need %d, have %d", totalPriestsNeeded, player.Resources.Priests)
+		return ruleErrorf(ReasonInsufficientPriests, "not enough priests for terraform: need %d, have %d", totalPriestsNeeded, player.Resources.Priests)
 	}
 	if totalPowerNeeded > 0 && !player.Resources.Power.CanSpend(totalPowerNeeded) {
 		if player.Faction.GetType() != models.FactionTheEnlightened {
-			return fmt.Errorf("not enough power for terraform: need %d, have %d", totalPowerNeeded, player.Resources.Power.Bowl3)
+			return ruleErrorf(ReasonInsufficientPower, "not enough power for terraform: need %d, have %d", totalPowerNeeded, player.Resources.Power.Bowl3)
 		}
 		requiredBurn := totalPowerNeeded - player.Resources.Power.Bowl3
 		if requiredBurn <= 0 || !player.Resources.Power.CanBurn(requiredBurn) {
-			return fmt.Errorf("not enough power for terraform: need %d, have %d", totalPowerNeeded, player.Resources.Power.Bowl3)
+			return ruleErrorf(ReasonInsufficientPower, "not enough power for terraform: need %d, have %d", totalPowerNeeded, player.Resources.Power.Bowl3)
 		}
 	}
 	if player.Resources.Coins < totalCoinsNeeded {
-		return fmt.Errorf("not enough coins: need %d, have %d", totalCoinsNeeded, player.Resources.Coins)
+		return ruleErrorf(ReasonInsufficientCoins, "not enough coins: need %d, have %d", totalCoinsNeeded, player.Resources.Coins)
 	}
 
 	return nil
@@ -219,14 +224,8 @@ func (a *TransformAndBuildAction) validateAdjacency(gs *GameState, player *Playe
 	// Check adjacency - required for both transforming and building
 	isAdjacent := gs.IsAdjacentToPlayerBuilding(a.TargetHex, a.PlayerID)
 
-	// Auto-detect UseSkip for Dwarves and Fakirs if hex is not adjacent
-	if !isAdjacent && !a.UseSkip {
-		factionType := player.Faction.GetType()
-		if factionType == models.FactionDwarves || factionType == models.FactionFakirs {
-			// Automatically enable skip ability for these factions when hex is not adjacent
-			a.UseSkip = true
-		}
-	}
+	// Skip is used exactly when Dwarves/Fakirs target a hex that is not directly reachable
+	a.UseSkip = resolveSkipUsage(player, isAdjacent, a.UseSkip)
 
 	// If using skip (Fakirs/Dwarves), check if player can skip and if range is valid
 	if a.UseSkip {
@@ -238,14 +237,14 @@ func (a *TransformAndBuildAction) validateAdjacency(gs *GameState, player *Playe
 		if !isAdjacent &&
 			!a.canUseCleanupCultRewardSpadeWithoutAdjacency(gs, player, mapHex) &&
 			!gs.isReplayCultSpadeBuildHex(a.PlayerID, a.TargetHex) {
-			return fmt.Errorf("hex is not adjacent to player's buildings")
+			return ruleErrorf(ReasonNotReachable, "hex is not adjacent to player's buildings")
 		}
 	}
 	return nil
 }
 
 func (a *TransformAndBuildAction) canUseCleanupCultRewardSpadeWithoutAdjacency(gs *GameState, player *Player, mapHex *board.MapHex) bool {
-	if gs == nil || player == nil || mapHex == nil || a.BuildDwelling || gs.Phase != PhaseCleanup {
+	if gs == nil || player == nil || mapHex == nil || a.BuildDwelling || gs.Phases().Current() != PhaseCleanup {
 		return false
 	}
 	if gs.PendingCultRewardSpades == nil || gs.PendingCultRewardSpades[a.PlayerID] <= 0 {
@@ -318,7 +317,7 @@ func (a *TransformAndBuildAction) calculateCosts(gs *GameState, player *Player,
 			switch {
 			case isDragonlords(player):
 				if player.Resources.Power.TotalPower() < volcanoTransformCost(gs, player, mapHex.Terrain) {
-					return 0, 0, 0, 0, fmt.Errorf("not enough power tokens for volcano transform")
+					return 0, 0, 0, 0, ruleErrorf(ReasonInsufficientPower, "not enough power tokens for volcano transform")
 				}
 			case isAcolytes(player):
 				if _, ok := gs.acolytesCultPaymentTrackForSelection(player, acolytesCultTransformCost(gs, player, mapHex.Terrain), a.AcolytesCultTrack); !ok {
@@ -326,7 +325,7 @@ func (a *TransformAndBuildAction) calculateCosts(gs *GameState, player *Player,
 				}
 			case isFirewalkers(player):
 				if firewalkersAvailableVP(player) < firewalkersVPTransformCost(gs, player, mapHex.Terrain) {
-					return 0, 0, 0, 0, fmt.Errorf("not enough available victory points for lava transform")
+					return 0, 0, 0, 0, ruleErrorf(ReasonInsufficientVP, "not enough available victory points for lava transform")
 				}
 			default:
 				return 0, 0, 0, 0, fmt.Errorf("only volcano factions may transform to volcano")
@@ -448,7 +447,7 @@ func (a *TransformAndBuildAction) validateDwelling(gs *GameState, player *Player
 	// Check if player can afford dwelling (coins and priests)
 	dwellingCost := getDwellingBuildCost(gs, player, a.TargetHex)
 	if !gs.canAffordWithReplayAutoConversions(player, dwellingCost) {
-		return fmt.Errorf("not enough resources for dwelling: need %v, have %v", dwellingCost, player.Resources)
+		return ruleErrorf(ReasonInsufficientResources, "not enough resources for dwelling: need %v, have %v", dwellingCost, player.Resources)
 	}
 	return nil
 }
@@ -567,6 +566,7 @@ func (a *TransformAndBuildAction) handleTransform(gs *GameState, player *Player,
 		requiredSpades = 2
 	}
 	requiredSpades = adjustRequiredSpadesForArchitects(gs, player, a.TargetHex, requiredSpades, a.BuildDwelling)
+	gs.recordPreviewSpades(requiredSpades)
 
 	// Check for free spades from BON1 (count for VP when used)
 	vpEligibleFreeSpades := 0
@@ -651,7 +651,7 @@ func (a *TransformAndBuildAction) handleTransform(gs *GameState, player *Player,
 			player.VictoryPoints += vpBonus
 		} else if isProspectors(player) {
 			player.Resources.Coins -= remainingSpades * getProspectorsGoldenSpadeCost(player)
-			player.Resources.GainPower(remainingSpades)
+			gs.GainPowerForReason(player.ID, remainingSpades, PowerGainSpades, "")
 			player.VictoryPoints += remainingSpades
 		} else if player.Faction.GetType() == models.FactionTheEnlightened {
 			powerCost := player.Faction.GetTerraformCost(remainingSpades)
@@ -697,7 +697,7 @@ func (a *TransformAndBuildAction) handleTransform(gs *GameState, player *Player,
 		}
 
 		// Award faction-specific spade bonuses (Halflings VP, Alchemists power)
-		AwardFactionSpadeBonuses(player, spadesForVP)
+		AwardFactionSpadeBonuses(gs, player, spadesForVP)
 	}
 
 	// Award faction-specific spade bonuses for cult reward spades too
@@ -707,7 +707,7 @@ func (a *TransformAndBuildAction) handleTransform(gs *GameState, player *Player,
 		if player.Faction.GetType() == models.FactionGiants {
 			spadesUsed = 2
 		}
-		AwardFactionSpadeBonuses(player, spadesUsed)
+		AwardFactionSpadeBonuses(gs, player, spadesUsed)
 	}
 	return nil
 }
@@ -796,7 +796,7 @@ func (a *UpgradeBuildingAction) Validate(gs *GameState) error {
 	cost := getUpgradeCost(gs, player, mapHex, a.NewBuildingType)
 
 	if !gs.canAffordWithReplayAutoConversions(player, cost) {
-		return fmt.Errorf("cannot afford upgrade to %v", a.NewBuildingType)
+		return ruleErrorf(ReasonInsufficientResources, "cannot afford upgrade to %v", a.NewBuildingType)
 	}
 
 	return nil
@@ -850,11 +850,8 @@ func (a *UpgradeBuildingAction) Execute(gs *GameState) error {
 func (a *UpgradeBuildingAction) handleUpgradeRewards(gs *GameState, player *Player) {
 	switch a.NewBuildingType {
 	case models.BuildingTradingHouse:
-		// Award VP from Water+1 favor tile (+3 VP when upgrading Dwelling→Trading House)
-		playerTiles := gs.FavorTiles.GetPlayerTiles(a.PlayerID)
-		if HasFavorTile(playerTiles, FavorWater1) {
-			player.VictoryPoints += 3
-		}
+		// Award VP from favor tiles (Water+1: +3 VP when upgrading Dwelling→Trading House)
+		player.VictoryPoints += gs.FavorModifiers(a.PlayerID).TradingHouseVP
 
 		// Award VP from scoring tile
 		gs.AwardActionVP(a.PlayerID, ScoringActionTradingHouse)
@@ -918,7 +915,7 @@ func (a *UpgradeBuildingAction) handleStrongholdBonuses(gs *GameState, player *P
 		// Alchemists gain 12 power immediately when building stronghold
 		if alchemists, ok := player.Faction.(*factions.Alchemists); ok {
 			powerBonus := alchemists.BuildStronghold()
-			player.Resources.GainPower(powerBonus)
+			gs.GainPowerForReason(player.ID, powerBonus, PowerGainStronghold, "")
 		}
 	case models.FactionCultists:
 		// Cultists get +7 VP immediately when building stronghold
@@ -1003,9 +1000,9 @@ func (a *UpgradeBuildingAction) handleStrongholdBonuses(gs *GameState, player *P
 			}
 		}
 	case models.FactionChildrenOfTheWyrm:
-		player.Resources.Power.Bowl1 += gs.childrenRemovedPowerTokenCount(a.PlayerID)
+		gs.GainPowerTokensForReason(a.PlayerID, 1, gs.childrenRemovedPowerTokenCount(a.PlayerID), PowerGainStronghold, "")
 	case models.FactionDragonlords:
-		player.Resources.Power.Bowl1 += len(gs.Players)
+		gs.GainPowerTokensForReason(a.PlayerID, 1, len(gs.Players), PowerGainStronghold, "")
 	case models.FactionSnowShamans:
 		gs.grantSnowShamansStrongholdDwellings(a.PlayerID)
 	case models.FactionProspectors:
@@ -1176,6 +1173,9 @@ func (a *AdvanceShippingAction) Validate(gs *GameState) error {
 	if player.Faction.GetType() == models.FactionSnowShamans {
 		return fmt.Errorf("snow shamans advance shipping only when passing")
 	}
+	if player.Faction.GetType() == models.FactionFakirs {
+		return fmt.Errorf("fakirs cannot advance shipping")
+	}
 
 	// Check if already at max level
 	if player.ShippingLevel >= 5 {
@@ -1185,7 +1185,7 @@ func (a *AdvanceShippingAction) Validate(gs *GameState) error {
 	// Check if player can afford shipping upgrade
 	cost := player.Faction.GetShippingCost(player.ShippingLevel)
 	if !gs.canAffordWithReplayAutoConversions(player, cost) {
-		return fmt.Errorf("cannot afford shipping upgrade")
+		return ruleErrorf(ReasonInsufficientResources, "cannot afford shipping upgrade")
 	}
 
 	return nil
@@ -1253,7 +1253,7 @@ func (a *AdvanceDiggingAction) Validate(gs *GameState) error {
 	// Check if player can afford digging upgrade
 	cost := player.Faction.GetDiggingCost(player.DiggingLevel)
 	if !gs.canAffordWithReplayAutoConversions(player, cost) {
-		return fmt.Errorf("cannot afford digging upgrade")
+		return ruleErrorf(ReasonInsufficientResources, "cannot afford digging upgrade")
 	}
 
 	return nil
@@ -1287,6 +1287,11 @@ type PassAction struct {
 	BaseAction
 	BonusCard          *BonusCardType          // Bonus card selection (required)
 	SnowShamansUpgrade *SnowShamansPassUpgrade // Optional pass upgrade choice for Snow Shamans
+	Confirmed          bool                    // Player confirmed the pass (see PlayerOptions.ConfirmPass)
+	// CardCoins is how many coins lay on BonusCard when it was taken, as logs
+	// show them ("PASS-BON-4C+1C"). It only annotates the log: Execute claims
+	// the coins actually on the card.
+	CardCoins int
 }
 
 // NewPassAction creates a new pass action
@@ -1386,7 +1391,7 @@ func (a *PassAction) Execute(gs *GameState) error {
 				return fmt.Errorf("failed to take archivists first bonus card: %w", err)
 			}
 			player.Resources.Coins += coins
-			player.Resources.Power.GainPower(coins * 2)
+			gs.GainPowerForReason(a.PlayerID, coins*2, PowerGainBonusCard, "")
 			gs.PendingArchivistsBonusSelection = &PendingArchivistsBonusSelection{
 				PlayerID:      a.PlayerID,
 				ReturnedCards: returnedCards,
@@ -1400,7 +1405,7 @@ func (a *PassAction) Execute(gs *GameState) error {
 		}
 		player.Resources.Coins += coins
 		if isArchivists(player) {
-			player.Resources.Power.GainPower(coins * 2)
+			gs.GainPowerForReason(a.PlayerID, coins*2, PowerGainBonusCard, "")
 		}
 	}
 
@@ -1441,19 +1446,9 @@ func applyPostPassBonuses(gs *GameState, player *Player) {
 		return
 	}
 
-	// Award VP from Air+1 favor tile (VP based on Trading House count).
-	playerTiles := gs.FavorTiles.GetPlayerTiles(player.ID)
-	if HasFavorTile(playerTiles, FavorAir1) {
-		tradingHouseCount := 0
-		for _, mapHex := range gs.Map.Hexes {
-			if mapHex.Building != nil &&
-				mapHex.Building.PlayerID == player.ID &&
-				mapHex.Building.Type == models.BuildingTradingHouse {
-				tradingHouseCount++
-			}
-		}
-
-		player.VictoryPoints += GetAir1PassVP(playerTiles, tradingHouseCount)
+	// Award VP from favor tiles (Air+1: VP based on Trading House count).
+	if favor := gs.FavorModifiers(player.ID); len(favor.TradingHousePassVP) > 0 {
+		player.VictoryPoints += favor.PassVP(countBuildings(gs, player.ID, models.BuildingTradingHouse))
 	}
 
 	// Award VP for Engineers stronghold ability (3 VP per bridge connecting two structures when passing).
@@ -1569,12 +1564,7 @@ func advanceAfterRoundComplete(gs *GameState) {
 	if gs.ExecuteCleanupPhase() {
 		gs.StartNewRound()
 		gs.AwardCultRewardsForRound(justCompletedRound)
-		if _, count := gs.GetPendingCultRewardSpadePlayer(); count == 0 {
-			gs.GrantIncome()
-			if !gs.HasPendingIncomeDecisions() {
-				gs.StartActionPhase()
-			}
-		}
+		gs.startCultRewardSpadePhase()
 	}
 }
 
@@ -1604,7 +1594,7 @@ func (a *SendPriestToCultAction) Validate(gs *GameState) error {
 
 	// Check if player has a priest
 	if player.Resources.Priests < 1 {
-		return fmt.Errorf("not enough priests: need 1, have %d", player.Resources.Priests)
+		return ruleErrorf(ReasonInsufficientPriests, "not enough priests: need 1, have %d", player.Resources.Priests)
 	}
 
 	// Validate spaces to climb
@@ -1667,6 +1657,24 @@ func (a *SendPriestToCultAction) Execute(gs *GameState) error {
 	return nil
 }
 
+// resolveSkipUsage decides whether a placement uses carpet flight/tunneling.
+// Fakirs and Dwarves use their skip exactly when the target hex is not
+// reachable by normal adjacency, whether or not it was requested, so the
+// priest or worker cost and the 4 VP bonus apply only when the skip was
+// actually needed. Other factions keep the requested value so
+// ValidateSkipAbility can reject it.
+func resolveSkipUsage(player *Player, isAdjacent, requested bool) bool {
+	if player == nil || player.Faction == nil {
+		return requested
+	}
+	switch player.Faction.GetType() {
+	case models.FactionDwarves, models.FactionFakirs:
+		return !isAdjacent
+	default:
+		return requested
+	}
+}
+
 // ValidateSkipAbility checks if a player can use their faction's skip ability (Carpet Flight/Tunneling)
 // Returns error if not valid, or nil if valid.
 // Also validates if the player has enough resources (but does not spend them).
@@ -1686,14 +1694,18 @@ func ValidateSkipAbility(gs *GameState, player *Player, targetHex board.Hex) err
 	// This function also checks for resource costs but does not deduct them.
 	switch f := player.Faction.(type) {
 	case *factions.Fakirs:
+		// Carpet flight skips over river spaces but can never land on one
+		if mapHex := gs.Map.GetHex(targetHex); gs.Map.IsRiver(targetHex) || (mapHex != nil && mapHex.Terrain == models.TerrainRiver) {
+			return fmt.Errorf("carpet flight cannot target a river hex")
+		}
 		// Calculate skip range for Fakirs
 		skipRange := f.GetFlightRange()
 		if !gs.Map.IsWithinSkipRange(targetHex, player.ID, skipRange) {
 			return fmt.Errorf("target hex is not within carpet flight range %d", skipRange)
 		}
-		// Check if player has priest to pay
+		// Check if player has priest to pay (priests in supply cannot be borrowed)
 		if player.Resources.Priests < 1 {
-			return fmt.Errorf("not enough priests for carpet flight: need 1, have %d", player.Resources.Priests)
+			return ruleErrorf(ReasonInsufficientPriests, "not enough priests for carpet flight: need 1, have %d", player.Resources.Priests)
 		}
 	case *factions.Dwarves:
 		// Dwarves can tunnel 1 space
@@ -1706,7 +1718,7 @@ func ValidateSkipAbility(gs *GameState, player *Player, targetHex board.Hex) err
 			workerCost = 1
 		}
 		if player.Resources.Workers < workerCost {
-			return fmt.Errorf("not enough workers for tunneling: need %d, have %d", workerCost, player.Resources.Workers)
+			return ruleErrorf(ReasonInsufficientWorkers, "not enough workers for tunneling: need %d, have %d", workerCost, player.Resources.Workers)
 		}
 	default:
 		return fmt.Errorf("only Fakirs and Dwarves can use skip ability")
@@ -1717,7 +1729,8 @@ func ValidateSkipAbility(gs *GameState, player *Player, targetHex board.Hex) err
 // PaySkipCost deducts the cost for using skip ability and awards VP
 func PaySkipCost(player *Player) {
 	if player.Faction.GetType() == models.FactionFakirs {
-		// Pay priest for carpet flight
+		// Pay priest for carpet flight. The priest returns to the general supply,
+		// so it no longer counts toward the player's 7-priest limit.
 		player.Resources.Priests--
 		// Award VP bonus
 		player.VictoryPoints += 4
@@ -0,0 +1,152 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// GameSummary is the admin overview of one game.
+type GameSummary struct {
+	ID              string      `json:"id"`
+	Phase           GamePhase   `json:"phase"`
+	Round           int         `json:"round"`
+	Revision        int         `json:"revision"`
+	Players         []string    `json:"players"`
+	PendingDecision interface{} `json:"pendingDecision"`
+	LeechWait       *LeechWait  `json:"leechWait,omitempty"`
+	LastActivity    time.Time   `json:"lastActivity"`
+}
+
+// ForcedResolution names an admin override for a stuck pending decision.
+type ForcedResolution string
+
+const (
+	// ForceDeclineLeeches declines every outstanding leech offer.
+	ForceDeclineLeeches ForcedResolution = "decline_leeches"
+	// ForceConfirmTurn confirms a pending turn confirmation or free-action window.
+	ForceConfirmTurn ForcedResolution = "confirm_turn"
+)
+
+// maxForcedResolutionSteps guards against resolutions that never clear.
+const maxForcedResolutionSteps = 64
+
+// ListGameSummaries returns an overview of every game, sorted by ID.
+func (m *Manager) ListGameSummaries() []GameSummary {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := make([]GameSummary, 0, len(m.games))
+	for id, gs := range m.games {
+		if gs == nil {
+			continue
+		}
+		out = append(out, GameSummary{
+			ID:              id,
+			Phase:           gs.Phase,
+			Round:           gs.Round,
+			Revision:        m.revisions[id],
+			Players:         append([]string(nil), gs.TurnOrder...),
+			PendingDecision: serializePendingDecision(gs),
+			LeechWait:       gs.LeechWait(),
+			LastActivity:    m.lastActivity[id],
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// DumpGameState returns the full internal state of a game as JSON, including
+// every pending* struct, along with its revision.
+func (m *Manager) DumpGameState(gameID string) (json.RawMessage, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	gs := m.games[gameID]
+	if gs == nil {
+		return nil, 0, fmt.Errorf("game %s not found", gameID)
+	}
+	raw, err := json.Marshal(gs)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to encode game %s: %w", gameID, err)
+	}
+	return raw, m.revisions[gameID], nil
+}
+
+// ForceResolvePending clears a stuck pending decision on behalf of the players
+// it is waiting on. Each step runs as a normal recorded action, so the game
+// stays exportable. It returns the resulting revision and the results of the
+// steps taken, whose events callers broadcast.
+func (m *Manager) ForceResolvePending(gameID string, resolution ForcedResolution) (int, []*ActionResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	gs := m.games[gameID]
+	if gs == nil {
+		return 0, nil, fmt.Errorf("game %s not found", gameID)
+	}
+	if resolution != ForceDeclineLeeches && resolution != ForceConfirmTurn {
+		return m.revisions[gameID], nil, fmt.Errorf("unknown resolution %q", resolution)
+	}
+
+	var results []*ActionResult
+	steps := 0
+	for ; steps < maxForcedResolutionSteps; steps++ {
+		decision, _ := serializePendingDecision(gs).(map[string]interface{})
+		decisionType, _ := decision["type"].(string)
+		playerID, _ := decision["playerId"].(string)
+
+		var (
+			action Action
+			record RecordedAction
+		)
+		switch {
+		case resolution == ForceDeclineLeeches:
+			playerID = nextLeechResponderForAdmin(gs)
+			if playerID == "" {
+				break
+			}
+			action = NewDeclinePowerLeechAction(playerID, 0)
+			record = RecordedAction{PlayerID: playerID, Type: "decline_leech", Params: json.RawMessage(`{"offerIndex":0}`)}
+		case resolution == ForceConfirmTurn && (decisionType == "post_action_free_actions" || decisionType == "turn_confirmation"):
+			action = NewConfirmTurnAction(playerID)
+			record = RecordedAction{PlayerID: playerID, Type: "confirm_turn"}
+		}
+		if action == nil {
+			break
+		}
+		result, err := m.executeDrainedLocked(gameID, action, ActionMeta{
+			ExpectedRevision: -1,
+			SeatID:           playerID,
+			Record:           &record,
+		})
+		if err != nil {
+			return m.revisions[gameID], results, fmt.Errorf("forced %s failed for %s: %w", resolution, playerID, err)
+		}
+		results = append(results, result)
+	}
+	if steps == 0 {
+		return m.revisions[gameID], nil, fmt.Errorf("no pending decision matches %q", resolution)
+	}
+	return m.revisions[gameID], results, nil
+}
+
+// nextLeechResponderForAdmin prefers the blocking responder, then any player
+// with an outstanding offer.
+func nextLeechResponderForAdmin(gs *GameState) string {
+	if playerID := gs.GetNextBlockingLeechResponder(); playerID != "" {
+		return playerID
+	}
+	playerIDs := make([]string, 0, len(gs.PendingLeechOffers))
+	for playerID, offers := range gs.PendingLeechOffers {
+		if len(offers) > 0 {
+			playerIDs = append(playerIDs, playerID)
+		}
+	}
+	sort.Strings(playerIDs)
+	if len(playerIDs) == 0 {
+		return ""
+	}
+	return playerIDs[0]
+}
@@ -0,0 +1,185 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+// AnnotationKind is the shape of a board annotation.
+type AnnotationKind string
+
+const (
+	// AnnotationPlannedBuild marks a building planned on Hex.
+	AnnotationPlannedBuild AnnotationKind = "planned_build"
+	// AnnotationArrow points from Hex to To.
+	AnnotationArrow AnnotationKind = "arrow"
+)
+
+// AnnotationVisibility selects who sees a board annotation.
+type AnnotationVisibility string
+
+const (
+	// AnnotationVisibilityPrivate shows the annotation to its author only.
+	AnnotationVisibilityPrivate AnnotationVisibility = "private"
+	// AnnotationVisibilityTeam shows the annotation to the author's side of
+	// the table: the seated players, or the spectators.
+	AnnotationVisibilityTeam AnnotationVisibility = "team"
+	// AnnotationVisibilityAll shows the annotation to everyone in the game.
+	AnnotationVisibilityAll AnnotationVisibility = "all"
+)
+
+const (
+	// maxAnnotationsPerGame bounds the annotations a game keeps; the oldest go
+	// first.
+	maxAnnotationsPerGame = 200
+	// maxAnnotationLabelLen and maxAnnotationAuthorLen bound the free-form
+	// strings an annotation carries, in bytes.
+	maxAnnotationLabelLen  = 80
+	maxAnnotationAuthorLen = 64
+)
+
+// annotationBuildings are the buildings a planned build may name.
+var annotationBuildings = map[string]bool{
+	"dwelling":      true,
+	"trading_house": true,
+	"temple":        true,
+	"sanctuary":     true,
+	"stronghold":    true,
+}
+
+// BoardAnnotation is a mark a player or spectator put on a game's board, such
+// as a planned build or an arrow. Annotations are not part of the game: they
+// are kept only while the game is loaded and are never saved or replayed.
+type BoardAnnotation struct {
+	ID         string               `json:"id"`
+	Kind       AnnotationKind       `json:"kind"`
+	Visibility AnnotationVisibility `json:"visibility"`
+	// AuthorID is the author's seat, or the connection of a spectator.
+	AuthorID  string     `json:"authorId"`
+	Spectator bool       `json:"spectator,omitempty"`
+	Hex       models.Hex `json:"hex"`
+	// To is where an arrow points.
+	To *models.Hex `json:"to,omitempty"`
+	// Building is the planned building of a planned build.
+	Building string `json:"building,omitempty"`
+	Label    string `json:"label,omitempty"`
+	// CreatedAtMs is the Unix time in milliseconds the annotation was placed.
+	CreatedAtMs int64 `json:"createdAtMs"`
+}
+
+// Validate checks the annotation's kind, visibility, building and hexes
+// against gs's map, and bounds its strings.
+func (a BoardAnnotation) Validate(gs *GameState) error {
+	switch a.Visibility {
+	case AnnotationVisibilityPrivate, AnnotationVisibilityTeam, AnnotationVisibilityAll:
+	default:
+		return fmt.Errorf("unknown annotation visibility: %q", a.Visibility)
+	}
+	if a.AuthorID == "" || len(a.AuthorID) > maxAnnotationAuthorLen {
+		return fmt.Errorf("annotation author must be 1 to %d characters", maxAnnotationAuthorLen)
+	}
+	hexes := []models.Hex{a.Hex}
+	switch a.Kind {
+	case AnnotationPlannedBuild:
+		if !annotationBuildings[a.Building] {
+			return fmt.Errorf("unknown planned building: %q", a.Building)
+		}
+	case AnnotationArrow:
+		if a.To == nil {
+			return fmt.Errorf("arrow annotation needs a target hex")
+		}
+		if a.Building != "" {
+			return fmt.Errorf("arrow annotation cannot name a building")
+		}
+		hexes = append(hexes, *a.To)
+	default:
+		return fmt.Errorf("unknown annotation kind: %q", a.Kind)
+	}
+	for _, hex := range hexes {
+		if gs.Map == nil || gs.Map.GetHex(board.Hex{Q: hex.Q, R: hex.R}) == nil {
+			return ruleErrorf(ReasonInvalidHex, "annotation hex %v is not on the map", hex)
+		}
+	}
+	if len(a.Label) > maxAnnotationLabelLen {
+		return fmt.Errorf("annotation label is longer than %d characters", maxAnnotationLabelLen)
+	}
+	return nil
+}
+
+// VisibleTo reports whether a viewer sees the annotation. viewerID is the
+// viewer's seat, or their connection when spectating.
+func (a BoardAnnotation) VisibleTo(viewerID string, spectator bool) bool {
+	switch a.Visibility {
+	case AnnotationVisibilityAll:
+		return true
+	case AnnotationVisibilityTeam:
+		return spectator == a.Spectator
+	default:
+		return spectator == a.Spectator && viewerID == a.AuthorID
+	}
+}
+
+// AddAnnotation validates annotation and stores it on gameID under a new ID,
+// returning the stored annotation.
+func (m *Manager) AddAnnotation(gameID string, annotation BoardAnnotation) (BoardAnnotation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	gs, ok := m.games[gameID]
+	if !ok || gs == nil {
+		return BoardAnnotation{}, fmt.Errorf("game not found: %s", gameID)
+	}
+	annotation.Building = strings.ToLower(strings.TrimSpace(annotation.Building))
+	annotation.Label = strings.TrimSpace(annotation.Label)
+	if err := annotation.Validate(gs); err != nil {
+		return BoardAnnotation{}, err
+	}
+	m.annotationSeq[gameID]++
+	annotation.ID = "a" + strconv.Itoa(m.annotationSeq[gameID])
+	annotation.CreatedAtMs = m.now().UnixMilli()
+
+	annotations := append(m.annotations[gameID], annotation)
+	if len(annotations) > maxAnnotationsPerGame {
+		annotations = annotations[len(annotations)-maxAnnotationsPerGame:]
+	}
+	m.annotations[gameID] = annotations
+	return annotation, nil
+}
+
+// RemoveAnnotation deletes annotation id from gameID if authorID placed it,
+// returning the removed annotation.
+func (m *Manager) RemoveAnnotation(gameID, id, authorID string, spectator bool) (BoardAnnotation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	annotations := m.annotations[gameID]
+	for i, annotation := range annotations {
+		if annotation.ID != id {
+			continue
+		}
+		if annotation.AuthorID != authorID || annotation.Spectator != spectator {
+			return BoardAnnotation{}, fmt.Errorf("annotation %s belongs to another author", id)
+		}
+		m.annotations[gameID] = append(annotations[:i:i], annotations[i+1:]...)
+		return annotation, nil
+	}
+	return BoardAnnotation{}, fmt.Errorf("annotation not found: %s", id)
+}
+
+// Annotations returns gameID's annotations a viewer sees, oldest first.
+func (m *Manager) Annotations(gameID, viewerID string, spectator bool) []BoardAnnotation {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	visible := []BoardAnnotation{}
+	for _, annotation := range m.annotations[gameID] {
+		if annotation.VisibleTo(viewerID, spectator) {
+			visible = append(visible, annotation)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/models"
+)
+
+func TestAnnotations_VisibilityAndOwnership(t *testing.T) {
+	mgr := NewManager()
+	if err := mgr.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	gs, _ := mgr.GetGame("g1")
+	var hex models.Hex
+	for h := range gs.Map.Hexes {
+		hex = models.Hex{Q: h.Q, R: h.R}
+		break
+	}
+
+	add := func(author string, spectator bool, visibility AnnotationVisibility) BoardAnnotation {
+		t.Helper()
+		annotation, err := mgr.AddAnnotation("g1", BoardAnnotation{
+			Kind:       AnnotationPlannedBuild,
+			Visibility: visibility,
+			AuthorID:   author,
+			Spectator:  spectator,
+			Hex:        hex,
+			Building:   "dwelling",
+		})
+		if err != nil {
+			t.Fatalf("add annotation: %v", err)
+		}
+		return annotation
+	}
+	private := add("p1", false, AnnotationVisibilityPrivate)
+	add("p1", false, AnnotationVisibilityAll)
+	add("p1", false, AnnotationVisibilityTeam)
+	add("spectator-1", true, AnnotationVisibilityPrivate)
+	add("spectator-1", true, AnnotationVisibilityTeam)
+	add("spectator-1", true, AnnotationVisibilityAll)
+
+	for _, tt := range []struct {
+		viewer    string
+		spectator bool
+		want      int
+	}{
+		{viewer: "p1", want: 4},
+		{viewer: "p2", want: 3},
+		{viewer: "spectator-1", spectator: true, want: 4},
+		{viewer: "spectator-2", spectator: true, want: 3},
+	} {
+		if got := mgr.Annotations("g1", tt.viewer, tt.spectator); len(got) != tt.want {
+			t.Fatalf("%s: expected %d annotations, got %+v", tt.viewer, tt.want, got)
+		}
+	}
+
+	if _, err := mgr.AddAnnotation("g1", BoardAnnotation{Kind: AnnotationArrow, Visibility: AnnotationVisibilityAll, AuthorID: "p1", Hex: hex}); err == nil {
+		t.Fatalf("expected an arrow without a target to be rejected")
+	}
+	if _, err := mgr.AddAnnotation("g1", BoardAnnotation{Kind: AnnotationPlannedBuild, Visibility: AnnotationVisibilityAll, AuthorID: "p1", Hex: models.Hex{Q: 99, R: 99}, Building: "dwelling"}); err == nil {
+		t.Fatalf("expected an off-map annotation to be rejected")
+	}
+	for _, invalid := range []BoardAnnotation{
+		{Kind: AnnotationPlannedBuild, Visibility: AnnotationVisibilityAll, AuthorID: "p1", Hex: hex, Building: "castle"},
+		{Kind: AnnotationPlannedBuild, Visibility: "side", AuthorID: "p1", Hex: hex, Building: "temple"},
+		{Kind: AnnotationPlannedBuild, Visibility: AnnotationVisibilityAll, AuthorID: "p1", Hex: hex, Building: "temple", Label: strings.Repeat("x", maxAnnotationLabelLen+1)},
+		{Kind: AnnotationArrow, Visibility: AnnotationVisibilityAll, AuthorID: "p1", Hex: hex, To: &hex, Building: "temple"},
+	} {
+		if _, err := mgr.AddAnnotation("g1", invalid); err == nil {
+			t.Fatalf("expected %+v to be rejected", invalid)
+		}
+	}
+	if _, err := mgr.RemoveAnnotation("g1", private.ID, "p2", false); err == nil {
+		t.Fatalf("expected p2 not to remove p1's annotation")
+	}
+	if _, err := mgr.RemoveAnnotation("g1", private.ID, "p1", false); err != nil {
+		t.Fatalf("remove annotation: %v", err)
+	}
+	if got := mgr.Annotations("g1", "p1", false); len(got) != 3 {
+		t.Fatalf("expected the private annotation to be gone, got %+v", got)
+	}
+}
@@ -0,0 +1,264 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// ErrGameNotArchived is returned by GameArchive.Load for unknown games.
+var ErrGameNotArchived = errors.New("game not archived")
+
+// GameArchive stores finished games outside the Manager's memory.
+type GameArchive interface {
+	Store(save *SaveFile) error
+	// Load returns the archived save file of gameID, or ErrGameNotArchived.
+	Load(gameID string) (*SaveFile, error)
+}
+
+// FileArchive is a GameArchive keeping one JSON save file per game.
+type FileArchive struct {
+	dir string
+}
+
+// NewFileArchive returns a FileArchive rooted at dir. The directory is created
+// on the first Store.
+func NewFileArchive(dir string) *FileArchive {
+	return &FileArchive{dir: dir}
+}
+
+func (a *FileArchive) path(gameID string) string {
+	return filepath.Join(a.dir, url.PathEscape(gameID)+".json")
+}
+
+// Store writes save, replacing any earlier archive of the same game.
+func (a *FileArchive) Store(save *SaveFile) error {
+	if save == nil || save.GameID == "" {
+		return fmt.Errorf("missing save file")
+	}
+	raw, err := json.Marshal(save)
+	if err != nil {
+		return fmt.Errorf("failed to encode game %s: %w", save.GameID, err)
+	}
+	if err := os.MkdirAll(a.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(a.dir, ".archive-*")
+	if err != nil {
+		return fmt.Errorf("failed to archive game %s: %w", save.GameID, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to archive game %s: %w", save.GameID, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to archive game %s: %w", save.GameID, err)
+	}
+	if err := os.Rename(tmp.Name(), a.path(save.GameID)); err != nil {
+		return fmt.Errorf("failed to archive game %s: %w", save.GameID, err)
+	}
+	return nil
+}
+
+// Load reads the archived save file of gameID.
+func (a *FileArchive) Load(gameID string) (*SaveFile, error) {
+	raw, err := os.ReadFile(a.path(gameID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrGameNotArchived
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archived game %s: %w", gameID, err)
+	}
+	var save SaveFile
+	if err := json.Unmarshal(raw, &save); err != nil {
+		return nil, fmt.Errorf("failed to decode archived game %s: %w", gameID, err)
+	}
+	return &save, nil
+}
+
+// ArchivePolicy configures how finished games leave the Manager's memory.
+type ArchivePolicy struct {
+	Archive GameArchive
+	// BuildAction rebuilds recorded actions when an archived game is loaded
+	// back.
+	BuildAction RecordedActionBuilder
+	// EvictAfter is how long a finished game stays in memory after its last
+	// activity.
+	EvictAfter time.Duration
+}
+
+// SetArchivePolicy enables archiving finished games and loading them back on
+// demand. A nil policy keeps every game in memory.
+func (m *Manager) SetArchivePolicy(policy *ArchivePolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.archivePolicy = policy
+}
+
+// SweepFinishedGames archives finished games that have not been archived at
+// their current revision and drops archived games idle for longer than
+// EvictAfter. Games without a replayable history are never evicted. Save files
+// are exported under the manager's lock but written outside it, so a slow
+// archive does not hold up other games. It returns the evicted game IDs,
+// sorted. A game that fails to archive is reported once and retried only
+// after it changes.
+func (m *Manager) SweepFinishedGames() ([]string, error) {
+	m.mu.Lock()
+	policy := m.archivePolicy
+	if policy == nil || policy.Archive == nil {
+		m.mu.Unlock()
+		return nil, nil
+	}
+	type pendingArchive struct {
+		save     *SaveFile
+		revision int
+	}
+	var (
+		pending []pendingArchive
+		errs    []error
+	)
+	for id, gs := range m.games {
+		if gs == nil || gs.Phase != PhaseEnd {
+			continue
+		}
+		if revision, ok := m.archivedRevision[id]; ok && revision == m.revisions[id] {
+			continue
+		}
+		if revision, ok := m.archiveFailed[id]; ok && revision == m.revisions[id] {
+			continue
+		}
+		save, err := m.exportGameLocked(id)
+		if err != nil {
+			m.archiveFailed[id] = m.revisions[id]
+			errs = append(errs, err)
+			continue
+		}
+		pending = append(pending, pendingArchive{save: save, revision: m.revisions[id]})
+	}
+	m.mu.Unlock()
+
+	stored := make(map[string]int, len(pending))
+	failed := make(map[string]int)
+	for _, archive := range pending {
+		if err := policy.Archive.Store(archive.save); err != nil {
+			failed[archive.save.GameID] = archive.revision
+			errs = append(errs, err)
+			continue
+		}
+		stored[archive.save.GameID] = archive.revision
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, revision := range stored {
+		delete(m.archiveMisses, id)
+		if _, ok := m.games[id]; ok {
+			m.archivedRevision[id] = revision
+			delete(m.archiveFailed, id)
+		}
+	}
+	for id, revision := range failed {
+		if _, ok := m.games[id]; ok {
+			m.archiveFailed[id] = revision
+		}
+	}
+	now := m.now()
+	var evicted []string
+	for id, gs := range m.games {
+		if gs == nil || gs.Phase != PhaseEnd {
+			continue
+		}
+		// A game that changed while it was being written is archived again
+		// by the next sweep before it may leave memory.
+		if revision, ok := m.archivedRevision[id]; !ok || revision != m.revisions[id] {
+			continue
+		}
+		if now.Sub(m.lastActivity[id]) >= policy.EvictAfter {
+			m.dropGameLocked(id)
+			evicted = append(evicted, id)
+		}
+	}
+	sort.Strings(evicted)
+	return evicted, errors.Join(errs...)
+}
+
+// maxArchiveMisses bounds Manager.archiveMisses; the misses are forgotten
+// once there are more.
+const maxArchiveMisses = 10000
+
+// rehydrate loads an evicted game back from the archive. Lookups of games
+// that are in memory, or when no archive is configured, are no-ops, and so are
+// lookups of games the archive does not have. It returns the error of a game
+// that is archived but cannot be loaded back. Misses are remembered, so
+// repeated lookups of an unknown or broken game do not go back to the archive.
+func (m *Manager) rehydrate(gameID string) error {
+	m.mu.RLock()
+	policy := m.archivePolicy
+	_, loaded := m.games[gameID]
+	missErr, missed := m.archiveMisses[gameID]
+	m.mu.RUnlock()
+	if loaded || policy == nil || policy.Archive == nil || policy.BuildAction == nil {
+		return nil
+	}
+	if missed {
+		return missErr
+	}
+
+	imported, err := m.loadArchived(policy, gameID)
+	if errors.Is(err, ErrGameNotArchived) {
+		err = nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.games[gameID]; exists {
+		return nil
+	}
+	if imported == nil {
+		if len(m.archiveMisses) >= maxArchiveMisses {
+			clear(m.archiveMisses)
+		}
+		m.archiveMisses[gameID] = err
+		return err
+	}
+	m.addImportedGameLocked(gameID, imported)
+	m.archivedRevision[gameID] = imported.Revision
+	return nil
+}
+
+func (m *Manager) loadArchived(policy *ArchivePolicy, gameID string) (*ImportedGame, error) {
+	save, err := policy.Archive.Load(gameID)
+	if err != nil {
+		return nil, err
+	}
+	imported, err := ReplaySaveFile(save, policy.BuildAction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load archived game %s: %w", gameID, err)
+	}
+	return imported, nil
+}
+
+func (m *Manager) dropGameLocked(id string) {
+	delete(m.games, id)
+	delete(m.revisions, id)
+	delete(m.appliedActionID, id)
+	delete(m.setups, id)
+	delete(m.history, id)
+	delete(m.unrecorded, id)
+	delete(m.lastActivity, id)
+	delete(m.archivedRevision, id)
+	delete(m.archiveFailed, id)
+	delete(m.shadows, id)
+	delete(m.annotations, id)
+	delete(m.annotationSeq, id)
+	delete(m.eventTurn, id)
+	m.spadeTargetsMu.Lock()
+	delete(m.spadeTargets, id)
+	m.spadeTargetsMu.Unlock()
+}
@@ -57,12 +57,12 @@ func (as *AuctionState) NominateFaction(playerID string, faction models.FactionT
 	}
 
 	if !as.NominationPhase {
-		return fmt.Errorf("nomination phase is over")
+		return ruleErrorf(ReasonWrongPhase, "nomination phase is over")
 	}
 
 	// Verify it's this player's turn to nominate
 	if as.SeatOrder[as.NominationsComplete] != playerID {
-		return fmt.Errorf("not your turn to nominate")
+		return ruleErrorf(ReasonNotYourTurn, "not your turn to nominate")
 	}
 
 	// Check if faction already nominated
@@ -114,7 +114,7 @@ func (as *AuctionState) validateBid(playerID string, faction models.FactionType,
 	}
 
 	if as.NominationPhase {
-		return fmt.Errorf("still in nomination phase")
+		return ruleErrorf(ReasonWrongPhase, "still in nomination phase")
 	}
 
 	// Check if player already has a faction (skip them)
@@ -124,7 +124,7 @@ func (as *AuctionState) validateBid(playerID string, faction models.FactionType,
 
 	// Verify it's this player's turn
 	if as.SeatOrder[as.CurrentBidderIndex] != playerID {
-		return fmt.Errorf("not your turn to bid")
+		return ruleErrorf(ReasonNotYourTurn, "not your turn to bid")
 	}
 
 	// Check if faction was nominated
@@ -204,7 +204,7 @@ func (as *AuctionState) SubmitFastBids(playerID string, bids map[models.FactionT
 		return fmt.Errorf("fast bid submission is only available in fast auction mode")
 	}
 	if as.NominationPhase {
-		return fmt.Errorf("still in nomination phase")
+		return ruleErrorf(ReasonWrongPhase, "still in nomination phase")
 	}
 	if as.FastSubmitted[playerID] {
 		return fmt.Errorf("player already submitted fast auction bids")
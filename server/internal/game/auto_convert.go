@@ -18,15 +18,7 @@ func (gs *GameState) ApplyAutoConvertOnPass(playerID string) {
 	// 1) Priest overflow protection:
 	// If next-round priest income would overflow the 7-priest cap, convert the overflow
 	// from priests in hand to workers now.
-	priestsOnCult := 0
-	if gs.CultTracks != nil {
-		priestsOnCult = gs.CultTracks.GetTotalPriestsOnCultTracks(playerID)
-	}
-	maxPriestsGainable := 7 - (player.Resources.Priests + priestsOnCult)
-	if maxPriestsGainable < 0 {
-		maxPriestsGainable = 0
-	}
-	overflowPriests := preview.Priests - maxPriestsGainable
+	overflowPriests := preview.Priests - gs.PriestLedger(playerID).Gainable(preview.Priests)
 	if overflowPriests > 0 {
 		if overflowPriests > player.Resources.Priests {
 			overflowPriests = player.Resources.Priests
@@ -3,6 +3,7 @@ package game
 import "github.com/lukev/tm_server/internal/models"
 
 // ResolveAutoLeechOffers applies per-player auto-leech policy until the next manual decision point.
+// Every offer it answers is recorded as an AutoLeechDecision.
 func (gs *GameState) ResolveAutoLeechOffers() error {
 	if gs == nil {
 		return nil
@@ -22,13 +23,23 @@ func (gs *GameState) ResolveAutoLeechOffers() error {
 
 		handled := false
 		for offerIndex, offer := range offers {
-			accept, auto := gs.shouldAutoResolveLeechOffer(playerID, offer)
+			accept, auto, rule := gs.shouldAutoResolveLeechOffer(playerID, offer)
 			if !auto {
 				continue
 			}
+			decision := AutoLeechDecision{
+				PlayerID:     playerID,
+				FromPlayerID: offer.FromPlayerID,
+				Amount:       offer.Amount,
+				Accepted:     accept,
+				VPCost:       leechVPCost(gs.GetPlayer(playerID), offer.Amount),
+				Rule:         rule,
+				Round:        gs.Round,
+			}
 			if err := executePowerLeechOffer(gs, playerID, offerIndex, accept); err != nil {
 				return err
 			}
+			gs.recordAutoLeechDecision(decision)
 			handled = true
 			break
 		}
@@ -39,10 +50,10 @@ func (gs *GameState) ResolveAutoLeechOffers() error {
 	}
 }
 
-func (gs *GameState) shouldAutoResolveLeechOffer(playerID string, offer *PowerLeechOffer) (accept bool, auto bool) {
+func (gs *GameState) shouldAutoResolveLeechOffer(playerID string, offer *PowerLeechOffer) (accept bool, auto bool, rule AutoLeechRule) {
 	player := gs.GetPlayer(playerID)
 	if player == nil || offer == nil {
-		return false, false
+		return false, false, ""
 	}
 
 	mode := player.Options.AutoLeechMode
@@ -54,16 +65,36 @@ func (gs *GameState) shouldAutoResolveLeechOffer(playerID string, offer *PowerLe
 	if source := gs.GetPlayer(offer.FromPlayerID); source != nil && source.Faction != nil {
 		switch source.Faction.GetType() {
 		case models.FactionCultists, models.FactionShapeshifters:
-			return false, false
+			return false, false, ""
 		}
 	}
 
 	// Exception 2: if player already passed and next-round income fully saturates bowl III anyway,
 	// auto-decline all leech to avoid VP loss for no benefit.
 	if mode != LeechAutoModeOff && player.HasPassed && gs.willIncomeFullySaturatePower(playerID) {
-		return false, true
+		return false, true, AutoLeechRuleIncomeSaturates
+	}
+
+	vpCost := leechVPCost(player, offer.Amount)
+	// Standing preferences come before the mode.
+	prefs, fromAccount := gs.leechPreferencesFor(player)
+	accept, rule = prefs.decide(offer.Amount, vpCost, player.VictoryPoints)
+	auto = rule != ""
+	if auto && fromAccount {
+		gs.noteLeechPreferencesUsed(playerID, prefs)
+	}
+	if !auto {
+		accept, auto = autoLeechModeDecision(mode, offer)
+		rule = AutoLeechRuleMode
 	}
+	// Exception 3: never auto-accept an offer the player cannot pay for in VP.
+	if accept && vpCost > player.VictoryPoints {
+		return false, false, ""
+	}
+	return accept, auto, rule
+}
 
+func autoLeechModeDecision(mode LeechAutoMode, offer *PowerLeechOffer) (accept bool, auto bool) {
 	switch mode {
 	case LeechAutoModeOff:
 		return false, false
@@ -13,6 +13,27 @@ func mustAddPlayer(t *testing.T, gs *GameState, id string, faction factions.Fact
 	}
 }
 
+// testSeat is a player seated by newActionTestGame.
+type testSeat struct {
+	id      string
+	faction factions.Faction
+}
+
+// newActionTestGame seats players in turn order, the first one to act, in the
+// action phase of round.
+func newActionTestGame(t *testing.T, round int, seats ...testSeat) *GameState {
+	t.Helper()
+	gs := NewGameState()
+	for _, seat := range seats {
+		mustAddPlayer(t, gs, seat.id, seat.faction)
+		gs.TurnOrder = append(gs.TurnOrder, seat.id)
+	}
+	gs.CurrentPlayerIndex = 0
+	gs.Phase = PhaseAction
+	gs.Round = round
+	return gs
+}
+
 func TestResolveAutoLeechOffers_AcceptsWithinThreshold(t *testing.T) {
 	gs := NewGameState()
 	mustAddPlayer(t, gs, "src", factions.NewNomads())
@@ -240,3 +261,102 @@ func TestApplyAutoConvertOnPass_PowerToCoinOnlyWhenGuaranteedFullBowl(t *testing
 		})
 	}
 }
+
+func TestManager_ConfirmationOptionsRequireConfirmedPassAndBurn(t *testing.T) {
+	gs := NewGameState()
+	mustAddPlayer(t, gs, "p1", factions.NewWitches())
+	mustAddPlayer(t, gs, "p2", factions.NewNomads())
+	gs.TurnOrder = []string{"p1", "p2"}
+	gs.CurrentPlayerIndex = 0
+	gs.Phase = PhaseAction
+	gs.Round = 6
+	gs.GetPlayer("p1").Resources.Power = NewPowerSystem(0, 4, 0)
+	mgr := NewManager()
+	mgr.CreateGameWithState("g1", gs)
+
+	enabled := true
+	options := NewSetPlayerOptionsAction("p1", nil, nil, nil, nil, nil)
+	options.ConfirmPass = &enabled
+	options.ConfirmBurn = &enabled
+	if _, err := mgr.ExecuteActionWithMeta("g1", options, ActionMeta{ExpectedRevision: -1, SeatID: "p1"}); err != nil {
+		t.Fatalf("set options: %v", err)
+	}
+
+	burn := &BurnPowerAction{BaseAction: BaseAction{Type: ActionBurnPower, PlayerID: "p1"}, Amount: 1}
+	if _, err := mgr.ExecuteActionWithMeta("g1", burn, ActionMeta{ExpectedRevision: -1, SeatID: "p1"}); ReasonCodeOf(err) != ReasonConfirmationRequired {
+		t.Fatalf("expected an unconfirmed burn to need confirmation, got %v", err)
+	}
+	burn.Confirmed = true
+	if _, err := mgr.ExecuteActionWithMeta("g1", burn, ActionMeta{ExpectedRevision: -1, SeatID: "p1"}); err != nil {
+		t.Fatalf("confirmed burn: %v", err)
+	}
+
+	pass := NewPassAction("p1", nil)
+	if _, err := mgr.ExecuteActionWithMeta("g1", pass, ActionMeta{ExpectedRevision: -1, SeatID: "p1"}); ReasonCodeOf(err) != ReasonConfirmationRequired {
+		t.Fatalf("expected an unconfirmed pass to need confirmation, got %v", err)
+	}
+	if gs.GetPlayer("p1").HasPassed {
+		t.Fatalf("expected the unconfirmed pass to be refused")
+	}
+	// Passes the server makes for a player, such as automatic passes, carry no seat.
+	if _, err := mgr.ExecuteActionWithMeta("g1", pass, ActionMeta{ExpectedRevision: -1}); err != nil {
+		t.Fatalf("seatless pass: %v", err)
+	}
+}
+
+func TestManager_ConfirmBurnCoversPowerActionsThatBurn(t *testing.T) {
+	gs := newActionTestGame(t, 2, testSeat{"p1", factions.NewWitches()}, testSeat{"p2", factions.NewNomads()})
+	p1 := gs.GetPlayer("p1")
+	p1.Resources.Power = NewPowerSystem(0, 6, 0)
+	p1.Options.ConfirmBurn = true
+	mgr := NewManager()
+	mgr.CreateGameWithState("g1", gs)
+
+	action := NewPowerAction("p1", PowerActionPriest)
+	if _, err := mgr.ExecuteActionWithMeta("g1", action, ActionMeta{ExpectedRevision: -1, SeatID: "p1"}); ReasonCodeOf(err) != ReasonConfirmationRequired {
+		t.Fatalf("expected a power action burning power to need confirmation, got %v", err)
+	}
+	if p1.Resources.Power.Bowl2 != 6 || p1.Resources.Priests != 0 {
+		t.Fatalf("expected the unconfirmed power action to be refused, got power %+v", p1.Resources.Power)
+	}
+	action.Confirmed = true
+	if _, err := mgr.ExecuteActionWithMeta("g1", action, ActionMeta{ExpectedRevision: -1, SeatID: "p1"}); err != nil {
+		t.Fatalf("confirmed power action: %v", err)
+	}
+	if p1.Resources.Power.Bowl1 != 3 || p1.Resources.Power.Bowl2 != 0 || p1.Resources.Priests != 1 {
+		t.Fatalf("expected 3 power burned to take the priest, got power %+v and %d priests", p1.Resources.Power, p1.Resources.Priests)
+	}
+}
+
+func TestManager_AccountConfirmationAppliesToEveryGame(t *testing.T) {
+	mgr := NewManager()
+	for _, gameID := range []string{"g1", "g2"} {
+		gs := NewGameState()
+		mustAddPlayer(t, gs, "p1", factions.NewWitches())
+		mustAddPlayer(t, gs, "p2", factions.NewNomads())
+		gs.TurnOrder = []string{"p1", "p2"}
+		gs.Phase = PhaseAction
+		gs.Round = 6
+		mgr.CreateGameWithState(gameID, gs)
+	}
+
+	enabled := true
+	prefs, err := mgr.SetConfirmationPreferences("p1", &enabled, nil)
+	if err != nil {
+		t.Fatalf("set confirmation preferences: %v", err)
+	}
+	if !prefs.ConfirmPass || prefs.ConfirmBurn || mgr.AccountPreferences("p1") != prefs {
+		t.Fatalf("expected only passes to need confirmation, got %+v", prefs)
+	}
+
+	for _, gameID := range []string{"g1", "g2"} {
+		pass := NewPassAction("p1", nil)
+		if _, err := mgr.ExecuteActionWithMeta(gameID, pass, ActionMeta{ExpectedRevision: -1, SeatID: "p1"}); ReasonCodeOf(err) != ReasonConfirmationRequired {
+			t.Fatalf("%s: expected an unconfirmed pass to need confirmation, got %v", gameID, err)
+		}
+		pass.Confirmed = true
+		if _, err := mgr.ExecuteActionWithMeta(gameID, pass, ActionMeta{ExpectedRevision: -1, SeatID: "p1"}); err != nil {
+			t.Fatalf("%s: confirmed pass: %v", gameID, err)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lukev/tm_server/internal/models"
+)
+
+// Community maps are shared as terrain-letter grids in the style of
+// snellman's map strings:
+//
+//	U,S,G,B,Y,R,U,K,R,G,B,R,K,E
+//	Y,I,I,U,K,I,I,Y,K,I,I,Y,E
+//	...
+//
+// Rows run top to bottom and end at a line break or an "E" token; tokens are
+// separated by commas or whitespace. A token is a terrain letter, a color
+// name, or a lower-case models.TerrainType name.
+var gridTerrainTokens = map[string]models.TerrainType{
+	"u": models.TerrainPlains, "brown": models.TerrainPlains,
+	"k": models.TerrainSwamp, "black": models.TerrainSwamp,
+	"b": models.TerrainLake, "blue": models.TerrainLake,
+	"g": models.TerrainForest, "green": models.TerrainForest,
+	"s": models.TerrainMountain, "gray": models.TerrainMountain, "grey": models.TerrainMountain,
+	"r": models.TerrainWasteland, "red": models.TerrainWasteland,
+	"y": models.TerrainDesert, "yellow": models.TerrainDesert,
+	"i": models.TerrainRiver, "x": models.TerrainRiver,
+	"w": models.TerrainIce, "white": models.TerrainIce,
+	"o": models.TerrainVolcano, "orange": models.TerrainVolcano,
+}
+
+// maxCommunityTerrainSpread is how much the hex counts of the seven home
+// terrains may differ on a community map. The built-in maps have equal
+// counts except Fjords, which is off by one.
+const maxCommunityTerrainSpread = 1
+
+// minCommunityRiverBody is the fewest directly connected river hexes a
+// community map may have in one place. Base and most maps have one river;
+// Fire & Ice splits it in two and Lakes has lakes of 3 to 9 hexes, but a
+// stranded one- or two-hex puddle is almost always a typo in the grid.
+const minCommunityRiverBody = 3
+
+// ParseCustomMapGrid reads a terrain-letter grid into a custom map definition.
+// Whether the first row is the longer one is taken from the second row.
+func ParseCustomMapGrid(name, grid string) (*CustomMapDefinition, error) {
+	var rows [][]models.TerrainType
+	var row []models.TerrainType
+	endRow := func() {
+		if len(row) > 0 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	for _, line := range strings.Split(grid, "\n") {
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\r'
+		})
+		for _, field := range fields {
+			token := strings.ToLower(field)
+			if token == "e" {
+				endRow()
+				continue
+			}
+			terrain, ok := gridTerrainTokens[token]
+			if !ok {
+				terrain, ok = terrainByName(token)
+			}
+			if !ok {
+				return nil, fmt.Errorf("row %d column %d has unknown terrain %q", len(rows), len(row), field)
+			}
+			row = append(row, terrain)
+		}
+		endRow()
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("map has no rows")
+	}
+
+	definition := &CustomMapDefinition{
+		Name:            strings.TrimSpace(name),
+		RowCount:        len(rows),
+		FirstRowColumns: len(rows[0]),
+		FirstRowLonger:  len(rows) == 1 || len(rows[1]) < len(rows[0]),
+		Rows:            rows,
+	}
+	if err := definition.validate(); err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+// ValidateCommunityMap applies the checks for user-submitted maps on top of
+// the layout checks every custom map passes: only the seven home terrains and
+// river may appear, no river hex may be cut off from the rest of the water,
+// and every home terrain must cover about the same number of hexes.
+func (d CustomMapDefinition) ValidateCommunityMap() error {
+	def, err := d.toMapDefinition()
+	if err != nil {
+		return err
+	}
+	layout := def.Layout()
+
+	counts := make(map[models.TerrainType]int)
+	rivers := make(map[Hex]bool)
+	for hex, terrain := range layout {
+		switch {
+		case terrain == models.TerrainRiver:
+			rivers[hex] = true
+		case terrain >= models.TerrainPlains && terrain <= models.TerrainDesert:
+			counts[terrain]++
+		default:
+			display := buildCoordinateIndex(def).displayByHex[hex]
+			return fmt.Errorf("%s: %s is not allowed on a community map", display, terrain)
+		}
+	}
+
+	minCount, maxCount := -1, 0
+	for terrain := models.TerrainPlains; terrain <= models.TerrainDesert; terrain++ {
+		count := counts[terrain]
+		if count == 0 {
+			return fmt.Errorf("map has no %s hexes", terrain)
+		}
+		if minCount < 0 || count < minCount {
+			minCount = count
+		}
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+	if maxCount-minCount > maxCommunityTerrainSpread {
+		return fmt.Errorf("terrain counts range from %d to %d hexes, expected at most %d apart",
+			minCount, maxCount, maxCommunityTerrainSpread)
+	}
+
+	if size, hex := smallestRiverBody(rivers); size > 0 && size < minCommunityRiverBody {
+		display := buildCoordinateIndex(def).displayByHex[hex]
+		return fmt.Errorf("%s: river hex is cut off in a body of %d hexes, expected at least %d connected hexes",
+			display, size, minCommunityRiverBody)
+	}
+	return nil
+}
+
+// smallestRiverBody returns the size of the smallest group of directly
+// adjacent river hexes and one of its hexes, or 0 when there is no river.
+func smallestRiverBody(rivers map[Hex]bool) (int, Hex) {
+	seen := make(map[Hex]bool, len(rivers))
+	smallest, smallestHex := 0, Hex{}
+	for start := range rivers {
+		if seen[start] {
+			continue
+		}
+		size := 0
+		seen[start] = true
+		queue := []Hex{start}
+		for len(queue) > 0 {
+			hex := queue[0]
+			queue = queue[1:]
+			size++
+			for _, neighbor := range hex.Neighbors() {
+				if rivers[neighbor] && !seen[neighbor] {
+					seen[neighbor] = true
+					queue = append(queue, neighbor)
+				}
+			}
+		}
+		if smallest == 0 || size < smallest {
+			smallest, smallestHex = size, start
+		}
+	}
+	return smallest, smallestHex
+}
@@ -0,0 +1,103 @@
+package board
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/models"
+)
+
+// communityTestGrid has two hexes of each home terrain and a single river.
+const communityTestGrid = `
+U,S,G,B,Y,R,K,E
+I,I,I,I,I,I,E
+K,R,Y,B,G,S,U,E
+`
+
+func TestParseCustomMapGrid_ReadsLettersAndNames(t *testing.T) {
+	custom, err := ParseCustomMapGrid(" Ring ", "brown x gray\nriver river")
+	if err != nil {
+		t.Fatalf("parse grid: %v", err)
+	}
+	if custom.Name != "Ring" || custom.RowCount != 2 || custom.FirstRowColumns != 3 || !custom.FirstRowLonger {
+		t.Fatalf("unexpected definition %+v", custom)
+	}
+	want := []models.TerrainType{models.TerrainPlains, models.TerrainRiver, models.TerrainMountain}
+	for i, terrain := range want {
+		if custom.Rows[0][i] != terrain {
+			t.Fatalf("row 0 column %d: got %s, want %s", i, custom.Rows[0][i], terrain)
+		}
+	}
+
+	shorterFirst, err := ParseCustomMapGrid("", "U,K,E,B,G,S,E")
+	if err != nil {
+		t.Fatalf("parse grid with shorter first row: %v", err)
+	}
+	if shorterFirst.FirstRowLonger {
+		t.Fatalf("expected the first row to be the shorter one")
+	}
+}
+
+func TestParseCustomMapGrid_RejectsBadRows(t *testing.T) {
+	if _, err := ParseCustomMapGrid("", "U,K,Q"); err == nil || !strings.Contains(err.Error(), `"Q"`) {
+		t.Fatalf("expected unknown terrain error, got %v", err)
+	}
+	if _, err := ParseCustomMapGrid("", "U,K,B\nG,S\nY,R"); err == nil {
+		t.Fatalf("expected a row length error")
+	}
+	if _, err := ParseCustomMapGrid("", " \n"); err == nil {
+		t.Fatalf("expected an empty grid to be rejected")
+	}
+}
+
+func TestValidateCommunityMap_AcceptsBuiltInMaps(t *testing.T) {
+	for _, info := range AvailableMaps() {
+		if info.ID == MapCustom {
+			continue
+		}
+		def, err := definitionForBuiltInMap(info.ID)
+		if err != nil {
+			t.Fatalf("load %s: %v", info.ID, err)
+		}
+		custom := CustomMapDefinition{
+			Name:            info.Name,
+			RowCount:        len(def.Rows),
+			FirstRowColumns: len(def.Rows[0].Terrains),
+			FirstRowLonger:  len(def.Rows) > 1 && len(def.Rows[1].Terrains) < len(def.Rows[0].Terrains),
+		}
+		for _, row := range def.Rows {
+			custom.Rows = append(custom.Rows, row.Terrains)
+		}
+		if err := custom.ValidateCommunityMap(); err != nil {
+			t.Fatalf("%s: %v", info.ID, err)
+		}
+	}
+}
+
+func TestValidateCommunityMap_ChecksRiverAndDistribution(t *testing.T) {
+	custom, err := ParseCustomMapGrid("Test", communityTestGrid)
+	if err != nil {
+		t.Fatalf("parse grid: %v", err)
+	}
+	if err := custom.ValidateCommunityMap(); err != nil {
+		t.Fatalf("expected the test grid to be valid: %v", err)
+	}
+
+	stranded := CloneCustomMapDefinition(custom)
+	stranded.Rows[1][2] = models.TerrainMountain
+	if err := stranded.ValidateCommunityMap(); err == nil || !strings.Contains(err.Error(), "cut off") {
+		t.Fatalf("expected a two-hex river fragment to be rejected, got %v", err)
+	}
+
+	unbalanced := CloneCustomMapDefinition(custom)
+	unbalanced.Rows[2][0] = models.TerrainPlains
+	if err := unbalanced.ValidateCommunityMap(); err == nil || !strings.Contains(err.Error(), "terrain counts") {
+		t.Fatalf("expected an unbalanced map to be rejected, got %v", err)
+	}
+
+	volcanic := CloneCustomMapDefinition(custom)
+	volcanic.Rows[1][0] = models.TerrainVolcano
+	if err := volcanic.ValidateCommunityMap(); err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Fatalf("expected volcano hexes to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package board
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// axialHexPattern matches a hex as it appears in messages: Hex.String's
+// "(q,r)" and the Go syntax "board.Hex{Q:q, R:r}" of %#v.
+var axialHexPattern = regexp.MustCompile(`\((-?\d+),(-?\d+)\)|(?:board\.)?Hex\{Q:(-?\d+), R:(-?\d+)\}`)
+
+// LabelHexes puts the board label in front of every axial hex in text that
+// is on the map, e.g. "hex (4,3) already has a building" becomes
+// "hex F5 (4,3) already has a building".
+func (m *TerraMysticaMap) LabelHexes(text string) string {
+	if m == nil || len(m.displayByHex) == 0 {
+		return text
+	}
+	return axialHexPattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := axialHexPattern.FindStringSubmatch(match)
+		q, r := groups[1], groups[2]
+		if q == "" {
+			q, r = groups[3], groups[4]
+		}
+		qi, errQ := strconv.Atoi(q)
+		ri, errR := strconv.Atoi(r)
+		if errQ != nil || errR != nil {
+			return match
+		}
+		label, ok := m.DisplayCoordinateForHex(NewHex(qi, ri))
+		if !ok {
+			return match
+		}
+		return label + " " + match
+	})
+}
+
+// LabelHexError returns err with its message labelled as by LabelHexes.
+// errors.Is and errors.As still reach err; err itself is returned when its
+// message names no hex on the map.
+func (m *TerraMysticaMap) LabelHexError(err error) error {
+	if err == nil {
+		return nil
+	}
+	text := m.LabelHexes(err.Error())
+	if text == err.Error() {
+		return err
+	}
+	return &labeledHexError{text: text, err: err}
+}
+
+type labeledHexError struct {
+	text string
+	err  error
+}
+
+func (e *labeledHexError) Error() string { return e.text }
+
+func (e *labeledHexError) Unwrap() error { return e.err }
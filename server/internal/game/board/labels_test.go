@@ -0,0 +1,31 @@
+package board
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestLabelHexes_AddsBoardLabelsToAxialHexes(t *testing.T) {
+	m := NewTerraMysticaMap()
+	hex, ok := m.HexForDisplayCoordinate("F5")
+	if !ok {
+		t.Fatalf("expected F5 on the base map")
+	}
+
+	got := m.LabelHexes(fmt.Sprintf("hex %s already has a building; target %#v; far (99,99)", hex, hex))
+	want := fmt.Sprintf("hex F5 %s already has a building; target F5 %#v; far (99,99)", hex, hex)
+	if got != want {
+		t.Fatalf("unexpected labels:\n got %q\nwant %q", got, want)
+	}
+
+	base := errors.New("hex " + hex.String() + " is not on the map")
+	labeled := m.LabelHexError(fmt.Errorf("wrapped: %w", base))
+	if labeled.Error() != "wrapped: hex F5 "+hex.String()+" is not on the map" || !errors.Is(labeled, base) {
+		t.Fatalf("unexpected labelled error %q", labeled)
+	}
+	plain := errors.New("no hexes here")
+	if m.LabelHexError(plain) != plain {
+		t.Fatalf("expected errors without hexes to be returned as is")
+	}
+}
@@ -3,6 +3,7 @@ package board
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/lukev/tm_server/internal/game/factions"
 	"github.com/lukev/tm_server/internal/models"
@@ -57,6 +58,81 @@ func (m *TerraMysticaMap) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// UnmarshalJSON reads a map written by MarshalJSON. The layout comes from the
+// map ID or custom definition; hexes, bridges and rivers from the JSON.
+func (m *TerraMysticaMap) UnmarshalJSON(raw []byte) error {
+	var aux struct {
+		ID         MapID                `json:"id"`
+		Hexes      map[string]*MapHex   `json:"hexes"`
+		Bridges    map[string]string    `json:"bridges"`
+		RiverHexes map[string]bool      `json:"riverHexes"`
+		CustomMap  *CustomMapDefinition `json:"customMap"`
+	}
+	if err := json.Unmarshal(raw, &aux); err != nil {
+		return err
+	}
+
+	var layout *TerraMysticaMap
+	var err error
+	switch {
+	case aux.CustomMap != nil:
+		layout, err = NewTerraMysticaMapForCustom(aux.CustomMap)
+	case aux.ID == "":
+		layout, err = NewTerraMysticaMapForID(MapBase)
+	default:
+		layout, err = NewTerraMysticaMapForID(aux.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	hexes := make(map[Hex]*MapHex, len(aux.Hexes))
+	for key, hex := range aux.Hexes {
+		coord, err := parseHexKey(key)
+		if err != nil {
+			return err
+		}
+		hexes[coord] = hex
+	}
+	bridges := make(map[BridgeKey]string, len(aux.Bridges))
+	for key, playerID := range aux.Bridges {
+		ends := strings.Split(key, "|")
+		if len(ends) != 2 {
+			return fmt.Errorf("invalid bridge %q", key)
+		}
+		h1, err := parseHexKey(ends[0])
+		if err != nil {
+			return err
+		}
+		h2, err := parseHexKey(ends[1])
+		if err != nil {
+			return err
+		}
+		bridges[NewBridgeKey(h1, h2)] = playerID
+	}
+	rivers := make(map[Hex]bool, len(aux.RiverHexes))
+	for key, isRiver := range aux.RiverHexes {
+		coord, err := parseHexKey(key)
+		if err != nil {
+			return err
+		}
+		rivers[coord] = isRiver
+	}
+
+	*m = TerraMysticaMap{Hexes: hexes, Bridges: bridges, RiverHexes: rivers}
+	m.CopyLayoutFrom(layout)
+	return nil
+}
+
+// parseHexKey reads a "q,r" key written by MarshalJSON.
+func parseHexKey(key string) (Hex, error) {
+	var h Hex
+	if _, err := fmt.Sscanf(key, "%d,%d", &h.Q, &h.R); err != nil {
+		return Hex{}, fmt.Errorf("invalid hex %q: %w", key, err)
+	}
+	return h, nil
+}
+
 // MapHex represents a single hex on the map
 type MapHex struct {
 	Coord                   Hex
@@ -168,6 +244,16 @@ func (m *TerraMysticaMap) HexForDisplayCoordinate(display string) (Hex, bool) {
 	return hex, ok
 }
 
+// CopyLayoutFrom gives m the map ID, display coordinates and custom
+// definition of src. The coordinate indexes never change after a map is
+// built, so they are shared.
+func (m *TerraMysticaMap) CopyLayoutFrom(src *TerraMysticaMap) {
+	m.ID = src.ID
+	m.displayByHex = src.displayByHex
+	m.hexByDisplayID = src.hexByDisplayID
+	m.customDefinition = src.customDefinition
+}
+
 func (m *TerraMysticaMap) CustomDefinition() *CustomMapDefinition {
 	return CloneCustomMapDefinition(m.customDefinition)
 }
@@ -457,27 +543,24 @@ func (m *TerraMysticaMap) riverNeighbors(h Hex) []Hex {
 	return out
 }
 
-// GetDirectNeighbors returns all directly adjacent hexes (including bridges)
+// GetDirectNeighbors returns every hex IsDirectlyAdjacent to h: its natural
+// neighbors plus the far ends of bridges from h.
 func (m *TerraMysticaMap) GetDirectNeighbors(h Hex) []Hex {
-	neighbors := []Hex{}
-
-	// Add natural neighbors (distance 1)
-	for _, neighbor := range h.Neighbors() {
-		if m.IsValidHex(neighbor) && m.IsDirectlyAdjacent(h, neighbor) {
-			neighbors = append(neighbors, neighbor)
+	candidates := h.Neighbors()
+	for bridgeKey := range m.Bridges {
+		if bridgeKey.H1.Equals(h) {
+			candidates = append(candidates, bridgeKey.H2)
+		} else if bridgeKey.H2.Equals(h) {
+			candidates = append(candidates, bridgeKey.H1)
 		}
 	}
 
-	// Add bridge-connected neighbors (distance 2)
-	// Bridges connect hexes that are not naturally adjacent
-	for bridgeKey := range m.Bridges {
-		if bridgeKey.H1.Equals(h) && m.IsValidHex(bridgeKey.H2) {
-			neighbors = append(neighbors, bridgeKey.H2)
-		} else if bridgeKey.H2.Equals(h) && m.IsValidHex(bridgeKey.H1) {
-			neighbors = append(neighbors, bridgeKey.H1)
+	neighbors := []Hex{}
+	for _, candidate := range candidates {
+		if m.IsValidHex(candidate) && m.IsDirectlyAdjacent(h, candidate) {
+			neighbors = append(neighbors, candidate)
 		}
 	}
-
 	return neighbors
 }
 
@@ -724,9 +807,10 @@ func (m *TerraMysticaMap) CalculateAdjacencyBonus(h Hex, faction models.FactionT
 	return bonus
 }
 
-// GetPowerLeechTargets returns all players who can leech power from a building placement
-// Returns map of faction -> power amount they can leech
-func (m *TerraMysticaMap) GetPowerLeechTargets(h Hex, placedFaction models.FactionType, powerValue int) map[models.FactionType]int {
+// GetPowerLeechTargets returns all factions that can leech power from a building placement
+// Returns map of faction -> power amount they can leech, which is the sum of the power
+// values of that faction's own buildings directly adjacent to h (bridges included)
+func (m *TerraMysticaMap) GetPowerLeechTargets(h Hex, placedFaction models.FactionType) map[models.FactionType]int {
 	targets := make(map[models.FactionType]int)
 
 	for _, neighbor := range m.GetDirectNeighbors(h) {
@@ -734,13 +818,7 @@ func (m *TerraMysticaMap) GetPowerLeechTargets(h Hex, placedFaction models.Facti
 		if mapHex != nil && mapHex.Building != nil {
 			// Can only leech from opponent buildings
 			if mapHex.Building.Faction != placedFaction {
-				faction := mapHex.Building.Faction
-				// Power leech amount equals the power value of the placed building
-				if existing, ok := targets[faction]; ok {
-					targets[faction] = existing + powerValue
-				} else {
-					targets[faction] = powerValue
-				}
+				targets[mapHex.Building.Faction] += BuildingPowerValue(mapHex.Building)
 			}
 		}
 	}
@@ -748,6 +826,71 @@ func (m *TerraMysticaMap) GetPowerLeechTargets(h Hex, placedFaction models.Facti
 	return targets
 }
 
+// AdjacentPlayerBuildings returns the hexes of a player's buildings that are directly
+// adjacent to h, including connections via bridges
+func (m *TerraMysticaMap) AdjacentPlayerBuildings(h Hex, playerID string) []Hex {
+	result := []Hex{}
+	seen := make(map[Hex]bool)
+	for _, neighbor := range m.GetDirectNeighbors(h) {
+		if seen[neighbor] {
+			continue
+		}
+		seen[neighbor] = true
+		mapHex := m.GetHex(neighbor)
+		if mapHex != nil && mapHex.Building != nil && mapHex.Building.PlayerID == playerID {
+			result = append(result, neighbor)
+		}
+	}
+	return result
+}
+
+// AdjacentBuildingPower returns the total power value of a player's buildings directly
+// adjacent to h (including bridges). This is the amount the player may leech when an
+// opponent builds or upgrades on h
+func (m *TerraMysticaMap) AdjacentBuildingPower(h Hex, playerID string) int {
+	return m.TotalBuildingPower(m.AdjacentPlayerBuildings(h, playerID))
+}
+
+// TotalBuildingPower returns the sum of the power values of the buildings on the given hexes
+func (m *TerraMysticaMap) TotalBuildingPower(hexes []Hex) int {
+	total := 0
+	for _, h := range hexes {
+		mapHex := m.GetHex(h)
+		if mapHex == nil || mapHex.Building == nil {
+			continue
+		}
+		total += BuildingPowerValue(mapHex.Building)
+	}
+	return total
+}
+
+// BuildingPowerValue returns the power value of a placed building. Faction-specific
+// values recorded on the building take precedence over the standard value for its type
+func BuildingPowerValue(building *models.Building) int {
+	if building == nil {
+		return 0
+	}
+	if building.PowerValue > 0 {
+		return building.PowerValue
+	}
+	return StandardPowerValue(building.Type)
+}
+
+// StandardPowerValue returns the standard power value of a building type
+// Dwelling=1, Trading House=2, Temple=2, Sanctuary=3, Stronghold=3
+func StandardPowerValue(buildingType models.BuildingType) int {
+	switch buildingType {
+	case models.BuildingDwelling:
+		return 1
+	case models.BuildingTradingHouse, models.BuildingTemple:
+		return 2
+	case models.BuildingStronghold, models.BuildingSanctuary:
+		return 3
+	default:
+		return 0
+	}
+}
+
 // GetConnectedBuildingsIncludingBridges finds all buildings connected to the starting hex
 // This includes connections via bridges
 func (m *TerraMysticaMap) GetConnectedBuildingsIncludingBridges(start Hex, playerID string) []Hex {
@@ -848,12 +991,12 @@ func (m *TerraMysticaMap) getConnectedBuildingsForMermaids(start Hex, playerID s
 
 	bestComponent := baseComponent
 	var bestRiver *Hex
-	bestPower := m.totalBuildingPower(baseComponent)
+	bestPower := m.TotalBuildingPower(baseComponent)
 
 	for river := range candidateRivers {
 		r := river
 		componentWithSkip := m.collectConnectedBuildingsWithRiver(start, playerID, &r)
-		componentPower := m.totalBuildingPower(componentWithSkip)
+		componentPower := m.TotalBuildingPower(componentWithSkip)
 		if componentPower > bestPower || (componentPower == bestPower && len(componentWithSkip) > len(bestComponent)) {
 			bestComponent = componentWithSkip
 			bestPower = componentPower
@@ -926,25 +1069,6 @@ func (m *TerraMysticaMap) hasPlayerBuildingAcrossRiver(current, river Hex, playe
 	return false
 }
 
-func (m *TerraMysticaMap) totalBuildingPower(hexes []Hex) int {
-	total := 0
-	for _, h := range hexes {
-		mapHex := m.GetHex(h)
-		if mapHex == nil || mapHex.Building == nil {
-			continue
-		}
-		switch mapHex.Building.Type {
-		case models.BuildingDwelling:
-			total += 1
-		case models.BuildingTradingHouse, models.BuildingTemple:
-			total += 2
-		case models.BuildingStronghold, models.BuildingSanctuary:
-			total += 3
-		}
-	}
-	return total
-}
-
 // CanTerraform checks if a hex can be terraformed
 func (m *TerraMysticaMap) CanTerraform(h Hex) error {
 	mapHex := m.GetHex(h)
@@ -150,3 +150,72 @@ func TestShipping_DirectAdjacencyExcluded(t *testing.T) {
 		t.Fatalf("expected false for directly adjacent hexes")
 	}
 }
+
+func TestAdjacentBuildingPower_IncludesBridgesAndUpgrades(t *testing.T) {
+	h1 := NewHex(0, 0)
+	midA := NewHex(0, -1)
+	midB := NewHex(1, -1)
+	h2 := NewHex(1, -2)
+	neighbor := NewHex(1, 0)
+	cells := map[Hex]models.TerrainType{
+		h1:       models.TerrainPlains,
+		midA:     models.TerrainRiver,
+		midB:     models.TerrainRiver,
+		h2:       models.TerrainForest,
+		neighbor: models.TerrainForest,
+	}
+	m := makeMap(cells)
+	m.Hexes[h2].Building = &models.Building{Type: models.BuildingDwelling, PlayerID: "p2", PowerValue: 1}
+	m.Hexes[neighbor].Building = &models.Building{Type: models.BuildingTemple, PlayerID: "p2"}
+
+	if got := m.AdjacentBuildingPower(h1, "p2"); got != 2 {
+		t.Fatalf("expected only the naturally adjacent temple (2) before bridge, got %d", got)
+	}
+
+	if err := m.BuildBridge(h1, h2, "p1"); err != nil {
+		t.Fatalf("build bridge: %v", err)
+	}
+	if got := m.AdjacentBuildingPower(h1, "p2"); got != 3 {
+		t.Fatalf("expected temple + bridged dwelling = 3, got %d", got)
+	}
+
+	// Upgrading the bridged dwelling mid-turn changes its contribution
+	m.Hexes[h2].Building = &models.Building{Type: models.BuildingTradingHouse, PlayerID: "p2", PowerValue: 2}
+	if got := m.AdjacentBuildingPower(h1, "p2"); got != 4 {
+		t.Fatalf("expected temple + bridged trading house = 4, got %d", got)
+	}
+	if got := m.AdjacentBuildingPower(h1, "p3"); got != 0 {
+		t.Fatalf("expected no adjacent power for p3, got %d", got)
+	}
+}
+
+func TestGetDirectNeighbors_IncludesBridgeEndsOnce(t *testing.T) {
+	h1 := NewHex(0, 0)
+	midA := NewHex(0, -1)
+	midB := NewHex(1, -1)
+	h2 := NewHex(1, -2)
+	m := makeMap(map[Hex]models.TerrainType{
+		h1:   models.TerrainPlains,
+		midA: models.TerrainRiver,
+		midB: models.TerrainRiver,
+		h2:   models.TerrainForest,
+	})
+	if err := m.BuildBridge(h1, h2, "p1"); err != nil {
+		t.Fatalf("build bridge: %v", err)
+	}
+
+	for _, pair := range [][2]Hex{{h1, h2}, {h2, h1}} {
+		count := 0
+		for _, neighbor := range m.GetDirectNeighbors(pair[0]) {
+			if !m.IsDirectlyAdjacent(pair[0], neighbor) {
+				t.Fatalf("%v listed as neighbor of %v but not directly adjacent", neighbor, pair[0])
+			}
+			if neighbor == pair[1] {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Fatalf("expected %v once among the neighbors of %v, got %d", pair[1], pair[0], count)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package board
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/lukev/tm_server/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Map layouts are data files, one per map:
+//
+//	id: base
+//	name: Base
+//	firstRowLonger: true
+//	rows:
+//	  - [plains, mountain, forest, ...]
+//
+// Rows are listed top to bottom. Even rows have as many hexes as the first
+// row; odd rows have one fewer when firstRowLonger is set and one more
+// otherwise. Terrain names are the lower-case models.TerrainType names.
+
+//go:embed data/maps/*.yaml
+var builtInMapFS embed.FS
+
+type mapFile struct {
+	ID             MapID      `yaml:"id"`
+	Name           string     `yaml:"name"`
+	FirstRowLonger bool       `yaml:"firstRowLonger"`
+	Rows           [][]string `yaml:"rows"`
+}
+
+type mapRegistry struct {
+	definitions map[MapID]mapDefinition
+	indexes     map[MapID]coordinateIndex
+}
+
+var (
+	builtInMaps *mapRegistry
+	loadedMaps  atomic.Pointer[mapRegistry]
+)
+
+func init() {
+	definitions, err := readMapFiles(builtInMapFS, "data/maps")
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in map data: %v", err))
+	}
+	builtInMaps = newMapRegistry(definitions)
+	loadedMaps.Store(builtInMaps)
+}
+
+func currentMaps() *mapRegistry {
+	return loadedMaps.Load()
+}
+
+func newMapRegistry(definitions map[MapID]mapDefinition) *mapRegistry {
+	registry := &mapRegistry{
+		definitions: definitions,
+		indexes:     make(map[MapID]coordinateIndex, len(definitions)),
+	}
+	for id, def := range definitions {
+		registry.indexes[id] = buildCoordinateIndex(def)
+	}
+	return registry
+}
+
+// LoadMapDirectory validates every *.yaml layout in dir and makes those maps
+// available next to the built-in ones; a file reusing a built-in id replaces
+// that map. Nothing changes unless every file is valid. Loading again drops
+// the maps of the previous load, so it doubles as a reload. Games keep the
+// map they were created with.
+func LoadMapDirectory(dir string) error {
+	maps, err := ReadMapDirectory(dir)
+	if err != nil {
+		return err
+	}
+	maps.Apply()
+	return nil
+}
+
+// MapData is a validated set of map layouts that is not in use yet.
+type MapData struct {
+	registry *mapRegistry
+}
+
+// ReadMapDirectory validates the layouts in dir like LoadMapDirectory without
+// making them available, so callers loading several kinds of data can apply
+// them only once all of it is valid.
+func ReadMapDirectory(dir string) (*MapData, error) {
+	loaded, err := readMapFiles(os.DirFS(dir), ".")
+	if err != nil {
+		return nil, err
+	}
+	definitions := make(map[MapID]mapDefinition, len(builtInMaps.definitions)+len(loaded))
+	for id, def := range builtInMaps.definitions {
+		definitions[id] = def
+	}
+	for id, def := range loaded {
+		definitions[id] = def
+	}
+	return &MapData{registry: newMapRegistry(definitions)}, nil
+}
+
+// Apply makes the maps available, replacing those of the previous load.
+func (d *MapData) Apply() {
+	loadedMaps.Store(d.registry)
+}
+
+func readMapFiles(fsys fs.FS, dir string) (map[MapID]mapDefinition, error) {
+	names, err := fs.Glob(fsys, path.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	definitions := make(map[MapID]mapDefinition, len(names))
+	for _, name := range names {
+		raw, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		def, err := parseMapFile(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if _, exists := definitions[def.Info.ID]; exists {
+			return nil, fmt.Errorf("%s: duplicate map id %q", name, def.Info.ID)
+		}
+		definitions[def.Info.ID] = def
+	}
+	return definitions, nil
+}
+
+func parseMapFile(raw []byte) (mapDefinition, error) {
+	var file mapFile
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		return mapDefinition{}, fmt.Errorf("invalid map file: %w", err)
+	}
+
+	id := MapID(strings.ToLower(strings.TrimSpace(string(file.ID))))
+	if id == "" {
+		return mapDefinition{}, fmt.Errorf("map id is required")
+	}
+	if id == MapCustom {
+		return mapDefinition{}, fmt.Errorf("map id %q is reserved", MapCustom)
+	}
+	name := strings.TrimSpace(file.Name)
+	if name == "" {
+		return mapDefinition{}, fmt.Errorf("map name is required")
+	}
+	if len(file.Rows) == 0 {
+		return mapDefinition{}, fmt.Errorf("map has no rows")
+	}
+
+	custom := CustomMapDefinition{
+		Name:            name,
+		RowCount:        len(file.Rows),
+		FirstRowColumns: len(file.Rows[0]),
+		FirstRowLonger:  file.FirstRowLonger,
+		Rows:            make([][]models.TerrainType, len(file.Rows)),
+	}
+	for rowIndex, row := range file.Rows {
+		custom.Rows[rowIndex] = make([]models.TerrainType, len(row))
+		for colIndex, terrainName := range row {
+			terrain, ok := terrainByName(terrainName)
+			if !ok {
+				return mapDefinition{}, fmt.Errorf("row %d column %d has unknown terrain %q", rowIndex, colIndex, terrainName)
+			}
+			custom.Rows[rowIndex][colIndex] = terrain
+		}
+	}
+
+	def, err := custom.toMapDefinition()
+	if err != nil {
+		return mapDefinition{}, err
+	}
+	def.Info = MapInfo{ID: id, Name: name}
+	return def, nil
+}
+
+func terrainByName(name string) (models.TerrainType, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for terrain := models.TerrainPlains; terrain <= models.TerrainVolcano; terrain++ {
+		if strings.ToLower(terrain.String()) == name {
+			return terrain, true
+		}
+	}
+	return models.TerrainTypeUnknown, false
+}
+
+// ValidateTerrainLayout checks the terrain of map id at display coordinates
+// (e.g. "E7") against expected, such as home terrains seen in game logs.
+func ValidateTerrainLayout(id MapID, expected map[string]models.TerrainType) error {
+	layout, err := LayoutForMap(id)
+	if err != nil {
+		return err
+	}
+
+	coords := make([]string, 0, len(expected))
+	for coord := range expected {
+		coords = append(coords, coord)
+	}
+	sort.Strings(coords)
+	for _, coord := range coords {
+		hex, ok := HexForDisplayCoordinate(id, coord)
+		if !ok {
+			return fmt.Errorf("%s: no such hex on map %s", coord, id)
+		}
+		if actual := layout[hex]; actual != expected[coord] {
+			return fmt.Errorf("%s (q=%d, r=%d): expected %s, got %s", coord, hex.Q, hex.R, expected[coord], actual)
+		}
+	}
+	return nil
+}
@@ -61,30 +61,6 @@ func (d mapDefinition) Layout() map[Hex]models.TerrainType {
 	return layout
 }
 
-func baseRow(r int, terrains ...models.TerrainType) rowDefinition {
-	return rowDefinition{
-		R:        r,
-		StartQ:   mapStartQForRow(r),
-		Terrains: append([]models.TerrainType(nil), terrains...),
-	}
-}
-
-func lakesRow(r int, terrains ...models.TerrainType) rowDefinition {
-	return rowDefinition{
-		R:        r,
-		StartQ:   lakesStartQForRow(r),
-		Terrains: append([]models.TerrainType(nil), terrains...),
-	}
-}
-
-func fireAndIceRow(r int, terrains ...models.TerrainType) rowDefinition {
-	return rowDefinition{
-		R:        r,
-		StartQ:   lakesStartQForRow(r),
-		Terrains: append([]models.TerrainType(nil), terrains...),
-	}
-}
-
 func mapStartQForRow(r int) int {
 	if r%2 == 0 {
 		return -(r / 2)
@@ -106,368 +82,6 @@ func startQForRow(firstRowLonger bool, r int) int {
 	return lakesStartQForRow(r)
 }
 
-func cloneRows(rows []rowDefinition) []rowDefinition {
-	cloned := make([]rowDefinition, len(rows))
-	for i, row := range rows {
-		cloned[i] = rowDefinition{
-			R:        row.R,
-			StartQ:   row.StartQ,
-			Terrains: append([]models.TerrainType(nil), row.Terrains...),
-		}
-	}
-	return cloned
-}
-
-var baseRows = []rowDefinition{
-	baseRow(0,
-		models.TerrainPlains, models.TerrainMountain, models.TerrainForest, models.TerrainLake,
-		models.TerrainDesert, models.TerrainWasteland, models.TerrainPlains, models.TerrainSwamp,
-		models.TerrainWasteland, models.TerrainForest, models.TerrainLake, models.TerrainWasteland,
-		models.TerrainSwamp,
-	),
-	baseRow(1,
-		models.TerrainDesert, models.TerrainRiver, models.TerrainRiver, models.TerrainPlains,
-		models.TerrainSwamp, models.TerrainRiver, models.TerrainRiver, models.TerrainDesert,
-		models.TerrainSwamp, models.TerrainRiver, models.TerrainRiver, models.TerrainDesert,
-	),
-	baseRow(2,
-		models.TerrainRiver, models.TerrainRiver, models.TerrainSwamp, models.TerrainRiver,
-		models.TerrainMountain, models.TerrainRiver, models.TerrainForest, models.TerrainRiver,
-		models.TerrainForest, models.TerrainRiver, models.TerrainMountain, models.TerrainRiver,
-		models.TerrainRiver,
-	),
-	baseRow(3,
-		models.TerrainForest, models.TerrainLake, models.TerrainDesert, models.TerrainRiver,
-		models.TerrainRiver, models.TerrainWasteland, models.TerrainLake, models.TerrainRiver,
-		models.TerrainWasteland, models.TerrainRiver, models.TerrainWasteland, models.TerrainPlains,
-	),
-	baseRow(4,
-		models.TerrainSwamp, models.TerrainPlains, models.TerrainWasteland, models.TerrainLake,
-		models.TerrainSwamp, models.TerrainPlains, models.TerrainMountain, models.TerrainDesert,
-		models.TerrainRiver, models.TerrainRiver, models.TerrainForest, models.TerrainSwamp,
-		models.TerrainLake,
-	),
-	baseRow(5,
-		models.TerrainMountain, models.TerrainForest, models.TerrainRiver, models.TerrainRiver,
-		models.TerrainDesert, models.TerrainForest, models.TerrainRiver, models.TerrainRiver,
-		models.TerrainRiver, models.TerrainPlains, models.TerrainMountain, models.TerrainPlains,
-	),
-	baseRow(6,
-		models.TerrainRiver, models.TerrainRiver, models.TerrainRiver, models.TerrainMountain,
-		models.TerrainRiver, models.TerrainWasteland, models.TerrainRiver, models.TerrainForest,
-		models.TerrainRiver, models.TerrainDesert, models.TerrainSwamp, models.TerrainLake,
-		models.TerrainDesert,
-	),
-	baseRow(7,
-		models.TerrainDesert, models.TerrainLake, models.TerrainPlains, models.TerrainRiver,
-		models.TerrainRiver, models.TerrainRiver, models.TerrainLake, models.TerrainSwamp,
-		models.TerrainRiver, models.TerrainMountain, models.TerrainPlains, models.TerrainMountain,
-	),
-	baseRow(8,
-		models.TerrainWasteland, models.TerrainSwamp, models.TerrainMountain, models.TerrainLake,
-		models.TerrainWasteland, models.TerrainForest, models.TerrainDesert, models.TerrainPlains,
-		models.TerrainMountain, models.TerrainRiver, models.TerrainLake, models.TerrainForest,
-		models.TerrainWasteland,
-	),
-}
-
-var revisedBaseRows = []rowDefinition{
-	baseRow(0,
-		models.TerrainPlains, models.TerrainMountain, models.TerrainForest, models.TerrainLake,
-		models.TerrainPlains, models.TerrainWasteland, models.TerrainPlains, models.TerrainSwamp,
-		models.TerrainWasteland, models.TerrainLake, models.TerrainForest, models.TerrainWasteland,
-		models.TerrainSwamp,
-	),
-	baseRow(1,
-		models.TerrainDesert, models.TerrainRiver, models.TerrainRiver, models.TerrainDesert,
-		models.TerrainSwamp, models.TerrainRiver, models.TerrainRiver, models.TerrainDesert,
-		models.TerrainForest, models.TerrainRiver, models.TerrainRiver, models.TerrainDesert,
-	),
-	baseRow(2,
-		models.TerrainRiver, models.TerrainRiver, models.TerrainSwamp, models.TerrainRiver,
-		models.TerrainMountain, models.TerrainRiver, models.TerrainForest, models.TerrainRiver,
-		models.TerrainSwamp, models.TerrainRiver, models.TerrainWasteland, models.TerrainRiver,
-		models.TerrainRiver,
-	),
-	baseRow(3,
-		models.TerrainForest, models.TerrainLake, models.TerrainDesert, models.TerrainRiver,
-		models.TerrainRiver, models.TerrainWasteland, models.TerrainLake, models.TerrainRiver,
-		models.TerrainWasteland, models.TerrainRiver, models.TerrainMountain, models.TerrainPlains,
-	),
-	baseRow(4,
-		models.TerrainSwamp, models.TerrainPlains, models.TerrainWasteland, models.TerrainLake,
-		models.TerrainDesert, models.TerrainPlains, models.TerrainForest, models.TerrainDesert,
-		models.TerrainRiver, models.TerrainRiver, models.TerrainForest, models.TerrainSwamp,
-		models.TerrainWasteland,
-	),
-	baseRow(5,
-		models.TerrainMountain, models.TerrainForest, models.TerrainRiver, models.TerrainRiver,
-		models.TerrainSwamp, models.TerrainMountain, models.TerrainRiver, models.TerrainRiver,
-		models.TerrainRiver, models.TerrainPlains, models.TerrainMountain, models.TerrainDesert,
-	),
-	baseRow(6,
-		models.TerrainRiver, models.TerrainRiver, models.TerrainRiver, models.TerrainMountain,
-		models.TerrainRiver, models.TerrainWasteland, models.TerrainRiver, models.TerrainForest,
-		models.TerrainRiver, models.TerrainDesert, models.TerrainSwamp, models.TerrainLake,
-		models.TerrainPlains,
-	),
-	baseRow(7,
-		models.TerrainDesert, models.TerrainLake, models.TerrainPlains, models.TerrainRiver,
-		models.TerrainRiver, models.TerrainRiver, models.TerrainLake, models.TerrainSwamp,
-		models.TerrainRiver, models.TerrainMountain, models.TerrainPlains, models.TerrainWasteland,
-	),
-	baseRow(8,
-		models.TerrainLake, models.TerrainSwamp, models.TerrainMountain, models.TerrainLake,
-		models.TerrainWasteland, models.TerrainForest, models.TerrainDesert, models.TerrainPlains,
-		models.TerrainMountain, models.TerrainRiver, models.TerrainLake, models.TerrainForest,
-		models.TerrainMountain,
-	),
-}
-
-var lakesRows = []rowDefinition{
-	lakesRow(0,
-		models.TerrainMountain, models.TerrainLake, models.TerrainWasteland, models.TerrainPlains,
-		models.TerrainDesert, models.TerrainLake, models.TerrainDesert, models.TerrainWasteland,
-		models.TerrainRiver, models.TerrainRiver, models.TerrainForest, models.TerrainLake,
-	),
-	lakesRow(1,
-		models.TerrainDesert, models.TerrainSwamp, models.TerrainForest, models.TerrainRiver,
-		models.TerrainRiver, models.TerrainSwamp, models.TerrainPlains, models.TerrainRiver,
-		models.TerrainForest, models.TerrainMountain, models.TerrainRiver, models.TerrainPlains,
-		models.TerrainSwamp,
-	),
-	lakesRow(2,
-		models.TerrainPlains, models.TerrainRiver, models.TerrainRiver, models.TerrainForest,
-		models.TerrainWasteland, models.TerrainMountain, models.TerrainRiver, models.TerrainSwamp,
-		models.TerrainLake, models.TerrainWasteland, models.TerrainRiver,
-		models.TerrainDesert,
-	),
-	lakesRow(3,
-		models.TerrainLake, models.TerrainWasteland, models.TerrainMountain, models.TerrainRiver,
-		models.TerrainDesert, models.TerrainPlains, models.TerrainForest, models.TerrainRiver,
-		models.TerrainRiver, models.TerrainDesert, models.TerrainRiver, models.TerrainSwamp,
-		models.TerrainWasteland,
-	),
-	lakesRow(4,
-		models.TerrainForest, models.TerrainDesert, models.TerrainRiver, models.TerrainSwamp,
-		models.TerrainLake, models.TerrainRiver, models.TerrainRiver, models.TerrainWasteland,
-		models.TerrainRiver, models.TerrainMountain, models.TerrainForest, models.TerrainPlains,
-	),
-	lakesRow(5,
-		models.TerrainMountain, models.TerrainRiver, models.TerrainPlains, models.TerrainMountain,
-		models.TerrainRiver, models.TerrainDesert, models.TerrainRiver, models.TerrainMountain,
-		models.TerrainRiver, models.TerrainPlains, models.TerrainSwamp, models.TerrainLake,
-		models.TerrainWasteland,
-	),
-	lakesRow(6,
-		models.TerrainWasteland, models.TerrainRiver, models.TerrainRiver, models.TerrainRiver,
-		models.TerrainWasteland, models.TerrainForest, models.TerrainPlains, models.TerrainSwamp,
-		models.TerrainDesert, models.TerrainRiver, models.TerrainRiver, models.TerrainMountain,
-	),
-	lakesRow(7,
-		models.TerrainDesert, models.TerrainLake, models.TerrainSwamp, models.TerrainRiver,
-		models.TerrainLake, models.TerrainMountain, models.TerrainLake, models.TerrainRiver,
-		models.TerrainRiver, models.TerrainMountain, models.TerrainForest, models.TerrainRiver,
-		models.TerrainLake,
-	),
-	lakesRow(8,
-		models.TerrainSwamp, models.TerrainPlains, models.TerrainRiver, models.TerrainForest,
-		models.TerrainRiver, models.TerrainRiver, models.TerrainRiver, models.TerrainForest,
-		models.TerrainWasteland, models.TerrainPlains, models.TerrainDesert, models.TerrainSwamp,
-	),
-}
-
-var fireAndIceRows = []rowDefinition{
-	fireAndIceRow(0,
-		models.TerrainPlains, models.TerrainRiver, models.TerrainPlains, models.TerrainSwamp,
-		models.TerrainDesert, models.TerrainRiver, models.TerrainMountain, models.TerrainForest,
-		models.TerrainWasteland, models.TerrainLake, models.TerrainDesert, models.TerrainLake,
-	),
-	fireAndIceRow(1,
-		models.TerrainWasteland, models.TerrainDesert, models.TerrainRiver, models.TerrainLake,
-		models.TerrainMountain, models.TerrainWasteland, models.TerrainRiver, models.TerrainRiver,
-		models.TerrainRiver, models.TerrainDesert, models.TerrainPlains, models.TerrainSwamp,
-		models.TerrainMountain,
-	),
-	fireAndIceRow(2,
-		models.TerrainForest, models.TerrainSwamp, models.TerrainRiver, models.TerrainRiver,
-		models.TerrainRiver, models.TerrainPlains, models.TerrainForest, models.TerrainDesert,
-		models.TerrainRiver, models.TerrainRiver, models.TerrainRiver, models.TerrainRiver,
-	),
-	fireAndIceRow(3,
-		models.TerrainDesert, models.TerrainMountain, models.TerrainForest, models.TerrainDesert,
-		models.TerrainSwamp, models.TerrainRiver, models.TerrainLake, models.TerrainWasteland,
-		models.TerrainPlains, models.TerrainRiver, models.TerrainForest, models.TerrainLake,
-		models.TerrainForest,
-	),
-	fireAndIceRow(4,
-		models.TerrainRiver, models.TerrainRiver, models.TerrainPlains, models.TerrainRiver,
-		models.TerrainRiver, models.TerrainWasteland, models.TerrainSwamp, models.TerrainForest,
-		models.TerrainMountain, models.TerrainRiver, models.TerrainPlains, models.TerrainSwamp,
-	),
-	fireAndIceRow(5,
-		models.TerrainForest, models.TerrainWasteland, models.TerrainRiver, models.TerrainRiver,
-		models.TerrainForest, models.TerrainRiver, models.TerrainRiver, models.TerrainRiver,
-		models.TerrainPlains, models.TerrainLake, models.TerrainRiver, models.TerrainMountain,
-		models.TerrainWasteland,
-	),
-	fireAndIceRow(6,
-		models.TerrainMountain, models.TerrainRiver, models.TerrainDesert, models.TerrainMountain,
-		models.TerrainLake, models.TerrainWasteland, models.TerrainForest, models.TerrainRiver,
-		models.TerrainWasteland, models.TerrainMountain, models.TerrainRiver, models.TerrainSwamp,
-	),
-	fireAndIceRow(7,
-		models.TerrainSwamp, models.TerrainLake, models.TerrainRiver, models.TerrainSwamp,
-		models.TerrainPlains, models.TerrainMountain, models.TerrainLake, models.TerrainRiver,
-		models.TerrainDesert, models.TerrainSwamp, models.TerrainRiver, models.TerrainWasteland,
-		models.TerrainLake,
-	),
-	fireAndIceRow(8,
-		models.TerrainMountain, models.TerrainForest, models.TerrainRiver, models.TerrainWasteland,
-		models.TerrainDesert, models.TerrainSwamp, models.TerrainDesert, models.TerrainRiver,
-		models.TerrainLake, models.TerrainPlains, models.TerrainRiver, models.TerrainPlains,
-	),
-}
-
-var mapDefinitions = map[MapID]mapDefinition{
-	MapBase: {
-		Info: MapInfo{ID: MapBase, Name: "Base"},
-		Rows: cloneRows(baseRows),
-	},
-	MapRevisedBase: {
-		Info: MapInfo{ID: MapRevisedBase, Name: "Revised Base"},
-		Rows: cloneRows(revisedBaseRows),
-	},
-	MapLakes: {
-		Info: MapInfo{ID: MapLakes, Name: "Lakes"},
-		Rows: cloneRows(lakesRows),
-	},
-	MapFireAndIce: {
-		Info: MapInfo{ID: MapFireAndIce, Name: "Fire & Ice"},
-		Rows: cloneRows(fireAndIceRows),
-	},
-	MapArchipelago: {
-		Info: MapInfo{ID: MapArchipelago, Name: "Archipelago"},
-		Rows: []rowDefinition{
-			baseRow(0,
-				models.TerrainSwamp, models.TerrainLake, models.TerrainWasteland, models.TerrainForest,
-				models.TerrainLake, models.TerrainPlains, models.TerrainRiver, models.TerrainWasteland,
-				models.TerrainPlains, models.TerrainSwamp, models.TerrainRiver, models.TerrainLake,
-				models.TerrainForest,
-			),
-			baseRow(1,
-				models.TerrainForest, models.TerrainMountain, models.TerrainSwamp, models.TerrainWasteland,
-				models.TerrainMountain, models.TerrainDesert, models.TerrainRiver, models.TerrainRiver,
-				models.TerrainForest, models.TerrainRiver, models.TerrainSwamp, models.TerrainMountain,
-			),
-			baseRow(2,
-				models.TerrainDesert, models.TerrainSwamp, models.TerrainDesert, models.TerrainPlains,
-				models.TerrainLake, models.TerrainForest, models.TerrainRiver, models.TerrainLake,
-				models.TerrainRiver, models.TerrainRiver, models.TerrainRiver, models.TerrainRiver,
-				models.TerrainLake,
-			),
-			baseRow(3,
-				models.TerrainRiver, models.TerrainMountain, models.TerrainWasteland, models.TerrainRiver,
-				models.TerrainRiver, models.TerrainRiver, models.TerrainPlains, models.TerrainDesert,
-				models.TerrainRiver, models.TerrainWasteland, models.TerrainDesert, models.TerrainRiver,
-			),
-			baseRow(4,
-				models.TerrainLake, models.TerrainRiver, models.TerrainForest, models.TerrainRiver,
-				models.TerrainWasteland, models.TerrainRiver, models.TerrainRiver, models.TerrainWasteland,
-				models.TerrainForest, models.TerrainRiver, models.TerrainPlains, models.TerrainSwamp,
-				models.TerrainPlains,
-			),
-			baseRow(5,
-				models.TerrainWasteland, models.TerrainRiver, models.TerrainRiver, models.TerrainMountain,
-				models.TerrainSwamp, models.TerrainRiver, models.TerrainRiver, models.TerrainRiver,
-				models.TerrainRiver, models.TerrainMountain, models.TerrainForest, models.TerrainRiver,
-			),
-			baseRow(6,
-				models.TerrainMountain, models.TerrainPlains, models.TerrainForest, models.TerrainLake,
-				models.TerrainDesert, models.TerrainLake, models.TerrainRiver, models.TerrainMountain,
-				models.TerrainSwamp, models.TerrainRiver, models.TerrainRiver, models.TerrainRiver,
-				models.TerrainLake,
-			),
-			baseRow(7,
-				models.TerrainSwamp, models.TerrainMountain, models.TerrainDesert, models.TerrainWasteland,
-				models.TerrainMountain, models.TerrainForest, models.TerrainRiver, models.TerrainPlains,
-				models.TerrainRiver, models.TerrainDesert, models.TerrainSwamp, models.TerrainWasteland,
-			),
-			baseRow(8,
-				models.TerrainLake, models.TerrainDesert, models.TerrainForest, models.TerrainPlains,
-				models.TerrainSwamp, models.TerrainPlains, models.TerrainRiver, models.TerrainDesert,
-				models.TerrainMountain, models.TerrainRiver, models.TerrainWasteland, models.TerrainPlains,
-				models.TerrainDesert,
-			),
-		},
-	},
-	MapFjords: {
-		Info: MapInfo{ID: MapFjords, Name: "Fjords"},
-		Rows: []rowDefinition{
-			baseRow(0,
-				models.TerrainForest, models.TerrainSwamp, models.TerrainRiver, models.TerrainPlains,
-				models.TerrainDesert, models.TerrainMountain, models.TerrainSwamp, models.TerrainMountain,
-				models.TerrainDesert, models.TerrainWasteland, models.TerrainSwamp, models.TerrainLake,
-				models.TerrainDesert,
-			),
-			baseRow(1,
-				models.TerrainLake, models.TerrainPlains, models.TerrainRiver, models.TerrainLake,
-				models.TerrainForest, models.TerrainWasteland, models.TerrainRiver, models.TerrainRiver,
-				models.TerrainRiver, models.TerrainRiver, models.TerrainRiver, models.TerrainPlains,
-			),
-			baseRow(2,
-				models.TerrainMountain, models.TerrainForest, models.TerrainWasteland, models.TerrainRiver,
-				models.TerrainRiver, models.TerrainPlains, models.TerrainRiver, models.TerrainSwamp,
-				models.TerrainMountain, models.TerrainPlains, models.TerrainDesert, models.TerrainRiver,
-				models.TerrainMountain,
-			),
-			baseRow(3,
-				models.TerrainRiver, models.TerrainRiver, models.TerrainRiver, models.TerrainMountain,
-				models.TerrainRiver, models.TerrainRiver, models.TerrainForest, models.TerrainWasteland,
-				models.TerrainLake, models.TerrainForest, models.TerrainWasteland, models.TerrainRiver,
-			),
-			baseRow(4,
-				models.TerrainWasteland, models.TerrainMountain, models.TerrainDesert, models.TerrainRiver,
-				models.TerrainLake, models.TerrainWasteland, models.TerrainRiver, models.TerrainPlains,
-				models.TerrainDesert, models.TerrainMountain, models.TerrainPlains, models.TerrainRiver,
-				models.TerrainSwamp,
-			),
-			baseRow(5,
-				models.TerrainSwamp, models.TerrainPlains, models.TerrainRiver, models.TerrainForest,
-				models.TerrainDesert, models.TerrainForest, models.TerrainRiver, models.TerrainMountain,
-				models.TerrainLake, models.TerrainForest, models.TerrainRiver, models.TerrainMountain,
-			),
-			baseRow(6,
-				models.TerrainDesert, models.TerrainLake, models.TerrainRiver, models.TerrainSwamp,
-				models.TerrainMountain, models.TerrainSwamp, models.TerrainLake, models.TerrainRiver,
-				models.TerrainPlains, models.TerrainSwamp, models.TerrainRiver, models.TerrainForest,
-				models.TerrainWasteland,
-			),
-			baseRow(7,
-				models.TerrainForest, models.TerrainRiver, models.TerrainPlains, models.TerrainWasteland,
-				models.TerrainPlains, models.TerrainDesert, models.TerrainWasteland, models.TerrainRiver,
-				models.TerrainRiver, models.TerrainRiver, models.TerrainWasteland, models.TerrainLake,
-			),
-			baseRow(8,
-				models.TerrainSwamp, models.TerrainRiver, models.TerrainRiver, models.TerrainForest,
-				models.TerrainLake, models.TerrainMountain, models.TerrainLake, models.TerrainRiver,
-				models.TerrainForest, models.TerrainDesert, models.TerrainSwamp, models.TerrainPlains,
-				models.TerrainDesert,
-			),
-		},
-	},
-}
-
-var mapCoordinateIndexes = buildMapCoordinateIndexes()
-
-func buildMapCoordinateIndexes() map[MapID]coordinateIndex {
-	indexes := make(map[MapID]coordinateIndex, len(mapDefinitions))
-	for id, def := range mapDefinitions {
-		indexes[id] = buildCoordinateIndex(def)
-	}
-	return indexes
-}
-
 func buildCoordinateIndex(def mapDefinition) coordinateIndex {
 	index := coordinateIndex{
 		displayByHex:   make(map[Hex]string),
@@ -598,7 +212,7 @@ func (d CustomMapDefinition) toMapDefinition() (mapDefinition, error) {
 }
 
 func definitionForBuiltInMap(id MapID) (mapDefinition, error) {
-	def, ok := mapDefinitions[id]
+	def, ok := currentMaps().definitions[id]
 	if !ok {
 		return mapDefinition{}, fmt.Errorf("unknown map id: %s", id)
 	}
@@ -606,8 +220,9 @@ func definitionForBuiltInMap(id MapID) (mapDefinition, error) {
 }
 
 func AvailableMaps() []MapInfo {
-	infos := make([]MapInfo, 0, len(mapDefinitions)+1)
-	for _, def := range mapDefinitions {
+	definitions := currentMaps().definitions
+	infos := make([]MapInfo, 0, len(definitions)+1)
+	for _, def := range definitions {
 		infos = append(infos, def.Info)
 	}
 	infos = append(infos, customMapInfo)
@@ -640,7 +255,7 @@ func MapInfoByID(id MapID) (MapInfo, bool) {
 	if id == MapCustom {
 		return customMapInfo, true
 	}
-	def, ok := mapDefinitions[id]
+	def, ok := currentMaps().definitions[id]
 	if !ok {
 		return MapInfo{}, false
 	}
@@ -651,7 +266,7 @@ func LayoutForMap(id MapID) (map[Hex]models.TerrainType, error) {
 	if id == MapCustom {
 		return nil, fmt.Errorf("custom maps require an explicit definition")
 	}
-	def, ok := mapDefinitions[id]
+	def, ok := currentMaps().definitions[id]
 	if !ok {
 		return nil, fmt.Errorf("unknown map id: %s", id)
 	}
@@ -659,7 +274,7 @@ func LayoutForMap(id MapID) (map[Hex]models.TerrainType, error) {
 }
 
 func DisplayCoordinateForHex(id MapID, hex Hex) (string, bool) {
-	index, ok := mapCoordinateIndexes[id]
+	index, ok := currentMaps().indexes[id]
 	if !ok {
 		return "", false
 	}
@@ -668,7 +283,7 @@ func DisplayCoordinateForHex(id MapID, hex Hex) (string, bool) {
 }
 
 func HexForDisplayCoordinate(id MapID, display string) (Hex, bool) {
-	index, ok := mapCoordinateIndexes[id]
+	index, ok := currentMaps().indexes[id]
 	if !ok {
 		return Hex{}, false
 	}
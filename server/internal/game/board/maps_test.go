@@ -1,6 +1,10 @@
 package board
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/lukev/tm_server/internal/models"
@@ -397,3 +401,123 @@ func TestFjordsLayout_TopAndFourthRows(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadMapDirectory_AddsMapsAndRejectsInvalidFiles(t *testing.T) {
+	t.Cleanup(func() { loadedMaps.Store(builtInMaps) })
+
+	dir := t.TempDir()
+	writeMap := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	writeMap("tiny.yaml", `id: tiny
+name: Tiny
+firstRowLonger: true
+rows:
+  - [plains, river, swamp]
+  - [forest, lake]
+`)
+	if err := LoadMapDirectory(dir); err != nil {
+		t.Fatalf("LoadMapDirectory failed: %v", err)
+	}
+	layout, err := LayoutForMap("tiny")
+	if err != nil {
+		t.Fatalf("LayoutForMap(tiny) failed: %v", err)
+	}
+	if len(layout) != 5 {
+		t.Fatalf("expected 5 hexes, got %d", len(layout))
+	}
+	if err := ValidateTerrainLayout("tiny", map[string]models.TerrainType{"A1": models.TerrainPlains, "B2": models.TerrainLake}); err != nil {
+		t.Fatalf("ValidateTerrainLayout failed: %v", err)
+	}
+	if _, err := LayoutForMap(MapBase); err != nil {
+		t.Fatalf("built-in maps should stay available: %v", err)
+	}
+
+	writeMap("broken.yaml", `id: broken
+name: Broken
+firstRowLonger: true
+rows:
+  - [plains, meadow]
+`)
+	err = LoadMapDirectory(dir)
+	if err == nil || !strings.Contains(err.Error(), `unknown terrain "meadow"`) {
+		t.Fatalf("expected unknown terrain error, got %v", err)
+	}
+	if _, err := LayoutForMap("tiny"); err != nil {
+		t.Fatalf("failed load should keep previous maps: %v", err)
+	}
+}
+
+func TestReadMapDirectory_AppliesOnlyOnApply(t *testing.T) {
+	t.Cleanup(func() { loadedMaps.Store(builtInMaps) })
+
+	dir := t.TempDir()
+	tiny := "id: tiny\nname: Tiny\nfirstRowLonger: true\nrows:\n  - [plains, river, swamp]\n  - [forest, lake]\n"
+	if err := os.WriteFile(filepath.Join(dir, "tiny.yaml"), []byte(tiny), 0o644); err != nil {
+		t.Fatalf("write tiny.yaml: %v", err)
+	}
+	maps, err := ReadMapDirectory(dir)
+	if err != nil {
+		t.Fatalf("ReadMapDirectory failed: %v", err)
+	}
+	if _, err := LayoutForMap("tiny"); err == nil {
+		t.Fatalf("reading maps should not make them available")
+	}
+	maps.Apply()
+	if _, err := LayoutForMap("tiny"); err != nil {
+		t.Fatalf("expected applied maps: %v", err)
+	}
+}
+
+func TestValidateTerrainLayout_ReportsMismatch(t *testing.T) {
+	if err := ValidateTerrainLayout(MapBase, map[string]models.TerrainType{"E7": models.TerrainMountain}); err != nil {
+		t.Fatalf("expected E7 to be mountain on the base map: %v", err)
+	}
+	err := ValidateTerrainLayout(MapBase, map[string]models.TerrainType{"E7": models.TerrainDesert})
+	if err == nil || !strings.Contains(err.Error(), "E7") {
+		t.Fatalf("expected E7 mismatch, got %v", err)
+	}
+}
+
+func TestTerraMysticaMap_JSONRoundTrip(t *testing.T) {
+	m, err := NewTerraMysticaMapForID(MapLakes)
+	if err != nil {
+		t.Fatalf("NewTerraMysticaMapForID: %v", err)
+	}
+	var land Hex
+	for hex, mapHex := range m.Hexes {
+		if mapHex.Terrain != models.TerrainRiver {
+			land = hex
+			break
+		}
+	}
+	if err := m.TransformTerrain(land, models.TerrainSwamp); err != nil {
+		t.Fatalf("TransformTerrain: %v", err)
+	}
+	m.Bridges[NewBridgeKey(NewHex(0, 0), NewHex(1, -2))] = "p1"
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var restored TerraMysticaMap
+	if err := json.Unmarshal(raw, &restored); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	again, err := json.Marshal(&restored)
+	if err != nil {
+		t.Fatalf("marshal restored: %v", err)
+	}
+	if string(again) != string(raw) {
+		t.Fatalf("map changed in a JSON round trip")
+	}
+	if restored.ID != MapLakes || restored.Hexes[land].Terrain != models.TerrainSwamp {
+		t.Fatalf("restored map lost its ID or terrain: %s, %v", restored.ID, restored.Hexes[land].Terrain)
+	}
+	if _, ok := restored.DisplayCoordinateForHex(land); !ok {
+		t.Fatalf("restored map has no layout labels")
+	}
+}
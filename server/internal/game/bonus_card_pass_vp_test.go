@@ -188,3 +188,35 @@ func TestBonusCard_PassVP_TradingHouseCard(t *testing.T) {
 			expectedVP, player.VictoryPoints)
 	}
 }
+
+// TestBonusCard_PassVP_StrongholdSanctuaryAndShipping tests that the pass VP of
+// the stronghold/sanctuary and shipping cards is read from the state at pass time
+func TestBonusCard_PassVP_StrongholdSanctuaryAndShipping(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewCultists()
+	gs.AddPlayer("player1", faction)
+	gs.AddPlayer("player2", factions.NewDwarves())
+	player := gs.GetPlayer("player1")
+
+	if vp := GetBonusCardPassVP(BonusCardStrongholdSanctuary, gs, "player1"); vp != 0 {
+		t.Errorf("expected 0 VP without stronghold or sanctuary, got %d", vp)
+	}
+	for i, buildingType := range []models.BuildingType{models.BuildingStronghold, models.BuildingSanctuary} {
+		gs.Map.GetHex(board.NewHex(i, 0)).Building = &models.Building{
+			Type:     buildingType,
+			Faction:  faction.GetType(),
+			PlayerID: "player1",
+		}
+	}
+	if vp := GetBonusCardPassVP(BonusCardStrongholdSanctuary, gs, "player1"); vp != 8 {
+		t.Errorf("expected 8 VP for stronghold and sanctuary (4 VP each), got %d", vp)
+	}
+
+	player.ShippingLevel = 2
+	if vp := GetBonusCardPassVP(BonusCardShippingVP, gs, "player1"); vp != 6 {
+		t.Errorf("expected 6 VP for shipping level 2 (3 VP per level), got %d", vp)
+	}
+	if vp := GetBonusCardPassVP(BonusCardShippingVP, gs, "player2"); vp != 0 {
+		t.Errorf("expected Dwarves to get no shipping VP, got %d", vp)
+	}
+}
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/lukev/tm_server/internal/game/board"
@@ -387,7 +388,7 @@ func TestBonusCard_CoinAccumulation(t *testing.T) {
 	}
 }
 
-// Test AddCoinsToLeftoverCards functionality
+// Test AddCoins functionality
 func TestBonusCard_AddCoinsToLeftover(t *testing.T) {
 	gs := NewGameState()
 	gs.AddPlayer("player1", factions.NewAuren())
@@ -403,7 +404,7 @@ func TestBonusCard_AddCoinsToLeftover(t *testing.T) {
 	}
 
 	// Add coins to leftover cards
-	gs.BonusCards.AddCoinsToLeftoverCards()
+	gs.BonusCards.AddCoins(1)
 
 	// All 3 cards should now have 1 coin
 	if gs.BonusCards.Available[BonusCard6Coins] != 1 {
@@ -416,11 +417,45 @@ func TestBonusCard_AddCoinsToLeftover(t *testing.T) {
 		t.Errorf("expected 1 coin on Worker/Power card after adding")
 	}
 
-	// Add coins again
-	gs.BonusCards.AddCoinsToLeftoverCards()
+	// Repeating the same round's placement changes nothing
+	if gs.BonusCards.AddCoins(1) || gs.BonusCards.Available[BonusCard6Coins] != 1 {
+		t.Errorf("expected coins for round 1 to be placed once")
+	}
+
+	// Add coins for the next round
+	gs.BonusCards.AddCoins(2)
 
 	// All 3 cards should now have 2 coins
 	if gs.BonusCards.Available[BonusCard6Coins] != 2 {
 		t.Errorf("expected 2 coins on 6 Coins card after second adding")
 	}
 }
+
+// Test that card coins survive serialization and undo snapshots
+func TestBonusCard_CoinsSurviveSerializationAndClone(t *testing.T) {
+	bcs := NewBonusCardState()
+	bcs.SetAvailableBonusCards([]BonusCardType{BonusCard6Coins, BonusCardPriest})
+	bcs.AddCoins(1)
+	bcs.AddCoins(2)
+
+	data, err := json.Marshal(bcs)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	decoded := NewBonusCardState()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	for _, restored := range []*BonusCardState{decoded, cloneBonusCardState(bcs)} {
+		if restored.GetCoinsOnCard(BonusCard6Coins) != 2 || restored.CoinsPlacedForRound != 2 {
+			t.Fatalf("expected 2 coins placed through round 2, got %+v", restored)
+		}
+		if restored.AddCoins(2) {
+			t.Fatalf("expected the restored state to remember round 2's coins")
+		}
+	}
+
+	if coins := bcs.ClaimCoins(BonusCard6Coins); coins != 2 || bcs.GetCoinsOnCard(BonusCard6Coins) != 0 {
+		t.Fatalf("expected to claim 2 coins and leave none, got %d", coins)
+	}
+}
@@ -3,6 +3,7 @@ package game
 import (
 	"fmt"
 	"math/rand"
+	"sort"
 	"time"
 
 	"github.com/lukev/tm_server/internal/models"
@@ -80,130 +81,24 @@ type BonusCard struct {
 	PassVPType string // "dwelling", "trading_house", "stronghold_sanctuary", "shipping"
 }
 
-// GetAllBonusCards returns all bonus cards with their properties
+// GetAllBonusCards returns all bonus cards with their properties, as defined in
+// data/tiles.yaml. Games use the definitions they were created with; see
+// GameState.AllBonusCards.
 func GetAllBonusCards() map[BonusCardType]BonusCard {
-	return map[BonusCardType]BonusCard{
-		BonusCardPriest: {
-			Type:             BonusCardPriest,
-			Name:             "Priest Income",
-			Description:      "Income: +1 Priest",
-			Coins:            0,
-			Workers:          0,
-			Priests:          1,
-			Power:            0,
-			HasSpecialAction: false,
-			ShippingBonus:    0,
-			PassVPType:       "",
-		},
-		BonusCardShipping: {
-			Type:             BonusCardShipping,
-			Name:             "Shipping Bonus",
-			Description:      "Income: +3 Power. Shipping +1 for the round (not Dwarves/Fakirs)",
-			Coins:            0,
-			Workers:          0,
-			Priests:          0,
-			Power:            3,
-			HasSpecialAction: false,
-			ShippingBonus:    1,
-			PassVPType:       "",
-		},
-		BonusCardDwellingVP: {
-			Type:             BonusCardDwellingVP,
-			Name:             "Dwelling VP",
-			Description:      "Income: +2 Coins. Pass: +1 VP per Dwelling",
-			Coins:            2,
-			Workers:          0,
-			Priests:          0,
-			Power:            0,
-			HasSpecialAction: false,
-			ShippingBonus:    0,
-			PassVPType:       "dwelling",
-		},
-		BonusCardWorkerPower: {
-			Type:             BonusCardWorkerPower,
-			Name:             "Worker & Power",
-			Description:      "Income: +1 Worker, +3 Power",
-			Coins:            0,
-			Workers:          1,
-			Priests:          0,
-			Power:            3,
-			HasSpecialAction: false,
-			ShippingBonus:    0,
-			PassVPType:       "",
-		},
-		BonusCardSpade: {
-			Type:             BonusCardSpade,
-			Name:             "Free Spade",
-			Description:      "Income: +2 Coins. Special action: 1 free spade (once per round)",
-			Coins:            2,
-			Workers:          0,
-			Priests:          0,
-			Power:            0,
-			HasSpecialAction: true,
-			ShippingBonus:    0,
-			PassVPType:       "",
-		},
-		BonusCardTradingHouseVP: {
-			Type:             BonusCardTradingHouseVP,
-			Name:             "Trading House VP",
-			Description:      "Income: +1 Worker. Pass: +2 VP per Trading House",
-			Coins:            0,
-			Workers:          1,
-			Priests:          0,
-			Power:            0,
-			HasSpecialAction: false,
-			ShippingBonus:    0,
-			PassVPType:       "trading_house",
-		},
-		BonusCard6Coins: {
-			Type:             BonusCard6Coins,
-			Name:             "6 Coins",
-			Description:      "Income: +6 Coins",
-			Coins:            6,
-			Workers:          0,
-			Priests:          0,
-			Power:            0,
-			HasSpecialAction: false,
-			ShippingBonus:    0,
-			PassVPType:       "",
-		},
-		BonusCardCultAdvance: {
-			Type:             BonusCardCultAdvance,
-			Name:             "Cult Advance",
-			Description:      "Income: +4 Coins. Special action: Advance 1 on any cult track (once per round)",
-			Coins:            4,
-			Workers:          0,
-			Priests:          0,
-			Power:            0,
-			HasSpecialAction: true,
-			ShippingBonus:    0,
-			PassVPType:       "",
-		},
-		BonusCardStrongholdSanctuary: {
-			Type:             BonusCardStrongholdSanctuary,
-			Name:             "Stronghold/Sanctuary VP",
-			Description:      "Income: +2 Workers. Pass: +4 VP if Stronghold built, +4 VP if Sanctuary built",
-			Coins:            0,
-			Workers:          2,
-			Priests:          0,
-			Power:            0,
-			HasSpecialAction: false,
-			ShippingBonus:    0,
-			PassVPType:       "stronghold_sanctuary",
-		},
-		BonusCardShippingVP: {
-			Type:             BonusCardShippingVP,
-			Name:             "Shipping VP",
-			Description:      "Income: +3 Power. Pass: +3 VP per shipping level (not Dwarves/Fakirs)",
-			Coins:            0,
-			Workers:          0,
-			Priests:          0,
-			Power:            3,
-			HasSpecialAction: false,
-			ShippingBonus:    0,
-			PassVPType:       "shipping",
-		},
+	return currentTiles().allBonusCards()
+}
+
+// AllBonusCards returns the bonus cards gs was created with.
+func (gs *GameState) AllBonusCards() map[BonusCardType]BonusCard {
+	return gs.tileDefinitions().allBonusCards()
+}
+
+func (c *tileCatalog) allBonusCards() map[BonusCardType]BonusCard {
+	out := make(map[BonusCardType]BonusCard, len(c.bonusCards))
+	for cardType, card := range c.bonusCards {
+		out[cardType] = card
 	}
+	return out
 }
 
 // BonusCardState tracks available bonus cards and player selections
@@ -219,6 +114,13 @@ type BonusCardState struct {
 
 	// Track which players have selected a card this round
 	PlayerHasCard map[string]bool `json:"playerHasCard"`
+
+	// Cards taken out of the game at setup so that playerCount + 3 remain.
+	Removed []BonusCardType `json:"removed,omitempty"`
+
+	// CoinsPlacedForRound is the last round whose coins were placed on the
+	// available cards; see AddCoins.
+	CoinsPlacedForRound int `json:"coinsPlacedForRound,omitempty"`
 }
 
 // NewBonusCardState creates a new bonus card state
@@ -236,33 +138,68 @@ func NewBonusCardState() *BonusCardState {
 // This should be called during game setup
 // Returns the selected card types
 func (bcs *BonusCardState) SelectRandomBonusCards(playerCount int) []BonusCardType {
-	// Get all 10 bonus cards
-	allCards := GetAllBonusCards()
-	allCardTypes := make([]BonusCardType, 0, len(allCards))
+	return bcs.SelectRandomBonusCardsWithRand(playerCount, rand.New(rand.NewSource(time.Now().UnixNano())))
+}
+
+// SelectRandomBonusCardsWithRand is SelectRandomBonusCards drawing from rng, so
+// a seeded source yields the same cards.
+func (bcs *BonusCardState) SelectRandomBonusCardsWithRand(playerCount int, rng *rand.Rand) []BonusCardType {
+	selected, _ := bcs.SelectBonusCardsWithRand(playerCount, nil, rng)
+	return selected
+}
+
+// SelectBonusCardsWithRand takes the chosen cards out of the game, then
+// randomly removes more until (playerCount + 3) cards remain, and makes those
+// available. It fails if a chosen card is unknown or repeated, or if more
+// cards are chosen than the player count removes.
+func (bcs *BonusCardState) SelectBonusCardsWithRand(playerCount int, removed []BonusCardType, rng *rand.Rand) ([]BonusCardType, error) {
+	return bcs.selectBonusCardsWithRand(GetAllBonusCards(), playerCount, removed, rng)
+}
+
+// selectBonusCardsWithRand is SelectBonusCardsWithRand choosing from allCards.
+func (bcs *BonusCardState) selectBonusCardsWithRand(allCards map[BonusCardType]BonusCard, playerCount int, removed []BonusCardType, rng *rand.Rand) ([]BonusCardType, error) {
+	numCards := playerCount + 3
+	if numCards > len(allCards) {
+		numCards = len(allCards)
+	}
+	if len(removed) > len(allCards)-numCards {
+		return nil, fmt.Errorf("%d players remove %d bonus cards, got %d", playerCount, len(allCards)-numCards, len(removed))
+	}
+	removedSet := make(map[BonusCardType]bool, len(removed))
+	for _, cardType := range removed {
+		if _, ok := allCards[cardType]; !ok {
+			return nil, fmt.Errorf("unknown bonus card %d", cardType)
+		}
+		if removedSet[cardType] {
+			return nil, fmt.Errorf("bonus card %d removed twice", cardType)
+		}
+		removedSet[cardType] = true
+	}
+
+	remaining := make([]BonusCardType, 0, len(allCards))
 	for cardType := range allCards {
-		allCardTypes = append(allCardTypes, cardType)
+		if !removedSet[cardType] {
+			remaining = append(remaining, cardType)
+		}
 	}
+	// Map iteration order is random; sort first so the shuffle alone decides.
+	sort.Slice(remaining, func(i, j int) bool { return remaining[i] < remaining[j] })
 
 	// Randomly shuffle the cards (Fisher-Yates shuffle).
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-	rng.Shuffle(len(allCardTypes), func(i, j int) {
-		allCardTypes[i], allCardTypes[j] = allCardTypes[j], allCardTypes[i]
+	rng.Shuffle(len(remaining), func(i, j int) {
+		remaining[i], remaining[j] = remaining[j], remaining[i]
 	})
 
-	// Select the first (playerCount + 3) cards
-	numCards := playerCount + 3
-	if numCards > len(allCardTypes) {
-		numCards = len(allCardTypes)
-	}
-
-	selectedCards := allCardTypes[:numCards]
+	selectedCards := remaining[:numCards]
+	bcs.Removed = append(append([]BonusCardType(nil), removed...), remaining[numCards:]...)
+	sort.Slice(bcs.Removed, func(i, j int) bool { return bcs.Removed[i] < bcs.Removed[j] })
 
 	// Add selected cards to available pool with 0 coins
 	for _, cardType := range selectedCards {
 		bcs.Available[cardType] = 0
 	}
 
-	return selectedCards
+	return selectedCards, nil
 }
 
 // SetAvailableBonusCards manually sets which bonus cards are available
@@ -272,6 +209,13 @@ func (bcs *BonusCardState) SetAvailableBonusCards(cardTypes []BonusCardType) {
 	for _, cardType := range cardTypes {
 		bcs.Available[cardType] = 0
 	}
+	bcs.Removed = nil
+	for cardType := range GetAllBonusCards() {
+		if _, ok := bcs.Available[cardType]; !ok {
+			bcs.Removed = append(bcs.Removed, cardType)
+		}
+	}
+	sort.Slice(bcs.Removed, func(i, j int) bool { return bcs.Removed[i] < bcs.Removed[j] })
 }
 
 // InitializePlayer initializes bonus card tracking for a player
@@ -290,6 +234,20 @@ func (bcs *BonusCardState) GetCoinsOnCard(cardType BonusCardType) int {
 	return bcs.Available[cardType]
 }
 
+// MakeAvailable puts a card in the available pool holding coins.
+func (bcs *BonusCardState) MakeAvailable(cardType BonusCardType, coins int) {
+	bcs.Available[cardType] = coins
+}
+
+// ClaimCoins takes the coins off an available card and returns them.
+func (bcs *BonusCardState) ClaimCoins(cardType BonusCardType) int {
+	coins := bcs.Available[cardType]
+	if coins > 0 {
+		bcs.Available[cardType] = 0
+	}
+	return coins
+}
+
 // TakeBonusCard assigns a bonus card to a player when they pass
 // Returns the number of coins that were on the card
 func (bcs *BonusCardState) TakeBonusCard(playerID string, cardType BonusCardType) (int, error) {
@@ -318,8 +276,7 @@ func (bcs *BonusCardState) TakeBonusCard(playerID string, cardType BonusCardType
 		bcs.ReturnAllBonusCards(playerID)
 	}
 
-	// Get coins from the card
-	coins := bcs.Available[cardType]
+	coins := bcs.ClaimCoins(cardType)
 
 	// Remove from available and assign to player
 	delete(bcs.Available, cardType)
@@ -345,7 +302,7 @@ func (bcs *BonusCardState) TakeAdditionalBonusCard(playerID string, cardType Bon
 		}
 	}
 
-	coins := bcs.Available[cardType]
+	coins := bcs.ClaimCoins(cardType)
 	delete(bcs.Available, cardType)
 	bcs.PlayerExtraCards[playerID] = append(bcs.PlayerExtraCards[playerID], cardType)
 	bcs.PlayerHasCard[playerID] = true
@@ -360,7 +317,7 @@ func (bcs *BonusCardState) ReturnBonusCard(playerID string) {
 func (bcs *BonusCardState) ReturnAllBonusCards(playerID string) []BonusCardType {
 	cards := bcs.GetPlayerCards(playerID)
 	for _, cardType := range cards {
-		bcs.Available[cardType] = 0
+		bcs.MakeAvailable(cardType, 0)
 	}
 	delete(bcs.PlayerCards, playerID)
 	delete(bcs.PlayerExtraCards, playerID)
@@ -368,12 +325,19 @@ func (bcs *BonusCardState) ReturnAllBonusCards(playerID string) []BonusCardType
 	return cards
 }
 
-// AddCoinsToLeftoverCards adds 1 coin to each leftover (unselected) bonus card
-// Called during cleanup phase
-func (bcs *BonusCardState) AddCoinsToLeftoverCards() {
+// AddCoins places 1 coin on each available card before round forRound: after
+// setup for round 1, and in the cleanup of the round before for the others.
+// Coins are placed once per round, so replaying or repeating the transition
+// leaves them as they are; it reports whether coins were placed.
+func (bcs *BonusCardState) AddCoins(forRound int) bool {
+	if forRound <= bcs.CoinsPlacedForRound {
+		return false
+	}
 	for cardType := range bcs.Available {
 		bcs.Available[cardType]++
 	}
+	bcs.CoinsPlacedForRound = forRound
+	return true
 }
 
 // GetPlayerCard returns the bonus card a player has this round
@@ -410,8 +374,11 @@ func (bcs *BonusCardState) PlayerHasCardType(cardType BonusCardType) bool {
 
 // GetBonusCardIncomeBonus returns the income bonus from a player's bonus card
 func GetBonusCardIncomeBonus(cardType BonusCardType) (coins, workers, priests, power int) {
-	allCards := GetAllBonusCards()
-	card, ok := allCards[cardType]
+	return currentTiles().bonusCardIncome(cardType)
+}
+
+func (c *tileCatalog) bonusCardIncome(cardType BonusCardType) (coins, workers, priests, power int) {
+	card, ok := c.bonusCards[cardType]
 	if !ok {
 		return 0, 0, 0, 0
 	}
@@ -422,8 +389,11 @@ func GetBonusCardIncomeBonus(cardType BonusCardType) (coins, workers, priests, p
 // GetBonusCardShippingBonus returns the shipping bonus from a player's bonus card
 // Returns 0 if player's faction doesn't benefit (Dwarves, Fakirs)
 func GetBonusCardShippingBonus(cardType BonusCardType, factionType models.FactionType) int {
-	allCards := GetAllBonusCards()
-	card, ok := allCards[cardType]
+	return currentTiles().bonusCardShippingBonus(cardType, factionType)
+}
+
+func (c *tileCatalog) bonusCardShippingBonus(cardType BonusCardType, factionType models.FactionType) int {
+	card, ok := c.bonusCards[cardType]
 	if !ok {
 		return 0
 	}
@@ -438,8 +408,7 @@ func GetBonusCardShippingBonus(cardType BonusCardType, factionType models.Factio
 
 // GetBonusCardPassVP returns VP gained when passing based on the bonus card
 func GetBonusCardPassVP(cardType BonusCardType, gs *GameState, playerID string) int {
-	allCards := GetAllBonusCards()
-	card, ok := allCards[cardType]
+	card, ok := gs.tileDefinitions().bonusCards[cardType]
 	if !ok {
 		return 0
 	}
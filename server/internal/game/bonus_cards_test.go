@@ -1,6 +1,7 @@
 package game
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/lukev/tm_server/internal/game/factions"
@@ -141,3 +142,43 @@ func TestBonusCards_AllowsRetakingSameCardWhenPassing(t *testing.T) {
 		t.Fatalf("retaking same bonus card should be allowed, got error: %v", err)
 	}
 }
+
+func TestSelectBonusCardsWithRand_KeepsChosenRemovalsOut(t *testing.T) {
+	bcs := NewBonusCardState()
+	removed := []BonusCardType{BonusCardSpade, BonusCardPriest}
+	selected, err := bcs.SelectBonusCardsWithRand(3, removed, rand.New(rand.NewSource(7)))
+	if err != nil {
+		t.Fatalf("select bonus cards: %v", err)
+	}
+	if len(selected) != 6 || len(bcs.Available) != 6 {
+		t.Fatalf("expected 6 cards for 3 players, got %v", selected)
+	}
+	if len(bcs.Removed) != 4 {
+		t.Fatalf("expected 4 removed cards, got %v", bcs.Removed)
+	}
+	for _, card := range removed {
+		if bcs.IsAvailable(card) {
+			t.Fatalf("expected chosen removal %d to stay out", card)
+		}
+	}
+	for _, card := range bcs.Removed {
+		if _, ok := bcs.Available[card]; ok {
+			t.Fatalf("removed card %d is also available", card)
+		}
+	}
+
+	if _, err := NewBonusCardState().SelectBonusCardsWithRand(5, []BonusCardType{BonusCardSpade, BonusCardPriest, BonusCard6Coins}, rand.New(rand.NewSource(7))); err == nil {
+		t.Fatalf("expected 5 players to remove only 2 cards")
+	}
+	if _, err := NewBonusCardState().SelectBonusCardsWithRand(2, []BonusCardType{BonusCardSpade, BonusCardSpade}, rand.New(rand.NewSource(7))); err == nil {
+		t.Fatalf("expected repeated removal to be rejected")
+	}
+}
+
+func TestSelectRandomBonusCardsWithRand_RecordsRemovedCards(t *testing.T) {
+	bcs := NewBonusCardState()
+	bcs.SelectRandomBonusCardsWithRand(2, rand.New(rand.NewSource(11)))
+	if len(bcs.Available) != 5 || len(bcs.Removed) != 5 {
+		t.Fatalf("expected 5 available and 5 removed cards for 2 players, got %v and %v", bcs.Available, bcs.Removed)
+	}
+}
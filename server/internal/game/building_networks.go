@@ -0,0 +1,139 @@
+package game
+
+import (
+	"sort"
+
+	"github.com/lukev/tm_server/internal/game/board"
+)
+
+// BuildingCluster is one group of a player's buildings that town formation
+// treats as connected.
+type BuildingCluster struct {
+	Hexes         []HexCoord `json:"hexes"`
+	BuildingCount int        `json:"buildingCount"`
+	Power         int        `json:"power"`
+	// PartOfTown reports that the cluster already holds a town and cannot
+	// found another.
+	PartOfTown bool `json:"partOfTown"`
+	// BuildingsToTown and PowerToTown are what the cluster still lacks to
+	// found a town. Both are zero once the requirements are met.
+	BuildingsToTown int `json:"buildingsToTown"`
+	PowerToTown     int `json:"powerToTown"`
+	// TownHexes are the empty land hexes where a single dwelling would found a
+	// town with this cluster ("town in 1"), merging it with the player's other
+	// clusters next to the hex where that applies.
+	TownHexes []HexCoord `json:"townHexes"`
+}
+
+// AnalyzeBuildingNetworks returns the building clusters of every player with
+// a faction, keyed by player ID.
+func (gs *GameState) AnalyzeBuildingNetworks() map[string][]BuildingCluster {
+	networks := make(map[string][]BuildingCluster, len(gs.Players))
+	if gs.Map == nil {
+		return networks
+	}
+	for playerID, player := range gs.Players {
+		if player == nil || player.Faction == nil {
+			continue
+		}
+		networks[playerID] = gs.buildingClustersForPlayer(player, playerID)
+	}
+	return networks
+}
+
+func (gs *GameState) buildingClustersForPlayer(player *Player, playerID string) []BuildingCluster {
+	buildingHexes := gs.getPlayerBuildingHexes(playerID)
+	sortHexes(buildingHexes)
+
+	clusterOf := make(map[board.Hex]int, len(buildingHexes))
+	var components [][]board.Hex
+	for _, hex := range buildingHexes {
+		if _, ok := clusterOf[hex]; ok {
+			continue
+		}
+		connected, _ := gs.townComponent(player, playerID, hex)
+		for _, h := range connected {
+			if _, ok := clusterOf[h]; !ok {
+				clusterOf[h] = len(components)
+			}
+		}
+		components = append(components, connected)
+	}
+
+	clusters := make([]BuildingCluster, len(components))
+	for i, component := range components {
+		measure := gs.measureTown(player, playerID, component, 0)
+		cluster := BuildingCluster{
+			Hexes:           hexCoordsOf(component),
+			BuildingCount:   measure.Buildings,
+			Power:           measure.Power,
+			BuildingsToTown: maxInt(0, measure.MinBuildings-measure.Buildings),
+			PowerToTown:     maxInt(0, measure.MinPower-measure.Power),
+			TownHexes:       []HexCoord{},
+		}
+		for _, hex := range component {
+			if mapHex := gs.Map.GetHex(hex); mapHex != nil && mapHex.PartOfTown {
+				cluster.PartOfTown = true
+				cluster.BuildingsToTown, cluster.PowerToTown = 0, 0
+				break
+			}
+		}
+		clusters[i] = cluster
+	}
+
+	for _, candidate := range gs.townCandidateHexes() {
+		adjacent := map[int]bool{}
+		for _, hex := range buildingHexes {
+			if gs.areHexesDirectlyAdjacentForPlayer(playerID, candidate, hex) {
+				adjacent[clusterOf[hex]] = true
+			}
+		}
+		if len(adjacent) == 0 {
+			continue
+		}
+		merged := []board.Hex{candidate}
+		inTown := false
+		for i := range adjacent {
+			merged = append(merged, components[i]...)
+			inTown = inTown || clusters[i].PartOfTown
+		}
+		if inTown || !gs.measureTown(player, playerID, merged, 1).complete() {
+			continue
+		}
+		for i := range adjacent {
+			clusters[i].TownHexes = append(clusters[i].TownHexes, hexCoordOf(candidate))
+		}
+	}
+	return clusters
+}
+
+// townCandidateHexes lists the empty land hexes, sorted.
+func (gs *GameState) townCandidateHexes() []board.Hex {
+	var hexes []board.Hex
+	for hex, mapHex := range gs.Map.Hexes {
+		if mapHex != nil && mapHex.Building == nil && !gs.Map.IsRiver(hex) {
+			hexes = append(hexes, hex)
+		}
+	}
+	sortHexes(hexes)
+	return hexes
+}
+
+func sortHexes(hexes []board.Hex) {
+	sort.Slice(hexes, func(i, j int) bool {
+		if hexes[i].R != hexes[j].R {
+			return hexes[i].R < hexes[j].R
+		}
+		return hexes[i].Q < hexes[j].Q
+	})
+}
+
+func hexCoordsOf(hexes []board.Hex) []HexCoord {
+	sorted := append([]board.Hex(nil), hexes...)
+	sortHexes(sorted)
+	coords := make([]HexCoord, len(sorted))
+	for i, hex := range sorted {
+		coords[i] = hexCoordOf(hex)
+	}
+	return coords
+}
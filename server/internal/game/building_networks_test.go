@@ -0,0 +1,75 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/game/factions"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+func TestAnalyzeBuildingNetworks_ReportsDistanceToTownAndTownHexes(t *testing.T) {
+	gs := NewGameState()
+	if err := gs.AddPlayer("p1", factions.NewHalflings()); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+	place := func(hex board.Hex, buildingType models.BuildingType) {
+		gs.Map.PlaceBuilding(hex, &models.Building{
+			Type:       buildingType,
+			Faction:    models.FactionHalflings,
+			PlayerID:   "p1",
+			PowerValue: GetPowerValue(buildingType),
+		})
+	}
+	// 3 buildings worth 6 power: one more dwelling founds a town.
+	cluster := []board.Hex{board.NewHex(0, 0), board.NewHex(1, 0), board.NewHex(0, 1)}
+	place(cluster[0], models.BuildingStronghold)
+	place(cluster[1], models.BuildingTradingHouse)
+	place(cluster[2], models.BuildingDwelling)
+	lone := board.NewHex(4, 8)
+	if gs.Map.GetHex(lone) == nil || gs.Map.IsRiver(lone) {
+		t.Fatalf("expected %v to be a land hex", lone)
+	}
+	place(lone, models.BuildingDwelling)
+
+	clusters := gs.AnalyzeBuildingNetworks()["p1"]
+	if len(clusters) != 2 {
+		t.Fatalf("expected 2 clusters, got %+v", clusters)
+	}
+	near, far := clusters[0], clusters[1]
+	if len(near.Hexes) != 3 || near.BuildingCount != 3 || near.Power != 6 {
+		t.Fatalf("unexpected near cluster: %+v", near)
+	}
+	if near.BuildingsToTown != 1 || near.PowerToTown != 1 {
+		t.Fatalf("expected near cluster one dwelling from a town, got %+v", near)
+	}
+	if far.BuildingsToTown != 3 || far.PowerToTown != 6 || len(far.TownHexes) != 0 {
+		t.Fatalf("unexpected far cluster: %+v", far)
+	}
+
+	want := map[HexCoord]bool{}
+	for hex, mapHex := range gs.Map.Hexes {
+		if mapHex.Building != nil || gs.Map.IsRiver(hex) {
+			continue
+		}
+		for _, built := range cluster {
+			if gs.Map.IsDirectlyAdjacent(hex, built) {
+				want[hexCoordOf(hex)] = true
+			}
+		}
+	}
+	if len(want) == 0 || len(near.TownHexes) != len(want) {
+		t.Fatalf("expected town hexes %v, got %v", want, near.TownHexes)
+	}
+	for _, coord := range near.TownHexes {
+		if !want[coord] {
+			t.Fatalf("unexpected town hex %v in %v", coord, near.TownHexes)
+		}
+	}
+
+	gs.Map.GetHex(cluster[0]).PartOfTown = true
+	near = gs.AnalyzeBuildingNetworks()["p1"][0]
+	if !near.PartOfTown || near.BuildingsToTown != 0 || len(near.TownHexes) != 0 {
+		t.Fatalf("expected town cluster without town hexes, got %+v", near)
+	}
+}
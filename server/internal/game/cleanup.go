@@ -3,7 +3,7 @@ package game
 // Cleanup Phase
 // Executes at the end of each round (rounds 1-5, not round 6)
 // Order of operations:
-// 1. Add coins to leftover bonus cards (players keep their cards across rounds)
+// 1. Add coins to leftover bonus cards for the next round (players keep their cards across rounds)
 // 2. Reset round-specific state
 //
 // NOTE: The cult-track rewards printed on the round scoring tiles are applied during
@@ -18,7 +18,7 @@ package game
 func (gs *GameState) ExecuteCleanupPhase() bool {
 	// Round 6 doesn't have a cleanup phase - game ends immediately
 	if gs.Round >= 6 {
-		gs.Phase = PhaseEnd
+		gs.Phases().Enter(PhaseEnd)
 		gs.FinalScoring = gs.CalculateFinalScoring()
 		// Keep player VP counters aligned with final scoring totals for replay/UI parity.
 		for playerID, score := range gs.FinalScoring {
@@ -32,37 +32,47 @@ func (gs *GameState) ExecuteCleanupPhase() bool {
 		return false
 	}
 
-	gs.Phase = PhaseCleanup
+	gs.Phases().Enter(PhaseCleanup)
 
 	// 1. Add 1 coin to each available (unselected) bonus card
 	// NOTE: Players keep their bonus cards across rounds - cards are only returned when
 	// players pass and select a new card. Coins accumulate on cards in the Available pool.
 	if gs.BonusCards != nil {
-		gs.BonusCards.AddCoinsToLeftoverCards()
+		gs.BonusCards.AddCoins(gs.Round + 1)
 	}
 
 	// 2. Reset round-specific state
-	gs.ResetRoundState()
+	RoundReset(gs)
 
 	// Game continues to next round
 	return true
 }
 
-// ResetRoundState resets all round-specific state
-// Called at end of round during cleanup
-func (gs *GameState) ResetRoundState() {
+// RoundReset makes every once-per-round resource available again: power
+// action spaces, faction/bonus card/favor tile special actions, bonus card
+// selection, passing, and the pending decisions and post-action windows of the
+// round just played. It is the only place round-scoped state is reset; the
+// cleanup phase invokes it, and so does entering a round without one (the
+// first round after setup).
+func RoundReset(gs *GameState) {
 	// Reset power actions
 	if gs.PowerActions != nil {
 		gs.PowerActions.ResetForNewRound()
 	}
 
-	// Reset player round-specific flags
+	// Players selected bonus cards in the previous round (or setup), now they can select again
+	if gs.BonusCards != nil {
+		gs.BonusCards.PlayerHasCard = make(map[string]bool)
+	}
+
+	// Reset player round-specific flags, including every once-per-round special
+	// action (stronghold, bonus card and favor tile actions share this tracker)
 	for _, player := range gs.Players {
-		player.HasPassed = false
+		player.HasPassed = player.Resigned
+		player.SpecialActionsUsed = make(map[SpecialActionType]bool)
 	}
 
 	// PassOrder is NOT cleared here - it is needed by StartNewRound to set TurnOrder
-	// gs.PassOrder = []string{}
 
 	// Reset pending offers/formations
 	gs.PendingLeechOffers = make(map[string][]*PowerLeechOffer)
@@ -77,6 +87,7 @@ func (gs *GameState) ResetRoundState() {
 	}
 	gs.PendingTownFormations = retainedTownFormations
 	gs.PendingFreeActionsPlayerID = ""
+	gs.MustPassPlayerID = ""
 	gs.PendingPostActionSpecialActions = nil
 	gs.PendingDjinniStartingCultChoice = nil
 	gs.PendingArchivistsBonusSelection = nil
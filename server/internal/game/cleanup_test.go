@@ -112,7 +112,7 @@ func TestExecuteCleanupPhase_Round5(t *testing.T) {
 	}
 }
 
-func TestResetRoundState(t *testing.T) {
+func TestRoundReset(t *testing.T) {
 	gs := NewGameState()
 	faction := factions.NewAuren()
 	gs.AddPlayer("player1", faction)
@@ -138,14 +138,14 @@ func TestResetRoundState(t *testing.T) {
 	gs.PendingTurnConfirmationSnapshot = NewGameState()
 
 	// Reset round state
-	gs.ResetRoundState()
+	RoundReset(gs)
 
 	// Check that state was reset
 	if player.HasPassed {
 		t.Error("HasPassed should be reset to false")
 	}
 	if len(gs.PassOrder) != 0 {
-		// PassOrder is NOT cleared in ResetRoundState as it's needed for next round turn order
+		// PassOrder is NOT cleared in RoundReset as it's needed for next round turn order
 		// t.Error("PassOrder should be cleared")
 	}
 	if len(gs.PendingLeechOffers) != 0 {
@@ -637,7 +637,7 @@ func TestBonusCards_AddCoinsToLeftover(t *testing.T) {
 	gs.BonusCards.PlayerHasCard["player1"] = true
 
 	// Add coins to leftover cards (not taken by players)
-	gs.BonusCards.AddCoinsToLeftoverCards()
+	gs.BonusCards.AddCoins(2)
 
 	// Priest card was taken, so it shouldn't get a coin (it's not in Available anymore)
 	// 6 Coins card should now have 1 coin
@@ -659,7 +659,7 @@ func TestPowerActions_ResetForNewRound(t *testing.T) {
 	gs.PowerActions.UsedActions[PowerActionPriest] = true
 
 	// Reset round state (which should reset power actions)
-	gs.ResetRoundState()
+	RoundReset(gs)
 
 	// Power actions should be available again
 	if gs.PowerActions.UsedActions[PowerActionBridge] {
@@ -859,7 +859,7 @@ func TestFullCleanupFlow(t *testing.T) {
 		t.Error("player2 HasPassed should be reset")
 	}
 	if len(gs.PassOrder) != 0 {
-		// PassOrder is NOT cleared in ResetRoundState
+		// PassOrder is NOT cleared in RoundReset
 		// t.Error("PassOrder should be cleared")
 	}
 
@@ -868,3 +868,157 @@ func TestFullCleanupFlow(t *testing.T) {
 		t.Error("power actions should be reset")
 	}
 }
+
+func TestCultRewardSpadePhase_PlayersActInTurnOrderBeforeIncome(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("player1", factions.NewAuren())
+	gs.AddPlayer("player2", factions.NewAlchemists())
+	for playerID, hex := range map[string]board.Hex{"player1": board.NewHex(0, 0), "player2": board.NewHex(6, 0)} {
+		player := gs.GetPlayer(playerID)
+		gs.Map.GetHex(hex).Terrain = player.Faction.GetHomeTerrain()
+		gs.Map.PlaceBuilding(hex, &models.Building{
+			Type:       models.BuildingDwelling,
+			Faction:    player.Faction.GetType(),
+			PlayerID:   playerID,
+			PowerValue: 1,
+		})
+	}
+	gs.Phase = PhaseIncome
+	gs.TurnOrder = []string{"player2", "player1"}
+	gs.PendingCultRewardSpades = map[string]int{"player1": 1, "player2": 2}
+
+	gs.startCultRewardSpadePhase()
+	if playerID, count := gs.GetPendingCultRewardSpadePlayer(); playerID != "player2" || count != 2 {
+		t.Fatalf("expected player2 to act first with 2 spades, got %s with %d", playerID, count)
+	}
+	decision, _ := serializePendingDecision(gs).(map[string]interface{})
+	if decision == nil || decision["type"] != "cult_reward_spade" || decision["playerId"] != "player2" {
+		t.Fatalf("expected cult_reward_spade decision for player2, got %v", decision)
+	}
+
+	target := gs.PendingSpadeTargets("player2")[0]
+	if err := NewUseCultSpadeAction("player2", target.Hex).Execute(gs); err != nil {
+		t.Fatalf("player2 first spade: %v", err)
+	}
+	if playerID, _ := gs.GetPendingCultRewardSpadePlayer(); playerID != "player2" {
+		t.Fatalf("expected player2 to keep the turn for the second spade, got %q", playerID)
+	}
+	if err := NewDiscardPendingSpadeAction("player2", 1).Execute(gs); err != nil {
+		t.Fatalf("player2 skip: %v", err)
+	}
+	if playerID, count := gs.GetPendingCultRewardSpadePlayer(); playerID != "player1" || count != 1 {
+		t.Fatalf("expected player1 to act next with 1 spade, got %s with %d", playerID, count)
+	}
+	if gs.Phase != PhaseIncome {
+		t.Fatalf("income must wait for the sub-phase, phase is %d", gs.Phase)
+	}
+
+	target = gs.PendingSpadeTargets("player1")[0]
+	if err := NewUseCultSpadeAction("player1", target.Hex).Execute(gs); err != nil {
+		t.Fatalf("player1 spade: %v", err)
+	}
+	if gs.InCultRewardSpadePhase() {
+		t.Fatal("expected the cult reward spade sub-phase to be over")
+	}
+	if gs.Phase != PhaseAction {
+		t.Fatalf("expected income to be granted and the action phase to start, got phase %d", gs.Phase)
+	}
+}
+
+func TestCultRewardSpadePhase_SkipsPlayersWithoutReachableHex(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("player1", factions.NewAuren())
+	gs.AddPlayer("player2", factions.NewAlchemists())
+	// Every land hex is Forest, so Auren have nothing left to transform.
+	for _, mapHex := range gs.Map.Hexes {
+		if mapHex.Terrain != models.TerrainRiver {
+			mapHex.Terrain = models.TerrainForest
+		}
+	}
+	for playerID, hex := range map[string]board.Hex{"player1": board.NewHex(0, 0), "player2": board.NewHex(6, 0)} {
+		player := gs.GetPlayer(playerID)
+		gs.Map.PlaceBuilding(hex, &models.Building{
+			Type:       models.BuildingDwelling,
+			Faction:    player.Faction.GetType(),
+			PlayerID:   playerID,
+			PowerValue: 1,
+		})
+	}
+	gs.Phase = PhaseIncome
+	gs.TurnOrder = []string{"player1", "player2"}
+	gs.PendingCultRewardSpades = map[string]int{"player1": 1, "player2": 1}
+
+	gs.startCultRewardSpadePhase()
+
+	if playerID, count := gs.GetPendingCultRewardSpadePlayer(); playerID != "player2" || count != 1 {
+		t.Fatalf("expected player1 to be skipped and player2 to act, got %s with %d", playerID, count)
+	}
+	if _, ok := gs.PendingCultRewardSpades["player1"]; ok {
+		t.Fatalf("expected player1's unusable spade to be forfeited, got %v", gs.PendingCultRewardSpades)
+	}
+	if gs.Phase != PhaseIncome {
+		t.Fatalf("income must wait for player2, phase is %d", gs.Phase)
+	}
+}
+
+func TestRoundReset_OncePerRoundResourcesAvailableNextRound(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("giants", factions.NewGiants())
+	gs.AddPlayer("nomads", factions.NewNomads())
+	gs.TurnOrder = []string{"giants", "nomads"}
+	gs.Round = 1
+	gs.Phase = PhaseAction
+	giants := gs.GetPlayer("giants")
+	nomads := gs.GetPlayer("nomads")
+
+	buildStrongholdForPlayer(gs, "giants", board.NewHex(0, 1))
+	if err := NewGiantsTransformAction("giants", board.NewHex(0, 0), false).Execute(gs); err != nil {
+		t.Fatalf("round 1 Giants transform failed: %v", err)
+	}
+	nomads.SpecialActionsUsed[SpecialActionNomadsSandstorm] = true
+	nomads.SpecialActionsUsed[SpecialActionChaosMagiciansDoubleTurn] = true
+	nomads.SpecialActionsUsed[SpecialActionBonusCardSpade] = true
+	nomads.SpecialActionsUsed[SpecialActionBonusCardCultAdvance] = true
+	nomads.SpecialActionsUsed[SpecialActionWater2CultAdvance] = true
+	for _, powerAction := range []PowerActionType{
+		PowerActionBridge, PowerActionPriest, PowerActionWorkers, PowerActionCoins, PowerActionSpade1, PowerActionSpade2,
+	} {
+		gs.PowerActions.MarkUsed(powerAction)
+	}
+	gs.BonusCards.PlayerHasCard["giants"] = true
+	gs.BonusCards.PlayerHasCard["nomads"] = true
+	giants.HasPassed = true
+	nomads.HasPassed = true
+	gs.PassOrder = []string{"nomads", "giants"}
+	gs.PendingPostActionSpecialActions = map[string]map[SpecialActionType]bool{
+		"giants": {SpecialActionGiantsTransform: true},
+	}
+
+	advanceAfterRoundComplete(gs)
+
+	if gs.Round != 2 {
+		t.Fatalf("expected round 2, got %d", gs.Round)
+	}
+	for _, player := range []*Player{giants, nomads} {
+		if player.HasPassed {
+			t.Errorf("%s should not have passed in round 2", player.ID)
+		}
+		if len(player.SpecialActionsUsed) != 0 {
+			t.Errorf("%s special actions still used in round 2: %v", player.ID, player.SpecialActionsUsed)
+		}
+		if gs.BonusCards.PlayerHasCard[player.ID] {
+			t.Errorf("%s should be able to select a bonus card again in round 2", player.ID)
+		}
+	}
+	for powerAction := PowerActionBridge; powerAction <= PowerActionSpade2; powerAction++ {
+		if !gs.PowerActions.IsAvailable(powerAction) {
+			t.Errorf("power action %v should be available in round 2", powerAction)
+		}
+	}
+	if gs.PendingPostActionSpecialActions != nil {
+		t.Errorf("post-action special actions should not carry into round 2: %v", gs.PendingPostActionSpecialActions)
+	}
+	if err := NewGiantsTransformAction("giants", board.NewHex(1, 0), false).Validate(gs); err != nil {
+		t.Errorf("Giants transform should be available again in round 2: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxConsistencyDiffs caps how many differing fields a ConsistencyError lists.
+const maxConsistencyDiffs = 10
+
+// ConsistencyError reports a live game whose state differs from its recorded
+// action log replayed in a shadow game, i.e. the action's wire form does not
+// reproduce what the interactive handlers did.
+type ConsistencyError struct {
+	Action RecordedAction
+	// Index is the position of Action in the game's recorded log.
+	Index int
+	// Diffs lists the differing serialized fields as "path: live != shadow".
+	Diffs []string
+}
+
+func (e *ConsistencyError) Error() string {
+	return fmt.Sprintf("live state diverged from recorded log at action %d (%s by %s): %s",
+		e.Index, e.Action.Type, e.Action.PlayerID, strings.Join(e.Diffs, "; "))
+}
+
+// SetConsistencyCheck enables a debug mode that replays every recorded action
+// in a shadow game, rebuilding it with buildAction the way save-file imports
+// do, and compares the shadow with the live state. An action whose replay
+// diverges fails with a *ConsistencyError and the game is restored to its
+// state before the action. A nil buildAction turns the check off.
+func (m *Manager) SetConsistencyCheck(buildAction RecordedActionBuilder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.consistencyBuild = buildAction
+	m.shadows = make(map[string]*Manager)
+}
+
+// checkConsistencyLocked applies record to the shadow of gameID and compares
+// the result with gs, the live state after the action. Games without a
+// replayable history are skipped.
+func (m *Manager) checkConsistencyLocked(gameID string, gs *GameState, record RecordedAction, now time.Time) error {
+	if m.consistencyBuild == nil || m.unrecorded[gameID] {
+		return nil
+	}
+	index := len(m.history[gameID])
+	shadow, err := m.shadowLocked(gameID)
+	if err != nil {
+		return fmt.Errorf("failed to build shadow of game %s: %w", gameID, err)
+	}
+	if shadow == nil {
+		return nil
+	}
+
+	fail := func(diffs ...string) error {
+		delete(m.shadows, gameID)
+		return &ConsistencyError{Action: record, Index: index, Diffs: diffs}
+	}
+	action, err := m.consistencyBuild(record)
+	if err != nil {
+		return fail(fmt.Sprintf("rebuilding the action failed: %v", err))
+	}
+	shadow.now = func() time.Time { return now }
+	if _, err := shadow.ExecuteActionWithMeta(shadowGameID, action, ActionMeta{
+		ExpectedRevision: -1,
+		SeatID:           record.PlayerID,
+		Record:           &record,
+	}); err != nil {
+		return fail(fmt.Sprintf("replaying the action failed: %v", err))
+	}
+	if diffs := diffConsistencyStates(gs, shadow.games[shadowGameID], gameID, now); len(diffs) > 0 {
+		return fail(diffs...)
+	}
+	return nil
+}
+
+const shadowGameID = "shadow"
+
+// shadowLocked returns the shadow of gameID, replaying the recorded log up to
+// now when there is none yet. It returns nil for games without recorded
+// settings.
+func (m *Manager) shadowLocked(gameID string) (*Manager, error) {
+	if shadow := m.shadows[gameID]; shadow != nil {
+		return shadow, nil
+	}
+	setup, ok := m.setups[gameID]
+	if !ok {
+		return nil, nil
+	}
+	shadow, err := replayRecordedLog(shadowGameID, setup.playerIDs, setup.settings, m.history[gameID], m.consistencyBuild, nil)
+	if err != nil {
+		return nil, err
+	}
+	m.shadows[gameID] = shadow
+	return shadow, nil
+}
+
+// diffConsistencyStates compares the serialized live and shadow states.
+// Clocks and the must-pass hint are left out: they depend on wall time and on
+// the live manager's configuration rather than on the recorded actions.
+func diffConsistencyStates(live, shadow *GameState, gameID string, now time.Time) []string {
+	normalize := func(gs *GameState) interface{} {
+		state := serializeStateWithRevisionAt(gs, gameID, 0, now, gs.PendingSpadeTargets)
+		delete(state, "turnTimer")
+		delete(state, "mustPassPlayerId")
+		raw, err := json.Marshal(state)
+		if err != nil {
+			return err.Error()
+		}
+		var detached interface{}
+		if err := json.Unmarshal(raw, &detached); err != nil {
+			return err.Error()
+		}
+		return detached
+	}
+	var diffs []string
+	diffConsistencyValues("", normalize(live), normalize(shadow), &diffs)
+	return diffs
+}
+
+func diffConsistencyValues(path string, live, shadow interface{}, diffs *[]string) {
+	if len(*diffs) >= maxConsistencyDiffs || reflect.DeepEqual(live, shadow) {
+		return
+	}
+	liveMap, liveIsMap := live.(map[string]interface{})
+	shadowMap, shadowIsMap := shadow.(map[string]interface{})
+	if liveIsMap && shadowIsMap {
+		keys := make(map[string]bool, len(liveMap)+len(shadowMap))
+		for key := range liveMap {
+			keys[key] = true
+		}
+		for key := range shadowMap {
+			keys[key] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+		for _, key := range sorted {
+			diffConsistencyValues(path+"."+key, liveMap[key], shadowMap[key], diffs)
+		}
+		return
+	}
+	*diffs = append(*diffs, fmt.Sprintf("%s: %v != %v", strings.TrimPrefix(path, "."), live, shadow))
+}
@@ -0,0 +1,57 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/models"
+)
+
+func TestConsistencyCheck_RejectsActionsTheRecordedLogDoesNotReproduce(t *testing.T) {
+	mgr := NewManager()
+	if err := mgr.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	mgr.SetConsistencyCheck(func(recorded RecordedAction) (Action, error) {
+		var params struct {
+			Faction string `json:"faction"`
+		}
+		if err := json.Unmarshal(recorded.Params, &params); err != nil {
+			return nil, err
+		}
+		return &SelectFactionAction{PlayerID: recorded.PlayerID, FactionType: models.FactionTypeFromString(params.Faction)}, nil
+	})
+	selectFaction := func(playerID string, live models.FactionType, recorded string) error {
+		_, err := mgr.ExecuteActionWithMeta("g1", &SelectFactionAction{PlayerID: playerID, FactionType: live}, ActionMeta{
+			ExpectedRevision: -1,
+			SeatID:           playerID,
+			Record:           &RecordedAction{PlayerID: playerID, Type: "select_faction", Params: []byte(`{"faction":"` + recorded + `"}`)},
+		})
+		return err
+	}
+
+	if err := selectFaction("p1", models.FactionWitches, "Witches"); err != nil {
+		t.Fatalf("consistent action rejected: %v", err)
+	}
+
+	err := selectFaction("p2", models.FactionNomads, "Halflings")
+	var consistencyErr *ConsistencyError
+	if !errors.As(err, &consistencyErr) {
+		t.Fatalf("expected a ConsistencyError, got %v", err)
+	}
+	if consistencyErr.Index != 1 || len(consistencyErr.Diffs) == 0 {
+		t.Fatalf("expected diffs for action 1, got %+v", consistencyErr)
+	}
+	gs, _ := mgr.GetGame("g1")
+	if gs.GetPlayer("p2").Faction != nil {
+		t.Fatalf("expected the diverging action to be rolled back")
+	}
+	if save, err := mgr.ExportGame("g1"); err != nil || len(save.Actions) != 1 {
+		t.Fatalf("expected only the first action in the log, got %v, %v", save, err)
+	}
+
+	if err := selectFaction("p2", models.FactionNomads, "Nomads"); err != nil {
+		t.Fatalf("expected the check to recover after a divergence: %v", err)
+	}
+}
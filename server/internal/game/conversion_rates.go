@@ -0,0 +1,191 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/lukev/tm_server/internal/models"
+)
+
+// ConversionBurnPower burns power: sacrificing Bowl II tokens moves others to
+// Bowl III. BurnPowerAction takes it too.
+const ConversionBurnPower ConversionType = "burn_power"
+
+// ConversionResources are the resources one trade of a conversion rate
+// spends or gains.
+type ConversionResources struct {
+	// Power is spent from Bowl III; gained Power moves from Bowl II to
+	// Bowl III, as burning does.
+	Power   int
+	Coins   int
+	Workers int
+	Priests int
+	VP      int
+	// PowerTokens are new tokens placed in Bowl I.
+	PowerTokens int
+	// SacrificedPower is removed from Bowl II for good.
+	SacrificedPower int
+}
+
+// ConversionRate is one row of the conversion table: a trade of Cost for
+// Gain. A conversion of Amount makes Amount/Step trades, Step defaulting to 1.
+type ConversionRate struct {
+	Type ConversionType
+	// Faction restricts the row to one faction; FactionUnknown is everyone.
+	Faction models.FactionType
+	// Stronghold restricts the row to players holding the stronghold ability.
+	Stronghold bool
+	// Ordination rows are only taken through Darklings priest ordination,
+	// which building the stronghold grants, never as a free action.
+	Ordination bool
+	Step       int
+	Cost       ConversionResources
+	Gain       ConversionResources
+}
+
+// conversionRates is the official conversion table. When several rows match
+// a player, the most specific one applies: faction and stronghold over
+// faction over everyone.
+var conversionRates = []ConversionRate{
+	{Type: ConversionBurnPower, Cost: ConversionResources{SacrificedPower: 1}, Gain: ConversionResources{Power: 1}},
+	{Type: ConversionBurnPower, Faction: models.FactionChildrenOfTheWyrm, Cost: ConversionResources{SacrificedPower: 1}, Gain: ConversionResources{Power: 2}},
+
+	{Type: ConversionPowerToCoin, Cost: ConversionResources{Power: 1}, Gain: ConversionResources{Coins: 1}},
+	{Type: ConversionPowerToWorker, Cost: ConversionResources{Power: 3}, Gain: ConversionResources{Workers: 1}},
+	{Type: ConversionPowerToPriest, Cost: ConversionResources{Power: 5}, Gain: ConversionResources{Priests: 1}},
+	{Type: ConversionPowerToCoin, Faction: models.FactionTheEnlightened, Stronghold: true, Cost: ConversionResources{Power: 1}, Gain: ConversionResources{Coins: 2}},
+	{Type: ConversionPowerToWorker, Faction: models.FactionTheEnlightened, Stronghold: true, Cost: ConversionResources{Power: 3}, Gain: ConversionResources{Workers: 2}},
+	{Type: ConversionPowerToPriest, Faction: models.FactionTheEnlightened, Stronghold: true, Cost: ConversionResources{Power: 5}, Gain: ConversionResources{Priests: 2}},
+
+	{Type: ConversionPriestToWorker, Cost: ConversionResources{Priests: 1}, Gain: ConversionResources{Workers: 1}},
+	{Type: ConversionPriestToWorker, Faction: models.FactionDynionGeifr, Cost: ConversionResources{Priests: 1}, Gain: ConversionResources{Workers: 2, Coins: 2}},
+	{Type: ConversionWorkerToCoin, Cost: ConversionResources{Workers: 1}, Gain: ConversionResources{Coins: 1}},
+
+	{Type: ConversionCoinToPower, Faction: models.FactionTheEnlightened, Cost: ConversionResources{Coins: 1}, Gain: ConversionResources{PowerTokens: 1}},
+	{Type: ConversionAlchVPToCoin, Faction: models.FactionAlchemists, Cost: ConversionResources{VP: 1}, Gain: ConversionResources{Coins: 1}},
+	{Type: ConversionAlchCoinToVP, Faction: models.FactionAlchemists, Step: 2, Cost: ConversionResources{Coins: 2}, Gain: ConversionResources{VP: 1}},
+	{Type: ConversionWorkerToPriest, Faction: models.FactionDarklings, Ordination: true, Cost: ConversionResources{Workers: 1}, Gain: ConversionResources{Priests: 1}},
+}
+
+// ConversionRateFor returns the conversion table row that applies to
+// playerID for conversionType.
+func (gs *GameState) ConversionRateFor(playerID string, conversionType ConversionType) (ConversionRate, bool) {
+	player := gs.GetPlayer(playerID)
+	if player == nil || player.Faction == nil {
+		return ConversionRate{}, false
+	}
+	best, bestScore := ConversionRate{}, -1
+	for _, rate := range conversionRates {
+		if rate.Type != conversionType {
+			continue
+		}
+		if rate.Faction != models.FactionUnknown && rate.Faction != player.Faction.GetType() {
+			continue
+		}
+		if rate.Stronghold && !player.HasStrongholdAbility {
+			continue
+		}
+		score := 0
+		if rate.Faction != models.FactionUnknown {
+			score += 2
+		}
+		if rate.Stronghold {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = rate, score
+		}
+	}
+	return best, bestScore >= 0
+}
+
+// trades returns how many trades of the rate a conversion of amount makes.
+func (r ConversionRate) trades(amount int) (int, error) {
+	step := max(r.Step, 1)
+	if amount <= 0 {
+		return 0, fmt.Errorf("conversion amount must be positive")
+	}
+	if amount%step != 0 {
+		return 0, fmt.Errorf("%s converts in lots of %d, got %d", r.Type, step, amount)
+	}
+	return amount / step, nil
+}
+
+// validateConversion checks a conversion of amount against the table and the
+// player's resources, returning its rate and trade count.
+func (gs *GameState) validateConversion(playerID string, conversionType ConversionType, amount int) (ConversionRate, int, error) {
+	player := gs.GetPlayer(playerID)
+	if player == nil {
+		return ConversionRate{}, 0, ruleErrorf(ReasonPlayerNotFound, "player not found: %s", playerID)
+	}
+	rate, ok := gs.ConversionRateFor(playerID, conversionType)
+	if !ok {
+		return ConversionRate{}, 0, fmt.Errorf("%s conversion is not available to %s", conversionType, player.Faction.GetType())
+	}
+	trades, err := rate.trades(amount)
+	if err != nil {
+		return rate, 0, err
+	}
+
+	cost := rate.Cost.times(trades)
+	power := player.Resources.Power
+	if burn := cost.SacrificedPower + rate.Gain.Power*trades; power.Bowl2 < burn {
+		return rate, 0, ruleErrorf(ReasonInsufficientPower, "cannot burn %d power, need %d in Bowl 2 but only have %d", amount, burn, power.Bowl2)
+	}
+	if !power.CanSpend(cost.Power) {
+		return rate, 0, ruleErrorf(ReasonInsufficientPower, "need %d power in bowl 3, only have %d", cost.Power, power.Bowl3)
+	}
+	if player.Resources.Coins < cost.Coins {
+		return rate, 0, ruleErrorf(ReasonInsufficientCoins, "not enough coins (have %d, need %d)", player.Resources.Coins, cost.Coins)
+	}
+	if player.Resources.Workers < cost.Workers {
+		return rate, 0, ruleErrorf(ReasonInsufficientWorkers, "not enough workers (have %d, need %d)", player.Resources.Workers, cost.Workers)
+	}
+	if player.Resources.Priests < cost.Priests {
+		return rate, 0, ruleErrorf(ReasonInsufficientPriests, "not enough priests (have %d, need %d)", player.Resources.Priests, cost.Priests)
+	}
+	if player.VictoryPoints < cost.VP {
+		return rate, 0, ruleErrorf(ReasonInsufficientVP, "not enough VP (have %d, need %d)", player.VictoryPoints, cost.VP)
+	}
+	return rate, trades, nil
+}
+
+// ConvertResources makes a conversion of amount for playerID at the rate the
+// conversion table gives them. It checks only the table and resources; turn
+// timing and priest limits are up to the calling action.
+func (gs *GameState) ConvertResources(playerID string, conversionType ConversionType, amount int) error {
+	rate, trades, err := gs.validateConversion(playerID, conversionType, amount)
+	if err != nil {
+		return err
+	}
+	gs.applyConversion(playerID, rate, trades)
+	return nil
+}
+
+func (gs *GameState) applyConversion(playerID string, rate ConversionRate, trades int) {
+	player := gs.GetPlayer(playerID)
+	cost, gain := rate.Cost.times(trades), rate.Gain.times(trades)
+	power := player.Resources.Power
+
+	power.Bowl2 -= cost.SacrificedPower + gain.Power
+	power.Bowl3 += gain.Power - cost.Power
+	power.Bowl1 += cost.Power + gain.PowerTokens
+	player.Resources.Coins += gain.Coins - cost.Coins
+	player.Resources.Workers += gain.Workers - cost.Workers
+	player.Resources.Priests -= cost.Priests
+	player.VictoryPoints += gain.VP - cost.VP
+	if gain.Priests > 0 {
+		gs.GainPriests(playerID, gain.Priests)
+	}
+}
+
+func (r ConversionResources) times(n int) ConversionResources {
+	return ConversionResources{
+		Power:           r.Power * n,
+		Coins:           r.Coins * n,
+		Workers:         r.Workers * n,
+		Priests:         r.Priests * n,
+		VP:              r.VP * n,
+		PowerTokens:     r.PowerTokens * n,
+		SacrificedPower: r.SacrificedPower * n,
+	}
+}
@@ -0,0 +1,104 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/factions"
+)
+
+func TestConvertResources_UsesMostSpecificRate(t *testing.T) {
+	tests := []struct {
+		name       string
+		faction    factions.Faction
+		stronghold bool
+		conversion ConversionType
+		amount     int
+		want       func(p *Player) bool
+	}{
+		{
+			name:       "power to workers",
+			faction:    factions.NewNomads(),
+			conversion: ConversionPowerToWorker,
+			amount:     2,
+			want: func(p *Player) bool {
+				return p.Resources.Workers == 2 && p.Resources.Power.Bowl3 == 4
+			},
+		},
+		{
+			name:       "Enlightened stronghold doubles power conversions",
+			faction:    factions.NewTheEnlightened(),
+			stronghold: true,
+			conversion: ConversionPowerToWorker,
+			amount:     2,
+			want: func(p *Player) bool {
+				return p.Resources.Workers == 4 && p.Resources.Power.Bowl3 == 4
+			},
+		},
+		{
+			name:       "Dynion Geifr priests give workers and coins",
+			faction:    factions.NewDynionGeifr(),
+			conversion: ConversionPriestToWorker,
+			amount:     1,
+			want: func(p *Player) bool {
+				return p.Resources.Workers == 2 && p.Resources.Coins == 6 && p.Resources.Priests == 0
+			},
+		},
+		{
+			name:       "Children of the Wyrm burn moves two per sacrifice",
+			faction:    factions.NewChildrenOfTheWyrm(),
+			conversion: ConversionBurnPower,
+			amount:     2,
+			want: func(p *Player) bool {
+				return p.Resources.Power.Bowl2 == 0 && p.Resources.Power.Bowl3 == 14
+			},
+		},
+		{
+			name:       "Alchemists trade coins for VP in pairs",
+			faction:    factions.NewAlchemists(),
+			conversion: ConversionAlchCoinToVP,
+			amount:     4,
+			want: func(p *Player) bool {
+				return p.VictoryPoints == 2 && p.Resources.Coins == 0
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := NewGameState()
+			mustAddPlayer(t, gs, "p1", tt.faction)
+			player := gs.GetPlayer("p1")
+			player.HasStrongholdAbility = tt.stronghold
+			player.Resources.Coins, player.Resources.Workers, player.Resources.Priests = 4, 0, 1
+			player.Resources.Power = NewPowerSystem(0, 6, 10)
+			player.VictoryPoints = 0
+
+			if err := gs.ConvertResources("p1", tt.conversion, tt.amount); err != nil {
+				t.Fatalf("convert: %v", err)
+			}
+			if !tt.want(player) {
+				t.Fatalf("unexpected resources %+v, power %+v, vp %d", player.Resources, player.Resources.Power, player.VictoryPoints)
+			}
+		})
+	}
+}
+
+func TestConvertResources_RejectsMissingRatesAndShortfalls(t *testing.T) {
+	gs := NewGameState()
+	mustAddPlayer(t, gs, "p1", factions.NewNomads())
+	player := gs.GetPlayer("p1")
+	player.Resources.Power = NewPowerSystem(5, 0, 2)
+	player.Resources.Workers = 0
+
+	if err := gs.ConvertResources("p1", ConversionAlchVPToCoin, 1); err == nil {
+		t.Fatalf("expected Alchemists-only conversion to be rejected")
+	}
+	if err := gs.ConvertResources("p1", ConversionPowerToWorker, 1); err == nil {
+		t.Fatalf("expected conversion without enough power to be rejected")
+	}
+	if err := gs.ConvertResources("p1", ConversionBurnPower, 1); err == nil {
+		t.Fatalf("expected burn without Bowl II power to be rejected")
+	}
+	if player.Resources.Power.Bowl3 != 2 || player.Resources.Workers != 0 {
+		t.Fatalf("expected rejected conversions to change nothing, got %+v", player.Resources)
+	}
+}
@@ -182,7 +182,7 @@ func (cts *CultTrackState) GetTotalPriestsOnCultTracks(playerID string) int {
 // AdvancePlayer advances a player on a cult track.
 // Returns the number of spaces actually advanced (may be less if at max or blocked).
 // Reaching position 10 consumes one key.
-// gs is accepted for API compatibility with callers.
+// Milestone power is gained through gs when it is set.
 func (cts *CultTrackState) AdvancePlayer(playerID string, track CultTrack, spaces int, player *Player, gs *GameState) (int, error) {
 	if spaces < 0 {
 		return 0, fmt.Errorf("cannot advance negative spaces")
@@ -222,7 +222,7 @@ func (cts *CultTrackState) AdvancePlayer(playerID string, track CultTrack, space
 
 	// Grant bonus power ONLY for passing milestone positions (3, 5, 7, 10)
 	if player != nil && actualAdvancement > 0 {
-		cts.grantMilestoneBonuses(playerID, track, currentPos, targetPos, player)
+		cts.grantMilestoneBonuses(playerID, track, currentPos, targetPos, player, gs)
 	}
 
 	return actualAdvancement, nil
@@ -275,7 +275,7 @@ func countBorrowablePendingTownKeys(gs *GameState, playerID string) int {
 	return borrowable
 }
 
-func (cts *CultTrackState) grantMilestoneBonuses(playerID string, track CultTrack, currentPos, targetPos int, player *Player) {
+func (cts *CultTrackState) grantMilestoneBonuses(playerID string, track CultTrack, currentPos, targetPos int, player *Player, gs *GameState) {
 	bonusPositions := map[int]int{
 		3:  1, // 1 bonus power
 		5:  2, // 2 bonus power
@@ -288,7 +288,11 @@ func (cts *CultTrackState) grantMilestoneBonuses(playerID string, track CultTrac
 		if currentPos < pos && targetPos >= pos {
 			// Check if we haven't already claimed this bonus
 			if !cts.BonusPositionsClaimed[playerID][track][pos] {
-				player.Resources.Power.GainPower(bonusPower)
+				if gs != nil {
+					gs.GainPowerForReason(playerID, bonusPower, PowerGainCultTrack, "")
+				} else {
+					player.Resources.Power.GainPower(bonusPower)
+				}
 				cts.BonusPositionsClaimed[playerID][track][pos] = true
 			}
 		}
@@ -0,0 +1,64 @@
+package game
+
+// Spades earned from a round's cult rewards are used at the start of the next
+// round, before income, in a sub-phase of their own: players act one at a
+// time in turn order, each transforming reachable hexes without building.
+// CultRewardSpadeOrder lists the players owed spades and CultRewardSpadeIndex
+// points at the one to act; PendingCultRewardSpades holds the counts.
+
+// startCultRewardSpadePhase queues every player owed cult reward spades and
+// hands the turn to the first who can use them. Income follows once the
+// sub-phase is over, immediately if nobody has a spade to use.
+func (gs *GameState) startCultRewardSpadePhase() {
+	gs.CultRewardSpadeOrder = nil
+	gs.CultRewardSpadeIndex = 0
+	for _, playerID := range gs.TurnOrder {
+		if gs.PendingCultRewardSpades[playerID] > 0 {
+			gs.CultRewardSpadeOrder = append(gs.CultRewardSpadeOrder, playerID)
+		}
+	}
+	gs.advanceCultRewardSpadePhase()
+}
+
+// InCultRewardSpadePhase reports whether a player still has to use cult
+// reward spades before income.
+func (gs *GameState) InCultRewardSpadePhase() bool {
+	return gs.CultRewardSpadeIndex < len(gs.CultRewardSpadeOrder)
+}
+
+// advanceCultRewardSpadePhase moves the turn pointer past players who have no
+// spades left, skipping players whose spades have no legal hex, and grants
+// income once nobody is left to act.
+func (gs *GameState) advanceCultRewardSpadePhase() {
+	if gs.settleCultRewardSpadeTurn() {
+		return
+	}
+	if gs.Phases().Current() != PhaseIncome {
+		return
+	}
+	if _, count := gs.GetPendingCultRewardSpadePlayer(); count > 0 {
+		return
+	}
+	gs.GrantIncome()
+	if !gs.HasPendingIncomeDecisions() {
+		gs.StartActionPhase()
+	}
+}
+
+// settleCultRewardSpadeTurn returns whether the player at the turn pointer can
+// still use a spade. Spades without any reachable hex are forfeited.
+func (gs *GameState) settleCultRewardSpadeTurn() bool {
+	for gs.InCultRewardSpadePhase() {
+		playerID := gs.CultRewardSpadeOrder[gs.CultRewardSpadeIndex]
+		if gs.PendingCultRewardSpades[playerID] > 0 {
+			if len(gs.PendingSpadeTargets(playerID)) > 0 {
+				return true
+			}
+			delete(gs.PendingCultRewardSpades, playerID)
+		}
+		gs.CultRewardSpadeIndex++
+	}
+	gs.CultRewardSpadeOrder = nil
+	gs.CultRewardSpadeIndex = 0
+	return false
+}
@@ -0,0 +1,138 @@
+package game
+
+import "sort"
+
+// GameEventType names a moment of a game worth telling people outside it
+// about, such as a turn notification.
+type GameEventType string
+
+const (
+	// GameEventTurnChanged reports that PlayerID is now due to take a main
+	// action.
+	GameEventTurnChanged GameEventType = "turn_changed"
+	// GameEventPassed reports that PlayerID passed for the round.
+	GameEventPassed GameEventType = "passed"
+	// GameEventTownFounded reports that PlayerID founded a town.
+	GameEventTownFounded GameEventType = "town_founded"
+	// GameEventGameEnded reports the end of the game with its final scores.
+	GameEventGameEnded GameEventType = "game_ended"
+)
+
+// GameEventTypes lists every game event type.
+var GameEventTypes = []GameEventType{
+	GameEventTurnChanged,
+	GameEventPassed,
+	GameEventTownFounded,
+	GameEventGameEnded,
+}
+
+// GameEvent is a compact record of a game event, as delivered to a
+// GameEventListener.
+type GameEvent struct {
+	Type     GameEventType `json:"type"`
+	GameID   string        `json:"gameId"`
+	Revision int           `json:"revision"`
+	Round    int           `json:"round"`
+	PlayerID string        `json:"playerId,omitempty"`
+	// Faction is the name of PlayerID's faction, such as "Witches".
+	Faction string `json:"faction,omitempty"`
+	// Scores are the players' final victory points, on game_ended.
+	Scores map[string]int `json:"scores,omitempty"`
+}
+
+// GameEventListener receives the events of every game as actions are
+// applied. It is called with the manager locked, so it must not block or
+// call back into the Manager.
+type GameEventListener func(GameEvent)
+
+// SetEventListener sets the listener told about game events; nil stops
+// reporting them.
+func (m *Manager) SetEventListener(listener GameEventListener) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.eventListener = listener
+}
+
+// eventSnapshot is the part of a game compared before and after an action to
+// find its events.
+type eventSnapshot struct {
+	passed map[string]bool
+	towns  map[string]int
+	ended  bool
+}
+
+func captureEventSnapshot(gs *GameState) eventSnapshot {
+	snapshot := eventSnapshot{passed: map[string]bool{}, towns: map[string]int{}}
+	if gs == nil {
+		return snapshot
+	}
+	for id, player := range gs.Players {
+		if player == nil {
+			continue
+		}
+		snapshot.passed[id] = player.HasPassed
+		snapshot.towns[id] = player.TownsFormed
+	}
+	snapshot.ended = gs.Phase == PhaseEnd
+	return snapshot
+}
+
+// emitEventsLocked reports the events of the action that took gameID from
+// before to gs. action is the applied action: its player passed even when
+// the pass ended the round and cleared every player's passed flag.
+func (m *Manager) emitEventsLocked(gameID string, gs *GameState, action Action, before eventSnapshot) {
+	if m.eventListener == nil || gs == nil {
+		return
+	}
+	event := func(typ GameEventType, playerID string) GameEvent {
+		out := GameEvent{Type: typ, GameID: gameID, Revision: m.revisions[gameID], Round: gs.Round, PlayerID: playerID}
+		if player := gs.GetPlayer(playerID); player != nil && player.Faction != nil {
+			out.Faction = player.Faction.GetType().String()
+		}
+		return out
+	}
+
+	playerIDs := make([]string, 0, len(gs.Players))
+	for id := range gs.Players {
+		playerIDs = append(playerIDs, id)
+	}
+	sort.Strings(playerIDs)
+
+	for _, id := range playerIDs {
+		player := gs.Players[id]
+		passedByAction := action != nil && action.GetType() == ActionPass && action.GetPlayerID() == id
+		if passedByAction || (player != nil && player.HasPassed && !before.passed[id]) {
+			m.eventListener(event(GameEventPassed, id))
+		}
+	}
+	for _, id := range playerIDs {
+		player := gs.Players[id]
+		if player == nil {
+			continue
+		}
+		for range player.TownsFormed - before.towns[id] {
+			m.eventListener(event(GameEventTownFounded, id))
+		}
+	}
+
+	if gs.Phase == PhaseEnd {
+		delete(m.eventTurn, gameID)
+		if !before.ended {
+			ended := event(GameEventGameEnded, "")
+			ended.Scores = make(map[string]int, len(playerIDs))
+			for _, id := range playerIDs {
+				if player := gs.Players[id]; player != nil {
+					ended.Scores[id] = player.VictoryPoints
+				}
+			}
+			m.eventListener(ended)
+		}
+		return
+	}
+	// Pending decisions leave no one on a main turn; the turn only changes
+	// when someone other than the last player told is due to act.
+	if next := mainTurnPlayerID(gs); next != "" && next != m.eventTurn[gameID] {
+		m.eventTurn[gameID] = next
+		m.eventListener(event(GameEventTurnChanged, next))
+	}
+}
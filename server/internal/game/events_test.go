@@ -0,0 +1,80 @@
+package game
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func recordEvents(mgr *Manager) *[]string {
+	var events []string
+	mgr.SetEventListener(func(event GameEvent) {
+		events = append(events, fmt.Sprintf("%s %s r%d", event.Type, event.PlayerID, event.Revision))
+	})
+	return &events
+}
+
+func TestEvents_TurnChangesAndPasses(t *testing.T) {
+	mgr, gs := newMustPassTestGame(t)
+	gs.GetPlayer("stuck").Options.AutoPassWhenStuck = true
+	gs.GetPlayer("stuck").Options.ConfirmActions = false
+	events := recordEvents(mgr)
+
+	actorSendsPriest(t, mgr)
+
+	want := []string{
+		"turn_changed stuck r1",
+		"passed stuck r2",
+		"turn_changed actor r2",
+	}
+	if !reflect.DeepEqual(*events, want) {
+		t.Fatalf("events = %q, want %q", *events, want)
+	}
+}
+
+func TestEvents_GameEndReportsScoresOnce(t *testing.T) {
+	mgr, gs := newMustPassTestGame(t)
+	gs.Round = 6
+	gs.GetPlayer("stuck").Options.AutoPassWhenStuck = true
+	var ended []GameEvent
+	var types []GameEventType
+	mgr.SetEventListener(func(event GameEvent) {
+		types = append(types, event.Type)
+		if event.Type == GameEventGameEnded {
+			ended = append(ended, event)
+		}
+	})
+
+	card := BonusCardShipping
+	if _, err := mgr.ExecuteActionWithMeta("g1", NewPassAction("actor", &card), ActionMeta{ExpectedRevision: -1}); err != nil {
+		t.Fatalf("actor pass: %v", err)
+	}
+
+	if gs.Phase != PhaseEnd {
+		t.Fatalf("phase = %v, want the game to end after round 6", gs.Phase)
+	}
+	wantTypes := []GameEventType{GameEventPassed, GameEventTurnChanged, GameEventPassed, GameEventGameEnded}
+	if !reflect.DeepEqual(types, wantTypes) {
+		t.Fatalf("event types = %v, want %v", types, wantTypes)
+	}
+	scores := ended[0].Scores
+	if len(scores) != 2 || scores["actor"] != gs.GetPlayer("actor").VictoryPoints {
+		t.Fatalf("final scores = %v", scores)
+	}
+}
+
+func TestEvents_TownFounded(t *testing.T) {
+	mgr, gs := newMustPassTestGame(t)
+	events := recordEvents(mgr)
+	before := captureEventSnapshot(gs)
+	gs.GetPlayer("actor").TownsFormed++
+
+	mgr.mu.Lock()
+	mgr.emitEventsLocked("g1", gs, nil, before)
+	mgr.mu.Unlock()
+
+	want := []string{"town_founded actor r0", "turn_changed actor r0"}
+	if !reflect.DeepEqual(*events, want) {
+		t.Fatalf("events = %q, want %q", *events, want)
+	}
+}
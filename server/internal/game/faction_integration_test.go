@@ -1,6 +1,7 @@
 package game
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/lukev/tm_server/internal/game/board"
@@ -360,7 +361,7 @@ func TestAlchemists_ConvertVPToCoins(t *testing.T) {
 	player.Resources.Coins = 5
 
 	// Convert 3 VP to 3 coins (1:1 ratio)
-	err := gs.AlchemistsConvertVPToCoins("player1", 3)
+	err := gs.ConvertResources("player1", ConversionAlchVPToCoin, 3)
 	if err != nil {
 		t.Fatalf("conversion failed: %v", err)
 	}
@@ -383,7 +384,7 @@ func TestAlchemists_ConvertVPToCoins_NotEnoughVP(t *testing.T) {
 	player.VictoryPoints = 2
 
 	// Try to convert more VP than available
-	err := gs.AlchemistsConvertVPToCoins("player1", 5)
+	err := gs.ConvertResources("player1", ConversionAlchVPToCoin, 5)
 	if err == nil {
 		t.Error("should fail when not enough VP")
 	}
@@ -398,7 +399,7 @@ func TestAlchemists_ConvertVPToCoins_OnlyAlchemists(t *testing.T) {
 	player.VictoryPoints = 10
 
 	// Try to convert as non-Alchemists
-	err := gs.AlchemistsConvertVPToCoins("player1", 3)
+	err := gs.ConvertResources("player1", ConversionAlchVPToCoin, 3)
 	if err == nil {
 		t.Error("should fail for non-Alchemists faction")
 	}
@@ -415,7 +416,7 @@ func TestAlchemists_ConvertCoinsToVP(t *testing.T) {
 	player.VictoryPoints = 5
 
 	// Convert 6 coins to 3 VP (2:1 ratio)
-	err := gs.AlchemistsConvertCoinsToVP("player1", 6)
+	err := gs.ConvertResources("player1", ConversionAlchCoinToVP, 6)
 	if err != nil {
 		t.Fatalf("conversion failed: %v", err)
 	}
@@ -438,7 +439,7 @@ func TestAlchemists_ConvertCoinsToVP_NotEnoughCoins(t *testing.T) {
 	player.Resources.Coins = 3
 
 	// Try to convert more coins than available
-	err := gs.AlchemistsConvertCoinsToVP("player1", 6)
+	err := gs.ConvertResources("player1", ConversionAlchCoinToVP, 6)
 	if err == nil {
 		t.Error("should fail when not enough coins")
 	}
@@ -453,7 +454,7 @@ func TestAlchemists_ConvertCoinsToVP_MustBeEven(t *testing.T) {
 	player.Resources.Coins = 10
 
 	// Try to convert odd number of coins
-	err := gs.AlchemistsConvertCoinsToVP("player1", 5)
+	err := gs.ConvertResources("player1", ConversionAlchCoinToVP, 5)
 	if err == nil {
 		t.Error("should fail when converting odd number of coins")
 	}
@@ -629,7 +630,7 @@ func TestAlchemists_ConversionDuringAction(t *testing.T) {
 	player.VictoryPoints = 20
 
 	// Convert 2 VP to 2 coins
-	err := gs.AlchemistsConvertVPToCoins("player1", 2)
+	err := gs.ConvertResources("player1", ConversionAlchVPToCoin, 2)
 	if err != nil {
 		t.Fatalf("conversion failed: %v", err)
 	}
@@ -1103,6 +1104,86 @@ func TestCultists_CultTrackSelection_AllOpponentsDecline(t *testing.T) {
 	}
 }
 
+func TestCultists_CultTrackSelection_QueuesOneAdvancePerTrigger(t *testing.T) {
+	gs := NewGameState()
+	cultistsFaction := factions.NewCultists()
+	aurenFaction := factions.NewAuren()
+	if err := gs.AddPlayer("cultists", cultistsFaction); err != nil {
+		t.Fatalf("failed to add cultists: %v", err)
+	}
+	if err := gs.AddPlayer("auren", aurenFaction); err != nil {
+		t.Fatalf("failed to add auren: %v", err)
+	}
+	cultistsPlayer := gs.GetPlayer("cultists")
+	aurenPlayer := gs.GetPlayer("auren")
+	cultistsPlayer.Resources.Power.Bowl1 = 10
+	aurenPlayer.Resources.Power.Bowl1 = 10
+	aurenPlayer.VictoryPoints = 10
+	earth, air := cultistsPlayer.CultPositions[CultEarth], cultistsPlayer.CultPositions[CultAir]
+
+	// Three Cultists buildings, each next to its own Auren dwelling, trigger
+	// three leech events in the same turn.
+	for _, pair := range [][2]board.Hex{
+		{board.NewHex(0, 0), board.NewHex(1, 0)},
+		{board.NewHex(4, 0), board.NewHex(5, 0)},
+		{board.NewHex(8, 0), board.NewHex(9, 0)},
+	} {
+		gs.Map.PlaceBuilding(pair[1], &models.Building{
+			Type: models.BuildingDwelling, Faction: aurenFaction.GetType(), PlayerID: "auren", PowerValue: 1,
+		})
+		gs.Map.PlaceBuilding(pair[0], &models.Building{
+			Type: models.BuildingDwelling, Faction: cultistsFaction.GetType(), PlayerID: "cultists", PowerValue: 1,
+		})
+		gs.TriggerPowerLeech(pair[0], "cultists")
+	}
+	if len(gs.PendingCultistsLeech) != 3 {
+		t.Fatalf("expected one pending bonus per trigger, got %d", len(gs.PendingCultistsLeech))
+	}
+	eventIDs := make([]int, 0, 3)
+	for _, offer := range gs.GetPendingLeechOffers("auren") {
+		eventIDs = append(eventIDs, offer.EventID)
+	}
+
+	// Accept the first, decline the second, accept the third.
+	if err := NewAcceptPowerLeechAction("auren", 0).Execute(gs); err != nil {
+		t.Fatalf("accept first: %v", err)
+	}
+	bowl1 := cultistsPlayer.Resources.Power.Bowl1
+	if err := NewDeclinePowerLeechAction("auren", 0).Execute(gs); err != nil {
+		t.Fatalf("decline second: %v", err)
+	}
+	if cultistsPlayer.Resources.Power.Bowl1 != bowl1-1 {
+		t.Fatalf("expected the declined trigger to give 1 power on its own, Bowl1 %d -> %d", bowl1, cultistsPlayer.Resources.Power.Bowl1)
+	}
+	if err := NewAcceptPowerLeechAction("auren", 0).Execute(gs); err != nil {
+		t.Fatalf("accept third: %v", err)
+	}
+
+	pending := gs.PendingCultistsCultSelection
+	if pending == nil || !slices.Equal(pending.EventIDs, []int{eventIDs[0], eventIDs[2]}) {
+		t.Fatalf("expected advances queued for events %v, got %+v", []int{eventIDs[0], eventIDs[2]}, pending)
+	}
+	if len(gs.PendingCultistsLeech) != 0 {
+		t.Fatalf("expected every trigger's bonus resolved, got %d left", len(gs.PendingCultistsLeech))
+	}
+
+	if err := NewSelectCultistsCultTrackAction("cultists", CultEarth).Execute(gs); err != nil {
+		t.Fatalf("first selection: %v", err)
+	}
+	if gs.PendingCultistsCultSelection == nil || !slices.Equal(gs.PendingCultistsCultSelection.EventIDs, []int{eventIDs[2]}) {
+		t.Fatalf("expected the second advance to stay queued, got %+v", gs.PendingCultistsCultSelection)
+	}
+	if err := NewSelectCultistsCultTrackAction("cultists", CultAir).Execute(gs); err != nil {
+		t.Fatalf("second selection: %v", err)
+	}
+	if gs.PendingCultistsCultSelection != nil {
+		t.Fatalf("expected the queue to be empty, got %+v", gs.PendingCultistsCultSelection)
+	}
+	if cultistsPlayer.CultPositions[CultEarth] != earth+1 || cultistsPlayer.CultPositions[CultAir] != air+1 {
+		t.Fatalf("expected one Earth and one Air advance, got %v", cultistsPlayer.CultPositions)
+	}
+}
+
 func TestCultists_CultTrackSelection_CannotSelectAtPosition10(t *testing.T) {
 	gs := NewGameState()
 	cultistsFaction := factions.NewCultists()
@@ -1177,8 +1258,8 @@ func TestGiants_TransformAwardsScoringTileVP(t *testing.T) {
 	gs.AddPlayer("player1", faction)
 	player := gs.GetPlayer("player1")
 
-	// Build stronghold to enable special action
-	player.HasStrongholdAbility = true
+	// Build stronghold next to the target to enable special action
+	buildStrongholdForPlayer(gs, "player1", board.NewHex(0, 1))
 
 	// Set up scoring tile: 2 VP per spade
 	gs.ScoringTiles = &ScoringTileState{
@@ -1223,8 +1304,8 @@ func TestGiants_TransformWithDwelling(t *testing.T) {
 	gs.AddPlayer("player1", faction)
 	player := gs.GetPlayer("player1")
 
-	// Build stronghold
-	player.HasStrongholdAbility = true
+	// Build stronghold next to the target
+	buildStrongholdForPlayer(gs, "player1", board.NewHex(0, 1))
 
 	// Give player resources for dwelling
 	player.Resources.Coins = 10
@@ -1259,9 +1340,8 @@ func TestGiants_TransformOncePerRound(t *testing.T) {
 	gs := NewGameState()
 	faction := factions.NewGiants()
 	gs.AddPlayer("player1", faction)
-	player := gs.GetPlayer("player1")
 
-	player.HasStrongholdAbility = true
+	buildStrongholdForPlayer(gs, "player1", board.NewHex(0, 1))
 
 	targetHex1 := board.NewHex(0, 0)
 	targetHex2 := board.NewHex(1, 0)
@@ -1278,6 +1358,57 @@ func TestGiants_TransformOncePerRound(t *testing.T) {
 	err = action2.Execute(gs)
 	if err == nil {
 		t.Error("second Giants transform in same round should fail")
+	} else if ReasonCodeOf(err) != ReasonActionSpaceTaken {
+		t.Errorf("expected reason %s, got %s", ReasonActionSpaceTaken, ReasonCodeOf(err))
+	}
+}
+
+func TestGiants_TransformRequiresReachableHex(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("player1", factions.NewGiants())
+	player := gs.GetPlayer("player1")
+	player.HasStrongholdAbility = true
+
+	// Without any structure there is nothing to be adjacent to
+	targetHex := board.NewHex(0, 0)
+	err := NewGiantsTransformAction("player1", targetHex, false).Validate(gs)
+	if ReasonCodeOf(err) != ReasonNotReachable {
+		t.Fatalf("expected %s without structures, got %v", ReasonNotReachable, err)
+	}
+
+	buildStrongholdForPlayer(gs, "player1", board.NewHex(0, 1))
+	distantHex := board.NewHex(5, 5)
+	gs.Map.GetHex(distantHex).Terrain = models.TerrainForest
+	err = NewGiantsTransformAction("player1", distantHex, false).Validate(gs)
+	if ReasonCodeOf(err) != ReasonNotReachable {
+		t.Fatalf("expected %s for a distant hex, got %v", ReasonNotReachable, err)
+	}
+
+	gs.Map.GetHex(targetHex).Terrain = models.TerrainRiver
+	err = NewGiantsTransformAction("player1", targetHex, false).Validate(gs)
+	if ReasonCodeOf(err) != ReasonInvalidHex {
+		t.Fatalf("expected %s for a river hex, got %v", ReasonInvalidHex, err)
+	}
+}
+
+func TestGiants_TransformWithDwellingRequiresResources(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("player1", factions.NewGiants())
+	player := gs.GetPlayer("player1")
+	buildStrongholdForPlayer(gs, "player1", board.NewHex(0, 1))
+	player.Resources.Coins = 0
+	player.Resources.Workers = 0
+
+	targetHex := board.NewHex(0, 0)
+	err := NewGiantsTransformAction("player1", targetHex, true).Execute(gs)
+	if ReasonCodeOf(err) != ReasonInsufficientResources {
+		t.Fatalf("expected %s, got %v", ReasonInsufficientResources, err)
+	}
+	if gs.Map.GetHex(targetHex).Terrain == models.TerrainWasteland {
+		t.Error("expected terrain to stay untouched when the dwelling cannot be paid for")
+	}
+	if player.SpecialActionsUsed[SpecialActionGiantsTransform] {
+		t.Error("expected Giants transform to stay available")
 	}
 }
 
@@ -1651,8 +1782,8 @@ func TestFakirs_CarpetFlightWithPowerAction(t *testing.T) {
 		PowerValue: 1,
 	})
 
-	// Target hex is 1 space away
-	targetHex := board.NewHex(1, 0)
+	// Target hex is distance 2 away
+	targetHex := board.NewHex(2, 0)
 	gs.Map.Hexes[targetHex] = &board.MapHex{Coord: targetHex, Terrain: models.TerrainPlains}
 
 	// Give player power and priests
@@ -1681,6 +1812,43 @@ func TestFakirs_CarpetFlightWithPowerAction(t *testing.T) {
 	}
 }
 
+func TestFakirs_AdjacentBuildDoesNotUseCarpetFlight(t *testing.T) {
+	for _, requested := range []bool{false, true} {
+		gs := NewGameState()
+		faction := factions.NewFakirs()
+		gs.AddPlayer("player1", faction)
+		player := gs.GetPlayer("player1")
+
+		initialHex := board.NewHex(0, 0)
+		gs.Map.Hexes[initialHex] = &board.MapHex{Coord: initialHex, Terrain: faction.GetHomeTerrain()}
+		gs.Map.PlaceBuilding(initialHex, &models.Building{
+			Type:       models.BuildingDwelling,
+			Faction:    faction.GetType(),
+			PlayerID:   "player1",
+			PowerValue: 1,
+		})
+		targetHex := board.NewHex(1, 0)
+		gs.Map.Hexes[targetHex] = &board.MapHex{Coord: targetHex, Terrain: faction.GetHomeTerrain()}
+
+		player.Resources.Workers = 10
+		player.Resources.Coins = 10
+		player.Resources.Priests = 2
+		initialVP := player.VictoryPoints
+
+		action := NewTransformAndBuildAction("player1", targetHex, true, models.TerrainTypeUnknown)
+		action.UseSkip = requested
+		if err := action.Execute(gs); err != nil {
+			t.Fatalf("requested=%v: adjacent build failed: %v", requested, err)
+		}
+		if player.Resources.Priests != 2 {
+			t.Errorf("requested=%v: expected no priest spent on an adjacent build, have %d", requested, player.Resources.Priests)
+		}
+		if vpGained := player.VictoryPoints - initialVP; vpGained != 0 {
+			t.Errorf("requested=%v: expected no carpet flight VP on an adjacent build, got +%d", requested, vpGained)
+		}
+	}
+}
+
 func TestFakirs_CannotUpgradeShipping(t *testing.T) {
 	gs := NewGameState()
 	faction := factions.NewFakirs()
@@ -1693,6 +1861,89 @@ func TestFakirs_CannotUpgradeShipping(t *testing.T) {
 	}
 }
 
+func TestFakirs_AdvanceShippingRejected(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewFakirs()
+	gs.AddPlayer("player1", faction)
+	player := gs.GetPlayer("player1")
+	player.Resources.Coins = 20
+	player.Resources.Priests = 3
+
+	action := &AdvanceShippingAction{BaseAction: BaseAction{Type: ActionAdvanceShipping, PlayerID: "player1"}}
+	if err := action.Validate(gs); err == nil {
+		t.Fatal("expected Fakirs shipping advance to be rejected")
+	}
+	if player.ShippingLevel != 0 {
+		t.Errorf("expected shipping level to stay 0, got %d", player.ShippingLevel)
+	}
+}
+
+func TestFakirs_CarpetFlightCannotTargetRiver(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewFakirs()
+	gs.AddPlayer("player1", faction)
+	player := gs.GetPlayer("player1")
+
+	initialHex := board.NewHex(0, 0)
+	gs.Map.Hexes[initialHex] = &board.MapHex{Coord: initialHex, Terrain: faction.GetHomeTerrain()}
+	gs.Map.PlaceBuilding(initialHex, &models.Building{
+		Type:       models.BuildingDwelling,
+		Faction:    faction.GetType(),
+		PlayerID:   "player1",
+		PowerValue: 1,
+	})
+
+	riverHex := board.NewHex(2, 0)
+	gs.Map.Hexes[riverHex] = &board.MapHex{Coord: riverHex, Terrain: models.TerrainRiver}
+	player.Resources.Priests = 2
+
+	if err := ValidateSkipAbility(gs, player, riverHex); err == nil {
+		t.Fatal("expected carpet flight onto a river hex to be rejected")
+	}
+}
+
+func TestFakirs_CarpetFlightPriestReturnsToSupply(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewFakirs()
+	gs.AddPlayer("player1", faction)
+	player := gs.GetPlayer("player1")
+	player.Resources.Priests = 7
+
+	PaySkipCost(player)
+	if got := gs.PriestLedger("player1").Owned(); got != 6 {
+		t.Fatalf("expected 6 owned priests after carpet flight, got %d", got)
+	}
+	if gained := gs.GainPriests("player1", 1); gained != 1 {
+		t.Errorf("expected spent priest to be regainable from supply, gained %d", gained)
+	}
+}
+
+func TestFakirs_FlightRangeStacksAndClonesIndependently(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewFakirs()
+	gs.AddPlayer("player1", faction)
+
+	faction.BuildStronghold()
+	faction.BuildStronghold() // Stronghold bonus applies only once
+	faction.IncrementFlightRange()
+	if got := faction.GetFlightRange(); got != 3 {
+		t.Fatalf("expected stronghold + town tile range 3, got %d", got)
+	}
+
+	snapshot := gs.CloneForUndo()
+	faction.IncrementFlightRange()
+	cloned := snapshot.GetPlayer("player1").Faction.(*factions.Fakirs)
+	if cloned.GetFlightRange() != 3 {
+		t.Errorf("expected cloned flight range 3, got %d", cloned.GetFlightRange())
+	}
+
+	state := SerializeState(gs, "g1")
+	playerState := state["players"].(map[string]interface{})["player1"].(map[string]interface{})
+	if playerState["carpetFlightRange"] != 4 {
+		t.Errorf("expected serialized carpetFlightRange 4, got %v", playerState["carpetFlightRange"])
+	}
+}
+
 // ============================================================================
 // DWARVES TESTS
 // ============================================================================
@@ -1987,6 +2238,77 @@ func TestDwarves_BonusCardSpadeWithTunneling(t *testing.T) {
 	}
 }
 
+func TestDwarves_ExplicitTunnelingOnAdjacentHexIsFree(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewDwarves()
+	gs.AddPlayer("player1", faction)
+	player := gs.GetPlayer("player1")
+
+	initialHex := board.NewHex(0, 0)
+	gs.Map.Hexes[initialHex] = &board.MapHex{Coord: initialHex, Terrain: faction.GetHomeTerrain()}
+	gs.Map.PlaceBuilding(initialHex, &models.Building{
+		Type:       models.BuildingDwelling,
+		Faction:    faction.GetType(),
+		PlayerID:   "player1",
+		PowerValue: 1,
+	})
+
+	// (1,0) is directly adjacent, so tunneling is not actually used
+	targetHex := board.NewHex(1, 0)
+	gs.Map.Hexes[targetHex] = &board.MapHex{Coord: targetHex, Terrain: faction.GetHomeTerrain()}
+	player.Resources.Workers = 5
+	player.Resources.Coins = 5
+	initialVP := player.VictoryPoints
+
+	action := NewTransformAndBuildActionWithSkip("player1", targetHex, true, models.TerrainTypeUnknown)
+	if err := action.Execute(gs); err != nil {
+		t.Fatalf("build on adjacent hex failed: %v", err)
+	}
+	if player.Resources.Workers != 4 {
+		t.Errorf("expected only the dwelling worker to be spent, have %d workers", player.Resources.Workers)
+	}
+	if player.VictoryPoints != initialVP {
+		t.Errorf("expected no tunneling VP for adjacent build, gained %d", player.VictoryPoints-initialVP)
+	}
+}
+
+func TestDwarves_TunneledBuildingNotConnectedForTown(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewDwarves()
+	gs.AddPlayer("player1", faction)
+	player := gs.GetPlayer("player1")
+
+	initialHex := board.NewHex(0, 0)
+	gs.Map.Hexes[initialHex] = &board.MapHex{Coord: initialHex, Terrain: faction.GetHomeTerrain()}
+	gs.Map.PlaceBuilding(initialHex, &models.Building{
+		Type:       models.BuildingDwelling,
+		Faction:    faction.GetType(),
+		PlayerID:   "player1",
+		PowerValue: 1,
+	})
+	targetHex := board.NewHex(2, 0)
+	gs.Map.Hexes[targetHex] = &board.MapHex{Coord: targetHex, Terrain: faction.GetHomeTerrain()}
+	player.Resources.Workers = 5
+	player.Resources.Coins = 5
+
+	action := NewTransformAndBuildAction("player1", targetHex, true, models.TerrainTypeUnknown)
+	if err := action.Execute(gs); err != nil {
+		t.Fatalf("tunneling build failed: %v", err)
+	}
+
+	// Reachable for building, but not directly adjacent for towns
+	connected := gs.getConnectedBuildingsForPlayer("player1", initialHex)
+	for _, hex := range connected {
+		if hex == targetHex {
+			t.Fatalf("tunneled dwelling should not be connected for town formation: %v", connected)
+		}
+	}
+	// Tunneling still connects buildings for final area scoring
+	if !gs.areHexesConnectedForAreaScoring("player1", player, initialHex, targetHex) {
+		t.Error("expected tunneled dwelling to count as connected for area scoring")
+	}
+}
+
 // ============================================================================
 // DARKLINGS TESTS
 // ============================================================================
@@ -2642,6 +2964,83 @@ func TestChaosMagicians_DoubleTurnCanOnlyUseOnce(t *testing.T) {
 	}
 }
 
+func TestChaosMagicians_DoubleTurnSecondActionSeesFirst(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewChaosMagicians()
+	gs.AddPlayer("player1", faction)
+
+	player := gs.GetPlayer("player1")
+	player.Resources.Coins = 50
+	player.Resources.Workers = 20
+
+	strongholdHex := board.NewHex(0, 1)
+	gs.Map.PlaceBuilding(strongholdHex, &models.Building{
+		Type:       models.BuildingStronghold,
+		Faction:    faction.GetType(),
+		PlayerID:   "player1",
+		PowerValue: 3,
+	})
+	gs.Map.TransformTerrain(strongholdHex, models.TerrainWasteland)
+	player.HasStrongholdAbility = true
+
+	// The second action upgrades the dwelling the first action builds.
+	targetHex := board.NewHex(1, 0)
+	gs.Map.GetHex(targetHex).Terrain = models.TerrainWasteland
+	action := NewChaosMagiciansDoubleTurnAction("player1",
+		NewTransformAndBuildAction("player1", targetHex, true, models.TerrainTypeUnknown),
+		NewUpgradeBuildingAction("player1", targetHex, models.BuildingTradingHouse))
+
+	if err := action.Execute(gs); err != nil {
+		t.Fatalf("expected double turn to succeed, got error: %v", err)
+	}
+	if building := gs.Map.GetHex(targetHex).Building; building == nil || building.Type != models.BuildingTradingHouse {
+		t.Fatalf("expected trading house at %v, got %+v", targetHex, building)
+	}
+}
+
+func TestChaosMagicians_DoubleTurnIllegalSecondActionRollsBack(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewChaosMagicians()
+	gs.AddPlayer("player1", faction)
+
+	player := gs.GetPlayer("player1")
+	player.Resources.Coins = 50
+	player.Resources.Workers = 20
+
+	strongholdHex := board.NewHex(0, 1)
+	gs.Map.PlaceBuilding(strongholdHex, &models.Building{
+		Type:       models.BuildingStronghold,
+		Faction:    faction.GetType(),
+		PlayerID:   "player1",
+		PowerValue: 3,
+	})
+	gs.Map.TransformTerrain(strongholdHex, models.TerrainWasteland)
+	player.HasStrongholdAbility = true
+
+	// Both actions build on the same hex, so the second one is illegal once
+	// the first has run.
+	targetHex := board.NewHex(1, 0)
+	gs.Map.GetHex(targetHex).Terrain = models.TerrainWasteland
+	action := NewChaosMagiciansDoubleTurnAction("player1",
+		NewTransformAndBuildAction("player1", targetHex, true, models.TerrainTypeUnknown),
+		NewTransformAndBuildAction("player1", targetHex, true, models.TerrainTypeUnknown))
+
+	if err := action.Execute(gs); err == nil {
+		t.Fatal("expected double turn with an illegal second action to fail")
+	}
+
+	player = gs.GetPlayer("player1")
+	if gs.Map.GetHex(targetHex).Building != nil {
+		t.Error("expected the first action's dwelling to be rolled back")
+	}
+	if player.Resources.Coins != 50 || player.Resources.Workers != 20 {
+		t.Errorf("expected resources to be restored, got %d coins and %d workers", player.Resources.Coins, player.Resources.Workers)
+	}
+	if player.SpecialActionsUsed[SpecialActionChaosMagiciansDoubleTurn] {
+		t.Error("expected double turn to stay available after rollback")
+	}
+}
+
 // ===== NOMADS TESTS =====
 
 func TestNomads_SandstormBasic(t *testing.T) {
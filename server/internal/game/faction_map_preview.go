@@ -0,0 +1,164 @@
+package game
+
+import (
+	"sort"
+
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/game/factions"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+// maxSuggestedStarts caps the suggested starting hexes listed per faction.
+const maxSuggestedStarts = 3
+
+// FactionMapPreview summarizes the board for draft UIs while factions are
+// being selected.
+type FactionMapPreview struct {
+	// TerrainCounts is the number of land hexes of each terrain, by name.
+	TerrainCounts map[string]int `json:"terrainCounts"`
+	// Factions follows the order of AvailableFactions.
+	Factions []FactionStartPreview `json:"factions"`
+}
+
+// FactionStartPreview describes where a selectable faction could start.
+type FactionStartPreview struct {
+	Faction string `json:"faction"`
+	// HomeTerrain is empty for factions that pick their terrain during setup;
+	// their hex lists are empty too.
+	HomeTerrain string `json:"homeTerrain,omitempty"`
+	HomeHexes   int    `json:"homeHexes"`
+	// ContestedHexes are home hexes directly adjacent to the home terrain of
+	// a faction already in play.
+	ContestedHexes []StartHexPreview `json:"contestedHexes"`
+	// SuggestedStarts are the uncontested home hexes with the most room,
+	// best first.
+	SuggestedStarts []StartHexPreview `json:"suggestedStarts"`
+}
+
+// StartHexPreview is a candidate starting hex.
+type StartHexPreview struct {
+	HexCoord
+	DisplayCoord string `json:"displayCoord,omitempty"`
+	// Room counts the directly adjacent empty land hexes that are the home
+	// terrain or one spade away from it.
+	Room int `json:"room"`
+}
+
+// PreviewFactionMap builds the FactionMapPreview for the factions still
+// available. Faction selection has no buildings yet, so the preview depends
+// only on the board layout and the factions already taken.
+func (gs *GameState) PreviewFactionMap() *FactionMapPreview {
+	preview := &FactionMapPreview{TerrainCounts: make(map[string]int), Factions: []FactionStartPreview{}}
+	if gs == nil || gs.Map == nil {
+		return preview
+	}
+
+	hexesByTerrain := make(map[models.TerrainType][]board.Hex)
+	for hex, mapHex := range gs.Map.Hexes {
+		if mapHex == nil || !isFixedHomeTerrain(mapHex.Terrain) || gs.Map.IsRiver(hex) {
+			continue
+		}
+		preview.TerrainCounts[mapHex.Terrain.String()]++
+		hexesByTerrain[mapHex.Terrain] = append(hexesByTerrain[mapHex.Terrain], hex)
+	}
+
+	inPlay := make(map[models.TerrainType]bool)
+	for _, player := range gs.Players {
+		if terrain := effectiveHomeTerrain(player); isFixedHomeTerrain(terrain) {
+			inPlay[terrain] = true
+		}
+	}
+
+	for _, factionType := range AvailableFactions(gs) {
+		entry := FactionStartPreview{
+			Faction:         factionType.String(),
+			ContestedHexes:  []StartHexPreview{},
+			SuggestedStarts: []StartHexPreview{},
+		}
+		faction := factions.NewFaction(factionType)
+		if faction == nil || !isFixedHomeTerrain(faction.GetHomeTerrain()) {
+			preview.Factions = append(preview.Factions, entry)
+			continue
+		}
+		home := faction.GetHomeTerrain()
+		entry.HomeTerrain = home.String()
+		entry.HomeHexes = len(hexesByTerrain[home])
+
+		var uncontested []StartHexPreview
+		for _, hex := range hexesByTerrain[home] {
+			if gs.Map.GetHex(hex).Building != nil {
+				continue
+			}
+			start := gs.startHexPreview(hex, home)
+			if gs.touchesTerrain(hex, inPlay) {
+				entry.ContestedHexes = append(entry.ContestedHexes, start)
+			} else {
+				uncontested = append(uncontested, start)
+			}
+		}
+		sortStartHexes(entry.ContestedHexes, false)
+		sortStartHexes(uncontested, true)
+		if len(uncontested) > maxSuggestedStarts {
+			uncontested = uncontested[:maxSuggestedStarts]
+		}
+		entry.SuggestedStarts = append(entry.SuggestedStarts, uncontested...)
+		preview.Factions = append(preview.Factions, entry)
+	}
+	return preview
+}
+
+func (gs *GameState) startHexPreview(hex board.Hex, home models.TerrainType) StartHexPreview {
+	start := StartHexPreview{HexCoord: hexCoordOf(hex)}
+	start.DisplayCoord, _ = gs.Map.DisplayCoordinateForHex(hex)
+	for _, neighbor := range gs.Map.GetDirectNeighbors(hex) {
+		mapHex := gs.Map.GetHex(neighbor)
+		if mapHex == nil || mapHex.Building != nil || !isFixedHomeTerrain(mapHex.Terrain) || gs.Map.IsRiver(neighbor) {
+			continue
+		}
+		if board.TerrainDistance(mapHex.Terrain, home) <= 1 {
+			start.Room++
+		}
+	}
+	return start
+}
+
+// touchesTerrain reports whether hex is directly adjacent to a hex of one of
+// terrains.
+func (gs *GameState) touchesTerrain(hex board.Hex, terrains map[models.TerrainType]bool) bool {
+	for _, neighbor := range gs.Map.GetDirectNeighbors(hex) {
+		if mapHex := gs.Map.GetHex(neighbor); mapHex != nil && terrains[mapHex.Terrain] {
+			return true
+		}
+	}
+	return false
+}
+
+// sortStartHexes orders hexes by board position, most room first when
+// byRoom is set.
+func sortStartHexes(hexes []StartHexPreview, byRoom bool) {
+	sort.Slice(hexes, func(i, j int) bool {
+		a, b := hexes[i], hexes[j]
+		if byRoom && a.Room != b.Room {
+			return a.Room > b.Room
+		}
+		if a.R != b.R {
+			return a.R < b.R
+		}
+		return a.Q < b.Q
+	})
+}
+
+// isFixedHomeTerrain reports whether terrain is one of the seven colors a
+// faction can call home from the start.
+func isFixedHomeTerrain(terrain models.TerrainType) bool {
+	return terrain >= models.TerrainPlains && terrain <= models.TerrainDesert
+}
+
+// serializeFactionMapPreview is the preview during faction selection, and nil
+// otherwise.
+func serializeFactionMapPreview(gs *GameState) interface{} {
+	if gs.Phase != PhaseFactionSelection {
+		return nil
+	}
+	return gs.PreviewFactionMap()
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+func TestPreviewFactionMap_ListsRemainingFactionsAndContestedStarts(t *testing.T) {
+	gs := NewGameState()
+	for _, id := range []string{"p1", "p2"} {
+		if err := gs.AddPlayer(id, nil); err != nil {
+			t.Fatalf("add %s: %v", id, err)
+		}
+	}
+	gs.Phase = PhaseFactionSelection
+	gs.TurnOrder = []string{"p1", "p2"}
+	if err := (&SelectFactionAction{PlayerID: "p1", FactionType: models.FactionHalflings}).Execute(gs); err != nil {
+		t.Fatalf("select faction: %v", err)
+	}
+
+	preview := gs.PreviewFactionMap()
+
+	land := 0
+	for _, mapHex := range gs.Map.Hexes {
+		if mapHex.Terrain != models.TerrainRiver {
+			land++
+		}
+	}
+	total := 0
+	for _, count := range preview.TerrainCounts {
+		total += count
+	}
+	if total != land {
+		t.Fatalf("terrain counts cover %d hexes, want %d land hexes", total, land)
+	}
+
+	var darklings *FactionStartPreview
+	for i := range preview.Factions {
+		switch preview.Factions[i].Faction {
+		case models.FactionHalflings.String(), models.FactionCultists.String():
+			t.Fatalf("expected the Plains factions to be gone once Halflings are taken")
+		case models.FactionDarklings.String():
+			darklings = &preview.Factions[i]
+		}
+	}
+	if darklings == nil {
+		t.Fatalf("expected Darklings among %+v", preview.Factions)
+	}
+	if darklings.HomeTerrain != "Swamp" || darklings.HomeHexes != preview.TerrainCounts["Swamp"] {
+		t.Fatalf("unexpected Darklings home: %+v", darklings)
+	}
+	if len(darklings.ContestedHexes) == 0 {
+		t.Fatalf("expected some Swamp hexes to touch Plains on the base map")
+	}
+	for _, start := range darklings.ContestedHexes {
+		if !gs.touchesTerrain(board.NewHex(start.Q, start.R), map[models.TerrainType]bool{models.TerrainPlains: true}) {
+			t.Fatalf("contested hex %+v does not touch Plains", start)
+		}
+	}
+	if len(darklings.SuggestedStarts) == 0 || len(darklings.SuggestedStarts) > maxSuggestedStarts {
+		t.Fatalf("expected 1-%d suggested starts, got %+v", maxSuggestedStarts, darklings.SuggestedStarts)
+	}
+	for i, start := range darklings.SuggestedStarts {
+		if gs.touchesTerrain(board.NewHex(start.Q, start.R), map[models.TerrainType]bool{models.TerrainPlains: true}) {
+			t.Fatalf("suggested start %+v is contested", start)
+		}
+		if i > 0 && start.Room > darklings.SuggestedStarts[i-1].Room {
+			t.Fatalf("suggested starts not ordered by room: %+v", darklings.SuggestedStarts)
+		}
+	}
+
+	state := SerializeState(gs, "g1")
+	if state["factionMapPreview"] == nil {
+		t.Fatalf("expected a factionMapPreview during faction selection")
+	}
+	gs.Phase = PhaseSetup
+	if SerializeState(gs, "g1")["factionMapPreview"] != nil {
+		t.Fatalf("expected no factionMapPreview after faction selection")
+	}
+}
@@ -8,7 +8,7 @@ import (
 // This includes:
 // - Halflings: +1 VP per spade
 // - Alchemists: +2 power per spade (after building stronghold)
-func AwardFactionSpadeBonuses(player *Player, spadesUsed int) {
+func AwardFactionSpadeBonuses(gs *GameState, player *Player, spadesUsed int) {
 	// Award faction-specific spade VP bonus (e.g., Halflings +1 VP per spade)
 	// Award faction-specific spade VP bonus (e.g., Halflings +1 VP per spade)
 	if player.Faction.GetType() == models.FactionHalflings {
@@ -21,7 +21,7 @@ func AwardFactionSpadeBonuses(player *Player, spadesUsed int) {
 	if player.Faction.GetType() == models.FactionAlchemists && player.HasStrongholdAbility {
 		powerBonus := 2 * spadesUsed
 		if powerBonus > 0 {
-			player.Resources.GainPower(powerBonus)
+			gs.GainPowerForReason(player.ID, powerBonus, PowerGainSpades, "")
 		}
 	}
 }
@@ -19,7 +19,6 @@ import (
 //	Expensive Sanctuary (4 workers, 8 coins vs standard 4 workers, 6 coins)
 type ChaosMagicians struct {
 	BaseFaction
-	doubleTurnUsedThisRound bool // Special action usage tracking
 }
 
 // NewChaosMagicians creates a new Chaos Magicians faction
@@ -38,7 +37,6 @@ func NewChaosMagicians() *ChaosMagicians {
 			},
 			DiggingLevel: 0,
 		},
-		doubleTurnUsedThisRound: false,
 	}
 }
 
@@ -17,7 +17,6 @@ import (
 // Special: All standard building costs
 type Giants struct {
 	BaseFaction
-	freeSpadesUsedThisRound bool // Special action usage tracking
 }
 
 // NewGiants creates a new Giants faction
@@ -36,7 +35,6 @@ func NewGiants() *Giants {
 			},
 			DiggingLevel: 0,
 		},
-		freeSpadesUsedThisRound: false,
 	}
 }
 
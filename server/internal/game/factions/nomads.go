@@ -18,7 +18,6 @@ import (
 // Special: Start with 2 workers, 15 coins (not standard 3 workers, 15 coins)
 type Nomads struct {
 	BaseFaction
-	sandstormUsedThisRound bool // Special action usage tracking
 }
 
 // NewNomads creates a new Nomads faction
@@ -37,7 +36,6 @@ func NewNomads() *Nomads {
 			},
 			DiggingLevel: 0,
 		},
-		sandstormUsedThisRound: false,
 	}
 }
 
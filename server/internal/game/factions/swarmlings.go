@@ -15,7 +15,6 @@ import (
 //	Start with 12 workers and 20 coins (not standard 3 workers and 15 coins)
 type Swarmlings struct {
 	BaseFaction
-	tradingHouseUpgradeUsedThisRound bool // Special action usage tracking
 }
 
 // NewSwarmlings creates a new Swarmlings faction
@@ -34,7 +33,6 @@ func NewSwarmlings() *Swarmlings {
 			},
 			DiggingLevel: 0,
 		},
-		tradingHouseUpgradeUsedThisRound: false,
 	}
 }
 
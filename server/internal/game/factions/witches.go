@@ -12,7 +12,6 @@ import (
 //	and ignoring adjacency rule
 type Witches struct {
 	BaseFaction
-	witchesRideUsedThisRound bool
 }
 
 // NewWitches creates a new Witches faction
@@ -31,7 +30,6 @@ func NewWitches() *Witches {
 			},
 			DiggingLevel: 0,
 		},
-		witchesRideUsedThisRound: false,
 	}
 }
 
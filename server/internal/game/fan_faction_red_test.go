@@ -506,7 +506,7 @@ func TestTreasurersPriestsInTreasuryBlockPriestPowerActionAtCap(t *testing.T) {
 	gs.CultTracks.PriestsOnActionSpaces["p1"][CultFire] = 2
 	gs.CultTracks.PriestsOnActionSpaces["p1"][CultWater] = 2
 
-	if got := gs.GetTotalOwnedPriests("p1"); got != 7 {
+	if got := gs.PriestLedger("p1").Owned(); got != 7 {
 		t.Fatalf("total owned priests = %d, want 7", got)
 	}
 
@@ -73,125 +73,116 @@ type FavorTile struct {
 	Description  string // Description of the special ability
 	HasAbility   bool   // Whether this tile has an ongoing ability
 	AvailableQty int    // How many of this tile are available (1 for +3, 3 for others)
+	Modifiers    FavorModifiers
 }
 
-// GetAllFavorTiles returns all favor tiles with their properties
+// FavorModifiers are the ongoing effects of favor tiles. Income, building,
+// passing, town formation and the Water+2 special action read the summed
+// modifiers of a player's tiles through GameState.FavorModifiers.
+type FavorModifiers struct {
+	IncomeCoins   int `yaml:"incomeCoins"`
+	IncomeWorkers int `yaml:"incomeWorkers"`
+	IncomePower   int `yaml:"incomePower"`
+	// DwellingVP is scored for every dwelling built.
+	DwellingVP int `yaml:"dwellingVp"`
+	// TradingHouseVP is scored for every upgrade to a trading house.
+	TradingHouseVP int `yaml:"tradingHouseVp"`
+	// TownPower, when set, replaces the power a town needs (7).
+	TownPower int `yaml:"townPower"`
+	// CultAction grants the special action to advance 1 on any cult track.
+	CultAction bool `yaml:"cultAction"`
+	// TradingHousePassVP is the VP for passing with 1, 2, ... trading houses.
+	// Larger counts score the last entry.
+	TradingHousePassVP []int `yaml:"tradingHousePassVp"`
+}
+
+func (m FavorModifiers) add(other FavorModifiers) FavorModifiers {
+	m.IncomeCoins += other.IncomeCoins
+	m.IncomeWorkers += other.IncomeWorkers
+	m.IncomePower += other.IncomePower
+	m.DwellingVP += other.DwellingVP
+	m.TradingHouseVP += other.TradingHouseVP
+	if other.TownPower > 0 && (m.TownPower == 0 || other.TownPower < m.TownPower) {
+		m.TownPower = other.TownPower
+	}
+	m.CultAction = m.CultAction || other.CultAction
+	if len(other.TradingHousePassVP) > 0 {
+		sum := make([]int, max(len(m.TradingHousePassVP), len(other.TradingHousePassVP)))
+		for i := range sum {
+			sum[i] = passVPAt(m.TradingHousePassVP, i+1) + passVPAt(other.TradingHousePassVP, i+1)
+		}
+		m.TradingHousePassVP = sum
+	}
+	return m
+}
+
+// TownPowerRequirement is the power a town of this player needs.
+func (m FavorModifiers) TownPowerRequirement() int {
+	if m.TownPower > 0 {
+		return m.TownPower
+	}
+	return 7
+}
+
+// PassVP is the VP for passing with tradingHouses trading houses on the map.
+func (m FavorModifiers) PassVP(tradingHouses int) int {
+	return passVPAt(m.TradingHousePassVP, tradingHouses)
+}
+
+func passVPAt(table []int, count int) int {
+	if count <= 0 || len(table) == 0 {
+		return 0
+	}
+	return table[min(count, len(table))-1]
+}
+
+func (m FavorModifiers) nonNegative() bool {
+	if m.IncomeCoins < 0 || m.IncomeWorkers < 0 || m.IncomePower < 0 || m.DwellingVP < 0 || m.TradingHouseVP < 0 || m.TownPower < 0 {
+		return false
+	}
+	for _, vp := range m.TradingHousePassVP {
+		if vp < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// favorModifiers sums the modifiers of tiles.
+func (c *tileCatalog) favorModifiers(tiles []FavorTileType) FavorModifiers {
+	var modifiers FavorModifiers
+	for _, tileType := range tiles {
+		modifiers = modifiers.add(c.favorTiles[tileType].Modifiers)
+	}
+	return modifiers
+}
+
+// FavorModifiers returns the summed ongoing effects of playerID's favor tiles.
+func (gs *GameState) FavorModifiers(playerID string) FavorModifiers {
+	if gs == nil || gs.FavorTiles == nil {
+		return FavorModifiers{}
+	}
+	return gs.tileDefinitions().favorModifiers(gs.FavorTiles.GetPlayerTiles(playerID))
+}
+
+// GetAllFavorTiles returns all favor tiles with their properties, as defined in
+// data/tiles.yaml. Games use the definitions they were created with; see
+// GameState.AllFavorTiles.
 func GetAllFavorTiles() map[FavorTileType]FavorTile {
-	return map[FavorTileType]FavorTile{
-		// +3 Cult advancement only (1 of each)
-		FavorFire3: {
-			Type:         FavorFire3,
-			CultTrack:    CultFire,
-			CultAdvance:  3,
-			Name:         "Fire +3",
-			Description:  "Advance 3 spaces on Fire cult track",
-			HasAbility:   false,
-			AvailableQty: 1,
-		},
-		FavorWater3: {
-			Type:         FavorWater3,
-			CultTrack:    CultWater,
-			CultAdvance:  3,
-			Name:         "Water +3",
-			Description:  "Advance 3 spaces on Water cult track",
-			HasAbility:   false,
-			AvailableQty: 1,
-		},
-		FavorEarth3: {
-			Type:         FavorEarth3,
-			CultTrack:    CultEarth,
-			CultAdvance:  3,
-			Name:         "Earth +3",
-			Description:  "Advance 3 spaces on Earth cult track",
-			HasAbility:   false,
-			AvailableQty: 1,
-		},
-		FavorAir3: {
-			Type:         FavorAir3,
-			CultTrack:    CultAir,
-			CultAdvance:  3,
-			Name:         "Air +3",
-			Description:  "Advance 3 spaces on Air cult track",
-			HasAbility:   false,
-			AvailableQty: 1,
-		},
-
-		// +2 Cult advancement with special ability (3 of each)
-		FavorFire2: {
-			Type:         FavorFire2,
-			CultTrack:    CultFire,
-			CultAdvance:  2,
-			Name:         "Fire +2: Town Power",
-			Description:  "Town power requirement reduced to 6 (from 7)",
-			HasAbility:   true,
-			AvailableQty: 3,
-		},
-		FavorWater2: {
-			Type:         FavorWater2,
-			CultTrack:    CultWater,
-			CultAdvance:  2,
-			Name:         "Water +2: Cult Advance",
-			Description:  "Special action: Advance 1 space on any cult track (once per round)",
-			HasAbility:   true,
-			AvailableQty: 3,
-		},
-		FavorEarth2: {
-			Type:         FavorEarth2,
-			CultTrack:    CultEarth,
-			CultAdvance:  2,
-			Name:         "Earth +2: Worker Income",
-			Description:  "Income: +1 worker, +1 power",
-			HasAbility:   true,
-			AvailableQty: 3,
-		},
-		FavorAir2: {
-			Type:         FavorAir2,
-			CultTrack:    CultAir,
-			CultAdvance:  2,
-			Name:         "Air +2: Power Income",
-			Description:  "Income: +4 power",
-			HasAbility:   true,
-			AvailableQty: 3,
-		},
-
-		// +1 Cult advancement with special ability (3 of each)
-		FavorFire1: {
-			Type:         FavorFire1,
-			CultTrack:    CultFire,
-			CultAdvance:  1,
-			Name:         "Fire +1: Coin Income",
-			Description:  "Income: +3 coins",
-			HasAbility:   true,
-			AvailableQty: 3,
-		},
-		FavorWater1: {
-			Type:         FavorWater1,
-			CultTrack:    CultWater,
-			CultAdvance:  1,
-			Name:         "Water +1: Trading House VP",
-			Description:  "+3 VP when upgrading Dwelling to Trading House",
-			HasAbility:   true,
-			AvailableQty: 3,
-		},
-		FavorEarth1: {
-			Type:         FavorEarth1,
-			CultTrack:    CultEarth,
-			CultAdvance:  1,
-			Name:         "Earth +1: Dwelling VP",
-			Description:  "+2 VP when building Dwelling",
-			HasAbility:   true,
-			AvailableQty: 3,
-		},
-		FavorAir1: {
-			Type:         FavorAir1,
-			CultTrack:    CultAir,
-			CultAdvance:  1,
-			Name:         "Air +1: Trading House Pass VP",
-			Description:  "VP when passing: 2/3/3/4 for 1/2/3/4 Trading Houses",
-			HasAbility:   true,
-			AvailableQty: 3,
-		},
+	return currentTiles().allFavorTiles()
+}
+
+// AllFavorTiles returns the favor tiles gs was created with.
+func (gs *GameState) AllFavorTiles() map[FavorTileType]FavorTile {
+	return gs.tileDefinitions().allFavorTiles()
+}
+
+func (c *tileCatalog) allFavorTiles() map[FavorTileType]FavorTile {
+	out := make(map[FavorTileType]FavorTile, len(c.favorTiles))
+	for tileType, tile := range c.favorTiles {
+		out[tileType] = tile
 	}
+	return out
 }
 
 // FavorTileState tracks available favor tiles and which players have which tiles
@@ -205,10 +196,12 @@ type FavorTileState struct {
 
 // NewFavorTileState creates a new favor tile state with all tiles available
 func NewFavorTileState() *FavorTileState {
-	available := make(map[FavorTileType]int)
-	allTiles := GetAllFavorTiles()
+	return newFavorTileStateFrom(currentTiles())
+}
 
-	for tileType, tile := range allTiles {
+func newFavorTileStateFrom(c *tileCatalog) *FavorTileState {
+	available := make(map[FavorTileType]int)
+	for tileType, tile := range c.favorTiles {
 		available[tileType] = tile.AvailableQty
 	}
 
@@ -309,11 +302,10 @@ func (fts *FavorTileState) GetPlayerTiles(playerID string) []FavorTileType {
 func ApplyFavorTileImmediate(gs *GameState, playerID string, tileType FavorTileType) error {
 	player := gs.GetPlayer(playerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", playerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", playerID)
 	}
 
-	allTiles := GetAllFavorTiles()
-	tile, ok := allTiles[tileType]
+	tile, ok := gs.tileDefinitions().favorTiles[tileType]
 	if !ok {
 		return fmt.Errorf("invalid favor tile type: %v", tileType)
 	}
@@ -335,18 +327,8 @@ func ApplyFavorTileImmediate(gs *GameState, playerID string, tileType FavorTileT
 
 // GetFavorTileIncomeBonus returns the income bonus from a player's favor tiles
 func GetFavorTileIncomeBonus(playerTiles []FavorTileType) (coins int, workers int, power int) {
-	for _, tileType := range playerTiles {
-		switch tileType {
-		case FavorFire1:
-			coins += 3
-		case FavorEarth2:
-			workers += 1
-			power += 1
-		case FavorAir2:
-			power += 4
-		}
-	}
-	return coins, workers, power
+	modifiers := currentTiles().favorModifiers(playerTiles)
+	return modifiers.IncomeCoins, modifiers.IncomeWorkers, modifiers.IncomePower
 }
 
 // HasFavorTile checks if a player has a specific favor tile
@@ -362,33 +344,13 @@ func HasFavorTile(playerTiles []FavorTileType, tileType FavorTileType) bool {
 // GetTownPowerRequirement returns the power requirement for founding a town
 // (7 normally, 6 if player has Fire +2 favor tile)
 func GetTownPowerRequirement(playerTiles []FavorTileType) int {
-	if HasFavorTile(playerTiles, FavorFire2) {
-		return 6
-	}
-	return 7
+	return currentTiles().favorModifiers(playerTiles).TownPowerRequirement()
 }
 
 // GetAir1PassVP returns VP gained when passing based on Trading House count
 // Only applies if player has Air +1 favor tile
 func GetAir1PassVP(playerTiles []FavorTileType, tradingHouseCount int) int {
-	if !HasFavorTile(playerTiles, FavorAir1) {
-		return 0
-	}
-
-	switch tradingHouseCount {
-	case 0:
-		return 0
-	case 1:
-		return 2
-	case 2:
-		return 3
-	case 3:
-		return 3
-	case 4:
-		return 4
-	default:
-		return 4 // Max 4 trading houses
-	}
+	return currentTiles().favorModifiers(playerTiles).PassVP(tradingHouseCount)
 }
 
 // GetFavorTileCount returns how many favor tiles a player should receive
@@ -228,3 +228,16 @@ func TestFavorTileQuantities(t *testing.T) {
 		}
 	}
 }
+
+func TestFavorModifiers_SumAcrossTiles(t *testing.T) {
+	mods := currentTiles().favorModifiers([]FavorTileType{FavorFire1, FavorEarth2, FavorFire2})
+	if mods.IncomeCoins != 3 || mods.IncomeWorkers != 1 || mods.IncomePower != 1 {
+		t.Errorf("expected income 3c/1w/1p, got %dc/%dw/%dp", mods.IncomeCoins, mods.IncomeWorkers, mods.IncomePower)
+	}
+	if got := mods.TownPowerRequirement(); got != 6 {
+		t.Errorf("expected town power requirement 6, got %d", got)
+	}
+	if mods.CultAction || mods.PassVP(2) != 0 {
+		t.Errorf("unexpected abilities from tiles without them: %+v", mods)
+	}
+}
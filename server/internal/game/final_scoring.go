@@ -11,6 +11,8 @@ import (
 // 1. Largest connected area of buildings (18 VP for largest)
 // 2. Cult track majority bonuses (8/4/2 VP for top 3 per track)
 // 3. Resource conversion (3 coins = 1 VP, 1 worker = 1 VP, 1 priest = 1 VP)
+// Resigned players keep the VP they had when they left, take no part in the
+// bonuses above and rank below every active player.
 
 // PlayerFinalScore represents a player's final score breakdown
 type PlayerFinalScore struct {
@@ -21,10 +23,19 @@ type PlayerFinalScore struct {
 	FireIceVP          int    `json:"fireIceVp"`
 	FireIceMetricValue int    `json:"fireIceMetricValue"`
 	CultVP             int    `json:"cultVp"`
-	ResourceVP         int    `json:"resourceVp"`
-	TotalVP            int    `json:"totalVp"`
-	LargestAreaSize    int    `json:"largestAreaSize"`
-	TotalResourceValue int    `json:"totalResourceValue"`
+	// CultVPByTrack splits CultVP by track, indexed by CultTrack.
+	CultVPByTrack [4]int `json:"cultVpByTrack"`
+	ResourceVP    int    `json:"resourceVp"`
+	// FactionVP is the part of ResourceVP that the faction's own conversion
+	// rules add over the standard 3 coins per VP and 2 Bowl II per coin.
+	FactionVP          int  `json:"factionVp"`
+	TotalVP            int  `json:"totalVp"`
+	LargestAreaSize    int  `json:"largestAreaSize"`
+	TotalResourceValue int  `json:"totalResourceValue"`
+	Resigned           bool `json:"resigned,omitempty"`
+	// ResourcesHidden marks a score projection that leaves out resources the
+	// viewer cannot see.
+	ResourcesHidden bool `json:"resourcesHidden,omitempty"`
 }
 
 // CalculateFinalScoring calculates all end-game scoring
@@ -48,6 +59,7 @@ func (gs *GameState) CalculateFinalScoring() map[string]*PlayerFinalScore {
 			PlayerID:   playerID,
 			PlayerName: name,
 			BaseVP:     player.VictoryPoints,
+			Resigned:   player.Resigned,
 		}
 	}
 
@@ -101,6 +113,9 @@ type playerMetric struct {
 func (gs *GameState) getRankedAreas(scores map[string]*PlayerFinalScore) []playerArea {
 	var ranked []playerArea
 	for id, score := range scores {
+		if score.Resigned {
+			continue
+		}
 		ranked = append(ranked, playerArea{id, score.LargestAreaSize})
 	}
 
@@ -179,7 +194,7 @@ func (gs *GameState) calculateFireIceBonuses(scores map[string]*PlayerFinalScore
 	for playerID := range gs.Players {
 		value := gs.fireIceMetricForPlayer(playerID, tile)
 		scores[playerID].FireIceMetricValue = value
-		if value > 0 {
+		if value > 0 && !scores[playerID].Resigned {
 			ranked = append(ranked, playerMetric{playerID: playerID, value: value})
 		}
 	}
@@ -438,7 +453,7 @@ func (gs *GameState) calculateCultBonuses(scores map[string]*PlayerFinalScore) {
 
 	for _, track := range tracks {
 		positionGroups := gs.getRankedCultPositions(track)
-		gs.distributeCultVP(scores, positionGroups)
+		gs.distributeCultVP(scores, track, positionGroups)
 	}
 }
 
@@ -450,7 +465,10 @@ func (gs *GameState) getRankedCultPositions(track CultTrack) [][]string {
 	}
 
 	positions := []playerPosition{}
-	for playerID := range gs.Players {
+	for playerID, player := range gs.Players {
+		if player.Resigned {
+			continue
+		}
 		pos := gs.CultTracks.GetPosition(playerID, track)
 		if pos > 0 { // Only include players who advanced on this track
 			positions = append(positions, playerPosition{playerID, pos})
@@ -488,7 +506,7 @@ func (gs *GameState) getRankedCultPositions(track CultTrack) [][]string {
 	return positionGroups
 }
 
-func (gs *GameState) distributeCultVP(scores map[string]*PlayerFinalScore, positionGroups [][]string) {
+func (gs *GameState) distributeCultVP(scores map[string]*PlayerFinalScore, track CultTrack, positionGroups [][]string) {
 	if len(positionGroups) == 0 {
 		return
 	}
@@ -515,6 +533,7 @@ func (gs *GameState) distributeCultVP(scores map[string]*PlayerFinalScore, posit
 
 		for _, playerID := range group {
 			scores[playerID].CultVP += vpPerPlayer
+			scores[playerID].CultVPByTrack[track] += vpPerPlayer
 		}
 
 		// Move to next award level
@@ -531,6 +550,9 @@ func (gs *GameState) distributeCultVP(scores map[string]*PlayerFinalScore, posit
 // 5. All Coins → VP at 3:1 (or 2:1 for Alchemists)
 func (gs *GameState) calculateResourceConversion(scores map[string]*PlayerFinalScore) {
 	for playerID, player := range gs.Players {
+		if player.Resigned {
+			continue
+		}
 		// Step 1 & 2: Convert workers and priests to coins
 		workerCoins := player.Resources.Workers
 		priestCoins := player.Resources.Priests
@@ -551,6 +573,8 @@ func (gs *GameState) calculateResourceConversion(scores map[string]*PlayerFinalS
 
 		// Convert all coins to VP
 		scores[playerID].ResourceVP = totalCoins / coinsPerVP
+		standardCoins := totalCoins - bowl2Coins + player.Resources.Power.Bowl2/2
+		scores[playerID].FactionVP = scores[playerID].ResourceVP - standardCoins/3
 
 		// Track total resource value (in coins) for tiebreaker
 		scores[playerID].TotalResourceValue = totalCoins
@@ -567,7 +591,7 @@ func finalBowl2CoinValue(player *Player) int {
 	return bowl2 / 2
 }
 
-// GetWinner returns the player ID of the winner
+// GetWinner returns the player ID of the winner, never a resigned player
 // Tiebreaker: highest total resource value (coins + workers + priests)
 func (gs *GameState) GetWinner(scores map[string]*PlayerFinalScore) string {
 	var winner string
@@ -575,6 +599,9 @@ func (gs *GameState) GetWinner(scores map[string]*PlayerFinalScore) string {
 	maxResources := -1
 
 	for playerID, score := range scores {
+		if score.Resigned {
+			continue
+		}
 		if score.TotalVP > maxVP {
 			winner = playerID
 			maxVP = score.TotalVP
@@ -591,7 +618,8 @@ func (gs *GameState) GetWinner(scores map[string]*PlayerFinalScore) string {
 	return winner
 }
 
-// GetRankedPlayers returns players sorted by final score (descending)
+// GetRankedPlayers returns players sorted by final score (descending), with
+// resigned players last
 func GetRankedPlayers(scores map[string]*PlayerFinalScore) []*PlayerFinalScore {
 	ranked := make([]*PlayerFinalScore, 0, len(scores))
 	for _, score := range scores {
@@ -599,6 +627,9 @@ func GetRankedPlayers(scores map[string]*PlayerFinalScore) []*PlayerFinalScore {
 	}
 
 	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Resigned != ranked[j].Resigned {
+			return !ranked[i].Resigned
+		}
 		if ranked[i].TotalVP != ranked[j].TotalVP {
 			return ranked[i].TotalVP > ranked[j].TotalVP
 		}
@@ -0,0 +1,119 @@
+package game
+
+import "sort"
+
+// ScoringStepCategory names one stage of the final scoring reveal.
+type ScoringStepCategory string
+
+const (
+	ScoringStepArea      ScoringStepCategory = "area"
+	ScoringStepFireIce   ScoringStepCategory = "fire_ice"
+	ScoringStepCult      ScoringStepCategory = "cult"
+	ScoringStepResources ScoringStepCategory = "resources"
+	// ScoringStepFaction is what faction conversion rules, such as the
+	// Alchemists' 2 coins per VP, add to the resources step.
+	ScoringStepFaction ScoringStepCategory = "faction"
+)
+
+// ScoringStep is one stage of final scoring, in the order the engine scores
+// them. Observers can reveal steps one by one; the sum of all steps is the
+// breakdown in FinalScoring.
+type ScoringStep struct {
+	Index    int                 `json:"index"`
+	Count    int                 `json:"count"`
+	Category ScoringStepCategory `json:"category"`
+	// Track is set for cult steps.
+	Track  *CultTrack     `json:"track,omitempty"`
+	Awards []ScoringAward `json:"awards"`
+}
+
+// ScoringAward is what one player scored in a ScoringStep.
+type ScoringAward struct {
+	PlayerID string `json:"playerId"`
+	// Value is the ranked quantity: area size, Fire & Ice metric, cult track
+	// position or, for resources and faction steps, resource value in coins.
+	Value int `json:"value"`
+	VP    int `json:"vp"`
+	// TotalVP is the player's running total after this step.
+	TotalVP int `json:"totalVp"`
+}
+
+// FinalScoringSteps splits FinalScoring into reveal steps: area, the Fire &
+// Ice tile if one is in play, each cult track, resources, then faction
+// bonuses. It returns nil before final scoring.
+func (gs *GameState) FinalScoringSteps() []ScoringStep {
+	if gs == nil || gs.FinalScoring == nil {
+		return nil
+	}
+
+	running := make(map[string]int, len(gs.FinalScoring))
+	for playerID, score := range gs.FinalScoring {
+		if score != nil {
+			running[playerID] = score.BaseVP
+		}
+	}
+
+	var steps []ScoringStep
+	addStep := func(category ScoringStepCategory, track *CultTrack, value, vp func(*PlayerFinalScore) int) {
+		step := ScoringStep{Category: category, Track: track, Awards: make([]ScoringAward, 0, len(running))}
+		for playerID, score := range gs.FinalScoring {
+			if score == nil {
+				continue
+			}
+			running[playerID] += vp(score)
+			step.Awards = append(step.Awards, ScoringAward{
+				PlayerID: playerID,
+				Value:    value(score),
+				VP:       vp(score),
+				TotalVP:  running[playerID],
+			})
+		}
+		sort.Slice(step.Awards, func(i, j int) bool {
+			if step.Awards[i].Value != step.Awards[j].Value {
+				return step.Awards[i].Value > step.Awards[j].Value
+			}
+			return step.Awards[i].PlayerID < step.Awards[j].PlayerID
+		})
+		steps = append(steps, step)
+	}
+
+	addStep(ScoringStepArea, nil,
+		func(s *PlayerFinalScore) int { return s.LargestAreaSize },
+		func(s *PlayerFinalScore) int { return s.AreaVP })
+	if gs.FireIceFinalScoringTile != FireIceFinalScoringTileNone {
+		addStep(ScoringStepFireIce, nil,
+			func(s *PlayerFinalScore) int { return s.FireIceMetricValue },
+			func(s *PlayerFinalScore) int { return s.FireIceVP })
+	}
+	for _, track := range []CultTrack{CultFire, CultWater, CultEarth, CultAir} {
+		addStep(ScoringStepCult, &track,
+			func(s *PlayerFinalScore) int {
+				if gs.CultTracks == nil {
+					return 0
+				}
+				return gs.CultTracks.GetPosition(s.PlayerID, track)
+			},
+			func(s *PlayerFinalScore) int { return s.CultVPByTrack[track] })
+	}
+	addStep(ScoringStepResources, nil,
+		func(s *PlayerFinalScore) int { return s.TotalResourceValue },
+		func(s *PlayerFinalScore) int { return s.ResourceVP - s.FactionVP })
+	addStep(ScoringStepFaction, nil,
+		func(s *PlayerFinalScore) int { return s.TotalResourceValue },
+		func(s *PlayerFinalScore) int { return s.FactionVP })
+
+	for i := range steps {
+		steps[i].Index = i
+		steps[i].Count = len(steps)
+	}
+	return steps
+}
+
+// FinalScoringSteps returns the final scoring reveal steps of gameID, or nil
+// if the game is unknown or not scored yet.
+func (m *Manager) FinalScoringSteps(gameID string) []ScoringStep {
+	m.rehydrate(gameID)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.games[gameID].FinalScoringSteps()
+}
@@ -456,6 +456,65 @@ func TestCultBonus_MultipleTracks(t *testing.T) {
 	if scores["player2"].CultVP != 12 {
 		t.Errorf("player2: expected 12 VP, got %d", scores["player2"].CultVP)
 	}
+
+	if got, want := scores["player1"].CultVPByTrack, [4]int{8, 4, 0, 0}; got != want {
+		t.Errorf("player1 cult VP by track: expected %v, got %v", want, got)
+	}
+	if got, want := scores["player2"].CultVPByTrack, [4]int{4, 8, 0, 0}; got != want {
+		t.Errorf("player2 cult VP by track: expected %v, got %v", want, got)
+	}
+}
+
+func TestFinalScoringSteps_RevealsEachCategoryWithRunningTotals(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("player1", factions.NewAuren())
+	gs.AddPlayer("player2", factions.NewSwarmlings())
+	player1 := gs.GetPlayer("player1")
+	player2 := gs.GetPlayer("player2")
+	player1.VictoryPoints = 50
+	player2.VictoryPoints = 45
+	for _, p := range gs.Players {
+		for _, track := range []CultTrack{CultFire, CultWater, CultEarth, CultAir} {
+			p.CultPositions[track] = 0
+			gs.CultTracks.PlayerPositions[p.ID][track] = 0
+		}
+	}
+	player1.Keys = 1
+	gs.CultTracks.AdvancePlayer("player1", CultFire, 10, player1, gs)
+	gs.CultTracks.AdvancePlayer("player2", CultFire, 8, player2, gs)
+
+	if steps := gs.FinalScoringSteps(); steps != nil {
+		t.Fatalf("expected no steps before final scoring, got %d", len(steps))
+	}
+	gs.FinalScoring = gs.CalculateFinalScoring()
+	steps := gs.FinalScoringSteps()
+
+	wantCategories := []ScoringStepCategory{
+		ScoringStepArea, ScoringStepCult, ScoringStepCult, ScoringStepCult, ScoringStepCult,
+		ScoringStepResources, ScoringStepFaction,
+	}
+	if len(steps) != len(wantCategories) {
+		t.Fatalf("expected %d steps, got %d", len(wantCategories), len(steps))
+	}
+	for i, step := range steps {
+		if step.Category != wantCategories[i] || step.Index != i || step.Count != len(steps) {
+			t.Fatalf("step %d: got category=%s index=%d count=%d", i, step.Category, step.Index, step.Count)
+		}
+	}
+
+	fire := steps[1]
+	if fire.Track == nil || *fire.Track != CultFire {
+		t.Fatalf("expected fire track on step 1, got %v", fire.Track)
+	}
+	if fire.Awards[0].PlayerID != "player1" || fire.Awards[0].Value != 10 || fire.Awards[0].VP != 8 {
+		t.Fatalf("expected player1 to lead fire with 8 VP, got %+v", fire.Awards[0])
+	}
+
+	for _, award := range steps[len(steps)-1].Awards {
+		if want := gs.FinalScoring[award.PlayerID].TotalVP; award.TotalVP != want {
+			t.Errorf("%s: final running total %d, expected %d", award.PlayerID, award.TotalVP, want)
+		}
+	}
 }
 
 func TestResourceConversion(t *testing.T) {
@@ -681,6 +740,9 @@ func TestResourceConversion_Alchemists(t *testing.T) {
 	if scores["player1"].ResourceVP != 5 {
 		t.Errorf("expected 5 VP (11 coins / 2), got %d", scores["player1"].ResourceVP)
 	}
+	if scores["player1"].FactionVP != 2 {
+		t.Errorf("expected 2 of those VP over the standard 11 coins / 3, got %d", scores["player1"].FactionVP)
+	}
 }
 
 func TestResourceConversion_AlchemistsWithPower(t *testing.T) {
@@ -229,7 +229,7 @@ func isAdjacentToPlayerBuildingWithExtraShipping(gs *GameState, targetHex board.
 		return true
 	}
 
-	effectiveShipping := gs.effectiveShippingLevel(playerID) + extraShipping
+	effectiveShipping := gs.EffectiveShippingLevel(playerID) + extraShipping
 	if effectiveShipping <= 0 {
 		return false
 	}
@@ -264,7 +264,7 @@ func (gs *GameState) convertFactionSpadeReward(playerID string, spades int, vpEl
 	}
 	switch player.Faction.GetType() {
 	case models.FactionDragonlords:
-		player.Resources.Power.Bowl1 += spades
+		gs.GainPowerTokensForReason(playerID, 1, spades, PowerGainSpades, "")
 	case models.FactionAcolytes:
 		for i := 0; i < spades; i++ {
 			track := gs.bestAcolytesCultTrackForGain(player)
@@ -310,7 +310,7 @@ func (gs *GameState) spendAcolytesCultSteps(playerID string, amount int) error {
 func (gs *GameState) spendAcolytesCultStepsFromTrack(playerID string, amount int, selectedTrack *CultTrack) error {
 	player := gs.GetPlayer(playerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", playerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", playerID)
 	}
 	track, ok := gs.acolytesCultPaymentTrackForSelection(player, amount, selectedTrack)
 	if !ok {
@@ -506,13 +506,13 @@ func (gs *GameState) grantSnowShamansStrongholdDwellings(playerID string) {
 func (gs *GameState) removePowerTokens(playerID string, count int) error {
 	player := gs.GetPlayer(playerID)
 	if player == nil || player.Resources == nil || player.Resources.Power == nil {
-		return fmt.Errorf("player not found: %s", playerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", playerID)
 	}
 	if count < 0 {
 		return fmt.Errorf("cannot remove negative power tokens")
 	}
 	if player.Resources.Power.TotalPower() < count {
-		return fmt.Errorf("not enough power tokens available")
+		return ruleErrorf(ReasonInsufficientPower, "not enough power tokens available")
 	}
 	remaining := count
 	remove := remaining
@@ -0,0 +1,126 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Handicap is extra starting VP, workers and coins given to a player to even
+// out games between players of mixed skill. It is added on top of the
+// faction's starting resources (and the auction's starting VP) once the
+// player has a faction.
+type Handicap struct {
+	VP      int `json:"vp,omitempty"`
+	Workers int `json:"workers,omitempty"`
+	Coins   int `json:"coins,omitempty"`
+}
+
+// Handicaps are capped so that a typo cannot decide the game before it starts.
+const (
+	maxHandicapVP      = 40
+	maxHandicapWorkers = 5
+	maxHandicapCoins   = 20
+)
+
+// handicapUnits are the suffixes of the handicap notation, e.g. "5VP 1W 2C".
+var handicapUnits = []struct {
+	suffix string
+	field  func(*Handicap) *int
+}{
+	{"VP", func(h *Handicap) *int { return &h.VP }},
+	{"W", func(h *Handicap) *int { return &h.Workers }},
+	{"C", func(h *Handicap) *int { return &h.Coins }},
+}
+
+// IsZero reports whether the handicap gives nothing.
+func (h Handicap) IsZero() bool {
+	return h == Handicap{}
+}
+
+// Validate rejects negative bonuses and bonuses above the caps.
+func (h Handicap) Validate() error {
+	if h.VP < 0 || h.Workers < 0 || h.Coins < 0 {
+		return fmt.Errorf("handicap bonuses cannot be negative: %s", h)
+	}
+	if h.VP > maxHandicapVP || h.Workers > maxHandicapWorkers || h.Coins > maxHandicapCoins {
+		return fmt.Errorf("handicap %s exceeds %dVP %dW %dC", h, maxHandicapVP, maxHandicapWorkers, maxHandicapCoins)
+	}
+	return nil
+}
+
+// String renders the handicap in log notation, e.g. "5VP 2C".
+func (h Handicap) String() string {
+	var parts []string
+	for _, unit := range handicapUnits {
+		if value := *unit.field(&h); value != 0 {
+			parts = append(parts, strconv.Itoa(value)+unit.suffix)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// ParseHandicap parses the notation produced by Handicap.String.
+func ParseHandicap(s string) (Handicap, error) {
+	var h Handicap
+	for _, token := range strings.Fields(strings.ToUpper(s)) {
+		parsed := false
+		for _, unit := range handicapUnits {
+			number, ok := strings.CutSuffix(token, unit.suffix)
+			if !ok {
+				continue
+			}
+			value, err := strconv.Atoi(number)
+			if err != nil {
+				return Handicap{}, fmt.Errorf("invalid handicap %q: %w", token, err)
+			}
+			*unit.field(&h) += value
+			parsed = true
+			break
+		}
+		if !parsed {
+			return Handicap{}, fmt.Errorf("invalid handicap %q", token)
+		}
+	}
+	return h, h.Validate()
+}
+
+// validateHandicaps checks that handicaps only name seated players and that
+// each is valid. Empty handicaps are dropped.
+func validateHandicaps(handicaps map[string]Handicap, playerIDs []string) (map[string]Handicap, error) {
+	if len(handicaps) == 0 {
+		return nil, nil
+	}
+	seated := make(map[string]bool, len(playerIDs))
+	for _, playerID := range playerIDs {
+		seated[playerID] = true
+	}
+	valid := make(map[string]Handicap, len(handicaps))
+	for playerID, handicap := range handicaps {
+		if !seated[playerID] {
+			return nil, fmt.Errorf("handicap for unknown player %s", playerID)
+		}
+		if err := handicap.Validate(); err != nil {
+			return nil, fmt.Errorf("player %s: %w", playerID, err)
+		}
+		if !handicap.IsZero() {
+			valid[playerID] = handicap
+		}
+	}
+	if len(valid) == 0 {
+		return nil, nil
+	}
+	return valid, nil
+}
+
+// Apply adds the handicap to player's VP and resources.
+func (h Handicap) Apply(player *Player) {
+	if player == nil {
+		return
+	}
+	player.VictoryPoints += h.VP
+	if player.Resources != nil {
+		player.Resources.Workers += h.Workers
+		player.Resources.Coins += h.Coins
+	}
+}
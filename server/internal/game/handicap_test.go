@@ -0,0 +1,127 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/models"
+)
+
+func TestParseHandicap_RoundTripsNotation(t *testing.T) {
+	h, err := ParseHandicap("5vp 1W 2C")
+	if err != nil {
+		t.Fatalf("parse handicap: %v", err)
+	}
+	if h != (Handicap{VP: 5, Workers: 1, Coins: 2}) {
+		t.Fatalf("unexpected handicap: %+v", h)
+	}
+	if got := h.String(); got != "5VP 1W 2C" {
+		t.Fatalf("unexpected notation: %q", got)
+	}
+	if _, err := ParseHandicap("3P"); err == nil {
+		t.Fatalf("expected unknown unit to be rejected")
+	}
+	if _, err := ParseHandicap("-2C"); err == nil {
+		t.Fatalf("expected negative bonus to be rejected")
+	}
+	for _, capped := range []string{"41VP", "6W", "21C"} {
+		if _, err := ParseHandicap(capped); err == nil {
+			t.Fatalf("expected %s to exceed the handicap cap", capped)
+		}
+	}
+	if _, err := ParseHandicap("40VP 5W 20C"); err != nil {
+		t.Fatalf("expected the caps themselves to be allowed: %v", err)
+	}
+}
+
+func TestCreateGameWithOptions_ValidatesHandicaps(t *testing.T) {
+	mgr := NewManager()
+	if err := mgr.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{
+		Handicaps: map[string]Handicap{"p3": {VP: 5}},
+	}); err == nil {
+		t.Fatalf("expected handicap for an unseated player to be rejected")
+	}
+	if err := mgr.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{
+		Handicaps: map[string]Handicap{"p1": {Coins: -1}},
+	}); err == nil {
+		t.Fatalf("expected negative handicap to be rejected")
+	}
+	if err := mgr.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{
+		Handicaps: map[string]Handicap{"p1": {}, "p2": {VP: 3}},
+	}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	gs, _ := mgr.GetGame("g1")
+	if len(gs.Handicaps) != 1 || gs.Handicaps["p2"].VP != 3 {
+		t.Fatalf("expected only p2's handicap to be kept, got %+v", gs.Handicaps)
+	}
+}
+
+func TestSelectFaction_AppliesHandicapAndSaveFileReproducesIt(t *testing.T) {
+	selectWitches := func(mgr *Manager, gameID string) *Player {
+		t.Helper()
+		record := &RecordedAction{PlayerID: "p1", Type: "select_faction", Params: []byte(`{"faction":"Witches"}`)}
+		if _, err := mgr.ExecuteActionWithMeta(gameID, &SelectFactionAction{PlayerID: "p1", FactionType: models.FactionWitches}, ActionMeta{
+			ExpectedRevision: -1,
+			SeatID:           "p1",
+			Record:           record,
+		}); err != nil {
+			t.Fatalf("select faction: %v", err)
+		}
+		gs, _ := mgr.GetGame(gameID)
+		return gs.GetPlayer("p1")
+	}
+
+	mgr := NewManager()
+	if err := mgr.CreateGameWithOptions("plain", []string{"p1", "p2"}, CreateGameOptions{}); err != nil {
+		t.Fatalf("create plain game: %v", err)
+	}
+	plain := selectWitches(mgr, "plain")
+
+	handicap := Handicap{VP: 5, Workers: 1, Coins: 2}
+	if err := mgr.CreateGameWithOptions("handicap", []string{"p1", "p2"}, CreateGameOptions{
+		Handicaps: map[string]Handicap{"p1": handicap},
+	}); err != nil {
+		t.Fatalf("create handicap game: %v", err)
+	}
+	got := selectWitches(mgr, "handicap")
+	if got.VictoryPoints != plain.VictoryPoints+5 || got.Resources.Workers != plain.Resources.Workers+1 || got.Resources.Coins != plain.Resources.Coins+2 {
+		t.Fatalf("expected handicap on top of the faction start, got vp=%d workers=%d coins=%d (plain vp=%d workers=%d coins=%d)",
+			got.VictoryPoints, got.Resources.Workers, got.Resources.Coins,
+			plain.VictoryPoints, plain.Resources.Workers, plain.Resources.Coins)
+	}
+
+	save, err := mgr.ExportGame("handicap")
+	if err != nil {
+		t.Fatalf("export game: %v", err)
+	}
+	raw, err := json.Marshal(save)
+	if err != nil {
+		t.Fatalf("marshal save file: %v", err)
+	}
+	var decoded SaveFile
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unmarshal save file: %v", err)
+	}
+	if decoded.Settings.Handicaps["p1"] != handicap {
+		t.Fatalf("expected save file to record the handicap, got %+v", decoded.Settings.Handicaps)
+	}
+	imported, err := ReplaySaveFile(&decoded, func(recorded RecordedAction) (Action, error) {
+		var params struct {
+			Faction string `json:"faction"`
+		}
+		if err := json.Unmarshal(recorded.Params, &params); err != nil {
+			return nil, err
+		}
+		return &SelectFactionAction{PlayerID: recorded.PlayerID, FactionType: models.FactionTypeFromString(params.Faction)}, nil
+	})
+	if err != nil {
+		t.Fatalf("replay save file: %v", err)
+	}
+	replayed := imported.State.GetPlayer("p1")
+	if replayed.VictoryPoints != got.VictoryPoints || replayed.Resources.Workers != got.Resources.Workers || replayed.Resources.Coins != got.Resources.Coins {
+		t.Fatalf("replayed handicap differs: got vp=%d workers=%d coins=%d, want vp=%d workers=%d coins=%d",
+			replayed.VictoryPoints, replayed.Resources.Workers, replayed.Resources.Coins,
+			got.VictoryPoints, got.Resources.Workers, got.Resources.Coins)
+	}
+}
@@ -1,6 +1,10 @@
 package game
 
-import "github.com/lukev/tm_server/internal/models"
+import (
+	"sort"
+
+	"github.com/lukev/tm_server/internal/models"
+)
 
 // Income Phase Implementation
 //
@@ -17,11 +21,11 @@ import "github.com/lukev/tm_server/internal/models"
 
 // BaseIncome represents the standard income for each faction
 type BaseIncome struct {
-	Coins         int
-	Workers       int
-	Priests       int
-	Power         int // Power cycles through bowls using GainPower()
-	VictoryPoints int
+	Coins         int `json:"coins"`
+	Workers       int `json:"workers"`
+	Priests       int `json:"priests"`
+	Power         int `json:"power"` // Power cycles through bowls using GainPower()
+	VictoryPoints int `json:"vp"`
 }
 
 func chashIncomeTrackIncome(level int) BaseIncome {
@@ -37,18 +41,95 @@ func chashIncomeTrackIncome(level int) BaseIncome {
 	return income
 }
 
-// GrantIncome grants income to all players at the start of a round
-func (gs *GameState) GrantIncome() {
+// IncomeSource names where a part of a player's income comes from.
+type IncomeSource string
+
+const (
+	IncomeSourceFaction       IncomeSource = "faction"
+	IncomeSourceDwellings     IncomeSource = "dwellings"
+	IncomeSourceTradingHouses IncomeSource = "trading_houses"
+	IncomeSourceTemples       IncomeSource = "temples"
+	IncomeSourceSanctuary     IncomeSource = "sanctuary"
+	IncomeSourceStronghold    IncomeSource = "stronghold"
+	IncomeSourceFavorTiles    IncomeSource = "favor_tiles"
+	IncomeSourceBonusCard     IncomeSource = "bonus_card"
+)
+
+// IncomeContribution is the income one source adds.
+type IncomeContribution struct {
+	Source IncomeSource `json:"source"`
+	Income BaseIncome   `json:"income"`
+}
+
+// IncomeReport breaks down the income a player received at the start of a
+// round. Total is the sum of the contributions; Applied is what the player
+// received after the 7-priest limit. Power is gained through the bowls, so
+// Applied.Power is the power gained, not tokens moved. Warnings list the
+// income lost to those caps.
+type IncomeReport struct {
+	PlayerID      string               `json:"playerId"`
+	Round         int                  `json:"round"`
+	Contributions []IncomeContribution `json:"contributions"`
+	Total         BaseIncome           `json:"total"`
+	Applied       BaseIncome           `json:"applied"`
+	Warnings      []IncomeWarning      `json:"warnings,omitempty"`
+}
+
+// IncomeWarningResource names an income resource that can be lost to a cap.
+type IncomeWarningResource string
+
+const (
+	IncomeWarningPower   IncomeWarningResource = "power"
+	IncomeWarningPriests IncomeWarningResource = "priests"
+)
+
+// IncomeWarning is income a player wastes: power their bowls can no longer
+// cycle, or priests beyond the 7-priest limit.
+type IncomeWarning struct {
+	Resource IncomeWarningResource `json:"resource"`
+	Wasted   int                   `json:"wasted"`
+}
+
+func incomeWarnings(wastedPower, wastedPriests int) []IncomeWarning {
+	var warnings []IncomeWarning
+	if wastedPower > 0 {
+		warnings = append(warnings, IncomeWarning{Resource: IncomeWarningPower, Wasted: wastedPower})
+	}
+	if wastedPriests > 0 {
+		warnings = append(warnings, IncomeWarning{Resource: IncomeWarningPriests, Wasted: wastedPriests})
+	}
+	return warnings
+}
+
+// priestRoom is how many priests player can still take: their room under the
+// 7-priest limit, or for Riverwalkers the terrain unlocks they can choose.
+func (gs *GameState) priestRoom(player *Player) int {
+	if isRiverwalkers(player) {
+		return gs.riverwalkersPriestChoiceCapacity(player)
+	}
+	return gs.PriestLedger(player.ID).Capacity()
+}
+
+// GrantIncome grants income to all players at the start of a round and
+// returns one report per player who received income, in turn order. The
+// reports are also kept until TakeIncomeReports.
+func (gs *GameState) GrantIncome() []IncomeReport {
 	// Note: We do NOT clear PendingSpades here
 	// Cult reward spades from the previous round's cleanup phase persist into the new round
 	// and can be used during the action phase
 	// Example: A player reaches position 7 on a cult track at end of round 5,
 	// gets 1 spade reward, and can use it in round 6
 
-	for _, player := range gs.Players {
+	var reports []IncomeReport
+	for _, playerID := range gs.incomeOrder() {
+		player := gs.GetPlayer(playerID)
+		if player == nil || player.Resigned {
+			continue
+		}
 		released := gs.releaseTreasuryBeforeIncome(player.ID)
-		income := calculatePlayerIncome(gs, player)
-		applied := applyIncome(gs, player, income)
+		contributions := incomeContributions(gs, player)
+		income := sumIncome(contributions)
+		applied, warnings := applyIncome(gs, player, income)
 		gs.queueTreasurersDeposit(
 			player.ID,
 			released.Coins+applied.Coins,
@@ -56,59 +137,102 @@ func (gs *GameState) GrantIncome() {
 			released.Priests+applied.Priests,
 			"income",
 		)
+		reports = append(reports, IncomeReport{
+			PlayerID:      player.ID,
+			Round:         gs.Round,
+			Contributions: contributions,
+			Total:         income,
+			Applied:       applied,
+			Warnings:      warnings,
+		})
 	}
+	gs.incomeReports = append(gs.incomeReports, reports...)
+	return reports
+}
+
+// TakeIncomeReports returns the income reports since the last call and
+// forgets them.
+func (gs *GameState) TakeIncomeReports() []IncomeReport {
+	reports := gs.incomeReports
+	gs.incomeReports = nil
+	return reports
+}
+
+// incomeOrder is the turn order followed by any players missing from it, by ID.
+func (gs *GameState) incomeOrder() []string {
+	order := make([]string, 0, len(gs.Players))
+	seen := make(map[string]bool, len(gs.Players))
+	for _, playerID := range gs.TurnOrder {
+		if gs.Players[playerID] != nil && !seen[playerID] {
+			order = append(order, playerID)
+			seen[playerID] = true
+		}
+	}
+	var rest []string
+	for playerID := range gs.Players {
+		if !seen[playerID] {
+			rest = append(rest, playerID)
+		}
+	}
+	sort.Strings(rest)
+	return append(order, rest...)
 }
 
 // calculatePlayerIncome calculates the total income for a player
 func calculatePlayerIncome(gs *GameState, player *Player) BaseIncome {
-	income := BaseIncome{}
+	return sumIncome(incomeContributions(gs, player))
+}
+
+// incomeContributions lists the non-empty income sources of a player.
+func incomeContributions(gs *GameState, player *Player) []IncomeContribution {
+	var contributions []IncomeContribution
+	add := func(source IncomeSource, income BaseIncome) {
+		if income != (BaseIncome{}) {
+			contributions = append(contributions, IncomeContribution{Source: source, Income: income})
+		}
+	}
 	faction := player.Faction
 
 	// 1. Base faction income (uses faction method)
-	baseIncome := faction.GetBaseFactionIncome()
-	income.Coins += baseIncome.Coins
-	income.Workers += baseIncome.Workers
-	income.Priests += baseIncome.Priests
-	income.Power += baseIncome.Power
-	income.VictoryPoints += baseIncome.VictoryPoints
+	baseIncome := BaseIncome(faction.GetBaseFactionIncome())
 	if faction.GetType() == models.FactionChashDallah {
-		trackIncome := chashIncomeTrackIncome(player.ChashIncomeTrackLevel)
-		income.Coins += trackIncome.Coins
-		income.Workers += trackIncome.Workers
-		income.VictoryPoints += trackIncome.VictoryPoints
+		baseIncome = baseIncome.plus(chashIncomeTrackIncome(player.ChashIncomeTrackLevel))
 	}
+	add(IncomeSourceFaction, baseIncome)
 
 	// 2. Income from buildings on the map (uses faction methods)
-	buildingIncome := calculateBuildingIncome(gs, player)
-	income.Coins += buildingIncome.Coins
-	income.Workers += buildingIncome.Workers
-	income.Priests += buildingIncome.Priests
-	income.Power += buildingIncome.Power
-	income.VictoryPoints += buildingIncome.VictoryPoints
+	contributions = append(contributions, buildingIncomeContributions(gs, player)...)
 
 	// 3. Income from favor tiles
-	playerTiles := gs.FavorTiles.GetPlayerTiles(player.ID)
-	favorCoins, favorWorkers, favorPower := GetFavorTileIncomeBonus(playerTiles)
-	income.Coins += favorCoins
-	income.Workers += favorWorkers
-	income.Power += favorPower
+	favor := gs.FavorModifiers(player.ID)
+	add(IncomeSourceFavorTiles, BaseIncome{
+		Coins:   favor.IncomeCoins,
+		Workers: favor.IncomeWorkers,
+		Power:   favor.IncomePower,
+	})
 
 	// 4. Income from bonus cards
 	for _, bonusCard := range gs.BonusCards.GetPlayerCards(player.ID) {
-		bonusCoins, bonusWorkers, bonusPriests, bonusPower := GetBonusCardIncomeBonus(bonusCard)
-		income.Coins += bonusCoins
-		income.Workers += bonusWorkers
-		income.Priests += bonusPriests
-		income.Power += bonusPower
+		bonusCoins, bonusWorkers, bonusPriests, bonusPower := gs.tileDefinitions().bonusCardIncome(bonusCard)
+		add(IncomeSourceBonusCard, BaseIncome{
+			Coins:   bonusCoins,
+			Workers: bonusWorkers,
+			Priests: bonusPriests,
+			Power:   bonusPower,
+		})
 	}
 
-	return income
+	return contributions
 }
 
 // calculateBuildingIncome calculates income from buildings on the map
-// Uses faction methods for income calculations
 func calculateBuildingIncome(gs *GameState, player *Player) BaseIncome {
-	income := BaseIncome{}
+	return sumIncome(buildingIncomeContributions(gs, player))
+}
+
+// buildingIncomeContributions lists the income of each building row.
+// Uses faction methods for income calculations
+func buildingIncomeContributions(gs *GameState, player *Player) []IncomeContribution {
 	faction := player.Faction
 
 	// Count buildings of each type
@@ -135,56 +259,49 @@ func calculateBuildingIncome(gs *GameState, player *Player) BaseIncome {
 		}
 	}
 
-	// Dwelling income (uses faction method)
-	dwellingIncome := faction.GetDwellingIncome(dwellings)
-	income.Coins += dwellingIncome.Coins
-	income.Workers += dwellingIncome.Workers
-	income.Priests += dwellingIncome.Priests
-	income.Power += dwellingIncome.Power
-	income.VictoryPoints += dwellingIncome.VictoryPoints
-
-	// Trading house income (uses faction method)
-	thIncome := faction.GetTradingHouseIncome(tradingHouses)
-	income.Coins += thIncome.Coins
-	income.Workers += thIncome.Workers
-	income.Priests += thIncome.Priests
-	income.Power += thIncome.Power
-	income.VictoryPoints += thIncome.VictoryPoints
-
-	// Temple income (uses faction method)
-	templeIncome := faction.GetTempleIncome(temples)
-	income.Coins += templeIncome.Coins
-	income.Workers += templeIncome.Workers
-	income.Priests += templeIncome.Priests
-	income.Power += templeIncome.Power
-	income.VictoryPoints += templeIncome.VictoryPoints
-
-	// Sanctuary income (uses faction method, only 1 per faction)
+	rows := []IncomeContribution{
+		{Source: IncomeSourceDwellings, Income: BaseIncome(faction.GetDwellingIncome(dwellings))},
+		{Source: IncomeSourceTradingHouses, Income: BaseIncome(faction.GetTradingHouseIncome(tradingHouses))},
+		{Source: IncomeSourceTemples, Income: BaseIncome(faction.GetTempleIncome(temples))},
+	}
+	// Sanctuary and stronghold income (only 1 per faction)
 	if sanctuaries > 0 {
-		sanctuaryIncome := faction.GetSanctuaryIncome()
-		income.Coins += sanctuaryIncome.Coins
-		income.Workers += sanctuaryIncome.Workers
-		income.Priests += sanctuaryIncome.Priests
-		income.Power += sanctuaryIncome.Power
-		income.VictoryPoints += sanctuaryIncome.VictoryPoints
+		rows = append(rows, IncomeContribution{Source: IncomeSourceSanctuary, Income: BaseIncome(faction.GetSanctuaryIncome())})
 	}
-
-	// Stronghold income (uses faction method)
 	if strongholds > 0 {
-		strongholdIncome := faction.GetStrongholdIncome()
-		income.Coins += strongholdIncome.Coins
-		income.Workers += strongholdIncome.Workers
-		income.Priests += strongholdIncome.Priests
-		income.Power += strongholdIncome.Power
-		income.VictoryPoints += strongholdIncome.VictoryPoints
+		rows = append(rows, IncomeContribution{Source: IncomeSourceStronghold, Income: BaseIncome(faction.GetStrongholdIncome())})
 	}
 
-	return income
+	contributions := rows[:0]
+	for _, row := range rows {
+		if row.Income != (BaseIncome{}) {
+			contributions = append(contributions, row)
+		}
+	}
+	return contributions
+}
+
+func sumIncome(contributions []IncomeContribution) BaseIncome {
+	total := BaseIncome{}
+	for _, contribution := range contributions {
+		total = total.plus(contribution.Income)
+	}
+	return total
+}
+
+func (i BaseIncome) plus(other BaseIncome) BaseIncome {
+	return BaseIncome{
+		Coins:         i.Coins + other.Coins,
+		Workers:       i.Workers + other.Workers,
+		Priests:       i.Priests + other.Priests,
+		Power:         i.Power + other.Power,
+		VictoryPoints: i.VictoryPoints + other.VictoryPoints,
+	}
 }
 
 // applyIncome applies the calculated income to a player's resources and
-// returns the actual coin/worker/priest gains applied.
-func applyIncome(gs *GameState, player *Player, income BaseIncome) BaseIncome {
+// returns the actual gains applied, with warnings for the income wasted.
+func applyIncome(gs *GameState, player *Player, income BaseIncome) (BaseIncome, []IncomeWarning) {
 	applied := BaseIncome{}
 	player.Resources.Coins += income.Coins
 	applied.Coins = income.Coins
@@ -192,15 +309,17 @@ func applyIncome(gs *GameState, player *Player, income BaseIncome) BaseIncome {
 	applied.Workers = income.Workers
 
 	// Apply priest income with 7-priest limit enforcement
+	wastedPriests := 0
 	if income.Priests > 0 {
+		wastedPriests = income.Priests - gs.priestRoom(player)
 		applied.Priests = gs.GainPriestsForReason(player.ID, income.Priests, "income")
 	}
 
 	// Use GainPower to properly cycle power through bowls
 	if income.Power > 0 {
-		player.Resources.Power.GainPower(income.Power)
+		applied.Power = gs.GainPowerForReason(player.ID, income.Power, PowerGainIncome, "")
 	}
 	player.VictoryPoints += income.VictoryPoints
 	applied.VictoryPoints = income.VictoryPoints
-	return applied
+	return applied, incomeWarnings(income.Power-applied.Power, wastedPriests)
 }
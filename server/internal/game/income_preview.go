@@ -1,5 +1,7 @@
 package game
 
+import "strconv"
+
 // IncomePreview is a JSON-friendly next-round resource summary.
 type IncomePreview struct {
 	Coins   int `json:"coins"`
@@ -64,8 +66,7 @@ func (gs *GameState) getRoundCultRewardPreview(playerID string, round int) Incom
 		return preview
 	}
 
-	position := gs.CultTracks.GetPosition(playerID, tile.CultTrack)
-	rewardCount := position / tile.CultThreshold
+	rewardCount := tile.cultRewardProgress(gs.CultTracks.GetPosition(playerID, tile.CultTrack)).RewardCount
 	if rewardCount <= 0 {
 		return preview
 	}
@@ -85,3 +86,59 @@ func (gs *GameState) getRoundCultRewardPreview(playerID string, round int) Incom
 	}
 	return preview
 }
+
+// PassIncomeWarnings estimates the income playerID would waste at the start
+// of the next round if they passed now taking card: card's income replaces
+// that of the cards they return, the round's cult rewards are gained first,
+// and the power bowls and priests are taken as they are now. It reports false
+// when there is no next round.
+func (gs *GameState) PassIncomeWarnings(playerID string, card BonusCardType) ([]IncomeWarning, bool) {
+	if gs == nil || gs.Round < 1 || gs.Round > 5 {
+		return nil, false
+	}
+	player := gs.GetPlayer(playerID)
+	if player == nil || player.Faction == nil || player.Resources == nil || player.Resources.Power == nil {
+		return nil, false
+	}
+
+	income := calculatePlayerIncome(gs, player)
+	for _, held := range gs.BonusCards.GetPlayerCards(playerID) {
+		coins, workers, priests, power := gs.tileDefinitions().bonusCardIncome(held)
+		income.Coins -= coins
+		income.Workers -= workers
+		income.Priests -= priests
+		income.Power -= power
+	}
+	coins, workers, priests, power := gs.tileDefinitions().bonusCardIncome(card)
+	income = income.plus(BaseIncome{Coins: coins, Workers: workers, Priests: priests, Power: power})
+
+	cultReward := gs.getRoundCultRewardPreview(playerID, gs.Round)
+	bowls := *player.Resources.Power
+	bowls.GainPower(cultReward.Power)
+	wastedPower := income.Power - bowls.GainPower(income.Power)
+	wastedPriests := income.Priests - max(gs.priestRoom(player)-cultReward.Priests, 0)
+	return incomeWarnings(wastedPower, wastedPriests), true
+}
+
+func serializePassIncomeWarnings(gs *GameState) interface{} {
+	if gs == nil || gs.Phases().Current() != PhaseAction || gs.Round < 1 || gs.Round > 5 {
+		return nil
+	}
+	warnings := make(map[string]map[string][]IncomeWarning)
+	for playerID, player := range gs.Players {
+		if player == nil || player.HasPassed || player.Resigned {
+			continue
+		}
+		for card := range gs.BonusCards.Available {
+			cardWarnings, ok := gs.PassIncomeWarnings(playerID, card)
+			if !ok || len(cardWarnings) == 0 {
+				continue
+			}
+			if warnings[playerID] == nil {
+				warnings[playerID] = make(map[string][]IncomeWarning)
+			}
+			warnings[playerID][strconv.Itoa(int(card))] = cardWarnings
+		}
+	}
+	return warnings
+}
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/lukev/tm_server/internal/game/board"
@@ -624,3 +625,103 @@ func TestIncome_WithFavorTiles(t *testing.T) {
 		t.Errorf("expected %d power in bowl 2, got %d", expectedBowl2, player.Resources.Power.Bowl2)
 	}
 }
+
+func TestGrantIncome_ReportsEachSource(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("player1", factions.NewAuren())
+	gs.AddPlayer("player2", factions.NewNomads())
+	gs.TurnOrder = []string{"player2", "player1"}
+	gs.Round = 2
+	player := gs.GetPlayer("player1")
+	player.Resources.Priests = 6
+
+	gs.Map.GetHex(board.NewHex(0, 0)).Building = &models.Building{
+		Type:       models.BuildingTemple,
+		Faction:    models.FactionAuren,
+		PlayerID:   "player1",
+		PowerValue: 2,
+	}
+	gs.FavorTiles.TakeFavorTile("player1", FavorFire1)
+	gs.BonusCards.SetAvailableBonusCards([]BonusCardType{BonusCardPriest})
+	gs.BonusCards.TakeBonusCard("player1", BonusCardPriest)
+
+	reports := gs.GrantIncome()
+	if len(reports) != 2 || reports[0].PlayerID != "player2" || reports[1].PlayerID != "player1" {
+		t.Fatalf("expected a report per player in turn order, got %+v", reports)
+	}
+	report := reports[1]
+	want := []IncomeContribution{
+		{Source: IncomeSourceFaction, Income: BaseIncome{Workers: 1}},
+		{Source: IncomeSourceTemples, Income: BaseIncome{Priests: 1}},
+		{Source: IncomeSourceFavorTiles, Income: BaseIncome{Coins: 3}},
+		{Source: IncomeSourceBonusCard, Income: BaseIncome{Priests: 1}},
+	}
+	if !reflect.DeepEqual(report.Contributions, want) {
+		t.Fatalf("expected contributions %+v, got %+v", want, report.Contributions)
+	}
+	if report.Round != 2 || report.Total != (BaseIncome{Coins: 3, Workers: 1, Priests: 2}) {
+		t.Fatalf("unexpected total %+v in round %d", report.Total, report.Round)
+	}
+	// The second priest would break the 7-priest limit.
+	if report.Applied != (BaseIncome{Coins: 3, Workers: 1, Priests: 1}) {
+		t.Fatalf("expected the priest limit to cap applied income, got %+v", report.Applied)
+	}
+	if want := []IncomeWarning{{Resource: IncomeWarningPriests, Wasted: 1}}; !reflect.DeepEqual(report.Warnings, want) {
+		t.Fatalf("expected warnings %+v, got %+v", want, report.Warnings)
+	}
+
+	if taken := gs.TakeIncomeReports(); len(taken) != 2 || len(gs.TakeIncomeReports()) != 0 {
+		t.Fatalf("expected the reports to be taken once, got %+v", taken)
+	}
+}
+
+func TestGrantIncome_WarnsAboutWastedPower(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("player1", factions.NewNomads())
+	gs.TurnOrder = []string{"player1"}
+	gs.Round = 2
+	player := gs.GetPlayer("player1")
+	player.Resources.Power = NewPowerSystem(0, 1, 11)
+	gs.BonusCards.SetAvailableBonusCards([]BonusCardType{BonusCardShipping})
+	gs.BonusCards.TakeBonusCard("player1", BonusCardShipping)
+
+	report := gs.GrantIncome()[0]
+	if report.Total.Power != 3 || report.Applied.Power != 1 {
+		t.Fatalf("expected 1 of 3 power income gained, got total %+v applied %+v", report.Total, report.Applied)
+	}
+	if want := []IncomeWarning{{Resource: IncomeWarningPower, Wasted: 2}}; !reflect.DeepEqual(report.Warnings, want) {
+		t.Fatalf("expected warnings %+v, got %+v", want, report.Warnings)
+	}
+}
+
+func TestPassIncomeWarnings_ComparesBonusCards(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("player1", factions.NewNomads())
+	gs.Round = 2
+	gs.Phase = PhaseAction
+	player := gs.GetPlayer("player1")
+	player.Resources.Power = NewPowerSystem(0, 2, 10)
+	player.Resources.Priests = 7
+	gs.BonusCards.SetAvailableBonusCards([]BonusCardType{BonusCardShipping, BonusCardPriest, BonusCard6Coins, BonusCardWorkerPower})
+	gs.BonusCards.TakeBonusCard("player1", BonusCardWorkerPower)
+
+	tests := []struct {
+		card BonusCardType
+		want []IncomeWarning
+	}{
+		{BonusCardShipping, []IncomeWarning{{Resource: IncomeWarningPower, Wasted: 1}}},
+		{BonusCardPriest, []IncomeWarning{{Resource: IncomeWarningPriests, Wasted: 1}}},
+		{BonusCard6Coins, nil},
+	}
+	for _, tt := range tests {
+		got, ok := gs.PassIncomeWarnings("player1", tt.card)
+		if !ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PassIncomeWarnings(%v) = %+v, %v; want %+v", tt.card, got, ok, tt.want)
+		}
+	}
+
+	gs.Round = 6
+	if _, ok := gs.PassIncomeWarnings("player1", BonusCardShipping); ok {
+		t.Fatalf("expected no advice in the final round")
+	}
+}
@@ -0,0 +1,250 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lukev/tm_server/internal/models"
+)
+
+// Conservation limits checked by CheckInvariants.
+const (
+	basePowerTokens = 12
+	maxOwnedPriests = 7
+	maxCultPosition = 10
+)
+
+// buildingStock is how many buildings of each type a player owns.
+var buildingStock = map[models.BuildingType]int{
+	models.BuildingDwelling:     8,
+	models.BuildingTradingHouse: 4,
+	models.BuildingTemple:       3,
+	models.BuildingSanctuary:    1,
+	models.BuildingStronghold:   1,
+}
+
+// InvariantError lists the conservation rules a state breaks, with a dump of
+// the state they were checked on.
+type InvariantError struct {
+	Round      int
+	Phase      GamePhase
+	Violations []string
+	Dump       string
+}
+
+func (e *InvariantError) Error() string {
+	return fmt.Sprintf("game invariants violated (round %d, phase %d): %s\n%s",
+		e.Round, e.Phase, strings.Join(e.Violations, "; "), e.Dump)
+}
+
+// CheckInvariants validates conservation rules that no sequence of legal
+// actions can break, so a violation points at an engine bug:
+//   - power bowls are never negative, and players of base-game factions (who
+//     cannot gain power tokens) hold at most 12 tokens;
+//   - nobody has more buildings of a type than the stock holds;
+//   - nobody owns more than 7 priests, counting priests in hand, on cult
+//     action spaces and in the Treasurers' treasury;
+//   - cult positions stay within 0-10 and agree with the cult board;
+//   - every bonus card in play is either available or held by one player.
+//
+// It returns an *InvariantError, or nil when every rule holds.
+func (gs *GameState) CheckInvariants() error {
+	if gs == nil {
+		return nil
+	}
+	var violations []string
+	violate := func(format string, args ...any) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+
+	buildings := gs.invariantBuildingCounts()
+	for _, playerID := range gs.sortedPlayerIDs() {
+		player := gs.Players[playerID]
+		if player == nil {
+			continue
+		}
+		if power := player.Resources.powerOrNil(); power != nil {
+			if power.Bowl1 < 0 || power.Bowl2 < 0 || power.Bowl3 < 0 {
+				violate("%s has negative power (%d/%d/%d)", playerID, power.Bowl1, power.Bowl2, power.Bowl3)
+			}
+			if isBaseGameFaction(player) && power.TotalPower() > basePowerTokens {
+				violate("%s has %d power tokens, more than %d", playerID, power.TotalPower(), basePowerTokens)
+			}
+		}
+		for buildingType, limit := range buildingStock {
+			if count := buildings[playerID][buildingType]; count > limit {
+				violate("%s has %d %v, more than the stock of %d", playerID, count, buildingType, limit)
+			}
+		}
+		if owned := gs.PriestLedger(playerID).Owned(); owned > maxOwnedPriests {
+			violate("%s owns %d priests, more than %d", playerID, owned, maxOwnedPriests)
+		}
+		for _, track := range []CultTrack{CultFire, CultWater, CultEarth, CultAir} {
+			position := player.CultPositions[track]
+			if position < 0 || position > maxCultPosition {
+				violate("%s is at %d on cult track %d", playerID, position, track)
+			}
+			if gs.CultTracks != nil && gs.CultTracks.PlayerPositions[playerID] != nil {
+				if board := gs.CultTracks.PlayerPositions[playerID][track]; board != position {
+					violate("%s is at %d on cult track %d but at %d on the cult board", playerID, position, track, board)
+				}
+			}
+		}
+	}
+	violations = append(violations, gs.bonusCardInvariantViolations()...)
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return &InvariantError{
+		Round:      gs.Round,
+		Phase:      gs.Phase,
+		Violations: violations,
+		Dump:       gs.invariantDump(buildings),
+	}
+}
+
+func (rp *ResourcePool) powerOrNil() *PowerSystem {
+	if rp == nil {
+		return nil
+	}
+	return rp.Power
+}
+
+func isBaseGameFaction(player *Player) bool {
+	if player == nil || player.Faction == nil {
+		return false
+	}
+	factionType := player.Faction.GetType()
+	return !factionType.IsFanFaction() && !factionType.IsFireIceFaction()
+}
+
+func (gs *GameState) sortedPlayerIDs() []string {
+	playerIDs := make([]string, 0, len(gs.Players))
+	for playerID := range gs.Players {
+		playerIDs = append(playerIDs, playerID)
+	}
+	sort.Strings(playerIDs)
+	return playerIDs
+}
+
+func (gs *GameState) invariantBuildingCounts() map[string]map[models.BuildingType]int {
+	counts := make(map[string]map[models.BuildingType]int, len(gs.Players))
+	if gs.Map == nil {
+		return counts
+	}
+	for _, mapHex := range gs.Map.Hexes {
+		if mapHex == nil || mapHex.Building == nil {
+			continue
+		}
+		playerID := mapHex.Building.PlayerID
+		if counts[playerID] == nil {
+			counts[playerID] = make(map[models.BuildingType]int)
+		}
+		counts[playerID][mapHex.Building.Type]++
+	}
+	return counts
+}
+
+// bonusCardInvariantViolations checks that no bonus card is both available
+// and held, or held twice, and that the cards in play number players + 3
+// (one more once Archivists are in the game) after they were dealt.
+func (gs *GameState) bonusCardInvariantViolations() []string {
+	cards := gs.BonusCards
+	if cards == nil {
+		return nil
+	}
+	var violations []string
+	holders := make(map[BonusCardType]string)
+	for _, playerID := range gs.sortedPlayerIDs() {
+		for _, cardType := range cards.GetPlayerCards(playerID) {
+			if other, held := holders[cardType]; held {
+				violations = append(violations, fmt.Sprintf("bonus card %d is held by %s and %s", cardType, other, playerID))
+			}
+			holders[cardType] = playerID
+			if cards.IsAvailable(cardType) {
+				violations = append(violations, fmt.Sprintf("bonus card %d is held by %s and available", cardType, playerID))
+			}
+		}
+	}
+
+	inPlay := len(cards.Available) + len(holders)
+	if inPlay == 0 {
+		return violations
+	}
+	want := len(gs.Players) + 3
+	for _, player := range gs.Players {
+		if player != nil && player.Faction != nil && player.Faction.GetType() == models.FactionArchivists {
+			want++
+			break
+		}
+	}
+	if inPlay != want {
+		violations = append(violations, fmt.Sprintf("%d bonus cards in play, want %d", inPlay, want))
+	}
+	return violations
+}
+
+type invariantPlayerDump struct {
+	Faction        string          `json:"faction,omitempty"`
+	Power          [3]int          `json:"power"`
+	Priests        int             `json:"priests"`
+	PriestsOnCults int             `json:"priestsOnCults"`
+	TreasuryPriest int             `json:"treasuryPriests,omitempty"`
+	Buildings      map[string]int  `json:"buildings"`
+	Cults          [4]int          `json:"cults"`
+	BonusCards     []BonusCardType `json:"bonusCards,omitempty"`
+}
+
+// invariantDump renders the state the invariants are checked on as JSON.
+func (gs *GameState) invariantDump(buildings map[string]map[models.BuildingType]int) string {
+	players := make(map[string]invariantPlayerDump, len(gs.Players))
+	for playerID, player := range gs.Players {
+		if player == nil {
+			continue
+		}
+		dump := invariantPlayerDump{
+			TreasuryPriest: player.TreasuryPriests,
+			Buildings:      make(map[string]int),
+			BonusCards:     gs.BonusCards.GetPlayerCards(playerID),
+		}
+		if player.Faction != nil {
+			dump.Faction = player.Faction.GetType().String()
+		}
+		if player.Resources != nil {
+			dump.Priests = player.Resources.Priests
+		}
+		if power := player.Resources.powerOrNil(); power != nil {
+			dump.Power = [3]int{power.Bowl1, power.Bowl2, power.Bowl3}
+		}
+		if gs.CultTracks != nil {
+			dump.PriestsOnCults = gs.CultTracks.GetTotalPriestsOnCultTracks(playerID)
+		}
+		for buildingType, count := range buildings[playerID] {
+			dump.Buildings[buildingType.String()] = count
+		}
+		for i, track := range []CultTrack{CultFire, CultWater, CultEarth, CultAir} {
+			dump.Cults[i] = player.CultPositions[track]
+		}
+		players[playerID] = dump
+	}
+	var available []BonusCardType
+	if gs.BonusCards != nil {
+		for cardType := range gs.BonusCards.Available {
+			available = append(available, cardType)
+		}
+		sort.Slice(available, func(i, j int) bool { return available[i] < available[j] })
+	}
+	raw, err := json.MarshalIndent(struct {
+		Round               int                            `json:"round"`
+		Phase               GamePhase                      `json:"phase"`
+		Players             map[string]invariantPlayerDump `json:"players"`
+		AvailableBonusCards []BonusCardType                `json:"availableBonusCards"`
+	}{gs.Round, gs.Phase, players, available}, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("state dump failed: %v", err)
+	}
+	return string(raw)
+}
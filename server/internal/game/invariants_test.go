@@ -0,0 +1,86 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/game/factions"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+func TestCheckInvariants_ReportsConservationViolations(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("p1", factions.NewHalflings())
+	gs.AddPlayer("p2", factions.NewWitches())
+	gs.BonusCards.SetAvailableBonusCards([]BonusCardType{
+		BonusCardPriest, BonusCardShipping, BonusCardDwellingVP, BonusCardWorkerPower, BonusCard6Coins,
+	})
+	if err := gs.CheckInvariants(); err != nil {
+		t.Fatalf("expected a fresh game to hold its invariants, got %v", err)
+	}
+
+	p1 := gs.GetPlayer("p1")
+	p1.Resources.Power.Bowl1 += 3
+	p1.Resources.Priests = 8
+	gs.CultTracks.PlayerPositions["p2"][CultFire] = 11
+	gs.GetPlayer("p2").CultPositions[CultFire] = 11
+	if _, err := gs.BonusCards.TakeBonusCard("p1", BonusCardPriest); err != nil {
+		t.Fatalf("take bonus card: %v", err)
+	}
+	gs.BonusCards.Available[BonusCardPriest] = 0
+	for _, hex := range []board.Hex{board.NewHex(0, 0), board.NewHex(1, 0)} {
+		gs.Map.GetHex(hex).Building = &models.Building{Type: models.BuildingStronghold, PlayerID: "p2"}
+	}
+
+	err := gs.CheckInvariants()
+	var invariantErr *InvariantError
+	if !errors.As(err, &invariantErr) {
+		t.Fatalf("expected an InvariantError, got %v", err)
+	}
+	want := []string{
+		"p1 has 15 power tokens, more than 12",
+		"p1 owns 8 priests, more than 7",
+		"p2 has 2 Stronghold, more than the stock of 1",
+		"p2 is at 11 on cult track 0",
+		"is held by p1 and available",
+		"6 bonus cards in play, want 5",
+	}
+	for _, violation := range want {
+		if !strings.Contains(strings.Join(invariantErr.Violations, "\n"), violation) {
+			t.Errorf("expected violation %q, got %q", violation, invariantErr.Violations)
+		}
+	}
+	if !strings.Contains(invariantErr.Dump, `"priests": 8`) {
+		t.Errorf("expected the dump to show the state, got %s", invariantErr.Dump)
+	}
+}
+
+func TestManagerCheckInvariants_RejectsActionAndRestoresState(t *testing.T) {
+	mgr := NewManager()
+	mgr.SetCheckInvariants(true)
+	if err := mgr.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	gs, _ := mgr.GetGame("g1")
+	first := gs.TurnOrder[0]
+	second := gs.TurnOrder[1]
+	if _, err := mgr.ExecuteActionWithMeta("g1", &SelectFactionAction{PlayerID: first, FactionType: models.FactionWitches}, ActionMeta{ExpectedRevision: -1}); err != nil {
+		t.Fatalf("select faction: %v", err)
+	}
+
+	gs.GetPlayer(first).Resources.Priests = 8
+	_, err := mgr.ExecuteActionWithMeta("g1", &SelectFactionAction{PlayerID: second, FactionType: models.FactionNomads}, ActionMeta{ExpectedRevision: -1})
+	var invariantErr *InvariantError
+	if !errors.As(err, &invariantErr) {
+		t.Fatalf("expected the action to fail the invariant check, got %v", err)
+	}
+	if revision, _ := mgr.GetRevision("g1"); revision != 1 {
+		t.Fatalf("expected the failed action not to bump the revision, got %d", revision)
+	}
+	restored, _ := mgr.GetGame("g1")
+	if restored.GetPlayer(second).Faction != nil {
+		t.Fatalf("expected the game to be restored to before the failed action")
+	}
+}
@@ -0,0 +1,141 @@
+package game
+
+import "fmt"
+
+// LeechPreferences are a player's standing answers to leech offers, kept
+// across their games. The zero value answers nothing.
+type LeechPreferences struct {
+	// AcceptUpTo accepts offers of at most this much power; 0 disables it.
+	AcceptUpTo int `json:"acceptUpTo"`
+	// DeclineFromVPCost declines offers costing at least this many VP while
+	// the player has more than DeclineAboveVP; 0 disables it.
+	DeclineFromVPCost int `json:"declineFromVpCost"`
+	DeclineAboveVP    int `json:"declineAboveVp"`
+}
+
+func (p LeechPreferences) Validate() error {
+	if p.AcceptUpTo < 0 || p.DeclineFromVPCost < 0 || p.DeclineAboveVP < 0 {
+		return fmt.Errorf("leech preferences cannot be negative")
+	}
+	return nil
+}
+
+// decide returns the preferred answer to an offer of amount power costing
+// vpCost to a player with vp. Declining wins when both preferences match.
+func (p LeechPreferences) decide(amount, vpCost, vp int) (accept bool, rule AutoLeechRule) {
+	if p.DeclineFromVPCost > 0 && vpCost >= p.DeclineFromVPCost && vp > p.DeclineAboveVP {
+		return false, AutoLeechRuleDeclineVPCost
+	}
+	if p.AcceptUpTo > 0 && amount <= p.AcceptUpTo {
+		return true, AutoLeechRuleAcceptUpTo
+	}
+	return false, ""
+}
+
+// AutoLeechRule names what answered a leech offer without the player.
+type AutoLeechRule string
+
+const (
+	// AutoLeechRuleMode is the player's auto-leech mode option.
+	AutoLeechRuleMode AutoLeechRule = "auto_leech_mode"
+	// AutoLeechRuleIncomeSaturates declines for a passed player whose income
+	// fills bowl III anyway.
+	AutoLeechRuleIncomeSaturates AutoLeechRule = "income_saturates"
+	// AutoLeechRuleAcceptUpTo and AutoLeechRuleDeclineVPCost are the
+	// player's LeechPreferences.
+	AutoLeechRuleAcceptUpTo    AutoLeechRule = "accept_up_to"
+	AutoLeechRuleDeclineVPCost AutoLeechRule = "decline_vp_cost"
+)
+
+// AutoLeechDecision is a leech offer the engine answered for a player. The
+// game state keeps those of its latest action, the consequences recorded with
+// each action keep them for good, and action results report them.
+type AutoLeechDecision struct {
+	PlayerID     string        `json:"playerId"`
+	FromPlayerID string        `json:"fromPlayerId"`
+	Amount       int           `json:"amount"`
+	Accepted     bool          `json:"accepted"`
+	VPCost       int           `json:"vpCost"`
+	Rule         AutoLeechRule `json:"rule"`
+	Round        int           `json:"round"`
+}
+
+func (gs *GameState) recordAutoLeechDecision(decision AutoLeechDecision) {
+	gs.AutoLeechDecisions = append(gs.AutoLeechDecisions, decision)
+	gs.newAutoLeechDecisions = append(gs.newAutoLeechDecisions, decision)
+}
+
+// TakeAutoLeechDecisions returns the auto-leech decisions since the last call
+// and forgets them. The game's log keeps them.
+func (gs *GameState) TakeAutoLeechDecisions() []AutoLeechDecision {
+	decisions := gs.newAutoLeechDecisions
+	gs.newAutoLeechDecisions = nil
+	return decisions
+}
+
+// SetLeechPreferences stores playerID's standing leech preferences. The
+// engine applies them in every game the player is seated in when an offer
+// matches, unless the player set preferences in that game; the action that
+// made the offer records the preferences it used, so exports replay the same
+// answers. Games are not changed until then.
+func (m *Manager) SetLeechPreferences(playerID string, prefs LeechPreferences) error {
+	if err := prefs.Validate(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	account := m.accountPreferences[playerID]
+	account.Leech = prefs
+	m.accountPreferences[playerID] = account
+	m.mu.Unlock()
+	return m.saveAccountPreferences()
+}
+
+// LeechPreferences returns playerID's standing leech preferences.
+func (m *Manager) LeechPreferences(playerID string) LeechPreferences {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.accountPreferences[playerID].Leech
+}
+
+// lendLeechPreferences makes the account preferences available to the
+// action about to run on gs: those recorded with it when it is replayed,
+// otherwise the stored ones of the game's players. The returned func takes
+// them back and returns those the action used.
+func (m *Manager) lendLeechPreferences(gs *GameState, record *RecordedAction) func() map[string]LeechPreferences {
+	lent := map[string]LeechPreferences{}
+	if record != nil && record.LeechPreferences != nil {
+		lent = record.LeechPreferences
+	} else {
+		for playerID := range gs.Players {
+			if account, ok := m.accountPreferences[playerID]; ok && account.Leech != (LeechPreferences{}) {
+				lent[playerID] = account.Leech
+			}
+		}
+	}
+	previousLent, previousUsed := gs.accountLeechPreferences, gs.usedLeechPreferences
+	gs.accountLeechPreferences, gs.usedLeechPreferences = lent, nil
+	return func() map[string]LeechPreferences {
+		used := gs.usedLeechPreferences
+		gs.accountLeechPreferences, gs.usedLeechPreferences = previousLent, previousUsed
+		return used
+	}
+}
+
+// leechPreferencesFor returns the preferences answering playerID's offers:
+// those set in the game, or else the account's lent by the Manager.
+func (gs *GameState) leechPreferencesFor(player *Player) (LeechPreferences, bool) {
+	if player.Options.LeechPreferences != (LeechPreferences{}) {
+		return player.Options.LeechPreferences, false
+	}
+	prefs, ok := gs.accountLeechPreferences[player.ID]
+	return prefs, ok
+}
+
+// noteLeechPreferencesUsed records that the account preferences of playerID
+// answered an offer.
+func (gs *GameState) noteLeechPreferencesUsed(playerID string, prefs LeechPreferences) {
+	if gs.usedLeechPreferences == nil {
+		gs.usedLeechPreferences = map[string]LeechPreferences{}
+	}
+	gs.usedLeechPreferences[playerID] = prefs
+}
@@ -0,0 +1,158 @@
+package game
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/factions"
+)
+
+func TestResolveAutoLeechOffers_LeechPreferences(t *testing.T) {
+	tests := []struct {
+		name       string
+		prefs      LeechPreferences
+		mode       LeechAutoMode
+		amount     int
+		vp         int
+		wantAuto   bool
+		wantAccept bool
+		wantRule   AutoLeechRule
+	}{
+		{name: "accepts small offers", prefs: LeechPreferences{AcceptUpTo: 1}, amount: 1, vp: 20, wantAuto: true, wantAccept: true, wantRule: AutoLeechRuleAcceptUpTo},
+		{name: "leaves larger offers", prefs: LeechPreferences{AcceptUpTo: 1}, amount: 2, vp: 20},
+		{name: "declines costly offers when ahead", prefs: LeechPreferences{DeclineFromVPCost: 2, DeclineAboveVP: 50}, amount: 3, vp: 60, wantAuto: true, wantRule: AutoLeechRuleDeclineVPCost},
+		{name: "keeps costly offers when behind", prefs: LeechPreferences{DeclineFromVPCost: 2, DeclineAboveVP: 50}, amount: 3, vp: 40},
+		{name: "decline wins over accept", prefs: LeechPreferences{AcceptUpTo: 4, DeclineFromVPCost: 2}, amount: 3, vp: 20, wantAuto: true, wantRule: AutoLeechRuleDeclineVPCost},
+		{name: "mode answers what preferences leave", prefs: LeechPreferences{AcceptUpTo: 1}, mode: LeechAutoModeAccept2, amount: 2, vp: 20, wantAuto: true, wantAccept: true, wantRule: AutoLeechRuleMode},
+		{name: "never accepts what the player cannot pay", prefs: LeechPreferences{AcceptUpTo: 3}, amount: 3, vp: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := NewGameState()
+			mustAddPlayer(t, gs, "src", factions.NewNomads())
+			mustAddPlayer(t, gs, "dst", factions.NewAuren())
+			gs.TurnOrder = []string{"src", "dst"}
+			gs.Round = 2
+
+			dst := gs.GetPlayer("dst")
+			dst.Options.AutoLeechMode = tt.mode
+			dst.Options.LeechPreferences = tt.prefs
+			dst.Resources.Power = NewPowerSystem(0, 4, 0)
+			dst.VictoryPoints = tt.vp
+			gs.PendingLeechOffers["dst"] = []*PowerLeechOffer{{Amount: tt.amount, FromPlayerID: "src", EventID: 1}}
+
+			if err := gs.ResolveAutoLeechOffers(); err != nil {
+				t.Fatalf("resolve auto leech: %v", err)
+			}
+			decisions := gs.TakeAutoLeechDecisions()
+			if !tt.wantAuto {
+				if len(decisions) != 0 || len(gs.PendingLeechOffers["dst"]) != 1 {
+					t.Fatalf("expected the offer to wait, got decisions %+v", decisions)
+				}
+				return
+			}
+			want := AutoLeechDecision{
+				PlayerID:     "dst",
+				FromPlayerID: "src",
+				Amount:       tt.amount,
+				Accepted:     tt.wantAccept,
+				VPCost:       tt.amount - 1,
+				Rule:         tt.wantRule,
+				Round:        2,
+			}
+			if len(decisions) != 1 || decisions[0] != want {
+				t.Fatalf("expected %+v, got %+v", want, decisions)
+			}
+			if len(gs.AutoLeechDecisions) != 1 || len(gs.TakeAutoLeechDecisions()) != 0 {
+				t.Fatalf("expected the decision to stay in the log only, got %+v", gs.AutoLeechDecisions)
+			}
+		})
+	}
+}
+
+func newLeechPreferencesTestGame(t *testing.T) *GameState {
+	t.Helper()
+	gs := NewGameState()
+	mustAddPlayer(t, gs, "src", factions.NewNomads())
+	mustAddPlayer(t, gs, "dst1", factions.NewAuren())
+	mustAddPlayer(t, gs, "dst2", factions.NewGiants())
+	gs.Phase = PhaseAction
+	gs.TurnOrder = []string{"src", "dst1", "dst2"}
+	gs.PendingLeechOffers["dst1"] = []*PowerLeechOffer{{Amount: 2, VPCost: 1, FromPlayerID: "src", EventID: 1}}
+	gs.PendingLeechOffers["dst2"] = []*PowerLeechOffer{{Amount: 1, FromPlayerID: "src", EventID: 1}}
+	return gs
+}
+
+func TestSetLeechPreferences_AppliesWhenAnOfferMatches(t *testing.T) {
+	mgr := NewManager()
+	gs := newLeechPreferencesTestGame(t)
+	mgr.CreateGameWithState("g1", gs)
+
+	prefs := LeechPreferences{AcceptUpTo: 1}
+	if err := mgr.SetLeechPreferences("dst2", prefs); err != nil {
+		t.Fatalf("set leech preferences: %v", err)
+	}
+	if got := mgr.LeechPreferences("dst2"); got != prefs {
+		t.Fatalf("expected stored preferences, got %+v", got)
+	}
+	if revision, _ := mgr.GetRevision("g1"); revision != 0 || len(gs.PendingLeechOffers["dst2"]) != 1 {
+		t.Fatalf("expected the game to be left alone until an offer matches, got revision %d", revision)
+	}
+
+	decline := RecordedAction{PlayerID: "dst1", Type: "decline_leech", Params: []byte(`{"offerIndex":0}`)}
+	result, err := mgr.ExecuteActionWithMeta("g1", NewDeclinePowerLeechAction("dst1", 0), ActionMeta{
+		ExpectedRevision: -1,
+		SeatID:           "dst1",
+		Record:           &decline,
+	})
+	if err != nil {
+		t.Fatalf("decline: %v", err)
+	}
+	if len(result.AutoLeechDecisions) != 1 || result.AutoLeechDecisions[0].Rule != AutoLeechRuleAcceptUpTo {
+		t.Fatalf("expected dst2's preferences to accept the offer, got %+v", result.AutoLeechDecisions)
+	}
+	if gs.GetPlayer("dst2").Options.LeechPreferences != (LeechPreferences{}) {
+		t.Fatalf("expected the game options to stay unset")
+	}
+	recorded := mgr.history["g1"][0]
+	if recorded.LeechPreferences["dst2"] != prefs || len(recorded.Consequences.AutoLeech) != 1 {
+		t.Fatalf("expected the used preferences and decision to be recorded, got %+v", recorded)
+	}
+
+	// A replay without the account preferences answers the same way.
+	replayed := NewManager()
+	replayedState := newLeechPreferencesTestGame(t)
+	replayed.CreateGameWithState("g1", replayedState)
+	result, err = replayed.ExecuteActionWithMeta("g1", NewDeclinePowerLeechAction("dst1", 0), ActionMeta{
+		ExpectedRevision: -1,
+		SeatID:           "dst1",
+		Record:           &recorded,
+	})
+	if err != nil || len(result.AutoLeechDecisions) != 1 || len(replayedState.PendingLeechOffers["dst2"]) != 0 {
+		t.Fatalf("expected the replay to accept dst2's offer, got %+v (%v)", result, err)
+	}
+
+	if err := mgr.SetLeechPreferences("p1", LeechPreferences{AcceptUpTo: -1}); err == nil {
+		t.Fatalf("expected negative preferences to be rejected")
+	}
+}
+
+func TestSetLeechPreferences_StoreKeepsPreferencesAcrossManagers(t *testing.T) {
+	store := NewFileAccountPreferenceStore(filepath.Join(t.TempDir(), "prefs", "accounts.json"))
+	mgr := NewManager()
+	if err := mgr.SetAccountPreferenceStore(store); err != nil {
+		t.Fatalf("set store: %v", err)
+	}
+	prefs := LeechPreferences{DeclineFromVPCost: 2, DeclineAboveVP: 80}
+	if err := mgr.SetLeechPreferences("p1", prefs); err != nil {
+		t.Fatalf("set leech preferences: %v", err)
+	}
+
+	restarted := NewManager()
+	if err := restarted.SetAccountPreferenceStore(store); err != nil {
+		t.Fatalf("set store: %v", err)
+	}
+	if got := restarted.LeechPreferences("p1"); got != prefs {
+		t.Fatalf("expected preferences to survive a restart, got %+v", got)
+	}
+}
@@ -0,0 +1,48 @@
+package game
+
+// LeechWait is who a game waits on while leech offers hold up the next turn:
+// the players yet to answer, in the order they are asked, and the player whose
+// turn comes once they have. Clients show it instead of a board that seems
+// frozen.
+type LeechWait struct {
+	PlayerIDs []string `json:"playerIds"`
+	// NextPlayerID is empty when the round ends after the leeches.
+	NextPlayerID string `json:"nextPlayerId,omitempty"`
+}
+
+// LeechWait returns the leech responders blocking the next turn, or nil when
+// no offer blocks it. Passed players answer offers too, but do not block.
+func (gs *GameState) LeechWait() *LeechWait {
+	if gs == nil || !gs.HasBlockingPendingLeechOffers() || len(gs.TurnOrder) == 0 {
+		return nil
+	}
+	wait := &LeechWait{NextPlayerID: gs.nextTurnPlayerID()}
+	// Same order as GetNextBlockingLeechResponder: from the player after the
+	// one on turn, around the table.
+	for i := 1; i <= len(gs.TurnOrder); i++ {
+		playerID := gs.TurnOrder[(max(gs.CurrentPlayerIndex, 0)+i)%len(gs.TurnOrder)]
+		if len(gs.PendingLeechOffers[playerID]) > 0 && gs.isBlockingLeechResponder(playerID) {
+			wait.PlayerIDs = append(wait.PlayerIDs, playerID)
+		}
+	}
+	if len(wait.PlayerIDs) == 0 {
+		return nil
+	}
+	return wait
+}
+
+// nextTurnPlayerID is the player NextTurn would hand the turn to once the
+// leeches are answered, without advancing it.
+func (gs *GameState) nextTurnPlayerID() string {
+	current := gs.GetCurrentPlayer()
+	if current != nil && (gs.SuppressTurnAdvance || gs.hasPendingFollowups(current.ID)) {
+		return current.ID
+	}
+	for i := 1; i <= len(gs.TurnOrder); i++ {
+		playerID := gs.TurnOrder[(max(gs.CurrentPlayerIndex, 0)+i)%len(gs.TurnOrder)]
+		if player := gs.GetPlayer(playerID); player != nil && !player.HasPassed {
+			return playerID
+		}
+	}
+	return ""
+}
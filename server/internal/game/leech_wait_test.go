@@ -0,0 +1,51 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/factions"
+)
+
+func TestLeechWait_ListsBlockingRespondersAndNextPlayer(t *testing.T) {
+	gs := NewGameState()
+	mustAddPlayer(t, gs, "p1", factions.NewNomads())
+	mustAddPlayer(t, gs, "p2", factions.NewMermaids())
+	mustAddPlayer(t, gs, "p3", factions.NewAuren())
+	mustAddPlayer(t, gs, "p4", factions.NewHalflings())
+	gs.Phase = PhaseAction
+	gs.TurnOrder = []string{"p1", "p2", "p3", "p4"}
+	gs.CurrentPlayerIndex = 2
+
+	if wait := gs.LeechWait(); wait != nil {
+		t.Fatalf("expected no wait without offers, got %+v", wait)
+	}
+
+	gs.PendingLeechOffers["p1"] = []*PowerLeechOffer{{Amount: 1, FromPlayerID: "p3"}}
+	gs.PendingLeechOffers["p2"] = []*PowerLeechOffer{{Amount: 2, FromPlayerID: "p3"}}
+	gs.PendingLeechOffers["p4"] = []*PowerLeechOffer{{Amount: 1, FromPlayerID: "p3"}}
+	gs.GetPlayer("p4").HasPassed = true
+
+	wait := gs.LeechWait()
+	if wait == nil {
+		t.Fatalf("expected a wait")
+	}
+	// p4 has passed and does not block; p1 is asked before p2.
+	if len(wait.PlayerIDs) != 2 || wait.PlayerIDs[0] != "p1" || wait.PlayerIDs[1] != "p2" {
+		t.Fatalf("unexpected responders %v", wait.PlayerIDs)
+	}
+	if wait.NextPlayerID != "p1" {
+		t.Fatalf("next player = %q, want p1", wait.NextPlayerID)
+	}
+
+	gs.PendingFavorTileSelection = &PendingFavorTileSelection{PlayerID: "p3", Count: 1}
+	if wait := gs.LeechWait(); wait == nil || wait.NextPlayerID != "p3" {
+		t.Fatalf("expected p3 to keep the turn for its favor tile, got %+v", wait)
+	}
+	gs.PendingFavorTileSelection = nil
+
+	gs.PendingLeechOffers["p1"] = nil
+	gs.PendingLeechOffers["p2"] = nil
+	if wait := gs.LeechWait(); wait != nil {
+		t.Fatalf("expected offers to passed players not to block, got %+v", wait)
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/game/factions"
 	"github.com/lukev/tm_server/internal/models"
 )
 
@@ -22,6 +25,36 @@ type CreateGameOptions struct {
 	EnableFireIceFactions bool
 	FireIceScoring        FireIceFinalScoringSetting
 	CustomMap             *board.CustomMapDefinition
+	// HiddenResources enables the fog-of-resources variant: opponents' coins
+	// and power bowls are withheld from each player until the opponent passes.
+	HiddenResources bool
+	// Hotseat lets one connection act for every seat, for local play and for
+	// reproducing bug reports by hand.
+	Hotseat bool
+	// PublicSummary serves the game's summary to anyone once it has finished:
+	// every seat agrees to it by joining. Seated players can withdraw or give
+	// their own consent later with SetPublicSummary.
+	PublicSummary bool
+	// PublicSummaryConsent lists the seats that agreed to a public summary
+	// when PublicSummary is unset. The summary goes public once all have.
+	PublicSummaryConsent []string
+	// Handicaps gives the listed players extra starting VP, workers and coins.
+	Handicaps map[string]Handicap
+	// TurnOrderPolicy decides how a round's passes set the next round's turn
+	// order. Empty means TurnOrderPolicyPassOrder, the variable turn order.
+	TurnOrderPolicy TurnOrderPolicy
+	// RemovedBonusCards are bonus cards the creator takes out of the game.
+	// Further cards are removed at random until player count + 3 remain.
+	RemovedBonusCards []BonusCardType
+	// Seed fixes the setup randomness (scoring tiles, bonus cards, turn order,
+	// Fire & Ice tile). When nil, the manager draws a fresh seed.
+	Seed *int64
+	// Draws, when set, are used instead of the draws from the seed. Exports of
+	// running games carry them, as their seed stays secret.
+	Draws *SetupDraws
+	// SpeedPreset configures the turn timer, unless TurnTimer is set, the
+	// players' auto-leech policy and turn reminders.
+	SpeedPreset SpeedPreset
 }
 
 // ActionMeta provides metadata for action execution.
@@ -30,12 +63,43 @@ type ActionMeta struct {
 	ExpectedRevision       int
 	SeatID                 string
 	AllowAZAutoConversions bool
+	// Record is the wire form of the action, appended to the game's exportable
+	// history when the action is accepted. Actions without one make the game
+	// unexportable.
+	Record *RecordedAction
+	// skipTurnValidation lets ApplyConversionWithoutTurnCheck replay
+	// conversions logged outside their player's turn.
+	skipTurnValidation bool
+	// skipUndoSnapshot spares the copy of the state kept to undo or roll back
+	// the action. Only for games that are never undone, checked or recorded,
+	// such as playouts.
+	skipUndoSnapshot bool
 }
 
 // ActionResult reports action execution outcome.
 type ActionResult struct {
 	Revision  int
 	Duplicate bool
+	// GameEnded reports that this action moved the game to PhaseEnd.
+	GameEnded bool
+	// PhaseChanges lists the phase transitions the action caused, in order.
+	PhaseChanges []PhaseChange
+	// PowerGains lists the power the action made players gain, in order.
+	PowerGains []PowerGainEvent
+	// AutoLeechDecisions lists the leech offers the action's follow-ups
+	// answered for players, in order.
+	AutoLeechDecisions []AutoLeechDecision
+	// IncomeReports lists the income granted if the action started a round.
+	IncomeReports []IncomeReport
+	// Consequences are the VP, leech offers and towns the action caused.
+	Consequences *ActionConsequences
+}
+
+// GameActionResults are the results of the actions the server played in one
+// game on its players' behalf, in the order it played them.
+type GameActionResults struct {
+	GameID  string
+	Results []*ActionResult
 }
 
 // RevisionMismatchError indicates stale optimistic concurrency data.
@@ -55,56 +119,219 @@ type Manager struct {
 	revisions       map[string]int
 	appliedActionID map[string]map[string]int
 	now             func() time.Time
+	newSeed         func() int64
+	setups          map[string]gameSetup
+	history         map[string][]RecordedAction
+	unrecorded      map[string]bool
+	lastActivity    map[string]time.Time
+	// archivePolicy, when set, moves finished games to an archive; see
+	// SweepFinishedGames.
+	archivePolicy    *ArchivePolicy
+	archivedRevision map[string]int
+	// archiveFailed is the revision a game failed to archive at, so sweeps
+	// retry it only once it changes.
+	archiveFailed map[string]int
+	// archiveMisses remembers lookups the archive could not serve: a nil
+	// error for games it does not have, or the error loading them back.
+	archiveMisses map[string]error
+	// mustPass, when set, detects players left with nothing to do but pass;
+	// see SetMustPassDetector.
+	mustPass MustPassDetector
+	// vacationPolicy, when set, lets players pause their clocks in all their
+	// games; see SetVacation.
+	vacationPolicy *VacationPolicy
+	vacations      map[string]*VacationRecord
+	vacationSaveMu sync.Mutex
+	// reminders tracks how long games with a speed preset have waited on
+	// each player; see SweepSpeedPresets.
+	reminders map[string]map[string]*reminderClock
+	// accountPreferences are each player's standing preferences across
+	// their games; see SetLeechPreferences and SetConfirmationPreferences.
+	accountPreferences      map[string]AccountPreferences
+	accountPreferenceStore  AccountPreferenceStore
+	accountPreferenceSaveMu sync.Mutex
+	// annotations are each game's board annotations, kept in memory only;
+	// see AddAnnotation.
+	annotations   map[string][]BoardAnnotation
+	annotationSeq map[string]int
+	// checkInvariants validates the game invariants after every action; see
+	// SetCheckInvariants.
+	checkInvariants bool
+	// consistencyBuild, when set, replays recorded actions in shadow games
+	// and compares them with the live state; see SetConsistencyCheck.
+	consistencyBuild RecordedActionBuilder
+	shadows          map[string]*Manager
+	// eventListener, when set, is told about turn changes, passes, towns and
+	// game ends; eventTurn is the player it was last told is on turn in each
+	// game. See SetEventListener.
+	eventListener GameEventListener
+	eventTurn     map[string]string
+	// spadeTargets caches the pending spade targets of each game at one
+	// revision, so state broadcasts do not enumerate them again; see
+	// spadeTargetsLocked. Readers holding m.mu.RLock fill it, hence its own
+	// lock.
+	spadeTargetsMu sync.Mutex
+	spadeTargets   map[string]cachedSpadeTargets
 }
 
 // NewManager creates a new game manager.
 func NewManager() *Manager {
 	return &Manager{
-		games:           make(map[string]*GameState),
-		revisions:       make(map[string]int),
-		appliedActionID: make(map[string]map[string]int),
-		now:             time.Now,
+		games:              make(map[string]*GameState),
+		revisions:          make(map[string]int),
+		appliedActionID:    make(map[string]map[string]int),
+		now:                time.Now,
+		newSeed:            func() int64 { return time.Now().UnixNano() },
+		setups:             make(map[string]gameSetup),
+		history:            make(map[string][]RecordedAction),
+		unrecorded:         make(map[string]bool),
+		lastActivity:       make(map[string]time.Time),
+		archivedRevision:   make(map[string]int),
+		archiveFailed:      make(map[string]int),
+		archiveMisses:      make(map[string]error),
+		vacations:          make(map[string]*VacationRecord),
+		reminders:          make(map[string]map[string]*reminderClock),
+		accountPreferences: make(map[string]AccountPreferences),
+		annotations:        make(map[string][]BoardAnnotation),
+		annotationSeq:      make(map[string]int),
+		eventTurn:          make(map[string]string),
+		spadeTargets:       make(map[string]cachedSpadeTargets),
 	}
 }
 
+// SetSeedSource overrides how seeds are drawn for games created without an
+// explicit CreateGameOptions.Seed. Intended for tests.
+func (m *Manager) SetSeedSource(newSeed func() int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.newSeed = newSeed
+}
+
+// SetCheckInvariants enables a debug mode that runs GameState.CheckInvariants
+// after every action. An action that breaks an invariant fails with the
+// *InvariantError and the game is restored to its state before the action.
+func (m *Manager) SetCheckInvariants(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checkInvariants = enabled
+}
+
 // CreateGameWithState creates a game with an existing GameState.
 func (m *Manager) CreateGameWithState(id string, gs *GameState) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	if gs != nil && gs.TurnTimer != nil {
-		gs.TurnTimer.SyncActivePlayers(activeDecisionPlayerIDs(gs), m.now())
-	}
+	m.syncTurnTimerLocked(gs, m.now())
 	m.games[id] = gs
 	m.revisions[id] = 0
 	m.appliedActionID[id] = make(map[string]int)
+	delete(m.setups, id)
+	delete(m.history, id)
+	delete(m.unrecorded, id)
+	delete(m.archivedRevision, id)
+	delete(m.archiveFailed, id)
+	m.lastActivity[id] = m.now()
 }
 
-// GetGame retrieves a game by ID.
+// GetGame retrieves a game by ID, loading it back from the archive if it was
+// evicted.
 func (m *Manager) GetGame(id string) (*GameState, bool) {
+	m.rehydrate(id)
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	g, ok := m.games[id]
 	return g, ok
 }
 
+// IsHotseat reports whether gameID is a hotseat game, in which one
+// connection acts for every seat.
+func (m *Manager) IsHotseat(id string) bool {
+	m.rehydrate(id)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	gs := m.games[id]
+	return gs != nil && gs.Hotseat
+}
+
+// HistoryHidden reports whether gameID's history must not be served: in a
+// hidden-resources game it would reveal every player's resources, so it stays
+// private until the game ends.
+func (m *Manager) HistoryHidden(id string) bool {
+	m.rehydrate(id)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	gs := m.games[id]
+	return gs != nil && gs.HiddenResources && gs.Phase != PhaseEnd
+}
+
 // GetGameSnapshot returns a state clone and its matching revision atomically.
 func (m *Manager) GetGameSnapshot(id string) (*GameState, int, bool) {
+	m.rehydrate(id)
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	gs, ok := m.games[id]
 	if !ok || gs == nil {
 		return nil, 0, false
 	}
+	return cloneWithTurnConfirmation(gs), m.revisions[id], true
+}
+
+// cloneWithTurnConfirmation is CloneForUndo that also keeps the pending turn
+// confirmation, so the clone accepts exactly the actions the original would.
+func cloneWithTurnConfirmation(gs *GameState) *GameState {
 	clone := gs.CloneForUndo()
 	clone.PendingTurnConfirmationPlayerID = gs.PendingTurnConfirmationPlayerID
 	if gs.PendingTurnConfirmationSnapshot != nil {
 		clone.PendingTurnConfirmationSnapshot = gs.PendingTurnConfirmationSnapshot.CloneForUndo()
 	}
-	return clone, m.revisions[id], true
+	return clone
+}
+
+// ActionValidationError explains why ValidateAction rejected an action. Stage
+// is "turn" when the action is out of turn or blocked by a pending decision,
+// and "rules" when the action itself is illegal.
+type ActionValidationError struct {
+	Stage string
+	Err   error
+}
+
+func (e *ActionValidationError) Error() string {
+	return fmt.Sprintf("%s validation failed: %v", e.Stage, e.Err)
+}
+
+func (e *ActionValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateAction reports whether action would be accepted by
+// ExecuteActionWithMeta right now, without changing the game. Rejections are
+// returned as *ActionValidationError.
+func (m *Manager) ValidateAction(gameID string, action Action, meta ActionMeta) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	gs := m.games[gameID]
+	if gs == nil {
+		return fmt.Errorf("game %s not found", gameID)
+	}
+	// Validate against a clone: expiring a free-action window mutates state and
+	// some validators normalize their inputs.
+	clone := cloneWithTurnConfirmation(gs)
+	restoreAutoConversions := setScopedAZAutoConversions(clone, meta.AllowAZAutoConversions)
+	defer restoreAutoConversions()
+
+	if err := validateActionTurnAndPendingState(clone, action); err != nil {
+		return &ActionValidationError{Stage: "turn", Err: err}
+	}
+	maybeExpirePendingFreeActionsWindow(clone, action)
+	if err := action.Validate(clone); err != nil {
+		return &ActionValidationError{Stage: "rules", Err: err}
+	}
+	return nil
 }
 
 // GetRevision returns the current revision for a game.
 func (m *Manager) GetRevision(id string) (int, bool) {
+	m.rehydrate(id)
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	_, ok := m.games[id]
@@ -130,6 +357,8 @@ func (m *Manager) ApplyFixtureSettings(gameID string, scoringTiles []ScoringTile
 	if turnOrderPolicy != "" {
 		gs.TurnOrderPolicy = turnOrderPolicy
 	}
+	m.unrecorded[gameID] = true
+	m.lastActivity[gameID] = m.now()
 
 	nextRevision := m.revisions[gameID] + 1
 	m.revisions[gameID] = nextRevision
@@ -139,44 +368,19 @@ func (m *Manager) ApplyFixtureSettings(gameID string, scoringTiles []ScoringTile
 // ApplyConversionWithoutTurnCheck applies a conversion action for test replay and
 // UI automation paths without requiring player turn ownership.
 func (m *Manager) ApplyConversionWithoutTurnCheck(gameID, playerID string, conversionType ConversionType, amount int) (int, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	gs := m.games[gameID]
-	if gs == nil {
-		return 0, fmt.Errorf("game %s not found", gameID)
-	}
-
 	action := &ConversionAction{
 		BaseAction:     BaseAction{Type: ActionConversion, PlayerID: playerID},
 		ConversionType: conversionType,
 		Amount:         amount,
 	}
-	player := gs.GetPlayer(playerID)
-	beforeCoins, beforeWorkers, beforePriests := 0, 0, 0
-	if player != nil && player.Resources != nil {
-		beforeCoins = player.Resources.Coins
-		beforeWorkers = player.Resources.Workers
-		beforePriests = player.Resources.Priests
-	}
-
-	if err := action.Validate(gs); err != nil {
-		return 0, fmt.Errorf("conversion validation failed: %w", err)
-	}
-
-	if err := action.Execute(gs); err != nil {
-		return 0, fmt.Errorf("conversion execution failed: %w", err)
-	}
-
-	if err := gs.ResolveAutoLeechOffers(); err != nil {
-		return 0, fmt.Errorf("auto leech resolution failed: %w", err)
+	result, err := m.ExecuteActionWithMeta(gameID, action, ActionMeta{
+		ExpectedRevision:   -1,
+		skipTurnValidation: true,
+	})
+	if err != nil {
+		return 0, err
 	}
-	maybeQueueTreasurersDepositAfterAction(gs, action, beforeCoins, beforeWorkers, beforePriests)
-
-	currentRevision := m.revisions[gameID]
-	nextRevision := currentRevision + 1
-	m.revisions[gameID] = nextRevision
-	return nextRevision, nil
+	return result.Revision, nil
 }
 
 // ListGames returns all active games.
@@ -190,6 +394,43 @@ func (m *Manager) ListGames() []*GameState {
 	return out
 }
 
+// PlayerGame summarizes a game a player is seated in.
+type PlayerGame struct {
+	GameID   string    `json:"gameId"`
+	Phase    GamePhase `json:"phase"`
+	Round    int       `json:"round"`
+	Revision int       `json:"revision"`
+	// ActivePlayers are the players the game is waiting on.
+	ActivePlayers []string `json:"activePlayers"`
+	YourTurn      bool     `json:"yourTurn"`
+	Ended         bool     `json:"ended"`
+}
+
+// PlayerGames returns the games in memory that playerID is seated in, sorted
+// by game ID.
+func (m *Manager) PlayerGames(playerID string) []PlayerGame {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var out []PlayerGame
+	for id, gs := range m.games {
+		if gs == nil || gs.GetPlayer(playerID) == nil {
+			continue
+		}
+		active := gs.Phases().ActivePlayers()
+		out = append(out, PlayerGame{
+			GameID:        id,
+			Phase:         gs.Phase,
+			Round:         gs.Round,
+			Revision:      m.revisions[id],
+			ActivePlayers: active,
+			YourTurn:      slices.Contains(active, playerID),
+			Ended:         gs.Phase == PhaseEnd,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].GameID < out[j].GameID })
+	return out
+}
+
 // ExecuteAction executes an action in the specified game (legacy API).
 func (m *Manager) ExecuteAction(gameID string, action Action) error {
 	_, err := m.ExecuteActionWithMeta(gameID, action, ActionMeta{ExpectedRevision: -1})
@@ -200,7 +441,38 @@ func (m *Manager) ExecuteAction(gameID string, action Action) error {
 func (m *Manager) ExecuteActionWithMeta(gameID string, action Action, meta ActionMeta) (*ActionResult, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	return m.executeDrainedLocked(gameID, action, meta)
+}
 
+// executeDrainedLocked runs executeActionLocked and moves the phase changes,
+// power gains, auto-leech decisions and income reports the action caused into
+// its result. Actions the server plays on its own go through it too, so their
+// events can be broadcast like those of client actions.
+func (m *Manager) executeDrainedLocked(gameID string, action Action, meta ActionMeta) (*ActionResult, error) {
+	gs := m.games[gameID]
+	if gs != nil {
+		gs.Phases().TakeChanges()
+		gs.TakePowerGains()
+		gs.TakeAutoLeechDecisions()
+		gs.TakeIncomeReports()
+	}
+	result, err := m.executeActionLocked(gameID, action, meta)
+	if current := m.games[gameID]; current != nil {
+		changes := current.Phases().TakeChanges()
+		gains := current.TakePowerGains()
+		decisions := current.TakeAutoLeechDecisions()
+		incomes := current.TakeIncomeReports()
+		if result != nil && err == nil {
+			result.PhaseChanges = changes
+			result.PowerGains = gains
+			result.AutoLeechDecisions = decisions
+			result.IncomeReports = incomes
+		}
+	}
+	return result, err
+}
+
+func (m *Manager) executeActionLocked(gameID string, action Action, meta ActionMeta) (*ActionResult, error) {
 	gs := m.games[gameID]
 	if gs == nil {
 		return nil, fmt.Errorf("game %s not found", gameID)
@@ -211,8 +483,14 @@ func (m *Manager) ExecuteActionWithMeta(gameID string, action Action, meta Actio
 	}
 
 	currentRevision := m.revisions[gameID]
+	wasEnded := gs.Phase == PhaseEnd
 	beforeTurn := captureTurnProgress(gs)
-	undoSnapshot := gs.CloneForUndo()
+	beforeEvents := captureEventSnapshot(gs)
+	consequences := TrackConsequences(gs)
+	var undoSnapshot *GameState
+	if !meta.skipUndoSnapshot {
+		undoSnapshot = gs.CloneForUndo()
+	}
 	beforeCoins, beforeWorkers, beforePriests := 0, 0, 0
 	if player := gs.GetPlayer(action.GetPlayerID()); player != nil && player.Resources != nil {
 		beforeCoins = player.Resources.Coins
@@ -231,8 +509,16 @@ func (m *Manager) ExecuteActionWithMeta(gameID string, action Action, meta Actio
 	restoreAutoConversions := setScopedAZAutoConversions(gs, meta.AllowAZAutoConversions)
 	defer restoreAutoConversions()
 
-	if err := validateActionTurnAndPendingState(gs, action); err != nil {
-		return nil, fmt.Errorf("action turn validation failed: %w", err)
+	if !meta.skipTurnValidation {
+		if err := validateActionTurnAndPendingState(gs, action); err != nil {
+			return nil, fmt.Errorf("action turn validation failed: %w", err)
+		}
+	}
+
+	if meta.SeatID != "" {
+		if err := requireSeatConfirmation(gs, action, m.accountPreferences[action.GetPlayerID()]); err != nil {
+			return nil, fmt.Errorf("action validation failed: %w", err)
+		}
 	}
 
 	maybeExpirePendingFreeActionsWindow(gs, action)
@@ -241,31 +527,71 @@ func (m *Manager) ExecuteActionWithMeta(gameID string, action Action, meta Actio
 		return nil, fmt.Errorf("action validation failed: %w", err)
 	}
 
+	// The state keeps the auto-leech decisions of its latest action only;
+	// the recorded consequences keep the rest.
+	gs.AutoLeechDecisions = nil
+	returnLeechPreferences := m.lendLeechPreferences(gs, meta.Record)
 	if err := action.Execute(gs); err != nil {
+		returnLeechPreferences()
 		return nil, fmt.Errorf("action execution failed: %w", err)
 	}
-	if err := gs.ResolveAutoLeechOffers(); err != nil {
+	consequences.NoteLeechOffers(gs)
+	err := gs.ResolveAutoLeechOffers()
+	usedLeechPreferences := returnLeechPreferences()
+	if err != nil {
 		return nil, fmt.Errorf("auto leech resolution failed: %w", err)
 	}
+	var record *RecordedAction
+	if meta.Record != nil {
+		copied := *meta.Record
+		if copied.LeechPreferences == nil {
+			copied.LeechPreferences = usedLeechPreferences
+		}
+		record = &copied
+	}
 	maybeQueueTreasurersDepositAfterAction(gs, action, beforeCoins, beforeWorkers, beforePriests)
 	updatePendingFreeActionsWindow(gs, action)
 	stageTurnConfirmation(gs, action, beforeTurn, undoSnapshot)
 	syncTurnConfirmationPreferences(gs, action)
 	refreshTurnConfirmationUndoCheckpoint(gs, action)
-	if gs.TurnTimer != nil {
-		gs.TurnTimer.SyncActivePlayers(activeDecisionPlayerIDs(gs), now)
+	m.syncTurnTimerLocked(gs, now)
+	if m.checkInvariants {
+		if err := gs.CheckInvariants(); err != nil {
+			m.games[gameID] = undoSnapshot
+			return nil, fmt.Errorf("invariant check failed: %w", err)
+		}
+	}
+	if record != nil {
+		if err := m.checkConsistencyLocked(gameID, gs, *record, now); err != nil {
+			m.games[gameID] = undoSnapshot
+			return nil, fmt.Errorf("consistency check failed: %w", err)
+		}
 	}
 
 	currentRevision++
 	m.revisions[gameID] = currentRevision
+	m.lastActivity[gameID] = now
+	result := &ActionResult{Consequences: consequences.Consequences(gs, action)}
+	if record != nil {
+		record.Consequences = result.Consequences
+		m.history[gameID] = append(m.history[gameID], *record)
+	} else {
+		m.unrecorded[gameID] = true
+	}
 	if meta.ActionID != "" {
 		if m.appliedActionID[gameID] == nil {
 			m.appliedActionID[gameID] = make(map[string]int)
 		}
 		m.appliedActionID[gameID][meta.ActionID] = currentRevision
 	}
+	m.emitEventsLocked(gameID, gs, action, beforeEvents)
+	if err := m.refreshMustPassLocked(gameID, gs); err != nil {
+		return nil, fmt.Errorf("automatic pass failed: %w", err)
+	}
 
-	return &ActionResult{Revision: currentRevision, Duplicate: false}, nil
+	result.Revision = m.revisions[gameID]
+	result.GameEnded = !wasEnded && gs.Phase == PhaseEnd
+	return result, nil
 }
 
 func setScopedAZAutoConversions(gs *GameState, enabled bool) func() {
@@ -289,7 +615,7 @@ func maybeQueueTreasurersDepositAfterAction(gs *GameState, action Action, before
 	if skipper, ok := action.(skipNetTreasurersDepositQueue); ok && skipper.SkipNetTreasurersDepositQueue() {
 		return
 	}
-	if gs.Phase != PhaseAction {
+	if gs.Phases().Current() != PhaseAction {
 		return
 	}
 	if action.GetType() == ActionSelectTreasurersDeposit {
@@ -325,47 +651,54 @@ func validateActionTurnAndPendingState(gs *GameState, action Action) error {
 	playerID := action.GetPlayerID()
 	if actionType == ActionConfirmTurn || actionType == ActionUndoTurn {
 		if !gs.HasPendingTurnConfirmation() {
-			return fmt.Errorf("no pending turn confirmation")
+			return ruleErrorf(ReasonNoPendingDecision, "no pending turn confirmation")
 		}
 		if strings.TrimSpace(playerID) != strings.TrimSpace(gs.PendingTurnConfirmationPlayerID) {
-			return fmt.Errorf("turn confirmation pending for player %s", gs.PendingTurnConfirmationPlayerID)
+			return ruleErrorf(ReasonPendingDecision, "turn confirmation pending for player %s", gs.PendingTurnConfirmationPlayerID)
 		}
 		return nil
 	}
 
-	if actionType == ActionSetPlayerOptions {
+	if actionType == ActionSetPlayerOptions || actionType == ActionResign {
 		if gs.GetPlayer(playerID) == nil {
-			return fmt.Errorf("player not found")
+			return ruleErrorf(ReasonPlayerNotFound, "player not found")
 		}
 		return nil
 	}
 
+	if actionType == ActionConversion || actionType == ActionBurnPower {
+		// Pending decisions below may still hold free actions back.
+		if err := gs.ValidateFreeActionTiming(playerID); err != nil {
+			return err
+		}
+	}
+
 	if gs.PendingTownCultTopChoice != nil {
 		if actionType != ActionSelectTownCultTop {
-			return fmt.Errorf("town cult-top choice pending for player %s", gs.PendingTownCultTopChoice.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "town cult-top choice pending for player %s", gs.PendingTownCultTopChoice.PlayerID)
 		}
 		if playerID != gs.PendingTownCultTopChoice.PlayerID {
-			return fmt.Errorf("town cult-top choice required from player %s", gs.PendingTownCultTopChoice.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "town cult-top choice required from player %s", gs.PendingTownCultTopChoice.PlayerID)
 		}
 		return nil
 	}
 
 	if townPlayer := gs.GetPendingTownSelectionPlayer(); townPlayer != "" {
 		if actionType != ActionSelectTownTile {
-			return fmt.Errorf("town tile selection pending for player %s", townPlayer)
+			return ruleErrorf(ReasonPendingDecision, "town tile selection pending for player %s", townPlayer)
 		}
 		if playerID != townPlayer {
-			return fmt.Errorf("town tile selection required from player %s", townPlayer)
+			return ruleErrorf(ReasonPendingDecision, "town tile selection required from player %s", townPlayer)
 		}
 		return nil
 	}
 
 	if gs.PendingDarklingsPriestOrdination != nil {
 		if actionType != ActionUseDarklingsPriestOrdination {
-			return fmt.Errorf("darklings priest ordination pending for player %s", gs.PendingDarklingsPriestOrdination.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "darklings priest ordination pending for player %s", gs.PendingDarklingsPriestOrdination.PlayerID)
 		}
 		if playerID != gs.PendingDarklingsPriestOrdination.PlayerID {
-			return fmt.Errorf("darklings priest ordination required from player %s", gs.PendingDarklingsPriestOrdination.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "darklings priest ordination required from player %s", gs.PendingDarklingsPriestOrdination.PlayerID)
 		}
 		return nil
 	}
@@ -375,9 +708,9 @@ func validateActionTurnAndPendingState(gs *GameState, action Action) error {
 		if actionType == ActionAcceptPowerLeech || actionType == ActionDeclinePowerLeech {
 			if len(gs.PendingLeechOffers[playerID]) == 0 {
 				if expected != "" {
-					return fmt.Errorf("leech response required from player %s", expected)
+					return ruleErrorf(ReasonPendingDecision, "leech response required from player %s", expected)
 				}
-				return fmt.Errorf("no pending leech offer for player %s", playerID)
+				return ruleErrorf(ReasonNoPendingDecision, "no pending leech offer for player %s", playerID)
 			}
 			return nil
 		}
@@ -385,17 +718,17 @@ func validateActionTurnAndPendingState(gs *GameState, action Action) error {
 			if canCurrentPlayerContinueFreeActionBeforeLeech(gs, action) {
 				return nil
 			}
-			return fmt.Errorf("leech response pending for player %s", expected)
+			return ruleErrorf(ReasonPendingDecision, "leech response pending for player %s", expected)
 		}
 	}
 
 	if gs.PendingCultistsCultSelection != nil {
 		pendingPlayer := strings.TrimSpace(gs.PendingCultistsCultSelection.PlayerID)
 		if strings.TrimSpace(playerID) != pendingPlayer {
-			return fmt.Errorf("cultists cult selection required from player %s", gs.PendingCultistsCultSelection.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "cultists cult selection required from player %s", gs.PendingCultistsCultSelection.PlayerID)
 		}
 		if actionType != ActionSelectCultistsCultTrack {
-			return fmt.Errorf("cultists cult selection pending for player %s", gs.PendingCultistsCultSelection.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "cultists cult selection pending for player %s", gs.PendingCultistsCultSelection.PlayerID)
 		}
 		return nil
 	}
@@ -403,10 +736,10 @@ func validateActionTurnAndPendingState(gs *GameState, action Action) error {
 	if gs.PendingDjinniStartingCultChoice != nil {
 		pendingPlayer := strings.TrimSpace(gs.PendingDjinniStartingCultChoice.PlayerID)
 		if strings.TrimSpace(playerID) != pendingPlayer {
-			return fmt.Errorf("djinni starting cult choice required from player %s", gs.PendingDjinniStartingCultChoice.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "djinni starting cult choice required from player %s", gs.PendingDjinniStartingCultChoice.PlayerID)
 		}
 		if actionType != ActionSelectDjinniStartingCultTrack {
-			return fmt.Errorf("djinni starting cult choice pending for player %s", gs.PendingDjinniStartingCultChoice.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "djinni starting cult choice pending for player %s", gs.PendingDjinniStartingCultChoice.PlayerID)
 		}
 		return nil
 	}
@@ -414,10 +747,10 @@ func validateActionTurnAndPendingState(gs *GameState, action Action) error {
 	if gs.PendingRiverwalkersPriestChoice != nil {
 		pendingPlayer := strings.TrimSpace(gs.PendingRiverwalkersPriestChoice.PlayerID)
 		if strings.TrimSpace(playerID) != pendingPlayer {
-			return fmt.Errorf("riverwalkers priest choice required from player %s", gs.PendingRiverwalkersPriestChoice.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "riverwalkers priest choice required from player %s", gs.PendingRiverwalkersPriestChoice.PlayerID)
 		}
 		if actionType != ActionSelectRiverwalkersPriestChoice {
-			return fmt.Errorf("riverwalkers priest choice pending for player %s", gs.PendingRiverwalkersPriestChoice.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "riverwalkers priest choice pending for player %s", gs.PendingRiverwalkersPriestChoice.PlayerID)
 		}
 		return nil
 	}
@@ -425,10 +758,10 @@ func validateActionTurnAndPendingState(gs *GameState, action Action) error {
 	if gs.PendingTreasurersDeposit != nil {
 		pendingPlayer := strings.TrimSpace(gs.PendingTreasurersDeposit.PlayerID)
 		if strings.TrimSpace(playerID) != pendingPlayer {
-			return fmt.Errorf("treasurers deposit choice required from player %s", gs.PendingTreasurersDeposit.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "treasurers deposit choice required from player %s", gs.PendingTreasurersDeposit.PlayerID)
 		}
 		if actionType != ActionSelectTreasurersDeposit {
-			return fmt.Errorf("treasurers deposit choice pending for player %s", gs.PendingTreasurersDeposit.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "treasurers deposit choice pending for player %s", gs.PendingTreasurersDeposit.PlayerID)
 		}
 		return nil
 	}
@@ -436,10 +769,10 @@ func validateActionTurnAndPendingState(gs *GameState, action Action) error {
 	if gs.PendingArchivistsBonusSelection != nil {
 		pendingPlayer := strings.TrimSpace(gs.PendingArchivistsBonusSelection.PlayerID)
 		if strings.TrimSpace(playerID) != pendingPlayer {
-			return fmt.Errorf("archivists bonus card selection required from player %s", gs.PendingArchivistsBonusSelection.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "archivists bonus card selection required from player %s", gs.PendingArchivistsBonusSelection.PlayerID)
 		}
 		if actionType != ActionSelectArchivistsBonusCard {
-			return fmt.Errorf("archivists bonus card selection pending for player %s", gs.PendingArchivistsBonusSelection.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "archivists bonus card selection pending for player %s", gs.PendingArchivistsBonusSelection.PlayerID)
 		}
 		return nil
 	}
@@ -447,60 +780,60 @@ func validateActionTurnAndPendingState(gs *GameState, action Action) error {
 	if gs.PendingGoblinsCultSteps != nil {
 		pendingPlayer := strings.TrimSpace(gs.PendingGoblinsCultSteps.PlayerID)
 		if strings.TrimSpace(playerID) != pendingPlayer {
-			return fmt.Errorf("goblins cult-step selection required from player %s", gs.PendingGoblinsCultSteps.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "goblins cult-step selection required from player %s", gs.PendingGoblinsCultSteps.PlayerID)
 		}
 		if actionType != ActionSelectGoblinsCultTrack {
-			return fmt.Errorf("goblins cult-step selection pending for player %s", gs.PendingGoblinsCultSteps.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "goblins cult-step selection pending for player %s", gs.PendingGoblinsCultSteps.PlayerID)
 		}
 		return nil
 	}
 
 	if gs.PendingFavorTileSelection != nil {
 		if actionType != ActionSelectFavorTile {
-			return fmt.Errorf("favor tile selection pending for player %s", gs.PendingFavorTileSelection.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "favor tile selection pending for player %s", gs.PendingFavorTileSelection.PlayerID)
 		}
 		if playerID != gs.PendingFavorTileSelection.PlayerID {
-			return fmt.Errorf("favor tile selection required from player %s", gs.PendingFavorTileSelection.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "favor tile selection required from player %s", gs.PendingFavorTileSelection.PlayerID)
 		}
 		return nil
 	}
 
 	if gs.PendingHalflingsSpades != nil {
 		if playerID != gs.PendingHalflingsSpades.PlayerID {
-			return fmt.Errorf("halflings spade follow-up required from player %s", gs.PendingHalflingsSpades.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "halflings spade follow-up required from player %s", gs.PendingHalflingsSpades.PlayerID)
 		}
 		if actionType != ActionApplyHalflingsSpade && actionType != ActionBuildHalflingsDwelling && actionType != ActionSkipHalflingsDwelling {
-			return fmt.Errorf("halflings spade follow-up pending for player %s", gs.PendingHalflingsSpades.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "halflings spade follow-up pending for player %s", gs.PendingHalflingsSpades.PlayerID)
 		}
 		return nil
 	}
 
 	if gs.PendingWispsStrongholdDwelling != nil {
 		if playerID != gs.PendingWispsStrongholdDwelling.PlayerID {
-			return fmt.Errorf("wisps stronghold dwelling required from player %s", gs.PendingWispsStrongholdDwelling.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "wisps stronghold dwelling required from player %s", gs.PendingWispsStrongholdDwelling.PlayerID)
 		}
 		if actionType != ActionBuildWispsStrongholdDwelling {
-			return fmt.Errorf("wisps stronghold dwelling pending for player %s", gs.PendingWispsStrongholdDwelling.PlayerID)
+			return ruleErrorf(ReasonPendingDecision, "wisps stronghold dwelling pending for player %s", gs.PendingWispsStrongholdDwelling.PlayerID)
 		}
 		return nil
 	}
 
 	if requiredPlayer, _ := gs.GetPendingSpadeFollowupPlayer(); requiredPlayer != "" {
 		if playerID != requiredPlayer {
-			return fmt.Errorf("spade follow-up required from player %s", requiredPlayer)
+			return ruleErrorf(ReasonPendingDecision, "spade follow-up required from player %s", requiredPlayer)
 		}
 		if actionType != ActionTransformAndBuild && actionType != ActionDiscardPendingSpade {
-			return fmt.Errorf("spade follow-up pending for player %s", requiredPlayer)
+			return ruleErrorf(ReasonPendingDecision, "spade follow-up pending for player %s", requiredPlayer)
 		}
 		return nil
 	}
 
 	if requiredPlayer, _ := gs.GetPendingCultRewardSpadePlayer(); requiredPlayer != "" {
 		if playerID != requiredPlayer {
-			return fmt.Errorf("cult reward spade follow-up required from player %s", requiredPlayer)
+			return ruleErrorf(ReasonPendingDecision, "cult reward spade follow-up required from player %s", requiredPlayer)
 		}
 		if actionType != ActionUseCultSpade && actionType != ActionDiscardPendingSpade {
-			return fmt.Errorf("cult reward spade follow-up pending for player %s", requiredPlayer)
+			return ruleErrorf(ReasonPendingDecision, "cult reward spade follow-up pending for player %s", requiredPlayer)
 		}
 		return nil
 	}
@@ -509,7 +842,7 @@ func validateActionTurnAndPendingState(gs *GameState, action Action) error {
 		if pendingTowns, ok := gs.PendingTownFormations[playerID]; ok && len(pendingTowns) > 0 {
 			return nil
 		}
-		return fmt.Errorf("no pending town formation for player %s", playerID)
+		return ruleErrorf(ReasonNoPendingDecision, "no pending town formation for player %s", playerID)
 	}
 
 	if pendingPlayerID := strings.TrimSpace(gs.PendingFreeActionsPlayerID); pendingPlayerID != "" &&
@@ -519,7 +852,7 @@ func validateActionTurnAndPendingState(gs *GameState, action Action) error {
 		}
 		current := gs.GetCurrentPlayer()
 		if current != nil && strings.TrimSpace(current.ID) == pendingPlayerID {
-			return fmt.Errorf("post-action free actions pending for player %s", pendingPlayerID)
+			return ruleErrorf(ReasonPendingDecision, "post-action free actions pending for player %s", pendingPlayerID)
 		}
 	}
 
@@ -527,47 +860,16 @@ func validateActionTurnAndPendingState(gs *GameState, action Action) error {
 		return nil
 	}
 
-	if gs.Phase == PhaseFactionSelection {
-		switch gs.SetupMode {
-		case SetupModeAuction:
-			if gs.AuctionState == nil || !gs.AuctionState.Active {
-				return fmt.Errorf("regular auction setup is not active")
-			}
-			if gs.AuctionState.NominationPhase {
-				if actionType != ActionAuctionNominateFaction {
-					return fmt.Errorf("auction nomination is required")
-				}
-			} else if actionType != ActionAuctionPlaceBid {
-				return fmt.Errorf("regular auction bid is required")
-			}
-		case SetupModeFastAuction:
-			if gs.AuctionState == nil || !gs.AuctionState.Active {
-				return fmt.Errorf("fast auction setup is not active")
-			}
-			if gs.AuctionState.NominationPhase {
-				if actionType != ActionAuctionNominateFaction {
-					return fmt.Errorf("auction nomination is required")
-				}
-			} else if actionType != ActionFastAuctionSubmitBids {
-				return fmt.Errorf("fast auction bid submission is required")
-			}
-		default:
-			if actionType != ActionSelectFaction {
-				return fmt.Errorf("faction selection is required")
-			}
-		}
-	}
-
-	if gs.Phase == PhaseSetup && gs.SetupSubphase == SetupSubphaseBonusCards && actionType != ActionSetupBonusCard {
-		return fmt.Errorf("setup bonus card selection is required")
+	if err := gs.Phases().ValidateAction(actionType, playerID); err != nil {
+		return err
 	}
 
 	if actionType == ActionAcceptPowerLeech || actionType == ActionDeclinePowerLeech {
-		return fmt.Errorf("no pending leech offer for player")
+		return ruleErrorf(ReasonNoPendingDecision, "no pending leech offer for player")
 	}
 
 	if actionType == ActionSelectTownCultTop || actionType == ActionSelectFavorTile || actionType == ActionUseDarklingsPriestOrdination || actionType == ActionApplyHalflingsSpade || actionType == ActionBuildHalflingsDwelling || actionType == ActionSkipHalflingsDwelling || actionType == ActionBuildWispsStrongholdDwelling || actionType == ActionSelectCultistsCultTrack || actionType == ActionSelectDjinniStartingCultTrack || actionType == ActionSelectTreasurersDeposit || actionType == ActionSelectRiverwalkersPriestChoice || actionType == ActionSelectGoblinsCultTrack || actionType == ActionSelectArchivistsBonusCard || actionType == ActionDiscardPendingSpade {
-		return fmt.Errorf("no pending decision for requested action")
+		return ruleErrorf(ReasonNoPendingDecision, "no pending decision for requested action")
 	}
 
 	if pendingPlayerID := strings.TrimSpace(gs.PendingTurnConfirmationPlayerID); pendingPlayerID != "" {
@@ -575,9 +877,9 @@ func validateActionTurnAndPendingState(gs *GameState, action Action) error {
 			if canPlayerUsePendingFreeActionsWindow(gs, action) || actionType == ActionSetPlayerOptions {
 				return nil
 			}
-			return fmt.Errorf("turn confirmation pending for player %s", pendingPlayerID)
+			return ruleErrorf(ReasonPendingDecision, "turn confirmation pending for player %s", pendingPlayerID)
 		}
-		return fmt.Errorf("turn confirmation pending for player %s", pendingPlayerID)
+		return ruleErrorf(ReasonPendingDecision, "turn confirmation pending for player %s", pendingPlayerID)
 	}
 
 	if actionRequiresTurnOwnership(actionType) {
@@ -586,7 +888,7 @@ func validateActionTurnAndPendingState(gs *GameState, action Action) error {
 			return fmt.Errorf("no current player")
 		}
 		if current.ID != playerID {
-			return fmt.Errorf("not your turn")
+			return ruleErrorf(ReasonNotYourTurn, "not your turn")
 		}
 	}
 
@@ -646,7 +948,7 @@ func maybeExpirePendingFreeActionsWindow(gs *GameState, action Action) {
 	if playerID == pendingPlayerID && canPlayerUsePendingFreeActionsWindow(gs, action) {
 		return
 	}
-	if isPendingResolutionActionType(action.GetType()) {
+	if isPendingResolutionActionType(action.GetType()) || action.GetType() == ActionResign {
 		return
 	}
 	current := gs.GetCurrentPlayer()
@@ -668,7 +970,7 @@ func updatePendingFreeActionsWindow(gs *GameState, action Action) {
 	if gs == nil {
 		return
 	}
-	if gs.Phase != PhaseAction {
+	if gs.Phases().Current() != PhaseAction {
 		gs.PendingFreeActionsPlayerID = ""
 		return
 	}
@@ -855,6 +1157,7 @@ func actionRequiresTurnOwnership(actionType ActionType) bool {
 		ActionSelectArchivistsBonusCard,
 		ActionDiscardPendingSpade,
 		ActionSetPlayerOptions,
+		ActionResign,
 		ActionConfirmTurn,
 		ActionUndoTurn,
 		ActionFastAuctionSubmitBids:
@@ -918,45 +1221,101 @@ func (m *Manager) CreateGameWithOptions(id string, playerIDs []string, opts Crea
 	gs.EnableFireIceFactions = opts.EnableFireIceFactions
 	fireIceSetting := normalizeFireIceFinalScoringSetting(opts.FireIceScoring)
 	gs.FireIceFinalScoringSetting = fireIceSetting
-	gs.FireIceFinalScoringTile = resolveFireIceFinalScoringTile(fireIceSetting, rand.New(rand.NewSource(time.Now().UnixNano())))
+	gs.HiddenResources = opts.HiddenResources
+	gs.Hotseat = opts.Hotseat
+	switch opts.TurnOrderPolicy {
+	case "":
+	case TurnOrderPolicyPassOrder, TurnOrderPolicyCyclicFromFirstPasser:
+		gs.TurnOrderPolicy = opts.TurnOrderPolicy
+	default:
+		return fmt.Errorf("invalid turn order policy: %s", opts.TurnOrderPolicy)
+	}
+	if opts.SpeedPreset != "" && !opts.SpeedPreset.IsValid() {
+		return fmt.Errorf("invalid speed preset: %s", opts.SpeedPreset)
+	}
+	handicaps, err := validateHandicaps(opts.Handicaps, playerIDs)
+	if err != nil {
+		return err
+	}
+	gs.Handicaps = handicaps
 
-	if err := gs.ScoringTiles.InitializeForGame(); err != nil {
+	// Every random setup draw comes from one source seeded by gs.Seed so a game
+	// can be reproduced from its recorded seed.
+	if opts.Seed != nil {
+		gs.Seed = *opts.Seed
+	} else {
+		gs.Seed = m.newSeed()
+	}
+	rng := rand.New(rand.NewSource(gs.Seed))
+	gs.FireIceFinalScoringTile = resolveFireIceFinalScoringTile(fireIceSetting, rng)
+
+	if err := gs.ScoringTiles.drawWithRand(gs.AllScoringTiles(), rng); err != nil {
 		return fmt.Errorf("failed to initialize scoring tiles: %w", err)
 	}
 
-	gs.BonusCards.SelectRandomBonusCards(len(playerIDs))
+	removedBonusCards := opts.RemovedBonusCards
+	if opts.Draws != nil {
+		// With every card but the drawn ones removed, the shuffle cannot
+		// change which cards are in play.
+		removedBonusCards = opts.Draws.RemovedBonusCards
+	}
+	if _, err := gs.BonusCards.selectBonusCardsWithRand(gs.tileDefinitions().bonusCards, len(playerIDs), removedBonusCards, rng); err != nil {
+		return fmt.Errorf("failed to select bonus cards: %w", err)
+	}
 
 	turnOrder := make([]string, len(playerIDs))
 	copy(turnOrder, playerIDs)
 	if opts.RandomizeTurnOrder {
-		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 		rng.Shuffle(len(turnOrder), func(i, j int) {
 			turnOrder[i], turnOrder[j] = turnOrder[j], turnOrder[i]
 		})
 	}
+	if opts.Draws != nil {
+		if turnOrder, err = opts.Draws.apply(gs, playerIDs); err != nil {
+			return fmt.Errorf("invalid setup draws: %w", err)
+		}
+	}
 
 	for _, pid := range turnOrder {
 		if err := gs.AddPlayer(pid, nil); err != nil {
 			return fmt.Errorf("failed to add player %s: %w", pid, err)
 		}
 	}
+	if opts.PublicSummary {
+		gs.setPublicSummaryConsent(turnOrder)
+	} else {
+		gs.setPublicSummaryConsent(opts.PublicSummaryConsent)
+	}
 
-	gs.Phase = PhaseFactionSelection
+	gs.Phases().Enter(PhaseFactionSelection)
 	gs.TurnOrder = turnOrder
 	gs.CurrentPlayerIndex = 0
 	if setupMode == SetupModeAuction || setupMode == SetupModeFastAuction {
 		gs.AuctionState = NewAuctionStateWithMode(turnOrder, setupMode)
 	}
-	if opts.TurnTimer != nil {
-		gs.TurnTimer = NewTurnTimerState(turnOrder, *opts.TurnTimer)
-		if gs.TurnTimer != nil {
-			gs.TurnTimer.SyncActivePlayers(activeDecisionPlayerIDs(gs), m.now())
+	turnTimer := opts.TurnTimer
+	if opts.SpeedPreset != "" {
+		speed := opts.SpeedPreset.Settings()
+		gs.SpeedPreset = opts.SpeedPreset
+		if turnTimer == nil {
+			turnTimer = speed.TurnTimer
 		}
+		for _, player := range gs.Players {
+			player.Options.AutoLeechMode = speed.AutoLeechMode
+		}
+	}
+	if turnTimer != nil {
+		gs.TurnTimer = NewTurnTimerState(turnOrder, *turnTimer)
+		m.syncTurnTimerLocked(gs, m.now())
 	}
 
 	m.games[id] = gs
 	m.revisions[id] = 0
 	m.appliedActionID[id] = make(map[string]int)
+	m.setups[id] = newGameSetup(playerIDs, opts, gs.Seed)
+	delete(m.archivedRevision, id)
+	delete(m.archiveFailed, id)
+	m.lastActivity[id] = m.now()
 	return nil
 }
 
@@ -971,6 +1330,15 @@ func normalizeFireIceFinalScoringSetting(setting FireIceFinalScoringSetting) Fir
 	}
 }
 
+// revealedSeed is the seed of a finished game, or nil: while the game runs
+// the seed stays on the server so clients cannot predict its draws.
+func revealedSeed(gs *GameState) interface{} {
+	if gs.Phase != PhaseEnd {
+		return nil
+	}
+	return gs.Seed
+}
+
 func resolveFireIceFinalScoringTile(setting FireIceFinalScoringSetting, rng *rand.Rand) FireIceFinalScoringTile {
 	setting = normalizeFireIceFinalScoringSetting(setting)
 	if setting == FireIceFinalScoringOff {
@@ -994,6 +1362,17 @@ func resolveFireIceFinalScoringTile(setting FireIceFinalScoringSetting, rng *ran
 
 // SerializeGameState converts GameState to a JSON-friendly format for the frontend.
 func (m *Manager) SerializeGameState(gameID string) map[string]interface{} {
+	return m.serializeGameState(gameID, false)
+}
+
+// SerializeGameStateWithMapAnalysis is SerializeGameState with an added
+// "mapAnalysis" entry (see GameState.AnalyzeMap).
+func (m *Manager) SerializeGameStateWithMapAnalysis(gameID string) map[string]interface{} {
+	return m.serializeGameState(gameID, true)
+}
+
+func (m *Manager) serializeGameState(gameID string, includeMapAnalysis bool) map[string]interface{} {
+	m.rehydrate(gameID)
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -1001,7 +1380,11 @@ func (m *Manager) SerializeGameState(gameID string) map[string]interface{} {
 	if gs == nil {
 		return nil
 	}
-	state := serializeStateWithRevisionAt(gs, gameID, m.revisions[gameID], m.now())
+	state := serializeStateWithRevisionAt(gs, gameID, m.revisions[gameID], m.now(), m.spadeTargetsLocked(gameID))
+	state["vacationPlayerIds"] = m.vacationPlayersLocked(gs)
+	if includeMapAnalysis {
+		state["mapAnalysis"] = gs.AnalyzeMap()
+	}
 
 	// Detach nested mutable maps/slices while the manager read-lock is held so JSON
 	// encoding in websocket handlers does not race with concurrent action writes.
@@ -1018,15 +1401,17 @@ func (m *Manager) SerializeGameState(gameID string) map[string]interface{} {
 
 // SerializeState converts the game state to a map for JSON response.
 func SerializeState(gs *GameState, gameID string) map[string]interface{} {
-	return serializeStateWithRevisionAt(gs, gameID, 0, time.Now())
+	return serializeStateWithRevisionAt(gs, gameID, 0, time.Now(), gs.PendingSpadeTargets)
 }
 
 // SerializeStateWithRevision converts game state to JSON-friendly map including revision.
 func SerializeStateWithRevision(gs *GameState, gameID string, revision int) map[string]interface{} {
-	return serializeStateWithRevisionAt(gs, gameID, revision, time.Now())
+	return serializeStateWithRevisionAt(gs, gameID, revision, time.Now(), gs.PendingSpadeTargets)
 }
 
-func serializeStateWithRevisionAt(gs *GameState, gameID string, revision int, now time.Time) map[string]interface{} {
+// serializeStateWithRevisionAt serializes gs, taking the targets of a pending
+// spade decision from spadeTargets.
+func serializeStateWithRevisionAt(gs *GameState, gameID string, revision int, now time.Time, spadeTargets func(playerID string) []PendingSpadeTarget) map[string]interface{} {
 	players := make(map[string]interface{})
 	for playerID, player := range gs.Players {
 		var factionType models.FactionType
@@ -1049,22 +1434,27 @@ func serializeStateWithRevisionAt(gs *GameState, gameID string, revision int, no
 					"powerIII": player.Resources.Power.Bowl3,
 				},
 			},
-			"shipping":              player.ShippingLevel,
-			"digging":               player.DiggingLevel,
-			"chashIncomeTrackLevel": player.ChashIncomeTrackLevel,
-			"hasPassed":             player.HasPassed,
-			"hasStrongholdAbility":  player.HasStrongholdAbility,
-			"victoryPoints":         player.VictoryPoints,
-			"firewalkersBlockerVp":  player.FirewalkersBlockerVP,
-			"keys":                  player.Keys,
-			"townsFormed":           player.TownsFormed,
-			"townTiles":             player.TownTiles,
-			"goblinTreasureTokens":  player.GoblinTreasureTokens,
-			"djinniLampTokens":      player.DjinniLampTokens,
-			"treasuryCoins":         player.TreasuryCoins,
-			"treasuryWorkers":       player.TreasuryWorkers,
-			"treasuryPriests":       player.TreasuryPriests,
-			"specialActionsUsed":    player.SpecialActionsUsed,
+			"shipping":                player.ShippingLevel,
+			"shippingBonus":           gs.TemporaryShippingBonus(playerID),
+			"carpetFlightRange":       carpetFlightRange(player),
+			"digging":                 player.DiggingLevel,
+			"chashIncomeTrackLevel":   player.ChashIncomeTrackLevel,
+			"hasPassed":               player.HasPassed,
+			"resigned":                player.Resigned,
+			"hasStrongholdAbility":    player.HasStrongholdAbility,
+			"victoryPoints":           player.VictoryPoints,
+			"firewalkersBlockerVp":    player.FirewalkersBlockerVP,
+			"keys":                    player.Keys,
+			"townsFormed":             player.TownsFormed,
+			"powerGained":             player.PowerGained,
+			"townTiles":               player.TownTiles,
+			"goblinTreasureTokens":    player.GoblinTreasureTokens,
+			"djinniLampTokens":        player.DjinniLampTokens,
+			"treasuryCoins":           player.TreasuryCoins,
+			"treasuryWorkers":         player.TreasuryWorkers,
+			"treasuryPriests":         player.TreasuryPriests,
+			"specialActionsUsed":      player.SpecialActionsUsed,
+			"availableSpecialActions": gs.AvailableSpecialActions(playerID),
 			"cults": map[string]interface{}{
 				"0": player.CultPositions[CultFire],
 				"1": player.CultPositions[CultWater],
@@ -1114,7 +1504,7 @@ func serializeStateWithRevisionAt(gs *GameState, gameID string, revision int, no
 			factionType = p.Faction.GetType()
 		}
 
-		bridges = append(bridges, map[string]interface{}{
+		bridge := map[string]interface{}{
 			"ownerPlayerId": ownerID,
 			"faction":       factionType,
 			"fromCoord": map[string]int{
@@ -1125,7 +1515,14 @@ func serializeStateWithRevisionAt(gs *GameState, gameID string, revision int, no
 				"q": bridgeKey.H2.Q,
 				"r": bridgeKey.H2.R,
 			},
-		})
+		}
+		if displayCoord, ok := gs.Map.DisplayCoordinateForHex(bridgeKey.H1); ok {
+			bridge["fromDisplayCoord"] = displayCoord
+		}
+		if displayCoord, ok := gs.Map.DisplayCoordinateForHex(bridgeKey.H2); ok {
+			bridge["toDisplayCoord"] = displayCoord
+		}
+		bridges = append(bridges, bridge)
 	}
 
 	return map[string]interface{}{
@@ -1136,6 +1533,12 @@ func serializeStateWithRevisionAt(gs *GameState, gameID string, revision int, no
 		"enableFireIceFactions":      gs.EnableFireIceFactions,
 		"fireIceFinalScoringSetting": gs.FireIceFinalScoringSetting,
 		"fireIceFinalScoringTile":    gs.FireIceFinalScoringTile,
+		"hiddenResources":            gs.HiddenResources,
+		"hotseat":                    gs.Hotseat,
+		"publicSummary":              gs.PublicSummary,
+		"publicSummaryConsent":       gs.PublicSummaryConsent,
+		"handicaps":                  gs.Handicaps,
+		"seed":                       revealedSeed(gs),
 		"phase":                      gs.Phase,
 		"setupMode":                  gs.SetupMode,
 		"turnOrderPolicy":            gs.TurnOrderPolicy,
@@ -1152,8 +1555,11 @@ func serializeStateWithRevisionAt(gs *GameState, gameID string, revision int, no
 			"hexes":   hexes,
 			"bridges": bridges,
 		},
-		"turnOrder": gs.TurnOrder,
-		"passOrder": gs.PassOrder,
+		"turnOrder":         gs.TurnOrder,
+		"passOrder":         gs.PassOrder,
+		"upcomingTurnOrder": serializeUpcomingTurnOrder(gs),
+		"leechWait":         gs.LeechWait(),
+		"opponentSummaries": gs.OpponentSummaries(),
 		"round": map[string]interface{}{
 			"round": gs.Round,
 		},
@@ -1198,11 +1604,14 @@ func serializeStateWithRevisionAt(gs *GameState, gameID string, revision int, no
 			}
 			return gs.CultTracks
 		}(),
+		"cultRewardProgress":               gs.CultRewardProgress(),
 		"pendingLeechOffers":               gs.PendingLeechOffers,
 		"pendingTownFormations":            gs.PendingTownFormations,
 		"pendingSpades":                    gs.PendingSpades,
 		"pendingSpadeBuildAllowed":         gs.PendingSpadeBuildAllowed,
 		"pendingCultRewardSpades":          gs.PendingCultRewardSpades,
+		"cultRewardSpadeOrder":             gs.CultRewardSpadeOrder,
+		"cultRewardSpadeIndex":             gs.CultRewardSpadeIndex,
 		"pendingFavorTileSelection":        gs.PendingFavorTileSelection,
 		"pendingHalflingsSpades":           gs.PendingHalflingsSpades,
 		"pendingDarklingsPriestOrdination": gs.PendingDarklingsPriestOrdination,
@@ -1210,11 +1619,17 @@ func serializeStateWithRevisionAt(gs *GameState, gameID string, revision int, no
 		"pendingRiverwalkersPriestChoice":  gs.PendingRiverwalkersPriestChoice,
 		"pendingTownCultTopChoice":         gs.PendingTownCultTopChoice,
 		"pendingFreeActionsPlayerId":       gs.PendingFreeActionsPlayerID,
+		"mustPassPlayerId":                 gs.MustPassPlayerID,
 		"pendingTurnConfirmationPlayerId":  gs.PendingTurnConfirmationPlayerID,
-		"pendingDecision":                  serializePendingDecision(gs),
+		"pendingDecision":                  serializePendingDecisionWith(gs, spadeTargets),
 		"auctionState":                     serializeAuctionState(gs.AuctionState),
+		"availableFactions":                serializeAvailableFactions(gs),
+		"factionMapPreview":                serializeFactionMapPreview(gs),
 		"turnTimer":                        serializeTurnTimer(gs.TurnTimer, now),
+		"autoLeechDecisions":               gs.AutoLeechDecisions,
+		"speedPreset":                      gs.SpeedPreset,
 		"nextRoundIncome":                  serializeNextRoundIncomePreview(gs),
+		"passIncomeWarnings":               serializePassIncomeWarnings(gs),
 		"finalScoring": func() interface{} {
 			if gs.FinalScoring == nil {
 				return nil
@@ -1224,6 +1639,14 @@ func serializeStateWithRevisionAt(gs *GameState, gameID string, revision int, no
 	}
 }
 
+// carpetFlightRange returns the Fakirs carpet flight range, or nil for other factions.
+func carpetFlightRange(player *Player) interface{} {
+	if fakirs, ok := player.Faction.(*factions.Fakirs); ok {
+		return fakirs.GetFlightRange()
+	}
+	return nil
+}
+
 func serializeNextRoundIncomePreview(gs *GameState) interface{} {
 	if gs == nil || gs.Round < 1 || gs.Round > 5 {
 		return nil
@@ -1237,10 +1660,26 @@ func serializeNextRoundIncomePreview(gs *GameState) interface{} {
 	return preview
 }
 
+func serializeUpcomingTurnOrder(gs *GameState) interface{} {
+	if gs == nil || gs.Phases().Current() != PhaseAction || gs.Round < 1 || gs.Round > 5 {
+		return nil
+	}
+	return gs.NextRoundTurnOrder()
+}
+
 func serializePendingDecision(gs *GameState) interface{} {
 	if gs == nil {
 		return nil
 	}
+	return serializePendingDecisionWith(gs, gs.PendingSpadeTargets)
+}
+
+// serializePendingDecisionWith serializes the decision gs waits on, taking
+// the targets of pending spades from spadeTargets.
+func serializePendingDecisionWith(gs *GameState, spadeTargets func(playerID string) []PendingSpadeTarget) interface{} {
+	if gs == nil {
+		return nil
+	}
 
 	if gs.Phase == PhaseFactionSelection && gs.AuctionState != nil && gs.AuctionState.Active {
 		factions := make([]string, 0, len(gs.AuctionState.NominationOrder))
@@ -1279,52 +1718,42 @@ func serializePendingDecision(gs *GameState) interface{} {
 	if gs.Phase == PhaseSetup && gs.SetupSubphase == SetupSubphaseBonusCards {
 		if playerID := gs.currentSetupBonusPlayerID(); playerID != "" {
 			return map[string]interface{}{
-				"type":     "setup_bonus_card",
-				"playerId": playerID,
+				"type":                "setup_bonus_card",
+				"playerId":            playerID,
+				"availableBonusCards": gs.SetupBonusCardOptions(playerID),
 			}
 		}
 	}
 
 	if gs.PendingTownCultTopChoice != nil {
-		return map[string]interface{}{
-			"type":            "town_cult_top_choice",
-			"playerId":        gs.PendingTownCultTopChoice.PlayerID,
-			"candidateTracks": gs.PendingTownCultTopChoice.CandidateTracks,
-			"maxSelections":   gs.PendingTownCultTopChoice.MaxSelections,
-			"advanceAmount":   gs.PendingTownCultTopChoice.AdvanceAmount,
-		}
+		return pendingDecisionMap(TownCultTopChoiceDecision{
+			PlayerID:        gs.PendingTownCultTopChoice.PlayerID,
+			CandidateTracks: gs.PendingTownCultTopChoice.CandidateTracks,
+			MaxSelections:   gs.PendingTownCultTopChoice.MaxSelections,
+			AdvanceAmount:   gs.PendingTownCultTopChoice.AdvanceAmount,
+		})
 	}
 
 	if townPlayer := gs.GetPendingTownSelectionPlayer(); townPlayer != "" {
-		return map[string]interface{}{
-			"type":     "town_tile_selection",
-			"playerId": townPlayer,
-		}
+		return pendingDecisionMap(TownTileSelectionDecision{PlayerID: townPlayer})
 	}
 
 	if gs.PendingDarklingsPriestOrdination != nil {
-		return map[string]interface{}{
-			"type":     "darklings_ordination",
-			"playerId": gs.PendingDarklingsPriestOrdination.PlayerID,
-		}
+		playerID := gs.PendingDarklingsPriestOrdination.PlayerID
+		return pendingDecisionMap(DarklingsOrdinationDecision{PlayerID: playerID, MaxWorkers: gs.DarklingsOrdinationMaxWorkers(playerID)})
 	}
 
 	if gs.HasPendingLeechOffers() {
 		if playerID := gs.GetNextBlockingLeechResponder(); playerID != "" {
-			offers := gs.PendingLeechOffers[playerID]
-			return map[string]interface{}{
-				"type":     "leech_offer",
-				"playerId": playerID,
-				"offers":   offers,
-			}
+			return pendingDecisionMap(LeechOfferDecision{PlayerID: playerID, Offers: gs.PendingLeechOffers[playerID]})
 		}
 	}
 
 	if gs.PendingCultistsCultSelection != nil {
-		return map[string]interface{}{
-			"type":     "cultists_cult_choice",
-			"playerId": gs.PendingCultistsCultSelection.PlayerID,
-		}
+		return pendingDecisionMap(CultistsCultChoiceDecision{
+			PlayerID: gs.PendingCultistsCultSelection.PlayerID,
+			Count:    max(len(gs.PendingCultistsCultSelection.EventIDs), 1),
+		})
 	}
 
 	if gs.PendingDjinniStartingCultChoice != nil {
@@ -1366,11 +1795,13 @@ func serializePendingDecision(gs *GameState) interface{} {
 	}
 
 	if gs.PendingHalflingsSpades != nil {
-		return map[string]interface{}{
-			"type":            "halflings_spades",
-			"playerId":        gs.PendingHalflingsSpades.PlayerID,
-			"spadesRemaining": gs.PendingHalflingsSpades.SpadesRemaining,
-		}
+		playerID := gs.PendingHalflingsSpades.PlayerID
+		return pendingDecisionMap(HalflingsSpadesDecision{
+			PlayerID:        playerID,
+			SpadesRemaining: gs.PendingHalflingsSpades.SpadesRemaining,
+			Targets:         serializePendingSpadeTargets(spadeTargets(playerID)),
+			DwellingHexes:   gs.halflingsDwellingHexes(),
+		})
 	}
 
 	if gs.PendingGoblinsCultSteps != nil {
@@ -1393,12 +1824,12 @@ func serializePendingDecision(gs *GameState) interface{} {
 		if allowed, ok := gs.PendingSpadeBuildAllowed[playerID]; ok {
 			canBuildDwelling = allowed
 		}
-		return map[string]interface{}{
-			"type":             "spade_followup",
-			"playerId":         playerID,
-			"spadesRemaining":  count,
-			"canBuildDwelling": canBuildDwelling,
-		}
+		return pendingDecisionMap(SpadeFollowupDecision{
+			PlayerID:         playerID,
+			SpadesRemaining:  count,
+			CanBuildDwelling: canBuildDwelling,
+			Targets:          serializePendingSpadeTargets(spadeTargets(playerID)),
+		})
 	}
 
 	if playerID, count := gs.GetPendingCultRewardSpadePlayer(); playerID != "" {
@@ -1406,6 +1837,10 @@ func serializePendingDecision(gs *GameState) interface{} {
 			"type":            "cult_reward_spade",
 			"playerId":        playerID,
 			"spadesRemaining": count,
+			"targets":         serializePendingSpadeTargets(spadeTargets(playerID)),
+			"order":           gs.CultRewardSpadeOrder,
+			"orderIndex":      gs.CultRewardSpadeIndex,
+			"canSkip":         true,
 		}
 	}
 
@@ -1450,12 +1885,26 @@ func serializeRiverwalkersPriestChoice(gs *GameState, pending *PendingRiverwalke
 		"playerId":         pending.PlayerID,
 		"priestsRemaining": pending.PriestsRemaining,
 		"reason":           pending.Reason,
-		"canTakePriest":    player != nil && gs.RemainingPriestCapacity(pending.PlayerID) > 0,
+		"canTakePriest":    player != nil && gs.PriestLedger(pending.PlayerID).CanGain(1),
 		"coins":            coins,
 		"terrainOptions":   options,
 	}
 }
 
+// serializeAvailableFactions lists selectable faction names during faction
+// selection, and nil otherwise.
+func serializeAvailableFactions(gs *GameState) interface{} {
+	if gs.Phase != PhaseFactionSelection {
+		return nil
+	}
+	available := AvailableFactions(gs)
+	names := make([]string, 0, len(available))
+	for _, f := range available {
+		names = append(names, f.String())
+	}
+	return names
+}
+
 func serializeAuctionState(as *AuctionState) interface{} {
 	if as == nil {
 		return nil
@@ -1,8 +1,14 @@
 package game
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/game/factions"
 	"github.com/lukev/tm_server/internal/models"
 )
 
@@ -58,3 +64,360 @@ func TestManagerExecuteActionWithMeta_RevisionAndIdempotency(t *testing.T) {
 		t.Fatalf("expected revision 1 after stale request, got %d", rev)
 	}
 }
+
+func TestManagerExportGame_RequiresRecordedHistory(t *testing.T) {
+	mgr := NewManager()
+	if err := mgr.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{RandomizeTurnOrder: false}); err != nil {
+		t.Fatalf("failed creating game: %v", err)
+	}
+	record := &RecordedAction{PlayerID: "p1", Type: "select_faction", Params: []byte(`{"faction":"Witches"}`)}
+	if _, err := mgr.ExecuteActionWithMeta("g1", &SelectFactionAction{PlayerID: "p1", FactionType: models.FactionWitches}, ActionMeta{
+		ExpectedRevision: -1,
+		SeatID:           "p1",
+		Record:           record,
+	}); err != nil {
+		t.Fatalf("recorded action failed: %v", err)
+	}
+
+	save, err := mgr.ExportGame("g1")
+	if err != nil {
+		t.Fatalf("expected export to succeed: %v", err)
+	}
+	if len(save.Actions) != 1 || save.Actions[0].Type != "select_faction" || save.Revision != 1 {
+		t.Fatalf("unexpected save file: %+v", save)
+	}
+
+	if err := mgr.ExecuteAction("g1", &SelectFactionAction{PlayerID: "p2", FactionType: models.FactionHalflings}); err != nil {
+		t.Fatalf("unrecorded action failed: %v", err)
+	}
+	if _, err := mgr.ExportGame("g1"); err == nil {
+		t.Fatalf("expected export to fail after an unrecorded action")
+	}
+
+	mgr.CreateGameWithState("g2", NewGameState())
+	if _, err := mgr.ExportGame("g2"); err == nil {
+		t.Fatalf("expected export to fail without recorded settings")
+	}
+}
+
+func TestManagerForceResolvePending_DeclinesAllLeeches(t *testing.T) {
+	gs := NewGameState()
+	mustAddPlayer(t, gs, "src", factions.NewNomads())
+	mustAddPlayer(t, gs, "dst1", factions.NewAuren())
+	mustAddPlayer(t, gs, "dst2", factions.NewGiants())
+	gs.Phase = PhaseAction
+	gs.TurnOrder = []string{"src", "dst1", "dst2"}
+	gs.PendingLeechOffers["dst1"] = []*PowerLeechOffer{{Amount: 2, VPCost: 1, FromPlayerID: "src", EventID: 1}}
+	gs.PendingLeechOffers["dst2"] = []*PowerLeechOffer{{Amount: 1, FromPlayerID: "src", EventID: 1}}
+
+	mgr := NewManager()
+	mgr.CreateGameWithState("g1", gs)
+
+	summaries := mgr.ListGameSummaries()
+	if len(summaries) != 1 || summaries[0].ID != "g1" || summaries[0].LastActivity.IsZero() {
+		t.Fatalf("unexpected summaries: %+v", summaries)
+	}
+	if wait := summaries[0].LeechWait; wait == nil || len(wait.PlayerIDs) != 2 || wait.NextPlayerID != "dst1" {
+		t.Fatalf("expected the summary to show the leech wait, got %+v", wait)
+	}
+
+	revision, results, err := mgr.ForceResolvePending("g1", ForceDeclineLeeches)
+	if err != nil {
+		t.Fatalf("force resolve: %v", err)
+	}
+	if revision != 2 || len(results) != 2 {
+		t.Fatalf("expected one revision and result per declined offer, got %d and %d", revision, len(results))
+	}
+	for _, playerID := range []string{"dst1", "dst2"} {
+		if got := len(gs.PendingLeechOffers[playerID]); got != 0 {
+			t.Fatalf("expected %s offers cleared, got %d", playerID, got)
+		}
+	}
+
+	if _, _, err := mgr.ForceResolvePending("g1", ForceDeclineLeeches); err == nil {
+		t.Fatalf("expected error when no leech offers remain")
+	}
+	if _, _, err := mgr.ForceResolvePending("g1", ForcedResolution("nope")); err == nil {
+		t.Fatalf("expected unknown resolution to be rejected")
+	}
+	if _, _, err := mgr.DumpGameState("g1"); err != nil {
+		t.Fatalf("dump game state: %v", err)
+	}
+}
+
+func TestManagerValidateAction_DoesNotExecute(t *testing.T) {
+	mgr := NewManager()
+	if err := mgr.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{RandomizeTurnOrder: false}); err != nil {
+		t.Fatalf("failed creating game: %v", err)
+	}
+
+	if err := mgr.ValidateAction("g1", &SelectFactionAction{PlayerID: "p1", FactionType: models.FactionWitches}, ActionMeta{}); err != nil {
+		t.Fatalf("expected valid faction selection, got %v", err)
+	}
+	if revision, _ := mgr.GetRevision("g1"); revision != 0 {
+		t.Fatalf("validation advanced revision to %d", revision)
+	}
+	gs, _ := mgr.GetGame("g1")
+	if gs.GetPlayer("p1").Faction != nil {
+		t.Fatalf("validation should not select a faction")
+	}
+
+	err := mgr.ValidateAction("g1", &SelectFactionAction{PlayerID: "p2", FactionType: models.FactionWitches}, ActionMeta{})
+	invalid, ok := err.(*ActionValidationError)
+	if !ok || invalid.Stage != "turn" {
+		t.Fatalf("expected out-of-turn selection to fail turn validation, got %v", err)
+	}
+	if code := ReasonCodeOf(err); code != ReasonNotYourTurn {
+		t.Fatalf("expected reason %s, got %s", ReasonNotYourTurn, code)
+	}
+
+	if _, err := mgr.ExecuteActionWithMeta("g1", &SelectFactionAction{PlayerID: "p1", FactionType: models.FactionWitches}, ActionMeta{ExpectedRevision: -1}); err != nil {
+		t.Fatalf("select faction: %v", err)
+	}
+	err = mgr.ValidateAction("g1", &SelectFactionAction{PlayerID: "p2", FactionType: models.FactionWitches}, ActionMeta{})
+	invalid, ok = err.(*ActionValidationError)
+	if !ok || invalid.Stage != "rules" {
+		t.Fatalf("expected duplicate faction to fail rules validation, got %v", err)
+	}
+	if code := ReasonCodeOf(err); code != ReasonFactionTaken {
+		t.Fatalf("expected reason %s, got %s", ReasonFactionTaken, code)
+	}
+}
+
+func TestReasonCodeOf_ClassifiesRuleFailures(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewHalflings() // Plains
+	if err := gs.AddPlayer("p1", faction); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+	player := gs.GetPlayer("p1")
+	player.Resources.Workers = 0
+	gs.Map.GetHex(board.NewHex(1, 1)).Building = &models.Building{
+		Type:       models.BuildingDwelling,
+		Faction:    faction.GetType(),
+		PlayerID:   "p1",
+		PowerValue: 1,
+	}
+	gs.Map.TransformTerrain(board.NewHex(1, 0), models.TerrainSwamp)
+
+	cases := []struct {
+		name   string
+		action Action
+		want   ReasonCode
+	}{
+		{"occupied", NewTransformAndBuildAction("p1", board.NewHex(1, 1), true, models.TerrainTypeUnknown), ReasonTerrainOccupied},
+		{"unreachable", NewTransformAndBuildAction("p1", board.NewHex(8, 4), false, models.TerrainTypeUnknown), ReasonNotReachable},
+		{"workers", NewTransformAndBuildAction("p1", board.NewHex(1, 0), false, models.TerrainTypeUnknown), ReasonInsufficientWorkers},
+		{"unknown player", NewTransformAndBuildAction("nobody", board.NewHex(1, 0), false, models.TerrainTypeUnknown), ReasonPlayerNotFound},
+	}
+	for _, tc := range cases {
+		err := tc.action.Validate(gs)
+		if got := ReasonCodeOf(err); got != tc.want {
+			t.Errorf("%s: expected %s, got %s (%v)", tc.name, tc.want, got, err)
+		}
+	}
+
+	if got := ReasonCodeOf(fmt.Errorf("wrapped: %w", ruleErrorf(ReasonWrongPhase, "nope"))); got != ReasonWrongPhase {
+		t.Fatalf("expected wrapped reason to be found, got %s", got)
+	}
+	if got := ReasonCodeOf(fmt.Errorf("free text")); got != ReasonUnspecified {
+		t.Fatalf("expected unspecified reason for plain errors, got %s", got)
+	}
+	if got := ReasonCodeOf(nil); got != "" {
+		t.Fatalf("expected empty reason for nil error, got %s", got)
+	}
+}
+
+func TestManagerSweepFinishedGames_ArchivesEvictsAndRehydrates(t *testing.T) {
+	mgr := NewManager()
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	mgr.now = func() time.Time { return clock }
+	if err := mgr.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{RandomizeTurnOrder: false}); err != nil {
+		t.Fatalf("failed creating game: %v", err)
+	}
+	record := &RecordedAction{PlayerID: "p1", Type: "select_faction", Params: []byte(`{"faction":"Witches"}`)}
+	if _, err := mgr.ExecuteActionWithMeta("g1", &SelectFactionAction{PlayerID: "p1", FactionType: models.FactionWitches}, ActionMeta{
+		ExpectedRevision: -1,
+		SeatID:           "p1",
+		Record:           record,
+	}); err != nil {
+		t.Fatalf("recorded action failed: %v", err)
+	}
+	mgr.games["g1"].Phase = PhaseEnd
+
+	mgr.CreateGameWithState("g2", NewGameState())
+	mgr.games["g2"].Phase = PhaseEnd
+
+	archive := NewFileArchive(t.TempDir())
+	mgr.SetArchivePolicy(&ArchivePolicy{
+		Archive: archive,
+		BuildAction: func(recorded RecordedAction) (Action, error) {
+			var params struct {
+				Faction string `json:"faction"`
+			}
+			if err := json.Unmarshal(recorded.Params, &params); err != nil {
+				return nil, err
+			}
+			return &SelectFactionAction{PlayerID: recorded.PlayerID, FactionType: models.FactionTypeFromString(params.Faction)}, nil
+		},
+		EvictAfter: time.Hour,
+	})
+
+	evicted, err := mgr.SweepFinishedGames()
+	if err == nil {
+		t.Fatalf("expected the unrecorded finished game to fail archiving")
+	}
+	if len(evicted) != 0 {
+		t.Fatalf("expected no eviction before the TTL, got %v", evicted)
+	}
+	if _, err := archive.Load("g1"); err != nil {
+		t.Fatalf("expected finished game to be archived on sweep: %v", err)
+	}
+
+	clock = clock.Add(2 * time.Hour)
+	evicted, _ = mgr.SweepFinishedGames()
+	if len(evicted) != 1 || evicted[0] != "g1" {
+		t.Fatalf("expected g1 to be evicted, got %v", evicted)
+	}
+	if _, ok := mgr.games["g1"]; ok {
+		t.Fatalf("expected g1 to be dropped from memory")
+	}
+	if _, ok := mgr.games["g2"]; !ok {
+		t.Fatalf("expected the unarchived game to stay in memory")
+	}
+
+	revision, ok := mgr.GetRevision("g1")
+	if !ok || revision != 1 {
+		t.Fatalf("expected g1 to be rehydrated at revision 1, got %d %v", revision, ok)
+	}
+	gs, ok := mgr.GetGame("g1")
+	if !ok || gs.GetPlayer("p1") == nil || gs.GetPlayer("p1").Faction == nil || gs.GetPlayer("p1").Faction.GetType() != models.FactionWitches {
+		t.Fatalf("expected rehydrated game to keep p1's faction")
+	}
+	if save, err := mgr.ExportGame("g1"); err != nil || len(save.Actions) != 1 {
+		t.Fatalf("expected rehydrated game to keep its history: %v", err)
+	}
+	if _, ok := mgr.GetGame("missing"); ok {
+		t.Fatalf("expected unknown game to stay missing")
+	}
+}
+
+// lockProbeArchive records whether the manager's lock was free while a save
+// file was written.
+type lockProbeArchive struct {
+	mgr       *Manager
+	stored    []string
+	lockTaken bool
+}
+
+func (a *lockProbeArchive) Store(save *SaveFile) error {
+	if !a.mgr.mu.TryLock() {
+		a.lockTaken = true
+	} else {
+		a.mgr.mu.Unlock()
+	}
+	a.stored = append(a.stored, save.GameID)
+	return nil
+}
+
+func (a *lockProbeArchive) Load(gameID string) (*SaveFile, error) {
+	return nil, ErrGameNotArchived
+}
+
+func TestManagerSweepFinishedGames_StoresOutsideTheLock(t *testing.T) {
+	mgr := NewManager()
+	if err := mgr.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{}); err != nil {
+		t.Fatalf("failed creating game: %v", err)
+	}
+	mgr.games["g1"].Phase = PhaseEnd
+	archive := &lockProbeArchive{mgr: mgr}
+	mgr.SetArchivePolicy(&ArchivePolicy{Archive: archive, EvictAfter: time.Hour})
+
+	if _, err := mgr.SweepFinishedGames(); err != nil {
+		t.Fatalf("sweep: %v", err)
+	}
+	if len(archive.stored) != 1 || archive.lockTaken {
+		t.Fatalf("expected g1 to be stored without the manager's lock, stored %v lock taken %v", archive.stored, archive.lockTaken)
+	}
+	if revision, ok := mgr.archivedRevision["g1"]; !ok || revision != mgr.revisions["g1"] {
+		t.Fatalf("expected g1 to be marked archived at its revision")
+	}
+}
+
+// countingArchive counts loads and fails every store with storeErr.
+type countingArchive struct {
+	storeErr error
+	loadErr  map[string]error
+	stores   int
+	loads    map[string]int
+}
+
+func (a *countingArchive) Store(save *SaveFile) error {
+	a.stores++
+	return a.storeErr
+}
+
+func (a *countingArchive) Load(gameID string) (*SaveFile, error) {
+	a.loads[gameID]++
+	if err, ok := a.loadErr[gameID]; ok {
+		return nil, err
+	}
+	return nil, ErrGameNotArchived
+}
+
+func TestManagerSweepFinishedGames_ReportsAFailedArchiveOnce(t *testing.T) {
+	mgr := NewManager()
+	if err := mgr.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{}); err != nil {
+		t.Fatalf("failed creating game: %v", err)
+	}
+	mgr.games["g1"].Phase = PhaseEnd
+	archive := &countingArchive{storeErr: fmt.Errorf("disk full"), loads: map[string]int{}}
+	mgr.SetArchivePolicy(&ArchivePolicy{Archive: archive, EvictAfter: time.Hour})
+
+	if _, err := mgr.SweepFinishedGames(); err == nil {
+		t.Fatalf("expected the failed store to be reported")
+	}
+	if _, err := mgr.SweepFinishedGames(); err != nil {
+		t.Fatalf("expected the unchanged game to be skipped, got %v", err)
+	}
+	if archive.stores != 1 {
+		t.Fatalf("expected one store attempt, got %d", archive.stores)
+	}
+
+	mgr.revisions["g1"]++
+	archive.storeErr = nil
+	if _, err := mgr.SweepFinishedGames(); err != nil {
+		t.Fatalf("expected the changed game to be archived again: %v", err)
+	}
+	if archive.stores != 2 {
+		t.Fatalf("expected the changed game to be stored, got %d stores", archive.stores)
+	}
+	if _, ok := mgr.archiveFailed["g1"]; ok {
+		t.Fatalf("expected the archived game to be no longer marked failed")
+	}
+}
+
+func TestManagerRehydrate_RemembersMissesAndReportsLoadErrors(t *testing.T) {
+	mgr := NewManager()
+	archive := &countingArchive{
+		loadErr: map[string]error{"broken": fmt.Errorf("corrupt save")},
+		loads:   map[string]int{},
+	}
+	mgr.SetArchivePolicy(&ArchivePolicy{
+		Archive:     archive,
+		BuildAction: func(RecordedAction) (Action, error) { return nil, fmt.Errorf("unused") },
+		EvictAfter:  time.Hour,
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, ok := mgr.GetGame("missing"); ok {
+			t.Fatalf("expected unknown game to stay missing")
+		}
+		if _, err := mgr.ExportGame("broken"); err == nil || !strings.Contains(err.Error(), "corrupt save") {
+			t.Fatalf("expected the load error to be reported, got %v", err)
+		}
+	}
+	if archive.loads["missing"] != 1 || archive.loads["broken"] != 1 {
+		t.Fatalf("expected each miss to reach the archive once, got %v", archive.loads)
+	}
+}
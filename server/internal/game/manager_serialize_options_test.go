@@ -1,10 +1,12 @@
 package game
 
 import (
+	"fmt"
 	"math/rand"
 	"testing"
 
 	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/game/factions"
 	"github.com/lukev/tm_server/internal/models"
 )
 
@@ -216,3 +218,196 @@ func TestCreateGameWithOptions_SerializesCustomMapDisplayCoordinates(t *testing.
 		t.Fatalf("custom B1 display coord: got %v, want %q", got, "B1")
 	}
 }
+
+func TestCreateGameWithOptions_SeedReproducesSetup(t *testing.T) {
+	players := []string{"p1", "p2", "p3", "p4"}
+	seed := int64(4242)
+	create := func(m *Manager) *GameState {
+		t.Helper()
+		if err := m.CreateGameWithOptions("g1", players, CreateGameOptions{
+			RandomizeTurnOrder: true,
+			SetupMode:          SetupModeSnellman,
+			FireIceScoring:     FireIceFinalScoringRandom,
+			Seed:               &seed,
+		}); err != nil {
+			t.Fatalf("create game: %v", err)
+		}
+		gs, ok := m.GetGame("g1")
+		if !ok {
+			t.Fatalf("game missing")
+		}
+		return gs
+	}
+
+	first := create(NewManager())
+	second := create(NewManager())
+
+	if first.Seed != seed || second.Seed != seed {
+		t.Fatalf("seed not recorded: got %d and %d, want %d", first.Seed, second.Seed, seed)
+	}
+	for i := range first.TurnOrder {
+		if first.TurnOrder[i] != second.TurnOrder[i] {
+			t.Fatalf("turn order differs: %v vs %v", first.TurnOrder, second.TurnOrder)
+		}
+	}
+	for i := range first.ScoringTiles.Tiles {
+		if first.ScoringTiles.Tiles[i].Type != second.ScoringTiles.Tiles[i].Type {
+			t.Fatalf("scoring tile %d differs: %v vs %v", i, first.ScoringTiles.Tiles[i].Type, second.ScoringTiles.Tiles[i].Type)
+		}
+	}
+	if len(first.BonusCards.Available) != len(second.BonusCards.Available) {
+		t.Fatalf("bonus card count differs: %d vs %d", len(first.BonusCards.Available), len(second.BonusCards.Available))
+	}
+	for card := range first.BonusCards.Available {
+		if _, ok := second.BonusCards.Available[card]; !ok {
+			t.Fatalf("bonus card %v missing from second game", card)
+		}
+	}
+	if first.FireIceFinalScoringTile != second.FireIceFinalScoringTile {
+		t.Fatalf("fire & ice tile differs: %q vs %q", first.FireIceFinalScoringTile, second.FireIceFinalScoringTile)
+	}
+}
+
+func TestCreateGameWithOptions_RecordsDrawnSeed(t *testing.T) {
+	manager := NewManager()
+	manager.SetSeedSource(func() int64 { return 99 })
+	if err := manager.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+
+	gs, ok := manager.GetGame("g1")
+	if !ok || gs.Seed != 99 {
+		t.Fatalf("expected drawn seed 99 to be recorded")
+	}
+	state := manager.SerializeGameState("g1")
+	if seed, ok := state["seed"]; !ok || seed != nil {
+		t.Fatalf("expected the seed hidden while the game runs, got %v", seed)
+	}
+	gs.Phase = PhaseEnd
+	state = manager.SerializeGameState("g1")
+	if seed, _ := state["seed"].(float64); seed != 99 {
+		t.Fatalf("expected the seed revealed once the game ends, got %v", state["seed"])
+	}
+}
+
+func TestAnalyzeMap_ReportsReachabilityBuildabilityAndBridges(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewHalflings() // Plains
+	if err := gs.AddPlayer("p1", faction); err != nil {
+		t.Fatalf("add player: %v", err)
+	}
+	player := gs.GetPlayer("p1")
+	player.Resources.Coins = 20
+	player.Resources.Workers = 20
+	gs.Map.GetHex(board.NewHex(1, 1)).Building = &models.Building{
+		Type:       models.BuildingDwelling,
+		Faction:    faction.GetType(),
+		PlayerID:   "p1",
+		PowerValue: 1,
+	}
+	gs.Map.TransformTerrain(board.NewHex(1, 0), models.TerrainSwamp)
+	gs.Map.Bridges[board.NewBridgeKey(board.NewHex(0, 0), board.NewHex(1, -2))] = "p1"
+
+	analysis := gs.AnalyzeMap()
+	if len(analysis.Hexes) != len(gs.Map.Hexes) {
+		t.Fatalf("expected %d analyzed hexes, got %d", len(gs.Map.Hexes), len(analysis.Hexes))
+	}
+
+	home := analysis.Hexes["1,1"]
+	if home.Building == nil || home.Building.OwnerPlayerID != "p1" || home.Building.Type != models.BuildingDwelling {
+		t.Fatalf("expected p1 dwelling on 1,1, got %+v", home.Building)
+	}
+	if home.Players["p1"].Buildable {
+		t.Fatalf("occupied hex must not be buildable")
+	}
+
+	neighbor := analysis.Hexes["1,0"]
+	if !neighbor.Players["p1"].Reachable || !neighbor.Players["p1"].Buildable {
+		t.Fatalf("expected adjacent swamp to be reachable and buildable, got %+v", neighbor.Players["p1"])
+	}
+
+	far, ok := analysis.Hexes["8,4"]
+	if !ok {
+		t.Fatalf("expected 8,4 in analysis")
+	}
+	if far.Players["p1"].Reachable || far.Players["p1"].Buildable {
+		t.Fatalf("expected distant hex to be out of reach, got %+v", far.Players["p1"])
+	}
+
+	bridged := analysis.Hexes["0,0"]
+	if len(bridged.Bridges) != 1 || bridged.Bridges[0].To != (HexCoord{Q: 1, R: -2}) || bridged.Bridges[0].OwnerPlayerID != "p1" {
+		t.Fatalf("expected bridge from 0,0 to 1,-2, got %+v", bridged.Bridges)
+	}
+
+	for key, hex := range analysis.Hexes {
+		if hex.IsRiver != gs.Map.IsRiver(board.NewHex(hex.Coord.Q, hex.Coord.R)) {
+			t.Fatalf("river flag mismatch for %s", key)
+		}
+		if hex.IsRiver && hex.Players["p1"].Buildable {
+			t.Fatalf("river hex %s must not be buildable", key)
+		}
+	}
+}
+
+func TestSerializeGameStateWithMapAnalysis_AddsAnalysisOnlyWhenRequested(t *testing.T) {
+	manager := NewManager()
+	if err := manager.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{SetupMode: SetupModeSnellman}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+
+	if _, ok := manager.SerializeGameState("g1")["mapAnalysis"]; ok {
+		t.Fatalf("plain state must not include mapAnalysis")
+	}
+	analysis, ok := manager.SerializeGameStateWithMapAnalysis("g1")["mapAnalysis"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected mapAnalysis in serialized state")
+	}
+	hexes, ok := analysis["hexes"].(map[string]interface{})
+	if !ok || len(hexes) == 0 {
+		t.Fatalf("expected analyzed hexes, got %v", analysis["hexes"])
+	}
+}
+
+func TestCreateGameWithOptions_TurnOrderPolicyShapesUpcomingTurnOrder(t *testing.T) {
+	manager := NewManager()
+	if err := manager.CreateGameWithOptions("g1", []string{"p1", "p2", "p3"}, CreateGameOptions{
+		TurnOrderPolicy: TurnOrderPolicyCyclicFromFirstPasser,
+	}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	if err := manager.CreateGameWithOptions("g2", []string{"p1", "p2"}, CreateGameOptions{
+		TurnOrderPolicy: "clockwise",
+	}); err == nil {
+		t.Fatalf("expected an unknown turn order policy to be rejected")
+	}
+
+	gs, _ := manager.GetGame("g1")
+	if gs.TurnOrderPolicy != TurnOrderPolicyCyclicFromFirstPasser {
+		t.Fatalf("expected the cyclic policy, got %q", gs.TurnOrderPolicy)
+	}
+	gs.Round = 2
+	gs.Phase = PhaseAction
+	gs.TurnOrder = []string{"p1", "p2", "p3"}
+	gs.PassOrder = []string{"p2"}
+
+	state := manager.SerializeGameState("g1")
+	if got, want := fmt.Sprint(state["upcomingTurnOrder"]), "[p2 p3 p1]"; got != want {
+		t.Fatalf("cyclic upcomingTurnOrder: got %v, want %v", got, want)
+	}
+
+	gs.TurnOrderPolicy = TurnOrderPolicyPassOrder
+	gs.PassOrder = []string{"p3"}
+	state = manager.SerializeGameState("g1")
+	if got, want := fmt.Sprint(state["upcomingTurnOrder"]), "[p3 p1 p2]"; got != want {
+		t.Fatalf("pass order upcomingTurnOrder: got %v, want %v", got, want)
+	}
+
+	gs.PassOrder = []string{"p3", "p1", "p2"}
+	gs.StartNewRound()
+	if got, want := fmt.Sprint(gs.TurnOrder), "[p3 p1 p2]"; got != want {
+		t.Fatalf("next round turn order: got %v, want %v", got, want)
+	}
+	if state := manager.SerializeGameState("g1"); state["upcomingTurnOrder"] != nil {
+		t.Fatalf("expected no upcomingTurnOrder outside the action phase, got %v", state["upcomingTurnOrder"])
+	}
+}
@@ -0,0 +1,124 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+// MapAnalysis is a render-oriented view of the board. It carries the
+// adjacency and shipping results front-ends would otherwise have to
+// reimplement.
+type MapAnalysis struct {
+	// Hexes is keyed by "q,r", matching the hexes of the serialized state.
+	Hexes map[string]HexAnalysis `json:"hexes"`
+	// Networks lists each player's building clusters and how close they are
+	// to founding a town.
+	Networks map[string][]BuildingCluster `json:"networks"`
+}
+
+// HexCoord is an axial hex coordinate.
+type HexCoord struct {
+	Q int `json:"q"`
+	R int `json:"r"`
+}
+
+// HexAnalysis describes one hex of the board.
+type HexAnalysis struct {
+	Coord    HexCoord                  `json:"coord"`
+	Terrain  models.TerrainType        `json:"terrain"`
+	IsRiver  bool                      `json:"isRiver"`
+	Building *HexBuildingAnalysis      `json:"building,omitempty"`
+	Bridges  []HexBridgeAnalysis       `json:"bridges"`
+	Players  map[string]HexPlayerFlags `json:"players"`
+}
+
+// HexBuildingAnalysis is the building standing on a hex.
+type HexBuildingAnalysis struct {
+	Type          models.BuildingType `json:"type"`
+	OwnerPlayerID string              `json:"ownerPlayerId"`
+	Faction       models.FactionType  `json:"faction"`
+}
+
+// HexBridgeAnalysis is a bridge incident to a hex.
+type HexBridgeAnalysis struct {
+	To            HexCoord `json:"to"`
+	OwnerPlayerID string   `json:"ownerPlayerId"`
+}
+
+// HexPlayerFlags are one player's relation to a hex.
+type HexPlayerFlags struct {
+	// Reachable reports whether the hex is directly or indirectly (shipping,
+	// bridges) adjacent to the player's buildings.
+	Reachable bool `json:"reachable"`
+	// Buildable reports whether the player could transform the hex and build a
+	// dwelling on it now, including affordability, if it were their turn.
+	Buildable bool `json:"buildable"`
+}
+
+// AnalyzeMap builds the MapAnalysis for the current state.
+func (gs *GameState) AnalyzeMap() *MapAnalysis {
+	analysis := &MapAnalysis{Hexes: make(map[string]HexAnalysis), Networks: make(map[string][]BuildingCluster)}
+	if gs == nil || gs.Map == nil {
+		return analysis
+	}
+	analysis.Networks = gs.AnalyzeBuildingNetworks()
+
+	bridgesByHex := make(map[board.Hex][]HexBridgeAnalysis)
+	for key, ownerID := range gs.Map.Bridges {
+		bridgesByHex[key.H1] = append(bridgesByHex[key.H1], HexBridgeAnalysis{To: hexCoordOf(key.H2), OwnerPlayerID: ownerID})
+		bridgesByHex[key.H2] = append(bridgesByHex[key.H2], HexBridgeAnalysis{To: hexCoordOf(key.H1), OwnerPlayerID: ownerID})
+	}
+
+	for hex, mapHex := range gs.Map.Hexes {
+		if mapHex == nil {
+			continue
+		}
+		isRiver := gs.Map.IsRiver(hex)
+		hexAnalysis := HexAnalysis{
+			Coord:   hexCoordOf(hex),
+			Terrain: mapHex.Terrain,
+			IsRiver: isRiver,
+			Bridges: bridgesByHex[hex],
+			Players: make(map[string]HexPlayerFlags, len(gs.Players)),
+		}
+		if hexAnalysis.Bridges == nil {
+			hexAnalysis.Bridges = []HexBridgeAnalysis{}
+		}
+		sort.Slice(hexAnalysis.Bridges, func(i, j int) bool {
+			a, b := hexAnalysis.Bridges[i].To, hexAnalysis.Bridges[j].To
+			if a.R != b.R {
+				return a.R < b.R
+			}
+			return a.Q < b.Q
+		})
+		if mapHex.Building != nil {
+			hexAnalysis.Building = &HexBuildingAnalysis{
+				Type:          mapHex.Building.Type,
+				OwnerPlayerID: mapHex.Building.PlayerID,
+				Faction:       mapHex.Building.Faction,
+			}
+		}
+		for playerID, player := range gs.Players {
+			// Seats without a faction yet have nowhere to build.
+			if player == nil || player.Faction == nil {
+				hexAnalysis.Players[playerID] = HexPlayerFlags{}
+				continue
+			}
+			flags := HexPlayerFlags{Reachable: gs.IsAdjacentToPlayerBuilding(hex, playerID)}
+			if mapHex.Building == nil && !isRiver {
+				action := NewTransformAndBuildAction(playerID, hex, true, models.TerrainTypeUnknown)
+				flags.Buildable = action.Validate(gs) == nil
+			}
+			hexAnalysis.Players[playerID] = flags
+		}
+		analysis.Hexes[fmt.Sprintf("%d,%d", hex.Q, hex.R)] = hexAnalysis
+	}
+	return analysis
+}
+
+func hexCoordOf(hex board.Hex) HexCoord {
+	return HexCoord{Q: hex.Q, R: hex.R}
+}
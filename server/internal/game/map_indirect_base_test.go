@@ -135,3 +135,71 @@ func TestBonusCard_ShippingBonus_FakirsNoEffect(t *testing.T) {
 		t.Fatal("player should have bonus card")
 	}
 }
+
+func TestBonusCard_ShippingBonus_EndsWhenCardReturnedOnPass(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewAuren()
+	gs.AddPlayer("player1", faction)
+	gs.AddPlayer("player2", factions.NewNomads())
+	gs.TurnOrder = []string{"player1", "player2"}
+	gs.CurrentPlayerIndex = 0
+	gs.Phase = PhaseAction
+	gs.Round = 2
+	player := gs.GetPlayer("player1")
+	player.ShippingLevel = 1
+
+	gs.BonusCards.SetAvailableBonusCards([]BonusCardType{BonusCardShipping, BonusCardPriest, BonusCard6Coins})
+	gs.BonusCards.TakeBonusCard("player1", BonusCardShipping)
+	// The card was taken when passing last round.
+	gs.BonusCards.PlayerHasCard = make(map[string]bool)
+
+	b1 := board.NewHex(0, 1)
+	gs.Map.GetHex(b1).Terrain = faction.GetHomeTerrain()
+	gs.Map.GetHex(b1).Building = &models.Building{
+		Type:       models.BuildingDwelling,
+		Faction:    faction.GetType(),
+		PlayerID:   "player1",
+		PowerValue: 1,
+	}
+	// b2 requires shipping=2 to reach from b1
+	b2 := board.NewHex(3, 1)
+
+	if got := gs.EffectiveShippingLevel("player1"); got != 2 {
+		t.Fatalf("effective shipping while holding the card = %d, want 2", got)
+	}
+	if !gs.IsAdjacentToPlayerBuilding(b2, "player1") {
+		t.Fatal("expected b2 to be reachable while the shipping card is held")
+	}
+
+	// Cult reward spades never use the bonus card shipping.
+	gs.PendingCultRewardSpades = map[string]int{"player1": 1}
+	if err := NewUseCultSpadeAction("player1", b2).Validate(gs); ReasonCodeOf(err) != ReasonNotReachable {
+		t.Fatalf("cult spade on b2 error = %v, want %s", err, ReasonNotReachable)
+	}
+	gs.PendingCultRewardSpades = nil
+
+	priest := BonusCardPriest
+	if err := NewPassAction("player1", &priest).Execute(gs); err != nil {
+		t.Fatalf("pass: %v", err)
+	}
+	if got := gs.EffectiveShippingLevel("player1"); got != 1 {
+		t.Fatalf("effective shipping after returning the card = %d, want 1", got)
+	}
+	if gs.IsAdjacentToPlayerBuilding(b2, "player1") {
+		t.Fatal("expected b2 to be unreachable once the shipping card is returned")
+	}
+
+	// The returned card is available to others with the same temporary effect.
+	player2 := gs.GetPlayer("player2")
+	player2.ShippingLevel = 1
+	shipping := BonusCardShipping
+	if err := NewPassAction("player2", &shipping).Execute(gs); err != nil {
+		t.Fatalf("player2 pass: %v", err)
+	}
+	if got := gs.EffectiveShippingLevel("player2"); got != 2 {
+		t.Fatalf("player2 effective shipping after taking the card = %d, want 2", got)
+	}
+	if got := gs.EffectiveShippingLevel("player1"); got != 1 {
+		t.Fatalf("player1 effective shipping after player2 took the card = %d, want 1", got)
+	}
+}
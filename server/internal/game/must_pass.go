@@ -0,0 +1,94 @@
+package game
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// MustPassDetector reports whether playerID, whose main turn it is, has no
+// legal action left but passing (and free conversions). The legal-move
+// generator lives in az/actions, which imports this package, so live servers
+// inject it with SetMustPassDetector.
+type MustPassDetector func(gs *GameState, playerID string) bool
+
+// SetMustPassDetector enables must-pass detection: after every action the
+// stuck player, if any, is flagged in mustPassPlayerId, and players with the
+// autoPassWhenStuck option pass automatically.
+func (m *Manager) SetMustPassDetector(detect MustPassDetector) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mustPass = detect
+}
+
+// mainTurnPlayerID returns the player due to take a main action, or "" while
+// anything else (a pending decision, another phase) is awaited.
+func mainTurnPlayerID(gs *GameState) string {
+	if gs == nil || gs.Phases().Current() != PhaseAction || serializePendingDecisionWith(gs, noSpadeTargets) != nil {
+		return ""
+	}
+	player := gs.GetCurrentPlayer()
+	if player == nil || player.HasPassed {
+		return ""
+	}
+	return player.ID
+}
+
+// refreshMustPassLocked re-evaluates the must-pass flag of gameID and runs the
+// automatic pass of a stuck player who opted in. The pass is recorded like a
+// client action so exported games replay without a detector.
+func (m *Manager) refreshMustPassLocked(gameID string, gs *GameState) error {
+	gs.MustPassPlayerID = ""
+	if m.mustPass == nil {
+		return nil
+	}
+	playerID := mainTurnPlayerID(gs)
+	if playerID == "" || !m.mustPass(gs, playerID) {
+		return nil
+	}
+	gs.MustPassPlayerID = playerID
+	if !gs.GetPlayer(playerID).Options.AutoPassWhenStuck {
+		return nil
+	}
+
+	pass, record := automaticPass(gs, playerID)
+	if pass == nil {
+		return nil
+	}
+	_, err := m.executeActionLocked(gameID, pass, ActionMeta{ExpectedRevision: -1, Record: record})
+	return err
+}
+
+// automaticPass builds the pass of a stuck player, taking the available bonus
+// card with the most coins on it (lowest card first on ties). It returns nil
+// if no pass is legal.
+func automaticPass(gs *GameState, playerID string) (*PassAction, *RecordedAction) {
+	if gs.Round >= 6 || gs.BonusCards == nil {
+		pass := NewPassAction(playerID, nil)
+		if pass.Validate(gs) != nil {
+			return nil, nil
+		}
+		return pass, &RecordedAction{PlayerID: playerID, Type: "pass"}
+	}
+
+	cards := make([]BonusCardType, 0, len(gs.BonusCards.Available))
+	for card := range gs.BonusCards.Available {
+		cards = append(cards, card)
+	}
+	sort.Slice(cards, func(i, j int) bool {
+		ci, cj := gs.BonusCards.Available[cards[i]], gs.BonusCards.Available[cards[j]]
+		if ci != cj {
+			return ci > cj
+		}
+		return cards[i] < cards[j]
+	})
+	for _, card := range cards {
+		card := card
+		pass := NewPassAction(playerID, &card)
+		if pass.Validate(gs) != nil {
+			continue
+		}
+		params, _ := json.Marshal(map[string]int{"bonusCard": int(card)})
+		return pass, &RecordedAction{PlayerID: playerID, Type: "pass", Params: params}
+	}
+	return nil, nil
+}
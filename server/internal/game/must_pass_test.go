@@ -0,0 +1,79 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/factions"
+)
+
+func newMustPassTestGame(t *testing.T) (*Manager, *GameState) {
+	t.Helper()
+	gs := newActionTestGame(t, 2, testSeat{"actor", factions.NewCultists()}, testSeat{"stuck", factions.NewWitches()})
+	gs.BonusCards.Available = map[BonusCardType]int{BonusCardPriest: 0, BonusCardShipping: 2, BonusCard6Coins: 1}
+	gs.GetPlayer("actor").Resources.Priests = 1
+	gs.GetPlayer("actor").Options.ConfirmActions = false
+
+	mgr := NewManager()
+	mgr.SetMustPassDetector(func(gs *GameState, playerID string) bool { return playerID == "stuck" })
+	mgr.CreateGameWithState("g1", gs)
+	return mgr, gs
+}
+
+func actorSendsPriest(t *testing.T, mgr *Manager) *ActionResult {
+	t.Helper()
+	result, err := mgr.ExecuteActionWithMeta("g1", &SendPriestToCultAction{
+		BaseAction:    BaseAction{Type: ActionSendPriestToCult, PlayerID: "actor"},
+		Track:         CultFire,
+		SpacesToClimb: 1,
+	}, ActionMeta{ExpectedRevision: -1})
+	if err != nil {
+		t.Fatalf("actor send_priest: %v", err)
+	}
+	return result
+}
+
+func TestMustPass_FlagsStuckPlayerOnTheirTurn(t *testing.T) {
+	mgr, gs := newMustPassTestGame(t)
+
+	if gs.MustPassPlayerID != "" {
+		t.Fatalf("must-pass flag set before any action: %q", gs.MustPassPlayerID)
+	}
+	actorSendsPriest(t, mgr)
+
+	if gs.MustPassPlayerID != "stuck" {
+		t.Fatalf("mustPassPlayerId = %q, want stuck", gs.MustPassPlayerID)
+	}
+	if state := mgr.SerializeGameState("g1"); state["mustPassPlayerId"] != "stuck" {
+		t.Fatalf("serialized mustPassPlayerId = %v, want stuck", state["mustPassPlayerId"])
+	}
+	if gs.GetPlayer("stuck").HasPassed {
+		t.Fatal("stuck player passed without opting in to auto-pass")
+	}
+}
+
+func TestMustPass_AutoPassTakesRichestBonusCardAndIsRecorded(t *testing.T) {
+	mgr, gs := newMustPassTestGame(t)
+	gs.GetPlayer("stuck").Options.AutoPassWhenStuck = true
+
+	result := actorSendsPriest(t, mgr)
+
+	stuck := gs.GetPlayer("stuck")
+	if !stuck.HasPassed {
+		t.Fatal("expected the stuck player to pass automatically")
+	}
+	if card, _ := gs.BonusCards.GetPlayerCard("stuck"); card != BonusCardShipping {
+		t.Fatalf("auto-pass took %v, want the card with the most coins (%v)", card, BonusCardShipping)
+	}
+	if result.Revision != 2 {
+		t.Fatalf("revision = %d, want 2 after the action and the automatic pass", result.Revision)
+	}
+	history := mgr.history["g1"]
+	if len(history) != 1 || history[0].Type != "pass" || history[0].PlayerID != "stuck" {
+		t.Fatalf("expected the automatic pass to be recorded, got %+v", history)
+	}
+	var params map[string]int
+	if err := json.Unmarshal(history[0].Params, &params); err != nil || params["bonusCard"] != int(BonusCardShipping) {
+		t.Fatalf("recorded pass params = %s", history[0].Params)
+	}
+}
@@ -0,0 +1,159 @@
+package game
+
+import (
+	"sort"
+
+	"github.com/lukev/tm_server/internal/models"
+)
+
+// OpponentSummary is the at-a-glance view of one player that clients show for
+// their opponents, so they need not reconstruct it from the map. Field names
+// are part of the protocol and are read by the statistics module; add fields
+// rather than renaming them.
+type OpponentSummary struct {
+	PlayerID           string                `json:"playerId"`
+	Faction            models.FactionType    `json:"faction"`
+	Resources          OpponentResources     `json:"resources"`
+	ResourcesHidden    bool                  `json:"resourcesHidden,omitempty"`
+	BuildingsRemaining BuildingsRemaining    `json:"buildingsRemaining"`
+	Shipping           int                   `json:"shipping"`
+	Digging            int                   `json:"digging"`
+	Cults              CultPositions         `json:"cults"`
+	BonusCards         []BonusCardType       `json:"bonusCards"`
+	Favors             []FavorTileType       `json:"favors"`
+	Towns              []models.TownTileType `json:"towns"`
+	VictoryPoints      int                   `json:"victoryPoints"`
+	Passed             bool                  `json:"passed"`
+}
+
+// OpponentResources are a player's resources. Coins and Power are nil while
+// they are hidden from the viewer.
+type OpponentResources struct {
+	Coins   *int           `json:"coins,omitempty"`
+	Workers int            `json:"workers"`
+	Priests int            `json:"priests"`
+	Power   *OpponentPower `json:"power,omitempty"`
+}
+
+// OpponentPower is the content of a player's three power bowls.
+type OpponentPower struct {
+	PowerI   int `json:"powerI"`
+	PowerII  int `json:"powerII"`
+	PowerIII int `json:"powerIII"`
+}
+
+// BuildingsRemaining counts the buildings of each type a player has not built yet.
+type BuildingsRemaining struct {
+	Dwellings     int `json:"dwellings"`
+	TradingHouses int `json:"tradingHouses"`
+	Temples       int `json:"temples"`
+	Sanctuaries   int `json:"sanctuaries"`
+	Strongholds   int `json:"strongholds"`
+}
+
+// CultPositions are a player's positions on the four cult tracks.
+type CultPositions struct {
+	Fire  int `json:"fire"`
+	Water int `json:"water"`
+	Earth int `json:"earth"`
+	Air   int `json:"air"`
+}
+
+// OpponentSummaries returns a summary of every player, in turn order (players
+// missing from it follow, sorted by ID).
+func (gs *GameState) OpponentSummaries() []OpponentSummary {
+	if gs == nil {
+		return nil
+	}
+	summaries := make([]OpponentSummary, 0, len(gs.Players))
+	for _, playerID := range gs.summaryPlayerOrder() {
+		summaries = append(summaries, gs.opponentSummary(playerID))
+	}
+	return summaries
+}
+
+func (gs *GameState) summaryPlayerOrder() []string {
+	order := make([]string, 0, len(gs.Players))
+	seen := make(map[string]bool, len(gs.Players))
+	for _, playerID := range gs.TurnOrder {
+		if _, ok := gs.Players[playerID]; ok && !seen[playerID] {
+			order = append(order, playerID)
+			seen[playerID] = true
+		}
+	}
+	rest := make([]string, 0, len(gs.Players))
+	for playerID := range gs.Players {
+		if !seen[playerID] {
+			rest = append(rest, playerID)
+		}
+	}
+	sort.Strings(rest)
+	return append(order, rest...)
+}
+
+func (gs *GameState) opponentSummary(playerID string) OpponentSummary {
+	player := gs.Players[playerID]
+	summary := OpponentSummary{
+		PlayerID:      playerID,
+		Shipping:      player.ShippingLevel,
+		Digging:       player.DiggingLevel,
+		VictoryPoints: player.VictoryPoints,
+		Passed:        player.HasPassed,
+		Cults: CultPositions{
+			Fire:  player.CultPositions[CultFire],
+			Water: player.CultPositions[CultWater],
+			Earth: player.CultPositions[CultEarth],
+			Air:   player.CultPositions[CultAir],
+		},
+		BonusCards: gs.BonusCards.GetPlayerCards(playerID),
+		Favors:     []FavorTileType{},
+		Towns:      append([]models.TownTileType{}, player.TownTiles...),
+	}
+	if player.Faction != nil {
+		summary.Faction = player.Faction.GetType()
+	}
+	if summary.BonusCards == nil {
+		summary.BonusCards = []BonusCardType{}
+	}
+	if gs.FavorTiles != nil {
+		summary.Favors = append(summary.Favors, gs.FavorTiles.GetPlayerTiles(playerID)...)
+	}
+	if player.Resources != nil {
+		coins := player.Resources.Coins
+		summary.Resources = OpponentResources{
+			Coins:   &coins,
+			Workers: player.Resources.Workers,
+			Priests: player.Resources.Priests,
+		}
+		if player.Resources.Power != nil {
+			summary.Resources.Power = &OpponentPower{
+				PowerI:   player.Resources.Power.Bowl1,
+				PowerII:  player.Resources.Power.Bowl2,
+				PowerIII: player.Resources.Power.Bowl3,
+			}
+		}
+	}
+
+	built := gs.countPlayerBuildings(playerID)
+	summary.BuildingsRemaining = BuildingsRemaining{
+		Dwellings:     buildingLimit(models.BuildingDwelling) - built[models.BuildingDwelling],
+		TradingHouses: buildingLimit(models.BuildingTradingHouse) - built[models.BuildingTradingHouse],
+		Temples:       buildingLimit(models.BuildingTemple) - built[models.BuildingTemple],
+		Sanctuaries:   buildingLimit(models.BuildingSanctuary) - built[models.BuildingSanctuary],
+		Strongholds:   buildingLimit(models.BuildingStronghold) - built[models.BuildingStronghold],
+	}
+	return summary
+}
+
+func (gs *GameState) countPlayerBuildings(playerID string) map[models.BuildingType]int {
+	counts := make(map[models.BuildingType]int)
+	if gs.Map == nil {
+		return counts
+	}
+	for _, mapHex := range gs.Map.Hexes {
+		if mapHex.Building != nil && mapHex.Building.PlayerID == playerID {
+			counts[mapHex.Building.Type]++
+		}
+	}
+	return counts
+}
@@ -0,0 +1,64 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/game/factions"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+func TestOpponentSummaries_ReportsPlayersInTurnOrder(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("nomads", factions.NewNomads())
+	gs.AddPlayer("giants", factions.NewGiants())
+	gs.TurnOrder = []string{"nomads", "giants"}
+
+	giants := gs.GetPlayer("giants")
+	giants.Resources.Coins = 11
+	giants.Resources.Workers = 4
+	giants.ShippingLevel = 2
+	giants.DiggingLevel = 1
+	giants.CultPositions[CultEarth] = 5
+	giants.HasPassed = true
+	giants.TownTiles = []models.TownTileType{models.TownTile5Points}
+	gs.BonusCards.PlayerCards["giants"] = BonusCardSpade
+	if err := gs.FavorTiles.TakeFavorTile("giants", FavorWater2); err != nil {
+		t.Fatalf("TakeFavorTile failed: %v", err)
+	}
+	gs.Map.PlaceBuilding(board.NewHex(0, 1), &models.Building{
+		Type: models.BuildingStronghold, Faction: models.FactionGiants, PlayerID: "giants", PowerValue: 3,
+	})
+	gs.Map.PlaceBuilding(board.NewHex(1, 0), &models.Building{
+		Type: models.BuildingDwelling, Faction: models.FactionGiants, PlayerID: "giants", PowerValue: 1,
+	})
+
+	summaries := gs.OpponentSummaries()
+	if len(summaries) != 2 || summaries[0].PlayerID != "nomads" || summaries[1].PlayerID != "giants" {
+		t.Fatalf("expected summaries for nomads then giants, got %+v", summaries)
+	}
+
+	summary := summaries[1]
+	if summary.Faction != models.FactionGiants || !summary.Passed {
+		t.Errorf("unexpected faction/passed: %+v", summary)
+	}
+	if summary.Resources.Coins == nil || *summary.Resources.Coins != 11 || summary.Resources.Workers != 4 || summary.Resources.Power == nil {
+		t.Errorf("unexpected resources: %+v", summary.Resources)
+	}
+	if summary.Shipping != 2 || summary.Digging != 1 || summary.Cults.Earth != 5 {
+		t.Errorf("unexpected shipping/digging/cults: %+v", summary)
+	}
+	if summary.BuildingsRemaining != (BuildingsRemaining{Dwellings: 7, TradingHouses: 4, Temples: 3, Sanctuaries: 1, Strongholds: 0}) {
+		t.Errorf("unexpected buildings remaining: %+v", summary.BuildingsRemaining)
+	}
+	if len(summary.BonusCards) != 1 || summary.BonusCards[0] != BonusCardSpade {
+		t.Errorf("unexpected bonus cards: %v", summary.BonusCards)
+	}
+	if len(summary.Favors) != 1 || summary.Favors[0] != FavorWater2 {
+		t.Errorf("unexpected favors: %v", summary.Favors)
+	}
+	if len(summary.Towns) != 1 || summary.Towns[0] != models.TownTile5Points {
+		t.Errorf("unexpected towns: %v", summary.Towns)
+	}
+
+}
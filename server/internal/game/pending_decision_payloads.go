@@ -0,0 +1,152 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PendingDecision is a typed pendingDecision payload. serializePendingDecision
+// builds every typed decision from these structs; their JSON fields plus
+// "type" are the wire schema. Decisions without a typed payload are still
+// serialized as plain maps.
+type PendingDecision interface {
+	// DecisionType is the payload's "type" field.
+	DecisionType() string
+}
+
+// LeechOfferDecision ("leech_offer"): PlayerID accepts or declines the
+// oldest of Offers. Responses: accept_leech, decline_leech.
+type LeechOfferDecision struct {
+	PlayerID string             `json:"playerId"`
+	Offers   []*PowerLeechOffer `json:"offers"`
+}
+
+// TownTileSelectionDecision ("town_tile_selection"): PlayerID picks the town
+// tile for a town they formed. Responses: select_town_tile.
+type TownTileSelectionDecision struct {
+	PlayerID string `json:"playerId"`
+}
+
+// SpadeTarget is a hex a pending spade may transform, with the terrains it may
+// become.
+type SpadeTarget struct {
+	Q        int   `json:"q"`
+	R        int   `json:"r"`
+	Terrains []int `json:"terrains"`
+}
+
+// SpadeFollowupDecision ("spade_followup"): PlayerID spends the
+// SpadesRemaining spades of a power or special action, optionally building a
+// dwelling on the transformed hex when CanBuildDwelling. Responses:
+// transform_build, discard_pending_spade.
+type SpadeFollowupDecision struct {
+	PlayerID         string        `json:"playerId"`
+	SpadesRemaining  int           `json:"spadesRemaining"`
+	CanBuildDwelling bool          `json:"canBuildDwelling"`
+	Targets          []SpadeTarget `json:"targets"`
+}
+
+// CultistsCultChoiceDecision ("cultists_cult_choice"): PlayerID picks the cult
+// track for one of Count queued Cultists advances. Responses:
+// select_cultists_track.
+type CultistsCultChoiceDecision struct {
+	PlayerID string `json:"playerId"`
+	Count    int    `json:"count"`
+}
+
+// DarklingsOrdinationDecision ("darklings_ordination"): PlayerID trades 0-3
+// workers for priests after building the Darklings stronghold. MaxWorkers is
+// the most workers that still gain a priest each, given the workers in hand
+// and the 7-priest limit. Responses: darklings_ordination.
+type DarklingsOrdinationDecision struct {
+	PlayerID   string `json:"playerId"`
+	MaxWorkers int    `json:"maxWorkers"`
+}
+
+// HalflingsSpadesDecision ("halflings_spades"): PlayerID spends the
+// SpadesRemaining spades of the Halflings stronghold on Targets, then may
+// build a dwelling on one of DwellingHexes, the transformed hexes still open
+// to one. Responses: halflings_apply_spade, halflings_build_dwelling,
+// halflings_skip_dwelling.
+type HalflingsSpadesDecision struct {
+	PlayerID        string        `json:"playerId"`
+	SpadesRemaining int           `json:"spadesRemaining"`
+	Targets         []SpadeTarget `json:"targets"`
+	DwellingHexes   []HexCoord    `json:"dwellingHexes"`
+}
+
+// TownCultTopChoiceDecision ("town_cult_top_choice"): PlayerID picks up to
+// MaxSelections of CandidateTracks to advance AdvanceAmount onto the top
+// space, when more tracks compete for it than keys allow. Responses:
+// select_town_cult_top.
+type TownCultTopChoiceDecision struct {
+	PlayerID        string      `json:"playerId"`
+	CandidateTracks []CultTrack `json:"candidateTracks"`
+	MaxSelections   int         `json:"maxSelections"`
+	AdvanceAmount   int         `json:"advanceAmount"`
+}
+
+func (LeechOfferDecision) DecisionType() string          { return "leech_offer" }
+func (TownTileSelectionDecision) DecisionType() string   { return "town_tile_selection" }
+func (SpadeFollowupDecision) DecisionType() string       { return "spade_followup" }
+func (CultistsCultChoiceDecision) DecisionType() string  { return "cultists_cult_choice" }
+func (DarklingsOrdinationDecision) DecisionType() string { return "darklings_ordination" }
+func (HalflingsSpadesDecision) DecisionType() string     { return "halflings_spades" }
+func (TownCultTopChoiceDecision) DecisionType() string   { return "town_cult_top_choice" }
+
+// typedPendingDecisions holds a zero value of every typed payload, by type.
+var typedPendingDecisions = []PendingDecision{
+	LeechOfferDecision{},
+	TownTileSelectionDecision{},
+	SpadeFollowupDecision{},
+	CultistsCultChoiceDecision{},
+	DarklingsOrdinationDecision{},
+	HalflingsSpadesDecision{},
+	TownCultTopChoiceDecision{},
+}
+
+// DecisionResponses lists the perform_action types that answer a
+// pendingDecision of decisionType.
+func DecisionResponses(decisionType string) []string {
+	return append([]string(nil), decisionResponses[decisionType]...)
+}
+
+// DecodePendingDecision parses a serialized pendingDecision into its typed
+// payload. Decisions without one are an error.
+func DecodePendingDecision(raw []byte) (PendingDecision, error) {
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, fmt.Errorf("invalid pending decision: %w", err)
+	}
+	for _, zero := range typedPendingDecisions {
+		if zero.DecisionType() != header.Type {
+			continue
+		}
+		decision := reflect.New(reflect.TypeOf(zero))
+		if err := json.Unmarshal(raw, decision.Interface()); err != nil {
+			return nil, fmt.Errorf("invalid %s decision: %w", header.Type, err)
+		}
+		return decision.Elem().Interface().(PendingDecision), nil
+	}
+	return nil, fmt.Errorf("pending decision %q has no typed payload", header.Type)
+}
+
+// pendingDecisionMap converts decision into the map form pendingDecision is
+// served in, keyed by the struct's JSON field names plus "type".
+func pendingDecisionMap(decision PendingDecision) map[string]interface{} {
+	value := reflect.ValueOf(decision)
+	out := make(map[string]interface{}, value.NumField()+1)
+	out["type"] = decision.DecisionType()
+	for i := 0; i < value.NumField(); i++ {
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		out[name] = value.Field(i).Interface()
+	}
+	return out
+}
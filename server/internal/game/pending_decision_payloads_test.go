@@ -0,0 +1,82 @@
+package game
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/factions"
+)
+
+func TestPendingDecisionPayloads_RoundTripThroughTheWireForm(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("p1", factions.NewCultists())
+	gs.AddPlayer("p2", factions.NewHalflings())
+	offer := &PowerLeechOffer{Amount: 2, CappedAmount: 2, VPCost: 1, FromPlayerID: "p2", EventID: 3}
+	gs.TurnOrder = []string{"p2", "p1"}
+	gs.PendingLeechOffers["p1"] = []*PowerLeechOffer{offer}
+
+	wire, ok := serializePendingDecision(gs).(map[string]interface{})
+	if !ok || wire["type"] != "leech_offer" || wire["playerId"] != "p1" {
+		t.Fatalf("expected a leech_offer for p1, got %v", serializePendingDecision(gs))
+	}
+	raw, err := json.Marshal(wire)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	decoded, err := DecodePendingDecision(raw)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := LeechOfferDecision{PlayerID: "p1", Offers: []*PowerLeechOffer{offer}}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("decoded %+v, want %+v", decoded, want)
+	}
+
+	if _, err := DecodePendingDecision([]byte(`{"type":"turn_confirmation","playerId":"p1"}`)); err == nil {
+		t.Fatalf("expected decisions without a typed payload to be rejected")
+	}
+	for _, decision := range typedPendingDecisions {
+		if len(DecisionResponses(decision.DecisionType())) == 0 {
+			t.Errorf("typed decision %s lists no responses", decision.DecisionType())
+		}
+	}
+}
+
+func TestPendingDecisionPayloads_DarklingsOrdinationCapsWorkers(t *testing.T) {
+	tests := []struct {
+		name             string
+		workers, priests int
+		onCultSpaces     int
+		want             int
+	}{
+		{name: "three at most", workers: 5, want: 3},
+		{name: "workers in hand", workers: 2, want: 2},
+		{name: "priest limit", workers: 5, priests: 5, want: 2},
+		{name: "priests on cult spaces count", workers: 5, priests: 4, onCultSpaces: 3, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := NewGameState()
+			gs.AddPlayer("p1", factions.NewDarklings())
+			player := gs.GetPlayer("p1")
+			player.Resources.Workers = tt.workers
+			player.Resources.Priests = tt.priests
+			gs.CultTracks.InitializePlayer("p1")
+			gs.CultTracks.PriestsOnActionSpaces["p1"][CultFire] = tt.onCultSpaces
+			gs.PendingDarklingsPriestOrdination = &PendingDarklingsPriestOrdination{PlayerID: "p1"}
+
+			raw, err := json.Marshal(serializePendingDecision(gs))
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			decoded, err := DecodePendingDecision(raw)
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if want := (DarklingsOrdinationDecision{PlayerID: "p1", MaxWorkers: tt.want}); decoded != want {
+				t.Fatalf("decision = %+v, want %+v", decoded, want)
+			}
+		})
+	}
+}
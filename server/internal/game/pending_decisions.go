@@ -0,0 +1,96 @@
+package game
+
+// decisionResponses lists, per pendingDecision type, the perform_action types
+// that answer it.
+var decisionResponses = map[string][]string{
+	"auction_nomination":         {"auction_nominate"},
+	"auction_bid":                {"auction_bid"},
+	"fast_auction_bid_matrix":    {"fast_auction_submit_bids"},
+	"setup_bonus_card":           {"setup_bonus_card"},
+	"town_cult_top_choice":       {"select_town_cult_top"},
+	"town_tile_selection":        {"select_town_tile"},
+	"darklings_ordination":       {"darklings_ordination"},
+	"leech_offer":                {"accept_leech", "decline_leech"},
+	"cultists_cult_choice":       {"select_cultists_track"},
+	"djinni_start_cult_choice":   {"select_djinni_start_cult_track"},
+	"riverwalkers_priest_choice": {"select_riverwalkers_priest_choice"},
+	"treasurers_deposit":         {"select_treasurers_deposit"},
+	"archivists_bonus_card":      {"select_archivists_bonus_card"},
+	"favor_tile_selection":       {"select_favor_tile"},
+	"halflings_spades":           {"halflings_apply_spade", "halflings_build_dwelling", "halflings_skip_dwelling"},
+	"goblins_cult_steps":         {"select_goblins_cult_track"},
+	"wisps_stronghold_dwelling":  {"wisps_stronghold_dwelling"},
+	"spade_followup":             {"transform_build", "discard_pending_spade"},
+	"cult_reward_spade":          {"use_cult_spade", "discard_pending_spade"},
+	"post_action_free_actions":   {"conversion", "burn_power", "special_action_use"},
+	"turn_confirmation":          {"confirm_turn", "undo_turn"},
+}
+
+// PendingDecisionsFor lists every decision outstanding for playerID in
+// gameID, for re-delivery when the player reconnects. See
+// GameState.PendingDecisionsFor.
+func (m *Manager) PendingDecisionsFor(gameID, playerID string) []map[string]interface{} {
+	m.rehydrate(gameID)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	gs := m.games[gameID]
+	if gs == nil {
+		return nil
+	}
+	return gs.pendingDecisionsFor(playerID, m.spadeTargetsLocked(gameID))
+}
+
+// PendingDecisionsFor lists the decisions addressed to playerID. The decision
+// the game is blocked on comes first with "active" set; leech offers, town
+// tiles and cult reward spades the player still owes behind it follow with
+// "active" unset. Each entry carries the perform_action types that answer it
+// under "responses".
+func (gs *GameState) PendingDecisionsFor(playerID string) []map[string]interface{} {
+	return gs.pendingDecisionsFor(playerID, gs.PendingSpadeTargets)
+}
+
+func (gs *GameState) pendingDecisionsFor(playerID string, spadeTargets func(playerID string) []PendingSpadeTarget) []map[string]interface{} {
+	decisions := []map[string]interface{}{}
+	seen := map[string]bool{}
+	if current, ok := serializePendingDecisionWith(gs, spadeTargets).(map[string]interface{}); ok && decisionAddressedTo(current, playerID) {
+		current["active"] = true
+		current["responses"] = decisionResponses[current["type"].(string)]
+		decisions = append(decisions, current)
+		seen[current["type"].(string)] = true
+	}
+
+	queued := func(decision map[string]interface{}) {
+		decisionType := decision["type"].(string)
+		if seen[decisionType] {
+			return
+		}
+		decision["playerId"] = playerID
+		decision["active"] = false
+		decision["responses"] = decisionResponses[decisionType]
+		decisions = append(decisions, decision)
+	}
+	if offers := gs.PendingLeechOffers[playerID]; len(offers) > 0 {
+		queued(pendingDecisionMap(LeechOfferDecision{Offers: offers}))
+	}
+	if towns := gs.PendingTownFormations[playerID]; len(towns) > 0 {
+		queued(map[string]interface{}{"type": "town_tile_selection", "count": len(towns)})
+	}
+	if spades := gs.PendingCultRewardSpades[playerID]; spades > 0 {
+		queued(map[string]interface{}{"type": "cult_reward_spade", "spadesRemaining": spades})
+	}
+	return decisions
+}
+
+func decisionAddressedTo(decision map[string]interface{}, playerID string) bool {
+	if decision["playerId"] == playerID {
+		return true
+	}
+	playerIDs, _ := decision["playerIds"].([]string)
+	for _, id := range playerIDs {
+		if id == playerID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/factions"
+)
+
+func TestPendingDecisionsFor_ListsActiveThenQueuedDecisions(t *testing.T) {
+	gs := NewGameState()
+	if err := gs.AddPlayer("p1", factions.NewCultists()); err != nil {
+		t.Fatalf("add p1: %v", err)
+	}
+	if err := gs.AddPlayer("p2", factions.NewNomads()); err != nil {
+		t.Fatalf("add p2: %v", err)
+	}
+	gs.TurnOrder = []string{"p1", "p2"}
+	gs.Phase = PhaseAction
+	gs.PendingTownFormations["p1"] = []*PendingTownFormation{{PlayerID: "p1"}}
+	gs.PendingCultRewardSpades = map[string]int{"p1": 1}
+	gs.PendingLeechOffers["p2"] = []*PowerLeechOffer{{Amount: 1, CappedAmount: 1, FromPlayerID: "p1"}}
+
+	p1 := gs.PendingDecisionsFor("p1")
+	if len(p1) != 2 {
+		t.Fatalf("p1 decisions = %v, want town tile then cult spade", p1)
+	}
+	if p1[0]["type"] != "town_tile_selection" || p1[0]["active"] != true {
+		t.Fatalf("first p1 decision = %v, want active town_tile_selection", p1[0])
+	}
+	if p1[1]["type"] != "cult_reward_spade" || p1[1]["active"] != false || p1[1]["spadesRemaining"] != 1 {
+		t.Fatalf("second p1 decision = %v, want queued cult_reward_spade", p1[1])
+	}
+
+	p2 := gs.PendingDecisionsFor("p2")
+	if len(p2) != 1 || p2[0]["type"] != "leech_offer" || p2[0]["active"] != false {
+		t.Fatalf("p2 decisions = %v, want queued leech_offer", p2)
+	}
+	if responses, _ := p2[0]["responses"].([]string); len(responses) != 2 {
+		t.Fatalf("leech responses = %v, want accept and decline", p2[0]["responses"])
+	}
+}
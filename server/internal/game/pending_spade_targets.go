@@ -0,0 +1,136 @@
+package game
+
+import (
+	"sort"
+
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+// PendingSpadeTarget is a hex a pending spade can legally be applied to, with
+// the terrains it may be transformed into.
+type PendingSpadeTarget struct {
+	Hex      board.Hex
+	Terrains []models.TerrainType
+}
+
+// pendingSpadeCandidateTerrains are the explicit targets tried for each hex.
+var pendingSpadeCandidateTerrains = []models.TerrainType{
+	models.TerrainPlains,
+	models.TerrainSwamp,
+	models.TerrainLake,
+	models.TerrainForest,
+	models.TerrainMountain,
+	models.TerrainWasteland,
+	models.TerrainDesert,
+	models.TerrainIce,
+	models.TerrainVolcano,
+}
+
+// PendingSpadeTargets lists every hex playerID can use a pending spade on,
+// sorted by coordinate. Follow-up spades (bonus card, power action) are
+// checked as transform-only TransformAndBuild actions, so partial use toward a
+// non-home terrain and any extra paid spades are covered; cult reward spades
+// are checked as UseCultSpade actions and Halflings stronghold spades as
+// ApplyHalflingsSpade actions. It returns nil when the player has no pending
+// spades.
+func (gs *GameState) PendingSpadeTargets(playerID string) []PendingSpadeTarget {
+	if gs == nil || gs.Map == nil {
+		return nil
+	}
+
+	var validate func(hex board.Hex, terrain models.TerrainType) bool
+	switch {
+	case gs.PendingSpades[playerID] > 0:
+		validate = func(hex board.Hex, terrain models.TerrainType) bool {
+			action := NewTransformAndBuildAction(playerID, hex, false, terrain)
+			return action.Validate(gs) == nil
+		}
+	case gs.PendingCultRewardSpades[playerID] > 0:
+		validate = func(hex board.Hex, terrain models.TerrainType) bool {
+			action := NewUseCultSpadeActionWithTerrain(playerID, hex, terrain)
+			return action.Validate(gs) == nil
+		}
+	case gs.PendingHalflingsSpades != nil && gs.PendingHalflingsSpades.PlayerID == playerID && gs.PendingHalflingsSpades.SpadesRemaining > 0:
+		validate = func(hex board.Hex, terrain models.TerrainType) bool {
+			action := &ApplyHalflingsSpadeAction{
+				BaseAction:    BaseAction{Type: ActionApplyHalflingsSpade, PlayerID: playerID},
+				TargetHex:     hex,
+				TargetTerrain: terrain,
+			}
+			return action.Validate(gs) == nil
+		}
+	default:
+		return nil
+	}
+
+	var targets []PendingSpadeTarget
+	for hex, mapHex := range gs.Map.Hexes {
+		if mapHex == nil || mapHex.Building != nil || mapHex.Terrain == models.TerrainRiver {
+			continue
+		}
+		var terrains []models.TerrainType
+		for _, terrain := range pendingSpadeCandidateTerrains {
+			if terrain != mapHex.Terrain && validate(hex, terrain) {
+				terrains = append(terrains, terrain)
+			}
+		}
+		if len(terrains) > 0 {
+			targets = append(targets, PendingSpadeTarget{Hex: hex, Terrains: terrains})
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Hex.R != targets[j].Hex.R {
+			return targets[i].Hex.R < targets[j].Hex.R
+		}
+		return targets[i].Hex.Q < targets[j].Hex.Q
+	})
+	return targets
+}
+
+// noSpadeTargets skips enumerating targets where only the kind of pending
+// decision matters.
+func noSpadeTargets(string) []PendingSpadeTarget {
+	return nil
+}
+
+// cachedSpadeTargets are one player's pending spade targets in a game state
+// at one revision.
+type cachedSpadeTargets struct {
+	state    *GameState
+	revision int
+	playerID string
+	targets  []PendingSpadeTarget
+}
+
+// spadeTargetsLocked returns PendingSpadeTargets for gameID, enumerated once
+// per revision: every action bumps the revision, and undo swaps in another
+// state, so a cached list never outlives the state it describes. Callers hold
+// m.mu, for reading or writing.
+func (m *Manager) spadeTargetsLocked(gameID string) func(playerID string) []PendingSpadeTarget {
+	gs := m.games[gameID]
+	revision := m.revisions[gameID]
+	return func(playerID string) []PendingSpadeTarget {
+		m.spadeTargetsMu.Lock()
+		defer m.spadeTargetsMu.Unlock()
+		cached, ok := m.spadeTargets[gameID]
+		if ok && cached.state == gs && cached.revision == revision && cached.playerID == playerID {
+			return cached.targets
+		}
+		targets := gs.PendingSpadeTargets(playerID)
+		m.spadeTargets[gameID] = cachedSpadeTargets{state: gs, revision: revision, playerID: playerID, targets: targets}
+		return targets
+	}
+}
+
+func serializePendingSpadeTargets(targets []PendingSpadeTarget) []SpadeTarget {
+	out := make([]SpadeTarget, 0, len(targets))
+	for _, target := range targets {
+		terrains := make([]int, 0, len(target.Terrains))
+		for _, terrain := range target.Terrains {
+			terrains = append(terrains, int(terrain))
+		}
+		out = append(out, SpadeTarget{Q: target.Hex.Q, R: target.Hex.R, Terrains: terrains})
+	}
+	return out
+}
@@ -0,0 +1,220 @@
+package game
+
+import "strings"
+
+// PhaseChange is emitted whenever the game moves from one phase to another.
+type PhaseChange struct {
+	From  GamePhase `json:"from"`
+	To    GamePhase `json:"to"`
+	Round int       `json:"round"`
+}
+
+// PhaseController owns the phase of a game: it performs every transition
+// (recording a PhaseChange for each), answers which players are expected to
+// act, and produces the WRONG_PHASE errors of action validation. Actions and
+// turn flow read the phase through Current.
+type PhaseController struct {
+	gs *GameState
+}
+
+// Phases returns the phase controller of gs.
+func (gs *GameState) Phases() PhaseController {
+	return PhaseController{gs: gs}
+}
+
+// Current returns the phase the game is in.
+func (pc PhaseController) Current() GamePhase {
+	return pc.gs.Phase
+}
+
+// Enter moves the game to phase. Re-entering the current phase is a no-op.
+func (pc PhaseController) Enter(phase GamePhase) {
+	if pc.gs.Phase == phase {
+		return
+	}
+	pc.gs.phaseChanges = append(pc.gs.phaseChanges, PhaseChange{From: pc.gs.Phase, To: phase, Round: pc.gs.Round})
+	pc.gs.Phase = phase
+}
+
+// TakeChanges returns the transitions since the last call and forgets them.
+func (pc PhaseController) TakeChanges() []PhaseChange {
+	changes := pc.gs.phaseChanges
+	pc.gs.phaseChanges = nil
+	return changes
+}
+
+// Require returns a WRONG_PHASE rule error with message unless the game is in
+// phase.
+func (pc PhaseController) Require(phase GamePhase, message string) error {
+	if pc.gs.Phase != phase {
+		return ruleErrorf(ReasonWrongPhase, "%s", message)
+	}
+	return nil
+}
+
+// RequireSetup returns a WRONG_PHASE rule error with message unless the game
+// is in one of the setup subphases.
+func (pc PhaseController) RequireSetup(message string, subphases ...SetupSubphase) error {
+	for _, subphase := range subphases {
+		if pc.gs.SetupSubphase == subphase {
+			return nil
+		}
+	}
+	return ruleErrorf(ReasonWrongPhase, "%s", message)
+}
+
+// Reject returns the WRONG_PHASE rule error of an action the current phase
+// does not expect, for checks beyond the phase itself.
+func (pc PhaseController) Reject(format string, args ...interface{}) error {
+	return ruleErrorf(ReasonWrongPhase, format, args...)
+}
+
+// ValidateAction rejects actionType when the current phase does not accept
+// it from playerID. Phase-specific decisions are checked by the caller first,
+// so only turn actions reach the income, cleanup and end checks.
+func (pc PhaseController) ValidateAction(actionType ActionType, playerID string) error {
+	gs := pc.gs
+	switch gs.Phase {
+	case PhaseFactionSelection:
+		switch gs.SetupMode {
+		case SetupModeAuction:
+			if gs.AuctionState == nil || !gs.AuctionState.Active {
+				return ruleErrorf(ReasonWrongPhase, "regular auction setup is not active")
+			}
+			if gs.AuctionState.NominationPhase {
+				if actionType != ActionAuctionNominateFaction {
+					return ruleErrorf(ReasonWrongPhase, "auction nomination is required")
+				}
+			} else if actionType != ActionAuctionPlaceBid {
+				return ruleErrorf(ReasonWrongPhase, "regular auction bid is required")
+			}
+		case SetupModeFastAuction:
+			if gs.AuctionState == nil || !gs.AuctionState.Active {
+				return ruleErrorf(ReasonWrongPhase, "fast auction setup is not active")
+			}
+			if gs.AuctionState.NominationPhase {
+				if actionType != ActionAuctionNominateFaction {
+					return ruleErrorf(ReasonWrongPhase, "auction nomination is required")
+				}
+			} else if actionType != ActionFastAuctionSubmitBids {
+				return ruleErrorf(ReasonWrongPhase, "fast auction bid submission is required")
+			}
+		default:
+			if actionType != ActionSelectFaction {
+				return ruleErrorf(ReasonWrongPhase, "faction selection is required")
+			}
+		}
+	case PhaseSetup:
+		switch gs.SetupSubphase {
+		case SetupSubphaseBonusCards:
+			if actionType != ActionSetupBonusCard {
+				return ruleErrorf(ReasonWrongPhase, "setup bonus card selection is required")
+			}
+		case SetupSubphaseDwellings:
+			if actionType != ActionSetupDwelling {
+				return ruleErrorf(ReasonWrongPhase, "setup dwelling placement is required")
+			}
+			if expected := gs.currentSetupDwellingPlayerID(); expected != playerID {
+				return ruleErrorf(ReasonNotYourTurn, "setup dwelling placement expected from player %s", expected)
+			}
+		}
+	case PhaseIncome, PhaseCleanup:
+		if actionRequiresTurnOwnership(actionType) {
+			return ruleErrorf(ReasonWrongPhase, "turns can only be taken during the action phase")
+		}
+	case PhaseEnd:
+		if actionRequiresTurnOwnership(actionType) {
+			return ruleErrorf(ReasonWrongPhase, "the game is over")
+		}
+	}
+	return nil
+}
+
+// ActivePlayers returns the players the game is waiting on: the owner of the
+// first pending decision, otherwise whoever holds the turn in the current
+// phase. It is empty in phases nobody acts in.
+func (pc PhaseController) ActivePlayers() []string {
+	gs := pc.gs
+	if gs == nil {
+		return nil
+	}
+
+	if gs.Phase == PhaseFactionSelection && gs.AuctionState != nil && gs.AuctionState.Active {
+		if gs.AuctionState.NominationPhase {
+			if playerID := strings.TrimSpace(gs.AuctionState.GetCurrentBidder()); playerID != "" {
+				return []string{playerID}
+			}
+			return nil
+		}
+		if gs.SetupMode == SetupModeFastAuction {
+			return gs.AuctionState.GetPendingFastSubmitters()
+		}
+		if playerID := strings.TrimSpace(gs.AuctionState.GetCurrentBidder()); playerID != "" {
+			return []string{playerID}
+		}
+		return nil
+	}
+
+	if gs.Phase == PhaseSetup && gs.SetupSubphase == SetupSubphaseBonusCards {
+		if playerID := strings.TrimSpace(gs.currentSetupBonusPlayerID()); playerID != "" {
+			return []string{playerID}
+		}
+	}
+
+	if gs.PendingTownCultTopChoice != nil {
+		return []string{gs.PendingTownCultTopChoice.PlayerID}
+	}
+	if townPlayer := strings.TrimSpace(gs.GetPendingTownSelectionPlayer()); townPlayer != "" {
+		return []string{townPlayer}
+	}
+	if gs.PendingDarklingsPriestOrdination != nil {
+		return []string{gs.PendingDarklingsPriestOrdination.PlayerID}
+	}
+	if gs.HasPendingLeechOffers() {
+		if playerID := strings.TrimSpace(gs.GetNextBlockingLeechResponder()); playerID != "" {
+			return []string{playerID}
+		}
+	}
+	if gs.PendingCultistsCultSelection != nil {
+		return []string{gs.PendingCultistsCultSelection.PlayerID}
+	}
+	if gs.PendingDjinniStartingCultChoice != nil {
+		return []string{gs.PendingDjinniStartingCultChoice.PlayerID}
+	}
+	if gs.PendingRiverwalkersPriestChoice != nil {
+		return []string{gs.PendingRiverwalkersPriestChoice.PlayerID}
+	}
+	if gs.PendingTreasurersDeposit != nil {
+		return []string{gs.PendingTreasurersDeposit.PlayerID}
+	}
+	if gs.PendingArchivistsBonusSelection != nil {
+		return []string{gs.PendingArchivistsBonusSelection.PlayerID}
+	}
+	if gs.PendingFavorTileSelection != nil {
+		return []string{gs.PendingFavorTileSelection.PlayerID}
+	}
+	if gs.PendingHalflingsSpades != nil {
+		return []string{gs.PendingHalflingsSpades.PlayerID}
+	}
+	if playerID, _ := gs.GetPendingSpadeFollowupPlayer(); strings.TrimSpace(playerID) != "" {
+		return []string{playerID}
+	}
+	if playerID, _ := gs.GetPendingCultRewardSpadePlayer(); strings.TrimSpace(playerID) != "" {
+		return []string{playerID}
+	}
+	if playerID := strings.TrimSpace(gs.PendingFreeActionsPlayerID); playerID != "" {
+		return []string{playerID}
+	}
+	if playerID := strings.TrimSpace(gs.PendingTurnConfirmationPlayerID); playerID != "" {
+		return []string{playerID}
+	}
+
+	switch gs.Phase {
+	case PhaseFactionSelection, PhaseSetup, PhaseAction:
+		if current := gs.GetCurrentPlayer(); current != nil && strings.TrimSpace(current.ID) != "" {
+			return []string{current.ID}
+		}
+	}
+
+	return nil
+}
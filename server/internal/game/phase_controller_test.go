@@ -0,0 +1,68 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/factions"
+)
+
+func newPhaseControllerTestGame(t *testing.T) (*Manager, *GameState) {
+	t.Helper()
+	gs := newActionTestGame(t, 2, testSeat{"p1", factions.NewCultists()}, testSeat{"p2", factions.NewNomads()})
+	gs.BonusCards.Available = map[BonusCardType]int{BonusCardPriest: 0, BonusCardShipping: 0, BonusCard6Coins: 0}
+	for _, player := range gs.Players {
+		player.Options.ConfirmActions = false
+	}
+
+	mgr := NewManager()
+	mgr.CreateGameWithState("g1", gs)
+	return mgr, gs
+}
+
+func TestPhaseController_ReportsRoundTransitions(t *testing.T) {
+	mgr, gs := newPhaseControllerTestGame(t)
+
+	priest, coins := BonusCardPriest, BonusCard6Coins
+	result, err := mgr.ExecuteActionWithMeta("g1", NewPassAction("p1", &priest), ActionMeta{ExpectedRevision: -1})
+	if err != nil {
+		t.Fatalf("p1 pass: %v", err)
+	}
+	if len(result.PhaseChanges) != 0 {
+		t.Fatalf("first pass changed phase: %+v", result.PhaseChanges)
+	}
+
+	result, err = mgr.ExecuteActionWithMeta("g1", NewPassAction("p2", &coins), ActionMeta{ExpectedRevision: -1})
+	if err != nil {
+		t.Fatalf("p2 pass: %v", err)
+	}
+	want := []PhaseChange{
+		{From: PhaseAction, To: PhaseCleanup, Round: 2},
+		{From: PhaseCleanup, To: PhaseIncome, Round: 3},
+		{From: PhaseIncome, To: PhaseAction, Round: 3},
+	}
+	if len(result.PhaseChanges) != len(want) {
+		t.Fatalf("phase changes = %+v, want %+v", result.PhaseChanges, want)
+	}
+	for i := range want {
+		if result.PhaseChanges[i] != want[i] {
+			t.Fatalf("phase change %d = %+v, want %+v", i, result.PhaseChanges[i], want[i])
+		}
+	}
+	if got := gs.Phases().ActivePlayers(); len(got) != 1 || got[0] != "p1" {
+		t.Fatalf("active players = %v, want [p1]", got)
+	}
+}
+
+func TestPhaseController_RejectsTurnActionsOutsideActionPhase(t *testing.T) {
+	mgr, gs := newPhaseControllerTestGame(t)
+	gs.Phase = PhaseIncome
+
+	priest := BonusCardPriest
+	_, err := mgr.ExecuteActionWithMeta("g1", NewPassAction("p1", &priest), ActionMeta{ExpectedRevision: -1})
+	if code := ReasonCodeOf(err); code != ReasonWrongPhase {
+		t.Fatalf("reason = %q (%v), want %q", code, err, ReasonWrongPhase)
+	}
+	if got := gs.Phases().ActivePlayers(); len(got) != 0 {
+		t.Fatalf("active players during income = %v, want none", got)
+	}
+}
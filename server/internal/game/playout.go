@@ -0,0 +1,87 @@
+package game
+
+import "fmt"
+
+// playoutGameID names the game inside a playout's private manager.
+const playoutGameID = "__playout__"
+
+// PlayoutPolicy picks the moves of a playout. Legal move generation lives
+// with the bots (see az/actions), so the policy owns it.
+type PlayoutPolicy interface {
+	// NextAction returns the next action to apply to gs, or nil to stop the
+	// playout. It must not keep or modify gs.
+	NextAction(gs *GameState) Action
+}
+
+// PlayoutResult is the outcome of one playout.
+type PlayoutResult struct {
+	// Scores are the players' VP, including final scoring when the game ended.
+	Scores map[string]int
+	// Actions is the number of actions applied.
+	Actions int
+	// Completed reports that the game reached its end.
+	Completed bool
+}
+
+// Playout clones gs and plays policy's moves on the clone until the game
+// ends, the policy stops, or maxActions actions were applied (no limit when
+// maxActions <= 0). Actions run in place through a private manager that keeps
+// no undo snapshots, so after the first clone the state is only copied where
+// the rules copy it themselves, as when a player passes. Turn confirmations
+// are skipped and missing resources are covered by automatic conversions, as
+// in bot searches. gs itself is left untouched.
+func Playout(gs *GameState, policy PlayoutPolicy, maxActions int) (PlayoutResult, error) {
+	if gs == nil {
+		return PlayoutResult{}, fmt.Errorf("nil game state")
+	}
+	if policy == nil {
+		return PlayoutResult{}, fmt.Errorf("nil playout policy")
+	}
+
+	sim := gs.CloneForUndo()
+	sim.PendingTurnConfirmationPlayerID = ""
+	sim.PendingTurnConfirmationSnapshot = nil
+	for _, player := range sim.Players {
+		if player != nil {
+			player.Options.ConfirmActions = false
+		}
+	}
+	EnableAZAutoConversionsForClone(sim)
+	mgr := NewManager()
+	mgr.CreateGameWithState(playoutGameID, sim)
+
+	result := PlayoutResult{}
+	meta := ActionMeta{ExpectedRevision: -1, skipUndoSnapshot: true}
+	for sim.Phase != PhaseEnd && (maxActions <= 0 || result.Actions < maxActions) {
+		action := policy.NextAction(sim)
+		if action == nil {
+			break
+		}
+		if _, err := mgr.ExecuteActionWithMeta(playoutGameID, action, meta); err != nil {
+			return playoutScores(sim, result), fmt.Errorf("playout action %d (type %d by %s): %w", result.Actions, action.GetType(), action.GetPlayerID(), err)
+		}
+		result.Actions++
+		// The manager may swap in a new state (e.g. when restoring a snapshot).
+		if next, ok := mgr.GetGame(playoutGameID); ok && next != nil {
+			sim = next
+		}
+	}
+	return playoutScores(sim, result), nil
+}
+
+func playoutScores(gs *GameState, result PlayoutResult) PlayoutResult {
+	result.Completed = gs.Phase == PhaseEnd || gs.IsGameOver()
+	result.Scores = make(map[string]int, len(gs.Players))
+	var final map[string]*PlayerFinalScore
+	if result.Completed {
+		final = gs.CalculateFinalScoring()
+	}
+	for playerID, player := range gs.Players {
+		if score := final[playerID]; score != nil {
+			result.Scores[playerID] = score.TotalVP
+		} else if player != nil {
+			result.Scores[playerID] = player.VictoryPoints
+		}
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/factions"
+)
+
+// passPolicy passes for whoever holds the turn, without a bonus card.
+type passPolicy struct{}
+
+func (passPolicy) NextAction(gs *GameState) Action {
+	current := gs.GetCurrentPlayer()
+	if current == nil {
+		return nil
+	}
+	return NewPassAction(current.ID, nil)
+}
+
+func TestPlayout_PlaysCloneToTheEnd(t *testing.T) {
+	gs := newActionTestGame(t, 6, testSeat{"p1", factions.NewCultists()}, testSeat{"p2", factions.NewNomads()})
+	gs.GetPlayer("p1").VictoryPoints = 30
+
+	result, err := Playout(gs, passPolicy{}, 0)
+	if err != nil {
+		t.Fatalf("playout: %v", err)
+	}
+	if !result.Completed || result.Actions != 2 {
+		t.Fatalf("expected a completed 2-action playout, got %+v", result)
+	}
+	ended := gs.CloneForUndo()
+	for _, player := range ended.Players {
+		player.Options.ConfirmActions = false
+	}
+	mgr := NewManager()
+	mgr.CreateGameWithState("g1", ended)
+	for _, playerID := range []string{"p1", "p2"} {
+		if _, err := mgr.ExecuteActionWithMeta("g1", NewPassAction(playerID, nil), ActionMeta{ExpectedRevision: -1}); err != nil {
+			t.Fatalf("%s pass: %v", playerID, err)
+		}
+	}
+	for playerID, final := range ended.CalculateFinalScoring() {
+		if result.Scores[playerID] != final.TotalVP {
+			t.Fatalf("%s scored %d, want %d", playerID, result.Scores[playerID], final.TotalVP)
+		}
+	}
+	if gs.Phase != PhaseAction || gs.GetPlayer("p1").HasPassed {
+		t.Fatalf("playout changed the source state: phase %v, p1 passed %v", gs.Phase, gs.GetPlayer("p1").HasPassed)
+	}
+}
+
+func TestPlayout_StopsAtMaxActions(t *testing.T) {
+	gs := newActionTestGame(t, 6, testSeat{"p1", factions.NewCultists()}, testSeat{"p2", factions.NewNomads()})
+
+	result, err := Playout(gs, passPolicy{}, 1)
+	if err != nil {
+		t.Fatalf("playout: %v", err)
+	}
+	if result.Completed || result.Actions != 1 {
+		t.Fatalf("expected a truncated 1-action playout, got %+v", result)
+	}
+	if result.Scores["p1"] != gs.GetPlayer("p1").VictoryPoints {
+		t.Fatalf("truncated playout should report current VP, got %+v", result.Scores)
+	}
+}
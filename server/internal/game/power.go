@@ -28,6 +28,12 @@ func (ps *PowerSystem) TotalPower() int {
 	return ps.Bowl1 + ps.Bowl2 + ps.Bowl3
 }
 
+// LeechCapacity returns how much power can still be gained by cycling tokens:
+// each token in Bowl 1 can move twice (I->II->III), each token in Bowl 2 once.
+func (ps *PowerSystem) LeechCapacity() int {
+	return 2*ps.Bowl1 + ps.Bowl2
+}
+
 // GainPower adds power to the system
 // If there is power in bowl 1, it moves to bowl 2.
 // If there is power in bowl 2 but not bowl 1, then power cycles through Bowl 2 to Bowl 3
@@ -92,9 +92,15 @@ type PowerAction struct {
 	TargetHex     *board.Hex // Optional: for spade actions
 	BuildDwelling bool       // Optional: for spade actions
 	UseSkip       bool       // Optional: for spade actions (Fakirs/Dwarves skip)
+	// FollowupTargets spend the spades TargetHex leaves, in order, like
+	// transform_build follow-ups taken in the same request.
+	FollowupTargets []PowerSpadeTarget
 	// For bridge action, these fields specify the bridge endpoints
 	BridgeHex1 *board.Hex // Optional: for bridge action
 	BridgeHex2 *board.Hex // Optional: for bridge action
+	// Confirmed marks the power burned to pay for the action as confirmed
+	// (see PlayerOptions.ConfirmBurn).
+	Confirmed bool
 }
 
 // NewPowerAction creates a new power action
@@ -122,6 +128,29 @@ func NewPowerActionWithTransform(playerID string, actionType PowerActionType, ta
 	}
 }
 
+// PowerSpadeTarget is one hex a spade power action transforms to home terrain.
+type PowerSpadeTarget struct {
+	Hex           board.Hex
+	BuildDwelling bool
+	UseSkip       bool
+}
+
+// NewPowerActionWithTransforms creates a spade power action spending its
+// spades on targets in order. Spades the targets leave unused stay pending as
+// a spade follow-up.
+func NewPowerActionWithTransforms(playerID string, actionType PowerActionType, targets []PowerSpadeTarget) *PowerAction {
+	a := NewPowerAction(playerID, actionType)
+	if len(targets) == 0 {
+		return a
+	}
+	hex := targets[0].Hex
+	a.TargetHex = &hex
+	a.BuildDwelling = targets[0].BuildDwelling
+	a.UseSkip = targets[0].UseSkip
+	a.FollowupTargets = append([]PowerSpadeTarget(nil), targets[1:]...)
+	return a
+}
+
 // NewPowerActionWithBridge creates a power action for building a bridge
 func NewPowerActionWithBridge(playerID string, hex1, hex2 board.Hex) *PowerAction {
 	return &PowerAction{
@@ -140,12 +169,12 @@ func NewPowerActionWithBridge(playerID string, hex1, hex2 board.Hex) *PowerActio
 func (a *PowerAction) Validate(gs *GameState) error {
 	player := gs.GetPlayer(a.PlayerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", a.PlayerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", a.PlayerID)
 	}
 
 	// Check if this power action is still available
 	if !gs.PowerActions.IsAvailable(a.ActionType) && !(player.Faction != nil && player.Faction.GetType() == models.FactionYetis && player.HasStrongholdAbility) {
-		return fmt.Errorf("power action %v has already been taken this round", a.ActionType)
+		return ruleErrorf(ReasonActionSpaceTaken, "power action %v has already been taken this round", a.ActionType)
 	}
 
 	powerCost := getPowerActionCostForPlayer(player, a.ActionType)
@@ -154,7 +183,7 @@ func (a *PowerAction) Validate(gs *GameState) error {
 			return fmt.Errorf("only Chash Dallah with stronghold may pay coins for power actions")
 		}
 		if player.Resources.Coins < powerCost {
-			return fmt.Errorf("not enough coins for power action: need %d, have %d", powerCost, player.Resources.Coins)
+			return ruleErrorf(ReasonInsufficientCoins, "not enough coins for power action: need %d, have %d", powerCost, player.Resources.Coins)
 		}
 	} else {
 		requiredBurn := a.requiredAutoBurn(player)
@@ -163,7 +192,7 @@ func (a *PowerAction) Validate(gs *GameState) error {
 			canBurn = player.Resources.Power.CanBurnChildren(requiredBurn)
 		}
 		if requiredBurn > 0 && !canBurn {
-			return fmt.Errorf("not enough power for action: need %d in Bowl III, have %d and cannot auto-burn %d more from Bowl II", powerCost, player.Resources.Power.Bowl3, requiredBurn)
+			return ruleErrorf(ReasonInsufficientPower, "not enough power for action: need %d in Bowl III, have %d and cannot auto-burn %d more from Bowl II", powerCost, player.Resources.Power.Bowl3, requiredBurn)
 		}
 	}
 
@@ -176,7 +205,14 @@ func (a *PowerAction) Validate(gs *GameState) error {
 			if err := a.validateSpadeAction(gs, player); err != nil {
 				return err
 			}
+			if err := a.validateFollowupTargets(gs, player); err != nil {
+				return err
+			}
+		} else if len(a.FollowupTargets) > 0 {
+			return fmt.Errorf("spade power action cannot take transform targets for this faction")
 		}
+	} else if len(a.FollowupTargets) > 0 {
+		return fmt.Errorf("only spade power actions take transform targets")
 	}
 
 	// Validate bridge action
@@ -185,7 +221,7 @@ func (a *PowerAction) Validate(gs *GameState) error {
 			return err
 		}
 	}
-	if a.ActionType == PowerActionPriest && gs.RemainingPriestCapacity(a.PlayerID) < 1 && (!isRiverwalkers(player) || !gs.riverwalkersHasAffordableUnlockOption(player)) {
+	if a.ActionType == PowerActionPriest && !gs.PriestLedger(a.PlayerID).CanGain(1) && (!isRiverwalkers(player) || !gs.riverwalkersHasAffordableUnlockOption(player)) {
 		return fmt.Errorf("cannot take priest power action at the 7-priest limit")
 	}
 	if isProspectors(player) && (a.ActionType == PowerActionSpade1 || a.ActionType == PowerActionSpade2) {
@@ -193,7 +229,7 @@ func (a *PowerAction) Validate(gs *GameState) error {
 		if a.ActionType == PowerActionSpade2 {
 			requiredPriests = 2
 		}
-		if gs.RemainingPriestCapacity(a.PlayerID) < requiredPriests {
+		if !gs.PriestLedger(a.PlayerID).CanGain(requiredPriests) {
 			return fmt.Errorf("not enough priest capacity for prospectors spade action")
 		}
 	}
@@ -210,33 +246,63 @@ func (a *PowerAction) validateSpadeAction(gs *GameState, player *Player) error {
 	// This is similar to TransformAndBuild validation
 	mapHex := gs.Map.GetHex(*a.TargetHex)
 	if mapHex == nil {
-		return fmt.Errorf("hex does not exist: %v", a.TargetHex)
+		return ruleErrorf(ReasonInvalidHex, "hex does not exist: %v", a.TargetHex)
 	}
 	if mapHex.Building != nil {
-		return fmt.Errorf("hex already has a building")
+		return ruleErrorf(ReasonTerrainOccupied, "hex already has a building")
 	}
 
 	// Check adjacency (or skip range for Fakirs/Dwarves)
 	isAdjacent := gs.IsAdjacentToPlayerBuilding(*a.TargetHex, a.PlayerID)
-	if !isAdjacent && !a.UseSkip {
-		factionType := player.Faction.GetType()
-		if factionType == models.FactionDwarves || factionType == models.FactionFakirs {
-			// Match transform/build behavior: auto-enable skip when needed.
-			a.UseSkip = true
-		}
-	}
+	// Match transform/build behavior: skip is used only when actually needed.
+	a.UseSkip = resolveSkipUsage(player, isAdjacent, a.UseSkip)
 	if a.UseSkip {
 		if err := ValidateSkipAbility(gs, player, *a.TargetHex); err != nil {
 			return err
 		}
 	} else {
 		if !isAdjacent {
-			return fmt.Errorf("hex is not adjacent to player's buildings")
+			return ruleErrorf(ReasonNotReachable, "hex is not adjacent to player's buildings")
+		}
+	}
+	return nil
+}
+
+// validateFollowupTargets checks that FollowupTargets are distinct hexes and
+// that the spades left after TargetHex cover one for each of them. Adjacency
+// and costs are checked when each target runs.
+func (a *PowerAction) validateFollowupTargets(gs *GameState, player *Player) error {
+	if len(a.FollowupTargets) == 0 {
+		return nil
+	}
+	requiredSpades, err := a.requiredSpadesForTransform(gs, player)
+	if err != nil {
+		return err
+	}
+	leftSpades := a.freeSpades() - requiredSpades
+	if leftSpades < len(a.FollowupTargets) {
+		return fmt.Errorf("spade power action leaves %d spades for %d more transform targets", max(leftSpades, 0), len(a.FollowupTargets))
+	}
+	seen := map[board.Hex]bool{*a.TargetHex: true}
+	for _, target := range a.FollowupTargets {
+		if seen[target.Hex] {
+			return fmt.Errorf("spade power action targets hex %v twice", target.Hex)
+		}
+		seen[target.Hex] = true
+		if _, err := gs.ValidateHex(target.Hex); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+func (a *PowerAction) freeSpades() int {
+	if a.ActionType == PowerActionSpade2 {
+		return 2
+	}
+	return 1
+}
+
 func (a *PowerAction) validateBridgeAction(gs *GameState, player *Player) error {
 	// Check if player has bridges remaining (max 3)
 	if player.BridgesBuilt >= 3 {
@@ -267,6 +333,18 @@ func (a *PowerAction) validateBridgeAction(gs *GameState, player *Player) error
 
 // Execute performs the power action
 func (a *PowerAction) Execute(gs *GameState) error {
+	if len(a.FollowupTargets) > 0 {
+		// Follow-up targets run after the action is paid for, so try the whole
+		// action on a copy first: a rejected target must leave gs untouched.
+		trial := *a
+		if err := trial.execute(gs.CloneForUndo()); err != nil {
+			return err
+		}
+	}
+	return a.execute(gs)
+}
+
+func (a *PowerAction) execute(gs *GameState) error {
 	if err := a.Validate(gs); err != nil {
 		return err
 	}
@@ -352,10 +430,7 @@ func (a *PowerAction) Execute(gs *GameState) error {
 			return fmt.Errorf("spade action requires target hex")
 		}
 
-		freeSpadesFromAction := 1
-		if a.ActionType == PowerActionSpade2 {
-			freeSpadesFromAction = 2
-		}
+		freeSpadesFromAction := a.freeSpades()
 
 		requiredSpades, err := a.requiredSpadesForTransform(gs, player)
 		if err != nil {
@@ -387,12 +462,37 @@ func (a *PowerAction) Execute(gs *GameState) error {
 			}
 			gs.PendingSpadeBuildAllowed[a.PlayerID] = canBuildDwelling
 		}
+		if err := a.executeFollowupTargets(gs); err != nil {
+			return err
+		}
 	}
 
 	gs.NextTurn()
 	return nil
 }
 
+// executeFollowupTargets spends the pending spades the action left on
+// FollowupTargets, without advancing the turn in between.
+func (a *PowerAction) executeFollowupTargets(gs *GameState) error {
+	if len(a.FollowupTargets) == 0 {
+		return nil
+	}
+	suppressed := gs.SuppressTurnAdvance
+	gs.SuppressTurnAdvance = true
+	defer func() { gs.SuppressTurnAdvance = suppressed }()
+	for _, target := range a.FollowupTargets {
+		if gs.PendingSpades[a.PlayerID] <= 0 {
+			return fmt.Errorf("no spades left for transform target %v", target.Hex)
+		}
+		followup := NewTransformAndBuildAction(a.PlayerID, target.Hex, target.BuildDwelling, models.TerrainTypeUnknown)
+		followup.UseSkip = target.UseSkip
+		if err := followup.Execute(gs); err != nil {
+			return fmt.Errorf("transform target %v: %w", target.Hex, err)
+		}
+	}
+	return nil
+}
+
 func (a *PowerAction) requiredAutoBurn(player *Player) int {
 	if player == nil || player.Resources == nil || player.Resources.Power == nil {
 		return 0
@@ -449,7 +549,7 @@ func (a *PowerAction) requiredSpadesForTransform(gs *GameState, player *Player)
 
 	mapHex := gs.Map.GetHex(*a.TargetHex)
 	if mapHex == nil {
-		return 0, fmt.Errorf("hex does not exist: %v", *a.TargetHex)
+		return 0, ruleErrorf(ReasonInvalidHex, "hex does not exist: %v", *a.TargetHex)
 	}
 
 	targetTerrain := effectiveHomeTerrain(player)
@@ -490,6 +590,7 @@ func (a *PowerAction) executeTransformWithFreeSpades(gs *GameState, player *Play
 	if distance == 0 {
 		return fmt.Errorf("hex is already home terrain")
 	}
+	gs.recordPreviewSpades(requiredSpades)
 
 	remainingSpades := a.calculateRemainingSpades(requiredSpades, freeSpades)
 
@@ -531,7 +632,7 @@ func (a *PowerAction) paySpadeCosts(player *Player, remainingSpades int) error {
 		if player.Faction.GetType() == models.FactionDarklings {
 			priestsNeeded := remainingSpades
 			if player.Resources.Priests < priestsNeeded {
-				return fmt.Errorf("not enough priests: need %d, have %d", priestsNeeded, player.Resources.Priests)
+				return ruleErrorf(ReasonInsufficientPriests, "not enough priests: need %d, have %d", priestsNeeded, player.Resources.Priests)
 			}
 			player.Resources.Priests -= priestsNeeded
 
@@ -541,7 +642,7 @@ func (a *PowerAction) paySpadeCosts(player *Player, remainingSpades int) error {
 		} else if player.Faction.GetType() == models.FactionTheEnlightened {
 			powerNeeded := player.Faction.GetTerraformCost(remainingSpades)
 			if !player.Resources.Power.CanSpend(powerNeeded) {
-				return fmt.Errorf("not enough power: need %d, have %d", powerNeeded, player.Resources.Power.Bowl3)
+				return ruleErrorf(ReasonInsufficientPower, "not enough power: need %d, have %d", powerNeeded, player.Resources.Power.Bowl3)
 			}
 			if err := player.Resources.Power.SpendPower(powerNeeded); err != nil {
 				return err
@@ -550,7 +651,7 @@ func (a *PowerAction) paySpadeCosts(player *Player, remainingSpades int) error {
 			// Other factions pay workers
 			workersNeeded := player.Faction.GetTerraformCost(remainingSpades)
 			if player.Resources.Workers < workersNeeded {
-				return fmt.Errorf("not enough workers: need %d, have %d", workersNeeded, player.Resources.Workers)
+				return ruleErrorf(ReasonInsufficientWorkers, "not enough workers: need %d, have %d", workersNeeded, player.Resources.Workers)
 			}
 			player.Resources.Workers -= workersNeeded
 		}
@@ -571,7 +672,7 @@ func (a *PowerAction) awardSpadeBonuses(gs *GameState, player *Player, totalSpad
 		}
 
 		// Award faction-specific spade bonuses (Halflings VP, Alchemists power)
-		AwardFactionSpadeBonuses(player, spadesUsed)
+		AwardFactionSpadeBonuses(gs, player, spadesUsed)
 	}
 }
 
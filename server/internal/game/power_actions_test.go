@@ -452,6 +452,72 @@ func TestPowerAction_Spade2TwoHexes(t *testing.T) {
 	}
 }
 
+func TestPowerAction_Spade2TransformTargetsSpendBothSpades(t *testing.T) {
+	gs := NewGameState()
+	faction := factions.NewHalflings() // Plains
+	gs.AddPlayer("player1", faction)
+
+	player := gs.GetPlayer("player1")
+	player.Resources.Power.Bowl3 = 8
+	player.Resources.Coins = 20
+	player.Resources.Workers = 20
+
+	initialHex := board.NewHex(1, 1)
+	gs.Map.GetHex(initialHex).Building = &models.Building{
+		Type:       models.BuildingDwelling,
+		Faction:    faction.GetType(),
+		PlayerID:   "player1",
+		PowerValue: 1,
+	}
+	targetHex1 := board.NewHex(1, 0)
+	gs.Map.TransformTerrain(targetHex1, models.TerrainSwamp)
+	targetHex2 := board.NewHex(2, 1)
+	gs.Map.TransformTerrain(targetHex2, models.TerrainSwamp)
+	farHex := board.NewHex(10, 5)
+	gs.Map.TransformTerrain(farHex, models.TerrainSwamp)
+
+	// A target out of reach rejects the whole action.
+	rejected := NewPowerActionWithTransforms("player1", PowerActionSpade2, []PowerSpadeTarget{
+		{Hex: targetHex1, BuildDwelling: true},
+		{Hex: farHex},
+	})
+	if err := rejected.Execute(gs); err == nil {
+		t.Fatalf("expected an unreachable transform target to be rejected")
+	}
+	if gs.Map.GetHex(targetHex1).Building != nil || player.Resources.Power.Bowl3 != 8 || !gs.PowerActions.IsAvailable(PowerActionSpade2) {
+		t.Fatalf("expected a rejected action to leave the game untouched")
+	}
+
+	tooMany := NewPowerActionWithTransforms("player1", PowerActionSpade1, []PowerSpadeTarget{
+		{Hex: targetHex1},
+		{Hex: targetHex2},
+	})
+	if err := tooMany.Validate(gs); err == nil {
+		t.Fatalf("expected more targets than spades to be rejected")
+	}
+
+	action := NewPowerActionWithTransforms("player1", PowerActionSpade2, []PowerSpadeTarget{
+		{Hex: targetHex1, BuildDwelling: true},
+		{Hex: targetHex2},
+	})
+	if err := action.Execute(gs); err != nil {
+		t.Fatalf("expected spade2 action with two targets to succeed, got error: %v", err)
+	}
+
+	if gs.Map.GetHex(targetHex1).Building == nil {
+		t.Fatalf("expected a dwelling on the first target")
+	}
+	if terrain := gs.Map.GetHex(targetHex2).Terrain; terrain != models.TerrainPlains {
+		t.Fatalf("expected the second target to be Plains, got %v", terrain)
+	}
+	if _, ok := gs.PendingSpades["player1"]; ok {
+		t.Fatalf("expected no spade follow-up when the targets use both spades")
+	}
+	if player.Resources.Workers != 19 {
+		t.Fatalf("expected only the dwelling to cost workers, workers = %d", player.Resources.Workers)
+	}
+}
+
 func TestPowerAction_OncePerRound(t *testing.T) {
 	gs := NewGameState()
 	faction1 := factions.NewHalflings()  // Plains
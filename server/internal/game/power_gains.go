@@ -0,0 +1,116 @@
+package game
+
+// Reasons a player gains power, as recorded on PowerGainEvent.
+const (
+	PowerGainIncome             = "income"
+	PowerGainLeech              = "leech"
+	PowerGainCultistsLeech      = "cultists_leech"
+	PowerGainShapeshiftersLeech = "shapeshifters_leech"
+	PowerGainCultTrack          = "cult_track"
+	PowerGainCultReward         = "cult_reward"
+	PowerGainTown               = "town"
+	PowerGainStronghold         = "stronghold"
+	PowerGainSpades             = "spades"
+	PowerGainSpecialAction      = "special_action"
+	PowerGainBonusCard          = "bonus_card"
+	PowerGainGoblinsTreasure    = "goblins_treasure"
+	PowerGainLog                = "log"
+)
+
+// PowerGainEvent records one power gain. Most gains cycle existing tokens
+// through the bowls; Tokens marks gains that add new tokens to Bowl instead
+// (Shapeshifters, Dragonlords, Children of the Wyrm).
+type PowerGainEvent struct {
+	PlayerID string `json:"playerId"`
+	// SourcePlayerID is the player whose action caused the gain, when it is
+	// not PlayerID's own, e.g. the builder a leech came from.
+	SourcePlayerID string `json:"sourcePlayerId,omitempty"`
+	Reason         string `json:"reason"`
+	Amount         int    `json:"amount"`
+	Gained         int    `json:"gained"`
+	Tokens         bool   `json:"tokens,omitempty"`
+	Bowl           int    `json:"bowl,omitempty"`
+	Round          int    `json:"round"`
+}
+
+// GainPowerForReason cycles amount power through playerID's bowls, records a
+// PowerGainEvent and adds the power gained to the player's statistics. Power
+// gained during a game goes through here (or GainPowerTokensForReason), so
+// factions that change how power is gained hook in at one place. Moving
+// tokens between bowls as a cost or an action, such as the Time Travelers'
+// power shift or Firewalkers paying from their lower bowls, is not a gain and
+// bypasses it, as do cult track bonuses applied without a game state. Returns
+// the power actually gained.
+func (gs *GameState) GainPowerForReason(playerID string, amount int, reason string, sourcePlayerID string) int {
+	player := gs.GetPlayer(playerID)
+	if player == nil || player.Resources == nil || player.Resources.Power == nil || amount <= 0 {
+		return 0
+	}
+	gained := player.Resources.Power.GainPower(amount)
+	gs.recordPowerGain(player, PowerGainEvent{
+		PlayerID:       playerID,
+		SourcePlayerID: sourcePlayerID,
+		Reason:         reason,
+		Amount:         amount,
+		Gained:         gained,
+		Round:          gs.Round,
+	})
+	return gained
+}
+
+// GainPowerTokensForReason adds amount new power tokens to bowl (1-3) of
+// playerID and records them like GainPowerForReason.
+func (gs *GameState) GainPowerTokensForReason(playerID string, bowl int, amount int, reason string, sourcePlayerID string) {
+	player := gs.GetPlayer(playerID)
+	if player == nil || player.Resources == nil || player.Resources.Power == nil || amount <= 0 {
+		return
+	}
+	switch bowl {
+	case 1:
+		player.Resources.Power.Bowl1 += amount
+	case 2:
+		player.Resources.Power.Bowl2 += amount
+	case 3:
+		player.Resources.Power.Bowl3 += amount
+	default:
+		return
+	}
+	gs.recordPowerGain(player, PowerGainEvent{
+		PlayerID:       playerID,
+		SourcePlayerID: sourcePlayerID,
+		Reason:         reason,
+		Amount:         amount,
+		Gained:         amount,
+		Tokens:         true,
+		Bowl:           bowl,
+		Round:          gs.Round,
+	})
+}
+
+func (gs *GameState) recordPowerGain(player *Player, event PowerGainEvent) {
+	gs.powerGains = append(gs.powerGains, event)
+	if event.Gained <= 0 {
+		return
+	}
+	if player.PowerGained == nil {
+		player.PowerGained = make(map[string]int)
+	}
+	player.PowerGained[event.Reason] += event.Gained
+}
+
+// AcceptPowerLeech gains the power of offer for playerID as a leech from the
+// offering player and returns the VP it costs, based on the power actually
+// gained.
+func (gs *GameState) AcceptPowerLeech(playerID string, offer *PowerLeechOffer) int {
+	if offer == nil {
+		return 0
+	}
+	return leechGainVPCost(gs.GainPowerForReason(playerID, offer.Amount, PowerGainLeech, offer.FromPlayerID))
+}
+
+// TakePowerGains returns the power gains since the last call and forgets them.
+func (gs *GameState) TakePowerGains() []PowerGainEvent {
+	gains := gs.powerGains
+	gs.powerGains = nil
+	return gains
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/game/factions"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+func TestGainPowerForReason_RecordsEventsAndStatistics(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("p1", factions.NewWitches())
+	gs.AddPlayer("p2", factions.NewShapeshifters())
+	gs.Round = 2
+	p1 := gs.GetPlayer("p1")
+	p1.Resources.Power = NewPowerSystem(1, 2, 0)
+
+	if gained := gs.GainPowerForReason("p1", 5, PowerGainTown, ""); gained != 4 {
+		t.Fatalf("expected 4 power gained with bowls of 1/2/0, got %d", gained)
+	}
+	gs.GainPowerTokensForReason("p2", 3, 1, PowerGainShapeshiftersLeech, "")
+
+	gains := gs.TakePowerGains()
+	want := []PowerGainEvent{
+		{PlayerID: "p1", Reason: PowerGainTown, Amount: 5, Gained: 4, Round: 2},
+		{PlayerID: "p2", Reason: PowerGainShapeshiftersLeech, Amount: 1, Gained: 1, Tokens: true, Bowl: 3, Round: 2},
+	}
+	if len(gains) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), gains)
+	}
+	for i := range want {
+		if gains[i] != want[i] {
+			t.Errorf("event %d: expected %+v, got %+v", i, want[i], gains[i])
+		}
+	}
+	if len(gs.TakePowerGains()) != 0 {
+		t.Fatalf("expected TakePowerGains to forget the events it returned")
+	}
+	if p1.PowerGained[PowerGainTown] != 4 {
+		t.Fatalf("expected town power in p1's statistics, got %v", p1.PowerGained)
+	}
+	if gs.CloneForUndo().GetPlayer("p1").PowerGained[PowerGainTown] != 4 {
+		t.Fatalf("expected undo snapshots to keep power statistics")
+	}
+}
+
+func TestAcceptPowerLeech_RecordsSourcePlayer(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("builder", factions.NewHalflings())
+	gs.AddPlayer("leecher", factions.NewAuren())
+	gs.GetPlayer("leecher").VictoryPoints = 20
+
+	gs.Map.PlaceBuilding(board.NewHex(1, 0), &models.Building{
+		Type: models.BuildingTradingHouse, Faction: models.FactionAuren, PlayerID: "leecher", PowerValue: 2,
+	})
+	gs.Map.PlaceBuilding(board.NewHex(0, 0), &models.Building{
+		Type: models.BuildingDwelling, Faction: models.FactionHalflings, PlayerID: "builder", PowerValue: 1,
+	})
+	gs.TriggerPowerLeech(board.NewHex(0, 0), "builder")
+	if err := NewAcceptPowerLeechAction("leecher", 0).Execute(gs); err != nil {
+		t.Fatalf("accept leech: %v", err)
+	}
+
+	gains := gs.TakePowerGains()
+	if len(gains) != 1 || gains[0].Reason != PowerGainLeech || gains[0].SourcePlayerID != "builder" || gains[0].Gained != 2 {
+		t.Fatalf("expected a 2 power leech from builder, got %+v", gains)
+	}
+	if gs.GetPlayer("leecher").VictoryPoints != 19 {
+		t.Fatalf("expected the leech to cost 1 VP, got %d VP", gs.GetPlayer("leecher").VictoryPoints)
+	}
+}
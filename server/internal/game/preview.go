@@ -0,0 +1,118 @@
+package game
+
+import "fmt"
+
+// PreviewResources is a player's spendable state at one point of a preview.
+type PreviewResources struct {
+	Coins         int `json:"coins"`
+	Workers       int `json:"workers"`
+	Priests       int `json:"priests"`
+	PowerI        int `json:"powerI"`
+	PowerII       int `json:"powerII"`
+	PowerIII      int `json:"powerIII"`
+	VictoryPoints int `json:"victoryPoints"`
+}
+
+// ActionCost is what an action takes from the acting player.
+type ActionCost struct {
+	Coins   int `json:"coins"`
+	Workers int `json:"workers"`
+	Priests int `json:"priests"`
+	// Spades counts every spade the action applies, free or paid.
+	Spades int `json:"spades"`
+}
+
+// ActionPreview is the cost/benefit summary of an action for its player.
+type ActionPreview struct {
+	PlayerID string           `json:"playerId"`
+	Before   PreviewResources `json:"before"`
+	After    PreviewResources `json:"after"`
+	Cost     ActionCost       `json:"cost"`
+	// VictoryPoints is the net VP change, including scoring tile, favor and
+	// faction bonuses.
+	VictoryPoints int `json:"victoryPoints"`
+}
+
+// actionPreviewTrace collects details Execute reports while running under
+// PreviewAction that cannot be read back from the resulting state.
+type actionPreviewTrace struct {
+	spades int
+}
+
+// recordPreviewSpades notes spades applied by the current action when it runs
+// under PreviewAction.
+func (gs *GameState) recordPreviewSpades(spades int) {
+	if gs.previewTrace != nil && spades > 0 {
+		gs.previewTrace.spades += spades
+	}
+}
+
+// PreviewAction reports what action would cost and earn its player. It runs
+// the real Validate and Execute on a clone of gs, so the preview always matches
+// what executing the action does; gs is not modified.
+func PreviewAction(gs *GameState, action Action) (*ActionPreview, error) {
+	if gs == nil || action == nil {
+		return nil, fmt.Errorf("missing game state or action")
+	}
+	clone := cloneWithTurnConfirmation(gs)
+	player := clone.GetPlayer(action.GetPlayerID())
+	if player == nil || player.Resources == nil {
+		return nil, ruleErrorf(ReasonPlayerNotFound, "player not found: %s", action.GetPlayerID())
+	}
+
+	before := previewResourcesFor(player)
+	trace := &actionPreviewTrace{}
+	clone.previewTrace = trace
+	defer func() { clone.previewTrace = nil }()
+
+	if err := action.Validate(clone); err != nil {
+		return nil, fmt.Errorf("action validation failed: %w", err)
+	}
+	if err := action.Execute(clone); err != nil {
+		return nil, fmt.Errorf("action execution failed: %w", err)
+	}
+
+	after := previewResourcesFor(player)
+	return &ActionPreview{
+		PlayerID: action.GetPlayerID(),
+		Before:   before,
+		After:    after,
+		Cost: ActionCost{
+			Coins:   max(0, before.Coins-after.Coins),
+			Workers: max(0, before.Workers-after.Workers),
+			Priests: max(0, before.Priests-after.Priests),
+			Spades:  trace.spades,
+		},
+		VictoryPoints: after.VictoryPoints - before.VictoryPoints,
+	}, nil
+}
+
+func previewResourcesFor(player *Player) PreviewResources {
+	out := PreviewResources{
+		Coins:         player.Resources.Coins,
+		Workers:       player.Resources.Workers,
+		Priests:       player.Resources.Priests,
+		VictoryPoints: player.VictoryPoints,
+	}
+	if power := player.Resources.Power; power != nil {
+		out.PowerI = power.Bowl1
+		out.PowerII = power.Bowl2
+		out.PowerIII = power.Bowl3
+	}
+	return out
+}
+
+// PreviewAction previews action against the current state of gameID.
+func (m *Manager) PreviewAction(gameID string, action Action) (*ActionPreview, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	gs := m.games[gameID]
+	if gs == nil {
+		return nil, fmt.Errorf("game %s not found", gameID)
+	}
+	if err := validateActionTurnAndPendingState(gs, action); err != nil {
+		return nil, fmt.Errorf("action turn validation failed: %w", err)
+	}
+	return PreviewAction(gs, action)
+}
@@ -0,0 +1,48 @@
+package game
+
+// PlayerPriestLedger counts the priests a player owns against the 7-priest
+// limit: priests in hand, priests permanently spent on cult track action
+// spaces, and priests deposited in the Treasury. Priests returned to the
+// supply, such as those sent to the cult track's 1-step space, are not owned.
+type PlayerPriestLedger struct {
+	InHand       int
+	OnCultSpaces int
+	InTreasury   int
+}
+
+// PriestLedger returns the priest ledger of playerID, or an empty ledger for
+// an unknown player.
+func (gs *GameState) PriestLedger(playerID string) PlayerPriestLedger {
+	player := gs.GetPlayer(playerID)
+	if player == nil || player.Resources == nil {
+		return PlayerPriestLedger{}
+	}
+	ledger := PlayerPriestLedger{
+		InHand:     player.Resources.Priests,
+		InTreasury: player.TreasuryPriests,
+	}
+	if gs.CultTracks != nil {
+		ledger.OnCultSpaces = gs.CultTracks.GetTotalPriestsOnCultTracks(playerID)
+	}
+	return ledger
+}
+
+// Owned is the number of priests counting toward the limit.
+func (l PlayerPriestLedger) Owned() int {
+	return l.InHand + l.OnCultSpaces + l.InTreasury
+}
+
+// Capacity is how many more priests may be gained before the limit.
+func (l PlayerPriestLedger) Capacity() int {
+	return max(maxOwnedPriests-l.Owned(), 0)
+}
+
+// CanGain reports whether n more priests stay within the limit.
+func (l PlayerPriestLedger) CanGain(n int) bool {
+	return n <= l.Capacity()
+}
+
+// Gainable caps a gain of n priests at the limit.
+func (l PlayerPriestLedger) Gainable(n int) int {
+	return max(min(n, l.Capacity()), 0)
+}
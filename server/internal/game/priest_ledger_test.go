@@ -0,0 +1,42 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/factions"
+)
+
+func TestPriestLedger_CountsHandCultSpacesAndTreasury(t *testing.T) {
+	gs := NewGameState()
+	mustAddPlayer(t, gs, "p1", factions.NewDarklings())
+	player := gs.GetPlayer("p1")
+	player.Resources.Priests = 2
+	player.TreasuryPriests = 1
+	gs.CultTracks.PriestsOnActionSpaces["p1"] = map[CultTrack]int{CultFire: 1, CultWater: 2}
+
+	ledger := gs.PriestLedger("p1")
+	want := PlayerPriestLedger{InHand: 2, OnCultSpaces: 3, InTreasury: 1}
+	if ledger != want {
+		t.Fatalf("expected %+v, got %+v", want, ledger)
+	}
+	if ledger.Owned() != 6 || ledger.Capacity() != 1 {
+		t.Fatalf("expected 6 owned and room for 1, got %d and %d", ledger.Owned(), ledger.Capacity())
+	}
+	if !ledger.CanGain(1) || ledger.CanGain(2) {
+		t.Fatalf("expected to gain exactly one more priest")
+	}
+	if got := ledger.Gainable(3); got != 1 {
+		t.Fatalf("expected a gain of 3 to be capped at 1, got %d", got)
+	}
+	if got := gs.GainPriests("p1", 3); got != 1 || player.Resources.Priests != 3 {
+		t.Fatalf("expected to gain 1 priest, got %d (hand %d)", got, player.Resources.Priests)
+	}
+
+	player.Resources.Priests = 9
+	if ledger := gs.PriestLedger("p1"); ledger.Capacity() != 0 || ledger.Gainable(1) != 0 {
+		t.Fatalf("expected no room above the limit, got %+v", ledger)
+	}
+	if gs.PriestLedger("nobody") != (PlayerPriestLedger{}) {
+		t.Fatalf("expected an empty ledger for an unknown player")
+	}
+}
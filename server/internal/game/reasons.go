@@ -0,0 +1,63 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ReasonCode is a machine-readable explanation of why an action is illegal.
+// Clients can map codes to hints without parsing error text.
+type ReasonCode string
+
+const (
+	// ReasonUnspecified is reported for rule failures that have no specific code.
+	ReasonUnspecified ReasonCode = "INVALID_ACTION"
+
+	ReasonInsufficientWorkers   ReasonCode = "INSUFFICIENT_WORKERS"
+	ReasonInsufficientPriests   ReasonCode = "INSUFFICIENT_PRIESTS"
+	ReasonInsufficientCoins     ReasonCode = "INSUFFICIENT_COINS"
+	ReasonInsufficientPower     ReasonCode = "INSUFFICIENT_POWER"
+	ReasonInsufficientVP        ReasonCode = "INSUFFICIENT_VP"
+	ReasonInsufficientResources ReasonCode = "INSUFFICIENT_RESOURCES"
+	ReasonNotReachable          ReasonCode = "NOT_REACHABLE"
+	ReasonTerrainOccupied       ReasonCode = "TERRAIN_OCCUPIED"
+	ReasonActionSpaceTaken      ReasonCode = "ACTION_SPACE_TAKEN"
+	ReasonFactionTaken          ReasonCode = "FACTION_TAKEN"
+	ReasonFactionColorTaken     ReasonCode = "FACTION_COLOR_TAKEN"
+	ReasonBuildingLimit         ReasonCode = "BUILDING_LIMIT"
+	ReasonWrongPhase            ReasonCode = "WRONG_PHASE"
+	ReasonNotYourTurn           ReasonCode = "NOT_YOUR_TURN"
+	ReasonPendingDecision       ReasonCode = "PENDING_DECISION"
+	ReasonNoPendingDecision     ReasonCode = "NO_PENDING_DECISION"
+	ReasonPlayerNotFound        ReasonCode = "PLAYER_NOT_FOUND"
+	ReasonInvalidHex            ReasonCode = "INVALID_HEX"
+	ReasonConfirmationRequired  ReasonCode = "CONFIRMATION_REQUIRED"
+)
+
+// RuleError is a rule violation carrying a ReasonCode. Its message is the same
+// free text the engine has always reported.
+type RuleError struct {
+	Code    ReasonCode
+	Message string
+}
+
+func (e *RuleError) Error() string {
+	return e.Message
+}
+
+// ReasonCodeOf returns the ReasonCode of the first RuleError in err's chain,
+// or ReasonUnspecified if there is none. It returns "" for a nil error.
+func ReasonCodeOf(err error) ReasonCode {
+	if err == nil {
+		return ""
+	}
+	var ruleErr *RuleError
+	if errors.As(err, &ruleErr) {
+		return ruleErr.Code
+	}
+	return ReasonUnspecified
+}
+
+func ruleErrorf(code ReasonCode, format string, args ...interface{}) error {
+	return &RuleError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
@@ -46,7 +46,7 @@ func (rp *ResourcePool) CanAfford(cost factions.Cost) bool {
 // Returns error if not enough resources
 func (rp *ResourcePool) Spend(cost factions.Cost) error {
 	if !rp.CanAfford(cost) {
-		return fmt.Errorf("insufficient resources: need (coins:%d, workers:%d, priests:%d, power:%d), have (coins:%d, workers:%d, priests:%d, power:%d)",
+		return ruleErrorf(ReasonInsufficientResources, "insufficient resources: need (coins:%d, workers:%d, priests:%d, power:%d), have (coins:%d, workers:%d, priests:%d, power:%d)",
 			cost.Coins, cost.Workers, cost.Priests, cost.Power,
 			rp.Coins, rp.Workers, rp.Priests, rp.Power.Bowl3)
 	}
@@ -95,63 +95,6 @@ func (rp *ResourcePool) ConvertPowerToCoins(amount int) error {
 	return nil
 }
 
-func (rp *ResourcePool) ConvertPowerToWorkers(numWorkers int) error {
-	powerNeeded := numWorkers * 3
-	if !rp.Power.CanSpend(powerNeeded) {
-		return fmt.Errorf("need %d power in bowl 3 to convert to %d workers, only have %d", powerNeeded, numWorkers, rp.Power.Bowl3)
-	}
-
-	if err := rp.Power.SpendPower(powerNeeded); err != nil {
-		return err
-	}
-
-	rp.Workers += numWorkers
-	return nil
-}
-
-func (rp *ResourcePool) ConvertPowerToPriests(numPriests int) error {
-	powerNeeded := numPriests * 5
-	if !rp.Power.CanSpend(powerNeeded) {
-		return fmt.Errorf("need %d power in bowl 3 to convert to %d priests, only have %d", powerNeeded, numPriests, rp.Power.Bowl3)
-	}
-
-	if err := rp.Power.SpendPower(powerNeeded); err != nil {
-		return err
-	}
-
-	rp.Priests += numPriests
-	return nil
-}
-
-func (rp *ResourcePool) ConvertPriestToWorker(numWorkers int) error {
-	if rp.Priests < numWorkers {
-		return fmt.Errorf("need %d priests, only have %d", numWorkers, rp.Priests)
-	}
-
-	rp.Priests -= numWorkers
-	rp.Workers += numWorkers
-	return nil
-}
-
-func (rp *ResourcePool) ConvertWorkerToCoin(numCoins int) error {
-	if rp.Workers < numCoins {
-		return fmt.Errorf("need %d workers, only have %d", numCoins, rp.Workers)
-	}
-
-	rp.Workers -= numCoins
-	rp.Coins += numCoins
-	return nil
-}
-
-func (rp *ResourcePool) ConvertCoinToPowerTokens(numTokens int) error {
-	if rp.Coins < numTokens {
-		return fmt.Errorf("need %d coins, only have %d", numTokens, rp.Coins)
-	}
-	rp.Coins -= numTokens
-	rp.Power.Bowl1 += numTokens
-	return nil
-}
-
 // BurnPower converts power from bowl 2 to bowl 3 at 2:1 ratio
 func (rp *ResourcePool) BurnPower(amount int) error {
 	return rp.Power.BurnPower(amount)
@@ -184,11 +127,16 @@ type PowerLeechOffer struct {
 	// Amount of power offered by the source event. This is not capped by the
 	// receiver's current power-cycle capacity; the receiver may only gain as
 	// much power as they can charge when accepting.
-	Amount       int
-	VPCost       int // VP cost to accept (usually Amount - 1)
+	Amount int
+	// CappedAmount is the power the receiver could actually absorb when the
+	// offer was created: min(Amount, 2*Bowl1 + Bowl2).
+	CappedAmount int `json:"cappedAmount"`
+	VPCost       int // VP cost to accept (usually CappedAmount - 1)
 	FromPlayerID string
 	SourceHex    *board.Hex `json:"sourceHex,omitempty"`
-	EventID      int `json:"eventId"`
+	// SourceLabel is SourceHex's board label, e.g. "F5".
+	SourceLabel string `json:"sourceLabel,omitempty"`
+	EventID     int    `json:"eventId"`
 }
 
 // NewPowerLeechOffer creates a power leech offer based on building value and player's power capacity
@@ -205,17 +153,22 @@ func NewPowerLeechOffer(buildingValue int, fromPlayerID string, targetPower *Pow
 	// - Each token in Bowl I can contribute up to 2 gained power (I->II then II->III).
 	// - Each token in Bowl II can contribute up to 1 gained power (II->III).
 	// Bowl III is already active and does not provide capacity to gain.
-	capacity := targetPower.Bowl2 + 2*targetPower.Bowl1
+	capacity := targetPower.LeechCapacity()
 	if capacity <= 0 {
 		return nil
 	}
+	cappedAmount := buildingValue
+	if cappedAmount > capacity {
+		cappedAmount = capacity
+	}
 
 	return &PowerLeechOffer{
-		Amount: buildingValue,
+		Amount:       buildingValue,
+		CappedAmount: cappedAmount,
 		// Snellman leech VP cost model: actualTaken - 1 (minimum 0).
 		// Note: this value is informational; actual VP cost is derived from the
 		// power actually gained at acceptance time (capacity can change).
-		VPCost:       maxInt(0, buildingValue-1),
+		VPCost:       maxInt(0, cappedAmount-1),
 		FromPlayerID: fromPlayerID,
 	}
 }
@@ -231,13 +184,16 @@ func (rp *ResourcePool) AcceptPowerLeech(offer *PowerLeechOffer) int {
 	// VP cost is based on power actually gained now, not on the offer's
 	// original snapshot. This matters when multiple pending leeches resolve
 	// sequentially and the player no longer has capacity for the full amount.
-	actualGained := rp.GainPower(offer.Amount)
-	// VP cost is based on power actually gained (capacity can change as offers resolve).
-	// Snellman cost curve: actualTaken - 1 (minimum 0).
-	if actualGained <= 1 {
+	return leechGainVPCost(rp.GainPower(offer.Amount))
+}
+
+// leechGainVPCost is the VP cost of leeching gained power.
+// Snellman cost curve: actualTaken - 1 (minimum 0).
+func leechGainVPCost(gained int) int {
+	if gained <= 1 {
 		return 0
 	}
-	return actualGained - 1
+	return gained - 1
 }
 
 func maxInt(a, b int) int {
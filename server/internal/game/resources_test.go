@@ -2,71 +2,80 @@ package game
 
 import (
 	"testing"
+
+	"github.com/lukev/tm_server/internal/game/factions"
 )
 
 func TestNewPowerLeechOffer_CapacityCalculation(t *testing.T) {
 	tests := []struct {
-		name          string
-		bowl1         int
-		bowl2         int
-		bowl3         int
-		buildingValue int
-		expectedOffer int
-		description   string
+		name           string
+		bowl1          int
+		bowl2          int
+		bowl3          int
+		buildingValue  int
+		expectedOffer  int
+		expectedCapped int
+		description    string
 	}{
 		{
-			name:          "Standard case - full capacity",
-			bowl1:         5,
-			bowl2:         7,
-			bowl3:         0,
-			buildingValue: 2,
-			expectedOffer: 2,
-			description:   "Offer amount is the (uncapped) leech amount; capacity limits apply at acceptance time",
+			name:           "Standard case - full capacity",
+			bowl1:          5,
+			bowl2:          7,
+			bowl3:          0,
+			buildingValue:  2,
+			expectedOffer:  2,
+			expectedCapped: 2,
+			description:    "Offer amount is the (uncapped) leech amount; capacity limits apply at acceptance time",
 		},
 		{
-			name:          "Auren bug case - Bowl1=1, Bowl2=0",
-			bowl1:         1,
-			bowl2:         0,
-			bowl3:         4,
-			buildingValue: 2,
-			expectedOffer: 2,
-			description:   "Offer is still created when there is at least some charging capacity",
+			name:           "Auren bug case - Bowl1=1, Bowl2=0",
+			bowl1:          1,
+			bowl2:          0,
+			bowl3:          4,
+			buildingValue:  2,
+			expectedOffer:  2,
+			expectedCapped: 2,
+			description:    "Offer is still created when there is at least some charging capacity",
 		},
 		{
-			name:          "Only Bowl2 available",
-			bowl1:         0,
-			bowl2:         3,
-			bowl3:         2,
-			buildingValue: 2,
-			expectedOffer: 2,
-			description:   "Offer amount is not capped by remaining capacity",
+			name:           "Only Bowl2 available",
+			bowl1:          0,
+			bowl2:          3,
+			bowl3:          2,
+			buildingValue:  2,
+			expectedOffer:  2,
+			expectedCapped: 2,
+			description:    "Offer amount is not capped by remaining capacity",
 		},
 		{
-			name:          "Limited by Bowl1+Bowl2",
-			bowl1:         1,
-			bowl2:         1,
-			bowl3:         0,
-			buildingValue: 5,
-			expectedOffer: 5,
-			description:   "Offer is not capped; actual power gained is limited by current bowls when accepting",
+			name:           "Limited by Bowl1+Bowl2",
+			bowl1:          1,
+			bowl2:          1,
+			bowl3:          0,
+			buildingValue:  5,
+			expectedOffer:  5,
+			expectedCapped: 3,
+			description:    "Offer is not capped; actual power gained is limited by current bowls when accepting",
 		},
 		{
-			name:          "No capacity",
-			bowl1:         0,
-			bowl2:         0,
-			bowl3:         12,
-			buildingValue: 2,
-			expectedOffer: 0,
-			description:   "Player with 0/0/12 (full Bowl3) cannot receive power",
+			name:           "No capacity",
+			bowl1:          0,
+			bowl2:          0,
+			bowl3:          12,
+			buildingValue:  2,
+			expectedOffer:  0,
+			expectedCapped: 0,
+			description:    "Player with 0/0/12 (full Bowl3) cannot receive power",
 		},
 		{
-			name:          "Capacity of 1",
-			bowl1:         0,
-			bowl2:         1,
-			bowl3:         4,
-			buildingValue: 2,
-			expectedOffer: 2,
-			description:   "Offer amount is not capped; acceptance will only gain up to 1 power here",
+			name:           "Capacity of 1",
+			bowl1:          0,
+			bowl2:          1,
+			bowl3:          4,
+			buildingValue:  2,
+			expectedOffer:  2,
+			expectedCapped: 1,
+			description:    "Offer amount is not capped; acceptance will only gain up to 1 power here",
 		},
 	}
 
@@ -84,6 +93,8 @@ func TestNewPowerLeechOffer_CapacityCalculation(t *testing.T) {
 					t.Errorf("%s: expected offer of %d, got nil", tt.description, tt.expectedOffer)
 				} else if offer.Amount != tt.expectedOffer {
 					t.Errorf("%s: expected offer of %d, got %d", tt.description, tt.expectedOffer, offer.Amount)
+				} else if offer.CappedAmount != tt.expectedCapped {
+					t.Errorf("%s: expected capped amount %d, got %d", tt.description, tt.expectedCapped, offer.CappedAmount)
 				}
 			}
 		})
@@ -129,3 +140,60 @@ func TestAcceptPowerLeech_ChargesByActualGain(t *testing.T) {
 		}
 	})
 }
+
+func TestAcceptPowerLeechAction_RejectsVPBelowZero(t *testing.T) {
+	gs := NewGameState()
+	mustAddPlayer(t, gs, "src", factions.NewNomads())
+	mustAddPlayer(t, gs, "dst", factions.NewAuren())
+
+	dst := gs.GetPlayer("dst")
+	dst.Resources.Power = NewPowerSystem(0, 5, 0)
+	dst.VictoryPoints = 1
+	gs.PendingLeechOffers["dst"] = []*PowerLeechOffer{
+		{Amount: 3, CappedAmount: 3, VPCost: 2, FromPlayerID: "src", EventID: 1},
+	}
+
+	if err := NewAcceptPowerLeechAction("dst", 0).Validate(gs); err == nil {
+		t.Fatal("expected accepting 3 power with 1 VP to be rejected")
+	}
+
+	// Accepting only what the player can pay for is still allowed.
+	partial := NewAcceptPowerLeechAmountAction("dst", 0, 2)
+	if err := partial.Execute(gs); err != nil {
+		t.Fatalf("partial accept: %v", err)
+	}
+	if dst.VictoryPoints != 0 {
+		t.Fatalf("expected VP=0 after accepting 2 power, got %d", dst.VictoryPoints)
+	}
+	if dst.Resources.Power.Bowl3 != 2 {
+		t.Fatalf("expected bowlIII=2 after partial accept, got %d", dst.Resources.Power.Bowl3)
+	}
+}
+
+func TestAcceptPowerLeechAction_SplitOfferCostsByCapacity(t *testing.T) {
+	gs := NewGameState()
+	mustAddPlayer(t, gs, "src", factions.NewNomads())
+	mustAddPlayer(t, gs, "dst", factions.NewAuren())
+
+	dst := gs.GetPlayer("dst")
+	dst.Resources.Power = NewPowerSystem(0, 3, 9)
+	dst.VictoryPoints = 10
+	gs.PendingLeechOffers["dst"] = []*PowerLeechOffer{
+		{Amount: 5, CappedAmount: 3, VPCost: 2, FromPlayerID: "src", EventID: 1},
+	}
+
+	if err := NewAcceptPowerLeechAmountAction("dst", 0, 2).Execute(gs); err != nil {
+		t.Fatalf("partial accept: %v", err)
+	}
+	if dst.VictoryPoints != 9 {
+		t.Fatalf("expected 1 VP paid for 2 power, got VP=%d", dst.VictoryPoints)
+	}
+	offers := gs.PendingLeechOffers["dst"]
+	if len(offers) != 1 || offers[0].Amount != 3 {
+		t.Fatalf("expected a remaining offer of 3 power, got %+v", offers)
+	}
+	// One power fits after the partial accept, which is free.
+	if offers[0].VPCost != 0 {
+		t.Fatalf("expected the remainder to cost 0 VP at the capacity left, got %d", offers[0].VPCost)
+	}
+}
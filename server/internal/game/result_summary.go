@@ -0,0 +1,133 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	// ErrGameNotFinished is returned for the summary of a game still in play.
+	ErrGameNotFinished = errors.New("game is not finished")
+	// ErrSummaryNotPublic is returned for the summary of a game its players
+	// have not made public.
+	ErrSummaryNotPublic = errors.New("game summary is not public")
+)
+
+// ResultSummary is the shareable result of a finished game.
+type ResultSummary struct {
+	GameID  string                `json:"gameId"`
+	Rounds  int                   `json:"rounds"`
+	Players []ResultSummaryPlayer `json:"players"`
+	// ReplayPath is the client route of the game's replay.
+	ReplayPath string `json:"replayPath"`
+}
+
+// ResultSummaryPlayer is one player's result. Players tied on VP and on the
+// resource tiebreaker share a rank.
+type ResultSummaryPlayer struct {
+	Rank     int               `json:"rank"`
+	PlayerID string            `json:"playerId"`
+	Faction  string            `json:"faction"`
+	TotalVP  int               `json:"totalVp"`
+	Score    *PlayerFinalScore `json:"score"`
+}
+
+// SetPublicSummary records whether playerID, who must be seated in gameID,
+// agrees to a public summary. The summary is public only while every seat
+// agrees, so one player cannot publish the others' results. Consent is kept in
+// the game's save file, so it survives archiving.
+func (m *Manager) SetPublicSummary(gameID, playerID string, public bool) error {
+	if err := m.rehydrate(gameID); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	gs := m.games[gameID]
+	if gs == nil {
+		return fmt.Errorf("game %s not found", gameID)
+	}
+	if gs.GetPlayer(playerID) == nil {
+		return fmt.Errorf("player %s is not seated in game %s", playerID, gameID)
+	}
+	consent := slices.DeleteFunc(slices.Clone(gs.PublicSummaryConsent), func(id string) bool {
+		return id == playerID
+	})
+	if public {
+		consent = append(consent, playerID)
+	}
+	gs.setPublicSummaryConsent(consent)
+	if setup, ok := m.setups[gameID]; ok {
+		setup.settings.PublicSummary = gs.PublicSummary
+		setup.settings.PublicSummaryConsent = slices.Clone(gs.PublicSummaryConsent)
+		m.setups[gameID] = setup
+	}
+	delete(m.archivedRevision, gameID)
+	return nil
+}
+
+// setPublicSummaryConsent keeps the seated players among playerIDs as the
+// ones agreeing to a public summary, and makes it public once all seats do.
+func (gs *GameState) setPublicSummaryConsent(playerIDs []string) {
+	var consent []string
+	for _, playerID := range playerIDs {
+		if gs.GetPlayer(playerID) != nil && !slices.Contains(consent, playerID) {
+			consent = append(consent, playerID)
+		}
+	}
+	slices.Sort(consent)
+	gs.PublicSummaryConsent = consent
+	gs.PublicSummary = len(gs.Players) > 0 && len(consent) == len(gs.Players)
+}
+
+// ResultSummary returns the summary of a finished game whose players made it
+// public.
+func (m *Manager) ResultSummary(gameID string) (*ResultSummary, error) {
+	if err := m.rehydrate(gameID); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	gs := m.games[gameID]
+	if gs == nil {
+		return nil, fmt.Errorf("game %s not found", gameID)
+	}
+	if !gs.PublicSummary {
+		return nil, ErrSummaryNotPublic
+	}
+	if gs.Phase != PhaseEnd || gs.FinalScoring == nil {
+		return nil, ErrGameNotFinished
+	}
+	return BuildResultSummary(gameID, gs), nil
+}
+
+// BuildResultSummary summarizes the final scoring of gs.
+func BuildResultSummary(gameID string, gs *GameState) *ResultSummary {
+	summary := &ResultSummary{
+		GameID:     gameID,
+		Rounds:     gs.Round,
+		Players:    []ResultSummaryPlayer{},
+		ReplayPath: "/replay/" + gameID,
+	}
+	var previous *PlayerFinalScore
+	for i, score := range GetRankedPlayers(gs.FinalScoring) {
+		rank := i + 1
+		if previous != nil && !score.Resigned && !previous.Resigned &&
+			score.TotalVP == previous.TotalVP && score.TotalResourceValue == previous.TotalResourceValue {
+			rank = summary.Players[i-1].Rank
+		}
+		faction := ""
+		if player := gs.GetPlayer(score.PlayerID); player != nil && player.Faction != nil {
+			faction = player.Faction.GetType().String()
+		}
+		summary.Players = append(summary.Players, ResultSummaryPlayer{
+			Rank:     rank,
+			PlayerID: score.PlayerID,
+			Faction:  faction,
+			TotalVP:  score.TotalVP,
+			Score:    score,
+		})
+		previous = score
+	}
+	return summary
+}
@@ -0,0 +1,47 @@
+package game
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSetPublicSummary_NeedsEverySeatAndSurvivesExport(t *testing.T) {
+	mgr := NewManager()
+	if err := mgr.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{PublicSummary: true}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	gs, _ := mgr.GetGame("g1")
+	if !gs.PublicSummary || !slices.Equal(gs.PublicSummaryConsent, []string{"p1", "p2"}) {
+		t.Fatalf("expected every seat to agree at creation, got public=%v consent=%v", gs.PublicSummary, gs.PublicSummaryConsent)
+	}
+
+	if err := mgr.SetPublicSummary("g1", "p2", false); err != nil {
+		t.Fatalf("withdraw consent: %v", err)
+	}
+	if gs.PublicSummary || !slices.Equal(gs.PublicSummaryConsent, []string{"p1"}) {
+		t.Fatalf("expected one opt-out to make the summary private, got public=%v consent=%v", gs.PublicSummary, gs.PublicSummaryConsent)
+	}
+
+	save, err := mgr.ExportGame("g1")
+	if err != nil {
+		t.Fatalf("export game: %v", err)
+	}
+	imported, err := ReplaySaveFile(save, func(recorded RecordedAction) (Action, error) {
+		t.Fatalf("unexpected recorded action %+v", recorded)
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("replay save file: %v", err)
+	}
+	if imported.State.PublicSummary || !slices.Equal(imported.State.PublicSummaryConsent, []string{"p1"}) {
+		t.Fatalf("expected consent to survive export, got public=%v consent=%v",
+			imported.State.PublicSummary, imported.State.PublicSummaryConsent)
+	}
+
+	if err := mgr.SetPublicSummary("g1", "p2", true); err != nil {
+		t.Fatalf("give consent: %v", err)
+	}
+	if !gs.PublicSummary {
+		t.Fatalf("expected the summary to go public once every seat agrees")
+	}
+}
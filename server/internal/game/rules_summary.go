@@ -0,0 +1,285 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lukev/tm_server/internal/game/board"
+)
+
+// RulesSummary lists the options in effect for a game, for lobbies and the
+// top of transcripts. Lines renders the same options as text, one per line.
+type RulesSummary struct {
+	GameID                string                     `json:"gameId"`
+	MapID                 board.MapID                `json:"mapId"`
+	MapName               string                     `json:"mapName"`
+	SetupMode             SetupMode                  `json:"setupMode"`
+	TurnOrderPolicy       TurnOrderPolicy            `json:"turnOrderPolicy"`
+	EnableFanFactions     bool                       `json:"enableFanFactions"`
+	EnableFireIceFactions bool                       `json:"enableFireIceFactions"`
+	FireIceScoring        FireIceFinalScoringSetting `json:"fireIceScoring"`
+	// FireIceScoringTile is the resolved extra final scoring tile, if any.
+	FireIceScoringTile FireIceFinalScoringTile `json:"fireIceScoringTile,omitempty"`
+	HiddenResources    bool                    `json:"hiddenResources"`
+	Hotseat            bool                    `json:"hotseat,omitempty"`
+	SpeedPreset        SpeedPreset             `json:"speedPreset,omitempty"`
+	TurnTimer          *TurnTimerConfig        `json:"turnTimer,omitempty"`
+	Handicaps          map[string]Handicap     `json:"handicaps,omitempty"`
+	// ScoringTiles are the round scoring tile names, round 1 first.
+	ScoringTiles []string `json:"scoringTiles"`
+	// BonusCards are the names of the bonus cards in play.
+	BonusCards        []string `json:"bonusCards"`
+	RemovedBonusCards []string `json:"removedBonusCards,omitempty"`
+	Lines             []string `json:"lines"`
+}
+
+// RulesSummary summarizes the options of the started game gameID. The turn
+// timer comes from the recorded settings, so games without them leave it out.
+// Lobbies use BuildLobbyRulesSummary.
+func (m *Manager) RulesSummary(gameID string) (*RulesSummary, error) {
+	if err := m.rehydrate(gameID); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	gs := m.games[gameID]
+	if gs == nil {
+		return nil, fmt.Errorf("game %s not found", gameID)
+	}
+	var settings *SaveFileSettings
+	if setup, ok := m.setups[gameID]; ok {
+		settings = &setup.settings
+	}
+	summary := BuildRulesSummary(gs, settings)
+	summary.GameID = gameID
+	return summary, nil
+}
+
+// BuildRulesSummary summarizes gs, taking what the state does not keep from
+// settings when given.
+func BuildRulesSummary(gs *GameState, settings *SaveFileSettings) *RulesSummary {
+	summary := &RulesSummary{
+		SetupMode:             gs.SetupMode,
+		TurnOrderPolicy:       gs.TurnOrderPolicy,
+		EnableFanFactions:     gs.EnableFanFactions,
+		EnableFireIceFactions: gs.EnableFireIceFactions,
+		FireIceScoring:        gs.FireIceFinalScoringSetting,
+		FireIceScoringTile:    gs.FireIceFinalScoringTile,
+		HiddenResources:       gs.HiddenResources,
+		Hotseat:               gs.Hotseat,
+		SpeedPreset:           gs.SpeedPreset,
+		ScoringTiles:          []string{},
+		BonusCards:            []string{},
+	}
+	if summary.TurnOrderPolicy == "" {
+		summary.TurnOrderPolicy = TurnOrderPolicyPassOrder
+	}
+	if summary.FireIceScoring == "" {
+		summary.FireIceScoring = FireIceFinalScoringOff
+	}
+	if gs.Map != nil {
+		summary.MapID = gs.Map.ID
+		if custom := gs.Map.CustomDefinition(); custom != nil {
+			summary.MapName = custom.MapInfo().Name
+		} else if info, ok := board.MapInfoByID(gs.Map.ID); ok {
+			summary.MapName = info.Name
+		}
+	}
+	if len(gs.Handicaps) > 0 {
+		summary.Handicaps = make(map[string]Handicap, len(gs.Handicaps))
+		for playerID, handicap := range gs.Handicaps {
+			summary.Handicaps[playerID] = handicap
+		}
+	}
+	if gs.ScoringTiles != nil {
+		for _, tile := range gs.ScoringTiles.Tiles {
+			summary.ScoringTiles = append(summary.ScoringTiles, scoringTileName(tile.Type))
+		}
+	}
+	summary.BonusCards = bonusCardNames(gs, bonusCardsInPlay(gs.BonusCards))
+	// The state lists the cards removed at random as well as the creator's.
+	if gs.BonusCards != nil && len(gs.BonusCards.Removed) > 0 {
+		summary.RemovedBonusCards = bonusCardNames(gs, gs.BonusCards.Removed)
+	} else if settings != nil {
+		summary.RemovedBonusCards = bonusCardNames(gs, settings.RemovedBonusCards)
+	}
+	if settings != nil {
+		summary.TurnTimer = turnTimerFor(settings.TurnTimer, settings.SpeedPreset)
+	}
+	summary.Lines = summary.render()
+	return summary
+}
+
+// BuildLobbyRulesSummary summarizes the options of a game that has not
+// started. Scoring tiles and bonus cards are drawn at the start, so only the
+// cards the creator removed are listed, and options picked when starting are
+// left out while unset in opts.
+func BuildLobbyRulesSummary(opts CreateGameOptions) *RulesSummary {
+	summary := &RulesSummary{
+		MapID:                 opts.MapID,
+		SetupMode:             opts.SetupMode,
+		TurnOrderPolicy:       opts.TurnOrderPolicy,
+		EnableFanFactions:     opts.EnableFanFactions,
+		EnableFireIceFactions: opts.EnableFireIceFactions,
+		FireIceScoring:        opts.FireIceScoring,
+		HiddenResources:       opts.HiddenResources,
+		Hotseat:               opts.Hotseat,
+		SpeedPreset:           opts.SpeedPreset,
+		TurnTimer:             turnTimerFor(opts.TurnTimer, opts.SpeedPreset),
+		ScoringTiles:          []string{},
+		BonusCards:            []string{},
+	}
+	if summary.FireIceScoring == "" {
+		summary.FireIceScoring = FireIceFinalScoringOff
+	}
+	if opts.CustomMap != nil {
+		info := opts.CustomMap.MapInfo()
+		summary.MapID = info.ID
+		summary.MapName = info.Name
+	} else if info, ok := board.MapInfoByID(opts.MapID); ok {
+		summary.MapName = info.Name
+	}
+	if len(opts.Handicaps) > 0 {
+		summary.Handicaps = make(map[string]Handicap, len(opts.Handicaps))
+		for playerID, handicap := range opts.Handicaps {
+			summary.Handicaps[playerID] = handicap
+		}
+	}
+	if len(opts.RemovedBonusCards) > 0 {
+		summary.RemovedBonusCards = bonusCardNames(nil, opts.RemovedBonusCards)
+	}
+	summary.Lines = summary.render()
+	return summary
+}
+
+// turnTimerFor returns a copy of the turn timer in effect: timer when set,
+// otherwise the speed preset's.
+func turnTimerFor(timer *TurnTimerConfig, speedPreset SpeedPreset) *TurnTimerConfig {
+	if timer == nil {
+		timer = speedPreset.Settings().TurnTimer
+	}
+	if timer == nil {
+		return nil
+	}
+	copied := *timer
+	return &copied
+}
+
+func (s *RulesSummary) render() []string {
+	onOff := func(enabled bool) string {
+		if enabled {
+			return "on"
+		}
+		return "off"
+	}
+	mapName := s.MapName
+	if mapName == "" {
+		mapName = string(s.MapID)
+	}
+	lines := []string{"Map: " + mapName}
+	// Lobbies leave the setup and turn order unset until the game starts.
+	if s.SetupMode != "" {
+		lines = append(lines, "Setup: "+string(s.SetupMode))
+	}
+	if s.TurnOrderPolicy != "" {
+		lines = append(lines, "Turn order: "+string(s.TurnOrderPolicy))
+	}
+	lines = append(lines,
+		"Fan factions: "+onOff(s.EnableFanFactions),
+		"Fire & Ice factions: "+onOff(s.EnableFireIceFactions),
+	)
+	fireIce := "Fire & Ice final scoring: " + string(s.FireIceScoring)
+	if s.FireIceScoringTile != FireIceFinalScoringTileNone {
+		fireIce += fmt.Sprintf(" (%s)", s.FireIceScoringTile)
+	}
+	lines = append(lines, fireIce, "Hidden resources: "+onOff(s.HiddenResources))
+	if s.Hotseat {
+		lines = append(lines, "Hotseat: on")
+	}
+	if s.SpeedPreset != "" {
+		lines = append(lines, "Speed: "+string(s.SpeedPreset))
+	}
+	if s.TurnTimer != nil && s.TurnTimer.PerTurn {
+		lines = append(lines, fmt.Sprintf("Turn timer: %s per turn",
+			time.Duration(s.TurnTimer.InitialTimeMs)*time.Millisecond))
+	} else if s.TurnTimer != nil {
+		lines = append(lines, fmt.Sprintf("Turn timer: %s + %s per turn",
+			time.Duration(s.TurnTimer.InitialTimeMs)*time.Millisecond,
+			time.Duration(s.TurnTimer.IncrementMs)*time.Millisecond))
+	}
+	if len(s.Handicaps) > 0 {
+		playerIDs := make([]string, 0, len(s.Handicaps))
+		for playerID := range s.Handicaps {
+			playerIDs = append(playerIDs, playerID)
+		}
+		sort.Strings(playerIDs)
+		handicaps := make([]string, len(playerIDs))
+		for i, playerID := range playerIDs {
+			handicaps[i] = playerID + " " + s.Handicaps[playerID].String()
+		}
+		lines = append(lines, "Handicaps: "+strings.Join(handicaps, ", "))
+	}
+	if len(s.ScoringTiles) > 0 {
+		rounds := make([]string, len(s.ScoringTiles))
+		for i, tile := range s.ScoringTiles {
+			rounds[i] = fmt.Sprintf("R%d %s", i+1, tile)
+		}
+		lines = append(lines, "Scoring tiles: "+strings.Join(rounds, ", "))
+	}
+	if len(s.BonusCards) > 0 {
+		lines = append(lines, "Bonus cards: "+strings.Join(s.BonusCards, ", "))
+	}
+	if len(s.RemovedBonusCards) > 0 {
+		lines = append(lines, "Removed bonus cards: "+strings.Join(s.RemovedBonusCards, ", "))
+	}
+	return lines
+}
+
+// bonusCardsInPlay lists the cards on offer or held by players, in card order.
+func bonusCardsInPlay(state *BonusCardState) []BonusCardType {
+	if state == nil {
+		return nil
+	}
+	inPlay := make(map[BonusCardType]bool)
+	for card := range state.Available {
+		inPlay[card] = true
+	}
+	for _, card := range state.PlayerCards {
+		inPlay[card] = true
+	}
+	for _, cards := range state.PlayerExtraCards {
+		for _, card := range cards {
+			inPlay[card] = true
+		}
+	}
+	cards := make([]BonusCardType, 0, len(inPlay))
+	for card := range inPlay {
+		cards = append(cards, card)
+	}
+	sort.Slice(cards, func(i, j int) bool { return cards[i] < cards[j] })
+	return cards
+}
+
+func bonusCardNames(gs *GameState, cards []BonusCardType) []string {
+	definitions := gs.tileDefinitions().bonusCards
+	names := make([]string, 0, len(cards))
+	for _, card := range cards {
+		if definition, ok := definitions[card]; ok && definition.Name != "" {
+			names = append(names, definition.Name)
+		} else {
+			names = append(names, fmt.Sprintf("bonus card %d", card))
+		}
+	}
+	return names
+}
+
+func scoringTileName(tileType ScoringTileType) string {
+	for name, candidate := range scoringTileTypeMap {
+		if candidate == tileType {
+			return name
+		}
+	}
+	return fmt.Sprintf("scoring tile %d", tileType)
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/board"
+)
+
+func TestRulesSummary_ReflectsCreateGameOptions(t *testing.T) {
+	manager := NewManager()
+	seed := int64(7)
+	if err := manager.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{
+		MapID:             board.MapFjords,
+		SetupMode:         SetupModeAuction,
+		TurnTimer:         &TurnTimerConfig{InitialTimeMs: 25 * 60 * 1000, IncrementMs: 30 * 1000},
+		HiddenResources:   true,
+		Handicaps:         map[string]Handicap{"p2": {VP: 5}},
+		TurnOrderPolicy:   TurnOrderPolicyCyclicFromFirstPasser,
+		RemovedBonusCards: []BonusCardType{BonusCardSpade},
+		Seed:              &seed,
+	}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+
+	summary, err := manager.RulesSummary("g1")
+	if err != nil {
+		t.Fatalf("rules summary: %v", err)
+	}
+	if summary.GameID != "g1" || summary.MapID != board.MapFjords || summary.SetupMode != SetupModeAuction ||
+		summary.TurnOrderPolicy != TurnOrderPolicyCyclicFromFirstPasser || !summary.HiddenResources {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	if len(summary.ScoringTiles) != 6 {
+		t.Fatalf("expected six scoring tiles, got %v", summary.ScoringTiles)
+	}
+	// The random removals are listed with the creator's.
+	if len(summary.BonusCards) != 5 || len(summary.RemovedBonusCards) != len(GetAllBonusCards())-5 {
+		t.Fatalf("expected five bonus cards in play and the removed ones listed, got %v / %v",
+			summary.BonusCards, summary.RemovedBonusCards)
+	}
+	spade := GetAllBonusCards()[BonusCardSpade].Name
+	for _, name := range summary.BonusCards {
+		if name == spade {
+			t.Fatalf("expected the removed card %q out of play, got %v", spade, summary.BonusCards)
+		}
+	}
+
+	text := strings.Join(summary.Lines, "\n")
+	for _, want := range []string{"Map: Fjords", "Setup: auction", "Turn timer: 25m0s + 30s per turn", "Handicaps: p2 5VP", "R1 ", "Removed bonus cards: "} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in the rules summary:\n%s", want, text)
+		}
+	}
+
+	if _, err := manager.RulesSummary("missing"); err == nil {
+		t.Fatalf("expected an unknown game to be an error")
+	}
+}
+
+func TestBuildLobbyRulesSummary_ListsChosenOptions(t *testing.T) {
+	summary := BuildLobbyRulesSummary(CreateGameOptions{
+		MapID:                 board.MapFjords,
+		EnableFireIceFactions: true,
+		RemovedBonusCards:     []BonusCardType{BonusCardSpade},
+	})
+	if summary.MapName != "Fjords" || !summary.EnableFireIceFactions || summary.FireIceScoring != FireIceFinalScoringOff {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	spade := GetAllBonusCards()[BonusCardSpade].Name
+	if len(summary.ScoringTiles) != 0 || len(summary.BonusCards) != 0 ||
+		len(summary.RemovedBonusCards) != 1 || summary.RemovedBonusCards[0] != spade {
+		t.Fatalf("expected only the chosen removal before the draws, got %+v", summary)
+	}
+
+	text := strings.Join(summary.Lines, "\n")
+	for _, want := range []string{"Map: Fjords", "Fire & Ice factions: on", "Removed bonus cards: " + spade} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in the rules summary:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "Setup:") || strings.Contains(text, "Turn order:") {
+		t.Fatalf("expected the options chosen at the start left out:\n%s", text)
+	}
+}
@@ -0,0 +1,298 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lukev/tm_server/internal/game/board"
+)
+
+// SaveFileVersion is the current portable save-file format version.
+const SaveFileVersion = 1
+
+// RecordedAction is one accepted action in its wire form, as submitted by a
+// seat. Replaying the recorded actions against the recorded settings rebuilds
+// the game.
+type RecordedAction struct {
+	PlayerID string          `json:"playerId"`
+	Type     string          `json:"type"`
+	Params   json.RawMessage `json:"params,omitempty"`
+	// LegacyHex and LegacyFaction carry the deprecated top-level fields when a
+	// client used them.
+	LegacyHex     json.RawMessage `json:"legacyHex,omitempty"`
+	LegacyFaction string          `json:"legacyFaction,omitempty"`
+	// Consequences are what the action caused when it was accepted. Replays
+	// recompute them; they are not read back.
+	Consequences *ActionConsequences `json:"consequences,omitempty"`
+	// LeechPreferences are the account leech preferences that answered
+	// offers during the action. Replays apply them again.
+	LeechPreferences map[string]LeechPreferences `json:"leechPreferences,omitempty"`
+}
+
+// SaveFileSettings are the CreateGameOptions a saved game was started with.
+// Seed reproduces the setup draws on import; exports of running games keep it
+// secret and carry the Draws instead.
+type SaveFileSettings struct {
+	RandomizeTurnOrder    bool                       `json:"randomizeTurnOrder"`
+	SetupMode             SetupMode                  `json:"setupMode"`
+	TurnTimer             *TurnTimerConfig           `json:"turnTimer,omitempty"`
+	MapID                 board.MapID                `json:"mapId"`
+	EnableFanFactions     bool                       `json:"enableFanFactions"`
+	EnableFireIceFactions bool                       `json:"enableFireIceFactions"`
+	FireIceScoring        FireIceFinalScoringSetting `json:"fireIceScoring"`
+	CustomMap             *board.CustomMapDefinition `json:"customMap,omitempty"`
+	HiddenResources       bool                       `json:"hiddenResources,omitempty"`
+	Hotseat               bool                       `json:"hotseat,omitempty"`
+	PublicSummary         bool                       `json:"publicSummary,omitempty"`
+	PublicSummaryConsent  []string                   `json:"publicSummaryConsent,omitempty"`
+	TurnOrderPolicy       TurnOrderPolicy            `json:"turnOrderPolicy,omitempty"`
+	Handicaps             map[string]Handicap        `json:"handicaps,omitempty"`
+	RemovedBonusCards     []BonusCardType            `json:"removedBonusCards,omitempty"`
+	SpeedPreset           SpeedPreset                `json:"speedPreset,omitempty"`
+	Seed                  int64                      `json:"seed,omitempty"`
+	Draws                 *SetupDraws                `json:"draws,omitempty"`
+}
+
+// SaveFile is a self-contained export of an in-progress game: the settings and
+// seats it was created with, every accepted action, and the pending decision at
+// export time (used to verify the import).
+type SaveFile struct {
+	Version         int                    `json:"version"`
+	GameID          string                 `json:"gameId"`
+	PlayerIDs       []string               `json:"playerIds"`
+	Settings        SaveFileSettings       `json:"settings"`
+	Actions         []RecordedAction       `json:"actions"`
+	Revision        int                    `json:"revision"`
+	PendingDecision map[string]interface{} `json:"pendingDecision,omitempty"`
+}
+
+// RecordedActionBuilder turns a recorded wire action back into an Action.
+type RecordedActionBuilder func(RecordedAction) (Action, error)
+
+// ImportedGame is a game rebuilt from a save file, ready to be registered with
+// AddImportedGame.
+type ImportedGame struct {
+	State    *GameState
+	Revision int
+
+	setup   gameSetup
+	history []RecordedAction
+}
+
+// gameSetup records how a game was created so it can be exported.
+type gameSetup struct {
+	playerIDs []string
+	settings  SaveFileSettings
+}
+
+func newGameSetup(playerIDs []string, opts CreateGameOptions, seed int64) gameSetup {
+	return gameSetup{
+		playerIDs: append([]string(nil), playerIDs...),
+		settings: SaveFileSettings{
+			RandomizeTurnOrder:    opts.RandomizeTurnOrder,
+			SetupMode:             opts.SetupMode,
+			TurnTimer:             opts.TurnTimer,
+			MapID:                 opts.MapID,
+			EnableFanFactions:     opts.EnableFanFactions,
+			EnableFireIceFactions: opts.EnableFireIceFactions,
+			FireIceScoring:        opts.FireIceScoring,
+			CustomMap:             board.CloneCustomMapDefinition(opts.CustomMap),
+			HiddenResources:       opts.HiddenResources,
+			Hotseat:               opts.Hotseat,
+			PublicSummary:         opts.PublicSummary,
+			PublicSummaryConsent:  append([]string(nil), opts.PublicSummaryConsent...),
+			TurnOrderPolicy:       opts.TurnOrderPolicy,
+			Handicaps:             cloneHandicaps(opts.Handicaps),
+			RemovedBonusCards:     append([]BonusCardType(nil), opts.RemovedBonusCards...),
+			SpeedPreset:           opts.SpeedPreset,
+			Seed:                  seed,
+			Draws:                 opts.Draws,
+		},
+	}
+}
+
+func (s SaveFileSettings) createGameOptions() CreateGameOptions {
+	seed := s.Seed
+	return CreateGameOptions{
+		RandomizeTurnOrder:    s.RandomizeTurnOrder,
+		SetupMode:             s.SetupMode,
+		TurnTimer:             s.TurnTimer,
+		MapID:                 s.MapID,
+		EnableFanFactions:     s.EnableFanFactions,
+		EnableFireIceFactions: s.EnableFireIceFactions,
+		FireIceScoring:        s.FireIceScoring,
+		CustomMap:             board.CloneCustomMapDefinition(s.CustomMap),
+		HiddenResources:       s.HiddenResources,
+		Hotseat:               s.Hotseat,
+		PublicSummary:         s.PublicSummary,
+		PublicSummaryConsent:  append([]string(nil), s.PublicSummaryConsent...),
+		TurnOrderPolicy:       s.TurnOrderPolicy,
+		Handicaps:             cloneHandicaps(s.Handicaps),
+		RemovedBonusCards:     append([]BonusCardType(nil), s.RemovedBonusCards...),
+		SpeedPreset:           s.SpeedPreset,
+		Seed:                  &seed,
+		Draws:                 s.Draws,
+	}
+}
+
+// ExportGame builds a save file for gameID. Games created from an existing
+// state, or changed outside recorded actions (test fixtures, bot moves), have
+// no replayable history and cannot be exported. The seed of a running game is
+// replaced by the draws it made, which replay the game without revealing it.
+func (m *Manager) ExportGame(gameID string) (*SaveFile, error) {
+	if err := m.rehydrate(gameID); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	save, err := m.exportGameLocked(gameID)
+	ended := err == nil && m.games[gameID].Phase == PhaseEnd
+	m.mu.RUnlock()
+	if err != nil || ended {
+		return save, err
+	}
+
+	draws, err := DeriveSetupDraws(save.PlayerIDs, save.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("derive setup draws: %w", err)
+	}
+	draws.Seed = 0
+	save.Settings.Seed = 0
+	save.Settings.Draws = draws
+	return save, nil
+}
+
+func (m *Manager) exportGameLocked(gameID string) (*SaveFile, error) {
+	gs := m.games[gameID]
+	if gs == nil {
+		return nil, fmt.Errorf("game %s not found", gameID)
+	}
+	setup, ok := m.setups[gameID]
+	if !ok {
+		return nil, fmt.Errorf("game %s has no recorded settings", gameID)
+	}
+	if m.unrecorded[gameID] {
+		return nil, fmt.Errorf("game %s was modified outside recorded actions", gameID)
+	}
+
+	pendingDecision, _ := serializePendingDecision(gs).(map[string]interface{})
+	return &SaveFile{
+		Version:         SaveFileVersion,
+		GameID:          gameID,
+		PlayerIDs:       append([]string(nil), setup.playerIDs...),
+		Settings:        setup.settings,
+		Actions:         append([]RecordedAction(nil), m.history[gameID]...),
+		Revision:        m.revisions[gameID],
+		PendingDecision: pendingDecision,
+	}, nil
+}
+
+// ReplaySaveFile rebuilds a game from save by recreating it with the recorded
+// settings and replaying every action. The result is checked against the
+// recorded revision and pending decision.
+func ReplaySaveFile(save *SaveFile, buildAction RecordedActionBuilder) (*ImportedGame, error) {
+	return ReplaySaveFileObserved(save, buildAction, nil)
+}
+
+// ReplaySaveFileObserved is ReplaySaveFile, calling observe with the state
+// each action is about to be applied to. observe must not modify the state.
+func ReplaySaveFileObserved(save *SaveFile, buildAction RecordedActionBuilder, observe func(gs *GameState, action Action)) (*ImportedGame, error) {
+	if save == nil {
+		return nil, fmt.Errorf("missing save file")
+	}
+	if save.Version != SaveFileVersion {
+		return nil, fmt.Errorf("unsupported save file version %d", save.Version)
+	}
+	if len(save.PlayerIDs) < 2 {
+		return nil, fmt.Errorf("save file requires at least 2 players")
+	}
+
+	const replayID = "import"
+	scratch, err := replayRecordedLog(replayID, save.PlayerIDs, save.Settings, save.Actions, buildAction, observe)
+	if err != nil {
+		return nil, err
+	}
+
+	gs := scratch.games[replayID]
+	revision := scratch.revisions[replayID]
+	if revision != save.Revision {
+		return nil, fmt.Errorf("replayed revision %d does not match saved revision %d", revision, save.Revision)
+	}
+	if err := verifyImportedPendingDecision(gs, save.PendingDecision); err != nil {
+		return nil, err
+	}
+	return &ImportedGame{
+		State:    gs,
+		Revision: revision,
+		setup:    scratch.setups[replayID],
+		history:  scratch.history[replayID],
+	}, nil
+}
+
+// replayRecordedLog recreates a game as gameID in a fresh Manager and applies
+// the recorded actions to it.
+func replayRecordedLog(gameID string, playerIDs []string, settings SaveFileSettings, actions []RecordedAction, buildAction RecordedActionBuilder, observe func(gs *GameState, action Action)) (*Manager, error) {
+	scratch := NewManager()
+	if err := scratch.CreateGameWithOptions(gameID, playerIDs, settings.createGameOptions()); err != nil {
+		return nil, fmt.Errorf("failed to recreate game: %w", err)
+	}
+	for i, recorded := range actions {
+		action, err := buildAction(recorded)
+		if err != nil {
+			return nil, fmt.Errorf("action %d (%s): %w", i, recorded.Type, err)
+		}
+		if observe != nil {
+			observe(scratch.games[gameID], action)
+		}
+		record := recorded
+		if _, err := scratch.ExecuteActionWithMeta(gameID, action, ActionMeta{
+			ExpectedRevision: -1,
+			SeatID:           recorded.PlayerID,
+			Record:           &record,
+		}); err != nil {
+			return nil, fmt.Errorf("action %d (%s): %w", i, recorded.Type, err)
+		}
+	}
+	return scratch, nil
+}
+
+func verifyImportedPendingDecision(gs *GameState, saved map[string]interface{}) error {
+	got, _ := serializePendingDecision(gs).(map[string]interface{})
+	if len(saved) == 0 && len(got) == 0 {
+		return nil
+	}
+	if len(saved) == 0 || len(got) == 0 {
+		return fmt.Errorf("replayed pending decision does not match save file")
+	}
+	for _, key := range []string{"type", "playerId"} {
+		if fmt.Sprint(got[key]) != fmt.Sprint(saved[key]) {
+			return fmt.Errorf("replayed pending decision %s %v does not match saved %v", key, got[key], saved[key])
+		}
+	}
+	return nil
+}
+
+// AddImportedGame registers a replayed game under id.
+func (m *Manager) AddImportedGame(id string, imported *ImportedGame) error {
+	if imported == nil || imported.State == nil {
+		return fmt.Errorf("missing imported game")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.games[id]; exists {
+		return fmt.Errorf("game already exists")
+	}
+	m.addImportedGameLocked(id, imported)
+	delete(m.archivedRevision, id)
+	return nil
+}
+
+func (m *Manager) addImportedGameLocked(id string, imported *ImportedGame) {
+	m.games[id] = imported.State
+	m.revisions[id] = imported.Revision
+	m.appliedActionID[id] = make(map[string]int)
+	m.setups[id] = imported.setup
+	m.history[id] = imported.history
+	m.lastActivity[id] = m.now()
+	delete(m.shadows, id)
+}
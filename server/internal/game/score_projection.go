@@ -0,0 +1,61 @@
+package game
+
+import "fmt"
+
+// ProjectFinalScores scores the current position as if the game ended now:
+// area, Fire & Ice, cult and resource bonuses on top of the VP already
+// earned. It does not change gs, and returns copies, so callers may edit the
+// scores. Once the game is over it returns the final scores. It returns nil
+// before every player has a faction.
+func ProjectFinalScores(gs *GameState) map[string]*PlayerFinalScore {
+	if gs == nil || len(gs.Players) == 0 {
+		return nil
+	}
+	for _, player := range gs.Players {
+		if player == nil || player.Faction == nil || player.Resources == nil || player.Resources.Power == nil {
+			return nil
+		}
+	}
+
+	scores := gs.CalculateFinalScoring()
+	projected := make(map[string]*PlayerFinalScore, len(scores))
+	for playerID, score := range scores {
+		if score == nil {
+			continue
+		}
+		copied := *score
+		projected[playerID] = &copied
+	}
+	return projected
+}
+
+// ScoreProjection projects the final scores of gameID for viewerID. In
+// hidden-resources games the resource conversion of opponents who have not
+// passed is left out, as their coins and power are.
+func (m *Manager) ScoreProjection(gameID, viewerID string) (map[string]*PlayerFinalScore, error) {
+	if err := m.rehydrate(gameID); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	gs := m.games[gameID]
+	if gs == nil {
+		return nil, fmt.Errorf("game %s not found", gameID)
+	}
+	scores := ProjectFinalScores(gs)
+	if !gs.HiddenResources || gs.Phase == PhaseEnd {
+		return scores, nil
+	}
+	for playerID, score := range scores {
+		player := gs.Players[playerID]
+		if playerID == viewerID || player.HasPassed {
+			continue
+		}
+		score.TotalVP -= score.ResourceVP
+		score.ResourceVP = 0
+		score.FactionVP = 0
+		score.TotalResourceValue = 0
+		score.ResourcesHidden = true
+	}
+	return scores, nil
+}
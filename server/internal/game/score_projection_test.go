@@ -0,0 +1,85 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/factions"
+)
+
+func TestProjectFinalScores_ScoresCurrentPositionWithoutMutatingState(t *testing.T) {
+	gs := NewGameState()
+	mustAddPlayer(t, gs, "player1", factions.NewAuren())
+	mustAddPlayer(t, gs, "player2", factions.NewSwarmlings())
+	gs.Phase = PhaseAction
+	gs.Round = 3
+	gs.GetPlayer("player1").VictoryPoints = 30
+	gs.GetPlayer("player1").Resources.Coins = 9
+	gs.CultTracks.PlayerPositions["player1"][CultFire] = 5
+
+	scores := ProjectFinalScores(gs)
+	if scores == nil {
+		t.Fatalf("expected a projection")
+	}
+	p1 := scores["player1"]
+	if p1.BaseVP != 30 || p1.CultVPByTrack[CultFire] != 8 || p1.ResourceVP == 0 {
+		t.Fatalf("unexpected projection for player1: %+v", p1)
+	}
+	if p1.TotalVP != p1.BaseVP+p1.AreaVP+p1.FireIceVP+p1.CultVP+p1.ResourceVP {
+		t.Fatalf("expected the total to add up, got %+v", p1)
+	}
+	if gs.GetPlayer("player1").VictoryPoints != 30 || gs.GetPlayer("player1").Resources.Coins != 9 || gs.FinalScoring != nil {
+		t.Fatalf("expected the projection to leave the state alone")
+	}
+
+	gs.Phase = PhaseEnd
+	gs.FinalScoring = map[string]*PlayerFinalScore{"player1": {PlayerID: "player1", TotalVP: 99}}
+	scores = ProjectFinalScores(gs)
+	scores["player1"].TotalVP = 0
+	if gs.FinalScoring["player1"].TotalVP != 99 {
+		t.Fatalf("expected the projection of a finished game to copy its final scores")
+	}
+}
+
+func TestProjectFinalScores_NilBeforeFactionSelection(t *testing.T) {
+	gs := NewGameState()
+	mustAddPlayer(t, gs, "player1", factions.NewAuren())
+	gs.Players["player2"] = &Player{ID: "player2"}
+	if scores := ProjectFinalScores(gs); scores != nil {
+		t.Fatalf("expected no projection before every faction is chosen, got %+v", scores)
+	}
+}
+
+func TestScoreProjection_HidesOpponentResources(t *testing.T) {
+	manager := NewManager()
+	if err := manager.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{HiddenResources: true}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	gs, _ := manager.GetGame("g1")
+	gs.Players["p1"].Faction = factions.NewAuren()
+	gs.Players["p2"].Faction = factions.NewSwarmlings()
+	gs.Players["p1"].Resources.Coins = 9
+	gs.Players["p2"].Resources.Coins = 9
+	gs.Phase = PhaseAction
+
+	scores, err := manager.ScoreProjection("g1", "p1")
+	if err != nil {
+		t.Fatalf("score projection: %v", err)
+	}
+	if scores["p1"].ResourcesHidden || scores["p1"].ResourceVP == 0 {
+		t.Fatalf("expected the viewer's own resources scored, got %+v", scores["p1"])
+	}
+	p2 := scores["p2"]
+	if !p2.ResourcesHidden || p2.ResourceVP != 0 || p2.TotalResourceValue != 0 {
+		t.Fatalf("expected the opponent's resources left out, got %+v", p2)
+	}
+
+	gs.Players["p2"].HasPassed = true
+	scores, _ = manager.ScoreProjection("g1", "p1")
+	if scores["p2"].ResourcesHidden {
+		t.Fatalf("expected a passed opponent's resources scored, got %+v", scores["p2"])
+	}
+
+	if _, err := manager.ScoreProjection("missing", "p1"); err == nil {
+		t.Fatalf("expected an unknown game to be an error")
+	}
+}
@@ -3,6 +3,7 @@ package game
 import (
 	"fmt"
 	"math/rand"
+	"time"
 
 	"github.com/lukev/tm_server/internal/models"
 )
@@ -77,91 +78,16 @@ type ScoringTile struct {
 	CultRewardAmount int               `json:"cultRewardAmount"`
 }
 
-// GetAllScoringTiles returns all 9 scoring tiles
+// GetAllScoringTiles returns all scoring tiles, as defined in data/tiles.yaml.
+// Games use the definitions they were created with; see
+// GameState.AllScoringTiles.
 func GetAllScoringTiles() []ScoringTile {
-	return []ScoringTile{
-		{
-			Type:             ScoringDwellingWater,
-			ActionType:       ScoringActionDwelling,
-			ActionVP:         2,
-			CultTrack:        CultWater,
-			CultThreshold:    4,
-			CultRewardType:   CultRewardPriest,
-			CultRewardAmount: 1,
-		},
-		{
-			Type:             ScoringDwellingFire,
-			ActionType:       ScoringActionDwelling,
-			ActionVP:         2,
-			CultTrack:        CultFire,
-			CultThreshold:    4,
-			CultRewardType:   CultRewardPower,
-			CultRewardAmount: 4,
-		},
-		{
-			Type:             ScoringTradingHouseWater,
-			ActionType:       ScoringActionTradingHouse,
-			ActionVP:         3,
-			CultTrack:        CultWater,
-			CultThreshold:    4,
-			CultRewardType:   CultRewardSpade,
-			CultRewardAmount: 1,
-		},
-		{
-			Type:             ScoringTradingHouseAir,
-			ActionType:       ScoringActionTradingHouse,
-			ActionVP:         3,
-			CultTrack:        CultAir,
-			CultThreshold:    4,
-			CultRewardType:   CultRewardSpade,
-			CultRewardAmount: 1,
-		},
-		{
-			Type:             ScoringTemplePriest,
-			ActionType:       ScoringActionTemple,
-			ActionVP:         4,
-			CultTrack:        CultFire, // Not used for this tile
-			CultThreshold:    0,        // Special: 2 coins per priest sent to cult
-			CultRewardType:   CultRewardCoin,
-			CultRewardAmount: 2,
-		},
-		{
-			Type:             ScoringStrongholdFire,
-			ActionType:       ScoringActionStronghold,
-			ActionVP:         5,
-			CultTrack:        CultFire,
-			CultThreshold:    2,
-			CultRewardType:   CultRewardWorker,
-			CultRewardAmount: 1,
-		},
-		{
-			Type:             ScoringStrongholdAir,
-			ActionType:       ScoringActionStronghold,
-			ActionVP:         5,
-			CultTrack:        CultAir,
-			CultThreshold:    2,
-			CultRewardType:   CultRewardWorker,
-			CultRewardAmount: 1,
-		},
-		{
-			Type:             ScoringSpades,
-			ActionType:       ScoringActionSpades,
-			ActionVP:         2,
-			CultTrack:        CultEarth,
-			CultThreshold:    1,
-			CultRewardType:   CultRewardCoin,
-			CultRewardAmount: 1,
-		},
-		{
-			Type:             ScoringTown,
-			ActionType:       ScoringActionTown,
-			ActionVP:         5,
-			CultTrack:        CultEarth,
-			CultThreshold:    4,
-			CultRewardType:   CultRewardSpade,
-			CultRewardAmount: 1,
-		},
-	}
+	return append([]ScoringTile(nil), currentTiles().scoringTiles...)
+}
+
+// AllScoringTiles returns the scoring tiles gs was created with.
+func (gs *GameState) AllScoringTiles() []ScoringTile {
+	return append([]ScoringTile(nil), gs.tileDefinitions().scoringTiles...)
 }
 
 // ScoringTileState tracks the scoring tiles for the game
@@ -181,10 +107,20 @@ func NewScoringTileState() *ScoringTileState {
 // InitializeForGame randomly selects 6 scoring tiles for the game
 // Spades tile cannot be in rounds 5 or 6
 func (sts *ScoringTileState) InitializeForGame() error {
-	allTiles := GetAllScoringTiles()
+	return sts.InitializeForGameWithRand(rand.New(rand.NewSource(time.Now().UnixNano())))
+}
+
+// InitializeForGameWithRand is InitializeForGame drawing from rng, so a seeded
+// source yields the same tiles.
+func (sts *ScoringTileState) InitializeForGameWithRand(rng *rand.Rand) error {
+	return sts.drawWithRand(GetAllScoringTiles(), rng)
+}
+
+// drawWithRand draws the game's tiles from allTiles, which it shuffles.
+func (sts *ScoringTileState) drawWithRand(allTiles []ScoringTile, rng *rand.Rand) error {
 
 	// Shuffle tiles
-	rand.Shuffle(len(allTiles), func(i, j int) {
+	rng.Shuffle(len(allTiles), func(i, j int) {
 		allTiles[i], allTiles[j] = allTiles[j], allTiles[i]
 	})
 
@@ -339,30 +275,71 @@ func (gs *GameState) awardSpecialCultRewards(tile *ScoringTile) {
 }
 
 func (gs *GameState) awardRegularCultRewards(tile *ScoringTile) {
-	// Award rewards based on how many thresholds the player has crossed
-	// e.g., "2 steps = 1 worker" means position 8 gives 4 workers (8/2 = 4)
+	if tile.CultThreshold == 0 {
+		return // No threshold (shouldn't happen for regular tiles)
+	}
 	for playerID, player := range gs.Players {
 		if isArchivists(player) {
 			continue
 		}
-		position := gs.CultTracks.GetPosition(playerID, tile.CultTrack)
-
-		if tile.CultThreshold == 0 {
-			continue // Skip if no threshold (shouldn't happen for regular tiles)
+		progress := tile.cultRewardProgress(gs.CultTracks.GetPosition(playerID, tile.CultTrack))
+		if progress.RewardCount > 0 {
+			gs.grantCultReward(playerID, player, tile.CultRewardType, progress.RewardCount*tile.CultRewardAmount)
 		}
+	}
+}
 
-		// Calculate how many times the threshold was crossed
-		rewardCount := position / tile.CultThreshold
+// CultRewardProgress is how far a player is toward a scoring tile's cult
+// reward, which pays once per CultThreshold steps on its track.
+type CultRewardProgress struct {
+	Track    CultTrack `json:"track"`
+	Position int       `json:"position"`
+	// RewardCount is how many times the reward pays out at Position.
+	RewardCount int `json:"rewardCount"`
+	// StepsToNext is how many more steps pay it once more; 0 when the next
+	// multiple lies beyond the top of the track.
+	StepsToNext int `json:"stepsToNext"`
+}
 
-		if rewardCount > 0 {
-			totalReward := rewardCount * tile.CultRewardAmount
+// cultRewardProgress is the progress toward tile's cult reward from position,
+// e.g. for "2 steps = 1 worker" position 7 pays 3 workers, 1 step from a 4th.
+func (tile *ScoringTile) cultRewardProgress(position int) CultRewardProgress {
+	progress := CultRewardProgress{Track: tile.CultTrack, Position: position}
+	if tile.CultThreshold <= 0 {
+		return progress
+	}
+	progress.RewardCount = position / tile.CultThreshold
+	if next := (progress.RewardCount + 1) * tile.CultThreshold; next <= 10 {
+		progress.StepsToNext = next - position
+	}
+	return progress
+}
 
-			gs.grantCultReward(playerID, player, tile.CultRewardType, totalReward)
+// CultRewardProgress returns each player's progress toward the current
+// round's cult reward, or nil when the round's tile has no cult threshold.
+// Archivists, who take no cult rewards, are left out.
+func (gs *GameState) CultRewardProgress() map[string]CultRewardProgress {
+	if gs == nil || gs.ScoringTiles == nil || gs.CultTracks == nil {
+		return nil
+	}
+	tile := gs.ScoringTiles.GetTileForRound(gs.Round)
+	if tile == nil || tile.Type == ScoringTemplePriest || tile.CultThreshold <= 0 {
+		return nil
+	}
+	out := make(map[string]CultRewardProgress, len(gs.Players))
+	for playerID, player := range gs.Players {
+		if player == nil || isArchivists(player) {
+			continue
 		}
+		out[playerID] = tile.cultRewardProgress(gs.CultTracks.GetPosition(playerID, tile.CultTrack))
 	}
+	return out
 }
 
 func (gs *GameState) grantCultReward(playerID string, player *Player, rewardType CultRewardType, amount int) {
+	if player.Resigned {
+		return
+	}
 	amount = adjustCultRewardAmount(player, rewardType, amount)
 	switch rewardType {
 	case CultRewardPriest:
@@ -371,7 +348,7 @@ func (gs *GameState) grantCultReward(playerID string, player *Player, rewardType
 			gs.queueTreasurersDeposit(playerID, 0, 0, gained, "cult_reward")
 		}
 	case CultRewardPower:
-		player.Resources.Power.GainPower(amount)
+		gs.GainPowerForReason(playerID, amount, PowerGainCultReward, "")
 	case CultRewardSpade:
 		if isRiverwalkers(player) {
 			return
@@ -376,3 +376,42 @@ func TestAwardCultRewards_PriestCoins(t *testing.T) {
 		t.Errorf("expected priest count to be reset, got %d", gs.ScoringTiles.GetPriestsSent("player1"))
 	}
 }
+
+func TestCultRewardProgress_CountsStepsToNextReward(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("player1", factions.NewNomads())
+	gs.AddPlayer("player2", factions.NewAuren())
+	gs.Round = 1
+
+	// 4 steps on Water = 1 spade
+	gs.ScoringTiles.Tiles = []ScoringTile{
+		{
+			Type:             ScoringDwellingWater,
+			CultTrack:        CultWater,
+			CultThreshold:    4,
+			CultRewardType:   CultRewardSpade,
+			CultRewardAmount: 1,
+		},
+	}
+	gs.CultTracks.AdvancePlayer("player1", CultWater, 5, gs.GetPlayer("player1"), gs)
+	gs.CultTracks.AdvancePlayer("player2", CultWater, 8, gs.GetPlayer("player2"), gs)
+
+	progress := gs.CultRewardProgress()
+	if got, want := progress["player1"], (CultRewardProgress{Track: CultWater, Position: 5, RewardCount: 1, StepsToNext: 3}); got != want {
+		t.Errorf("player1 progress = %+v, want %+v", got, want)
+	}
+	// A third spade would need position 12, past the top of the track.
+	if got := progress["player2"]; got.RewardCount != 2 || got.StepsToNext != 0 {
+		t.Errorf("expected player2 at 2 spades with none to come, got %+v", got)
+	}
+
+	gs.AwardCultRewards()
+	if got := gs.PendingCultRewardSpades["player1"]; got != progress["player1"].RewardCount {
+		t.Errorf("expected the cleanup reward to match the progress, got %d spades", got)
+	}
+
+	gs.ScoringTiles.Tiles[0] = ScoringTile{Type: ScoringTemplePriest, CultRewardType: CultRewardCoin, CultRewardAmount: 2}
+	if progress := gs.CultRewardProgress(); progress != nil {
+		t.Errorf("expected no progress for the priest tile, got %+v", progress)
+	}
+}
@@ -0,0 +1,127 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SetupDraws are the outcomes of a game's random setup draws. All of them
+// follow from the seed, so anyone holding the seed and the settings can
+// re-derive them.
+type SetupDraws struct {
+	Seed                    int64                   `json:"seed"`
+	ScoringTiles            []ScoringTileType       `json:"scoringTiles"`
+	RemovedBonusCards       []BonusCardType         `json:"removedBonusCards"`
+	FireIceFinalScoringTile FireIceFinalScoringTile `json:"fireIceFinalScoringTile,omitempty"`
+	// TurnOrder is the drawn starting order. Passing changes it during the
+	// game, so audits report it without checking it.
+	TurnOrder []string `json:"turnOrder"`
+}
+
+// DeriveSetupDraws redoes the setup draws of a game created for playerIDs
+// with settings, seed included.
+func DeriveSetupDraws(playerIDs []string, settings SaveFileSettings) (*SetupDraws, error) {
+	const auditID = "audit"
+	scratch := NewManager()
+	if err := scratch.CreateGameWithOptions(auditID, playerIDs, settings.createGameOptions()); err != nil {
+		return nil, err
+	}
+	return setupDrawsOf(scratch.games[auditID]), nil
+}
+
+func setupDrawsOf(gs *GameState) *SetupDraws {
+	draws := &SetupDraws{
+		Seed:                    gs.Seed,
+		FireIceFinalScoringTile: gs.FireIceFinalScoringTile,
+		TurnOrder:               append([]string(nil), gs.TurnOrder...),
+		ScoringTiles:            []ScoringTileType{},
+		RemovedBonusCards:       []BonusCardType{},
+	}
+	if gs.ScoringTiles != nil {
+		for _, tile := range gs.ScoringTiles.Tiles {
+			draws.ScoringTiles = append(draws.ScoringTiles, tile.Type)
+		}
+	}
+	if gs.BonusCards != nil {
+		draws.RemovedBonusCards = append(draws.RemovedBonusCards, gs.BonusCards.Removed...)
+	}
+	return draws
+}
+
+// apply replaces the scoring tiles and Fire & Ice tile drawn for gs with d's,
+// and returns d's turn order for playerIDs. Bonus cards are applied by
+// removing d's removed cards when they are selected.
+func (d *SetupDraws) apply(gs *GameState, playerIDs []string) ([]string, error) {
+	if len(d.TurnOrder) != len(playerIDs) {
+		return nil, fmt.Errorf("turn order %v does not seat %v", d.TurnOrder, playerIDs)
+	}
+	for _, playerID := range playerIDs {
+		if !slices.Contains(d.TurnOrder, playerID) {
+			return nil, fmt.Errorf("turn order %v does not seat %s", d.TurnOrder, playerID)
+		}
+	}
+
+	all := gs.tileDefinitions().scoringTiles
+	tiles := make([]ScoringTile, 0, len(d.ScoringTiles))
+	for _, tileType := range d.ScoringTiles {
+		i := slices.IndexFunc(all, func(tile ScoringTile) bool { return tile.Type == tileType })
+		if i < 0 {
+			return nil, fmt.Errorf("unknown scoring tile %d", tileType)
+		}
+		tiles = append(tiles, all[i])
+	}
+	gs.ScoringTiles.Tiles = tiles
+	gs.FireIceFinalScoringTile = d.FireIceFinalScoringTile
+	return append([]string(nil), d.TurnOrder...), nil
+}
+
+// matches reports the first draw that played differs on from d.
+func (d *SetupDraws) matches(played *SetupDraws) error {
+	switch {
+	case d.Seed != played.Seed:
+		return fmt.Errorf("seed %d was recorded, %d was played", d.Seed, played.Seed)
+	case !slices.Equal(d.ScoringTiles, played.ScoringTiles):
+		return fmt.Errorf("seed derives scoring tiles %v, %v were played", d.ScoringTiles, played.ScoringTiles)
+	case !slices.Equal(d.RemovedBonusCards, played.RemovedBonusCards):
+		return fmt.Errorf("seed derives removed bonus cards %v, %v were removed", d.RemovedBonusCards, played.RemovedBonusCards)
+	case d.FireIceFinalScoringTile != played.FireIceFinalScoringTile:
+		return fmt.Errorf("seed derives Fire & Ice tile %q, %q was played", d.FireIceFinalScoringTile, played.FireIceFinalScoringTile)
+	}
+	return nil
+}
+
+// AuditSeed re-derives the setup draws of the finished game gameID from its
+// recorded seed and settings, and checks them against the game that was
+// played. The seed stays secret until the game ends, so running games cannot
+// be audited. Nor can games imported from the export of a running game: they
+// were set up from the recorded draws, not from a seed.
+func (m *Manager) AuditSeed(gameID string) (*SetupDraws, error) {
+	if err := m.rehydrate(gameID); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	gs := m.games[gameID]
+	setup, hasSetup := m.setups[gameID]
+	var played *SetupDraws
+	if gs != nil {
+		played = setupDrawsOf(gs)
+	}
+	ended := gs != nil && gs.Phase == PhaseEnd
+	m.mu.RUnlock()
+
+	switch {
+	case gs == nil:
+		return nil, fmt.Errorf("game %s not found", gameID)
+	case !ended:
+		return nil, fmt.Errorf("game %s has not ended", gameID)
+	case !hasSetup:
+		return nil, fmt.Errorf("game %s has no recorded settings", gameID)
+	case setup.settings.Draws != nil:
+		return nil, fmt.Errorf("game %s was set up from recorded draws without a seed and cannot be audited", gameID)
+	}
+	derived, err := DeriveSetupDraws(setup.playerIDs, setup.settings)
+	if err != nil {
+		return nil, fmt.Errorf("derive setup draws: %w", err)
+	}
+	return derived, derived.matches(played)
+}
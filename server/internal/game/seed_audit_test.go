@@ -0,0 +1,105 @@
+package game
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAuditSeed_RederivesSetupDrawsOfFinishedGame(t *testing.T) {
+	manager := NewManager()
+	seed := int64(1234)
+	if err := manager.CreateGameWithOptions("g1", []string{"p1", "p2", "p3"}, CreateGameOptions{
+		RandomizeTurnOrder: true,
+		FireIceScoring:     FireIceFinalScoringRandom,
+		RemovedBonusCards:  []BonusCardType{BonusCardSpade},
+		Seed:               &seed,
+	}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	gs, _ := manager.GetGame("g1")
+	startingOrder := append([]string(nil), gs.TurnOrder...)
+
+	if _, err := manager.AuditSeed("g1"); err == nil || !strings.Contains(err.Error(), "has not ended") {
+		t.Fatalf("expected a running game to refuse the audit, got %v", err)
+	}
+
+	gs.Phase = PhaseEnd
+	gs.TurnOrder = []string{startingOrder[2], startingOrder[0], startingOrder[1]}
+	draws, err := manager.AuditSeed("g1")
+	if err != nil {
+		t.Fatalf("audit seed: %v", err)
+	}
+	if draws.Seed != seed || len(draws.ScoringTiles) != 6 || !slices.Contains(draws.RemovedBonusCards, BonusCardSpade) {
+		t.Fatalf("unexpected draws %+v", draws)
+	}
+	if !slices.Equal(draws.TurnOrder, startingOrder) {
+		t.Fatalf("expected the drawn starting order %v, got %v", startingOrder, draws.TurnOrder)
+	}
+
+	gs.ScoringTiles.Tiles[0], gs.ScoringTiles.Tiles[1] = gs.ScoringTiles.Tiles[1], gs.ScoringTiles.Tiles[0]
+	if _, err := manager.AuditSeed("g1"); err == nil || !strings.Contains(err.Error(), "scoring tiles") {
+		t.Fatalf("expected swapped scoring tiles to fail the audit, got %v", err)
+	}
+}
+
+func TestExportGame_ReplacesSeedOfRunningGameWithDraws(t *testing.T) {
+	manager := NewManager()
+	seed := int64(99)
+	if err := manager.CreateGameWithOptions("g1", []string{"p1", "p2", "p3"}, CreateGameOptions{
+		RandomizeTurnOrder: true,
+		FireIceScoring:     FireIceFinalScoringRandom,
+		Seed:               &seed,
+	}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	gs, _ := manager.GetGame("g1")
+
+	save, err := manager.ExportGame("g1")
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if save.Settings.Seed != 0 || save.Settings.Draws == nil || save.Settings.Draws.Seed != 0 {
+		t.Fatalf("expected the seed to be replaced by draws, got %+v", save.Settings)
+	}
+
+	imported, err := ReplaySaveFile(save, func(RecordedAction) (Action, error) { return nil, nil })
+	if err != nil {
+		t.Fatalf("replay save file: %v", err)
+	}
+	played, replayed := setupDrawsOf(gs), setupDrawsOf(imported.State)
+	if !slices.Equal(played.ScoringTiles, replayed.ScoringTiles) || !slices.Equal(played.RemovedBonusCards, replayed.RemovedBonusCards) ||
+		!slices.Equal(played.TurnOrder, replayed.TurnOrder) || played.FireIceFinalScoringTile != replayed.FireIceFinalScoringTile {
+		t.Fatalf("expected the import to reproduce the draws %+v, got %+v", played, replayed)
+	}
+
+	gs.Phase = PhaseEnd
+	if save, err := manager.ExportGame("g1"); err != nil || save.Settings.Seed != seed || save.Settings.Draws != nil {
+		t.Fatalf("expected a finished game to export its seed, got %+v (%v)", save, err)
+	}
+}
+
+func TestAuditSeed_RefusesGameImportedWithDraws(t *testing.T) {
+	manager := NewManager()
+	seed := int64(5)
+	if err := manager.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{Seed: &seed}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	save, err := manager.ExportGame("g1")
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	imported, err := ReplaySaveFile(save, func(RecordedAction) (Action, error) { return nil, nil })
+	if err != nil {
+		t.Fatalf("replay save file: %v", err)
+	}
+	if err := manager.AddImportedGame("g2", imported); err != nil {
+		t.Fatalf("add imported game: %v", err)
+	}
+	gs, _ := manager.GetGame("g2")
+	gs.Phase = PhaseEnd
+
+	if draws, err := manager.AuditSeed("g2"); draws != nil || err == nil || !strings.Contains(err.Error(), "cannot be audited") {
+		t.Fatalf("expected a game set up from draws to be unauditable, got %+v (%v)", draws, err)
+	}
+}
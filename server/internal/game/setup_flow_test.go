@@ -185,3 +185,89 @@ func TestSetupDwelling_ReplayCompatibilityWithoutTurnOrder(t *testing.T) {
 		t.Fatalf("expected setup subphase to remain none in replay compatibility mode, got %s", gs.SetupSubphase)
 	}
 }
+
+func TestSetupFlow_ManagerRejectsOutOfOrderSetupActions(t *testing.T) {
+	gs := NewGameState()
+	if err := gs.AddPlayer("nomads", factions.NewNomads()); err != nil {
+		t.Fatalf("failed adding nomads: %v", err)
+	}
+	if err := gs.AddPlayer("witches", factions.NewWitches()); err != nil {
+		t.Fatalf("failed adding witches: %v", err)
+	}
+	gs.TurnOrder = []string{"nomads", "witches"}
+	gs.InitializeSetupSequence()
+
+	hex := board.NewHex(0, 1)
+	gs.Map.TransformTerrain(hex, gs.GetPlayer("witches").Faction.GetHomeTerrain())
+
+	err := validateActionTurnAndPendingState(gs, NewSetupDwellingAction("witches", hex))
+	if got := ReasonCodeOf(err); got != ReasonNotYourTurn {
+		t.Fatalf("expected out-of-order setup dwelling to be rejected with %s, got %v", ReasonNotYourTurn, err)
+	}
+	if got := ReasonCodeOf(NewSetupDwellingAction("witches", hex).Validate(gs)); got != ReasonNotYourTurn {
+		t.Fatalf("expected setup dwelling validation to report %s, got %s", ReasonNotYourTurn, got)
+	}
+
+	err = validateActionTurnAndPendingState(gs, &SetupBonusCardAction{
+		BaseAction: BaseAction{Type: ActionSetupBonusCard, PlayerID: "nomads"},
+		BonusCard:  BonusCardPriest,
+	})
+	if got := ReasonCodeOf(err); got != ReasonWrongPhase {
+		t.Fatalf("expected bonus card pick during dwelling placement to be rejected with %s, got %v", ReasonWrongPhase, err)
+	}
+
+	if err := validateActionTurnAndPendingState(gs, NewSetupDwellingAction("nomads", hex)); err != nil {
+		t.Fatalf("expected first setup dwelling from nomads to pass turn validation: %v", err)
+	}
+}
+
+func TestSetupBonusCardOptions_OnlyForCurrentPicker(t *testing.T) {
+	gs := NewGameState()
+	if err := gs.AddPlayer("nomads", factions.NewNomads()); err != nil {
+		t.Fatalf("failed adding nomads: %v", err)
+	}
+	if err := gs.AddPlayer("witches", factions.NewWitches()); err != nil {
+		t.Fatalf("failed adding witches: %v", err)
+	}
+	gs.TurnOrder = []string{"nomads", "witches"}
+	gs.InitializeSetupSequence()
+	for gs.SetupSubphase == SetupSubphaseDwellings {
+		gs.AdvanceSetupAfterDwelling()
+	}
+	gs.BonusCards.SetAvailableBonusCards([]BonusCardType{BonusCardShipping, BonusCardPriest, BonusCardDwellingVP})
+
+	if got := gs.SetupBonusCardOptions("nomads"); got != nil {
+		t.Fatalf("expected no options for nomads before witches pick, got %v", got)
+	}
+	options := gs.SetupBonusCardOptions("witches")
+	if len(options) != 3 || options[0] > options[1] || options[1] > options[2] {
+		t.Fatalf("expected all three cards sorted for witches, got %v", options)
+	}
+
+	decision, ok := serializePendingDecision(gs).(map[string]interface{})
+	if !ok || decision["type"] != "setup_bonus_card" || decision["playerId"] != "witches" {
+		t.Fatalf("unexpected pending decision: %#v", decision)
+	}
+
+	pick := &SetupBonusCardAction{
+		BaseAction: BaseAction{Type: ActionSetupBonusCard, PlayerID: "nomads"},
+		BonusCard:  BonusCardPriest,
+	}
+	if got := ReasonCodeOf(pick.Validate(gs)); got != ReasonNotYourTurn {
+		t.Fatalf("expected out-of-order setup bonus pick to report %s, got %s", ReasonNotYourTurn, got)
+	}
+	pick.PlayerID = "witches"
+	if err := pick.Execute(gs); err != nil {
+		t.Fatalf("witches setup bonus pick failed: %v", err)
+	}
+
+	options = gs.SetupBonusCardOptions("nomads")
+	for _, card := range options {
+		if card == BonusCardPriest {
+			t.Fatalf("expected taken card to be excluded from nomads options: %v", options)
+		}
+	}
+	if len(options) != 2 {
+		t.Fatalf("expected two remaining options for nomads, got %v", options)
+	}
+}
@@ -0,0 +1,108 @@
+package game
+
+import "github.com/lukev/tm_server/internal/models"
+
+// strongholdSpecialActions maps each stronghold special action to the faction
+// whose stronghold grants it.
+var strongholdSpecialActions = map[SpecialActionType]models.FactionType{
+	SpecialActionAurenCultAdvance:          models.FactionAuren,
+	SpecialActionWitchesRide:               models.FactionWitches,
+	SpecialActionSwarmlingsUpgrade:         models.FactionSwarmlings,
+	SpecialActionChaosMagiciansDoubleTurn:  models.FactionChaosMagicians,
+	SpecialActionGiantsTransform:           models.FactionGiants,
+	SpecialActionNomadsSandstorm:           models.FactionNomads,
+	SpecialActionEnlightenedGainPower:      models.FactionTheEnlightened,
+	SpecialActionConspiratorsSwapFavor:     models.FactionConspirators,
+	SpecialActionChildrenPlacePowerTokens:  models.FactionChildrenOfTheWyrm,
+	SpecialActionProspectorsGainCoins:      models.FactionProspectors,
+	SpecialActionTimeTravelersPowerShift:   models.FactionTimeTravelers,
+	SpecialActionArchitectsMoveBridge:      models.FactionArchitects,
+	SpecialActionShapeshiftersShiftTerrain: models.FactionShapeshifters,
+	SpecialActionSelkiesStronghold:         models.FactionSelkies,
+}
+
+// AvailableSpecialActions lists, in type order, the special actions playerID
+// can take now: it is their main turn, they hold the stronghold, favor tile,
+// bonus card or tokens granting the action, its octagon is not yet covered
+// this round, and they have what every use of it costs. Checks that depend on
+// the chosen target, such as a hex or cult track, are left to Validate.
+// Mermaids' river town is passive and never listed.
+func (gs *GameState) AvailableSpecialActions(playerID string) []SpecialActionType {
+	available := []SpecialActionType{}
+	player := gs.GetPlayer(playerID)
+	if player == nil || player.Faction == nil || mainTurnPlayerID(gs) != playerID {
+		return available
+	}
+	for actionType := SpecialActionAurenCultAdvance; actionType <= SpecialActionSelkiesStronghold; actionType++ {
+		if tracksSpecialActionUsage(actionType) && player.SpecialActionsUsed[actionType] {
+			continue
+		}
+		if gs.specialActionAvailable(player, actionType) {
+			available = append(available, actionType)
+		}
+	}
+	return available
+}
+
+func (gs *GameState) specialActionAvailable(player *Player, actionType SpecialActionType) bool {
+	if faction, ok := strongholdSpecialActions[actionType]; ok {
+		if !player.HasStrongholdAbility || player.Faction.GetType() != faction {
+			return false
+		}
+	}
+	power := player.Resources.Power
+
+	switch actionType {
+	case SpecialActionAurenCultAdvance:
+		for _, track := range []CultTrack{CultFire, CultWater, CultEarth, CultAir} {
+			if player.CultPositions[track] < 10 {
+				return true
+			}
+		}
+		return false
+	case SpecialActionWitchesRide:
+		return gs.CheckBuildingLimit(player.ID, models.BuildingDwelling) == nil
+	case SpecialActionSwarmlingsUpgrade:
+		return gs.CheckBuildingLimit(player.ID, models.BuildingTradingHouse) == nil
+	case SpecialActionConspiratorsSwapFavor:
+		return len(gs.FavorTiles.GetPlayerTiles(player.ID)) > 0
+	case SpecialActionChildrenPlacePowerTokens:
+		return power != nil && power.TotalPower() >= 1
+	case SpecialActionTimeTravelersPowerShift:
+		return power != nil && power.Bowl1 >= 1
+	case SpecialActionShapeshiftersShiftTerrain:
+		return power != nil && power.TotalPower() >= 5
+	case SpecialActionArchitectsMoveBridge:
+		for _, owner := range gs.Map.Bridges {
+			if owner == player.ID {
+				return true
+			}
+		}
+		return false
+	case SpecialActionGiantsTransform, SpecialActionNomadsSandstorm, SpecialActionSelkiesStronghold,
+		SpecialActionChaosMagiciansDoubleTurn, SpecialActionEnlightenedGainPower, SpecialActionProspectorsGainCoins:
+		return true
+	case SpecialActionWater2CultAdvance:
+		return gs.FavorModifiers(player.ID).CultAction
+	case SpecialActionBonusCardCultAdvance:
+		return gs.hasBonusCard(player.ID, BonusCardCultAdvance)
+	case SpecialActionBonusCardSpade:
+		if !gs.hasBonusCard(player.ID, BonusCardSpade) || isRiverwalkers(player) {
+			return false
+		}
+		return !isProspectors(player) || gs.PriestLedger(player.ID).CanGain(1)
+	case SpecialActionDjinniSwapCults:
+		return isDjinni(player) && player.DjinniLampTokens > 0
+	default:
+		return false
+	}
+}
+
+func (gs *GameState) hasBonusCard(playerID string, cardType BonusCardType) bool {
+	for _, card := range gs.BonusCards.GetPlayerCards(playerID) {
+		if card == cardType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/game/factions"
+)
+
+func TestAvailableSpecialActions(t *testing.T) {
+	gs := NewGameState()
+	mustAddPlayer(t, gs, "p1", factions.NewAuren())
+	mustAddPlayer(t, gs, "p2", factions.NewNomads())
+	gs.TurnOrder = []string{"p1", "p2"}
+	gs.Phase = PhaseAction
+	gs.Round = 1
+	player := gs.GetPlayer("p1")
+
+	if got := gs.AvailableSpecialActions("p1"); len(got) != 0 {
+		t.Fatalf("expected nothing before the stronghold, got %v", got)
+	}
+
+	buildStrongholdForPlayer(gs, "p1", board.NewHex(0, 1))
+	gs.BonusCards.SetAvailableBonusCards([]BonusCardType{BonusCardCultAdvance})
+	gs.BonusCards.TakeBonusCard("p1", BonusCardCultAdvance)
+	want := []SpecialActionType{SpecialActionAurenCultAdvance, SpecialActionBonusCardCultAdvance}
+	if got := gs.AvailableSpecialActions("p1"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	player.SpecialActionsUsed[SpecialActionAurenCultAdvance] = true
+	want = []SpecialActionType{SpecialActionBonusCardCultAdvance}
+	if got := gs.AvailableSpecialActions("p1"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected the used octagon to be left out, got %v", got)
+	}
+
+	// Only the player on their main turn can take one.
+	buildStrongholdForPlayer(gs, "p2", board.NewHex(0, 2))
+	if got := gs.AvailableSpecialActions("p2"); len(got) != 0 {
+		t.Fatalf("expected nothing off turn, got %v", got)
+	}
+	gs.CurrentPlayerIndex = 1
+	want = []SpecialActionType{SpecialActionNomadsSandstorm}
+	if got := gs.AvailableSpecialActions("p2"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v on p2's turn, got %v", want, got)
+	}
+
+	state := SerializeState(gs, "g1")
+	p2 := state["players"].(map[string]interface{})["p2"].(map[string]interface{})
+	if got := p2["availableSpecialActions"]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected the payload to carry %v, got %v", want, got)
+	}
+}
@@ -377,36 +377,11 @@ func (a *SpecialAction) Validate(gs *GameState) error {
 	// Check if this specific special action has already been used this round
 	// Skip this check for Mermaids river town - it's a passive ability, not a limited action
 	if tracksSpecialActionUsage(a.ActionType) && player.SpecialActionsUsed[a.ActionType] {
-		return fmt.Errorf("special action %v already used this round", a.ActionType)
+		return ruleErrorf(ReasonActionSpaceTaken, "special action %v already used this round", a.ActionType)
 	}
 
 	// Stronghold actions require the stronghold ability
-	strongholdActions := []SpecialActionType{
-		SpecialActionAurenCultAdvance,
-		SpecialActionWitchesRide,
-		SpecialActionSwarmlingsUpgrade,
-		SpecialActionChaosMagiciansDoubleTurn,
-		SpecialActionGiantsTransform,
-		SpecialActionNomadsSandstorm,
-		SpecialActionEnlightenedGainPower,
-		SpecialActionConspiratorsSwapFavor,
-		SpecialActionChildrenPlacePowerTokens,
-		SpecialActionProspectorsGainCoins,
-		SpecialActionTimeTravelersPowerShift,
-		SpecialActionArchitectsMoveBridge,
-		SpecialActionShapeshiftersShiftTerrain,
-		SpecialActionSelkiesStronghold,
-	}
-
-	isStrongholdAction := false
-	for _, sa := range strongholdActions {
-		if a.ActionType == sa {
-			isStrongholdAction = true
-			break
-		}
-	}
-
-	if isStrongholdAction && !player.HasStrongholdAbility {
+	if _, isStrongholdAction := strongholdSpecialActions[a.ActionType]; isStrongholdAction && !player.HasStrongholdAbility {
 		return fmt.Errorf("player does not have stronghold special ability")
 	}
 
@@ -488,7 +463,7 @@ func (a *SpecialAction) validateShapeshiftersShiftTerrain(gs *GameState, player
 		return nil
 	}
 	if player.Resources.Power.TotalPower() < 5 {
-		return fmt.Errorf("not enough power to shift terrain")
+		return ruleErrorf(ReasonInsufficientPower, "not enough power to shift terrain")
 	}
 	return nil
 }
@@ -532,7 +507,7 @@ func (a *SpecialAction) validateChildrenPlacePowerTokens(gs *GameState, player *
 		return fmt.Errorf("children of the wyrm must place 1 or 2 power tokens")
 	}
 	if player.Resources == nil || player.Resources.Power == nil || player.Resources.Power.TotalPower() < len(a.TargetHexes) {
-		return fmt.Errorf("not enough power tokens available")
+		return ruleErrorf(ReasonInsufficientPower, "not enough power tokens available")
 	}
 	if gs.childrenNeedsBowl3ForBoardTokens(a.PlayerID, len(a.TargetHexes)) && !a.ConfirmSpendBowl3 {
 		return fmt.Errorf("placing these power tokens would consume Bowl III power; confirmation required")
@@ -569,7 +544,7 @@ func (a *SpecialAction) validateTimeTravelersPowerShift(player *Player) error {
 		return fmt.Errorf("time travelers must move between 1 and 4 power tokens")
 	}
 	if player.Resources == nil || player.Resources.Power == nil || player.Resources.Power.Bowl1 < a.Amount {
-		return fmt.Errorf("not enough power tokens in Bowl I")
+		return ruleErrorf(ReasonInsufficientPower, "not enough power tokens in Bowl I")
 	}
 	return nil
 }
@@ -675,12 +650,12 @@ func (a *SpecialAction) validateWitchesRide(gs *GameState, player *Player) error
 
 	mapHex := gs.Map.GetHex(*a.TargetHex)
 	if mapHex == nil {
-		return fmt.Errorf("hex does not exist: %v", a.TargetHex)
+		return ruleErrorf(ReasonInvalidHex, "hex does not exist: %v", a.TargetHex)
 	}
 
 	// Must be an unoccupied Forest space
 	if mapHex.Building != nil {
-		return fmt.Errorf("hex already has a building")
+		return ruleErrorf(ReasonTerrainOccupied, "hex already has a building")
 	}
 
 	if mapHex.Terrain != models.TerrainForest {
@@ -707,7 +682,7 @@ func (a *SpecialAction) validateSwarmlingsUpgrade(gs *GameState, player *Player)
 
 	mapHex := gs.Map.GetHex(*a.UpgradeHex)
 	if mapHex == nil {
-		return fmt.Errorf("hex does not exist: %v", a.UpgradeHex)
+		return ruleErrorf(ReasonInvalidHex, "hex does not exist: %v", a.UpgradeHex)
 	}
 
 	if mapHex.Building == nil {
@@ -740,15 +715,12 @@ func (a *SpecialAction) validateChaosMagiciansDoubleTurn(gs *GameState, player *
 		return fmt.Errorf("both actions must be specified for double-turn")
 	}
 
-	// Validate both actions
+	// The second action is validated when it executes, against the state the
+	// first action leaves behind.
 	if err := a.FirstAction.Validate(gs); err != nil {
 		return fmt.Errorf("first action invalid: %w", err)
 	}
 
-	if err := a.SecondAction.Validate(gs); err != nil {
-		return fmt.Errorf("second action invalid: %w", err)
-	}
-
 	return nil
 }
 
@@ -764,23 +736,33 @@ func (a *SpecialAction) validateGiantsTransform(gs *GameState, player *Player) e
 
 	mapHex := gs.Map.GetHex(*a.TargetHex)
 	if mapHex == nil {
-		return fmt.Errorf("hex does not exist: %v", a.TargetHex)
+		return ruleErrorf(ReasonInvalidHex, "hex does not exist: %v", a.TargetHex)
+	}
+
+	if mapHex.Terrain == models.TerrainRiver {
+		return ruleErrorf(ReasonInvalidHex, "cannot transform a river hex")
 	}
 
 	if mapHex.Building != nil {
-		return fmt.Errorf("hex already has a building")
+		return ruleErrorf(ReasonTerrainOccupied, "hex already has a building")
 	}
 
-	// Check adjacency to player's buildings
-	if !gs.IsAdjacentToPlayerBuilding(*a.TargetHex, a.PlayerID) {
-		return fmt.Errorf("hex is not adjacent to player's buildings")
+	// Check adjacency to player's buildings (directly, by bridge or by shipping).
+	// A player without buildings has nothing to be adjacent to, unlike a
+	// first dwelling placement.
+	if !gs.playerHasAnyBuilding(a.PlayerID) || !gs.IsAdjacentToPlayerBuilding(*a.TargetHex, a.PlayerID) {
+		return ruleErrorf(ReasonNotReachable, "hex is not adjacent to player's buildings")
 	}
 
-	// If building dwelling, check limit
+	// If building dwelling, check limit and cost
 	if a.BuildDwelling {
 		if err := gs.CheckBuildingLimit(a.PlayerID, models.BuildingDwelling); err != nil {
 			return err
 		}
+		dwellingCost := getDwellingBuildCost(gs, player, *a.TargetHex)
+		if !gs.canAffordWithReplayAutoConversions(player, dwellingCost) {
+			return ruleErrorf(ReasonInsufficientResources, "not enough resources for dwelling: need %v, have %v", dwellingCost, player.Resources)
+		}
 	}
 
 	return nil
@@ -798,11 +780,15 @@ func (a *SpecialAction) validateNomadsSandstorm(gs *GameState, player *Player) e
 
 	mapHex := gs.Map.GetHex(*a.TargetHex)
 	if mapHex == nil {
-		return fmt.Errorf("hex does not exist: %v", a.TargetHex)
+		return ruleErrorf(ReasonInvalidHex, "hex does not exist: %v", a.TargetHex)
+	}
+
+	if mapHex.Terrain == models.TerrainRiver {
+		return ruleErrorf(ReasonInvalidHex, "sandstorm cannot target a river hex")
 	}
 
 	if mapHex.Building != nil {
-		return fmt.Errorf("hex already has a building")
+		return ruleErrorf(ReasonTerrainOccupied, "hex already has a building")
 	}
 
 	// Must be directly adjacent (not via bridge or shipping)
@@ -817,7 +803,7 @@ func (a *SpecialAction) validateNomadsSandstorm(gs *GameState, player *Player) e
 	}
 
 	if !hasAdjacentBuilding {
-		return fmt.Errorf("sandstorm requires direct adjacency to player's structure")
+		return ruleErrorf(ReasonNotReachable, "sandstorm requires direct adjacency to player's structure")
 	}
 
 	// If building dwelling, check limit
@@ -840,13 +826,13 @@ func (a *SpecialAction) validateSelkiesStronghold(gs *GameState, player *Player)
 
 	mapHex := gs.Map.GetHex(*a.TargetHex)
 	if mapHex == nil {
-		return fmt.Errorf("hex does not exist: %v", a.TargetHex)
+		return ruleErrorf(ReasonInvalidHex, "hex does not exist: %v", a.TargetHex)
 	}
 	if mapHex.Building != nil {
-		return fmt.Errorf("hex already has a building")
+		return ruleErrorf(ReasonTerrainOccupied, "hex already has a building")
 	}
 	if !isAdjacentToPlayerBuildingWithExtraShipping(gs, *a.TargetHex, a.PlayerID, 1) {
-		return fmt.Errorf("hex is not adjacent to player's buildings within shipping range +1")
+		return ruleErrorf(ReasonNotReachable, "hex is not adjacent to player's buildings within shipping range +1")
 	}
 
 	return nil
@@ -854,8 +840,7 @@ func (a *SpecialAction) validateSelkiesStronghold(gs *GameState, player *Player)
 
 func (a *SpecialAction) validateWater2CultAdvance(gs *GameState) error {
 	// Check if player has Water+2 favor tile
-	playerTiles := gs.FavorTiles.GetPlayerTiles(a.PlayerID)
-	if !HasFavorTile(playerTiles, FavorWater2) {
+	if !gs.FavorModifiers(a.PlayerID).CultAction {
 		return fmt.Errorf("player does not have Water+2 favor tile")
 	}
 
@@ -891,7 +876,7 @@ func (a *SpecialAction) validateBonusCardSpade(gs *GameState, player *Player) er
 		return fmt.Errorf("riverwalkers cannot gain or use spades")
 	}
 	if isProspectors(player) {
-		if gs.RemainingPriestCapacity(a.PlayerID) < 1 {
+		if !gs.PriestLedger(a.PlayerID).CanGain(1) {
 			return fmt.Errorf("not enough priest capacity for prospectors bonus card spade")
 		}
 		return nil
@@ -907,24 +892,18 @@ func (a *SpecialAction) validateBonusCardSpade(gs *GameState, player *Player) er
 
 	mapHex := gs.Map.GetHex(*a.TargetHex)
 	if mapHex == nil {
-		return fmt.Errorf("hex does not exist: %v", a.TargetHex)
+		return ruleErrorf(ReasonInvalidHex, "hex does not exist: %v", a.TargetHex)
 	}
 
 	if mapHex.Building != nil {
-		return fmt.Errorf("hex already has a building")
+		return ruleErrorf(ReasonTerrainOccupied, "hex already has a building")
 	}
 
 	// Check adjacency (or skip range for Fakirs/Dwarves)
 	isAdjacent := gs.IsAdjacentToPlayerBuilding(*a.TargetHex, a.PlayerID)
 
-	// Auto-detect UseSkip for Dwarves and Fakirs if hex is not adjacent
-	if !isAdjacent && !a.UseSkip {
-		factionType := player.Faction.GetType()
-		if factionType == models.FactionDwarves || factionType == models.FactionFakirs {
-			// Automatically enable skip ability for these factions when hex is not adjacent
-			a.UseSkip = true
-		}
-	}
+	// Skip is used exactly when Dwarves/Fakirs target a hex that is not directly reachable
+	a.UseSkip = resolveSkipUsage(player, isAdjacent, a.UseSkip)
 
 	if a.UseSkip {
 		if err := ValidateSkipAbility(gs, player, *a.TargetHex); err != nil {
@@ -932,7 +911,7 @@ func (a *SpecialAction) validateBonusCardSpade(gs *GameState, player *Player) er
 		}
 	} else {
 		if !isAdjacent {
-			return fmt.Errorf("hex is not adjacent to player's buildings")
+			return ruleErrorf(ReasonNotReachable, "hex is not adjacent to player's buildings")
 		}
 	}
 
@@ -966,6 +945,10 @@ func (a *SpecialAction) Execute(gs *GameState) error {
 	}
 
 	player := gs.GetPlayer(a.PlayerID)
+	var beforeDoubleTurn *doubleTurnSnapshot
+	if a.ActionType == SpecialActionChaosMagiciansDoubleTurn {
+		beforeDoubleTurn = captureDoubleTurnSnapshot(gs)
+	}
 	suppressTurnAdvance := gs.consumePendingPostActionSpecialAction(a.PlayerID, a.ActionType)
 
 	// Mark this specific special action as used
@@ -987,7 +970,13 @@ func (a *SpecialAction) Execute(gs *GameState) error {
 			return err
 		}
 	case SpecialActionChaosMagiciansDoubleTurn:
-		return a.executeChaosMagiciansDoubleTurn(gs)
+		// Both actions apply or neither does: a failed second action undoes
+		// the first.
+		if err := a.executeChaosMagiciansDoubleTurn(gs); err != nil {
+			beforeDoubleTurn.restore(gs)
+			return err
+		}
+		return nil
 	case SpecialActionGiantsTransform:
 		if err := a.executeGiantsTransform(gs, player); err != nil {
 			return err
@@ -1017,7 +1006,7 @@ func (a *SpecialAction) Execute(gs *GameState) error {
 			return err
 		}
 	case SpecialActionEnlightenedGainPower:
-		if err := a.executeEnlightenedGainPower(player); err != nil {
+		if err := a.executeEnlightenedGainPower(gs, player); err != nil {
 			return err
 		}
 	case SpecialActionConspiratorsSwapFavor:
@@ -1066,7 +1055,7 @@ func (a *SpecialAction) executeChildrenPlacePowerTokens(gs *GameState, player *P
 	for _, targetHex := range a.TargetHexes {
 		mapHex := gs.Map.GetHex(targetHex)
 		if mapHex == nil {
-			return fmt.Errorf("hex does not exist: %v", targetHex)
+			return ruleErrorf(ReasonInvalidHex, "hex does not exist: %v", targetHex)
 		}
 		mapHex.PowerTokenOwnerPlayerID = a.PlayerID
 	}
@@ -1193,11 +1182,8 @@ func (a *SpecialAction) executeSwarmlingsUpgrade(gs *GameState, player *Player)
 		PowerValue: 2,
 	}
 
-	// Award VP from Water+1 favor tile (+3 VP when upgrading Dwelling→Trading House)
-	playerTiles := gs.FavorTiles.GetPlayerTiles(a.PlayerID)
-	if HasFavorTile(playerTiles, FavorWater1) {
-		player.VictoryPoints += 3
-	}
+	// Award VP from favor tiles (Water+1: +3 VP when upgrading Dwelling→Trading House)
+	player.VictoryPoints += gs.FavorModifiers(a.PlayerID).TradingHouseVP
 
 	// Award VP from scoring tile
 	gs.AwardActionVP(a.PlayerID, ScoringActionTradingHouse)
@@ -1211,6 +1197,33 @@ func (a *SpecialAction) executeSwarmlingsUpgrade(gs *GameState, player *Player)
 	return nil
 }
 
+// doubleTurnSnapshot holds the state a Chaos Magicians double-turn rolls back
+// to. CloneForUndo covers the game itself; the shallow copy keeps the session
+// fields and event buffers it leaves out.
+type doubleTurnSnapshot struct {
+	state   *GameState
+	session GameState
+}
+
+func captureDoubleTurnSnapshot(gs *GameState) *doubleTurnSnapshot {
+	return &doubleTurnSnapshot{state: gs.CloneForUndo(), session: *gs}
+}
+
+func (s *doubleTurnSnapshot) restore(gs *GameState) {
+	restored := *s.state
+	restored.EnableFanFactions = s.session.EnableFanFactions
+	restored.EnableFireIceFactions = s.session.EnableFireIceFactions
+	restored.PendingTurnConfirmationPlayerID = s.session.PendingTurnConfirmationPlayerID
+	restored.PendingTurnConfirmationSnapshot = s.session.PendingTurnConfirmationSnapshot
+	restored.allowAZAutoConversions = s.session.allowAZAutoConversions
+	restored.previewTrace = s.session.previewTrace
+	restored.phaseChanges = s.session.phaseChanges
+	restored.powerGains = s.session.powerGains
+	restored.newAutoLeechDecisions = s.session.newAutoLeechDecisions
+	restored.incomeReports = s.session.incomeReports
+	*gs = restored
+}
+
 func (a *SpecialAction) executeChaosMagiciansDoubleTurn(gs *GameState) error {
 	// Execute first action
 	// Suppress turn advance so the turn doesn't change between actions
@@ -1259,7 +1272,8 @@ func (a *SpecialAction) executeGiantsTransform(gs *GameState, player *Player) er
 }
 
 func (a *SpecialAction) executeNomadsSandstorm(gs *GameState, player *Player) error {
-	// Transform terrain to home terrain (Sandstorm - not considered a spade)
+	// Transform terrain to home terrain (Sandstorm - not considered a spade,
+	// so no spade scoring VP and no Alchemists power)
 	targetTerrain := player.Faction.GetHomeTerrain()
 	if err := gs.Map.TransformTerrain(*a.TargetHex, targetTerrain); err != nil {
 		return fmt.Errorf("failed to transform terrain: %w", err)
@@ -1344,14 +1358,14 @@ func (a *SpecialAction) executeBonusCardSpade(gs *GameState, player *Player) err
 	if workersNeeded > 0 {
 		if player.Faction.GetType() == models.FactionTheEnlightened {
 			if !player.Resources.Power.CanSpend(workersNeeded) {
-				return fmt.Errorf("not enough power: need %d, have %d", workersNeeded, player.Resources.Power.Bowl3)
+				return ruleErrorf(ReasonInsufficientPower, "not enough power: need %d, have %d", workersNeeded, player.Resources.Power.Bowl3)
 			}
 			if err := player.Resources.Power.SpendPower(workersNeeded); err != nil {
 				return err
 			}
 		} else {
 			if player.Resources.Workers < workersNeeded {
-				return fmt.Errorf("not enough workers: need %d, have %d", workersNeeded, player.Resources.Workers)
+				return ruleErrorf(ReasonInsufficientWorkers, "not enough workers: need %d, have %d", workersNeeded, player.Resources.Workers)
 			}
 			player.Resources.Workers -= workersNeeded
 		}
@@ -1373,7 +1387,7 @@ func (a *SpecialAction) executeBonusCardSpade(gs *GameState, player *Player) err
 	}
 
 	// Award faction-specific spade bonuses (Halflings VP, Alchemists power)
-	AwardFactionSpadeBonuses(player, spadesUsed)
+	AwardFactionSpadeBonuses(gs, player, spadesUsed)
 
 	// Optionally build dwelling if requested
 	if a.BuildDwelling {
@@ -1475,14 +1489,13 @@ func (a *SpecialAction) executeMermaidsRiverTown(gs *GameState, player *Player)
 	return nil
 }
 
-func (a *SpecialAction) executeEnlightenedGainPower(player *Player) error {
-	player.Resources.GainPower(4)
+func (a *SpecialAction) executeEnlightenedGainPower(gs *GameState, player *Player) error {
+	gs.GainPowerForReason(player.ID, 4, PowerGainSpecialAction, "")
 	return nil
 }
 
 func (a *SpecialAction) executeConspiratorsSwapFavor(gs *GameState, player *Player) error {
-	allTiles := GetAllFavorTiles()
-	returnedTile, ok := allTiles[*a.ReturnFavorTile]
+	returnedTile, ok := gs.tileDefinitions().favorTiles[*a.ReturnFavorTile]
 	if !ok {
 		return fmt.Errorf("invalid returned favor tile: %v", *a.ReturnFavorTile)
 	}
@@ -165,6 +165,25 @@ func TestAurenCultAdvance_AlreadyAtMax(t *testing.T) {
 	}
 }
 
+func TestAurenCultAdvance_StopsBeforeTenWithoutKey(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("player1", factions.NewAuren())
+	player := gs.GetPlayer("player1")
+	buildStrongholdForPlayer(gs, "player1", board.NewHex(0, 1))
+	player.CultPositions[CultAir] = 8
+	player.Keys = 0
+
+	if err := NewAurenCultAdvanceAction("player1", CultAir).Execute(gs); err != nil {
+		t.Fatalf("expected Auren cult advance to succeed, got error: %v", err)
+	}
+	if player.CultPositions[CultAir] != 9 {
+		t.Errorf("expected Air cult position to stop at 9 without a key, got %d", player.CultPositions[CultAir])
+	}
+	if !player.SpecialActionsUsed[SpecialActionAurenCultAdvance] {
+		t.Error("expected Auren cult advance to be marked as used")
+	}
+}
+
 func TestAurenCultAdvance_OncePerRound(t *testing.T) {
 	gs := NewGameState()
 	faction := factions.NewAuren()
@@ -706,6 +725,52 @@ func TestNomadsSandstorm_RequiresDirectAdjacency(t *testing.T) {
 	}
 }
 
+func TestNomadsSandstorm_RejectsRiverHex(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("player1", factions.NewNomads())
+
+	strongholdHex := board.NewHex(0, 1)
+	buildStrongholdForPlayer(gs, "player1", strongholdHex)
+
+	targetHex := board.NewHex(1, 0)
+	gs.Map.GetHex(targetHex).Terrain = models.TerrainRiver
+
+	err := NewNomadsSandstormAction("player1", targetHex, false).Validate(gs)
+	if err == nil {
+		t.Fatal("expected sandstorm on a river hex to be rejected")
+	}
+	if ReasonCodeOf(err) != ReasonInvalidHex {
+		t.Errorf("expected reason %s, got %s", ReasonInvalidHex, ReasonCodeOf(err))
+	}
+}
+
+func TestNomadsSandstorm_NoSpadeScoring(t *testing.T) {
+	gs := NewGameState()
+	gs.AddPlayer("player1", factions.NewNomads())
+	gs.ScoringTiles = &ScoringTileState{Tiles: []ScoringTile{
+		{Type: ScoringSpades, ActionType: ScoringActionSpades, ActionVP: 2},
+	}}
+	gs.Round = 1
+
+	player := gs.GetPlayer("player1")
+	strongholdHex := board.NewHex(0, 1)
+	buildStrongholdForPlayer(gs, "player1", strongholdHex)
+
+	targetHex := board.NewHex(1, 0)
+	gs.Map.TransformTerrain(targetHex, models.TerrainSwamp)
+	initialVP := player.VictoryPoints
+
+	if err := NewNomadsSandstormAction("player1", targetHex, false).Execute(gs); err != nil {
+		t.Fatalf("expected Nomads sandstorm to succeed, got error: %v", err)
+	}
+	if gs.Map.GetHex(targetHex).Terrain != models.TerrainDesert {
+		t.Errorf("expected Desert terrain, got %v", gs.Map.GetHex(targetHex).Terrain)
+	}
+	if player.VictoryPoints != initialVP {
+		t.Errorf("expected no spade scoring VP for sandstorm, got %d VP (was %d)", player.VictoryPoints, initialVP)
+	}
+}
+
 func TestSelkiesStronghold_AllowsShippingPlusOne(t *testing.T) {
 	gs, err := NewGameStateWithMap(board.MapFjords)
 	if err != nil {
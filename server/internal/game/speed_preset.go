@@ -0,0 +1,249 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SpeedPreset is a pace chosen at game creation. It configures the turn
+// timer, the players' starting auto-leech policy and how often waiting players
+// are reminded.
+type SpeedPreset string
+
+const (
+	// SpeedPresetBlitz gives every turn 30 seconds and takes the turn for a
+	// player whose time runs out; see SweepSpeedPresets.
+	SpeedPresetBlitz SpeedPreset = "blitz"
+	// SpeedPresetStandard is a chess clock for live games.
+	SpeedPresetStandard SpeedPreset = "standard"
+	// SpeedPresetAsync has no clock and reminds waiting players once a day.
+	SpeedPresetAsync SpeedPreset = "async"
+)
+
+// SpeedSettings are what a SpeedPreset configures.
+type SpeedSettings struct {
+	// TurnTimer is used unless the game is created with its own.
+	TurnTimer *TurnTimerConfig
+	// AutoResolve takes the turn of a player whose clock ran out.
+	AutoResolve bool
+	// AutoLeechMode is every player's auto-leech option at the start.
+	AutoLeechMode LeechAutoMode
+	// ReminderInterval is how long a player may keep the game waiting before
+	// each reminder.
+	ReminderInterval time.Duration
+}
+
+func (p SpeedPreset) IsValid() bool {
+	switch p {
+	case SpeedPresetBlitz, SpeedPresetStandard, SpeedPresetAsync:
+		return true
+	default:
+		return false
+	}
+}
+
+// Settings returns the settings of p, or zero settings for an unknown preset.
+func (p SpeedPreset) Settings() SpeedSettings {
+	switch p {
+	case SpeedPresetBlitz:
+		return SpeedSettings{
+			TurnTimer:        &TurnTimerConfig{InitialTimeMs: 30 * 1000, PerTurn: true},
+			AutoResolve:      true,
+			AutoLeechMode:    LeechAutoModeAccept1,
+			ReminderInterval: 10 * time.Second,
+		}
+	case SpeedPresetStandard:
+		return SpeedSettings{
+			TurnTimer:        &TurnTimerConfig{InitialTimeMs: 25 * 60 * 1000, IncrementMs: 15 * 1000},
+			AutoLeechMode:    LeechAutoModeOff,
+			ReminderInterval: 2 * time.Minute,
+		}
+	case SpeedPresetAsync:
+		return SpeedSettings{
+			AutoLeechMode:    LeechAutoModeAccept1,
+			ReminderInterval: 24 * time.Hour,
+		}
+	default:
+		return SpeedSettings{}
+	}
+}
+
+// TurnReminder tells a player that a game is waiting on them.
+type TurnReminder struct {
+	GameID   string `json:"gameId"`
+	PlayerID string `json:"playerId"`
+	// WaitingMs is how long the game has been waiting on the player.
+	WaitingMs int64 `json:"waitingMs"`
+}
+
+type reminderClock struct {
+	since time.Time
+	last  time.Time
+}
+
+// SweepSpeedPresets takes the expired turns of games whose preset
+// auto-resolves and collects the reminders that are due. It returns the
+// results of the timeout actions of each game it changed, sorted by game, and
+// the reminders ordered by game and player. Games without a speed preset are
+// left alone.
+func (m *Manager) SweepSpeedPresets() ([]GameActionResults, []TurnReminder, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	for gameID := range m.reminders {
+		if gs := m.games[gameID]; gs == nil || gs.SpeedPreset == "" || gs.Phase == PhaseEnd {
+			delete(m.reminders, gameID)
+		}
+	}
+
+	gameIDs := make([]string, 0, len(m.games))
+	for gameID, gs := range m.games {
+		if gs != nil && gs.SpeedPreset != "" && gs.Phase != PhaseEnd {
+			gameIDs = append(gameIDs, gameID)
+		}
+	}
+	sort.Strings(gameIDs)
+
+	var (
+		changed   []GameActionResults
+		reminders []TurnReminder
+		errs      []error
+	)
+	for _, gameID := range gameIDs {
+		gs := m.games[gameID]
+		settings := gs.SpeedPreset.Settings()
+		if settings.AutoResolve {
+			results, err := m.resolveExpiredTurnsLocked(gameID, gs, now)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("game %s: %w", gameID, err))
+			}
+			if len(results) > 0 {
+				changed = append(changed, GameActionResults{GameID: gameID, Results: results})
+			}
+		}
+		reminders = append(reminders, m.dueRemindersLocked(gameID, gs, settings.ReminderInterval, now)...)
+	}
+	return changed, reminders, errors.Join(errs...)
+}
+
+// resolveExpiredTurnsLocked plays the timeout action of every player whose
+// clock ran out, until nobody's has, and returns their results. Each action
+// is recorded like a client action so exported games replay.
+func (m *Manager) resolveExpiredTurnsLocked(gameID string, gs *GameState, now time.Time) ([]*ActionResult, error) {
+	if gs.TurnTimer == nil {
+		return nil, nil
+	}
+	var results []*ActionResult
+	// Every timeout action ends a turn or answers an offer; the bound only
+	// guards against an action that leaves its player expired and waited on.
+	for range 4 * len(gs.Players) {
+		gs.TurnTimer.ChargeActivePlayers(now)
+		playerID := expiredActivePlayer(gs)
+		if playerID == "" {
+			return results, nil
+		}
+		action, record := timeoutAction(gs, playerID)
+		if action == nil {
+			return results, nil
+		}
+		result, err := m.executeDrainedLocked(gameID, action, ActionMeta{ExpectedRevision: -1, Record: record})
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+		gs = m.games[gameID]
+	}
+	return results, nil
+}
+
+// expiredActivePlayer returns the first player, by ID, whose running clock
+// has no time left.
+func expiredActivePlayer(gs *GameState) string {
+	playerIDs := make([]string, 0, len(gs.TurnTimer.Players))
+	for playerID, timer := range gs.TurnTimer.Players {
+		if timer != nil && timer.ActiveSinceMs > 0 && timer.RemainingMs <= 0 {
+			playerIDs = append(playerIDs, playerID)
+		}
+	}
+	sort.Strings(playerIDs)
+	if len(playerIDs) == 0 {
+		return ""
+	}
+	return playerIDs[0]
+}
+
+// timeoutAction is what is played for a player out of time: confirming a
+// pending turn, declining the next leech offer, or passing on a main turn. It
+// returns nil for other decisions, which keep waiting.
+func timeoutAction(gs *GameState, playerID string) (Action, *RecordedAction) {
+	if gs.HasPendingTurnConfirmation() && strings.TrimSpace(gs.PendingTurnConfirmationPlayerID) == playerID {
+		return NewConfirmTurnAction(playerID), &RecordedAction{PlayerID: playerID, Type: "confirm_turn"}
+	}
+	if len(gs.PendingLeechOffers[playerID]) > 0 && gs.GetNextLeechResponder() == playerID {
+		decline := NewDeclinePowerLeechAction(playerID, 0)
+		if decline.Validate(gs) != nil {
+			return nil, nil
+		}
+		params, _ := json.Marshal(map[string]int{"offerIndex": 0})
+		return decline, &RecordedAction{PlayerID: playerID, Type: "decline_leech", Params: params}
+	}
+	if mainTurnPlayerID(gs) == playerID {
+		pass, record := automaticPass(gs, playerID)
+		if pass == nil {
+			return nil, nil
+		}
+		return pass, record
+	}
+	return nil, nil
+}
+
+// dueRemindersLocked tracks how long gameID has waited on each of its active
+// players and returns a reminder for every player who has waited interval
+// since the start of the wait or the last reminder. Players on vacation are
+// not reminded.
+func (m *Manager) dueRemindersLocked(gameID string, gs *GameState, interval time.Duration, now time.Time) []TurnReminder {
+	if interval <= 0 {
+		return nil
+	}
+	clocks := m.reminders[gameID]
+	if clocks == nil {
+		clocks = make(map[string]*reminderClock)
+		m.reminders[gameID] = clocks
+	}
+
+	active := append([]string(nil), gs.Phases().ActivePlayers()...)
+	activeSet := make(map[string]bool, len(active))
+	for _, playerID := range active {
+		activeSet[playerID] = true
+	}
+	for playerID := range clocks {
+		if !activeSet[playerID] {
+			delete(clocks, playerID)
+		}
+	}
+
+	sort.Strings(active)
+	var reminders []TurnReminder
+	for _, playerID := range active {
+		clock := clocks[playerID]
+		if clock == nil {
+			clocks[playerID] = &reminderClock{since: now, last: now}
+			continue
+		}
+		if now.Sub(clock.last) < interval || m.onVacationLocked(playerID) {
+			continue
+		}
+		clock.last = now
+		reminders = append(reminders, TurnReminder{
+			GameID:    gameID,
+			PlayerID:  playerID,
+			WaitingMs: now.Sub(clock.since).Milliseconds(),
+		})
+	}
+	return reminders
+}
@@ -0,0 +1,138 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukev/tm_server/internal/game/factions"
+)
+
+func newSpeedPresetTestManager(t *testing.T, now *time.Time, preset SpeedPreset) (*Manager, *GameState) {
+	t.Helper()
+	mgr := NewManager()
+	mgr.now = func() time.Time { return *now }
+
+	gs := newActionTestGame(t, 1, testSeat{"p1", factions.NewCultists()}, testSeat{"p2", factions.NewWitches()})
+	gs.BonusCards.Available = map[BonusCardType]int{BonusCardPriest: 0, BonusCardShipping: 1}
+	gs.SpeedPreset = preset
+	if timer := preset.Settings().TurnTimer; timer != nil {
+		gs.TurnTimer = NewTurnTimerState(gs.TurnOrder, *timer)
+	}
+	mgr.CreateGameWithState("g1", gs)
+	return mgr, gs
+}
+
+func TestSpeedPreset_ConfiguresNewGames(t *testing.T) {
+	mgr := NewManager()
+	if err := mgr.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{SpeedPreset: SpeedPresetBlitz}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	gs, _ := mgr.GetGame("g1")
+	if gs.SpeedPreset != SpeedPresetBlitz || gs.TurnTimer == nil {
+		t.Fatalf("expected blitz game with a turn timer, got preset %q timer %+v", gs.SpeedPreset, gs.TurnTimer)
+	}
+	if config := gs.TurnTimer.Config; config.InitialTimeMs != 30_000 || !config.PerTurn {
+		t.Fatalf("expected 30s per turn, got %+v", config)
+	}
+	for playerID, player := range gs.Players {
+		if player.Options.AutoLeechMode != LeechAutoModeAccept1 {
+			t.Fatalf("%s auto leech = %s, want %s", playerID, player.Options.AutoLeechMode, LeechAutoModeAccept1)
+		}
+	}
+	save, err := mgr.ExportGame("g1")
+	if err != nil || save.Settings.SpeedPreset != SpeedPresetBlitz {
+		t.Fatalf("expected exported speed preset, got %+v (%v)", save, err)
+	}
+
+	// An explicit timer wins over the preset's.
+	custom := &TurnTimerConfig{InitialTimeMs: 90_000}
+	if err := mgr.CreateGameWithOptions("g2", []string{"p1", "p2"}, CreateGameOptions{SpeedPreset: SpeedPresetStandard, TurnTimer: custom}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	if gs, _ := mgr.GetGame("g2"); gs.TurnTimer.Config.InitialTimeMs != 90_000 {
+		t.Fatalf("expected the explicit timer, got %+v", gs.TurnTimer.Config)
+	}
+
+	if err := mgr.CreateGameWithOptions("g3", []string{"p1", "p2"}, CreateGameOptions{SpeedPreset: "bullet"}); err == nil {
+		t.Fatalf("expected unknown speed preset to be rejected")
+	}
+}
+
+func TestSweepSpeedPresets_PassesForBlitzPlayerOutOfTime(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	mgr, gs := newSpeedPresetTestManager(t, &now, SpeedPresetBlitz)
+	mgr.mu.Lock()
+	mgr.syncTurnTimerLocked(gs, now)
+	mgr.mu.Unlock()
+
+	now = now.Add(29 * time.Second)
+	if changed, _, err := mgr.SweepSpeedPresets(); err != nil || len(changed) != 0 {
+		t.Fatalf("expected no timeout yet, got %v (%v)", changed, err)
+	}
+
+	now = now.Add(2 * time.Second)
+	changed, _, err := mgr.SweepSpeedPresets()
+	if err != nil {
+		t.Fatalf("sweep: %v", err)
+	}
+	if len(changed) != 1 || changed[0].GameID != "g1" || len(changed[0].Results) == 0 {
+		t.Fatalf("expected timeouts in g1, got %+v", changed)
+	}
+
+	if !gs.GetPlayer("p1").HasPassed {
+		t.Fatalf("expected p1 to be passed for")
+	}
+	if card, ok := gs.BonusCards.GetPlayerCard("p1"); !ok || card != BonusCardShipping {
+		t.Fatalf("expected p1 to take the card with coins, got %v", card)
+	}
+	if timer := gs.TurnTimer.Players["p2"]; timer.ActiveSinceMs == 0 || timer.RemainingMs != 30_000 {
+		t.Fatalf("expected p2 to start a fresh turn, got %+v", timer)
+	}
+}
+
+func TestSweepSpeedPresets_RemindsWaitingPlayers(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	mgr, _ := newSpeedPresetTestManager(t, &now, SpeedPresetAsync)
+
+	if _, reminders, _ := mgr.SweepSpeedPresets(); len(reminders) != 0 {
+		t.Fatalf("expected no reminder when the wait starts, got %+v", reminders)
+	}
+	now = now.Add(23 * time.Hour)
+	if _, reminders, _ := mgr.SweepSpeedPresets(); len(reminders) != 0 {
+		t.Fatalf("expected no reminder before a day, got %+v", reminders)
+	}
+	now = now.Add(time.Hour)
+	_, reminders, _ := mgr.SweepSpeedPresets()
+	want := TurnReminder{GameID: "g1", PlayerID: "p1", WaitingMs: (24 * time.Hour).Milliseconds()}
+	if len(reminders) != 1 || reminders[0] != want {
+		t.Fatalf("expected %+v, got %+v", want, reminders)
+	}
+	now = now.Add(time.Hour)
+	if _, reminders, _ := mgr.SweepSpeedPresets(); len(reminders) != 0 {
+		t.Fatalf("expected the next reminder a day later, got %+v", reminders)
+	}
+}
+
+func TestSweepSpeedPresets_ReportsEventsOfTimeouts(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	mgr, gs := newSpeedPresetTestManager(t, &now, SpeedPresetBlitz)
+	gs.GetPlayer("p2").HasPassed = true
+	mgr.mu.Lock()
+	mgr.syncTurnTimerLocked(gs, now)
+	mgr.mu.Unlock()
+
+	now = now.Add(31 * time.Second)
+	changed, _, err := mgr.SweepSpeedPresets()
+	if err != nil {
+		t.Fatalf("sweep: %v", err)
+	}
+	var changes []PhaseChange
+	for _, group := range changed {
+		for _, result := range group.Results {
+			changes = append(changes, result.PhaseChanges...)
+		}
+	}
+	if len(changes) == 0 {
+		t.Fatalf("expected the round-ending timeout to report its phase changes, got %+v", changed)
+	}
+}
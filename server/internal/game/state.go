@@ -2,6 +2,7 @@ package game
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -26,6 +27,12 @@ type GameState struct {
 	EnableFireIceFactions            bool                                  `json:"enableFireIceFactions"`
 	FireIceFinalScoringSetting       FireIceFinalScoringSetting            `json:"fireIceFinalScoringSetting"`
 	FireIceFinalScoringTile          FireIceFinalScoringTile               `json:"fireIceFinalScoringTile,omitempty"`
+	HiddenResources                  bool                                  `json:"hiddenResources,omitempty"`
+	Hotseat                          bool                                  `json:"hotseat,omitempty"`
+	PublicSummary                    bool                                  `json:"publicSummary,omitempty"`
+	PublicSummaryConsent             []string                              `json:"publicSummaryConsent,omitempty"`
+	Handicaps                        map[string]Handicap                   `json:"handicaps,omitempty"`
+	Seed                             int64                                 `json:"seed"`
 	SetupSubphase                    SetupSubphase                         `json:"setupSubphase"`
 	AuctionState                     *AuctionState                         `json:"auctionState,omitempty"`
 	SetupDwellingOrder               []string                              `json:"setupDwellingOrder"`
@@ -48,6 +55,8 @@ type GameState struct {
 	PendingSpades                    map[string]int                        `json:"pendingSpades"`
 	PendingSpadeBuildAllowed         map[string]bool                       `json:"pendingSpadeBuildAllowed"`
 	PendingCultRewardSpades          map[string]int                        `json:"pendingCultRewardSpades"`
+	CultRewardSpadeOrder             []string                              `json:"cultRewardSpadeOrder,omitempty"`
+	CultRewardSpadeIndex             int                                   `json:"cultRewardSpadeIndex,omitempty"`
 	PendingCultistsLeech             map[int]*CultistsLeechBonus           `json:"pendingCultistsLeech"`
 	PendingShapeshiftersLeech        map[int]*CultistsLeechBonus           `json:"pendingShapeshiftersLeech"`
 	NextLeechEventID                 int                                   `json:"-"`
@@ -66,21 +75,34 @@ type GameState struct {
 	PendingTownCultTopChoice         *PendingTownCultTopChoice             `json:"pendingTownCultTopChoice"`
 	PendingFreeActionsPlayerID       string                                `json:"pendingFreeActionsPlayerId"`
 	PendingTurnConfirmationPlayerID  string                                `json:"pendingTurnConfirmationPlayerId"`
+	MustPassPlayerID                 string                                `json:"mustPassPlayerId,omitempty"`
 	PendingTurnConfirmationSnapshot  *GameState                            `json:"-"`
 	PendingWispsTradingPostSpade     map[string]board.Hex                  `json:"-"`
 	PendingPostActionSpecialActions  map[string]map[SpecialActionType]bool `json:"-"`
 	TurnTimer                        *TurnTimerState                       `json:"turnTimer,omitempty"`
+	SpeedPreset                      SpeedPreset                           `json:"speedPreset,omitempty"`
 	ReplayMode                       map[string]bool                       `json:"replayMode"`
 	allowAZAutoConversions           bool
-	ReplayAcolytesCultTracks         map[string][]CultTrack            `json:"-"`
-	ReplayAcolytesCultTrackIndex     map[string]int                    `json:"-"`
-	ReplayRiverBuildHexes            map[string][]board.Hex            `json:"-"`
-	ReplayRiverBuildHexIndex         map[string]int                    `json:"-"`
-	ReplayCultSpadeBuildHexes        map[string]map[board.Hex]bool     `json:"-"`
-	PendingSnowShamansPassUpgrade    map[string]SnowShamansPassUpgrade `json:"-"`
-	FinalScoring                     map[string]*PlayerFinalScore      `json:"finalScoring"`
-	SuppressTurnAdvance              bool                              `json:"-"`
-	RiverTownHex                     *board.Hex                        `json:"-"` // For Mermaids river town formation
+	previewTrace                     *actionPreviewTrace
+	phaseChanges                     []PhaseChange
+	powerGains                       []PowerGainEvent
+	AutoLeechDecisions               []AutoLeechDecision `json:"autoLeechDecisions,omitempty"`
+	newAutoLeechDecisions            []AutoLeechDecision
+	// accountLeechPreferences and usedLeechPreferences are lent and
+	// collected by the Manager around each action; see lendLeechPreferences.
+	accountLeechPreferences       map[string]LeechPreferences
+	usedLeechPreferences          map[string]LeechPreferences
+	incomeReports                 []IncomeReport
+	tiles                         *tileCatalog
+	ReplayAcolytesCultTracks      map[string][]CultTrack            `json:"-"`
+	ReplayAcolytesCultTrackIndex  map[string]int                    `json:"-"`
+	ReplayRiverBuildHexes         map[string][]board.Hex            `json:"-"`
+	ReplayRiverBuildHexIndex      map[string]int                    `json:"-"`
+	ReplayCultSpadeBuildHexes     map[string]map[board.Hex]bool     `json:"-"`
+	PendingSnowShamansPassUpgrade map[string]SnowShamansPassUpgrade `json:"-"`
+	FinalScoring                  map[string]*PlayerFinalScore      `json:"finalScoring"`
+	SuppressTurnAdvance           bool                              `json:"-"`
+	RiverTownHex                  *board.Hex                        `json:"-"` // For Mermaids river town formation
 }
 
 // PendingTownFormation represents a town that can be formed but awaits tile selection
@@ -91,9 +113,11 @@ type PendingTownFormation struct {
 	CanBeDelayed    bool        // For Mermaids: true if town uses river skipping (can be claimed later), false if only land tiles (must claim now)
 }
 
-// CultistsLeechBonus tracks Cultists' pending cult advance or power bonus from power leech
+// CultistsLeechBonus tracks Cultists' pending cult advance or power bonus from power leech.
+// Each leech event (trigger) has its own bonus, resolved once all of its offers are.
 type CultistsLeechBonus struct {
 	PlayerID      string
+	EventID       int // Leech event that triggered the bonus
 	OffersCreated int // Number of offers created
 	ResolvedCount int // Number of offers resolved (accepted or declined), including 0-power offers
 	AcceptedCount int // Number of offers accepted
@@ -144,8 +168,10 @@ type PendingDarklingsPriestOrdination struct {
 
 // PendingCultistsCultSelection represents Cultists player who needs to select a cult track
 // Triggered by: Power leech bonus (when at least one opponent accepts power)
+// A turn with several leech events can earn several advances; they queue here.
 type PendingCultistsCultSelection struct {
 	PlayerID string
+	EventIDs []int // Leech events still owed an advance, in trigger order
 }
 
 // PendingDjinniStartingCultChoice represents Djinni choosing which cult
@@ -276,6 +302,7 @@ type Player struct {
 	HasStrongholdAbility  bool                        `json:"hasStrongholdAbility"` // Whether the stronghold special ability is available
 	SpecialActionsUsed    map[SpecialActionType]bool  `json:"specialActionsUsed"`   // Track which special actions have been used this round
 	HasPassed             bool                        `json:"hasPassed"`
+	Resigned              bool                        `json:"resigned"` // Left the game: passes every round and collects nothing
 	VictoryPoints         int                         `json:"victoryPoints"`
 	Keys                  int                         `json:"keys"`        // Keys for advancing to position 10 on cult tracks
 	TownsFormed           int                         `json:"townsFormed"` // Number of towns formed
@@ -289,6 +316,7 @@ type Player struct {
 	HasStartingTerrain    bool                        `json:"hasStartingTerrain"`
 	UnlockedTerrains      map[models.TerrainType]bool `json:"unlockedTerrains,omitempty"`
 	FirewalkersBlockerVP  int                         `json:"firewalkersBlockerVp"`
+	PowerGained           map[string]int              `json:"powerGained,omitempty"` // Power gained so far, by PowerGainEvent reason
 	AtlanteansTownHexes   []board.Hex                 `json:"-"`
 	AtlanteansTownRewards map[int]bool                `json:"-"`
 }
@@ -491,7 +519,7 @@ func (gs *GameState) ensureArchivistsBonusCardAvailable() {
 	if len(gs.BonusCards.Available) >= len(gs.Players)+4 {
 		return
 	}
-	allCards := GetAllBonusCards()
+	allCards := gs.tileDefinitions().bonusCards
 	candidates := make([]BonusCardType, 0, len(allCards))
 	for cardType := range allCards {
 		if gs.BonusCards.IsAvailable(cardType) {
@@ -506,7 +534,7 @@ func (gs *GameState) ensureArchivistsBonusCardAvailable() {
 		return
 	}
 	sort.Slice(candidates, func(i, j int) bool { return candidates[i] < candidates[j] })
-	gs.BonusCards.Available[candidates[0]] = 0
+	gs.BonusCards.MakeAvailable(candidates[0], 0)
 }
 
 // LeechAutoMode controls automatic accept/decline behavior for power leech offers.
@@ -541,18 +569,30 @@ type PlayerOptions struct {
 	AutoConvertOnPass bool          `json:"autoConvertOnPass"`
 	ConfirmActions    bool          `json:"confirmActions"`
 	ShowIncomePreview bool          `json:"showIncomePreview"`
+	AutoPassWhenStuck bool          `json:"autoPassWhenStuck"`
+	// ConfirmPass and ConfirmBurn make the server refuse passes and power
+	// burns the player's client did not mark as confirmed.
+	ConfirmPass bool `json:"confirmPass"`
+	ConfirmBurn bool `json:"confirmBurn"`
+	// LeechPreferences are applied before AutoLeechMode.
+	LeechPreferences LeechPreferences `json:"leechPreferences"`
 }
 
 // TurnTimerConfig configures optional chess-clock style timing for a game.
 type TurnTimerConfig struct {
 	InitialTimeMs int64 `json:"initialTimeMs"`
 	IncrementMs   int64 `json:"incrementMs"`
+	// PerTurn resets a player's clock to InitialTimeMs whenever the game
+	// starts waiting on them, instead of carrying time over between turns.
+	PerTurn bool `json:"perTurn,omitempty"`
 }
 
 // PlayerTurnTimer tracks one player's remaining time and whether their clock is running.
 type PlayerTurnTimer struct {
 	RemainingMs   int64 `json:"remainingMs"`
 	ActiveSinceMs int64 `json:"-"`
+	// Paused stops the clock while the player is on vacation.
+	Paused bool `json:"paused,omitempty"`
 }
 
 // TurnTimerState stores authoritative timer state for all players in a game.
@@ -600,7 +640,9 @@ func NewGameStateWithCustomMap(definition *board.CustomMapDefinition) (*GameStat
 }
 
 func newGameStateWithBoard(gameMap *board.TerraMysticaMap) *GameState {
+	tiles := currentTiles()
 	return &GameState{
+		tiles:                         tiles,
 		Map:                           gameMap,
 		Players:                       make(map[string]*Player),
 		Round:                         1,
@@ -612,9 +654,9 @@ func newGameStateWithBoard(gameMap *board.TerraMysticaMap) *GameState {
 		TurnOrderPolicy:               TurnOrderPolicyPassOrder,
 		PowerActions:                  NewPowerActionState(),
 		CultTracks:                    NewCultTrackState(),
-		FavorTiles:                    NewFavorTileState(),
+		FavorTiles:                    newFavorTileStateFrom(tiles),
 		BonusCards:                    NewBonusCardState(),
-		TownTiles:                     NewTownTileState(),
+		TownTiles:                     newTownTileStateFrom(tiles),
 		ScoringTiles:                  NewScoringTileState(),
 		PendingLeechOffers:            make(map[string][]*PowerLeechOffer),
 		PendingTownFormations:         make(map[string][]*PendingTownFormation),
@@ -728,7 +770,7 @@ func (gs *GameState) GetPlayer(playerID string) *Player {
 func (gs *GameState) AdvanceCultTrack(playerID string, track CultTrack, spaces int) (int, error) {
 	player := gs.GetPlayer(playerID)
 	if player == nil {
-		return 0, fmt.Errorf("player not found: %s", playerID)
+		return 0, ruleErrorf(ReasonPlayerNotFound, "player not found: %s", playerID)
 	}
 
 	// Sync CultTrackState with player's local position (for tests that set it manually)
@@ -759,7 +801,7 @@ func (gs *GameState) AdvanceCultTrack(playerID string, track CultTrack, spaces i
 func (gs *GameState) DecreaseCultTrack(playerID string, track CultTrack, spaces int) (int, error) {
 	player := gs.GetPlayer(playerID)
 	if player == nil {
-		return 0, fmt.Errorf("player not found: %s", playerID)
+		return 0, ruleErrorf(ReasonPlayerNotFound, "player not found: %s", playerID)
 	}
 	if gs.CultTracks == nil {
 		return 0, fmt.Errorf("cult tracks not initialized")
@@ -836,16 +878,30 @@ func (gs *GameState) getPlayerBuildingHexes(playerID string) []board.Hex {
 	return hexes
 }
 
-func (gs *GameState) effectiveShippingLevel(playerID string) int {
+// EffectiveShippingLevel is the shipping range playerID reaches with right
+// now: their shipping level plus the temporary bonus of the bonus cards they
+// hold. Final area scoring and the shipping pass VP use ShippingLevel alone.
+func (gs *GameState) EffectiveShippingLevel(playerID string) int {
 	player := gs.GetPlayer(playerID)
 	if player == nil || player.Faction == nil {
 		return 0
 	}
-	effectiveShipping := player.ShippingLevel
+	return player.ShippingLevel + gs.TemporaryShippingBonus(playerID)
+}
+
+// TemporaryShippingBonus is the shipping playerID gains from the bonus cards
+// they hold. It lasts while the card is held and ends when the card is
+// returned on pass.
+func (gs *GameState) TemporaryShippingBonus(playerID string) int {
+	player := gs.GetPlayer(playerID)
+	if player == nil || player.Faction == nil || gs.BonusCards == nil {
+		return 0
+	}
+	bonus := 0
 	for _, bonusCard := range gs.BonusCards.GetPlayerCards(playerID) {
-		effectiveShipping += GetBonusCardShippingBonus(bonusCard, player.Faction.GetType())
+		bonus += gs.tileDefinitions().bonusCardShippingBonus(bonusCard, player.Faction.GetType())
 	}
-	return effectiveShipping
+	return bonus
 }
 
 func (gs *GameState) childrenTokenComponentsForPlayer(playerID string) (map[board.Hex]int, map[int]bool) {
@@ -908,9 +964,6 @@ func (gs *GameState) areHexesDirectlyAdjacentForPlayer(playerID string, h1, h2 b
 	if gs.Map.IsDirectlyAdjacent(h1, h2) {
 		return true
 	}
-	if gs.Map.HasBridge(h1, h2) {
-		return true
-	}
 
 	player := gs.GetPlayer(playerID)
 	if player == nil || player.Faction == nil || player.Faction.GetType() != models.FactionChildrenOfTheWyrm {
@@ -1080,13 +1133,13 @@ func (gs *GameState) childrenNeedsBowl3ForBoardTokens(playerID string, count int
 func (gs *GameState) removeChildrenPowerTokensForBoard(playerID string, count int) error {
 	player := gs.GetPlayer(playerID)
 	if player == nil || player.Resources == nil || player.Resources.Power == nil {
-		return fmt.Errorf("player not found: %s", playerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", playerID)
 	}
 	if count < 0 {
 		return fmt.Errorf("cannot remove negative power tokens")
 	}
 	if player.Resources.Power.TotalPower() < count {
-		return fmt.Errorf("not enough power tokens available")
+		return ruleErrorf(ReasonInsufficientPower, "not enough power tokens available")
 	}
 
 	remaining := count
@@ -1105,7 +1158,7 @@ func (gs *GameState) removeChildrenPowerTokensForBoard(playerID string, count in
 	player.Resources.Power.Bowl2 = 0
 
 	if player.Resources.Power.Bowl3 < remaining {
-		return fmt.Errorf("not enough power tokens available")
+		return ruleErrorf(ReasonInsufficientPower, "not enough power tokens available")
 	}
 	player.Resources.Power.Bowl3 -= remaining
 	return nil
@@ -1116,6 +1169,20 @@ func (gs *GameState) removeChildrenPowerTokensForBoard(playerID string, count in
 // 1. Direct adjacency (shared edge or connected via bridge)
 // 2. Indirect adjacency (connected via river navigation with shipping)
 func (gs *GameState) IsAdjacentToPlayerBuilding(targetHex board.Hex, playerID string) bool {
+	return gs.isReachableWithShipping(targetHex, playerID, gs.EffectiveShippingLevel(playerID))
+}
+
+// isAdjacentWithoutTemporaryShipping is IsAdjacentToPlayerBuilding without the
+// bonus card shipping, for effects that ignore it such as cult reward spades.
+func (gs *GameState) isAdjacentWithoutTemporaryShipping(targetHex board.Hex, playerID string) bool {
+	player := gs.GetPlayer(playerID)
+	if player == nil {
+		return false
+	}
+	return gs.isReachableWithShipping(targetHex, playerID, player.ShippingLevel)
+}
+
+func (gs *GameState) isReachableWithShipping(targetHex board.Hex, playerID string, shipping int) bool {
 	player := gs.GetPlayer(playerID)
 	if player == nil {
 		return false
@@ -1132,13 +1199,12 @@ func (gs *GameState) IsAdjacentToPlayerBuilding(targetHex board.Hex, playerID st
 		}
 	}
 
-	effectiveShipping := gs.effectiveShippingLevel(playerID)
-	if effectiveShipping <= 0 {
+	if shipping <= 0 {
 		return false
 	}
 
 	for _, buildingHex := range gs.getPlayerBuildingHexes(playerID) {
-		if gs.Map.IsIndirectlyAdjacent(targetHex, buildingHex, effectiveShipping) {
+		if gs.Map.IsIndirectlyAdjacent(targetHex, buildingHex, shipping) {
 			return true
 		}
 	}
@@ -1158,11 +1224,8 @@ func (gs *GameState) leechSourceBuildingsForPlayer(targetHex board.Hex, playerID
 		sourceHexes = append(sourceHexes, hex)
 	}
 
-	for _, neighbor := range gs.Map.GetDirectNeighbors(targetHex) {
-		mapHex := gs.Map.GetHex(neighbor)
-		if mapHex != nil && mapHex.Building != nil && mapHex.Building.PlayerID == playerID {
-			addSource(neighbor)
-		}
+	for _, neighbor := range gs.Map.AdjacentPlayerBuildings(targetHex, playerID) {
+		addSource(neighbor)
 	}
 
 	player := gs.GetPlayer(playerID)
@@ -1200,12 +1263,8 @@ func (gs *GameState) TriggerPowerLeech(buildingHex board.Hex, buildingPlayerID s
 		if playerID == buildingPlayerID {
 			continue
 		}
-		for _, sourceHex := range gs.leechSourceBuildingsForPlayer(buildingHex, playerID) {
-			mapHex := gs.Map.GetHex(sourceHex)
-			if mapHex == nil || mapHex.Building == nil || mapHex.Building.PlayerID != playerID {
-				continue
-			}
-			adjacentPlayerPower[playerID] += mapHex.Building.PowerValue
+		if power := gs.Map.TotalBuildingPower(gs.leechSourceBuildingsForPlayer(buildingHex, playerID)); power > 0 {
+			adjacentPlayerPower[playerID] = power
 		}
 	}
 
@@ -1229,6 +1288,7 @@ func (gs *GameState) TriggerPowerLeech(buildingHex board.Hex, buildingPlayerID s
 			}
 			sourceHex := buildingHex
 			offer.SourceHex = &sourceHex
+			offer.SourceLabel, _ = gs.Map.DisplayCoordinateForHex(sourceHex)
 			offer.EventID = eventID
 			// Store offer for player to accept/decline
 			if gs.PendingLeechOffers[neighborPlayerID] == nil {
@@ -1248,15 +1308,22 @@ func (gs *GameState) TriggerPowerLeech(buildingHex board.Hex, buildingPlayerID s
 				if gs.PendingCultistsLeech == nil {
 					gs.PendingCultistsLeech = make(map[int]*CultistsLeechBonus)
 				}
-				gs.PendingCultistsLeech[eventID] = &CultistsLeechBonus{PlayerID: buildingPlayerID, OffersCreated: offersCreated}
+				gs.PendingCultistsLeech[eventID] = &CultistsLeechBonus{PlayerID: buildingPlayerID, EventID: eventID, OffersCreated: offersCreated}
 			case models.FactionShapeshifters:
 				if gs.PendingShapeshiftersLeech == nil {
 					gs.PendingShapeshiftersLeech = make(map[int]*CultistsLeechBonus)
 				}
-				gs.PendingShapeshiftersLeech[eventID] = &CultistsLeechBonus{PlayerID: buildingPlayerID, OffersCreated: offersCreated}
+				gs.PendingShapeshiftersLeech[eventID] = &CultistsLeechBonus{PlayerID: buildingPlayerID, EventID: eventID, OffersCreated: offersCreated}
 			}
 		}
 	}
+
+	// Resigned players never answer; their offers count as declined.
+	for neighborPlayerID := range adjacentPlayerPower {
+		if neighbor := gs.GetPlayer(neighborPlayerID); neighbor != nil && neighbor.Resigned {
+			gs.declineResignedLeechOffers(neighborPlayerID)
+		}
+	}
 }
 
 // GetPendingLeechOffers returns all pending leech offers for a player
@@ -1308,11 +1375,11 @@ func (gs *GameState) AcceptLeechOffer(playerID string, offerIndex int) error {
 	offer := offers[offerIndex]
 	player := gs.GetPlayer(playerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", playerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", playerID)
 	}
 
 	// Gain power and lose VP based on the amount actually gained.
-	vpCost := player.Resources.AcceptPowerLeech(offer)
+	vpCost := gs.AcceptPowerLeech(playerID, offer)
 	if player.Faction != nil && player.Faction.GetType() == models.FactionChildrenOfTheWyrm && vpCost > 0 {
 		vpCost--
 	}
@@ -1346,7 +1413,7 @@ func (gs *GameState) ClearPendingLeechOffers(playerID string) {
 func (gs *GameState) ValidatePlayer(playerID string) (*Player, error) {
 	player := gs.GetPlayer(playerID)
 	if player == nil {
-		return nil, fmt.Errorf("player not found: %s", playerID)
+		return nil, ruleErrorf(ReasonPlayerNotFound, "player not found: %s", playerID)
 	}
 	return player, nil
 }
@@ -1355,7 +1422,7 @@ func (gs *GameState) ValidatePlayer(playerID string) (*Player, error) {
 func (gs *GameState) ValidateHex(hex board.Hex) (*board.MapHex, error) {
 	mapHex := gs.Map.GetHex(hex)
 	if mapHex == nil {
-		return nil, fmt.Errorf("hex does not exist: %v", hex)
+		return nil, ruleErrorf(ReasonInvalidHex, "hex does not exist: %v", hex)
 	}
 	return mapHex, nil
 }
@@ -1372,27 +1439,35 @@ func (gs *GameState) CheckBuildingLimit(playerID string, buildingType models.Bui
 	}
 
 	// Check limits
-	var limit int
-	switch buildingType {
-	case models.BuildingDwelling:
-		limit = 8
-	case models.BuildingTradingHouse:
-		limit = 4
-	case models.BuildingTemple:
-		limit = 3
-	case models.BuildingSanctuary, models.BuildingStronghold:
-		limit = 1
-	default:
+	limit := buildingLimit(buildingType)
+	if limit == 0 {
 		return nil
 	}
 
 	if count >= limit {
-		return fmt.Errorf("building limit reached: cannot have more than %d %v", limit, buildingType)
+		return ruleErrorf(ReasonBuildingLimit, "building limit reached: cannot have more than %d %v", limit, buildingType)
 	}
 
 	return nil
 }
 
+// buildingLimit returns how many buildings of a type a player may have, or 0
+// for types without a limit.
+func buildingLimit(buildingType models.BuildingType) int {
+	switch buildingType {
+	case models.BuildingDwelling:
+		return 8
+	case models.BuildingTradingHouse:
+		return 4
+	case models.BuildingTemple:
+		return 3
+	case models.BuildingSanctuary, models.BuildingStronghold:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // BuildDwelling handles all dwelling placement logic including:
 // - Placing the dwelling building on the map
 // - Awarding VP from Earth+1 favor tile (+2 VP when building Dwelling)
@@ -1419,11 +1494,8 @@ func (gs *GameState) BuildDwelling(playerID string, targetHex board.Hex) error {
 	}
 	mapHex.Building = dwelling
 
-	// Award VP from Earth+1 favor tile (+2 VP when building Dwelling)
-	playerTiles := gs.FavorTiles.GetPlayerTiles(playerID)
-	if HasFavorTile(playerTiles, FavorEarth1) {
-		player.VictoryPoints += 2
-	}
+	// Award VP from favor tiles (Earth+1: +2 VP when building Dwelling)
+	player.VictoryPoints += gs.FavorModifiers(playerID).DwellingVP
 	if player.Faction.GetType() == models.FactionSelkies && mapHex.Terrain == models.TerrainRiver {
 		player.VictoryPoints += 2
 	}
@@ -1473,7 +1545,7 @@ func (gs *GameState) currentSetupBonusPlayerID() string {
 }
 
 func (gs *GameState) InitializeSetupSequence() {
-	gs.Phase = PhaseSetup
+	gs.Phases().Enter(PhaseSetup)
 	gs.SetupSubphase = SetupSubphaseDwellings
 	gs.SetupDwellingOrder = []string{}
 	gs.SetupDwellingIndex = 0
@@ -1599,18 +1671,13 @@ func (gs *GameState) hasAvailableWispsStrongholdLake() bool {
 
 func (gs *GameState) CompleteSetupAndStartRoundOne() {
 	if gs.BonusCards != nil {
-		gs.BonusCards.AddCoinsToLeftoverCards()
+		gs.BonusCards.AddCoins(1)
 	}
 
+	RoundReset(gs)
 	gs.PassOrder = []string{}
-	gs.PowerActions.ResetForNewRound()
-	gs.BonusCards.PlayerHasCard = make(map[string]bool)
-	for _, player := range gs.Players {
-		player.HasPassed = false
-		player.SpecialActionsUsed = make(map[SpecialActionType]bool)
-	}
 
-	gs.Phase = PhaseIncome
+	gs.Phases().Enter(PhaseIncome)
 	gs.GrantIncome()
 	if !gs.HasPendingIncomeDecisions() {
 		gs.StartActionPhase()
@@ -1680,81 +1747,89 @@ func (gs *GameState) checkRoundCompletion() bool {
 	return true
 }
 
+// NextRoundTurnOrder derives the next round's turn order from the pass order
+// under TurnOrderPolicy; see NextRoundOrder. Resigned players go last.
+func (gs *GameState) NextRoundTurnOrder() []string {
+	return gs.withResignedPlayersLast(NextRoundOrder(gs.TurnOrderPolicy, gs.TurnOrder, gs.PassOrder))
+}
+
+// NextRoundOrder derives the next round's turn order from a round's turn
+// order and the order its players passed in. It is shared by the engine and
+// the log converters so both order rounds the same way.
+//
+// With the pass-order policy (the Fire & Ice variable turn order) the passers
+// come first, in the order they passed; mid-round the players still to pass
+// follow in turn order, previewing the order if they all passed now. With the
+// cyclic policy (the base game) the first passer starts and the others keep
+// their seats. With no passes yet the turn order is kept.
+func NextRoundOrder(policy TurnOrderPolicy, turnOrder, passOrder []string) []string {
+	if len(passOrder) == 0 {
+		return append([]string{}, turnOrder...)
+	}
+	if policy == TurnOrderPolicyCyclicFromFirstPasser {
+		for i, playerID := range turnOrder {
+			if playerID == passOrder[0] {
+				rotated := append([]string{}, turnOrder[i:]...)
+				return append(rotated, turnOrder[:i]...)
+			}
+		}
+	}
+	order := append([]string{}, passOrder...)
+	for _, playerID := range turnOrder {
+		if !slices.Contains(passOrder, playerID) {
+			order = append(order, playerID)
+		}
+	}
+	return order
+}
+
 // StartNewRound prepares the game for a new round
 // This transitions from PhaseCleanup (or PhaseSetup for round 1) to PhaseIncome
 func (gs *GameState) StartNewRound() {
 	// If transitioning from setup to Round 1, add coins to leftover bonus cards
 	// During setup, players pass and take bonus cards. The cards they don't take
 	// should accumulate 1 coin before Round 1 begins.
-	if gs.Phase == PhaseSetup && gs.BonusCards != nil {
-		gs.BonusCards.AddCoinsToLeftoverCards()
+	if gs.Phases().Current() == PhaseSetup && gs.BonusCards != nil {
+		gs.BonusCards.AddCoins(gs.Round + 1)
+	}
+
+	// The cleanup phase has already reset the round it closed; a round entered
+	// from anywhere else (setup, or a replay that skipped cleanup) has not.
+	if gs.Phases().Current() != PhaseCleanup {
+		RoundReset(gs)
 	}
 
 	gs.Round++
 	gs.CurrentPlayerIndex = 0
-	// Post-action confirmation/free-action windows never carry across rounds.
-	gs.PendingFreeActionsPlayerID = ""
-	gs.ClearPendingTurnConfirmation()
 
 	// Set turn order based on pass order (first to pass goes first next round)
-	if len(gs.PassOrder) > 0 {
-		switch gs.TurnOrderPolicy {
-		case TurnOrderPolicyCyclicFromFirstPasser:
-			firstPasser := gs.PassOrder[0]
-			anchor := -1
-			for i, playerID := range gs.TurnOrder {
-				if playerID == firstPasser {
-					anchor = i
-					break
-				}
-			}
-			if anchor >= 0 {
-				rotated := append([]string{}, gs.TurnOrder[anchor:]...)
-				rotated = append(rotated, gs.TurnOrder[:anchor]...)
-				gs.TurnOrder = rotated
-			} else {
-				gs.TurnOrder = append([]string{}, gs.PassOrder...)
-			}
-		default:
-			gs.TurnOrder = append([]string{}, gs.PassOrder...)
-		}
-	}
+	gs.TurnOrder = gs.NextRoundTurnOrder()
 
 	// Reset pass order for the new round
 	gs.PassOrder = []string{}
 
-	// Reset power actions for the new round
-	gs.PowerActions.ResetForNewRound()
-
-	// Reset bonus card selections for the new round
-	// Players selected cards in the previous round (or setup), now they can select again
-	gs.BonusCards.PlayerHasCard = make(map[string]bool)
-
-	// Reset all players' passed status and special action usage
-	for _, player := range gs.Players {
-		player.HasPassed = false
-		player.SpecialActionsUsed = make(map[SpecialActionType]bool)
-	}
-
 	// Start with income phase
-	gs.Phase = PhaseIncome
+	gs.Phases().Enter(PhaseIncome)
 }
 
 // StartIncomePhase transitions to the income phase
 func (gs *GameState) StartIncomePhase() {
-	gs.Phase = PhaseIncome
+	gs.Phases().Enter(PhaseIncome)
 	// Income is granted by calling GrantIncome() for each player
 }
 
 // StartActionPhase transitions to the action phase
 func (gs *GameState) StartActionPhase() {
-	gs.Phase = PhaseAction
+	gs.Phases().Enter(PhaseAction)
 	gs.CurrentPlayerIndex = 0
+	if current := gs.GetCurrentPlayer(); current != nil && current.Resigned {
+		gs.advanceToNextPlayer()
+	}
 }
 
 // StartCleanupPhase transitions to the cleanup phase
 func (gs *GameState) StartCleanupPhase() {
-	gs.Phase = PhaseCleanup
+	gs.Phases().Enter(PhaseCleanup)
 	// Cleanup logic executed by calling ExecuteCleanupPhase()
 	// - Cult track rewards
 	// - Add coins to bonus tiles
@@ -1763,7 +1838,7 @@ func (gs *GameState) StartCleanupPhase() {
 
 // EndGame transitions to the end game phase
 func (gs *GameState) EndGame() {
-	gs.Phase = PhaseEnd
+	gs.Phases().Enter(PhaseEnd)
 	// Final scores calculated by calling CalculateFinalScores()
 }
 
@@ -1813,20 +1888,7 @@ func (gs *GameState) gainPriestsToResources(playerID string, amount int) int {
 	if player == nil {
 		return 0
 	}
-	totalPriests := gs.GetTotalOwnedPriests(playerID)
-	maxNewPriests := 7 - totalPriests
-
-	if maxNewPriests <= 0 {
-		// Already at or above the 7-priest limit, cannot gain any priests
-		return 0
-	}
-
-	// Cap the amount to not exceed the limit
-	priestsToGain := amount
-	if priestsToGain > maxNewPriests {
-		priestsToGain = maxNewPriests
-	}
-
+	priestsToGain := gs.PriestLedger(playerID).Gainable(amount)
 	player.Resources.Priests += priestsToGain
 	return priestsToGain
 }
@@ -1867,7 +1929,7 @@ func (gs *GameState) riverwalkersPriestChoiceCapacity(player *Player) int {
 	if gs == nil || player == nil {
 		return 0
 	}
-	capacity := gs.RemainingPriestCapacity(player.ID)
+	capacity := gs.PriestLedger(player.ID).Capacity()
 	costs := make([]int, 0, 7)
 	for terrain := models.TerrainPlains; terrain <= models.TerrainDesert; terrain++ {
 		if player.UnlockedTerrains != nil && player.UnlockedTerrains[terrain] {
@@ -1894,7 +1956,7 @@ func (gs *GameState) riverwalkersCanResolvePriestChoice(player *Player) bool {
 	if gs == nil || player == nil {
 		return false
 	}
-	if gs.RemainingPriestCapacity(player.ID) > 0 {
+	if gs.PriestLedger(player.ID).CanGain(1) {
 		return true
 	}
 	return gs.riverwalkersHasAffordableUnlockOption(player)
@@ -1923,7 +1985,7 @@ func (gs *GameState) consumeRiverwalkersPriestChoice(player *Player) {
 	if gs.PendingRiverwalkersPriestChoice.PriestsRemaining <= 0 || !gs.riverwalkersCanResolvePriestChoice(player) {
 		gs.PendingRiverwalkersPriestChoice = nil
 	}
-	if gs.Phase == PhaseIncome && !gs.HasPendingIncomeDecisions() {
+	if gs.Phases().Current() == PhaseIncome && !gs.HasPendingIncomeDecisions() {
 		gs.StartActionPhase()
 	}
 }
@@ -1932,29 +1994,6 @@ func (gs *GameState) HasPendingIncomeDecisions() bool {
 	return gs != nil && (gs.PendingTreasurersDeposit != nil || gs.PendingRiverwalkersPriestChoice != nil)
 }
 
-func (gs *GameState) GetTotalOwnedPriests(playerID string) int {
-	player := gs.GetPlayer(playerID)
-	if player == nil || player.Resources == nil {
-		return 0
-	}
-
-	priestsInHand := player.Resources.Priests
-	priestsInTreasury := player.TreasuryPriests
-	priestsOnCult := 0
-	if gs != nil && gs.CultTracks != nil {
-		priestsOnCult = gs.CultTracks.GetTotalPriestsOnCultTracks(playerID)
-	}
-	return priestsInHand + priestsOnCult + priestsInTreasury
-}
-
-func (gs *GameState) RemainingPriestCapacity(playerID string) int {
-	remaining := 7 - gs.GetTotalOwnedPriests(playerID)
-	if remaining < 0 {
-		return 0
-	}
-	return remaining
-}
-
 // IsGameOver checks if the game has ended (after round 6)
 func (gs *GameState) IsGameOver() bool {
 	return gs.Round > 6
@@ -1969,7 +2008,7 @@ func (gs *GameState) IsGameOver() bool {
 func (gs *GameState) AdvanceShippingLevel(playerID string) error {
 	player := gs.GetPlayer(playerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", playerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", playerID)
 	}
 	if player.Faction.GetType() == models.FactionRiverwalkers {
 		return fmt.Errorf("riverwalkers cannot advance shipping")
@@ -2019,7 +2058,7 @@ func (gs *GameState) AdvanceShippingLevel(playerID string) error {
 func (gs *GameState) AdvanceDiggingLevel(playerID string) error {
 	player := gs.GetPlayer(playerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", playerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", playerID)
 	}
 
 	factionType := player.Faction.GetType()
@@ -2082,7 +2121,7 @@ func maxDiggingLevelForFaction(factionType models.FactionType) (int, error) {
 func (gs *GameState) advanceDiggingLevelWithoutVP(playerID string) error {
 	player := gs.GetPlayer(playerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", playerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", playerID)
 	}
 
 	maxLevel, err := maxDiggingLevelForFaction(player.Faction.GetType())
@@ -2106,75 +2145,14 @@ func (gs *GameState) advanceDiggingLevelWithoutVP(playerID string) error {
 	return nil
 }
 
-// AlchemistsConvertVPToCoins allows Alchemists to convert VP to Coins (1 VP -> 1 Coin)
-// This can be done at any time and does not count as an action
-func (gs *GameState) AlchemistsConvertVPToCoins(playerID string, vp int) error {
-	player := gs.GetPlayer(playerID)
-	if player == nil {
-		return fmt.Errorf("player not found")
-	}
-
-	// Check if player is Alchemists
-	alchemists, ok := player.Faction.(*factions.Alchemists)
-	if !ok {
-		return fmt.Errorf("only Alchemists can use Philosopher's Stone")
-	}
-
-	// Validate conversion
-	coins, err := alchemists.ConvertVPToCoins(vp)
-	if err != nil {
-		return err
-	}
-
-	// Check if player has enough VP
-	if player.VictoryPoints < vp {
-		return fmt.Errorf("not enough VP (have %d, need %d)", player.VictoryPoints, vp)
-	}
-
-	// Execute conversion
-	player.VictoryPoints -= vp
-	player.Resources.Coins += coins
-
-	return nil
-}
-
-// AlchemistsConvertCoinsToVP allows Alchemists to convert Coins to VP (2 Coins -> 1 VP)
-// This can be done at any time and does not count as an action
-func (gs *GameState) AlchemistsConvertCoinsToVP(playerID string, coins int) error {
-	player := gs.GetPlayer(playerID)
-	if player == nil {
-		return fmt.Errorf("player not found")
-	}
-
-	// Check if player is Alchemists
-	alchemists, ok := player.Faction.(*factions.Alchemists)
-	if !ok {
-		return fmt.Errorf("only Alchemists can use Philosopher's Stone")
-	}
-
-	// Validate conversion
-	vp, err := alchemists.ConvertCoinsToVP(coins)
-	if err != nil {
-		return err
-	}
-
-	// Check if player has enough coins
-	if player.Resources.Coins < coins {
-		return fmt.Errorf("not enough coins (have %d, need %d)", player.Resources.Coins, coins)
-	}
-
-	// Execute conversion
-	player.Resources.Coins -= coins
-	player.VictoryPoints += vp
-
-	return nil
-}
-
 // HasPendingActions checks if a player has any pending actions that block turn advancement
 func (gs *GameState) HasPendingActions(playerID string) bool {
-	if gs.HasBlockingPendingLeechOffers() {
-		return true
-	}
+	return gs.HasBlockingPendingLeechOffers() || gs.hasPendingFollowups(playerID)
+}
+
+// hasPendingFollowups reports whether playerID still owes a decision from their
+// own action, leaving leech offers aside.
+func (gs *GameState) hasPendingFollowups(playerID string) bool {
 	if gs.PendingFavorTileSelection != nil && gs.PendingFavorTileSelection.PlayerID == playerID {
 		return true
 	}
@@ -2245,6 +2223,10 @@ func (gs *GameState) GetPendingSpadeFollowupPlayer() (string, int) {
 }
 
 func (gs *GameState) GetPendingCultRewardSpadePlayer() (string, int) {
+	if gs.InCultRewardSpadePhase() {
+		playerID := gs.CultRewardSpadeOrder[gs.CultRewardSpadeIndex]
+		return playerID, gs.PendingCultRewardSpades[playerID]
+	}
 	if len(gs.PendingCultRewardSpades) == 0 {
 		return "", 0
 	}
@@ -0,0 +1,199 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/game/factions"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+// stateCheckpoint is a GameState with the fields its client JSON leaves out.
+type stateCheckpoint struct {
+	State                           *GameState                            `json:"state"`
+	NextLeechEventID                int                                   `json:"nextLeechEventId"`
+	PendingTreasurersDepositQueue   []*PendingTreasurersDeposit           `json:"pendingTreasurersDepositQueue,omitempty"`
+	PendingWispsTradingPostSpade    map[string]board.Hex                  `json:"pendingWispsTradingPostSpade,omitempty"`
+	PendingPostActionSpecialActions map[string]map[SpecialActionType]bool `json:"pendingPostActionSpecialActions,omitempty"`
+	ReplayAcolytesCultTracks        map[string][]CultTrack                `json:"replayAcolytesCultTracks,omitempty"`
+	ReplayAcolytesCultTrackIndex    map[string]int                        `json:"replayAcolytesCultTrackIndex,omitempty"`
+	ReplayRiverBuildHexes           map[string][]board.Hex                `json:"replayRiverBuildHexes,omitempty"`
+	ReplayRiverBuildHexIndex        map[string]int                        `json:"replayRiverBuildHexIndex,omitempty"`
+	// ReplayCultSpadeBuildHexes lists the hexes of each set, as JSON has no
+	// struct keys.
+	ReplayCultSpadeBuildHexes     map[string][]board.Hex            `json:"replayCultSpadeBuildHexes,omitempty"`
+	PendingSnowShamansPassUpgrade map[string]SnowShamansPassUpgrade `json:"pendingSnowShamansPassUpgrade,omitempty"`
+	SuppressTurnAdvance           bool                              `json:"suppressTurnAdvance,omitempty"`
+	RiverTownHex                  *board.Hex                        `json:"riverTownHex,omitempty"`
+	Players                       map[string]playerCheckpoint       `json:"players"`
+}
+
+// playerCheckpoint holds the hidden fields of a Player and its faction.
+type playerCheckpoint struct {
+	AtlanteansTownHexes   []board.Hex  `json:"atlanteansTownHexes,omitempty"`
+	AtlanteansTownRewards map[int]bool `json:"atlanteansTownRewards,omitempty"`
+	// FlightRange is the carpet flight range of Fakirs.
+	FlightRange int `json:"flightRange,omitempty"`
+	// FactionShippingLevel is the shipping level factions such as the
+	// Mermaids track themselves.
+	FactionShippingLevel *int `json:"factionShippingLevel,omitempty"`
+}
+
+// factionShippingLevel is implemented by factions keeping their own shipping
+// level.
+type factionShippingLevel interface {
+	GetShippingLevel() int
+	SetShippingLevel(level int)
+}
+
+// MarshalCheckpoint encodes gs so that UnmarshalCheckpoint restores it, for
+// tools that stop a replay and carry on from the same state later. Undo
+// snapshots, including a pending turn confirmation's, are not kept.
+func (gs *GameState) MarshalCheckpoint() ([]byte, error) {
+	checkpoint := stateCheckpoint{
+		State:                           gs,
+		NextLeechEventID:                gs.NextLeechEventID,
+		PendingTreasurersDepositQueue:   gs.PendingTreasurersDepositQueue,
+		PendingWispsTradingPostSpade:    gs.PendingWispsTradingPostSpade,
+		PendingPostActionSpecialActions: gs.PendingPostActionSpecialActions,
+		ReplayAcolytesCultTracks:        gs.ReplayAcolytesCultTracks,
+		ReplayAcolytesCultTrackIndex:    gs.ReplayAcolytesCultTrackIndex,
+		ReplayRiverBuildHexes:           gs.ReplayRiverBuildHexes,
+		ReplayRiverBuildHexIndex:        gs.ReplayRiverBuildHexIndex,
+		PendingSnowShamansPassUpgrade:   gs.PendingSnowShamansPassUpgrade,
+		SuppressTurnAdvance:             gs.SuppressTurnAdvance,
+		RiverTownHex:                    gs.RiverTownHex,
+		Players:                         make(map[string]playerCheckpoint, len(gs.Players)),
+	}
+	if gs.ReplayCultSpadeBuildHexes != nil {
+		checkpoint.ReplayCultSpadeBuildHexes = make(map[string][]board.Hex, len(gs.ReplayCultSpadeBuildHexes))
+		for playerID, hexes := range gs.ReplayCultSpadeBuildHexes {
+			list := make([]board.Hex, 0, len(hexes))
+			for hex, ok := range hexes {
+				if ok {
+					list = append(list, hex)
+				}
+			}
+			checkpoint.ReplayCultSpadeBuildHexes[playerID] = list
+		}
+	}
+	for playerID, player := range gs.Players {
+		if player == nil {
+			continue
+		}
+		hidden := playerCheckpoint{
+			AtlanteansTownHexes:   player.AtlanteansTownHexes,
+			AtlanteansTownRewards: player.AtlanteansTownRewards,
+		}
+		if fakirs, ok := player.Faction.(*factions.Fakirs); ok {
+			hidden.FlightRange = fakirs.GetFlightRange()
+		}
+		if shipping, ok := player.Faction.(factionShippingLevel); ok {
+			level := shipping.GetShippingLevel()
+			hidden.FactionShippingLevel = &level
+		}
+		checkpoint.Players[playerID] = hidden
+	}
+	return json.Marshal(checkpoint)
+}
+
+// UnmarshalCheckpoint restores a state written by MarshalCheckpoint.
+func UnmarshalCheckpoint(raw []byte) (*GameState, error) {
+	var checkpoint stateCheckpoint
+	if err := json.Unmarshal(raw, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to decode state checkpoint: %w", err)
+	}
+	gs := checkpoint.State
+	if gs == nil {
+		return nil, fmt.Errorf("state checkpoint has no state")
+	}
+	gs.NextLeechEventID = checkpoint.NextLeechEventID
+	gs.PendingTreasurersDepositQueue = checkpoint.PendingTreasurersDepositQueue
+	gs.PendingWispsTradingPostSpade = checkpoint.PendingWispsTradingPostSpade
+	gs.PendingPostActionSpecialActions = checkpoint.PendingPostActionSpecialActions
+	gs.ReplayAcolytesCultTracks = checkpoint.ReplayAcolytesCultTracks
+	gs.ReplayAcolytesCultTrackIndex = checkpoint.ReplayAcolytesCultTrackIndex
+	gs.ReplayRiverBuildHexes = checkpoint.ReplayRiverBuildHexes
+	gs.ReplayRiverBuildHexIndex = checkpoint.ReplayRiverBuildHexIndex
+	gs.PendingSnowShamansPassUpgrade = checkpoint.PendingSnowShamansPassUpgrade
+	gs.SuppressTurnAdvance = checkpoint.SuppressTurnAdvance
+	gs.RiverTownHex = checkpoint.RiverTownHex
+	if checkpoint.ReplayCultSpadeBuildHexes != nil {
+		gs.ReplayCultSpadeBuildHexes = make(map[string]map[board.Hex]bool, len(checkpoint.ReplayCultSpadeBuildHexes))
+		for playerID, hexes := range checkpoint.ReplayCultSpadeBuildHexes {
+			set := make(map[board.Hex]bool, len(hexes))
+			for _, hex := range hexes {
+				set[hex] = true
+			}
+			gs.ReplayCultSpadeBuildHexes[playerID] = set
+		}
+	}
+
+	for playerID, player := range gs.Players {
+		if player == nil {
+			continue
+		}
+		hidden := checkpoint.Players[playerID]
+		player.AtlanteansTownHexes = hidden.AtlanteansTownHexes
+		player.AtlanteansTownRewards = hidden.AtlanteansTownRewards
+		if player.Faction == nil || gs.Map == nil {
+			continue
+		}
+		// Factions remember their stronghold themselves; it is built exactly
+		// when the player has one on the map.
+		if builder, ok := player.Faction.(interface{ BuildStronghold() }); ok && hasStrongholdOnMap(gs.Map, playerID) {
+			builder.BuildStronghold()
+		}
+		if fakirs, ok := player.Faction.(*factions.Fakirs); ok {
+			for fakirs.GetFlightRange() < hidden.FlightRange {
+				fakirs.IncrementFlightRange()
+			}
+		}
+		if shipping, ok := player.Faction.(factionShippingLevel); ok && hidden.FactionShippingLevel != nil {
+			shipping.SetShippingLevel(*hidden.FactionShippingLevel)
+		}
+	}
+	return gs, nil
+}
+
+func hasStrongholdOnMap(m *board.TerraMysticaMap, playerID string) bool {
+	for _, hex := range m.Hexes {
+		if hex != nil && hex.Building != nil && hex.Building.PlayerID == playerID && hex.Building.Type == models.BuildingStronghold {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalJSON reads a Player from its JSON, making its faction from the
+// faction type.
+func (p *Player) UnmarshalJSON(raw []byte) error {
+	type plainPlayer Player
+	aux := struct {
+		*plainPlayer
+		Faction json.RawMessage `json:"faction"`
+	}{plainPlayer: (*plainPlayer)(p)}
+	if err := json.Unmarshal(raw, &aux); err != nil {
+		return err
+	}
+	p.Faction = nil
+	if len(aux.Faction) == 0 || string(aux.Faction) == "null" {
+		return nil
+	}
+	var head struct {
+		Type models.FactionType
+	}
+	if err := json.Unmarshal(aux.Faction, &head); err != nil {
+		return fmt.Errorf("failed to decode faction of %s: %w", p.ID, err)
+	}
+	faction := factions.NewFaction(head.Type)
+	if faction == nil {
+		return fmt.Errorf("unknown faction %v of %s", head.Type, p.ID)
+	}
+	if err := json.Unmarshal(aux.Faction, faction); err != nil {
+		return fmt.Errorf("failed to decode faction of %s: %w", p.ID, err)
+	}
+	p.Faction = faction
+	return nil
+}
@@ -2,6 +2,7 @@ package game
 
 import (
 	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/game/factions"
 	"github.com/lukev/tm_server/internal/models"
 )
 
@@ -13,11 +14,18 @@ func (gs *GameState) CloneForUndo() *GameState {
 	}
 
 	clone := &GameState{
+		tiles:                           gs.tiles,
 		Round:                           gs.Round,
 		Phase:                           gs.Phase,
 		SetupMode:                       gs.SetupMode,
 		FireIceFinalScoringSetting:      gs.FireIceFinalScoringSetting,
 		FireIceFinalScoringTile:         gs.FireIceFinalScoringTile,
+		HiddenResources:                 gs.HiddenResources,
+		Hotseat:                         gs.Hotseat,
+		PublicSummary:                   gs.PublicSummary,
+		PublicSummaryConsent:            append([]string(nil), gs.PublicSummaryConsent...),
+		SpeedPreset:                     gs.SpeedPreset,
+		Seed:                            gs.Seed,
 		SetupSubphase:                   gs.SetupSubphase,
 		SetupDwellingIndex:              gs.SetupDwellingIndex,
 		SetupBonusIndex:                 gs.SetupBonusIndex,
@@ -31,8 +39,11 @@ func (gs *GameState) CloneForUndo() *GameState {
 		PendingSpades:                   cloneStringIntMap(gs.PendingSpades),
 		PendingSpadeBuildAllowed:        cloneStringBoolMap(gs.PendingSpadeBuildAllowed),
 		PendingCultRewardSpades:         cloneStringIntMap(gs.PendingCultRewardSpades),
+		CultRewardSpadeOrder:            append([]string(nil), gs.CultRewardSpadeOrder...),
+		CultRewardSpadeIndex:            gs.CultRewardSpadeIndex,
 		NextLeechEventID:                gs.NextLeechEventID,
 		PendingFreeActionsPlayerID:      gs.PendingFreeActionsPlayerID,
+		MustPassPlayerID:                gs.MustPassPlayerID,
 		PendingCultistsLeech:            clonePendingCultistsLeech(gs.PendingCultistsLeech),
 		PendingShapeshiftersLeech:       clonePendingCultistsLeech(gs.PendingShapeshiftersLeech),
 		SkipAbilityUsedThisAction:       cloneSkipAbilityUsedThisAction(gs.SkipAbilityUsedThisAction),
@@ -76,6 +87,8 @@ func (gs *GameState) CloneForUndo() *GameState {
 	clone.PendingTownCultTopChoice = clonePendingTownCultTopChoice(gs.PendingTownCultTopChoice)
 	clone.FinalScoring = cloneFinalScoring(gs.FinalScoring)
 	clone.TurnTimer = cloneTurnTimerState(gs.TurnTimer)
+	clone.Handicaps = cloneHandicaps(gs.Handicaps)
+	clone.AutoLeechDecisions = append([]AutoLeechDecision(nil), gs.AutoLeechDecisions...)
 
 	if gs.RiverTownHex != nil {
 		hex := *gs.RiverTownHex
@@ -130,6 +143,12 @@ func clonePlayer(src *Player) *Player {
 	if src.Resources != nil {
 		dst.Resources = src.Resources.Clone()
 	}
+	// Fakirs keep mutable flight range state on the faction itself; copy it so
+	// undo snapshots do not share stronghold/town-tile range increases.
+	if fakirs, ok := src.Faction.(*factions.Fakirs); ok {
+		fakirsCopy := *fakirs
+		dst.Faction = &fakirsCopy
+	}
 	if src.CultPositions != nil {
 		dst.CultPositions = make(map[CultTrack]int, len(src.CultPositions))
 		for track, pos := range src.CultPositions {
@@ -149,8 +168,9 @@ func clonePlayer(src *Player) *Player {
 		}
 	}
 	if src.TownTiles != nil {
-		dst.TownTiles = append([]models.TownTileType(nil), src.TownTiles...)
+		dst.TownTiles = append(make([]models.TownTileType, 0, len(src.TownTiles)), src.TownTiles...)
 	}
+	dst.PowerGained = cloneStringIntMap(src.PowerGained)
 	if src.AtlanteansTownHexes != nil {
 		dst.AtlanteansTownHexes = append([]board.Hex(nil), src.AtlanteansTownHexes...)
 	}
@@ -219,6 +239,7 @@ func cloneMap(src *board.TerraMysticaMap) *board.TerraMysticaMap {
 		Bridges:    make(map[board.BridgeKey]string, len(src.Bridges)),
 		RiverHexes: make(map[board.Hex]bool, len(src.RiverHexes)),
 	}
+	dst.CopyLayoutFrom(src)
 	for coord, hex := range src.Hexes {
 		if hex == nil {
 			dst.Hexes[coord] = nil
@@ -274,6 +295,17 @@ func cloneAuctionState(src *AuctionState) *AuctionState {
 	return &dst
 }
 
+func cloneHandicaps(src map[string]Handicap) map[string]Handicap {
+	if src == nil {
+		return nil
+	}
+	dst := make(map[string]Handicap, len(src))
+	for playerID, handicap := range src {
+		dst[playerID] = handicap
+	}
+	return dst
+}
+
 func cloneTurnTimerState(src *TurnTimerState) *TurnTimerState {
 	if src == nil {
 		return nil
@@ -367,7 +399,7 @@ func cloneFavorTileState(src *FavorTileState) *FavorTileState {
 		dst.Available[tileType] = count
 	}
 	for playerID, tiles := range src.PlayerTiles {
-		dst.PlayerTiles[playerID] = append([]FavorTileType(nil), tiles...)
+		dst.PlayerTiles[playerID] = append(make([]FavorTileType, 0, len(tiles)), tiles...)
 	}
 	return dst
 }
@@ -394,6 +426,8 @@ func cloneBonusCardState(src *BonusCardState) *BonusCardState {
 	for playerID, hasCard := range src.PlayerHasCard {
 		dst.PlayerHasCard[playerID] = hasCard
 	}
+	dst.Removed = append([]BonusCardType(nil), src.Removed...)
+	dst.CoinsPlacedForRound = src.CoinsPlacedForRound
 	return dst
 }
 
@@ -554,6 +588,7 @@ func clonePendingCultistsCultSelection(src *PendingCultistsCultSelection) *Pendi
 		return nil
 	}
 	dst := *src
+	dst.EventIDs = append([]int(nil), src.EventIDs...)
 	return &dst
 }
 
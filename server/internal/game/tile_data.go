@@ -0,0 +1,345 @@
+package game
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+	"sync/atomic"
+
+	"github.com/lukev/tm_server/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Scoring tiles, bonus cards, favor tiles and town tiles are defined in
+// data/tiles.yaml. Tiles are named by the strings the *TypeFromString
+// functions accept; the rules each tile triggers stay in code, the data files
+// only set their numbers.
+
+//go:embed data/tiles.yaml
+var builtInTileData []byte
+
+type tileDataFile struct {
+	ScoringTiles []scoringTileData `yaml:"scoringTiles"`
+	BonusCards   []bonusCardData   `yaml:"bonusCards"`
+	FavorTiles   []favorTileData   `yaml:"favorTiles"`
+	TownTiles    []townTileData    `yaml:"townTiles"`
+}
+
+type scoringTileData struct {
+	Type             string `yaml:"type"`
+	Action           string `yaml:"action"`
+	ActionVP         int    `yaml:"actionVp"`
+	CultTrack        string `yaml:"cultTrack"`
+	CultThreshold    int    `yaml:"cultThreshold"`
+	CultReward       string `yaml:"cultReward"`
+	CultRewardAmount int    `yaml:"cultRewardAmount"`
+}
+
+type bonusCardData struct {
+	Type          string `yaml:"type"`
+	Description   string `yaml:"description"`
+	Coins         int    `yaml:"coins"`
+	Workers       int    `yaml:"workers"`
+	Priests       int    `yaml:"priests"`
+	Power         int    `yaml:"power"`
+	SpecialAction bool   `yaml:"specialAction"`
+	ShippingBonus int    `yaml:"shippingBonus"`
+	PassVP        string `yaml:"passVp"`
+}
+
+type favorTileData struct {
+	Type        string `yaml:"type"`
+	CultTrack   string `yaml:"cultTrack"`
+	CultAdvance int    `yaml:"cultAdvance"`
+	Description string `yaml:"description"`
+	HasAbility  bool   `yaml:"hasAbility"`
+	Count       int    `yaml:"count"`
+	// Modifiers are the tile's ongoing effects; see FavorModifiers.
+	Modifiers FavorModifiers `yaml:"modifiers"`
+}
+
+type townTileData struct {
+	Type  string `yaml:"type"`
+	Count int    `yaml:"count"`
+}
+
+type tileCatalog struct {
+	scoringTiles []ScoringTile
+	bonusCards   map[BonusCardType]BonusCard
+	favorTiles   map[FavorTileType]FavorTile
+	townTiles    map[models.TownTileType]int
+}
+
+var (
+	builtInTiles *tileCatalog
+	loadedTiles  atomic.Pointer[tileCatalog]
+)
+
+func init() {
+	catalog, err := parseTileData(builtInTileData, nil)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in tile data: %v", err))
+	}
+	builtInTiles = catalog
+	loadedTiles.Store(catalog)
+}
+
+func currentTiles() *tileCatalog {
+	return loadedTiles.Load()
+}
+
+// tileDefinitions returns the tile definitions gs was created with, so a
+// reload does not change the rules of games in progress. States built without
+// newGameStateWithBoard use the loaded definitions.
+func (gs *GameState) tileDefinitions() *tileCatalog {
+	if gs == nil || gs.tiles == nil {
+		return currentTiles()
+	}
+	return gs.tiles
+}
+
+// LoadTileDataFile validates a tile data file and applies it on top of the
+// built-in tiles: each section the file lists replaces that whole section.
+// Nothing changes if the file is invalid. Loading again starts from the
+// built-in tiles, so it doubles as a reload. Games already loaded keep the
+// definitions they were created with; games replayed from a save file, such
+// as archived ones, use the definitions loaded at the time.
+func LoadTileDataFile(path string) error {
+	tiles, err := ReadTileDataFile(path)
+	if err != nil {
+		return err
+	}
+	tiles.Apply()
+	return nil
+}
+
+// TileData is a validated tile data file that is not in use yet.
+type TileData struct {
+	catalog *tileCatalog
+}
+
+// ReadTileDataFile validates a tile data file like LoadTileDataFile without
+// applying it, so callers loading several kinds of data can apply them only
+// once all of it is valid.
+func ReadTileDataFile(path string) (*TileData, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	catalog, err := parseTileData(raw, builtInTiles)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &TileData{catalog: catalog}, nil
+}
+
+// Apply makes the tile definitions the ones new games are created with.
+func (d *TileData) Apply() {
+	loadedTiles.Store(d.catalog)
+}
+
+// parseTileData parses a tile data file. Sections missing from the file are
+// taken from base, which must be set unless the file lists every section.
+func parseTileData(raw []byte, base *tileCatalog) (*tileCatalog, error) {
+	var file tileDataFile
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid tile data: %w", err)
+	}
+	if base == nil && (file.ScoringTiles == nil || file.BonusCards == nil || file.FavorTiles == nil || file.TownTiles == nil) {
+		return nil, fmt.Errorf("tile data must list scoringTiles, bonusCards, favorTiles and townTiles")
+	}
+
+	catalog := &tileCatalog{}
+	if base != nil {
+		*catalog = *base
+	}
+	var err error
+	if file.ScoringTiles != nil {
+		if catalog.scoringTiles, err = parseScoringTileData(file.ScoringTiles); err != nil {
+			return nil, err
+		}
+	}
+	if file.BonusCards != nil {
+		if catalog.bonusCards, err = parseBonusCardData(file.BonusCards); err != nil {
+			return nil, err
+		}
+	}
+	if file.FavorTiles != nil {
+		if catalog.favorTiles, err = parseFavorTileData(file.FavorTiles); err != nil {
+			return nil, err
+		}
+	}
+	if file.TownTiles != nil {
+		if catalog.townTiles, err = parseTownTileData(file.TownTiles); err != nil {
+			return nil, err
+		}
+	}
+	return catalog, nil
+}
+
+var (
+	scoringActionsByName = map[string]ScoringActionType{
+		"dwelling":       ScoringActionDwelling,
+		"trading_house":  ScoringActionTradingHouse,
+		"stronghold":     ScoringActionStronghold,
+		"temple":         ScoringActionTemple,
+		"spades":         ScoringActionSpades,
+		"town":           ScoringActionTown,
+		"priest_to_cult": ScoringActionPriestToCult,
+	}
+	cultRewardsByName = map[string]CultRewardType{
+		"priest": CultRewardPriest,
+		"power":  CultRewardPower,
+		"spade":  CultRewardSpade,
+		"worker": CultRewardWorker,
+		"coin":   CultRewardCoin,
+	}
+	cultTracksByName = map[string]CultTrack{
+		"fire":  CultFire,
+		"water": CultWater,
+		"earth": CultEarth,
+		"air":   CultAir,
+	}
+	passVPTypes = map[string]bool{
+		"":                     true,
+		"dwelling":             true,
+		"trading_house":        true,
+		"stronghold_sanctuary": true,
+		"shipping":             true,
+	}
+)
+
+func parseScoringTileData(entries []scoringTileData) ([]ScoringTile, error) {
+	tiles := make([]ScoringTile, 0, len(entries))
+	seen := make(map[ScoringTileType]bool, len(entries))
+	for _, entry := range entries {
+		tileType := ScoringTileTypeFromString(entry.Type)
+		if tileType == ScoringTileUnknown {
+			return nil, fmt.Errorf("unknown scoring tile %q", entry.Type)
+		}
+		if seen[tileType] {
+			return nil, fmt.Errorf("duplicate scoring tile %q", entry.Type)
+		}
+		seen[tileType] = true
+		action, ok := scoringActionsByName[entry.Action]
+		if !ok {
+			return nil, fmt.Errorf("scoring tile %q: unknown action %q", entry.Type, entry.Action)
+		}
+		track, ok := cultTracksByName[entry.CultTrack]
+		if !ok {
+			return nil, fmt.Errorf("scoring tile %q: unknown cult track %q", entry.Type, entry.CultTrack)
+		}
+		reward, ok := cultRewardsByName[entry.CultReward]
+		if !ok {
+			return nil, fmt.Errorf("scoring tile %q: unknown cult reward %q", entry.Type, entry.CultReward)
+		}
+		if entry.ActionVP < 0 || entry.CultThreshold < 0 || entry.CultRewardAmount < 0 {
+			return nil, fmt.Errorf("scoring tile %q: values must not be negative", entry.Type)
+		}
+		tiles = append(tiles, ScoringTile{
+			Type:             tileType,
+			ActionType:       action,
+			ActionVP:         entry.ActionVP,
+			CultTrack:        track,
+			CultThreshold:    entry.CultThreshold,
+			CultRewardType:   reward,
+			CultRewardAmount: entry.CultRewardAmount,
+		})
+	}
+	// Six rounds need six tiles.
+	if len(tiles) < 6 {
+		return nil, fmt.Errorf("scoring tiles cannot fill 6 rounds: got %d tiles", len(tiles))
+	}
+	// Seeded draws shuffle this list, so its order must not depend on the file.
+	sort.Slice(tiles, func(i, j int) bool { return tiles[i].Type < tiles[j].Type })
+	return tiles, nil
+}
+
+func parseBonusCardData(entries []bonusCardData) (map[BonusCardType]BonusCard, error) {
+	cards := make(map[BonusCardType]BonusCard, len(entries))
+	for _, entry := range entries {
+		cardType := BonusCardTypeFromString(entry.Type)
+		if cardType == BonusCardUnknown {
+			return nil, fmt.Errorf("unknown bonus card %q", entry.Type)
+		}
+		if _, exists := cards[cardType]; exists {
+			return nil, fmt.Errorf("duplicate bonus card %q", entry.Type)
+		}
+		if !passVPTypes[entry.PassVP] {
+			return nil, fmt.Errorf("bonus card %q: unknown pass VP type %q", entry.Type, entry.PassVP)
+		}
+		if entry.Coins < 0 || entry.Workers < 0 || entry.Priests < 0 || entry.Power < 0 || entry.ShippingBonus < 0 {
+			return nil, fmt.Errorf("bonus card %q: values must not be negative", entry.Type)
+		}
+		cards[cardType] = BonusCard{
+			Type:             cardType,
+			Name:             entry.Type,
+			Description:      entry.Description,
+			Coins:            entry.Coins,
+			Workers:          entry.Workers,
+			Priests:          entry.Priests,
+			Power:            entry.Power,
+			HasSpecialAction: entry.SpecialAction,
+			ShippingBonus:    entry.ShippingBonus,
+			PassVPType:       entry.PassVP,
+		}
+	}
+	// A 5-player game draws 8 cards.
+	if len(cards) < 8 {
+		return nil, fmt.Errorf("at least 8 bonus cards are required, got %d", len(cards))
+	}
+	return cards, nil
+}
+
+func parseFavorTileData(entries []favorTileData) (map[FavorTileType]FavorTile, error) {
+	tiles := make(map[FavorTileType]FavorTile, len(entries))
+	for _, entry := range entries {
+		tileType := FavorTileTypeFromString(entry.Type)
+		if tileType == FavorTileUnknown {
+			return nil, fmt.Errorf("unknown favor tile %q", entry.Type)
+		}
+		if _, exists := tiles[tileType]; exists {
+			return nil, fmt.Errorf("duplicate favor tile %q", entry.Type)
+		}
+		track, ok := cultTracksByName[entry.CultTrack]
+		if !ok {
+			return nil, fmt.Errorf("favor tile %q: unknown cult track %q", entry.Type, entry.CultTrack)
+		}
+		if entry.CultAdvance < 0 || entry.Count < 0 || !entry.Modifiers.nonNegative() {
+			return nil, fmt.Errorf("favor tile %q: values must not be negative", entry.Type)
+		}
+		tiles[tileType] = FavorTile{
+			Type:         tileType,
+			CultTrack:    track,
+			CultAdvance:  entry.CultAdvance,
+			Name:         entry.Type,
+			Description:  entry.Description,
+			HasAbility:   entry.HasAbility,
+			AvailableQty: entry.Count,
+			Modifiers:    entry.Modifiers,
+		}
+	}
+	return tiles, nil
+}
+
+func parseTownTileData(entries []townTileData) (map[models.TownTileType]int, error) {
+	counts := make(map[models.TownTileType]int, len(entries))
+	for _, entry := range entries {
+		tileType := models.TownTileTypeFromString(entry.Type)
+		if tileType == models.TownTileUnknown {
+			return nil, fmt.Errorf("unknown town tile %q", entry.Type)
+		}
+		if _, exists := counts[tileType]; exists {
+			return nil, fmt.Errorf("duplicate town tile %q", entry.Type)
+		}
+		if entry.Count < 0 {
+			return nil, fmt.Errorf("town tile %q: count must not be negative", entry.Type)
+		}
+		counts[tileType] = entry.Count
+	}
+	return counts, nil
+}
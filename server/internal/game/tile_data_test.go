@@ -0,0 +1,153 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBuiltInTileData_MatchesBaseGame(t *testing.T) {
+	if got := len(GetAllScoringTiles()); got != 9 {
+		t.Fatalf("expected 9 scoring tiles, got %d", got)
+	}
+	if got := len(GetAllBonusCards()); got != 10 {
+		t.Fatalf("expected 10 bonus cards, got %d", got)
+	}
+	if got := len(GetAllFavorTiles()); got != 12 {
+		t.Fatalf("expected 12 favor tiles, got %d", got)
+	}
+	if coins, _, _, _ := GetBonusCardIncomeBonus(BonusCard6Coins); coins != 6 {
+		t.Fatalf("expected 6 Coins card to give 6 coins, got %d", coins)
+	}
+}
+
+func TestLoadTileDataFile_ReplacesListedSections(t *testing.T) {
+	t.Cleanup(func() { loadedTiles.Store(builtInTiles) })
+
+	var cards strings.Builder
+	cards.WriteString("bonusCards:\n")
+	for _, card := range GetAllBonusCards() {
+		coins := card.Coins
+		if card.Type == BonusCard6Coins {
+			coins = 7
+		}
+		cards.WriteString("  - type: \"" + card.Name + "\"\n")
+		cards.WriteString("    coins: " + strconv.Itoa(coins) + "\n")
+	}
+	path := filepath.Join(t.TempDir(), "tiles.yaml")
+	if err := os.WriteFile(path, []byte(cards.String()), 0o644); err != nil {
+		t.Fatalf("write tile data: %v", err)
+	}
+	if err := LoadTileDataFile(path); err != nil {
+		t.Fatalf("LoadTileDataFile failed: %v", err)
+	}
+	if coins, _, _, _ := GetBonusCardIncomeBonus(BonusCard6Coins); coins != 7 {
+		t.Fatalf("expected house-ruled 7 coins, got %d", coins)
+	}
+	if got := len(GetAllScoringTiles()); got != 9 {
+		t.Fatalf("sections missing from the file should keep built-in tiles, got %d scoring tiles", got)
+	}
+
+	bad := "favorTiles:\n  - type: \"Fire +4\"\n    cultTrack: fire\n"
+	if err := os.WriteFile(path, []byte(bad), 0o644); err != nil {
+		t.Fatalf("write tile data: %v", err)
+	}
+	err := LoadTileDataFile(path)
+	if err == nil || !strings.Contains(err.Error(), `unknown favor tile "Fire +4"`) {
+		t.Fatalf("expected unknown favor tile error, got %v", err)
+	}
+	if coins, _, _, _ := GetBonusCardIncomeBonus(BonusCard6Coins); coins != 7 {
+		t.Fatalf("failed load should keep previous data, got %d coins", coins)
+	}
+}
+
+func TestLoadTileDataFile_HouseRulesFavorModifiers(t *testing.T) {
+	t.Cleanup(func() { loadedTiles.Store(builtInTiles) })
+
+	raw, err := os.ReadFile(filepath.Join("data", "tiles.yaml"))
+	if err != nil {
+		t.Fatalf("read built-in tile data: %v", err)
+	}
+	data := strings.Replace(string(raw), "incomeCoins: 3", "incomeCoins: 4", 1)
+	path := filepath.Join(t.TempDir(), "tiles.yaml")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write tile data: %v", err)
+	}
+	if err := LoadTileDataFile(path); err != nil {
+		t.Fatalf("LoadTileDataFile failed: %v", err)
+	}
+	if coins, _, _ := GetFavorTileIncomeBonus([]FavorTileType{FavorFire1}); coins != 4 {
+		t.Fatalf("expected house-ruled Fire+1 income of 4 coins, got %d", coins)
+	}
+
+	negative := strings.Replace(string(raw), "incomeCoins: 3", "incomeCoins: -1", 1)
+	if err := os.WriteFile(path, []byte(negative), 0o644); err != nil {
+		t.Fatalf("write tile data: %v", err)
+	}
+	if err := LoadTileDataFile(path); err == nil {
+		t.Fatal("expected negative favor modifier to be rejected")
+	}
+}
+
+func TestLoadTileDataFile_KeepsDefinitionsOfRunningGames(t *testing.T) {
+	t.Cleanup(func() { loadedTiles.Store(builtInTiles) })
+
+	running := NewGameState()
+	raw, err := os.ReadFile(filepath.Join("data", "tiles.yaml"))
+	if err != nil {
+		t.Fatalf("read built-in tile data: %v", err)
+	}
+	data := strings.Replace(string(raw), "incomeCoins: 3", "incomeCoins: 4", 1)
+	path := filepath.Join(t.TempDir(), "tiles.yaml")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write tile data: %v", err)
+	}
+	if err := LoadTileDataFile(path); err != nil {
+		t.Fatalf("LoadTileDataFile failed: %v", err)
+	}
+
+	running.FavorTiles.PlayerTiles["p1"] = []FavorTileType{FavorFire1}
+	if got := running.FavorModifiers("p1").IncomeCoins; got != 3 {
+		t.Fatalf("expected the running game to keep Fire+1 income of 3 coins, got %d", got)
+	}
+	if got := running.CloneForUndo().FavorModifiers("p1").IncomeCoins; got != 3 {
+		t.Fatalf("expected a clone to keep the game's definitions, got %d coins", got)
+	}
+	created := NewGameState()
+	created.FavorTiles.PlayerTiles["p1"] = []FavorTileType{FavorFire1}
+	if got := created.FavorModifiers("p1").IncomeCoins; got != 4 {
+		t.Fatalf("expected a new game to use the reloaded 4 coins, got %d", got)
+	}
+}
+
+func TestReadTileDataFile_AppliesOnlyOnApply(t *testing.T) {
+	t.Cleanup(func() { loadedTiles.Store(builtInTiles) })
+
+	var cards strings.Builder
+	cards.WriteString("bonusCards:\n")
+	for _, card := range GetAllBonusCards() {
+		coins := card.Coins
+		if card.Type == BonusCard6Coins {
+			coins = 7
+		}
+		cards.WriteString("  - type: \"" + card.Name + "\"\n")
+		cards.WriteString("    coins: " + strconv.Itoa(coins) + "\n")
+	}
+	path := filepath.Join(t.TempDir(), "tiles.yaml")
+	if err := os.WriteFile(path, []byte(cards.String()), 0o644); err != nil {
+		t.Fatalf("write tile data: %v", err)
+	}
+	tiles, err := ReadTileDataFile(path)
+	if err != nil {
+		t.Fatalf("ReadTileDataFile failed: %v", err)
+	}
+	if coins, _, _, _ := GetBonusCardIncomeBonus(BonusCard6Coins); coins != 6 {
+		t.Fatalf("reading tile data should not apply it, got %d coins", coins)
+	}
+	tiles.Apply()
+	if coins, _, _, _ := GetBonusCardIncomeBonus(BonusCard6Coins); coins != 7 {
+		t.Fatalf("expected applied tile data, got %d coins", coins)
+	}
+}
@@ -13,20 +13,19 @@ type TownTileState struct {
 	Available map[models.TownTileType]int `json:"available"` // How many of each tile remain
 }
 
-// NewTownTileState creates a new town tile state with all tiles available
+// NewTownTileState creates a new town tile state with all tiles available, in
+// the counts of data/tiles.yaml
 func NewTownTileState() *TownTileState {
-	return &TownTileState{
-		Available: map[models.TownTileType]int{
-			models.TownTile5Points:  2, // 2 copies
-			models.TownTile6Points:  2, // 2 copies
-			models.TownTile7Points:  2, // 2 copies
-			models.TownTile4Points:  2, // 2 copies (shipping/range upgrade, TW7)
-			models.TownTile8Points:  2, // 2 copies
-			models.TownTile9Points:  2, // 2 copies
-			models.TownTile11Points: 1, // 1 copy
-			models.TownTile2Points:  1, // 1 copy
-		},
+	return newTownTileStateFrom(currentTiles())
+}
+
+func newTownTileStateFrom(c *tileCatalog) *TownTileState {
+	counts := c.townTiles
+	available := make(map[models.TownTileType]int, len(counts))
+	for tileType, count := range counts {
+		available[tileType] = count
 	}
+	return &TownTileState{Available: available}
 }
 
 // IsAvailable checks if a town tile is still available
@@ -76,18 +75,7 @@ func (gs *GameState) CheckForTownFormation(playerID string, hex board.Hex) []boa
 		return nil
 	}
 
-	// Find all connected buildings for this player
-	// Mermaids can skip one river hex when founding towns
-	var connected []board.Hex
-	var skippedRiver *board.Hex
-
-	if player.Faction.GetType() == models.FactionMermaids {
-		connected, skippedRiver = gs.Map.GetConnectedBuildingsForMermaids(hex, playerID)
-	} else if player.Faction.GetType() == models.FactionChildrenOfTheWyrm {
-		connected = gs.getConnectedBuildingsForPlayer(playerID, hex)
-	} else {
-		connected = gs.Map.GetConnectedBuildingsIncludingBridges(hex, playerID)
-	}
+	connected, skippedRiver := gs.townComponent(player, playerID, hex)
 
 	// Check if any building in the component is already part of a town
 	for _, h := range connected {
@@ -106,6 +94,20 @@ func (gs *GameState) CheckForTownFormation(playerID string, hex board.Hex) []boa
 	return nil
 }
 
+// townComponent returns the buildings connected to hex for town formation.
+// Mermaids can skip one river hex when founding towns; that hex is returned
+// as skippedRiver.
+func (gs *GameState) townComponent(player *Player, playerID string, hex board.Hex) (connected []board.Hex, skippedRiver *board.Hex) {
+	switch player.Faction.GetType() {
+	case models.FactionMermaids:
+		return gs.Map.GetConnectedBuildingsForMermaids(hex, playerID)
+	case models.FactionChildrenOfTheWyrm:
+		return gs.getConnectedBuildingsForPlayer(playerID, hex), nil
+	default:
+		return gs.Map.GetConnectedBuildingsIncludingBridges(hex, playerID), nil
+	}
+}
+
 func (gs *GameState) createPendingTown(playerID string, connected []board.Hex, skippedRiver *board.Hex, factionType models.FactionType) {
 	// Avoid duplicate pending formations for the same connected component.
 	// This can happen when a full-board recheck (e.g. after taking Fire+2) touches
@@ -176,10 +178,28 @@ func (gs *GameState) CanFormTown(playerID string, hexes []board.Hex) bool {
 	if player == nil {
 		return false
 	}
+	return gs.measureTown(player, playerID, hexes, 0).complete()
+}
+
+// townMeasure compares a group of connected buildings with the town
+// requirements.
+type townMeasure struct {
+	Buildings    int
+	Power        int
+	MinBuildings int
+	MinPower     int
+}
 
+func (t townMeasure) complete() bool {
+	return t.Buildings >= t.MinBuildings && t.Power >= t.MinPower
+}
+
+// measureTown measures hexes as a town, counting extraDwellings more
+// dwellings built into the group.
+func (gs *GameState) measureTown(player *Player, playerID string, hexes []board.Hex, extraDwellings int) townMeasure {
 	// Count buildings and calculate total power
-	buildingCount := 0
-	totalPower := 0
+	buildingCount := extraDwellings
+	totalPower := extraDwellings * getStructurePowerValue(player, models.BuildingDwelling)
 	hasSanctuary := false
 	hasStronghold := false
 	hexSet := make(map[board.Hex]bool, len(hexes))
@@ -189,11 +209,7 @@ func (gs *GameState) CanFormTown(playerID string, hexes []board.Hex) bool {
 		mapHex := gs.Map.GetHex(h)
 		if mapHex != nil && mapHex.Building != nil {
 			buildingCount++
-			if mapHex.Building.PowerValue > 0 {
-				totalPower += mapHex.Building.PowerValue
-			} else {
-				totalPower += GetPowerValue(mapHex.Building.Type)
-			}
+			totalPower += board.BuildingPowerValue(mapHex.Building)
 			if mapHex.Building.Type == models.BuildingSanctuary {
 				hasSanctuary = true
 			}
@@ -216,14 +232,13 @@ func (gs *GameState) CanFormTown(playerID string, hexes []board.Hex) bool {
 		minBuildings = 3
 	}
 
-	if buildingCount < minBuildings {
-		return false
+	return townMeasure{
+		Buildings:    buildingCount,
+		Power:        totalPower,
+		MinBuildings: minBuildings,
+		// 6 with Fire 2 favor tile, 7 otherwise
+		MinPower: gs.GetTownPowerRequirement(playerID),
 	}
-
-	// Check power requirement (6 with Fire 2 favor tile, 7 otherwise)
-	minPower := gs.GetTownPowerRequirement(playerID)
-
-	return totalPower >= minPower
 }
 
 func (gs *GameState) defaultTownAnchorHex(playerID string, pending *PendingTownFormation) *board.Hex {
@@ -245,33 +260,13 @@ func (gs *GameState) defaultTownAnchorHex(playerID string, pending *PendingTownF
 }
 
 func GetPowerValue(buildingType models.BuildingType) int {
-	switch buildingType {
-	case models.BuildingDwelling:
-		return 1
-	case models.BuildingTradingHouse:
-		return 2
-	case models.BuildingTemple:
-		return 2
-	case models.BuildingSanctuary:
-		return 3
-	case models.BuildingStronghold:
-		return 3
-	default:
-		return 0
-	}
+	return board.StandardPowerValue(buildingType)
 }
 
 // GetTownPowerRequirement returns the minimum power required for a town
 // Returns 6 if player has Fire 2 favor tile, 7 otherwise
 func (gs *GameState) GetTownPowerRequirement(playerID string) int {
-	// Check if player has Fire 2 favor tile
-	playerTiles := gs.FavorTiles.GetPlayerTiles(playerID)
-	for _, tile := range playerTiles {
-		if tile == FavorFire2 {
-			return 6
-		}
-	}
-	return 7
+	return gs.FavorModifiers(playerID).TownPowerRequirement()
 }
 
 func (gs *GameState) FormTown(playerID string, hexes []board.Hex, tileType models.TownTileType, skippedRiverHex *board.Hex) error {
@@ -287,7 +282,7 @@ func (gs *GameState) FormTown(playerID string, hexes []board.Hex, tileType model
 func (gs *GameState) FormTownWithAnchor(playerID string, hexes []board.Hex, tileType models.TownTileType, skippedRiverHex *board.Hex, anchorHex *board.Hex) error {
 	player := gs.GetPlayer(playerID)
 	if player == nil {
-		return fmt.Errorf("player not found: %s", playerID)
+		return ruleErrorf(ReasonPlayerNotFound, "player not found: %s", playerID)
 	}
 	// Check if tile is available
 	if !gs.TownTiles.IsAvailable(tileType) {
@@ -386,7 +381,7 @@ func (gs *GameState) applyTownTileSpecifics(player *Player, tileType models.Town
 	case models.TownTile6Points:
 		player.VictoryPoints += 6
 		if !skipResources {
-			player.Resources.Power.GainPower(8)
+			gs.GainPowerForReason(player.ID, 8, PowerGainTown, "")
 		}
 		player.Keys++
 
@@ -524,11 +519,7 @@ func (gs *GameState) updateAtlanteansStrongholdTown(playerID string) {
 			continue
 		}
 		mapHex.PartOfTown = true
-		if mapHex.Building.PowerValue > 0 {
-			totalPower += mapHex.Building.PowerValue
-		} else {
-			totalPower += GetPowerValue(mapHex.Building.Type)
-		}
+		totalPower += board.BuildingPowerValue(mapHex.Building)
 	}
 
 	if totalPower >= 7 && !player.AtlanteansTownRewards[7] {
@@ -103,17 +103,15 @@ func TestGetPowerLeechTargets(t *testing.T) {
 		PowerValue: 2,
 	})
 
-	// Place a temple (power value 2) at h
-	powerValue := 2
-	targets := m.GetPowerLeechTargets(h, faction1, powerValue)
+	// Each opponent leeches the power value of their own adjacent buildings
+	targets := m.GetPowerLeechTargets(h, faction1)
 
-	// Both opponents should be able to leech 2 power
 	if len(targets) != 2 {
 		t.Errorf("expected 2 leech targets, got %d", len(targets))
 	}
 
-	if targets[faction2] != 2 {
-		t.Errorf("expected faction2 to leech 2 power, got %d", targets[faction2])
+	if targets[faction2] != 1 {
+		t.Errorf("expected faction2 to leech 1 power, got %d", targets[faction2])
 	}
 
 	if targets[faction3] != 2 {
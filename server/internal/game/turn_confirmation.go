@@ -75,7 +75,12 @@ func (gs *GameState) UndoPendingTurn(playerID string) error {
 	}
 
 	restored := snapshot.CloneForUndo()
+	changes := gs.phaseChanges
+	if restored.Phase != gs.Phase {
+		changes = append(changes, PhaseChange{From: gs.Phase, To: restored.Phase, Round: restored.Round})
+	}
 	*gs = *restored
+	gs.phaseChanges = changes
 	gs.ClearPendingTurnConfirmation()
 	return nil
 }
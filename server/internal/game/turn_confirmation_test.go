@@ -133,7 +133,7 @@ func TestManagerTurnConfirmation_DisablingConfirmClearsPendingWindow(t *testing.
 	}
 
 	confirmActions := false
-	if _, err := mgr.ExecuteActionWithMeta("g1", NewSetPlayerOptionsAction("actor", nil, nil, &confirmActions, nil), ActionMeta{ExpectedRevision: 1}); err != nil {
+	if _, err := mgr.ExecuteActionWithMeta("g1", NewSetPlayerOptionsAction("actor", nil, nil, &confirmActions, nil, nil), ActionMeta{ExpectedRevision: 1}); err != nil {
 		t.Fatalf("disable confirm action: %v", err)
 	}
 
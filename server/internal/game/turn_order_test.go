@@ -1,6 +1,7 @@
 package game
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/lukev/tm_server/internal/game/factions"
@@ -203,3 +204,26 @@ func TestTurnOrder_AllPlayersPassed(t *testing.T) {
 		t.Error("expected AllPlayersPassed to be true with all players passed")
 	}
 }
+
+func TestNextRoundOrder_Policies(t *testing.T) {
+	turnOrder := []string{"p1", "p2", "p3", "p4"}
+	tests := []struct {
+		name      string
+		policy    TurnOrderPolicy
+		passOrder []string
+		want      []string
+	}{
+		{name: "pass order", policy: TurnOrderPolicyPassOrder, passOrder: []string{"p3", "p1", "p4", "p2"}, want: []string{"p3", "p1", "p4", "p2"}},
+		{name: "pass order mid-round", policy: TurnOrderPolicyPassOrder, passOrder: []string{"p3"}, want: []string{"p3", "p1", "p2", "p4"}},
+		{name: "cyclic from first passer", policy: TurnOrderPolicyCyclicFromFirstPasser, passOrder: []string{"p3", "p1", "p4", "p2"}, want: []string{"p3", "p4", "p1", "p2"}},
+		{name: "no passes", policy: TurnOrderPolicyCyclicFromFirstPasser, want: turnOrder},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextRoundOrder(tt.policy, turnOrder, tt.passOrder)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
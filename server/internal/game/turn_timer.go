@@ -15,6 +15,7 @@ func NewTurnTimerState(playerIDs []string, config TurnTimerConfig) *TurnTimerSta
 		Config: TurnTimerConfig{
 			InitialTimeMs: config.InitialTimeMs,
 			IncrementMs:   maxInt64(0, config.IncrementMs),
+			PerTurn:       config.PerTurn,
 		},
 		Players: make(map[string]*PlayerTurnTimer, len(playerIDs)),
 	}
@@ -62,7 +63,7 @@ func (tt *TurnTimerState) SyncActivePlayers(activePlayerIDs []string, now time.T
 			continue
 		}
 		timer := tt.Players[playerID]
-		if timer == nil {
+		if timer == nil || timer.Paused {
 			continue
 		}
 		nextActiveSet[playerID] = true
@@ -78,6 +79,9 @@ func (tt *TurnTimerState) SyncActivePlayers(activePlayerIDs []string, now time.T
 			continue
 		}
 		if nextActiveSet[playerID] {
+			if tt.Config.PerTurn && !previouslyActiveSet[playerID] {
+				timer.RemainingMs = tt.Config.InitialTimeMs
+			}
 			timer.ActiveSinceMs = nowMs
 			continue
 		}
@@ -85,91 +89,6 @@ func (tt *TurnTimerState) SyncActivePlayers(activePlayerIDs []string, now time.T
 	}
 }
 
-func activeDecisionPlayerIDs(gs *GameState) []string {
-	if gs == nil {
-		return nil
-	}
-
-	if gs.Phase == PhaseFactionSelection && gs.AuctionState != nil && gs.AuctionState.Active {
-		if gs.AuctionState.NominationPhase {
-			if playerID := strings.TrimSpace(gs.AuctionState.GetCurrentBidder()); playerID != "" {
-				return []string{playerID}
-			}
-			return nil
-		}
-		if gs.SetupMode == SetupModeFastAuction {
-			return gs.AuctionState.GetPendingFastSubmitters()
-		}
-		if playerID := strings.TrimSpace(gs.AuctionState.GetCurrentBidder()); playerID != "" {
-			return []string{playerID}
-		}
-		return nil
-	}
-
-	if gs.Phase == PhaseSetup && gs.SetupSubphase == SetupSubphaseBonusCards {
-		if playerID := strings.TrimSpace(gs.currentSetupBonusPlayerID()); playerID != "" {
-			return []string{playerID}
-		}
-	}
-
-	if gs.PendingTownCultTopChoice != nil {
-		return []string{gs.PendingTownCultTopChoice.PlayerID}
-	}
-	if townPlayer := strings.TrimSpace(gs.GetPendingTownSelectionPlayer()); townPlayer != "" {
-		return []string{townPlayer}
-	}
-	if gs.PendingDarklingsPriestOrdination != nil {
-		return []string{gs.PendingDarklingsPriestOrdination.PlayerID}
-	}
-	if gs.HasPendingLeechOffers() {
-		if playerID := strings.TrimSpace(gs.GetNextBlockingLeechResponder()); playerID != "" {
-			return []string{playerID}
-		}
-	}
-	if gs.PendingCultistsCultSelection != nil {
-		return []string{gs.PendingCultistsCultSelection.PlayerID}
-	}
-	if gs.PendingDjinniStartingCultChoice != nil {
-		return []string{gs.PendingDjinniStartingCultChoice.PlayerID}
-	}
-	if gs.PendingRiverwalkersPriestChoice != nil {
-		return []string{gs.PendingRiverwalkersPriestChoice.PlayerID}
-	}
-	if gs.PendingTreasurersDeposit != nil {
-		return []string{gs.PendingTreasurersDeposit.PlayerID}
-	}
-	if gs.PendingArchivistsBonusSelection != nil {
-		return []string{gs.PendingArchivistsBonusSelection.PlayerID}
-	}
-	if gs.PendingFavorTileSelection != nil {
-		return []string{gs.PendingFavorTileSelection.PlayerID}
-	}
-	if gs.PendingHalflingsSpades != nil {
-		return []string{gs.PendingHalflingsSpades.PlayerID}
-	}
-	if playerID, _ := gs.GetPendingSpadeFollowupPlayer(); strings.TrimSpace(playerID) != "" {
-		return []string{playerID}
-	}
-	if playerID, _ := gs.GetPendingCultRewardSpadePlayer(); strings.TrimSpace(playerID) != "" {
-		return []string{playerID}
-	}
-	if playerID := strings.TrimSpace(gs.PendingFreeActionsPlayerID); playerID != "" {
-		return []string{playerID}
-	}
-	if playerID := strings.TrimSpace(gs.PendingTurnConfirmationPlayerID); playerID != "" {
-		return []string{playerID}
-	}
-
-	switch gs.Phase {
-	case PhaseFactionSelection, PhaseSetup, PhaseAction:
-		if current := gs.GetCurrentPlayer(); current != nil && strings.TrimSpace(current.ID) != "" {
-			return []string{current.ID}
-		}
-	}
-
-	return nil
-}
-
 func serializeTurnTimer(tt *TurnTimerState, now time.Time) interface{} {
 	if tt == nil {
 		return nil
@@ -191,6 +110,7 @@ func serializeTurnTimer(tt *TurnTimerState, now time.Time) interface{} {
 		players[playerID] = map[string]interface{}{
 			"remainingMs": remainingMs,
 			"isActive":    isActive,
+			"paused":      timer.Paused,
 		}
 	}
 	slices.Sort(activePlayerIDs)
@@ -198,6 +118,7 @@ func serializeTurnTimer(tt *TurnTimerState, now time.Time) interface{} {
 	return map[string]interface{}{
 		"initialTimeMs":   tt.Config.InitialTimeMs,
 		"incrementMs":     tt.Config.IncrementMs,
+		"perTurn":         tt.Config.PerTurn,
 		"serverNowMs":     nowMs,
 		"activePlayerIds": activePlayerIDs,
 		"players":         players,
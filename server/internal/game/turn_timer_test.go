@@ -179,7 +179,7 @@ func TestTurnTimer_CultSpadeRoundStartClearsStaleTurnConfirmationWindows(t *test
 		IncrementMs:   5_000,
 	})
 
-	gs.ResetRoundState()
+	RoundReset(gs)
 	gs.StartNewRound()
 	gs.PendingCultRewardSpades = map[string]int{"giants": 1}
 
@@ -230,3 +230,22 @@ func TestTurnTimer_CultSpadeRoundStartClearsStaleTurnConfirmationWindows(t *test
 		t.Fatalf("dwarves should be able to act after cult spades resolve: %v", err)
 	}
 }
+
+func TestTurnTimer_PerTurnResetsWhenPlayerBecomesActive(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	tt := NewTurnTimerState([]string{"p1", "p2"}, TurnTimerConfig{InitialTimeMs: 30_000, PerTurn: true})
+
+	tt.SyncActivePlayers([]string{"p1"}, now)
+	now = now.Add(20 * time.Second)
+	tt.SyncActivePlayers([]string{"p1"}, now)
+	if remaining := tt.Players["p1"].RemainingMs; remaining != 10_000 {
+		t.Fatalf("expected the clock to keep running within a turn, got %d", remaining)
+	}
+
+	tt.SyncActivePlayers([]string{"p2"}, now)
+	now = now.Add(5 * time.Second)
+	tt.SyncActivePlayers([]string{"p1"}, now)
+	if remaining := tt.Players["p1"].RemainingMs; remaining != 30_000 {
+		t.Fatalf("expected a fresh 30s turn, got %d", remaining)
+	}
+}
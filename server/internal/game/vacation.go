@@ -0,0 +1,359 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+var (
+	// ErrVacationsDisabled is returned when the server has no VacationPolicy.
+	ErrVacationsDisabled = errors.New("vacations are not enabled")
+	// ErrVacationBudgetExhausted is returned when a player has no vacation
+	// time left this year.
+	ErrVacationBudgetExhausted = errors.New("vacation budget exhausted")
+	// ErrVacationNotSaved is returned with the new status when a vacation
+	// took effect but could not be written to the policy's Store.
+	ErrVacationNotSaved = errors.New("vacation not saved")
+)
+
+// VacationPolicy lets players pause their turn timers across all their games.
+type VacationPolicy struct {
+	// AnnualBudget is the vacation time each player may take per calendar
+	// year (UTC). A vacation ends by itself once the budget is used up; see
+	// SweepVacations.
+	AnnualBudget time.Duration
+	// Store, when set, keeps the vacation records across restarts. Without
+	// it they live in memory only.
+	Store VacationStore
+}
+
+// VacationStore keeps every player's VacationRecord.
+type VacationStore interface {
+	// Load returns the saved records, or none if nothing was saved yet.
+	Load() (map[string]VacationRecord, error)
+	// Save replaces the saved records with records.
+	Save(records map[string]VacationRecord) error
+}
+
+// VacationStatus is a player's vacation state as reported to clients.
+type VacationStatus struct {
+	OnVacation  bool  `json:"onVacation"`
+	SinceMs     int64 `json:"sinceMs,omitempty"`
+	RemainingMs int64 `json:"remainingMs"`
+}
+
+// VacationRecord is the vacation time a player took in Year.
+type VacationRecord struct {
+	Since time.Time     `json:"since,omitzero"` // zero unless on vacation
+	Year  int           `json:"year"`
+	Used  time.Duration `json:"used"`
+}
+
+// SetVacationPolicy enables vacations with policy, or disables them if nil.
+// The records saved in the policy's Store replace those in memory.
+func (m *Manager) SetVacationPolicy(policy *VacationPolicy) error {
+	var loaded map[string]VacationRecord
+	if policy != nil && policy.Store != nil {
+		var err error
+		if loaded, err = policy.Store.Load(); err != nil {
+			return fmt.Errorf("failed to load vacations: %w", err)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.vacationPolicy = policy
+	if loaded != nil {
+		m.vacations = make(map[string]*VacationRecord, len(loaded))
+		for playerID, record := range loaded {
+			m.vacations[playerID] = &record
+		}
+		now := m.now()
+		for playerID := range m.vacations {
+			m.syncPlayerGamesLocked(playerID, now)
+		}
+	}
+	return nil
+}
+
+// SetVacation starts or ends playerID's vacation. While on vacation the
+// player's clock is paused in every game with a turn timer. It returns the
+// player's new status and the IDs of the games they are seated in, sorted.
+func (m *Manager) SetVacation(playerID string, onVacation bool) (VacationStatus, []string, error) {
+	status, gameIDs, err := m.setVacation(playerID, onVacation)
+	if err != nil {
+		return VacationStatus{}, nil, err
+	}
+	return status, gameIDs, m.saveVacations()
+}
+
+func (m *Manager) setVacation(playerID string, onVacation bool) (VacationStatus, []string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.vacationPolicy == nil {
+		return VacationStatus{}, nil, ErrVacationsDisabled
+	}
+	now := m.now()
+	record := m.vacationRecordLocked(playerID, now)
+	switch {
+	case onVacation && record.Since.IsZero():
+		if m.vacationPolicy.AnnualBudget-record.Used <= 0 {
+			return VacationStatus{}, nil, ErrVacationBudgetExhausted
+		}
+		record.Since = now
+	case !onVacation && !record.Since.IsZero():
+		record.Used += now.Sub(record.Since)
+		record.Since = time.Time{}
+	}
+	return m.vacationStatusLocked(playerID, now), m.syncPlayerGamesLocked(playerID, now), nil
+}
+
+// VacationStatus returns playerID's vacation state.
+func (m *Manager) VacationStatus(playerID string) VacationStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.vacationStatusLocked(playerID, m.now())
+}
+
+// OnVacation reports whether playerID is on vacation.
+func (m *Manager) OnVacation(playerID string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.onVacationLocked(playerID)
+}
+
+// SweepVacations ends the vacations that used up their annual budget. It
+// returns those players and the IDs of the games they are seated in, both
+// sorted.
+func (m *Manager) SweepVacations() ([]string, []string, error) {
+	ended, gameIDs := m.sweepVacations()
+	if len(ended) == 0 {
+		return nil, nil, nil
+	}
+	return ended, gameIDs, m.saveVacations()
+}
+
+func (m *Manager) sweepVacations() ([]string, []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.vacationPolicy == nil {
+		return nil, nil
+	}
+	now := m.now()
+	var ended []string
+	affected := make(map[string]bool)
+	for playerID, record := range m.vacations {
+		if record.Since.IsZero() || m.vacationStatusLocked(playerID, now).RemainingMs > 0 {
+			continue
+		}
+		record = m.vacationRecordLocked(playerID, now)
+		record.Used += now.Sub(record.Since)
+		record.Since = time.Time{}
+		for _, gameID := range m.syncPlayerGamesLocked(playerID, now) {
+			affected[gameID] = true
+		}
+		ended = append(ended, playerID)
+	}
+	gameIDs := make([]string, 0, len(affected))
+	for gameID := range affected {
+		gameIDs = append(gameIDs, gameID)
+	}
+	sort.Strings(ended)
+	sort.Strings(gameIDs)
+	return ended, gameIDs
+}
+
+// saveVacations writes a snapshot of the vacation records to the policy's
+// Store, outside the manager's lock. Saves run one at a time and each takes
+// its snapshot once it may run, so the last save holds the latest records.
+func (m *Manager) saveVacations() error {
+	m.vacationSaveMu.Lock()
+	defer m.vacationSaveMu.Unlock()
+
+	m.mu.RLock()
+	var store VacationStore
+	if m.vacationPolicy != nil {
+		store = m.vacationPolicy.Store
+	}
+	records := make(map[string]VacationRecord, len(m.vacations))
+	for playerID, record := range m.vacations {
+		records[playerID] = *record
+	}
+	m.mu.RUnlock()
+
+	if store == nil {
+		return nil
+	}
+	if err := store.Save(records); err != nil {
+		return fmt.Errorf("%w: %w", ErrVacationNotSaved, err)
+	}
+	return nil
+}
+
+// vacationRecordLocked returns playerID's record, starting a fresh budget at
+// the turn of the year.
+func (m *Manager) vacationRecordLocked(playerID string, now time.Time) *VacationRecord {
+	record := m.vacations[playerID]
+	if record == nil {
+		record = &VacationRecord{Year: now.UTC().Year()}
+		m.vacations[playerID] = record
+	}
+	if year := now.UTC().Year(); record.Year != year {
+		if !record.Since.IsZero() {
+			record.Since = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		}
+		record.Year = year
+		record.Used = 0
+	}
+	return record
+}
+
+func (m *Manager) vacationStatusLocked(playerID string, now time.Time) VacationStatus {
+	if m.vacationPolicy == nil {
+		return VacationStatus{}
+	}
+	status := VacationStatus{RemainingMs: m.vacationPolicy.AnnualBudget.Milliseconds()}
+	record := m.vacations[playerID]
+	if record == nil {
+		return status
+	}
+	if !record.Since.IsZero() {
+		status.OnVacation = true
+		status.SinceMs = record.Since.UnixMilli()
+	}
+	status.RemainingMs = maxInt64(0, (m.vacationPolicy.AnnualBudget - record.usedAt(now)).Milliseconds())
+	return status
+}
+
+// usedAt returns the vacation time taken in the calendar year of now,
+// including the running vacation.
+func (r *VacationRecord) usedAt(now time.Time) time.Duration {
+	year := now.UTC().Year()
+	used, since := r.Used, r.Since
+	if r.Year != year {
+		used = 0
+		if !since.IsZero() {
+			since = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		}
+	}
+	if !since.IsZero() {
+		used += now.Sub(since)
+	}
+	return used
+}
+
+func (m *Manager) onVacationLocked(playerID string) bool {
+	record := m.vacations[playerID]
+	return m.vacationPolicy != nil && record != nil && !record.Since.IsZero()
+}
+
+// vacationPlayersLocked lists the players of gs on vacation, sorted.
+func (m *Manager) vacationPlayersLocked(gs *GameState) []string {
+	players := []string{}
+	for playerID := range gs.Players {
+		if m.onVacationLocked(playerID) {
+			players = append(players, playerID)
+		}
+	}
+	sort.Strings(players)
+	return players
+}
+
+// syncPlayerGamesLocked re-syncs the turn timers of every game playerID is
+// seated in and returns those game IDs, sorted.
+func (m *Manager) syncPlayerGamesLocked(playerID string, now time.Time) []string {
+	var gameIDs []string
+	for id, gs := range m.games {
+		if gs == nil || gs.GetPlayer(playerID) == nil {
+			continue
+		}
+		m.syncTurnTimerLocked(gs, now)
+		gameIDs = append(gameIDs, id)
+	}
+	sort.Strings(gameIDs)
+	return gameIDs
+}
+
+// syncTurnTimerLocked pauses the clocks of players on vacation and runs the
+// clocks of the players gs is waiting on.
+func (m *Manager) syncTurnTimerLocked(gs *GameState, now time.Time) {
+	if gs == nil || gs.TurnTimer == nil {
+		return
+	}
+	gs.TurnTimer.ChargeActivePlayers(now)
+	for playerID, timer := range gs.TurnTimer.Players {
+		if timer == nil {
+			continue
+		}
+		timer.Paused = m.onVacationLocked(playerID)
+		if timer.Paused {
+			timer.ActiveSinceMs = 0
+		}
+	}
+	gs.TurnTimer.SyncActivePlayers(gs.Phases().ActivePlayers(), now)
+}
+
+// FileVacationStore is a VacationStore keeping the records in one JSON file.
+type FileVacationStore struct {
+	path string
+}
+
+// NewFileVacationStore returns a FileVacationStore writing path. Its
+// directory is created on the first Save.
+func NewFileVacationStore(path string) *FileVacationStore {
+	return &FileVacationStore{path: path}
+}
+
+// Load reads the saved records, returning none if the file does not exist.
+func (s *FileVacationStore) Load() (map[string]VacationRecord, error) {
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]VacationRecord{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	records := map[string]VacationRecord{}
+	if err := json.Unmarshal(raw, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", s.path, err)
+	}
+	return records, nil
+}
+
+// Save replaces the file with records.
+func (s *FileVacationStore) Save(records map[string]VacationRecord) error {
+	raw, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return replaceFile(s.path, ".vacations-*", raw)
+}
+
+// replaceFile writes raw to path through a temporary file named after
+// pattern, so readers never see a partial file.
+func replaceFile(path, pattern string, raw []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
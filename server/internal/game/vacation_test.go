@@ -0,0 +1,107 @@
+package game
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukev/tm_server/internal/game/factions"
+)
+
+func newVacationTestManager(t *testing.T, now *time.Time) (*Manager, *GameState) {
+	t.Helper()
+	mgr := NewManager()
+	mgr.now = func() time.Time { return *now }
+	mgr.SetVacationPolicy(&VacationPolicy{AnnualBudget: time.Hour})
+
+	gs := newActionTestGame(t, 1, testSeat{"away", factions.NewCultists()}, testSeat{"home", factions.NewWitches()})
+	gs.TurnTimer = NewTurnTimerState(gs.TurnOrder, TurnTimerConfig{InitialTimeMs: 60_000})
+	mgr.CreateGameWithState("g1", gs)
+	return mgr, gs
+}
+
+func TestVacation_PausesClockAcrossGames(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	mgr, gs := newVacationTestManager(t, &now)
+	timer := gs.TurnTimer.Players["away"]
+
+	now = now.Add(10 * time.Second)
+	status, gameIDs, err := mgr.SetVacation("away", true)
+	if err != nil {
+		t.Fatalf("start vacation: %v", err)
+	}
+	if !status.OnVacation || len(gameIDs) != 1 || gameIDs[0] != "g1" {
+		t.Fatalf("status = %+v games = %v, want on vacation in g1", status, gameIDs)
+	}
+	if timer.RemainingMs != 50_000 || timer.ActiveSinceMs != 0 || !timer.Paused {
+		t.Fatalf("timer on vacation = %+v, want paused at 50000ms", timer)
+	}
+	if state := mgr.SerializeGameState("g1"); len(state["vacationPlayerIds"].([]interface{})) != 1 {
+		t.Fatalf("vacationPlayerIds = %v, want [away]", state["vacationPlayerIds"])
+	}
+
+	now = now.Add(20 * time.Minute)
+	if status, _, err = mgr.SetVacation("away", false); err != nil {
+		t.Fatalf("end vacation: %v", err)
+	}
+	if status.OnVacation || status.RemainingMs != (40*time.Minute).Milliseconds() {
+		t.Fatalf("status after vacation = %+v, want 40m left", status)
+	}
+	if timer.RemainingMs != 50_000 || timer.ActiveSinceMs != now.UnixMilli() || timer.Paused {
+		t.Fatalf("timer after vacation = %+v, want running from 50000ms", timer)
+	}
+}
+
+func TestVacation_BudgetEndsVacationAndResetsYearly(t *testing.T) {
+	now := time.Date(2026, time.December, 31, 10, 0, 0, 0, time.UTC)
+	mgr, _ := newVacationTestManager(t, &now)
+
+	if _, _, err := mgr.SetVacation("away", true); err != nil {
+		t.Fatalf("start vacation: %v", err)
+	}
+	now = now.Add(59 * time.Minute)
+	if ended, _, err := mgr.SweepVacations(); err != nil || len(ended) != 0 {
+		t.Fatalf("swept %v with budget left (err %v)", ended, err)
+	}
+	now = now.Add(time.Minute)
+	if ended, gameIDs, err := mgr.SweepVacations(); err != nil || len(ended) != 1 || ended[0] != "away" || len(gameIDs) != 1 || gameIDs[0] != "g1" {
+		t.Fatalf("swept %v in games %v, want [away] in [g1] (err %v)", ended, gameIDs, err)
+	}
+	if _, _, err := mgr.SetVacation("away", true); !errors.Is(err, ErrVacationBudgetExhausted) {
+		t.Fatalf("restart with no budget: %v, want ErrVacationBudgetExhausted", err)
+	}
+
+	now = time.Date(2027, time.January, 1, 0, 0, 1, 0, time.UTC)
+	if status := mgr.VacationStatus("away"); status.RemainingMs != time.Hour.Milliseconds() {
+		t.Fatalf("remaining in new year = %d, want full budget", status.RemainingMs)
+	}
+	if _, _, err := mgr.SetVacation("away", true); err != nil {
+		t.Fatalf("start vacation in new year: %v", err)
+	}
+}
+
+func TestVacation_StoreKeepsRecordsAcrossManagers(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	store := NewFileVacationStore(filepath.Join(t.TempDir(), "vacations", "vacations.json"))
+	mgr, _ := newVacationTestManager(t, &now)
+	if err := mgr.SetVacationPolicy(&VacationPolicy{AnnualBudget: time.Hour, Store: store}); err != nil {
+		t.Fatalf("set policy: %v", err)
+	}
+	if _, _, err := mgr.SetVacation("away", true); err != nil {
+		t.Fatalf("start vacation: %v", err)
+	}
+	now = now.Add(10 * time.Minute)
+
+	restarted, gs := newVacationTestManager(t, &now)
+	if err := restarted.SetVacationPolicy(&VacationPolicy{AnnualBudget: time.Hour, Store: store}); err != nil {
+		t.Fatalf("set policy after restart: %v", err)
+	}
+	status := restarted.VacationStatus("away")
+	if !status.OnVacation || status.RemainingMs != (50*time.Minute).Milliseconds() {
+		t.Fatalf("status after restart = %+v, want on vacation with 50m left", status)
+	}
+	if !gs.TurnTimer.Players["away"].Paused {
+		t.Fatalf("expected the restored vacation to pause the clock")
+	}
+}
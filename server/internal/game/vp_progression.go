@@ -0,0 +1,186 @@
+package game
+
+import "sort"
+
+// VPSource names where a player's VP came from in a VPProgression.
+type VPSource string
+
+const (
+	// VPSourceStart is the VP a player begins the game with.
+	VPSourceStart VPSource = "start"
+	// VPSourceActions covers turns other than passing, leeching and towns,
+	// including round scoring tile and favor tile VP earned on those turns.
+	VPSourceActions  VPSource = "actions"
+	VPSourcePass     VPSource = "pass"
+	VPSourceLeech    VPSource = "leech"
+	VPSourceTowns    VPSource = "towns"
+	VPSourceRoundEnd VPSource = "round_end"
+
+	VPSourceFinalArea      VPSource = "final_area"
+	VPSourceFinalFireIce   VPSource = "final_fire_ice"
+	VPSourceFinalCult      VPSource = "final_cult"
+	VPSourceFinalResources VPSource = "final_resources"
+)
+
+// VPProgression is the score history of a game for progression charts. The
+// sources of a player always add up to their current VP.
+type VPProgression struct {
+	// Rounds holds each player's VP at the end of every round reached so
+	// far, the current round last. Final scoring is not included.
+	Rounds []VPRoundPoints `json:"rounds"`
+	// Sources maps player IDs to the VP they gained or lost per source.
+	Sources map[string]map[VPSource]int `json:"sources"`
+	// Final is each player's total after final scoring, once the game ended.
+	Final map[string]int `json:"final,omitempty"`
+}
+
+// VPRoundPoints is the VP of every player at the end of a round.
+type VPRoundPoints struct {
+	Round int            `json:"round"`
+	VP    map[string]int `json:"vp"`
+}
+
+// VPSourceForAction classifies the VP an action changes.
+func VPSourceForAction(action Action) VPSource {
+	if action == nil {
+		return VPSourceRoundEnd
+	}
+	switch action.GetType() {
+	case ActionPass:
+		return VPSourcePass
+	case ActionAcceptPowerLeech, ActionDeclinePowerLeech:
+		return VPSourceLeech
+	case ActionSelectTownTile, ActionSelectTownCultTop:
+		return VPSourceTowns
+	default:
+		return VPSourceActions
+	}
+}
+
+// VPProgressionRecorder builds a VPProgression while a game is stepped
+// through. Call Observe after every step with the source of that step.
+type VPProgressionRecorder struct {
+	progression VPProgression
+	last        map[string]int
+	round       int
+	finalized   bool
+}
+
+// NewVPProgressionRecorder starts recording from gs, crediting every player's
+// current VP to VPSourceStart.
+func NewVPProgressionRecorder(gs *GameState) *VPProgressionRecorder {
+	r := &VPProgressionRecorder{
+		progression: VPProgression{Rounds: []VPRoundPoints{}, Sources: make(map[string]map[VPSource]int)},
+		last:        make(map[string]int),
+	}
+	r.Observe(gs, VPSourceStart)
+	return r
+}
+
+// Observe credits every VP change since the previous observation to source.
+// The step that reaches final scoring has the final scoring parts split off
+// into their own sources.
+func (r *VPProgressionRecorder) Observe(gs *GameState, source VPSource) {
+	if gs == nil {
+		return
+	}
+	final := gs.FinalScoring != nil && !r.finalized
+	for playerID, player := range gs.Players {
+		if player == nil {
+			continue
+		}
+		sources := r.progression.Sources[playerID]
+		if sources == nil {
+			sources = make(map[VPSource]int)
+			r.progression.Sources[playerID] = sources
+		}
+		delta := player.VictoryPoints - r.last[playerID]
+		if score := gs.FinalScoring[playerID]; final && score != nil {
+			parts := map[VPSource]int{
+				VPSourceFinalArea:      score.AreaVP,
+				VPSourceFinalFireIce:   score.FireIceVP,
+				VPSourceFinalCult:      score.CultVP,
+				VPSourceFinalResources: score.ResourceVP,
+			}
+			for partSource, vp := range parts {
+				sources[partSource] += vp
+				delta -= vp
+			}
+		}
+		if delta != 0 || source == VPSourceStart {
+			sources[source] += delta
+		}
+		r.last[playerID] = player.VictoryPoints
+	}
+
+	if final {
+		r.finalized = true
+		r.progression.Final = copyVP(r.last)
+		return
+	}
+	if r.finalized || gs.Round < 1 {
+		return
+	}
+	if gs.Round != r.round && r.round >= 1 {
+		// Whatever the closing step scored, such as pass VP, belongs to the
+		// round it closed.
+		r.setRound(r.round)
+	}
+	r.round = gs.Round
+	r.setRound(gs.Round)
+}
+
+func (r *VPProgressionRecorder) setRound(round int) {
+	vp := copyVP(r.last)
+	for i := range r.progression.Rounds {
+		if r.progression.Rounds[i].Round == round {
+			r.progression.Rounds[i].VP = vp
+			return
+		}
+	}
+	r.progression.Rounds = append(r.progression.Rounds, VPRoundPoints{Round: round, VP: vp})
+	sort.Slice(r.progression.Rounds, func(i, j int) bool {
+		return r.progression.Rounds[i].Round < r.progression.Rounds[j].Round
+	})
+}
+
+// Progression returns the progression recorded so far.
+func (r *VPProgressionRecorder) Progression() *VPProgression {
+	return &r.progression
+}
+
+func copyVP(vp map[string]int) map[string]int {
+	out := make(map[string]int, len(vp))
+	for playerID, points := range vp {
+		out[playerID] = points
+	}
+	return out
+}
+
+// VPProgression replays the recorded actions of gameID and returns its score
+// history up to the current position.
+func (m *Manager) VPProgression(gameID string, buildAction RecordedActionBuilder) (*VPProgression, error) {
+	save, err := m.ExportGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	var recorder *VPProgressionRecorder
+	pending := VPSourceStart
+	imported, err := ReplaySaveFileObserved(save, buildAction, func(gs *GameState, action Action) {
+		if recorder == nil {
+			recorder = NewVPProgressionRecorder(gs)
+		} else {
+			recorder.Observe(gs, pending)
+		}
+		pending = VPSourceForAction(action)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if recorder == nil {
+		recorder = NewVPProgressionRecorder(imported.State)
+	} else {
+		recorder.Observe(imported.State, pending)
+	}
+	return recorder.Progression(), nil
+}
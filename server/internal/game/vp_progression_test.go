@@ -0,0 +1,131 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game/factions"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+func TestVPProgressionRecorder_TracksRoundsSourcesAndFinalScoring(t *testing.T) {
+	gs := NewGameState()
+	if err := gs.AddPlayer("p1", factions.NewWitches()); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+	if err := gs.AddPlayer("p2", factions.NewNomads()); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+	p1, p2 := gs.GetPlayer("p1"), gs.GetPlayer("p2")
+	p1.VictoryPoints, p2.VictoryPoints = 20, 20
+
+	recorder := NewVPProgressionRecorder(gs)
+	gs.Round = 1
+	p1.VictoryPoints += 5
+	recorder.Observe(gs, VPSourceActions)
+	p2.VictoryPoints--
+	recorder.Observe(gs, VPSourceLeech)
+
+	// The last pass closes round 1 and starts round 2.
+	p2.VictoryPoints += 3
+	gs.Round = 2
+	recorder.Observe(gs, VPSourcePass)
+	p1.VictoryPoints += 7
+	recorder.Observe(gs, VPSourceTowns)
+
+	gs.FinalScoring = map[string]*PlayerFinalScore{
+		"p1": {AreaVP: 18, CultVP: 8},
+		"p2": {AreaVP: 12, ResourceVP: 2},
+	}
+	p1.VictoryPoints += 2 + 18 + 8
+	p2.VictoryPoints += 12 + 2
+	recorder.Observe(gs, VPSourcePass)
+
+	progression := recorder.Progression()
+	if len(progression.Rounds) != 2 {
+		t.Fatalf("expected two rounds, got %+v", progression.Rounds)
+	}
+	if round1 := progression.Rounds[0]; round1.Round != 1 || round1.VP["p1"] != 25 || round1.VP["p2"] != 22 {
+		t.Fatalf("unexpected round 1 points %+v", round1)
+	}
+	if round2 := progression.Rounds[1]; round2.Round != 2 || round2.VP["p1"] != 32 || round2.VP["p2"] != 22 {
+		t.Fatalf("unexpected round 2 points %+v", round2)
+	}
+	if progression.Final["p1"] != 60 || progression.Final["p2"] != 36 {
+		t.Fatalf("unexpected final totals %+v", progression.Final)
+	}
+
+	want := map[string]map[VPSource]int{
+		"p1": {VPSourceStart: 20, VPSourceActions: 5, VPSourceTowns: 7, VPSourcePass: 2, VPSourceFinalArea: 18, VPSourceFinalCult: 8},
+		"p2": {VPSourceStart: 20, VPSourceLeech: -1, VPSourcePass: 3, VPSourceFinalArea: 12, VPSourceFinalResources: 2},
+	}
+	for playerID, sources := range want {
+		total := 0
+		for source, vp := range progression.Sources[playerID] {
+			total += vp
+			if vp != sources[source] {
+				t.Fatalf("%s %s: got %d, want %d", playerID, source, vp, sources[source])
+			}
+		}
+		if total != progression.Final[playerID] {
+			t.Fatalf("%s sources add up to %d, want %d", playerID, total, progression.Final[playerID])
+		}
+	}
+}
+
+func TestVPSourceForAction(t *testing.T) {
+	cases := map[VPSource]Action{
+		VPSourcePass:     NewPassAction("p1", nil),
+		VPSourceLeech:    &AcceptPowerLeechAction{BaseAction: BaseAction{Type: ActionAcceptPowerLeech, PlayerID: "p1"}},
+		VPSourceTowns:    &SelectTownTileAction{BaseAction: BaseAction{Type: ActionSelectTownTile, PlayerID: "p1"}},
+		VPSourceActions:  &SendPriestToCultAction{BaseAction: BaseAction{Type: ActionSendPriestToCult, PlayerID: "p1"}},
+		VPSourceRoundEnd: nil,
+	}
+	for want, action := range cases {
+		if got := VPSourceForAction(action); got != want {
+			t.Fatalf("%T: got %s, want %s", action, got, want)
+		}
+	}
+}
+
+func TestManager_VPProgression_ReplaysRecordedActions(t *testing.T) {
+	mgr := NewManager()
+	if err := mgr.CreateGameWithOptions("g1", []string{"p1", "p2"}, CreateGameOptions{}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	buildAction := func(recorded RecordedAction) (Action, error) {
+		var params struct {
+			Faction string `json:"faction"`
+		}
+		if err := json.Unmarshal(recorded.Params, &params); err != nil {
+			return nil, err
+		}
+		return &SelectFactionAction{PlayerID: recorded.PlayerID, FactionType: models.FactionTypeFromString(params.Faction)}, nil
+	}
+	for _, pick := range []struct{ playerID, faction string }{{"p1", "Witches"}, {"p2", "Nomads"}} {
+		playerID := pick.playerID
+		record := RecordedAction{PlayerID: playerID, Type: "select_faction", Params: []byte(`{"faction":"` + pick.faction + `"}`)}
+		action, _ := buildAction(record)
+		if _, err := mgr.ExecuteActionWithMeta("g1", action, ActionMeta{ExpectedRevision: -1, SeatID: playerID, Record: &record}); err != nil {
+			t.Fatalf("select faction: %v", err)
+		}
+	}
+
+	progression, err := mgr.VPProgression("g1", buildAction)
+	if err != nil {
+		t.Fatalf("VPProgression: %v", err)
+	}
+	gs, _ := mgr.GetGame("g1")
+	for playerID, player := range gs.Players {
+		total := 0
+		for _, vp := range progression.Sources[playerID] {
+			total += vp
+		}
+		if total != player.VictoryPoints {
+			t.Fatalf("%s: sources add up to %d, want %d", playerID, total, player.VictoryPoints)
+		}
+	}
+	if progression.Final != nil {
+		t.Fatalf("expected no final totals before the game ends, got %+v", progression.Final)
+	}
+}
@@ -0,0 +1,200 @@
+// Package i18n renders the user-facing strings the server sends to clients
+// from message codes. Codes are namespaced by what they describe:
+//
+//	error.<code>      websocket error codes such as error.game_full
+//	reason.<code>     game.ReasonCode values such as reason.INSUFFICIENT_COINS
+//	decision.<type>   pending decision prompts such as decision.leech_offer
+//	event.<type>      event descriptions such as event.scoring_step.area
+//
+// Messages may reference parameters as {name}. English is built in; other
+// locales are registered at startup.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the locale every lookup falls back to.
+const DefaultLocale = "en"
+
+// Catalog maps locales to message templates keyed by code.
+type Catalog struct {
+	mu       sync.RWMutex
+	messages map[string]map[string]string
+}
+
+// Default is the catalog the server uses unless it is given another one.
+var Default = NewCatalog()
+
+// NewCatalog returns a catalog holding the built-in English messages.
+func NewCatalog() *Catalog {
+	c := &Catalog{messages: make(map[string]map[string]string)}
+	c.Register(DefaultLocale, englishMessages)
+	return c
+}
+
+// Register adds messages for locale, replacing existing messages with the
+// same code.
+func (c *Catalog) Register(locale string, messages map[string]string) {
+	locale = NormalizeLocale(locale)
+	if locale == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	existing := c.messages[locale]
+	if existing == nil {
+		existing = make(map[string]string, len(messages))
+		c.messages[locale] = existing
+	}
+	for code, text := range messages {
+		existing[code] = text
+	}
+}
+
+// LoadDirectory registers every <locale>.json file in dir. Each file is a flat
+// JSON object of code to message. Files are validated before any is applied.
+func (c *Catalog) LoadDirectory(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	loaded := make(map[string]map[string]string, len(paths))
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(raw, &messages); err != nil {
+			return fmt.Errorf("%s: invalid message catalog: %w", path, err)
+		}
+		locale := NormalizeLocale(strings.TrimSuffix(filepath.Base(path), ".json"))
+		if locale == "" {
+			return fmt.Errorf("%s: file name is not a locale", path)
+		}
+		for code := range messages {
+			if _, known := englishMessages[code]; !known {
+				return fmt.Errorf("%s: unknown message code %q", path, code)
+			}
+		}
+		loaded[locale] = messages
+	}
+	for locale, messages := range loaded {
+		c.Register(locale, messages)
+	}
+	return nil
+}
+
+// Locales lists the registered locales in sorted order.
+func (c *Catalog) Locales() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	locales := make([]string, 0, len(c.messages))
+	for locale := range c.messages {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Message renders code in locale, falling back to the locale's base language,
+// then English, then the code itself.
+func (c *Catalog) Message(locale, code string, params map[string]any) string {
+	c.mu.RLock()
+	text, ok := c.lookup(locale, code)
+	c.mu.RUnlock()
+	if !ok {
+		return code
+	}
+	return format(text, params)
+}
+
+// Messages returns every code rendered as in Message, without parameters
+// filled in, so clients can render codes themselves.
+func (c *Catalog) Messages(locale string) map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make(map[string]string, len(c.messages[DefaultLocale]))
+	for code := range c.messages[DefaultLocale] {
+		out[code], _ = c.lookup(locale, code)
+	}
+	return out
+}
+
+// Negotiate picks the registered locale best matching an explicit choice, or
+// failing that an Accept-Language header. It returns DefaultLocale if nothing
+// matches.
+func (c *Catalog) Negotiate(explicit, acceptLanguage string) string {
+	candidates := []string{explicit}
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, _, _ := strings.Cut(part, ";")
+		candidates = append(candidates, tag)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, candidate := range candidates {
+		locale := NormalizeLocale(candidate)
+		if locale == "" {
+			continue
+		}
+		if _, ok := c.messages[locale]; ok {
+			return locale
+		}
+		if base := baseLanguage(locale); base != locale {
+			if _, ok := c.messages[base]; ok {
+				return base
+			}
+		}
+	}
+	return DefaultLocale
+}
+
+func (c *Catalog) lookup(locale, code string) (string, bool) {
+	locale = NormalizeLocale(locale)
+	for _, candidate := range []string{locale, baseLanguage(locale), DefaultLocale} {
+		if text, ok := c.messages[candidate][code]; ok {
+			return text, true
+		}
+	}
+	return "", false
+}
+
+// NormalizeLocale lower-cases a language tag and uses "-" as its separator,
+// so "pt_BR" and "pt-br" name the same locale. It returns "" for "*" and
+// for tags that are not letters, digits and separators.
+func NormalizeLocale(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(tag, "_", "-")))
+	if tag == "" || tag == "*" {
+		return ""
+	}
+	for _, r := range tag {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return ""
+		}
+	}
+	return tag
+}
+
+func baseLanguage(locale string) string {
+	base, _, _ := strings.Cut(locale, "-")
+	return base
+}
+
+func format(text string, params map[string]any) string {
+	if len(params) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+	replacements := make([]string, 0, 2*len(params))
+	for name, value := range params {
+		replacements = append(replacements, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(text)
+}
@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCatalog_MessageFallsBackToBaseLanguageThenEnglish(t *testing.T) {
+	c := NewCatalog()
+	c.Register("de", map[string]string{
+		"reason.INSUFFICIENT_COINS": "Nicht genug Münzen.",
+		"error.revision_mismatch":   "Das Spiel hat sich geändert (Revision {currentRevision}).",
+	})
+
+	if got := c.Message("de-AT", "reason.INSUFFICIENT_COINS", nil); got != "Nicht genug Münzen." {
+		t.Fatalf("expected base language fallback, got %q", got)
+	}
+	if got := c.Message("de", "reason.NOT_YOUR_TURN", nil); got != "It is not your turn." {
+		t.Fatalf("expected English fallback, got %q", got)
+	}
+	if got := c.Message("de", "error.revision_mismatch", map[string]any{"currentRevision": 7}); got != "Das Spiel hat sich geändert (Revision 7)." {
+		t.Fatalf("expected parameter substitution, got %q", got)
+	}
+	if got := c.Message("fr", "error.not_a_code", nil); got != "error.not_a_code" {
+		t.Fatalf("expected unknown code to render as itself, got %q", got)
+	}
+
+	messages := c.Messages("de")
+	if messages["reason.INSUFFICIENT_COINS"] != "Nicht genug Münzen." || messages["decision.leech_offer"] == "" {
+		t.Fatalf("expected merged catalog, got %v", messages)
+	}
+}
+
+func TestCatalog_Negotiate(t *testing.T) {
+	c := NewCatalog()
+	c.Register("pt_BR", map[string]string{"error.game_full": "O jogo está cheio."})
+	c.Register("fr", map[string]string{"error.game_full": "La partie est complète."})
+
+	cases := []struct {
+		explicit, accept, want string
+	}{
+		{"", "", DefaultLocale},
+		{"PT-br", "fr", "pt-br"},
+		{"", "es-ES, fr-CH;q=0.9, en;q=0.8", "fr"},
+		{"xx", "*", DefaultLocale},
+	}
+	for _, tc := range cases {
+		if got := c.Negotiate(tc.explicit, tc.accept); got != tc.want {
+			t.Fatalf("Negotiate(%q, %q) = %q, want %q", tc.explicit, tc.accept, got, tc.want)
+		}
+	}
+}
+
+func TestCatalog_LoadDirectory(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	write("nl.json", `{"error.game_full": "Het spel is vol."}`)
+
+	c := NewCatalog()
+	if err := c.LoadDirectory(dir); err != nil {
+		t.Fatalf("LoadDirectory failed: %v", err)
+	}
+	if got := c.Message("nl", "error.game_full", nil); got != "Het spel is vol." {
+		t.Fatalf("expected loaded translation, got %q", got)
+	}
+
+	write("sv.json", `{"error.gamefull": "Spelet är fullt."}`)
+	c = NewCatalog()
+	err := c.LoadDirectory(dir)
+	if err == nil || !strings.Contains(err.Error(), `unknown message code "error.gamefull"`) {
+		t.Fatalf("expected unknown code error, got %v", err)
+	}
+	if locales := c.Locales(); len(locales) != 1 {
+		t.Fatalf("failed load should register nothing, got %v", locales)
+	}
+}
@@ -0,0 +1,112 @@
+package i18n
+
+// englishMessages are the built-in English messages and the set of codes a
+// catalog knows. Other locales may translate any subset of them.
+var englishMessages = map[string]string{
+	// Websocket error codes.
+	"error.action_invalid":             "That action is not allowed right now.",
+	"error.action_rejected":            "The action was rejected.",
+	"error.already_in_game":            "You are already seated in another open game.",
+	"error.annotation_not_found":       "The annotation does not exist or is not yours.",
+	"error.annotation_rate_limited":    "You are placing annotations too quickly; wait a moment.",
+	"error.apply_failed":               "The fixture settings could not be applied.",
+	"error.client_seed_not_allowed":    "This server does not accept seeds from clients.",
+	"error.conversion_failed":          "The conversion could not be made.",
+	"error.create_game_failed":         "The game could not be created.",
+	"error.forbidden":                  "You are not allowed to do that.",
+	"error.game_full":                  "The game is full.",
+	"error.game_locked":                "The host has locked this game.",
+	"error.game_not_found":             "The game does not exist.",
+	"error.game_not_full":              "The game needs {maxPlayers} players to start; {playerCount} have joined.",
+	"error.game_started":               "The game has already started.",
+	"error.host_only":                  "Only the host can do that.",
+	"error.invalid_action":             "The action is malformed.",
+	"error.invalid_action_payload":     "The action could not be read.",
+	"error.invalid_amount":             "The amount must be positive.",
+	"error.invalid_annotation":         "The annotation is invalid.",
+	"error.invalid_bonus_card_removal": "The bonus cards to remove are invalid.",
+	"error.invalid_bonus_cards":        "The bonus card selection is invalid.",
+	"error.invalid_conversion_type":    "The conversion is invalid.",
+	"error.invalid_custom_map":         "The custom map is invalid.",
+	"error.invalid_fire_ice_scoring":   "The Fire & Ice scoring option is invalid.",
+	"error.invalid_handicap":           "The handicap settings are invalid.",
+	"error.invalid_index":              "The action index is out of range.",
+	"error.invalid_leech_preferences":  "The leech preferences are invalid.",
+	"error.invalid_map":                "The map is invalid.",
+	"error.invalid_payload":            "The request could not be read.",
+	"error.invalid_scoring_tiles":      "The scoring tile selection is invalid.",
+	"error.invalid_setup_mode":         "The setup mode is invalid.",
+	"error.invalid_speed_preset":       "The game speed is invalid.",
+	"error.invalid_turn_order_policy":  "The turn order option is invalid.",
+	"error.invalid_turn_timer":         "The turn timer settings are invalid.",
+	"error.join_failed":                "Could not join the game.",
+	"error.lobby_error":                "The lobby request failed.",
+	"error.missing_creator":            "A player name is required to create a game.",
+	"error.missing_game_id":            "No game was given.",
+	"error.missing_model_opponent":     "Model opponent settings are required.",
+	"error.missing_player_id":          "No player was given.",
+	"error.model_game_setup_failed":    "The game against the model could not be set up.",
+	"error.not_in_game":                "You are not in this game.",
+	"error.player_banned":              "The host has banned you from this game.",
+	"error.preview_failed":             "The action cannot be previewed.",
+	"error.replay_failed":              "The actions could not be replayed.",
+	"error.revision_mismatch":          "The game changed before your action arrived (now at revision {currentRevision}).",
+	"error.seat_reserved":              "The remaining seats are reserved for invited players.",
+	"error.test_command_failed":        "The test command failed.",
+	"error.unauthorized":               "You are not seated in this game.",
+	"error.vacation_budget_exhausted":  "You have used all your vacation time.",
+	"error.vacation_failed":            "Your vacation could not be updated.",
+	"error.vacations_disabled":         "Vacations are not enabled on this server.",
+
+	// Rule failures, keyed by game.ReasonCode.
+	"reason.INVALID_ACTION":         "That action is not allowed.",
+	"reason.INSUFFICIENT_WORKERS":   "Not enough workers.",
+	"reason.INSUFFICIENT_PRIESTS":   "Not enough priests.",
+	"reason.INSUFFICIENT_COINS":     "Not enough coins.",
+	"reason.INSUFFICIENT_POWER":     "Not enough power.",
+	"reason.INSUFFICIENT_VP":        "Not enough victory points.",
+	"reason.INSUFFICIENT_RESOURCES": "Not enough resources.",
+	"reason.NOT_REACHABLE":          "That hex is not reachable.",
+	"reason.TERRAIN_OCCUPIED":       "That hex is already occupied.",
+	"reason.ACTION_SPACE_TAKEN":     "That action space has already been used this round.",
+	"reason.FACTION_TAKEN":          "That faction has already been chosen.",
+	"reason.FACTION_COLOR_TAKEN":    "A faction of that color has already been chosen.",
+	"reason.BUILDING_LIMIT":         "No buildings of that type are left.",
+	"reason.WRONG_PHASE":            "That is not possible in the current phase.",
+	"reason.NOT_YOUR_TURN":          "It is not your turn.",
+	"reason.PENDING_DECISION":       "A pending decision must be resolved first.",
+	"reason.NO_PENDING_DECISION":    "There is no decision to resolve.",
+	"reason.PLAYER_NOT_FOUND":       "That player is not in the game.",
+	"reason.INVALID_HEX":            "That hex is not on the map.",
+	"reason.CONFIRMATION_REQUIRED":  "Confirm this action first; your settings ask for confirmation.",
+
+	// Pending decision prompts, keyed by pendingDecision.type.
+	"decision.archivists_bonus_card":      "Choose a bonus card for the Archivists.",
+	"decision.auction_bid":                "Bid on a faction.",
+	"decision.auction_nomination":         "Nominate a faction for the auction.",
+	"decision.cult_reward_spade":          "Use your cult reward spades.",
+	"decision.cultists_cult_choice":       "Choose a cult track to advance.",
+	"decision.darklings_ordination":       "Choose how many workers to trade for priests.",
+	"decision.djinni_start_cult_choice":   "Choose your starting cult track.",
+	"decision.fast_auction_bid_matrix":    "Submit your bids for every faction.",
+	"decision.favor_tile_selection":       "Choose a favor tile.",
+	"decision.goblins_cult_steps":         "Choose a cult track to advance.",
+	"decision.halflings_spades":           "Place your stronghold spades.",
+	"decision.leech_offer":                "Accept or decline power from a neighbor's build.",
+	"decision.post_action_free_actions":   "Take free actions or end your turn.",
+	"decision.riverwalkers_priest_choice": "Choose a terrain to unlock with your priest.",
+	"decision.setup_bonus_card":           "Choose your starting bonus card.",
+	"decision.spade_followup":             "Finish using your spades.",
+	"decision.town_cult_top_choice":       "Choose which cult tracks to advance to the top.",
+	"decision.town_tile_selection":        "Choose a town tile.",
+	"decision.treasurers_deposit":         "Choose what to deposit in the treasury.",
+	"decision.turn_confirmation":          "Confirm or undo your turn.",
+	"decision.wisps_stronghold_dwelling":  "Place the dwelling from your stronghold.",
+
+	// Event descriptions.
+	"event.scoring_step.area":      "Largest connected area",
+	"event.scoring_step.fire_ice":  "Fire & Ice final scoring",
+	"event.scoring_step.cult":      "Cult track majorities",
+	"event.scoring_step.resources": "Leftover resources",
+	"event.scoring_step.faction":   "Faction final scoring",
+}
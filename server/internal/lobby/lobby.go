@@ -3,11 +3,13 @@ package lobby
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/lukev/tm_server/internal/game"
 	"github.com/lukev/tm_server/internal/game/board"
 )
 
@@ -18,6 +20,10 @@ var (
 	ErrAlreadyInOpenGame  = errors.New("player already seated in another open game")
 	ErrPlayerNotInGame    = errors.New("player not seated in this game")
 	ErrInvalidMap         = errors.New("invalid map")
+	ErrNotHost            = errors.New("only the host can manage this game")
+	ErrPlayerBanned       = errors.New("player is banned from this game")
+	ErrGameLocked         = errors.New("game is locked")
+	ErrSeatReserved       = errors.New("remaining seats are reserved")
 )
 
 type GameMeta struct {
@@ -33,8 +39,28 @@ type GameMeta struct {
 	MaxPlayers            int                        `json:"maxPlayers"`
 	Started               bool                       `json:"started"`
 	CreatedAt             time.Time                  `json:"createdAt"`
+	// ReservedSeats names players who keep a seat until they join, even
+	// when the game is locked. Banned players cannot join at all; a locked
+	// game only admits reserved players.
+	ReservedSeats []string `json:"reservedSeats,omitempty"`
+	Banned        []string `json:"banned,omitempty"`
+	Locked        bool     `json:"locked,omitempty"`
+	// PlayersOnVacation lists the seated players currently on vacation.
+	PlayersOnVacation []string `json:"playersOnVacation,omitempty"`
+	// PlayerStatus maps each seated player to one of the Status values.
+	PlayerStatus map[string]string `json:"playerStatus,omitempty"`
 }
 
+// Player presence as listed in GameMeta.PlayerStatus. The presence source
+// reports the first three; online players seated in a started game are
+// listed as StatusInGame.
+const (
+	StatusOffline = "offline"
+	StatusOnline  = "online"
+	StatusIdle    = "idle"
+	StatusInGame  = "in_game"
+)
+
 // Manager maintains a list of open games for joining
 // This is separate from the game.Manager which holds full game state
 
@@ -43,6 +69,8 @@ type Manager struct {
 	games          map[string]*GameMeta
 	openGameByUser map[string]string
 	nextID         int
+	onVacation     func(playerID string) bool
+	presence       func(playerID string) string
 }
 
 func NewManager() *Manager {
@@ -53,12 +81,75 @@ func NewManager() *Manager {
 	}
 }
 
+// SetVacationSource sets how listed games learn which of their players are
+// on vacation.
+func (m *Manager) SetVacationSource(onVacation func(playerID string) bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onVacation = onVacation
+}
+
+// SetPresenceSource sets how listed games learn whether their players are
+// connected. presence returns StatusOffline, StatusOnline or StatusIdle.
+func (m *Manager) SetPresenceSource(presence func(playerID string) string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.presence = presence
+}
+
+// listedGameMeta clones g for callers, filling in PlayersOnVacation and
+// PlayerStatus.
+func (m *Manager) listedGameMeta(g *GameMeta) *GameMeta {
+	out := cloneGameMeta(g)
+	if m.onVacation != nil {
+		for _, playerID := range out.Players {
+			if m.onVacation(playerID) {
+				out.PlayersOnVacation = append(out.PlayersOnVacation, playerID)
+			}
+		}
+	}
+	if m.presence != nil && len(out.Players) > 0 {
+		out.PlayerStatus = make(map[string]string, len(out.Players))
+		for _, playerID := range out.Players {
+			status := m.presence(playerID)
+			if status == StatusOnline && m.seatedInStartedGameLocked(playerID) {
+				status = StatusInGame
+			}
+			out.PlayerStatus[playerID] = status
+		}
+	}
+	return out
+}
+
+func (m *Manager) seatedInStartedGameLocked(playerID string) bool {
+	for _, g := range m.games {
+		if g.Started && slices.Contains(g.Players, playerID) {
+			return true
+		}
+	}
+	return false
+}
+
+// GameOptions returns the game options fixed when the lobby was created. The
+// rest are chosen when the host starts the game.
+func (g *GameMeta) GameOptions() game.CreateGameOptions {
+	return game.CreateGameOptions{
+		MapID:                 board.NormalizeMapID(g.MapID),
+		EnableFanFactions:     g.EnableFanFactions,
+		EnableFireIceFactions: g.EnableFireIceFactions,
+		FireIceScoring:        game.FireIceFinalScoringSetting(strings.TrimSpace(g.FireIceScoring)),
+		CustomMap:             board.CloneCustomMapDefinition(g.CustomMap),
+	}
+}
+
 func cloneGameMeta(in *GameMeta) *GameMeta {
 	if in == nil {
 		return nil
 	}
 	out := *in
 	out.Players = append([]string(nil), in.Players...)
+	out.ReservedSeats = append([]string(nil), in.ReservedSeats...)
+	out.Banned = append([]string(nil), in.Banned...)
 	out.CustomMap = board.CloneCustomMapDefinition(in.CustomMap)
 	return &out
 }
@@ -115,6 +206,44 @@ func (m *Manager) CreateGame(name string, maxPlayers int, host string, mapID str
 	return cloneGameMeta(g), nil
 }
 
+// AddStartedGame registers an already-started game, such as one imported from a
+// save file, with the given seats. The first player becomes the host.
+func (m *Manager) AddStartedGame(name string, players []string, mapID string, customMap *board.CustomMapDefinition, enableFanFactions bool, enableFireIceFactions bool, fireIceScoring string) (*GameMeta, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(players) == 0 {
+		return nil, fmt.Errorf("started game requires players")
+	}
+	normalizedMapID := board.NormalizeMapID(mapID)
+	if normalizedMapID == "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMap, strings.TrimSpace(mapID))
+	}
+	fireIceScoring = strings.TrimSpace(fireIceScoring)
+	if fireIceScoring == "" {
+		fireIceScoring = "off"
+	}
+
+	id := strconv.Itoa(m.nextID)
+	m.nextID++
+	g := &GameMeta{
+		ID:                    id,
+		Name:                  name,
+		Host:                  players[0],
+		MapID:                 string(normalizedMapID),
+		EnableFanFactions:     enableFanFactions,
+		EnableFireIceFactions: enableFireIceFactions,
+		FireIceScoring:        fireIceScoring,
+		CustomMap:             board.CloneCustomMapDefinition(customMap),
+		Players:               append([]string(nil), players...),
+		MaxPlayers:            len(players),
+		Started:               true,
+		CreatedAt:             time.Now(),
+	}
+	m.games[id] = g
+	return cloneGameMeta(g), nil
+}
+
 func (m *Manager) GetGame(id string) (*GameMeta, bool) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -122,7 +251,7 @@ func (m *Manager) GetGame(id string) (*GameMeta, bool) {
 	if !ok {
 		return nil, false
 	}
-	return cloneGameMeta(g), true
+	return m.listedGameMeta(g), true
 }
 
 func (m *Manager) JoinGame(id string, playerName string) error {
@@ -143,14 +272,125 @@ func (m *Manager) JoinGame(id string, playerName string) error {
 		}
 		return fmt.Errorf("%w: %s", ErrAlreadyInOpenGame, existingID)
 	}
+	if slices.Contains(g.Banned, playerName) {
+		return ErrPlayerBanned
+	}
+	reserved := slices.Contains(g.ReservedSeats, playerName)
+	if g.Locked && !reserved {
+		return ErrGameLocked
+	}
 	if len(g.Players) >= g.MaxPlayers {
 		return ErrGameFull
 	}
+	if !reserved && len(g.Players)+g.unclaimedReservations() >= g.MaxPlayers {
+		return ErrSeatReserved
+	}
 	g.Players = append(g.Players, playerName)
 	m.openGameByUser[playerName] = id
 	return nil
 }
 
+// unclaimedReservations counts the reserved seats whose player has not
+// joined yet.
+func (g *GameMeta) unclaimedReservations() int {
+	count := 0
+	for _, playerName := range g.ReservedSeats {
+		if !slices.Contains(g.Players, playerName) {
+			count++
+		}
+	}
+	return count
+}
+
+// openGameForHost returns the unstarted game id if host is its host.
+func (m *Manager) openGameForHost(id string, host string) (*GameMeta, error) {
+	g, ok := m.games[id]
+	if !ok {
+		return nil, ErrGameNotFound
+	}
+	if g.Started {
+		return nil, ErrGameAlreadyStarted
+	}
+	if strings.TrimSpace(host) == "" || g.Host != strings.TrimSpace(host) {
+		return nil, ErrNotHost
+	}
+	return g, nil
+}
+
+// KickPlayer removes playerName from the unstarted game id on behalf of its
+// host, banning them from rejoining when ban is set. A kicked player loses
+// any seat reserved for them.
+func (m *Manager) KickPlayer(id string, host string, playerName string, ban bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g, err := m.openGameForHost(id, host)
+	if err != nil {
+		return err
+	}
+	playerName = strings.TrimSpace(playerName)
+	if playerName == g.Host {
+		return fmt.Errorf("%w: the host cannot remove themselves", ErrNotHost)
+	}
+	seated := slices.Contains(g.Players, playerName)
+	if !seated && !ban {
+		return ErrPlayerNotInGame
+	}
+	if seated {
+		g.Players = slices.DeleteFunc(g.Players, func(p string) bool { return p == playerName })
+		delete(m.openGameByUser, playerName)
+	}
+	g.ReservedSeats = slices.DeleteFunc(g.ReservedSeats, func(p string) bool { return p == playerName })
+	if ban && playerName != "" && !slices.Contains(g.Banned, playerName) {
+		g.Banned = append(g.Banned, playerName)
+	}
+	return nil
+}
+
+// ReserveSeats replaces the seats the host of game id holds for named
+// players. Reserving a seat lifts a ban on that player.
+func (m *Manager) ReserveSeats(id string, host string, playerNames []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g, err := m.openGameForHost(id, host)
+	if err != nil {
+		return err
+	}
+	reserved := make([]string, 0, len(playerNames))
+	for _, playerName := range playerNames {
+		playerName = strings.TrimSpace(playerName)
+		if playerName != "" && playerName != g.Host && !slices.Contains(reserved, playerName) {
+			reserved = append(reserved, playerName)
+		}
+	}
+	seats := len(g.Players)
+	for _, playerName := range reserved {
+		if !slices.Contains(g.Players, playerName) {
+			seats++
+		}
+	}
+	if seats > g.MaxPlayers {
+		return fmt.Errorf("%w: %d players and reservations for %d seats", ErrGameFull, seats, g.MaxPlayers)
+	}
+	g.ReservedSeats = reserved
+	g.Banned = slices.DeleteFunc(g.Banned, func(p string) bool { return slices.Contains(reserved, p) })
+	return nil
+}
+
+// SetLocked locks or unlocks game id on behalf of its host.
+func (m *Manager) SetLocked(id string, host string, locked bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g, err := m.openGameForHost(id, host)
+	if err != nil {
+		return err
+	}
+	g.Locked = locked
+	return nil
+}
+
 func (m *Manager) LeaveGame(id string, playerName string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -205,12 +445,30 @@ func (m *Manager) StartGame(id string) error {
 	return nil
 }
 
+// RemoveGame drops game id from the lobby, for games whose setup failed
+// after they were listed.
+func (m *Manager) RemoveGame(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[id]; !ok {
+		return ErrGameNotFound
+	}
+	delete(m.games, id)
+	for playerID, gameID := range m.openGameByUser {
+		if gameID == id {
+			delete(m.openGameByUser, playerID)
+		}
+	}
+	return nil
+}
+
 func (m *Manager) ListGames() []*GameMeta {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	out := make([]*GameMeta, 0, len(m.games))
 	for _, g := range m.games {
-		out = append(out, cloneGameMeta(g))
+		out = append(out, m.listedGameMeta(g))
 	}
 	return out
 }
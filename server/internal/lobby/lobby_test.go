@@ -83,6 +83,27 @@ func TestManager_StartGame_RemovesGameFromOpenListAndBlocksLeave(t *testing.T) {
 	}
 }
 
+func TestManager_RemoveGame_FreesSeats(t *testing.T) {
+	manager := NewManager()
+
+	meta, err := manager.CreateGame("Table", 2, "host", "", nil, false, false, "off")
+	if err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	if err := manager.RemoveGame(meta.ID); err != nil {
+		t.Fatalf("remove game: %v", err)
+	}
+	if _, ok := manager.GetGame(meta.ID); ok {
+		t.Fatalf("expected the removed game to be gone")
+	}
+	if _, err := manager.CreateGame("Another table", 2, "host", "", nil, false, false, "off"); err != nil {
+		t.Fatalf("expected the host to be free to open another game: %v", err)
+	}
+	if err := manager.RemoveGame(meta.ID); !errors.Is(err, ErrGameNotFound) {
+		t.Fatalf("expected ErrGameNotFound removing twice, got %v", err)
+	}
+}
+
 func TestManager_CreateGame_StoresSelectedMap(t *testing.T) {
 	manager := NewManager()
 
@@ -167,3 +188,137 @@ func TestManager_CreateGame_StoresFireIceScoring(t *testing.T) {
 		t.Fatalf("expected fireIceScoring=random, got %q", meta.FireIceScoring)
 	}
 }
+
+func TestManager_ListGames_ReportsPlayersOnVacation(t *testing.T) {
+	manager := NewManager()
+	created, err := manager.CreateGame("Async", 3, "host", "", nil, false, false, "off")
+	if err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	if err := manager.JoinGame(created.ID, "away"); err != nil {
+		t.Fatalf("join game: %v", err)
+	}
+	manager.SetVacationSource(func(playerID string) bool { return playerID == "away" })
+
+	games := manager.ListGames()
+	if len(games) != 1 || len(games[0].PlayersOnVacation) != 1 || games[0].PlayersOnVacation[0] != "away" {
+		t.Fatalf("expected away on vacation, got %+v", games)
+	}
+	if meta, ok := manager.GetGame(created.ID); !ok || len(meta.PlayersOnVacation) != 1 {
+		t.Fatalf("expected GetGame to report away on vacation, got %+v", meta)
+	}
+}
+
+func TestManager_ListGames_ReportsPlayerStatus(t *testing.T) {
+	manager := NewManager()
+	started, err := manager.CreateGame("Running", 2, "host", "", nil, false, false, "off")
+	if err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	if err := manager.JoinGame(started.ID, "guest"); err != nil {
+		t.Fatalf("join game: %v", err)
+	}
+	if err := manager.StartGame(started.ID); err != nil {
+		t.Fatalf("start game: %v", err)
+	}
+	open, err := manager.CreateGame("Open", 3, "host", "", nil, false, false, "off")
+	if err != nil {
+		t.Fatalf("create open game: %v", err)
+	}
+	if err := manager.JoinGame(open.ID, "ghost"); err != nil {
+		t.Fatalf("join open game: %v", err)
+	}
+	statuses := map[string]string{"host": StatusOnline, "guest": StatusIdle}
+	manager.SetPresenceSource(func(playerID string) string {
+		if status, ok := statuses[playerID]; ok {
+			return status
+		}
+		return StatusOffline
+	})
+
+	meta, _ := manager.GetGame(open.ID)
+	if got := meta.PlayerStatus["host"]; got != StatusInGame {
+		t.Fatalf("expected host in a started game to be %q, got %q", StatusInGame, got)
+	}
+	if got := meta.PlayerStatus["ghost"]; got != StatusOffline {
+		t.Fatalf("expected a disconnected seat to be offline, got %q", got)
+	}
+	meta, _ = manager.GetGame(started.ID)
+	if got := meta.PlayerStatus["guest"]; got != StatusIdle {
+		t.Fatalf("expected idle players to stay idle, got %q", got)
+	}
+}
+
+func TestManager_KickPlayer_HostOnlyAndBanBlocksRejoin(t *testing.T) {
+	manager := NewManager()
+	created, err := manager.CreateGame("Kick", 3, "host", "", nil, false, false, "off")
+	if err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	for _, player := range []string{"guest", "troll"} {
+		if err := manager.JoinGame(created.ID, player); err != nil {
+			t.Fatalf("join %s: %v", player, err)
+		}
+	}
+
+	if err := manager.KickPlayer(created.ID, "guest", "troll", false); !errors.Is(err, ErrNotHost) {
+		t.Fatalf("expected a non-host kick to fail with ErrNotHost, got %v", err)
+	}
+	if err := manager.KickPlayer(created.ID, "host", "host", false); !errors.Is(err, ErrNotHost) {
+		t.Fatalf("expected the host to be unable to kick themselves, got %v", err)
+	}
+	if err := manager.KickPlayer(created.ID, "host", "guest", false); err != nil {
+		t.Fatalf("kick guest: %v", err)
+	}
+	if err := manager.KickPlayer(created.ID, "host", "troll", true); err != nil {
+		t.Fatalf("ban troll: %v", err)
+	}
+	meta, _ := manager.GetGame(created.ID)
+	if len(meta.Players) != 1 || len(meta.Banned) != 1 || meta.Banned[0] != "troll" {
+		t.Fatalf("expected only the host seated and troll banned, got %+v", meta)
+	}
+
+	if err := manager.JoinGame(created.ID, "guest"); err != nil {
+		t.Fatalf("expected a kicked player to rejoin, got %v", err)
+	}
+	if err := manager.JoinGame(created.ID, "troll"); !errors.Is(err, ErrPlayerBanned) {
+		t.Fatalf("expected a banned player to be refused, got %v", err)
+	}
+}
+
+func TestManager_ReserveSeatsAndLock_RestrictJoins(t *testing.T) {
+	manager := NewManager()
+	created, err := manager.CreateGame("Friends", 3, "host", "", nil, false, false, "off")
+	if err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	if err := manager.ReserveSeats(created.ID, "host", []string{"ann", "bob", "cid"}); !errors.Is(err, ErrGameFull) {
+		t.Fatalf("expected more reservations than seats to fail, got %v", err)
+	}
+	if err := manager.ReserveSeats(created.ID, "host", []string{"ann", " ann "}); err != nil {
+		t.Fatalf("reserve seats: %v", err)
+	}
+	if err := manager.JoinGame(created.ID, "stranger"); err != nil {
+		t.Fatalf("expected a stranger to take the unreserved seat, got %v", err)
+	}
+	if err := manager.JoinGame(created.ID, "latecomer"); !errors.Is(err, ErrSeatReserved) {
+		t.Fatalf("expected the reserved seat to be held, got %v", err)
+	}
+	if err := manager.KickPlayer(created.ID, "host", "stranger", false); err != nil {
+		t.Fatalf("kick stranger: %v", err)
+	}
+
+	if err := manager.SetLocked(created.ID, "host", true); err != nil {
+		t.Fatalf("lock game: %v", err)
+	}
+	if err := manager.JoinGame(created.ID, "stranger"); !errors.Is(err, ErrGameLocked) {
+		t.Fatalf("expected a locked game to refuse strangers, got %v", err)
+	}
+	if err := manager.JoinGame(created.ID, "ann"); err != nil {
+		t.Fatalf("expected the reserved player to join a locked game, got %v", err)
+	}
+	meta, _ := manager.GetGame(created.ID)
+	if !meta.Locked || len(meta.ReservedSeats) != 1 || len(meta.Players) != 2 {
+		t.Fatalf("unexpected listing %+v", meta)
+	}
+}
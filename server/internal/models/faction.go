@@ -1,5 +1,7 @@
 package models
 
+import "strings"
+
 // FactionType enumerates the base game factions plus supported fan factions.
 // Note: Exact abilities to be implemented in the game engine layer
 
@@ -229,84 +231,63 @@ func (f FactionType) IsFireIceFaction() bool {
 	}
 }
 
-// FactionTypeFromString converts a string representation to FactionType
+// AllFactionTypes lists every faction, FactionUnknown excluded, in enum order.
+func AllFactionTypes() []FactionType {
+	all := make([]FactionType, 0, int(FactionSnowShamans))
+	for f := FactionNomads; f <= FactionSnowShamans; f++ {
+		all = append(all, f)
+	}
+	return all
+}
+
+// IsBaseGame reports whether f is one of the fourteen base game factions.
+func (f FactionType) IsBaseGame() bool {
+	return f >= FactionNomads && f <= FactionDwarves
+}
+
+// factionAliases are the names a faction goes by besides its String() name,
+// keyed by FactionKey: the spellings of Snellman, BGA and older fan-faction
+// releases.
+var factionAliases = map[string]FactionType{
+	"cashdallah":     FactionChashDallah,
+	"djinn":          FactionDjinni,
+	"golddiggers":    FactionProspectors,
+	"timetravellers": FactionTimeTravelers,
+	"yeti":           FactionYetis,
+}
+
+var factionsByKey = func() map[string]FactionType {
+	byKey := make(map[string]FactionType, len(factionAliases)+int(FactionSnowShamans))
+	for _, f := range AllFactionTypes() {
+		byKey[FactionKey(f.String())] = f
+	}
+	for alias, f := range factionAliases {
+		byKey[alias] = f
+	}
+	return byKey
+}()
+
+// FactionKey folds a faction name for matching: case, spaces, underscores and
+// hyphens are ignored, so "Chaos Magicians", "chaos_magicians" and
+// "ChaosMagicians" share the key "chaosmagicians".
+func FactionKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch r {
+		case ' ', '\t', '_', '-':
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// FactionTypeFromString converts a faction name in any of its spellings (see
+// FactionKey and factionAliases) to FactionType. Unknown names give
+// FactionUnknown.
 func FactionTypeFromString(s string) FactionType {
-	switch s {
-	case "Nomads":
-		return FactionNomads
-	case "Fakirs":
-		return FactionFakirs
-	case "ChaosMagicians", "Chaos Magicians":
-		return FactionChaosMagicians
-	case "Giants":
-		return FactionGiants
-	case "Swarmlings":
-		return FactionSwarmlings
-	case "Mermaids":
-		return FactionMermaids
-	case "Witches":
-		return FactionWitches
-	case "Auren":
-		return FactionAuren
-	case "Halflings":
-		return FactionHalflings
-	case "Cultists":
-		return FactionCultists
-	case "Alchemists":
-		return FactionAlchemists
-	case "Darklings":
-		return FactionDarklings
-	case "Engineers":
-		return FactionEngineers
-	case "Dwarves":
-		return FactionDwarves
-	case "Architects":
-		return FactionArchitects
-	case "Archivists":
-		return FactionArchivists
-	case "Atlanteans":
-		return FactionAtlanteans
-	case "ChashDallah", "Chash Dallah", "CashDallah", "Cash Dallah":
-		return FactionChashDallah
-	case "ChildrenOfTheWyrm", "Children of the Wyrm", "Children Of The Wyrm":
-		return FactionChildrenOfTheWyrm
-	case "Conspirators":
-		return FactionConspirators
-	case "Djinni", "Djinn":
-		return FactionDjinni
-	case "DynionGeifr", "Dynion Geifr":
-		return FactionDynionGeifr
-	case "Goblins":
-		return FactionGoblins
-	case "Prospectors", "GoldDiggers", "Gold Diggers":
-		return FactionProspectors
-	case "TheEnlightened", "The Enlightened":
-		return FactionTheEnlightened
-	case "TimeTravelers", "Time Travelers", "TimeTravellers", "Time Travellers":
-		return FactionTimeTravelers
-	case "Treasurers":
-		return FactionTreasurers
-	case "Wisps":
-		return FactionWisps
-	case "IceMaidens", "Ice Maidens":
-		return FactionIceMaidens
-	case "Yetis", "Yeti":
-		return FactionYetis
-	case "Dragonlords", "Dragon Lords":
-		return FactionDragonlords
-	case "Acolytes":
-		return FactionAcolytes
-	case "Shapeshifters", "Shape Shifters":
-		return FactionShapeshifters
-	case "Riverwalkers", "River Walkers":
-		return FactionRiverwalkers
-	case "Firewalkers", "Fire Walkers":
-		return FactionFirewalkers
-	case "Selkies":
-		return FactionSelkies
-	case "SnowShamans", "Snow Shamans":
-		return FactionSnowShamans
-	default:
-		return FactionUnknown
+	if f, ok := factionsByKey[FactionKey(s)]; ok {
+		return f
 	}
+	return FactionUnknown
 }
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFactionTypeFromString_RoundTripsEveryFaction(t *testing.T) {
+	for _, f := range AllFactionTypes() {
+		name := f.String()
+		if name == "Unknown" {
+			t.Fatalf("faction %d has no name", f)
+		}
+		spaced := strings.TrimSpace(strings.Join(splitCamelCase(name), " "))
+		for _, spelling := range []string{
+			name,
+			strings.ToLower(name),
+			strings.ToUpper(name),
+			spaced,
+			strings.ReplaceAll(strings.ToLower(spaced), " ", "_"),
+			strings.ReplaceAll(spaced, " ", "-"),
+			" " + spaced + " ",
+		} {
+			if got := FactionTypeFromString(spelling); got != f {
+				t.Errorf("FactionTypeFromString(%q) = %v, want %v", spelling, got, f)
+			}
+		}
+	}
+}
+
+func TestFactionTypeFromString_Aliases(t *testing.T) {
+	for alias, want := range factionAliases {
+		if got := FactionTypeFromString(alias); got != want {
+			t.Errorf("FactionTypeFromString(%q) = %v, want %v", alias, got, want)
+		}
+	}
+	for spelling, want := range map[string]FactionType{
+		"Chaos Magicians":      FactionChaosMagicians,
+		"Children of the Wyrm": FactionChildrenOfTheWyrm,
+		"Cash Dallah":          FactionChashDallah,
+		"Djinn":                FactionDjinni,
+		"Gold Diggers":         FactionProspectors,
+		"Time Travellers":      FactionTimeTravelers,
+		"Yeti":                 FactionYetis,
+		"Dragon Lords":         FactionDragonlords,
+	} {
+		if got := FactionTypeFromString(spelling); got != want {
+			t.Errorf("FactionTypeFromString(%q) = %v, want %v", spelling, got, want)
+		}
+	}
+	for _, unknown := range []string{"", "Unknown", "chaos", "wizards"} {
+		if got := FactionTypeFromString(unknown); got != FactionUnknown {
+			t.Errorf("FactionTypeFromString(%q) = %v, want FactionUnknown", unknown, got)
+		}
+	}
+}
+
+func splitCamelCase(name string) []string {
+	var words []string
+	start := 0
+	for i := 1; i < len(name); i++ {
+		if name[i] >= 'A' && name[i] <= 'Z' {
+			words = append(words, name[start:i])
+			start = i
+		}
+	}
+	return append(words, name[start:])
+}
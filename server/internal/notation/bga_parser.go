@@ -81,6 +81,10 @@ func NewBGAParser(content string) *BGAParser {
 }
 
 func (p *BGAParser) Parse() ([]LogItem, error) {
+	if err := DetectUnsupportedBGAFeatures(strings.Join(p.lines, "\n")); err != nil {
+		return nil, err
+	}
+
 	// Regex patterns
 	reMove := regexp.MustCompile(`^Move (\d+) :`)
 	reFactionSelection := regexp.MustCompile(`(.*) is playing the (.*) Faction(?: \(with (\d+) VP Starting VPs\))?`)
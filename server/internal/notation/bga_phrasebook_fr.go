@@ -0,0 +1,56 @@
+package notation
+
+import "regexp"
+
+// bgaFrenchPhrasebook reads BGA logs exported from the French interface. It
+// covers setup, builds, upgrades, leeches and passes; lines it does not know
+// reach the BGA parser untranslated.
+var bgaFrenchPhrasebook = BGAPhrasebook{
+	Language: "fr",
+	Markers:  []string{"Plateau de jeu :", "~ Phase d'actions ~"},
+	Lines: []BGAPhraseLine{
+		{regexp.MustCompile(`^Plateau de jeu : (.*)$`), "Game board: $1"},
+		{regexp.MustCompile(`^Mini-extensions : Activées$`), "Mini-expansions: On"},
+		{regexp.MustCompile(`^Mini-extensions : Désactivées$`), "Mini-expansions: Off"},
+		{regexp.MustCompile(`^Décompte de la manche (\d+) : (.*)$`), "Round $1 scoring: $2"},
+		{regexp.MustCompile(`^Retrait de la tuile (.*)$`), "Removing tile $1"},
+		{regexp.MustCompile(`^Coup (\d+) :`), "Move $1 :"},
+		{regexp.MustCompile(`^(.*) joue la faction (.*) \(avec (\d+) PV de départ\)$`), "$1 is playing the $2 Faction (with $3 VP Starting VPs)"},
+		{regexp.MustCompile(`^(.*) joue la faction (.*)$`), "$1 is playing the $2 Faction"},
+		{regexp.MustCompile(`^(.*) place une Habitation \[(.*)\]$`), "$1 places a Dwelling [$2]"},
+		{regexp.MustCompile(`^(.*) transforme une case Terrain (.*) → (.*) pour (\d+) pelle\(s\) \[(.*)\]$`), "$1 transforms a Terrain space $2 → $3 for $4 spade(s) [$5]"},
+		{regexp.MustCompile(`^(.*) construit une Habitation pour (.*) \[(.*)\]$`), "$1 builds a Dwelling for $2 [$3]"},
+		{regexp.MustCompile(`^(.*) améliore une (.*) en (.*) pour (.*) \[(.*)\]$`), "$1 upgrades a $2 to a $3 for $4 [$5]"},
+		{regexp.MustCompile(`^(.*) reçoit (\d+) pouvoir via les Structures \[(.*)\]$`), "$1 gets $2 power via Structures [$3]"},
+		{regexp.MustCompile(`^(.*) passe$`), "$1 passes"},
+	},
+	Phrases: map[string]string{
+		"~ Chaque joueur a choisi une Faction et reçoit les ressources de départ correspondantes. ~": "~ Every player has chosen a Faction and receives the matching starting resources. ~",
+		"~ Phase d'actions ~": "~ Action phase ~",
+
+		// Buildings.
+		"Habitation": "Dwelling",
+		"Comptoir":   "Trading house",
+		"Sanctuaire": "Sanctuary",
+		"Forteresse": "Faction Stronghold",
+
+		// Resources.
+		"ouvriers": "workers",
+		"pièces":   "coins",
+		"prêtres":  "Priests",
+		"pouvoir":  "power",
+
+		// Terrains.
+		"marais":           "swamp",
+		"plaines":          "plains",
+		"lacs":             "lakes",
+		"forêt":            "forest",
+		"montagnes":        "mountains",
+		"désert":           "desert",
+		"terres dévastées": "wasteland",
+	},
+}
+
+func init() {
+	RegisterBGAPhrasebook(bgaFrenchPhrasebook)
+}
@@ -0,0 +1,304 @@
+package notation
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Dialect names the shape a game log was exported in.
+type Dialect string
+
+const (
+	DialectConcise      Dialect = "concise"
+	DialectSnellman     Dialect = "snellman"
+	DialectBGA          Dialect = "bga"
+	DialectBGALocalized Dialect = "bga_localized"
+)
+
+// DialectParser detects and parses one log dialect.
+type DialectParser interface {
+	Dialect() Dialect
+	// Detect reports whether content, already normalized, is in this dialect.
+	Detect(content string) bool
+	Parse(content string) ([]LogItem, error)
+}
+
+var (
+	dialectMu      sync.RWMutex
+	dialectParsers = []DialectParser{
+		conciseDialect{},
+		snellmanDialect{},
+		bgaLocalizedDialect{},
+		bgaDialect{},
+	}
+)
+
+// RegisterDialectParser adds p ahead of the built-in parsers, so it gets the
+// first look at every log.
+func RegisterDialectParser(p DialectParser) {
+	dialectMu.Lock()
+	defer dialectMu.Unlock()
+	dialectParsers = append([]DialectParser{p}, dialectParsers...)
+}
+
+// Parse parses a game log in any known dialect. content may be the text
+// export or the raw HTML page of a Snellman or BGA game.
+func Parse(content string) ([]LogItem, Dialect, error) {
+	normalized, err := NormalizeLog(content)
+	if err != nil {
+		return nil, "", err
+	}
+	parser, err := detectDialect(normalized)
+	if err != nil {
+		return nil, "", err
+	}
+	items, err := parser.Parse(normalized)
+	if err != nil {
+		return nil, parser.Dialect(), fmt.Errorf("parse %s log: %w", parser.Dialect(), err)
+	}
+	return items, parser.Dialect(), nil
+}
+
+// ParseDialect parses normalized content as dialect, skipping detection.
+func ParseDialect(content string, dialect Dialect) ([]LogItem, error) {
+	dialectMu.RLock()
+	defer dialectMu.RUnlock()
+	for _, parser := range dialectParsers {
+		if parser.Dialect() == dialect {
+			return parser.Parse(content)
+		}
+	}
+	return nil, fmt.Errorf("unknown log dialect %q", dialect)
+}
+
+// DetectDialect reports the dialect of normalized content.
+func DetectDialect(content string) (Dialect, error) {
+	parser, err := detectDialect(content)
+	if err != nil {
+		return "", err
+	}
+	return parser.Dialect(), nil
+}
+
+func detectDialect(content string) (DialectParser, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, fmt.Errorf("log content is empty")
+	}
+	dialectMu.RLock()
+	defer dialectMu.RUnlock()
+	for _, parser := range dialectParsers {
+		if parser.Detect(content) {
+			return parser, nil
+		}
+	}
+	return nil, fmt.Errorf("log is in no known dialect")
+}
+
+// NormalizeLog turns an exported log into text the dialect parsers agree on:
+// HTML pages are reduced to their log text, line endings become "\n" and
+// non-breaking spaces become plain spaces.
+func NormalizeLog(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if strings.HasPrefix(content, "<") {
+		var err error
+		if IsSnellmanHTML(content) {
+			content, err = ParseSnellmanHTML(content)
+		} else {
+			content, err = ParseBGAHTML(content)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse HTML: %w", err)
+		}
+	}
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.ReplaceAll(content, "\r", "\n")
+	content = strings.ReplaceAll(content, "\u00a0", " ")
+	return strings.TrimSpace(content), nil
+}
+
+// IsConciseLog reports whether content is in this project's concise notation.
+func IsConciseLog(content string) bool {
+	if strings.Contains(content, "Game:") || strings.Contains(content, "StartingVPs:") {
+		return true
+	}
+	return strings.Contains(content, "TurnOrder:") && strings.Contains(content, "|")
+}
+
+type conciseDialect struct{}
+
+func (conciseDialect) Dialect() Dialect           { return DialectConcise }
+func (conciseDialect) Detect(content string) bool { return IsConciseLog(content) }
+func (conciseDialect) Parse(content string) ([]LogItem, error) {
+	return ParseConciseLogStrict(content)
+}
+
+type snellmanDialect struct{}
+
+func (snellmanDialect) Dialect() Dialect           { return DialectSnellman }
+func (snellmanDialect) Detect(content string) bool { return IsSnellmanTextFormat(content) }
+func (snellmanDialect) Parse(content string) ([]LogItem, error) {
+	concise, err := ConvertSnellmanToConciseForReplay(content)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConciseLogStrict(concise)
+}
+
+// bgaDialect is the English BGA log. It detects everything, so it stays last
+// and keeps the old behavior of treating any unrecognized log as BGA.
+type bgaDialect struct{}
+
+func (bgaDialect) Dialect() Dialect   { return DialectBGA }
+func (bgaDialect) Detect(string) bool { return true }
+func (bgaDialect) Parse(content string) ([]LogItem, error) {
+	return NewBGAParser(NormalizeBGAText(content)).Parse()
+}
+
+var (
+	reBGAMoveHeader = regexp.MustCompile(`^Move\s+(\d+)\s*:\s*`)
+	reBGASpaces     = regexp.MustCompile(`[ \t]+`)
+	reBGATileLine   = regexp.MustCompile(`^(Removing tile |Round \d+ scoring: )([^,]+)(.*)$`)
+)
+
+// bgaTileRenames maps tile labels some BGA exports carry instead of the BGA
+// tile codes the parser reads: the bonus cards under this project's names.
+var bgaTileRenames = map[string]string{
+	"BON-SPD":     "BON1",
+	"BON-4C":      "BON2",
+	"BON-6C":      "BON3",
+	"BON-SHIP":    "BON4",
+	"BON-WP":      "BON5",
+	"BON-BB":      "BON6",
+	"BON-TP":      "BON7",
+	"BON-P":       "BON8",
+	"BON-DW":      "BON9",
+	"BON-SHIP-VP": "BON10",
+}
+
+// NormalizeBGAText evens out the shapes BGA text exports come in, depending
+// on how the page was copied: runs of spaces and tabs are collapsed, "Move 12:"
+// becomes "Move 12 :", blank lines are dropped, and renamed tiles (see
+// bgaTileRenames) get their BGA codes back.
+func NormalizeBGAText(content string) string {
+	lines := strings.Split(content, "\n")
+	normalized := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(reBGASpaces.ReplaceAllString(line, " "))
+		if line == "" {
+			continue
+		}
+		if matches := reBGAMoveHeader.FindStringSubmatch(line); matches != nil {
+			rest := line[len(matches[0]):]
+			line = "Move " + matches[1] + " :"
+			if rest != "" {
+				line += " " + rest
+			}
+		}
+		if matches := reBGATileLine.FindStringSubmatch(line); matches != nil {
+			if code, ok := bgaTileRenames[strings.TrimSpace(matches[2])]; ok {
+				line = matches[1] + code + matches[3]
+			}
+		}
+		normalized = append(normalized, line)
+	}
+	return strings.Join(normalized, "\n")
+}
+
+// BGAPhrasebook translates a localized BGA log into the English phrasing the
+// BGA parser reads. It also serves for English logs whose tile or building
+// names were renamed between BGA releases.
+type BGAPhrasebook struct {
+	Language string
+	// Markers are phrases only logs in this language contain.
+	Markers []string
+	// Lines rewrite whole lines whose word order differs from English; the
+	// first matching one applies.
+	Lines []BGAPhraseLine
+	// Phrases maps localized phrases to their English form. They apply after
+	// Lines, anywhere in the log.
+	Phrases map[string]string
+}
+
+// BGAPhraseLine rewrites a line matching Pattern into English, as in
+// regexp.Regexp.ReplaceAllString.
+type BGAPhraseLine struct {
+	Pattern *regexp.Regexp
+	English string
+}
+
+var (
+	phrasebookMu sync.RWMutex
+	phrasebooks  []BGAPhrasebook
+)
+
+// RegisterBGAPhrasebook makes logs carrying one of book's markers parse as
+// localized BGA logs.
+func RegisterBGAPhrasebook(book BGAPhrasebook) {
+	phrasebookMu.Lock()
+	defer phrasebookMu.Unlock()
+	phrasebooks = append(phrasebooks, book)
+}
+
+func phrasebookFor(content string) (BGAPhrasebook, bool) {
+	phrasebookMu.RLock()
+	defer phrasebookMu.RUnlock()
+	for _, book := range phrasebooks {
+		for _, marker := range book.Markers {
+			if strings.Contains(content, marker) {
+				return book, true
+			}
+		}
+	}
+	return BGAPhrasebook{}, false
+}
+
+// Translate rewrites content into English: line by line through Lines, then
+// through Phrases, longest first so that a phrase is never split by a shorter
+// one it contains.
+func (b BGAPhrasebook) Translate(content string) string {
+	if len(b.Lines) > 0 {
+		lines := strings.Split(content, "\n")
+		for i, line := range lines {
+			for _, rule := range b.Lines {
+				if rule.Pattern.MatchString(line) {
+					lines[i] = rule.Pattern.ReplaceAllString(line, rule.English)
+					break
+				}
+			}
+		}
+		content = strings.Join(lines, "\n")
+	}
+	localized := make([]string, 0, len(b.Phrases))
+	for phrase := range b.Phrases {
+		localized = append(localized, phrase)
+	}
+	sort.Slice(localized, func(i, j int) bool {
+		if len(localized[i]) != len(localized[j]) {
+			return len(localized[i]) > len(localized[j])
+		}
+		return localized[i] < localized[j]
+	})
+	pairs := make([]string, 0, 2*len(localized))
+	for _, phrase := range localized {
+		pairs = append(pairs, phrase, b.Phrases[phrase])
+	}
+	return strings.NewReplacer(pairs...).Replace(content)
+}
+
+type bgaLocalizedDialect struct{}
+
+func (bgaLocalizedDialect) Dialect() Dialect { return DialectBGALocalized }
+func (bgaLocalizedDialect) Detect(content string) bool {
+	_, ok := phrasebookFor(content)
+	return ok
+}
+func (bgaLocalizedDialect) Parse(content string) ([]LogItem, error) {
+	book, ok := phrasebookFor(content)
+	if !ok {
+		return nil, fmt.Errorf("no phrasebook matches this log")
+	}
+	return bgaDialect{}.Parse(book.Translate(NormalizeBGAText(content)))
+}
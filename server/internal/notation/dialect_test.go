@@ -0,0 +1,193 @@
+package notation
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const dialectTestBGALog = `Game board: Base Game
+Mini-expansions: On
+alice is playing the Halflings Faction (with 20 VP Starting VPs)
+bob is playing the Auren Faction (with 20 VP Starting VPs)
+~ Every player has chosen a Faction and receives the matching starting resources. ~
+Move 3 :
+alice places a Dwelling [F5]
+bob places a Dwelling [F4]
+bob places a Dwelling [C3]
+alice places a Dwelling [E6]
+~ Action phase ~
+`
+
+func TestDetectDialect(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Dialect
+	}{
+		{"concise", "Game: Base\nStartingVPs: 20\n", DialectConcise},
+		{"snellman", "option strict-leech\ndefault game options\nrandomize setup\nengineers\t20 VP\n\n", DialectSnellman},
+		{"bga", dialectTestBGALog, DialectBGA},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectDialect(tt.content)
+			if err != nil || got != tt.want {
+				t.Fatalf("DetectDialect() = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+	if _, err := DetectDialect("  \n"); err == nil {
+		t.Fatalf("expected empty content to be an error")
+	}
+}
+
+func TestParse_BGAExportShapesParseAlike(t *testing.T) {
+	want, dialect, err := Parse(dialectTestBGALog)
+	if err != nil || dialect != DialectBGA {
+		t.Fatalf("Parse() = %v, %q; want BGA items", err, dialect)
+	}
+	if len(want) == 0 {
+		t.Fatalf("expected items from the canonical log")
+	}
+
+	reshaped := strings.ReplaceAll(dialectTestBGALog, "Move 3 :", "Move 3:")
+	reshaped = strings.ReplaceAll(reshaped, "places a", "places a  ")
+	reshaped = strings.ReplaceAll(reshaped, "\n", "\r\n\r\n")
+	got, dialect, err := Parse(reshaped)
+	if err != nil || dialect != DialectBGA {
+		t.Fatalf("Parse(reshaped) = %v, %q; want BGA items", err, dialect)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected the reshaped export to parse like the canonical one:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParse_LocalizedBGALogUsesPhrasebook(t *testing.T) {
+	phrasebookMu.Lock()
+	saved := phrasebooks
+	phrasebooks = nil
+	phrasebookMu.Unlock()
+	t.Cleanup(func() {
+		phrasebookMu.Lock()
+		phrasebooks = saved
+		phrasebookMu.Unlock()
+	})
+
+	RegisterBGAPhrasebook(BGAPhrasebook{
+		Language: "test",
+		Markers:  []string{"Plateau de jeu:"},
+		Phrases: map[string]string{
+			"Plateau de jeu:":  "Game board:",
+			"joue la faction":  "is playing the",
+			"place une Maison": "places a Dwelling",
+			"place une":        "places a",
+		},
+	})
+	localized := strings.NewReplacer(
+		"Game board:", "Plateau de jeu:",
+		"is playing the", "joue la faction",
+		"places a Dwelling", "place une Maison",
+	).Replace(dialectTestBGALog)
+
+	want, _, err := Parse(dialectTestBGALog)
+	if err != nil {
+		t.Fatalf("parse English log: %v", err)
+	}
+	got, dialect, err := Parse(localized)
+	if err != nil || dialect != DialectBGALocalized {
+		t.Fatalf("Parse(localized) = %v, %q; want localized BGA items", err, dialect)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected the localized log to parse like the English one:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func readDialectFixture(t *testing.T, name string) string {
+	t.Helper()
+	raw, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	return string(raw)
+}
+
+func TestParse_FrenchBGAFixtureParsesLikeEnglish(t *testing.T) {
+	want, _, err := Parse(readDialectFixture(t, "bga_sample_en.txt"))
+	if err != nil {
+		t.Fatalf("parse English fixture: %v", err)
+	}
+	got, dialect, err := Parse(readDialectFixture(t, "bga_sample_fr.txt"))
+	if err != nil || dialect != DialectBGALocalized {
+		t.Fatalf("Parse(French fixture) = %v, %q; want localized BGA items", err, dialect)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected the French fixture to parse like the English one:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+// Logs replayed as BGA are normalized first; for logs already in the
+// canonical shape that must not change what they parse to.
+func TestParseDialect_BGANormalizationKeepsCanonicalLogs(t *testing.T) {
+	for _, content := range []string{dialectTestBGALog, readDialectFixture(t, "bga_sample_en.txt")} {
+		want, err := NewBGAParser(content).Parse()
+		if err != nil {
+			t.Fatalf("parse without normalizing: %v", err)
+		}
+		got, err := ParseDialect(content, DialectBGA)
+		if err != nil {
+			t.Fatalf("ParseDialect: %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("normalizing changed the parse:\n got %+v\nwant %+v", got, want)
+		}
+	}
+}
+
+func TestParse_RenamedBGATilesGetTheirCodes(t *testing.T) {
+	content := readDialectFixture(t, "bga_sample_en.txt")
+	want, _, err := Parse(content)
+	if err != nil {
+		t.Fatalf("parse fixture: %v", err)
+	}
+	renamed := strings.NewReplacer(
+		"Removing tile BON3\n", "Removing tile BON-6C\n",
+		"Removing tile BON10\n", "Removing tile BON-SHIP-VP\n",
+	).Replace(content)
+	if renamed == content {
+		t.Fatalf("fixture has no tiles to rename")
+	}
+	got, _, err := Parse(renamed)
+	if err != nil {
+		t.Fatalf("parse renamed tiles: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected renamed tiles to parse like their codes:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+type fixedDialect struct{}
+
+func (fixedDialect) Dialect() Dialect           { return "fixed" }
+func (fixedDialect) Detect(content string) bool { return strings.HasPrefix(content, "FIXED") }
+func (fixedDialect) Parse(string) ([]LogItem, error) {
+	return []LogItem{RoundStartItem{Round: 1}}, nil
+}
+
+func TestRegisterDialectParser_TakesPrecedence(t *testing.T) {
+	dialectMu.Lock()
+	saved := dialectParsers
+	dialectMu.Unlock()
+	t.Cleanup(func() {
+		dialectMu.Lock()
+		dialectParsers = saved
+		dialectMu.Unlock()
+	})
+
+	RegisterDialectParser(fixedDialect{})
+	items, dialect, err := Parse("FIXED\nGame: Base")
+	if err != nil || dialect != "fixed" || len(items) != 1 {
+		t.Fatalf("Parse() = %v, %q, %v; want the registered parser", items, dialect, err)
+	}
+}
@@ -2,6 +2,7 @@ package notation
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/lukev/tm_server/internal/game"
@@ -141,8 +142,13 @@ func GenerateConciseLog(items []LogItem) ([]string, []LogLocation) {
 			finalizeRows()
 			// Print settings
 			itemLocations[k] = LogLocation{LineIndex: len(lines), ColumnIndex: 0}
+			var nameParts []string
 			for key, val := range v.Settings {
-				if !strings.HasPrefix(key, "StartingVP:") {
+				if name, ok := strings.CutPrefix(key, "Player:"); ok && name != val {
+					nameParts = append(nameParts, fmt.Sprintf("%s:%s", val, name))
+					continue
+				}
+				if !strings.HasPrefix(key, "StartingVP:") && !strings.HasPrefix(key, "Handicap:") {
 					lines = append(lines, fmt.Sprintf("%s: %s", key, val))
 				}
 			}
@@ -160,6 +166,20 @@ func GenerateConciseLog(items []LogItem) ([]string, []LogLocation) {
 			if len(vpParts) > 0 {
 				lines = append(lines, fmt.Sprintf("StartingVPs: %s", strings.Join(vpParts, ", ")))
 			}
+			var handicapParts []string
+			for key, val := range v.Settings {
+				if faction, ok := strings.CutPrefix(key, "Handicap:"); ok {
+					handicapParts = append(handicapParts, fmt.Sprintf("%s:%s", faction, val))
+				}
+			}
+			if len(handicapParts) > 0 {
+				sort.Strings(handicapParts)
+				lines = append(lines, fmt.Sprintf("Handicaps: %s", strings.Join(handicapParts, ", ")))
+			}
+			if len(nameParts) > 0 {
+				sort.Strings(nameParts)
+				lines = append(lines, fmt.Sprintf("PlayerNames: %s", strings.Join(nameParts, ", ")))
+			}
 			lines = append(lines, "") // Empty line
 
 		case RoundStartItem:
@@ -212,6 +232,11 @@ func GenerateConciseLog(items []LogItem) ([]string, []LogLocation) {
 				homeTerrain = faction.GetHomeTerrain()
 			}
 
+			if _, ok := action.(*game.SkipHalflingsDwellingAction); ok {
+				// Implied when no dwelling token follows the stronghold spades
+				continue
+			}
+
 			code := generateActionCode(action, homeTerrain)
 			leechSource := extractLeechSourceFromAction(action)
 
@@ -332,7 +357,7 @@ func enforceLeechSourceOrderForEvents(events []logTableEvent) []logTableEvent {
 			if !isLeechOrDeclineToken(strings.TrimSpace(ev.cell.token)) {
 				continue
 			}
-			sourceFaction := normalizeFactionNameForMatching(ev.cell.sourceFaction)
+			sourceFaction := models.FactionKey(ev.cell.sourceFaction)
 			if sourceFaction == "" {
 				continue
 			}
@@ -343,7 +368,7 @@ func enforceLeechSourceOrderForEvents(events []logTableEvent) []logTableEvent {
 				if tok == "" || isLeechOrDeclineToken(tok) {
 					continue
 				}
-				if normalizeFactionNameForMatching(events[j].faction) == sourceFaction && actionMayTriggerLeech(tok) {
+				if models.FactionKey(events[j].faction) == sourceFaction && actionMayTriggerLeech(tok) {
 					req = j
 					break
 				}
@@ -354,7 +379,7 @@ func enforceLeechSourceOrderForEvents(events []logTableEvent) []logTableEvent {
 					if tok == "" || isLeechOrDeclineToken(tok) {
 						continue
 					}
-					if normalizeFactionNameForMatching(events[j].faction) == sourceFaction && actionMayTriggerLeech(tok) {
+					if models.FactionKey(events[j].faction) == sourceFaction && actionMayTriggerLeech(tok) {
 						req = j
 						break
 					}
@@ -369,7 +394,7 @@ func enforceLeechSourceOrderForEvents(events []logTableEvent) []logTableEvent {
 					if tok == "" || isLeechOrDeclineToken(tok) {
 						continue
 					}
-					if normalizeFactionNameForMatching(events[j].faction) == sourceFaction && actionMayTriggerLeech(tok) {
+					if models.FactionKey(events[j].faction) == sourceFaction && actionMayTriggerLeech(tok) {
 						req = j
 						break
 					}
@@ -464,11 +489,23 @@ func generateActionCode(action game.Action, homeTerrain models.TerrainType) stri
 			return fmt.Sprintf("T-%s-%s", HexToShortString(a.TargetHex), terrainCode)
 		}
 		return fmt.Sprintf("T-%s", HexToShortString(a.TargetHex))
+	case *game.ApplyHalflingsSpadeAction:
+		// Halflings stronghold spades read as plain transforms while pending
+		if a.TargetTerrain == homeTerrain {
+			return fmt.Sprintf("T-%s", HexToShortString(a.TargetHex))
+		}
+		return fmt.Sprintf("T-%s-%s", HexToShortString(a.TargetHex), getTerrainShortCode(a.TargetTerrain))
+	case *game.BuildHalflingsDwellingAction:
+		return HexToShortString(a.TargetHex)
 	case *game.UpgradeBuildingAction:
 		// UP-TH-C4
 		shortType := getBuildingShortCode(a.NewBuildingType)
 		return fmt.Sprintf("UP-%s-%s", shortType, HexToShortString(a.TargetHex))
 	case *game.PassAction:
+		if a.BonusCard != nil && a.CardCoins > 0 {
+			// PASS-BON-4C+1C
+			return fmt.Sprintf("PASS-%s+%dC", getBonusCardShortCode(*a.BonusCard), a.CardCoins)
+		}
 		if a.BonusCard != nil {
 			return fmt.Sprintf("PASS-%s", getBonusCardShortCode(*a.BonusCard))
 		}
@@ -516,8 +553,42 @@ func generateActionCode(action game.Action, homeTerrain models.TerrainType) stri
 		return a.Tile
 	case *LogSpecialAction:
 		return a.ActionCode
+	case *game.SpecialAction:
+		// ACT-SH-<track>, as the Snellman converter writes the Auren stronghold
+		if a.ActionType == game.SpecialActionAurenCultAdvance && a.CultTrack != nil {
+			return "ACT-SH-" + getCultShortCode(*a.CultTrack)
+		}
+		// ACT-SH-T-<coord>, with the coord repeated when the sandstorm builds
+		if a.ActionType == game.SpecialActionNomadsSandstorm && a.TargetHex != nil {
+			code := "ACT-SH-T-" + HexToShortString(*a.TargetHex)
+			if a.BuildDwelling {
+				code += "." + HexToShortString(*a.TargetHex)
+			}
+			return code
+		}
+		// ACT-SH-S-<coord>, followed by the dwelling as its own build token
+		if a.ActionType == game.SpecialActionGiantsTransform && a.TargetHex != nil {
+			code := "ACT-SH-S-" + HexToShortString(*a.TargetHex)
+			if a.BuildDwelling {
+				code += "." + HexToShortString(*a.TargetHex)
+			}
+			return code
+		}
+		// ACT-SH-2X followed by both sub-actions, chained like the Snellman converter's rows
+		if a.ActionType == game.SpecialActionChaosMagiciansDoubleTurn && a.FirstAction != nil && a.SecondAction != nil {
+			return strings.Join([]string{
+				"ACT-SH-2X",
+				generateActionCode(a.FirstAction, homeTerrain),
+				generateActionCode(a.SecondAction, homeTerrain),
+			}, ".")
+		}
+		return fmt.Sprintf("UNKNOWN(%T)", action)
 	case *LogConversionAction:
-		return generateConversionCode(a)
+		return ConversionCode(a)
+	case *game.UseDarklingsPriestOrdinationAction:
+		// Written as the worker-to-priest conversion it is, including "C0W:0P"
+		// so that declining the ordination is recorded too.
+		return fmt.Sprintf("C%dW:%dP", a.WorkersToConvert, a.WorkersToConvert)
 	case *LogTownAction:
 		return fmt.Sprintf("TW%dVP", a.VP)
 	case *LogBonusCardSelectionAction:
@@ -701,7 +772,8 @@ func getBonusCardShortCode(t game.BonusCardType) string {
 	return "?"
 }
 
-func generateConversionCode(a *LogConversionAction) string {
+// ConversionCode returns the concise notation of a conversion, e.g. "C1PW:1C".
+func ConversionCode(a *LogConversionAction) string {
 	// Format: C[Cost]:[Reward]
 	// Order: P, W, PW, VP, C
 	costStr := formatResources(a.Cost)
@@ -3,6 +3,8 @@ package notation
 import (
 	"strings"
 	"testing"
+
+	"github.com/lukev/tm_server/internal/models"
 )
 
 func TestGenerateConciseLog_ReanchorsDistinctLeechSourcesWithPlainLeechTokens(t *testing.T) {
@@ -54,7 +56,7 @@ UP-TH-F7     |              |              |
 		if !ok {
 			t.Fatalf("no previous non-leech token for leech %q at index %d\n%s", leech.FromPlayerID, i, strings.Join(logStrings, "\n"))
 		}
-		if normalizeFactionNameForMatching(prevFaction) != normalizeFactionNameForMatching(leech.FromPlayerID) {
+		if models.FactionKey(prevFaction) != models.FactionKey(leech.FromPlayerID) {
 			t.Fatalf("leech source mismatch at index %d: previous non-leech=%q expected=%q\n%s", i, prevFaction, leech.FromPlayerID, strings.Join(logStrings, "\n"))
 		}
 		checked++
@@ -110,7 +112,7 @@ func TestGenerateConciseLog_SnellmanRound1_MermaidsLeechesAnchorToCultistsThenDa
 		if !ok {
 			t.Fatalf("no previous non-leech token for leech %q at index %d\n%s", leech.FromPlayerID, i, strings.Join(logStrings, "\n"))
 		}
-		if normalizeFactionNameForMatching(prevFaction) != normalizeFactionNameForMatching(leech.FromPlayerID) {
+		if models.FactionKey(prevFaction) != models.FactionKey(leech.FromPlayerID) {
 			t.Fatalf("leech source mismatch at index %d: previous non-leech=%q expected=%q\n%s", i, prevFaction, leech.FromPlayerID, strings.Join(logStrings, "\n"))
 		}
 		if tok := tokenAt(logStrings, loc.LineIndex, loc.ColumnIndex, len(columns)); tok != "L" {
@@ -61,7 +61,7 @@ func parseConciseLog(content string, strict bool) ([]LogItem, error) {
 		}
 
 		// Parse Game Settings
-		if strings.HasPrefix(line, "Game:") || strings.HasPrefix(line, "MiniExpansions:") || strings.HasPrefix(line, "ScoringTiles:") || strings.HasPrefix(line, "BonusCards:") {
+		if strings.HasPrefix(line, "Game:") || strings.HasPrefix(line, "MiniExpansions:") || strings.HasPrefix(line, "ScoringTiles:") || strings.HasPrefix(line, "BonusCards:") || strings.HasPrefix(line, "RemovedBonusCards:") || strings.HasPrefix(line, "Seed:") {
 			parts := strings.SplitN(line, ":", 2)
 			if len(parts) == 2 {
 				key := strings.TrimSpace(parts[0])
@@ -84,8 +84,11 @@ func parseConciseLog(content string, strict bool) ([]LogItem, error) {
 				pParts := strings.Split(part, ":")
 				if len(pParts) >= 1 {
 					playerName := strings.TrimSpace(pParts[0])
-					// Add Player setting: Player:Halflings -> Halflings
-					settings["Player:"+playerName] = playerName
+					// Add Player setting: Player:Halflings -> Halflings, unless
+					// PlayerNames already named the faction's player.
+					if !hasNamedPlayer(settings, playerName) {
+						settings["Player:"+playerName] = playerName
+					}
 					// fmt.Printf("DEBUG: Parsed player from StartingVPs: %s\n", playerName)
 
 					// Add StartingVP setting
@@ -97,6 +100,33 @@ func parseConciseLog(content string, strict bool) ([]LogItem, error) {
 			continue
 		}
 
+		if strings.HasPrefix(line, "PlayerNames:") {
+			// PlayerNames: Cultists:jere, Darklings:canetts
+			for _, part := range strings.Split(strings.TrimPrefix(line, "PlayerNames:"), ",") {
+				faction, name, ok := strings.Cut(part, ":")
+				faction, name = strings.TrimSpace(faction), strings.TrimSpace(name)
+				if !ok || faction == "" || name == "" {
+					continue
+				}
+				if name != faction {
+					delete(settings, "Player:"+faction)
+				}
+				settings["Player:"+name] = faction
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line, "Handicaps:") {
+			// Handicaps: Halflings:5VP 2C, Auren:1W
+			for _, part := range strings.Split(strings.TrimPrefix(line, "Handicaps:"), ",") {
+				faction, handicap, ok := strings.Cut(part, ":")
+				if ok && strings.TrimSpace(faction) != "" {
+					settings["Handicap:"+strings.TrimSpace(faction)] = strings.TrimSpace(handicap)
+				}
+			}
+			continue
+		}
+
 		// Emit settings if we have them and haven't emitted yet
 		if len(settings) > 0 && len(items) == 0 {
 			items = append(items, GameSettingsItem{Settings: settings})
@@ -205,26 +235,19 @@ func parseConciseLog(content string, strict bool) ([]LogItem, error) {
 	return items, nil
 }
 
-func isFactionHeaderName(playerID string) bool {
-	switch strings.ToLower(strings.TrimSpace(playerID)) {
-	case "nomads",
-		"fakirs",
-		"chaos magicians",
-		"giants",
-		"swarmlings",
-		"mermaids",
-		"witches",
-		"auren",
-		"halflings",
-		"cultists",
-		"alchemists",
-		"darklings",
-		"engineers",
-		"dwarves":
-		return true
-	default:
-		return false
+// hasNamedPlayer reports whether settings already seat a player under a name
+// other than faction's own.
+func hasNamedPlayer(settings map[string]string, faction string) bool {
+	for key, value := range settings {
+		if name, ok := strings.CutPrefix(key, "Player:"); ok && value == faction && name != faction {
+			return true
+		}
 	}
+	return false
+}
+
+func isFactionHeaderName(playerID string) bool {
+	return models.FactionTypeFromString(playerID).IsBaseGame()
 }
 
 func parseActionCode(playerID, code string) (game.Action, error) {
@@ -285,12 +308,20 @@ func parseActionCodeWithContext(playerID, code string, inCompound bool) (game.Ac
 	// Simple parser based on prefixes
 	if strings.HasPrefix(upperCode, "PASS") {
 		var bonusCard *game.BonusCardType
+		cardCoins := 0
 		if strings.HasPrefix(upperCode, "PASS-") {
 			cardCode := strings.TrimPrefix(upperCode, "PASS-")
+			// PASS-BON-4C+1C: the coins lying on the card.
+			if m := regexp.MustCompile(`\+(\d+)C$`).FindStringSubmatch(cardCode); m != nil {
+				cardCode = strings.TrimSuffix(cardCode, m[0])
+				cardCoins, _ = strconv.Atoi(m[1])
+			}
 			cardType := ParseBonusCardCode(cardCode)
 			bonusCard = &cardType
 		}
-		return game.NewPassAction(playerID, bonusCard), nil
+		pass := game.NewPassAction(playerID, bonusCard)
+		pass.CardCoins = cardCoins
+		return pass, nil
 	}
 	if strings.HasPrefix(upperCode, "BON") {
 		// BON1, BON2 etc.
@@ -429,16 +460,11 @@ func parseActionCodeWithContext(playerID, code string, inCompound bool) (game.Ac
 		if len(parts) == 2 {
 			cost := parseResourceString(parts[0])
 			reward := parseResourceString(parts[1])
-			if inCompound &&
-				strings.EqualFold(strings.TrimSpace(playerID), "darklings") &&
-				len(cost) == 1 && len(reward) == 1 &&
-				cost[models.ResourceWorker] > 0 &&
-				reward[models.ResourcePriest] > 0 &&
-				cost[models.ResourceWorker] == reward[models.ResourcePriest] &&
-				len(reward) == 1 {
+			if workers, ok := darklingsOrdinationWorkers(cost, reward); inCompound && ok &&
+				strings.EqualFold(strings.TrimSpace(playerID), "darklings") {
 				return &game.UseDarklingsPriestOrdinationAction{
 					BaseAction:       game.BaseAction{Type: game.ActionUseDarklingsPriestOrdination, PlayerID: playerID},
-					WorkersToConvert: cost[models.ResourceWorker],
+					WorkersToConvert: workers,
 				}, nil
 			}
 			if !inCompound {
@@ -568,6 +594,9 @@ func parseActionCodeWithContext(playerID, code string, inCompound bool) (game.Ac
 		targetTerrain := models.TerrainTypeUnknown
 		if len(parts) > 2 {
 			targetTerrain = parseTerrainShortCode(parts[2])
+			if targetTerrain == models.TerrainTypeUnknown {
+				return nil, fmt.Errorf("invalid transform terrain in %s: %s", code, parts[2])
+			}
 		}
 
 		return game.NewTransformAndBuildAction(playerID, hex, false, targetTerrain), nil
@@ -677,6 +706,19 @@ func isCoord(s string) bool {
 	_, err := ConvertLogCoordToAxial(upper)
 	return err == nil
 }
+
+// darklingsOrdinationWorkers reports whether a conversion trades workers for
+// as many priests, as Darklings priest ordination does. "C0W:0P" is an
+// ordination that converted nothing.
+func darklingsOrdinationWorkers(cost, reward map[models.ResourceType]int) (int, bool) {
+	workers, paysWorkers := cost[models.ResourceWorker]
+	priests, gainsPriests := reward[models.ResourcePriest]
+	if len(cost) != 1 || len(reward) != 1 || !paysWorkers || !gainsPriests || workers != priests {
+		return 0, false
+	}
+	return workers, true
+}
+
 func parseResourceString(s string) map[models.ResourceType]int {
 	res := make(map[models.ResourceType]int)
 	s = strings.ToUpper(strings.TrimSpace(s))
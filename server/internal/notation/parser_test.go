@@ -7,6 +7,9 @@ import (
 	"testing"
 
 	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/game/factions"
+	"github.com/lukev/tm_server/internal/models"
 )
 
 func TestParseActionCode_RecognizesSpecialACTCodes(t *testing.T) {
@@ -173,6 +176,189 @@ func TestParseActionCode_DarklingsWorkerToPriestConversion(t *testing.T) {
 	}
 }
 
+func TestGenerateActionCode_DarklingsOrdinationRoundTrips(t *testing.T) {
+	for _, workers := range []int{0, 2} {
+		code := generateActionCode(&LogCompoundAction{Actions: []game.Action{
+			&LogBurnAction{PlayerID: "Darklings", Amount: 3},
+			&game.UseDarklingsPriestOrdinationAction{
+				BaseAction:       game.BaseAction{Type: game.ActionUseDarklingsPriestOrdination, PlayerID: "Darklings"},
+				WorkersToConvert: workers,
+			},
+		}}, models.TerrainTypeUnknown)
+		if want := fmt.Sprintf("BURN3.C%dW:%dP", workers, workers); code != want {
+			t.Fatalf("generateActionCode() = %q, want %q", code, want)
+		}
+
+		action, err := parseActionCode("Darklings", code)
+		if err != nil {
+			t.Fatalf("parseActionCode(%q) error = %v", code, err)
+		}
+		compound, ok := action.(*LogCompoundAction)
+		if !ok || len(compound.Actions) != 2 {
+			t.Fatalf("parseActionCode(%q) = %#v, want a two-part compound", code, action)
+		}
+		ord, ok := compound.Actions[1].(*game.UseDarklingsPriestOrdinationAction)
+		if !ok || ord.WorkersToConvert != workers {
+			t.Fatalf("parseActionCode(%q) second action = %#v, want an ordination of %d workers", code, compound.Actions[1], workers)
+		}
+	}
+}
+
+func TestGenerateActionCode_PassCardCoinsRoundTrip(t *testing.T) {
+	bonus := game.BonusCardCultAdvance
+	pass := game.NewPassAction("Witches", &bonus)
+	pass.CardCoins = 2
+	code := generateActionCode(pass, models.TerrainTypeUnknown)
+	if code != "PASS-BON-4C+2C" {
+		t.Fatalf("generateActionCode() = %q, want PASS-BON-4C+2C", code)
+	}
+
+	action, err := parseActionCode("Witches", code)
+	if err != nil {
+		t.Fatalf("parseActionCode(%q) error = %v", code, err)
+	}
+	parsed, ok := action.(*game.PassAction)
+	if !ok || parsed.BonusCard == nil || *parsed.BonusCard != bonus || parsed.CardCoins != 2 {
+		t.Fatalf("parseActionCode(%q) = %#v, want a pass taking BON-4C with 2 coins", code, action)
+	}
+
+	pass.CardCoins = 0
+	if code := generateActionCode(pass, models.TerrainTypeUnknown); code != "PASS-BON-4C" {
+		t.Fatalf("generateActionCode() without coins = %q, want PASS-BON-4C", code)
+	}
+}
+
+func TestGenerateActionCode_AurenStrongholdRoundTrips(t *testing.T) {
+	code := generateActionCode(game.NewAurenCultAdvanceAction("Auren", game.CultWater), models.TerrainTypeUnknown)
+	if code != "ACT-SH-W" {
+		t.Fatalf("generateActionCode() = %q, want ACT-SH-W", code)
+	}
+
+	gs := game.NewGameState()
+	if err := gs.AddPlayer("Auren", factions.NewAuren()); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+	player := gs.GetPlayer("Auren")
+	player.HasStrongholdAbility = true
+	player.CultPositions[game.CultWater] = 2
+
+	action, err := parseActionCode("Auren", code)
+	if err != nil {
+		t.Fatalf("parseActionCode(%q) error = %v", code, err)
+	}
+	if err := action.Execute(gs); err != nil {
+		t.Fatalf("Execute(%q) error = %v", code, err)
+	}
+	if player.CultPositions[game.CultWater] != 4 || !player.SpecialActionsUsed[game.SpecialActionAurenCultAdvance] {
+		t.Fatalf("after %q Water = %d, used = %v; want 4 and used", code, player.CultPositions[game.CultWater], player.SpecialActionsUsed[game.SpecialActionAurenCultAdvance])
+	}
+}
+
+func TestGenerateActionCode_NomadsSandstormRoundTrips(t *testing.T) {
+	strongholdHex := board.NewHex(0, 1)
+	targetHex := board.NewHex(1, 0)
+	coord := HexToShortString(targetHex)
+
+	if code := generateActionCode(game.NewNomadsSandstormAction("Nomads", targetHex, false), models.TerrainDesert); code != "ACT-SH-T-"+coord {
+		t.Fatalf("generateActionCode(no build) = %q, want ACT-SH-T-%s", code, coord)
+	}
+	code := generateActionCode(game.NewNomadsSandstormAction("Nomads", targetHex, true), models.TerrainDesert)
+	if code != "ACT-SH-T-"+coord+"."+coord {
+		t.Fatalf("generateActionCode(build) = %q, want ACT-SH-T-%s.%s", code, coord, coord)
+	}
+
+	gs := game.NewGameState()
+	if err := gs.AddPlayer("Nomads", factions.NewNomads()); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+	player := gs.GetPlayer("Nomads")
+	player.Resources.Coins = 20
+	player.Resources.Workers = 20
+	player.HasStrongholdAbility = true
+	gs.Map.TransformTerrain(strongholdHex, models.TerrainDesert)
+	gs.Map.PlaceBuilding(strongholdHex, &models.Building{
+		Type: models.BuildingStronghold, Faction: models.FactionNomads, PlayerID: "Nomads", PowerValue: 3,
+	})
+	gs.Map.TransformTerrain(targetHex, models.TerrainSwamp)
+
+	action, err := parseActionCode("Nomads", code)
+	if err != nil {
+		t.Fatalf("parseActionCode(%q) error = %v", code, err)
+	}
+	if err := action.Execute(gs); err != nil {
+		t.Fatalf("Execute(%q) error = %v", code, err)
+	}
+	mapHex := gs.Map.GetHex(targetHex)
+	if mapHex.Terrain != models.TerrainDesert || mapHex.Building == nil || mapHex.Building.Type != models.BuildingDwelling {
+		t.Fatalf("after %q hex = %v with %+v, want desert with a dwelling", code, mapHex.Terrain, mapHex.Building)
+	}
+}
+
+func TestGenerateActionCode_GiantsTransformWithDwellingRoundTrips(t *testing.T) {
+	strongholdHex := board.NewHex(0, 1)
+	targetHex := board.NewHex(1, 0)
+	coord := HexToShortString(targetHex)
+
+	code := generateActionCode(game.NewGiantsTransformAction("Giants", targetHex, true), models.TerrainWasteland)
+	if code != "ACT-SH-S-"+coord+"."+coord {
+		t.Fatalf("generateActionCode() = %q, want ACT-SH-S-%s.%s", code, coord, coord)
+	}
+
+	gs := game.NewGameState()
+	if err := gs.AddPlayer("Giants", factions.NewGiants()); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+	player := gs.GetPlayer("Giants")
+	player.Resources.Coins = 20
+	player.Resources.Workers = 20
+	player.HasStrongholdAbility = true
+	gs.Map.TransformTerrain(strongholdHex, models.TerrainWasteland)
+	gs.Map.PlaceBuilding(strongholdHex, &models.Building{
+		Type: models.BuildingStronghold, Faction: models.FactionGiants, PlayerID: "Giants", PowerValue: 3,
+	})
+	gs.Map.TransformTerrain(targetHex, models.TerrainLake)
+
+	action, err := parseActionCode("Giants", code)
+	if err != nil {
+		t.Fatalf("parseActionCode(%q) error = %v", code, err)
+	}
+	if err := action.Execute(gs); err != nil {
+		t.Fatalf("Execute(%q) error = %v", code, err)
+	}
+	mapHex := gs.Map.GetHex(targetHex)
+	if mapHex.Terrain != models.TerrainWasteland || mapHex.Building == nil || mapHex.Building.Type != models.BuildingDwelling {
+		t.Fatalf("after %q hex = %v with %+v, want wasteland with a dwelling", code, mapHex.Terrain, mapHex.Building)
+	}
+}
+
+func TestGenerateActionCode_ChaosMagiciansDoubleTurnIsCompound(t *testing.T) {
+	hex := board.NewHex(0, 1)
+	action := game.NewChaosMagiciansDoubleTurnAction("Chaos Magicians",
+		game.NewAdvanceDiggingAction("Chaos Magicians"),
+		game.NewTransformAndBuildAction("Chaos Magicians", hex, true, models.TerrainTypeUnknown))
+
+	code := generateActionCode(action, models.TerrainWasteland)
+	want := "ACT-SH-2X.+DIG." + HexToShortString(hex)
+	if code != want {
+		t.Fatalf("generateActionCode() = %q, want %q", code, want)
+	}
+
+	parsed, err := parseActionCode("Chaos Magicians", code)
+	if err != nil {
+		t.Fatalf("parseActionCode(%q) error = %v", code, err)
+	}
+	compound, ok := parsed.(*LogCompoundAction)
+	if !ok {
+		t.Fatalf("parseActionCode(%q) type = %T, want *LogCompoundAction", code, parsed)
+	}
+	if len(compound.Actions) != 3 {
+		t.Fatalf("parseActionCode(%q) parsed %d actions, want 3", code, len(compound.Actions))
+	}
+	if marker, ok := compound.Actions[0].(*LogSpecialAction); !ok || marker.ActionCode != "ACT-SH-2X" {
+		t.Fatalf("first parsed action = %#v, want ACT-SH-2X marker", compound.Actions[0])
+	}
+}
+
 func TestParseActionCode_NonDarklingsWorkerToPriestConversionStaysLogConversion(t *testing.T) {
 	action, err := parseActionCode("Cultists", "BURN3.C3W:3P")
 	if err != nil {
@@ -316,3 +502,38 @@ BADTOKEN     |`
 		t.Fatalf("invalid parse error location: line=%d column=%d", parseErr.Line, parseErr.Column)
 	}
 }
+
+func TestParseConciseLog_PlayerNamesReplaceFactionPlayers(t *testing.T) {
+	for _, header := range []string{
+		"StartingVPs: Cultists:20, Engineers:20\nPlayerNames: Cultists:jere, Engineers:canetts",
+		"PlayerNames: Cultists:jere, Engineers:canetts\nStartingVPs: Cultists:20, Engineers:20",
+	} {
+		input := "Game: Base\n" + header + `
+
+Round 1
+TurnOrder: Cultists, Engineers
+------------------------------------------------------------
+Cultists     | Engineers
+------------------------------------------------------------
+UP-TH-E6     |`
+
+		items, err := ParseConciseLogStrict(input)
+		if err != nil {
+			t.Fatalf("ParseConciseLogStrict() error = %v", err)
+		}
+		settings, ok := items[0].(GameSettingsItem)
+		if !ok {
+			t.Fatalf("items[0] = %T, want GameSettingsItem", items[0])
+		}
+		for key, want := range map[string]string{"Player:jere": "Cultists", "Player:canetts": "Engineers", "Player:Cultists": "", "Player:Engineers": ""} {
+			if got := settings.Settings[key]; got != want {
+				t.Fatalf("settings[%q] = %q, want %q (header %q)", key, got, want, header)
+			}
+		}
+
+		logStrings, _ := GenerateConciseLog(items)
+		if generated := strings.Join(logStrings, "\n"); !strings.Contains(generated, "PlayerNames: Cultists:jere, Engineers:canetts") {
+			t.Fatalf("generated log lost the player names:\n%s", generated)
+		}
+	}
+}
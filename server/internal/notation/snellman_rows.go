@@ -0,0 +1,106 @@
+package notation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Kinds of Snellman ledger rows the converter recognizes and leaves out of
+// the concise log.
+const (
+	// SnellmanRowWait is a player waiting for the others ("wait").
+	SnellmanRowWait = "wait"
+	// SnellmanRowDone is a player ending their turn without acting ("done").
+	SnellmanRowDone = "done"
+	// SnellmanRowSetup is the "setup" row opening each faction's ledger.
+	SnellmanRowSetup = "setup"
+	// SnellmanRowIncome is an income row; the engine computes income itself.
+	SnellmanRowIncome = "income"
+	// SnellmanRowScoring is an end-of-game scoring row: "+8vp for FIRE",
+	// "+18vp for network" or score_resources.
+	SnellmanRowScoring = "scoring"
+	// SnellmanRowNote is a bracketed ledger note such as "[opponent accepted
+	// power]"; the leech itself is logged on its own row.
+	SnellmanRowNote = "note"
+	// SnellmanRowAdmin is a correction entered by a game administrator. It is
+	// ignored, so the replay may no longer match the ledger after it.
+	SnellmanRowAdmin = "admin"
+)
+
+// ConversionWarning is a ledger row the Snellman converter left out of its
+// output although it may have been part of the game: an unrecognized action
+// or an admin correction.
+type ConversionWarning struct {
+	// Line is the 1-based line of the row in the ledger.
+	Line    int    `json:"line"`
+	Faction string `json:"faction,omitempty"`
+	Row     string `json:"row"`
+	Message string `json:"message"`
+}
+
+func (w ConversionWarning) String() string {
+	return fmt.Sprintf("line %d: %s: %s", w.Line, w.Message, w.Row)
+}
+
+var (
+	snellmanScoringRowPattern = regexp.MustCompile(`(?i)^[+-]?\d+\s*vp\s+for\s+\S`)
+	snellmanAdminRowPattern   = regexp.MustCompile(`(?i)^\[?admin\b`)
+)
+
+// classifySnellmanRow reports which housekeeping row action is, if any. The
+// action is the row's last non-empty column, as extractSnellmanAction sees it.
+func classifySnellmanRow(action string) (string, bool) {
+	action = strings.TrimSpace(action)
+	lower := strings.ToLower(strings.TrimSuffix(action, "."))
+	switch lower {
+	case "wait":
+		return SnellmanRowWait, true
+	case "done":
+		return SnellmanRowDone, true
+	case "setup":
+		return SnellmanRowSetup, true
+	case "score_resources":
+		return SnellmanRowScoring, true
+	}
+	if isSnellmanIncomeMetaAction(lower) {
+		return SnellmanRowIncome, true
+	}
+	if snellmanScoringRowPattern.MatchString(action) {
+		return SnellmanRowScoring, true
+	}
+	if snellmanAdminRowPattern.MatchString(action) {
+		return SnellmanRowAdmin, true
+	}
+	if strings.HasPrefix(action, "[") && strings.HasSuffix(action, "]") {
+		return SnellmanRowNote, true
+	}
+	return "", false
+}
+
+// trimSnellmanTurnMarkers drops the "wait" and "done" segments a player
+// typed after their action, as in "+FIRE. wait".
+func trimSnellmanTurnMarkers(action string) string {
+	segments := strings.Split(action, ".")
+	for len(segments) > 1 {
+		kind, _ := classifySnellmanRow(segments[len(segments)-1])
+		if kind != SnellmanRowWait && kind != SnellmanRowDone {
+			break
+		}
+		segments = segments[:len(segments)-1]
+	}
+	return strings.TrimSpace(strings.Join(segments, "."))
+}
+
+// snellmanWarnings collects the warnings of one conversion; a nil collector
+// discards them.
+type snellmanWarnings struct {
+	list []ConversionWarning
+}
+
+func (w *snellmanWarnings) add(line int, faction, row, message string) {
+	if w == nil {
+		return
+	}
+	w.list = append(w.list, ConversionWarning{Line: line, Faction: faction, Row: row, Message: message})
+}
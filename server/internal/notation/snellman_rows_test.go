@@ -0,0 +1,139 @@
+package notation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClassifySnellmanRow(t *testing.T) {
+	tests := []struct {
+		action string
+		want   string
+	}{
+		{"wait", SnellmanRowWait},
+		{"Wait", SnellmanRowWait},
+		{"done", SnellmanRowDone},
+		{"setup", SnellmanRowSetup},
+		{"other_income_for_faction", SnellmanRowIncome},
+		{"cult_income_for_faction", SnellmanRowIncome},
+		{"score_resources", SnellmanRowScoring},
+		{"+8vp for FIRE", SnellmanRowScoring},
+		{"+18vp for network", SnellmanRowScoring},
+		{"[opponent accepted power]", SnellmanRowNote},
+		{"[all opponents declined power]", SnellmanRowNote},
+		{"admin: set C to 12", SnellmanRowAdmin},
+		{"[admin correction]", SnellmanRowAdmin},
+		{"build E7", ""},
+		{"+FIRE. wait", ""},
+		{"action BON1. transform I10 to gray", ""},
+	}
+	for _, tt := range tests {
+		got, ok := classifySnellmanRow(tt.action)
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("classifySnellmanRow(%q) = %q, %v; want %q", tt.action, got, ok, tt.want)
+		}
+	}
+}
+
+func TestExtractSnellmanAction_HousekeepingRowsKeepTheirColumn(t *testing.T) {
+	tests := []struct {
+		name string
+		row  string
+		want string
+	}{
+		{
+			name: "income marker is not read as an action",
+			row:  "engineers\t\t20 VP\t+6\t16 C\t+2\t4 W\t\t0 P\t\t3/9/0 PW\t\t0/0/0/0\t\tother_income_for_faction",
+			want: "other_income_for_faction",
+		},
+		{
+			name: "final cult scoring does not fall through to the priest column",
+			row:  "cultists\t+8\t121 VP\t\t0 C\t\t1 W\t\t3 P\t\t0/4/0 PW\t\t10/8/9/10\t\t+8vp for FIRE",
+			want: "+8vp for FIRE",
+		},
+		{
+			name: "row without an action column yields nothing",
+			row:  "cultists\t+8\t102 VP\t\t2 C\t\t4 W\t\t1 P\t\t0/2/4 PW\t\t10/6/8/10\t\t",
+			want: "",
+		},
+		{
+			name: "delta-only row yields nothing",
+			row:  "darklings\t\t88 VP\t+5\t9 C\t+7\t11 W\t+4\t4 P\t+1\t0/3/5 PW\t\t2/3/10/1",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractSnellmanAction(strings.Split(tt.row, "\t")); got != tt.want {
+				t.Fatalf("extractSnellmanAction() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrimSnellmanTurnMarkers(t *testing.T) {
+	for action, want := range map[string]string{
+		"+FIRE. wait":            "+FIRE",
+		"pass BON3. done":        "pass BON3",
+		"build E7":               "build E7",
+		"convert 1PW to 1C.":     "convert 1PW to 1C.",
+		"+EARTH. wait. done":     "+EARTH",
+		"dig 1. build A1":        "dig 1. build A1",
+		"upgrade E5 to TP. Wait": "upgrade E5 to TP",
+	} {
+		if got := trimSnellmanTurnMarkers(action); got != want {
+			t.Errorf("trimSnellmanTurnMarkers(%q) = %q, want %q", action, got, want)
+		}
+	}
+}
+
+func TestConvertSnellmanToConciseWithWarnings_IgnoresHousekeepingRows(t *testing.T) {
+	header := []string{
+		"option strict-leech\tshow history",
+		"engineers\t\t20 VP\t\t10 C\t\t2 W\t\t0 P\t\t3/9/0 PW\t\t0/0/0/0\t\tsetup",
+		"witches\t\t20 VP\t\t15 C\t\t3 W\t\t0 P\t\t5/7/0 PW\t\t0/0/0/2\t\tsetup",
+		"Round 1, turn 1\tshow history",
+		"engineers\t\t20 VP\t\t8 C\t\t1 W\t\t0 P\t\t3/9/0 PW\t\t0/0/0/0\t\tbuild E7",
+	}
+	clean := append(append([]string(nil), header...),
+		"witches\t\t20 VP\t\t13 C\t\t2 W\t\t0 P\t\t5/7/0 PW\t\t0/0/0/2\t\tbuild F4",
+	)
+	noisy := append(append([]string(nil), header...),
+		"engineers\t\t20 VP\t\t8 C\t\t1 W\t\t0 P\t\t3/9/0 PW\t\t0/0/0/0\t\t[opponent accepted power]",
+		"witches\t\t20 VP\t\t15 C\t\t3 W\t\t0 P\t\t5/7/0 PW\t\t0/0/0/2\t\twait",
+		"admin\tset engineers coins to 8",
+		"witches\t\t20 VP\t\t13 C\t\t2 W\t\t0 P\t\t5/7/0 PW\t\t0/0/0/2\t\tbuild F4. done",
+		"engineers\t\t20 VP\t\t8 C\t\t1 W\t\t0 P\t\t3/9/0 PW\t\t0/0/0/0\t\tfrobnicate E7",
+		"engineers\t+8\t28 VP\t\t8 C\t\t1 W\t\t0 P\t\t3/9/0 PW\t\t0/0/0/0\t\t+8vp for FIRE",
+		"engineers\t\t28 VP\t\t0 C\t\t1 W\t\t0 P\t\t3/9/0 PW\t\t0/0/0/0\t\tscore_resources",
+	)
+
+	want, err := ConvertSnellmanToConcise(strings.Join(clean, "\n"))
+	if err != nil {
+		t.Fatalf("convert clean ledger: %v", err)
+	}
+	got, warnings, err := ConvertSnellmanToConciseWithWarnings(strings.Join(noisy, "\n"))
+	if err != nil {
+		t.Fatalf("convert ledger with housekeeping rows: %v", err)
+	}
+	if got != want {
+		t.Fatalf("housekeeping rows changed the output:\n%s\nwant:\n%s", got, want)
+	}
+
+	wantWarnings := []ConversionWarning{
+		{Line: 8, Row: "admin\tset engineers coins to 8", Message: "admin correction ignored"},
+		{Line: 10, Faction: "engineers", Row: "frobnicate E7", Message: "unrecognized action dropped"},
+	}
+	if !reflect.DeepEqual(warnings, wantWarnings) {
+		t.Fatalf("warnings = %+v, want %+v", warnings, wantWarnings)
+	}
+
+	_, replayWarnings, err := ConvertSnellmanToConciseForReplayWithWarnings(strings.Join(noisy, "\n"))
+	if err != nil {
+		t.Fatalf("convert ledger for replay: %v", err)
+	}
+	if !reflect.DeepEqual(replayWarnings, wantWarnings) {
+		t.Fatalf("replay warnings = %+v, want %+v", replayWarnings, wantWarnings)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/lukev/tm_server/internal/game"
 	"github.com/lukev/tm_server/internal/game/factions"
 	"github.com/lukev/tm_server/internal/models"
 )
@@ -58,7 +59,16 @@ func IsSnellmanTextFormat(content string) bool {
 // ConvertSnellmanToConcise converts Snellman's tab-delimited ledger format to Concise Notation format.
 // This is primarily a display-oriented conversion and may apply layout heuristics.
 func ConvertSnellmanToConcise(content string) (string, error) {
-	return convertSnellmanToConcise(content, false, false)
+	return convertSnellmanToConcise(content, false, false, nil)
+}
+
+// ConvertSnellmanToConciseWithWarnings is ConvertSnellmanToConcise, also
+// returning the ledger rows it left out although they may have been part of
+// the game. Housekeeping rows (wait, done, income, scoring) are not reported.
+func ConvertSnellmanToConciseWithWarnings(content string) (string, []ConversionWarning, error) {
+	var warnings snellmanWarnings
+	concise, err := convertSnellmanToConcise(content, false, false, &warnings)
+	return concise, warnings.list, err
 }
 
 // ConvertSnellmanToConciseForReplay converts Snellman's tab-delimited ledger format to Concise
@@ -66,10 +76,23 @@ func ConvertSnellmanToConcise(content string) (string, error) {
 // row becomes (at most) one concise token placed on its own grid row, so replay execution order
 // matches the Snellman ledger.
 func ConvertSnellmanToConciseForReplay(content string) (string, error) {
-	return convertSnellmanToConcise(content, true, true)
+	return convertSnellmanToConcise(content, true, true, nil)
+}
+
+// ConvertSnellmanToConciseForReplayWithWarnings is
+// ConvertSnellmanToConciseForReplay, also returning the warnings described at
+// ConvertSnellmanToConciseWithWarnings.
+func ConvertSnellmanToConciseForReplayWithWarnings(content string) (string, []ConversionWarning, error) {
+	var warnings snellmanWarnings
+	concise, err := convertSnellmanToConcise(content, true, true, &warnings)
+	return concise, warnings.list, err
 }
 
-func convertSnellmanToConcise(content string, linear bool, enforceLinearSourceOrder bool) (string, error) {
+func convertSnellmanToConcise(content string, linear bool, enforceLinearSourceOrder bool, warnings *snellmanWarnings) (string, error) {
+	if err := DetectUnsupportedSnellmanFeatures(content); err != nil {
+		return "", err
+	}
+
 	var result []string
 	scanner := bufio.NewScanner(strings.NewReader(content))
 
@@ -78,8 +101,10 @@ func convertSnellmanToConcise(content string, linear bool, enforceLinearSourceOr
 	var removedBonusCards []string
 	var factions []string
 	factionVPs := make(map[string]int)
-	maintainPlayerOrder := false
-	variableTurnOrder := false
+	// Snellman's maintain-player-order only seats players in the order they
+	// are listed instead of randomly, which the listed faction order already
+	// reflects; the next round's order depends on variable-turn-order alone.
+	turnOrderPolicy := game.TurnOrderPolicyCyclicFromFirstPasser
 
 	// Round tracking
 	var rounds []*snellmanRoundData
@@ -118,7 +143,7 @@ func convertSnellmanToConcise(content string, linear bool, enforceLinearSourceOr
 		}
 	}
 	startRound := func(roundNum int) {
-		turnOrder := computeRoundTurnOrder(rounds, factions, maintainPlayerOrder, variableTurnOrder)
+		turnOrder := computeRoundTurnOrder(rounds, factions, turnOrderPolicy)
 		currentRound = &snellmanRoundData{
 			Number:    roundNum,
 			Rows:      []map[string]string{},
@@ -140,20 +165,26 @@ func convertSnellmanToConcise(content string, linear bool, enforceLinearSourceOr
 	scoringPattern := regexp.MustCompile(`(?i)^round\s+\d+\s+scoring\b`)
 	scoreCodePattern := regexp.MustCompile(`(?i)\b(SCORE\d+)\b`)
 	removedBonusPattern := regexp.MustCompile(`(?i)^removing\s+(?:tile|bonus\s+tile)\s+(BON\d+)\b`)
+	// "Player 2: canetts" seats a player; seats pick their factions in order.
+	playerLinePattern := regexp.MustCompile(`(?i)^player\s+(\d+):\s*([^\t]*[^\s])`)
+	playerNames := make(map[int]string)
 	seenScoringTiles := make(map[string]bool)
 
 	var savedLine string
+	lineNumber, savedLineNumber := 0, 0
 
 	for {
 		var line string
 		if savedLine != "" {
 			line = savedLine
+			lineNumber = savedLineNumber
 			savedLine = ""
 		} else {
 			if !scanner.Scan() {
 				break
 			}
 			line = scanner.Text()
+			lineNumber++
 		}
 
 		line = strings.TrimSpace(line)
@@ -162,15 +193,21 @@ func convertSnellmanToConcise(content string, linear bool, enforceLinearSourceOr
 			continue
 		}
 
-		// Parse scoring tiles
+		// Game options. Only the turn order one changes the conversion; the
+		// rest (email-notify and the like) are site settings.
 		if strings.HasPrefix(strings.ToLower(line), "option ") {
 			l := strings.ToLower(line)
-			if strings.Contains(l, "maintain-player-order") {
-				maintainPlayerOrder = true
-			}
 			if strings.Contains(l, "variable-turn-order") {
-				variableTurnOrder = true
+				turnOrderPolicy = game.TurnOrderPolicyPassOrder
 			}
+			continue
+		}
+
+		if m := playerLinePattern.FindStringSubmatch(line); m != nil {
+			if seat, err := strconv.Atoi(m[1]); err == nil {
+				playerNames[seat] = strings.TrimSpace(m[2])
+			}
+			continue
 		}
 
 		// Parse scoring tiles.
@@ -214,10 +251,11 @@ func convertSnellmanToConcise(content string, linear bool, enforceLinearSourceOr
 			for scanner.Scan() {
 				incomeLine := scanner.Text()
 				incomeLine = strings.TrimSpace(incomeLine)
+				lineNumber++
 
 				if incomeLine == "" || strings.HasPrefix(incomeLine, "Round ") || strings.HasPrefix(incomeLine, "Turn ") {
 					// End of income block, save line for next iteration
-					savedLine = incomeLine
+					savedLine, savedLineNumber = incomeLine, lineNumber
 					break
 				}
 
@@ -236,7 +274,7 @@ func convertSnellmanToConcise(content string, linear bool, enforceLinearSourceOr
 						embedded := strings.Contains(action, "other_income_for_faction") || strings.Contains(action, "cult_income_for_faction")
 						inIncomeInterlude = !embedded
 						inIncomeEmbeddedAction = embedded
-						savedLine = incomeLine
+						savedLine, savedLineNumber = incomeLine, lineNumber
 						break
 					}
 				}
@@ -263,6 +301,11 @@ func convertSnellmanToConcise(content string, linear bool, enforceLinearSourceOr
 			continue
 		}
 
+		if snellmanAdminRowPattern.MatchString(line) {
+			warnings.add(lineNumber, "", line, "admin correction ignored")
+			continue
+		}
+
 		// Parse faction action lines
 		parts := strings.Split(line, "\t")
 
@@ -293,6 +336,13 @@ func convertSnellmanToConcise(content string, linear bool, enforceLinearSourceOr
 		if action == "" {
 			continue
 		}
+		if kind, ok := classifySnellmanRow(action); ok {
+			if kind == SnellmanRowAdmin {
+				warnings.add(lineNumber, factionName, action, "admin correction ignored")
+			}
+			continue
+		}
+		action = trimSnellmanTurnMarkers(action)
 
 		appendCultBonus := func(cultBonus string) {
 			targetRows := make([]int, 0, 2)
@@ -374,6 +424,7 @@ func convertSnellmanToConcise(content string, linear bool, enforceLinearSourceOr
 		cultDelta := extractCultDelta(parts)
 		conciseAction := convertActionToConcise(action, factionName, inSetupPhase, cultDelta)
 		if conciseAction == "" {
+			warnings.add(lineNumber, factionName, action, "unrecognized action dropped")
 			continue
 		}
 		phasePrefix := ""
@@ -699,6 +750,9 @@ func convertSnellmanToConcise(content string, linear bool, enforceLinearSourceOr
 		}
 		result = append(result, fmt.Sprintf("StartingVPs: %s", strings.Join(vpParts, ", ")))
 	}
+	if names := snellmanFactionPlayerNames(factions, playerNames); len(names) > 0 {
+		result = append(result, fmt.Sprintf("PlayerNames: %s", strings.Join(names, ", ")))
+	}
 
 	result = append(result, "")
 
@@ -903,7 +957,7 @@ func enforceLeechSourceOrder(round *snellmanRoundData, columns []string, anchors
 			if isLeechOrDeclineToken(events[j].token) {
 				continue
 			}
-			if normalizeFactionNameForMatching(events[j].faction) == normalizeFactionNameForMatching(ev.sourceFaction) && actionMayTriggerLeech(events[j].token) {
+			if models.FactionKey(events[j].faction) == models.FactionKey(ev.sourceFaction) && actionMayTriggerLeech(events[j].token) {
 				req = j
 				break
 			}
@@ -915,7 +969,7 @@ func enforceLeechSourceOrder(round *snellmanRoundData, columns []string, anchors
 				if isLeechOrDeclineToken(events[j].token) {
 					continue
 				}
-				if normalizeFactionNameForMatching(events[j].faction) == normalizeFactionNameForMatching(ev.sourceFaction) && actionMayTriggerLeech(events[j].token) {
+				if models.FactionKey(events[j].faction) == models.FactionKey(ev.sourceFaction) && actionMayTriggerLeech(events[j].token) {
 					req = j
 					break
 				}
@@ -931,7 +985,7 @@ func enforceLeechSourceOrder(round *snellmanRoundData, columns []string, anchors
 				if isLeechOrDeclineToken(events[j].token) {
 					continue
 				}
-				if normalizeFactionNameForMatching(events[j].faction) == normalizeFactionNameForMatching(ev.sourceFaction) && actionMayTriggerLeech(events[j].token) {
+				if models.FactionKey(events[j].faction) == models.FactionKey(ev.sourceFaction) && actionMayTriggerLeech(events[j].token) {
 					req = j
 					break
 				}
@@ -978,12 +1032,12 @@ func enforceReplayLinearSourceLeechOrder(round *snellmanRoundData, columns []str
 		if !isLeechOrDeclineToken(ev.token) {
 			continue
 		}
-		sourceFaction := normalizeFactionNameForMatching(resolveLeechSourceFromEvent(ev))
+		sourceFaction := models.FactionKey(resolveLeechSourceFromEvent(ev))
 		if sourceFaction == "" {
 			continue
 		}
 		sourceIndex := resolveSourceEventIndex(events, i, sourceFaction)
-		bindingKey := normalizeFactionNameForMatching(ev.faction) + "\x00" + sourceFaction
+		bindingKey := models.FactionKey(ev.faction) + "\x00" + sourceFaction
 		if prevSourceIndex, ok := lastSourceByReactorAndSource[bindingKey]; ok && sourceIndex <= prevSourceIndex {
 			if nextSourceIndex := findNextSourceEventIndex(events, prevSourceIndex+1, sourceFaction); nextSourceIndex >= 0 {
 				sourceIndex = nextSourceIndex
@@ -1043,7 +1097,7 @@ func enforceReplayLinearSourceLeechOrder(round *snellmanRoundData, columns []str
 }
 
 func findNextSourceEventIndex(events []anchoredEvent, start int, sourceFaction string) int {
-	source := normalizeFactionNameForMatching(sourceFaction)
+	source := models.FactionKey(sourceFaction)
 	if source == "" {
 		return -1
 	}
@@ -1054,7 +1108,7 @@ func findNextSourceEventIndex(events []anchoredEvent, start int, sourceFaction s
 		if isLeechOrDeclineToken(events[i].token) {
 			continue
 		}
-		if normalizeFactionNameForMatching(events[i].faction) == source && actionMayTriggerLeech(events[i].token) {
+		if models.FactionKey(events[i].faction) == source && actionMayTriggerLeech(events[i].token) {
 			return i
 		}
 	}
@@ -1075,7 +1129,7 @@ func resolveSourceEventIndex(events []anchoredEvent, eventIndex int, sourceFacti
 	if eventIndex <= 0 || len(events) == 0 || eventIndex >= len(events) {
 		return -1
 	}
-	source := normalizeFactionNameForMatching(sourceFaction)
+	source := models.FactionKey(sourceFaction)
 	if source == "" {
 		return -1
 	}
@@ -1083,7 +1137,7 @@ func resolveSourceEventIndex(events []anchoredEvent, eventIndex int, sourceFacti
 		if isLeechOrDeclineToken(events[i].token) {
 			continue
 		}
-		if normalizeFactionNameForMatching(events[i].faction) == source && actionMayTriggerLeech(events[i].token) {
+		if models.FactionKey(events[i].faction) == source && actionMayTriggerLeech(events[i].token) {
 			return i
 		}
 	}
@@ -1091,7 +1145,7 @@ func resolveSourceEventIndex(events []anchoredEvent, eventIndex int, sourceFacti
 		if isLeechOrDeclineToken(events[i].token) {
 			continue
 		}
-		if normalizeFactionNameForMatching(events[i].faction) == source && actionMayTriggerLeech(events[i].token) {
+		if models.FactionKey(events[i].faction) == source && actionMayTriggerLeech(events[i].token) {
 			return i
 		}
 	}
@@ -1190,14 +1244,14 @@ func stabilizeLeechBindings(round *snellmanRoundData, columns []string, anchors
 				}
 				sourceFaction := ""
 				if m, ok := anchors[r]; ok {
-					sourceFaction = normalizeFactionNameForMatching(m[reactorFaction])
+					sourceFaction = models.FactionKey(m[reactorFaction])
 				}
 				needsMove := false
 				duplicatePrev := false
 				if !actionMayTriggerLeech(pTok) {
 					needsMove = true
 				}
-				if sourceFaction != "" && normalizeFactionNameForMatching(columns[pCol]) != sourceFaction {
+				if sourceFaction != "" && models.FactionKey(columns[pCol]) != sourceFaction {
 					needsMove = true
 				}
 				if prev, ok := lastPrevByReactor[reactorFaction]; ok && prev.row == pRow && prev.col == pCol {
@@ -1378,7 +1432,7 @@ func hasPastValidLeechBinding(
 		if !actionMayTriggerLeech(pTok) {
 			continue
 		}
-		if sourceFaction != "" && normalizeFactionNameForMatching(columns[pCol]) != sourceFaction {
+		if sourceFaction != "" && models.FactionKey(columns[pCol]) != sourceFaction {
 			continue
 		}
 		if lastPrevRow >= 0 && lastPrevCol >= 0 && lastPrevRow == pRow && lastPrevCol == pCol {
@@ -1463,7 +1517,7 @@ func hasFutureValidLeechBinding(
 		if !actionMayTriggerLeech(pTok) {
 			continue
 		}
-		if sourceFaction != "" && normalizeFactionNameForMatching(columns[pCol]) != sourceFaction {
+		if sourceFaction != "" && models.FactionKey(columns[pCol]) != sourceFaction {
 			continue
 		}
 		if lastPrevRow >= 0 && lastPrevCol >= 0 && lastPrevRow == pRow && lastPrevCol == pCol {
@@ -1496,7 +1550,7 @@ func findFutureLeechPlacementRow(
 		if !actionMayTriggerLeech(pTok) {
 			continue
 		}
-		if sourceFaction != "" && normalizeFactionNameForMatching(columns[pCol]) != sourceFaction {
+		if sourceFaction != "" && models.FactionKey(columns[pCol]) != sourceFaction {
 			continue
 		}
 		if lastPrevRow >= 0 && lastPrevCol >= 0 && lastPrevRow == pRow && lastPrevCol == pCol {
@@ -1529,7 +1583,7 @@ func findPastLeechPlacementRow(
 		if !actionMayTriggerLeech(pTok) {
 			continue
 		}
-		if sourceFaction != "" && normalizeFactionNameForMatching(columns[pCol]) != sourceFaction {
+		if sourceFaction != "" && models.FactionKey(columns[pCol]) != sourceFaction {
 			continue
 		}
 		if lastPrevRow >= 0 && lastPrevCol >= 0 && lastPrevRow == pRow && lastPrevCol == pCol {
@@ -1614,14 +1668,14 @@ func alignLeechesToAnchoredSource(round *snellmanRoundData, columns []string, an
 				}
 				sourceFaction := ""
 				if m, ok := anchors[r]; ok {
-					sourceFaction = normalizeFactionNameForMatching(m[reactorFaction])
+					sourceFaction = models.FactionKey(m[reactorFaction])
 				}
 				if sourceFaction == "" {
 					continue
 				}
 				_, pCol, pTok, ok := findPreviousNonLeech(round, columns, r, c)
 				if ok && actionMayTriggerLeech(pTok) &&
-					normalizeFactionNameForMatching(columns[pCol]) == sourceFaction {
+					models.FactionKey(columns[pCol]) == sourceFaction {
 					continue
 				}
 				target := findSourceBindingTargetRow(round, columns, reactorFaction, sourceFaction, r, c)
@@ -1671,7 +1725,7 @@ func findSourceBindingTargetRow(
 		if !ok || !actionMayTriggerLeech(pTok) {
 			continue
 		}
-		if normalizeFactionNameForMatching(columns[pCol]) != sourceFaction {
+		if models.FactionKey(columns[pCol]) != sourceFaction {
 			continue
 		}
 		// Prefer earliest valid binding row to preserve chronological ordering.
@@ -1698,7 +1752,7 @@ func resolveDuplicateLeechBindings(round *snellmanRoundData, columns []string, a
 				}
 				source := ""
 				if m, ok := anchors[r]; ok {
-					source = normalizeFactionNameForMatching(m[reactorFaction])
+					source = models.FactionKey(m[reactorFaction])
 				}
 				pRow, pCol, _, ok := findPreviousNonLeech(round, columns, r, c)
 				if !ok {
@@ -1816,7 +1870,7 @@ func resolveAnchoredDuplicateSources(round *snellmanRoundData, columns []string,
 				}
 				source := ""
 				if m, ok := anchors[r]; ok {
-					source = normalizeFactionNameForMatching(m[reactorFaction])
+					source = models.FactionKey(m[reactorFaction])
 				}
 				pRow, pCol, pTok, ok := findPreviousNonLeech(round, columns, r, c)
 				if !ok || !actionMayTriggerLeech(pTok) {
@@ -2217,7 +2271,7 @@ func extractLeechSourceFaction(action string) string {
 	if len(m) < 2 {
 		return ""
 	}
-	source := normalizeFactionNameForMatching(m[1])
+	source := models.FactionKey(m[1])
 	if isKnownFaction(source) {
 		return source
 	}
@@ -2234,7 +2288,7 @@ func extractLeechSourceFromConciseToken(token string) string {
 	if len(m) < 2 {
 		return ""
 	}
-	source := normalizeFactionNameForMatching(strings.TrimSpace(m[1]))
+	source := models.FactionKey(strings.TrimSpace(m[1]))
 	if source == "" {
 		return ""
 	}
@@ -2300,7 +2354,7 @@ func splitConciseActionIntoStandaloneReactions(
 		chain = chain[:0]
 	}
 
-	isCultists := normalizeFactionNameForMatching(faction) == "cultists"
+	isCultists := models.FactionTypeFromString(faction) == models.FactionCultists
 	seenMain := false
 	hasUpcomingNonLeech := func(start int) bool {
 		for i := start; i < len(parts); i++ {
@@ -2431,7 +2485,9 @@ func maxInt(a, b int) int {
 	return b
 }
 
-func computeRoundTurnOrder(rounds []*snellmanRoundData, factions []string, _ bool, variableTurnOrder bool) []string {
+// computeRoundTurnOrder orders the round after the last parsed one like the
+// engine does. A round whose passes were not all parsed keeps the seat order.
+func computeRoundTurnOrder(rounds []*snellmanRoundData, factions []string, policy game.TurnOrderPolicy) []string {
 	if len(rounds) == 0 {
 		return append([]string(nil), factions...)
 	}
@@ -2439,31 +2495,7 @@ func computeRoundTurnOrder(rounds []*snellmanRoundData, factions []string, _ boo
 	if len(prevRound.PassOrder) != len(factions) {
 		return append([]string(nil), factions...)
 	}
-	// Policy: if variable-turn-order is present, use full pass order.
-	// Otherwise always use maintain/cyclic order (ignore maintain-player-order option).
-	if variableTurnOrder {
-		return append([]string(nil), prevRound.PassOrder...)
-	}
-	if len(prevRound.PassOrder) > 0 {
-		return rotateToFirst(factions, prevRound.PassOrder[0])
-	}
-	return append([]string(nil), factions...)
-}
-
-func rotateToFirst(order []string, first string) []string {
-	idx := -1
-	for i, f := range order {
-		if f == first {
-			idx = i
-			break
-		}
-	}
-	if idx <= 0 {
-		return append([]string(nil), order...)
-	}
-	rot := append([]string(nil), order[idx:]...)
-	rot = append(rot, order[:idx]...)
-	return rot
+	return game.NextRoundOrder(policy, roundColumns(prevRound, factions), prevRound.PassOrder)
 }
 
 func actionMayTriggerLeech(action string) bool {
@@ -2515,7 +2547,20 @@ func findLatestEligibleLeechSourceRow(round *snellmanRoundData, sourceFaction st
 }
 
 func extractSnellmanAction(parts []string) string {
-	for i := len(parts) - 1; i >= 0; i-- {
+	// Housekeeping rows are matched whole first: "other_income_for_faction"
+	// would otherwise be read as an "action" and a "+8vp for FIRE" scoring row
+	// would fall through to its priest column.
+	for i := len(parts) - 1; i >= 1; i-- {
+		part := strings.TrimSpace(parts[i])
+		if part == "" {
+			continue
+		}
+		if _, ok := classifySnellmanRow(part); ok {
+			return part
+		}
+		break
+	}
+	for i := len(parts) - 1; i >= 1; i-- {
 		part := strings.TrimSpace(parts[i])
 		if part == "" {
 			continue
@@ -2529,7 +2574,7 @@ func extractSnellmanAction(parts []string) string {
 		}
 		// Preserve explicit +TRACK action strings (e.g. "+EARTH. pass BON9")
 		// so deferred Cultists bonuses can be backtracked correctly.
-		if strings.HasPrefix(part, "+") && regexp.MustCompile(`^\+[A-Za-z0-9]+(\.|$)`).MatchString(part) {
+		if strings.HasPrefix(part, "+") && regexp.MustCompile(`^\+[A-Za-z][A-Za-z0-9]*(\.|$)`).MatchString(part) {
 			if i > 0 {
 				prev := strings.TrimSpace(parts[i-1])
 				prevLower := strings.ToLower(prev)
@@ -2570,8 +2615,8 @@ func extractSnellmanAction(parts []string) string {
 			strings.Contains(part, "/") || part == "C" || part == "W" || part == "P" {
 			continue
 		}
-		// Skip numeric deltas
-		if isNumericWithDelta(part) {
+		// Skip numeric deltas and the coin, worker and priest columns ("3 P")
+		if isNumericWithDelta(part) || snellmanResourceColumnPattern.MatchString(part) {
 			continue
 		}
 		return part
@@ -2579,6 +2624,8 @@ func extractSnellmanAction(parts []string) string {
 	return ""
 }
 
+var snellmanResourceColumnPattern = regexp.MustCompile(`^\d+ [CWP]$`)
+
 func convertActionToConcise(action, faction string, isSetup bool, cultDelta int) string {
 	action = strings.TrimSpace(action)
 	lowerAction := strings.ToLower(action)
@@ -3508,7 +3555,7 @@ func convertSendPriestToConcise(action string, cultDelta int) string {
 func factionHomeColorShort(faction string) string {
 	// Avoid manual faction->color mappings here; bugs in this mapping silently corrupt
 	// Snellman "transform X to <color>" conversions (e.g. Nomads desert vs plains).
-	ft := models.FactionTypeFromString(factionDisplayName(faction))
+	ft := models.FactionTypeFromString(faction)
 	f := factions.NewFaction(ft)
 	if f == nil {
 		return ""
@@ -3566,21 +3613,10 @@ func trackToShort(track string) string {
 	}
 }
 
+// isKnownFaction reports whether name is a base game faction, the only ones
+// Snellman ledgers are parsed for.
 func isKnownFaction(name string) bool {
-	name = normalizeFactionNameForMatching(name)
-	known := map[string]bool{
-		"engineers": true, "darklings": true, "cultists": true, "witches": true,
-		"halflings": true, "auren": true, "alchemists": true, "chaosmagicians": true,
-		"nomads": true, "fakirs": true, "giants": true, "dwarves": true,
-		"mermaids": true, "swarmlings": true,
-	}
-	return known[name]
-}
-
-func normalizeFactionNameForMatching(name string) string {
-	n := strings.ToLower(strings.TrimSpace(name))
-	n = strings.ReplaceAll(n, " ", "")
-	return n
+	return models.FactionTypeFromString(name).IsBaseGame()
 }
 
 func getBonusCardsMinusRemoved(removed []string) []string {
@@ -3615,6 +3651,18 @@ func getBonusCardsMinusRemoved(removed []string) []string {
 	return result
 }
 
+// snellmanFactionPlayerNames pairs each faction with the player seated in the
+// order the factions were picked, as "Cultists:jere".
+func snellmanFactionPlayerNames(factions []string, playerNames map[int]string) []string {
+	var names []string
+	for i, faction := range factions {
+		if name := playerNames[i+1]; name != "" {
+			names = append(names, fmt.Sprintf("%s:%s", factionDisplayName(faction), name))
+		}
+	}
+	return names
+}
+
 func formatFactionList(factions []string) string {
 	var titled []string
 	for _, f := range factions {
@@ -3632,8 +3680,8 @@ func formatFactionHeader(factions []string) string {
 }
 
 func factionDisplayName(name string) string {
-	switch normalizeFactionNameForMatching(name) {
-	case "chaosmagicians":
+	switch models.FactionTypeFromString(name) {
+	case models.FactionChaosMagicians:
 		return "Chaos Magicians"
 	default:
 		return strings.Title(strings.ToLower(strings.TrimSpace(name)))
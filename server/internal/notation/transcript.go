@@ -0,0 +1,400 @@
+package notation
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+// Transcript settings read from a GameSettingsItem, next to the concise log
+// header settings such as "Game".
+const (
+	// transcriptPlayerPrefix + player ID names the player in the transcript,
+	// e.g. "Player:alice" = "alice (Nomads)". Concise logs use faction names as
+	// player IDs and need no names.
+	transcriptPlayerPrefix = "Player:"
+	// transcriptMapSetting selects the map whose coordinates label hexes.
+	// Without it hexes are labelled as on the base map, like concise logs.
+	transcriptMapSetting = "MapID"
+	// removedBonusCardsSetting lists the bonus cards taken out at setup, e.g.
+	// "BON-SPD,BON-P". Concise logs carry it next to "BonusCards".
+	removedBonusCardsSetting = "RemovedBonusCards"
+	// handicapSettingPrefix + player ID gives the player's handicap in
+	// game.Handicap notation, e.g. "Handicap:alice" = "5VP 2C". Concise logs
+	// key it by faction, like their player IDs.
+	handicapSettingPrefix = "Handicap:"
+	// seedSetting is the seed of a finished live game, from which its setup
+	// draws can be re-derived (see game.DeriveSetupDraws).
+	seedSetting = "Seed"
+)
+
+// RenderTranscript renders log items as a readable narrative with one
+// paragraph per round, e.g.
+//
+//	Round 3: Nomads build a dwelling at E7, Witches leech 2 power, ...
+//
+// Actions before the first round are reported under "Setup".
+func RenderTranscript(items []LogItem) string {
+	r := transcriptRenderer{names: make(map[string]string)}
+	var paragraphs []string
+	heading := "Setup"
+	var events []string
+	flush := func() {
+		if len(events) > 0 {
+			paragraphs = append(paragraphs, heading+": "+strings.Join(events, ", ")+".")
+		}
+		events = nil
+	}
+
+	for _, item := range items {
+		switch it := item.(type) {
+		case GameSettingsItem:
+			for key, value := range it.Settings {
+				if playerID, ok := strings.CutPrefix(key, transcriptPlayerPrefix); ok {
+					r.names[playerID] = value
+				}
+			}
+			if mapID := it.Settings[transcriptMapSetting]; mapID != "" {
+				r.mapID = board.MapID(mapID)
+			}
+			if name := it.Settings["Game"]; name != "" && len(paragraphs) == 0 && len(events) == 0 {
+				paragraphs = append(paragraphs, "Game: "+name+".")
+			}
+			if removed := it.Settings[removedBonusCardsSetting]; removed != "" {
+				paragraphs = append(paragraphs, "Removed bonus cards: "+strings.ReplaceAll(removed, ",", ", ")+".")
+			}
+			if seed := it.Settings[seedSetting]; seed != "" {
+				paragraphs = append(paragraphs, "Seed: "+seed+".")
+			}
+			var handicaps []string
+			for key, value := range it.Settings {
+				if playerID, ok := strings.CutPrefix(key, handicapSettingPrefix); ok {
+					handicaps = append(handicaps, r.player(playerID)+" "+value)
+				}
+			}
+			if len(handicaps) > 0 {
+				sort.Strings(handicaps)
+				paragraphs = append(paragraphs, "Handicaps: "+strings.Join(handicaps, ", ")+".")
+			}
+		case RoundStartItem:
+			flush()
+			heading = fmt.Sprintf("Round %d", it.Round)
+		case ActionItem:
+			if it.Action == nil {
+				continue
+			}
+			if text := r.describe(it.Action); text != "" {
+				events = append(events, r.player(it.Action.GetPlayerID())+" "+text)
+			}
+		case FinalScoringValidationItem:
+			flush()
+			heading = "Final scoring"
+			events = append(events, r.finalScoring(it)...)
+		}
+	}
+	flush()
+	if len(paragraphs) == 0 {
+		return ""
+	}
+	return strings.Join(paragraphs, "\n\n") + "\n"
+}
+
+type transcriptRenderer struct {
+	names map[string]string
+	mapID board.MapID
+}
+
+func (r transcriptRenderer) player(playerID string) string {
+	if name := r.names[playerID]; name != "" {
+		return name
+	}
+	return playerID
+}
+
+func (r transcriptRenderer) hex(h board.Hex) string {
+	if r.mapID != "" {
+		if label, ok := board.DisplayCoordinateForHex(r.mapID, h); ok {
+			return label
+		}
+	}
+	return HexToShortString(h)
+}
+
+// describe renders one action as a verb phrase following the player name.
+// Actions without a phrase of their own fall back to their concise code.
+func (r transcriptRenderer) describe(action game.Action) string {
+	switch a := action.(type) {
+	case *game.SetupDwellingAction:
+		return "place a starting dwelling at " + r.hex(a.Hex)
+	case *game.TransformAndBuildAction:
+		transform := ""
+		if a.TargetTerrain != models.TerrainTypeUnknown {
+			transform = fmt.Sprintf("transform %s to %s", r.hex(a.TargetHex), a.TargetTerrain)
+		}
+		switch {
+		case a.BuildDwelling && transform != "":
+			return transform + " and build a dwelling"
+		case a.BuildDwelling:
+			return "build a dwelling at " + r.hex(a.TargetHex)
+		case transform != "":
+			return transform
+		default:
+			return "transform " + r.hex(a.TargetHex)
+		}
+	case *game.UpgradeBuildingAction:
+		return fmt.Sprintf("upgrade %s to a %s", r.hex(a.TargetHex), buildingName(a.NewBuildingType))
+	case *game.ApplyHalflingsSpadeAction:
+		return fmt.Sprintf("transform %s to %s", r.hex(a.TargetHex), a.TargetTerrain)
+	case *game.BuildHalflingsDwellingAction:
+		return "build a dwelling at " + r.hex(a.TargetHex)
+	case *game.SkipHalflingsDwellingAction:
+		return "skip the stronghold dwelling"
+	case *game.PassAction:
+		if a.BonusCard != nil && a.CardCoins > 0 {
+			return fmt.Sprintf("pass and take %s with %s on it", bonusCardName(*a.BonusCard), plural(a.CardCoins, "coin"))
+		}
+		if a.BonusCard != nil {
+			return "pass and take " + bonusCardName(*a.BonusCard)
+		}
+		return "pass"
+	case *game.SendPriestToCultAction:
+		if a.SpacesToClimb > 0 {
+			return fmt.Sprintf("send a priest to %s (%s)", cultTrackName(a.Track), plural(a.SpacesToClimb, "step"))
+		}
+		return "send a priest to " + cultTrackName(a.Track)
+	case *game.AdvanceShippingAction:
+		return "advance shipping"
+	case *game.AdvanceDiggingAction:
+		return "advance digging"
+	case *game.AcceptPowerLeechAction:
+		if a.Amount > 0 {
+			return fmt.Sprintf("leech %d power", a.Amount)
+		}
+		return "leech power"
+	case *LogAcceptLeechAction:
+		if a.PowerAmount > 0 {
+			return fmt.Sprintf("leech %d power", a.PowerAmount)
+		}
+		return "leech power"
+	case *game.DeclinePowerLeechAction, *LogDeclineLeechAction:
+		return "decline power"
+	case *LogPowerAction:
+		return "take power action " + a.ActionCode
+	case *LogSpecialAction:
+		return "use special action " + a.ActionCode
+	case *game.SpecialAction:
+		if a.ActionType == game.SpecialActionAurenCultAdvance && a.CultTrack != nil {
+			return "use the Auren stronghold to advance 2 on " + cultTrackName(*a.CultTrack)
+		}
+		if a.ActionType == game.SpecialActionNomadsSandstorm && a.TargetHex != nil {
+			if a.BuildDwelling {
+				return "sandstorm " + r.hex(*a.TargetHex) + " and build a dwelling"
+			}
+			return "sandstorm " + r.hex(*a.TargetHex)
+		}
+		if a.ActionType == game.SpecialActionGiantsTransform && a.TargetHex != nil {
+			if a.BuildDwelling {
+				return "use the Giants stronghold on " + r.hex(*a.TargetHex) + " and build a dwelling"
+			}
+			return "use the Giants stronghold on " + r.hex(*a.TargetHex)
+		}
+		if a.ActionType == game.SpecialActionChaosMagiciansDoubleTurn && a.FirstAction != nil && a.SecondAction != nil {
+			return "take a Chaos Magicians double turn: " + r.describe(a.FirstAction) + ", then " + r.describe(a.SecondAction)
+		}
+		return "play " + generateActionCode(action, models.TerrainTypeUnknown)
+	case *LogBurnAction:
+		return "burn " + plural(a.Amount, "power")
+	case *LogDigTransformAction:
+		return fmt.Sprintf("dig %s at %s", plural(a.Spades, "spade"), r.hex(a.Target))
+	case *LogPreIncomeAction:
+		if a.Action == nil {
+			return ""
+		}
+		return r.describe(a.Action) + " before income"
+	case *LogPostIncomeAction:
+		if a.Action == nil {
+			return ""
+		}
+		return r.describe(a.Action) + " after income"
+	case *LogFavorTileAction:
+		return "take favor tile " + a.Tile
+	case *LogConversionAction:
+		return fmt.Sprintf("convert %s to %s", formatResources(a.Cost), formatResources(a.Reward))
+	case *game.UseDarklingsPriestOrdinationAction:
+		if a.WorkersToConvert == 0 {
+			return "ordain no priests"
+		}
+		return fmt.Sprintf("ordain %s", plural(a.WorkersToConvert, "priest"))
+	case *LogTownAction:
+		return fmt.Sprintf("found a town (%d VP)", a.VP)
+	case *LogBonusCardSelectionAction:
+		return "take bonus card " + a.BonusCard
+	case *LogCompoundAction:
+		parts := make([]string, 0, len(a.Actions))
+		for _, sub := range a.Actions {
+			if text := r.describe(sub); text != "" {
+				parts = append(parts, text)
+			}
+		}
+		return strings.Join(parts, " then ")
+	case *LogHalflingsSpadeAction:
+		return "transform " + strings.Join(a.TransformCoords, " and ")
+	case *LogCultistAdvanceAction:
+		return "advance on " + cultTrackName(a.Track)
+	case *LogCultTrackDecreaseAction:
+		return "drop one step on " + cultTrackName(a.Track)
+	default:
+		return "play " + generateActionCode(action, models.TerrainTypeUnknown)
+	}
+}
+
+func (r transcriptRenderer) finalScoring(item FinalScoringValidationItem) []string {
+	playerIDs := make([]string, 0, len(item.Scores))
+	for playerID := range item.Scores {
+		playerIDs = append(playerIDs, playerID)
+	}
+	sort.Strings(playerIDs)
+
+	lines := make([]string, 0, len(playerIDs))
+	for _, playerID := range playerIDs {
+		score := item.Scores[playerID]
+		if score == nil {
+			continue
+		}
+		parts := []string{fmt.Sprintf("%d cult VP", score.CultVP)}
+		if score.HasAreaScore {
+			parts = append(parts, fmt.Sprintf("%d area VP", score.AreaVP))
+		}
+		if score.HasFireIceScore {
+			parts = append(parts, fmt.Sprintf("%d Fire & Ice VP", score.FireIceVP))
+		}
+		if score.HasResourceScore {
+			parts = append(parts, fmt.Sprintf("%d resource VP", score.ResourceVP))
+		}
+		lines = append(lines, r.player(playerID)+" score "+strings.Join(parts, ", "))
+	}
+	return lines
+}
+
+func buildingName(t models.BuildingType) string {
+	if t == models.BuildingTradingHouse {
+		return "trading house"
+	}
+	return strings.ToLower(t.String())
+}
+
+func bonusCardName(t game.BonusCardType) string {
+	if card, ok := game.GetAllBonusCards()[t]; ok && card.Name != "" {
+		return "the " + card.Name + " bonus card"
+	}
+	return getBonusCardShortCode(t)
+}
+
+func cultTrackName(t game.CultTrack) string {
+	switch t {
+	case game.CultFire:
+		return "Fire"
+	case game.CultWater:
+		return "Water"
+	case game.CultEarth:
+		return "Earth"
+	case game.CultAir:
+		return "Air"
+	}
+	return "?"
+}
+
+func plural(n int, unit string) string {
+	if n == 1 || unit == "power" {
+		return fmt.Sprintf("%d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// LogItemsFromSaveFile rebuilds the log of a live game from its save file, for
+// RenderTranscript: players are named with their factions, a RoundStartItem
+// precedes each round's first action, and final scoring is reported once the
+// game has ended.
+func LogItemsFromSaveFile(save *game.SaveFile, buildAction game.RecordedActionBuilder) ([]LogItem, error) {
+	var actions []LogItem
+	round := 0
+	imported, err := game.ReplaySaveFileObserved(save, buildAction, func(gs *game.GameState, action game.Action) {
+		if gs.Phase == game.PhaseAction && gs.Round != round {
+			round = gs.Round
+			actions = append(actions, RoundStartItem{Round: round, TurnOrder: append([]string(nil), gs.TurnOrder...)})
+		}
+		if pass, ok := action.(*game.PassAction); ok && pass.BonusCard != nil && gs.BonusCards != nil {
+			pass.CardCoins = gs.BonusCards.GetCoinsOnCard(*pass.BonusCard)
+		}
+		actions = append(actions, ActionItem{Action: action})
+	})
+	if err != nil {
+		return nil, err
+	}
+	gs := imported.State
+
+	settings := GameSettingsItem{Settings: map[string]string{transcriptMapSetting: string(save.Settings.MapID)}}
+	if gs.BonusCards != nil && len(gs.BonusCards.Removed) > 0 {
+		removed := make(map[game.BonusCardType]bool, len(gs.BonusCards.Removed))
+		var removedCodes, inPlayCodes []string
+		for _, card := range gs.BonusCards.Removed {
+			removed[card] = true
+			removedCodes = append(removedCodes, getBonusCardShortCode(card))
+		}
+		for _, card := range sortedBonusCardTypes() {
+			if !removed[card] {
+				inPlayCodes = append(inPlayCodes, getBonusCardShortCode(card))
+			}
+		}
+		settings.Settings["BonusCards"] = strings.Join(inPlayCodes, ",")
+		settings.Settings[removedBonusCardsSetting] = strings.Join(removedCodes, ",")
+	}
+	for _, playerID := range save.PlayerIDs {
+		name := playerID
+		if player := gs.GetPlayer(playerID); player != nil && player.Faction != nil {
+			name = fmt.Sprintf("%s (%s)", playerID, player.Faction.GetType())
+		}
+		settings.Settings[transcriptPlayerPrefix+playerID] = name
+	}
+	for playerID, handicap := range save.Settings.Handicaps {
+		if !handicap.IsZero() {
+			settings.Settings[handicapSettingPrefix+playerID] = handicap.String()
+		}
+	}
+	if gs.Phase == game.PhaseEnd {
+		settings.Settings[seedSetting] = strconv.FormatInt(save.Settings.Seed, 10)
+	}
+	items := append([]LogItem{settings}, actions...)
+
+	if gs.Phase == game.PhaseEnd && gs.FinalScoring != nil {
+		scores := make(map[string]*FinalScoringExpectation, len(gs.FinalScoring))
+		for playerID, score := range gs.FinalScoring {
+			scores[playerID] = &FinalScoringExpectation{
+				PlayerID:         playerID,
+				CultVP:           score.CultVP,
+				AreaVP:           score.AreaVP,
+				FireIceVP:        score.FireIceVP,
+				ResourceVP:       score.ResourceVP,
+				HasAreaScore:     true,
+				HasFireIceScore:  score.FireIceVP != 0,
+				HasResourceScore: true,
+			}
+		}
+		items = append(items, FinalScoringValidationItem{Scores: scores})
+	}
+	return items, nil
+}
+
+func sortedBonusCardTypes() []game.BonusCardType {
+	cards := make([]game.BonusCardType, 0, len(game.GetAllBonusCards()))
+	for card := range game.GetAllBonusCards() {
+		cards = append(cards, card)
+	}
+	sort.Slice(cards, func(i, j int) bool { return cards[i] < cards[j] })
+	return cards
+}
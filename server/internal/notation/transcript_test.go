@@ -0,0 +1,138 @@
+package notation
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+func TestRenderTranscript_GroupsActionsByRound(t *testing.T) {
+	bonus := game.BonusCard6Coins
+	items := []LogItem{
+		GameSettingsItem{Settings: map[string]string{"Game": "Base Game", "Player:p1": "p1 (Nomads)"}},
+		ActionItem{Action: game.NewSetupDwellingAction("p1", board.NewHex(2, 4))},
+		RoundStartItem{Round: 3, TurnOrder: []string{"p1", "Witches"}},
+		ActionItem{Action: game.NewTransformAndBuildAction("p1", board.NewHex(4, 4), true, models.TerrainTypeUnknown)},
+		ActionItem{Action: &LogAcceptLeechAction{PlayerID: "Witches", PowerAmount: 2}},
+		ActionItem{Action: &LogCompoundAction{Actions: []game.Action{
+			&LogBurnAction{PlayerID: "Witches", Amount: 3},
+			&LogPowerAction{PlayerID: "Witches", ActionCode: "ACT2"},
+		}}},
+		ActionItem{Action: game.NewPassAction("p1", &bonus)},
+		FinalScoringValidationItem{Scores: map[string]*FinalScoringExpectation{
+			"Witches": {CultVP: 8, AreaVP: 18, HasAreaScore: true},
+		}},
+	}
+
+	got := RenderTranscript(items)
+	want := strings.Join([]string{
+		"Game: Base Game.",
+		"Setup: p1 (Nomads) place a starting dwelling at " + HexToShortString(board.NewHex(2, 4)) + ".",
+		"Round 3: p1 (Nomads) build a dwelling at " + HexToShortString(board.NewHex(4, 4)) +
+			", Witches leech 2 power, Witches burn 3 power then take power action ACT2, p1 (Nomads) pass and take the 6 Coins bonus card.",
+		"Final scoring: Witches score 8 cult VP, 18 area VP.",
+	}, "\n\n") + "\n"
+	if got != want {
+		t.Fatalf("unexpected transcript:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestRenderTranscript_FromConciseLog(t *testing.T) {
+	items, err := ParseConciseLog(strings.Join([]string{
+		"Game: Base Game",
+		"",
+		"Witches         | Nomads",
+		"-----------------------------------",
+		"S-F4            | S-D3",
+		"Round 1",
+		"TurnOrder: Witches, Nomads",
+		"Witches         | Nomads",
+		"-----------------------------------",
+		"UP-TH-F4        | L",
+	}, "\n"))
+	if err != nil {
+		t.Fatalf("ParseConciseLog failed: %v", err)
+	}
+
+	got := RenderTranscript(items)
+	for _, want := range []string{
+		"Setup: Witches place a starting dwelling at F4, Nomads place a starting dwelling at D3.",
+		"Round 1: Witches upgrade F4 to a trading house, Nomads leech 1 power.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("transcript missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderTranscript_ShowsCoinsOnPassCards(t *testing.T) {
+	items, err := ParseConciseLog(strings.Join([]string{
+		"Game: Base Game",
+		"",
+		"Witches         | Nomads",
+		"-----------------------------------",
+		"S-F4            | S-D3",
+		"Round 1",
+		"TurnOrder: Witches, Nomads",
+		"Witches         | Nomads",
+		"-----------------------------------",
+		"PASS-BON-4C+1C  | PASS-BON-6C",
+	}, "\n"))
+	if err != nil {
+		t.Fatalf("ParseConciseLog failed: %v", err)
+	}
+
+	got := RenderTranscript(items)
+	want := "Round 1: Witches pass and take the Cult Advance bonus card with 1 coin on it, Nomads pass and take the 6 Coins bonus card."
+	if !strings.Contains(got, want) {
+		t.Fatalf("transcript missing %q:\n%s", want, got)
+	}
+}
+
+func TestRenderTranscript_ReportsSeedFromConciseLogHeader(t *testing.T) {
+	items, err := ParseConciseLog(strings.Join([]string{
+		"Game: Base Game",
+		"Seed: 1234",
+		"",
+		"Witches         | Nomads",
+		"-----------------------------------",
+		"S-F4            | S-D3",
+	}, "\n"))
+	if err != nil {
+		t.Fatalf("ParseConciseLog failed: %v", err)
+	}
+
+	if got := RenderTranscript(items); !strings.Contains(got, "Seed: 1234.") {
+		t.Fatalf("expected the seed in the transcript, got:\n%s", got)
+	}
+}
+
+func TestLogItemsFromSaveFile_KeepsHandicaps(t *testing.T) {
+	mgr := game.NewManager()
+	if err := mgr.CreateGameWithOptions("g1", []string{"p1", "p2"}, game.CreateGameOptions{
+		Handicaps: map[string]game.Handicap{"p2": {VP: 5, Coins: 2}},
+	}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	save, err := mgr.ExportGame("g1")
+	if err != nil {
+		t.Fatalf("export game: %v", err)
+	}
+	items, err := LogItemsFromSaveFile(save, func(game.RecordedAction) (game.Action, error) {
+		return nil, fmt.Errorf("no actions were recorded")
+	})
+	if err != nil {
+		t.Fatalf("LogItemsFromSaveFile failed: %v", err)
+	}
+	settings, ok := items[0].(GameSettingsItem)
+	if !ok || settings.Settings["Handicap:p2"] != "5VP 2C" || settings.Settings["Handicap:p1"] != "" {
+		t.Fatalf("expected p2's handicap in the settings, got %+v", items[0])
+	}
+	if got := RenderTranscript(items); !strings.Contains(got, "Handicaps: p2 5VP 2C.") {
+		t.Fatalf("expected the handicap in the transcript, got:\n%s", got)
+	}
+}
@@ -3,6 +3,7 @@ package notation
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -73,10 +74,10 @@ func (a *LogShapeshiftersLeechBonusAction) Execute(gs *game.GameState) error {
 			return fmt.Errorf("not enough VP for Shapeshifters leech bonus")
 		}
 		player.VictoryPoints--
-		player.Resources.Power.Bowl3++
+		gs.GainPowerTokensForReason(a.PlayerID, 3, 1, game.PowerGainShapeshiftersLeech, "")
 		return nil
 	}
-	player.Resources.GainPower(1)
+	gs.GainPowerForReason(a.PlayerID, 1, game.PowerGainShapeshiftersLeech, "")
 	return nil
 }
 
@@ -163,7 +164,7 @@ func (a *LogAcceptLeechAction) Execute(gs *game.GameState) error {
 			}
 		}
 		if a.Explicit && a.PowerAmount > 0 && strings.TrimSpace(a.FromPlayerID) != "" {
-			return executeLoggedLeechWithoutPendingOffer(player, a)
+			return executeLoggedLeechWithoutPendingOffer(gs, player, a)
 		}
 		return fmt.Errorf("no pending leech offers for %q", a.PlayerID)
 	}
@@ -221,7 +222,7 @@ func (a *LogAcceptLeechAction) Execute(gs *game.GameState) error {
 	return nil
 }
 
-func executeLoggedLeechWithoutPendingOffer(player *game.Player, action *LogAcceptLeechAction) error {
+func executeLoggedLeechWithoutPendingOffer(gs *game.GameState, player *game.Player, action *LogAcceptLeechAction) error {
 	if player == nil || player.Resources == nil {
 		return fmt.Errorf("player resources not found: %s", action.PlayerID)
 	}
@@ -231,7 +232,7 @@ func executeLoggedLeechWithoutPendingOffer(player *game.Player, action *LogAccep
 		FromPlayerID: action.FromPlayerID,
 		SourceHex:    action.FromHex,
 	}
-	vpCost := player.Resources.AcceptPowerLeech(offer)
+	vpCost := gs.AcceptPowerLeech(action.PlayerID, offer)
 	if player.Faction != nil && player.Faction.GetType() == models.FactionChildrenOfTheWyrm && vpCost > 0 {
 		vpCost--
 	}
@@ -692,7 +693,7 @@ func (a *LogDigTransformAction) Execute(gs *game.GameState) error {
 		for i := 0; i < spadesForVP; i++ {
 			gs.AwardActionVP(a.PlayerID, game.ScoringActionSpades)
 		}
-		game.AwardFactionSpadeBonuses(player, spadesForVP)
+		game.AwardFactionSpadeBonuses(gs, player, spadesForVP)
 	}
 
 	// Award faction bonuses for cult-reward spades too (no VP).
@@ -701,7 +702,7 @@ func (a *LogDigTransformAction) Execute(gs *game.GameState) error {
 		if player.Faction.GetType() == models.FactionGiants {
 			spadesUsed = 2
 		}
-		game.AwardFactionSpadeBonuses(player, spadesUsed)
+		game.AwardFactionSpadeBonuses(gs, player, spadesUsed)
 	}
 
 	return nil
@@ -1004,6 +1005,9 @@ func (a *LogSpecialAction) executeStrongholdAction(gs *game.GameState, player *g
 		buildDwelling := false
 		if dotIdx := strings.Index(coordPart, "."); dotIdx > 0 {
 			// Combined action - extract just the coord and set BuildDwelling=true
+			if buildCoord := coordPart[dotIdx+1:]; !strings.EqualFold(buildCoord, coordPart[:dotIdx]) {
+				return fmt.Errorf("ACT-SH-T builds at %s but sandstorms %s", buildCoord, coordPart[:dotIdx])
+			}
 			coordPart = coordPart[:dotIdx]
 			buildDwelling = true
 		}
@@ -1628,7 +1632,7 @@ func (a *LogConversionAction) Execute(gs *game.GameState) error {
 	player.Resources.Coins += a.Reward[models.ResourceCoin]
 
 	if p := a.Reward[models.ResourcePower]; p > 0 {
-		player.Resources.GainPower(p)
+		gs.GainPowerForReason(a.PlayerID, p, game.PowerGainLog, "")
 	}
 
 	if vp := a.Reward[models.ResourceVictoryPoint]; vp > 0 {
@@ -2062,11 +2066,18 @@ func (a *LogCompoundAction) Execute(gs *game.GameState) error {
 	}()
 
 	preExecuted := make(map[int]bool, len(a.Actions))
+	// A Halflings stronghold dwelling merged into an earlier transform token
+	// is built once every stronghold spade is applied.
+	var deferred []game.Action
 
 	for i, action := range a.Actions {
 		if preExecuted[i] {
 			continue
 		}
+		action, build := halflingsStrongholdActions(gs, action)
+		if build != nil {
+			deferred = append(deferred, build)
+		}
 		if err := action.Execute(gs); err != nil {
 			// Snellman rows often place resource conversions at the end of the
 			// compound token even when they fund an earlier build/upgrade.
@@ -2095,6 +2106,11 @@ func (a *LogCompoundAction) Execute(gs *game.GameState) error {
 			}
 		}
 	}
+	for _, action := range deferred {
+		if err := action.Execute(gs); err != nil {
+			return err
+		}
+	}
 	succeeded = true
 	return nil
 }
@@ -2192,7 +2208,7 @@ func (a *LogBonusCardSelectionAction) Execute(gs *game.GameState) error {
 
 	// Ensure card is available (hack for replay if missing)
 	if !gs.BonusCards.IsAvailable(cardType) {
-		gs.BonusCards.Available[cardType] = 0
+		gs.BonusCards.MakeAvailable(cardType, 0)
 	}
 
 	if _, err := gs.BonusCards.TakeBonusCard(a.PlayerID, cardType); err != nil {
@@ -2294,6 +2310,58 @@ func (a *LogHalflingsSpadeAction) Execute(gs *game.GameState) error {
 	return nil
 }
 
+// WithHalflingsStrongholdSpades prepares a replayed action played while the
+// Halflings stronghold spades of its player are pending: a lone transform or
+// build token is wrapped in a compound, whose Execute reads it as the
+// Halflings action it stands for. Other actions are returned unchanged.
+func WithHalflingsStrongholdSpades(gs *game.GameState, action game.Action) game.Action {
+	transform, ok := action.(*game.TransformAndBuildAction)
+	if !ok || gs == nil || gs.PendingHalflingsSpades == nil || gs.PendingHalflingsSpades.PlayerID != transform.PlayerID {
+		return action
+	}
+	return &LogCompoundAction{Actions: []game.Action{action}}
+}
+
+// halflingsStrongholdActions reads a transform or build token played while
+// the Halflings stronghold spades of its player are pending: "T-A1" applies
+// spades to A1 and "A1" builds the optional dwelling on a transformed hex.
+// For a merged transform and build ("TB-A1") the spades are applied now and
+// the dwelling is returned as build, to follow once every spade is applied.
+// Other actions are returned unchanged.
+func halflingsStrongholdActions(gs *game.GameState, action game.Action) (game.Action, game.Action) {
+	transform, ok := action.(*game.TransformAndBuildAction)
+	if !ok || gs == nil || gs.PendingHalflingsSpades == nil || gs.PendingHalflingsSpades.PlayerID != transform.PlayerID {
+		return action, nil
+	}
+	pending := gs.PendingHalflingsSpades
+	build := &game.BuildHalflingsDwellingAction{
+		BaseAction: game.BaseAction{Type: game.ActionBuildHalflingsDwelling, PlayerID: transform.PlayerID},
+		TargetHex:  transform.TargetHex,
+	}
+	if transform.BuildDwelling && slices.Contains(pending.TransformedHexes, transform.TargetHex) {
+		return build, nil
+	}
+	if pending.SpadesRemaining == 0 {
+		return action, nil
+	}
+
+	targetTerrain := transform.TargetTerrain
+	if targetTerrain == models.TerrainTypeUnknown {
+		if player := gs.GetPlayer(transform.PlayerID); player != nil && player.Faction != nil {
+			targetTerrain = player.Faction.GetHomeTerrain()
+		}
+	}
+	apply := &game.ApplyHalflingsSpadeAction{
+		BaseAction:    game.BaseAction{Type: game.ActionApplyHalflingsSpade, PlayerID: transform.PlayerID},
+		TargetHex:     transform.TargetHex,
+		TargetTerrain: targetTerrain,
+	}
+	if transform.BuildDwelling {
+		return apply, build
+	}
+	return apply, nil
+}
+
 // getTerrainTypeFromName converts a terrain name string to TerrainType
 func getTerrainTypeFromName(name string) models.TerrainType {
 	switch strings.ToLower(name) {
@@ -2348,8 +2416,9 @@ func (a *LogCultistAdvanceAction) Execute(gs *game.GameState) error {
 		return fmt.Errorf("failed to advance cult track: %w", err)
 	}
 
-	// Note: In the real game, this consumes a pending permission/state.
-	// For log replay, we just apply the effect.
+	// Consume the oldest queued trigger, so each "+TRACK" row maps to the leech
+	// event that earned it. With no selection pending only the advance applies.
+	gs.ConsumeCultistsCultSelection(a.PlayerID)
 	return nil
 }
 
@@ -0,0 +1,153 @@
+package notation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+// Kinds of UnsupportedFeature.
+const (
+	UnsupportedFaction = "faction"
+	UnsupportedMap     = "map"
+	UnsupportedAuction = "auction"
+	UnsupportedOption  = "option"
+)
+
+// UnsupportedFeature is something a log uses that the converters cannot
+// replay.
+type UnsupportedFeature struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Line is the 1-based line of the log the feature was first seen on.
+	Line int `json:"line"`
+}
+
+// UnsupportedFeatureError is returned before conversion starts when a log
+// uses features the replay cannot reproduce, instead of letting the replay
+// fail partway through on an unrelated-looking action.
+type UnsupportedFeatureError struct {
+	// Source is the log format, "snellman" or "bga".
+	Source   string               `json:"source"`
+	Features []UnsupportedFeature `json:"features"`
+}
+
+func (e *UnsupportedFeatureError) Error() string {
+	parts := make([]string, len(e.Features))
+	for i, feature := range e.Features {
+		parts[i] = fmt.Sprintf("%s %s (line %d)", feature.Kind, feature.Name, feature.Line)
+	}
+	return fmt.Sprintf("%s log uses unsupported features: %s", e.Source, strings.Join(parts, ", "))
+}
+
+type unsupportedFeatures struct {
+	features []UnsupportedFeature
+	seen     map[string]bool
+}
+
+func (u *unsupportedFeatures) add(kind, name string, line int) {
+	key := kind + ":" + name
+	if u.seen == nil {
+		u.seen = make(map[string]bool)
+	}
+	if u.seen[key] {
+		return
+	}
+	u.seen[key] = true
+	u.features = append(u.features, UnsupportedFeature{Kind: kind, Name: name, Line: line})
+}
+
+func (u *unsupportedFeatures) err(source string) error {
+	if len(u.features) == 0 {
+		return nil
+	}
+	return &UnsupportedFeatureError{Source: source, Features: u.features}
+}
+
+var (
+	snellmanMapPattern = regexp.MustCompile(`(?i)^(?:select\s+)?map\s+(\S+)`)
+	snellmanBidPattern = regexp.MustCompile(`(?i)^bid\s`)
+)
+
+// DetectUnsupportedSnellmanFeatures checks a Snellman ledger for factions the
+// converter does not know, alternate maps, faction auctions and Fire & Ice
+// options. It returns nil when the log can be converted.
+func DetectUnsupportedSnellmanFeatures(content string) error {
+	var found unsupportedFeatures
+	for i, raw := range strings.Split(content, "\n") {
+		lineNumber := i + 1
+		line := strings.TrimSpace(raw)
+		lower := strings.ToLower(line)
+
+		if option, ok := strings.CutPrefix(lower, "option "); ok {
+			option = strings.TrimSpace(option)
+			switch {
+			case strings.Contains(option, "auction"):
+				found.add(UnsupportedAuction, option, lineNumber)
+			case strings.HasPrefix(option, "fire-and-ice"):
+				found.add(UnsupportedOption, option, lineNumber)
+			}
+			continue
+		}
+		if m := snellmanMapPattern.FindStringSubmatch(line); m != nil {
+			found.add(UnsupportedMap, m[1], lineNumber)
+			continue
+		}
+
+		parts := strings.Split(line, "\t")
+		if len(parts) < 2 || !isSnellmanLedgerRow(parts) {
+			continue
+		}
+		faction := strings.ToLower(strings.TrimSpace(parts[0]))
+		if !isKnownFaction(faction) {
+			found.add(UnsupportedFaction, faction, lineNumber)
+			continue
+		}
+		if snellmanBidPattern.MatchString(strings.TrimSpace(extractSnellmanAction(parts))) {
+			found.add(UnsupportedAuction, "bid", lineNumber)
+		}
+	}
+	return found.err("snellman")
+}
+
+// isSnellmanLedgerRow reports whether a tab-split line is a faction row,
+// which always carries a VP column.
+func isSnellmanLedgerRow(parts []string) bool {
+	for _, part := range parts[1:] {
+		if strings.HasSuffix(strings.TrimSpace(part), " VP") {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	bgaBoardPattern   = regexp.MustCompile(`Game board: (.*)`)
+	bgaFactionPattern = regexp.MustCompile(`(?:.* is playing the (.*) Faction|.* selected the faction (.*) on [A-Za-z]+ to play)`)
+)
+
+// DetectUnsupportedBGAFeatures checks a BGA log for boards and factions the
+// engine does not have. It returns nil when the log can be parsed.
+func DetectUnsupportedBGAFeatures(content string) error {
+	var found unsupportedFeatures
+	for i, raw := range strings.Split(content, "\n") {
+		line := strings.TrimSpace(raw)
+		if m := bgaBoardPattern.FindStringSubmatch(line); m != nil {
+			name := strings.TrimSpace(m[1])
+			if _, ok := board.MapInfoByID(board.NormalizeMapID(name)); !ok {
+				found.add(UnsupportedMap, name, i+1)
+			}
+			continue
+		}
+		if m := bgaFactionPattern.FindStringSubmatch(line); m != nil {
+			name := strings.TrimSpace(m[1] + m[2])
+			if models.FactionTypeFromString(name) == models.FactionUnknown {
+				found.add(UnsupportedFaction, name, i+1)
+			}
+		}
+	}
+	return found.err("bga")
+}
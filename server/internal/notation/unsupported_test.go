@@ -0,0 +1,82 @@
+package notation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDetectUnsupportedSnellmanFeatures_ListsFeatures(t *testing.T) {
+	content := strings.Join([]string{
+		"option strict-leech",
+		"option fire-and-ice-final-scoring",
+		"option variable-turn-order",
+		"map 95a66999127893f5925a5f591d54f8bcb9a670e6",
+		"Round 1 scoring: SCORE9, TE >> 4",
+		"icemaidens\t\t20 VP\t\t15 C\t\t3 W\t\t0 P\t\t6/6/0 PW\t\t0/1/0/1\t\tsetup",
+		"witches\t\t20 VP\t\t15 C\t\t3 W\t\t0 P\t\t5/7/0 PW\t\t0/0/0/2\t\tsetup",
+		"icemaidens\t\t20 VP\t\t15 C\t\t3 W\t\t0 P\t\t6/6/0 PW\t\t0/1/0/1\t\tbuild E7",
+	}, "\n")
+
+	err := DetectUnsupportedSnellmanFeatures(content)
+	var unsupported *UnsupportedFeatureError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("expected an UnsupportedFeatureError, got %v", err)
+	}
+	want := []UnsupportedFeature{
+		{Kind: UnsupportedOption, Name: "fire-and-ice-final-scoring", Line: 2},
+		{Kind: UnsupportedMap, Name: "95a66999127893f5925a5f591d54f8bcb9a670e6", Line: 4},
+		{Kind: UnsupportedFaction, Name: "icemaidens", Line: 6},
+	}
+	if unsupported.Source != "snellman" || len(unsupported.Features) != len(want) {
+		t.Fatalf("unexpected features %+v", unsupported)
+	}
+	for i, feature := range want {
+		if unsupported.Features[i] != feature {
+			t.Fatalf("feature %d: got %+v, want %+v", i, unsupported.Features[i], feature)
+		}
+	}
+
+	if _, err := ConvertSnellmanToConciseForReplay(content); !errors.As(err, &unsupported) {
+		t.Fatalf("expected conversion to stop with an UnsupportedFeatureError, got %v", err)
+	}
+}
+
+func TestDetectUnsupportedSnellmanFeatures_AcceptsBaseGame(t *testing.T) {
+	content := strings.Join([]string{
+		"option strict-leech",
+		"option mini-expansion-1",
+		"Player 1: RA",
+		"witches\t\t20 VP\t\t15 C\t\t3 W\t\t0 P\t\t5/7/0 PW\t\t0/0/0/2\t\tsetup",
+		"chaosmagicians\t\t20 VP\t\t15 C\t\t4 W\t\t0 P\t\t5/7/0 PW\t\t2/0/0/0\t\tsetup",
+	}, "\n")
+	if err := DetectUnsupportedSnellmanFeatures(content); err != nil {
+		t.Fatalf("expected no unsupported features, got %v", err)
+	}
+}
+
+func TestDetectUnsupportedBGAFeatures_ChecksBoardAndFactions(t *testing.T) {
+	content := strings.Join([]string{
+		"Game board: Moonlit Isles",
+		"Alice is playing the Ice Maidens Faction",
+		"Bob is playing the Sky Pirates Faction (with 20 VP Starting VPs)",
+	}, "\n")
+
+	err := DetectUnsupportedBGAFeatures(content)
+	var unsupported *UnsupportedFeatureError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("expected an UnsupportedFeatureError, got %v", err)
+	}
+	if len(unsupported.Features) != 2 ||
+		unsupported.Features[0] != (UnsupportedFeature{Kind: UnsupportedMap, Name: "Moonlit Isles", Line: 1}) ||
+		unsupported.Features[1] != (UnsupportedFeature{Kind: UnsupportedFaction, Name: "Sky Pirates", Line: 3}) {
+		t.Fatalf("unexpected features %+v", unsupported.Features)
+	}
+	if !strings.Contains(err.Error(), "faction Sky Pirates (line 3)") {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+
+	if err := DetectUnsupportedBGAFeatures("Game board: Revised Base Game\nAlice is playing the Witches Faction"); err != nil {
+		t.Fatalf("expected a supported log to pass, got %v", err)
+	}
+}
@@ -0,0 +1,435 @@
+package protocol
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/lukev/tm_server/internal/az/actions"
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/lobby"
+)
+
+// schemaBaseURL prefixes the $id of every published document.
+const schemaBaseURL = "https://github.com/lukev/tm_server/schemas/"
+
+func gameRef(extra map[string]*Schema, required ...string) *Schema {
+	properties := map[string]*Schema{"gameId": String()}
+	for name, schema := range extra {
+		properties[name] = schema
+	}
+	return Object(properties, append([]string{"gameId"}, required...)...)
+}
+
+// PendingDecisionSchemas returns the schema of every pendingDecision payload
+// by its "type". Typed decisions are generated from their game structs.
+func PendingDecisionSchemas() map[string]*Schema {
+	decisions := map[string]*Schema{}
+	for _, decision := range []game.PendingDecision{
+		game.LeechOfferDecision{},
+		game.TownTileSelectionDecision{},
+		game.SpadeFollowupDecision{},
+		game.CultistsCultChoiceDecision{},
+		game.DarklingsOrdinationDecision{},
+		game.HalflingsSpadesDecision{},
+		game.TownCultTopChoiceDecision{},
+	} {
+		decisions[decision.DecisionType()] = For(decision)
+	}
+
+	spadeTargets := Nullable(ArrayOf(For(game.SpadeTarget{})))
+	factions := Nullable(ArrayOf(String()))
+	for decisionType, schema := range map[string]*Schema{
+		"auction_nomination": Object(map[string]*Schema{
+			"setupMode": String(), "nominatedFactions": factions,
+		}, "nominatedFactions"),
+		"auction_bid": Object(map[string]*Schema{
+			"setupMode": String(), "nominatedFactions": factions,
+		}, "nominatedFactions"),
+		"fast_auction_bid_matrix": Object(map[string]*Schema{
+			"playerIds": Nullable(ArrayOf(String())), "setupMode": String(), "nominatedFactions": factions,
+		}, "playerIds", "nominatedFactions"),
+		"setup_bonus_card": Object(map[string]*Schema{
+			"availableBonusCards": Nullable(ArrayOf(Integer())),
+		}, "availableBonusCards"),
+		"djinni_start_cult_choice": Object(nil),
+		"riverwalkers_priest_choice": Object(map[string]*Schema{
+			"priestsRemaining": Integer(),
+			"reason":           String(),
+			"canTakePriest":    Boolean(),
+			"coins":            Integer(),
+			"terrainOptions": ArrayOf(Object(map[string]*Schema{
+				"terrain": Integer(), "cost": Integer(), "unlocked": Boolean(), "affordable": Boolean(),
+			}, "terrain", "cost", "unlocked", "affordable")),
+		}, "priestsRemaining", "canTakePriest", "terrainOptions"),
+		"treasurers_deposit": Object(map[string]*Schema{
+			"availableCoins": Integer(), "availableWorkers": Integer(), "availablePriests": Integer(), "reason": String(),
+		}, "availableCoins", "availableWorkers", "availablePriests"),
+		"archivists_bonus_card": Object(map[string]*Schema{
+			"returnedCards": Nullable(ArrayOf(Integer())),
+		}, "returnedCards"),
+		"favor_tile_selection":      Object(map[string]*Schema{"count": Integer()}, "count"),
+		"goblins_cult_steps":        Object(map[string]*Schema{"stepsRemaining": Integer()}, "stepsRemaining"),
+		"wisps_stronghold_dwelling": Object(nil),
+		// Queued cult reward spades only carry spadesRemaining.
+		"cult_reward_spade": Object(map[string]*Schema{
+			"spadesRemaining": Integer(),
+			"targets":         spadeTargets,
+			"order":           Nullable(ArrayOf(String())),
+			"orderIndex":      Integer(),
+			"canSkip":         Boolean(),
+		}, "spadesRemaining"),
+		"post_action_free_actions": Object(nil),
+		"turn_confirmation":        Object(nil),
+	} {
+		decisions[decisionType] = schema
+	}
+
+	for decisionType, schema := range decisions {
+		if schema.Properties == nil {
+			schema.Properties = map[string]*Schema{}
+		}
+		schema.Properties["type"] = Const(decisionType)
+		schema.Properties["playerId"] = String()
+		// Entries of a pending_decisions message also say whether the game is
+		// blocked on them and which actions answer them.
+		schema.Properties["active"] = Boolean()
+		schema.Properties["responses"] = Nullable(ArrayOf(String()))
+		schema.Required = append([]string{"type", "playerId"}, withoutString(schema.Required, "playerId")...)
+		schema.Title = decisionType
+	}
+	return decisions
+}
+
+// PendingDecisionSchema accepts any pendingDecision payload.
+func PendingDecisionSchema() *Schema {
+	decisions := PendingDecisionSchemas()
+	schemas := make([]*Schema, 0, len(decisions))
+	for _, decisionType := range sortedKeys(decisions) {
+		schemas = append(schemas, decisions[decisionType])
+	}
+	return OneOf(schemas...)
+}
+
+// gameStateSchema describes game_state_update payloads: the fields clients
+// rely on, typed from the game's structs where the server sends them as is.
+func gameStateSchema() *Schema {
+	hex := Object(map[string]*Schema{
+		"coord":        Object(map[string]*Schema{"q": Integer(), "r": Integer()}, "q", "r"),
+		"terrain":      Integer(),
+		"displayCoord": String(),
+		"building": Object(map[string]*Schema{
+			"ownerPlayerId": String(), "faction": Integer(), "type": Integer(),
+		}, "ownerPlayerId", "faction", "type"),
+		"hasTownTile":             Boolean(),
+		"townTileType":            Integer(),
+		"townTileOwnerPlayerId":   String(),
+		"powerTokenOwnerPlayerId": String(),
+	}, "coord", "terrain")
+	coord := Object(map[string]*Schema{"q": Integer(), "r": Integer()}, "q", "r")
+	bridge := Object(map[string]*Schema{
+		"ownerPlayerId": String(), "faction": Integer(), "fromCoord": coord, "toCoord": coord,
+		"fromDisplayCoord": String(), "toDisplayCoord": String(),
+	}, "ownerPlayerId", "fromCoord", "toCoord")
+
+	// With hidden resources, other players' coins and power are withheld.
+	resources := Object(map[string]*Schema{
+		"coins":   Integer(),
+		"workers": Integer(),
+		"priests": Integer(),
+		"power": Object(map[string]*Schema{
+			"powerI": Integer(), "powerII": Integer(), "powerIII": Integer(),
+		}, "powerI", "powerII", "powerIII"),
+	}, "workers", "priests")
+	player := Object(map[string]*Schema{
+		"id":                      String(),
+		"name":                    String(),
+		"faction":                 Integer(),
+		"resources":               resources,
+		"resourcesHidden":         Boolean(),
+		"shipping":                Integer(),
+		"digging":                 Integer(),
+		"hasPassed":               Boolean(),
+		"resigned":                Boolean(),
+		"hasStrongholdAbility":    Boolean(),
+		"victoryPoints":           Integer(),
+		"keys":                    Integer(),
+		"townsFormed":             Integer(),
+		"specialActionsUsed":      Any(),
+		"availableSpecialActions": Nullable(ArrayOf(Any())),
+		"cults": Object(map[string]*Schema{
+			"0": Integer(), "1": Integer(), "2": Integer(), "3": Integer(),
+		}, "0", "1", "2", "3"),
+	}, "id", "faction", "resources", "shipping", "digging", "hasPassed", "victoryPoints", "cults")
+
+	return Object(map[string]*Schema{
+		"id":                 String(),
+		"revision":           Integer(),
+		"mapId":              String(),
+		"hiddenResources":    Boolean(),
+		"hotseat":            Boolean(),
+		"publicSummary":      Boolean(),
+		"seed":               Nullable(Integer()),
+		"phase":              Integer(),
+		"setupMode":          String(),
+		"turnOrderPolicy":    String(),
+		"setupSubphase":      String(),
+		"currentTurn":        Integer(),
+		"players":            MapOf(player),
+		"map":                Object(map[string]*Schema{"id": String(), "hexes": MapOf(hex), "bridges": ArrayOf(bridge)}, "hexes", "bridges"),
+		"turnOrder":          Nullable(ArrayOf(String())),
+		"passOrder":          Nullable(ArrayOf(String())),
+		"upcomingTurnOrder":  Nullable(ArrayOf(String())),
+		"leechWait":          Nullable(For(game.LeechWait{})),
+		"opponentSummaries":  For([]game.OpponentSummary(nil)),
+		"round":              Object(map[string]*Schema{"round": Integer()}, "round"),
+		"started":            Boolean(),
+		"finished":           Boolean(),
+		"scoringTiles":       For((*game.ScoringTileState)(nil)),
+		"bonusCards":         For((*game.BonusCardState)(nil)),
+		"townTiles":          For((*game.TownTileState)(nil)),
+		"powerActions":       For((*game.PowerActionState)(nil)),
+		"cultTracks":         For((*game.CultTrackState)(nil)),
+		"cultRewardProgress": Nullable(MapOf(For(game.CultRewardProgress{}))),
+		"pendingLeechOffers": For(map[string][]*game.PowerLeechOffer(nil)),
+		"pendingDecision":    Nullable(PendingDecisionSchema()),
+		"autoLeechDecisions": For([]game.AutoLeechDecision(nil)),
+		"nextRoundIncome":    Nullable(MapOf(For(game.IncomePreview{}))),
+		"passIncomeWarnings": Nullable(MapOf(MapOf(For([]game.IncomeWarning(nil))))),
+		"finalScoring":       For(map[string]*game.PlayerFinalScore(nil)),
+		"vacationPlayerIds":  Nullable(ArrayOf(String())),
+	}, "id", "revision", "phase", "players", "map", "turnOrder", "round", "started", "finished", "pendingDecision")
+}
+
+// MessageSchemas returns the payload schema of every message the server
+// sends, by message type.
+func MessageSchemas() map[string]*Schema {
+	state := gameStateSchema()
+	pendingDecisions := ArrayOf(PendingDecisionSchema())
+
+	return map[string]*Schema{
+		"lobby_state":    Describe(For([]*lobby.GameMeta(nil)), "Every game in the lobby."),
+		"available_maps": Describe(For([]board.MapInfo(nil)), "The maps a game can be created on."),
+		"message_catalog": Describe(Object(map[string]*Schema{
+			"locale":   String(),
+			"locales":  ArrayOf(String()),
+			"messages": MapOf(String()),
+		}, "locale", "locales", "messages"), "The client's locale and its localized messages, in reply to set_locale."),
+		"error": Describe(OneOf(String(), Object(map[string]*Schema{
+			"error": String(),
+		}, "error")), "A request failed: an error code, or an object carrying the code under \"error\"."),
+		"game_created":       Describe(gameRef(map[string]*Schema{"playerId": String()}, "playerId"), "The client created a game and holds its first seat."),
+		"model_game_started": Describe(gameRef(map[string]*Schema{"playerId": String()}, "playerId"), "A game against the model opponent was created and started."),
+		"game_joined":        Describe(gameRef(map[string]*Schema{"playerId": String()}, "playerId"), "The client took a seat in a game."),
+		"game_left": Describe(gameRef(map[string]*Schema{
+			"playerId": String(), "kicked": Boolean(), "banned": Boolean(),
+		}, "playerId"), "The client left a game's seat, or the host removed it."),
+		"rules_summary": Describe(For((*game.RulesSummary)(nil)), "The options in effect for a game, or those chosen so far for a lobby."),
+		"score_projection": Describe(gameRef(map[string]*Schema{
+			"scores": Nullable(For(map[string]*game.PlayerFinalScore(nil))),
+		}, "scores"), "The scores of a game if it ended now, as the requesting seat may see them."),
+		"game_state_update": Describe(state, "The full state of a game, filtered for the receiving seat."),
+		"decision_required": Describe(PendingDecisionSchema(), "The decision a game is blocked on."),
+		"pending_decisions": Describe(gameRef(map[string]*Schema{
+			"playerId":  String(),
+			"decisions": pendingDecisions,
+		}, "playerId", "decisions"), "Every decision the player owes, the blocking one first."),
+		"action_accepted": Describe(Object(map[string]*Schema{
+			"actionId":    String(),
+			"gameId":      String(),
+			"seq":         Integer(),
+			"newRevision": Integer(),
+			"duplicate":   Boolean(),
+			// consequences is null for duplicates.
+			"consequences": Nullable(For(game.ActionConsequences{})),
+		}, "actionId", "gameId", "seq", "newRevision", "duplicate"), "An action was executed."),
+		"action_rejected": Describe(Object(map[string]*Schema{
+			"actionId":         String(),
+			"gameId":           String(),
+			"seq":              Integer(),
+			"error":            String(),
+			"message":          String(),
+			"localizedMessage": String(),
+			"reasonCode":       String(),
+			"expectedRevision": Integer(),
+			"currentRevision":  Integer(),
+		}, "actionId", "error", "message", "localizedMessage"), "An action was refused; seq is the revision to roll back to."),
+		"action_validation": Describe(Object(map[string]*Schema{
+			"actionId":         String(),
+			"valid":            Boolean(),
+			"stage":            String(),
+			"error":            String(),
+			"reasonCode":       String(),
+			"message":          String(),
+			"localizedMessage": String(),
+		}, "actionId", "valid", "stage", "error", "reasonCode", "message"), "Whether validate_action's action would be accepted."),
+		"action_preview": Describe(Object(map[string]*Schema{
+			"actionId": String(),
+			"preview":  For((*game.ActionPreview)(nil)),
+		}, "actionId", "preview"), "What preview_action's action would cost and gain."),
+		"action_preview_error": Describe(Object(map[string]*Schema{
+			"actionId":         String(),
+			"error":            String(),
+			"reasonCode":       String(),
+			"message":          String(),
+			"localizedMessage": String(),
+		}, "actionId", "error", "message", "localizedMessage"), "Why preview_action's action could not be previewed; nothing was rejected."),
+		"legal_actions": Describe(gameRef(map[string]*Schema{
+			"playerId": String(),
+			"revision": Integer(),
+			"actions":  ArrayOf(For(actions.Option{})),
+		}, "playerId", "revision", "actions"), "The actions the seat may take, for perform_legal_action."),
+		"phase_changed":       Describe(For(game.PhaseChange{}), "A game moved to another phase."),
+		"power_gained":        Describe(For(game.PowerGainEvent{}), "A player gained power."),
+		"income_report":       Describe(For(game.IncomeReport{}), "The income a player received."),
+		"leech_auto_resolved": Describe(For(game.AutoLeechDecision{}), "The game answered a leech offer for a player."),
+		"scoring_step": Describe(func() *Schema {
+			step := For(game.ScoringStep{})
+			step.Properties["gameId"] = String()
+			step.Required = append([]string{"gameId"}, step.Required...)
+			return step
+		}(), "One step of the final scoring reveal."),
+		"turn_reminder": Describe(For(game.TurnReminder{}), "The seat has been on turn for a while."),
+		"vacation_status": Describe(Object(map[string]*Schema{
+			"playerId":    String(),
+			"onVacation":  Boolean(),
+			"sinceMs":     Integer(),
+			"remainingMs": Integer(),
+		}, "playerId", "onVacation", "sinceMs", "remainingMs"), "The player's vacation status, in reply to set_vacation."),
+		"leech_preferences": Describe(Object(map[string]*Schema{
+			"playerId":    String(),
+			"preferences": For(game.LeechPreferences{}),
+		}, "playerId", "preferences"), "The player's leech preferences, in reply to set_leech_preferences."),
+		"confirmation_preferences": Describe(Object(map[string]*Schema{
+			"playerId":    String(),
+			"confirmPass": Boolean(),
+			"confirmBurn": Boolean(),
+		}, "playerId", "confirmPass", "confirmBurn"), "Whether the player's passes and power burns need confirmation in all their games, in reply to set_confirmation_preferences."),
+		"my_games": Describe(Object(map[string]*Schema{
+			"playerId": String(),
+			"games": Nullable(ArrayOf(Object(map[string]*Schema{
+				"gameId":        String(),
+				"name":          String(),
+				"players":       Nullable(ArrayOf(String())),
+				"maxPlayers":    Integer(),
+				"started":       Boolean(),
+				"phase":         Integer(),
+				"round":         Integer(),
+				"revision":      Integer(),
+				"activePlayers": Nullable(ArrayOf(String())),
+				"yourTurn":      Boolean(),
+				"ended":         Boolean(),
+			}, "gameId", "name", "players", "maxPlayers", "started", "yourTurn", "ended"))),
+		}, "playerId", "games"), "The games the player is seated in."),
+		"games_resumed": Describe(Object(map[string]*Schema{
+			"playerId": String(),
+			"gameIds":  Nullable(ArrayOf(String())),
+		}, "playerId", "gameIds"), "The games resume_game re-seated the client in."),
+		"annotations": Describe(gameRef(map[string]*Schema{
+			"viewerId":    String(),
+			"annotations": ArrayOf(For(game.BoardAnnotation{})),
+		}, "viewerId", "annotations"), "The board annotations the client sees in a game."),
+		"bot_status": Describe(gameRef(map[string]*Schema{
+			"playerId": String(),
+			"thinking": Boolean(),
+			"lastMove": String(),
+		}, "playerId", "thinking", "lastMove"), "What a bot seat is doing."),
+		"test_command_applied": Describe(Object(map[string]*Schema{
+			"gameID": String(),
+		}, "gameID"), "A test command was applied; only sent with TM_ENABLE_TEST_COMMANDS=1."),
+	}
+}
+
+// MessageSchema returns the schema of whole messages of msgType: the
+// envelope with its payload.
+func MessageSchema(msgType string) (*Schema, bool) {
+	payload, ok := MessageSchemas()[msgType]
+	if !ok {
+		return nil, false
+	}
+	return envelope(msgType, payload), true
+}
+
+func envelope(msgType string, payload *Schema) *Schema {
+	body := *payload
+	body.Description = ""
+	message := Object(map[string]*Schema{
+		"type":    Const(msgType),
+		"payload": &body,
+	}, "type", "payload")
+	message.Title = msgType
+	message.Description = payload.Description
+	return message
+}
+
+// messageSchemas caches MessageSchemas for ValidateMessage.
+var messageSchemas = MessageSchemas()
+
+// ValidateMessage checks a raw server message against the schema of its
+// type. Messages of unknown types are an error.
+func ValidateMessage(raw []byte) error {
+	var message map[string]any
+	if err := json.Unmarshal(raw, &message); err != nil {
+		return fmt.Errorf("invalid message: %w", err)
+	}
+	msgType, _ := message["type"].(string)
+	payload, ok := messageSchemas[msgType]
+	if !ok {
+		return fmt.Errorf("no schema for message type %q", msgType)
+	}
+	if err := envelope(msgType, payload).Validate(message); err != nil {
+		return fmt.Errorf("%s message: %w", msgType, err)
+	}
+	return nil
+}
+
+// Documents renders the published schema documents by file name: one per
+// message type under messages/ and one per pendingDecision type under
+// pending_decisions/.
+func Documents() (map[string][]byte, error) {
+	documents := map[string][]byte{}
+	add := func(name string, schema *Schema) error {
+		doc := *schema
+		doc.Dialect = SchemaDialect
+		doc.ID = schemaBaseURL + name
+		raw, err := json.MarshalIndent(&doc, "", "  ")
+		if err != nil {
+			return fmt.Errorf("render %s: %w", name, err)
+		}
+		documents[name] = append(raw, '\n')
+		return nil
+	}
+	messages := MessageSchemas()
+	for _, msgType := range sortedKeys(messages) {
+		if err := add("messages/"+msgType+".schema.json", envelope(msgType, messages[msgType])); err != nil {
+			return nil, err
+		}
+	}
+	decisions := PendingDecisionSchemas()
+	for _, decisionType := range sortedKeys(decisions) {
+		if err := add("pending_decisions/"+decisionType+".schema.json", decisions[decisionType]); err != nil {
+			return nil, err
+		}
+	}
+	return documents, nil
+}
+
+func sortedKeys(schemas map[string]*Schema) []string {
+	keys := make([]string, 0, len(schemas))
+	for key := range schemas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func withoutString(values []string, drop string) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != drop {
+			out = append(out, value)
+		}
+	}
+	return out
+}
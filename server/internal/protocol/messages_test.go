@@ -0,0 +1,98 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/lobby"
+)
+
+const schemasDir = "schemas"
+
+// TestPublishedSchemasUpToDate keeps schemas/ in step with the code. Run with
+// TM_UPDATE_SCHEMAS=1 to regenerate it.
+func TestPublishedSchemasUpToDate(t *testing.T) {
+	documents, err := Documents()
+	if err != nil {
+		t.Fatalf("render documents: %v", err)
+	}
+	update := os.Getenv("TM_UPDATE_SCHEMAS") == "1"
+	if update {
+		if err := os.RemoveAll(schemasDir); err != nil {
+			t.Fatalf("clear %s: %v", schemasDir, err)
+		}
+	}
+	for name, want := range documents {
+		path := filepath.Join(schemasDir, filepath.FromSlash(name))
+		if update {
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatalf("create %s: %v", filepath.Dir(path), err)
+			}
+			if err := os.WriteFile(path, want, 0o644); err != nil {
+				t.Fatalf("write %s: %v", path, err)
+			}
+			continue
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v (run with TM_UPDATE_SCHEMAS=1 to regenerate)", path, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("%s is out of date; run with TM_UPDATE_SCHEMAS=1 to regenerate", path)
+		}
+	}
+
+	published, err := filepath.Glob(filepath.Join(schemasDir, "*", "*.schema.json"))
+	if err != nil {
+		t.Fatalf("list %s: %v", schemasDir, err)
+	}
+	for _, path := range published {
+		name := filepath.ToSlash(strings.TrimPrefix(path, schemasDir+string(filepath.Separator)))
+		if _, ok := documents[name]; !ok {
+			t.Fatalf("%s has no schema any more; run with TM_UPDATE_SCHEMAS=1 to remove it", path)
+		}
+	}
+}
+
+func TestPendingDecisionSchemas_HaveResponses(t *testing.T) {
+	for decisionType := range PendingDecisionSchemas() {
+		if len(game.DecisionResponses(decisionType)) == 0 {
+			t.Fatalf("pending decision %q has a schema but no responses", decisionType)
+		}
+	}
+}
+
+func TestValidateMessage(t *testing.T) {
+	lobbyState, err := json.Marshal(map[string]any{
+		"type":    "lobby_state",
+		"payload": []*lobby.GameMeta{{ID: "g1", Name: "game", MaxPlayers: 2, Players: []string{"p1"}}},
+	})
+	if err != nil {
+		t.Fatalf("marshal lobby_state: %v", err)
+	}
+	if err := ValidateMessage(lobbyState); err != nil {
+		t.Fatalf("expected lobby_state to validate: %v", err)
+	}
+
+	for raw, want := range map[string]string{
+		`{"type": "error", "payload": "not_in_game"}`:                                                 "",
+		`{"type": "error", "payload": {"error": "game_not_full", "playerCount": 1}}`:                  "",
+		`{"type": "decision_required", "payload": {"type": "turn_confirmation", "playerId": "a"}}`:    "",
+		`{"type": "decision_required", "payload": {"type": "favor_tile_selection", "playerId": "a"}}`: "decision_required message",
+		`{"type": "game_joined", "payload": {"gameId": "g1"}}`:                                        `missing required property "playerId"`,
+		`{"type": "made_up", "payload": {}}`:                                                          `no schema for message type "made_up"`,
+	} {
+		err := ValidateMessage([]byte(raw))
+		switch {
+		case want == "" && err != nil:
+			t.Fatalf("expected %s to validate: %v", raw, err)
+		case want != "" && (err == nil || !strings.Contains(err.Error(), want)):
+			t.Fatalf("validate %s: got %v, want %q", raw, err, want)
+		}
+	}
+}
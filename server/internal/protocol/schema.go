@@ -0,0 +1,226 @@
+// Package protocol is the machine-checkable definition of the websocket
+// protocol: a JSON Schema for every server message and pendingDecision
+// payload, and a validator for them.
+//
+// Schemas of payloads the server marshals from Go structs are generated from
+// those structs, so they follow the code; payloads built as maps are
+// described by hand next to the registry. The published documents under
+// schemas/ are regenerated with
+//
+//	TM_UPDATE_SCHEMAS=1 go test ./internal/protocol
+package protocol
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SchemaDialect is the JSON Schema draft the documents declare.
+const SchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is the subset of JSON Schema the protocol uses. Objects accept
+// properties they do not list, so the server may add fields without breaking
+// clients.
+type Schema struct {
+	Dialect              string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Const                any                `json:"const,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+}
+
+// Types is a schema's "type": one JSON type, or several when a value may
+// also be null.
+type Types []string
+
+// MarshalJSON writes a single type as a string and several as an array.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// JSON types.
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeNull    = "null"
+)
+
+// Any accepts every value.
+func Any() *Schema { return &Schema{} }
+
+// String accepts a string.
+func String() *Schema { return &Schema{Type: Types{TypeString}} }
+
+// Integer accepts a whole number.
+func Integer() *Schema { return &Schema{Type: Types{TypeInteger}} }
+
+// Number accepts any number.
+func Number() *Schema { return &Schema{Type: Types{TypeNumber}} }
+
+// Boolean accepts true or false.
+func Boolean() *Schema { return &Schema{Type: Types{TypeBoolean}} }
+
+// Const accepts exactly value.
+func Const(value any) *Schema { return &Schema{Const: value} }
+
+// ArrayOf accepts an array whose items match items.
+func ArrayOf(items *Schema) *Schema { return &Schema{Type: Types{TypeArray}, Items: items} }
+
+// MapOf accepts an object whose values match values, whatever the keys.
+func MapOf(values *Schema) *Schema {
+	return &Schema{Type: Types{TypeObject}, AdditionalProperties: values}
+}
+
+// Object accepts an object with properties, of which required must be
+// present.
+func Object(properties map[string]*Schema, required ...string) *Schema {
+	return &Schema{Type: Types{TypeObject}, Properties: properties, Required: required}
+}
+
+// OneOf accepts a value matching exactly one of schemas.
+func OneOf(schemas ...*Schema) *Schema { return &Schema{OneOf: schemas} }
+
+// Nullable returns a schema accepting what s accepts, and null.
+func Nullable(s *Schema) *Schema {
+	out := *s
+	switch {
+	case len(out.Type) > 0:
+		if !out.Type.has(TypeNull) {
+			out.Type = append(append(Types(nil), out.Type...), TypeNull)
+		}
+	case out.Const != nil || len(out.Enum) > 0 || len(out.OneOf) > 0:
+		return OneOf(&Schema{Type: Types{TypeNull}}, s)
+	}
+	return &out
+}
+
+// Describe returns a copy of s with description set.
+func Describe(s *Schema, description string) *Schema {
+	out := *s
+	out.Description = description
+	return &out
+}
+
+func (t Types) has(want string) bool {
+	for _, typ := range t {
+		if typ == want {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	rawType       = reflect.TypeOf(json.RawMessage(nil))
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// For generates the schema of the JSON encoding/json produces for values of
+// v's type.
+func For(v any) *Schema {
+	return forType(reflect.TypeOf(v), map[reflect.Type]bool{})
+}
+
+// forType builds the schema of t. visiting breaks cycles through recursive
+// types, which then accept any value.
+func forType(t reflect.Type, visiting map[reflect.Type]bool) *Schema {
+	if t == nil || t == rawType {
+		return Any()
+	}
+	if t == timeType {
+		return String()
+	}
+	if t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+		return Any()
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return Nullable(forType(t.Elem(), visiting))
+	case reflect.Interface:
+		return Any()
+	case reflect.Bool:
+		return Boolean()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Integer()
+	case reflect.Float32, reflect.Float64:
+		return Number()
+	case reflect.String:
+		return String()
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return Nullable(String())
+		}
+		return Nullable(ArrayOf(forType(t.Elem(), visiting)))
+	case reflect.Array:
+		return ArrayOf(forType(t.Elem(), visiting))
+	case reflect.Map:
+		return Nullable(MapOf(forType(t.Elem(), visiting)))
+	case reflect.Struct:
+		if visiting[t] {
+			return Any()
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+		schema := Object(map[string]*Schema{})
+		addStructFields(schema, t, visiting)
+		return schema
+	default:
+		return Any()
+	}
+}
+
+// addStructFields adds t's encoded fields to schema, flattening embedded
+// structs as encoding/json does. Fields without omitempty are required.
+func addStructFields(schema *Schema, t reflect.Type, visiting map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addStructFields(schema, embedded, visiting)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fieldSchema := forType(field.Type, visiting)
+		if strings.Contains(options, "string") {
+			fieldSchema = String()
+		}
+		schema.Properties[name] = fieldSchema
+		if !strings.Contains(options, "omitempty") && !strings.Contains(options, "omitzero") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
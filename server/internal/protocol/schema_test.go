@@ -0,0 +1,90 @@
+package protocol
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type innerPayload struct {
+	Count int `json:"count"`
+}
+
+type samplePayload struct {
+	innerPayload
+	Name     string            `json:"name"`
+	Note     string            `json:"note,omitempty"`
+	Next     *innerPayload     `json:"next"`
+	Tags     []string          `json:"tags"`
+	Scores   map[string]int    `json:"scores,omitempty"`
+	Raw      json.RawMessage   `json:"raw,omitempty"`
+	Ignored  string            `json:"-"`
+	Nested   map[string][]bool `json:"nested,omitempty"`
+	internal int
+}
+
+func decode(t *testing.T, raw string) any {
+	t.Helper()
+	var value any
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		t.Fatalf("unmarshal %s: %v", raw, err)
+	}
+	return value
+}
+
+func TestFor_StructFields(t *testing.T) {
+	schema := For(samplePayload{})
+	if !slices.Equal(schema.Required, []string{"count", "name", "next", "tags"}) {
+		t.Fatalf("unexpected required fields: %v", schema.Required)
+	}
+	for _, name := range []string{"Ignored", "internal", "innerPayload"} {
+		if _, ok := schema.Properties[name]; ok {
+			t.Fatalf("expected %s not to be a property", name)
+		}
+	}
+	if got := schema.Properties["next"].Type; !slices.Equal(got, Types{TypeObject, TypeNull}) {
+		t.Fatalf("expected pointer field to be a nullable object, got %v", got)
+	}
+	if got := schema.Properties["scores"].AdditionalProperties.Type; !slices.Equal(got, Types{TypeInteger}) {
+		t.Fatalf("expected map values to be integers, got %v", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	schema := For(samplePayload{})
+	valid := `{"count": 2, "name": "a", "next": null, "tags": ["x"], "raw": {"any": [1]}, "extra": true}`
+	if err := schema.Validate(decode(t, valid)); err != nil {
+		t.Fatalf("expected payload to validate: %v", err)
+	}
+
+	for raw, want := range map[string]string{
+		`{"name": "a", "next": null, "tags": null}`:                               `missing required property "count"`,
+		`{"count": 1.5, "name": "a", "next": null, "tags": null}`:                 "/count: expected integer, got number",
+		`{"count": 1, "name": "a", "next": {"count": "x"}, "tags": null}`:         "/next/count: expected integer, got string",
+		`{"count": 1, "name": "a", "next": null, "tags": [1]}`:                    "/tags/0: expected string, got integer",
+		`{"count": 1, "name": "a", "next": null, "tags": [], "nested": {"k": 3}}`: "/nested/k: expected array or null, got integer",
+	} {
+		err := schema.Validate(decode(t, raw))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("validate %s: got %v, want %q", raw, err, want)
+		}
+	}
+}
+
+func TestValidate_OneOfAndNullable(t *testing.T) {
+	schema := Nullable(OneOf(
+		Object(map[string]*Schema{"type": Const("a"), "n": Integer()}, "type", "n"),
+		Object(map[string]*Schema{"type": Const("b")}, "type"),
+	))
+	for _, raw := range []string{`null`, `{"type": "a", "n": 1}`, `{"type": "b"}`} {
+		if err := schema.Validate(decode(t, raw)); err != nil {
+			t.Fatalf("expected %s to validate: %v", raw, err)
+		}
+	}
+	for _, raw := range []string{`{"type": "a"}`, `{"type": "c"}`, `"a"`} {
+		if err := schema.Validate(decode(t, raw)); err == nil {
+			t.Fatalf("expected %s to be rejected", raw)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package protocol
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ValidationError is a value that does not match its schema, at Path: a JSON
+// pointer into the value.
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("%s: %s", path, e.Message)
+}
+
+// Validate checks a decoded JSON value (as produced by encoding/json into
+// an any) against s, returning the first mismatch.
+func (s *Schema) Validate(value any) error {
+	return s.validate("", value)
+}
+
+func (s *Schema) validate(path string, value any) error {
+	if s == nil {
+		return nil
+	}
+	fail := func(format string, args ...any) error {
+		return &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if len(s.Type) > 0 {
+		typ := jsonType(value)
+		if !s.Type.has(typ) && !(typ == TypeInteger && s.Type.has(TypeNumber)) {
+			return fail("expected %s, got %s", strings.Join(s.Type, " or "), typ)
+		}
+	}
+	if s.Const != nil && !reflect.DeepEqual(s.Const, value) {
+		return fail("expected %v, got %v", s.Const, value)
+	}
+	if len(s.Enum) > 0 && !containsValue(s.Enum, value) {
+		return fail("%v is not one of %v", value, s.Enum)
+	}
+	if len(s.OneOf) > 0 {
+		matched := 0
+		var firstErr error
+		for _, option := range s.OneOf {
+			if err := option.validate(path, value); err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			matched++
+		}
+		switch {
+		case matched == 0 && len(s.OneOf) == 1:
+			return firstErr
+		case matched == 0:
+			return fail("matches none of %d alternatives", len(s.OneOf))
+		case matched > 1:
+			return fail("matches %d of %d alternatives", matched, len(s.OneOf))
+		}
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := typed[name]; !ok {
+				return fail("missing required property %q", name)
+			}
+		}
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			property, ok := s.Properties[key]
+			if !ok {
+				property = s.AdditionalProperties
+			}
+			if err := property.validate(path+"/"+escapePointer(key), typed[key]); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, item := range typed {
+			if err := s.Items.validate(fmt.Sprintf("%s/%d", path, i), item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonType names the JSON type of a decoded value. Whole numbers are
+// integers.
+func jsonType(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return TypeNull
+	case bool:
+		return TypeBoolean
+	case string:
+		return TypeString
+	case float64:
+		if typed == math.Trunc(typed) && !math.IsInf(typed, 0) {
+			return TypeInteger
+		}
+		return TypeNumber
+	case []any:
+		return TypeArray
+	case map[string]any:
+		return TypeObject
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
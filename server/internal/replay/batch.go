@@ -0,0 +1,256 @@
+package replay
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lukev/tm_server/internal/game"
+)
+
+// BatchGame is one game log of a batch validation run.
+type BatchGame struct {
+	GameID string
+	Log    string
+	// Format is the log format passed to ImportText ("snellman", "bga",
+	// "concise" or "auto").
+	Format string
+	// ExpectedFactions, if set, lists every player or faction of the game.
+	// Names are compared ignoring case and punctuation.
+	ExpectedFactions []string
+	// ExpectedTotalVP maps player or faction names to final VP totals.
+	ExpectedTotalVP map[string]int
+	// ExpectedRoundVP maps rounds to player or faction names to their VP at
+	// the end of the round, before final scoring.
+	ExpectedRoundVP map[int]map[string]int
+}
+
+// BatchResult is the outcome of replaying one BatchGame.
+type BatchResult struct {
+	GameID string
+	// Session is the replay session after the run, or nil if the log could
+	// not be imported.
+	Session *ReplaySession
+	// FailingIndex is the action index the replay stopped at, or -1 if every
+	// action was replayed.
+	FailingIndex int
+	Err          error
+	Duration     time.Duration
+	// Coverage counts the rules paths the replay exercised, up to where it
+	// stopped.
+	Coverage *Coverage
+}
+
+// BatchReport aggregates the results of a batch run. Results are in the
+// order the games were given.
+type BatchReport struct {
+	Results []BatchResult
+	Passed  int
+	Failed  int
+	Workers int
+	Elapsed time.Duration
+}
+
+// Coverage merges the coverage of every replayed game.
+func (r *BatchReport) Coverage() *Coverage {
+	coverage := NewCoverage()
+	for _, result := range r.Results {
+		coverage.Merge(result.Coverage)
+	}
+	return coverage
+}
+
+// Failures returns the results of the games that did not validate.
+func (r *BatchReport) Failures() []BatchResult {
+	var out []BatchResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			out = append(out, result)
+		}
+	}
+	return out
+}
+
+// ValidateBatch replays games to completion on a pool of workers and checks
+// their final scores. Every game runs in its own ReplayManager, so games share
+// no GameState. workers <= 0 uses GOMAXPROCS. Imported logs are stored under
+// scriptDir.
+func ValidateBatch(games []BatchGame, scriptDir string, workers int) *BatchReport {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(games) {
+		workers = len(games)
+	}
+
+	start := time.Now()
+	report := &BatchReport{
+		Results: make([]BatchResult, len(games)),
+		Workers: workers,
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				started := time.Now()
+				result := validateBatchGame(games[i], scriptDir)
+				result.Duration = time.Since(started)
+				report.Results[i] = result
+			}
+		}()
+	}
+	for i := range games {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, result := range report.Results {
+		if result.Err != nil {
+			report.Failed++
+		} else {
+			report.Passed++
+		}
+	}
+	report.Elapsed = time.Since(start)
+	return report
+}
+
+func validateBatchGame(g BatchGame, scriptDir string) BatchResult {
+	result := BatchResult{GameID: g.GameID, FailingIndex: -1}
+
+	manager := NewReplayManager(scriptDir)
+	if err := manager.ImportText(g.GameID, g.Log, g.Format); err != nil {
+		result.Err = fmt.Errorf("import failed: %w", err)
+		return result
+	}
+	session := manager.GetSession(strings.TrimSpace(g.GameID))
+	if session == nil {
+		result.Err = fmt.Errorf("session not found after import")
+		return result
+	}
+	result.Session = session
+	result.Coverage = NewCoverage()
+	session.Simulator.SetCoverage(result.Coverage)
+
+	totalActions := len(session.Simulator.Actions)
+	err := manager.JumpTo(session.GameID, totalActions)
+	result.Coverage.AddGame(session.Simulator.GetState())
+	if err != nil {
+		result.FailingIndex = session.Simulator.CurrentIndex
+		result.Err = fmt.Errorf("JumpTo(%d) failed at index %d: %w", totalActions, result.FailingIndex, err)
+		return result
+	}
+
+	state := session.Simulator.GetState()
+	if state == nil {
+		result.Err = fmt.Errorf("state is nil after replay")
+		return result
+	}
+	if state.FinalScoring == nil {
+		result.Err = fmt.Errorf("final scoring is nil")
+		return result
+	}
+	if len(g.ExpectedFactions) > 0 {
+		if err := checkBatchFactions(g.ExpectedFactions, state.Players); err != nil {
+			result.Err = err
+			return result
+		}
+	}
+	for expectedPlayer, expectedTotal := range g.ExpectedTotalVP {
+		matched := false
+		for actualPlayer, score := range state.FinalScoring {
+			if score == nil || normalizePlayerKey(actualPlayer) != normalizePlayerKey(expectedPlayer) {
+				continue
+			}
+			matched = true
+			if score.TotalVP != expectedTotal {
+				result.Err = fmt.Errorf("%s final total VP mismatch: got %d, want %d (score=%+v)", expectedPlayer, score.TotalVP, expectedTotal, *score)
+				return result
+			}
+			break
+		}
+		if !matched {
+			result.Err = fmt.Errorf("missing final scoring entry for %q", expectedPlayer)
+			return result
+		}
+	}
+	if len(g.ExpectedRoundVP) > 0 {
+		progression, err := RecordVPProgression(session)
+		if err != nil {
+			result.Err = fmt.Errorf("record VP progression: %w", err)
+			return result
+		}
+		if err := checkBatchRoundVP(g.ExpectedRoundVP, progression.Rounds); err != nil {
+			result.Err = err
+			return result
+		}
+	}
+	return result
+}
+
+func checkBatchFactions(expected []string, players map[string]*game.Player) error {
+	want := make(map[string]bool, len(expected))
+	for _, name := range expected {
+		want[normalizePlayerKey(name)] = true
+	}
+	got := make(map[string]bool, len(players))
+	for playerID := range players {
+		got[normalizePlayerKey(playerID)] = true
+	}
+	for name := range want {
+		if !got[name] {
+			return fmt.Errorf("expected faction %q is not in the game", name)
+		}
+	}
+	for name := range got {
+		if !want[name] {
+			return fmt.Errorf("unexpected faction %q in the game", name)
+		}
+	}
+	return nil
+}
+
+func checkBatchRoundVP(expected map[int]map[string]int, rounds []game.VPRoundPoints) error {
+	for round, expectedVP := range expected {
+		var actual map[string]int
+		for _, points := range rounds {
+			if points.Round == round {
+				actual = points.VP
+			}
+		}
+		if actual == nil {
+			return fmt.Errorf("round %d was not reached", round)
+		}
+		for expectedPlayer, expectedTotal := range expectedVP {
+			matched := false
+			for actualPlayer, vp := range actual {
+				if normalizePlayerKey(actualPlayer) != normalizePlayerKey(expectedPlayer) {
+					continue
+				}
+				matched = true
+				if vp != expectedTotal {
+					return fmt.Errorf("%s round %d VP mismatch: got %d, want %d", expectedPlayer, round, vp, expectedTotal)
+				}
+			}
+			if !matched {
+				return fmt.Errorf("missing round %d VP for %q", round, expectedPlayer)
+			}
+		}
+	}
+	return nil
+}
+
+var nonAlnum = regexp.MustCompile(`[^a-z0-9]+`)
+
+func normalizePlayerKey(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return nonAlnum.ReplaceAllString(s, "")
+}
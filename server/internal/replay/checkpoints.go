@@ -0,0 +1,233 @@
+package replay
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/game/board"
+)
+
+// Checkpoint is the per-player state after a replay has applied Index log
+// items. Checkpoints from two runs of the same log, e.g. before and after a
+// rules change, are compared with DiffCheckpoints.
+type Checkpoint struct {
+	Index int `json:"index"`
+	// Line is the concise log line of the last applied item, for orientation.
+	Line    string                      `json:"line,omitempty"`
+	Players map[string]PlayerCheckpoint `json:"players"`
+}
+
+// PlayerCheckpoint is the part of a player's state that replay diffs compare.
+type PlayerCheckpoint struct {
+	Coins    int    `json:"coins"`
+	Workers  int    `json:"workers"`
+	Priests  int    `json:"priests"`
+	Power    [3]int `json:"power"`
+	VP       int    `json:"vp"`
+	Cults    [4]int `json:"cults"`
+	Shipping int    `json:"shipping"`
+	Digging  int    `json:"digging"`
+	// Buildings maps display coordinates to building types.
+	Buildings map[string]string `json:"buildings"`
+}
+
+// Divergence is the first difference found for a player.
+type Divergence struct {
+	PlayerID string `json:"playerId"`
+	Index    int    `json:"index"`
+	Line     string `json:"line,omitempty"`
+	Field    string `json:"field"`
+	Left     string `json:"left"`
+	Right    string `json:"right"`
+}
+
+// RecordCheckpoints replays session from the start and captures a checkpoint
+// after every log item. If the replay fails, the checkpoints recorded so far
+// are returned with the error.
+func RecordCheckpoints(session *ReplaySession) ([]Checkpoint, error) {
+	initialState, err := createInitialState(session.Simulator.Actions)
+	if err != nil {
+		return nil, err
+	}
+	sim := NewGameSimulator(initialState, session.Simulator.Actions)
+	checkpoints := make([]Checkpoint, 0, len(sim.Actions))
+	for index := 1; index <= len(sim.Actions); index++ {
+		if err := sim.JumpTo(index); err != nil {
+			return checkpoints, fmt.Errorf("item %d (%s): %w", index-1, session.logLine(index-1), err)
+		}
+		checkpoints = append(checkpoints, Checkpoint{
+			Index:   index,
+			Line:    session.logLine(index - 1),
+			Players: capturePlayers(sim.GetState()),
+		})
+	}
+	return checkpoints, nil
+}
+
+func (s *ReplaySession) logLine(itemIndex int) string {
+	if itemIndex < 0 || itemIndex >= len(s.LogLocations) {
+		return ""
+	}
+	line := s.LogLocations[itemIndex].LineIndex
+	if line < 0 || line >= len(s.LogStrings) {
+		return ""
+	}
+	return strings.TrimSpace(s.LogStrings[line])
+}
+
+func capturePlayers(gs *game.GameState) map[string]PlayerCheckpoint {
+	players := make(map[string]PlayerCheckpoint, len(gs.Players))
+	for playerID, player := range gs.Players {
+		if player == nil || player.Resources == nil {
+			continue
+		}
+		cp := PlayerCheckpoint{
+			Coins:     player.Resources.Coins,
+			Workers:   player.Resources.Workers,
+			Priests:   player.Resources.Priests,
+			VP:        player.VictoryPoints,
+			Shipping:  player.ShippingLevel,
+			Digging:   player.DiggingLevel,
+			Buildings: make(map[string]string),
+		}
+		if power := player.Resources.Power; power != nil {
+			cp.Power = [3]int{power.Bowl1, power.Bowl2, power.Bowl3}
+		}
+		for _, track := range []game.CultTrack{game.CultFire, game.CultWater, game.CultEarth, game.CultAir} {
+			if gs.CultTracks != nil {
+				cp.Cults[track] = gs.CultTracks.GetPosition(playerID, track)
+			}
+		}
+		players[playerID] = cp
+	}
+	if gs.Map != nil {
+		for hex, mapHex := range gs.Map.Hexes {
+			if mapHex == nil || mapHex.Building == nil {
+				continue
+			}
+			cp, ok := players[mapHex.Building.PlayerID]
+			if !ok {
+				continue
+			}
+			label, ok := board.DisplayCoordinateForHex(gs.Map.ID, hex)
+			if !ok {
+				label = fmt.Sprintf("%d,%d", hex.Q, hex.R)
+			}
+			cp.Buildings[label] = mapHex.Building.Type.String()
+		}
+	}
+	return players
+}
+
+// DiffCheckpoints compares two checkpoint sequences of the same log and
+// reports the first divergence of every player, ordered by index. A player
+// missing from one side, or a sequence ending early, is a divergence too.
+func DiffCheckpoints(left, right []Checkpoint) []Divergence {
+	found := make(map[string]Divergence)
+	n := len(left)
+	if len(right) < n {
+		n = len(right)
+	}
+	for i := 0; i < n; i++ {
+		for playerID := range unionKeys(left[i].Players, right[i].Players) {
+			if _, done := found[playerID]; done {
+				continue
+			}
+			l, inLeft := left[i].Players[playerID]
+			r, inRight := right[i].Players[playerID]
+			var field, lv, rv string
+			switch {
+			case !inLeft || !inRight:
+				field, lv, rv = "player", fmt.Sprint(inLeft), fmt.Sprint(inRight)
+			default:
+				field, lv, rv = firstPlayerDifference(l, r)
+			}
+			if field != "" {
+				found[playerID] = Divergence{PlayerID: playerID, Index: left[i].Index, Line: left[i].Line, Field: field, Left: lv, Right: rv}
+			}
+		}
+	}
+	if len(left) != len(right) {
+		// Every player not yet diverged diverges where the shorter run stopped.
+		longer := left
+		if len(right) > len(left) {
+			longer = right
+		}
+		for playerID := range longer[n].Players {
+			if _, done := found[playerID]; !done {
+				found[playerID] = Divergence{
+					PlayerID: playerID,
+					Index:    longer[n].Index,
+					Line:     longer[n].Line,
+					Field:    "checkpoints",
+					Left:     fmt.Sprint(len(left)),
+					Right:    fmt.Sprint(len(right)),
+				}
+			}
+		}
+	}
+
+	out := make([]Divergence, 0, len(found))
+	for _, d := range found {
+		out = append(out, d)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Index != out[j].Index {
+			return out[i].Index < out[j].Index
+		}
+		return out[i].PlayerID < out[j].PlayerID
+	})
+	return out
+}
+
+func firstPlayerDifference(l, r PlayerCheckpoint) (field, left, right string) {
+	fields := []struct {
+		name        string
+		left, right any
+	}{
+		{"coins", l.Coins, r.Coins},
+		{"workers", l.Workers, r.Workers},
+		{"priests", l.Priests, r.Priests},
+		{"power", l.Power, r.Power},
+		{"vp", l.VP, r.VP},
+		{"cults", l.Cults, r.Cults},
+		{"shipping", l.Shipping, r.Shipping},
+		{"digging", l.Digging, r.Digging},
+	}
+	for _, f := range fields {
+		if f.left != f.right {
+			return f.name, fmt.Sprint(f.left), fmt.Sprint(f.right)
+		}
+	}
+	hexes := make([]string, 0, len(l.Buildings)+len(r.Buildings))
+	for hex := range unionKeys(l.Buildings, r.Buildings) {
+		hexes = append(hexes, hex)
+	}
+	sort.Strings(hexes)
+	for _, hex := range hexes {
+		if l.Buildings[hex] != r.Buildings[hex] {
+			return "buildings@" + hex, buildingOrNone(l.Buildings[hex]), buildingOrNone(r.Buildings[hex])
+		}
+	}
+	return "", "", ""
+}
+
+func buildingOrNone(building string) string {
+	if building == "" {
+		return "none"
+	}
+	return building
+}
+
+func unionKeys[V any](a, b map[string]V) map[string]struct{} {
+	keys := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+	return keys
+}
@@ -0,0 +1,69 @@
+package replay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecordCheckpoints_MatchesFinalReplayState(t *testing.T) {
+	manager := NewReplayManager(t.TempDir())
+	manager.SetSourceAnchoredLeechOrdering(true)
+	content, err := os.ReadFile(filepath.Join("testdata", "snellman_batch", "4pLeague_S69_D1L1_G6.txt"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	if err := manager.ImportText("checkpoints", string(content), "snellman"); err != nil {
+		t.Fatalf("ImportText: %v", err)
+	}
+	session := manager.GetSession("checkpoints")
+
+	checkpoints, err := RecordCheckpoints(session)
+	if err != nil {
+		t.Fatalf("RecordCheckpoints: %v", err)
+	}
+	if len(checkpoints) != len(session.Simulator.Actions) {
+		t.Fatalf("expected one checkpoint per log item, got %d for %d items", len(checkpoints), len(session.Simulator.Actions))
+	}
+
+	if err := manager.JumpTo("checkpoints", len(session.Simulator.Actions)); err != nil {
+		t.Fatalf("JumpTo: %v", err)
+	}
+	final := capturePlayers(session.Simulator.GetState())
+	last := checkpoints[len(checkpoints)-1]
+	if diffs := DiffCheckpoints([]Checkpoint{last}, []Checkpoint{{Index: last.Index, Players: final}}); len(diffs) != 0 {
+		t.Fatalf("last checkpoint differs from final replay state: %+v", diffs)
+	}
+	if diffs := DiffCheckpoints(checkpoints, checkpoints); len(diffs) != 0 {
+		t.Fatalf("expected identical runs to match, got %+v", diffs)
+	}
+}
+
+func TestDiffCheckpoints_ReportsFirstDivergencePerPlayer(t *testing.T) {
+	player := func(coins int, buildings map[string]string) PlayerCheckpoint {
+		return PlayerCheckpoint{Coins: coins, Buildings: buildings}
+	}
+	left := []Checkpoint{
+		{Index: 1, Players: map[string]PlayerCheckpoint{"a": player(5, nil), "b": player(3, map[string]string{"E7": "Dwelling"})}},
+		{Index: 2, Players: map[string]PlayerCheckpoint{"a": player(6, nil), "b": player(3, map[string]string{"E7": "TradingHouse"})}},
+		{Index: 3, Players: map[string]PlayerCheckpoint{"a": player(7, nil), "b": player(4, map[string]string{"E7": "TradingHouse"})}},
+	}
+	right := []Checkpoint{
+		{Index: 1, Players: map[string]PlayerCheckpoint{"a": player(5, nil), "b": player(3, map[string]string{"E7": "Dwelling"})}},
+		{Index: 2, Players: map[string]PlayerCheckpoint{"a": player(6, nil), "b": player(3, map[string]string{"E7": "Temple"})}},
+	}
+
+	got := DiffCheckpoints(left, right)
+	want := []Divergence{
+		{PlayerID: "b", Index: 2, Field: "buildings@E7", Left: "TradingHouse", Right: "Temple"},
+		{PlayerID: "a", Index: 3, Field: "checkpoints", Left: "3", Right: "2"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d divergences, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("divergence %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -69,43 +69,26 @@ type ConversionComponent struct {
 }
 
 func (c *ConversionComponent) Execute(gs *game.GameState, playerID string) error {
-	player := gs.GetPlayer(playerID)
-	if player == nil {
-		return fmt.Errorf("player %s not found", playerID)
-	}
-
 	switch c.Type {
 	case ConvBurn:
-		return player.Resources.BurnPower(c.Amount)
+		return gs.ConvertResources(playerID, game.ConversionBurnPower, c.Amount)
 	case ConvPowerToCoins:
-		return player.Resources.ConvertPowerToCoins(c.Amount)
+		return gs.ConvertResources(playerID, game.ConversionPowerToCoin, c.Amount)
 	case ConvPowerToWorkers:
-		return player.Resources.ConvertPowerToWorkers(c.Amount)
+		return gs.ConvertResources(playerID, game.ConversionPowerToWorker, c.Amount)
 	case ConvPowerToPriests:
-		return player.Resources.ConvertPowerToPriests(c.Amount)
+		return gs.ConvertResources(playerID, game.ConversionPowerToPriest, c.Amount)
 	case ConvPriestToWorker:
-		if player.Faction != nil && player.Faction.GetType() == models.FactionDynionGeifr {
-			if player.Resources.Priests < c.Amount {
-				return fmt.Errorf("need %d priests, only have %d", c.Amount, player.Resources.Priests)
-			}
-			player.Resources.Priests -= c.Amount
-			player.Resources.Workers += 2 * c.Amount
-			player.Resources.Coins += 2 * c.Amount
-			return nil
-		}
-		return player.Resources.ConvertPriestToWorker(c.Amount)
+		return gs.ConvertResources(playerID, game.ConversionPriestToWorker, c.Amount)
 	case ConvWorkerToCoin:
-		return player.Resources.ConvertWorkerToCoin(c.Amount)
+		return gs.ConvertResources(playerID, game.ConversionWorkerToCoin, c.Amount)
 	case ConvVPToCoins:
 		// Alchemists: VP -> Coins (1:1)
-		// c.Amount is target coins, but function expects source VP
-		// Since ratio is 1:1, source VP = target coins
-		return gs.AlchemistsConvertVPToCoins(playerID, c.Amount)
+		return gs.ConvertResources(playerID, game.ConversionAlchVPToCoin, c.Amount)
 	case ConvCoinsToVP:
-		// Alchemists: Coins -> VP (2:1, i.e., 2 coins = 1 VP)
-		// c.Amount is target VP, but function expects source coins
-		// source coins = target VP * 2
-		return gs.AlchemistsConvertCoinsToVP(playerID, c.Amount*2)
+		// Alchemists: Coins -> VP (2:1). c.Amount is target VP, but the
+		// conversion counts source coins
+		return gs.ConvertResources(playerID, game.ConversionAlchCoinToVP, c.Amount*2)
 	default:
 		return fmt.Errorf("unknown conversion type: %v", c.Type)
 	}
@@ -485,7 +468,7 @@ func (t *TransformTerrainComponent) Execute(gs *game.GameState, playerID string)
 
 		// Award faction-specific spade bonuses (Halflings VP, Alchemists power)
 		// Note: Darklings faction bonus is already awarded above when paying priests
-		game.AwardFactionSpadeBonuses(player, spadesUsed)
+		game.AwardFactionSpadeBonuses(gs, player, spadesUsed)
 	}
 
 	// Award faction-specific spade bonuses for cult reward spades too
@@ -495,7 +478,7 @@ func (t *TransformTerrainComponent) Execute(gs *game.GameState, playerID string)
 		if player.Faction.GetType() == models.FactionGiants {
 			spadesUsed = 2
 		}
-		game.AwardFactionSpadeBonuses(player, spadesUsed)
+		game.AwardFactionSpadeBonuses(gs, player, spadesUsed)
 	}
 
 	return nil
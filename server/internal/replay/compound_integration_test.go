@@ -1,6 +1,7 @@
 package replay
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/lukev/tm_server/internal/game"
@@ -55,3 +56,39 @@ func TestCompoundAction_Round6Patterns(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertLogEntryToAction_SetupBuildsFollowSetupOrder(t *testing.T) {
+	gs := game.NewGameState()
+	if err := gs.AddPlayer("witches", factions.NewWitches()); err != nil {
+		t.Fatalf("add witches: %v", err)
+	}
+	if err := gs.AddPlayer("nomads", factions.NewNomads()); err != nil {
+		t.Fatalf("add nomads: %v", err)
+	}
+	gs.TurnOrder = []string{"witches", "nomads"}
+	gs.Phase = game.PhaseSetup
+
+	build := func(faction models.FactionType, coord string) error {
+		hex, err := ConvertLogCoordToAxial(coord)
+		if err != nil {
+			t.Fatalf("convert %s: %v", coord, err)
+		}
+		gs.Map.TransformTerrain(hex, gs.GetPlayer(strings.ToLower(faction.String())).Faction.GetHomeTerrain())
+		action, err := ConvertLogEntryToAction(&LogEntry{Faction: faction, Action: "build " + coord}, gs)
+		if err != nil {
+			t.Fatalf("convert build %s: %v", coord, err)
+		}
+		return action.Execute(gs)
+	}
+
+	err := build(models.FactionNomads, "E7")
+	if got := game.ReasonCodeOf(err); got != game.ReasonNotYourTurn {
+		t.Fatalf("expected out-of-order setup row to be rejected with %s, got %v", game.ReasonNotYourTurn, err)
+	}
+	if err := build(models.FactionWitches, "E7"); err != nil {
+		t.Fatalf("expected first setup row to succeed: %v", err)
+	}
+	if err := build(models.FactionNomads, "E8"); err != nil {
+		t.Fatalf("expected second setup row to succeed: %v", err)
+	}
+}
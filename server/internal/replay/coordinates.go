@@ -89,12 +89,11 @@ func ValidateCoordinateConversion() error {
 	return nil
 }
 
-// ValidateTerrainLayout verifies our terrain layout against known setup positions from game logs
+// ValidateTerrainLayout verifies the base map layout against known setup
+// positions from game logs.
 func ValidateTerrainLayout() error {
-	layout := board.BaseGameTerrainLayout()
-
 	// From game log setup - these are home terrain builds (no transformation)
-	expected := map[string]models.TerrainType{
+	return board.ValidateTerrainLayout(board.MapBase, map[string]models.TerrainType{
 		// Engineers (gray/mountain)
 		"E7": models.TerrainMountain,
 		"F1": models.TerrainMountain,
@@ -107,19 +106,5 @@ func ValidateTerrainLayout() error {
 		// Witches (green/forest)
 		"F4": models.TerrainForest,
 		"E9": models.TerrainForest,
-	}
-
-	for coord, expectedTerrain := range expected {
-		hex, err := ConvertLogCoordToAxial(coord)
-		if err != nil {
-			return fmt.Errorf("failed to convert %s: %w", coord, err)
-		}
-		actualTerrain := layout[hex]
-		if actualTerrain != expectedTerrain {
-			return fmt.Errorf("%s (q=%d, r=%d): expected %s, got %s",
-				coord, hex.Q, hex.R, expectedTerrain, actualTerrain)
-		}
-	}
-
-	return nil
+	})
 }
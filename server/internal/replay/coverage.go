@@ -0,0 +1,299 @@
+package replay
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/models"
+	"github.com/lukev/tm_server/internal/notation"
+)
+
+// Coverage counts the rules paths replays exercise, so a fixture corpus can
+// be checked for factions, abilities and tiles it never reaches. Every map is
+// keyed by a readable name and counts occurrences across all replayed games,
+// except Factions, which counts games.
+type Coverage struct {
+	Games int `json:"games"`
+	// Actions counts replayed log actions by type, including the parts of
+	// compound actions, e.g. "notation.LogPowerAction" or "game.PassAction".
+	Actions        map[string]int `json:"actions"`
+	Factions       map[string]int `json:"factions"`
+	Strongholds    map[string]int `json:"strongholds"`
+	SpecialActions map[string]int `json:"specialActions"`
+	PowerActions   map[string]int `json:"powerActions"`
+	FavorTiles     map[string]int `json:"favorTiles"`
+	TownTiles      map[string]int `json:"townTiles"`
+	BonusCards     map[string]int `json:"bonusCards"`
+}
+
+// CoverageGap lists the names of one Coverage category that no replay
+// reached.
+type CoverageGap struct {
+	Category string   `json:"category"`
+	Missing  []string `json:"missing"`
+}
+
+var specialActionNames = map[game.SpecialActionType]string{
+	game.SpecialActionAurenCultAdvance:          "Auren cult advance",
+	game.SpecialActionWitchesRide:               "Witches' Ride",
+	game.SpecialActionAlchemistsConvert:         "Alchemists convert",
+	game.SpecialActionSwarmlingsUpgrade:         "Swarmlings upgrade",
+	game.SpecialActionChaosMagiciansDoubleTurn:  "Chaos Magicians double turn",
+	game.SpecialActionGiantsTransform:           "Giants transform",
+	game.SpecialActionNomadsSandstorm:           "Nomads sandstorm",
+	game.SpecialActionWater2CultAdvance:         "Water 2 favor cult advance",
+	game.SpecialActionBonusCardSpade:            "Bonus card spade",
+	game.SpecialActionBonusCardCultAdvance:      "Bonus card cult advance",
+	game.SpecialActionMermaidsRiverTown:         "Mermaids river town",
+	game.SpecialActionEnlightenedGainPower:      "Enlightened gain power",
+	game.SpecialActionConspiratorsSwapFavor:     "Conspirators swap favor",
+	game.SpecialActionChildrenPlacePowerTokens:  "Children place power tokens",
+	game.SpecialActionProspectorsGainCoins:      "Prospectors gain coins",
+	game.SpecialActionTimeTravelersPowerShift:   "Time Travelers power shift",
+	game.SpecialActionDjinniSwapCults:           "Djinni swap cults",
+	game.SpecialActionArchitectsMoveBridge:      "Architects move bridge",
+	game.SpecialActionShapeshiftersShiftTerrain: "Shapeshifters shift terrain",
+	game.SpecialActionSelkiesStronghold:         "Selkies stronghold",
+}
+
+func NewCoverage() *Coverage {
+	return &Coverage{
+		Actions:        make(map[string]int),
+		Factions:       make(map[string]int),
+		Strongholds:    make(map[string]int),
+		SpecialActions: make(map[string]int),
+		PowerActions:   make(map[string]int),
+		FavorTiles:     make(map[string]int),
+		TownTiles:      make(map[string]int),
+		BonusCards:     make(map[string]int),
+	}
+}
+
+// Merge adds the counts of other to c.
+func (c *Coverage) Merge(other *Coverage) {
+	if c == nil || other == nil {
+		return
+	}
+	c.Games += other.Games
+	for _, pair := range [][2]map[string]int{
+		{c.Actions, other.Actions},
+		{c.Factions, other.Factions},
+		{c.Strongholds, other.Strongholds},
+		{c.SpecialActions, other.SpecialActions},
+		{c.PowerActions, other.PowerActions},
+		{c.FavorTiles, other.FavorTiles},
+		{c.TownTiles, other.TownTiles},
+		{c.BonusCards, other.BonusCards},
+	} {
+		for name, count := range pair[1] {
+			pair[0][name] += count
+		}
+	}
+}
+
+// AddGame counts a replayed game and the factions playing it.
+func (c *Coverage) AddGame(gs *game.GameState) {
+	if c == nil || gs == nil {
+		return
+	}
+	c.Games++
+	for _, player := range gs.Players {
+		if player != nil && player.Faction != nil {
+			c.Factions[player.Faction.GetType().String()]++
+		}
+	}
+}
+
+// Gaps returns, per category with a known set of names, the names no replay
+// reached. Actions have no such set and are left out.
+func (c *Coverage) Gaps() []CoverageGap {
+	var factions []string
+	for factionType := models.FactionNomads; factionType <= models.FactionSnowShamans; factionType++ {
+		factions = append(factions, factionType.String())
+	}
+	var specialActions []string
+	for _, name := range specialActionNames {
+		specialActions = append(specialActions, name)
+	}
+	var powerActions []string
+	for _, name := range powerActionNames() {
+		powerActions = append(powerActions, name)
+	}
+	var favorTiles []string
+	for _, tile := range game.GetAllFavorTiles() {
+		favorTiles = append(favorTiles, tile.Name)
+	}
+	var townTiles []string
+	for _, name := range townTileNames() {
+		townTiles = append(townTiles, name)
+	}
+	var bonusCards []string
+	for _, card := range game.GetAllBonusCards() {
+		bonusCards = append(bonusCards, card.Name)
+	}
+
+	var gaps []CoverageGap
+	for _, category := range []struct {
+		name  string
+		all   []string
+		count map[string]int
+	}{
+		{"factions", factions, c.Factions},
+		{"strongholds", factions, c.Strongholds},
+		{"special actions", specialActions, c.SpecialActions},
+		{"power actions", powerActions, c.PowerActions},
+		{"favor tiles", favorTiles, c.FavorTiles},
+		{"town tiles", townTiles, c.TownTiles},
+		{"bonus cards", bonusCards, c.BonusCards},
+	} {
+		var missing []string
+		for _, name := range category.all {
+			if category.count[name] == 0 {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			gaps = append(gaps, CoverageGap{Category: category.name, Missing: missing})
+		}
+	}
+	return gaps
+}
+
+func powerActionNames() map[game.PowerActionType]string {
+	names := make(map[game.PowerActionType]string)
+	for i := 1; i <= 6; i++ {
+		code := fmt.Sprintf("ACT%d", i)
+		names[notation.ParsePowerActionCode(code)] = code
+	}
+	return names
+}
+
+func townTileNames() map[models.TownTileType]string {
+	names := make(map[models.TownTileType]string)
+	for vp := 0; vp <= 11; vp++ {
+		if tile, err := notation.GetTownTileFromVP(vp); err == nil {
+			names[tile] = fmt.Sprintf("TW%dVP", vp)
+		}
+	}
+	return names
+}
+
+// coverageMarks is what a replay step is compared against to see which
+// abilities and tiles it used.
+type coverageMarks struct {
+	strongholds    map[string]bool
+	specialActions map[string]map[game.SpecialActionType]bool
+	powerActions   map[game.PowerActionType]bool
+	favorTiles     map[string]int
+	townTiles      map[string]int
+	bonusCards     map[string][]game.BonusCardType
+}
+
+func markCoverage(gs *game.GameState) coverageMarks {
+	marks := coverageMarks{
+		strongholds:    make(map[string]bool),
+		specialActions: make(map[string]map[game.SpecialActionType]bool),
+		powerActions:   make(map[game.PowerActionType]bool),
+		favorTiles:     make(map[string]int),
+		townTiles:      make(map[string]int),
+		bonusCards:     make(map[string][]game.BonusCardType),
+	}
+	if gs == nil {
+		return marks
+	}
+	for playerID, player := range gs.Players {
+		if player == nil {
+			continue
+		}
+		marks.strongholds[playerID] = player.HasStrongholdAbility
+		used := make(map[game.SpecialActionType]bool)
+		for actionType, ok := range player.SpecialActionsUsed {
+			used[actionType] = ok
+		}
+		marks.specialActions[playerID] = used
+		marks.townTiles[playerID] = len(player.TownTiles)
+		if gs.FavorTiles != nil {
+			marks.favorTiles[playerID] = len(gs.FavorTiles.PlayerTiles[playerID])
+		}
+		marks.bonusCards[playerID] = gs.BonusCards.GetPlayerCards(playerID)
+	}
+	if gs.PowerActions != nil {
+		for actionType, ok := range gs.PowerActions.UsedActions {
+			marks.powerActions[actionType] = ok
+		}
+	}
+	return marks
+}
+
+// observe counts the log item replayed and the abilities and tiles that
+// became used between before and gs.
+func (c *Coverage) observe(item notation.LogItem, before coverageMarks, gs *game.GameState) {
+	if c == nil || gs == nil {
+		return
+	}
+	if actionItem, ok := item.(notation.ActionItem); ok {
+		c.countAction(actionItem.Action)
+	}
+
+	powerNames := powerActionNames()
+	if gs.PowerActions != nil {
+		for actionType, used := range gs.PowerActions.UsedActions {
+			if used && !before.powerActions[actionType] {
+				c.PowerActions[powerNames[actionType]]++
+			}
+		}
+	}
+	townNames := townTileNames()
+	allFavorTiles := game.GetAllFavorTiles()
+	allBonusCards := game.GetAllBonusCards()
+	for playerID, player := range gs.Players {
+		if player == nil {
+			continue
+		}
+		if player.HasStrongholdAbility && !before.strongholds[playerID] && player.Faction != nil {
+			c.Strongholds[player.Faction.GetType().String()]++
+		}
+		for actionType, used := range player.SpecialActionsUsed {
+			if used && !before.specialActions[playerID][actionType] {
+				if name, ok := specialActionNames[actionType]; ok {
+					c.SpecialActions[name]++
+				}
+			}
+		}
+		for _, tile := range player.TownTiles[min(before.townTiles[playerID], len(player.TownTiles)):] {
+			c.TownTiles[townNames[tile]]++
+		}
+		if gs.FavorTiles != nil {
+			tiles := gs.FavorTiles.PlayerTiles[playerID]
+			for _, tile := range tiles[min(before.favorTiles[playerID], len(tiles)):] {
+				c.FavorTiles[allFavorTiles[tile].Name]++
+			}
+		}
+		for _, card := range gs.BonusCards.GetPlayerCards(playerID) {
+			if !slices.Contains(before.bonusCards[playerID], card) {
+				c.BonusCards[allBonusCards[card].Name]++
+			}
+		}
+	}
+}
+
+func (c *Coverage) countAction(action game.Action) {
+	switch v := action.(type) {
+	case nil:
+		return
+	case *notation.LogCompoundAction:
+		for _, part := range v.Actions {
+			c.countAction(part)
+		}
+	case *notation.LogPreIncomeAction:
+		c.countAction(v.Action)
+	case *notation.LogPostIncomeAction:
+		c.countAction(v.Action)
+	default:
+		c.Actions[strings.TrimPrefix(fmt.Sprintf("%T", action), "*")]++
+	}
+}
@@ -0,0 +1,47 @@
+package replay
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestValidateBatch_RecordsCoverage(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "snellman_batch", "4pLeague_S69_D1L1_G6.txt"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	report := ValidateBatch([]BatchGame{{GameID: "s69_g6", Log: string(content), Format: "snellman"}}, t.TempDir(), 1)
+	coverage := report.Coverage()
+
+	if coverage.Games != 1 || len(coverage.Factions) != 4 {
+		t.Fatalf("expected one game with four factions, got %d games and %v", coverage.Games, coverage.Factions)
+	}
+	if coverage.Actions["game.PassAction"] == 0 {
+		t.Fatalf("expected passes to be counted, got %v", coverage.Actions)
+	}
+	if coverage.Factions["Witches"] != 1 || coverage.Strongholds["Witches"] != 1 {
+		t.Fatalf("expected the Witches to play and build their stronghold, got factions %v strongholds %v", coverage.Factions, coverage.Strongholds)
+	}
+	if coverage.SpecialActions["Witches' Ride"] == 0 {
+		t.Fatalf("expected Witches' Ride to be counted, got %v", coverage.SpecialActions)
+	}
+	sumFavor := 0
+	for _, count := range coverage.FavorTiles {
+		sumFavor += count
+	}
+	if sumFavor == 0 || len(coverage.BonusCards) == 0 || len(coverage.PowerActions) == 0 {
+		t.Fatalf("expected tiles and power actions to be counted, got favor %v bonus %v power %v", coverage.FavorTiles, coverage.BonusCards, coverage.PowerActions)
+	}
+
+	var factionGap []string
+	for _, gap := range coverage.Gaps() {
+		if gap.Category == "factions" {
+			factionGap = gap.Missing
+		}
+	}
+	if !slices.Contains(factionGap, "Wisps") || slices.Contains(factionGap, "Witches") {
+		t.Fatalf("expected unplayed factions only in the gaps, got %v", factionGap)
+	}
+}
@@ -0,0 +1,100 @@
+package replay
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GoldenFixture is the manifest of a golden game log, kept as a YAML file
+// next to the log:
+//
+//	log_file: 4pLeague_S69_D1L1_G3.txt
+//	factions: [cultists, engineers, darklings, witches]
+//	final_vp:
+//	  cultists: 148
+//	  ...
+//	round_vp:
+//	  3:
+//	    cultists: 61
+//	    ...
+//
+// log_file defaults to the manifest name with a .txt extension and format
+// defaults to "snellman".
+type GoldenFixture struct {
+	GameID   string   `yaml:"game_id"`
+	LogFile  string   `yaml:"log_file"`
+	Format   string   `yaml:"format"`
+	Factions []string `yaml:"factions"`
+	// FinalVP maps player or faction names to final VP totals.
+	FinalVP map[string]int `yaml:"final_vp"`
+	// RoundVP optionally maps rounds to each player's VP at the end of that
+	// round, before final scoring.
+	RoundVP map[int]map[string]int `yaml:"round_vp"`
+	// Skip, if set, says why the fixture is kept but not checked.
+	Skip string `yaml:"skip"`
+
+	dir string
+}
+
+// LoadGoldenFixtures reads every *.yaml manifest in dir, ordered by file name.
+func LoadGoldenFixtures(dir string) ([]GoldenFixture, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	fixtures := make([]GoldenFixture, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var fixture GoldenFixture
+		if err := yaml.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		if fixture.GameID == "" {
+			fixture.GameID = base
+		}
+		if fixture.LogFile == "" {
+			fixture.LogFile = base + ".txt"
+		}
+		if fixture.Format == "" {
+			fixture.Format = "snellman"
+		}
+		if len(fixture.FinalVP) == 0 {
+			return nil, fmt.Errorf("%s: final_vp is required", path)
+		}
+		fixture.dir = dir
+		fixtures = append(fixtures, fixture)
+	}
+	return fixtures, nil
+}
+
+// LogPath is the path of the fixture's log.
+func (f GoldenFixture) LogPath() string {
+	return filepath.Join(f.dir, f.LogFile)
+}
+
+// BatchGame reads the fixture's log into a game for ValidateBatch.
+func (f GoldenFixture) BatchGame() (BatchGame, error) {
+	data, err := os.ReadFile(f.LogPath())
+	if err != nil {
+		return BatchGame{}, err
+	}
+	return BatchGame{
+		GameID:           f.GameID,
+		Log:              string(data),
+		Format:           f.Format,
+		ExpectedFactions: f.Factions,
+		ExpectedTotalVP:  f.FinalVP,
+		ExpectedRoundVP:  f.RoundVP,
+	}, nil
+}
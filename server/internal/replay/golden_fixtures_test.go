@@ -0,0 +1,59 @@
+package replay
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game"
+)
+
+func TestLoadGoldenFixtures_AppliesDefaults(t *testing.T) {
+	dir := t.TempDir()
+	manifest := "factions: [witches, nomads]\nfinal_vp: {witches: 120, nomads: 99}\nround_vp:\n  2: {witches: 40}\n"
+	if err := os.WriteFile(filepath.Join(dir, "game1.yaml"), []byte(manifest), 0o644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "game1.txt"), []byte("log"), 0o644); err != nil {
+		t.Fatalf("write log: %v", err)
+	}
+
+	fixtures, err := LoadGoldenFixtures(dir)
+	if err != nil {
+		t.Fatalf("LoadGoldenFixtures: %v", err)
+	}
+	if len(fixtures) != 1 {
+		t.Fatalf("expected one fixture, got %d", len(fixtures))
+	}
+	batchGame, err := fixtures[0].BatchGame()
+	if err != nil {
+		t.Fatalf("BatchGame: %v", err)
+	}
+	if batchGame.GameID != "game1" || batchGame.Format != "snellman" || batchGame.Log != "log" ||
+		batchGame.ExpectedTotalVP["nomads"] != 99 || batchGame.ExpectedRoundVP[2]["witches"] != 40 || len(batchGame.ExpectedFactions) != 2 {
+		t.Fatalf("unexpected batch game %+v", batchGame)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("factions: [witches]\n"), 0o644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	if _, err := LoadGoldenFixtures(dir); err == nil || !strings.Contains(err.Error(), "final_vp") {
+		t.Fatalf("expected a manifest without final_vp to be rejected, got %v", err)
+	}
+}
+
+func TestCheckBatchRoundVP_ReportsMismatches(t *testing.T) {
+	expected := map[int]map[string]int{2: {"Ice Maidens": 40}}
+	if err := checkBatchRoundVP(expected, nil); err == nil || !strings.Contains(err.Error(), "not reached") {
+		t.Fatalf("expected an unreached round error, got %v", err)
+	}
+	rounds := []game.VPRoundPoints{{Round: 2, VP: map[string]int{"IceMaidens": 41}}}
+	if err := checkBatchRoundVP(expected, rounds); err == nil || !strings.Contains(err.Error(), "got 41, want 40") {
+		t.Fatalf("expected a round VP mismatch, got %v", err)
+	}
+	rounds[0].VP["IceMaidens"] = 40
+	if err := checkBatchRoundVP(expected, rounds); err != nil {
+		t.Fatalf("expected matching round VP to pass, got %v", err)
+	}
+}
@@ -0,0 +1,291 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrLogNotStored is returned by LogStore.Load for games without a cached log.
+var ErrLogNotStored = errors.New("replay log not stored")
+
+// LogStore caches replay logs, fetched or imported, by game ID.
+type LogStore interface {
+	// Load returns the stored log of gameID, or ErrLogNotStored.
+	Load(gameID string) (string, error)
+	Store(gameID string, content string) error
+}
+
+// LogStoreLimits bounds a LogStore. Zero values mean no limit.
+type LogStoreLimits struct {
+	// MaxLogBytes rejects single logs larger than this.
+	MaxLogBytes int64
+	// MaxTotalBytes and MaxLogs evict the least recently used logs once the
+	// store grows past them.
+	MaxTotalBytes int64
+	MaxLogs       int
+}
+
+func (l LogStoreLimits) checkLog(gameID string, content string) error {
+	if l.MaxLogBytes > 0 && int64(len(content)) > l.MaxLogBytes {
+		return fmt.Errorf("log of game %s is %d bytes, limit is %d", gameID, len(content), l.MaxLogBytes)
+	}
+	return nil
+}
+
+// storedLog is one cached log as seen by eviction.
+type storedLog struct {
+	key      string
+	size     int64
+	lastUsed time.Time
+}
+
+// evictions returns the keys to delete, oldest first, so that logs fit the
+// limits. keep is never evicted.
+func (l LogStoreLimits) evictions(logs []storedLog, keep string) []string {
+	if l.MaxTotalBytes <= 0 && l.MaxLogs <= 0 {
+		return nil
+	}
+	sort.Slice(logs, func(i, j int) bool {
+		if !logs[i].lastUsed.Equal(logs[j].lastUsed) {
+			return logs[i].lastUsed.Before(logs[j].lastUsed)
+		}
+		return logs[i].key < logs[j].key
+	})
+	var total int64
+	for _, stored := range logs {
+		total += stored.size
+	}
+	count := len(logs)
+	var evict []string
+	for _, stored := range logs {
+		overBytes := l.MaxTotalBytes > 0 && total > l.MaxTotalBytes
+		overCount := l.MaxLogs > 0 && count > l.MaxLogs
+		if !overBytes && !overCount {
+			break
+		}
+		if stored.key == keep {
+			continue
+		}
+		evict = append(evict, stored.key)
+		total -= stored.size
+		count--
+	}
+	return evict
+}
+
+// FileLogStore is a LogStore keeping one game_<id>.txt file per game, the
+// layout the fetch scripts write.
+type FileLogStore struct {
+	dir    string
+	limits LogStoreLimits
+
+	mu sync.Mutex
+}
+
+// NewFileLogStore returns a FileLogStore rooted at dir. The directory is
+// created on the first Store.
+func NewFileLogStore(dir string, limits LogStoreLimits) *FileLogStore {
+	return &FileLogStore{dir: dir, limits: limits}
+}
+
+func logFileName(gameID string) string {
+	return fmt.Sprintf("game_%s.txt", url.PathEscape(gameID))
+}
+
+func (s *FileLogStore) path(gameID string) string {
+	return filepath.Join(s.dir, logFileName(gameID))
+}
+
+// Load reads the log of gameID and marks it as recently used.
+func (s *FileLogStore) Load(gameID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.path(gameID)
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrLogNotStored
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read log file: %w", err)
+	}
+	if s.limits.MaxTotalBytes > 0 || s.limits.MaxLogs > 0 {
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+	}
+	return string(content), nil
+}
+
+// Store writes the log of gameID, replacing any earlier one, and evicts the
+// least recently used logs beyond the store's limits.
+func (s *FileLogStore) Store(gameID string, content string) error {
+	if err := s.limits.checkLog(gameID, content); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create scripts directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, ".log-*")
+	if err != nil {
+		return fmt.Errorf("failed to save log file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save log file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save log file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(gameID)); err != nil {
+		return fmt.Errorf("failed to save log file: %w", err)
+	}
+	return s.evictLocked(logFileName(gameID))
+}
+
+func (s *FileLogStore) evictLocked(keep string) error {
+	if s.limits.MaxTotalBytes <= 0 && s.limits.MaxLogs <= 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("failed to list log files: %w", err)
+	}
+	var logs []storedLog
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "game_") || !strings.HasSuffix(name, ".txt") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		logs = append(logs, storedLog{key: name, size: info.Size(), lastUsed: info.ModTime()})
+	}
+	var errs []error
+	for _, name := range s.limits.evictions(logs, keep) {
+		if err := os.Remove(filepath.Join(s.dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("failed to evict log file %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BucketObject describes an object listed from a Bucket.
+type BucketObject struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// Bucket is the subset of an object store BucketLogStore needs. Get returns
+// ErrLogNotStored for missing keys.
+type Bucket interface {
+	Get(key string) ([]byte, error)
+	Put(key string, data []byte) error
+	Delete(key string) error
+	List(prefix string) ([]BucketObject, error)
+}
+
+// BucketLogStore is a LogStore keeping logs in an object store bucket, under
+// <prefix>game_<id>.txt. Object stores do not track reads, so eviction drops
+// the least recently stored logs.
+//
+// To evict, the store lists the prefix once and then keeps its own index of
+// the logs it stores and deletes, so it should be the only writer below
+// prefix.
+type BucketLogStore struct {
+	bucket Bucket
+	prefix string
+	limits LogStoreLimits
+
+	mu sync.Mutex
+	// index is nil until the first Store that has limits to enforce.
+	index map[string]storedLog
+}
+
+// NewBucketLogStore returns a BucketLogStore writing below prefix in bucket.
+func NewBucketLogStore(bucket Bucket, prefix string, limits LogStoreLimits) *BucketLogStore {
+	return &BucketLogStore{bucket: bucket, prefix: prefix, limits: limits}
+}
+
+func (s *BucketLogStore) key(gameID string) string {
+	return s.prefix + logFileName(gameID)
+}
+
+// Load fetches the log of gameID from the bucket.
+func (s *BucketLogStore) Load(gameID string) (string, error) {
+	data, err := s.bucket.Get(s.key(gameID))
+	if err != nil {
+		if errors.Is(err, ErrLogNotStored) {
+			return "", ErrLogNotStored
+		}
+		return "", fmt.Errorf("failed to read log of game %s: %w", gameID, err)
+	}
+	return string(data), nil
+}
+
+// Store uploads the log of gameID and evicts the oldest logs beyond the
+// store's limits.
+func (s *BucketLogStore) Store(gameID string, content string) error {
+	if err := s.limits.checkLog(gameID, content); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := s.key(gameID)
+	if err := s.bucket.Put(key, []byte(content)); err != nil {
+		return fmt.Errorf("failed to save log of game %s: %w", gameID, err)
+	}
+	if s.limits.MaxTotalBytes <= 0 && s.limits.MaxLogs <= 0 {
+		return nil
+	}
+	if err := s.loadIndexLocked(); err != nil {
+		return err
+	}
+	s.index[key] = storedLog{key: key, size: int64(len(content)), lastUsed: time.Now()}
+	logs := make([]storedLog, 0, len(s.index))
+	for _, stored := range s.index {
+		logs = append(logs, stored)
+	}
+	var errs []error
+	for _, evict := range s.limits.evictions(logs, key) {
+		if err := s.bucket.Delete(evict); err != nil {
+			errs = append(errs, fmt.Errorf("failed to evict log %s: %w", evict, err))
+			continue
+		}
+		delete(s.index, evict)
+	}
+	return errors.Join(errs...)
+}
+
+// loadIndexLocked lists the logs already below the prefix, once.
+func (s *BucketLogStore) loadIndexLocked() error {
+	if s.index != nil {
+		return nil
+	}
+	objects, err := s.bucket.List(s.prefix)
+	if err != nil {
+		return fmt.Errorf("failed to list stored logs: %w", err)
+	}
+	s.index = make(map[string]storedLog, len(objects))
+	for _, object := range objects {
+		if !strings.HasPrefix(strings.TrimPrefix(object.Key, s.prefix), "game_") {
+			continue
+		}
+		s.index[object.Key] = storedLog{key: object.Key, size: object.Size, lastUsed: object.LastModified}
+	}
+	return nil
+}
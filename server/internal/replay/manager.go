@@ -1,10 +1,12 @@
 package replay
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,7 +23,9 @@ type ReplayManager struct {
 	mu                          sync.RWMutex
 	sessions                    map[string]*ReplaySession
 	scriptDir                   string
+	logStore                    LogStore
 	sourceAnchoredLeechOrdering bool
+	checkInvariants             bool
 }
 
 type ReplayLogFormat string
@@ -33,21 +37,36 @@ const (
 	ReplayLogFormatConcise  ReplayLogFormat = "concise"
 )
 
-// NewReplayManager creates a new ReplayManager
+// NewReplayManager creates a new ReplayManager. Fetched and imported logs are
+// cached as files in scriptDir until SetLogStore picks another store.
 func NewReplayManager(scriptDir string) *ReplayManager {
 	return &ReplayManager{
 		sessions:                    make(map[string]*ReplaySession),
 		scriptDir:                   scriptDir,
+		logStore:                    NewFileLogStore(scriptDir, LogStoreLimits{}),
 		sourceAnchoredLeechOrdering: false,
 	}
 }
 
+// SetLogStore makes the manager cache fetched and imported logs in store.
+func (m *ReplayManager) SetLogStore(store LogStore) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logStore = store
+}
+
 // SetSourceAnchoredLeechOrdering enables replay-only reanchoring of source-tagged
 // leech reactions directly after the triggering source action.
 func (m *ReplayManager) SetSourceAnchoredLeechOrdering(enabled bool) {
 	m.sourceAnchoredLeechOrdering = enabled
 }
 
+// SetCheckInvariants makes replays validate the game invariants after every
+// action and stop at the first violation. Meant for debugging the engine.
+func (m *ReplayManager) SetCheckInvariants(enabled bool) {
+	m.checkInvariants = enabled
+}
+
 // ReplaySession represents an active replay session
 type ReplaySession struct {
 	GameID       string
@@ -107,9 +126,13 @@ func (m *ReplayManager) StartReplay(gameID string, restart bool) (*ReplaySession
 	}
 
 	// Create simulator
-	initialState := createInitialState(items)
+	initialState, err := createInitialState(items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up game: %w", err)
+	}
 
 	simulator := NewGameSimulator(initialState, items)
+	simulator.SetCheckInvariants(m.checkInvariants)
 
 	// Create session
 	logStrings, logLocations := notation.GenerateConciseLog(items)
@@ -127,26 +150,37 @@ func (m *ReplayManager) StartReplay(gameID string, restart bool) (*ReplaySession
 }
 
 func (m *ReplayManager) fetchLog(gameID string) (string, error) {
-	scriptPath := filepath.Join(m.scriptDir, "fetch_bga_log.py")
-	outputPath := filepath.Join(m.scriptDir, fmt.Sprintf("game_%s.txt", gameID))
-
-	// Check if the log file already exists (e.g., from ImportLog)
-	if content, err := os.ReadFile(outputPath); err == nil {
-		return string(content), nil
+	// Check if the log is already cached (e.g., from ImportLog)
+	content, err := m.logStore.Load(gameID)
+	if err == nil {
+		return content, nil
+	}
+	if !errors.Is(err, ErrLogNotStored) {
+		return "", err
 	}
 
-	// If file doesn't exist, try to fetch it using the Python script
+	// If it isn't, try to fetch it using the Python script
+	tmpDir, err := os.MkdirTemp("", "replay-log-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create fetch directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	scriptPath := filepath.Join(m.scriptDir, "fetch_bga_log.py")
+	outputPath := filepath.Join(tmpDir, fmt.Sprintf("game_%s.txt", gameID))
 	cmd := exec.Command("python3", scriptPath, gameID, "--output", outputPath)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return "", fmt.Errorf("script failed: %w, output: %s", err, output)
 	}
 
-	content, err := os.ReadFile(outputPath)
+	raw, err := os.ReadFile(outputPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read log file: %w", err)
 	}
-	return string(content), nil
+	if err := m.logStore.Store(gameID, string(raw)); err != nil {
+		return "", err
+	}
+	return string(raw), nil
 }
 
 // ImportLog imports a log from raw HTML content
@@ -242,36 +276,26 @@ func (m *ReplayManager) parseReplayLogContent(content string, format ReplayLogFo
 		}
 		return items, concise, nil
 	case ReplayLogFormatBGA:
-		parser := notation.NewBGAParser(trimmed)
-		items, err := parser.Parse()
+		// The BGA dialect runs notation.NormalizeBGAText first, so exports
+		// with collapsed move headers, doubled spaces or renamed tiles parse
+		// like canonical ones; canonical logs parse as before.
+		items, err := notation.ParseDialect(trimmed, notation.DialectBGA)
 		if err != nil {
 			return nil, "", err
 		}
 		return items, trimmed, nil
 	case ReplayLogFormatAuto:
-		if looksLikeConciseLog(trimmed) {
-			items, err := notation.ParseConciseLogStrict(trimmed)
-			if err != nil {
-				return nil, "", err
-			}
-			return items, trimmed, nil
+		dialect, err := notation.DetectDialect(trimmed)
+		if err != nil {
+			return nil, "", err
 		}
-		if notation.IsSnellmanTextFormat(trimmed) {
-			concise, err := notation.ConvertSnellmanToConciseForReplay(trimmed)
-			if err != nil {
-				return nil, "", err
-			}
-			items, err := notation.ParseConciseLogStrict(concise)
-			if err != nil {
-				return nil, "", err
-			}
-			if m.sourceAnchoredLeechOrdering {
-				items = reorderSourceAnchoredLeechActions(items)
-			}
-			return items, concise, nil
+		switch dialect {
+		case notation.DialectConcise:
+			return m.parseReplayLogContent(trimmed, ReplayLogFormatConcise)
+		case notation.DialectSnellman:
+			return m.parseReplayLogContent(trimmed, ReplayLogFormatSnellman)
 		}
-		parser := notation.NewBGAParser(trimmed)
-		items, err := parser.Parse()
+		items, err := notation.ParseDialect(trimmed, dialect)
 		if err != nil {
 			return nil, "", err
 		}
@@ -281,16 +305,6 @@ func (m *ReplayManager) parseReplayLogContent(content string, format ReplayLogFo
 	}
 }
 
-func looksLikeConciseLog(content string) bool {
-	if strings.Contains(content, "Game:") || strings.Contains(content, "StartingVPs:") {
-		return true
-	}
-	if strings.Contains(content, "TurnOrder:") && strings.Contains(content, "|") {
-		return true
-	}
-	return false
-}
-
 // reorderSourceAnchoredLeechActions moves source-annotated leech reactions directly
 // behind the triggering source action so replay stepping matches concise log semantics.
 func reorderSourceAnchoredLeechActions(items []notation.LogItem) []notation.LogItem {
@@ -305,7 +319,7 @@ func reorderSourceAnchoredLeechActions(items []notation.LogItem) []notation.LogI
 			continue
 		}
 
-		sourceNorm := normalizeFactionIDForReplay(leechSourceFaction(actionItem.Action))
+		sourceNorm := models.FactionKey(leechSourceFaction(actionItem.Action))
 		if sourceNorm == "" {
 			continue
 		}
@@ -321,7 +335,7 @@ func reorderSourceAnchoredLeechActions(items []notation.LogItem) []notation.LogI
 			if !ok || nextActionItem.Action == nil {
 				break
 			}
-			if normalizeFactionIDForReplay(leechSourceFaction(nextActionItem.Action)) != sourceNorm {
+			if models.FactionKey(leechSourceFaction(nextActionItem.Action)) != sourceNorm {
 				break
 			}
 			insertAt++
@@ -348,7 +362,7 @@ func findLatestLeechSourceActionIndex(items []notation.LogItem, leechIndex int,
 			if v.Action == nil {
 				continue
 			}
-			if normalizeFactionIDForReplay(v.Action.GetPlayerID()) != sourceNorm {
+			if models.FactionKey(v.Action.GetPlayerID()) != sourceNorm {
 				continue
 			}
 			if actionMayTriggerLeechForReplay(v.Action) {
@@ -412,19 +426,11 @@ func leechSourceFaction(action game.Action) string {
 	}
 }
 
-func normalizeFactionIDForReplay(faction string) string {
-	return strings.ToLower(strings.Join(strings.Fields(strings.TrimSpace(faction)), " "))
-}
-
 func (m *ReplayManager) storeImportedLog(gameID string, content string) error {
-	if err := os.MkdirAll(m.scriptDir, 0755); err != nil {
-		return fmt.Errorf("failed to create scripts directory: %w", err)
-	}
-	outputPath := filepath.Join(m.scriptDir, fmt.Sprintf("game_%s.txt", gameID))
-	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to save log file: %w", err)
-	}
-	return nil
+	m.mu.RLock()
+	store := m.logStore
+	m.mu.RUnlock()
+	return store.Store(gameID, content)
 }
 
 // ProvideInfo updates the session with missing information
@@ -485,57 +491,12 @@ func (m *ReplayManager) ProvideInfo(gameID string, info *ProvidedGameInfo) error
 	session.Simulator.Actions[settingsIndex] = *settingsItem
 
 	// 1.5 Inject Bonus Card Selections (Round 0)
-	// We need to insert these actions AFTER settings but BEFORE Round 1
-	// Find index of Round 1 start
-	round1Index := -1
-	for i, item := range session.Simulator.Actions {
-		if rs, ok := item.(notation.RoundStartItem); ok && rs.Round == 1 {
-			round1Index = i
-			break
-		}
-	}
-
-	if round1Index == -1 {
-		// If Round 1 not found, append to end? Or just after settings?
-		round1Index = settingsIndex + 1
-	}
-
-	// Check if Round 0 bonus card selections already exist (e.g., from a previous ProvideInfo call)
-	hasRound0BonusCards := false
-	for _, item := range session.Simulator.Actions {
-		if actionItem, ok := item.(notation.ActionItem); ok {
-			if _, ok := actionItem.Action.(*notation.LogBonusCardSelectionAction); ok {
-				hasRound0BonusCards = true
-				break
-			}
-		}
-	}
-
-	// Only insert Round 0 bonus card selections if they don't already exist
-	if !hasRound0BonusCards {
-		if roundSelections, ok := info.BonusCardSelections["0"]; ok && len(roundSelections) > 0 {
-			newActions := make([]notation.LogItem, 0)
-			for playerID, cardStr := range roundSelections {
-				// Parse "BON1 (Desc)" -> "BON1"
-				parts := strings.Split(cardStr, " ")
-				cardCode := parts[0]
-
-				action := &notation.LogBonusCardSelectionAction{
-					PlayerID:  playerID,
-					BonusCard: cardCode,
-				}
-				newActions = append(newActions, notation.ActionItem{Action: action})
-			}
-
-			// Insert newActions at round1Index
-			// We need to be careful with slice manipulation
-			// Actions = [0...round1Index-1] + newActions + [round1Index...]
-			if round1Index >= len(session.Simulator.Actions) {
-				session.Simulator.Actions = append(session.Simulator.Actions, newActions...)
-			} else {
-				session.Simulator.Actions = append(session.Simulator.Actions[:round1Index], append(newActions, session.Simulator.Actions[round1Index:]...)...)
-			}
+	if roundSelections, ok := info.BonusCardSelections["0"]; ok && len(roundSelections) > 0 {
+		actions, err := InjectSetupBonusCardSelections(session.Simulator.Actions, roundSelections)
+		if err != nil {
+			return err
 		}
+		session.Simulator.Actions = actions
 	}
 
 	// 1.6 Update Pass Actions (Runtime)
@@ -588,7 +549,11 @@ func (m *ReplayManager) ProvideInfo(gameID string, info *ProvidedGameInfo) error
 	// However, we can fast-forward to the previous index.
 	targetIndex := session.Simulator.CurrentIndex
 	session.Simulator.CurrentIndex = 0
-	session.Simulator.CurrentState = createInitialState(session.Simulator.Actions)
+	initialState, err := createInitialState(session.Simulator.Actions)
+	if err != nil {
+		return err
+	}
+	session.Simulator.CurrentState = initialState
 	session.Simulator.History = make([]*game.GameState, 0)
 
 	// Re-detect missing info (global only)
@@ -631,15 +596,19 @@ func (m *ReplayManager) JumpTo(gameID string, targetIndex int) error {
 
 	// If jumping backwards, we need to reset the simulator
 	if targetIndex < session.Simulator.CurrentIndex {
-		initialState := createInitialState(session.Simulator.Actions)
+		initialState, err := createInitialState(session.Simulator.Actions)
+		if err != nil {
+			return err
+		}
 		session.Simulator = NewGameSimulator(initialState, session.Simulator.Actions)
+		session.Simulator.SetCheckInvariants(m.checkInvariants)
 	}
 
 	// Fast forward to target
 	return session.Simulator.JumpTo(targetIndex)
 }
 
-func createInitialState(items []notation.LogItem) *game.GameState {
+func createInitialState(items []notation.LogItem) (*game.GameState, error) {
 	// Pre-populate players and settings from GameSettingsItem if present
 	for _, item := range items {
 		if s, ok := item.(notation.GameSettingsItem); ok {
@@ -665,6 +634,13 @@ func createInitialState(items []notation.LogItem) *game.GameState {
 							}
 						}
 					}
+					if handicapStr, ok := s.Settings["Handicap:"+factionName]; ok {
+						handicap, err := game.ParseHandicap(handicapStr)
+						if err != nil {
+							return nil, fmt.Errorf("player %s: %w", factionName, err)
+						}
+						handicap.Apply(initialState.Players[factionName])
+					}
 				} else if k == "BonusCards" {
 					// Parse bonus cards
 					cards := strings.Split(v, ",")
@@ -707,27 +683,13 @@ func createInitialState(items []notation.LogItem) *game.GameState {
 				}
 			}
 
-			for k, v := range s.Settings {
-				if !strings.HasPrefix(k, "InitialBonusCard:") {
-					continue
-				}
-				playerID := strings.TrimPrefix(k, "InitialBonusCard:")
-				cardType := notation.ParseBonusCardCode(v)
-				if cardType == game.BonusCardUnknown {
-					continue
-				}
-				if _, err := initialState.BonusCards.TakeBonusCard(playerID, cardType); err != nil {
-					fmt.Printf("Warning: failed to assign initial bonus card %s to %s: %v\n", v, playerID, err)
-				}
-			}
-
 			applyReplayStartingTerrainSettings(initialState, s.Settings)
 
-			return initialState // Only need the first settings item
+			return initialState, nil // Only need the first settings item
 		}
 	}
 
-	return newReplayInitialState(nil)
+	return newReplayInitialState(nil), nil
 }
 
 func newReplayInitialState(settings map[string]string) *game.GameState {
@@ -746,6 +708,11 @@ func newReplayInitialState(settings map[string]string) *game.GameState {
 	if settings != nil && strings.EqualFold(settings["ReplaySource"], "BGA") {
 		initialState.ReplayMode["__bga__"] = true
 	}
+	// Logs of finished live games carry the seed their setup was drawn from,
+	// which the replay reports like the live game does once it ends.
+	if seed, err := strconv.ParseInt(settings["Seed"], 10, 64); err == nil {
+		initialState.Seed = seed
+	}
 	return initialState
 }
 
@@ -798,10 +765,79 @@ func parseReplayStartingTerrain(raw string) (models.TerrainType, bool) {
 	}
 }
 
-func CreateInitialState(items []notation.LogItem) *game.GameState {
+func CreateInitialState(items []notation.LogItem) (*game.GameState, error) {
 	return createInitialState(items)
 }
 
+// InjectSetupBonusCardSelections inserts the setup bonus card picks (player ID
+// to card code) ahead of the round 1 start, in reverse turn order like the live
+// setup sub-phase. Logs that already record setup picks are returned unchanged.
+// It fails if a selection names no card.
+func InjectSetupBonusCardSelections(items []notation.LogItem, selections map[string]string) ([]notation.LogItem, error) {
+	if len(selections) == 0 {
+		return items, nil
+	}
+
+	insertIndex := -1
+	settingsIndex := -1
+	var turnOrder []string
+	for i, item := range items {
+		switch v := item.(type) {
+		case notation.GameSettingsItem:
+			if settingsIndex == -1 {
+				settingsIndex = i
+			}
+		case notation.RoundStartItem:
+			if v.Round == 1 && insertIndex == -1 {
+				insertIndex = i
+				turnOrder = v.TurnOrder
+			}
+		case notation.ActionItem:
+			if _, ok := v.Action.(*notation.LogBonusCardSelectionAction); ok {
+				return items, nil
+			}
+		}
+	}
+	if insertIndex == -1 {
+		insertIndex = settingsIndex + 1
+	}
+
+	pickOrder := make([]string, 0, len(selections))
+	seen := make(map[string]bool, len(selections))
+	for i := len(turnOrder) - 1; i >= 0; i-- {
+		if _, ok := selections[turnOrder[i]]; ok && !seen[turnOrder[i]] {
+			pickOrder = append(pickOrder, turnOrder[i])
+			seen[turnOrder[i]] = true
+		}
+	}
+	var rest []string
+	for playerID := range selections {
+		if !seen[playerID] {
+			rest = append(rest, playerID)
+		}
+	}
+	sort.Strings(rest)
+	pickOrder = append(pickOrder, rest...)
+
+	picks := make([]notation.LogItem, 0, len(pickOrder))
+	for _, playerID := range pickOrder {
+		// Parse "BON1 (Desc)" -> "BON1"
+		fields := strings.Fields(selections[playerID])
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("setup bonus card selection for %s is empty", playerID)
+		}
+		picks = append(picks, notation.ActionItem{Action: &notation.LogBonusCardSelectionAction{
+			PlayerID:  playerID,
+			BonusCard: fields[0],
+		}})
+	}
+
+	out := make([]notation.LogItem, 0, len(items)+len(picks))
+	out = append(out, items[:insertIndex]...)
+	out = append(out, picks...)
+	return append(out, items[insertIndex:]...), nil
+}
+
 func detectMissingInfo(items []notation.LogItem) *MissingGameInfo {
 	missing := &MissingGameInfo{
 		BonusCardSelections: make(map[int]map[string]bool),
@@ -1,6 +1,7 @@
 package replay
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -60,6 +61,40 @@ func TestParseReplayLogContent_SnellmanConvertsToConcise(t *testing.T) {
 	}
 }
 
+func TestParseReplayLogContent_SnellmanNamesPlayersFromPreamble(t *testing.T) {
+	manager := NewReplayManager(t.TempDir())
+	snellman := strings.Join([]string{
+		"option strict-leech\tshow history",
+		"option email-notify\tshow history",
+		"Player 1: jere\tshow history",
+		"Player 2: canetts\tshow history",
+		"cultists\t\t20 VP\t\t15 C\t\t3 W\t\t0 P\t\t5/7/0 PW\t\t1/0/1/0\t\tsetup",
+		"engineers\t\t20 VP\t\t10 C\t\t2 W\t\t0 P\t\t3/9/0 PW\t\t0/0/0/0\t\tsetup",
+		"Round 1 income\tshow history",
+		"Round 1, turn 1\tshow history",
+		"cultists\t\t20 VP\t-3\t12 C\t-1\t2 W\t\t0 P\t\t5/7/0 PW\t\t1/0/1/0\t\tupgrade E6 to TP",
+	}, "\n")
+
+	items, canonical, err := manager.parseReplayLogContent(snellman, ReplayLogFormatSnellman)
+	if err != nil {
+		t.Fatalf("parseReplayLogContent(snellman) error = %v", err)
+	}
+	if !strings.Contains(canonical, "PlayerNames: Cultists:jere, Engineers:canetts") {
+		t.Fatalf("expected player names in canonical output, got:\n%s", canonical)
+	}
+
+	state := mustCreateInitialState(t, items)
+	if len(state.Players) != 2 {
+		t.Fatalf("players = %d, want one per faction", len(state.Players))
+	}
+	for faction, name := range map[string]string{"Cultists": "jere", "Engineers": "canetts"} {
+		player := state.GetPlayer(faction)
+		if player == nil || player.Name != name || player.VictoryPoints != 20 {
+			t.Fatalf("player %s = %+v, want name %s with 20 VP", faction, player, name)
+		}
+	}
+}
+
 func TestParseReplayLogContent_SnellmanExtractsSetupScoringAndBonuses(t *testing.T) {
 	manager := NewReplayManager(t.TempDir())
 	snellman := strings.Join([]string{
@@ -172,6 +207,29 @@ BADTOKEN     |`
 	}
 }
 
+func TestImportText_ReportsUnsupportedFeaturesBeforeReplay(t *testing.T) {
+	manager := NewReplayManager(t.TempDir())
+	snellman := strings.Join([]string{
+		"option strict-leech",
+		"yetis\t\t20 VP\t\t15 C\t\t3 W\t\t0 P\t\t0/12/0 PW\t\t0/0/1/1\t\tsetup",
+		"engineers\t\t20 VP\t\t10 C\t\t2 W\t\t0 P\t\t3/9/0 PW\t\t0/0/0/0\t\tsetup",
+		"Round 1 income\tshow history",
+		"Round 1, turn 1\tshow history",
+	}, "\n")
+
+	err := manager.ImportText("unsupported", snellman, "auto")
+	var unsupported *notation.UnsupportedFeatureError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("expected an UnsupportedFeatureError, got %v", err)
+	}
+	if len(unsupported.Features) != 1 || unsupported.Features[0].Name != "yetis" {
+		t.Fatalf("unexpected features %+v", unsupported.Features)
+	}
+	if manager.GetSession("unsupported") != nil {
+		t.Fatalf("expected no replay session for an unsupported log")
+	}
+}
+
 func TestParseReplayLogContent_SnellmanReanchorsLeechAfterSourceAction(t *testing.T) {
 	manager := NewReplayManager(t.TempDir())
 	manager.SetSourceAnchoredLeechOrdering(true)
@@ -286,6 +344,32 @@ func TestReplayWithSourceAnchoredLeechOrdering_S69G6Completes(t *testing.T) {
 	}
 }
 
+func TestReplayWithCheckInvariants_S69G6HoldsInvariants(t *testing.T) {
+	manager := NewReplayManager(t.TempDir())
+	manager.SetSourceAnchoredLeechOrdering(true)
+	manager.SetCheckInvariants(true)
+
+	content, err := os.ReadFile(filepath.Join("testdata", "snellman_batch", "4pLeague_S69_D1L1_G6.txt"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	gameID := "invariants_s69_g6"
+	if err := manager.ImportText(gameID, string(content), "snellman"); err != nil {
+		t.Fatalf("ImportText: %v", err)
+	}
+	session := manager.GetSession(gameID)
+	if err := manager.JumpTo(gameID, len(session.Simulator.Actions)); err != nil {
+		t.Fatalf("expected every step to hold the invariants: %v", err)
+	}
+
+	if err := manager.JumpTo(gameID, 1); err != nil {
+		t.Fatalf("JumpTo(1): %v", err)
+	}
+	if !manager.GetSession(gameID).Simulator.checkInvariants {
+		t.Fatalf("expected the simulator rebuilt for a backward jump to keep checking invariants")
+	}
+}
+
 func findActionIndex(items []notation.LogItem, predicate func(game.Action) bool) int {
 	for i, item := range items {
 		actionItem, ok := item.(notation.ActionItem)
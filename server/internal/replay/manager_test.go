@@ -72,7 +72,7 @@ func TestCreateInitialState_UsesGameBoardSetting(t *testing.T) {
 		},
 	}
 
-	initialState := createInitialState(items)
+	initialState := mustCreateInitialState(t, items)
 
 	if initialState.Map == nil {
 		t.Fatal("initialState.Map is nil")
@@ -94,7 +94,7 @@ func TestCreateInitialState_AppliesStartingTerrainSetting(t *testing.T) {
 		},
 	}
 
-	initialState := createInitialState(items)
+	initialState := mustCreateInitialState(t, items)
 	player := initialState.Players["Ice Maidens"]
 	if player == nil {
 		t.Fatal("Ice Maidens player not found")
@@ -106,3 +106,99 @@ func TestCreateInitialState_AppliesStartingTerrainSetting(t *testing.T) {
 		t.Fatalf("starting terrain = %v, want %v", player.StartingTerrain, models.TerrainMountain)
 	}
 }
+
+func TestCreateInitialState_KeepsSeedSetting(t *testing.T) {
+	items := []notation.LogItem{
+		notation.GameSettingsItem{
+			Settings: map[string]string{
+				"Game":         "Base Game",
+				"Player:alice": "Witches",
+				"Seed":         "1234",
+			},
+		},
+	}
+
+	if seed := mustCreateInitialState(t, items).Seed; seed != 1234 {
+		t.Fatalf("initialState.Seed = %d, want 1234", seed)
+	}
+}
+
+func TestCreateInitialState_RejectsInvalidHandicap(t *testing.T) {
+	items := []notation.LogItem{
+		notation.GameSettingsItem{
+			Settings: map[string]string{
+				"Player:alice":     "Witches",
+				"Handicap:Witches": "5XP",
+			},
+		},
+	}
+
+	if _, err := createInitialState(items); err == nil {
+		t.Fatal("expected an invalid handicap to be rejected")
+	}
+}
+
+func mustCreateInitialState(t *testing.T, items []notation.LogItem) *game.GameState {
+	t.Helper()
+	initialState, err := createInitialState(items)
+	if err != nil {
+		t.Fatalf("createInitialState: %v", err)
+	}
+	return initialState
+}
+
+func TestInjectSetupBonusCardSelections_UsesReverseTurnOrderBeforeRoundOne(t *testing.T) {
+	items := []notation.LogItem{
+		notation.GameSettingsItem{Settings: map[string]string{"BonusCards": "BON-P,BON-SHIP,BON-DW,BON-WP"}},
+		notation.RoundStartItem{Round: 1, TurnOrder: []string{"Witches", "Nomads", "Engineers"}},
+		notation.ActionItem{Action: game.NewPassAction("Witches", nil)},
+	}
+
+	got, err := InjectSetupBonusCardSelections(items, map[string]string{
+		"Witches":   "BON-P",
+		"Nomads":    "BON-SHIP (Shipping)",
+		"Engineers": "BON-DW",
+	})
+	if err != nil {
+		t.Fatalf("InjectSetupBonusCardSelections failed: %v", err)
+	}
+	if len(got) != len(items)+3 {
+		t.Fatalf("expected 3 injected picks, got %d items", len(got))
+	}
+
+	wantPlayers := []string{"Engineers", "Nomads", "Witches"}
+	wantCards := []string{"BON-DW", "BON-SHIP", "BON-P"}
+	for i := range wantPlayers {
+		item, ok := got[1+i].(notation.ActionItem)
+		if !ok {
+			t.Fatalf("item %d: expected action item, got %T", 1+i, got[1+i])
+		}
+		pick, ok := item.Action.(*notation.LogBonusCardSelectionAction)
+		if !ok {
+			t.Fatalf("item %d: expected setup bonus pick, got %T", 1+i, item.Action)
+		}
+		if pick.PlayerID != wantPlayers[i] || pick.BonusCard != wantCards[i] {
+			t.Fatalf("pick %d: got %s %s, want %s %s", i, pick.PlayerID, pick.BonusCard, wantPlayers[i], wantCards[i])
+		}
+	}
+	if rs, ok := got[4].(notation.RoundStartItem); !ok || rs.Round != 1 {
+		t.Fatalf("expected round 1 start after the setup picks, got %#v", got[4])
+	}
+
+	again, err := InjectSetupBonusCardSelections(got, map[string]string{"Witches": "BON-WP"})
+	if err != nil || len(again) != len(got) {
+		t.Fatalf("expected existing setup picks to be kept, got %d items want %d (err %v)", len(again), len(got), err)
+	}
+}
+
+func TestInjectSetupBonusCardSelections_RejectsEmptySelection(t *testing.T) {
+	items := []notation.LogItem{
+		notation.GameSettingsItem{Settings: map[string]string{"BonusCards": "BON-P,BON-SHIP,BON-DW"}},
+		notation.RoundStartItem{Round: 1, TurnOrder: []string{"Witches", "Nomads"}},
+	}
+	for _, selection := range []string{"", "   "} {
+		if _, err := InjectSetupBonusCardSelections(items, map[string]string{"Witches": "BON-P", "Nomads": selection}); err == nil {
+			t.Fatalf("expected selection %q to be rejected", selection)
+		}
+	}
+}
@@ -123,38 +123,11 @@ const (
 
 // ParseFaction converts a faction string to the internal FactionType
 func ParseFaction(factionStr string) (models.FactionType, error) {
-	switch factionStr {
-	case "witches":
-		return models.FactionWitches, nil
-	case "nomads":
-		return models.FactionNomads, nil
-	case "halflings":
-		return models.FactionHalflings, nil
-	case "cultists":
-		return models.FactionCultists, nil
-	case "alchemists":
-		return models.FactionAlchemists, nil
-	case "darklings":
-		return models.FactionDarklings, nil
-	case "mermaids":
-		return models.FactionMermaids, nil
-	case "swarmlings":
-		return models.FactionSwarmlings, nil
-	case "engineers":
-		return models.FactionEngineers, nil
-	case "chaosmagicians":
-		return models.FactionChaosMagicians, nil
-	case "giants":
-		return models.FactionGiants, nil
-	case "fakirs":
-		return models.FactionFakirs, nil
-	case "dwarves":
-		return models.FactionDwarves, nil
-	case "auren":
-		return models.FactionAuren, nil
-	default:
+	faction := models.FactionTypeFromString(factionStr)
+	if faction == models.FactionUnknown {
 		return 0, fmt.Errorf("unknown faction: %s", factionStr)
 	}
+	return faction, nil
 }
 
 // ParseBuildingType converts a building string to the internal BuildingType
@@ -0,0 +1,191 @@
+package replay
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/models"
+	"github.com/lukev/tm_server/internal/notation"
+)
+
+// maxRepairAmount caps the amounts tried for candidate conversions and burns.
+const maxRepairAmount = 5
+
+// Repair is a candidate correction for a log that stops replaying: log items
+// to insert before the item at Index. Community exports often drop rows, most
+// commonly leech declines and free conversions.
+type Repair struct {
+	Index       int
+	Description string
+	Insert      []notation.LogItem
+	// Reach is how many of the original log items replay with the repair in
+	// place, and Complete reports that the whole log replays.
+	Reach    int
+	Complete bool
+}
+
+// ReplayFailure is the first log item that fails to replay.
+type ReplayFailure struct {
+	Index int
+	Err   error
+}
+
+func (f *ReplayFailure) Error() string {
+	return fmt.Sprintf("item %d: %v", f.Index, f.Err)
+}
+
+func (f *ReplayFailure) Unwrap() error {
+	return f.Err
+}
+
+// FirstReplayFailure replays items from the start and returns the first item
+// that fails, or nil if the whole log replays.
+func FirstReplayFailure(items []notation.LogItem) *ReplayFailure {
+	sim, err := replayItems(items, len(items))
+	if err == nil {
+		return nil
+	}
+	return &ReplayFailure{Index: sim.CurrentIndex, Err: err}
+}
+
+// ProposeRepairs returns the first failure of items and the candidate
+// repairs that replay past it, best first: repairs that let more of the log
+// replay come first, and among equals the order below (leech declines, then
+// conversions, then burns). It returns a nil failure when items replay.
+func ProposeRepairs(items []notation.LogItem) (*ReplayFailure, []Repair) {
+	failure := FirstReplayFailure(items)
+	if failure == nil {
+		return nil, nil
+	}
+	sim, err := replayItems(items, failure.Index)
+	if err != nil {
+		return failure, nil
+	}
+
+	var repairs []Repair
+	for _, candidate := range repairCandidates(sim.GetState(), items[failure.Index]) {
+		candidate.Index = failure.Index
+		patched := ApplyRepair(items, candidate)
+		reach, complete := replayReach(patched)
+		if reach > failure.Index+len(candidate.Insert) {
+			candidate.Reach = reach - len(candidate.Insert)
+		} else {
+			candidate.Reach = min(reach, failure.Index)
+		}
+		candidate.Complete = complete
+		if candidate.Reach > failure.Index {
+			repairs = append(repairs, candidate)
+		}
+	}
+	sort.SliceStable(repairs, func(i, j int) bool {
+		return repairs[i].Reach > repairs[j].Reach
+	})
+	return failure, repairs
+}
+
+// ApplyRepair returns a copy of items with the repair inserted.
+func ApplyRepair(items []notation.LogItem, repair Repair) []notation.LogItem {
+	patched := make([]notation.LogItem, 0, len(items)+len(repair.Insert))
+	patched = append(patched, items[:repair.Index]...)
+	patched = append(patched, repair.Insert...)
+	return append(patched, items[repair.Index:]...)
+}
+
+// RepairLog applies the best repair until items replay completely, no
+// repair gets past the failure, or maxRepairs repairs were applied. It
+// returns the patched items, the applied repairs and the remaining failure.
+func RepairLog(items []notation.LogItem, maxRepairs int) ([]notation.LogItem, []Repair, *ReplayFailure) {
+	var applied []Repair
+	for {
+		failure, repairs := ProposeRepairs(items)
+		if failure == nil || len(repairs) == 0 || len(applied) >= maxRepairs {
+			return items, applied, failure
+		}
+		items = ApplyRepair(items, repairs[0])
+		applied = append(applied, repairs[0])
+	}
+}
+
+func replayItems(items []notation.LogItem, target int) (*GameSimulator, error) {
+	initialState, err := createInitialState(items)
+	if err != nil {
+		return nil, err
+	}
+	sim := NewGameSimulator(initialState, items)
+	return sim, sim.JumpTo(target)
+}
+
+func replayReach(items []notation.LogItem) (int, bool) {
+	sim, err := replayItems(items, len(items))
+	if sim == nil {
+		return 0, false
+	}
+	return sim.CurrentIndex, err == nil
+}
+
+// repairCandidates lists the corrections worth trying before failing, given
+// the state it failed on.
+func repairCandidates(gs *game.GameState, failing notation.LogItem) []Repair {
+	var candidates []Repair
+
+	pendingLeech := make([]string, 0, len(gs.PendingLeechOffers))
+	for playerID, offers := range gs.PendingLeechOffers {
+		if len(offers) > 0 {
+			pendingLeech = append(pendingLeech, playerID)
+		}
+	}
+	sort.Strings(pendingLeech)
+	for _, playerID := range pendingLeech {
+		declines := make([]notation.LogItem, len(gs.PendingLeechOffers[playerID]))
+		for i := range declines {
+			declines[i] = notation.ActionItem{Action: &notation.LogDeclineLeechAction{PlayerID: playerID}}
+		}
+		candidates = append(candidates, Repair{
+			Description: fmt.Sprintf("%s declines %d pending leech offer(s)", playerID, len(declines)),
+			Insert:      declines,
+		})
+	}
+
+	item, ok := failing.(notation.ActionItem)
+	if !ok || item.Action == nil {
+		return candidates
+	}
+	playerID := item.Action.GetPlayerID()
+	player := gs.GetPlayer(playerID)
+	if player == nil || player.Resources == nil || player.Resources.Power == nil {
+		return candidates
+	}
+
+	power := player.Resources.Power
+	spendablePower := power.Bowl3 + power.Bowl2/2
+	conversion := func(cost, reward map[models.ResourceType]int) {
+		action := &notation.LogConversionAction{PlayerID: playerID, Cost: cost, Reward: reward}
+		candidates = append(candidates, Repair{
+			Description: fmt.Sprintf("%s converts %s", playerID, notation.ConversionCode(action)),
+			Insert:      []notation.LogItem{notation.ActionItem{Action: action}},
+		})
+	}
+	for amount := 1; amount <= min(spendablePower, maxRepairAmount); amount++ {
+		conversion(map[models.ResourceType]int{models.ResourcePower: amount}, map[models.ResourceType]int{models.ResourceCoin: amount})
+	}
+	if spendablePower >= 3 {
+		conversion(map[models.ResourceType]int{models.ResourcePower: 3}, map[models.ResourceType]int{models.ResourceWorker: 1})
+	}
+	if spendablePower >= 5 {
+		conversion(map[models.ResourceType]int{models.ResourcePower: 5}, map[models.ResourceType]int{models.ResourcePriest: 1})
+	}
+	for amount := 1; amount <= min(player.Resources.Priests, maxRepairAmount); amount++ {
+		conversion(map[models.ResourceType]int{models.ResourcePriest: amount}, map[models.ResourceType]int{models.ResourceWorker: amount})
+	}
+	for amount := 1; amount <= min(player.Resources.Workers, maxRepairAmount); amount++ {
+		conversion(map[models.ResourceType]int{models.ResourceWorker: amount}, map[models.ResourceType]int{models.ResourceCoin: amount})
+	}
+	for amount := 1; amount <= min(power.Bowl2/2, maxRepairAmount); amount++ {
+		candidates = append(candidates, Repair{
+			Description: fmt.Sprintf("%s burns %d power", playerID, amount),
+			Insert:      []notation.LogItem{notation.ActionItem{Action: &notation.LogBurnAction{PlayerID: playerID, Amount: amount}}},
+		})
+	}
+	return candidates
+}
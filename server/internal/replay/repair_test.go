@@ -0,0 +1,63 @@
+package replay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/notation"
+)
+
+func TestProposeRepairs_RestoresDroppedConversion(t *testing.T) {
+	manager := NewReplayManager(t.TempDir())
+	manager.SetSourceAnchoredLeechOrdering(true)
+	content, err := os.ReadFile(filepath.Join("testdata", "snellman_batch", "4pLeague_S69_D1L1_G6.txt"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	if err := manager.ImportText("repair", string(content), "snellman"); err != nil {
+		t.Fatalf("ImportText: %v", err)
+	}
+	items := manager.GetSession("repair").Simulator.Actions
+	if failure := FirstReplayFailure(items); failure != nil {
+		t.Fatalf("expected the fixture to replay, got %v", failure)
+	}
+
+	// Drop the leading conversion of the first compound row that cannot
+	// replay without it, as a truncated export would.
+	for i, item := range items {
+		action, ok := item.(notation.ActionItem)
+		if !ok {
+			continue
+		}
+		compound, ok := action.Action.(*notation.LogCompoundAction)
+		if !ok || len(compound.Actions) < 2 {
+			continue
+		}
+		dropped, ok := compound.Actions[0].(*notation.LogConversionAction)
+		if !ok {
+			continue
+		}
+		corrupt := append([]notation.LogItem(nil), items...)
+		corrupt[i] = notation.ActionItem{Action: &notation.LogCompoundAction{Actions: compound.Actions[1:]}}
+		failure, repairs := ProposeRepairs(corrupt)
+		if failure == nil || failure.Index != i {
+			continue
+		}
+
+		if len(repairs) == 0 || !repairs[0].Complete || repairs[0].Index != i {
+			t.Fatalf("expected a complete repair before item %d, got %+v", i, repairs)
+		}
+		inserted, ok := repairs[0].Insert[0].(notation.ActionItem).Action.(*notation.LogConversionAction)
+		if !ok || notation.ConversionCode(inserted) != notation.ConversionCode(dropped) {
+			t.Fatalf("expected the dropped %s to be proposed first, got %s", notation.ConversionCode(dropped), repairs[0].Description)
+		}
+
+		repaired, applied, remaining := RepairLog(corrupt, 5)
+		if remaining != nil || len(applied) != 1 || len(repaired) != len(items)+1 {
+			t.Fatalf("expected one repair to fix the log, got %d repairs and %v", len(applied), remaining)
+		}
+		return
+	}
+	t.Fatalf("no compound row in the fixture depends on its leading conversion")
+}
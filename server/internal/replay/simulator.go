@@ -1,7 +1,9 @@
 package replay
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -23,6 +25,9 @@ type GameSimulator struct {
 	incomePending              bool              // RoundStart seen but income not yet granted
 	incomeGranted              bool              // Income granted for current round, but action phase may not have started yet
 	lastTreasurersIncomeOffers map[string]*game.PendingTreasurersDeposit
+	checkInvariants            bool
+	coverage                   *Coverage
+	lastConsequences           *game.ActionConsequences
 }
 
 // NewGameSimulator creates a new simulator
@@ -47,11 +52,121 @@ func NewGameSimulator(initialState *game.GameState, actions []notation.LogItem)
 	return sim
 }
 
-// StepForward executes the next action
+// simulatorResumePoint is what MarshalResumePoint saves of a simulator.
+type simulatorResumePoint struct {
+	Index                      int                                       `json:"index"`
+	State                      json.RawMessage                           `json:"state"`
+	IncomePending              bool                                      `json:"incomePending,omitempty"`
+	IncomeGranted              bool                                      `json:"incomeGranted,omitempty"`
+	LastTreasurersIncomeOffers map[string]*game.PendingTreasurersDeposit `json:"lastTreasurersIncomeOffers,omitempty"`
+}
+
+// MarshalResumePoint saves the simulator's position and state, so that
+// ResumeGameSimulator carries on from them in another process.
+func (s *GameSimulator) MarshalResumePoint() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	state, err := s.CurrentState.MarshalCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(simulatorResumePoint{
+		Index:                      s.CurrentIndex,
+		State:                      state,
+		IncomePending:              s.incomePending,
+		IncomeGranted:              s.incomeGranted,
+		LastTreasurersIncomeOffers: s.lastTreasurersIncomeOffers,
+	})
+}
+
+// ResumeGameSimulator returns a simulator over actions at the position saved
+// by MarshalResumePoint. It starts there, so it cannot step back before it.
+func ResumeGameSimulator(actions []notation.LogItem, raw []byte) (*GameSimulator, error) {
+	var point simulatorResumePoint
+	if err := json.Unmarshal(raw, &point); err != nil {
+		return nil, fmt.Errorf("failed to decode resume point: %w", err)
+	}
+	if point.Index < 0 || point.Index > len(actions) {
+		return nil, fmt.Errorf("resume point is at item %d but the log has %d", point.Index, len(actions))
+	}
+	state, err := game.UnmarshalCheckpoint(point.State)
+	if err != nil {
+		return nil, err
+	}
+	sim := NewGameSimulator(state, actions)
+	sim.CurrentIndex = point.Index
+	sim.incomePending = point.IncomePending
+	sim.incomeGranted = point.IncomeGranted
+	if point.LastTreasurersIncomeOffers != nil {
+		sim.lastTreasurersIncomeOffers = point.LastTreasurersIncomeOffers
+	}
+	return sim, nil
+}
+
+// SetCheckInvariants makes every step validate the game invariants (see
+// game.GameState.CheckInvariants) and fail on the first violation.
+func (s *GameSimulator) SetCheckInvariants(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkInvariants = enabled
+}
+
+// SetCoverage makes every step count the rules paths it exercises in
+// coverage; nil stops counting.
+func (s *GameSimulator) SetCoverage(coverage *Coverage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.coverage = coverage
+}
+
+// StepForward executes the next action. Hexes in its errors carry their
+// board labels.
 func (s *GameSimulator) StepForward() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	var item notation.LogItem
+	var marks coverageMarks
+	if s.coverage != nil && s.CurrentIndex < len(s.Actions) {
+		item = s.Actions[s.CurrentIndex]
+		marks = markCoverage(s.CurrentState)
+	}
+	var action game.Action
+	var consequences *game.ConsequenceTracker
+	if s.CurrentIndex < len(s.Actions) {
+		if actionItem, ok := s.Actions[s.CurrentIndex].(notation.ActionItem); ok && actionItem.Action != nil {
+			action = actionItem.Action
+			consequences = game.TrackConsequences(s.CurrentState)
+		}
+	}
+	err := s.stepForward()
+	s.lastConsequences = nil
+	if err == nil && consequences != nil {
+		s.lastConsequences = consequences.Consequences(s.CurrentState, action)
+	}
+	if err == nil && s.coverage != nil {
+		s.coverage.observe(item, marks, s.CurrentState)
+	}
+	if err != nil && s.CurrentState != nil {
+		return s.CurrentState.Map.LabelHexError(err)
+	}
+	if err == nil && s.checkInvariants {
+		if err := s.CurrentState.CheckInvariants(); err != nil {
+			return fmt.Errorf("after item %d: %w", s.CurrentIndex-1, err)
+		}
+	}
+	return err
+}
+
+// LastConsequences returns the consequences of the action the last
+// StepForward executed, or nil when it did not execute one.
+func (s *GameSimulator) LastConsequences() *game.ActionConsequences {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastConsequences
+}
+
+func (s *GameSimulator) stepForward() error {
 	if s.CurrentIndex >= len(s.Actions) {
 		return fmt.Errorf("no more actions")
 	}
@@ -59,6 +174,7 @@ func (s *GameSimulator) StepForward() error {
 	item := s.Actions[s.CurrentIndex]
 	s.autoResolveImplicitZeroTreasurersDeposits(item)
 	s.autoResolveImplicitRiverwalkersPriestChoices(item)
+	s.autoResolveImplicitHalflingsDwellingSkip(item)
 
 	switch v := item.(type) {
 	case notation.ActionItem:
@@ -162,7 +278,7 @@ func (s *GameSimulator) StepForward() error {
 			}
 
 			actionSnapshot := s.CurrentState.CloneForUndo()
-			if err := v.Action.Execute(s.CurrentState); err != nil {
+			if err := notation.WithHalflingsStrongholdSpades(s.CurrentState, v.Action).Execute(s.CurrentState); err != nil {
 				if shouldIgnoreMissingDeclineLeechAtFullPower(v.Action, s.CurrentState, err) {
 					s.CurrentIndex++
 					return nil
@@ -243,6 +359,14 @@ func (s *GameSimulator) StepForward() error {
 				// We use the faction name as the player ID in the simulator for simplicity,
 				// matching how actions are parsed (Action.GetPlayerID() returns faction name).
 				factionName := settingValue
+				var handicap game.Handicap
+				if handicapStr, ok := settings["Handicap:"+factionName]; ok {
+					parsed, err := game.ParseHandicap(handicapStr)
+					if err != nil {
+						return fmt.Errorf("player %s: %w", factionName, err)
+					}
+					handicap = parsed
+				}
 				// Create player if not exists
 				if _, exists := s.CurrentState.Players[factionName]; !exists {
 					factionType := models.FactionTypeFromString(factionName)
@@ -250,13 +374,15 @@ func (s *GameSimulator) StepForward() error {
 					if err := s.CurrentState.AddPlayer(factionName, faction); err != nil {
 						return fmt.Errorf("failed to add player %s: %w", factionName, err)
 					}
-				} else {
+					// Players pre-populated by createInitialState already have
+					// their handicap.
+					handicap.Apply(s.CurrentState.Players[factionName])
 				}
 
 				// Set starting VPs if specified (always update, even if player exists)
 				if vpStr, ok := settings["StartingVP:"+factionName]; ok {
 					if vp, err := strconv.Atoi(vpStr); err == nil {
-						s.CurrentState.Players[factionName].VictoryPoints = vp
+						s.CurrentState.Players[factionName].VictoryPoints = vp + handicap.VP
 					}
 				}
 			} else if k == "BonusCards" {
@@ -360,6 +486,43 @@ func (s *GameSimulator) autoResolveImplicitRiverwalkersPriestChoices(nextItem no
 	}
 }
 
+// autoResolveImplicitHalflingsDwellingSkip skips the optional Halflings
+// stronghold dwelling once every spade is applied, unless nextItem builds it:
+// concise logs write no token for the skip.
+func (s *GameSimulator) autoResolveImplicitHalflingsDwellingSkip(nextItem notation.LogItem) {
+	if s == nil || s.CurrentState == nil {
+		return
+	}
+	pending := s.CurrentState.PendingHalflingsSpades
+	if pending == nil || pending.SpadesRemaining > 0 || nextItemIsHalflingsDwelling(nextItem, pending) {
+		return
+	}
+	skip := &game.SkipHalflingsDwellingAction{
+		BaseAction: game.BaseAction{Type: game.ActionSkipHalflingsDwelling, PlayerID: pending.PlayerID},
+	}
+	if err := skip.Execute(s.CurrentState); err != nil {
+		s.CurrentState.PendingHalflingsSpades = nil
+	}
+}
+
+func nextItemIsHalflingsDwelling(item notation.LogItem, pending *game.PendingHalflingsSpades) bool {
+	actionItem, ok := item.(notation.ActionItem)
+	if !ok || actionItem.Action == nil {
+		return false
+	}
+	action := actionItem.Action
+	if compound, ok := action.(*notation.LogCompoundAction); ok && compound != nil && len(compound.Actions) > 0 {
+		action = compound.Actions[0]
+	}
+	switch a := action.(type) {
+	case *game.BuildHalflingsDwellingAction:
+		return a.PlayerID == pending.PlayerID
+	case *game.TransformAndBuildAction:
+		return a.PlayerID == pending.PlayerID && a.BuildDwelling && slices.Contains(pending.TransformedHexes, a.TargetHex)
+	}
+	return false
+}
+
 func nextItemIsRiverwalkersUnlock(item notation.LogItem, pending *game.PendingRiverwalkersPriestChoice) bool {
 	if pending == nil {
 		return false
@@ -541,6 +704,7 @@ func (s *GameSimulator) updateCachedFinalScoringForPlayer(playerID string) {
 
 	score.TotalResourceValue = totalResourceValue
 	score.ResourceVP = totalResourceValue / coinsPerVP
+	score.FactionVP = score.ResourceVP - totalResourceValue/3
 	score.TotalVP = score.BaseVP + score.AreaVP + score.CultVP + score.ResourceVP
 	player.VictoryPoints = score.TotalVP
 }
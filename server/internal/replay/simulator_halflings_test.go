@@ -0,0 +1,147 @@
+package replay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/game/factions"
+	"github.com/lukev/tm_server/internal/models"
+	"github.com/lukev/tm_server/internal/notation"
+)
+
+var (
+	halflingsTradingHouse = board.NewHex(0, 0)
+	halflingsOneSpade     = board.NewHex(1, 0)
+	halflingsTwoSpades    = board.NewHex(2, 0)
+)
+
+func newHalflingsStrongholdState(t *testing.T) *game.GameState {
+	t.Helper()
+	gs := game.NewGameState()
+	if err := gs.AddPlayer("Halflings", factions.NewHalflings()); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+	if err := gs.AddPlayer("Nomads", factions.NewNomads()); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+	gs.TurnOrder = []string{"Halflings", "Nomads"}
+	gs.Phase = game.PhaseAction
+	gs.Round = 1
+	gs.ScoringTiles = &game.ScoringTileState{Tiles: []game.ScoringTile{
+		{Type: game.ScoringSpades, ActionType: game.ScoringActionSpades, ActionVP: 2},
+	}}
+	player := gs.GetPlayer("Halflings")
+	player.Resources.Coins = 20
+	player.Resources.Workers = 10
+	nomads := gs.GetPlayer("Nomads")
+	nomads.Resources.Coins = 20
+	nomads.Resources.Priests = 2
+
+	gs.Map.TransformTerrain(halflingsTradingHouse, models.TerrainPlains)
+	gs.Map.PlaceBuilding(halflingsTradingHouse, &models.Building{
+		Type: models.BuildingTradingHouse, Faction: models.FactionHalflings, PlayerID: "Halflings", PowerValue: 2,
+	})
+	gs.Map.TransformTerrain(halflingsOneSpade, models.TerrainSwamp)
+	gs.Map.TransformTerrain(halflingsTwoSpades, models.TerrainLake)
+	return gs
+}
+
+func TestStepForward_ReplaysGeneratedHalflingsStrongholdSpades(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		build bool
+	}{
+		{name: "with dwelling", build: true},
+		{name: "without dwelling"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			live := newHalflingsStrongholdState(t)
+			actions := []game.Action{
+				game.NewUpgradeBuildingAction("Halflings", halflingsTradingHouse, models.BuildingStronghold),
+				&game.ApplyHalflingsSpadeAction{
+					BaseAction:    game.BaseAction{Type: game.ActionApplyHalflingsSpade, PlayerID: "Halflings"},
+					TargetHex:     halflingsOneSpade,
+					TargetTerrain: models.TerrainPlains,
+				},
+				&game.ApplyHalflingsSpadeAction{
+					BaseAction:    game.BaseAction{Type: game.ActionApplyHalflingsSpade, PlayerID: "Halflings"},
+					TargetHex:     halflingsTwoSpades,
+					TargetTerrain: models.TerrainPlains,
+				},
+			}
+			if tt.build {
+				actions = append(actions, &game.BuildHalflingsDwellingAction{
+					BaseAction: game.BaseAction{Type: game.ActionBuildHalflingsDwelling, PlayerID: "Halflings"},
+					TargetHex:  halflingsOneSpade,
+				})
+			} else {
+				actions = append(actions, &game.SkipHalflingsDwellingAction{
+					BaseAction: game.BaseAction{Type: game.ActionSkipHalflingsDwelling, PlayerID: "Halflings"},
+				})
+			}
+			actions = append(actions, game.NewAdvanceShippingAction("Nomads"))
+
+			items := []notation.LogItem{
+				notation.GameSettingsItem{Settings: map[string]string{"Game": "Base"}},
+				notation.RoundStartItem{Round: 1, TurnOrder: []string{"Halflings", "Nomads"}},
+			}
+			for _, action := range actions {
+				if err := action.Execute(live); err != nil {
+					t.Fatalf("live %T: %v", action, err)
+				}
+				items = append(items, notation.ActionItem{Action: action})
+			}
+
+			lines, _ := notation.GenerateConciseLog(items)
+			log := strings.Join(lines, "\n")
+			sh := notation.HexToShortString(halflingsTradingHouse)
+			one := notation.HexToShortString(halflingsOneSpade)
+			two := notation.HexToShortString(halflingsTwoSpades)
+			tokens := []string{"UP-SH-" + sh, "T-" + one, "T-" + two}
+			if tt.build {
+				tokens = append(tokens, one)
+			}
+			wantCell := strings.Join(tokens, ".")
+			if !strings.Contains(log, wantCell+" ") {
+				t.Fatalf("generated log lacks %q:\n%s", wantCell, log)
+			}
+
+			parsed, err := notation.ParseConciseLogStrict(log)
+			if err != nil {
+				t.Fatalf("ParseConciseLogStrict() error = %v\n%s", err, log)
+			}
+			var replayItems []notation.LogItem
+			for _, item := range parsed {
+				if _, ok := item.(notation.ActionItem); ok {
+					replayItems = append(replayItems, item)
+				}
+			}
+			sim := NewGameSimulator(newHalflingsStrongholdState(t), replayItems)
+			for range replayItems {
+				if err := sim.StepForward(); err != nil {
+					t.Fatalf("StepForward() error = %v", err)
+				}
+			}
+			replayed := sim.CurrentState
+
+			if replayed.PendingHalflingsSpades != nil {
+				t.Fatal("expected the replay to end the stronghold spades")
+			}
+			livePlayer, replayedPlayer := live.GetPlayer("Halflings"), replayed.GetPlayer("Halflings")
+			if replayedPlayer.VictoryPoints != livePlayer.VictoryPoints || replayedPlayer.Resources.Coins != livePlayer.Resources.Coins {
+				t.Fatalf("replayed VP/coins = %d/%d, live %d/%d", replayedPlayer.VictoryPoints, replayedPlayer.Resources.Coins, livePlayer.VictoryPoints, livePlayer.Resources.Coins)
+			}
+			for _, hex := range []board.Hex{halflingsOneSpade, halflingsTwoSpades} {
+				liveHex, replayedHex := live.Map.GetHex(hex), replayed.Map.GetHex(hex)
+				if replayedHex.Terrain != models.TerrainPlains || (replayedHex.Building == nil) != (liveHex.Building == nil) {
+					t.Fatalf("replayed hex %v = %+v, live %+v", hex, replayedHex, liveHex)
+				}
+			}
+			if replayed.GetPlayer("Nomads").ShippingLevel != live.GetPlayer("Nomads").ShippingLevel {
+				t.Fatal("expected the next player's action to replay after the stronghold turn")
+			}
+		})
+	}
+}
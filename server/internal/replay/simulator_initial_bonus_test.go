@@ -32,7 +32,7 @@ func TestGameSimulator_ReportsMissingInitialBonusCardsBeforeRoundOneAction(t *te
 		},
 	}
 
-	initialState := createInitialState(items)
+	initialState := mustCreateInitialState(t, items)
 	sim := NewGameSimulator(initialState, items)
 
 	if err := sim.StepForward(); err != nil {
@@ -76,7 +76,7 @@ func TestGameSimulator_ReportsMissingDjinniStartingCultChoiceBeforeSetupAction(t
 		},
 	}
 
-	initialState := createInitialState(items)
+	initialState := mustCreateInitialState(t, items)
 	sim := NewGameSimulator(initialState, items)
 
 	if err := sim.StepForward(); err != nil {
@@ -0,0 +1,57 @@
+package replay
+
+import (
+	"testing"
+)
+
+func TestResumeGameSimulator_CompletesLikeAFullReplay(t *testing.T) {
+	items := loadSnellmanBatchFixture(t, "4pLeague_S69_D1L1_G3.txt")
+
+	full := NewGameSimulator(mustCreateInitialState(t, items), items)
+	if err := full.JumpTo(len(items)); err != nil {
+		t.Fatalf("full replay: %v", err)
+	}
+
+	for _, cut := range []int{len(items) / 4, len(items) / 2, 3 * len(items) / 4} {
+		first := NewGameSimulator(mustCreateInitialState(t, items), items)
+		if err := first.JumpTo(cut); err != nil {
+			t.Fatalf("replay up to %d: %v", cut, err)
+		}
+		raw, err := first.MarshalResumePoint()
+		if err != nil {
+			t.Fatalf("MarshalResumePoint at %d: %v", cut, err)
+		}
+
+		resumed, err := ResumeGameSimulator(items, raw)
+		if err != nil {
+			t.Fatalf("ResumeGameSimulator at %d: %v", cut, err)
+		}
+		if resumed.CurrentIndex != cut {
+			t.Fatalf("resumed at %d, want %d", resumed.CurrentIndex, cut)
+		}
+		if got, want := GenerateSnapshot(resumed.GetState()), GenerateSnapshot(first.GetState()); got != want {
+			t.Fatalf("resumed state at %d differs:\n%s\nwant\n%s", cut, got, want)
+		}
+		if err := resumed.JumpTo(len(items)); err != nil {
+			t.Fatalf("replay resumed at %d: %v", cut, err)
+		}
+		if got, want := GenerateSnapshot(resumed.GetState()), GenerateSnapshot(full.GetState()); got != want {
+			t.Fatalf("replay resumed at %d ends differently:\n%s\nwant\n%s", cut, got, want)
+		}
+	}
+}
+
+func TestResumeGameSimulator_RejectsAPointPastTheLog(t *testing.T) {
+	items := loadSnellmanBatchFixture(t, "4pLeague_S69_D1L1_G3.txt")
+	sim := NewGameSimulator(mustCreateInitialState(t, items), items)
+	if err := sim.JumpTo(len(items)); err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	raw, err := sim.MarshalResumePoint()
+	if err != nil {
+		t.Fatalf("MarshalResumePoint: %v", err)
+	}
+	if _, err := ResumeGameSimulator(items[:10], raw); err == nil {
+		t.Fatalf("expected a resume point past the end of the log to be refused")
+	}
+}
@@ -266,7 +266,7 @@ func ParseSnapshot(content string) (*game.GameState, error) {
 					count, _ := strconv.Atoi(strings.TrimSpace(parts[1]))
 					bonusType := game.BonusCardTypeFromString(bonusName)
 					if bonusType != game.BonusCardUnknown {
-						gs.BonusCards.Available[bonusType] = count
+						gs.BonusCards.MakeAvailable(bonusType, count)
 					}
 				}
 			case "Favors":
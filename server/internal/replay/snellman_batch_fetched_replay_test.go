@@ -0,0 +1,13 @@
+package replay
+
+import "testing"
+
+// Fixtures in snellman_fetched are added by cmd/snellman_fetch, so the game
+// count is not pinned.
+func TestSnellmanBatchReplayFetched_FinalScoresMatch(t *testing.T) {
+	fixtures := loadSnellmanBatch(t, "snellman_fetched")
+	if len(fixtures) == 0 {
+		t.Skip("no fetched snellman fixtures registered")
+	}
+	replaySnellmanBatch(t, fixtures, false)
+}
@@ -1,11 +1,9 @@
 package replay
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 	"testing"
 
@@ -13,90 +11,68 @@ import (
 	"github.com/lukev/tm_server/internal/notation"
 )
 
-type snellmanBatchManifest struct {
-	Games []snellmanBatchGame `json:"games"`
+func TestSnellmanBatchReplay_FinalScoresMatch(t *testing.T) {
+	fixtures := loadSnellmanBatch(t, "snellman_batch")
+	if len(fixtures) != 21 {
+		t.Fatalf("unexpected game count in testdata: got %d, want 21", len(fixtures))
+	}
+	replaySnellmanBatch(t, fixtures, true)
 }
 
-type snellmanBatchGame struct {
-	GameID          string         `json:"game_id"`
-	LogFile         string         `json:"log_file"`
-	ExpectedTotalVP map[string]int `json:"expected_total_vp"`
+// loadSnellmanBatch reads the golden fixture manifests of testdata/dir.
+func loadSnellmanBatch(t *testing.T, dir string) []GoldenFixture {
+	t.Helper()
+	fixtures, err := LoadGoldenFixtures(filepath.Join("testdata", dir))
+	if err != nil {
+		t.Fatalf("load fixtures: %v", err)
+	}
+	return fixtures
 }
 
-var nonAlnum = regexp.MustCompile(`[^a-z0-9]+`)
-
-func normalizePlayerKey(s string) string {
-	s = strings.ToLower(strings.TrimSpace(s))
-	return nonAlnum.ReplaceAllString(s, "")
-}
+// replaySnellmanBatch replays fixtures on a worker pool and reports every game
+// as a subtest. Fixtures whose manifest sets skip are reported as skipped.
+func replaySnellmanBatch(t *testing.T, fixtures []GoldenFixture, requirePhaseEnd bool) {
+	t.Helper()
 
-func TestSnellmanBatchReplay_FinalScoresMatch(t *testing.T) {
-	manifestPath := filepath.Join("testdata", "snellman_batch", "manifest.json")
-	manifestBytes, err := os.ReadFile(manifestPath)
-	if err != nil {
-		t.Fatalf("read manifest: %v", err)
+	batch := make([]BatchGame, 0, len(fixtures))
+	for _, fixture := range fixtures {
+		if fixture.Skip != "" {
+			continue
+		}
+		batchGame, err := fixture.BatchGame()
+		if err != nil {
+			t.Fatalf("read log fixture %s: %v", fixture.LogFile, err)
+		}
+		batch = append(batch, batchGame)
 	}
 
-	var manifest snellmanBatchManifest
-	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
-		t.Fatalf("parse manifest json: %v", err)
+	report := ValidateBatch(batch, t.TempDir(), 0)
+	results := make(map[string]BatchResult, len(report.Results))
+	for _, result := range report.Results {
+		results[result.GameID] = result
 	}
-	if len(manifest.Games) != 21 {
-		t.Fatalf("unexpected game count in manifest: got %d, want 21", len(manifest.Games))
-	}
-
-	manager := NewReplayManager(t.TempDir())
-
-	for _, tc := range manifest.Games {
-		tc := tc
-		t.Run(tc.GameID, func(t *testing.T) {
-			logPath := filepath.Join("testdata", "snellman_batch", tc.LogFile)
-			logBytes, err := os.ReadFile(logPath)
-			if err != nil {
-				t.Fatalf("read log fixture %s: %v", tc.LogFile, err)
-			}
-
-			if err := manager.ImportText(tc.GameID, string(logBytes), "snellman"); err != nil {
-				t.Fatalf("ImportText failed: %v", err)
-			}
-
-			session, err := manager.StartReplay(tc.GameID, true)
-			if err != nil {
-				t.Fatalf("StartReplay failed: %v", err)
-			}
-
-			totalActions := len(session.Simulator.Actions)
-			if err := manager.JumpTo(tc.GameID, totalActions); err != nil {
-				failingIndex := session.Simulator.CurrentIndex
-				token := describeFailingToken(session, failingIndex)
-				item := describeFailingItem(session, failingIndex)
-				t.Fatalf("JumpTo(%d) failed at index %d token %s item %s: %v", totalActions, failingIndex, token, item, err)
-			}
+	t.Logf("replayed %d games on %d workers in %s", len(report.Results), report.Workers, report.Elapsed)
 
-			state := session.Simulator.GetState()
-			if state == nil {
-				t.Fatalf("state is nil after replay")
+	for _, fixture := range fixtures {
+		fixture := fixture
+		t.Run(fixture.GameID, func(t *testing.T) {
+			if fixture.Skip != "" {
+				t.Skipf("skipping %s: %s", fixture.LogFile, fixture.Skip)
 			}
-			if state.Phase != game.PhaseEnd {
-				t.Fatalf("expected phase end (%v), got %v", game.PhaseEnd, state.Phase)
-			}
-			if state.FinalScoring == nil {
-				t.Fatalf("final scoring is nil")
-			}
-
-			for expectedPlayer, expectedTotal := range tc.ExpectedTotalVP {
-				var matched *game.PlayerFinalScore
-				for actualPlayer, score := range state.FinalScoring {
-					if normalizePlayerKey(actualPlayer) == normalizePlayerKey(expectedPlayer) {
-						matched = score
-						break
-					}
+			result := results[fixture.GameID]
+			if result.Err != nil {
+				if result.FailingIndex >= 0 {
+					session := result.Session
+					token := describeFailingToken(session, result.FailingIndex)
+					item := describeFailingItem(session, result.FailingIndex)
+					context := describeFailingContext(session, result.FailingIndex, 20)
+					t.Fatalf("%v\ntoken %s item %s\ncontext:\n%s", result.Err, token, item, context)
 				}
-				if matched == nil {
-					t.Fatalf("missing final scoring entry for %q", expectedPlayer)
-				}
-				if matched.TotalVP != expectedTotal {
-					t.Fatalf("%s final total VP mismatch: got %d, want %d (score=%+v)", expectedPlayer, matched.TotalVP, expectedTotal, *matched)
+				t.Fatal(result.Err)
+			}
+			if requirePhaseEnd {
+				if state := result.Session.Simulator.GetState(); state.Phase != game.PhaseEnd {
+					t.Fatalf("expected phase end (%v), got %v", game.PhaseEnd, state.Phase)
 				}
 			}
 		})
@@ -192,3 +168,40 @@ func describeFailingContext(session *ReplaySession, idx int, radius int) string
 	}
 	return strings.TrimRight(b.String(), "\n")
 }
+
+func TestValidateBatch_AggregatesResultsInInputOrder(t *testing.T) {
+	fixture := loadSnellmanBatch(t, "snellman_batch")[0]
+	logBytes, err := os.ReadFile(fixture.LogPath())
+	if err != nil {
+		t.Fatalf("read log fixture %s: %v", fixture.LogFile, err)
+	}
+
+	wrongVP := make(map[string]int, len(fixture.FinalVP))
+	for player, vp := range fixture.FinalVP {
+		wrongVP[player] = vp + 1
+	}
+	games := []BatchGame{
+		{GameID: "good", Log: string(logBytes), Format: "snellman", ExpectedTotalVP: fixture.FinalVP},
+		{GameID: "empty", Log: "", Format: "snellman"},
+		{GameID: "wrong-score", Log: string(logBytes), Format: "snellman", ExpectedTotalVP: wrongVP},
+	}
+
+	report := ValidateBatch(games, t.TempDir(), 2)
+	if report.Passed != 1 || report.Failed != 2 {
+		t.Fatalf("expected 1 passed and 2 failed, got %d passed %d failed", report.Passed, report.Failed)
+	}
+	for i, want := range []string{"good", "empty", "wrong-score"} {
+		if report.Results[i].GameID != want {
+			t.Fatalf("result %d: got game %s, want %s", i, report.Results[i].GameID, want)
+		}
+	}
+	if report.Results[1].Session != nil {
+		t.Fatalf("expected no session for a log that fails to import")
+	}
+	if !strings.Contains(report.Results[2].Err.Error(), "final total VP mismatch") {
+		t.Fatalf("expected a score mismatch, got %v", report.Results[2].Err)
+	}
+	if failures := report.Failures(); len(failures) != 2 || failures[0].GameID != "empty" {
+		t.Fatalf("unexpected failures: %+v", failures)
+	}
+}
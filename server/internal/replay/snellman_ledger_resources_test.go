@@ -296,7 +296,7 @@ func runSnellmanLedgerResourcesMatchFromDir(t *testing.T, fixtureDir string, fix
 		t.Fatalf("ParseConciseLogStrict: %v", err)
 	}
 
-	sim := NewGameSimulator(createInitialState(items), items)
+	sim := NewGameSimulator(mustCreateInitialState(t, items), items)
 
 	expIdx := 0
 	consumed := make([]bool, len(expected))
@@ -16,8 +16,9 @@ type GameValidator struct {
 	LogEntries                       []*LogEntry
 	CurrentEntry                     int
 	Errors                           []ValidationError
-	IncomeApplied                    bool           // Track if income has been applied for current round
-	AlchemistsSpadesWithPowerGranted map[string]int // Track cult spades that had power granted during cult income
+	IncomeApplied                    bool                         // Track if income has been applied for current round
+	IncomeReports                    map[string]game.IncomeReport // Income granted this round, by player
+	AlchemistsSpadesWithPowerGranted map[string]int               // Track cult spades that had power granted during cult income
 }
 
 // ValidationError represents a validation error
@@ -28,6 +29,8 @@ type ValidationError struct {
 	Expected interface{}
 	Actual   interface{}
 	Message  string
+	// ReasonCode is set when the engine rejected the logged action.
+	ReasonCode game.ReasonCode
 }
 
 // NewGameValidator creates a new validator
@@ -190,7 +193,10 @@ func (v *GameValidator) handleIncome(entry *LogEntry) error {
 	if entry.Action == "other_income_for_faction" || strings.HasSuffix(entry.Action, ". other_income_for_faction") {
 		// Apply income once for all players when we see the first income entry
 		if !v.IncomeApplied {
-			v.GameState.GrantIncome()
+			v.IncomeReports = make(map[string]game.IncomeReport)
+			for _, report := range v.GameState.GrantIncome() {
+				v.IncomeReports[report.PlayerID] = report
+			}
 			v.IncomeApplied = true
 			if !v.GameState.HasPendingIncomeDecisions() {
 				v.GameState.StartActionPhase() // Transition to action phase
@@ -198,6 +204,9 @@ func (v *GameValidator) handleIncome(entry *LogEntry) error {
 		}
 
 		// If there's an action before the marker, process it first
+		if entry.Action == "other_income_for_faction" {
+			v.compareIncome(entry)
+		}
 		if strings.HasSuffix(entry.Action, ". other_income_for_faction") {
 			actualAction := strings.TrimSuffix(entry.Action, ". other_income_for_faction")
 			// Create a temporary entry with just the action
@@ -225,6 +234,35 @@ func (v *GameValidator) handleIncome(entry *LogEntry) error {
 	return nil
 }
 
+// compareIncome checks the coins, workers and priests of a Snellman income row
+// against the income the engine granted the row's player, naming the sources
+// on a mismatch. Power is logged as bowls, so it is left to ValidateState.
+func (v *GameValidator) compareIncome(entry *LogEntry) {
+	report, ok := v.IncomeReports[entry.GetPlayerID()]
+	if !ok {
+		return
+	}
+	sources := make([]string, 0, len(report.Contributions))
+	for _, contribution := range report.Contributions {
+		sources = append(sources, string(contribution.Source))
+	}
+	for _, field := range []struct {
+		name     string
+		expected int
+		actual   int
+	}{
+		{"IncomeCoins", entry.CoinsDelta, report.Applied.Coins},
+		{"IncomeWorkers", entry.WorkersDelta, report.Applied.Workers},
+		{"IncomePriests", entry.PriestsDelta, report.Applied.Priests},
+	} {
+		if field.expected != field.actual {
+			v.addError(v.CurrentEntry, entry, field.name, field.expected, field.actual,
+				fmt.Sprintf("%s mismatch: expected %d, got %d from %s",
+					field.name, field.expected, field.actual, strings.Join(sources, ", ")))
+		}
+	}
+}
+
 // handleAlchemistsCultIncome handles special Alchemists power grant during cult income
 func (v *GameValidator) handleAlchemistsCultIncome(entry *LogEntry) {
 	player := v.GameState.GetPlayer(entry.GetPlayerID())
@@ -240,7 +278,7 @@ func (v *GameValidator) handleAlchemistsCultIncome(entry *LogEntry) {
 					if pendingSpades, hasPending := v.GameState.PendingCultRewardSpades[entry.GetPlayerID()]; hasPending && pendingSpades > 0 {
 						// Grant power for all pending cult reward spades
 						totalPower := pendingSpades * powerPerSpade
-						player.Resources.Power.GainPower(totalPower)
+						v.GameState.GainPowerForReason(entry.GetPlayerID(), totalPower, game.PowerGainSpades, "")
 
 						// Track that these spades have had power granted
 						v.AlchemistsSpadesWithPowerGranted[entry.GetPlayerID()] = pendingSpades
@@ -430,8 +468,18 @@ func (v *GameValidator) ReplayGame() error {
 	// Process all entries
 	for v.CurrentEntry < len(v.LogEntries) {
 		if err := v.ValidateNextEntry(); err != nil {
+			if v.GameState != nil {
+				err = v.GameState.Map.LabelHexError(err)
+			}
 			// Don't fail immediately, collect error and continue
 			fmt.Printf("Error at entry %d: %v\n", v.CurrentEntry, err)
+			v.Errors = append(v.Errors, ValidationError{
+				Line:       v.CurrentEntry,
+				Entry:      v.LogEntries[v.CurrentEntry-1],
+				Field:      "action",
+				Message:    err.Error(),
+				ReasonCode: game.ReasonCodeOf(err),
+			})
 			// For now, stop on first error to make debugging easier
 			return err
 		}
@@ -928,6 +976,10 @@ func (v *GameValidator) GetErrorSummary() string {
 			summary += fmt.Sprintf("... and %d more errors\n", len(v.Errors)-10)
 			break
 		}
+		if err.ReasonCode != "" {
+			summary += fmt.Sprintf("  Line %d: [%s] %s\n", err.Line, err.ReasonCode, err.Message)
+			continue
+		}
 		summary += fmt.Sprintf("  Line %d: %s\n", err.Line, err.Message)
 	}
 	return summary
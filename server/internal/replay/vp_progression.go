@@ -0,0 +1,41 @@
+package replay
+
+import (
+	"fmt"
+
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/notation"
+)
+
+// RecordVPProgression replays session from the start up to its current
+// position and returns the score history for progression charts.
+func RecordVPProgression(session *ReplaySession) (*game.VPProgression, error) {
+	target := session.Simulator.CurrentIndex
+	initialState, err := createInitialState(session.Simulator.Actions)
+	if err != nil {
+		return nil, err
+	}
+	sim := NewGameSimulator(initialState, session.Simulator.Actions)
+	recorder := game.NewVPProgressionRecorder(sim.GetState())
+	for index := 1; index <= target; index++ {
+		if err := sim.JumpTo(index); err != nil {
+			return recorder.Progression(), fmt.Errorf("item %d (%s): %w", index-1, session.logLine(index-1), err)
+		}
+		recorder.Observe(sim.GetState(), vpSourceForItem(sim.Actions[index-1]))
+	}
+	return recorder.Progression(), nil
+}
+
+func vpSourceForItem(item notation.LogItem) game.VPSource {
+	actionItem, ok := item.(notation.ActionItem)
+	if !ok || actionItem.Action == nil {
+		return game.VPSourceRoundEnd
+	}
+	switch actionItem.Action.(type) {
+	case *notation.LogTownAction:
+		return game.VPSourceTowns
+	case *notation.LogPreIncomeAction, *notation.LogPostIncomeAction:
+		return game.VPSourceRoundEnd
+	}
+	return game.VPSourceForAction(actionItem.Action)
+}
@@ -0,0 +1,45 @@
+package replay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecordVPProgression_AddsUpToReplayedScores(t *testing.T) {
+	manager := NewReplayManager(t.TempDir())
+	manager.SetSourceAnchoredLeechOrdering(true)
+	content, err := os.ReadFile(filepath.Join("testdata", "snellman_batch", "4pLeague_S69_D1L1_G6.txt"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	if err := manager.ImportText("progression", string(content), "snellman"); err != nil {
+		t.Fatalf("ImportText: %v", err)
+	}
+	session := manager.GetSession("progression")
+	if err := manager.JumpTo("progression", len(session.Simulator.Actions)); err != nil {
+		t.Fatalf("JumpTo: %v", err)
+	}
+
+	progression, err := RecordVPProgression(session)
+	if err != nil {
+		t.Fatalf("RecordVPProgression: %v", err)
+	}
+	if len(progression.Rounds) != 6 {
+		t.Fatalf("expected six rounds, got %d", len(progression.Rounds))
+	}
+	state := session.Simulator.GetState()
+	for playerID, player := range state.Players {
+		total := 0
+		for _, vp := range progression.Sources[playerID] {
+			total += vp
+		}
+		if total != player.VictoryPoints || progression.Final[playerID] != player.VictoryPoints {
+			t.Fatalf("%s: sources add up to %d and final is %d, want %d",
+				playerID, total, progression.Final[playerID], player.VictoryPoints)
+		}
+		if round6 := progression.Rounds[5].VP[playerID]; round6 > player.VictoryPoints {
+			t.Fatalf("%s: round 6 shows %d VP, more than the final %d", playerID, round6, player.VictoryPoints)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Package testharness provides reusable helpers for multi-player websocket
+// integration tests: connecting seats, creating/starting games, performing
+// actions and waiting for the resulting state, and resolving pending decisions.
+//
+// The harness only speaks the websocket protocol, so it can be used against any
+// server handler without importing the websocket package itself.
+package testharness
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gws "github.com/gorilla/websocket"
+)
+
+// DefaultTimeout is the read timeout used when waiting for server messages.
+const DefaultTimeout = 4 * time.Second
+
+// Session tracks one multiplayer game driven over websocket connections.
+type Session struct {
+	T       testing.TB
+	GameID  string
+	Clients map[string]*gws.Conn
+	// State is the most recent game_state_update payload.
+	State map[string]any
+	// Timeout bounds every wait for a server message.
+	Timeout time.Duration
+	// ActionIDPrefix is prepended to generated action IDs.
+	ActionIDPrefix string
+
+	step int
+}
+
+// StartServer serves handler over an httptest server and returns it with the
+// websocket URL clients should dial.
+func StartServer(t testing.TB, handler http.Handler) (*httptest.Server, string) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	return server, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// Dial opens a websocket connection to wsURL.
+func Dial(t testing.TB, wsURL string) *gws.Conn {
+	t.Helper()
+	conn, _, err := gws.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	return conn
+}
+
+// ConnectPlayers opens one websocket connection per player ID.
+func ConnectPlayers(t testing.TB, wsURL string, playerIDs []string) map[string]*gws.Conn {
+	t.Helper()
+	clients := make(map[string]*gws.Conn, len(playerIDs))
+	for _, playerID := range playerIDs {
+		clients[playerID] = Dial(t, wsURL)
+	}
+	return clients
+}
+
+// CloseConnections closes every non-nil connection.
+func CloseConnections(clients map[string]*gws.Conn) {
+	for _, conn := range clients {
+		if conn != nil {
+			_ = conn.Close()
+		}
+	}
+}
+
+// GameOptions controls how CreateAndStartGame sets up the game.
+type GameOptions struct {
+	Name string
+	// StartPayload holds extra start_game payload fields (setupMode, mapId, ...).
+	StartPayload map[string]any
+}
+
+// CreateAndStartGame creates a game as playerIDs[0], joins the remaining seats,
+// starts the game, and returns a Session holding the initial state.
+func CreateAndStartGame(t testing.TB, clients map[string]*gws.Conn, playerIDs []string, opts GameOptions) *Session {
+	t.Helper()
+	if len(playerIDs) < 2 {
+		t.Fatalf("setup requires at least 2 players")
+	}
+	name := opts.Name
+	if name == "" {
+		name = "harness"
+	}
+
+	creatorID := playerIDs[0]
+	SendJSON(t, clients[creatorID], map[string]any{
+		"type": "create_game",
+		"payload": map[string]any{
+			"name":       name,
+			"maxPlayers": len(playerIDs),
+			"creator":    creatorID,
+		},
+	})
+	created := ReadUntilType(t, clients[creatorID], "game_created", DefaultTimeout)
+	gameID := AsString(AsMap(created["payload"])["gameId"])
+	if gameID == "" {
+		t.Fatalf("missing game id in game_created payload")
+	}
+	_ = ReadUntilType(t, clients[creatorID], "lobby_state", DefaultTimeout)
+
+	for _, playerID := range playerIDs[1:] {
+		SendJSON(t, clients[playerID], map[string]any{
+			"type": "join_game",
+			"payload": map[string]any{
+				"id":   gameID,
+				"name": playerID,
+			},
+		})
+		_ = ReadUntilType(t, clients[playerID], "game_joined", DefaultTimeout)
+	}
+
+	startPayload := map[string]any{
+		"gameID":             gameID,
+		"randomizeTurnOrder": false,
+	}
+	for key, value := range opts.StartPayload {
+		startPayload[key] = value
+	}
+	SendJSON(t, clients[creatorID], map[string]any{
+		"type":    "start_game",
+		"payload": startPayload,
+	})
+
+	state := AsMap(ReadUntilType(t, clients[creatorID], "game_state_update", DefaultTimeout)["payload"])
+	return &Session{
+		T:       t,
+		GameID:  gameID,
+		Clients: clients,
+		State:   state,
+		Timeout: DefaultTimeout,
+	}
+}
+
+// Refresh requests the current game state through playerID's connection.
+func (s *Session) Refresh(playerID string) map[string]any {
+	s.T.Helper()
+	SendJSON(s.T, s.Clients[playerID], map[string]any{
+		"type": "get_game_state",
+		"payload": map[string]any{
+			"gameID":   s.GameID,
+			"playerID": playerID,
+		},
+	})
+	s.State = AsMap(ReadUntilType(s.T, s.Clients[playerID], "game_state_update", s.timeout())["payload"])
+	return s.State
+}
+
+// Perform sends a perform_action for playerID at the current revision and waits
+// for the next state. A rejected action is returned as an error.
+func (s *Session) Perform(playerID, actionType string, params map[string]any) error {
+	s.T.Helper()
+	if params == nil {
+		params = map[string]any{}
+	}
+	conn := s.Clients[playerID]
+	if conn == nil {
+		return fmt.Errorf("missing websocket client for player %s", playerID)
+	}
+
+	expectedRevision := AsInt(s.State["revision"])
+	actionID := fmt.Sprintf("%s%04d-%s-%s", s.ActionIDPrefix, s.step, playerID, actionType)
+	s.step++
+	SendJSON(s.T, conn, map[string]any{
+		"type": "perform_action",
+		"payload": map[string]any{
+			"type":             actionType,
+			"gameID":           s.GameID,
+			"actionId":         actionID,
+			"expectedRevision": expectedRevision,
+			"params":           params,
+		},
+	})
+
+	msg, err := readUntilAnyType(conn, []string{"action_accepted", "action_rejected"}, s.timeout())
+	if err != nil {
+		return err
+	}
+	if AsString(msg["type"]) == "action_rejected" {
+		payload := AsMap(msg["payload"])
+		return &RejectedError{Code: AsString(payload["error"]), Message: AsString(payload["message"])}
+	}
+	s.State = ReadUntilStateRevision(s.T, conn, expectedRevision+1, s.timeout())
+	return nil
+}
+
+// PerformAndAwait performs an action and fails the test if it is rejected.
+// It returns the resulting state.
+func (s *Session) PerformAndAwait(playerID, actionType string, params map[string]any) map[string]any {
+	s.T.Helper()
+	if err := s.Perform(playerID, actionType, params); err != nil {
+		s.T.Fatalf("%s by %s failed: %v", actionType, playerID, err)
+	}
+	return s.State
+}
+
+// ExpectReject performs an action and fails the test unless it is rejected.
+// It returns the rejection error code.
+func (s *Session) ExpectReject(playerID, actionType string, params map[string]any) string {
+	s.T.Helper()
+	err := s.Perform(playerID, actionType, params)
+	rejected, ok := err.(*RejectedError)
+	if !ok {
+		s.T.Fatalf("expected %s by %s to be rejected, got %v", actionType, playerID, err)
+	}
+	if rejected.Code == "" {
+		s.T.Fatalf("expected rejection error code for %s by %s", actionType, playerID)
+	}
+	return rejected.Code
+}
+
+// CurrentTurnPlayerID returns the player whose turn it is in the latest state.
+func (s *Session) CurrentTurnPlayerID() string {
+	return CurrentTurnPlayerID(s.State)
+}
+
+// Close closes every client connection.
+func (s *Session) Close() {
+	CloseConnections(s.Clients)
+}
+
+func (s *Session) timeout() time.Duration {
+	if s.Timeout <= 0 {
+		return DefaultTimeout
+	}
+	return s.Timeout
+}
+
+// RejectedError reports an action_rejected response.
+type RejectedError struct {
+	Code    string
+	Message string
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("action rejected (%s): %s", e.Code, e.Message)
+}
@@ -0,0 +1,194 @@
+package testharness
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	gws "github.com/gorilla/websocket"
+	"github.com/lukev/tm_server/internal/protocol"
+)
+
+// SendJSON writes payload as a JSON websocket message.
+func SendJSON(t testing.TB, conn *gws.Conn, payload map[string]any) {
+	t.Helper()
+	if err := conn.SetWriteDeadline(time.Now().Add(2 * time.Second)); err != nil {
+		t.Fatalf("set write deadline failed: %v", err)
+	}
+	if err := conn.WriteJSON(payload); err != nil {
+		t.Fatalf("write json failed: %v", err)
+	}
+}
+
+// readMessage reads the next message from conn and checks it against the
+// protocol schema of its type, so every message an integration test reads is
+// also a contract test of the published schemas.
+func readMessage(conn *gws.Conn) (map[string]any, error) {
+	_, raw, err := conn.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+	var msg map[string]any
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return nil, err
+	}
+	if err := protocol.ValidateMessage(raw); err != nil {
+		return msg, &ContractError{Err: err}
+	}
+	return msg, nil
+}
+
+// ContractError is a server message that does not match its protocol schema.
+type ContractError struct {
+	Err error
+}
+
+func (e *ContractError) Error() string {
+	return "protocol contract violated: " + e.Err.Error()
+}
+
+// ReadUntilType reads messages until one of type want arrives. Unexpected
+// action_rejected or error messages fail the test.
+func ReadUntilType(t testing.TB, conn *gws.Conn, want string, timeout time.Duration) map[string]any {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		if err := conn.SetReadDeadline(deadline); err != nil {
+			t.Fatalf("set read deadline failed: %v", err)
+		}
+		msg, err := readMessage(conn)
+		if err != nil {
+			t.Fatalf("read json failed while waiting for %s: %v", want, err)
+		}
+		if AsString(msg["type"]) == "action_rejected" && want != "action_rejected" {
+			t.Fatalf("unexpected action_rejected while waiting for %s: %v", want, msg["payload"])
+		}
+		if AsString(msg["type"]) == "error" && want != "error" {
+			t.Fatalf("unexpected error while waiting for %s: %v", want, msg["payload"])
+		}
+		if AsString(msg["type"]) == want {
+			return msg
+		}
+	}
+}
+
+// ReadUntilStateRevision reads messages until a game_state_update with exactly
+// the given revision arrives. Skipping past the revision fails the test.
+func ReadUntilStateRevision(t testing.TB, conn *gws.Conn, revision int, timeout time.Duration) map[string]any {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		if err := conn.SetReadDeadline(deadline); err != nil {
+			t.Fatalf("set read deadline failed: %v", err)
+		}
+		msg, err := readMessage(conn)
+		if err != nil {
+			t.Fatalf("read json failed while waiting for state revision %d: %v", revision, err)
+		}
+
+		msgType := AsString(msg["type"])
+		if msgType == "action_rejected" {
+			t.Fatalf("unexpected action_rejected while waiting for state revision %d: %v", revision, msg["payload"])
+		}
+		if msgType == "error" {
+			t.Fatalf("unexpected error while waiting for state revision %d: %v", revision, msg["payload"])
+		}
+		if msgType != "game_state_update" {
+			continue
+		}
+
+		payload := AsMap(msg["payload"])
+		got := AsInt(payload["revision"])
+		if got == revision {
+			return payload
+		}
+		if got > revision {
+			t.Fatalf("unexpected websocket state revision while waiting for revision %d: got %d", revision, got)
+		}
+	}
+}
+
+func readUntilAnyType(conn *gws.Conn, wants []string, timeout time.Duration) (map[string]any, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		if err := conn.SetReadDeadline(deadline); err != nil {
+			return nil, fmt.Errorf("set read deadline failed: %w", err)
+		}
+		msg, err := readMessage(conn)
+		if err != nil {
+			return nil, fmt.Errorf("read json failed while waiting for %v: %w", wants, err)
+		}
+		msgType := AsString(msg["type"])
+		if msgType == "error" {
+			return nil, fmt.Errorf("unexpected error while waiting for %v: %v", wants, msg["payload"])
+		}
+		for _, want := range wants {
+			if msgType == want {
+				return msg, nil
+			}
+		}
+	}
+}
+
+// CurrentTurnPlayerID returns turnOrder[currentTurn] from a state payload.
+func CurrentTurnPlayerID(state map[string]any) string {
+	turnOrder, _ := state["turnOrder"].([]any)
+	currentTurn := AsInt(state["currentTurn"])
+	if currentTurn < 0 || currentTurn >= len(turnOrder) {
+		return ""
+	}
+	return AsString(turnOrder[currentTurn])
+}
+
+// AsMap converts a decoded JSON value to an object, returning an empty map otherwise.
+func AsMap(v any) map[string]any {
+	if v == nil {
+		return map[string]any{}
+	}
+	if m, ok := v.(map[string]any); ok {
+		return m
+	}
+	// handle json.RawMessage-like values passed through interface
+	if raw, ok := v.([]byte); ok {
+		out := map[string]any{}
+		_ = json.Unmarshal(raw, &out)
+		return out
+	}
+	return map[string]any{}
+}
+
+// AsString converts a decoded JSON value to a string, returning "" otherwise.
+func AsString(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return ""
+}
+
+// AsInt converts a decoded JSON number to an int, returning 0 otherwise.
+func AsInt(v any) int {
+	switch x := v.(type) {
+	case int:
+		return x
+	case int32:
+		return int(x)
+	case int64:
+		return int(x)
+	case float64:
+		return int(x)
+	case json.Number:
+		n, _ := x.Int64()
+		return int(n)
+	default:
+		return 0
+	}
+}
+
+// AsBool converts a decoded JSON value to a bool, returning false otherwise.
+func AsBool(v any) bool {
+	if b, ok := v.(bool); ok {
+		return b
+	}
+	return false
+}
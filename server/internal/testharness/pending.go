@@ -0,0 +1,103 @@
+package testharness
+
+import "fmt"
+
+// PendingDecision is the pendingDecision object from a state payload.
+type PendingDecision struct {
+	Type     string
+	PlayerID string
+	Raw      map[string]any
+}
+
+// Pending returns the current pending decision, or ok=false when none is set.
+func (s *Session) Pending() (PendingDecision, bool) {
+	raw := AsMap(s.State["pendingDecision"])
+	decisionType := AsString(raw["type"])
+	if decisionType == "" {
+		return PendingDecision{}, false
+	}
+	return PendingDecision{
+		Type:     decisionType,
+		PlayerID: AsString(raw["playerId"]),
+		Raw:      raw,
+	}, true
+}
+
+// PendingResolver resolves a single pending decision. It returns handled=false
+// when it does not apply to the decision so the next resolver can try.
+type PendingResolver func(s *Session, decision PendingDecision) (handled bool, err error)
+
+// maxPendingResolutions guards against resolvers that never clear a decision.
+const maxPendingResolutions = 32
+
+// ResolvePending applies resolvers until no pending decision remains or none of
+// the resolvers handles the current decision. It returns the unhandled
+// decision, if any.
+func (s *Session) ResolvePending(resolvers ...PendingResolver) (*PendingDecision, error) {
+	for guard := 0; guard < maxPendingResolutions; guard++ {
+		decision, ok := s.Pending()
+		if !ok {
+			return nil, nil
+		}
+		handled := false
+		for _, resolve := range resolvers {
+			var err error
+			handled, err = resolve(s, decision)
+			if err != nil {
+				return &decision, err
+			}
+			if handled {
+				break
+			}
+		}
+		if !handled {
+			return &decision, nil
+		}
+	}
+	return nil, fmt.Errorf("pending decision resolution exceeded iteration guard")
+}
+
+// MustResolvePending is ResolvePending that fails the test on errors.
+func (s *Session) MustResolvePending(resolvers ...PendingResolver) *PendingDecision {
+	s.T.Helper()
+	decision, err := s.ResolvePending(resolvers...)
+	if err != nil {
+		s.T.Fatalf("resolve pending decision: %v", err)
+	}
+	return decision
+}
+
+// ConfirmTurns confirms post-action free-action windows and turn confirmations.
+func ConfirmTurns(s *Session, decision PendingDecision) (bool, error) {
+	switch decision.Type {
+	case "post_action_free_actions", "turn_confirmation":
+		if decision.PlayerID == "" {
+			return false, nil
+		}
+		return true, s.Perform(decision.PlayerID, "confirm_turn", nil)
+	default:
+		return false, nil
+	}
+}
+
+// DeclineLeeches declines the first outstanding leech offer.
+func DeclineLeeches(s *Session, decision PendingDecision) (bool, error) {
+	if decision.Type != "leech_offer" {
+		return false, nil
+	}
+	return true, s.Perform(decision.PlayerID, "decline_leech", map[string]any{"offerIndex": 0})
+}
+
+// AcceptLeeches accepts the first outstanding leech offer in full.
+func AcceptLeeches(s *Session, decision PendingDecision) (bool, error) {
+	if decision.Type != "leech_offer" {
+		return false, nil
+	}
+	return true, s.Perform(decision.PlayerID, "accept_leech", map[string]any{"offerIndex": 0})
+}
+
+// DefaultResolvers confirms turns and declines all leech offers, which is
+// enough to keep most scripted integration games moving.
+func DefaultResolvers() []PendingResolver {
+	return []PendingResolver{ConfirmTurns, DeclineLeeches}
+}
@@ -0,0 +1,236 @@
+// Package webhooks posts game events to URLs registered by server operators,
+// so communities can be told about turns in a Discord or Slack channel
+// without polling the server.
+//
+// A webhook is either server-wide or limited to one game, and may subscribe
+// to some event types only. Each delivery is one POST of a compact JSON body:
+// the game.GameEvent fields plus a one-line summary in "content" (read by
+// Discord) and "text" (read by Slack).
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lukev/tm_server/internal/game"
+)
+
+// queueSize bounds the deliveries waiting to be sent; events beyond it are
+// dropped rather than slowing the games down.
+const queueSize = 256
+
+// deliveryTimeout bounds each POST.
+const deliveryTimeout = 10 * time.Second
+
+// Webhook is a registered URL and the events it receives.
+type Webhook struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// GameID limits the webhook to one game; empty means every game.
+	GameID string `json:"gameId,omitempty"`
+	// Events limits the webhook to these event types; empty means all.
+	Events    []game.GameEventType `json:"events,omitempty"`
+	CreatedAt time.Time            `json:"createdAt"`
+}
+
+func (w Webhook) wants(event game.GameEvent) bool {
+	if w.GameID != "" && w.GameID != event.GameID {
+		return false
+	}
+	return len(w.Events) == 0 || slices.Contains(w.Events, event.Type)
+}
+
+// Payload is the body posted for an event.
+type Payload struct {
+	game.GameEvent
+	// Content and Text hold the same one-line summary, under the keys Discord
+	// and Slack incoming webhooks read.
+	Content string `json:"content"`
+	Text    string `json:"text"`
+}
+
+type delivery struct {
+	url     string
+	payload Payload
+}
+
+// Dispatcher holds the registered webhooks and delivers events to them in
+// the order they happened.
+type Dispatcher struct {
+	mu     sync.Mutex
+	hooks  map[string]Webhook
+	nextID int
+	client *http.Client
+	now    func() time.Time
+	queue  chan delivery
+}
+
+// NewDispatcher creates a dispatcher posting with client, or a client with a
+// short timeout when nil. Call Run to start delivering.
+func NewDispatcher(client *http.Client) *Dispatcher {
+	if client == nil {
+		client = &http.Client{Timeout: deliveryTimeout}
+	}
+	return &Dispatcher{
+		hooks:  make(map[string]Webhook),
+		client: client,
+		now:    time.Now,
+		queue:  make(chan delivery, queueSize),
+	}
+}
+
+// Register validates hook and adds it with a new ID, returning the stored
+// webhook.
+func (d *Dispatcher) Register(hook Webhook) (Webhook, error) {
+	hook.URL = strings.TrimSpace(hook.URL)
+	hook.GameID = strings.TrimSpace(hook.GameID)
+	parsed, err := url.Parse(hook.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return Webhook{}, fmt.Errorf("invalid webhook url %q: must be an absolute http(s) URL", hook.URL)
+	}
+	for _, typ := range hook.Events {
+		if !slices.Contains(game.GameEventTypes, typ) {
+			return Webhook{}, fmt.Errorf("unknown event type %q", typ)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.nextID++
+	hook.ID = "wh" + strconv.Itoa(d.nextID)
+	hook.CreatedAt = d.now()
+	d.hooks[hook.ID] = hook
+	return hook, nil
+}
+
+// Remove deletes the webhook with id, reporting whether it existed.
+func (d *Dispatcher) Remove(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.hooks[id]; !ok {
+		return false
+	}
+	delete(d.hooks, id)
+	return true
+}
+
+// List returns the registered webhooks in registration order. A non-empty
+// gameID keeps only the webhooks of that game.
+func (d *Dispatcher) List(gameID string) []Webhook {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make([]Webhook, 0, len(d.hooks))
+	for _, hook := range d.hooks {
+		if gameID == "" || hook.GameID == gameID {
+			out = append(out, hook)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return idNumber(out[i].ID) < idNumber(out[j].ID) })
+	return out
+}
+
+// Notify queues event for every webhook that wants it. It never blocks, so
+// it can serve as the game manager's event listener.
+func (d *Dispatcher) Notify(event game.GameEvent) {
+	d.mu.Lock()
+	var urls []string
+	for _, hook := range d.hooks {
+		if hook.wants(event) {
+			urls = append(urls, hook.URL)
+		}
+	}
+	d.mu.Unlock()
+	if len(urls) == 0 {
+		return
+	}
+
+	sort.Strings(urls)
+	summary := Summary(event)
+	payload := Payload{GameEvent: event, Content: summary, Text: summary}
+	for _, u := range urls {
+		select {
+		case d.queue <- delivery{url: u, payload: payload}:
+		default:
+			log.Printf("webhooks: queue full, dropping %s event of game %s", event.Type, event.GameID)
+		}
+	}
+}
+
+// Run delivers queued events until Close is called.
+func (d *Dispatcher) Run() {
+	for item := range d.queue {
+		if err := d.post(item); err != nil {
+			log.Printf("webhooks: %s event of game %s: %v", item.payload.Type, item.payload.GameID, err)
+		}
+	}
+}
+
+// Close stops Run once the queued events are delivered.
+func (d *Dispatcher) Close() {
+	close(d.queue)
+}
+
+func (d *Dispatcher) post(item delivery) error {
+	body, err := json.Marshal(item.payload)
+	if err != nil {
+		return err
+	}
+	resp, err := d.client.Post(item.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s answered %s", item.url, resp.Status)
+	}
+	return nil
+}
+
+// Summary is the one-line description of event posted with it.
+func Summary(event game.GameEvent) string {
+	player := event.PlayerID
+	if event.Faction != "" {
+		player = fmt.Sprintf("%s (%s)", event.PlayerID, event.Faction)
+	}
+	switch event.Type {
+	case game.GameEventTurnChanged:
+		return fmt.Sprintf("%s: it is %s's turn (round %d)", event.GameID, player, event.Round)
+	case game.GameEventPassed:
+		return fmt.Sprintf("%s: %s passed (round %d)", event.GameID, player, event.Round)
+	case game.GameEventTownFounded:
+		return fmt.Sprintf("%s: %s founded a town (round %d)", event.GameID, player, event.Round)
+	case game.GameEventGameEnded:
+		ids := make([]string, 0, len(event.Scores))
+		for id := range event.Scores {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			if event.Scores[ids[i]] != event.Scores[ids[j]] {
+				return event.Scores[ids[i]] > event.Scores[ids[j]]
+			}
+			return ids[i] < ids[j]
+		})
+		scores := make([]string, len(ids))
+		for i, id := range ids {
+			scores[i] = fmt.Sprintf("%s %d", id, event.Scores[id])
+		}
+		return fmt.Sprintf("%s: game over: %s", event.GameID, strings.Join(scores, ", "))
+	default:
+		return fmt.Sprintf("%s: %s", event.GameID, event.Type)
+	}
+}
+
+func idNumber(id string) int {
+	n, _ := strconv.Atoi(strings.TrimPrefix(id, "wh"))
+	return n
+}
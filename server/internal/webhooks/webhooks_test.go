@@ -0,0 +1,108 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lukev/tm_server/internal/game"
+)
+
+func receiver(t *testing.T) (*httptest.Server, <-chan map[string]any) {
+	t.Helper()
+	received := make(chan map[string]any, 16)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode webhook body: %v", err)
+		}
+		received <- body
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+func next(t *testing.T, received <-chan map[string]any) map[string]any {
+	t.Helper()
+	select {
+	case body := <-received:
+		return body
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a webhook delivery")
+		return nil
+	}
+}
+
+func TestDispatcherDeliversMatchingEvents(t *testing.T) {
+	all, allReceived := receiver(t)
+	g1, g1Received := receiver(t)
+
+	d := NewDispatcher(nil)
+	go d.Run()
+	t.Cleanup(d.Close)
+	if _, err := d.Register(Webhook{URL: all.URL}); err != nil {
+		t.Fatalf("register server-wide webhook: %v", err)
+	}
+	if _, err := d.Register(Webhook{URL: g1.URL, GameID: "g1", Events: []game.GameEventType{game.GameEventGameEnded}}); err != nil {
+		t.Fatalf("register game webhook: %v", err)
+	}
+
+	d.Notify(game.GameEvent{Type: game.GameEventTurnChanged, GameID: "g1", Revision: 3, Round: 2, PlayerID: "alice", Faction: "Witches"})
+	d.Notify(game.GameEvent{Type: game.GameEventGameEnded, GameID: "g2", Scores: map[string]int{"a": 90}})
+	d.Notify(game.GameEvent{Type: game.GameEventGameEnded, GameID: "g1", Scores: map[string]int{"alice": 120, "bob": 131}})
+
+	turn := next(t, allReceived)
+	if turn["type"] != "turn_changed" || turn["gameId"] != "g1" || turn["playerId"] != "alice" || turn["revision"] != float64(3) {
+		t.Fatalf("unexpected turn payload %v", turn)
+	}
+	if turn["content"] != "g1: it is alice (Witches)'s turn (round 2)" || turn["text"] != turn["content"] {
+		t.Fatalf("unexpected summary %q / %q", turn["content"], turn["text"])
+	}
+	if got := next(t, allReceived); got["gameId"] != "g2" {
+		t.Fatalf("second server-wide delivery = %v, want the end of g2", got)
+	}
+	next(t, allReceived)
+
+	ended := next(t, g1Received)
+	if ended["type"] != "game_ended" || ended["content"] != "g1: game over: bob 131, alice 120" {
+		t.Fatalf("unexpected game end payload %v", ended)
+	}
+	select {
+	case extra := <-g1Received:
+		t.Fatalf("game webhook received an event it did not subscribe to: %v", extra)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDispatcherRegisterValidatesAndRemoves(t *testing.T) {
+	d := NewDispatcher(nil)
+	if _, err := d.Register(Webhook{URL: "ftp://example.com/hook"}); err == nil {
+		t.Fatal("expected a non-http url to be rejected")
+	}
+	if _, err := d.Register(Webhook{URL: "https://example.com/hook", Events: []game.GameEventType{"moved"}}); err == nil {
+		t.Fatal("expected an unknown event type to be rejected")
+	}
+
+	first, err := d.Register(Webhook{URL: "https://example.com/a", GameID: "g1"})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	second, err := d.Register(Webhook{URL: "https://example.com/b"})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if hooks := d.List(""); len(hooks) != 2 || hooks[0].ID != first.ID || hooks[1].ID != second.ID {
+		t.Fatalf("list = %+v, want both webhooks in registration order", hooks)
+	}
+	if hooks := d.List("g1"); len(hooks) != 1 || hooks[0].ID != first.ID {
+		t.Fatalf("list g1 = %+v, want only the game webhook", hooks)
+	}
+	if !d.Remove(first.ID) || d.Remove(first.ID) {
+		t.Fatal("expected the first removal to succeed and the second to report a missing webhook")
+	}
+	if hooks := d.List(""); len(hooks) != 1 {
+		t.Fatalf("list after remove = %+v", hooks)
+	}
+}
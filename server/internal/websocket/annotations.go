@@ -0,0 +1,140 @@
+package websocket
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/models"
+)
+
+const (
+	// maxAnnotationAdds is how many annotations a client may place per
+	// annotationAddWindow.
+	maxAnnotationAdds   = 20
+	annotationAddWindow = time.Minute
+)
+
+// spectatorSeq numbers the connections that annotate games they only watch.
+var spectatorSeq atomic.Int64
+
+type addAnnotationPayload struct {
+	GameID     string                    `json:"gameID"`
+	Kind       game.AnnotationKind       `json:"kind"`
+	Visibility game.AnnotationVisibility `json:"visibility"`
+	Hex        models.Hex                `json:"hex"`
+	To         *models.Hex               `json:"to,omitempty"`
+	Building   string                    `json:"building,omitempty"`
+	Label      string                    `json:"label,omitempty"`
+}
+
+type removeAnnotationPayload struct {
+	GameID string `json:"gameID"`
+	ID     string `json:"id"`
+}
+
+// annotationViewer identifies the client to gameID's annotations: by seat, or
+// by an opaque per-connection ID while spectating.
+func (c *Client) annotationViewer(gameID string) (viewerID string, spectator bool) {
+	if seatID := c.seatForGame(gameID); seatID != "" {
+		return seatID, false
+	}
+	c.seatsMu.Lock()
+	defer c.seatsMu.Unlock()
+	if c.spectatorID == "" {
+		c.spectatorID = "spectator-" + strconv.FormatInt(spectatorSeq.Add(1), 10)
+	}
+	return c.spectatorID, true
+}
+
+// allowAnnotationAdd records an add at now and reports whether the client is
+// still within maxAnnotationAdds for the trailing annotationAddWindow.
+func (c *Client) allowAnnotationAdd(now time.Time) bool {
+	recent := c.annotationAdds[:0]
+	for _, at := range c.annotationAdds {
+		if now.Sub(at) < annotationAddWindow {
+			recent = append(recent, at)
+		}
+	}
+	c.annotationAdds = recent
+	if len(recent) >= maxAnnotationAdds {
+		return false
+	}
+	c.annotationAdds = append(c.annotationAdds, now)
+	return true
+}
+
+// handleAddAnnotation places a board annotation on a game the client is
+// seated in or watching, then sends every viewer the annotations they see.
+func (c *Client) handleAddAnnotation(payload json.RawMessage) {
+	var p addAnnotationPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		c.sendError("invalid_payload")
+		return
+	}
+	if !c.hub.InGame(c, p.GameID) {
+		c.sendError("not_in_game")
+		return
+	}
+	if !c.allowAnnotationAdd(time.Now()) {
+		c.sendError("annotation_rate_limited")
+		return
+	}
+	authorID, spectator := c.annotationViewer(p.GameID)
+	if _, err := c.deps.Games.AddAnnotation(p.GameID, game.BoardAnnotation{
+		Kind:       p.Kind,
+		Visibility: p.Visibility,
+		AuthorID:   authorID,
+		Spectator:  spectator,
+		Hex:        p.Hex,
+		To:         p.To,
+		Building:   p.Building,
+		Label:      p.Label,
+	}); err != nil {
+		c.sendError("invalid_annotation")
+		return
+	}
+	BroadcastAnnotations(c.hub, c.deps.Games, p.GameID)
+}
+
+// handleRemoveAnnotation deletes one of the client's own annotations.
+func (c *Client) handleRemoveAnnotation(payload json.RawMessage) {
+	var p removeAnnotationPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		c.sendError("invalid_payload")
+		return
+	}
+	if !c.hub.InGame(c, p.GameID) {
+		c.sendError("not_in_game")
+		return
+	}
+	authorID, spectator := c.annotationViewer(p.GameID)
+	if _, err := c.deps.Games.RemoveAnnotation(p.GameID, p.ID, authorID, spectator); err != nil {
+		c.sendError("annotation_not_found")
+		return
+	}
+	BroadcastAnnotations(c.hub, c.deps.Games, p.GameID)
+}
+
+// annotationsMessageFor renders the annotations of gameID client sees.
+func annotationsMessageFor(games *game.Manager, gameID string, client *Client) []byte {
+	viewerID, spectator := client.annotationViewer(gameID)
+	msg, _ := json.Marshal(map[string]any{
+		"type": "annotations",
+		"payload": map[string]any{
+			"gameId":      gameID,
+			"viewerId":    viewerID,
+			"annotations": games.Annotations(gameID, viewerID, spectator),
+		},
+	})
+	return msg
+}
+
+// BroadcastAnnotations sends every client in gameID the annotations it sees.
+func BroadcastAnnotations(hub *Hub, games *game.Manager, gameID string) {
+	hub.BroadcastToGameFor(gameID, func(c *Client) []byte {
+		return annotationsMessageFor(games, gameID, c)
+	})
+}
@@ -153,6 +153,7 @@ func (b *BotManager) run(gameID string, hub *Hub) {
 			return
 		}
 		b.broadcastGameState(hub, gameID)
+		BroadcastActionEvents(hub, b.games, gameID, result)
 		b.broadcastStatus(hub, gameID, config.PlayerID, false, label)
 		time.Sleep(25 * time.Millisecond)
 	}
@@ -209,22 +210,7 @@ func (b *BotManager) chooseAction(position *env.Position, legal []actions.Option
 }
 
 func (b *BotManager) broadcastGameState(hub *Hub, gameID string) {
-	gameState := b.games.SerializeGameState(gameID)
-	if gameState == nil {
-		return
-	}
-	stateMsg, _ := json.Marshal(map[string]any{
-		"type":    "game_state_update",
-		"payload": gameState,
-	})
-	hub.BroadcastToGame(gameID, stateMsg)
-	if pendingDecision, ok := gameState["pendingDecision"]; ok && pendingDecision != nil {
-		decisionMsg, _ := json.Marshal(map[string]any{
-			"type":    "decision_required",
-			"payload": pendingDecision,
-		})
-		hub.BroadcastToGame(gameID, decisionMsg)
-	}
+	BroadcastGameState(hub, b.games, gameID)
 }
 
 func (b *BotManager) broadcastStatus(hub *Hub, gameID, playerID string, thinking bool, lastMove string) {
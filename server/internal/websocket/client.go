@@ -8,8 +8,11 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -21,10 +24,16 @@ import (
 )
 
 const (
-	writeWait      = 10 * time.Second
-	pongWait       = 60 * time.Second
-	pingPeriod     = (pongWait * 9) / 10
+	writeWait = 10 * time.Second
+	// A connection that misses a pong for pongWait is closed, so dead
+	// connections drop out of the lobby within seconds. Pinging twice per
+	// pongWait tolerates one lost or slow pong.
+	pongWait       = 10 * time.Second
+	pingPeriod     = pongWait / 2
 	maxMessageSize = 512 * 1024
+	// Smaller messages are sent uncompressed even when permessage-deflate was
+	// negotiated; deflating them costs more than it saves.
+	compressionThreshold = 512
 )
 
 var (
@@ -42,7 +51,23 @@ type Client struct {
 
 	deps ServerDeps
 
+	// seatsMu guards seatsByGame, which the hub reads to filter game state
+	// per seat, and spectatorID.
+	seatsMu     sync.RWMutex
 	seatsByGame map[string]string
+	// spectatorID identifies the client to the annotations of games it
+	// watches without a seat; see annotationViewer.
+	spectatorID string
+
+	// annotationAdds are the times of the client's recent add_annotation
+	// messages; see allowAnnotationAdd. Only readPump touches it.
+	annotationAdds []time.Time
+
+	// locale selects the language of localizedMessage fields.
+	locale string
+
+	// lastActive is the UnixNano time of the last inbound message.
+	lastActive atomic.Int64
 }
 
 type inboundMsg struct {
@@ -59,7 +84,11 @@ type createGamePayload struct {
 	EnableFireIceFactions bool                       `json:"enableFireIceFactions,omitempty"`
 	FireIceScoring        string                     `json:"fireIceScoring,omitempty"`
 	CustomMap             *board.CustomMapDefinition `json:"customMap,omitempty"`
-	ModelOpponent         *modelOpponentPayload      `json:"modelOpponent,omitempty"`
+	// CustomMapGrid is a community map as a terrain-letter grid; see
+	// board.ParseCustomMapGrid. It is stored as CustomMap once validated.
+	CustomMapGrid string                `json:"customMapGrid,omitempty"`
+	CustomMapName string                `json:"customMapName,omitempty"`
+	ModelOpponent *modelOpponentPayload `json:"modelOpponent,omitempty"`
 }
 
 type joinGamePayload struct {
@@ -72,6 +101,38 @@ type leaveGamePayload struct {
 	Name string `json:"name,omitempty"`
 }
 
+type kickPlayerPayload struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Ban  bool   `json:"ban,omitempty"`
+}
+
+type reserveSeatsPayload struct {
+	ID    string   `json:"id"`
+	Names []string `json:"names"`
+}
+
+type lockGamePayload struct {
+	ID     string `json:"id"`
+	Locked bool   `json:"locked"`
+}
+
+type setVacationPayload struct {
+	Name       string `json:"name"`
+	OnVacation bool   `json:"onVacation"`
+}
+
+type setLeechPreferencesPayload struct {
+	Name        string                `json:"name"`
+	Preferences game.LeechPreferences `json:"preferences"`
+}
+
+type setConfirmationPreferencesPayload struct {
+	Name        string `json:"name"`
+	ConfirmPass *bool  `json:"confirmPass,omitempty"`
+	ConfirmBurn *bool  `json:"confirmBurn,omitempty"`
+}
+
 type startGamePayload struct {
 	GameID             string                `json:"gameID"`
 	RandomizeTurnOrder *bool                 `json:"randomizeTurnOrder,omitempty"`
@@ -80,6 +141,23 @@ type startGamePayload struct {
 	TurnTimerSeconds   *int                  `json:"turnTimerSeconds,omitempty"`
 	TurnTimerIncrement *int                  `json:"turnTimerIncrementSeconds,omitempty"`
 	ModelOpponent      *modelOpponentPayload `json:"modelOpponent,omitempty"`
+	Seed               *int64                `json:"seed,omitempty"`
+	// HiddenResources hides opponents' coins and power bowls until they pass.
+	HiddenResources bool `json:"hiddenResources,omitempty"`
+	// Hotseat lets the host's connection act for every seat. It turns hidden
+	// resources off, as one screen shows every player's resources anyway.
+	Hotseat bool `json:"hotseat,omitempty"`
+	// TurnOrderPolicy is "pass_order" (the default variable turn order) or
+	// "cyclic_from_first_passer".
+	TurnOrderPolicy string `json:"turnOrderPolicy,omitempty"`
+	// Handicaps gives players, by ID, extra starting VP, workers and coins.
+	Handicaps map[string]game.Handicap `json:"handicaps,omitempty"`
+	// RemovedBonusCards are bonus card codes (e.g. "BON-SPD") taken out of the
+	// game; the rest of the removals for the player count are random.
+	RemovedBonusCards []string `json:"removedBonusCards,omitempty"`
+	// SpeedPreset is "blitz", "standard" or "async". It sets the turn timer
+	// unless turnTimerEnabled is also given.
+	SpeedPreset string `json:"speedPreset,omitempty"`
 }
 
 type modelOpponentPayload struct {
@@ -103,6 +181,8 @@ type lobbyStateMsg struct {
 type hexParam struct {
 	Q int `json:"q"`
 	R int `json:"r"`
+	// Label is a board label ("F5") given instead of coordinates.
+	Label string `json:"-"`
 }
 
 type performActionPayload struct {
@@ -120,6 +200,38 @@ type performActionPayload struct {
 	Hex     *hexParam `json:"hex,omitempty"`
 }
 
+// spadeTargetParam is one entry of a spade power_action_claim's "targets".
+type spadeTargetParam struct {
+	Hex           *hexParam `json:"hex"`
+	BuildDwelling bool      `json:"buildDwelling"`
+	UseSkip       bool      `json:"useSkip"`
+}
+
+func parseSpadeTargets(raw json.RawMessage) ([]game.PowerSpadeTarget, error) {
+	var params []spadeTargetParam
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, fmt.Errorf("invalid targets parameter: %w", err)
+	}
+	if len(params) == 0 {
+		return nil, fmt.Errorf("targets parameter is empty")
+	}
+	targets := make([]game.PowerSpadeTarget, 0, len(params))
+	for _, param := range params {
+		if param.Hex == nil {
+			return nil, fmt.Errorf("transform target is missing its hex")
+		}
+		if param.Hex.Label != "" {
+			return nil, fmt.Errorf("unresolved hex label %q", param.Hex.Label)
+		}
+		targets = append(targets, game.PowerSpadeTarget{
+			Hex:           board.NewHex(param.Hex.Q, param.Hex.R),
+			BuildDwelling: param.BuildDwelling,
+			UseSkip:       param.UseSkip,
+		})
+	}
+	return targets, nil
+}
+
 type nestedActionPayload struct {
 	Type   string          `json:"type"`
 	Params json.RawMessage `json:"params,omitempty"`
@@ -163,9 +275,13 @@ func (c *Client) sendLobbyState() {
 }
 
 func (c *Client) broadcastLobbyState() {
-	games := c.deps.Lobby.ListGames()
+	broadcastLobbyState(c.hub, c.deps.Lobby)
+}
+
+func broadcastLobbyState(hub *Hub, lobbyMgr *lobby.Manager) {
+	games := lobbyMgr.ListGames()
 	out, _ := json.Marshal(lobbyStateMsg{Type: "lobby_state", Payload: games})
-	c.hub.BroadcastMessage(out)
+	hub.BroadcastMessage(out)
 }
 
 func (c *Client) sendAvailableMaps() {
@@ -173,7 +289,23 @@ func (c *Client) sendAvailableMaps() {
 	c.send <- out
 }
 
+// sendPendingDecisions re-delivers every decision outstanding for playerID,
+// so a reconnecting client does not have to dig them out of the state blob.
+func (c *Client) sendPendingDecisions(gameID, playerID string) {
+	msg, _ := json.Marshal(map[string]any{
+		"type": "pending_decisions",
+		"payload": map[string]any{
+			"gameId":    gameID,
+			"playerId":  playerID,
+			"decisions": c.deps.Games.PendingDecisionsFor(gameID, playerID),
+		},
+	})
+	c.send <- msg
+}
+
 func (c *Client) bindSeat(gameID, playerID string) {
+	c.seatsMu.Lock()
+	defer c.seatsMu.Unlock()
 	if c.seatsByGame == nil {
 		c.seatsByGame = make(map[string]string)
 	}
@@ -181,6 +313,8 @@ func (c *Client) bindSeat(gameID, playerID string) {
 }
 
 func (c *Client) seatForGame(gameID string) string {
+	c.seatsMu.RLock()
+	defer c.seatsMu.RUnlock()
 	if c.seatsByGame == nil {
 		return ""
 	}
@@ -188,6 +322,8 @@ func (c *Client) seatForGame(gameID string) string {
 }
 
 func (c *Client) unbindSeat(gameID string) {
+	c.seatsMu.Lock()
+	defer c.seatsMu.Unlock()
 	if c.seatsByGame == nil {
 		return
 	}
@@ -214,6 +350,7 @@ func (c *Client) readPump() {
 			}
 			break
 		}
+		c.touch()
 		message = bytes.TrimSpace(bytes.ReplaceAll(message, newline, space))
 
 		var env inboundMsg
@@ -232,10 +369,14 @@ func (c *Client) handleInboundMessage(env inboundMsg) {
 		c.sendLobbyState()
 		c.sendAvailableMaps()
 
+	case "set_locale":
+		c.handleSetLocale(env.Payload)
+
 	case "get_game_state":
 		var p struct {
-			GameID   string `json:"gameID"`
-			PlayerID string `json:"playerID,omitempty"`
+			GameID             string `json:"gameID"`
+			PlayerID           string `json:"playerID,omitempty"`
+			IncludeMapAnalysis bool   `json:"includeMapAnalysis,omitempty"`
 		}
 		if err := json.Unmarshal(env.Payload, &p); err != nil {
 			log.Printf("error parsing get_game_state payload: %v", err)
@@ -261,14 +402,19 @@ func (c *Client) handleInboundMessage(env inboundMsg) {
 			}
 		}
 		c.hub.JoinGame(c, p.GameID)
-		gameState := c.deps.Games.SerializeGameState(p.GameID)
+		var gameState map[string]interface{}
+		if p.IncludeMapAnalysis {
+			gameState = c.deps.Games.SerializeGameStateWithMapAnalysis(p.GameID)
+		} else {
+			gameState = c.deps.Games.SerializeGameState(p.GameID)
+		}
 		if gameState != nil {
-			gameStateMsg, _ := json.Marshal(map[string]any{
-				"type":    "game_state_update",
-				"payload": gameState,
-			})
-			c.send <- gameStateMsg
+			c.send <- gameStateUpdateFor(gameState, c.seatForGame(p.GameID))
+		}
+		if seatID := c.seatForGame(p.GameID); seatID != "" {
+			c.sendPendingDecisions(p.GameID, seatID)
 		}
+		c.send <- annotationsMessageFor(c.deps.Games, p.GameID, c)
 
 	case "start_game":
 		c.handleStartGame(env.Payload)
@@ -285,8 +431,53 @@ func (c *Client) handleInboundMessage(env inboundMsg) {
 	case "leave_game":
 		c.handleLeaveGame(env.Payload)
 
+	case "kick_player":
+		c.handleKickPlayer(env.Payload)
+
+	case "reserve_seats":
+		c.handleReserveSeats(env.Payload)
+
+	case "lock_game":
+		c.handleLockGame(env.Payload)
+
+	case "set_vacation":
+		c.handleSetVacation(env.Payload)
+
+	case "set_leech_preferences":
+		c.handleSetLeechPreferences(env.Payload)
+
+	case "set_confirmation_preferences":
+		c.handleSetConfirmationPreferences(env.Payload)
+
+	case "set_public_summary":
+		c.handleSetPublicSummary(env.Payload)
+
+	case "list_my_games":
+		c.handleListMyGames(env.Payload)
+
+	case "get_rules_summary":
+		c.handleGetRulesSummary(env.Payload)
+	case "get_score_projection":
+		c.handleGetScoreProjection(env.Payload)
+
+	case "resume_game":
+		c.handleResumeGame(env.Payload)
+
+	case "add_annotation":
+		c.handleAddAnnotation(env.Payload)
+	case "remove_annotation":
+		c.handleRemoveAnnotation(env.Payload)
+
 	case "perform_action":
 		c.handlePerformAction(env.Payload)
+	case "get_legal_actions":
+		c.handleGetLegalActions(env.Payload)
+	case "perform_legal_action":
+		c.handlePerformLegalAction(env.Payload)
+	case "validate_action":
+		c.handleValidateAction(env.Payload)
+	case "preview_action":
+		c.handlePreviewAction(env.Payload)
 	case "test_apply_conversion":
 		c.handleTestApplyConversion(env.Payload)
 	case "test_apply_fixture_settings":
@@ -332,7 +523,7 @@ func (c *Client) handleTestApplyFixtureSettings(payload json.RawMessage) {
 		c.sendActionRejected("", "invalid_bonus_cards", err.Error())
 		return
 	}
-	turnOrderPolicy, err := turnOrderPolicyFromFixturePayload(p.TurnOrderPolicy)
+	turnOrderPolicy, err := turnOrderPolicyFromPayload(p.TurnOrderPolicy)
 	if err != nil {
 		c.sendActionRejected("", "invalid_turn_order_policy", err.Error())
 		return
@@ -349,11 +540,7 @@ func (c *Client) handleTestApplyFixtureSettings(payload json.RawMessage) {
 		c.sendActionRejected("", "apply_failed", "failed to serialize game state")
 		return
 	}
-	stateMsg, _ := json.Marshal(map[string]any{
-		"type":    "game_state_update",
-		"payload": gameState,
-	})
-	c.hub.BroadcastToGame(p.GameID, stateMsg)
+	broadcastGameStateUpdate(c.hub, p.GameID, gameState)
 
 	ack, _ := json.Marshal(map[string]any{
 		"type": "test_command_applied",
@@ -428,11 +615,7 @@ func (c *Client) handleTestApplyConversion(payload json.RawMessage) {
 		return
 	}
 
-	stateMsg, _ := json.Marshal(map[string]any{
-		"type":    "game_state_update",
-		"payload": gameState,
-	})
-	c.hub.BroadcastToGame(gameID, stateMsg)
+	broadcastGameStateUpdate(c.hub, gameID, gameState)
 
 	ack, _ := json.Marshal(map[string]any{
 		"type": "test_command_applied",
@@ -487,7 +670,7 @@ func (c *Client) handleTestReplayActionsToIndex(payload json.RawMessage) {
 
 		if strings.TrimSpace(replay.Type) == "replay_conversion" {
 			if err := c.handleTestReplayConversionPayload(p.GameID, playerID, replay.Params); err != nil {
-				c.sendActionRejected("", "replay_failed", fmt.Sprintf("action %d execute failed: %v", i, err))
+				c.sendActionRejected("", "replay_failed", c.labelHexes(p.GameID, fmt.Sprintf("action %d execute failed: %v", i, err)))
 				return
 			}
 			continue
@@ -499,6 +682,10 @@ func (c *Client) handleTestReplayActionsToIndex(payload json.RawMessage) {
 			ActionID: fmt.Sprintf("test-replay-%d", i),
 			Params:   replay.Params,
 		}
+		if err := c.resolveHexLabels(p.GameID, &req); err != nil {
+			c.sendActionRejected("", "invalid_action", fmt.Sprintf("action %d parse failed: %v", i, err))
+			return
+		}
 		action, err := buildActionFromPayload(req, playerID)
 		if err != nil {
 			c.sendActionRejected("", "invalid_action", fmt.Sprintf("action %d parse failed: %v", i, err))
@@ -509,9 +696,10 @@ func (c *Client) handleTestReplayActionsToIndex(payload json.RawMessage) {
 			ActionID:         req.ActionID,
 			ExpectedRevision: -1,
 			SeatID:           playerID,
+			Record:           recordedActionFromPayload(req, playerID),
 		})
 		if err != nil {
-			c.sendActionRejected("", "replay_failed", fmt.Sprintf("action %d execute failed: %v", i, err))
+			c.sendActionRejected("", "replay_failed", c.labelHexes(p.GameID, fmt.Sprintf("action %d execute failed: %v", i, err)))
 			return
 		}
 	}
@@ -522,11 +710,7 @@ func (c *Client) handleTestReplayActionsToIndex(payload json.RawMessage) {
 		return
 	}
 
-	stateMsg, _ := json.Marshal(map[string]any{
-		"type":    "game_state_update",
-		"payload": gameState,
-	})
-	c.hub.BroadcastToGame(p.GameID, stateMsg)
+	broadcastGameStateUpdate(c.hub, p.GameID, gameState)
 
 	ack, _ := json.Marshal(map[string]any{
 		"type": "test_command_applied",
@@ -631,6 +815,11 @@ func (c *Client) handleStartGame(payload json.RawMessage) {
 		setupMode = game.SetupModeSnellman
 	}
 
+	if p.Seed != nil && !c.deps.AllowClientSeeds {
+		c.sendActionRejected("", "client_seed_not_allowed", "this server does not accept client seeds")
+		return
+	}
+
 	var turnTimer *game.TurnTimerConfig
 	if p.TurnTimerEnabled != nil && *p.TurnTimerEnabled {
 		initialSeconds := 25 * 60
@@ -655,16 +844,55 @@ func (c *Client) handleStartGame(payload json.RawMessage) {
 		}
 	}
 
-	err := c.deps.Games.CreateGameWithOptions(p.GameID, meta.Players, game.CreateGameOptions{
-		RandomizeTurnOrder:    randomize,
-		SetupMode:             setupMode,
-		TurnTimer:             turnTimer,
-		MapID:                 board.NormalizeMapID(meta.MapID),
-		EnableFanFactions:     meta.EnableFanFactions,
-		EnableFireIceFactions: meta.EnableFireIceFactions,
-		FireIceScoring:        game.FireIceFinalScoringSetting(strings.TrimSpace(meta.FireIceScoring)),
-		CustomMap:             board.CloneCustomMapDefinition(meta.CustomMap),
-	})
+	for playerID, handicap := range p.Handicaps {
+		if !slices.Contains(meta.Players, playerID) {
+			c.sendActionRejected("", "invalid_handicap", fmt.Sprintf("handicap for unknown player %s", playerID))
+			return
+		}
+		if err := handicap.Validate(); err != nil {
+			c.sendActionRejected("", "invalid_handicap", err.Error())
+			return
+		}
+	}
+
+	removedBonusCards := make([]game.BonusCardType, 0, len(p.RemovedBonusCards))
+	for _, code := range p.RemovedBonusCards {
+		card := notation.ParseBonusCardCode(strings.ToUpper(strings.TrimSpace(code)))
+		if card == game.BonusCardUnknown || slices.Contains(removedBonusCards, card) {
+			c.sendActionRejected("", "invalid_bonus_card_removal", fmt.Sprintf("invalid or repeated bonus card %q", code))
+			return
+		}
+		removedBonusCards = append(removedBonusCards, card)
+	}
+	if limit := len(game.GetAllBonusCards()) - len(meta.Players) - 3; len(removedBonusCards) > limit {
+		c.sendActionRejected("", "invalid_bonus_card_removal", fmt.Sprintf("%d players remove %d bonus cards", len(meta.Players), max(limit, 0)))
+		return
+	}
+
+	turnOrderPolicy, err := turnOrderPolicyFromPayload(p.TurnOrderPolicy)
+	if err != nil {
+		c.sendActionRejected("", "invalid_turn_order_policy", err.Error())
+		return
+	}
+
+	speedPreset := game.SpeedPreset(strings.TrimSpace(p.SpeedPreset))
+	if speedPreset != "" && !speedPreset.IsValid() {
+		c.sendActionRejected("", "invalid_speed_preset", fmt.Sprintf("unsupported speed preset: %s", p.SpeedPreset))
+		return
+	}
+
+	opts := meta.GameOptions()
+	opts.RandomizeTurnOrder = randomize
+	opts.SetupMode = setupMode
+	opts.TurnTimer = turnTimer
+	opts.HiddenResources = p.HiddenResources && !hasModelOpponent && !p.Hotseat
+	opts.Hotseat = p.Hotseat && !hasModelOpponent
+	opts.TurnOrderPolicy = turnOrderPolicy
+	opts.Handicaps = p.Handicaps
+	opts.RemovedBonusCards = removedBonusCards
+	opts.Seed = p.Seed
+	opts.SpeedPreset = speedPreset
+	err = c.deps.Games.CreateGameWithOptions(p.GameID, meta.Players, opts)
 	if err != nil && !strings.Contains(err.Error(), "game already exists") {
 		log.Printf("error creating game: %v", err)
 		c.sendError("create_game_failed")
@@ -691,11 +919,7 @@ func (c *Client) handleStartGame(payload json.RawMessage) {
 
 	gameState := c.deps.Games.SerializeGameState(p.GameID)
 	if gameState != nil {
-		gameStateMsg, _ := json.Marshal(map[string]any{
-			"type":    "game_state_update",
-			"payload": gameState,
-		})
-		c.hub.BroadcastToGame(p.GameID, gameStateMsg)
+		broadcastGameStateUpdate(c.hub, p.GameID, gameState)
 	}
 	if hasModelOpponent && c.deps.Bots != nil {
 		c.deps.Bots.RegisterGame(p.GameID, botConfig)
@@ -724,7 +948,7 @@ func (c *Client) prepareModelGame(gameID, humanPlayerID string, botConfig BotGam
 	if !ok {
 		return fmt.Errorf("game not found: %s", gameID)
 	}
-	if _, err := c.deps.Games.ExecuteActionWithMeta(gameID, game.NewSetPlayerOptionsAction(botConfig.PlayerID, nil, nil, &confirmActions, nil), game.ActionMeta{
+	if _, err := c.deps.Games.ExecuteActionWithMeta(gameID, game.NewSetPlayerOptionsAction(botConfig.PlayerID, nil, nil, &confirmActions, nil, nil), game.ActionMeta{
 		ActionID:         fmt.Sprintf("model-options:%s:%d", botConfig.PlayerID, revision),
 		ExpectedRevision: revision,
 		SeatID:           botConfig.PlayerID,
@@ -741,7 +965,7 @@ func (c *Client) prepareModelGame(gameID, humanPlayerID string, botConfig BotGam
 		if !ok || gs == nil {
 			return fmt.Errorf("game not found: %s", gameID)
 		}
-		if gs.Phase != game.PhaseFactionSelection || gs.SetupMode != game.SetupModeSnellman {
+		if gs.Phases().Current() != game.PhaseFactionSelection || gs.SetupMode != game.SetupModeSnellman {
 			return nil
 		}
 		current := gs.GetCurrentPlayer()
@@ -819,6 +1043,10 @@ func (c *Client) handleCreateGame(payload json.RawMessage) {
 		c.sendActionRejected("", "invalid_fire_ice_scoring", fmt.Sprintf("unsupported Fire & Ice scoring option: %s", p.FireIceScoring))
 		return
 	}
+	if err := p.loadCustomMapGrid(); err != nil {
+		c.sendActionRejected("", "invalid_custom_map", err.Error())
+		return
+	}
 
 	meta, err := c.deps.Lobby.CreateGame(
 		p.Name,
@@ -876,6 +1104,10 @@ func (c *Client) handleCreateAndStartModelGame(payload json.RawMessage) {
 		c.sendActionRejected("", "invalid_fire_ice_scoring", fmt.Sprintf("unsupported Fire & Ice scoring option: %s", p.FireIceScoring))
 		return
 	}
+	if err := p.loadCustomMapGrid(); err != nil {
+		c.sendActionRejected("", "invalid_custom_map", err.Error())
+		return
+	}
 
 	meta, err := c.deps.Lobby.CreateGame(
 		p.Name,
@@ -913,15 +1145,9 @@ func (c *Client) handleCreateAndStartModelGame(payload json.RawMessage) {
 		c.hub.JoinGame(c, meta.ID)
 	}
 
-	err = c.deps.Games.CreateGameWithOptions(meta.ID, meta.Players, game.CreateGameOptions{
-		RandomizeTurnOrder:    false,
-		SetupMode:             game.SetupModeSnellman,
-		MapID:                 board.NormalizeMapID(meta.MapID),
-		EnableFanFactions:     meta.EnableFanFactions,
-		EnableFireIceFactions: meta.EnableFireIceFactions,
-		FireIceScoring:        game.FireIceFinalScoringSetting(strings.TrimSpace(meta.FireIceScoring)),
-		CustomMap:             board.CloneCustomMapDefinition(meta.CustomMap),
-	})
+	opts := meta.GameOptions()
+	opts.SetupMode = game.SetupModeSnellman
+	err = c.deps.Games.CreateGameWithOptions(meta.ID, meta.Players, opts)
 	if err != nil && !strings.Contains(err.Error(), "game already exists") {
 		log.Printf("error creating model game: %v", err)
 		c.sendError("create_game_failed")
@@ -949,14 +1175,10 @@ func (c *Client) handleCreateAndStartModelGame(payload json.RawMessage) {
 
 	gameState := c.deps.Games.SerializeGameState(meta.ID)
 	if gameState != nil {
-		gameStateMsg, _ := json.Marshal(map[string]any{
-			"type":    "game_state_update",
-			"payload": gameState,
-		})
 		if c.hub != nil {
-			c.hub.BroadcastToGame(meta.ID, gameStateMsg)
+			broadcastGameStateUpdate(c.hub, meta.ID, gameState)
 		} else {
-			c.send <- gameStateMsg
+			c.send <- gameStateUpdateFor(gameState, creator)
 		}
 	}
 	if c.deps.Bots != nil {
@@ -966,6 +1188,32 @@ func (c *Client) handleCreateAndStartModelGame(payload json.RawMessage) {
 	c.broadcastLobbyState()
 }
 
+// loadCustomMapGrid replaces a CustomMapGrid with the CustomMap it describes
+// after the community map checks, so the lobby, the game and its save file
+// only ever see the parsed definition.
+func (p *createGamePayload) loadCustomMapGrid() error {
+	if strings.TrimSpace(p.CustomMapGrid) == "" {
+		return nil
+	}
+	if p.CustomMap != nil {
+		return fmt.Errorf("send either customMap or customMapGrid, not both")
+	}
+	custom, err := board.ParseCustomMapGrid(p.CustomMapName, p.CustomMapGrid)
+	if err != nil {
+		return fmt.Errorf("invalid custom map: %w", err)
+	}
+	if err := custom.ValidateCommunityMap(); err != nil {
+		return fmt.Errorf("invalid custom map: %w", err)
+	}
+	if mapID := strings.TrimSpace(p.MapID); mapID != "" && board.NormalizeMapID(mapID) != board.MapCustom {
+		return fmt.Errorf("customMapGrid requires mapId=%s", board.MapCustom)
+	}
+	p.MapID = string(board.MapCustom)
+	p.CustomMap = custom
+	p.CustomMapGrid = ""
+	return nil
+}
+
 func normalizeFireIceScoringPayload(value string) (string, error) {
 	fireIceScoring := strings.ToLower(strings.TrimSpace(value))
 	switch fireIceScoring {
@@ -1037,32 +1285,268 @@ func (c *Client) handleLeaveGame(payload json.RawMessage) {
 	c.broadcastLobbyState()
 }
 
-func (c *Client) handlePerformAction(payload json.RawMessage) {
-	var req performActionPayload
-	if err := json.Unmarshal(payload, &req); err != nil {
-		log.Printf("perform_action payload error: %v", err)
-		c.sendActionRejected("", "invalid_action_payload", "invalid action payload")
+// handleGetRulesSummary sends the options in effect for a game, or for a
+// lobby the options chosen so far.
+func (c *Client) handleGetRulesSummary(payload json.RawMessage) {
+	var p struct {
+		GameID string `json:"gameID"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		log.Printf("get_rules_summary payload error: %v", err)
 		return
 	}
+	summary, err := c.deps.Games.RulesSummary(p.GameID)
+	if err != nil {
+		meta, ok := c.deps.Lobby.GetGame(p.GameID)
+		if !ok || meta.Started {
+			c.sendError("game_not_found")
+			return
+		}
+		summary = game.BuildLobbyRulesSummary(meta.GameOptions())
+		summary.GameID = p.GameID
+	}
+	msg, _ := json.Marshal(map[string]any{
+		"type":    "rules_summary",
+		"payload": summary,
+	})
+	c.send <- msg
+}
 
-	gameID := req.GameID
-	if gameID == "" {
-		gameID = req.GameId
+// handleGetScoreProjection sends the scores of a game as if it ended now, as
+// the requesting seat may see them.
+func (c *Client) handleGetScoreProjection(payload json.RawMessage) {
+	var p struct {
+		GameID string `json:"gameID"`
 	}
-	if gameID == "" {
-		c.sendActionRejected(req.ActionID, "missing_game_id", "missing game id")
+	if err := json.Unmarshal(payload, &p); err != nil {
+		log.Printf("get_score_projection payload error: %v", err)
+		return
+	}
+	scores, err := c.deps.Games.ScoreProjection(p.GameID, c.seatForGame(p.GameID))
+	if err != nil {
+		c.sendError("game_not_found")
 		return
 	}
+	msg, _ := json.Marshal(map[string]any{
+		"type": "score_projection",
+		"payload": map[string]any{
+			"gameId": p.GameID,
+			"scores": scores,
+		},
+	})
+	c.send <- msg
+}
 
-	seatID := c.seatForGame(gameID)
-	if seatID == "" {
-		c.sendActionRejected(req.ActionID, "unauthorized", "you are not seated in this game")
+// handleKickPlayer lets the host of an open game remove a player, optionally
+// banning them. The kicked player's connections leave the game room.
+func (c *Client) handleKickPlayer(payload json.RawMessage) {
+	var p kickPlayerPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		log.Printf("kick_player payload error: %v", err)
+		return
+	}
+	playerID := strings.TrimSpace(p.Name)
+	if err := c.deps.Lobby.KickPlayer(p.ID, c.seatForGame(p.ID), playerID, p.Ban); err != nil {
+		c.sendLobbyError(err)
 		return
 	}
 
-	action, err := buildActionFromPayload(req, seatID)
+	kickedMsg, _ := json.Marshal(map[string]any{
+		"type": "game_left",
+		"payload": map[string]any{
+			"gameId":   p.ID,
+			"playerId": playerID,
+			"kicked":   true,
+			"banned":   p.Ban,
+		},
+	})
+	c.hub.RemoveSeat(p.ID, playerID, kickedMsg)
+	c.broadcastLobbyState()
+}
+
+func (c *Client) handleReserveSeats(payload json.RawMessage) {
+	var p reserveSeatsPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		log.Printf("reserve_seats payload error: %v", err)
+		return
+	}
+	if err := c.deps.Lobby.ReserveSeats(p.ID, c.seatForGame(p.ID), p.Names); err != nil {
+		c.sendLobbyError(err)
+		return
+	}
+	c.broadcastLobbyState()
+}
+
+func (c *Client) handleLockGame(payload json.RawMessage) {
+	var p lockGamePayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		log.Printf("lock_game payload error: %v", err)
+		return
+	}
+	if err := c.deps.Lobby.SetLocked(p.ID, c.seatForGame(p.ID), p.Locked); err != nil {
+		c.sendLobbyError(err)
+		return
+	}
+	c.broadcastLobbyState()
+}
+
+// handleSetVacation starts or ends the vacation of a player this client is
+// seated as, then refreshes the lobby and every game the player is in.
+func (c *Client) handleSetVacation(payload json.RawMessage) {
+	var p setVacationPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		log.Printf("set_vacation payload error: %v", err)
+		return
+	}
+	playerID := strings.TrimSpace(p.Name)
+	seated := false
+	c.seatsMu.RLock()
+	for _, seatID := range c.seatsByGame {
+		if seatID == playerID {
+			seated = true
+			break
+		}
+	}
+	c.seatsMu.RUnlock()
+	if playerID == "" || !seated {
+		c.sendError("not_in_game")
+		return
+	}
+
+	status, gameIDs, err := c.deps.Games.SetVacation(playerID, p.OnVacation)
+	switch {
+	case errors.Is(err, game.ErrVacationsDisabled):
+		c.sendError("vacations_disabled")
+		return
+	case errors.Is(err, game.ErrVacationBudgetExhausted):
+		c.sendError("vacation_budget_exhausted")
+		return
+	case errors.Is(err, game.ErrVacationNotSaved):
+		// The vacation is in effect; only a restart would lose it.
+		log.Printf("set_vacation for %s: %v", playerID, err)
+	case err != nil:
+		c.sendError("vacation_failed")
+		return
+	}
+
+	statusMsg, _ := json.Marshal(map[string]any{
+		"type": "vacation_status",
+		"payload": map[string]any{
+			"playerId":    playerID,
+			"onVacation":  status.OnVacation,
+			"sinceMs":     status.SinceMs,
+			"remainingMs": status.RemainingMs,
+		},
+	})
+	c.send <- statusMsg
+	for _, gameID := range gameIDs {
+		BroadcastGameState(c.hub, c.deps.Games, gameID)
+	}
+	c.broadcastLobbyState()
+}
+
+// handleSetLeechPreferences stores the standing leech preferences of a player
+// this client is seated as. Its games apply them when an offer matches.
+func (c *Client) handleSetLeechPreferences(payload json.RawMessage) {
+	var p setLeechPreferencesPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		c.sendError("invalid_payload")
+		return
+	}
+	playerID := strings.TrimSpace(p.Name)
+	if !c.seatedAs(playerID) {
+		c.sendError("not_in_game")
+		return
+	}
+	if err := p.Preferences.Validate(); err != nil {
+		c.sendError("invalid_leech_preferences")
+		return
+	}
+
+	if err := c.deps.Games.SetLeechPreferences(playerID, p.Preferences); err != nil {
+		log.Printf("set_leech_preferences for %s: %v", playerID, err)
+	}
+	prefsMsg, _ := json.Marshal(map[string]any{
+		"type": "leech_preferences",
+		"payload": map[string]any{
+			"playerId":    playerID,
+			"preferences": c.deps.Games.LeechPreferences(playerID),
+		},
+	})
+	c.send <- prefsMsg
+}
+
+// handleSetConfirmationPreferences sets whether the passes and power burns of
+// a player this client is seated as need confirmation in all their games.
+func (c *Client) handleSetConfirmationPreferences(payload json.RawMessage) {
+	var p setConfirmationPreferencesPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		c.sendError("invalid_payload")
+		return
+	}
+	playerID := strings.TrimSpace(p.Name)
+	if !c.seatedAs(playerID) {
+		c.sendError("not_in_game")
+		return
+	}
+	prefs, err := c.deps.Games.SetConfirmationPreferences(playerID, p.ConfirmPass, p.ConfirmBurn)
 	if err != nil {
-		c.sendActionRejected(req.ActionID, "invalid_action", err.Error())
+		log.Printf("set_confirmation_preferences for %s: %v", playerID, err)
+	}
+	msg, _ := json.Marshal(map[string]any{
+		"type": "confirmation_preferences",
+		"payload": map[string]any{
+			"playerId":    playerID,
+			"confirmPass": prefs.ConfirmPass,
+			"confirmBurn": prefs.ConfirmBurn,
+		},
+	})
+	c.send <- msg
+}
+
+// seatedAs reports whether this client holds a seat as playerID in any game.
+func (c *Client) seatedAs(playerID string) bool {
+	if playerID == "" {
+		return false
+	}
+	c.seatsMu.RLock()
+	defer c.seatsMu.RUnlock()
+	for _, seatID := range c.seatsByGame {
+		if seatID == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// handleSetPublicSummary lets a seated player share, or stop sharing, the
+// game's result at /api/games/{gameId}/summary.
+func (c *Client) handleSetPublicSummary(payload json.RawMessage) {
+	var p struct {
+		GameID string `json:"gameID"`
+		Public bool   `json:"public"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		c.sendError("invalid_payload")
+		return
+	}
+	seatID := c.seatForGame(p.GameID)
+	if seatID == "" {
+		c.sendError("not_in_game")
+		return
+	}
+	if err := c.deps.Games.SetPublicSummary(p.GameID, seatID, p.Public); err != nil {
+		log.Printf("set_public_summary for %s in %s: %v", seatID, p.GameID, err)
+		c.sendError("game_not_found")
+		return
+	}
+	BroadcastGameState(c.hub, c.deps.Games, p.GameID)
+}
+
+func (c *Client) handlePerformAction(payload json.RawMessage) {
+	req, gameID, seatID, action, code, message := c.parseSeatedAction(payload)
+	if code != "" {
+		c.sendActionNack(req.ActionID, gameID, code, message, nil)
 		return
 	}
 
@@ -1071,29 +1555,51 @@ func (c *Client) handlePerformAction(payload json.RawMessage) {
 		expectedRevision = *req.ExpectedRevision
 	}
 
+	if c.deps.Games.IsHotseat(gameID) {
+		log.Printf("hotseat game %s: %s played %s for seat %s", gameID, c.seatForGame(gameID), req.Type, seatID)
+	}
 	result, err := c.deps.Games.ExecuteActionWithMeta(gameID, action, game.ActionMeta{
 		ActionID:         req.ActionID,
 		ExpectedRevision: expectedRevision,
 		SeatID:           seatID,
+		Record:           recordedActionFromPayload(req, seatID),
 	})
 	if err != nil {
-		if mismatch, ok := err.(*game.RevisionMismatchError); ok {
-			c.sendActionRejected(req.ActionID, "revision_mismatch", mismatch.Error(), map[string]any{
-				"expectedRevision": mismatch.Expected,
-				"currentRevision":  mismatch.Current,
-			})
-			return
-		}
-		c.sendActionRejected(req.ActionID, "action_rejected", err.Error())
+		c.sendActionFailed(req.ActionID, gameID, err)
 		return
 	}
 
+	c.sendActionAccepted(req.ActionID, gameID, result)
+}
+
+// sendActionFailed nacks an action the game refused to execute.
+func (c *Client) sendActionFailed(actionID, gameID string, err error) {
+	if mismatch, ok := err.(*game.RevisionMismatchError); ok {
+		c.sendActionNack(actionID, gameID, "revision_mismatch", mismatch.Error(), map[string]any{
+			"expectedRevision": mismatch.Expected,
+			"currentRevision":  mismatch.Current,
+		})
+		return
+	}
+	c.sendActionNack(actionID, gameID, "action_rejected", c.labelHexes(gameID, err.Error()), map[string]any{
+		"reasonCode": game.ReasonCodeOf(err),
+	})
+}
+
+// sendActionAccepted acks an executed action, then sends everyone in gameID
+// the updated state and the events the action raised.
+func (c *Client) sendActionAccepted(actionID, gameID string, result *game.ActionResult) {
+	// seq is the revision the action produced, so the client can match the
+	// ack to the game_state_update carrying the same revision.
 	acceptedMsg, _ := json.Marshal(map[string]any{
 		"type": "action_accepted",
 		"payload": map[string]any{
-			"actionId":    req.ActionID,
-			"newRevision": result.Revision,
-			"duplicate":   result.Duplicate,
+			"actionId":     actionID,
+			"gameId":       gameID,
+			"seq":          result.Revision,
+			"newRevision":  result.Revision,
+			"duplicate":    result.Duplicate,
+			"consequences": result.Consequences,
 		},
 	})
 	c.send <- acceptedMsg
@@ -1102,11 +1608,7 @@ func (c *Client) handlePerformAction(payload json.RawMessage) {
 	if gameState == nil {
 		return
 	}
-	stateMsg, _ := json.Marshal(map[string]any{
-		"type":    "game_state_update",
-		"payload": gameState,
-	})
-	c.hub.BroadcastToGame(gameID, stateMsg)
+	broadcastGameStateUpdate(c.hub, gameID, gameState)
 
 	if pendingDecision, ok := gameState["pendingDecision"]; ok && pendingDecision != nil {
 		decisionMsg, _ := json.Marshal(map[string]any{
@@ -1115,11 +1617,162 @@ func (c *Client) handlePerformAction(payload json.RawMessage) {
 		})
 		c.hub.BroadcastToGame(gameID, decisionMsg)
 	}
+	BroadcastActionEvents(c.hub, c.deps.Games, gameID, result)
 	if c.deps.Bots != nil {
 		c.deps.Bots.Trigger(gameID, c.hub)
 	}
 }
 
+// handleValidateAction answers whether a perform_action payload would be
+// accepted right now, without executing it.
+func (c *Client) handleValidateAction(payload json.RawMessage) {
+	req, gameID, seatID, action, code, message := c.parseSeatedAction(payload)
+	if code != "" {
+		c.sendActionValidation(req.ActionID, "", code, "", message)
+		return
+	}
+
+	if err := c.deps.Games.ValidateAction(gameID, action, game.ActionMeta{SeatID: seatID}); err != nil {
+		if invalid, ok := err.(*game.ActionValidationError); ok {
+			c.sendActionValidation(req.ActionID, invalid.Stage, "action_invalid", game.ReasonCodeOf(invalid.Err), c.labelHexes(gameID, invalid.Err.Error()))
+			return
+		}
+		c.sendActionValidation(req.ActionID, "", "game_not_found", "", err.Error())
+		return
+	}
+	c.sendActionValidation(req.ActionID, "", "", "", "")
+}
+
+// handlePreviewAction replies with the cost/benefit summary of a
+// perform_action payload without executing it.
+func (c *Client) handlePreviewAction(payload json.RawMessage) {
+	req, gameID, _, action, code, message := c.parseSeatedAction(payload)
+	if code != "" {
+		c.sendActionPreviewError(req.ActionID, code, "", message)
+		return
+	}
+
+	preview, err := c.deps.Games.PreviewAction(gameID, action)
+	if err != nil {
+		c.sendActionPreviewError(req.ActionID, "preview_failed", game.ReasonCodeOf(err), c.labelHexes(gameID, err.Error()))
+		return
+	}
+	msg, _ := json.Marshal(map[string]any{
+		"type": "action_preview",
+		"payload": map[string]any{
+			"actionId": req.ActionID,
+			"preview":  preview,
+		},
+	})
+	c.send <- msg
+}
+
+// parseSeatedAction decodes a perform_action-shaped payload and builds the
+// action for this client's seat. A non-empty code describes why it could not.
+func (c *Client) parseSeatedAction(payload json.RawMessage) (req performActionPayload, gameID, seatID string, action game.Action, code, message string) {
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return req, "", "", nil, "invalid_action_payload", "invalid action payload"
+	}
+
+	gameID = req.GameID
+	if gameID == "" {
+		gameID = req.GameId
+	}
+	if gameID == "" {
+		return req, "", "", nil, "missing_game_id", "missing game id"
+	}
+
+	requested := req.PlayerID
+	if requested == "" {
+		requested = req.PlayerId
+	}
+	seatID = c.actingSeat(gameID, requested)
+	if seatID == "" {
+		return req, gameID, "", nil, "unauthorized", "you are not seated in this game"
+	}
+
+	if err := c.resolveHexLabels(gameID, &req); err != nil {
+		return req, gameID, seatID, nil, "invalid_action", err.Error()
+	}
+	action, err := buildActionFromPayload(req, seatID)
+	if err != nil {
+		return req, gameID, seatID, nil, "invalid_action", err.Error()
+	}
+	return req, gameID, seatID, action, "", ""
+}
+
+// sendActionValidation replies to validate_action. An empty code means the
+// action is valid; reasonCode explains rule failures.
+func (c *Client) sendActionValidation(actionID, stage, code string, reasonCode game.ReasonCode, message string) {
+	payload := map[string]any{
+		"actionId":   actionID,
+		"valid":      code == "",
+		"stage":      stage,
+		"error":      code,
+		"reasonCode": reasonCode,
+		"message":    message,
+	}
+	if code != "" {
+		payload["localizedMessage"] = c.localizedMessage(code, reasonCode, nil)
+	}
+	msg, _ := json.Marshal(map[string]any{
+		"type":    "action_validation",
+		"payload": payload,
+	})
+	c.send <- msg
+}
+
+// sendActionPreviewError tells the client a preview_action could not be
+// previewed. It is not an action_rejected: nothing was performed, so clients
+// have no optimistic move to roll back.
+func (c *Client) sendActionPreviewError(actionID, code string, reasonCode game.ReasonCode, message string) {
+	msg, _ := json.Marshal(map[string]any{
+		"type": "action_preview_error",
+		"payload": map[string]any{
+			"actionId":         actionID,
+			"error":            code,
+			"reasonCode":       reasonCode,
+			"message":          message,
+			"localizedMessage": c.localizedMessage(code, reasonCode, nil),
+		},
+	})
+	c.send <- msg
+}
+
+// recordedActionFromPayload captures the wire form of an action for the game's
+// exportable history.
+func recordedActionFromPayload(req performActionPayload, seatID string) *game.RecordedAction {
+	record := &game.RecordedAction{
+		PlayerID: seatID,
+		Type:     req.Type,
+		Params:   append(json.RawMessage(nil), req.Params...),
+
+		LegacyFaction: req.Faction,
+	}
+	if req.Hex != nil {
+		record.LegacyHex, _ = json.Marshal(req.Hex)
+	}
+	return record
+}
+
+// BuildRecordedAction rebuilds a game action from a recorded wire action. It is
+// the builder used to replay imported save files.
+func BuildRecordedAction(record game.RecordedAction) (game.Action, error) {
+	req := performActionPayload{
+		Type:    record.Type,
+		Params:  record.Params,
+		Faction: record.LegacyFaction,
+	}
+	if len(record.LegacyHex) > 0 {
+		var hex hexParam
+		if err := json.Unmarshal(record.LegacyHex, &hex); err != nil {
+			return nil, fmt.Errorf("invalid legacy hex: %w", err)
+		}
+		req.Hex = &hex
+	}
+	return buildActionFromPayload(req, record.PlayerID)
+}
+
 func buildActionFromPayload(req performActionPayload, seatID string) (game.Action, error) {
 	params := map[string]json.RawMessage{}
 	if len(req.Params) > 0 {
@@ -1144,9 +1797,15 @@ func buildActionFromPayload(req performActionPayload, seatID string) (game.Actio
 			if err := json.Unmarshal(raw, &hp); err != nil {
 				return board.Hex{}, fmt.Errorf("invalid hex parameter: %w", err)
 			}
+			if hp.Label != "" {
+				return board.Hex{}, fmt.Errorf("unresolved hex label %q", hp.Label)
+			}
 			return board.NewHex(hp.Q, hp.R), nil
 		}
 		if req.Hex != nil {
+			if req.Hex.Label != "" {
+				return board.Hex{}, fmt.Errorf("unresolved hex label %q", req.Hex.Label)
+			}
 			return board.NewHex(req.Hex.Q, req.Hex.R), nil
 		}
 		return board.Hex{}, fmt.Errorf("missing hex parameter")
@@ -1204,6 +1863,22 @@ func buildActionFromPayload(req performActionPayload, seatID string) (game.Actio
 		return val, nil
 	}
 
+	// parsePowerPayment reads how a power action is paid for: with coins, and
+	// whether the player confirmed the power it burns.
+	parsePowerPayment := func(a *game.PowerAction) (*game.PowerAction, error) {
+		useCoins, err := parseBoolParam(false, "useCoins")
+		if err != nil {
+			return nil, err
+		}
+		confirmed, err := parseBoolParam(false, "confirmed")
+		if err != nil {
+			return nil, err
+		}
+		a.UseCoins = useCoins
+		a.Confirmed = confirmed
+		return a, nil
+	}
+
 	parseOptionalBoolParam := func(keys ...string) (*bool, error) {
 		raw, ok := getParam(keys...)
 		if !ok {
@@ -1390,42 +2065,36 @@ func buildActionFromPayload(req performActionPayload, seatID string) (game.Actio
 				return nil, err
 			}
 			a := game.NewPowerActionWithBridge(seatID, hex1, hex2)
-			useCoins, err := parseBoolParam(false, "useCoins")
-			if err != nil {
-				return nil, err
-			}
-			a.UseCoins = useCoins
-			return a, nil
+			return parsePowerPayment(a)
 		}
 		if actionType == game.PowerActionSpade1 || actionType == game.PowerActionSpade2 {
-			hex, err := parseHexParam("hex", "targetHex")
-			if err != nil {
-				return nil, err
-			}
-			buildDwelling, err := parseBoolParam(false, "buildDwelling")
-			if err != nil {
-				return nil, err
-			}
-			a := game.NewPowerActionWithTransform(seatID, actionType, hex, buildDwelling)
-			useSkip, err := parseBoolParam(false, "useSkip")
-			if err != nil {
-				return nil, err
-			}
-			a.UseSkip = useSkip
-			useCoins, err := parseBoolParam(false, "useCoins")
-			if err != nil {
-				return nil, err
+			var a *game.PowerAction
+			if raw, ok := getParam("targets"); ok {
+				targets, err := parseSpadeTargets(raw)
+				if err != nil {
+					return nil, err
+				}
+				a = game.NewPowerActionWithTransforms(seatID, actionType, targets)
+			} else {
+				hex, err := parseHexParam("hex", "targetHex")
+				if err != nil {
+					return nil, err
+				}
+				buildDwelling, err := parseBoolParam(false, "buildDwelling")
+				if err != nil {
+					return nil, err
+				}
+				a = game.NewPowerActionWithTransform(seatID, actionType, hex, buildDwelling)
+				useSkip, err := parseBoolParam(false, "useSkip")
+				if err != nil {
+					return nil, err
+				}
+				a.UseSkip = useSkip
 			}
-			a.UseCoins = useCoins
-			return a, nil
+			return parsePowerPayment(a)
 		}
 		a := game.NewPowerAction(seatID, actionType)
-		useCoins, err := parseBoolParam(false, "useCoins")
-		if err != nil {
-			return nil, err
-		}
-		a.UseCoins = useCoins
-		return a, nil
+		return parsePowerPayment(a)
 
 	case "power_bridge_place":
 		hex1, hex2, err := parseBridgeEndpoints()
@@ -1433,12 +2102,7 @@ func buildActionFromPayload(req performActionPayload, seatID string) (game.Actio
 			return nil, err
 		}
 		a := game.NewPowerActionWithBridge(seatID, hex1, hex2)
-		useCoins, err := parseBoolParam(false, "useCoins")
-		if err != nil {
-			return nil, err
-		}
-		a.UseCoins = useCoins
-		return a, nil
+		return parsePowerPayment(a)
 
 	case "engineers_bridge":
 		hex1, hex2, err := parseBridgeEndpoints()
@@ -1502,6 +2166,11 @@ func buildActionFromPayload(req performActionPayload, seatID string) (game.Actio
 			value := game.SnowShamansPassUpgrade(upgrade)
 			passAction.SnowShamansUpgrade = &value
 		}
+		confirmed, err := parseBoolParam(false, "confirmed")
+		if err != nil {
+			return nil, err
+		}
+		passAction.Confirmed = confirmed
 		return passAction, nil
 
 	case "confirm_turn":
@@ -1510,6 +2179,9 @@ func buildActionFromPayload(req performActionPayload, seatID string) (game.Actio
 	case "undo_turn":
 		return game.NewUndoTurnAction(seatID), nil
 
+	case "resign":
+		return game.NewResignAction(seatID), nil
+
 	case "accept_leech":
 		offerIndex, err := parseIntParam("offerIndex")
 		if err != nil {
@@ -1704,9 +2376,14 @@ func buildActionFromPayload(req performActionPayload, seatID string) (game.Actio
 		if err != nil {
 			return nil, err
 		}
+		confirmed, err := parseBoolParam(false, "confirmed")
+		if err != nil {
+			return nil, err
+		}
 		return &game.BurnPowerAction{
 			BaseAction: game.BaseAction{Type: game.ActionBurnPower, PlayerID: seatID},
 			Amount:     amount,
+			Confirmed:  confirmed,
 		}, nil
 
 	case "set_player_options":
@@ -1731,7 +2408,25 @@ func buildActionFromPayload(req performActionPayload, seatID string) (game.Actio
 		if err != nil {
 			return nil, err
 		}
-		return game.NewSetPlayerOptionsAction(seatID, autoLeechMode, autoConvertOnPass, confirmActions, showIncomePreview), nil
+		autoPassWhenStuck, err := parseOptionalBoolParam("autoPassWhenStuck")
+		if err != nil {
+			return nil, err
+		}
+		action := game.NewSetPlayerOptionsAction(seatID, autoLeechMode, autoConvertOnPass, confirmActions, showIncomePreview, autoPassWhenStuck)
+		if action.ConfirmPass, err = parseOptionalBoolParam("confirmPass"); err != nil {
+			return nil, err
+		}
+		if action.ConfirmBurn, err = parseOptionalBoolParam("confirmBurn"); err != nil {
+			return nil, err
+		}
+		if raw, ok := getParam("leechPreferences"); ok {
+			var prefs game.LeechPreferences
+			if err := json.Unmarshal(raw, &prefs); err != nil {
+				return nil, fmt.Errorf("invalid leechPreferences: %w", err)
+			}
+			action.LeechPreferences = &prefs
+		}
+		return action, nil
 
 	default:
 		return nil, fmt.Errorf("unknown action type: %s", req.Type)
@@ -2178,27 +2873,36 @@ func parseTownTileType(getParam func(...string) (json.RawMessage, bool)) (models
 
 func parseTerrainTypeRaw(raw json.RawMessage) (models.TerrainType, error) {
 	if v, err := parseIntRaw(raw); err == nil {
-		return models.TerrainType(v), nil
+		terrain := models.TerrainType(v)
+		if terrain != models.TerrainTypeUnknown && (terrain < models.TerrainPlains || terrain > models.TerrainVolcano) {
+			return models.TerrainTypeUnknown, fmt.Errorf("invalid terrain type: %d", v)
+		}
+		return terrain, nil
 	}
 	if s, err := parseStringRaw(raw); err == nil {
 		s = strings.ToLower(strings.TrimSpace(s))
+		// Colors are accepted alongside terrain names, matching the T-COORD-COLOR notation.
 		switch s {
-		case "plains":
+		case "plains", "brown":
 			return models.TerrainPlains, nil
-		case "swamp":
+		case "swamp", "black":
 			return models.TerrainSwamp, nil
-		case "lake":
+		case "lake", "blue":
 			return models.TerrainLake, nil
-		case "forest":
+		case "forest", "green":
 			return models.TerrainForest, nil
-		case "mountain":
+		case "mountain", "gray", "grey":
 			return models.TerrainMountain, nil
-		case "wasteland":
+		case "wasteland", "red":
 			return models.TerrainWasteland, nil
-		case "desert":
+		case "desert", "yellow":
 			return models.TerrainDesert, nil
 		case "river":
 			return models.TerrainRiver, nil
+		case "ice", "white":
+			return models.TerrainIce, nil
+		case "volcano", "orange":
+			return models.TerrainVolcano, nil
 		}
 	}
 	return models.TerrainTypeUnknown, fmt.Errorf("invalid terrain type")
@@ -2269,7 +2973,7 @@ func scoringTilesFromCodesForFixture(codes []string) ([]game.ScoringTile, error)
 	return out, nil
 }
 
-func turnOrderPolicyFromFixturePayload(raw string) (game.TurnOrderPolicy, error) {
+func turnOrderPolicyFromPayload(raw string) (game.TurnOrderPolicy, error) {
 	value := strings.TrimSpace(raw)
 	if value == "" {
 		return game.TurnOrderPolicyPassOrder, nil
@@ -2302,6 +3006,39 @@ func bonusCardsFromCodesForFixture(codes []string) ([]game.BonusCardType, error)
 	return out, nil
 }
 
+// handleSetLocale switches the client's language and replies with the message
+// catalog for it, so front-ends can render codes such as pending decision
+// types without parsing English text.
+func (c *Client) handleSetLocale(payload json.RawMessage) {
+	var p struct {
+		Locale string `json:"locale"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		c.sendError("invalid_payload")
+		return
+	}
+	catalog := c.deps.messages()
+	c.locale = catalog.Negotiate(p.Locale, "")
+	msg, _ := json.Marshal(map[string]any{
+		"type": "message_catalog",
+		"payload": map[string]any{
+			"locale":   c.locale,
+			"locales":  catalog.Locales(),
+			"messages": catalog.Messages(c.locale),
+		},
+	})
+	c.send <- msg
+}
+
+// localizedMessage renders an error code in the client's locale. A reason
+// code, when set, describes the failure more precisely than the error code.
+func (c *Client) localizedMessage(code string, reasonCode game.ReasonCode, params map[string]any) string {
+	if reasonCode != "" && reasonCode != game.ReasonUnspecified {
+		return c.deps.messages().Message(c.locale, "reason."+string(reasonCode), params)
+	}
+	return c.deps.messages().Message(c.locale, "error."+code, params)
+}
+
 func (c *Client) sendError(code string) {
 	msg, _ := json.Marshal(map[string]any{
 		"type":    "error",
@@ -2330,9 +3067,18 @@ func (c *Client) sendLobbyError(err error) {
 		payload["error"] = "not_in_game"
 	case errors.Is(err, lobby.ErrInvalidMap):
 		payload["error"] = "invalid_map"
+	case errors.Is(err, lobby.ErrNotHost):
+		payload["error"] = "host_only"
+	case errors.Is(err, lobby.ErrPlayerBanned):
+		payload["error"] = "player_banned"
+	case errors.Is(err, lobby.ErrGameLocked):
+		payload["error"] = "game_locked"
+	case errors.Is(err, lobby.ErrSeatReserved):
+		payload["error"] = "seat_reserved"
 	default:
 		payload["error"] = "join_failed"
 	}
+	payload["localizedMessage"] = c.localizedMessage(payload["error"].(string), "", nil)
 	msg, _ := json.Marshal(map[string]any{
 		"type":    "error",
 		"payload": payload,
@@ -2340,6 +3086,23 @@ func (c *Client) sendLobbyError(err error) {
 	c.send <- msg
 }
 
+// sendActionNack rejects a perform_action with the game's current revision as
+// seq: the state a client rolls its optimistic update back to. seq is left
+// out when the game cannot be found.
+func (c *Client) sendActionNack(actionID, gameID, code, message string, extras map[string]any) {
+	payload := map[string]any{}
+	for k, v := range extras {
+		payload[k] = v
+	}
+	if gameID != "" {
+		payload["gameId"] = gameID
+		if revision, ok := c.deps.Games.GetRevision(gameID); ok {
+			payload["seq"] = revision
+		}
+	}
+	c.sendActionRejected(actionID, code, message, payload)
+}
+
 func (c *Client) sendActionRejected(actionID, code, message string, extras ...map[string]any) {
 	payload := map[string]any{
 		"actionId": actionID,
@@ -2351,6 +3114,8 @@ func (c *Client) sendActionRejected(actionID, code, message string, extras ...ma
 			payload[k] = v
 		}
 	}
+	reasonCode, _ := payload["reasonCode"].(game.ReasonCode)
+	payload["localizedMessage"] = c.localizedMessage(code, reasonCode, payload)
 	msg, _ := json.Marshal(map[string]any{
 		"type":    "action_rejected",
 		"payload": payload,
@@ -2387,7 +3152,19 @@ func (c *Client) handleWriteMessage(message []byte, ok bool) error {
 		return fmt.Errorf("channel closed")
 	}
 
-	w, err := c.conn.NextWriter(websocket.TextMessage)
+	messageType := websocket.TextMessage
+	if c.conn.Subprotocol() == msgpackSubprotocol {
+		encoded, err := jsonToMsgpack(message)
+		if err != nil {
+			log.Printf("dropping message to %s: %v", c.id, err)
+			return nil
+		}
+		message = encoded
+		messageType = websocket.BinaryMessage
+	}
+
+	c.conn.EnableWriteCompression(len(message) >= compressionThreshold)
+	w, err := c.conn.NextWriter(messageType)
 	if err != nil {
 		return err
 	}
@@ -1,7 +1,7 @@
 package websocket
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -9,11 +9,15 @@ import (
 	"testing"
 	"time"
 
+	"encoding/json"
 	gws "github.com/gorilla/websocket"
 	"github.com/lukev/tm_server/internal/game"
 	"github.com/lukev/tm_server/internal/game/board"
+	"github.com/lukev/tm_server/internal/i18n"
 	"github.com/lukev/tm_server/internal/lobby"
 	"github.com/lukev/tm_server/internal/models"
+	"github.com/lukev/tm_server/internal/notation"
+	"github.com/lukev/tm_server/internal/testharness"
 )
 
 func TestWebsocketE2E_SetupToActionAndTurnAuthority(t *testing.T) {
@@ -51,6 +55,238 @@ func TestWebsocketE2E_SetupToActionAndTurnAuthority(t *testing.T) {
 	}
 }
 
+func TestWebsocketE2E_AcksAndNacksCarrySequence(t *testing.T) {
+	_, server, gameID, clients, state := setupWebsocketGameToAction(t,
+		[]string{"p1", "p2"},
+		map[string]string{"p1": "Engineers", "p2": "Auren"},
+		false,
+	)
+	defer server.Close()
+	defer closeConnections(clients)
+
+	revision := asInt(state["revision"])
+	conn := clients[currentTurnPlayerID(state)]
+	convert := func(actionID string, expectedRevision int) {
+		sendJSON(t, conn, map[string]any{
+			"type": "perform_action",
+			"payload": map[string]any{
+				"type":             "conversion",
+				"gameID":           gameID,
+				"actionId":         actionID,
+				"expectedRevision": expectedRevision,
+				"params":           map[string]any{"conversionType": "worker_to_coin", "amount": 1},
+			},
+		})
+	}
+
+	convert("ack-1", revision)
+	ack := asMap(readUntilType(t, conn, "action_accepted", 4*time.Second)["payload"])
+	if asString(ack["actionId"]) != "ack-1" || asString(ack["gameId"]) != gameID || asInt(ack["seq"]) != revision+1 {
+		t.Fatalf("expected ack with seq %d, got %v", revision+1, ack)
+	}
+	// The state broadcast for the action carries the acked sequence.
+	if got := asInt(readUntilStateRevisionAtLeast(t, conn, revision+1, 4*time.Second)["revision"]); got != asInt(ack["seq"]) {
+		t.Fatalf("expected state revision %d, got %d", asInt(ack["seq"]), got)
+	}
+
+	// A stale action is nacked with the revision to roll back to.
+	convert("nack-1", revision)
+	nack := asMap(readUntilType(t, conn, "action_rejected", 4*time.Second)["payload"])
+	if asString(nack["error"]) != "revision_mismatch" || asString(nack["actionId"]) != "nack-1" || asInt(nack["seq"]) != revision+1 {
+		t.Fatalf("expected nack with seq %d, got %v", revision+1, nack)
+	}
+}
+
+func TestWebsocketE2E_HarnessSessionPassesRound(t *testing.T) {
+	_, server, gameID, clients, state := setupWebsocketGameToAction(t,
+		[]string{"p1", "p2"},
+		map[string]string{"p1": "Engineers", "p2": "Auren"},
+		false,
+	)
+	defer server.Close()
+	session := &testharness.Session{T: t, GameID: gameID, Clients: clients, State: state, ActionIDPrefix: "harness-"}
+	defer session.Close()
+
+	currentPlayerID := session.CurrentTurnPlayerID()
+	other := "p1"
+	if currentPlayerID == "p1" {
+		other = "p2"
+	}
+	if code := session.ExpectReject(other, "pass", map[string]any{"bonusCard": firstAvailableBonusCard(session.State)}); code == "" {
+		t.Fatalf("expected out-of-turn pass to be rejected with a code")
+	}
+	session.Refresh(currentPlayerID)
+
+	for range clients {
+		session.MustResolvePending(testharness.DefaultResolvers()...)
+		session.PerformAndAwait(session.CurrentTurnPlayerID(), "pass", map[string]any{
+			"bonusCard": firstAvailableBonusCard(session.State),
+		})
+	}
+	session.MustResolvePending(testharness.DefaultResolvers()...)
+	if round := asInt(asMap(session.State["round"])["round"]); round != 2 {
+		t.Fatalf("expected round 2 after both players passed, got %d", round)
+	}
+}
+
+func TestWebsocketE2E_ExportedGameReplaysOnFreshManager(t *testing.T) {
+	deps, server, gameID, clients, state := setupWebsocketGameToAction(t,
+		[]string{"p1", "p2"},
+		map[string]string{"p1": "Engineers", "p2": "Auren"},
+		true,
+	)
+	defer server.Close()
+	session := &testharness.Session{T: t, GameID: gameID, Clients: clients, State: state, ActionIDPrefix: "export-"}
+	defer session.Close()
+
+	session.PerformAndAwait(session.CurrentTurnPlayerID(), "pass", map[string]any{
+		"bonusCard": firstAvailableBonusCard(session.State),
+	})
+	session.MustResolvePending(testharness.DefaultResolvers()...)
+
+	save, err := deps.Games.ExportGame(gameID)
+	if err != nil {
+		t.Fatalf("export game: %v", err)
+	}
+	raw, err := json.Marshal(save)
+	if err != nil {
+		t.Fatalf("marshal save file: %v", err)
+	}
+	var decoded game.SaveFile
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unmarshal save file: %v", err)
+	}
+
+	imported, err := game.ReplaySaveFile(&decoded, BuildRecordedAction)
+	if err != nil {
+		t.Fatalf("replay save file: %v", err)
+	}
+	if imported.Revision != asInt(session.State["revision"]) {
+		t.Fatalf("imported revision: got %d, want %d", imported.Revision, asInt(session.State["revision"]))
+	}
+
+	items, err := notation.LogItemsFromSaveFile(&decoded, BuildRecordedAction)
+	if err != nil {
+		t.Fatalf("log items from save file: %v", err)
+	}
+	transcript := notation.RenderTranscript(items)
+	if !strings.Contains(transcript, "Round 1: ") || !strings.Contains(transcript, "p1 (Engineers)") || !strings.Contains(transcript, " pass and take the ") ||
+		!strings.Contains(transcript, "Removed bonus cards: BON-") || strings.Contains(transcript, "Seed: ") {
+		t.Fatalf("unexpected transcript:\n%s", transcript)
+	}
+
+	target := game.NewManager()
+	if err := target.AddImportedGame("copy", imported); err != nil {
+		t.Fatalf("add imported game: %v", err)
+	}
+	original, _ := deps.Games.GetGame(gameID)
+	copied, _ := target.GetGame("copy")
+	for _, playerID := range []string{"p1", "p2"} {
+		want := original.GetPlayer(playerID)
+		got := copied.GetPlayer(playerID)
+		if got.VictoryPoints != want.VictoryPoints || got.Resources.Coins != want.Resources.Coins || got.HasPassed != want.HasPassed {
+			t.Fatalf("player %s differs after import: got vp=%d coins=%d passed=%v, want vp=%d coins=%d passed=%v",
+				playerID, got.VictoryPoints, got.Resources.Coins, got.HasPassed, want.VictoryPoints, want.Resources.Coins, want.HasPassed)
+		}
+	}
+	if _, err := target.ExportGame("copy"); err != nil {
+		t.Fatalf("imported game should remain exportable: %v", err)
+	}
+}
+
+func TestWebsocketE2E_ConsistencyCheckReplaysHandlerActions(t *testing.T) {
+	deps, server, gameID, clients, state := setupWebsocketGameToAction(t,
+		[]string{"p1", "p2"},
+		map[string]string{"p1": "Engineers", "p2": "Auren"},
+		false,
+	)
+	defer server.Close()
+	deps.Games.SetConsistencyCheck(BuildRecordedAction)
+	session := &testharness.Session{T: t, GameID: gameID, Clients: clients, State: state, ActionIDPrefix: "shadow-"}
+	defer session.Close()
+
+	for range clients {
+		session.MustResolvePending(testharness.DefaultResolvers()...)
+		session.PerformAndAwait(session.CurrentTurnPlayerID(), "pass", map[string]any{
+			"bonusCard": firstAvailableBonusCard(session.State),
+		})
+	}
+	session.MustResolvePending(testharness.DefaultResolvers()...)
+	if round := asInt(asMap(session.State["round"])["round"]); round != 2 {
+		t.Fatalf("expected round 2 with the consistency check on, got %d", round)
+	}
+}
+
+func TestWebsocketE2E_ValidateActionDoesNotExecute(t *testing.T) {
+	_, server, gameID, clients, state := setupWebsocketGameToAction(t,
+		[]string{"p1", "p2"},
+		map[string]string{"p1": "Engineers", "p2": "Auren"},
+		false,
+	)
+	defer server.Close()
+	defer closeConnections(clients)
+
+	currentPlayerID := currentTurnPlayerID(state)
+	other := "p1"
+	if currentPlayerID == "p1" {
+		other = "p2"
+	}
+	validate := func(playerID string) map[string]any {
+		sendJSON(t, clients[playerID], map[string]any{
+			"type": "validate_action",
+			"payload": map[string]any{
+				"type":     "pass",
+				"gameID":   gameID,
+				"actionId": "validate-" + playerID,
+				"params":   map[string]any{"bonusCard": firstAvailableBonusCard(state)},
+			},
+		})
+		return asMap(readUntilType(t, clients[playerID], "action_validation", 4*time.Second)["payload"])
+	}
+
+	if result := validate(currentPlayerID); !asBool(result["valid"]) {
+		t.Fatalf("expected current player's pass to validate, got %v", result)
+	}
+	result := validate(other)
+	if asBool(result["valid"]) || asString(result["stage"]) != "turn" || asString(result["error"]) != "action_invalid" ||
+		asString(result["reasonCode"]) != string(game.ReasonNotYourTurn) {
+		t.Fatalf("expected out-of-turn pass to fail turn validation, got %v", result)
+	}
+	session := &testharness.Session{T: t, GameID: gameID, Clients: clients, State: state}
+	if refreshed := session.Refresh(currentPlayerID); asInt(refreshed["revision"]) != asInt(state["revision"]) {
+		t.Fatalf("validate_action changed revision: %v -> %v", state["revision"], refreshed["revision"])
+	}
+}
+
+func TestWebsocketContract_PreviewFailureIsNotARejection(t *testing.T) {
+	_, server, gameID, clients, state := setupWebsocketGameToAction(t,
+		[]string{"p1", "p2"},
+		map[string]string{"p1": "Engineers", "p2": "Auren"},
+		false,
+	)
+	defer server.Close()
+	defer closeConnections(clients)
+
+	playerID := currentTurnPlayerID(state)
+	sendJSON(t, clients[playerID], map[string]any{
+		"type": "preview_action",
+		"payload": map[string]any{
+			"type":     "transform_build",
+			"gameID":   gameID,
+			"actionId": "preview-far",
+			"params": map[string]any{
+				"targetHex":     map[string]any{"q": 12, "r": 8},
+				"buildDwelling": true,
+			},
+		},
+	})
+	msg := readUntilType(t, clients[playerID], "action_preview_error", 4*time.Second)
+	result := asMap(msg["payload"])
+	if asString(result["actionId"]) != "preview-far" || asString(result["error"]) != "preview_failed" || asString(result["message"]) == "" {
+		t.Fatalf("unexpected preview error %v", result)
+	}
+}
+
 func TestWebsocketContract_SpadeFollowupAndDiscard(t *testing.T) {
 	deps, server, gameID, clients, state := setupWebsocketGameToAction(t,
 		[]string{"p1", "p2"},
@@ -82,18 +318,23 @@ func TestWebsocketContract_SpadeFollowupAndDiscard(t *testing.T) {
 		"buildDwelling": true,
 	}, asInt(state["revision"]))
 
-	pending := asMap(state["pendingDecision"])
-	if asString(pending["type"]) != "spade_followup" {
-		t.Fatalf("expected spade_followup pending decision, got %v", pending)
+	rawPending, _ := json.Marshal(state["pendingDecision"])
+	decoded, err := game.DecodePendingDecision(rawPending)
+	if err != nil {
+		t.Fatalf("decode pending decision: %v", err)
+	}
+	pending, ok := decoded.(game.SpadeFollowupDecision)
+	if !ok {
+		t.Fatalf("expected spade_followup pending decision, got %s", rawPending)
 	}
-	if asString(pending["playerId"]) != "p1" {
-		t.Fatalf("expected p1 to resolve spade follow-up, got %v", pending["playerId"])
+	if pending.PlayerID != "p1" {
+		t.Fatalf("expected p1 to resolve spade follow-up, got %v", pending.PlayerID)
 	}
-	if asInt(pending["spadesRemaining"]) != 1 {
-		t.Fatalf("expected one remaining spade follow-up, got %v", pending["spadesRemaining"])
+	if pending.SpadesRemaining != 1 {
+		t.Fatalf("expected one remaining spade follow-up, got %v", pending.SpadesRemaining)
 	}
-	if canBuild, ok := pending["canBuildDwelling"].(bool); !ok || canBuild {
-		t.Fatalf("expected canBuildDwelling=false after first ACT6 build, got %v", pending["canBuildDwelling"])
+	if pending.CanBuildDwelling {
+		t.Fatalf("expected canBuildDwelling=false after first ACT6 build")
 	}
 
 	performActionExpectReject(t, clients["p1"], gameID, "conversion", map[string]any{
@@ -121,6 +362,339 @@ func TestWebsocketContract_SpadeFollowupAndDiscard(t *testing.T) {
 	}
 }
 
+func TestWebsocketContract_ReconnectRedeliversPendingDecisions(t *testing.T) {
+	deps, server, gameID, clients, _ := setupWebsocketGameToAction(t,
+		[]string{"p1", "p2"},
+		map[string]string{"p1": "Halflings", "p2": "Witches"},
+		false,
+	)
+	defer server.Close()
+	defer closeConnections(clients)
+
+	gs, ok := deps.Games.GetGame(gameID)
+	if !ok {
+		t.Fatalf("game %s not found", gameID)
+	}
+	gs.PendingLeechOffers["p2"] = []*game.PowerLeechOffer{{Amount: 2, CappedAmount: 2, VPCost: 1, FromPlayerID: "p1"}}
+
+	_ = clients["p2"].Close()
+	clients["p2"] = dialWS(t, "ws"+strings.TrimPrefix(server.URL, "http"))
+	sendJSON(t, clients["p2"], map[string]any{
+		"type": "get_game_state",
+		"payload": map[string]any{
+			"gameID":   gameID,
+			"playerID": "p2",
+		},
+	})
+	payload := asMap(readUntilType(t, clients["p2"], "pending_decisions", 4*time.Second)["payload"])
+	if asString(payload["playerId"]) != "p2" {
+		t.Fatalf("expected pending decisions for p2, got %v", payload["playerId"])
+	}
+	decisions, _ := payload["decisions"].([]any)
+	if len(decisions) != 1 {
+		t.Fatalf("expected one pending decision, got %v", payload["decisions"])
+	}
+	leech := asMap(decisions[0])
+	if asString(leech["type"]) != "leech_offer" {
+		t.Fatalf("expected leech_offer, got %v", leech)
+	}
+	responses, _ := leech["responses"].([]any)
+	if len(responses) != 2 || responses[0] != "accept_leech" || responses[1] != "decline_leech" {
+		t.Fatalf("expected accept/decline leech responses, got %v", leech["responses"])
+	}
+
+	sendJSON(t, clients["p1"], map[string]any{
+		"type": "get_game_state",
+		"payload": map[string]any{
+			"gameID":   gameID,
+			"playerID": "p1",
+		},
+	})
+	payload = asMap(readUntilType(t, clients["p1"], "pending_decisions", 4*time.Second)["payload"])
+	if decisions, _ := payload["decisions"].([]any); len(decisions) != 0 {
+		t.Fatalf("expected no pending decisions for p1, got %v", payload["decisions"])
+	}
+}
+
+func TestWebsocketContract_ListAndResumeMyGamesAfterReconnect(t *testing.T) {
+	_, server, gameID, clients, state := setupWebsocketGameToAction(t,
+		[]string{"p1", "p2"},
+		map[string]string{"p1": "Engineers", "p2": "Auren"},
+		false,
+	)
+	defer server.Close()
+	defer closeConnections(clients)
+
+	playerID := currentTurnPlayerID(state)
+	_ = clients[playerID].Close()
+	clients[playerID] = dialWS(t, "ws"+strings.TrimPrefix(server.URL, "http"))
+	conn := clients[playerID]
+
+	sendJSON(t, conn, map[string]any{"type": "list_my_games", "payload": map[string]any{"playerID": playerID}})
+	payload := asMap(readUntilType(t, conn, "my_games", 4*time.Second)["payload"])
+	games, _ := payload["games"].([]any)
+	if len(games) != 1 {
+		t.Fatalf("expected one game for %s, got %v", playerID, payload["games"])
+	}
+	entry := asMap(games[0])
+	if asString(entry["gameId"]) != gameID || !asBool(entry["started"]) || !asBool(entry["yourTurn"]) {
+		t.Fatalf("expected %s to be listed as started and waiting on %s, got %v", gameID, playerID, entry)
+	}
+	if asInt(entry["phase"]) != int(game.PhaseAction) {
+		t.Fatalf("expected the action phase, got %v", entry["phase"])
+	}
+
+	sendJSON(t, conn, map[string]any{"type": "list_my_games", "payload": map[string]any{"playerID": "stranger"}})
+	payload = asMap(readUntilType(t, conn, "my_games", 4*time.Second)["payload"])
+	if games, _ := payload["games"].([]any); len(games) != 0 {
+		t.Fatalf("expected no games for an unseated player, got %v", payload["games"])
+	}
+
+	sendJSON(t, conn, map[string]any{"type": "resume_game", "payload": map[string]any{"playerID": "stranger", "gameID": gameID}})
+	if code := asString(readUntilType(t, conn, "error", 4*time.Second)["payload"]); code != "not_in_game" {
+		t.Fatalf("expected not_in_game for an unseated player, got %q", code)
+	}
+
+	sendJSON(t, conn, map[string]any{"type": "resume_game", "payload": map[string]any{"playerID": playerID}})
+	resumed := readUntilStateRevisionAtLeast(t, conn, asInt(state["revision"]), 4*time.Second)
+	payload = asMap(readUntilType(t, conn, "games_resumed", 4*time.Second)["payload"])
+	if ids, _ := payload["gameIds"].([]any); len(ids) != 1 || ids[0] != gameID {
+		t.Fatalf("expected %s to be resumed, got %v", gameID, payload["gameIds"])
+	}
+
+	performActionAndReadState(t, conn, gameID, "conversion", map[string]any{
+		"conversionType": "worker_to_coin",
+		"amount":         1,
+	}, asInt(resumed["revision"]))
+}
+
+func TestWebsocketContract_AcceptsBoardLabelsForHexes(t *testing.T) {
+	deps, server, gameID, clients, state := setupWebsocketGameToFactionSelection(t, []string{"p1", "p2"}, false, "snellman")
+	defer server.Close()
+	defer closeConnections(clients)
+
+	factions := map[string]string{"p1": "Halflings", "p2": "Witches"}
+	for asInt(state["phase"]) == int(game.PhaseFactionSelection) {
+		playerID := currentTurnPlayerID(state)
+		state = performActionAndReadState(t, clients[playerID], gameID, "select_faction", map[string]any{
+			"faction": factions[playerID],
+		}, asInt(state["revision"]))
+	}
+
+	playerID := currentSetupDwellingPlayerID(state)
+	q, r := firstSetupDwellingHex(t, state, playerID)
+	label := asString(asMap(asMap(asMap(state["map"])["hexes"])[fmt.Sprintf("%d,%d", q, r)])["displayCoord"])
+	if label == "" {
+		t.Fatalf("expected hex %d,%d to have a board label", q, r)
+	}
+
+	performActionExpectReject(t, clients[playerID], gameID, "setup_dwelling", map[string]any{"hex": "Z99"}, asInt(state["revision"]))
+	state = performActionAndReadState(t, clients[playerID], gameID, "setup_dwelling", map[string]any{
+		"hex": strings.ToLower(label),
+	}, asInt(state["revision"]))
+
+	gs, _ := deps.Games.GetGame(gameID)
+	if mapHex := gs.Map.GetHex(board.NewHex(q, r)); mapHex == nil || mapHex.Building == nil || mapHex.Building.PlayerID != playerID {
+		t.Fatalf("expected %s's dwelling at %s", playerID, label)
+	}
+	save, err := deps.Games.ExportGame(gameID)
+	if err != nil {
+		t.Fatalf("export game: %v", err)
+	}
+	recorded := string(save.Actions[len(save.Actions)-1].Params)
+	if !strings.Contains(recorded, fmt.Sprintf(`"q":%d`, q)) || strings.Contains(recorded, label) {
+		t.Fatalf("expected the label to be recorded as coordinates, got %s", recorded)
+	}
+}
+
+func TestWebsocketContract_HiddenResourcesFilteredPerSeat(t *testing.T) {
+	deps, server, gameID, clients, _ := setupWebsocketGameToAction(t,
+		[]string{"p1", "p2"},
+		map[string]string{"p1": "Halflings", "p2": "Witches"},
+		false,
+	)
+	defer server.Close()
+	defer closeConnections(clients)
+
+	gs, ok := deps.Games.GetGame(gameID)
+	if !ok {
+		t.Fatalf("game %s not found", gameID)
+	}
+	gs.HiddenResources = true
+	// Full bowls make any power card a wasteful pick for both players.
+	for _, playerID := range []string{"p1", "p2"} {
+		power := gs.GetPlayer(playerID).Resources.Power
+		power.Bowl3 += power.Bowl1 + power.Bowl2
+		power.Bowl1, power.Bowl2 = 0, 0
+	}
+	held := map[game.BonusCardType]bool{}
+	for _, playerID := range []string{"p1", "p2"} {
+		for _, card := range gs.BonusCards.GetPlayerCards(playerID) {
+			held[card] = true
+		}
+	}
+	for _, card := range []game.BonusCardType{game.BonusCardShipping, game.BonusCardWorkerPower, game.BonusCardShippingVP} {
+		if !held[card] {
+			gs.BonusCards.Available[card] = 0
+		}
+	}
+
+	stateFor := func(playerID string) map[string]any {
+		sendJSON(t, clients[playerID], map[string]any{
+			"type": "get_game_state",
+			"payload": map[string]any{
+				"gameID":   gameID,
+				"playerID": playerID,
+			},
+		})
+		// Skip updates broadcast before the variant was switched on.
+		for {
+			state := asMap(readUntilType(t, clients[playerID], "game_state_update", 4*time.Second)["payload"])
+			if state["hiddenResources"] == true {
+				return state
+			}
+		}
+	}
+
+	state := stateFor("p1")
+	players := asMap(state["players"])
+	own, opponent := asMap(players["p1"]), asMap(players["p2"])
+	if _, ok := asMap(own["resources"])["coins"]; !ok || own["resourcesHidden"] != nil {
+		t.Fatalf("expected p1 to see own resources, got %v", own)
+	}
+	resources := asMap(opponent["resources"])
+	if _, ok := resources["coins"]; ok {
+		t.Fatalf("expected p2 coins hidden from p1, got %v", resources)
+	}
+	if _, ok := resources["power"]; ok {
+		t.Fatalf("expected p2 power hidden from p1, got %v", resources)
+	}
+	if _, ok := resources["workers"]; !ok || opponent["resourcesHidden"] != true {
+		t.Fatalf("expected p2 workers visible and resourcesHidden set, got %v", opponent)
+	}
+	for _, raw := range state["opponentSummaries"].([]any) {
+		summary := asMap(raw)
+		_, coinsShown := asMap(summary["resources"])["coins"]
+		if hidden := summary["playerId"] == "p2"; coinsShown == hidden || (summary["resourcesHidden"] == true) != hidden {
+			t.Fatalf("expected only p2's summary coins hidden from p1, got %v", summary)
+		}
+	}
+
+	warnings := asMap(state["passIncomeWarnings"])
+	if len(warnings) != 1 || warnings["p1"] == nil {
+		t.Fatalf("expected p1 to see only their own pass income warnings, got %v", warnings)
+	}
+
+	players = asMap(stateFor("p2")["players"])
+	if _, ok := asMap(asMap(players["p1"])["resources"])["coins"]; ok {
+		t.Fatalf("expected p1 coins hidden from p2, got %v", players["p1"])
+	}
+
+	gs.GetPlayer("p2").HasPassed = true
+	players = asMap(stateFor("p1")["players"])
+	if _, ok := asMap(asMap(players["p2"])["resources"])["coins"]; !ok {
+		t.Fatalf("expected p2 coins revealed after passing, got %v", players["p2"])
+	}
+}
+
+func TestWebsocketContract_HotseatHostActsForEverySeat(t *testing.T) {
+	deps, server, gameID, clients, state := setupWebsocketGameToAction(t,
+		[]string{"p1", "p2"},
+		map[string]string{"p1": "Engineers", "p2": "Auren"},
+		false,
+	)
+	defer server.Close()
+	defer closeConnections(clients)
+
+	meta, ok := deps.Lobby.GetGame(gameID)
+	if !ok {
+		t.Fatalf("lobby game %s not found", gameID)
+	}
+	host, guest := meta.Host, "p1"
+	if host == "p1" {
+		guest = "p2"
+	}
+	gs, ok := deps.Games.GetGame(gameID)
+	if !ok {
+		t.Fatalf("game %s not found", gameID)
+	}
+	setCurrent := func(playerID string) {
+		for i, id := range gs.TurnOrder {
+			if id == playerID {
+				gs.CurrentPlayerIndex = i
+			}
+		}
+	}
+	convertFor := func(from, playerID, want string) map[string]any {
+		sendJSON(t, clients[from], map[string]any{
+			"type": "perform_action",
+			"payload": map[string]any{
+				"type":             "conversion",
+				"gameID":           gameID,
+				"playerId":         playerID,
+				"actionId":         fmt.Sprintf("hotseat-%d", time.Now().UnixNano()),
+				"expectedRevision": asInt(state["revision"]),
+				"params":           map[string]any{"conversionType": "worker_to_coin", "amount": 1},
+			},
+		})
+		return readUntilType(t, clients[from], want, 4*time.Second)
+	}
+
+	// Outside hotseat games a named player is ignored: the host acts for
+	// its own seat, out of turn.
+	setCurrent(guest)
+	convertFor(host, guest, "action_rejected")
+
+	gs.Hotseat = true
+	// Another seated client cannot play the host's turns.
+	setCurrent(host)
+	convertFor(guest, host, "action_rejected")
+
+	setCurrent(guest)
+	convertFor(host, guest, "action_accepted")
+	state = readUntilStateRevisionAtLeast(t, clients[host], asInt(state["revision"])+1, 4*time.Second)
+	if state["hotseat"] != true {
+		t.Fatalf("expected game state to report the hotseat option, got %v", state["hotseat"])
+	}
+}
+
+func TestWebsocketContract_SeatedPlayerSharesResultSummary(t *testing.T) {
+	deps, server, gameID, clients, _ := setupWebsocketGameToAction(t,
+		[]string{"p1", "p2"},
+		map[string]string{"p1": "Halflings", "p2": "Witches"},
+		false,
+	)
+	defer server.Close()
+	defer closeConnections(clients)
+
+	sendJSON(t, clients["p2"], map[string]any{
+		"type":    "set_public_summary",
+		"payload": map[string]any{"gameID": gameID, "public": true},
+	})
+	for {
+		state := asMap(readUntilType(t, clients["p1"], "game_state_update", 4*time.Second)["payload"])
+		if consent, _ := state["publicSummaryConsent"].([]any); len(consent) == 1 {
+			if state["publicSummary"] == true {
+				t.Fatalf("expected one seat's consent not to make the summary public")
+			}
+			break
+		}
+	}
+	sendJSON(t, clients["p1"], map[string]any{
+		"type":    "set_public_summary",
+		"payload": map[string]any{"gameID": gameID, "public": true},
+	})
+	for {
+		state := asMap(readUntilType(t, clients["p1"], "game_state_update", 4*time.Second)["payload"])
+		if state["publicSummary"] == true {
+			break
+		}
+	}
+	if _, err := deps.Games.ResultSummary(gameID); !errors.Is(err, game.ErrGameNotFinished) {
+		t.Fatalf("expected the public summary to wait for the game to finish, got %v", err)
+	}
+}
+
 func TestWebsocketSoak_FivePlayers_ReconnectChurn(t *testing.T) {
 	playerIDs := []string{"p1", "p2", "p3", "p4", "p5"}
 	factions := map[string]string{
@@ -394,11 +968,315 @@ func TestWebsocketE2E_StartGameWithTurnTimer(t *testing.T) {
 
 	state := asMap(readUntilType(t, host, "game_state_update", 4*time.Second)["payload"])
 	turnTimer := asMap(state["turnTimer"])
-	if asInt(turnTimer["initialTimeMs"]) != 300_000 {
-		t.Fatalf("expected 300000ms initial timer, got %v", turnTimer["initialTimeMs"])
+	if asInt(turnTimer["initialTimeMs"]) != 300_000 {
+		t.Fatalf("expected 300000ms initial timer, got %v", turnTimer["initialTimeMs"])
+	}
+	if asInt(turnTimer["incrementMs"]) != 5_000 {
+		t.Fatalf("expected 5000ms increment, got %v", turnTimer["incrementMs"])
+	}
+}
+
+func TestWebsocketE2E_SetLeechPreferences(t *testing.T) {
+	deps, server, gameID, clients, initial := setupWebsocketGameToFactionSelection(t, []string{"p1", "p2"}, false, "snellman")
+	defer server.Close()
+	defer func() {
+		for _, conn := range clients {
+			_ = conn.Close()
+		}
+	}()
+
+	sendJSON(t, clients["p1"], map[string]any{
+		"type": "set_leech_preferences",
+		"payload": map[string]any{
+			"name":        "p1",
+			"preferences": map[string]any{"acceptUpTo": -1},
+		},
+	})
+	if code := asString(readUntilType(t, clients["p1"], "error", 4*time.Second)["payload"]); code != "invalid_leech_preferences" {
+		t.Fatalf("expected invalid_leech_preferences, got %q", code)
+	}
+
+	sendJSON(t, clients["p1"], map[string]any{
+		"type": "set_leech_preferences",
+		"payload": map[string]any{
+			"name":        "p1",
+			"preferences": map[string]any{"acceptUpTo": 1, "declineFromVpCost": 2, "declineAboveVp": 80},
+		},
+	})
+	reply := asMap(readUntilType(t, clients["p1"], "leech_preferences", 4*time.Second)["payload"])
+	if asInt(asMap(reply["preferences"])["acceptUpTo"]) != 1 {
+		t.Fatalf("expected stored preferences, got %v", reply)
+	}
+	if got := deps.Games.LeechPreferences("p1"); got.DeclineFromVPCost != 2 || got.DeclineAboveVP != 80 {
+		t.Fatalf("expected the engine to hold p1 preferences, got %+v", got)
+	}
+	if revision, _ := deps.Games.GetRevision(gameID); revision != asInt(initial["revision"]) {
+		t.Fatalf("expected the game to be left alone, got revision %d", revision)
+	}
+}
+
+func TestWebsocketE2E_BoardAnnotations(t *testing.T) {
+	deps, server, gameID, clients, _ := setupWebsocketGameToFactionSelection(t, []string{"p1", "p2"}, false, "snellman")
+	defer server.Close()
+	defer func() {
+		for _, conn := range clients {
+			_ = conn.Close()
+		}
+	}()
+
+	gs, _ := deps.Games.GetGame(gameID)
+	var hex map[string]any
+	for h := range gs.Map.Hexes {
+		hex = map[string]any{"q": h.Q, "r": h.R}
+		break
+	}
+	annotate := func(player, visibility string) {
+		sendJSON(t, clients[player], map[string]any{
+			"type": "add_annotation",
+			"payload": map[string]any{
+				"gameID":     gameID,
+				"kind":       "planned_build",
+				"visibility": visibility,
+				"hex":        hex,
+				"building":   "dwelling",
+			},
+		})
+	}
+	annotationsOf := func(player string) []any {
+		payload := asMap(readUntilType(t, clients[player], "annotations", 4*time.Second)["payload"])
+		list, _ := payload["annotations"].([]any)
+		return list
+	}
+
+	annotate("p1", "private")
+	own := annotationsOf("p1")
+	if len(own) != 1 {
+		t.Fatalf("expected p1 to see its private annotation, got %v", own)
+	}
+	if got := annotationsOf("p2"); len(got) != 0 {
+		t.Fatalf("expected p2 not to see p1's private annotation, got %v", got)
+	}
+	privateID := asString(asMap(own[0])["id"])
+
+	annotate("p1", "all")
+	if got := annotationsOf("p2"); len(got) != 1 || asString(asMap(got[0])["authorId"]) != "p1" {
+		t.Fatalf("expected p2 to see p1's shared annotation, got %v", got)
+	}
+
+	sendJSON(t, clients["p2"], map[string]any{
+		"type":    "remove_annotation",
+		"payload": map[string]any{"gameID": gameID, "id": privateID},
+	})
+	if code := asString(readUntilType(t, clients["p2"], "error", 4*time.Second)["payload"]); code != "annotation_not_found" {
+		t.Fatalf("expected annotation_not_found, got %q", code)
+	}
+
+	annotate("p2", "sideways")
+	if code := asString(readUntilType(t, clients["p2"], "error", 4*time.Second)["payload"]); code != "invalid_annotation" {
+		t.Fatalf("expected invalid_annotation, got %q", code)
+	}
+}
+
+func TestWebsocketE2E_LegalActions(t *testing.T) {
+	_, server, gameID, clients, _ := setupWebsocketGameToFactionSelection(t, []string{"p1", "p2"}, false, "snellman")
+	defer server.Close()
+	defer func() {
+		for _, conn := range clients {
+			_ = conn.Close()
+		}
+	}()
+
+	legalActionsOf := func(player string) map[string]any {
+		sendJSON(t, clients[player], map[string]any{
+			"type":    "get_legal_actions",
+			"payload": map[string]any{"gameID": gameID},
+		})
+		return asMap(readUntilType(t, clients[player], "legal_actions", 4*time.Second)["payload"])
+	}
+
+	mover, waiting := "p1", "p2"
+	listed := legalActionsOf(mover)
+	options, _ := listed["actions"].([]any)
+	if len(options) == 0 {
+		mover, waiting = waiting, mover
+		listed = legalActionsOf(mover)
+		options, _ = listed["actions"].([]any)
+	}
+	if len(options) == 0 {
+		t.Fatalf("expected the player to move to have legal actions, got %v", listed)
+	}
+	if got, _ := legalActionsOf(waiting)["actions"].([]any); len(got) != 0 {
+		t.Fatalf("expected %s to have no legal actions out of turn, got %v", waiting, got)
+	}
+	option := asMap(options[0])
+	if asString(option["type"]) != "select_faction" || asString(option["label"]) == "" {
+		t.Fatalf("expected a labelled select_faction option, got %v", option)
+	}
+
+	sendJSON(t, clients[mover], map[string]any{
+		"type": "perform_legal_action",
+		"payload": map[string]any{
+			"gameID":   gameID,
+			"optionId": "no-such-option",
+			"actionId": "cli-1",
+		},
+	})
+	if code := asString(asMap(readUntilType(t, clients[mover], "action_rejected", 4*time.Second)["payload"])["error"]); code != "action_rejected" {
+		t.Fatalf("expected unknown option to be rejected, got %q", code)
+	}
+
+	sendJSON(t, clients[mover], map[string]any{
+		"type": "perform_legal_action",
+		"payload": map[string]any{
+			"gameID":           gameID,
+			"optionId":         asString(option["id"]),
+			"actionId":         "cli-2",
+			"expectedRevision": asInt(listed["revision"]),
+		},
+	})
+	accepted := asMap(readUntilType(t, clients[mover], "action_accepted", 4*time.Second)["payload"])
+	if asString(accepted["actionId"]) != "cli-2" || asInt(accepted["newRevision"]) != asInt(listed["revision"])+1 {
+		t.Fatalf("unexpected action_accepted payload: %v", accepted)
+	}
+	if got, _ := legalActionsOf(waiting)["actions"].([]any); len(got) == 0 {
+		t.Fatalf("expected %s to move after %s picked a faction", waiting, mover)
+	}
+}
+
+func TestWebsocketE2E_LegalActionPassForwardsConfirmation(t *testing.T) {
+	deps, server, gameID, clients, state := setupWebsocketGameToAction(t,
+		[]string{"p1", "p2"},
+		map[string]string{"p1": "Engineers", "p2": "Auren"},
+		false,
+	)
+	defer server.Close()
+	defer closeConnections(clients)
+
+	mover := currentTurnPlayerID(state)
+	sendJSON(t, clients[mover], map[string]any{
+		"type":    "set_confirmation_preferences",
+		"payload": map[string]any{"name": mover, "confirmPass": true},
+	})
+	reply := asMap(readUntilType(t, clients[mover], "confirmation_preferences", 4*time.Second)["payload"])
+	if asString(reply["playerId"]) != mover || reply["confirmPass"] != true || reply["confirmBurn"] != false {
+		t.Fatalf("expected passes to need confirmation, got %v", reply)
+	}
+	if !deps.Games.AccountPreferences(mover).ConfirmPass {
+		t.Fatalf("expected the engine to hold %s's confirmation preferences", mover)
+	}
+
+	sendJSON(t, clients[mover], map[string]any{
+		"type":    "get_legal_actions",
+		"payload": map[string]any{"gameID": gameID},
+	})
+	listed := asMap(readUntilType(t, clients[mover], "legal_actions", 4*time.Second)["payload"])
+	options, _ := listed["actions"].([]any)
+	passID := ""
+	for _, raw := range options {
+		if option := asMap(raw); asString(option["type"]) == "pass" {
+			passID = asString(option["id"])
+			break
+		}
+	}
+	if passID == "" {
+		t.Fatalf("expected a legal pass, got %v", options)
+	}
+	pass := func(actionID string, confirmed bool) {
+		sendJSON(t, clients[mover], map[string]any{
+			"type": "perform_legal_action",
+			"payload": map[string]any{
+				"gameID":    gameID,
+				"optionId":  passID,
+				"actionId":  actionID,
+				"confirmed": confirmed,
+			},
+		})
+	}
+
+	pass("pass-1", false)
+	rejected := asMap(readUntilType(t, clients[mover], "action_rejected", 4*time.Second)["payload"])
+	if asString(rejected["reasonCode"]) != string(game.ReasonConfirmationRequired) {
+		t.Fatalf("expected an unconfirmed pass to need confirmation, got %v", rejected)
+	}
+
+	pass("pass-2", true)
+	accepted := asMap(readUntilType(t, clients[mover], "action_accepted", 4*time.Second)["payload"])
+	if asString(accepted["actionId"]) != "pass-2" {
+		t.Fatalf("unexpected action_accepted payload: %v", accepted)
+	}
+}
+
+func TestWebsocketE2E_StartGameWithSpeedPreset(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	deps := ServerDeps{
+		Lobby: lobby.NewManager(),
+		Games: game.NewManager(),
+	}
+	server, wsURL := testharness.StartServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeWs(hub, deps, w, r)
+	}))
+	defer server.Close()
+
+	host := dialWS(t, wsURL)
+	defer host.Close()
+	guest := dialWS(t, wsURL)
+	defer guest.Close()
+
+	sendJSON(t, host, map[string]any{
+		"type": "create_game",
+		"payload": map[string]any{
+			"name":       "blitz",
+			"maxPlayers": 2,
+			"creator":    "host",
+		},
+	})
+	created := readUntilType(t, host, "game_created", 4*time.Second)
+	gameID := asString(asMap(created["payload"])["gameId"])
+	_ = readUntilType(t, host, "lobby_state", 4*time.Second)
+
+	sendJSON(t, guest, map[string]any{
+		"type": "join_game",
+		"payload": map[string]any{
+			"id":   gameID,
+			"name": "guest",
+		},
+	})
+	_ = readUntilType(t, guest, "game_joined", 4*time.Second)
+
+	start := func(preset string) {
+		sendJSON(t, host, map[string]any{
+			"type": "start_game",
+			"payload": map[string]any{
+				"gameID":             gameID,
+				"randomizeTurnOrder": false,
+				"setupMode":          "snellman",
+				"speedPreset":        preset,
+			},
+		})
+	}
+
+	start("bullet")
+	rejected := asMap(readUntilType(t, host, "action_rejected", 4*time.Second)["payload"])
+	if asString(rejected["error"]) != "invalid_speed_preset" {
+		t.Fatalf("expected invalid_speed_preset, got %v", rejected)
+	}
+
+	start("blitz")
+	state := asMap(readUntilType(t, host, "game_state_update", 4*time.Second)["payload"])
+	if asString(state["speedPreset"]) != "blitz" {
+		t.Fatalf("expected blitz speed preset, got %v", state["speedPreset"])
+	}
+	turnTimer := asMap(state["turnTimer"])
+	if asInt(turnTimer["initialTimeMs"]) != 30_000 || !asBool(turnTimer["perTurn"]) {
+		t.Fatalf("expected 30s per turn, got %v", turnTimer)
 	}
-	if asInt(turnTimer["incrementMs"]) != 5_000 {
-		t.Fatalf("expected 5000ms increment, got %v", turnTimer["incrementMs"])
+	for playerID, raw := range asMap(state["players"]) {
+		options := asMap(asMap(raw)["options"])
+		if asString(options["autoLeechMode"]) != string(game.LeechAutoModeAccept1) {
+			t.Fatalf("%s auto leech = %v, want %s", playerID, options["autoLeechMode"], game.LeechAutoModeAccept1)
+		}
 	}
 }
 
@@ -470,6 +1348,54 @@ func TestWebsocketE2E_StartGameWithSelectedMap(t *testing.T) {
 	}
 }
 
+func TestWebsocketE2E_StartGameRejectsClientSeed(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	deps := ServerDeps{
+		Lobby: lobby.NewManager(),
+		Games: game.NewManager(),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeWs(hub, deps, w, r)
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	host := dialWS(t, wsURL)
+	defer host.Close()
+	guest := dialWS(t, wsURL)
+	defer guest.Close()
+
+	sendJSON(t, host, map[string]any{
+		"type": "create_game",
+		"payload": map[string]any{
+			"name":       "seeded",
+			"maxPlayers": 2,
+			"creator":    "host",
+		},
+	})
+	gameID := asString(asMap(readUntilType(t, host, "game_created", 4*time.Second)["payload"])["gameId"])
+	sendJSON(t, guest, map[string]any{
+		"type":    "join_game",
+		"payload": map[string]any{"id": gameID, "name": "guest"},
+	})
+	_ = readUntilType(t, guest, "game_joined", 4*time.Second)
+
+	sendJSON(t, host, map[string]any{
+		"type":    "start_game",
+		"payload": map[string]any{"gameID": gameID, "seed": 42},
+	})
+	rejected := asMap(readUntilType(t, host, "action_rejected", 4*time.Second)["payload"])
+	if got := asString(rejected["error"]); got != "client_seed_not_allowed" {
+		t.Fatalf("expected client_seed_not_allowed, got %v", rejected)
+	}
+	if _, ok := deps.Games.GetGame(gameID); ok {
+		t.Fatalf("expected no game to be created with a client seed")
+	}
+}
+
 func TestWebsocketE2E_StartGameWithCustomMap(t *testing.T) {
 	hub := NewHub()
 	go hub.Run()
@@ -555,6 +1481,128 @@ func TestWebsocketE2E_StartGameWithCustomMap(t *testing.T) {
 	}
 }
 
+func TestWebsocketE2E_CreateGameWithCustomMapGrid(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	deps := ServerDeps{
+		Lobby: lobby.NewManager(),
+		Games: game.NewManager(),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeWs(hub, deps, w, r)
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	host := dialWS(t, wsURL)
+	defer host.Close()
+
+	sendJSON(t, host, map[string]any{
+		"type": "create_game",
+		"payload": map[string]any{
+			"name":          "community",
+			"maxPlayers":    2,
+			"creator":       "host",
+			"customMapName": "Puddles",
+			"customMapGrid": "U,S,G,B,Y,R,K,E\nI,I,K,I,I,I,E\nK,R,Y,B,G,S,U,E",
+		},
+	})
+	rejected := asMap(readUntilType(t, host, "action_rejected", 4*time.Second)["payload"])
+	if got := asString(rejected["error"]); got != "invalid_custom_map" {
+		t.Fatalf("expected invalid_custom_map, got %q (%v)", got, rejected["message"])
+	}
+
+	sendJSON(t, host, map[string]any{
+		"type": "create_game",
+		"payload": map[string]any{
+			"name":          "community",
+			"maxPlayers":    2,
+			"creator":       "host",
+			"customMapName": "Stream",
+			"customMapGrid": "U,S,G,B,Y,R,K,E\nI,I,I,I,I,I,E\nK,R,Y,B,G,S,U,E",
+		},
+	})
+	_ = readUntilType(t, host, "game_created", 4*time.Second)
+	lobbyState := readUntilType(t, host, "lobby_state", 4*time.Second)
+	games := lobbyState["payload"].([]any)
+	if len(games) != 1 {
+		t.Fatalf("expected one lobby game, got %d", len(games))
+	}
+	if got := asString(asMap(games[0])["mapId"]); got != string(board.MapCustom) {
+		t.Fatalf("expected lobby mapId %q, got %q", board.MapCustom, got)
+	}
+	customMap := asMap(asMap(games[0])["customMap"])
+	if got := asString(customMap["name"]); got != "Stream" {
+		t.Fatalf("expected lobby custom map name Stream, got %q", got)
+	}
+	if rows, _ := customMap["rows"].([]any); len(rows) != 3 {
+		t.Fatalf("expected the parsed grid to be stored with 3 rows, got %v", customMap["rows"])
+	}
+}
+
+func TestWebsocketE2E_HostKicksAndBansPlayer(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	deps := ServerDeps{
+		Lobby: lobby.NewManager(),
+		Games: game.NewManager(),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeWs(hub, deps, w, r)
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	host := dialWS(t, wsURL)
+	defer host.Close()
+	guest := dialWS(t, wsURL)
+	defer guest.Close()
+
+	sendJSON(t, host, map[string]any{
+		"type":    "create_game",
+		"payload": map[string]any{"name": "kick", "maxPlayers": 3, "creator": "host"},
+	})
+	gameID := asString(asMap(readUntilType(t, host, "game_created", 4*time.Second)["payload"])["gameId"])
+	sendJSON(t, guest, map[string]any{
+		"type":    "join_game",
+		"payload": map[string]any{"id": gameID, "name": "guest"},
+	})
+	_ = readUntilType(t, guest, "game_joined", 4*time.Second)
+
+	sendJSON(t, guest, map[string]any{
+		"type":    "lock_game",
+		"payload": map[string]any{"id": gameID, "locked": true},
+	})
+	if got := asString(asMap(readUntilType(t, guest, "error", 4*time.Second)["payload"])["error"]); got != "host_only" {
+		t.Fatalf("expected host_only for a guest locking the game, got %q", got)
+	}
+
+	sendJSON(t, host, map[string]any{
+		"type":    "kick_player",
+		"payload": map[string]any{"id": gameID, "name": "guest", "ban": true},
+	})
+	left := asMap(readUntilType(t, guest, "game_left", 4*time.Second)["payload"])
+	if left["kicked"] != true || left["banned"] != true {
+		t.Fatalf("expected the guest to be told they were kicked and banned, got %v", left)
+	}
+
+	sendJSON(t, guest, map[string]any{
+		"type":    "join_game",
+		"payload": map[string]any{"id": gameID, "name": "guest"},
+	})
+	if got := asString(asMap(readUntilType(t, guest, "error", 4*time.Second)["payload"])["error"]); got != "player_banned" {
+		t.Fatalf("expected player_banned on rejoin, got %q", got)
+	}
+	meta, _ := deps.Lobby.GetGame(gameID)
+	if len(meta.Players) != 1 || len(meta.Banned) != 1 {
+		t.Fatalf("expected only the host seated and the guest banned, got %+v", meta)
+	}
+}
+
 func TestWebsocketE2E_SpectatorCanViewStartedGame(t *testing.T) {
 	hub := NewHub()
 	go hub.Run()
@@ -769,66 +1817,66 @@ func setupWebsocketGameToFactionSelection(
 ) (ServerDeps, *httptest.Server, string, map[string]*gws.Conn, map[string]any) {
 	t.Helper()
 
-	if len(playerIDs) < 2 {
-		t.Fatalf("setup requires at least 2 players")
-	}
+	deps, server, clients := startWebsocketTestServer(t, playerIDs)
+	session := testharness.CreateAndStartGame(t, clients, playerIDs, testharness.GameOptions{
+		Name: "e2e",
+		StartPayload: map[string]any{
+			"randomizeTurnOrder": randomizeTurnOrder,
+			"setupMode":          setupMode,
+		},
+	})
+	return deps, server, session.GameID, clients, session.State
+}
+
+// startWebsocketTestServer starts a hub-backed websocket server and connects one
+// client per player.
+func startWebsocketTestServer(t *testing.T, playerIDs []string) (ServerDeps, *httptest.Server, map[string]*gws.Conn) {
+	t.Helper()
 
 	hub := NewHub()
 	go hub.Run()
+	return startWebsocketTestServerWithHub(t, hub, playerIDs)
+}
+
+func startWebsocketTestServerWithHub(t *testing.T, hub *Hub, playerIDs []string) (ServerDeps, *httptest.Server, map[string]*gws.Conn) {
+	t.Helper()
 
 	deps := ServerDeps{
 		Lobby: lobby.NewManager(),
 		Games: game.NewManager(),
 	}
 
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server, wsURL := testharness.StartServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ServeWs(hub, deps, w, r)
 	}))
+	return deps, server, testharness.ConnectPlayers(t, wsURL, playerIDs)
+}
 
-	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
-	clients := make(map[string]*gws.Conn, len(playerIDs))
-	for _, playerID := range playerIDs {
-		clients[playerID] = dialWS(t, wsURL)
-	}
+// Shared websocket protocol helpers live in internal/testharness.
+var (
+	dialWS           = testharness.Dial
+	closeConnections = testharness.CloseConnections
+	asMap            = testharness.AsMap
+	asString         = testharness.AsString
+	asInt            = testharness.AsInt
+	asBool           = testharness.AsBool
 
-	creatorID := playerIDs[0]
-	sendJSON(t, clients[creatorID], map[string]any{
-		"type": "create_game",
-		"payload": map[string]any{
-			"name":       "e2e",
-			"maxPlayers": len(playerIDs),
-			"creator":    creatorID,
-		},
-	})
-	created := readUntilType(t, clients[creatorID], "game_created", 4*time.Second)
-	gameID := asString(asMap(created["payload"])["gameId"])
-	if gameID == "" {
-		t.Fatalf("missing game id in game_created payload")
-	}
-	_ = readUntilType(t, clients[creatorID], "lobby_state", 4*time.Second)
+	currentTurnPlayerID = testharness.CurrentTurnPlayerID
+)
 
-	for _, playerID := range playerIDs[1:] {
-		sendJSON(t, clients[playerID], map[string]any{
-			"type": "join_game",
-			"payload": map[string]any{
-				"id":   gameID,
-				"name": playerID,
-			},
-		})
-		_ = readUntilType(t, clients[playerID], "game_joined", 4*time.Second)
-	}
+func sendJSON(t *testing.T, conn *gws.Conn, payload map[string]any) {
+	t.Helper()
+	testharness.SendJSON(t, conn, payload)
+}
 
-	sendJSON(t, clients[creatorID], map[string]any{
-		"type": "start_game",
-		"payload": map[string]any{
-			"gameID":             gameID,
-			"randomizeTurnOrder": randomizeTurnOrder,
-			"setupMode":          setupMode,
-		},
-	})
+func readUntilType(t *testing.T, conn *gws.Conn, want string, timeout time.Duration) map[string]any {
+	t.Helper()
+	return testharness.ReadUntilType(t, conn, want, timeout)
+}
 
-	state := asMap(readUntilType(t, clients[creatorID], "game_state_update", 4*time.Second)["payload"])
-	return deps, server, gameID, clients, state
+func readUntilStateRevisionAtLeast(t *testing.T, conn *gws.Conn, revision int, timeout time.Duration) map[string]any {
+	t.Helper()
+	return testharness.ReadUntilStateRevision(t, conn, revision, timeout)
 }
 
 func configureSpadeFollowupScenario(t *testing.T, gs *game.GameState, playerID string) (board.Hex, board.Hex) {
@@ -907,56 +1955,6 @@ func configureSpadeFollowupScenario(t *testing.T, gs *game.GameState, playerID s
 	return target1, target2
 }
 
-func dialWS(t *testing.T, wsURL string) *gws.Conn {
-	t.Helper()
-	conn, _, err := gws.DefaultDialer.Dial(wsURL, nil)
-	if err != nil {
-		t.Fatalf("dial failed: %v", err)
-	}
-	return conn
-}
-
-func closeConnections(clients map[string]*gws.Conn) {
-	for _, conn := range clients {
-		if conn != nil {
-			_ = conn.Close()
-		}
-	}
-}
-
-func sendJSON(t *testing.T, conn *gws.Conn, payload map[string]any) {
-	t.Helper()
-	if err := conn.SetWriteDeadline(time.Now().Add(2 * time.Second)); err != nil {
-		t.Fatalf("set write deadline failed: %v", err)
-	}
-	if err := conn.WriteJSON(payload); err != nil {
-		t.Fatalf("write json failed: %v", err)
-	}
-}
-
-func readUntilType(t *testing.T, conn *gws.Conn, want string, timeout time.Duration) map[string]any {
-	t.Helper()
-	deadline := time.Now().Add(timeout)
-	for {
-		if err := conn.SetReadDeadline(deadline); err != nil {
-			t.Fatalf("set read deadline failed: %v", err)
-		}
-		var msg map[string]any
-		if err := conn.ReadJSON(&msg); err != nil {
-			t.Fatalf("read json failed while waiting for %s: %v", want, err)
-		}
-		if asString(msg["type"]) == "action_rejected" && want != "action_rejected" {
-			t.Fatalf("unexpected action_rejected while waiting for %s: %v", want, msg["payload"])
-		}
-		if asString(msg["type"]) == "error" && want != "error" {
-			t.Fatalf("unexpected error while waiting for %s: %v", want, msg["payload"])
-		}
-		if asString(msg["type"]) == want {
-			return msg
-		}
-	}
-}
-
 func performActionAndReadState(t *testing.T, conn *gws.Conn, gameID, actionType string, params map[string]any, expectedRevision int) map[string]any {
 	t.Helper()
 	sendJSON(t, conn, map[string]any{
@@ -1013,46 +2011,6 @@ func performActionExpectReject(t *testing.T, conn *gws.Conn, gameID, actionType
 	}
 }
 
-func readUntilStateRevisionAtLeast(t *testing.T, conn *gws.Conn, revision int, timeout time.Duration) map[string]any {
-	t.Helper()
-	deadline := time.Now().Add(timeout)
-	for {
-		if err := conn.SetReadDeadline(deadline); err != nil {
-			t.Fatalf("set read deadline failed: %v", err)
-		}
-		var msg map[string]any
-		if err := conn.ReadJSON(&msg); err != nil {
-			t.Fatalf("read json failed while waiting for state revision %d: %v", revision, err)
-		}
-
-		msgType := asString(msg["type"])
-		if msgType == "action_rejected" {
-			t.Fatalf("unexpected action_rejected while waiting for state revision %d: %v", revision, msg["payload"])
-		}
-		if msgType == "error" {
-			t.Fatalf("unexpected error while waiting for state revision %d: %v", revision, msg["payload"])
-		}
-		if msgType != "game_state_update" {
-			continue
-		}
-
-		payload := asMap(msg["payload"])
-		got := asInt(payload["revision"])
-		if got == revision {
-			return payload
-		}
-		if got > revision {
-			t.Fatalf("unexpected websocket state revision while waiting for revision %d: got %d", revision, got)
-		}
-	}
-}
-
-func currentTurnPlayerID(state map[string]any) string {
-	turnOrderAny := state["turnOrder"].([]any)
-	currentTurn := asInt(state["currentTurn"])
-	return asString(turnOrderAny[currentTurn])
-}
-
 func currentSetupDwellingPlayerID(state map[string]any) string {
 	order := state["setupDwellingOrder"].([]any)
 	idx := asInt(state["setupDwellingIndex"])
@@ -1126,54 +2084,6 @@ func terrainForFaction(faction models.FactionType) models.TerrainType {
 	}
 }
 
-func asMap(v any) map[string]any {
-	if v == nil {
-		return map[string]any{}
-	}
-	if m, ok := v.(map[string]any); ok {
-		return m
-	}
-	// handle json.RawMessage-like values passed through interface
-	if raw, ok := v.([]byte); ok {
-		out := map[string]any{}
-		_ = json.Unmarshal(raw, &out)
-		return out
-	}
-	return map[string]any{}
-}
-
-func asString(v any) string {
-	if s, ok := v.(string); ok {
-		return s
-	}
-	return ""
-}
-
-func asInt(v any) int {
-	switch x := v.(type) {
-	case int:
-		return x
-	case int32:
-		return int(x)
-	case int64:
-		return int(x)
-	case float64:
-		return int(x)
-	case json.Number:
-		n, _ := x.Int64()
-		return int(n)
-	default:
-		return 0
-	}
-}
-
-func asBool(v any) bool {
-	if b, ok := v.(bool); ok {
-		return b
-	}
-	return false
-}
-
 func allPlayersPassed(state map[string]any) bool {
 	players := asMap(state["players"])
 	if len(players) == 0 {
@@ -1187,3 +2097,48 @@ func allPlayersPassed(state map[string]any) bool {
 	}
 	return true
 }
+
+func TestWebsocketE2E_LocalizesRejectionsAndServesMessageCatalog(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+	messages := i18n.NewCatalog()
+	messages.Register("de", map[string]string{"error.missing_game_id": "Kein Spiel angegeben."})
+	deps := ServerDeps{Lobby: lobby.NewManager(), Games: game.NewManager(), Messages: messages}
+	server, wsURL := testharness.StartServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeWs(hub, deps, w, r)
+	}))
+	defer server.Close()
+
+	conn, _, err := gws.DefaultDialer.Dial(wsURL, http.Header{"Accept-Language": []string{"de-DE,de;q=0.9"}})
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	sendJSON(t, conn, map[string]any{"type": "perform_action", "payload": map[string]any{"type": "pass"}})
+	rejected := asMap(readUntilType(t, conn, "action_rejected", 2*time.Second)["payload"])
+	if asString(rejected["error"]) != "missing_game_id" || asString(rejected["localizedMessage"]) != "Kein Spiel angegeben." {
+		t.Fatalf("expected German rejection, got %v", rejected)
+	}
+
+	sendJSON(t, conn, map[string]any{"type": "set_locale", "payload": map[string]any{"locale": "fr"}})
+	catalog := asMap(readUntilType(t, conn, "message_catalog", 2*time.Second)["payload"])
+	if asString(catalog["locale"]) != i18n.DefaultLocale {
+		t.Fatalf("expected fallback to %s, got %v", i18n.DefaultLocale, catalog["locale"])
+	}
+	rendered := asMap(catalog["messages"])
+	if asString(rendered["decision.leech_offer"]) == "" {
+		t.Fatalf("expected decision prompts in catalog, got %v", rendered)
+	}
+	for _, code := range []game.ReasonCode{game.ReasonUnspecified, game.ReasonInsufficientCoins, game.ReasonInvalidHex} {
+		if asString(rendered["reason."+string(code)]) == "" {
+			t.Fatalf("missing English message for reason %s", code)
+		}
+	}
+
+	sendJSON(t, conn, map[string]any{"type": "perform_action", "payload": map[string]any{"type": "pass"}})
+	rejected = asMap(readUntilType(t, conn, "action_rejected", 2*time.Second)["payload"])
+	if asString(rejected["localizedMessage"]) != "No game was given." {
+		t.Fatalf("expected English rejection after set_locale, got %v", rejected)
+	}
+}
@@ -5,9 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"slices"
@@ -20,10 +20,10 @@ import (
 	gws "github.com/gorilla/websocket"
 	"github.com/lukev/tm_server/internal/game"
 	"github.com/lukev/tm_server/internal/game/board"
-	"github.com/lukev/tm_server/internal/lobby"
 	"github.com/lukev/tm_server/internal/models"
 	"github.com/lukev/tm_server/internal/notation"
 	"github.com/lukev/tm_server/internal/replay"
+	"github.com/lukev/tm_server/internal/testharness"
 )
 
 var errReplayEnded = errors.New("replay reached end")
@@ -37,59 +37,32 @@ type goldenFixtureSpec struct {
 	fixture  string
 	playerID []string
 	expected map[string]int
+	skip     string
 }
 
-func goldenFixtureCatalog() []goldenFixtureSpec {
-	return []goldenFixtureSpec{
-		{
-			id:       "s69_g2",
-			fixture:  "testdata/4pLeague_S69_D1L1_G2.txt",
-			playerID: []string{"Witches", "Nomads", "Darklings", "Mermaids"},
-			expected: map[string]int{
-				"Nomads":    166,
-				"Darklings": 137,
-				"Mermaids":  130,
-				"Witches":   124,
-			},
-		},
-		{
-			id:       "s60_g4",
-			fixture:  "testdata/4pLeague_S60_D1L1_G4.txt",
-			playerID: []string{"Cultists", "Darklings", "Dwarves", "Giants"},
-			expected: map[string]int{
-				"Dwarves":   167,
-				"Darklings": 151,
-				"Cultists":  149,
-				"Giants":    115,
-			},
-		},
-		{
-			id:       "s61_g3",
-			fixture:  "testdata/4pLeague_S61_D1L1_G3.txt",
-			playerID: []string{"Darklings", "Cultists", "Engineers", "Witches"},
-			expected: map[string]int{
-				"Cultists":  151,
-				"Darklings": 151,
-				"Witches":   126,
-				"Engineers": 125,
-			},
-		},
-		{
-			id:       "s69_g7",
-			fixture:  "testdata/4pLeague_S69_D1L1_G7.txt",
-			playerID: []string{"Cultists", "Engineers", "Swarmlings", "Nomads"},
-			expected: map[string]int{
-				"Swarmlings": 139,
-				"Nomads":     135,
-				"Engineers":  133,
-				"Cultists":   131,
-			},
-		},
+// goldenFixtureCatalog lists the fixtures with a YAML manifest in testdata
+// (see replay.GoldenFixture). Factions are listed in seat order.
+func goldenFixtureCatalog(t *testing.T) []goldenFixtureSpec {
+	t.Helper()
+	fixtures, err := replay.LoadGoldenFixtures("testdata")
+	if err != nil {
+		t.Fatalf("load golden fixtures: %v", err)
+	}
+	catalog := make([]goldenFixtureSpec, 0, len(fixtures))
+	for _, fixture := range fixtures {
+		catalog = append(catalog, goldenFixtureSpec{
+			id:       fixture.GameID,
+			fixture:  filepath.ToSlash(fixture.LogPath()),
+			playerID: fixture.Factions,
+			expected: fixture.FinalVP,
+			skip:     fixture.Skip,
+		})
 	}
+	return catalog
 }
 
-func findGoldenFixtureSpec(id string) (goldenFixtureSpec, bool) {
-	for _, spec := range goldenFixtureCatalog() {
+func findGoldenFixtureSpec(t *testing.T, id string) (goldenFixtureSpec, bool) {
+	for _, spec := range goldenFixtureCatalog(t) {
 		if spec.id == id {
 			return spec, true
 		}
@@ -97,8 +70,8 @@ func findGoldenFixtureSpec(id string) (goldenFixtureSpec, bool) {
 	return goldenFixtureSpec{}, false
 }
 
-func goldenFixtureIDs() []string {
-	catalog := goldenFixtureCatalog()
+func goldenFixtureIDs(t *testing.T) []string {
+	catalog := goldenFixtureCatalog(t)
 	ids := make([]string, 0, len(catalog))
 	for _, spec := range catalog {
 		ids = append(ids, spec.id)
@@ -122,34 +95,16 @@ type goldenExportScript struct {
 	ExpectedFinalScores map[string]int       `json:"expectedFinalScores"`
 }
 
-func TestWebsocketGolden_SnellmanS69D1L1G2_CompletesWithExpectedScores(t *testing.T) {
-	runGoldenSnellmanFixture(
-		t,
-		"testdata/4pLeague_S69_D1L1_G2.txt",
-		[]string{"Witches", "Nomads", "Darklings", "Mermaids"},
-		map[string]int{
-			"Nomads":    166,
-			"Darklings": 137,
-			"Mermaids":  130,
-			"Witches":   124,
-		},
-		true,
-	)
-}
-
-func TestWebsocketGolden_SnellmanS61D1L1G3_CompletesWithExpectedScores(t *testing.T) {
-	runGoldenSnellmanFixture(
-		t,
-		"testdata/4pLeague_S61_D1L1_G3.txt",
-		[]string{"Darklings", "Cultists", "Engineers", "Witches"},
-		map[string]int{
-			"Cultists":  151,
-			"Darklings": 151,
-			"Witches":   126,
-			"Engineers": 125,
-		},
-		true,
-	)
+func TestWebsocketGolden_FixturesCompleteWithExpectedScores(t *testing.T) {
+	for _, spec := range goldenFixtureCatalog(t) {
+		spec := spec
+		t.Run(spec.id, func(t *testing.T) {
+			if spec.skip != "" {
+				t.Skip(spec.skip)
+			}
+			runGoldenSnellmanFixture(t, spec.fixture, spec.playerID, spec.expected, true)
+		})
+	}
 }
 
 func TestWebsocketGolden_ExportActionScript(t *testing.T) {
@@ -162,9 +117,9 @@ func TestWebsocketGolden_ExportActionScript(t *testing.T) {
 		t.Skip("set TM_EXPORT_GOLDEN_ACTIONS_PATH to export a fixture action script")
 	}
 
-	spec, ok := findGoldenFixtureSpec(normalizedID)
+	spec, ok := findGoldenFixtureSpec(t, normalizedID)
 	if !ok {
-		t.Fatalf("unknown TM_EXPORT_GOLDEN_ID=%q (valid: %s)", normalizedID, strings.Join(goldenFixtureIDs(), ", "))
+		t.Fatalf("unknown TM_EXPORT_GOLDEN_ID=%q (valid: %s)", normalizedID, strings.Join(goldenFixtureIDs(t), ", "))
 	}
 
 	script := runGoldenSnellmanFixture(t, spec.fixture, spec.playerID, spec.expected, false)
@@ -285,7 +240,7 @@ func runGoldenSnellmanFixture(
 		t.Fatalf("no actions parsed from fixture")
 	}
 
-	deps, server, gameID, clients, state := setupWebsocketGoldenGame(t, playerIDs)
+	deps, server, gameID, clients, state := setupWebsocketGoldenGame(t, strings.TrimSuffix(filepath.Base(fixturePath), ".txt"), playerIDs)
 	defer server.Close()
 	defer closeConnections(clients)
 
@@ -1561,8 +1516,8 @@ func (r *goldenRunner) resolveFutureCultistsChoice(playerID string, upcoming []g
 		if action == nil {
 			continue
 		}
-		found := findCultistAdvanceInAction(action, playerID)
-		if found == nil || r.preExecutedActions[found] {
+		found := findCultistAdvanceInAction(action, playerID, r.preExecutedActions)
+		if found == nil {
 			continue
 		}
 		if err := r.executeActionWithUpcoming(found, upcoming[i:]); err != nil {
@@ -1636,23 +1591,26 @@ func leechActionPlayerID(action game.Action) string {
 	return ""
 }
 
-func findCultistAdvanceInAction(action game.Action, playerID string) *notation.LogCultistAdvanceAction {
+// findCultistAdvanceInAction returns the first "+TRACK" row of playerID in
+// action that is not in skip, so a row listing several Cultists advances maps
+// each queued leech trigger to its own advance.
+func findCultistAdvanceInAction(action game.Action, playerID string, skip map[game.Action]bool) *notation.LogCultistAdvanceAction {
 	switch a := action.(type) {
 	case *notation.LogCultistAdvanceAction:
-		if strings.TrimSpace(a.PlayerID) != playerID {
+		if strings.TrimSpace(a.PlayerID) != playerID || skip[a] {
 			return nil
 		}
 		return a
 	case *notation.LogCompoundAction:
 		for _, nested := range a.Actions {
-			if found := findCultistAdvanceInAction(nested, playerID); found != nil {
+			if found := findCultistAdvanceInAction(nested, playerID, skip); found != nil {
 				return found
 			}
 		}
 	case *notation.LogPreIncomeAction:
-		return findCultistAdvanceInAction(a.Action, playerID)
+		return findCultistAdvanceInAction(a.Action, playerID, skip)
 	case *notation.LogPostIncomeAction:
-		return findCultistAdvanceInAction(a.Action, playerID)
+		return findCultistAdvanceInAction(a.Action, playerID, skip)
 	}
 	return nil
 }
@@ -1765,7 +1723,7 @@ func actionResolvesPendingDecision(decisionType, playerID string, action game.Ac
 			return strings.TrimSpace(action.PlayerID) == playerID
 		}
 	case "cultists_cult_choice":
-		return findCultistAdvanceInAction(action, playerID) != nil
+		return findCultistAdvanceInAction(action, playerID, nil) != nil
 	case "favor_tile_selection":
 		return findFavorTileInAction(action, playerID) != nil
 	case "cult_reward_spade":
@@ -2807,64 +2765,20 @@ func isReorderableFreeAction(action game.Action, playerID string) bool {
 	}
 }
 
-func setupWebsocketGoldenGame(t *testing.T, playerIDs []string) (ServerDeps, *httptest.Server, string, map[string]*gws.Conn, map[string]any) {
+// setupWebsocketGoldenGame starts a server and a game for a fixture. With
+// TM_EXPORT_GOLDEN_STATES_DIR set, every state the game broadcasts is written
+// below <dir>/<fixtureID> for front-end snapshot tests.
+func setupWebsocketGoldenGame(t *testing.T, fixtureID string, playerIDs []string) (ServerDeps, *httptest.Server, string, map[string]*gws.Conn, map[string]any) {
 	t.Helper()
 
 	hub := NewHub()
 	go hub.Run()
-
-	deps := ServerDeps{
-		Lobby: lobby.NewManager(),
-		Games: game.NewManager(),
-	}
-
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ServeWs(hub, deps, w, r)
-	}))
-
-	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
-	clients := make(map[string]*gws.Conn, len(playerIDs))
-	for _, playerID := range playerIDs {
-		clients[playerID] = dialWS(t, wsURL)
-	}
-
-	creator := playerIDs[0]
-	sendJSON(t, clients[creator], map[string]any{
-		"type": "create_game",
-		"payload": map[string]any{
-			"name":       "golden-snellman",
-			"maxPlayers": len(playerIDs),
-			"creator":    creator,
-		},
-	})
-	created := readUntilType(t, clients[creator], "game_created", 4*time.Second)
-	gameID := asString(asMap(created["payload"])["gameId"])
-	if gameID == "" {
-		t.Fatalf("missing game id in create response")
-	}
-	_ = readUntilType(t, clients[creator], "lobby_state", 4*time.Second)
-
-	for _, playerID := range playerIDs[1:] {
-		sendJSON(t, clients[playerID], map[string]any{
-			"type": "join_game",
-			"payload": map[string]any{
-				"id":   gameID,
-				"name": playerID,
-			},
-		})
-		_ = readUntilType(t, clients[playerID], "game_joined", 4*time.Second)
+	if dir := strings.TrimSpace(os.Getenv("TM_EXPORT_GOLDEN_STATES_DIR")); dir != "" {
+		hub.SetStateExporter(NewStateExporter(filepath.Join(dir, fixtureID)))
 	}
-
-	sendJSON(t, clients[creator], map[string]any{
-		"type": "start_game",
-		"payload": map[string]any{
-			"gameID":             gameID,
-			"randomizeTurnOrder": false,
-		},
-	})
-
-	state := asMap(readUntilType(t, clients[creator], "game_state_update", 4*time.Second)["payload"])
-	return deps, server, gameID, clients, state
+	deps, server, clients := startWebsocketTestServerWithHub(t, hub, playerIDs)
+	session := testharness.CreateAndStartGame(t, clients, playerIDs, testharness.GameOptions{Name: "golden-snellman"})
+	return deps, server, session.GameID, clients, session.State
 }
 
 func applyGoldenSettings(gs *game.GameState, settings map[string]string) error {
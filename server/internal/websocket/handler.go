@@ -3,15 +3,28 @@ package websocket
 import (
 	"log"
 	"net/http"
+	"time"
 
+	"encoding/json"
 	"github.com/gorilla/websocket"
 	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/i18n"
 	"github.com/lukev/tm_server/internal/lobby"
 )
 
+// Subprotocols a client can offer to pick the encoding of server messages.
+// Clients offering neither receive JSON text frames.
+const (
+	jsonSubprotocol    = "tm.json"
+	msgpackSubprotocol = "tm.msgpack"
+)
+
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
+	// Negotiates permessage-deflate with clients that offer it.
+	EnableCompression: true,
+	Subprotocols:      []string{msgpackSubprotocol, jsonSubprotocol},
 	CheckOrigin: func(r *http.Request) bool {
 		// Allow all origins in development
 		// TODO: Restrict this in production
@@ -24,6 +37,18 @@ type ServerDeps struct {
 	Lobby *lobby.Manager
 	Games *game.Manager
 	Bots  *BotManager
+	// Messages renders localized text for clients; i18n.Default if nil.
+	Messages *i18n.Catalog
+	// AllowClientSeeds lets start_game fix the setup seed, which decides the
+	// draws of the game. Only test servers set it.
+	AllowClientSeeds bool
+}
+
+func (d ServerDeps) messages() *i18n.Catalog {
+	if d.Messages == nil {
+		return i18n.Default
+	}
+	return d.Messages
 }
 
 // ServeWs handles websocket requests from the peer.
@@ -44,7 +69,11 @@ func ServeWs(hub *Hub, deps ServerDeps, w http.ResponseWriter, r *http.Request)
 		id:          clientID,
 		deps:        deps,
 		seatsByGame: make(map[string]string),
+		// Clients pick a language with ?lang= or Accept-Language, and may
+		// change it later with set_locale.
+		locale: deps.messages().Negotiate(r.URL.Query().Get("lang"), r.Header.Get("Accept-Language")),
 	}
+	client.touch()
 	client.hub.register <- client
 
 	// Allow collection of memory referenced by the caller by doing all work in
@@ -52,3 +81,153 @@ func ServeWs(hub *Hub, deps ServerDeps, w http.ResponseWriter, r *http.Request)
 	go client.writePump()
 	go client.readPump()
 }
+
+// BroadcastGameState pushes the current state of gameID, and its pending
+// decision if any, to every client in the game. Used when a game changes
+// outside a websocket message, e.g. through an admin endpoint.
+func BroadcastGameState(hub *Hub, games *game.Manager, gameID string) {
+	gameState := games.SerializeGameState(gameID)
+	if gameState == nil {
+		return
+	}
+	broadcastGameStateUpdate(hub, gameID, gameState)
+	if pendingDecision, ok := gameState["pendingDecision"]; !ok || pendingDecision == nil {
+		return
+	}
+	if hidden, _ := gameState["hiddenResources"].(bool); !hidden {
+		hub.BroadcastToGame(gameID, decisionRequiredFor(gameState, ""))
+		return
+	}
+	hub.BroadcastToGameFor(gameID, func(c *Client) []byte {
+		return decisionRequiredFor(gameState, c.seatForGame(gameID))
+	})
+}
+
+// decisionRequiredFor renders the pending decision of gameState as seen by
+// seatID; see filterHiddenResources.
+func decisionRequiredFor(gameState map[string]interface{}, seatID string) []byte {
+	msg, _ := json.Marshal(map[string]any{
+		"type":    "decision_required",
+		"payload": filterHiddenResources(gameState, seatID)["pendingDecision"],
+	})
+	return msg
+}
+
+// BroadcastActionEvents sends the phase changes, power gains, auto-leech
+// decisions and income reports of an action result to every client in gameID,
+// and starts the final scoring reveal if the action ended the game.
+func BroadcastActionEvents(hub *Hub, games *game.Manager, gameID string, result *game.ActionResult) {
+	if result == nil {
+		return
+	}
+	BroadcastPhaseChanges(hub, gameID, result.PhaseChanges)
+	BroadcastPowerGains(hub, games, gameID, result.PowerGains)
+	BroadcastAutoLeechDecisions(hub, gameID, result.AutoLeechDecisions)
+	BroadcastIncomeReports(hub, games, gameID, result.IncomeReports)
+	if result.GameEnded {
+		go BroadcastScoringSteps(hub, games, gameID)
+	}
+}
+
+// BroadcastPhaseChanges sends one phase_changed message per transition to
+// every client in gameID.
+func BroadcastPhaseChanges(hub *Hub, gameID string, changes []game.PhaseChange) {
+	for _, change := range changes {
+		msg, _ := json.Marshal(map[string]any{
+			"type":    "phase_changed",
+			"payload": change,
+		})
+		hub.BroadcastToGame(gameID, msg)
+	}
+}
+
+// BroadcastPowerGains sends one power_gained message per gain to every client
+// in gameID, for clients to animate. With hidden resources a gain only goes to
+// the seat of the player gaining it.
+func BroadcastPowerGains(hub *Hub, games *game.Manager, gameID string, gains []game.PowerGainEvent) {
+	hidden := false
+	if gs, ok := games.GetGame(gameID); ok && gs != nil {
+		hidden = gs.HiddenResources
+	}
+	for _, gain := range gains {
+		msg, _ := json.Marshal(map[string]any{
+			"type":    "power_gained",
+			"payload": gain,
+		})
+		if !hidden {
+			hub.BroadcastToGame(gameID, msg)
+			continue
+		}
+		playerID := gain.PlayerID
+		hub.BroadcastToGameFor(gameID, func(c *Client) []byte {
+			if c.seatForGame(gameID) != playerID {
+				return nil
+			}
+			return msg
+		})
+	}
+}
+
+// BroadcastIncomeReports sends one income_report message per player who
+// received income. In games with hidden resources only the player's own seat
+// receives it.
+func BroadcastIncomeReports(hub *Hub, games *game.Manager, gameID string, reports []game.IncomeReport) {
+	hidden := false
+	if gs, ok := games.GetGame(gameID); ok && gs != nil {
+		hidden = gs.HiddenResources
+	}
+	for _, report := range reports {
+		msg, _ := json.Marshal(map[string]any{
+			"type":    "income_report",
+			"payload": report,
+		})
+		if !hidden {
+			hub.BroadcastToGame(gameID, msg)
+			continue
+		}
+		playerID := report.PlayerID
+		hub.BroadcastToGameFor(gameID, func(c *Client) []byte {
+			if c.seatForGame(gameID) != playerID {
+				return nil
+			}
+			return msg
+		})
+	}
+}
+
+// BroadcastAutoLeechDecisions sends one leech_auto_resolved message per leech
+// offer the engine answered for a player, so clients can log them apart from
+// the players' own answers.
+func BroadcastAutoLeechDecisions(hub *Hub, gameID string, decisions []game.AutoLeechDecision) {
+	for _, decision := range decisions {
+		msg, _ := json.Marshal(map[string]any{
+			"type":    "leech_auto_resolved",
+			"payload": decision,
+		})
+		hub.BroadcastToGame(gameID, msg)
+	}
+}
+
+// scoringStepDelay spaces scoring_step messages so clients can animate the
+// final scoring reveal.
+var scoringStepDelay = 1500 * time.Millisecond
+
+// BroadcastScoringSteps sends the final scoring of gameID to every client in
+// the game as a sequence of scoring_step messages, scoringStepDelay apart. It
+// blocks until the last step is sent, so callers run it in its own goroutine.
+// Clients that skip the reveal can read finalScoring from the game state.
+func BroadcastScoringSteps(hub *Hub, games *game.Manager, gameID string) {
+	for i, step := range games.FinalScoringSteps(gameID) {
+		if i > 0 {
+			time.Sleep(scoringStepDelay)
+		}
+		msg, _ := json.Marshal(map[string]any{
+			"type": "scoring_step",
+			"payload": struct {
+				GameID string `json:"gameId"`
+				game.ScoringStep
+			}{GameID: gameID, ScoringStep: step},
+		})
+		hub.BroadcastToGame(gameID, msg)
+	}
+}
@@ -0,0 +1,114 @@
+package websocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lukev/tm_server/internal/game/board"
+)
+
+// UnmarshalJSON accepts a hex as {"q": 4, "r": 3} or as its board label,
+// e.g. "F5". Labels are resolved against the game's map by resolveHexLabels
+// before the action is built.
+func (h *hexParam) UnmarshalJSON(raw []byte) error {
+	var label string
+	if err := json.Unmarshal(raw, &label); err == nil {
+		*h = hexParam{Label: strings.TrimSpace(label)}
+		return nil
+	}
+	type axial hexParam
+	var coords axial
+	if err := json.Unmarshal(raw, &coords); err != nil {
+		return err
+	}
+	*h = hexParam(coords)
+	return nil
+}
+
+// resolveHexLabels rewrites hexes given as board labels in an action payload
+// to axial coordinates on gameID's map, so built and recorded actions only
+// ever carry {q, r}. A label may stand in for any hex parameter (a key
+// containing "hex", including nested actions) and for the legacy top-level
+// hex.
+func (c *Client) resolveHexLabels(gameID string, req *performActionPayload) error {
+	gs, ok := c.deps.Games.GetGame(gameID)
+	if !ok || gs == nil || gs.Map == nil {
+		return nil
+	}
+	if req.Hex != nil && req.Hex.Label != "" {
+		hex, ok := gs.Map.HexForDisplayCoordinate(req.Hex.Label)
+		if !ok {
+			return fmt.Errorf("unknown hex label %q", req.Hex.Label)
+		}
+		req.Hex = &hexParam{Q: hex.Q, R: hex.R}
+	}
+	if len(req.Params) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(req.Params))
+	decoder.UseNumber()
+	var params any
+	if err := decoder.Decode(&params); err != nil {
+		// Left for buildActionFromPayload to report.
+		return nil
+	}
+	resolved, changed, err := resolveHexLabelValues(params, false, gs.Map)
+	if err != nil || !changed {
+		return err
+	}
+	raw, err := json.Marshal(resolved)
+	if err != nil {
+		return err
+	}
+	req.Params = raw
+	return nil
+}
+
+func resolveHexLabelValues(value any, isHex bool, m *board.TerraMysticaMap) (any, bool, error) {
+	switch v := value.(type) {
+	case string:
+		if !isHex {
+			return v, false, nil
+		}
+		hex, ok := m.HexForDisplayCoordinate(v)
+		if !ok {
+			return nil, false, fmt.Errorf("unknown hex label %q", v)
+		}
+		return map[string]int{"q": hex.Q, "r": hex.R}, true, nil
+	case []any:
+		changed := false
+		for i, item := range v {
+			resolved, itemChanged, err := resolveHexLabelValues(item, isHex, m)
+			if err != nil {
+				return nil, false, err
+			}
+			v[i] = resolved
+			changed = changed || itemChanged
+		}
+		return v, changed, nil
+	case map[string]any:
+		changed := false
+		for key, item := range v {
+			resolved, itemChanged, err := resolveHexLabelValues(item, strings.Contains(strings.ToLower(key), "hex"), m)
+			if err != nil {
+				return nil, false, err
+			}
+			v[key] = resolved
+			changed = changed || itemChanged
+		}
+		return v, changed, nil
+	default:
+		return v, false, nil
+	}
+}
+
+// labelHexes adds board labels to the axial hexes in a message about gameID.
+func (c *Client) labelHexes(gameID, text string) string {
+	gs, ok := c.deps.Games.GetGame(gameID)
+	if !ok || gs == nil {
+		return text
+	}
+	return gs.Map.LabelHexes(text)
+}
@@ -0,0 +1,171 @@
+package websocket
+
+import "encoding/json"
+
+// gameStateUpdateFor renders a game_state_update of gameState for a client
+// seated as seatID, or for a spectator when seatID is empty.
+func gameStateUpdateFor(gameState map[string]interface{}, seatID string) []byte {
+	msg, _ := json.Marshal(map[string]any{
+		"type":    "game_state_update",
+		"payload": filterHiddenResources(gameState, seatID),
+	})
+	return msg
+}
+
+// broadcastGameStateUpdate sends gameState to every client in gameID. Games
+// with hidden resources get one payload per seat; other games share a single
+// message. The update is also handed to the hub's state exporter, if any.
+func broadcastGameStateUpdate(hub *Hub, gameID string, gameState map[string]interface{}) {
+	hub.exportState(gameID, gameState)
+	if hidden, _ := gameState["hiddenResources"].(bool); !hidden {
+		hub.BroadcastToGame(gameID, gameStateUpdateFor(gameState, ""))
+		return
+	}
+	hub.BroadcastToGameFor(gameID, func(c *Client) []byte {
+		return gameStateUpdateFor(gameState, c.seatForGame(gameID))
+	})
+}
+
+// filterHiddenResources withholds the coins, power bowls and power statistics
+// of every player but seatID who has not passed yet, in the player entries
+// and the opponent summaries alike, marking them with resourcesHidden. Their
+// available special actions, some of which depend on power, are left out too.
+// Pass income warnings, which give away power bowls, and the must-pass flag
+// are kept for seatID only, and their leech offers are shown uncapped. It
+// copies what it changes, so gameState can be filtered for several seats.
+func filterHiddenResources(gameState map[string]interface{}, seatID string) map[string]interface{} {
+	if hidden, _ := gameState["hiddenResources"].(bool); !hidden {
+		return gameState
+	}
+	players, ok := gameState["players"].(map[string]interface{})
+	if !ok {
+		return gameState
+	}
+
+	hiddenPlayers := make(map[string]bool, len(players))
+	filteredPlayers := make(map[string]interface{}, len(players))
+	for playerID, raw := range players {
+		filteredPlayers[playerID] = raw
+		player, ok := raw.(map[string]interface{})
+		if !ok || playerID == seatID {
+			continue
+		}
+		if passed, _ := player["hasPassed"].(bool); passed {
+			continue
+		}
+		filtered := make(map[string]interface{}, len(player)+1)
+		for key, value := range player {
+			filtered[key] = value
+		}
+		if resources, ok := player["resources"].(map[string]interface{}); ok {
+			filtered["resources"] = visibleResources(resources)
+		}
+		delete(filtered, "powerGained")
+		delete(filtered, "availableSpecialActions")
+		filtered["resourcesHidden"] = true
+		filteredPlayers[playerID] = filtered
+		hiddenPlayers[playerID] = true
+	}
+
+	filteredState := make(map[string]interface{}, len(gameState))
+	for key, value := range gameState {
+		filteredState[key] = value
+	}
+	filteredState["players"] = filteredPlayers
+	if summaries, ok := gameState["opponentSummaries"].([]interface{}); ok {
+		filteredState["opponentSummaries"] = filterHiddenSummaries(summaries, hiddenPlayers)
+	}
+	if warnings, ok := gameState["passIncomeWarnings"].(map[string]interface{}); ok {
+		own := map[string]interface{}{}
+		if seatWarnings, ok := warnings[seatID]; ok {
+			own[seatID] = seatWarnings
+		}
+		filteredState["passIncomeWarnings"] = own
+	}
+	if mustPass, _ := gameState["mustPassPlayerId"].(string); mustPass != seatID {
+		delete(filteredState, "mustPassPlayerId")
+	}
+	if offers, ok := gameState["pendingLeechOffers"].(map[string]interface{}); ok {
+		filteredOffers := make(map[string]interface{}, len(offers))
+		for playerID, playerOffers := range offers {
+			filteredOffers[playerID] = playerOffers
+			if list, ok := playerOffers.([]interface{}); ok && hiddenPlayers[playerID] {
+				filteredOffers[playerID] = uncappedLeechOffers(list)
+			}
+		}
+		filteredState["pendingLeechOffers"] = filteredOffers
+	}
+	if decision, ok := gameState["pendingDecision"].(map[string]interface{}); ok && decision["type"] == "leech_offer" {
+		if playerID, _ := decision["playerId"].(string); hiddenPlayers[playerID] {
+			uncapped := make(map[string]interface{}, len(decision))
+			for key, value := range decision {
+				uncapped[key] = value
+			}
+			if offers, ok := decision["offers"].([]interface{}); ok {
+				uncapped["offers"] = uncappedLeechOffers(offers)
+			}
+			filteredState["pendingDecision"] = uncapped
+		}
+	}
+	return filteredState
+}
+
+// uncappedLeechOffers copies offers with the capped amount and VP cost worked
+// out from the offered amount alone, since the cap gives away the receiver's
+// power bowls.
+func uncappedLeechOffers(offers []interface{}) []interface{} {
+	uncapped := make([]interface{}, len(offers))
+	for i, raw := range offers {
+		uncapped[i] = raw
+		offer, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		amount, _ := offer["Amount"].(float64)
+		copied := make(map[string]interface{}, len(offer))
+		for key, value := range offer {
+			copied[key] = value
+		}
+		copied["cappedAmount"] = amount
+		copied["VPCost"] = max(0, amount-1)
+		uncapped[i] = copied
+	}
+	return uncapped
+}
+
+// filterHiddenSummaries withholds coins and power from the opponent summaries
+// of hiddenPlayers, copying the entries it changes.
+func filterHiddenSummaries(summaries []interface{}, hiddenPlayers map[string]bool) []interface{} {
+	filtered := make([]interface{}, len(summaries))
+	for i, raw := range summaries {
+		filtered[i] = raw
+		summary, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if playerID, _ := summary["playerId"].(string); !hiddenPlayers[playerID] {
+			continue
+		}
+		hidden := make(map[string]interface{}, len(summary)+1)
+		for key, value := range summary {
+			hidden[key] = value
+		}
+		if resources, ok := summary["resources"].(map[string]interface{}); ok {
+			hidden["resources"] = visibleResources(resources)
+		}
+		hidden["resourcesHidden"] = true
+		filtered[i] = hidden
+	}
+	return filtered
+}
+
+// visibleResources is resources without coins and power.
+func visibleResources(resources map[string]interface{}) map[string]interface{} {
+	visible := make(map[string]interface{}, len(resources))
+	for key, value := range resources {
+		if key != "coins" && key != "power" {
+			visible[key] = value
+		}
+	}
+	return visible
+}
@@ -0,0 +1,57 @@
+package websocket
+
+import (
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/game/factions"
+)
+
+func TestFilterHiddenResources_WithholdsOpponentPowerHints(t *testing.T) {
+	gs := game.NewGameState()
+	for id, faction := range map[string]factions.Faction{"p1": factions.NewCultists(), "p2": factions.NewWitches()} {
+		if err := gs.AddPlayer(id, faction); err != nil {
+			t.Fatalf("add %s: %v", id, err)
+		}
+	}
+	gs.TurnOrder = []string{"p1", "p2"}
+	gs.Phase = game.PhaseAction
+	gs.HiddenResources = true
+	gs.PendingLeechOffers["p2"] = []*game.PowerLeechOffer{{Amount: 3, CappedAmount: 1, VPCost: 0, FromPlayerID: "p1"}}
+	gs.MustPassPlayerID = "p2"
+	games := game.NewManager()
+	games.CreateGameWithState("g1", gs)
+	gameState := games.SerializeGameState("g1")
+
+	opponent := filterHiddenResources(gameState, "p1")
+	offer := asMap(asMap(opponent["pendingLeechOffers"])["p2"].([]interface{})[0])
+	if offer["cappedAmount"] != 3.0 || offer["VPCost"] != 2.0 {
+		t.Fatalf("expected p2's offer uncapped for p1, got %v", offer)
+	}
+	decision := asMap(opponent["pendingDecision"])
+	if decision["type"] != "leech_offer" || asMap(decision["offers"].([]interface{})[0])["cappedAmount"] != 3.0 {
+		t.Fatalf("expected the leech decision uncapped for p1, got %v", decision)
+	}
+	if _, ok := opponent["mustPassPlayerId"]; ok {
+		t.Fatalf("expected p2's must-pass flag withheld from p1")
+	}
+	if _, ok := asMap(asMap(opponent["players"])["p2"])["availableSpecialActions"]; ok {
+		t.Fatalf("expected p2's special actions withheld from p1")
+	}
+
+	own := filterHiddenResources(gameState, "p2")
+	offer = asMap(asMap(own["pendingLeechOffers"])["p2"].([]interface{})[0])
+	if offer["cappedAmount"] != 1.0 {
+		t.Fatalf("expected p2 to see its own capped offer, got %v", offer)
+	}
+	if own["mustPassPlayerId"] != "p2" {
+		t.Fatalf("expected p2 to keep its must-pass flag, got %v", own["mustPassPlayerId"])
+	}
+	if _, ok := asMap(asMap(own["players"])["p2"])["availableSpecialActions"]; !ok {
+		t.Fatalf("expected p2 to keep its own special actions")
+	}
+	shared := asMap(asMap(gameState["pendingLeechOffers"])["p2"].([]interface{})[0])
+	if shared["cappedAmount"] != 1.0 {
+		t.Fatalf("expected the shared state left untouched, got %v", shared)
+	}
+}
@@ -0,0 +1,20 @@
+package websocket
+
+// actingSeat is the seat this client acts for in gameID. In hotseat games the
+// host's client may act for any player it names; elsewhere, for other
+// clients, or when it names none, a client acts for its own seat, so a remote
+// player seated in a hotseat game cannot play another human's turns. It is
+// empty when the client has no seat.
+func (c *Client) actingSeat(gameID, requested string) string {
+	seatID := c.seatForGame(gameID)
+	if seatID == "" || requested == "" || requested == seatID {
+		return seatID
+	}
+	if !c.deps.Games.IsHotseat(gameID) {
+		return seatID
+	}
+	if meta, ok := c.deps.Lobby.GetGame(gameID); !ok || meta.Host != seatID {
+		return seatID
+	}
+	return requested
+}
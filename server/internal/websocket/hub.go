@@ -3,11 +3,15 @@ package websocket
 import (
 	"log"
 	"sync"
+	"time"
 )
 
 type gameBroadcastMessage struct {
 	GameID  string
 	Message []byte
+	// Render, when set, builds the message for each subscriber instead of
+	// Message. A nil result skips the subscriber.
+	Render func(*Client) []byte
 }
 
 // Hub maintains connected websocket clients and room subscriptions.
@@ -23,6 +27,13 @@ type Hub struct {
 
 	gameSubscribers map[string]map[*Client]bool
 	clientGames     map[*Client]map[string]bool
+
+	// presence holds the status of every connected player as of the last
+	// presence check; onPresenceChange is called when it changes.
+	presence         map[string]string
+	onPresenceChange func()
+
+	stateExporter *StateExporter
 }
 
 // NewHub creates a new Hub instance.
@@ -35,22 +46,32 @@ func NewHub() *Hub {
 		clients:         make(map[*Client]bool),
 		gameSubscribers: make(map[string]map[*Client]bool),
 		clientGames:     make(map[*Client]map[string]bool),
+		presence:        make(map[string]string),
 	}
 }
 
 // Run starts the hub loop.
 func (h *Hub) Run() {
+	presenceTicker := time.NewTicker(presenceCheckPeriod)
+	defer presenceTicker.Stop()
 	for {
 		select {
 		case client := <-h.register:
 			h.mu.Lock()
 			h.clients[client] = true
+			h.checkPresenceLocked()
 			h.mu.Unlock()
 			log.Printf("Client connected. Total clients: %d", len(h.clients))
 
 		case client := <-h.unregister:
 			h.mu.Lock()
 			h.unregisterClientLocked(client)
+			h.checkPresenceLocked()
+			h.mu.Unlock()
+
+		case <-presenceTicker.C:
+			h.mu.Lock()
+			h.checkPresenceLocked()
 			h.mu.Unlock()
 
 		case message := <-h.broadcast:
@@ -63,7 +84,13 @@ func (h *Hub) Run() {
 		case msg := <-h.gameBroadcast:
 			h.mu.RLock()
 			for client := range h.gameSubscribers[msg.GameID] {
-				h.sendToClientLocked(client, msg.Message)
+				message := msg.Message
+				if msg.Render != nil {
+					if message = msg.Render(client); message == nil {
+						continue
+					}
+				}
+				h.sendToClientLocked(client, message)
 			}
 			h.mu.RUnlock()
 		}
@@ -122,6 +149,12 @@ func (h *Hub) BroadcastToGame(gameID string, message []byte) {
 	h.gameBroadcast <- gameBroadcastMessage{GameID: gameID, Message: message}
 }
 
+// BroadcastToGameFor sends each subscriber of a game room the message render
+// builds for it.
+func (h *Hub) BroadcastToGameFor(gameID string, render func(*Client) []byte) {
+	h.gameBroadcast <- gameBroadcastMessage{GameID: gameID, Render: render}
+}
+
 // JoinGame subscribes a client to a game room.
 func (h *Hub) JoinGame(client *Client, gameID string) {
 	h.mu.Lock()
@@ -162,6 +195,51 @@ func (h *Hub) LeaveGame(client *Client, gameID string) {
 	}
 }
 
+// InGame reports whether client is subscribed to gameID's room.
+func (h *Hub) InGame(client *Client, gameID string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.clientGames[client][gameID]
+}
+
+// RemoveSeat unbinds every client seated as playerID in gameID, takes it out
+// of the game room and sends it message.
+func (h *Hub) RemoveSeat(gameID, playerID string, message []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for client := range h.gameSubscribers[gameID] {
+		if client.seatForGame(gameID) != playerID {
+			continue
+		}
+		client.unbindSeat(gameID)
+		delete(h.gameSubscribers[gameID], client)
+		if games := h.clientGames[client]; games != nil {
+			delete(games, gameID)
+			if len(games) == 0 {
+				delete(h.clientGames, client)
+			}
+		}
+		h.sendToClientLocked(client, message)
+	}
+	if len(h.gameSubscribers[gameID]) == 0 {
+		delete(h.gameSubscribers, gameID)
+	}
+}
+
+// SendToSeat sends message to every connected client seated as playerID in
+// gameID, whether or not it is in the game room.
+func (h *Hub) SendToSeat(gameID, playerID string, message []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for client := range h.clients {
+		if client.seatForGame(gameID) == playerID {
+			h.sendToClientLocked(client, message)
+		}
+	}
+}
+
 // GetClientCount returns connected clients.
 func (h *Hub) GetClientCount() int {
 	h.mu.RLock()
@@ -1,8 +1,13 @@
 package websocket
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
+
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/game/factions"
+	"github.com/lukev/tm_server/internal/lobby"
 )
 
 func TestHubBroadcastToGame_IsRoomScoped(t *testing.T) {
@@ -39,3 +44,198 @@ func TestHubBroadcastToGame_IsRoomScoped(t *testing.T) {
 	hub.unregister <- c1
 	hub.unregister <- c2
 }
+
+func TestBroadcastScoringSteps_SendsStepsInOrder(t *testing.T) {
+	previousDelay := scoringStepDelay
+	scoringStepDelay = 0
+	defer func() { scoringStepDelay = previousDelay }()
+
+	hub := NewHub()
+	go hub.Run()
+	c := &Client{hub: hub, send: make(chan []byte, 16), seatsByGame: make(map[string]string)}
+	hub.register <- c
+	hub.JoinGame(c, "g1")
+	defer func() { hub.unregister <- c }()
+
+	gs := game.NewGameState()
+	gs.AddPlayer("p1", factions.NewAuren())
+	gs.AddPlayer("p2", factions.NewSwarmlings())
+	gs.Phase = game.PhaseEnd
+	gs.FinalScoring = gs.CalculateFinalScoring()
+	games := game.NewManager()
+	games.CreateGameWithState("g1", gs)
+
+	BroadcastScoringSteps(hub, games, "g1")
+
+	want := len(gs.FinalScoringSteps())
+	for i := 0; i < want; i++ {
+		select {
+		case raw := <-c.send:
+			var msg struct {
+				Type    string `json:"type"`
+				Payload struct {
+					GameID string `json:"gameId"`
+					Index  int    `json:"index"`
+					Count  int    `json:"count"`
+				} `json:"payload"`
+			}
+			if err := json.Unmarshal(raw, &msg); err != nil {
+				t.Fatalf("invalid message: %v", err)
+			}
+			if msg.Type != "scoring_step" || msg.Payload.GameID != "g1" || msg.Payload.Index != i || msg.Payload.Count != want {
+				t.Fatalf("unexpected step %d: %s", i, raw)
+			}
+		case <-time.After(500 * time.Millisecond):
+			t.Fatalf("timed out waiting for scoring step %d", i)
+		}
+	}
+}
+
+func TestHubPresence_TracksSeatsIdleAndDisconnects(t *testing.T) {
+	previousIdle := idleAfter
+	idleAfter = time.Minute
+	defer func() { idleAfter = previousIdle }()
+
+	hub := NewHub()
+	go hub.Run()
+	changed := make(chan struct{}, 8)
+	hub.SetPresenceListener(func() { changed <- struct{}{} })
+
+	active := &Client{hub: hub, send: make(chan []byte, 8), seatsByGame: map[string]string{"1": "alice"}}
+	active.touch()
+	silent := &Client{hub: hub, send: make(chan []byte, 8), seatsByGame: map[string]string{"2": "bob"}}
+	silent.lastActive.Store(time.Now().Add(-2 * time.Minute).UnixNano())
+	waitForChange := func() {
+		t.Helper()
+		select {
+		case <-changed:
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for a presence change")
+		}
+	}
+	hub.register <- active
+	waitForChange()
+	hub.register <- silent
+	waitForChange()
+
+	if got := hub.PlayerStatus("alice"); got != lobby.StatusOnline {
+		t.Fatalf("expected alice online, got %q", got)
+	}
+	if got := hub.PlayerStatus("bob"); got != lobby.StatusIdle {
+		t.Fatalf("expected bob idle, got %q", got)
+	}
+	if got := hub.PlayerStatus("carol"); got != lobby.StatusOffline {
+		t.Fatalf("expected carol offline, got %q", got)
+	}
+
+	hub.unregister <- active
+	waitForChange()
+	if got := hub.PlayerStatus("alice"); got != lobby.StatusOffline {
+		t.Fatalf("expected alice offline after disconnecting, got %q", got)
+	}
+	hub.unregister <- silent
+}
+
+func TestSendTurnReminders_ReachesTheSeatOnly(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	seated := &Client{hub: hub, send: make(chan []byte, 8), seatsByGame: map[string]string{"g1": "p1"}}
+	other := &Client{hub: hub, send: make(chan []byte, 8), seatsByGame: map[string]string{"g1": "p2"}}
+	hub.register <- seated
+	hub.register <- other
+	defer func() {
+		hub.unregister <- seated
+		hub.unregister <- other
+	}()
+	// The seated player is not in the game room, e.g. while in the lobby.
+	hub.JoinGame(other, "g1")
+
+	sendTurnReminders(hub, []game.TurnReminder{{GameID: "g1", PlayerID: "p1", WaitingMs: 10_000}})
+
+	select {
+	case raw := <-seated.send:
+		var msg struct {
+			Type    string            `json:"type"`
+			Payload game.TurnReminder `json:"payload"`
+		}
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			t.Fatalf("decode reminder: %v", err)
+		}
+		if msg.Type != "turn_reminder" || msg.Payload.PlayerID != "p1" || msg.Payload.WaitingMs != 10_000 {
+			t.Fatalf("unexpected reminder %s", raw)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("timed out waiting for the reminder")
+	}
+	select {
+	case got := <-other.send:
+		t.Fatalf("other seat should not be reminded, got %s", got)
+	case <-time.After(150 * time.Millisecond):
+	}
+}
+
+func TestAllowAnnotationAdd_LimitsAddsPerWindow(t *testing.T) {
+	c := &Client{}
+	start := time.Unix(1_700_000_000, 0)
+	for i := 0; i < maxAnnotationAdds; i++ {
+		if !c.allowAnnotationAdd(start.Add(time.Duration(i) * time.Second)) {
+			t.Fatalf("expected add %d to be allowed", i)
+		}
+	}
+	if c.allowAnnotationAdd(start.Add(30 * time.Second)) {
+		t.Fatalf("expected the add over the limit to be refused")
+	}
+	if !c.allowAnnotationAdd(start.Add(annotationAddWindow)) {
+		t.Fatalf("expected an add once the oldest left the window")
+	}
+}
+
+func TestSweepVacations_BroadcastsEndedGames(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	games := game.NewManager()
+	if err := games.SetVacationPolicy(&game.VacationPolicy{AnnualBudget: time.Millisecond}); err != nil {
+		t.Fatalf("set vacation policy: %v", err)
+	}
+	if err := games.CreateGame("g1", []string{"away", "home"}); err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+	if _, _, err := games.SetVacation("away", true); err != nil {
+		t.Fatalf("start vacation: %v", err)
+	}
+
+	c := &Client{hub: hub, send: make(chan []byte, 8), seatsByGame: make(map[string]string)}
+	hub.register <- c
+	hub.JoinGame(c, "g1")
+
+	time.Sleep(5 * time.Millisecond)
+	ended, err := SweepVacations(hub, ServerDeps{Games: games, Lobby: lobby.NewManager()})
+	if err != nil {
+		t.Fatalf("sweep vacations: %v", err)
+	}
+	if len(ended) != 1 || ended[0] != "away" {
+		t.Fatalf("ended = %v, want [away]", ended)
+	}
+
+	select {
+	case got := <-c.send:
+		var msg struct {
+			Type    string `json:"type"`
+			Payload struct {
+				ID string `json:"id"`
+			} `json:"payload"`
+		}
+		if err := json.Unmarshal(got, &msg); err != nil {
+			t.Fatalf("decode message: %v", err)
+		}
+		if msg.Type != "game_state_update" || msg.Payload.ID != "g1" {
+			t.Fatalf("message = %s, want g1 game_state_update", string(got))
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("timed out waiting for g1 game state")
+	}
+
+	hub.unregister <- c
+}
@@ -0,0 +1,153 @@
+package websocket
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/lukev/tm_server/internal/az/actions"
+	"github.com/lukev/tm_server/internal/game"
+)
+
+type getLegalActionsPayload struct {
+	GameID string `json:"gameID"`
+	// PlayerID picks the seat in hotseat games.
+	PlayerID string `json:"playerId,omitempty"`
+}
+
+type performLegalActionPayload struct {
+	GameID           string `json:"gameID"`
+	OptionID         string `json:"optionId"`
+	ActionID         string `json:"actionId,omitempty"`
+	ExpectedRevision *int   `json:"expectedRevision,omitempty"`
+	// PlayerID picks the seat in hotseat games.
+	PlayerID string `json:"playerId,omitempty"`
+	// Confirmed confirms a pass or power burn, including the burn paying for a
+	// power action, for players who asked to confirm them.
+	Confirmed bool `json:"confirmed,omitempty"`
+}
+
+// legalActionsFor returns the legal actions of seatID in gameID, with the
+// revision they were generated at. It is empty when seatID is not to move.
+func (c *Client) legalActionsFor(gameID, seatID string) ([]actions.Option, int, bool) {
+	gs, revision, ok := c.deps.Games.GetGameSnapshot(gameID)
+	if !ok || gs == nil {
+		return nil, 0, false
+	}
+	seated := []actions.Option{}
+	if gs.Phase == game.PhaseEnd {
+		return seated, revision, true
+	}
+	for _, option := range actions.LegalActions(gs) {
+		if option.PlayerID == seatID {
+			seated = append(seated, option)
+		}
+	}
+	return seated, revision, true
+}
+
+// handleGetLegalActions lists the actions the client's seat may take right
+// now. Each option's ID can be sent back with perform_legal_action.
+func (c *Client) handleGetLegalActions(payload json.RawMessage) {
+	var p getLegalActionsPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		c.sendError("invalid_payload")
+		return
+	}
+	seatID := c.actingSeat(p.GameID, p.PlayerID)
+	if seatID == "" {
+		c.sendError("not_in_game")
+		return
+	}
+	options, revision, ok := c.legalActionsFor(p.GameID, seatID)
+	if !ok {
+		c.sendError("game_not_found")
+		return
+	}
+	msg, _ := json.Marshal(map[string]any{
+		"type": "legal_actions",
+		"payload": map[string]any{
+			"gameId":   p.GameID,
+			"playerId": seatID,
+			"revision": revision,
+			"actions":  options,
+		},
+	})
+	c.send <- msg
+}
+
+// handlePerformLegalAction executes one of the options get_legal_actions
+// listed. The option is looked up again, so a stale ID is rejected rather
+// than applied to a different position.
+func (c *Client) handlePerformLegalAction(payload json.RawMessage) {
+	var p performLegalActionPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		c.sendActionNack("", "", "invalid_action_payload", "invalid action payload", nil)
+		return
+	}
+	seatID := c.actingSeat(p.GameID, p.PlayerID)
+	if seatID == "" {
+		c.sendActionNack(p.ActionID, p.GameID, "unauthorized", "you are not seated in this game", nil)
+		return
+	}
+	options, revision, ok := c.legalActionsFor(p.GameID, seatID)
+	if !ok {
+		c.sendActionNack(p.ActionID, p.GameID, "game_not_found", "game not found", nil)
+		return
+	}
+	var selected *actions.Option
+	for i := range options {
+		if options[i].ID == p.OptionID {
+			selected = &options[i]
+			break
+		}
+	}
+	if selected == nil {
+		c.sendActionNack(p.ActionID, p.GameID, "action_rejected", "option is not a legal action: "+p.OptionID, nil)
+		return
+	}
+
+	// Options are generated against this revision, so they are only executed
+	// against it, whatever the client expected.
+	if p.ExpectedRevision != nil && *p.ExpectedRevision != revision {
+		c.sendActionFailed(p.ActionID, p.GameID, &game.RevisionMismatchError{Expected: *p.ExpectedRevision, Current: revision})
+		return
+	}
+	if c.deps.Games.IsHotseat(p.GameID) {
+		log.Printf("hotseat game %s: %s played option %s for seat %s", p.GameID, c.seatForGame(p.GameID), p.OptionID, seatID)
+	}
+	action := selected.Action
+	if p.Confirmed {
+		action = confirmedAction(action)
+	}
+	result, err := c.deps.Games.ExecuteActionWithMeta(p.GameID, action, game.ActionMeta{
+		ActionID:               p.ActionID,
+		ExpectedRevision:       revision,
+		SeatID:                 seatID,
+		AllowAZAutoConversions: true,
+	})
+	if err != nil {
+		c.sendActionFailed(p.ActionID, p.GameID, err)
+		return
+	}
+	c.sendActionAccepted(p.ActionID, p.GameID, result)
+}
+
+// confirmedAction returns a confirmed copy of a pass, power burn or power
+// action, and other actions unchanged.
+func confirmedAction(action game.Action) game.Action {
+	switch a := action.(type) {
+	case *game.PassAction:
+		confirmed := *a
+		confirmed.Confirmed = true
+		return &confirmed
+	case *game.BurnPowerAction:
+		confirmed := *a
+		confirmed.Confirmed = true
+		return &confirmed
+	case *game.PowerAction:
+		confirmed := *a
+		confirmed.Confirmed = true
+		return &confirmed
+	}
+	return action
+}
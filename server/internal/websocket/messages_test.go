@@ -0,0 +1,112 @@
+package websocket
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/i18n"
+)
+
+// errorCodeArgs is the argument that carries the error code, by the name of
+// the function sending it.
+var errorCodeArgs = map[string]int{
+	"sendError":          0,
+	"sendActionRejected": 1,
+	"sendActionNack":     2,
+}
+
+// sentErrorCodes lists the error code literals the package's sources send,
+// including those set as the "error" field of a payload.
+func sentErrorCodes(t *testing.T) []string {
+	t.Helper()
+	paths, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("list sources: %v", err)
+	}
+	codes := map[string]bool{}
+	addLiteral := func(expr ast.Expr) {
+		lit, ok := expr.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return
+		}
+		if code, err := strconv.Unquote(lit.Value); err == nil && code != "" {
+			codes[code] = true
+		}
+	}
+	isErrorKey := func(expr ast.Expr) bool {
+		lit, ok := expr.(*ast.BasicLit)
+		return ok && lit.Value == `"error"`
+	}
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatalf("parse %s: %v", path, err)
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.CallExpr:
+				if sel, ok := n.Fun.(*ast.SelectorExpr); ok {
+					if i, ok := errorCodeArgs[sel.Sel.Name]; ok && i < len(n.Args) {
+						addLiteral(n.Args[i])
+					}
+				}
+			case *ast.KeyValueExpr:
+				if isErrorKey(n.Key) {
+					addLiteral(n.Value)
+				}
+			case *ast.AssignStmt:
+				for i, lhs := range n.Lhs {
+					if index, ok := lhs.(*ast.IndexExpr); ok && isErrorKey(index.Index) && i < len(n.Rhs) {
+						addLiteral(n.Rhs[i])
+					}
+				}
+			}
+			return true
+		})
+	}
+	sorted := make([]string, 0, len(codes))
+	for code := range codes {
+		sorted = append(sorted, code)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func TestErrorCodes_HaveEnglishMessages(t *testing.T) {
+	messages := i18n.NewCatalog().Messages(i18n.DefaultLocale)
+	codes := sentErrorCodes(t)
+	if len(codes) == 0 {
+		t.Fatalf("found no error codes in the sources")
+	}
+	for _, code := range codes {
+		if _, ok := messages["error."+code]; !ok {
+			t.Errorf("error code %q has no error.%s message", code, code)
+		}
+	}
+}
+
+func TestScoringSteps_HaveEnglishMessages(t *testing.T) {
+	messages := i18n.NewCatalog().Messages(i18n.DefaultLocale)
+	for _, category := range []game.ScoringStepCategory{
+		game.ScoringStepArea,
+		game.ScoringStepFireIce,
+		game.ScoringStepCult,
+		game.ScoringStepResources,
+		game.ScoringStepFaction,
+	} {
+		if _, ok := messages["event.scoring_step."+string(category)]; !ok {
+			t.Errorf("scoring step %q has no message", category)
+		}
+	}
+}
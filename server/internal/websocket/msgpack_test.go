@@ -0,0 +1,158 @@
+package websocket
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	gws "github.com/gorilla/websocket"
+	"github.com/lukev/tm_server/internal/game"
+	"github.com/lukev/tm_server/internal/lobby"
+	"github.com/lukev/tm_server/internal/replay"
+	"github.com/lukev/tm_server/internal/testharness"
+)
+
+func TestJSONToMsgpack_EncodesDocument(t *testing.T) {
+	got, err := jsonToMsgpack([]byte(`{"type":"x","n":[1,-1,200,-200,1.5],"ok":true,"none":null}`))
+	if err != nil {
+		t.Fatalf("jsonToMsgpack failed: %v", err)
+	}
+	want := []byte{
+		0x84,
+		0xa1, 'n', 0x95, 0x01, 0xff, 0xcc, 0xc8, 0xd1, 0xff, 0x38, 0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0,
+		0xa4, 'n', 'o', 'n', 'e', 0xc0,
+		0xa2, 'o', 'k', 0xc3,
+		0xa4, 't', 'y', 'p', 'e', 0xa1, 'x',
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("unexpected encoding:\n got % x\nwant % x", got, want)
+	}
+}
+
+func TestJSONToMsgpack_UsesLengthPrefixedForms(t *testing.T) {
+	long := bytes.Repeat([]byte("a"), 40)
+	items := make([]int, 20)
+	raw, _ := json.Marshal(map[string]any{"s": string(long), "a": items})
+	got, err := jsonToMsgpack(raw)
+	if err != nil {
+		t.Fatalf("jsonToMsgpack failed: %v", err)
+	}
+	if !bytes.Contains(got, append([]byte{0xa1, 'a', 0xdc, 0x00, 20}, 0x00)) {
+		t.Fatalf("expected array16 header for 20 items: % x", got)
+	}
+	if !bytes.Contains(got, append([]byte{0xa1, 's', 0xd9, 40}, long...)) {
+		t.Fatalf("expected str8 header for 40-byte string: % x", got)
+	}
+}
+
+func TestServeWs_NegotiatesMsgpackAndCompression(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+	deps := ServerDeps{Lobby: lobby.NewManager(), Games: game.NewManager()}
+	server, wsURL := testharness.StartServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeWs(hub, deps, w, r)
+	}))
+	defer server.Close()
+
+	dialer := gws.Dialer{EnableCompression: true, Subprotocols: []string{msgpackSubprotocol}}
+	conn, resp, err := dialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	if conn.Subprotocol() != msgpackSubprotocol {
+		t.Fatalf("expected %s subprotocol, got %q", msgpackSubprotocol, conn.Subprotocol())
+	}
+	if ext := resp.Header.Get("Sec-WebSocket-Extensions"); !bytes.Contains([]byte(ext), []byte("permessage-deflate")) {
+		t.Fatalf("expected permessage-deflate to be negotiated, got %q", ext)
+	}
+
+	sendJSON(t, conn, map[string]any{"type": "list_games"})
+	lobbyType := append([]byte{0xa0 | byte(len("lobby_state"))}, "lobby_state"...)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		messageType, msg, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("read failed before lobby_state: %v", err)
+		}
+		if messageType != gws.BinaryMessage {
+			t.Fatalf("expected binary msgpack frame, got type %d: %s", messageType, msg)
+		}
+		if bytes.HasSuffix(msg, lobbyType) {
+			return
+		}
+	}
+}
+
+func TestServeWs_DefaultsToJSONText(t *testing.T) {
+	_, server, clients := startWebsocketTestServer(t, []string{"p1"})
+	defer server.Close()
+	defer closeConnections(clients)
+
+	conn := clients["p1"]
+	if conn.Subprotocol() != "" {
+		t.Fatalf("expected no subprotocol, got %q", conn.Subprotocol())
+	}
+	sendJSON(t, conn, map[string]any{"type": "list_games"})
+	readUntilType(t, conn, "lobby_state", 2*time.Second)
+}
+
+// BenchmarkLateGameStatePayload reports the size of a finished 4-player
+// game_state_update per encoding, plain and deflated.
+func BenchmarkLateGameStatePayload(b *testing.B) {
+	fixture, err := snellmanFixtureFS.ReadFile("testdata/4pLeague_S69_D1L1_G2.txt")
+	if err != nil {
+		b.Fatalf("read fixture: %v", err)
+	}
+	manager := replay.NewReplayManager(b.TempDir())
+	if err := manager.ImportText("bench", string(fixture), "snellman"); err != nil {
+		b.Fatalf("import fixture: %v", err)
+	}
+	session := manager.GetSession("bench")
+	if err := manager.JumpTo("bench", len(session.Simulator.Actions)); err != nil {
+		b.Fatalf("replay fixture: %v", err)
+	}
+	message, err := json.Marshal(map[string]any{
+		"type":    "game_state_update",
+		"payload": game.SerializeState(session.Simulator.GetState(), "bench"),
+	})
+	if err != nil {
+		b.Fatalf("marshal state: %v", err)
+	}
+
+	// gorilla deflates at flate.BestSpeed unless SetCompressionLevel is used.
+	deflate := func(b *testing.B, payload []byte) int {
+		var buf bytes.Buffer
+		w, err := flate.NewWriter(&buf, flate.BestSpeed)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_, _ = w.Write(payload)
+		_ = w.Close()
+		return buf.Len()
+	}
+
+	b.Run("json", func(b *testing.B) {
+		var deflated int
+		for i := 0; i < b.N; i++ {
+			deflated = deflate(b, message)
+		}
+		b.ReportMetric(float64(len(message)), "bytes/msg")
+		b.ReportMetric(float64(deflated), "deflated-bytes/msg")
+	})
+	b.Run("msgpack", func(b *testing.B) {
+		var encoded []byte
+		var deflated int
+		for i := 0; i < b.N; i++ {
+			if encoded, err = jsonToMsgpack(message); err != nil {
+				b.Fatal(err)
+			}
+			deflated = deflate(b, encoded)
+		}
+		b.ReportMetric(float64(len(encoded)), "bytes/msg")
+		b.ReportMetric(float64(deflated), "deflated-bytes/msg")
+	})
+}
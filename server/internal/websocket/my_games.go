@@ -0,0 +1,155 @@
+package websocket
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/lukev/tm_server/internal/game"
+)
+
+type listMyGamesPayload struct {
+	PlayerID string `json:"playerID"`
+}
+
+type resumeGamePayload struct {
+	PlayerID string `json:"playerID"`
+	// GameID resumes a single game; empty resumes every game the player is
+	// seated in.
+	GameID string `json:"gameID,omitempty"`
+}
+
+// myGame is one entry of a my_games message: the lobby listing of a game the
+// player is seated in, plus its progress once started.
+type myGame struct {
+	GameID        string         `json:"gameId"`
+	Name          string         `json:"name"`
+	Players       []string       `json:"players"`
+	MaxPlayers    int            `json:"maxPlayers"`
+	Started       bool           `json:"started"`
+	Phase         game.GamePhase `json:"phase"`
+	Round         int            `json:"round"`
+	Revision      int            `json:"revision"`
+	ActivePlayers []string       `json:"activePlayers"`
+	YourTurn      bool           `json:"yourTurn"`
+	Ended         bool           `json:"ended"`
+}
+
+// myGames lists the games playerID has a seat in, open lobbies included:
+// games waiting on the player first, then by game ID.
+func (c *Client) myGames(playerID string) []myGame {
+	byID := make(map[string]*myGame)
+	for _, meta := range c.deps.Lobby.ListGames() {
+		if meta == nil {
+			continue
+		}
+		for _, seat := range meta.Players {
+			if seat == playerID {
+				byID[meta.ID] = &myGame{
+					GameID:     meta.ID,
+					Name:       meta.Name,
+					Players:    meta.Players,
+					MaxPlayers: meta.MaxPlayers,
+					Started:    meta.Started,
+				}
+				break
+			}
+		}
+	}
+	for _, progress := range c.deps.Games.PlayerGames(playerID) {
+		entry := byID[progress.GameID]
+		if entry == nil {
+			entry = &myGame{GameID: progress.GameID, Name: progress.GameID}
+			byID[progress.GameID] = entry
+		}
+		entry.Started = true
+		entry.Phase = progress.Phase
+		entry.Round = progress.Round
+		entry.Revision = progress.Revision
+		entry.ActivePlayers = progress.ActivePlayers
+		entry.YourTurn = progress.YourTurn
+		entry.Ended = progress.Ended
+	}
+
+	games := make([]myGame, 0, len(byID))
+	for _, entry := range byID {
+		games = append(games, *entry)
+	}
+	sort.Slice(games, func(i, j int) bool {
+		if games[i].YourTurn != games[j].YourTurn {
+			return games[i].YourTurn
+		}
+		return games[i].GameID < games[j].GameID
+	})
+	return games
+}
+
+// handleListMyGames answers list_my_games with every game the player is
+// seated in and whose turn it is there, so a reconnecting client can find its
+// games without remembering their IDs.
+func (c *Client) handleListMyGames(payload json.RawMessage) {
+	var p listMyGamesPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		log.Printf("list_my_games payload error: %v", err)
+		return
+	}
+	playerID := strings.TrimSpace(p.PlayerID)
+	if playerID == "" {
+		c.sendError("missing_player_id")
+		return
+	}
+	msg, _ := json.Marshal(map[string]any{
+		"type": "my_games",
+		"payload": map[string]any{
+			"playerId": playerID,
+			"games":    c.myGames(playerID),
+		},
+	})
+	c.send <- msg
+}
+
+// handleResumeGame re-seats the client as the player in one or all of their
+// started games, subscribes it to their updates and sends each game's state
+// and the decisions awaiting the player.
+func (c *Client) handleResumeGame(payload json.RawMessage) {
+	var p resumeGamePayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		log.Printf("resume_game payload error: %v", err)
+		return
+	}
+	playerID := strings.TrimSpace(p.PlayerID)
+	if playerID == "" {
+		c.sendError("missing_player_id")
+		return
+	}
+
+	var gameIDs []string
+	for _, entry := range c.myGames(playerID) {
+		if !entry.Started || (p.GameID != "" && entry.GameID != p.GameID) {
+			continue
+		}
+		gameIDs = append(gameIDs, entry.GameID)
+	}
+	if p.GameID != "" && len(gameIDs) == 0 {
+		c.sendError("not_in_game")
+		return
+	}
+
+	for _, gameID := range gameIDs {
+		c.bindSeat(gameID, playerID)
+		c.hub.JoinGame(c, gameID)
+		if gameState := c.deps.Games.SerializeGameState(gameID); gameState != nil {
+			c.send <- gameStateUpdateFor(gameState, playerID)
+		}
+		c.sendPendingDecisions(gameID, playerID)
+	}
+	msg, _ := json.Marshal(map[string]any{
+		"type": "games_resumed",
+		"payload": map[string]any{
+			"playerId": playerID,
+			"gameIds":  gameIDs,
+		},
+	})
+	c.send <- msg
+}
@@ -0,0 +1,113 @@
+package websocket
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lukev/tm_server/internal/lobby"
+)
+
+var (
+	// idleAfter is how long a connected player may send nothing before they
+	// are reported idle.
+	idleAfter = 5 * time.Minute
+	// presenceCheckPeriod is how often the hub looks for players going idle
+	// or reconnecting under an existing seat.
+	presenceCheckPeriod = 5 * time.Second
+)
+
+// touch records inbound activity on the connection.
+func (c *Client) touch() {
+	c.lastActive.Store(time.Now().UnixNano())
+}
+
+// idle reports whether the client has been silent for idleAfter. Clients that
+// never recorded activity count as active.
+func (c *Client) idle(now time.Time) bool {
+	last := c.lastActive.Load()
+	return last != 0 && now.Sub(time.Unix(0, last)) >= idleAfter
+}
+
+// seatedPlayers lists the players the client holds a seat for.
+func (c *Client) seatedPlayers() []string {
+	c.seatsMu.RLock()
+	defer c.seatsMu.RUnlock()
+	players := make([]string, 0, len(c.seatsByGame))
+	for _, playerID := range c.seatsByGame {
+		players = append(players, playerID)
+	}
+	return players
+}
+
+// SetPresenceListener sets fn to be called, on its own goroutine, whenever a
+// player's status changes.
+func (h *Hub) SetPresenceListener(fn func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onPresenceChange = fn
+}
+
+// PlayerStatus reports whether playerID has a live connection holding one of
+// their seats, and whether any of those connections has been active lately.
+func (h *Hub) PlayerStatus(playerID string) string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if status, ok := h.presenceLocked(time.Now())[playerID]; ok {
+		return status
+	}
+	return lobby.StatusOffline
+}
+
+// presenceLocked maps every connected player to their status. Players with
+// no entry are offline.
+func (h *Hub) presenceLocked(now time.Time) map[string]string {
+	presence := make(map[string]string)
+	for client := range h.clients {
+		status := lobby.StatusOnline
+		if client.idle(now) {
+			status = lobby.StatusIdle
+		}
+		for _, playerID := range client.seatedPlayers() {
+			if presence[playerID] != lobby.StatusOnline {
+				presence[playerID] = status
+			}
+		}
+	}
+	for playerID := range h.presence {
+		if _, ok := presence[playerID]; !ok {
+			presence[playerID] = lobby.StatusOffline
+		}
+	}
+	return presence
+}
+
+// checkPresenceLocked records the current statuses and notifies the presence
+// listener when any of them changed since the last check.
+func (h *Hub) checkPresenceLocked() {
+	presence := h.presenceLocked(time.Now())
+	changed := len(presence) != len(h.presence)
+	for playerID, status := range presence {
+		if h.presence[playerID] != status {
+			changed = true
+		}
+	}
+	h.presence = presence
+	for playerID, status := range presence {
+		if status == lobby.StatusOffline {
+			delete(h.presence, playerID)
+		}
+	}
+	if changed && h.onPresenceChange != nil {
+		go h.onPresenceChange()
+	}
+}
+
+// ConnectPresence feeds hub presence into lobby listings and rebroadcasts the
+// lobby whenever a player's status changes.
+func ConnectPresence(hub *Hub, lobbyMgr *lobby.Manager) {
+	lobbyMgr.SetPresenceSource(hub.PlayerStatus)
+	hub.SetPresenceListener(func() {
+		out, _ := json.Marshal(lobbyStateMsg{Type: "lobby_state", Payload: lobbyMgr.ListGames()})
+		hub.BroadcastMessage(out)
+	})
+}
@@ -0,0 +1,36 @@
+package websocket
+
+import (
+	"encoding/json"
+
+	"github.com/lukev/tm_server/internal/game"
+)
+
+// SweepSpeedPresets runs one game.Manager.SweepSpeedPresets: the games whose
+// expired blitz turns were taken are broadcast with the events of the timeout
+// actions and handed to the bots, and each reminder is sent to the reminded
+// player as a turn_reminder message.
+func SweepSpeedPresets(hub *Hub, deps ServerDeps) error {
+	changed, reminders, err := deps.Games.SweepSpeedPresets()
+	for _, timeouts := range changed {
+		BroadcastGameState(hub, deps.Games, timeouts.GameID)
+		for _, result := range timeouts.Results {
+			BroadcastActionEvents(hub, deps.Games, timeouts.GameID, result)
+		}
+		if deps.Bots != nil {
+			deps.Bots.Trigger(timeouts.GameID, hub)
+		}
+	}
+	sendTurnReminders(hub, reminders)
+	return err
+}
+
+func sendTurnReminders(hub *Hub, reminders []game.TurnReminder) {
+	for _, reminder := range reminders {
+		msg, _ := json.Marshal(map[string]any{
+			"type":    "turn_reminder",
+			"payload": reminder,
+		})
+		hub.SendToSeat(reminder.GameID, reminder.PlayerID, msg)
+	}
+}
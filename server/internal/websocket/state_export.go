@@ -0,0 +1,93 @@
+package websocket
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// StateExporter writes every game_state_update broadcast to numbered JSON
+// files, <dir>/<gameID>/00001.json onwards, so front-end snapshot tests can
+// be built from real state sequences. Files hold the spectator view of the
+// update, with hidden resources withheld.
+type StateExporter struct {
+	dir string
+
+	mu   sync.Mutex
+	next map[string]int
+}
+
+// NewStateExporter creates an exporter writing below dir.
+func NewStateExporter(dir string) *StateExporter {
+	return &StateExporter{dir: dir, next: make(map[string]int)}
+}
+
+// Export writes gameState as the next update of gameID and returns the path
+// written. Numbering continues after files already in the game's directory.
+func (e *StateExporter) Export(gameID string, gameState map[string]interface{}) (string, error) {
+	if e == nil {
+		return "", nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	gameDir := filepath.Join(e.dir, filepath.Base(gameID))
+	seq, ok := e.next[gameID]
+	if !ok {
+		if err := os.MkdirAll(gameDir, 0o755); err != nil {
+			return "", fmt.Errorf("create state export dir: %w", err)
+		}
+		seq = lastExportedState(gameDir) + 1
+	}
+	path := filepath.Join(gameDir, fmt.Sprintf("%05d.json", seq))
+	if err := os.WriteFile(path, gameStateUpdateFor(gameState, ""), 0o644); err != nil {
+		return "", fmt.Errorf("write state export: %w", err)
+	}
+	e.next[gameID] = seq + 1
+	return path, nil
+}
+
+// lastExportedState returns the highest file number in gameDir, or 0.
+func lastExportedState(gameDir string) int {
+	entries, err := os.ReadDir(gameDir)
+	if err != nil {
+		return 0
+	}
+	last := 0
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		if seq, err := strconv.Atoi(strings.TrimSuffix(name, ".json")); err == nil && seq > last {
+			last = seq
+		}
+	}
+	return last
+}
+
+// exportState hands a broadcast state to the hub's exporter, if any. Export
+// failures are logged and never hold up the broadcast.
+func (h *Hub) exportState(gameID string, gameState map[string]interface{}) {
+	h.mu.RLock()
+	exporter := h.stateExporter
+	h.mu.RUnlock()
+	if exporter == nil {
+		return
+	}
+	if _, err := exporter.Export(gameID, gameState); err != nil {
+		log.Printf("state export for game %s failed: %v", gameID, err)
+	}
+}
+
+// SetStateExporter makes the hub write every game_state_update it broadcasts
+// through exporter; nil turns exporting off.
+func (h *Hub) SetStateExporter(exporter *StateExporter) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.stateExporter = exporter
+}
@@ -0,0 +1,72 @@
+package websocket
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStateExporter_NumbersUpdatesPerGameAndHidesResources(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "g2"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "g2", "00007.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatalf("seed export: %v", err)
+	}
+
+	hub := NewHub()
+	go hub.Run()
+	hub.SetStateExporter(NewStateExporter(dir))
+	client := &Client{hub: hub, send: make(chan []byte, 8), seatsByGame: make(map[string]string)}
+	hub.register <- client
+	hub.JoinGame(client, "g1")
+
+	hiddenState := map[string]interface{}{
+		"id":              "g1",
+		"revision":        1,
+		"hiddenResources": true,
+		"players": map[string]interface{}{
+			"p1": map[string]interface{}{"resources": map[string]interface{}{"coins": 5, "workers": 3}},
+		},
+	}
+	broadcastGameStateUpdate(hub, "g1", hiddenState)
+	broadcastGameStateUpdate(hub, "g1", map[string]interface{}{"id": "g1", "revision": 2})
+	broadcastGameStateUpdate(hub, "g2", map[string]interface{}{"id": "g2", "revision": 1})
+
+	select {
+	case <-client.send:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("timed out waiting for the broadcast itself")
+	}
+
+	for _, want := range []struct {
+		path     string
+		revision float64
+	}{
+		{"g1/00001.json", 1},
+		{"g1/00002.json", 2},
+		{"g2/00008.json", 1},
+	} {
+		raw, err := os.ReadFile(filepath.Join(dir, want.path))
+		if err != nil {
+			t.Fatalf("expected export %s: %v", want.path, err)
+		}
+		var msg map[string]any
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			t.Fatalf("export %s is not JSON: %v", want.path, err)
+		}
+		payload := asMap(msg["payload"])
+		if msg["type"] != "game_state_update" || payload["revision"] != want.revision {
+			t.Fatalf("unexpected export %s: %s", want.path, raw)
+		}
+		if want.path == "g1/00001.json" {
+			p1 := asMap(asMap(payload["players"])["p1"])
+			if _, leaked := asMap(p1["resources"])["coins"]; leaked || p1["resourcesHidden"] != true {
+				t.Fatalf("expected the export to withhold hidden resources, got %s", raw)
+			}
+		}
+	}
+}
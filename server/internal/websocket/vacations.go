@@ -0,0 +1,15 @@
+package websocket
+
+// SweepVacations runs one game.Manager.SweepVacations and, when vacations
+// ended, broadcasts the games of those players and the lobby, as ending a
+// vacation by hand does. It returns the players whose vacation ended.
+func SweepVacations(hub *Hub, deps ServerDeps) ([]string, error) {
+	ended, gameIDs, err := deps.Games.SweepVacations()
+	for _, gameID := range gameIDs {
+		BroadcastGameState(hub, deps.Games, gameID)
+	}
+	if len(ended) > 0 {
+		broadcastLobbyState(hub, deps.Lobby)
+	}
+	return ended, err
+}